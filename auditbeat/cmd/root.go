@@ -18,13 +18,18 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 
 	"github.com/elastic/beats/v7/auditbeat/ab"
 	"github.com/elastic/beats/v7/auditbeat/core"
+	"github.com/elastic/beats/v7/auditbeat/module/auditd"
+	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/cmd"
 	"github.com/elastic/beats/v7/libbeat/cmd/instance"
 	"github.com/elastic/beats/v7/libbeat/ecs"
@@ -77,11 +82,32 @@ func Initialize(settings instance.Settings) *cmd.BeatsRootCmd {
 			module.WithEventModifier(core.AddDatasetToEvent),
 		),
 	)
-	rootCmd := cmd.GenRootCmdWithSettings(create, settings)
+	rootCmd := cmd.GenRootCmdWithSettings(withRulesReload(create), settings)
 	rootCmd.AddCommand(ShowCmd)
 	return rootCmd
 }
 
+// withRulesReload wraps create to allow reloading the audit rules of the
+// auditd module through the HTTP API and Elastic Agent actions.
+func withRulesReload(create beat.Creator) beat.Creator {
+	return func(b *beat.Beat, c *conf.C) (beat.Beater, error) {
+		bt, err := create(b, c)
+		if err != nil || b.InSetupCmd {
+			return bt, err
+		}
+
+		if b.API != nil {
+			if err := b.API.AttachHandler(auditd.ReloadRulesRoute, auditd.ReloadRulesHandler{}); err != nil {
+				return nil, fmt.Errorf("failed to attach audit rules reload handler to the HTTP API: %w", err)
+			}
+		}
+		if b.Manager != nil {
+			b.Manager.RegisterAction(auditd.ReloadRulesAction{})
+		}
+		return bt, nil
+	}
+}
+
 func init() {
 	RootCmd = Initialize(AuditbeatSettings(nil))
 	initShowRules()
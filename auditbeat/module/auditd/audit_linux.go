@@ -95,6 +95,11 @@ type MetricSet struct {
 		counter uint32
 	}
 	backpressureStrategy backpressureStrategy
+
+	// rulesMu serializes the changes to the audit rules.
+	rulesMu sync.Mutex
+	// reporter is used to report failures to add rules when they are reloaded.
+	reporter mb.PushReporterV2
 }
 
 // New constructs a new MetricSet.
@@ -167,7 +172,9 @@ func (ms *MetricSet) Run(reporter mb.PushReporterV2) {
 	if status.Enabled == auditLocked {
 		err := errors.New("skipping rule configuration: Audit rules are locked")
 		reporter.Error(err)
-	} else if err := ms.addRules(reporter); err != nil {
+	} else if len(ms.config.rules()) == 0 {
+		ms.log.Info("No audit_rules were specified.")
+	} else if err := ms.addRules(reporter, ms.config.rules()); err != nil {
 		reporter.Error(err)
 		ms.log.Errorw("Failure adding audit rules", "error", err)
 		return
@@ -224,6 +231,22 @@ func (ms *MetricSet) Run(reporter mb.PushReporterV2) {
 		}()
 	}
 
+	// Allow the rules to be reloaded from the HTTP API or Elastic Agent, and
+	// when the rule files change.
+	ms.rulesMu.Lock()
+	ms.reporter = reporter
+	ms.rulesMu.Unlock()
+	registerReloader(ms)
+	defer unregisterReloader(ms)
+	if ms.config.RuleFilesReload.Enabled && len(ms.config.RuleFiles) > 0 {
+		go watchRuleFiles(reporter.Done(), ms.config.RuleFiles, ms.config.RuleFilesReload.Period, ms.log, func() {
+			if err := ms.reloadRules(); err != nil {
+				reporter.Error(err)
+				ms.log.Errorw("Failure reloading audit rules", "error", err)
+			}
+		})
+	}
+
 	// Spawn the stream buffer consumers
 	numConsumers := ms.config.StreamBufferConsumers
 	// By default (stream_buffer_consumers=0) use as many consumers as local CPUs
@@ -252,14 +275,8 @@ func (ms *MetricSet) Run(reporter mb.PushReporterV2) {
 	wg.Wait()
 }
 
-func (ms *MetricSet) addRules(reporter mb.PushReporterV2) error {
-	rules := ms.config.rules()
-
-	if len(rules) == 0 {
-		ms.log.Info("No audit_rules were specified.")
-		return nil
-	}
-
+// addRules replaces the rules in the kernel with rules.
+func (ms *MetricSet) addRules(reporter mb.PushReporterV2, rules []auditRule) error {
 	client, err := libaudit.NewAuditClient(nil)
 	if err != nil {
 		return fmt.Errorf("failed to create audit client for adding rules: %w", err)
@@ -295,6 +312,44 @@ func (ms *MetricSet) addRules(reporter mb.PushReporterV2) error {
 	return nil
 }
 
+// reloadRules reads the rules from the configuration again and replaces the
+// rules in the kernel with them if they changed. The rules in the kernel are
+// left unchanged if the rules cannot be read.
+func (ms *MetricSet) reloadRules() error {
+	ms.rulesMu.Lock()
+	defer ms.rulesMu.Unlock()
+
+	config := ms.config
+	config.auditRules = nil
+	if err := config.loadRules(); err != nil {
+		return fmt.Errorf("failed to reload audit rules: %w", err)
+	}
+	if rulesEqual(config.rules(), ms.config.rules()) {
+		ms.log.Info("Audit rules are unchanged, skipping reload.")
+		return nil
+	}
+
+	client, err := libaudit.NewAuditClient(nil)
+	if err != nil {
+		return fmt.Errorf("failed to create audit client for reloading rules: %w", err)
+	}
+	status, err := client.GetStatus()
+	client.Close()
+	if err != nil {
+		return fmt.Errorf("failed to get audit status before reloading rules: %w", err)
+	}
+	if status.Enabled == auditLocked {
+		return errors.New("failed to reload audit rules: Audit rules are locked")
+	}
+
+	if err := ms.addRules(ms.reporter, config.rules()); err != nil {
+		return fmt.Errorf("failed to reload audit rules: %w", err)
+	}
+	ms.config.auditRules = config.rules()
+	ms.log.Infof("Reloaded %d audit rules.", len(config.rules()))
+	return nil
+}
+
 func (ms *MetricSet) initClient() error {
 	if ms.config.SocketType == "multicast" {
 		// This request will fail with EPERM if this process does not have
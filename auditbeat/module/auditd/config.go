@@ -50,6 +50,9 @@ type Config struct {
 	Immutable    bool     `config:"immutable"`           // Sets kernel audit config immutable.
 	IgnoreErrors bool     `config:"ignore_errors"`       // Ignore errors when reading and parsing rules, equivalent to auditctl -i.
 
+	// RuleFilesReload configures the reload of the rules when the rule files change.
+	RuleFilesReload RuleFilesReloadConfig `config:"audit_rule_files_reload"`
+
 	// Tuning options (advanced, use with care)
 	ReassemblerMaxInFlight uint32        `config:"reassembler.max_in_flight"`
 	ReassemblerTimeout     time.Duration `config:"reassembler.timeout"`
@@ -64,6 +67,12 @@ type Config struct {
 	auditRules []auditRule
 }
 
+// RuleFilesReloadConfig defines how the rule files are watched for changes.
+type RuleFilesReloadConfig struct {
+	Enabled bool          `config:"enabled"`
+	Period  time.Duration `config:"period"` // Interval at which the rule files are checked for changes.
+}
+
 type auditRule struct {
 	flags string
 	data  []byte
@@ -88,6 +97,9 @@ var defaultConfig = Config{
 	ReassemblerTimeout:     2 * time.Second,
 	StreamBufferQueueSize:  8192,
 	StreamBufferConsumers:  0,
+	RuleFilesReload: RuleFilesReloadConfig{
+		Period: 10 * time.Second,
+	},
 }
 
 // Validate validates the rules specified in the config.
@@ -102,6 +114,10 @@ func (c *Config) Validate() error {
 		errs = append(errs, err)
 	}
 
+	if c.RuleFilesReload.Enabled && c.RuleFilesReload.Period < time.Second {
+		errs = append(errs, fmt.Errorf("audit_rule_files_reload.period must be at least 1s"))
+	}
+
 	c.SocketType = strings.ToLower(c.SocketType)
 	switch c.SocketType {
 	case "multicast":
@@ -122,6 +138,19 @@ func (c Config) rules() []auditRule {
 	return c.auditRules
 }
 
+// rulesEqual returns true if a and b contain the same rules in the same order.
+func rulesEqual(a, b []auditRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i].data, b[i].data) {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *Config) loadRules() error {
 	var log *logp.Logger
 	if c.IgnoreErrors {
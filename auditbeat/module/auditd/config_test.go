@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -117,6 +118,33 @@ audit_rules: |
 		t.Log(err)
 	})
 
+	t.Run("ValidateRuleFilesReload", func(t *testing.T) {
+		config := defaultConfig
+		config.RuleFilesReload.Enabled = true
+		assert.NoError(t, config.Validate())
+
+		config.RuleFilesReload.Period = 100 * time.Millisecond
+		assert.ErrorContains(t, config.Validate(), "audit_rule_files_reload.period must be at least 1s")
+	})
+
+	t.Run("RulesEqual", func(t *testing.T) {
+		a, err := parseConfig(t, "audit_rules: |\n  -w /etc/passwd -p wa -k auth\n  -a always,exit -S execve -k exec")
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := parseConfig(t, "audit_rules: |\n  -w /etc/passwd -p wa -k auth\n  -a always,exit -S execve   -k exec")
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := parseConfig(t, "audit_rules: |\n  -a always,exit -S execve -k exec\n  -w /etc/passwd -p wa -k auth")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, rulesEqual(a.rules(), b.rules()))
+		assert.False(t, rulesEqual(a.rules(), c.rules()))
+		assert.False(t, rulesEqual(a.rules(), a.rules()[:1]))
+	})
+
 	t.Run("ValidateImmutable", func(t *testing.T) {
 		tcs := []struct {
 			name       string
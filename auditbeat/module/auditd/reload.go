@@ -0,0 +1,164 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package auditd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	// ReloadRulesRoute is the route of the HTTP API endpoint reloading the
+	// audit rules.
+	ReloadRulesRoute = "/auditd/reload_rules"
+
+	// ReloadRulesActionName is the name of the Elastic Agent action reloading
+	// the audit rules.
+	ReloadRulesActionName = "auditd.reload_rules"
+)
+
+// errNotRunning is returned by ReloadRules when no auditd metricset is running.
+var errNotRunning = errors.New("the auditd module is not running")
+
+// rulesReloader reloads the audit rules from its configuration.
+type rulesReloader interface {
+	reloadRules() error
+}
+
+// reloaders are the running metricsets whose rules can be reloaded.
+var reloaders = struct {
+	sync.Mutex
+	set map[rulesReloader]struct{}
+}{set: map[rulesReloader]struct{}{}}
+
+func registerReloader(r rulesReloader) {
+	reloaders.Lock()
+	defer reloaders.Unlock()
+	reloaders.set[r] = struct{}{}
+}
+
+func unregisterReloader(r rulesReloader) {
+	reloaders.Lock()
+	defer reloaders.Unlock()
+	delete(reloaders.set, r)
+}
+
+// ReloadRules reads the audit_rules and audit_rule_files of the running
+// auditd module again and replaces the rules in the kernel with them. The
+// kernel rules are left unchanged when the rules cannot be read.
+func ReloadRules() error {
+	reloaders.Lock()
+	defer reloaders.Unlock()
+	if len(reloaders.set) == 0 {
+		return errNotRunning
+	}
+	var errs []error
+	for r := range reloaders.set {
+		errs = append(errs, r.reloadRules())
+	}
+	return errors.Join(errs...)
+}
+
+// ReloadRulesHandler is an HTTP handler reloading the audit rules on POST
+// requests.
+type ReloadRulesHandler struct{}
+
+func (ReloadRulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	resp := mapstr.M{"success": true}
+	if err := ReloadRules(); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		resp = mapstr.M{"success": false, "error": err.Error()}
+	}
+	_, _ = w.Write([]byte(resp.String()))
+}
+
+// ReloadRulesAction is an Elastic Agent action reloading the audit rules.
+type ReloadRulesAction struct{}
+
+// Name returns the name of the action.
+func (ReloadRulesAction) Name() string {
+	return ReloadRulesActionName
+}
+
+// Execute reloads the audit rules. The failure to reload the rules is
+// reported in the action result.
+func (ReloadRulesAction) Execute(context.Context, map[string]interface{}) (map[string]interface{}, error) {
+	start := time.Now().UTC()
+	err := ReloadRules()
+	res := map[string]interface{}{
+		"started_at":   start.Format(time.RFC3339Nano),
+		"completed_at": time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	if err != nil {
+		res["error"] = err.Error()
+	}
+	return res, nil
+}
+
+// watchRuleFiles calls reload when the files matching the patterns change,
+// until done is closed.
+func watchRuleFiles(done <-chan struct{}, patterns []string, period time.Duration, log *logp.Logger, reload func()) {
+	watchers := make([]*cfgfile.GlobWatcher, 0, len(patterns))
+	for _, pattern := range patterns {
+		if absPattern, err := filepath.Abs(pattern); err == nil {
+			pattern = absPattern
+		}
+		w := cfgfile.NewGlobWatcher(pattern, log)
+		// The rules of the current files are already loaded.
+		_, _, _ = w.Scan()
+		watchers = append(watchers, w)
+	}
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			var changed bool
+			for _, w := range watchers {
+				_, updated, err := w.Scan()
+				if err != nil {
+					log.Warnw("Failure checking audit rule files for changes", "error", err)
+					continue
+				}
+				changed = changed || updated
+			}
+			if changed {
+				log.Info("Audit rule files changed, reloading audit rules.")
+				reload()
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package auditd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+func TestReloadRules(t *testing.T) {
+	assert.ErrorIs(t, ReloadRules(), errNotRunning)
+
+	ok := &fakeReloader{}
+	registerReloader(ok)
+	defer unregisterReloader(ok)
+	require.NoError(t, ReloadRules())
+	assert.EqualValues(t, 1, ok.calls.Load())
+
+	failing := &fakeReloader{err: errors.New("rules are locked")}
+	registerReloader(failing)
+	assert.ErrorContains(t, ReloadRules(), "rules are locked")
+	assert.EqualValues(t, 2, ok.calls.Load())
+	assert.EqualValues(t, 1, failing.calls.Load())
+
+	unregisterReloader(failing)
+	require.NoError(t, ReloadRules())
+}
+
+func TestReloadRulesHandler(t *testing.T) {
+	r := &fakeReloader{}
+	registerReloader(r)
+	defer unregisterReloader(r)
+
+	rec := httptest.NewRecorder()
+	ReloadRulesHandler{}.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReloadRulesRoute, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.EqualValues(t, 0, r.calls.Load())
+
+	rec = httptest.NewRecorder()
+	ReloadRulesHandler{}.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ReloadRulesRoute, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"success": true}`, rec.Body.String())
+	assert.EqualValues(t, 1, r.calls.Load())
+
+	r.err = errors.New("failed to reload audit rules")
+	rec = httptest.NewRecorder()
+	ReloadRulesHandler{}.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ReloadRulesRoute, nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"success": false, "error": "failed to reload audit rules"}`, rec.Body.String())
+}
+
+func TestReloadRulesAction(t *testing.T) {
+	action := ReloadRulesAction{}
+	assert.Equal(t, ReloadRulesActionName, action.Name())
+
+	res, err := action.Execute(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, errNotRunning.Error(), res["error"])
+
+	r := &fakeReloader{}
+	registerReloader(r)
+	defer unregisterReloader(r)
+	res, err = action.Execute(context.Background(), nil)
+	require.NoError(t, err)
+	assert.NotContains(t, res, "error")
+	assert.Contains(t, res, "completed_at")
+	assert.EqualValues(t, 1, r.calls.Load())
+}
+
+func TestWatchRuleFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "audit.rules")
+	require.NoError(t, os.WriteFile(file, []byte("-w /etc/passwd -p wa -k auth\n"), 0o600))
+	// Make the file older than the first scan.
+	old := time.Now().Add(-time.Minute)
+	require.NoError(t, os.Chtimes(file, old, old))
+
+	var reloads atomic.Int32
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		watchRuleFiles(done, []string{filepath.Join(dir, "*.rules")}, 50*time.Millisecond, logptest.NewTestingLogger(t, ""), func() {
+			reloads.Add(1)
+		})
+	}()
+
+	// Unchanged files are not reloaded.
+	time.Sleep(200 * time.Millisecond)
+	assert.EqualValues(t, 0, reloads.Load())
+
+	// New files are reloaded.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "exec.rules"), []byte("-a always,exit -S execve -k exec\n"), 0o600))
+	assert.Eventually(t, func() bool { return reloads.Load() > 0 }, 5*time.Second, 10*time.Millisecond)
+
+	close(done)
+	<-stopped
+}
+
+type fakeReloader struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (r *fakeReloader) reloadRules() error {
+	r.calls.Add(1)
+	return r.err
+}
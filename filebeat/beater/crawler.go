@@ -26,6 +26,8 @@ import (
 	"github.com/elastic/beats/v7/filebeat/input"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/libbeat/monitoring/health"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
@@ -41,6 +43,7 @@ type crawler struct {
 	inputReloader   *cfgfile.Reloader
 	once            bool
 	beatDone        chan struct{}
+	health          *health.Registry
 }
 
 func newCrawler(
@@ -48,6 +51,7 @@ func newCrawler(
 	inputConfigs []*conf.C,
 	beatDone chan struct{},
 	once bool,
+	healthRegistry *health.Registry,
 	logger *logp.Logger,
 ) (*crawler, error) {
 	return &crawler{
@@ -58,6 +62,7 @@ func newCrawler(
 		inputConfigs:   inputConfigs,
 		once:           once,
 		beatDone:       beatDone,
+		health:         healthRegistry,
 	}, nil
 }
 
@@ -81,6 +86,7 @@ func (c *crawler) Start(
 
 	if configInputs.Enabled() {
 		c.inputReloader = cfgfile.NewReloader(log.Named("input.reloader"), pipeline, configInputs)
+		c.inputReloader.SetHealthRegistry(c.health)
 		if err := c.inputReloader.Check(c.inputsFactory); err != nil {
 			return fmt.Errorf("creating input reloader failed: %w", err)
 		}
@@ -88,6 +94,7 @@ func (c *crawler) Start(
 
 	if configModules.Enabled() {
 		c.modulesReloader = cfgfile.NewReloader(log.Named("module.reloader"), pipeline, configModules)
+		c.modulesReloader.SetHealthRegistry(c.health)
 		if err := c.modulesReloader.Check(c.modulesFactory); err != nil {
 			return fmt.Errorf("creating module reloader failed: %w", err)
 		}
@@ -139,6 +146,9 @@ func (c *crawler) startInput(
 	if inputRunner, ok := runner.(*input.Runner); ok {
 		inputRunner.Once = c.once
 	}
+	if runnerWithStatus, ok := runner.(status.WithStatusReporter); ok && c.health != nil {
+		runnerWithStatus.SetStatusReporter(cfgfile.NewHealthReporter(c.health, config, runner))
+	}
 
 	c.inputs[id] = runner
 
@@ -387,8 +387,13 @@ func (fb *Filebeat) Run(b *beat.Beat) error {
 		input.NewRunnerFactory(pipelineConnector, registrar, fb.done, fb.logger),
 	))
 
+	// The health of the inputs is tracked from their status updates. When
+	// running as a beat receiver the status is reported by the status
+	// factory wrapper instead.
+	healthRegistry := b.Monitoring.Health()
 	if fb.otelStatusFactoryWrapper != nil {
 		inputLoader = fb.otelStatusFactoryWrapper(inputLoader)
+		healthRegistry = nil
 	}
 
 	// Create a ES connection factory for dynamic modules pipeline loading
@@ -406,7 +411,7 @@ func (fb *Filebeat) Run(b *beat.Beat) error {
 		fb.logger.Warn(pipelinesWarning)
 	}
 	moduleLoader := fileset.NewFactory(inputLoader, b.Info, pipelineLoaderFactory, config.OverwritePipelines)
-	crawler, err := newCrawler(inputLoader, moduleLoader, config.Inputs, fb.done, *once, fb.logger)
+	crawler, err := newCrawler(inputLoader, moduleLoader, config.Inputs, fb.done, *once, healthRegistry, fb.logger)
 	if err != nil {
 		fb.logger.Errorf("Could not init crawler: %v", err)
 		return err
@@ -459,9 +464,11 @@ func (fb *Filebeat) Run(b *beat.Beat) error {
 
 	// Register reloadable list of inputs and modules
 	inputs := cfgfile.NewRunnerList(management.DebugK, inputLoader, fb.pipeline, fb.logger)
+	inputs.SetHealthRegistry(healthRegistry)
 	b.Registry.MustRegisterInput(inputs)
 
 	modules := cfgfile.NewRunnerList(management.DebugK, moduleLoader, fb.pipeline, fb.logger)
+	modules.SetHealthRegistry(healthRegistry)
 
 	var adiscover *autodiscover.Autodiscover
 	if fb.config.Autodiscover != nil {
@@ -78,6 +78,7 @@ func (f *onCreateFactory) Create(pipeline beat.PipelineConnector, cfg *conf.C) (
 //   - *fields_under_root*: select at which level to store the fields
 //   - *tags*: add additional tags to the events
 //   - *processors*: list of local processors to be added to the processing pipeline
+//   - *script*: script transforming events before they are processed
 //   - *keep_null*: keep or remove 'null' from events to be published
 //   - *_module_name* (hidden setting): Add fields describing the module name
 //   - *_ fileset_name* (hidden setting):
@@ -116,7 +117,16 @@ func withClientConfig(
 	if err != nil {
 		return nil, err
 	}
-	return pipetool.WithClientConfigEdit(pipeline, editor), nil
+	script, err := newInputScript(beatInfo, cfg)
+	if err != nil {
+		return nil, err
+	}
+	pipeline = pipetool.WithClientConfigEdit(pipeline, editor)
+	if script != nil {
+		// The script runs before the processors of the input.
+		pipeline = script.wrap(pipeline)
+	}
+	return pipeline, nil
 }
 
 func newCommonConfigEditor(
@@ -191,6 +191,38 @@ func TestProcessorsForConfigIsFlat(t *testing.T) {
 	assert.Equal(t, 2, len(lst.(*processors.Processors).List)) //nolint:errcheck //Safe to ignore in tests
 }
 
+func TestProcessorsForConfigDropEvent(t *testing.T) {
+	// Inputs like stdin don't build their own processing pipeline, the
+	// processors configured on the input are applied by the client config
+	// editor before the events reach the outlet.
+	configStr := `
+type: stdin
+processors:
+  - drop_event.when.contains.message: "DEBUG"
+`
+	config, err := conf.NewConfigFrom(configStr)
+	require.NoError(t, err)
+
+	editor, err := newCommonConfigEditor(beat.Info{Logger: logptest.NewTestingLogger(t, "")}, config)
+	require.NoError(t, err)
+
+	clientCfg, err := editor(beat.ClientConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "stdin", clientCfg.Processing.Fields["input"].(mapstr.M)["type"]) //nolint:errcheck //Safe to ignore in tests
+
+	var published []string
+	for _, msg := range []string{"INFO first line", "DEBUG noisy line", "WARN last line"} {
+		event := beat.Event{Fields: mapstr.M{"message": msg}}
+		processed, err := clientCfg.Processing.Processor.Run(&event)
+		require.NoError(t, err)
+		if processed == nil {
+			continue
+		}
+		published = append(published, msg)
+	}
+	assert.Equal(t, []string{"INFO first line", "WARN last line"}, published)
+}
+
 // setRawIndex is a bare-bones processor to set the raw_index field to a
 // constant string in the event metadata. It is used to test order of operations
 // for processorsForConfig.
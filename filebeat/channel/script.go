@@ -0,0 +1,561 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package channel
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	starlarkjson "go.starlark.net/lib/json"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// scriptExceptionTag is added to the tags of events that failed to be
+// transformed by the input script.
+const scriptExceptionTag = "_input_script_exception"
+
+// scriptPredeclared are the modules available to input scripts.
+var scriptPredeclared = starlark.StringDict{
+	"json": starlarkjson.Module,
+}
+
+// scriptConfig configures a script transforming the events published by an
+// input before they are handled by the processors of the input.
+type scriptConfig struct {
+	Lang    string                 `config:"lang" validate:"required"` // Script language, only starlark is supported.
+	Source  string                 `config:"source"`                   // Inline script.
+	File    string                 `config:"file"`                     // Script file.
+	Params  map[string]interface{} `config:"params"`                   // Parameters passed to the register function.
+	Timeout time.Duration          `config:"timeout" validate:"min=0"` // Timeout of the transformation of an event.
+}
+
+func (c *scriptConfig) Validate() error {
+	if !strings.EqualFold(c.Lang, "starlark") {
+		return fmt.Errorf("unsupported script language %q: only starlark is supported", c.Lang)
+	}
+	if (c.Source == "") == (c.File == "") {
+		return errors.New("script must be defined in only one of 'file' or inline as 'source'")
+	}
+	return nil
+}
+
+// inputScript is a compiled input script.
+type inputScript struct {
+	program *starlark.Program
+	params  map[string]interface{}
+	timeout time.Duration
+	log     *logp.Logger
+}
+
+// newInputScript returns the script configured for the input, or nil if
+// there is none.
+func newInputScript(beatInfo beat.Info, cfg *conf.C) (*inputScript, error) {
+	var config struct {
+		Script *scriptConfig `config:"script"`
+	}
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+	if config.Script == nil {
+		return nil, nil
+	}
+	c := config.Script
+
+	name, src := "inline.star", c.Source
+	if c.File != "" {
+		name = paths.Resolve(paths.Config, c.File)
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read script file: %w", err)
+		}
+		src = string(b)
+	}
+	_, program, err := starlark.SourceProgramOptions(&syntax.FileOptions{}, name, src, scriptPredeclared.Has)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile script: %w", err)
+	}
+	s := &inputScript{
+		program: program,
+		params:  c.Params,
+		timeout: c.Timeout,
+		log:     beatInfo.Logger.Named("input_script"),
+	}
+	// Check that the script can be run.
+	if _, err = s.newSession(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// wrap returns a pipeline connector whose clients transform events with
+// the script before publishing them to clients of pipeline.
+func (s *inputScript) wrap(pipeline beat.PipelineConnector) beat.PipelineConnector {
+	return &scriptPipeline{pipeline: pipeline, script: s}
+}
+
+// scriptSession is an instance of an input script. It is not safe for
+// concurrent use.
+type scriptSession struct {
+	script  *inputScript
+	process starlark.Callable
+}
+
+func (s *inputScript) newSession() (*scriptSession, error) {
+	// The globals are not frozen, so that register can keep state in
+	// global dicts and lists for process to use.
+	globals, err := s.program.Init(s.newThread(), scriptPredeclared)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run script: %w", err)
+	}
+
+	sess := &scriptSession{script: s}
+	process, ok := globals["process"].(starlark.Callable)
+	if !ok {
+		return nil, errors.New("script must define a process function")
+	}
+	sess.process = process
+	if register, ok := globals["register"].(starlark.Callable); ok {
+		params, err := toStarlark(s.params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert script params: %w", err)
+		}
+		_, err = starlark.Call(s.newThread(), register, starlark.Tuple{params}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register script: %w", err)
+		}
+	}
+	return sess, nil
+}
+
+// newThread returns a thread to run the script in. Threads are cheap and
+// cannot be reused once cancelled, so each call uses its own thread.
+func (s *inputScript) newThread() *starlark.Thread {
+	return &starlark.Thread{
+		Name: "input_script",
+		Print: func(_ *starlark.Thread, msg string) {
+			s.log.Debug(msg)
+		},
+	}
+}
+
+// run returns the events the script transforms event into.
+func (s *scriptSession) run(event beat.Event) ([]beat.Event, error) {
+	thread := s.script.newThread()
+	if s.script.timeout > 0 {
+		timer := time.AfterFunc(s.script.timeout, func() {
+			thread.Cancel("timeout transforming event")
+		})
+		defer timer.Stop()
+	}
+
+	fields := make(map[string]interface{}, len(event.Fields)+2)
+	for k, v := range event.Fields {
+		fields[k] = v
+	}
+	fields["@timestamp"] = event.Timestamp
+	if event.Meta != nil {
+		fields["@metadata"] = event.Meta
+	}
+	arg, err := toStarlark(fields)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := starlark.Call(thread, s.process, starlark.Tuple{arg}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var dicts []*starlark.Dict
+	switch ret := ret.(type) {
+	case starlark.NoneType:
+		// The event is dropped.
+		return nil, nil
+	case *starlark.Dict:
+		dicts = []*starlark.Dict{ret}
+	case starlark.Indexable:
+		for i := 0; i < ret.Len(); i++ {
+			d, ok := ret.Index(i).(*starlark.Dict)
+			if !ok {
+				return nil, fmt.Errorf("process returned a non-dict element %d of type %s", i, ret.Index(i).Type())
+			}
+			dicts = append(dicts, d)
+		}
+	default:
+		return nil, fmt.Errorf("process returned a value of type %s, expected a dict, a list of dicts or None", ret.Type())
+	}
+
+	events := make([]beat.Event, 0, len(dicts))
+	for _, d := range dicts {
+		e, err := fromStarlarkEvent(event, d)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// fromStarlarkEvent returns a copy of orig with the fields, metadata and
+// timestamp of the event dict d.
+func fromStarlarkEvent(orig beat.Event, d *starlark.Dict) (beat.Event, error) {
+	v, err := fromStarlark(d)
+	if err != nil {
+		return orig, err
+	}
+	fields, ok := v.(map[string]interface{})
+	if !ok {
+		return orig, errors.New("process returned an event that is not a dict of fields")
+	}
+	e := orig
+	e.Fields = mapstr.M(fields)
+	e.Meta = nil
+	if ts, ok := fields["@timestamp"]; ok {
+		delete(fields, "@timestamp")
+		s, ok := ts.(string)
+		if !ok {
+			return orig, fmt.Errorf("@timestamp is not a string: %T", ts)
+		}
+		e.Timestamp, err = time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return orig, fmt.Errorf("failed to parse @timestamp: %w", err)
+		}
+	}
+	if meta, ok := fields["@metadata"]; ok {
+		delete(fields, "@metadata")
+		m, ok := meta.(map[string]interface{})
+		if !ok {
+			return orig, fmt.Errorf("@metadata is not a dict of fields: %T", meta)
+		}
+		if len(m) != 0 {
+			e.Meta = mapstr.M(m)
+		}
+	}
+	return e, nil
+}
+
+// toStarlark returns the Starlark value of v. Maps are converted to dicts,
+// slices to lists, times to RFC3339 strings and numbers to Starlark ints
+// and floats.
+func toStarlark(v interface{}) (starlark.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return starlark.None, nil
+	case starlark.Value:
+		return v, nil
+	case string:
+		return starlark.String(v), nil
+	case bool:
+		return starlark.Bool(v), nil
+	case time.Time:
+		return starlark.String(v.UTC().Format(time.RFC3339Nano)), nil
+	case mapstr.M:
+		return toStarlark(map[string]interface{}(v))
+	case map[string]interface{}:
+		d := starlark.NewDict(len(v))
+		for k, e := range v {
+			sv, err := toStarlark(e)
+			if err != nil {
+				return nil, err
+			}
+			if err = d.SetKey(starlark.String(k), sv); err != nil {
+				return nil, err
+			}
+		}
+		return d, nil
+	case []interface{}:
+		l := make([]starlark.Value, 0, len(v))
+		for _, e := range v {
+			sv, err := toStarlark(e)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, sv)
+		}
+		return starlark.NewList(l), nil
+	case fmt.Stringer:
+		return starlark.String(v.String()), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return starlark.MakeInt64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return starlark.MakeUint64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return starlark.Float(rv.Float()), nil
+	case reflect.Slice, reflect.Array:
+		l := make([]interface{}, rv.Len())
+		for i := range l {
+			l[i] = rv.Index(i).Interface()
+		}
+		return toStarlark(l)
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		m := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = iter.Value().Interface()
+		}
+		return toStarlark(m)
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return starlark.None, nil
+		}
+		return toStarlark(rv.Elem().Interface())
+	}
+	return starlark.String(fmt.Sprint(v)), nil
+}
+
+// fromStarlark returns the Go value of v. Dicts are converted to maps, lists
+// and tuples to slices, ints to int64 (or uint64 when too large) and floats
+// to float64.
+func fromStarlark(v starlark.Value) (interface{}, error) {
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.String:
+		return string(v), nil
+	case starlark.Int:
+		if i, ok := v.Int64(); ok {
+			return i, nil
+		}
+		if u, ok := v.Uint64(); ok {
+			return u, nil
+		}
+		return nil, fmt.Errorf("integer %s is out of range", v)
+	case starlark.Float:
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("float %s cannot be published", v)
+		}
+		return f, nil
+	case *starlark.Dict:
+		m := make(map[string]interface{}, v.Len())
+		for _, item := range v.Items() {
+			k, ok := starlark.AsString(item[0])
+			if !ok {
+				return nil, fmt.Errorf("dict key %s is not a string", item[0])
+			}
+			e, err := fromStarlark(item[1])
+			if err != nil {
+				return nil, err
+			}
+			m[k] = e
+		}
+		return m, nil
+	case starlark.Indexable:
+		l := make([]interface{}, v.Len())
+		for i := range l {
+			e, err := fromStarlark(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			l[i] = e
+		}
+		return l, nil
+	default:
+		return v.String(), nil
+	}
+}
+
+// scriptPipeline is a pipeline connector whose clients transform events
+// with an input script.
+type scriptPipeline struct {
+	pipeline beat.PipelineConnector
+	script   *inputScript
+}
+
+func (p *scriptPipeline) Connect() (beat.Client, error) {
+	return p.ConnectWith(beat.ClientConfig{})
+}
+
+func (p *scriptPipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
+	sess, err := p.script.newSession()
+	if err != nil {
+		return nil, err
+	}
+	listener := &scriptListener{listener: cfg.EventListener}
+	cfg.EventListener = listener
+	client, err := p.pipeline.ConnectWith(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &scriptClient{client: client, listener: listener, session: sess, log: p.script.log}, nil
+}
+
+// scriptClient is a client publishing the events an input script transforms
+// the events published to it into.
+type scriptClient struct {
+	client   beat.Client
+	listener *scriptListener
+	log      *logp.Logger
+
+	mu      sync.Mutex
+	session *scriptSession
+}
+
+func (c *scriptClient) Publish(event beat.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.publish(event)
+}
+
+func (c *scriptClient) PublishAll(events []beat.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range events {
+		c.publish(e)
+	}
+}
+
+func (c *scriptClient) publish(event beat.Event) {
+	events, err := c.session.run(event)
+	if err != nil {
+		c.log.Debugw("failed to transform event", "error", err)
+		e := event.Clone()
+		_ = mapstr.AddTags(e.Fields, []string{scriptExceptionTag})
+		_, _ = e.PutValue("error.message", "failed to transform event: "+err.Error())
+		events = []beat.Event{*e}
+	}
+	c.listener.begin(event)
+	for _, e := range events {
+		c.client.Publish(e)
+	}
+	c.listener.end(len(events) == 0)
+}
+
+func (c *scriptClient) Close() error {
+	return c.client.Close()
+}
+
+// scriptListener is the event listener of the clients of a script client.
+// It reports the events published to the script client to the listener of
+// the input once all the events they were transformed into have been
+// acknowledged, so that inputs are not affected by the number of events
+// produced by the script.
+type scriptListener struct {
+	listener beat.EventListener
+
+	mu sync.Mutex
+	// groups are the published events that are not yet acknowledged,
+	// in publication order. The last group is being published while
+	// current is set.
+	groups  []*scriptGroup
+	current *scriptGroup
+}
+
+// scriptGroup is the set of events an event was transformed into.
+type scriptGroup struct {
+	event     beat.Event
+	seen      bool // At least an event of the group was handled by the client.
+	published bool // At least an event of the group was published.
+	pending   int  // Number of published events not yet acknowledged.
+	done      bool // All the events of the group were published.
+}
+
+// begin starts a group of events for event. AddEvent calls until end are
+// made for the events of the group.
+func (l *scriptListener) begin(event beat.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.current = &scriptGroup{event: event}
+	l.groups = append(l.groups, l.current)
+}
+
+// end ends the current group. dropped is true when the event was dropped
+// by the script.
+func (l *scriptListener) end(dropped bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	g := l.current
+	l.current = nil
+	g.done = true
+	if !g.published {
+		// None of the events were published, so that no ACK
+		// will be received for the group.
+		l.groups = l.groups[:len(l.groups)-1]
+	}
+	if l.listener != nil && (g.seen || dropped) {
+		l.listener.AddEvent(g.event, g.published)
+	}
+	l.flush()
+}
+
+func (l *scriptListener) AddEvent(_ beat.Event, published bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.current.seen = true
+	if published {
+		l.current.published = true
+		l.current.pending++
+	}
+}
+
+func (l *scriptListener) ACKEvents(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, g := range l.groups {
+		if n == 0 {
+			break
+		}
+		acked := min(n, g.pending)
+		g.pending -= acked
+		n -= acked
+	}
+	l.flush()
+}
+
+// flush reports the acknowledged groups to the listener of the input.
+// l.mu must be held.
+func (l *scriptListener) flush() {
+	n := 0
+	for _, g := range l.groups {
+		if !g.done || g.pending != 0 {
+			break
+		}
+		n++
+	}
+	l.groups = l.groups[n:]
+	if n != 0 && l.listener != nil {
+		l.listener.ACKEvents(n)
+	}
+}
+
+func (l *scriptListener) ClientClosed() {
+	if l.listener != nil {
+		l.listener.ClientClosed()
+	}
+}
@@ -0,0 +1,238 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package channel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestInputScript(t *testing.T) {
+	configYAML := `
+type: "filestream"
+processors:
+  - drop_event.when.equals.message: "b"
+script:
+  lang: starlark
+  params:
+    separator: ";"
+  source: |
+    state = {}
+
+    def register(params):
+        state["separator"] = params["separator"]
+
+    def process(event):
+        if event["message"] == "drop":
+            return None
+        return [
+            {"@timestamp": event["@timestamp"], "message": part, "count": 1}
+            for part in event["message"].split(state["separator"])
+            if part
+        ]
+`
+	cfg, err := conf.NewConfigWithYAML([]byte(configYAML), configYAML)
+	require.NoError(t, err)
+
+	var pipeline fakeACKPipeline
+	connector, err := withClientConfig(beat.Info{Logger: logptest.NewTestingLogger(t, "")}, &pipeline, cfg)
+	require.NoError(t, err)
+	var listener recordingListener
+	client, err := connector.ConnectWith(beat.ClientConfig{EventListener: &listener})
+	require.NoError(t, err)
+
+	ts := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	for _, fields := range []mapstr.M{
+		{"message": "a;b;c"},
+		{"message": "drop"},
+		{"message": "x"},
+		{"other": 1},
+	} {
+		client.Publish(beat.Event{Timestamp: ts, Fields: fields, Private: fields["message"]})
+	}
+
+	var got []mapstr.M
+	for _, e := range pipeline.client.published {
+		assert.Equal(t, ts, e.Timestamp)
+		msg, _ := e.Fields.GetValue("message")
+		if msg == nil {
+			// The event failing to be transformed is published unchanged.
+			msg = "a;b;c"
+		}
+		got = append(got, mapstr.M{"message": msg, "private": e.Private})
+	}
+	assert.Equal(t, []mapstr.M{
+		{"message": "a", "private": "a;b;c"},
+		{"message": "c", "private": "a;b;c"},
+		{"message": "x", "private": "x"},
+		{"message": "a;b;c", "private": nil},
+	}, got)
+	count, _ := pipeline.client.published[0].Fields.GetValue("count")
+	assert.Equal(t, int64(1), count)
+	last := pipeline.client.published[3]
+	assert.Equal(t, []string{scriptExceptionTag}, last.Fields["tags"])
+	assert.Contains(t, last.Fields["error"].(mapstr.M)["message"], "failed to transform event") //nolint:errcheck // Bad type will fail the test.
+
+	// The input is told about the events it published, not about the
+	// events produced by the script.
+	assert.Equal(t, []addedEvent{
+		{private: "a;b;c", published: true},
+		{private: "drop", published: false},
+		{private: "x", published: true},
+		{private: nil, published: true},
+	}, listener.added)
+
+	// Events published by the input are acknowledged once all the
+	// events they were transformed into are acknowledged.
+	pipeline.client.ack(1)
+	assert.Equal(t, 0, listener.acked)
+	pipeline.client.ack(1)
+	assert.Equal(t, 1, listener.acked)
+	pipeline.client.ack(2)
+	assert.Equal(t, 3, listener.acked)
+
+	require.NoError(t, client.Close())
+	assert.True(t, listener.closed)
+}
+
+func TestInputScriptTimeout(t *testing.T) {
+	configYAML := `
+script:
+  lang: starlark
+  timeout: 10ms
+  source: |
+    def process(event):
+        for i in range(1000000000):
+            pass
+        return event
+`
+	cfg, err := conf.NewConfigWithYAML([]byte(configYAML), configYAML)
+	require.NoError(t, err)
+	script, err := newInputScript(beat.Info{Logger: logptest.NewTestingLogger(t, "")}, cfg)
+	require.NoError(t, err)
+	sess, err := script.newSession()
+	require.NoError(t, err)
+
+	_, err = sess.run(beat.Event{Fields: mapstr.M{"message": "a"}})
+	assert.ErrorContains(t, err, "timeout transforming event")
+}
+
+func TestInputScriptConfig(t *testing.T) {
+	for name, test := range map[string]struct {
+		config  string
+		wantErr string
+	}{
+		"unsupported language": {
+			config:  `{script: {lang: lua, source: "function process(event) return event end"}}`,
+			wantErr: `unsupported script language "lua"`,
+		},
+		"missing source": {
+			config:  `{script: {lang: starlark}}`,
+			wantErr: "script must be defined",
+		},
+		"syntax error": {
+			config:  `{script: {lang: starlark, source: "def process(event"}}`,
+			wantErr: "failed to compile script",
+		},
+		"missing process": {
+			config:  `{script: {lang: starlark, source: "def transform(event): return event"}}`,
+			wantErr: "script must define a process function",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := conf.NewConfigWithYAML([]byte(test.config), name)
+			require.NoError(t, err)
+			_, err = newInputScript(beat.Info{Logger: logptest.NewTestingLogger(t, "")}, cfg)
+			assert.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
+// fakeACKPipeline creates a fakeACKClient.
+type fakeACKPipeline struct {
+	client *fakeACKClient
+}
+
+func (p *fakeACKPipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
+	p.client = &fakeACKClient{cfg: cfg}
+	return p.client, nil
+}
+
+func (p *fakeACKPipeline) Connect() (beat.Client, error) {
+	return p.ConnectWith(beat.ClientConfig{})
+}
+
+// fakeACKClient runs the processors of its configuration on the events
+// published to it, and acknowledges them on demand.
+type fakeACKClient struct {
+	cfg       beat.ClientConfig
+	published []beat.Event
+}
+
+func (c *fakeACKClient) Publish(e beat.Event) {
+	event, err := c.cfg.Processing.Processor.Run(&e)
+	if err != nil {
+		panic(err)
+	}
+	c.cfg.EventListener.AddEvent(e, event != nil)
+	if event != nil {
+		c.published = append(c.published, *event)
+	}
+}
+
+func (c *fakeACKClient) PublishAll(events []beat.Event) {
+	for _, e := range events {
+		c.Publish(e)
+	}
+}
+
+func (c *fakeACKClient) ack(n int) {
+	c.cfg.EventListener.ACKEvents(n)
+}
+
+func (c *fakeACKClient) Close() error {
+	c.cfg.EventListener.ClientClosed()
+	return nil
+}
+
+type addedEvent struct {
+	private   interface{}
+	published bool
+}
+
+// recordingListener records the calls made to it.
+type recordingListener struct {
+	added  []addedEvent
+	acked  int
+	closed bool
+}
+
+func (l *recordingListener) AddEvent(e beat.Event, published bool) {
+	l.added = append(l.added, addedEvent{private: e.Private, published: published})
+}
+
+func (l *recordingListener) ACKEvents(n int) { l.acked += n }
+func (l *recordingListener) ClientClosed()   { l.closed = true }
@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package filestream
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Values of the compression setting.
+const (
+	CompressionNone = "none"
+	CompressionAuto = "auto"
+	CompressionGZIP = "gzip"
+	CompressionZSTD = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// validateCompression checks that the compression setting is known.
+func validateCompression(compression string) error {
+	switch compression {
+	case "", CompressionNone, CompressionAuto, CompressionGZIP, CompressionZSTD:
+		return nil
+	}
+	return fmt.Errorf("unknown compression '%s', must be one of %s, %s, %s or %s",
+		compression, CompressionNone, CompressionAuto, CompressionGZIP, CompressionZSTD)
+}
+
+// detectCompression returns the codec a file is compressed with, based
+// on its magic bytes. Only the codecs enabled by the compression setting
+// are detected, an empty string is returned for all other files. The
+// file is rewound to its beginning.
+func detectCompression(f io.ReadSeeker, compression string) (string, error) {
+	if compression == "" || compression == CompressionNone {
+		return "", nil
+	}
+
+	magic := make([]byte, len(zstdMagic))
+	n, err := io.ReadFull(f, magic)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	magic = magic[:n]
+
+	switch {
+	case compression != CompressionZSTD && bytes.HasPrefix(magic, gzipMagic):
+		return CompressionGZIP, nil
+	case compression != CompressionGZIP && bytes.HasPrefix(magic, zstdMagic):
+		return CompressionZSTD, nil
+	}
+	return "", nil
+}
+
+// newDecompressor returns a reader of the decompressed content of r.
+// Closing the reader does not close r.
+func newDecompressor(r io.Reader, codec string) (io.ReadCloser, error) {
+	switch codec {
+	case CompressionGZIP:
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("could not create gzip reader: %w", err)
+		}
+		return zr, nil
+	case CompressionZSTD:
+		// A concurrency of 1 decodes synchronously, without background
+		// goroutines.
+		zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("could not create zstd reader: %w", err)
+		}
+		return zr.IOReadCloser(), nil
+	}
+	return nil, fmt.Errorf("unknown compression codec '%s'", codec)
+}
+
+// openDecompressor returns a reader of the decompressed content of f,
+// positioned at offset in the decompressed content. If the decompressed
+// content is shorter than the offset, the reader starts at the beginning
+// and truncated is true.
+func openDecompressor(f *os.File, codec string, offset int64) (dec io.Reader, truncated bool, err error) {
+	rc, err := newDecompressor(f, codec)
+	if err != nil {
+		return nil, false, err
+	}
+	if offset == 0 {
+		return rc, false, nil
+	}
+
+	_, err = io.CopyN(io.Discard, rc, offset)
+	if err == nil {
+		return rc, false, nil
+	}
+	_ = rc.Close()
+	if !errors.Is(err, io.EOF) {
+		return nil, false, fmt.Errorf("failed to skip to offset %d: %w", offset, err)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, true, err
+	}
+	dec, err = newDecompressor(f, codec)
+	return dec, true, err
+}
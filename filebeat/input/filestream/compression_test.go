@@ -0,0 +1,235 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package filestream
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	loginp "github.com/elastic/beats/v7/filebeat/input/filestream/internal/input-logfile"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestDetectCompression(t *testing.T) {
+	gz := compressTestData(t, CompressionGZIP, []byte("hello world\n"))
+	zst := compressTestData(t, CompressionZSTD, []byte("hello world\n"))
+	plain := []byte("hello world\n")
+
+	cases := []struct {
+		name        string
+		compression string
+		content     []byte
+		expected    string
+	}{
+		{"none does not detect gzip", CompressionNone, gz, ""},
+		{"empty does not detect gzip", "", gz, ""},
+		{"auto detects gzip", CompressionAuto, gz, CompressionGZIP},
+		{"auto detects zstd", CompressionAuto, zst, CompressionZSTD},
+		{"auto ignores plain files", CompressionAuto, plain, ""},
+		{"auto ignores short files", CompressionAuto, []byte{0x1f}, ""},
+		{"gzip detects gzip", CompressionGZIP, gz, CompressionGZIP},
+		{"gzip ignores zstd", CompressionGZIP, zst, ""},
+		{"zstd detects zstd", CompressionZSTD, zst, CompressionZSTD},
+		{"zstd ignores gzip", CompressionZSTD, gz, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := bytes.NewReader(tc.content)
+			codec, err := detectCompression(r, tc.compression)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, codec)
+
+			offset, err := r.Seek(0, io.SeekCurrent)
+			require.NoError(t, err)
+			assert.Zero(t, offset, "reader must be rewound")
+		})
+	}
+}
+
+func TestValidateCompression(t *testing.T) {
+	for _, compression := range []string{"", CompressionNone, CompressionAuto, CompressionGZIP, CompressionZSTD} {
+		assert.NoError(t, validateCompression(compression), compression)
+	}
+	assert.Error(t, validateCompression("lz4"))
+}
+
+func TestOpenDecompressor(t *testing.T) {
+	content := []byte("first line\nsecond line\nthird line\n")
+
+	for _, codec := range []string{CompressionGZIP, CompressionZSTD} {
+		t.Run(codec, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "test.log.1")
+			require.NoError(t, os.WriteFile(filename, compressTestData(t, codec, content), 0o644))
+
+			read := func(offset int64) ([]byte, bool) {
+				f, err := os.Open(filename)
+				require.NoError(t, err)
+				defer f.Close()
+
+				dec, truncated, err := openDecompressor(f, codec, offset)
+				require.NoError(t, err)
+				data, err := io.ReadAll(dec)
+				require.NoError(t, err)
+				return data, truncated
+			}
+
+			data, truncated := read(0)
+			assert.Equal(t, content, data)
+			assert.False(t, truncated)
+
+			data, truncated = read(11)
+			assert.Equal(t, "second line\nthird line\n", string(data))
+			assert.False(t, truncated)
+
+			data, truncated = read(int64(len(content)))
+			assert.Empty(t, data)
+			assert.False(t, truncated)
+
+			data, truncated = read(int64(len(content)) + 1)
+			assert.Equal(t, content, data, "a too large offset must read from the beginning")
+			assert.True(t, truncated)
+		})
+	}
+}
+
+func TestFileScannerCompressed(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "*.log*")}
+	content := []byte(strings.Repeat("a log line\n", 10))
+
+	plainFilename := filepath.Join(dir, "test.log")
+	gzFilename := filepath.Join(dir, "test.log.1.gz")
+	zstFilename := filepath.Join(dir, "test.log.2.zst")
+	incompleteFilename := filepath.Join(dir, "test.log.3.gz")
+	smallFilename := filepath.Join(dir, "test.log.4.gz")
+
+	var incomplete []byte
+	for i := 0; i < 100; i++ {
+		incomplete = append(incomplete, fmt.Sprintf("line %d %x\n", i, i*7919)...)
+	}
+	require.NoError(t, os.WriteFile(plainFilename, content, 0o644))
+	gz := compressTestData(t, CompressionGZIP, content)
+	require.NoError(t, os.WriteFile(gzFilename, gz, 0o644))
+	zst := compressTestData(t, CompressionZSTD, append([]byte("other "), content...))
+	require.NoError(t, os.WriteFile(zstFilename, zst, 0o644))
+	incompleteGz := compressTestData(t, CompressionGZIP, incomplete)
+	require.NoError(t, os.WriteFile(incompleteFilename, incompleteGz[:30], 0o644))
+	require.NoError(t, os.WriteFile(smallFilename, compressTestData(t, CompressionGZIP, []byte("short")), 0o644))
+
+	cfg := fileScannerConfig{
+		Fingerprint: fingerprintConfig{
+			Enabled: true,
+			Offset:  0,
+			Length:  64,
+		},
+	}
+	s, err := newFileScanner(logp.NewNopLogger(), paths, cfg)
+	require.NoError(t, err)
+	s.compression = CompressionAuto
+
+	files := s.GetFiles()
+	require.Len(t, files, 2, "only the plain and the zstd file must be found")
+	plain := files[plainFilename]
+	assert.Empty(t, plain.Compression)
+	assert.Equal(t, CompressionZSTD, files[zstFilename].Compression)
+
+	// once the plain file is removed, its compressed copy is found with
+	// the same fingerprint.
+	require.NoError(t, os.Remove(plainFilename))
+	files = s.GetFiles()
+	require.Len(t, files, 2)
+	require.Contains(t, files, gzFilename)
+	assert.Equal(t, CompressionGZIP, files[gzFilename].Compression)
+	assert.Equal(t, plain.Fingerprint, files[gzFilename].Fingerprint)
+}
+
+func TestFileWatcherCompressedRotation(t *testing.T) {
+	dir := t.TempDir()
+	paths := []string{filepath.Join(dir, "*.log*")}
+	cfgStr := `
+scanner:
+  check_interval: 100ms
+  fingerprint:
+    enabled: true
+    offset: 0
+    length: 64
+`
+	content := []byte(strings.Repeat("a log line\n", 10))
+	filename := filepath.Join(dir, "test.log")
+	gzFilename := filepath.Join(dir, "test.log.1.gz")
+	require.NoError(t, os.WriteFile(filename, content, 0o644))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg, err := conf.NewConfigWithYAML([]byte(cfgStr), cfgStr)
+	require.NoError(t, err)
+	ns := &conf.Namespace{}
+	require.NoError(t, ns.Unpack(cfg))
+
+	fw, err := newFileWatcher(logp.NewNopLogger(), paths, ns, CompressionAuto)
+	require.NoError(t, err)
+	go fw.Run(ctx)
+
+	e := fw.Event()
+	require.Equal(t, loginp.OpCreate, e.Op)
+	require.Equal(t, filename, e.NewPath)
+
+	require.NoError(t, os.WriteFile(gzFilename, compressTestData(t, CompressionGZIP, content), 0o644))
+	require.NoError(t, os.Remove(filename))
+
+	e = fw.Event()
+	assert.Equal(t, loginp.OpRename, e.Op)
+	assert.Equal(t, filename, e.OldPath)
+	assert.Equal(t, gzFilename, e.NewPath)
+	assert.Equal(t, CompressionGZIP, e.Descriptor.Compression)
+}
+
+func compressTestData(t *testing.T, codec string, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch codec {
+	case CompressionGZIP:
+		w = gzip.NewWriter(&buf)
+	case CompressionZSTD:
+		zw, err := zstd.NewWriter(&buf)
+		require.NoError(t, err)
+		w = zw
+	default:
+		t.Fatalf("unknown codec %s", codec)
+	}
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
@@ -50,6 +50,7 @@ type config struct {
 	IgnoreInactive ignoreInactiveType `config:"ignore_inactive"`
 	Rotation       *conf.Namespace    `config:"rotation"`
 	TakeOver       takeOverConfig     `config:"take_over"`
+	Compression    string             `config:"compression"`
 
 	// AllowIDDuplication is used by InputManager.Create
 	// (see internal/input-logfile/manager.go).
@@ -168,6 +169,10 @@ func (c *config) Validate() error {
 		return errors.New("'take_over' mode is only allowed if an input ID is set")
 	}
 
+	if err := validateCompression(c.Compression); err != nil {
+		return err
+	}
+
 	return nil
 }
 
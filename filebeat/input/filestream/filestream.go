@@ -45,6 +45,12 @@ type logFile struct {
 	log       *logp.Logger
 	readerCtx ctxtool.CancelContext
 
+	// decompressor reads the decompressed content of compressed files,
+	// it is nil for all other files. It is not closed with the file, as
+	// Close may run concurrently to Read and the decompressors do not hold
+	// any resources besides the file.
+	decompressor io.Reader
+
 	closeAfterInterval time.Duration
 	closeOnEOF         bool
 
@@ -72,11 +78,39 @@ func newFileReader(
 		return nil, err
 	}
 
+	return newLogFile(log, canceler, f, nil, offset, config, closerConfig), nil
+}
+
+// newCompressedFileReader creates a new log instance to read the decompressed
+// content of a compressed file. The offset is the offset in the decompressed
+// content the decompressor is positioned at.
+func newCompressedFileReader(
+	log *logp.Logger,
+	canceler input.Canceler,
+	f *os.File,
+	decompressor io.Reader,
+	offset int64,
+	config readerConfig,
+	closerConfig closerConfig,
+) *logFile {
+	return newLogFile(log, canceler, f, decompressor, offset, config, closerConfig)
+}
+
+func newLogFile(
+	log *logp.Logger,
+	canceler input.Canceler,
+	f *os.File,
+	decompressor io.Reader,
+	offset int64,
+	config readerConfig,
+	closerConfig closerConfig,
+) *logFile {
 	readerCtx := ctxtool.WithCancelContext(ctxtool.FromCanceller(canceler))
 	tg := unison.TaskGroupWithCancel(readerCtx)
 
 	l := &logFile{
 		file:               f,
+		decompressor:       decompressor,
 		log:                log,
 		closeAfterInterval: closerConfig.Reader.AfterInterval,
 		closeOnEOF:         closerConfig.Reader.OnEOF,
@@ -93,7 +127,7 @@ func newFileReader(
 
 	l.startFileMonitoringIfNeeded()
 
-	return l, nil
+	return l
 }
 
 // Read reads from the reader and updates the offset
@@ -102,7 +136,7 @@ func (f *logFile) Read(buf []byte) (int, error) {
 	totalN := 0
 
 	for f.readerCtx.Err() == nil {
-		n, err := f.file.Read(buf)
+		n, err := f.read(buf)
 		if n > 0 {
 			f.offset += int64(n)
 			f.lastTimeRead = time.Now()
@@ -135,6 +169,19 @@ func (f *logFile) Read(buf []byte) (int, error) {
 	return 0, ErrClosed
 }
 
+func (f *logFile) read(buf []byte) (int, error) {
+	if f.decompressor != nil {
+		return f.decompressor.Read(buf)
+	}
+	return f.file.Read(buf)
+}
+
+// isClosed returns true once the reader is closed, either because the
+// harvester stopped or because of a close.* setting.
+func (f *logFile) isClosed() bool {
+	return f.readerCtx.Err() != nil
+}
+
 func (f *logFile) startFileMonitoringIfNeeded() {
 	if f.closeInactive > 0 || f.closeRemoved || f.closeRenamed {
 		err := f.tg.Go(func(ctx context.Context) error {
@@ -48,6 +48,10 @@ const (
 
 var (
 	errFileTooSmall = errors.New("file size is too small for ingestion")
+	// errFileIncomplete is returned for compressed files whose content
+	// cannot be fingerprinted yet, usually because they are still being
+	// written.
+	errFileIncomplete = errors.New("compressed file is incomplete")
 )
 
 type fileWatcherConfig struct {
@@ -70,7 +74,7 @@ type fileWatcher struct {
 	events  chan loginp.FSEvent
 }
 
-func newFileWatcher(logger *logp.Logger, paths []string, ns *conf.Namespace) (loginp.FSWatcher, error) {
+func newFileWatcher(logger *logp.Logger, paths []string, ns *conf.Namespace, compression string) (loginp.FSWatcher, error) {
 	var config *conf.C
 	if ns == nil {
 		config = conf.NewConfig()
@@ -78,10 +82,10 @@ func newFileWatcher(logger *logp.Logger, paths []string, ns *conf.Namespace) (lo
 		config = ns.Config()
 	}
 
-	return newScannerWatcher(logger, paths, config)
+	return newScannerWatcher(logger, paths, config, compression)
 }
 
-func newScannerWatcher(logger *logp.Logger, paths []string, c *conf.C) (loginp.FSWatcher, error) {
+func newScannerWatcher(logger *logp.Logger, paths []string, c *conf.C, compression string) (loginp.FSWatcher, error) {
 	config := defaultFileWatcherConfig()
 	err := c.Unpack(&config)
 	if err != nil {
@@ -91,6 +95,7 @@ func newScannerWatcher(logger *logp.Logger, paths []string, c *conf.C) (loginp.F
 	if err != nil {
 		return nil, err
 	}
+	scanner.compression = compression
 
 	return &fileWatcher{
 		log:     logger.Named(watcherDebugKey),
@@ -294,6 +299,10 @@ type fileScanner struct {
 	log        *logp.Logger
 	hasher     hash.Hash
 	readBuffer []byte
+	// compression is the compression setting of the input, compressed
+	// files are detected and fingerprinted on their decompressed content
+	// unless it is empty or none.
+	compression string
 }
 
 func newFileScanner(logger *logp.Logger, paths []string, config fileScannerConfig) (*fileScanner, error) {
@@ -398,6 +407,10 @@ func (s *fileScanner) GetFiles() map[string]loginp.FileDescriptor {
 				s.log.Debugf("cannot start ingesting from file %q: %s", filename, err)
 				continue
 			}
+			if errors.Is(err, errFileIncomplete) {
+				s.log.Debugf("cannot start ingesting from file %q yet: %s", filename, err)
+				continue
+			}
 			if err != nil {
 				s.log.Warnf("cannot create a file descriptor for an ingest target %q: %s", filename, err)
 				continue
@@ -405,6 +418,12 @@ func (s *fileScanner) GetFiles() map[string]loginp.FileDescriptor {
 
 			fileID := fd.FileID()
 			if knownFilename, exists := uniqueIDs[fileID]; exists {
+				if fd.Compression != "" {
+					// The file it was compressed from is still there,
+					// the compressed file is picked up once it is removed.
+					s.log.Debugf("%q is a compressed copy of %q. Skipping", fd.Filename, knownFilename)
+					continue
+				}
 				s.log.Warnf("%q points to an already known ingest target %q [%s==%s]. Skipping", fd.Filename, knownFilename, fileID, fileID)
 				continue
 			}
@@ -498,6 +517,22 @@ func (s *fileScanner) toFileDescriptor(it *ingestTarget) (fd loginp.FileDescript
 	fd.Filename = it.filename
 	fd.Info = it.info
 
+	if s.compression != "" && s.compression != CompressionNone {
+		file, err := os.Open(it.originalFilename)
+		if err != nil {
+			return fd, fmt.Errorf("failed to open %q to detect compression: %w", it.originalFilename, err)
+		}
+		defer file.Close()
+
+		fd.Compression, err = detectCompression(file, s.compression)
+		if err != nil {
+			return fd, fmt.Errorf("failed to detect compression of %q: %w", fd.Filename, err)
+		}
+		if fd.Compression != "" {
+			return s.fingerprintCompressed(fd, file)
+		}
+	}
+
 	if s.cfg.Fingerprint.Enabled {
 		fileSize := it.info.Size()
 		// we should not open the file if we know it's too small
@@ -535,6 +570,45 @@ func (s *fileScanner) toFileDescriptor(it *ingestTarget) (fd loginp.FileDescript
 	return fd, nil
 }
 
+// fingerprintCompressed computes the fingerprint of a compressed file on
+// its decompressed content. This way a compressed file has the same
+// fingerprint as the file it was compressed from, so reading continues
+// where it left off when a file is compressed after rotation.
+func (s *fileScanner) fingerprintCompressed(fd loginp.FileDescriptor, file *os.File) (loginp.FileDescriptor, error) {
+	if !s.cfg.Fingerprint.Enabled {
+		return fd, nil
+	}
+
+	dec, err := newDecompressor(file, fd.Compression)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return fd, fmt.Errorf("header of %q is not written yet: %w", fd.Filename, errFileIncomplete)
+		}
+		return fd, fmt.Errorf("failed to decompress %q for fingerprinting: %w", fd.Filename, err)
+	}
+	defer dec.Close()
+
+	s.hasher.Reset()
+	_, err = io.CopyN(io.Discard, dec, s.cfg.Fingerprint.Offset)
+	var written int64
+	if err == nil {
+		lr := io.LimitReader(dec, s.cfg.Fingerprint.Length)
+		written, err = io.CopyBuffer(s.hasher, lr, s.readBuffer)
+	}
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return fd, fmt.Errorf("content of %q is not written yet: %w", fd.Filename, errFileIncomplete)
+	case errors.Is(err, io.EOF), err == nil && written != s.cfg.Fingerprint.Length:
+		minSize := s.cfg.Fingerprint.Offset + s.cfg.Fingerprint.Length
+		return fd, fmt.Errorf("decompressed content of %q is smaller than %d bytes needed for fingerprinting: %w", fd.Filename, minSize, errFileTooSmall)
+	case err != nil:
+		return fd, fmt.Errorf("failed to compute hash for first %d decompressed bytes of %q: %w", s.cfg.Fingerprint.Length, fd.Filename, err)
+	}
+
+	fd.Fingerprint = hex.EncodeToString(s.hasher.Sum(nil))
+	return fd, nil
+}
+
 func (s *fileScanner) isFileExcluded(file string) bool {
 	return len(s.cfg.ExcludedFiles) > 0 && s.matchAny(s.cfg.ExcludedFiles, file)
 }
@@ -65,6 +65,7 @@ type filestream struct {
 	closerConfig    closerConfig
 	parsers         parser.Config
 	takeOver        takeOverConfig
+	compression     string
 }
 
 // Plugin creates a new filestream input plugin for creating a stateful input.
@@ -107,6 +108,7 @@ func configure(cfg *conf.C, log *logp.Logger) (loginp.Prospector, loginp.Harvest
 		closerConfig:    config.Close,
 		parsers:         config.Reader.Parsers,
 		takeOver:        config.TakeOver,
+		compression:     config.Compression,
 	}
 
 	return prospector, filestream, nil
@@ -120,7 +122,7 @@ func (inp *filestream) Test(src loginp.Source, ctx input.TestContext) error {
 		return fmt.Errorf("not file source")
 	}
 
-	reader, _, err := inp.open(ctx.Logger, ctx.Cancelation, fs, 0)
+	reader, _, _, err := inp.open(ctx.Logger, ctx.Cancelation, fs, 0)
 	if err != nil {
 		return err
 	}
@@ -142,7 +144,7 @@ func (inp *filestream) Run(
 	log := ctx.Logger.With("path", fs.newPath).With("state-id", src.Name())
 	state := initState(log, cursor, fs)
 
-	r, truncated, err := inp.open(log, ctx.Cancelation, fs, state.Offset)
+	r, lf, truncated, err := inp.open(log, ctx.Cancelation, fs, state.Offset)
 	if err != nil {
 		log.Errorf("File could not be opened for reading: %v", err)
 		return err
@@ -168,7 +170,7 @@ func (inp *filestream) Run(
 
 	// The caller of Run already reports the error and filters out errors that
 	// must not be reported, like 'context cancelled'.
-	return inp.readFromSource(ctx, log, r, fs.newPath, state, publisher, metrics)
+	return inp.readFromSource(ctx, log, r, lf, fs.newPath, state, publisher, metrics)
 }
 
 func initState(log *logp.Logger, c loginp.Cursor, s fileSource) state {
@@ -185,16 +187,18 @@ func initState(log *logp.Logger, c loginp.Cursor, s fileSource) state {
 	return state
 }
 
+// open returns the reader of the parsed messages of the file, and the
+// underlying file reader.
 func (inp *filestream) open(
 	log *logp.Logger,
 	canceler input.Canceler,
 	fs fileSource,
 	offset int64,
-) (reader.Reader, bool, error) {
+) (reader.Reader, *logFile, bool, error) {
 
-	f, encoding, truncated, err := inp.openFile(log, fs.newPath, offset)
+	f, dec, encoding, truncated, err := inp.openFile(log, fs.newPath, fs.desc.Compression, offset)
 	if err != nil {
-		return nil, truncated, err
+		return nil, nil, truncated, err
 	}
 
 	if truncated {
@@ -207,9 +211,9 @@ func (inp *filestream) open(
 	log.Debug("newLogFileReader with config.MaxBytes:", inp.readerConfig.MaxBytes)
 
 	// if the file is archived, it means that it is not going to be updated in the future
-	// thus, when EOF is reached, it can be closed
+	// thus, when EOF is reached, it can be closed. The same applies to compressed files.
 	closerCfg := inp.closerConfig
-	if fs.archived && !inp.closerConfig.Reader.OnEOF {
+	if (fs.archived || dec != nil) && !inp.closerConfig.Reader.OnEOF {
 		closerCfg = closerConfig{
 			Reader: readerCloserConfig{
 				OnEOF:         true,
@@ -221,14 +225,19 @@ func (inp *filestream) open(
 	// NewLineReader uses additional buffering to deal with encoding and testing
 	// for new lines in input stream. Simple 8-bit based encodings, or plain
 	// don't require 'complicated' logic.
-	logReader, err := newFileReader(log, canceler, f, inp.readerConfig, closerCfg)
-	if err != nil {
-		return nil, truncated, err
+	var logReader *logFile
+	if dec != nil {
+		logReader = newCompressedFileReader(log, canceler, f, dec, offset, inp.readerConfig, closerCfg)
+	} else {
+		logReader, err = newFileReader(log, canceler, f, inp.readerConfig, closerCfg)
+		if err != nil {
+			return nil, nil, truncated, err
+		}
 	}
 
 	dbgReader, err := debug.AppendReaders(logReader)
 	if err != nil {
-		return nil, truncated, err
+		return nil, nil, truncated, err
 	}
 
 	// Configure MaxBytes limit for EncodeReader as multiplied by 4
@@ -245,7 +254,7 @@ func (inp *filestream) open(
 		MaxBytes:   encReaderMaxBytes,
 	})
 	if err != nil {
-		return nil, truncated, err
+		return nil, nil, truncated, err
 	}
 
 	r = readfile.NewStripNewline(r, inp.readerConfig.LineTerminator)
@@ -257,7 +266,7 @@ func (inp *filestream) open(
 	r = readfile.NewLimitReader(r, inp.readerConfig.MaxBytes)
 
 	ok = true // no need to close the file
-	return r, truncated, nil
+	return r, logReader, truncated, nil
 }
 
 // openFile opens a file and checks for the encoding. In case the encoding cannot be detected
@@ -266,63 +275,84 @@ func (inp *filestream) open(
 // the file system is scanned.
 //
 // openFile will also detect and hadle file truncation. If a file is truncated
-// then the 3rd return value is true.
+// then the 4th return value is true.
+//
+// If the file is compressed, the 2nd return value reads its decompressed
+// content, starting at offset in the decompressed content.
 func (inp *filestream) openFile(
 	log *logp.Logger,
 	path string,
+	compression string,
 	offset int64,
-) (*os.File, encoding.Encoding, bool, error) {
+) (*os.File, io.Reader, encoding.Encoding, bool, error) {
 	fi, err := os.Stat(path)
 	if err != nil {
-		return nil, nil, false, fmt.Errorf("failed to stat source file %s: %w", path, err)
+		return nil, nil, nil, false, fmt.Errorf("failed to stat source file %s: %w", path, err)
 	}
 
 	// it must be checked if the file is not a named pipe before we try to open it
 	// if it is a named pipe os.OpenFile fails, so there is no need to try opening it.
 	if fi.Mode()&os.ModeNamedPipe != 0 {
-		return nil, nil, false, fmt.Errorf("failed to open file %s, named pipes are not supported", fi.Name())
+		return nil, nil, nil, false, fmt.Errorf("failed to open file %s, named pipes are not supported", fi.Name())
 	}
 
 	f, err := file.ReadOpen(path)
 	if err != nil {
-		return nil, nil, false, fmt.Errorf("failed opening %s: %w", path, err)
+		return nil, nil, nil, false, fmt.Errorf("failed opening %s: %w", path, err)
 	}
 	ok := false
 	defer cleanup.IfNot(&ok, cleanup.IgnoreError(f.Close))
 
 	fi, err = f.Stat()
 	if err != nil {
-		return nil, nil, false, fmt.Errorf("failed to stat source file %s: %w", path, err)
+		return nil, nil, nil, false, fmt.Errorf("failed to stat source file %s: %w", path, err)
 	}
 
 	err = checkFileBeforeOpening(fi)
 	if err != nil {
-		return nil, nil, false, err
+		return nil, nil, nil, false, err
 	}
 
 	truncated := false
-	if fi.Size() < offset {
-		// if the file was truncated we need to reset the offset and notify
-		// all callers so they can also reset their offsets
-		truncated = true
-		log.Infof("File was truncated. Reading file from offset 0. Path=%s", path)
-		offset = 0
-	}
-	err = inp.initFileOffset(f, offset)
-	if err != nil {
-		return nil, nil, truncated, err
+	var dec io.Reader
+	if compression != "" {
+		// The offset of compressed files is an offset in the decompressed
+		// content, so it cannot be checked against the file size.
+		dec, truncated, err = openDecompressor(f, compression, offset)
+		if err != nil {
+			return nil, nil, nil, truncated, fmt.Errorf("failed to decompress %s: %w", path, err)
+		}
+		if truncated {
+			log.Infof("Decompressed file is shorter than the offset. Reading file from offset 0. Path=%s", path)
+		}
+	} else {
+		if fi.Size() < offset {
+			// if the file was truncated we need to reset the offset and notify
+			// all callers so they can also reset their offsets
+			truncated = true
+			log.Infof("File was truncated. Reading file from offset 0. Path=%s", path)
+			offset = 0
+		}
+		err = inp.initFileOffset(f, offset)
+		if err != nil {
+			return nil, nil, nil, truncated, err
+		}
 	}
 
-	encoding, err := inp.encodingFactory(f)
+	var r io.Reader = f
+	if dec != nil {
+		r = dec
+	}
+	encoding, err := inp.encodingFactory(r)
 	if err != nil {
 		if errors.Is(err, transform.ErrShortSrc) {
-			return nil, nil, truncated, fmt.Errorf("initialising encoding for '%v' failed due to file being too short", f)
+			return nil, nil, nil, truncated, fmt.Errorf("initialising encoding for '%v' failed due to file being too short", f)
 		}
-		return nil, nil, truncated, fmt.Errorf("initialising encoding for '%v' failed: %w", f, err)
+		return nil, nil, nil, truncated, fmt.Errorf("initialising encoding for '%v' failed: %w", f, err)
 	}
 
 	ok = true // no need to close the file
-	return f, encoding, truncated, nil
+	return f, dec, encoding, truncated, nil
 }
 
 func checkFileBeforeOpening(fi os.FileInfo) error {
@@ -348,6 +378,7 @@ func (inp *filestream) readFromSource(
 	ctx input.Context,
 	log *logp.Logger,
 	r reader.Reader,
+	lf *logFile,
 	path string,
 	s state,
 	p loginp.Publisher,
@@ -362,6 +393,14 @@ func (inp *filestream) readFromSource(
 
 	for ctx.Cancelation.Err() == nil {
 		message, err := r.Next()
+		if err == nil && inp.compression != "" && inp.compression != CompressionNone && lf.isClosed() {
+			// The reader was closed while reading the message, which might
+			// be an incomplete multiline event flushed when closing. Leave it
+			// to the next harvester, for example the one of the compressed
+			// file replacing this file after rotation, so it is read as a whole.
+			log.Debugf("Reader was closed, leaving last message to be read again. Path='%s'", path)
+			return nil
+		}
 		if err != nil {
 			if errors.Is(err, ErrFileTruncate) {
 				log.Infof("File was truncated, nothing to read. Path='%s'", path)
@@ -1161,3 +1161,91 @@ func TestRotatingCloseInactiveLowWriteRate(t *testing.T) {
 	cancelInput()
 	env.waitUntilInputStops()
 }
+
+func TestFilestreamCompressedRotation(t *testing.T) {
+	env := newInputTestingEnvironment(t)
+
+	testlogName := "test.log"
+	id := "fake-ID-" + uuid.Must(uuid.NewV4()).String()
+	inp := env.mustCreateInput(map[string]interface{}{
+		"id":                                    id,
+		"paths":                                 []string{env.abspath(testlogName) + "*"},
+		"compression":                           "auto",
+		"prospector.scanner.check_interval":     "1ms",
+		"prospector.scanner.fingerprint.length": 64,
+	})
+
+	lines := "first log line, long enough to be fingerprinted\nsecond log line\n"
+	env.mustWriteToFile(testlogName, []byte(lines))
+
+	ctx, cancelInput := context.WithCancel(context.Background())
+	env.startInput(ctx, id, inp)
+
+	env.waitUntilEventCount(2)
+
+	// the file is compressed after rotation before the last line was read
+	rotated := compressTestData(t, CompressionGZIP, []byte(lines+"third log line\n"))
+	env.mustWriteToFile(testlogName+".1.gz", rotated)
+	env.mustRemoveFile(testlogName)
+
+	env.waitUntilEventCount(3)
+	env.requireEventsReceived([]string{
+		"first log line, long enough to be fingerprinted",
+		"second log line",
+		"third log line",
+	})
+
+	cancelInput()
+	env.waitUntilInputStops()
+}
+
+func TestFilestreamCompressedRotationMultiline(t *testing.T) {
+	env := newInputTestingEnvironment(t)
+
+	testlogName := "test.log"
+	id := "fake-ID-" + uuid.Must(uuid.NewV4()).String()
+	inp := env.mustCreateInput(map[string]interface{}{
+		"id":                                    id,
+		"paths":                                 []string{env.abspath(testlogName) + "*"},
+		"compression":                           "auto",
+		"prospector.scanner.check_interval":     "100ms",
+		"prospector.scanner.fingerprint.length": 64,
+		"close.on_state_change.check_interval":  "1ms",
+		"close.on_state_change.removed":         true,
+		"parsers": []map[string]interface{}{
+			{
+				"multiline": map[string]interface{}{
+					"type":    "pattern",
+					"pattern": "^\\[",
+					"negate":  true,
+					"match":   "after",
+					"timeout": "1h",
+				},
+			},
+		},
+	})
+
+	lines := "[1] first event, long enough to be fingerprinted\n[2] second event\n  continued\n"
+	env.mustWriteToFile(testlogName, []byte(lines))
+
+	ctx, cancelInput := context.WithCancel(context.Background())
+	env.startInput(ctx, id, inp)
+
+	env.waitUntilEventCount(1)
+
+	// the second event continues in the compressed file, the reader of
+	// the removed file must not flush it incomplete.
+	rotated := compressTestData(t, CompressionGZIP, []byte(lines+"  continued after rotation\n[3] third event\n"))
+	env.mustWriteToFile(testlogName+".1.gz", rotated)
+	env.mustRemoveFile(testlogName)
+
+	env.waitUntilEventCount(3)
+	env.requireEventsReceived([]string{
+		"[1] first event, long enough to be fingerprinted",
+		"[2] second event\n  continued\n  continued after rotation",
+		"[3] third event",
+	})
+
+	cancelInput()
+	env.waitUntilInputStops()
+}
@@ -434,6 +434,15 @@ func (p *fileProspector) onRename(log *logp.Logger, ctx input.Context, fe loginp
 			log.Errorf("Failed to update cursor meta data of entry %s: %v", src.Name(), err)
 		}
 
+		if fe.Descriptor.Compression != "" {
+			// The file has been replaced by its compressed copy, usually after
+			// rotation. The harvester is restarted on the compressed file, so
+			// it continues from where it left off in the removed file.
+			log.Debugf("Continuing harvester on compressed file %s", fe.NewPath)
+			hg.Restart(ctx, src)
+			return
+		}
+
 		if p.stateChangeCloser.Renamed {
 			log.Debugf("Stopping harvester as file %s has been renamed and close.on_state_change.renamed is enabled.", src.Name())
 
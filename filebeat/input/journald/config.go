@@ -20,6 +20,9 @@
 package journald
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
 	"sync"
 	"time"
 
@@ -54,9 +57,16 @@ type config struct {
 	// Matches store the key value pairs to match entries.
 	Matches bwcIncludeMatches `config:"include_matches"`
 
+	// SinceBoot is the boot to start reading the journal from when there is
+	// no saved position. It takes precedence over Seek.
+	SinceBoot sinceBoot `config:"since_boot"`
+
 	// Units stores the units to monitor.
 	Units []string `config:"units"`
 
+	// UserUnits stores the user units to monitor.
+	UserUnits []string `config:"user_units"`
+
 	// Transports stores the list of transports to include in the messages.
 	Transports []string `config:"transports"`
 
@@ -73,6 +83,43 @@ type config struct {
 	Parsers parser.Config `config:",inline"`
 }
 
+// bootIDPattern matches a boot ID as displayed by `journalctl --list-boots`.
+var bootIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// sinceBoot is a boot to read the journal from. It is either "current",
+// "previous", a boot ID or an offset relative to the current boot, as
+// accepted by `journalctl --boot`.
+type sinceBoot struct {
+	boot string
+}
+
+func (b *sinceBoot) Unpack(value string) error {
+	switch value {
+	case "", "current", "previous":
+	default:
+		if bootIDPattern.MatchString(value) {
+			break
+		}
+		if offset, err := strconv.Atoi(value); err != nil || offset > 0 {
+			return fmt.Errorf("invalid since_boot '%s', must be current, previous, a boot ID or an offset lower or equal to 0", value)
+		}
+	}
+	b.boot = value
+	return nil
+}
+
+// arg returns the value of the journalctl --boot argument.
+func (b sinceBoot) arg() string {
+	switch b.boot {
+	case "current":
+		return "0"
+	case "previous":
+		return "-1"
+	default:
+		return b.boot
+	}
+}
+
 // bwcIncludeMatches is a wrapper that accepts include_matches configuration
 // from 7.x to allow old config to remain compatible.
 type bwcIncludeMatches journalfield.IncludeMatches
@@ -61,3 +61,37 @@ include_matches:
 		verify(t, yaml)
 	})
 }
+
+func TestConfigSinceBoot(t *testing.T) {
+	testCases := map[string]struct {
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		"current":  {value: "current", expected: "0"},
+		"previous": {value: "previous", expected: "-1"},
+		"offset":   {value: "-2", expected: "-2"},
+		"boot ID":  {value: "567980bb85ae41da8518f409570b0cb9", expected: "567980bb85ae41da8518f409570b0cb9"},
+		"positive": {value: "1", wantErr: true},
+		"invalid":  {value: "last", wantErr: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := conf.MustNewConfigFrom(map[string]interface{}{
+				"since_boot": tc.value,
+				"user_units": []string{"foo.service"},
+			})
+
+			config := defaultConfig()
+			err := c.Unpack(&config)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, config.SinceBoot.arg())
+			assert.Equal(t, []string{"foo.service"}, config.UserUnits)
+		})
+	}
+}
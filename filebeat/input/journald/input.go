@@ -52,6 +52,8 @@ type journald struct {
 	Seek               journalctl.SeekMode
 	Matches            journalfield.IncludeMatches
 	Units              []string
+	UserUnits          []string
+	SinceBoot          string
 	Transports         []string
 	Identifiers        []string
 	Facilities         []int
@@ -117,6 +119,8 @@ func Configure(cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
 		Seek:               config.Seek,
 		Matches:            journalfield.IncludeMatches(config.Matches),
 		Units:              config.Units,
+		UserUnits:          config.UserUnits,
+		SinceBoot:          config.SinceBoot.arg(),
 		Transports:         config.Transports,
 		Identifiers:        config.Identifiers,
 		Facilities:         config.Facilities,
@@ -132,6 +136,7 @@ func (inp *journald) Test(src cursor.Source, ctx input.TestContext) error {
 		ctx.Logger.With("input_id", inp.ID),
 		ctx.Cancelation,
 		inp.Units,
+		inp.UserUnits,
 		inp.Identifiers,
 		inp.Transports,
 		inp.Matches,
@@ -139,6 +144,7 @@ func (inp *journald) Test(src cursor.Source, ctx input.TestContext) error {
 		journalctl.SeekHead,
 		"",
 		inp.Since,
+		"",
 		src.Name(),
 		journalctl.Factory,
 	)
@@ -167,6 +173,7 @@ func (inp *journald) Run(
 		logger,
 		ctx.Cancelation,
 		inp.Units,
+		inp.UserUnits,
 		inp.Identifiers,
 		inp.Transports,
 		inp.Matches,
@@ -174,6 +181,7 @@ func (inp *journald) Run(
 		mode,
 		pos,
 		inp.Since,
+		inp.SinceBoot,
 		src.Name(),
 		journalctl.Factory,
 	)
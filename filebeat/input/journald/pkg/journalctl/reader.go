@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/elastic/beats/v7/filebeat/input/journald/pkg/journalfield"
@@ -40,6 +41,12 @@ const localSystemJournalID = "LOCAL_SYSTEM_JOURNAL"
 // Systemd/Journald.
 const sinceTimeFormat = "2006-01-02 15:04:05.999999999"
 
+// maxCursorFailures is the number of consecutive times journalctl can fail
+// when resuming from a cursor before the cursor is considered invalid, for
+// example because the journal file it points to was rotated away or is
+// corrupted.
+const maxCursorFailures = 3
+
 // ErrCancelled indicates the read was cancelled
 var ErrCancelled = errors.New("cancelled")
 var ErrRestarting = errors.New("restarting journalctl")
@@ -110,6 +117,10 @@ type Reader struct {
 	// cursor is the jornalctl cursor, it is also stored in Filebeat's registry
 	cursor string
 
+	// failures is the number of consecutive times journalctl exited with
+	// an error without returning any entry.
+	failures int
+
 	canceler input.Canceler
 
 	jctl        Jctl
@@ -142,12 +153,34 @@ func handleSeekAndCursor(mode SeekMode, since time.Duration, cursor string) ([]s
 	}
 }
 
+// cursorFallbackArgs returns the arguments to resume reading the journal
+// close to cursor without using it, based on the realtime timestamp stored
+// in the cursor. Entries logged in the same microsecond as the cursor are
+// read again. The bool is false if the cursor cannot be parsed.
+func cursorFallbackArgs(cursor string) ([]string, bool) {
+	// A cursor is a list of key=value pairs separated by semicolons, the
+	// realtime timestamp is stored in microseconds as hex in the 't' key.
+	// For example: s=…;i=…;b=<boot ID>;m=…;t=<realtime>;x=…
+	for _, kv := range strings.Split(cursor, ";") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k != "t" {
+			continue
+		}
+		usec, err := strconv.ParseUint(v, 16, 64)
+		if err != nil || usec == 0 {
+			return nil, false
+		}
+		return []string{"--since", fmt.Sprintf("@%d.%06d", usec/1e6, usec%1e6)}, true
+	}
+	return nil, false
+}
+
 // New instantiates and starts a reader for journald logs.
 //
 // The Reader starts a `journalctl` process with JSON output to read the journal
-// entries. Units and syslog identifiers are passed using the corresponding CLI
-// flags, matchers are passed directly to `journalctl` then transports are added
-// as matchers using `_TRANSPORTS` key.
+// entries. Units, user units and syslog identifiers are passed using the
+// corresponding CLI flags, matchers are passed directly to `journalctl` then
+// transports are added as matchers using `_TRANSPORTS` key.
 //
 // `mode` defines the 'seek mode'. It indicates whether the journal should be
 // read from the tail, head or starting from the cursor. If a cursor is passed,
@@ -157,6 +190,15 @@ func handleSeekAndCursor(mode SeekMode, since time.Duration, cursor string) ([]s
 // be a time.Duration relative to the current time to start reading the
 // journald.
 //
+// To start reading from the first entry of a boot, boot is set to a boot ID
+// or an offset as accepted by `journalctl --boot`, for example -1 for the
+// previous boot. It takes precedence over the seek mode and is ignored if a
+// cursor is passed. If the boot is not in the journal, the seek mode is used.
+//
+// If `journalctl` repeatedly fails to resume from the cursor, for example
+// because the journal was rotated or is corrupted, the cursor is dropped and
+// reading resumes from the timestamp stored in the cursor.
+//
 // File is the journal file to be read, for the system journal use the string
 // `LOCAL_SYSTEM_JOURNAL`.
 //
@@ -169,6 +211,7 @@ func New(
 	logger *logp.Logger,
 	canceler input.Canceler,
 	units []string,
+	userUnits []string,
 	syslogIdentifiers []string,
 	transports []string,
 	matchers journalfield.IncludeMatches,
@@ -176,6 +219,7 @@ func New(
 	mode SeekMode,
 	cursor string,
 	since time.Duration,
+	boot string,
 	file string,
 	newJctl JctlFactory,
 ) (*Reader, error) {
@@ -184,14 +228,20 @@ func New(
 
 	args := []string{"--utc", "--output=json", "--no-pager"}
 
+	var fileArgs []string
 	if file != "" && file != localSystemJournalID {
-		args = append(args, "--file", file)
+		fileArgs = []string{"--file", file}
+		args = append(args, fileArgs...)
 	}
 
 	for _, u := range units {
 		args = append(args, "--unit", u)
 	}
 
+	for _, u := range userUnits {
+		args = append(args, "--user-unit", u)
+	}
+
 	for _, i := range syslogIdentifiers {
 		args = append(args, "--identifier", i)
 	}
@@ -209,6 +259,18 @@ func New(
 	}
 
 	firstRunArgs, prevBoots := handleSeekAndCursor(mode, since, cursor)
+	if cursor == "" && boot != "" {
+		bootCursor, err := firstBootCursor(logger, canceler, newJctl, fileArgs, boot)
+		if err != nil {
+			return &Reader{}, err
+		}
+		if bootCursor != "" {
+			firstRunArgs, prevBoots = []string{"--cursor", bootCursor}, true
+		} else {
+			logger.Warnf("boot %s not found in the journal, using the seek mode instead", boot)
+		}
+	}
+
 	state := readingOldEntriesState // Initial state
 	if !prevBoots {
 		state = followingState
@@ -236,6 +298,35 @@ func New(
 	return &r, nil
 }
 
+// firstBootCursor returns the cursor of the first entry of boot, or an empty
+// string if boot is not in the journal.
+func firstBootCursor(logger *logp.Logger, canceler input.Canceler, newJctl JctlFactory, fileArgs []string, boot string) (string, error) {
+	args := append([]string{"--utc", "--output=json", "--no-pager", "--output-fields=_BOOT_ID", "--boot", boot}, fileArgs...)
+	jctl, err := newJctl(canceler, logger.Named("journalctl-runner"), "journalctl", args...)
+	if err != nil {
+		return "", fmt.Errorf("cannot start journalctl to find boot %s: %w", boot, err)
+	}
+	// Only the first entry is needed, journalctl might already have exited.
+	defer func() { _ = jctl.Kill() }()
+
+	data, finished, err := jctl.Next(canceler)
+	if errors.Is(err, ErrCancelled) {
+		return "", err
+	}
+	if err != nil || finished {
+		// journalctl exits with an error if the boot is not found.
+		return "", nil
+	}
+
+	var entry struct {
+		Cursor string `json:"__CURSOR"`
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return "", fmt.Errorf("cannot decode first entry of boot %s: %w", boot, err)
+	}
+	return entry.Cursor, nil
+}
+
 func (r *Reader) newJctl(extraArgs ...string) error {
 	args := append(r.args, extraArgs...)
 
@@ -287,13 +378,33 @@ func (r *Reader) next(cancel input.Canceler) ([]byte, error) {
 		if err != nil {
 			r.logger.Warnf("reader error: '%s', restarting...", err)
 			restart = true
+			r.failures++
+
+			if r.cursor != "" && r.failures >= maxCursorFailures {
+				// journalctl keeps failing to resume from the cursor, the
+				// journal file it points to might have been rotated away
+				// or be corrupted. Drop the cursor and resume from its
+				// timestamp instead, or from the seek mode if the cursor
+				// cannot be parsed.
+				if args, ok := cursorFallbackArgs(r.cursor); ok {
+					r.firstRunArgs = args
+				}
+				r.logger.Warnf("journalctl failed %d times resuming from cursor '%s', "+
+					"the journal might have been rotated or corrupted, resuming with '%s' instead",
+					r.failures, r.cursor, strings.Join(r.firstRunArgs, " "))
+				r.cursor = ""
+				r.failures = 0
+			}
 
-			if r.cursor == "" && r.state == readingOldEntriesState {
+			if r.cursor == "" {
 				// Corner case: journalctl exited with an error before reading the
 				// 1st message. This means we don't have a cursor and need to restart
 				// it with the initial arguments.
 				extraArgs = append(extraArgs, r.firstRunArgs...)
-			} else if r.cursor != "" {
+				if r.state == followingState {
+					extraArgs = append(extraArgs, "--follow")
+				}
+			} else {
 				// There is a cursor, so just append it to our arguments
 				extraArgs = append(extraArgs, "--after-cursor", r.cursor)
 
@@ -431,6 +542,7 @@ func (r *Reader) handleMessage(msg []byte) (JournalEntry, error) {
 
 	// Update our cursor so we can restart journalctl if needed
 	r.cursor = cursor
+	r.failures = 0
 
 	return JournalEntry{
 		Fields:             fields,
@@ -25,8 +25,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/elastic/beats/v7/filebeat/input/journald/pkg/journalfield"
 	input "github.com/elastic/beats/v7/filebeat/input/v2"
@@ -100,7 +104,7 @@ func TestRestartsJournalctlOnError(t *testing.T) {
 		return &mock, nil
 	}
 
-	reader, err := New(logp.L(), ctx, nil, nil, nil, journalfield.IncludeMatches{}, []int{}, SeekHead, "", 0, "", factory)
+	reader, err := New(logp.L(), ctx, nil, nil, nil, nil, journalfield.IncludeMatches{}, []int{}, SeekHead, "", 0, "", "", factory)
 	if err != nil {
 		t.Fatalf("cannot instantiate journalctl reader: %s", err)
 	}
@@ -158,3 +162,163 @@ func TestRestartsJournalctlOnError(t *testing.T) {
 		}
 	}
 }
+
+func TestCursorFallbackArgs(t *testing.T) {
+	testCases := map[string]struct {
+		cursor   string
+		expected []string
+	}{
+		"valid cursor": {
+			cursor:   "s=e82795fad4ce42b79fb3da0866d91f7e;i=4eb1b1;b=567980bb85ae41da8518f409570b0cb9;m=2bd4e2166;t=6200adaf0a66a;x=d9b1ac66921eaac9",
+			expected: []string{"--since", "@1724080855.230058"},
+		},
+		"no timestamp": {
+			cursor: "s=e82795fad4ce42b79fb3da0866d91f7e;i=4eb1b1",
+		},
+		"invalid timestamp": {
+			cursor: "s=e82795fad4ce42b79fb3da0866d91f7e;t=not-hex",
+		},
+		"garbage": {
+			cursor: "not a cursor",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			args, ok := cursorFallbackArgs(tc.cursor)
+			if ok != (tc.expected != nil) {
+				t.Fatalf("expecting ok to be %t, got %t", tc.expected != nil, ok)
+			}
+			if !slices.Equal(tc.expected, args) {
+				t.Fatalf("expecting args %v, got %v", tc.expected, args)
+			}
+		})
+	}
+}
+
+// recordingFactory returns a JctlFactory that records the arguments of each
+// journalctl call and returns the mock returned by next for it.
+func recordingFactory(next func(args []string) *JctlMock) (JctlFactory, func() [][]string) {
+	var (
+		mu    sync.Mutex
+		calls [][]string
+	)
+	factory := func(canceller input.Canceler, logger *logp.Logger, binary string, args ...string) (Jctl, error) {
+		mu.Lock()
+		calls = append(calls, args)
+		mu.Unlock()
+		return next(args), nil
+	}
+	return factory, func() [][]string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(calls)
+	}
+}
+
+func TestCursorFallbackOnRepeatedErrors(t *testing.T) {
+	ctx := context.Background()
+	const cursor = "s=e82795fad4ce42b79fb3da0866d91f7e;i=4eb1b1;b=567980bb85ae41da8518f409570b0cb9;m=2bd4e2166;t=6200adaf0a66a;x=d9b1ac66921eaac9"
+
+	factory, calls := recordingFactory(func(args []string) *JctlMock {
+		return &JctlMock{
+			KillFunc: func() error { return nil },
+			NextFunc: func(canceler input.Canceler) ([]byte, bool, error) {
+				// journalctl fails as long as it resumes from the cursor
+				if slices.Contains(args, "--after-cursor") {
+					return nil, false, errors.New("journalctl exited with code 1")
+				}
+				return jdEvent, false, nil
+			},
+		}
+	})
+
+	reader, err := New(logp.L(), ctx, nil, nil, nil, nil, journalfield.IncludeMatches{}, []int{}, SeekHead, cursor, 0, "", "", factory)
+	if err != nil {
+		t.Fatalf("cannot instantiate journalctl reader: %s", err)
+	}
+	reader.backoff = noBackoff{}
+
+	if _, err := reader.Next(ctx); err != nil {
+		t.Fatalf("expecting no error, got: %s", err)
+	}
+
+	got := calls()
+	if len(got) != maxCursorFailures+1 {
+		t.Fatalf("expecting journalctl to be started %d times, got %d: %v", maxCursorFailures+1, len(got), got)
+	}
+	for i, args := range got[:maxCursorFailures] {
+		if !slices.Contains(args, "--after-cursor") {
+			t.Fatalf("call %d must resume from the cursor, got: %v", i, args)
+		}
+	}
+	last := strings.Join(got[maxCursorFailures], " ")
+	if strings.Contains(last, "--after-cursor") || !strings.Contains(last, "--since @1724080855.230058") {
+		t.Fatalf("journalctl must resume from the cursor timestamp, got: %s", last)
+	}
+}
+
+func TestSinceBoot(t *testing.T) {
+	ctx := context.Background()
+	bootEntry := []byte(`{"__CURSOR": "s=1;i=1;b=2;m=3;t=4;x=5", "_BOOT_ID": "2"}`)
+
+	testCases := map[string]struct {
+		bootEntry    []byte
+		expectedArgs []string
+	}{
+		"boot found": {
+			bootEntry:    bootEntry,
+			expectedArgs: []string{"--cursor", "s=1;i=1;b=2;m=3;t=4;x=5"},
+		},
+		"boot not found": {
+			expectedArgs: []string{"--since", "now"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			factory, calls := recordingFactory(func(args []string) *JctlMock {
+				return &JctlMock{
+					KillFunc: func() error { return nil },
+					NextFunc: func(canceler input.Canceler) ([]byte, bool, error) {
+						if slices.Contains(args, "--boot") {
+							if tc.bootEntry == nil {
+								return nil, false, errors.New("journalctl exited with code 1")
+							}
+							return tc.bootEntry, false, nil
+						}
+						return jdEvent, false, nil
+					},
+				}
+			})
+
+			_, err := New(logp.L(), ctx, []string{"foo.service"}, []string{"bar.service"}, nil, nil, journalfield.IncludeMatches{}, []int{}, SeekTail, "", 0, "-1", "", factory)
+			if err != nil {
+				t.Fatalf("cannot instantiate journalctl reader: %s", err)
+			}
+
+			got := calls()
+			if len(got) != 2 {
+				t.Fatalf("expecting journalctl to be started twice, got %d: %v", len(got), got)
+			}
+			bootArgs := strings.Join(got[0], " ")
+			if !strings.Contains(bootArgs, "--boot -1") || strings.Contains(bootArgs, "--unit") {
+				t.Fatalf("first call must look up the boot without filters, got: %s", bootArgs)
+			}
+			args := strings.Join(got[1], " ")
+			if !strings.Contains(args, "--unit foo.service --user-unit bar.service") {
+				t.Fatalf("units and user units must be passed to journalctl, got: %s", args)
+			}
+			if !strings.HasSuffix(args, strings.Join(tc.expectedArgs, " ")) {
+				t.Fatalf("expecting journalctl to start with %v, got: %s", tc.expectedArgs, args)
+			}
+		})
+	}
+}
+
+// noBackoff is a backoff that never waits.
+type noBackoff struct{}
+
+func (noBackoff) Wait() bool      { return true }
+func (noBackoff) Reset()          {}
+func (noBackoff) Last() time.Time { return time.Time{} }
@@ -26,6 +26,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/kafka"
 	"github.com/elastic/beats/v7/libbeat/common/transport/kerberos"
 	"github.com/elastic/beats/v7/libbeat/reader/parser"
+	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/monitoring"
 	"github.com/elastic/elastic-agent-libs/monitoring/adapter"
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
@@ -53,7 +54,10 @@ type kafkaInputConfig struct {
 	Password                 string            `config:"password"`
 	Sasl                     kafka.SaslConfig  `config:"sasl"`
 	ExpandEventListFromField string            `config:"expand_event_list_from_field"`
-	Parsers                  parser.Config     `config:",inline"`
+	// SchemaRegistry configures the schema registry used to decode messages
+	// in the schema registry wire format.
+	SchemaRegistry *conf.C       `config:"schema_registry"`
+	Parsers        parser.Config `config:",inline"`
 }
 
 type kafkaFetch struct {
@@ -36,6 +36,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/backoff"
 	"github.com/elastic/beats/v7/libbeat/common/kafka"
 	"github.com/elastic/beats/v7/libbeat/feature"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/schemaregistry"
 	"github.com/elastic/beats/v7/libbeat/reader"
 	"github.com/elastic/beats/v7/libbeat/reader/parser"
 	conf "github.com/elastic/elastic-agent-libs/config"
@@ -71,12 +72,21 @@ func configure(cfg *conf.C) (input.Input, error) {
 }
 
 func NewInput(config kafkaInputConfig, saramaConfig *sarama.Config) (*kafkaInput, error) {
-	return &kafkaInput{config: config, saramaConfig: saramaConfig}, nil
+	input := &kafkaInput{config: config, saramaConfig: saramaConfig}
+	if config.SchemaRegistry != nil {
+		registryConfig := schemaregistry.DefaultConfig()
+		if err := config.SchemaRegistry.Unpack(&registryConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse schema registry config: %w", err)
+		}
+		input.registryConfig = &registryConfig
+	}
+	return input, nil
 }
 
 type kafkaInput struct {
 	config          kafkaInputConfig
 	saramaConfig    *sarama.Config
+	registryConfig  *schemaregistry.Config
 	saramaWaitGroup sync.WaitGroup // indicates a sarama consumer group is active
 }
 
@@ -109,6 +119,15 @@ func (input *kafkaInput) Test(ctx input.TestContext) error {
 func (input *kafkaInput) Run(ctx input.Context, pipeline beat.Pipeline) error {
 	log := ctx.Logger.Named("kafka input").With("hosts", input.config.Hosts)
 
+	var decoder *registryDecoder
+	if input.registryConfig != nil {
+		registry, err := schemaregistry.NewClient(*input.registryConfig, log)
+		if err != nil {
+			return fmt.Errorf("failed to create schema registry client: %w", err)
+		}
+		decoder = newRegistryDecoder(registry)
+	}
+
 	client, err := pipeline.ConnectWith(beat.ClientConfig{
 		EventListener: acker.ConnectionOnly(
 			acker.EventPrivateReporter(func(_ int, events []interface{}) {
@@ -161,7 +180,7 @@ func (input *kafkaInput) Run(ctx input.Context, pipeline beat.Pipeline) error {
 		// In an ideal run, this function never returns until shutdown; if it
 		// does, it means the errors have been logged and the consumer group
 		// has been closed, so we try creating a new one in the next iteration.
-		input.runConsumerGroup(log, client, goContext, consumerGroup)
+		input.runConsumerGroup(log, client, decoder, goContext, consumerGroup)
 	}
 
 	if errors.Is(ctx.Cancelation.Err(), context.Canceled) {
@@ -188,11 +207,12 @@ func (input *kafkaInput) Wait() {
 	input.saramaWaitGroup.Wait()
 }
 
-func (input *kafkaInput) runConsumerGroup(log *logp.Logger, client beat.Client, context context.Context, consumerGroup sarama.ConsumerGroup) {
+func (input *kafkaInput) runConsumerGroup(log *logp.Logger, client beat.Client, decoder *registryDecoder, context context.Context, consumerGroup sarama.ConsumerGroup) {
 	handler := &groupHandler{
 		version: input.config.Version,
 		client:  client,
 		parsers: input.config.Parsers,
+		decoder: decoder,
 		// expandEventListFromField will be assigned the configuration option expand_event_list_from_field
 		expandEventListFromField: input.config.ExpandEventListFromField,
 		log:                      log,
@@ -276,6 +296,9 @@ type groupHandler struct {
 	session sarama.ConsumerGroupSession
 	client  beat.Client
 	parsers parser.Config
+	// decoder decodes messages in the schema registry wire format, nil if
+	// no schema registry is configured.
+	decoder *registryDecoder
 	// if the fileset using this input expects to receive multiple messages bundled under a specific field then this value is assigned
 	// ex. in this case are the azure fielsets where the events are found under the json object "records"
 	expandEventListFromField string // TODO
@@ -296,6 +319,20 @@ func (h *groupHandler) Cleanup(_ sarama.ConsumerGroupSession) error {
 	return nil
 }
 
+// decode decodes messages in the schema registry wire format into their
+// JSON representation. Other messages, and messages that cannot be
+// decoded, are returned as is.
+func (h *groupHandler) decode(value []byte) ([]byte, error) {
+	if h.decoder == nil {
+		return value, nil
+	}
+	content, err := h.decoder.decode(value)
+	if err != nil {
+		return value, err
+	}
+	return content, nil
+}
+
 // ack informs the kafka cluster that this message has been consumed. Called
 // from the input's ACKEvents handler.
 func (h *groupHandler) ack(message *sarama.ConsumerMessage) {
@@ -363,7 +400,13 @@ func (m *recordReader) Next() (reader.Message, error) {
 	ackHandler := func() {
 		m.groupHandler.ack(msg)
 	}
-	return composeMessage(timestamp, msg.Value, kafkaFields, ackHandler), nil
+	content, err := m.groupHandler.decode(msg.Value)
+	message := composeMessage(timestamp, content, kafkaFields, ackHandler)
+	if err != nil {
+		m.log.Debugw("Failed to decode kafka message with the schema registry", "error", err)
+		_, _ = message.Fields.Put("error.message", "failed to decode message with the schema registry: "+err.Error())
+	}
+	return message, nil
 }
 
 type listFromFieldReader struct {
@@ -389,7 +432,11 @@ func (l *listFromFieldReader) Next() (reader.Message, error) {
 	}
 
 	timestamp, kafkaFields := composeEventMetadata(l.claim, l.groupHandler, msg)
-	messages := l.parseMultipleMessages(msg.Value)
+	content, err := l.groupHandler.decode(msg.Value)
+	if err != nil {
+		l.log.Errorw("Kafka decoding message with the schema registry", "error", err)
+	}
+	messages := l.parseMultipleMessages(content)
 
 	neededAcks := atomic.Int64{}
 	neededAcks.Add(int64(len(messages)))
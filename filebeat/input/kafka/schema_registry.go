@@ -0,0 +1,255 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/linkedin/goavro/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	// Register the well-known types commonly imported by protobuf schemas.
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/schemaregistry"
+)
+
+// payloadDecoder decodes a payload written with a registered schema into
+// its JSON representation.
+type payloadDecoder func(payload []byte) ([]byte, error)
+
+// registryDecoder decodes messages in the schema registry wire format, as
+// produced by the Confluent Avro and Protobuf serializers. Payload decoders
+// are cached per schema ID.
+type registryDecoder struct {
+	registry *schemaregistry.Client
+
+	mu       sync.Mutex
+	decoders map[int]payloadDecoder
+}
+
+func newRegistryDecoder(registry *schemaregistry.Client) *registryDecoder {
+	return &registryDecoder{
+		registry: registry,
+		decoders: map[int]payloadDecoder{},
+	}
+}
+
+// decode returns the JSON representation of data. Data that is not in the
+// schema registry wire format is returned as is.
+func (d *registryDecoder) decode(data []byte) ([]byte, error) {
+	id, payload, err := schemaregistry.ParseHeader(data)
+	if errors.Is(err, schemaregistry.ErrNotWireFormat) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	decode, err := d.payloadDecoder(id)
+	if err != nil {
+		return nil, err
+	}
+	return decode(payload)
+}
+
+func (d *registryDecoder) payloadDecoder(id int) (payloadDecoder, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if decode, ok := d.decoders[id]; ok {
+		return decode, nil
+	}
+
+	schema, err := d.registry.SchemaByID(id, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var decode payloadDecoder
+	switch schema.Type {
+	case schemaregistry.TypeAvro:
+		decode, err = d.avroDecoder(id, schema)
+	case schemaregistry.TypeProtobuf:
+		decode, err = d.protobufDecoder(id)
+	default:
+		err = fmt.Errorf("unsupported schema type %s", schema.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema %d: %w", id, err)
+	}
+
+	d.decoders[id] = decode
+	return decode, nil
+}
+
+func (d *registryDecoder) avroDecoder(id int, schema schemaregistry.Schema) (payloadDecoder, error) {
+	// Inline the referenced named types so the schema can be parsed on its
+	// own.
+	if len(schema.References) > 0 {
+		var err error
+		schema, err = d.registry.SchemaByID(id, "resolved")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	codec, err := goavro.NewCodec(schema.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse avro schema: %w", err)
+	}
+	return func(payload []byte) ([]byte, error) {
+		native, _, err := codec.NativeFromBinary(payload)
+		if err != nil {
+			return nil, err
+		}
+		return codec.TextualFromNative(nil, native)
+	}, nil
+}
+
+func (d *registryDecoder) protobufDecoder(id int) (payloadDecoder, error) {
+	schema, err := d.registry.SchemaByID(id, "serialized")
+	if err != nil {
+		return nil, err
+	}
+
+	files := &protoregistry.Files{}
+	if err := d.registerProtobufReferences(files, schema.References); err != nil {
+		return nil, err
+	}
+	fd, err := newProtobufFile(files, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(payload []byte) ([]byte, error) {
+		indexes, payload, err := schemaregistry.ParseMessageIndexes(payload)
+		if err != nil {
+			return nil, err
+		}
+		desc, err := protobufMessage(fd, indexes)
+		if err != nil {
+			return nil, err
+		}
+
+		msg := dynamicpb.NewMessage(desc)
+		if err := proto.Unmarshal(payload, msg); err != nil {
+			return nil, err
+		}
+		return protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	}, nil
+}
+
+// registerProtobufReferences registers the files imported by a protobuf
+// schema, and the files they import, into files.
+func (d *registryDecoder) registerProtobufReferences(files *protoregistry.Files, refs []schemaregistry.Reference) error {
+	for _, ref := range refs {
+		if _, err := files.FindFileByPath(ref.Name); err == nil {
+			continue
+		}
+
+		schema, err := d.registry.SchemaByVersion(ref.Subject, ref.Version, "serialized")
+		if err != nil {
+			return err
+		}
+		if err := d.registerProtobufReferences(files, schema.References); err != nil {
+			return err
+		}
+
+		fd, err := newProtobufFile(files, schema)
+		if err != nil {
+			return fmt.Errorf("failed to parse protobuf reference %s: %w", ref.Name, err)
+		}
+		if fd.Path() != ref.Name {
+			// Imports are resolved by the reference name, which may differ
+			// from the name the file was registered with.
+			fdp := protodesc.ToFileDescriptorProto(fd)
+			fdp.Name = proto.String(ref.Name)
+			if fd, err = protodesc.NewFile(fdp, resolver{files}); err != nil {
+				return fmt.Errorf("failed to parse protobuf reference %s: %w", ref.Name, err)
+			}
+		}
+		if err := files.RegisterFile(fd); err != nil {
+			return fmt.Errorf("failed to register protobuf reference %s: %w", ref.Name, err)
+		}
+	}
+	return nil
+}
+
+// newProtobufFile parses a protobuf schema in the serialized format, a
+// base64 encoded FileDescriptorProto, resolving its imports from files.
+func newProtobufFile(files *protoregistry.Files, schema schemaregistry.Schema) (protoreflect.FileDescriptor, error) {
+	b, err := base64.StdEncoding.DecodeString(schema.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode protobuf schema: %w", err)
+	}
+	var fdp descriptorpb.FileDescriptorProto
+	if err := proto.Unmarshal(b, &fdp); err != nil {
+		return nil, fmt.Errorf("failed to parse protobuf schema: %w", err)
+	}
+	return protodesc.NewFile(&fdp, resolver{files})
+}
+
+// protobufMessage returns the message type of fd selected by the message
+// indexes of the wire format.
+func protobufMessage(fd protoreflect.FileDescriptor, indexes []int) (protoreflect.MessageDescriptor, error) {
+	var desc protoreflect.MessageDescriptor
+	msgs := fd.Messages()
+	for _, i := range indexes {
+		if i >= msgs.Len() {
+			return nil, fmt.Errorf("protobuf message index %v not found in %s", indexes, fd.Path())
+		}
+		desc = msgs.Get(i)
+		msgs = desc.Messages()
+	}
+	return desc, nil
+}
+
+// resolver resolves protobuf imports from the schema references, falling
+// back to the well-known types.
+type resolver struct {
+	files *protoregistry.Files
+}
+
+func (r resolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.files.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r resolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if d, err := r.files.FindDescriptorByName(name); err == nil {
+		return d, nil
+	}
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
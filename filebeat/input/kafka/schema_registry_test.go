@@ -0,0 +1,273 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package kafka
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/schemaregistry"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/sarama"
+)
+
+const testAvroSchema = `{"type": "record", "name": "Event", "fields": [{"name": "message", "type": "string"}, {"name": "count", "type": "long"}]}`
+
+// testProtobufFiles returns the protobuf schemas used in the tests,
+// event.proto importing common.proto.
+func testProtobufFiles(t *testing.T) (common, event *descriptorpb.FileDescriptorProto) {
+	common = &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("common.proto"),
+		Package: proto.String("common"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Level"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("name"),
+				JsonName: proto.String("name"),
+				Number:   proto.Int32(1),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			}},
+		}},
+	}
+	event = &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("event.proto"),
+		Package:    proto.String("example"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"common.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Other")},
+			{
+				Name: proto.String("Event"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("message"),
+						JsonName: proto.String("message"),
+						Number:   proto.Int32(1),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					},
+					{
+						Name:     proto.String("log_level"),
+						JsonName: proto.String("logLevel"),
+						Number:   proto.Int32(2),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						TypeName: proto.String(".common.Level"),
+						Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					},
+				},
+			},
+		},
+	}
+	return common, event
+}
+
+func serializedSchema(t *testing.T, fdp *descriptorpb.FileDescriptorProto) string {
+	b, err := proto.Marshal(fdp)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func newTestRegistry(t *testing.T) *httptest.Server {
+	common, event := testProtobufFiles(t)
+	responses := map[string]interface{}{
+		"/schemas/ids/1": map[string]interface{}{
+			"schema": testAvroSchema,
+		},
+		"/schemas/ids/2": map[string]interface{}{
+			"schemaType": "PROTOBUF",
+			"schema":     `syntax = "proto3";`,
+			"references": []map[string]interface{}{{"name": "common.proto", "subject": "common", "version": 1}},
+		},
+		"/schemas/ids/2?format=serialized": map[string]interface{}{
+			"schemaType": "PROTOBUF",
+			"schema":     serializedSchema(t, event),
+			"references": []map[string]interface{}{{"name": "common.proto", "subject": "common", "version": 1}},
+		},
+		"/subjects/common/versions/1?format=serialized": map[string]interface{}{
+			"schemaType": "PROTOBUF",
+			"schema":     serializedSchema(t, common),
+		},
+		"/schemas/ids/3": map[string]interface{}{
+			"schemaType": "JSON",
+			"schema":     `{"type": "object"}`,
+		},
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		resp, ok := responses[req.URL.RequestURI()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code": 40403, "message": "Schema not found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func newTestDecoder(t *testing.T, url string) *registryDecoder {
+	config := schemaregistry.DefaultConfig()
+	config.URL = url
+	registry, err := schemaregistry.NewClient(config, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	return newRegistryDecoder(registry)
+}
+
+func avroTestMessage(t *testing.T) []byte {
+	codec, err := goavro.NewCodec(testAvroSchema)
+	require.NoError(t, err)
+	data, err := codec.BinaryFromNative(schemaregistry.AppendHeader(nil, 1), map[string]interface{}{
+		"message": "hello",
+		"count":   int64(3),
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func protobufTestMessage(t *testing.T) []byte {
+	common, event := testProtobufFiles(t)
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{common, event}}
+	files, err := protodesc.NewFiles(set)
+	require.NoError(t, err)
+	desc, err := files.FindDescriptorByName("example.Event")
+	require.NoError(t, err)
+
+	msgDesc := desc.(protoreflect.MessageDescriptor)
+	msg := dynamicpb.NewMessage(msgDesc)
+	msg.Set(msgDesc.Fields().ByName("message"), protoreflect.ValueOfString("hello"))
+	level := dynamicpb.NewMessage(msgDesc.Fields().ByName("log_level").Message())
+	level.Set(level.Descriptor().Fields().ByName("name"), protoreflect.ValueOfString("info"))
+	msg.Set(msgDesc.Fields().ByName("log_level"), protoreflect.ValueOfMessage(level))
+
+	payload, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	data := schemaregistry.AppendHeader(nil, 2)
+	data = schemaregistry.AppendMessageIndexes(data, []int{1})
+	return append(data, payload...)
+}
+
+func TestRegistryDecoder(t *testing.T) {
+	server := newTestRegistry(t)
+	defer server.Close()
+
+	tests := map[string]struct {
+		data    []byte
+		want    string
+		wantErr string
+	}{
+		"avro": {
+			data: avroTestMessage(t),
+			want: `{"message": "hello", "count": 3}`,
+		},
+		"protobuf": {
+			data: protobufTestMessage(t),
+			want: `{"message": "hello", "log_level": {"name": "info"}}`,
+		},
+		"not wire format": {
+			data: []byte(`{"message": "hello"}`),
+			want: `{"message": "hello"}`,
+		},
+		"unsupported schema type": {
+			data:    append(schemaregistry.AppendHeader(nil, 3), `{}`...),
+			wantErr: "unsupported schema type JSON",
+		},
+		"unknown schema": {
+			data:    append(schemaregistry.AppendHeader(nil, 4), 0),
+			wantErr: "Schema not found",
+		},
+		"invalid payload": {
+			data:    append(schemaregistry.AppendHeader(nil, 1), 1),
+			wantErr: "cannot decode binary record",
+		},
+	}
+
+	decoder := newTestDecoder(t, server.URL)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := decoder.decode(test.data)
+			if test.wantErr != "" {
+				assert.ErrorContains(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, test.want, string(got))
+		})
+	}
+}
+
+type testClaim struct {
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *testClaim) Topic() string                            { return "events" }
+func (c *testClaim) Partition() int32                         { return 0 }
+func (c *testClaim) InitialOffset() int64                     { return 0 }
+func (c *testClaim) HighWaterMarkOffset() int64               { return 0 }
+func (c *testClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func TestRecordReaderSchemaRegistry(t *testing.T) {
+	server := newTestRegistry(t)
+	defer server.Close()
+
+	claim := &testClaim{messages: make(chan *sarama.ConsumerMessage, 2)}
+	claim.messages <- &sarama.ConsumerMessage{Value: avroTestMessage(t), Timestamp: time.Now()}
+	claim.messages <- &sarama.ConsumerMessage{Value: append(schemaregistry.AppendHeader(nil, 4), 0), Timestamp: time.Now()}
+	close(claim.messages)
+
+	log := logptest.NewTestingLogger(t, "")
+	reader := &recordReader{
+		claim: claim,
+		groupHandler: &groupHandler{
+			version: "2.1.0",
+			decoder: newTestDecoder(t, server.URL),
+			log:     log,
+		},
+		log: log,
+	}
+
+	msg, err := reader.Next()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"message": "hello", "count": 3}`, string(msg.Content))
+	assert.JSONEq(t, `{"message": "hello", "count": 3}`, msg.Fields["message"].(string))
+
+	msg, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, string(append(schemaregistry.AppendHeader(nil, 4), 0)), msg.Fields["message"])
+	errMsg, err := msg.Fields.GetValue("error.message")
+	require.NoError(t, err)
+	assert.Contains(t, errMsg, "Schema not found")
+
+	_, err = reader.Next()
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package stdin
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/filebeat/channel"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors/dissect"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// headerMessageKey is the dissect key capturing the text following the header.
+const headerMessageKey = "@stdin.message"
+
+// headerOutlet dissects the header of each line before forwarding the event.
+// Lines that do not match the header pattern are forwarded unchanged.
+type headerOutlet struct {
+	channel.Outleter
+	dissector    *dissect.Dissector
+	targetPrefix string
+	logger       *logp.Logger
+}
+
+func newHeaderOutlet(out channel.Outleter, pattern, targetPrefix string, logger *logp.Logger) (*headerOutlet, error) {
+	dissector, err := dissect.New(pattern + "%{" + headerMessageKey + "}")
+	if err != nil {
+		return nil, fmt.Errorf("invalid header_pattern: %w", err)
+	}
+	return &headerOutlet{
+		Outleter:     out,
+		dissector:    dissector,
+		targetPrefix: targetPrefix,
+		logger:       logger,
+	}, nil
+}
+
+func (o *headerOutlet) OnEvent(event beat.Event) bool {
+	o.parseHeader(&event)
+	return o.Outleter.OnEvent(event)
+}
+
+func (o *headerOutlet) parseHeader(event *beat.Event) {
+	// events only carrying a state update have no message
+	line, ok := event.Fields["message"].(string)
+	if !ok {
+		return
+	}
+
+	m, err := o.dissector.Dissect(line)
+	if err != nil {
+		o.logger.Debugf("Line does not match the header pattern, keeping the full line as message: %v", err)
+		return
+	}
+
+	for k, v := range m {
+		if k == headerMessageKey {
+			continue
+		}
+		if o.targetPrefix != "" {
+			k = o.targetPrefix + "." + k
+		}
+		if _, err := event.Fields.Put(k, v); err != nil {
+			o.logger.Debugf("Failed to set header field %s: %v", k, err)
+		}
+	}
+	event.Fields["message"] = m[headerMessageKey]
+}
@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package stdin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type collectingOutlet struct {
+	events []beat.Event
+}
+
+func (o *collectingOutlet) Close() error          { return nil }
+func (o *collectingOutlet) Done() <-chan struct{} { return nil }
+func (o *collectingOutlet) OnEvent(event beat.Event) bool {
+	o.events = append(o.events, event)
+	return true
+}
+
+func TestHeaderOutlet(t *testing.T) {
+	tests := map[string]struct {
+		prefix   string
+		line     string
+		expected mapstr.M
+	}{
+		"matching line": {
+			prefix: "header",
+			line:   "2024-05-01T10:00:00Z web-01 GET /index.html 200",
+			expected: mapstr.M{
+				"message": "GET /index.html 200",
+				"header": mapstr.M{
+					"timestamp": "2024-05-01T10:00:00Z",
+					"host":      "web-01",
+				},
+			},
+		},
+		"matching line without prefix": {
+			line: "2024-05-01T10:00:00Z web-01 GET /index.html 200",
+			expected: mapstr.M{
+				"message":   "GET /index.html 200",
+				"timestamp": "2024-05-01T10:00:00Z",
+				"host":      "web-01",
+			},
+		},
+		"non matching line": {
+			prefix: "header",
+			line:   "no-header",
+			expected: mapstr.M{
+				"message": "no-header",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := &collectingOutlet{}
+			o, err := newHeaderOutlet(out, "%{timestamp} %{host} ", test.prefix, logptest.NewTestingLogger(t, ""))
+			require.NoError(t, err)
+
+			assert.True(t, o.OnEvent(beat.Event{Fields: mapstr.M{"message": test.line}}))
+			require.Len(t, out.events, 1)
+			assert.Equal(t, test.expected, out.events[0].Fields)
+		})
+	}
+
+	t.Run("state updates are forwarded", func(t *testing.T) {
+		out := &collectingOutlet{}
+		o, err := newHeaderOutlet(out, "%{timestamp} %{host} ", "header", logptest.NewTestingLogger(t, ""))
+		require.NoError(t, err)
+
+		assert.True(t, o.OnEvent(beat.Event{Private: "state"}))
+		require.Len(t, out.events, 1)
+		assert.Nil(t, out.events[0].Fields)
+	})
+}
+
+func TestHeaderOutletInvalidPattern(t *testing.T) {
+	_, err := newHeaderOutlet(&collectingOutlet{}, "%{&+timestamp} ", "header", logptest.NewTestingLogger(t, ""))
+	assert.Error(t, err)
+}
@@ -47,13 +47,30 @@ type Input struct {
 }
 
 // NewInput creates a new stdin input
-// This input contains one harvester which is reading from stdin
+// This input contains one harvester which is reading from stdin.
+// The input level settings like `processors` are applied by the
+// pipeline client the outlet is connected to. If a `header_pattern` is
+// configured, the header of each line is dissected before publishing.
 func NewInput(cfg *conf.C, outlet channel.Connector, context input.Context, logger *logp.Logger) (input.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
 	out, err := outlet.Connect(cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	if config.HeaderPattern != "" {
+		headerOut, err := newHeaderOutlet(out, config.HeaderPattern, config.HeaderTargetPrefix, logger)
+		if err != nil {
+			out.Close()
+			return nil, err
+		}
+		out = headerOut
+	}
+
 	p := &Input{
 		started:  false,
 		cfg:      cfg,
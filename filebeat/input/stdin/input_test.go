@@ -20,9 +20,24 @@
 package stdin
 
 import (
+	"os"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/filebeat/channel"
+	"github.com/elastic/beats/v7/filebeat/input"
 	"github.com/elastic/beats/v7/filebeat/input/inputtest"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	_ "github.com/elastic/beats/v7/libbeat/processors/actions"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
@@ -32,3 +47,82 @@ func TestNewInputDone(t *testing.T) {
 	}
 	inputtest.AssertNotStartedInputCanBeDone(t, NewInput, &config)
 }
+
+func TestInputProcessors(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = stdin })
+
+	var mu sync.Mutex
+	var published []string
+	pipeline := pubtest.FakeConnector{
+		ConnectFunc: func(cfg beat.ClientConfig) (beat.Client, error) {
+			return &pubtest.FakeClient{
+				PublishFunc: func(event beat.Event) {
+					// The pipeline applies the processors of the client.
+					e, err := cfg.Processing.Processor.Run(&event)
+					if err != nil || e == nil {
+						return
+					}
+					msg, _ := e.Fields.GetValue("message")
+					mu.Lock()
+					defer mu.Unlock()
+					published = append(published, msg.(string)) //nolint:errcheck //Safe to ignore in tests
+				},
+			}, nil
+		},
+	}
+
+	config := conf.MustNewConfigFrom(`
+type: stdin
+processors:
+  - drop_event.when.contains.message: "DEBUG"
+`)
+	logger := logptest.NewTestingLogger(t, "")
+	factory := channel.RunnerFactoryWithCommonInputSettings(beat.Info{Logger: logger}, runnerFactory{logger: logger})
+	runner, err := factory.Create(pipeline, config)
+	require.NoError(t, err)
+	runner.Start()
+	defer runner.Stop()
+
+	_, err = w.WriteString("INFO first line\nDEBUG noisy line\nWARN last line\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	want := []string{"INFO first line", "WARN last line"}
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(published) == len(want)
+	}, 10*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, want, published)
+}
+
+// runnerFactory creates stdin inputs, like the input runner factory of
+// filebeat does for the registered inputs.
+type runnerFactory struct {
+	logger *logp.Logger
+}
+
+func (f runnerFactory) Create(p beat.PipelineConnector, cfg *conf.C) (cfgfile.Runner, error) {
+	in, err := NewInput(cfg, channel.NewOutletFactory(nil).Create(p), input.Context{}, f.logger)
+	if err != nil {
+		return nil, err
+	}
+	return inputRunner{in}, nil
+}
+
+func (f runnerFactory) CheckConfig(cfg *conf.C) error {
+	return nil
+}
+
+type inputRunner struct {
+	input.Input
+}
+
+func (r inputRunner) Start()         { r.Run() }
+func (r inputRunner) String() string { return "stdin" }
@@ -18,6 +18,7 @@
 package syslog
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -36,6 +37,7 @@ import (
 
 type config struct {
 	harvester.ForwarderConfig `config:",inline"`
+	ID                        string            `config:"id"`
 	Format                    syslogFormat      `config:"format"`
 	Protocol                  conf.Namespace    `config:"protocol"`
 	Timezone                  *cfgtype.Timezone `config:"timezone"`
@@ -77,6 +79,29 @@ var defaultTCP = syslogTCP{
 	LineDelimiter: "\n",
 }
 
+// tlsName is the name of the RFC5425 syslog over TLS protocol.
+const tlsName = "tls"
+
+// syslogTLS is the configuration of the syslog over TLS transport defined
+// by RFC5425. Messages are always framed with octet counting.
+type syslogTLS struct {
+	tcp.Config `config:",inline"`
+}
+
+var defaultTLS = syslogTLS{
+	Config: tcp.Config{
+		Timeout:        time.Minute * 5,
+		MaxMessageSize: 20 * humanize.MiByte,
+	},
+}
+
+func (c *syslogTLS) Validate() error {
+	if !c.TLS.IsEnabled() {
+		return errors.New("ssl must be configured for the tls protocol")
+	}
+	return nil
+}
+
 type syslogUnix struct {
 	unix.Config `config:",inline"`
 }
@@ -99,6 +124,7 @@ var defaultUDP = udp.Config{
 func factory(
 	nf inputsource.NetworkFunc,
 	config conf.Namespace,
+	metrics *inputMetrics,
 	logger *logp.Logger,
 ) (inputsource.Network, error) {
 	n, cfg := config.Name(), config.Config()
@@ -118,7 +144,19 @@ func factory(
 		tcpLogger := logger.Named("input.syslog.tcp").With("address", config.Config.Host)
 		factory := streaming.SplitHandlerFactory(inputsource.FamilyTCP, tcpLogger, tcp.MetadataCallback, nf, splitFunc)
 
-		return tcp.New(&config.Config, factory, tcpLogger)
+		metrics.setDevice(config.Config.Host)
+		return tcp.New(&config.Config, metrics.handlerFactory(factory), tcpLogger)
+	case tlsName:
+		config := defaultTLS
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+
+		tlsLogger := logger.Named("input.syslog.tls").With("address", config.Config.Host)
+		factory := streaming.SplitHandlerFactory(inputsource.FamilyTCP, tlsLogger, tcp.MetadataCallback, nf, streaming.FactoryOctetCounting())
+
+		metrics.setDevice(config.Config.Host)
+		return tcp.New(&config.Config, metrics.handlerFactory(factory), tlsLogger)
 	case unix.Name:
 		config := defaultUnix()
 		if err := cfg.Unpack(&config); err != nil {
@@ -126,6 +164,7 @@ func factory(
 		}
 
 		unixLogger := logger.Named("input.syslog.unix").With("path", config.Config.Path)
+		metrics.setDevice(config.Config.Path)
 
 		return unix.New(unixLogger, &config.Config, nf)
 
@@ -135,9 +174,10 @@ func factory(
 			return nil, err
 		}
 		udpLogger := logger.Named("input.syslog.udp").With("address", config.Host)
+		metrics.setDevice(config.Host)
 		return udp.New(&config, nf, udpLogger), nil
 	default:
-		return nil, fmt.Errorf("you must choose between TCP or UDP")
+		return nil, fmt.Errorf("you must choose between TCP, TLS, UDP or Unix")
 	}
 }
 
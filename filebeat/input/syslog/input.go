@@ -104,6 +104,7 @@ type Input struct {
 	outlet  channel.Outleter
 	server  inputsource.Network
 	config  *config
+	metrics *inputMetrics
 	log     *logp.Logger
 }
 
@@ -131,9 +132,11 @@ func NewInput(
 	}
 
 	forwarder := harvester.NewForwarder(out)
-	cb := GetCbByConfig(config, forwarder, log)
-	server, err := factory(cb, config.Protocol, logger)
+	metrics := newInputMetrics(config.ID)
+	cb := metrics.networkFunc(GetCbByConfig(config, forwarder, log))
+	server, err := factory(cb, config.Protocol, metrics, logger)
 	if err != nil {
+		metrics.close()
 		return nil, err
 	}
 
@@ -142,6 +145,7 @@ func NewInput(
 		started: false,
 		server:  server,
 		config:  &config,
+		metrics: metrics,
 		log:     log,
 	}, nil
 }
@@ -167,6 +171,7 @@ func (p *Input) Stop() {
 	defer p.outlet.Close()
 	p.Lock()
 	defer p.Unlock()
+	defer p.metrics.close()
 
 	if !p.started {
 		return
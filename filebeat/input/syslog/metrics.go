@@ -0,0 +1,137 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+
+	"github.com/elastic/beats/v7/filebeat/inputsource"
+	"github.com/elastic/beats/v7/filebeat/inputsource/common/streaming"
+	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/monitoring/adapter"
+)
+
+// inputMetrics handles the input's metric reporting.
+type inputMetrics struct {
+	unregister func()
+
+	device             *monitoring.String // address or path being listened on
+	packets            *monitoring.Uint   // number of messages received
+	bytes              *monitoring.Uint   // number of bytes received
+	connectionsActive  *monitoring.Int    // number of currently open connections
+	connectionsTotal   *monitoring.Uint   // number of accepted connections
+	connectionErrors   *monitoring.Uint   // number of connections closed on an error, such as invalid framing
+	handshakeFailures  *monitoring.Uint   // number of failed TLS handshakes
+	connectionDuration metrics.Sample     // histogram of the connection lifetimes
+}
+
+// newInputMetrics returns the metrics of the syslog input. If id is empty a
+// nil inputMetrics is returned.
+func newInputMetrics(id string) *inputMetrics {
+	if id == "" {
+		return nil
+	}
+	reg, unreg := inputmon.NewInputRegistry("syslog", id, nil)
+	out := &inputMetrics{
+		unregister:         unreg,
+		device:             monitoring.NewString(reg, "device"),
+		packets:            monitoring.NewUint(reg, "received_events_total"),
+		bytes:              monitoring.NewUint(reg, "received_bytes_total"),
+		connectionsActive:  monitoring.NewInt(reg, "connections_active"),
+		connectionsTotal:   monitoring.NewUint(reg, "connections_total"),
+		connectionErrors:   monitoring.NewUint(reg, "connection_errors_total"),
+		handshakeFailures:  monitoring.NewUint(reg, "tls_handshake_failures_total"),
+		connectionDuration: metrics.NewUniformSample(1024),
+	}
+	_ = adapter.NewGoMetrics(reg, "connection_duration", adapter.Accept).
+		Register("histogram", metrics.NewHistogram(out.connectionDuration))
+
+	return out
+}
+
+func (m *inputMetrics) setDevice(device string) {
+	if m == nil {
+		return
+	}
+	m.device.Set(device)
+}
+
+// networkFunc wraps nf to count the received messages.
+func (m *inputMetrics) networkFunc(nf inputsource.NetworkFunc) inputsource.NetworkFunc {
+	if m == nil {
+		return nf
+	}
+	return func(data []byte, metadata inputsource.NetworkMetadata) {
+		m.packets.Add(1)
+		m.bytes.Add(uint64(len(data)))
+		nf(data, metadata)
+	}
+}
+
+// handlerFactory wraps the connection handlers created by factory to
+// report the metrics of each connection.
+func (m *inputMetrics) handlerFactory(factory streaming.HandlerFactory) streaming.HandlerFactory {
+	if m == nil {
+		return factory
+	}
+	return func(config streaming.ListenerConfig) streaming.ConnectionHandler {
+		handler := factory(config)
+		return func(ctx context.Context, conn net.Conn) error {
+			start := time.Now()
+			m.connectionsTotal.Inc()
+			m.connectionsActive.Inc()
+			defer func() {
+				m.connectionsActive.Dec()
+				m.connectionDuration.Update(time.Since(start).Nanoseconds())
+			}()
+
+			// Complete the handshake before reading so that failures,
+			// such as a missing or untrusted client certificate, are
+			// reported separately from other connection errors.
+			if tlsConn, ok := conn.(*tls.Conn); ok {
+				handshakeCtx, cancel := context.WithTimeout(ctx, config.Timeout)
+				err := tlsConn.HandshakeContext(handshakeCtx)
+				cancel()
+				if err != nil {
+					m.handshakeFailures.Inc()
+					return err
+				}
+			}
+
+			err := handler(ctx, conn)
+			if err != nil {
+				m.connectionErrors.Inc()
+			}
+			return err
+		}
+	}
+}
+
+func (m *inputMetrics) close() {
+	if m == nil || m.unregister == nil {
+		return
+	}
+	m.unregister()
+	m.unregister = nil
+}
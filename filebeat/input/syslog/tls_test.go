@@ -0,0 +1,184 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/filebeat/inputsource"
+	"github.com/elastic/beats/v7/filebeat/inputsource/tcp"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/testing/certutil"
+)
+
+func TestTLSConfig(t *testing.T) {
+	var config struct {
+		Protocol conf.Namespace `config:"protocol"`
+	}
+	cfg := conf.MustNewConfigFrom(mapstr.M{
+		"protocol.tls.host": "localhost:6514",
+	})
+	require.NoError(t, cfg.Unpack(&config))
+
+	_, err := factory(func([]byte, inputsource.NetworkMetadata) {}, config.Protocol, nil, logptest.NewTestingLogger(t, ""))
+	assert.ErrorContains(t, err, "ssl must be configured for the tls protocol")
+}
+
+// tlsCerts are the certificates of a server requiring client certificates.
+type tlsCerts struct {
+	ca         *x509.CertPool
+	client     tls.Certificate
+	untrusted  tls.Certificate
+	serverCfg  mapstr.M
+	serverName string
+}
+
+func newTLSCerts(t *testing.T) tlsCerts {
+	dir := t.TempDir()
+	writePair := func(name string, pair certutil.Pair) (string, string) {
+		cert, key := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+		require.NoError(t, os.WriteFile(cert, pair.Cert, 0o600))
+		require.NoError(t, os.WriteFile(key, pair.Key, 0o600))
+		return cert, key
+	}
+
+	caKey, caCert, caPair, err := certutil.NewRootCA()
+	require.NoError(t, err)
+	_, serverPair, err := certutil.GenerateChildCert("localhost", []net.IP{net.ParseIP("127.0.0.1")}, caKey, caCert)
+	require.NoError(t, err)
+	client, _, err := certutil.GenerateChildCert("client", nil, caKey, caCert, certutil.WithClientCert(true))
+	require.NoError(t, err)
+
+	otherKey, otherCert, _, err := certutil.NewRootCA()
+	require.NoError(t, err)
+	untrusted, _, err := certutil.GenerateChildCert("client", nil, otherKey, otherCert, certutil.WithClientCert(true))
+	require.NoError(t, err)
+
+	caFile, _ := writePair("ca", caPair)
+	certFile, keyFile := writePair("server", serverPair)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	return tlsCerts{
+		ca:        pool,
+		client:    *client,
+		untrusted: *untrusted,
+		serverCfg: mapstr.M{
+			"certificate":             certFile,
+			"key":                     keyFile,
+			"certificate_authorities": []string{caFile},
+			"client_authentication":   "required",
+		},
+		serverName: "localhost",
+	}
+}
+
+func TestTLSProtocol(t *testing.T) {
+	certs := newTLSCerts(t)
+
+	var config struct {
+		Protocol conf.Namespace `config:"protocol"`
+	}
+	cfg := conf.MustNewConfigFrom(mapstr.M{
+		"protocol.tls": mapstr.M{
+			"host": "127.0.0.1:0",
+			"ssl":  certs.serverCfg,
+		},
+	})
+	require.NoError(t, cfg.Unpack(&config))
+
+	var (
+		mu       sync.Mutex
+		messages []string
+		peers    []int
+	)
+	nf := func(data []byte, metadata inputsource.NetworkMetadata) {
+		mu.Lock()
+		defer mu.Unlock()
+		messages = append(messages, string(data))
+		if metadata.TLS != nil {
+			peers = append(peers, len(metadata.TLS.PeerCertificates))
+		}
+	}
+
+	metrics := newInputMetrics("syslog-tls-test")
+	defer metrics.close()
+	server, err := factory(metrics.networkFunc(nf), config.Protocol, metrics, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+	addr := server.(*tcp.Server).Listener.Listener.Addr().String()
+
+	send := func(cert tls.Certificate, data string) {
+		conn, err := tls.Dial("tcp", addr, &tls.Config{
+			RootCAs:      certs.ca,
+			ServerName:   certs.serverName,
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		})
+		if err != nil {
+			// The handshake can fail on the client side when the client
+			// certificate is rejected.
+			return
+		}
+		defer conn.Close()
+		_, _ = conn.Write([]byte(data))
+		// Wait for the server to close the connection.
+		_ = conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		_, _ = conn.Read(make([]byte, 1))
+	}
+
+	send(certs.client, "28 <13>1 - host app - - - hello30 <13>1 - host app - - - hello\n2")
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(messages) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	send(certs.untrusted, "28 <13>1 - host app - - - hello")
+	assert.Eventually(t, func() bool {
+		return metrics.handshakeFailures.Get() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	send(certs.client, "<13>1 - host app - - - hello\n")
+	assert.Eventually(t, func() bool {
+		return metrics.connectionErrors.Get() == 1 && metrics.connectionsActive.Get() == 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{
+		"<13>1 - host app - - - hello",
+		"<13>1 - host app - - - hello\n2",
+	}, messages)
+	assert.Equal(t, []int{1, 1}, peers, "client certificate must be in the metadata")
+	assert.Equal(t, uint64(2), metrics.packets.Get())
+	assert.Equal(t, uint64(3), metrics.connectionsTotal.Get())
+}
@@ -55,12 +55,14 @@ type FramingType int
 const (
 	FramingDelimiter = iota
 	FramingRFC6587
+	FramingOctetCounting
 )
 
 var (
 	framingTypes = map[string]FramingType{
-		"delimiter": FramingDelimiter,
-		"rfc6587":   FramingRFC6587,
+		"delimiter":      FramingDelimiter,
+		"rfc6587":        FramingRFC6587,
+		"octet_counting": FramingOctetCounting,
 	}
 
 	availableFramingTypesErrFormat string
@@ -230,6 +232,8 @@ func SplitFunc(framing FramingType, lineDelimiter []byte) (bufio.SplitFunc, erro
 		return FactoryDelimiter(lineDelimiter), nil
 	case FramingRFC6587:
 		return FactoryRFC6587Framing(lineDelimiter), nil
+	case FramingOctetCounting:
+		return FactoryOctetCounting(), nil
 	default:
 		return nil, fmt.Errorf("unknown SplitFunc for framing %d and line delimiter %q", framing, lineDelimiter)
 	}
@@ -20,9 +20,20 @@ package streaming
 import (
 	"bufio"
 	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"strconv"
 )
 
+// maxOctetCountDigits is the maximum number of digits of the message length
+// of an octet counted frame.
+const maxOctetCountDigits = 10
+
+// ErrInvalidOctetCount is returned when a frame does not start with a
+// valid message length when using octet counting framing.
+var ErrInvalidOctetCount = errors.New("invalid octet counting frame")
+
 // FactoryDelimiter return a function to split line using a custom delimiter supporting multibytes
 // delimiter, the delimiter is stripped from the returned value.
 func FactoryDelimiter(delimiter []byte) bufio.SplitFunc {
@@ -87,3 +98,60 @@ func FactoryRFC6587Framing(delimiter []byte) bufio.SplitFunc {
 		return 0, nil, nil
 	}
 }
+
+// FactoryOctetCounting returns a function that splits based on octet
+// counting framing only, as required by RFC5425 for syslog over TLS. Frames
+// that do not start with a message length are rejected with
+// ErrInvalidOctetCount.
+func FactoryOctetCounting() bufio.SplitFunc {
+	return func(data []byte, eof bool) (int, []byte, error) {
+		if eof && len(data) == 0 {
+			return 0, nil, nil
+		}
+		i := bytes.IndexByte(data, ' ')
+		if i < 0 {
+			if len(data) > maxOctetCountDigits || !isOctetCount(data, true) {
+				return 0, nil, fmt.Errorf("%w: %q", ErrInvalidOctetCount, data[:min(len(data), maxOctetCountDigits+1)])
+			}
+			if eof {
+				return 0, nil, io.ErrUnexpectedEOF
+			}
+			// request more data
+			return 0, nil, nil
+		}
+		if i > maxOctetCountDigits || !isOctetCount(data[:i], false) {
+			return 0, nil, fmt.Errorf("%w: %q", ErrInvalidOctetCount, data[:min(i, maxOctetCountDigits+1)])
+		}
+		length, err := strconv.Atoi(string(data[:i]))
+		if err != nil {
+			return 0, nil, fmt.Errorf("%w: %w", ErrInvalidOctetCount, err)
+		}
+		end := length + i + 1
+		if len(data) >= end {
+			return end, data[i+1 : end], nil
+		}
+		if eof {
+			return 0, nil, io.ErrUnexpectedEOF
+		}
+		// request more data
+		return 0, nil, nil
+	}
+}
+
+// isOctetCount returns true if data is a message length as defined by
+// RFC5425, a non-zero number without leading zeros. If partial is true,
+// data can be the beginning of a message length.
+func isOctetCount(data []byte, partial bool) bool {
+	if len(data) == 0 {
+		return partial
+	}
+	if data[0] == '0' {
+		return false
+	}
+	for _, c := range data {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
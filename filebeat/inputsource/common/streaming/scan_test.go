@@ -19,6 +19,7 @@ package streaming
 
 import (
 	"bufio"
+	"io"
 	"strings"
 	"testing"
 
@@ -178,3 +179,64 @@ func TestOctetCounting(t *testing.T) {
 		})
 	}
 }
+
+func TestOctetCountingOnly(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		err      error
+	}{
+		{
+			name:  "octet counting",
+			input: "13 <9> message 09 <6> msg 113 <3> message 2",
+			expected: []string{
+				"<9> message 0",
+				"<6> msg 1",
+				"<3> message 2",
+			},
+		},
+		{
+			name:  "embedded newline",
+			input: "14 <9> message \n010 <6> msg \n1",
+			expected: []string{
+				"<9> message \n0",
+				"<6> msg \n1",
+			},
+		},
+		{
+			name:     "non-transparent",
+			input:    "13 <9> message 0<6> msg 1\n",
+			expected: []string{"<9> message 0"},
+			err:      ErrInvalidOctetCount,
+		},
+		{
+			name:  "leading zero",
+			input: "013 <9> message 0",
+			err:   ErrInvalidOctetCount,
+		},
+		{
+			name:  "length too long",
+			input: "12345678901",
+			err:   ErrInvalidOctetCount,
+		},
+		{
+			name:     "truncated frame",
+			input:    "13 <9> message 013 <3> mess",
+			expected: []string{"<9> message 0"},
+			err:      io.ErrUnexpectedEOF,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scanner := bufio.NewScanner(strings.NewReader(test.input))
+			scanner.Split(FactoryOctetCounting())
+			var elements []string
+			for scanner.Scan() {
+				elements = append(elements, scanner.Text())
+			}
+			assert.EqualValues(t, test.expected, elements)
+			assert.ErrorIs(t, scanner.Err(), test.err)
+		})
+	}
+}
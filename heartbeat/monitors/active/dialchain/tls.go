@@ -18,6 +18,7 @@
 package dialchain
 
 import (
+	"context"
 	cryptoTLS "crypto/tls"
 	"fmt"
 	"net"
@@ -41,17 +42,43 @@ func TLSLayer(cfg *tlscommon.TLSConfig, to time.Duration) Layer {
 		next = startTimerAfterDial(&timer, next)
 
 		dialer := transport.TLSDialer(next, cfg, to)
-		return afterDial(dialer, func(conn net.Conn) (net.Conn, error) {
-			tlsConn, ok := conn.(*cryptoTLS.Conn)
-			if !ok {
-				panic(fmt.Sprintf("TLS afterDial received a non-tls connection %t. This should never happen", conn))
-			}
-			connState := tlsConn.ConnectionState()
-			timer.stop()
-
-			tlsmeta.AddTLSMetadata(event.Fields, connState, timer.duration())
-
-			return conn, nil
-		}), nil
+		return afterDial(dialer, addTLSMetadata(event, &timer)), nil
+	}
+}
+
+// ALPNTLSLayer configures a TLS layer like TLSLayer, additionally
+// negotiating one of the given application protocols during the handshake.
+// Protocols like HTTP/2 require the negotiation to succeed.
+func ALPNTLSLayer(cfg *tlscommon.TLSConfig, to time.Duration, protos []string) Layer {
+	return func(event *beat.Event, next transport.Dialer) (transport.Dialer, error) {
+		var timer timer
+
+		next = startTimerAfterDial(&timer, next)
+
+		h2, err := transport.TLSDialerH2(next, cfg, to)
+		if err != nil {
+			return nil, err
+		}
+		dialer := makeDialer(func(ctx context.Context, network, address string) (net.Conn, error) {
+			return h2.DialContext(ctx, network, address, &cryptoTLS.Config{NextProtos: protos})
+		})
+		return afterDial(dialer, addTLSMetadata(event, &timer)), nil
+	}
+}
+
+// addTLSMetadata returns an afterDial callback stopping the timer and
+// updating the event with the details of the established TLS connection.
+func addTLSMetadata(event *beat.Event, timer *timer) func(net.Conn) (net.Conn, error) {
+	return func(conn net.Conn) (net.Conn, error) {
+		tlsConn, ok := conn.(*cryptoTLS.Conn)
+		if !ok {
+			panic(fmt.Sprintf("TLS afterDial received a non-tls connection %t. This should never happen", conn))
+		}
+		connState := tlsConn.ConnectionState()
+		timer.stop()
+
+		tlsmeta.AddTLSMetadata(event.Fields, connState, timer.duration())
+
+		return conn, nil
 	}
 }
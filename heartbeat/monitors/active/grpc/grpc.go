@@ -0,0 +1,297 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/plugin"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/wraputil"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+func init() {
+	plugin.Register("grpc", create, "synthetics/grpc")
+}
+
+var debugf = logp.MakeDebug("grpc")
+
+var errConnUsed = errors.New("connection already used")
+
+func create(
+	name string,
+	cfg *conf.C,
+) (p plugin.Plugin, err error) {
+	return createWithResolver(cfg, monitors.NewStdResolver())
+}
+
+// Custom resolver is useful for tests against hostnames locally where we don't want to depend on any
+// hostnames existing in test environments
+func createWithResolver(
+	cfg *conf.C,
+	resolver monitors.Resolver,
+) (p plugin.Plugin, err error) {
+	jf, err := newJobFactory(cfg, resolver)
+	if err != nil {
+		return plugin.Plugin{}, err
+	}
+
+	js, err := jf.makeJobs()
+	if err != nil {
+		return plugin.Plugin{}, err
+	}
+
+	return plugin.Plugin{Jobs: js, Endpoints: len(js)}, nil
+}
+
+// jobFactory builds the jobs checking the health of every configured
+// service on every configured host.
+type jobFactory struct {
+	config    config
+	tlsConfig *tlscommon.TLSConfig
+	urls      []*url.URL
+	resolver  monitors.Resolver
+}
+
+func newJobFactory(commonCfg *conf.C, resolver monitors.Resolver) (*jobFactory, error) {
+	jf := &jobFactory{config: defaultConfig(), resolver: resolver}
+	err := jf.loadConfig(commonCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return jf, nil
+}
+
+// loadConfig parses the YAML config and populates the jobFactory fields.
+func (jf *jobFactory) loadConfig(commonCfg *conf.C) error {
+	var err error
+	if err = commonCfg.Unpack(&jf.config); err != nil {
+		return err
+	}
+
+	jf.tlsConfig, err = tlscommon.LoadTLSConfig(jf.config.TLS)
+	if err != nil {
+		return err
+	}
+
+	defaultScheme := "grpc"
+	if jf.tlsConfig != nil {
+		defaultScheme = "grpcs"
+	}
+
+	for _, h := range jf.config.Hosts {
+		u, err := makeURL(h, defaultScheme)
+		if err != nil {
+			return err
+		}
+		jf.urls = append(jf.urls, u)
+	}
+
+	return nil
+}
+
+// makeURL parses a host:port pair, optionally prefixed by a scheme.
+func makeURL(host, defaultScheme string) (*url.URL, error) {
+	u, err := url.Parse(host)
+	// If host is just a bare host:port pair it will either fail to parse, or
+	// the host will be parsed as the scheme.
+	if err != nil || u.Host == "" {
+		u = &url.URL{Scheme: defaultScheme, Host: host}
+	}
+
+	switch u.Scheme {
+	case "grpc", "grpcs":
+	default:
+		return nil, fmt.Errorf(
+			"'%s' is not a supported connection scheme in '%s', supported schemes are grpc and grpcs",
+			u.Scheme,
+			u,
+		)
+	}
+
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("could not parse grpc host '%s'", u)
+	}
+	if _, err := strconv.ParseUint(u.Port(), 10, 16); err != nil {
+		return nil, fmt.Errorf("host '%s' missing port number", u)
+	}
+
+	return &url.URL{Scheme: u.Scheme, Host: u.Host}, nil
+}
+
+// makeJobs returns the actual schedulable jobs for this monitor.
+func (jf *jobFactory) makeJobs() ([]jobs.Job, error) {
+	services := jf.config.Services
+	if len(services) == 0 {
+		// The empty service name checks the overall health of the server.
+		services = []string{""}
+	}
+
+	var js []jobs.Job
+	for _, u := range jf.urls {
+		for _, service := range services {
+			job, err := jf.makeEndpointJob(u, service)
+			if err != nil {
+				return nil, err
+			}
+			js = append(js, wraputil.WithURLField(u, job))
+		}
+	}
+
+	return js, nil
+}
+
+// makeEndpointJob makes a job checking a single service on a single host.
+func (jf *jobFactory) makeEndpointJob(endpointURL *url.URL, service string) (jobs.Job, error) {
+	job, err := monitors.MakeByHostJob(
+		endpointURL.Hostname(),
+		jf.config.Mode,
+		jf.resolver,
+		monitors.MakePingIPFactory(
+			func(event *beat.Event, ip *net.IPAddr) error {
+				// use address from resolved IP
+				ipPort := net.JoinHostPort(ip.String(), endpointURL.Port())
+
+				return jf.check(event, ipPort, endpointURL, service)
+			}))
+	if err != nil {
+		return nil, err
+	}
+
+	if service == "" {
+		return job, nil
+	}
+	return wraputil.WithFields(mapstr.M{"grpc": mapstr.M{"service": service}}, job), nil
+}
+
+// check connects to dialAddr and calls the grpc.health.v1.Health/Check method
+// for the given service. canonicalURL determines if TLS is used, and which
+// hostname is used to validate the server certificate and as authority.
+func (jf *jobFactory) check(event *beat.Event, dialAddr string, canonicalURL *url.URL, service string) error {
+	start := time.Now()
+	deadline := start.Add(jf.config.Timeout)
+
+	dc := &dialchain.DialerChain{
+		Net: dialchain.CreateNetDialer(jf.config.Timeout),
+	}
+	dc.AddLayer(dialchain.ConstAddrLayer(dialAddr))
+	if canonicalURL.Scheme == "grpcs" {
+		// gRPC servers reject TLS connections that did not negotiate HTTP/2.
+		dc.AddLayer(dialchain.ALPNTLSLayer(jf.tlsConfig, jf.config.Timeout, []string{"h2"}))
+		dc.AddLayer(dialchain.ConstAddrLayer(canonicalURL.Host))
+	}
+
+	dialer, err := dc.Build(event)
+	if err != nil {
+		return err
+	}
+
+	// The connection is established before creating the client, so that
+	// the event is not updated by reconnection attempts of the client.
+	conn, err := dialer.Dial("tcp", dialAddr)
+	if err != nil {
+		debugf("dial failed with: %v", err)
+		var certErr x509.CertificateInvalidError
+		if errors.As(err, &certErr) {
+			tlsFields := tlsmeta.CertFields(certErr.Cert, nil)
+			event.Fields.DeepUpdate(mapstr.M{"tls": tlsFields})
+		}
+		return reason.IOFailed(err)
+	}
+
+	var used atomic.Bool
+	client, err := grpc.NewClient("passthrough:///"+dialAddr,
+		grpc.WithAuthority(canonicalURL.Host),
+		// TLS is handled by the dialer.
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			if used.Swap(true) {
+				return nil, errConnUsed
+			}
+			return conn, nil
+		}),
+	)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	checkStart := time.Now()
+	resp, err := healthpb.NewHealthClient(client).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	end := time.Now()
+
+	fields := mapstr.M{
+		"code": status.Code(err).String(),
+		"rtt": mapstr.M{
+			"check": look.RTT(end.Sub(checkStart)),
+		},
+	}
+	if err == nil {
+		fields["status"] = resp.GetStatus().String()
+	}
+	eventext.MergeEventFields(event, mapstr.M{"grpc": fields})
+
+	if err != nil {
+		debugf("check failed with: %v", err)
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+			return reason.IOFailed(err)
+		default:
+			return reason.MakeValidateError(err)
+		}
+	}
+
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		if service == "" {
+			return reason.MakeValidateError(fmt.Errorf("server is %s", resp.GetStatus()))
+		}
+		return reason.MakeValidateError(fmt.Errorf("service '%s' is %s", service, resp.GetStatus()))
+	}
+
+	return nil
+}
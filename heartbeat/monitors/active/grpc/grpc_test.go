@@ -0,0 +1,366 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/testing/certutil"
+	"github.com/elastic/go-lookslike"
+	"github.com/elastic/go-lookslike/isdef"
+	"github.com/elastic/go-lookslike/testslike"
+	"github.com/elastic/go-lookslike/validator"
+
+	"github.com/elastic/beats/v7/heartbeat/ecserr"
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/hbtestllext"
+	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
+	"github.com/elastic/beats/v7/heartbeat/scheduler/schedule"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	btesting "github.com/elastic/beats/v7/libbeat/testing"
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func testGRPCConfigCheck(t *testing.T, configMap mapstr.M, endpoints int) []*beat.Event {
+	config, err := conf.NewConfigFrom(configMap)
+	require.NoError(t, err)
+
+	p, err := create("grpc", config)
+	require.NoError(t, err)
+	require.Equal(t, endpoints, p.Endpoints)
+
+	sched := schedule.MustParse("@every 1s")
+	js := wrappers.WrapCommon(p.Jobs, stdfields.StdMonitorFields{ID: "test", Type: "grpc", Schedule: sched, Timeout: 1}, nil)
+
+	var events []*beat.Event
+	for _, job := range js {
+		event := &beat.Event{}
+		_, err = job(event)
+		require.NoError(t, err)
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// startHealthServer starts a gRPC server implementing the health service,
+// with the overall health of the server set to SERVING.
+func startHealthServer(t *testing.T, opts ...grpc.ServerOption) (*health.Server, uint16) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	hs := health.NewServer()
+	server := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(server, hs)
+	go server.Serve(listener) //nolint:errcheck // returns when the server is stopped
+	t.Cleanup(server.Stop)
+
+	return hs, uint16(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func grpcChecks(code string, servingStatus string) validator.Validator {
+	fields := map[string]interface{}{
+		"code":         code,
+		"rtt.check.us": hbtestllext.IsInt64,
+	}
+	if servingStatus != "" {
+		fields["status"] = servingStatus
+	}
+	return lookslike.MustCompile(map[string]interface{}{"grpc": fields})
+}
+
+func TestServing(t *testing.T) {
+	_, port := startHealthServer(t)
+
+	events := testGRPCConfigCheck(t, mapstr.M{
+		"hosts":   "127.0.0.1:" + strconv.Itoa(int(port)),
+		"timeout": "1s",
+	}, 1)
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "up", "grpc"),
+			hbtest.SummaryStateChecks(1, 0),
+			hbtest.SimpleURLChecks(t, "grpc", "127.0.0.1", port),
+			hbtest.RespondingTCPChecks(),
+			grpcChecks("OK", "SERVING"),
+		)),
+		events[0].Fields,
+	)
+}
+
+func TestServices(t *testing.T) {
+	hs, port := startHealthServer(t)
+	hs.SetServingStatus("foo", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus("bar", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	events := testGRPCConfigCheck(t, mapstr.M{
+		"hosts":          "grpc://localhost:" + strconv.Itoa(int(port)),
+		"check.services": []string{"foo", "bar", "baz"},
+		"timeout":        "1s",
+	}, 3)
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "up", "grpc"),
+			hbtest.SummaryStateChecks(1, 0),
+			hbtest.SimpleURLChecks(t, "grpc", "localhost", port),
+			hbtest.RespondingTCPChecks(),
+			hbtest.ResolveChecks("127.0.0.1"),
+			grpcChecks("OK", "SERVING"),
+			lookslike.MustCompile(map[string]interface{}{"grpc.service": "foo"}),
+		)),
+		events[0].Fields,
+	)
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "down", "grpc"),
+			hbtest.SummaryStateChecks(0, 1),
+			hbtest.SimpleURLChecks(t, "grpc", "localhost", port),
+			hbtest.RespondingTCPChecks(),
+			hbtest.ResolveChecks("127.0.0.1"),
+			grpcChecks("OK", "NOT_SERVING"),
+			lookslike.MustCompile(map[string]interface{}{"grpc.service": "bar"}),
+			hbtest.ErrorChecks("service 'bar' is NOT_SERVING", "validate"),
+		)),
+		events[1].Fields,
+	)
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "down", "grpc"),
+			hbtest.SummaryStateChecks(0, 1),
+			hbtest.SimpleURLChecks(t, "grpc", "localhost", port),
+			hbtest.RespondingTCPChecks(),
+			hbtest.ResolveChecks("127.0.0.1"),
+			grpcChecks("NotFound", ""),
+			lookslike.MustCompile(map[string]interface{}{"grpc.service": "baz"}),
+			hbtest.ErrorChecks("unknown service", "validate"),
+		)),
+		events[2].Fields,
+	)
+}
+
+func TestHealthServiceNotImplemented(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	go server.Serve(listener) //nolint:errcheck // returns when the server is stopped
+	defer server.Stop()
+	port := uint16(listener.Addr().(*net.TCPAddr).Port)
+
+	events := testGRPCConfigCheck(t, mapstr.M{
+		"hosts":   "127.0.0.1:" + strconv.Itoa(int(port)),
+		"timeout": "1s",
+	}, 1)
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "down", "grpc"),
+			hbtest.SummaryStateChecks(0, 1),
+			hbtest.SimpleURLChecks(t, "grpc", "127.0.0.1", port),
+			hbtest.RespondingTCPChecks(),
+			grpcChecks("Unimplemented", ""),
+			hbtest.ErrorChecks("unknown service grpc.health.v1.Health", "validate"),
+		)),
+		events[0].Fields,
+	)
+}
+
+func TestConnectionRefused(t *testing.T) {
+	ip := "127.0.0.1"
+	port, err := btesting.AvailableTCP4Port()
+	require.NoError(t, err)
+
+	events := testGRPCConfigCheck(t, mapstr.M{
+		"hosts":   net.JoinHostPort(ip, strconv.Itoa(int(port))),
+		"timeout": "1s",
+	}, 1)
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks(ip, "down", "grpc"),
+			hbtest.SummaryStateChecks(0, 1),
+			hbtest.SimpleURLChecks(t, "grpc", ip, port),
+			hbtest.ECSErrCodeChecks(ecserr.CODE_NET_COULD_NOT_CONNECT, "dial tcp"),
+		)),
+		events[0].Fields,
+	)
+}
+
+type tlsFiles struct {
+	ca                    *x509.Certificate
+	server                *tls.Certificate
+	caFile                string
+	clientCert, clientKey string
+}
+
+func makeTLSFiles(t *testing.T) tlsFiles {
+	dir := t.TempDir()
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		return path
+	}
+
+	caKey, caCert, caPair, err := certutil.NewRootCA()
+	require.NoError(t, err)
+	server, _, err := certutil.GenerateChildCert("localhost", []net.IP{net.ParseIP("127.0.0.1")}, caKey, caCert)
+	require.NoError(t, err)
+	_, clientPair, err := certutil.GenerateChildCert("client", nil, caKey, caCert, certutil.WithClientCert(true))
+	require.NoError(t, err)
+
+	return tlsFiles{
+		ca:         caCert,
+		server:     server,
+		caFile:     writeFile("ca.crt", caPair.Cert),
+		clientCert: writeFile("client.crt", clientPair.Cert),
+		clientKey:  writeFile("client.key", clientPair.Key),
+	}
+}
+
+func TestTLS(t *testing.T) {
+	files := makeTLSFiles(t)
+	_, port := startHealthServer(t, grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{*files.server},
+	})))
+	serverCert, err := x509.ParseCertificate(files.server.Certificate[0])
+	require.NoError(t, err)
+
+	events := testGRPCConfigCheck(t, mapstr.M{
+		"hosts":   "localhost:" + strconv.Itoa(int(port)),
+		"ssl":     mapstr.M{"certificate_authorities": files.caFile},
+		"timeout": "1s",
+	}, 1)
+
+	testslike.Test(
+		t,
+		lookslike.Strict(lookslike.Compose(
+			hbtest.BaseChecks("127.0.0.1", "up", "grpc"),
+			hbtest.SummaryStateChecks(1, 0),
+			hbtest.SimpleURLChecks(t, "grpcs", "localhost", port),
+			hbtest.RespondingTCPChecks(),
+			hbtest.ResolveChecks("127.0.0.1"),
+			hbtest.TLSChecks(0, 0, serverCert),
+			lookslike.MustCompile(map[string]interface{}{"tls.next_protocol": "h2"}),
+			grpcChecks("OK", "SERVING"),
+		)),
+		events[0].Fields,
+	)
+}
+
+func TestMutualTLS(t *testing.T) {
+	files := makeTLSFiles(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(files.ca)
+	_, port := startHealthServer(t, grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{*files.server},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	})))
+	host := "grpcs://127.0.0.1:" + strconv.Itoa(int(port))
+
+	t.Run("with client certificate", func(t *testing.T) {
+		events := testGRPCConfigCheck(t, mapstr.M{
+			"hosts": host,
+			"ssl": mapstr.M{
+				"certificate_authorities": files.caFile,
+				"certificate":             files.clientCert,
+				"key":                     files.clientKey,
+			},
+			"timeout": "1s",
+		}, 1)
+
+		testslike.Test(
+			t,
+			lookslike.Compose(
+				hbtest.BaseChecks("127.0.0.1", "up", "grpc"),
+				hbtest.SummaryStateChecks(1, 0),
+				grpcChecks("OK", "SERVING"),
+			),
+			events[0].Fields,
+		)
+	})
+
+	t.Run("without client certificate", func(t *testing.T) {
+		events := testGRPCConfigCheck(t, mapstr.M{
+			"hosts":   host,
+			"ssl":     mapstr.M{"certificate_authorities": files.caFile},
+			"timeout": "1s",
+		}, 1)
+
+		testslike.Test(
+			t,
+			lookslike.Compose(
+				hbtest.BaseChecks("127.0.0.1", "down", "grpc"),
+				hbtest.SummaryStateChecks(0, 1),
+				lookslike.MustCompile(map[string]interface{}{
+					"error.type": "io",
+					"grpc.code":  isdef.IsEqual("Unavailable"),
+				}),
+			),
+			events[0].Fields,
+		)
+	})
+}
+
+func TestMakeURL(t *testing.T) {
+	for _, tc := range []struct {
+		host, defaultScheme string
+		want                string
+		wantErr             bool
+	}{
+		{host: "localhost:50051", defaultScheme: "grpc", want: "grpc://localhost:50051"},
+		{host: "127.0.0.1:50051", defaultScheme: "grpcs", want: "grpcs://127.0.0.1:50051"},
+		{host: "[::1]:50051", defaultScheme: "grpc", want: "grpc://[::1]:50051"},
+		{host: "grpcs://example.com:443", defaultScheme: "grpc", want: "grpcs://example.com:443"},
+		{host: "localhost", defaultScheme: "grpc", wantErr: true},
+		{host: "http://localhost:80", defaultScheme: "grpc", wantErr: true},
+	} {
+		t.Run(tc.host, func(t *testing.T) {
+			u, err := makeURL(tc.host, tc.defaultScheme)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, u.String())
+		})
+	}
+}
@@ -18,9 +18,11 @@
 package http
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 	"time"
 
@@ -56,6 +58,33 @@ type responseConfig struct {
 type checkConfig struct {
 	Request  requestParameters  `config:"request"`
 	Response responseParameters `config:"response"`
+	Steps    []stepConfig       `config:"steps"`
+}
+
+// stepConfig configures one request of a multi-step check. Steps are run in
+// order, and values extracted from a response can be used by the requests of
+// the following steps.
+type stepConfig struct {
+	Name     string             `config:"name"`
+	Request  stepRequestConfig  `config:"request"`
+	Response responseParameters `config:"response"`
+	Extract  []extractConfig    `config:"extract"`
+}
+
+type stepRequestConfig struct {
+	// URL is either absolute or relative to the monitored URL, which is
+	// requested if URL is empty.
+	URL    string            `config:"url"`
+	Params requestParameters `config:",inline"`
+}
+
+// extractConfig stores a value of a step response in a variable. Exactly one
+// of JSON, Header and Regex must be set.
+type extractConfig struct {
+	Name   string `config:"name" validate:"required"`
+	JSON   string `config:"json"`   // expression evaluated against the JSON body
+	Header string `config:"header"` // name of a response header
+	Regex  string `config:"regex"`  // first capture group, or whole match, in the body
 }
 
 type requestParameters struct {
@@ -87,6 +116,30 @@ func (check *jsonResponseCheck) Validate() error {
 	return nil
 }
 
+// InitDefaults initializes the defaults of a step, since steps are not
+// part of the monitor's default config.
+func (s *stepConfig) InitDefaults() {
+	s.Request.Params.Method = "GET"
+}
+
+// Validate validates of the extractConfig object is valid or not
+func (e *extractConfig) Validate() error {
+	if !placeholderName.MatchString(e.Name) {
+		return fmt.Errorf("invalid variable name '%s', only letters, digits, '_', '.' and '-' are allowed", e.Name)
+	}
+
+	sources := 0
+	for _, src := range []string{e.JSON, e.Header, e.Regex} {
+		if src != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("exactly one of 'json', 'header' or 'regex' must be specified for variable '%s'", e.Name)
+	}
+	return nil
+}
+
 type compressionConfig struct {
 	Type  string `config:"type"`
 	Level int    `config:"level"`
@@ -174,6 +227,10 @@ func (c *Config) Validate() error {
 		c.Hosts = append(c.Hosts, c.URLs...)
 	}
 
+	if err := c.Check.validateSteps(); err != nil {
+		return err
+	}
+
 	// updateScheme looks at TLS config to decide if http or https should be used to update the host
 	updateScheme := func(host string) string {
 		if c.Transport.TLS != nil && c.Transport.TLS.IsEnabled() {
@@ -197,3 +254,35 @@ func (c *Config) Validate() error {
 
 	return nil
 }
+
+// validateSteps validates that steps are not combined with a single request
+// check, and that steps only use variables extracted by previous steps.
+func (c *checkConfig) validateSteps() error {
+	if len(c.Steps) == 0 {
+		return nil
+	}
+
+	if c.Request.SendBody != "" || len(c.Request.SendHeaders) > 0 || !reflect.DeepEqual(c.Response, responseParameters{}) {
+		return errors.New("'check.steps' cannot be combined with 'check.request' or 'check.response', configure the request and response of each step instead")
+	}
+
+	defined := map[string]bool{}
+	for i, step := range c.Steps {
+		templates := []string{step.Request.URL, step.Request.Params.SendBody}
+		for _, v := range step.Request.Params.SendHeaders {
+			templates = append(templates, v)
+		}
+		for _, tmpl := range templates {
+			for _, name := range placeholders(tmpl) {
+				if !defined[name] {
+					return fmt.Errorf("step '%s' uses variable '%s' which is not extracted by a previous step", stepName(i, step.Name), name)
+				}
+			}
+		}
+
+		for _, e := range step.Extract {
+			defined[e.Name] = true
+		}
+	}
+	return nil
+}
@@ -80,7 +80,22 @@ func create(
 	// In the event that a ProxyURL is present, or redirect support is enabled
 	// we execute DNS resolution requests inline with the request, not running them as a separate job, and not returning
 	// separate DNS rtt data.
-	if (config.Transport.Proxy.URL != nil && !config.Transport.Proxy.Disable) || config.MaxRedirects > 0 {
+	// Multi-step checks resolve DNS inline as well, since each step may request a different host.
+	if len(config.Check.Steps) > 0 {
+		steps, err := makeSteps(config.Check.Steps)
+		if err != nil {
+			return plugin.Plugin{}, err
+		}
+
+		transport, err := newRoundTripper(&config)
+		if err != nil {
+			return plugin.Plugin{}, err
+		}
+
+		makeJob = func(urlStr string) (jobs.Job, error) {
+			return newHTTPMonitorStepsJob(urlStr, &config, transport, steps)
+		}
+	} else if (config.Transport.Proxy.URL != nil && !config.Transport.Proxy.Disable) || config.MaxRedirects > 0 {
 		transport, err := newRoundTripper(&config)
 		if err != nil {
 			return plugin.Plugin{}, err
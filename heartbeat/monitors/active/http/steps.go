@@ -0,0 +1,364 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PaesslerAG/gval"
+	"github.com/PaesslerAG/jsonpath"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var (
+	// placeholderName matches valid variable names.
+	placeholderName = regexp.MustCompile(`^[\w.-]+$`)
+	// placeholderRE matches {{name}} placeholders in step requests. The
+	// ${name} syntax can't be used since it's expanded when loading the config.
+	placeholderRE = regexp.MustCompile(`\{\{\s*([\w.-]+)\s*\}\}`)
+)
+
+// placeholders returns the names of the variables used in s.
+func placeholders(s string) (names []string) {
+	for _, m := range placeholderRE.FindAllStringSubmatch(s, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// expandVars replaces the placeholders in s with the values in vars.
+func expandVars(s string, vars map[string]string) string {
+	return placeholderRE.ReplaceAllStringFunc(s, func(m string) string {
+		if v, ok := vars[placeholderRE.FindStringSubmatch(m)[1]]; ok {
+			return v
+		}
+		return m
+	})
+}
+
+// stepName returns the configured name of the step at index i, or its
+// position if it has no name.
+func stepName(i int, name string) string {
+	if name != "" {
+		return name
+	}
+	return strconv.Itoa(i + 1)
+}
+
+// stepReason prefixes the message of a reason with the step that failed,
+// keeping its type.
+type stepReason struct {
+	reason.Reason
+	step string
+}
+
+func (r stepReason) Error() string {
+	return fmt.Sprintf("step '%s': %s", r.step, r.Reason.Error())
+}
+
+func stepError(step string, err error) error {
+	//nolint:errorlint // look.Reason doesn't unwrap reasons either
+	if r, ok := err.(reason.Reason); ok {
+		return stepReason{r, step}
+	}
+	return fmt.Errorf("step '%s': %w", step, err)
+}
+
+// extractor stores a value of a response in a variable.
+type extractor struct {
+	name   string
+	header string
+	json   gval.Evaluable
+	regex  *regexp.Regexp
+}
+
+func makeExtractor(cfg extractConfig) (extractor, error) {
+	e := extractor{name: cfg.Name, header: cfg.Header}
+	var err error
+	switch {
+	case cfg.JSON != "":
+		e.json, err = gval.Full(jsonpath.PlaceholderExtension()).NewEvaluable(cfg.JSON)
+		if err != nil {
+			return e, fmt.Errorf("could not compile gval expression '%s' for variable '%s': %w", cfg.JSON, cfg.Name, err)
+		}
+	case cfg.Regex != "":
+		e.regex, err = regexp.Compile(cfg.Regex)
+		if err != nil {
+			return e, fmt.Errorf("could not compile regex '%s' for variable '%s': %w", cfg.Regex, cfg.Name, err)
+		}
+	}
+	return e, nil
+}
+
+// extractValidators returns validators storing the values extracted by
+// extractors in vars. Failing to extract a value fails the validation.
+func extractValidators(extractors []extractor, vars map[string]string) (respValidators []respValidator, bodyValidators []bodyValidator) {
+	var fromJSON []extractor
+	for _, e := range extractors {
+		switch {
+		case e.header != "":
+			respValidators = append(respValidators, func(r *http.Response) error {
+				value := r.Header.Get(e.header)
+				if value == "" {
+					return fmt.Errorf("could not extract variable '%s': header %s not found", e.name, e.header)
+				}
+				vars[e.name] = value
+				return nil
+			})
+		case e.regex != nil:
+			bodyValidators = append(bodyValidators, func(_ *http.Response, body string) error {
+				m := e.regex.FindStringSubmatch(body)
+				if m == nil {
+					return fmt.Errorf("could not extract variable '%s': regex '%s' not matched", e.name, e.regex)
+				}
+				vars[e.name] = m[0]
+				if len(m) > 1 {
+					vars[e.name] = m[1]
+				}
+				return nil
+			})
+		default:
+			fromJSON = append(fromJSON, e)
+		}
+	}
+
+	if len(fromJSON) > 0 {
+		// Decode the body once for all JSON extractors.
+		bodyValidators = append(bodyValidators, func(_ *http.Response, body string) error {
+			decoded, err := decodeJson(body, false)
+			if err != nil {
+				return fmt.Errorf("could not extract variable '%s': %w", fromJSON[0].name, err)
+			}
+			for _, e := range fromJSON {
+				value, err := e.json(context.Background(), decoded)
+				if err == nil && value == nil {
+					err = errors.New("no value")
+				}
+				if err != nil {
+					return fmt.Errorf("could not extract variable '%s': %w", e.name, err)
+				}
+				vars[e.name] = formatValue(value)
+			}
+			return nil
+		})
+	}
+
+	return respValidators, bodyValidators
+}
+
+// formatValue formats a value decoded from JSON for use in a request.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+}
+
+// step is a compiled stepConfig.
+type step struct {
+	name       string
+	request    stepRequestConfig
+	validator  multiValidator
+	extractors []extractor
+}
+
+func makeSteps(cfgs []stepConfig) ([]*step, error) {
+	steps := make([]*step, 0, len(cfgs))
+	for i, cfg := range cfgs {
+		s := &step{name: stepName(i, cfg.Name), request: cfg.Request}
+
+		compression := cfg.Request.Params.Compression
+		if _, err := getContentEncoder(compression.Type, compression.Level); err != nil {
+			return nil, fmt.Errorf("step '%s': %w", s.name, err)
+		}
+
+		var err error
+		s.validator, err = makeValidateResponse(&cfg.Response)
+		if err != nil {
+			return nil, fmt.Errorf("step '%s': %w", s.name, err)
+		}
+
+		for _, ec := range cfg.Extract {
+			e, err := makeExtractor(ec)
+			if err != nil {
+				return nil, fmt.Errorf("step '%s': %w", s.name, err)
+			}
+			s.extractors = append(s.extractors, e)
+		}
+
+		steps = append(steps, s)
+	}
+	return steps, nil
+}
+
+// newHTTPMonitorStepsJob creates a job running all steps in order against
+// addr. The steps share cookies, and stop at the first failing step.
+func newHTTPMonitorStepsJob(
+	addr string,
+	config *Config,
+	transport http.RoundTripper,
+	steps []*step,
+) (jobs.Job, error) {
+	base, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs.MakeSimpleJob(func(event *beat.Event) error {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return err
+		}
+		client := &http.Client{
+			CheckRedirect: makeCheckRedirect(config.MaxRedirects, nil),
+			Transport:     transport,
+			Jar:           jar,
+		}
+
+		// The timeout applies to the whole sequence of steps.
+		deadline := time.Now().Add(config.Transport.Timeout)
+		vars := map[string]string{}
+		summaries := make([]mapstr.M, 0, len(steps))
+		for i, s := range steps {
+			stepEvent := &beat.Event{Fields: mapstr.M{}}
+			u, err := s.run(stepEvent, client, base, vars, config, time.Until(deadline))
+			summaries = append(summaries, stepSummary(s.name, u, stepEvent, err))
+
+			// The fields of the last executed step describe the response
+			// of the check.
+			if err != nil || i == len(steps)-1 {
+				eventext.MergeEventFields(event, stepEvent.Fields)
+			}
+			if err != nil {
+				_, _ = event.PutValue("http.steps", summaries)
+				return stepError(s.name, err)
+			}
+		}
+
+		_, _ = event.PutValue("http.steps", summaries)
+		return nil
+	}), nil
+}
+
+// run sends the request of the step and validates its response, storing the
+// extracted values in vars. It returns the requested URL.
+func (s *step) run(
+	event *beat.Event,
+	client *http.Client,
+	base *url.URL,
+	vars map[string]string,
+	config *Config,
+	timeout time.Duration,
+) (*url.URL, error) {
+	u, err := base.Parse(expandVars(s.request.URL, vars))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse step URL: %w", err)
+	}
+	if timeout <= 0 {
+		return u, reason.IOFailed(errors.New("timeout exceeded before the step was started"))
+	}
+
+	params := s.request.Params
+	req, err := http.NewRequestWithContext(context.TODO(), strings.ToUpper(params.Method), u.String(), nil)
+	if err != nil {
+		return u, fmt.Errorf("could not make http request: %w", err)
+	}
+	req.Close = true
+
+	// Credentials are only sent to the monitored host.
+	if config.Username != "" && u.Host == base.Host {
+		req.SetBasicAuth(config.Username, config.Password)
+	}
+	for k, v := range params.SendHeaders {
+		v = expandVars(v, vars)
+		// defining the Host header isn't enough. See https://github.com/golang/go/issues/7682
+		if k == "Host" {
+			req.Host = v
+		}
+
+		req.Header.Add(k, v)
+	}
+
+	var body []byte
+	if params.SendBody != "" {
+		enc, err := getContentEncoder(params.Compression.Type, params.Compression.Level)
+		if err != nil {
+			return u, err
+		}
+		buf := bytes.NewBuffer(nil)
+		if err := enc.Encode(buf, strings.NewReader(expandVars(params.SendBody, vars))); err != nil {
+			return u, err
+		}
+		enc.AddHeaders(&req.Header)
+		body = buf.Bytes()
+	}
+
+	// Values are only extracted once the configured checks passed.
+	respValidators, bodyValidators := extractValidators(s.extractors, vars)
+	validator := multiValidator{
+		respValidators: append(append([]respValidator{}, s.validator.respValidators...), respValidators...),
+		bodyValidators: append(append([]bodyValidator{}, s.validator.bodyValidators...), bodyValidators...),
+	}
+
+	client.Timeout = timeout
+	_, err = execPing(event, client, req, body, timeout, validator, config.Response)
+	return u, err
+}
+
+// stepSummary returns the fields describing an executed step.
+func stepSummary(name string, u *url.URL, event *beat.Event, err error) mapstr.M {
+	summary := mapstr.M{"name": name}
+	if u != nil {
+		summary["url"] = u.Redacted()
+	}
+	if status, getErr := event.GetValue("http.response.status_code"); getErr == nil {
+		summary["status_code"] = status
+	}
+	if rtt, getErr := event.GetValue("http.rtt.total"); getErr == nil {
+		summary["rtt"] = mapstr.M{"total": rtt}
+	}
+	if err != nil {
+		summary["error"] = look.Reason(err)
+	}
+	return summary
+}
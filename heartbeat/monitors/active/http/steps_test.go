@@ -0,0 +1,325 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/go-lookslike"
+	"github.com/elastic/go-lookslike/isdef"
+	"github.com/elastic/go-lookslike/testslike"
+
+	"github.com/elastic/beats/v7/heartbeat/hbtest"
+	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
+	"github.com/elastic/beats/v7/heartbeat/scheduler/schedule"
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+// loginHandler serves a login endpoint returning a token and a session
+// cookie, and an API endpoint requiring both.
+func loginHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || string(body) != `{"user":"heartbeat"}` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s3ss10n"})
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"token":"t0k3n","user":{"id":42}}`)
+	})
+	mux.HandleFunc("/api/users/42", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "s3ss10n" ||
+			r.Header.Get("Authorization") != "Bearer t0k3n" ||
+			r.Header.Get("X-Request-Id") != "req-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = io.WriteString(w, "user heartbeat")
+	})
+	return mux
+}
+
+func loginSteps(apiPath string, extract []interface{}) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name": "login",
+			"request": map[string]interface{}{
+				"url":    "/login",
+				"method": "POST",
+				"body":   `{"user":"heartbeat"}`,
+			},
+			"extract": extract,
+		},
+		map[string]interface{}{
+			"name": "api",
+			"request": map[string]interface{}{
+				"url": apiPath,
+				"headers": map[string]interface{}{
+					"Authorization": "Bearer {{token}}",
+					"X-Request-Id":  "{{request_id}}",
+				},
+			},
+			"response": map[string]interface{}{
+				"body": "user heartbeat",
+			},
+		},
+	}
+}
+
+var loginExtract = []interface{}{
+	map[string]interface{}{"name": "token", "json": "token"},
+	map[string]interface{}{"name": "user_id", "json": "$.user.id"},
+	map[string]interface{}{"name": "request_id", "header": "X-Request-Id"},
+}
+
+func runSteps(t *testing.T, serverURL string, steps []interface{}) *beat.Event {
+	config, err := conf.NewConfigFrom(map[string]interface{}{
+		"urls":        serverURL,
+		"timeout":     "1s",
+		"check.steps": steps,
+	})
+	require.NoError(t, err)
+
+	p, err := create("steps", config)
+	require.NoError(t, err)
+
+	sched := schedule.MustParse("@every 1s")
+	job := wrappers.WrapCommon(p.Jobs, stdfields.StdMonitorFields{ID: "steps", Type: "http", Schedule: sched, Timeout: 1}, nil)[0]
+
+	event := &beat.Event{}
+	_, err = job(event)
+	require.NoError(t, err)
+
+	return event
+}
+
+func TestSteps(t *testing.T) {
+	server := httptest.NewServer(loginHandler())
+	defer server.Close()
+
+	event := runSteps(t, server.URL, loginSteps("/api/users/{{user_id}}", loginExtract))
+
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks("", "up", "http"),
+			hbtest.SummaryStateChecks(1, 0),
+			lookslike.MustCompile(map[string]interface{}{
+				"url.full":                  server.URL,
+				"http.response.status_code": 200,
+				"http.response.body.bytes":  len("user heartbeat"),
+				"http.steps": []interface{}{
+					map[string]interface{}{
+						"name":        "login",
+						"url":         server.URL + "/login",
+						"status_code": 200,
+						"rtt.total":   isdef.KeyPresent,
+					},
+					map[string]interface{}{
+						"name":        "api",
+						"url":         server.URL + "/api/users/42",
+						"status_code": 200,
+						"rtt.total":   isdef.KeyPresent,
+					},
+				},
+			}),
+		),
+		event.Fields,
+	)
+}
+
+func TestStepsFailingStep(t *testing.T) {
+	server := httptest.NewServer(loginHandler())
+	defer server.Close()
+
+	event := runSteps(t, server.URL, loginSteps("/api/users/7", loginExtract))
+
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks("", "down", "http"),
+			hbtest.SummaryStateChecks(0, 1),
+			hbtest.ECSErrCodeChecks("BAD_HTTP_STATUS", "step 'api': Bad HTTP status Not Found encountered"),
+			lookslike.MustCompile(map[string]interface{}{
+				"http.response.status_code": 404,
+				"http.steps": []interface{}{
+					map[string]interface{}{
+						"name":        "login",
+						"status_code": 200,
+						"error":       isdef.KeyMissing,
+					},
+					map[string]interface{}{
+						"name":          "api",
+						"status_code":   404,
+						"error.message": "Bad HTTP status Not Found encountered",
+					},
+				},
+			}),
+		),
+		event.Fields,
+	)
+}
+
+func TestStepsFailingExtraction(t *testing.T) {
+	server := httptest.NewServer(loginHandler())
+	defer server.Close()
+
+	extract := []interface{}{
+		map[string]interface{}{"name": "token", "json": "access_token"},
+		map[string]interface{}{"name": "request_id", "header": "X-Request-Id"},
+	}
+	event := runSteps(t, server.URL, loginSteps("/api/users/42", extract))
+
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks("", "down", "http"),
+			hbtest.SummaryStateChecks(0, 1),
+			hbtest.ErrorChecks("step 'login': could not extract variable 'token'", "validate"),
+			lookslike.MustCompile(map[string]interface{}{
+				"http.response.status_code": 200,
+				"http.steps": []interface{}{
+					map[string]interface{}{"name": "login"},
+				},
+			}),
+		),
+		event.Fields,
+	)
+}
+
+func TestStepsExtractRegex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = io.WriteString(w, `<a href="/items/1234">next</a>`)
+		case "/items/1234":
+			_, _ = fmt.Fprintf(w, "item %s", r.URL.Query().Get("q"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	steps := []interface{}{
+		map[string]interface{}{
+			"extract": []interface{}{
+				map[string]interface{}{"name": "item", "regex": `href="(/items/\d+)"`},
+				map[string]interface{}{"name": "text", "regex": `next`},
+			},
+		},
+		map[string]interface{}{
+			"request":  map[string]interface{}{"url": "{{item}}?q={{text}}"},
+			"response": map[string]interface{}{"body": "item next"},
+		},
+	}
+	event := runSteps(t, server.URL, steps)
+
+	testslike.Test(
+		t,
+		lookslike.Compose(
+			hbtest.BaseChecks("", "up", "http"),
+			lookslike.MustCompile(map[string]interface{}{
+				"http.steps": []interface{}{
+					map[string]interface{}{"name": "1", "url": server.URL},
+					map[string]interface{}{"name": "2", "url": server.URL + "/items/1234?q=next"},
+				},
+			}),
+		),
+		event.Fields,
+	)
+}
+
+func TestStepsConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		err    string
+	}{
+		{
+			"undefined variable",
+			map[string]interface{}{
+				"check.steps": []interface{}{
+					map[string]interface{}{"request": map[string]interface{}{"url": "/users/{{id}}"}},
+				},
+			},
+			"step '1' uses variable 'id' which is not extracted by a previous step",
+		},
+		{
+			"variable extracted by the same step",
+			map[string]interface{}{
+				"check.steps": []interface{}{
+					map[string]interface{}{
+						"name":    "users",
+						"request": map[string]interface{}{"url": "/users/{{id}}"},
+						"extract": []interface{}{map[string]interface{}{"name": "id", "header": "X-Id"}},
+					},
+				},
+			},
+			"step 'users' uses variable 'id'",
+		},
+		{
+			"combined with check.response",
+			map[string]interface{}{
+				"check.response.status": []int{200},
+				"check.steps":           []interface{}{map[string]interface{}{}},
+			},
+			"'check.steps' cannot be combined",
+		},
+		{
+			"extract without source",
+			map[string]interface{}{
+				"check.steps": []interface{}{
+					map[string]interface{}{"extract": []interface{}{map[string]interface{}{"name": "id"}}},
+				},
+			},
+			"exactly one of 'json', 'header' or 'regex' must be specified for variable 'id'",
+		},
+		{
+			"invalid variable name",
+			map[string]interface{}{
+				"check.steps": []interface{}{
+					map[string]interface{}{"extract": []interface{}{map[string]interface{}{"name": "a b", "header": "X-Id"}}},
+				},
+			},
+			"invalid variable name 'a b'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.config["urls"] = "http://localhost"
+			config, err := conf.NewConfigFrom(test.config)
+			require.NoError(t, err)
+
+			_, err = create("steps", config)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
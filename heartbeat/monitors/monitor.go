@@ -141,7 +141,7 @@ func newMonitorUnsafe(
 		config:              config,
 		stats:               pluginFactory.Stats,
 		state:               MON_INIT,
-		monitorStateTracker: monitorstate.NewTracker(stateLoader, false),
+		monitorStateTracker: monitorstate.NewTracker(stateLoader, standardFields.FlapDamping.Enabled),
 	}
 
 	if m.stdFields.ID == "" {
@@ -31,6 +31,16 @@ type ServiceFields struct {
 	Name string `config:"name"`
 }
 
+// FlapDampingFields configures how quick changes between up and down are
+// reported. When enabled, a monitor whose status changes before Threshold
+// checks with the same status were performed enters the flapping state
+// instead of starting a new state, and leaves it once Threshold consecutive
+// checks have the same status.
+type FlapDampingFields struct {
+	Enabled   bool `config:"enabled"`
+	Threshold int  `config:"threshold" validate:"min=2"`
+}
+
 // StdMonitorFields represents the generic configuration options around a monitor plugin.
 type StdMonitorFields struct {
 	ID                 string              `config:"id"`
@@ -44,6 +54,10 @@ type StdMonitorFields struct {
 	Origin             string        `config:"origin"`
 	LegacyServiceName  string        `config:"service_name"`
 	MaxAttempts        uint16        `config:"max_attempts"`
+	// RetryDelay is the delay before the first retry of a failed attempt,
+	// it is doubled for each further retry.
+	RetryDelay  time.Duration     `config:"retry_delay"`
+	FlapDamping FlapDampingFields `config:"flap_damping"`
 	// Used by zip_url and local monitors
 	// kibana originating monitors only run one journey at a time
 	// and just use the `fields` syntax / manually set monitor IDs
@@ -61,12 +75,22 @@ type StdMonitorFields struct {
 }
 
 func ConfigToStdMonitorFields(conf *config.C) (StdMonitorFields, error) {
-	sFields := StdMonitorFields{Enabled: true, MaxAttempts: 1}
+	sFields := StdMonitorFields{
+		Enabled:     true,
+		MaxAttempts: 1,
+		RetryDelay:  time.Second,
+		// Same as monitorstate.FlappingThreshold
+		FlapDamping: FlapDampingFields{Threshold: 3},
+	}
 
 	if err := conf.Unpack(&sFields); err != nil {
 		return sFields, fmt.Errorf("error unpacking monitor plugin config: %w", err)
 	}
 
+	if sFields.RetryDelay <= 0 {
+		return sFields, fmt.Errorf("retry_delay must be positive, got %s", sFields.RetryDelay)
+	}
+
 	// Use `service_name` if `service.name` is unspecified
 	// `service_name` was only document in the 7.10.0 release.
 	if sFields.LegacyServiceName != "" {
@@ -20,6 +20,7 @@ package stdfields
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -65,3 +66,42 @@ func TestLegacyServiceNameConfig(t *testing.T) {
 	}
 
 }
+
+func TestRetryAndFlapDampingConfig(t *testing.T) {
+	base := mapstr.M{
+		"type":     "http",
+		"id":       "myId",
+		"schedule": "@every 1s",
+	}
+
+	c, err := conf.NewConfigFrom(base)
+	require.NoError(t, err)
+	f, err := ConfigToStdMonitorFields(c)
+	require.NoError(t, err)
+	require.Equal(t, time.Second, f.RetryDelay)
+	require.Equal(t, FlapDampingFields{Enabled: false, Threshold: 3}, f.FlapDamping)
+
+	custom := base.Clone()
+	custom["retry_delay"] = "5s"
+	custom["flap_damping"] = mapstr.M{"enabled": true, "threshold": 5}
+	c, err = conf.NewConfigFrom(custom)
+	require.NoError(t, err)
+	f, err = ConfigToStdMonitorFields(c)
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, f.RetryDelay)
+	require.Equal(t, FlapDampingFields{Enabled: true, Threshold: 5}, f.FlapDamping)
+
+	for name, invalid := range map[string]mapstr.M{
+		"zero retry delay":    {"retry_delay": "0s"},
+		"threshold too small": {"flap_damping.threshold": 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cm := base.Clone()
+			cm.DeepUpdate(invalid)
+			c, err := conf.NewConfigFrom(cm)
+			require.NoError(t, err)
+			_, err = ConfigToStdMonitorFields(c)
+			require.Error(t, err)
+		})
+	}
+}
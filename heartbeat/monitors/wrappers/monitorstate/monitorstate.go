@@ -29,8 +29,17 @@ import (
 // must occur for us to end a flapping state. FlappingThreshold-1 is the number
 // of consecutive checks that is insufficient to start a new state, but rather to
 // keep the current state and turn it into a flapping state.
+// It is the default of the flap_damping.threshold monitor option.
 const FlappingThreshold = 3
 
+// flappingThreshold returns the flapping threshold configured for a monitor.
+func flappingThreshold(sf stdfields.StdMonitorFields) int {
+	if sf.FlapDamping.Threshold > 0 {
+		return sf.FlapDamping.Threshold
+	}
+	return FlappingThreshold
+}
+
 type StateStatus string
 
 const (
@@ -95,14 +104,14 @@ func (s *State) incrementCounters(status StateStatus) {
 }
 
 // truncate flap history to be at most as many items as the threshold indicates, minus one
-func (s *State) truncateFlapHistory() {
+func (s *State) truncateFlapHistory(threshold int) {
 	endIdx := len(s.FlapHistory)
 	if endIdx <= 0 {
 		return // flap history is empty
 	}
 	// truncate to one less than the threshold since our later calculations
 	// an item that would stabilize the history at the threshold would start a new state
-	startIdx := endIdx - (FlappingThreshold - 1)
+	startIdx := endIdx - (threshold - 1)
 	if startIdx <= 0 {
 		return
 	}
@@ -114,8 +123,9 @@ func (s *State) truncateFlapHistory() {
 // if the state ends it actually swaps out the full value the state points to
 // and sets state.Ends.
 func (s *State) recordCheck(sf stdfields.StdMonitorFields, newStatus StateStatus, isFinalAttempt bool) {
+	threshold := flappingThreshold(sf)
 	if s.Status == StatusFlapping {
-		s.truncateFlapHistory()
+		s.truncateFlapHistory(threshold)
 
 		// Check if all statuses in flap history are identical, including the new status
 		hasStabilized := true
@@ -138,7 +148,7 @@ func (s *State) recordCheck(sf stdfields.StdMonitorFields, newStatus StateStatus
 	} else if s.Status == newStatus || !isFinalAttempt {
 		// The state is stable, no changes needed
 		s.incrementCounters(newStatus)
-	} else if s.Checks < FlappingThreshold && s.flappingEnabled {
+	} else if s.Checks < threshold && s.flappingEnabled {
 		// The state changed too quickly, we're now flapping
 		s.incrementCounters(newStatus)
 		s.Status = StatusFlapping
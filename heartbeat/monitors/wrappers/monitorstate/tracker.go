@@ -131,6 +131,8 @@ func (t *Tracker) GetCurrentState(sf stdfields.StdMonitorFields, rc RetryConfig)
 	}
 
 	if loadedState != nil {
+		// flapping is a setting of the tracker, not part of the stored state
+		loadedState.flappingEnabled = t.flappingEnabled
 		t.states[sf.ID] = loadedState
 	}
 	// Return what we found, even if nil
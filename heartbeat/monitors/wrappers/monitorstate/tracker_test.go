@@ -70,6 +70,36 @@ func TestTrackerRecordFlappingDisabled(t *testing.T) {
 	requireMSStatusCount(t, ms, StatusDown, 1)
 }
 
+func TestTrackerRecordFlappingThreshold(t *testing.T) {
+	sf := TestSf
+	sf.FlapDamping.Threshold = 5
+
+	mst := NewTracker(NilStateLoader, true)
+	_ = mst.RecordStatus(sf, StatusUp, true)
+	_ = mst.RecordStatus(sf, StatusDown, true)
+	ms := mst.RecordStatus(sf, StatusUp, true)
+	require.Equal(t, StatusFlapping, ms.Status)
+
+	// Stabilizing takes as many consecutive checks as the threshold
+	for i := 0; i < sf.FlapDamping.Threshold-1; i++ {
+		ms = mst.RecordStatus(sf, StatusDown, true)
+		require.Equal(t, StatusFlapping, ms.Status)
+	}
+	ms = mst.RecordStatus(sf, StatusDown, true)
+	require.Equal(t, StatusDown, ms.Status)
+	requireMSStatusCount(t, ms, StatusDown, 1)
+}
+
+func TestTrackerFlappingLoadedState(t *testing.T) {
+	loader := func(stdfields.StdMonitorFields) (*State, error) {
+		return &State{ID: "loaded", Status: StatusUp, Checks: 1, Up: 1}, nil
+	}
+	mst := NewTracker(loader, true)
+
+	ms := mst.RecordStatus(TestSf, StatusDown, true)
+	require.Equal(t, StatusFlapping, ms.Status)
+}
+
 func TestAtomicStateLoader(t *testing.T) {
 	stateA := &State{ID: "A"}
 	stateB := &State{ID: "B"}
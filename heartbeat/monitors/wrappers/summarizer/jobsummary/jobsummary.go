@@ -32,6 +32,17 @@ type JobSummary struct {
 	Down         uint16                   `json:"down"`
 	Status       monitorstate.StateStatus `json:"status"`
 	RetryGroup   string                   `json:"retry_group"`
+	// PreviousAttempts summarizes the attempts retried before this one.
+	PreviousAttempts []AttemptSummary `json:"previous_attempts,omitempty"`
+}
+
+// AttemptSummary is the summary of an attempt that was retried.
+type AttemptSummary struct {
+	Attempt uint16                   `json:"attempt"`
+	Status  monitorstate.StateStatus `json:"status"`
+	Up      uint16                   `json:"up"`
+	Down    uint16                   `json:"down"`
+	Error   string                   `json:"error,omitempty"`
 }
 
 func NewJobSummary(attempt uint16, maxAttempts uint16, retryGroup string) *JobSummary {
@@ -48,8 +59,18 @@ func NewJobSummary(attempt uint16, maxAttempts uint16, retryGroup string) *JobSu
 
 // BumpAttempt swaps the JobSummary object's pointer for a new job summary
 // that is a clone of the current one but with the Attempt field incremented.
-func (js *JobSummary) BumpAttempt() {
+// The current attempt, which failed with err, is added to PreviousAttempts.
+func (js *JobSummary) BumpAttempt(err error) {
+	attempt := AttemptSummary{Attempt: js.Attempt, Status: js.Status, Up: js.Up, Down: js.Down}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	// Copy the slice since it's shared with the summaries already emitted
+	n := len(js.PreviousAttempts)
+	previous := append(js.PreviousAttempts[:n:n], attempt)
+
 	*js = *NewJobSummary(js.Attempt+1, js.MaxAttempts, js.RetryGroup)
+	js.PreviousAttempts = previous
 }
 
 func (js *JobSummary) String() string {
@@ -122,6 +122,8 @@ type commonSSP struct {
 	stateTracker *monitorstate.Tracker
 	sf           stdfields.StdMonitorFields
 	checkGroup   string
+	// attemptErr is the first error of the current attempt
+	attemptErr error
 }
 
 func newCommonSSP(stateTracker *monitorstate.Tracker, sf stdfields.StdMonitorFields) *commonSSP {
@@ -139,6 +141,9 @@ func newCommonSSP(stateTracker *monitorstate.Tracker, sf stdfields.StdMonitorFie
 }
 
 func (ssp *commonSSP) BeforeEach(event *beat.Event, err error) {
+	if err != nil && ssp.attemptErr == nil {
+		ssp.attemptErr = err
+	}
 	_, _ = event.PutValue("monitor.check_group", fmt.Sprintf("%s-%d", ssp.checkGroup, ssp.js.Attempt))
 }
 
@@ -154,12 +159,12 @@ func (ssp *commonSSP) BeforeSummary(event *beat.Event) BeforeSummaryActions {
 	lastStatus := ssp.stateTracker.GetCurrentStatus(ssp.sf)
 
 	curCheckDown := ssp.js.Status == monitorstate.StatusDown
-	lastStateUpOrEmpty := lastStatus == monitorstate.StatusUp || lastStatus == monitorstate.StatusEmpty
+	lastStateNotDown := lastStatus != monitorstate.StatusDown
 	hasAttemptsRemaining := ssp.js.Attempt < ssp.js.MaxAttempts
 
 	// retry if...
 	retry := curCheckDown && // the current check is down
-		lastStateUpOrEmpty && // we were previously up or had no previous state, if we were previously down we just check once
+		lastStateNotDown && // we were previously up, flapping or had no previous state, if we were previously down we just check once
 		hasAttemptsRemaining // and we are configured to actually make multiple attempts
 	// if we aren't retrying this is the final attempt
 	ssp.js.FinalAttempt = !retry
@@ -189,5 +194,6 @@ func (ssp *commonSSP) BeforeSummary(event *beat.Event) BeforeSummaryActions {
 
 func (ssp *commonSSP) BeforeRetry() {
 	// mutate the js into the state for the next attempt
-	ssp.js.BumpAttempt()
+	ssp.js.BumpAttempt(ssp.attemptErr)
+	ssp.attemptErr = nil
 }
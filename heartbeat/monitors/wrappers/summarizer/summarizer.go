@@ -38,6 +38,7 @@ type Summarizer struct {
 	sf             stdfields.StdMonitorFields
 	mst            *monitorstate.Tracker
 	retryDelay     time.Duration
+	retries        uint16
 	plugins        []SummarizerPlugin
 	startedAt      time.Time
 }
@@ -83,13 +84,34 @@ func NewSummarizer(rootJob jobs.Job, sf stdfields.StdMonitorFields, mst *monitor
 		mtx:            &sync.Mutex{},
 		mst:            mst,
 		sf:             sf,
-		retryDelay:     time.Second,
+		retryDelay:     sf.RetryDelay,
 		startedAt:      time.Now(),
 	}
+	if s.retryDelay <= 0 {
+		s.retryDelay = time.Second
+	}
 	s.setupPlugins()
 	return s
 }
 
+// maxRetryDelay caps the growth of the delay between retries.
+const maxRetryDelay = 30 * time.Second
+
+// nextRetryDelay returns the delay before the next retry. The configured
+// delay is doubled for each retry already performed, so that failures lasting
+// longer than a few seconds are still confirmed by the last attempts.
+func (s *Summarizer) nextRetryDelay() time.Duration {
+	delay := s.retryDelay
+	for i := uint16(0); i < s.retries && delay < maxRetryDelay; i++ {
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+	s.retries++
+	return delay
+}
+
 func (s *Summarizer) setupPlugins() {
 	// ssp must appear before Err plugin since
 	// it intercepts errors
@@ -152,13 +174,14 @@ func (s *Summarizer) Wrap(j jobs.Job) jobs.Job {
 				// Bump the job summary for the next attempt
 				s.contsRemaining = 1
 
-				// Delay retries by 1s for two reasons:
+				// Delay retries (by 1s by default) for two reasons:
 				// 1. Since ES timestamps are millisecond resolution they can happen so fast
 				//    that it's hard to tell the sequence in which jobs executed apart in our
 				//    kibana queries
 				// 2. If the site error is very short 1s gives it a tiny bit of time to recover
+				delay := s.nextRetryDelay()
 				delayedRootJob := func(event *beat.Event) ([]jobs.Job, error) {
-					time.Sleep(s.retryDelay)
+					time.Sleep(delay)
 					for _, p := range s.plugins {
 						p.BeforeRetry()
 					}
@@ -376,6 +376,90 @@ func TestRetryLightweightMonitorDuration(t *testing.T) {
 	require.GreaterOrEqual(t, look.RTTMS(retryElapsed), rcvdDuration)
 }
 
+func TestSummarizerPreviousAttempts(t *testing.T) {
+	t.Parallel()
+
+	tracker := monitorstate.NewTracker(monitorstate.NilStateLoader, false)
+	sf := stdfields.StdMonitorFields{ID: "testmon", Name: "testmon", Type: "http", MaxAttempts: uint16(3)}
+	// Retries only happen when the monitor wasn't down already
+	_ = tracker.RecordStatus(sf, monitorstate.StatusUp, true)
+
+	attempt := 0
+	job := func(event *beat.Event) (j []jobs.Job, retErr error) {
+		attempt++
+		event.Fields = mapstr.M{
+			"monitor": mapstr.M{
+				"id":     "test",
+				"status": string(monitorstate.StatusDown),
+			},
+		}
+		return nil, fmt.Errorf("attempt %d failed", attempt)
+	}
+
+	s := NewSummarizer(job, sf, tracker)
+	// Shorten retry delay to make tests run faster
+	s.retryDelay = 2 * time.Millisecond
+	events, _ := jobs.ExecJobAndConts(t, s.Wrap(job))
+	require.Len(t, events, 3)
+
+	var summaries []*jobsummary.JobSummary
+	for _, event := range events {
+		summaryIface, _ := event.GetValue("summary")
+		summaries = append(summaries, summaryIface.(*jobsummary.JobSummary))
+	}
+
+	require.Empty(t, summaries[0].PreviousAttempts)
+	require.Equal(t, []jobsummary.AttemptSummary{
+		{Attempt: 1, Status: monitorstate.StatusDown, Down: 1, Error: "attempt 1 failed"},
+	}, summaries[1].PreviousAttempts)
+	require.Equal(t, []jobsummary.AttemptSummary{
+		{Attempt: 1, Status: monitorstate.StatusDown, Down: 1, Error: "attempt 1 failed"},
+		{Attempt: 2, Status: monitorstate.StatusDown, Down: 1, Error: "attempt 2 failed"},
+	}, summaries[2].PreviousAttempts)
+	require.True(t, summaries[2].FinalAttempt)
+}
+
+func TestSummarizerRetryOnFlapping(t *testing.T) {
+	t.Parallel()
+
+	tracker := monitorstate.NewTracker(monitorstate.NilStateLoader, true)
+	sf := stdfields.StdMonitorFields{ID: "testmon", Name: "testmon", Type: "http", MaxAttempts: uint16(2)}
+	// a quick change from up to down makes the monitor flap
+	_ = tracker.RecordStatus(sf, monitorstate.StatusUp, true)
+	_ = tracker.RecordStatus(sf, monitorstate.StatusDown, true)
+	require.Equal(t, monitorstate.StatusFlapping, tracker.GetCurrentStatus(sf))
+
+	job := func(event *beat.Event) (j []jobs.Job, retErr error) {
+		event.Fields = mapstr.M{
+			"monitor": mapstr.M{
+				"id":     "test",
+				"status": string(monitorstate.StatusDown),
+			},
+		}
+		return nil, fmt.Errorf("dummyerr")
+	}
+
+	s := NewSummarizer(job, sf, tracker)
+	s.retryDelay = 2 * time.Millisecond
+	events, _ := jobs.ExecJobAndConts(t, s.Wrap(job))
+	// A down check of a flapping monitor is confirmed with a retry
+	require.Len(t, events, 2)
+}
+
+func TestNextRetryDelay(t *testing.T) {
+	s := NewSummarizer(nil, stdfields.StdMonitorFields{RetryDelay: 5 * time.Second}, nil)
+
+	var delays []time.Duration
+	for i := 0; i < 5; i++ {
+		delays = append(delays, s.nextRetryDelay())
+	}
+	require.Equal(t, []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, maxRetryDelay, maxRetryDelay}, delays)
+
+	// Defaults to 1s
+	s = NewSummarizer(nil, stdfields.StdMonitorFields{}, nil)
+	require.Equal(t, time.Second, s.nextRetryDelay())
+}
+
 type MockPlugin struct {
 	eachEvent       func(e *beat.Event, err error)
 	beforeSummary   func(e *beat.Event)
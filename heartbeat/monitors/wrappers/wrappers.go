@@ -37,7 +37,7 @@ import (
 
 // WrapCommon applies the common wrappers that all monitor jobs get.
 func WrapCommon(js []jobs.Job, stdMonFields stdfields.StdMonitorFields, stateLoader monitorstate.StateLoader) []jobs.Job {
-	mst := monitorstate.NewTracker(stateLoader, false)
+	mst := monitorstate.NewTracker(stateLoader, stdMonFields.FlapDamping.Enabled)
 	var wrapped []jobs.Job
 	if stdMonFields.Type != "browser" || stdMonFields.BadConfig {
 		wrapped = WrapLightweight(js, stdMonFields, mst)
@@ -56,7 +56,7 @@ func WrapCommon(js []jobs.Job, stdMonFields stdfields.StdMonitorFields, stateLoa
 	return wrapped
 }
 
-// WrapLightweight applies to http/tcp/icmp, everything but journeys involving node
+// WrapLightweight applies to http/tcp/icmp/grpc, everything but journeys involving node
 func WrapLightweight(js []jobs.Job, stdMonFields stdfields.StdMonitorFields, mst *monitorstate.Tracker) []jobs.Job {
 	return jobs.WrapAll(
 		js,
@@ -17,7 +17,11 @@
 
 package api
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"strings"
+)
 
 // Config is the configuration for the API endpoint.
 type Config struct {
@@ -26,6 +30,22 @@ type Config struct {
 	Port               int    `config:"port"`
 	User               string `config:"named_pipe.user"`
 	SecurityDescriptor string `config:"named_pipe.security_descriptor"`
+
+	Prometheus PrometheusConfig `config:"prometheus"`
+}
+
+// PrometheusConfig is the configuration of the Prometheus metrics endpoint.
+type PrometheusConfig struct {
+	Enabled bool   `config:"enabled"`
+	Path    string `config:"path"`
+}
+
+// Validate checks the Prometheus endpoint path.
+func (c *PrometheusConfig) Validate() error {
+	if !strings.HasPrefix(c.Path, "/") || c.Path == "/" {
+		return fmt.Errorf("invalid prometheus path '%v', must start with '/' and must not be the root path", c.Path)
+	}
+	return nil
 }
 
 // DefaultConfig is the default configuration used by the API endpoint.
@@ -33,6 +53,10 @@ var DefaultConfig = Config{
 	Enabled: false,
 	Host:    "localhost",
 	Port:    5066,
+	Prometheus: PrometheusConfig{
+		Enabled: false,
+		Path:    "/metrics",
+	},
 }
 
 // File mode for the socket file, owner of the process can do everything, member of the group can read.
@@ -0,0 +1,226 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
+	"github.com/elastic/beats/v7/libbeat/monitoring/metrictype"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+const (
+	prometheusPrefix      = "beat_"
+	prometheusInputPrefix = "beat_input_"
+)
+
+// infoLabels are the info registry values exposed as labels of the
+// beat_info metric.
+var infoLabels = []string{"beat", "name", "version", "uuid"}
+
+// makePrometheusHandler returns a handler exposing the numeric values of
+// the stats and inputs registries in the Prometheus exposition format.
+// Metric names are derived from the registry keys, for example
+// libbeat.pipeline.events.total becomes beat_libbeat_pipeline_events_total.
+// Per-input metrics are prefixed with beat_input_ and labeled with the
+// input id and type.
+func makePrometheusHandler(info, stats, inputs *monitoring.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		families := prometheusFamilies{}
+		families.addInfo(info)
+		families.addRegistry(stats)
+		families.addInputs(inputs)
+
+		format := expfmt.Negotiate(r.Header)
+		w.Header().Set("Content-Type", string(format))
+
+		enc := expfmt.NewEncoder(w, format)
+		for _, mf := range families.sorted() {
+			if err := enc.Encode(mf); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		if closer, ok := enc.(expfmt.Closer); ok {
+			_ = closer.Close()
+		}
+	}
+}
+
+type prometheusFamilies map[string]*dto.MetricFamily
+
+func (f prometheusFamilies) addInfo(registry *monitoring.Registry) {
+	if registry == nil {
+		return
+	}
+
+	snapshot := monitoring.CollectFlatSnapshot(registry, monitoring.Full, false)
+	var labels []*dto.LabelPair
+	for _, name := range infoLabels {
+		if value, found := snapshot.Strings[name]; found {
+			labels = append(labels, labelPair(name, value))
+		}
+	}
+	f.add(prometheusPrefix+"info", dto.MetricType_GAUGE, labels, 1)
+}
+
+func (f prometheusFamilies) addRegistry(registry *monitoring.Registry) {
+	if registry == nil {
+		return
+	}
+
+	snapshot := monitoring.CollectFlatSnapshot(registry, monitoring.Full, false)
+	for key, value := range snapshot.Ints {
+		f.addValue(prometheusPrefix, key, nil, float64(value))
+	}
+	for key, value := range snapshot.Floats {
+		f.addValue(prometheusPrefix, key, nil, value)
+	}
+}
+
+func (f prometheusFamilies) addInputs(registry *monitoring.Registry) {
+	if registry == nil {
+		return
+	}
+
+	snapshot := monitoring.CollectStructSnapshot(registry, monitoring.Full, false)
+	f.addInputTables(snapshot)
+}
+
+// addInputTables adds the metrics of all input tables found in the given
+// snapshot, descending into nested inputs.
+func (f prometheusFamilies) addInputTables(snapshot map[string]any) {
+	for _, value := range snapshot {
+		table, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		id, _ := table["id"].(string)
+		input, _ := table["input"].(string)
+		if id == "" || input == "" {
+			continue
+		}
+		if input == inputmon.InputNested {
+			f.addInputTables(table)
+			continue
+		}
+
+		labels := []*dto.LabelPair{labelPair("id", id), labelPair("input", input)}
+		f.addInputValues("", table, labels)
+	}
+}
+
+func (f prometheusFamilies) addInputValues(prefix string, values map[string]any, labels []*dto.LabelPair) {
+	for key, value := range values {
+		switch v := value.(type) {
+		case int64:
+			f.addValue(prometheusInputPrefix, prefix+key, labels, float64(v))
+		case float64:
+			f.addValue(prometheusInputPrefix, prefix+key, labels, v)
+		case map[string]any:
+			f.addInputValues(prefix+key+".", v, labels)
+		}
+	}
+}
+
+func (f prometheusFamilies) addValue(prefix, key string, labels []*dto.LabelPair, value float64) {
+	if math.IsNaN(value) {
+		return
+	}
+
+	name := prometheusName(key)
+	if !strings.HasPrefix(name, prefix) {
+		name = prefix + name
+	}
+
+	typ := dto.MetricType_COUNTER
+	if metrictype.IsGauge(key) {
+		typ = dto.MetricType_GAUGE
+	}
+	f.add(name, typ, labels, value)
+}
+
+func (f prometheusFamilies) add(name string, typ dto.MetricType, labels []*dto.LabelPair, value float64) {
+	mf, found := f[name]
+	if !found {
+		mf = &dto.MetricFamily{Name: &name, Type: typ.Enum()}
+		f[name] = mf
+	}
+
+	m := &dto.Metric{Label: labels}
+	switch mf.GetType() {
+	case dto.MetricType_COUNTER:
+		m.Counter = &dto.Counter{Value: &value}
+	default:
+		m.Gauge = &dto.Gauge{Value: &value}
+	}
+	mf.Metric = append(mf.Metric, m)
+}
+
+func (f prometheusFamilies) sorted() []*dto.MetricFamily {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	families := make([]*dto.MetricFamily, 0, len(names))
+	for _, name := range names {
+		mf := f[name]
+		sort.Slice(mf.Metric, func(i, j int) bool {
+			return labelsKey(mf.Metric[i].Label) < labelsKey(mf.Metric[j].Label)
+		})
+		families = append(families, mf)
+	}
+	return families
+}
+
+// prometheusName converts a registry key into a valid Prometheus metric
+// name by replacing all invalid characters with underscores.
+func prometheusName(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
+
+func labelPair(name, value string) *dto.LabelPair {
+	return &dto.LabelPair{Name: &name, Value: &value}
+}
+
+func labelsKey(labels []*dto.LabelPair) string {
+	var sb strings.Builder
+	for _, l := range labels {
+		sb.WriteString(l.GetName())
+		sb.WriteByte('=')
+		sb.WriteString(l.GetValue())
+		sb.WriteByte(',')
+	}
+	return sb.String()
+}
@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestPrometheusHandler(t *testing.T) {
+	info := monitoring.NewRegistry()
+	monitoring.NewString(info, "beat").Set("testbeat")
+	monitoring.NewString(info, "version").Set("9.0.0")
+
+	stats := monitoring.NewRegistry()
+	monitoring.NewInt(stats, "libbeat.pipeline.events.total").Set(42)
+	monitoring.NewInt(stats, "libbeat.pipeline.events.active").Set(3)
+	monitoring.NewUint(stats, "beat.memstats.rss").Set(1024)
+	monitoring.NewString(stats, "libbeat.output.type").Set("elasticsearch")
+
+	inputs := monitoring.NewRegistry()
+	reg, cancel := inputmon.NewInputRegistry("filestream", "my.input", inputs)
+	defer cancel()
+	monitoring.NewUint(reg, "events_processed_total").Set(7)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	resp := httptest.NewRecorder()
+	makePrometheusHandler(info, stats, inputs)(resp, req)
+
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "text/plain")
+
+	body := resp.Body.String()
+	assert.Contains(t, body, `beat_info{beat="testbeat",version="9.0.0"} 1`)
+	assert.Contains(t, body, "# TYPE beat_libbeat_pipeline_events_total counter\nbeat_libbeat_pipeline_events_total 42\n")
+	assert.Contains(t, body, "# TYPE beat_libbeat_pipeline_events_active gauge\nbeat_libbeat_pipeline_events_active 3\n")
+	assert.Contains(t, body, "# TYPE beat_memstats_rss gauge\nbeat_memstats_rss 1024\n")
+	assert.Contains(t, body, `beat_input_events_processed_total{id="my.input",input="filestream"} 7`)
+	assert.NotContains(t, body, "elasticsearch")
+}
+
+func TestPrometheusRoute(t *testing.T) {
+	logger := logptest.NewTestingLogger(t, "")
+
+	tests := map[string]struct {
+		config map[string]interface{}
+		path   string
+		status int
+	}{
+		"disabled by default": {
+			config: map[string]interface{}{},
+			path:   "/metrics",
+			status: http.StatusNotFound,
+		},
+		"enabled": {
+			config: map[string]interface{}{"prometheus.enabled": true},
+			path:   "/metrics",
+			status: http.StatusOK,
+		},
+		"custom path": {
+			config: map[string]interface{}{"prometheus.enabled": true, "prometheus.path": "/prometheus"},
+			path:   "/prometheus",
+			status: http.StatusOK,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.config["host"] = "localhost"
+			test.config["port"] = 0
+			s, err := NewWithDefaultRoutes(logger, config.MustNewConfigFrom(test.config),
+				monitoring.NewRegistry(), monitoring.NewRegistry(), monitoring.NewRegistry(), monitoring.NewRegistry())
+			require.NoError(t, err)
+			defer s.Stop()
+
+			resp := httptest.NewRecorder()
+			s.Router().ServeHTTP(resp, httptest.NewRequest(http.MethodGet, test.path, nil))
+			assert.Equal(t, test.status, resp.Code)
+		})
+	}
+}
+
+func TestPrometheusInvalidPath(t *testing.T) {
+	logger := logptest.NewTestingLogger(t, "")
+	for _, path := range []string{"/", "metrics"} {
+		_, err := New(logger, config.MustNewConfigFrom(map[string]interface{}{
+			"prometheus.enabled": true,
+			"prometheus.path":    path,
+		}))
+		assert.ErrorContains(t, err, "invalid prometheus path")
+	}
+}
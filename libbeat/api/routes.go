@@ -58,6 +58,13 @@ func NewWithDefaultRoutes(log *logp.Logger, config *config.C,
 		return nil, err
 	}
 
+	if api.config.Prometheus.Enabled {
+		err = api.AttachHandler(api.config.Prometheus.Path, makePrometheusHandler(info, stats, inputs))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return api, nil
 }
 
@@ -121,6 +121,11 @@ func (beat *Beat) GenerateUserAgent() {
 type BeatConfig struct {
 	// output/publishing related configurations
 	Output config.Namespace `config:"output"`
+
+	// Outputs configures multiple outputs events are published to. The
+	// first output is also set as Output, and is used for setup and
+	// monitoring.
+	Outputs []config.Namespace `config:"outputs"`
 }
 
 // OverwritePipelinesCallback can be used by the Beat to register Ingest pipeline loader
@@ -17,7 +17,10 @@
 
 package beat
 
-import "github.com/elastic/elastic-agent-libs/monitoring"
+import (
+	"github.com/elastic/beats/v7/libbeat/monitoring/health"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
 
 type Monitoring struct {
 	// Previously monitoring.GetNamespace("info")
@@ -31,6 +34,9 @@ type Monitoring struct {
 
 	// Previously monitoring.GetNamespace("dataset")
 	inputsRegistry *monitoring.Registry
+
+	// Health state reported by the inputs and modules.
+	health *health.Registry
 }
 
 // Returns a Monitoring struct that shadows the legacy global monitoring API,
@@ -44,6 +50,8 @@ func NewGlobalMonitoring() Monitoring {
 		infoRegistry:  monitoring.GetNamespace("info").GetRegistry(),
 
 		inputsRegistry: monitoring.GetNamespace("dataset").GetRegistry(),
+
+		health: health.NewRegistry(),
 	}
 }
 
@@ -58,6 +66,8 @@ func NewMonitoring() Monitoring {
 		infoRegistry:  monitoring.NewRegistry(),
 
 		inputsRegistry: monitoring.NewRegistry(),
+
+		health: health.NewRegistry(),
 	}
 }
 
@@ -86,3 +96,10 @@ func (m Monitoring) StatsRegistry() *monitoring.Registry {
 func (m Monitoring) InputsRegistry() *monitoring.Registry {
 	return m.inputsRegistry
 }
+
+// The health registry tracking the health state reported by the inputs and
+// modules of the Beat or Beat Receiver. It is published in the state
+// registry under "health".
+func (m Monitoring) Health() *health.Registry {
+	return m.health
+}
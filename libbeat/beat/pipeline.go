@@ -151,6 +151,15 @@ type Processor interface {
 	Run(in *Event) (event *Event, err error)
 }
 
+// BatchProcessor is implemented by processors that can share work between
+// the events published in one PublishAll call. RunBatch must give the same
+// results as calling Run on each event. The returned events and errors match
+// the input events by index.
+type BatchProcessor interface {
+	Processor
+	RunBatch(in []*Event) (events []*Event, errs []error)
+}
+
 // PublishMode enum sets some requirements on the client connection to the beats
 // publisher pipeline
 type PublishMode uint8
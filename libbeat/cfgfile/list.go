@@ -29,6 +29,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/diagnostics"
 	"github.com/elastic/beats/v7/libbeat/common/reload"
 	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/libbeat/monitoring/health"
 	"github.com/elastic/beats/v7/libbeat/publisher/pipetool"
 	"github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -41,18 +42,34 @@ type RunnerList struct {
 	factory  RunnerFactory
 	pipeline beat.PipelineConnector
 	logger   *logp.Logger
+
+	// health tracks the health state reported by the runners, reporters
+	// holds the health reporter of each runner.
+	health    *health.Registry
+	reporters map[uint64]*health.Reporter
 }
 
 // NewRunnerList builds and returns a RunnerList
 func NewRunnerList(name string, factory RunnerFactory, pipeline beat.PipelineConnector, logger *logp.Logger) *RunnerList {
 	return &RunnerList{
-		runners:  map[uint64]Runner{},
-		factory:  factory,
-		pipeline: pipeline,
-		logger:   logger.Named(name),
+		runners:   map[uint64]Runner{},
+		factory:   factory,
+		pipeline:  pipeline,
+		logger:    logger.Named(name),
+		reporters: map[uint64]*health.Reporter{},
 	}
 }
 
+// SetHealthRegistry sets the registry tracking the health of the runners
+// started by the list. The status updates of runners implementing
+// status.WithStatusReporter are reported to it, in addition to the status
+// reporter of the runner configuration.
+func (r *RunnerList) SetHealthRegistry(registry *health.Registry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.health = registry
+}
+
 // Runners returns a slice containing all
 // currently running runners
 func (r *RunnerList) Runners() []Runner {
@@ -114,6 +131,7 @@ func (r *RunnerList) Reload(configs []*reload.ConfigWithMeta) error {
 		wg.Add(1)
 		r.logger.Debugf("Stopping runner: %s", runner)
 		delete(r.runners, hash)
+		r.unregisterHealth(hash)
 		go func(runner Runner) {
 			defer wg.Done()
 			runner.Stop()
@@ -151,9 +169,15 @@ func (r *RunnerList) Reload(configs []*reload.ConfigWithMeta) error {
 
 		r.logger.Debugf("Starting runner: %s", runner)
 		r.runners[hash] = runner
-		if config.StatusReporter != nil {
-			if runnerWithStatus, ok := runner.(status.WithStatusReporter); ok {
-				runnerWithStatus.SetStatusReporter(config.StatusReporter)
+		if runnerWithStatus, ok := runner.(status.WithStatusReporter); ok {
+			statusReporter := config.StatusReporter
+			if r.health != nil {
+				reporter := NewHealthReporter(r.health, config.Config, runner)
+				r.reporters[hash] = reporter
+				statusReporter = withHealthReporter(reporter, statusReporter)
+			}
+			if statusReporter != nil {
+				runnerWithStatus.SetStatusReporter(statusReporter)
 			}
 		}
 
@@ -196,6 +220,7 @@ func (r *RunnerList) Stop() {
 		wg.Add(1)
 
 		delete(r.runners, hash)
+		r.unregisterHealth(hash)
 
 		// Stop modules in parallel
 		go func(h uint64, run Runner) {
@@ -226,6 +251,13 @@ func HashConfig(c *config.C) (uint64, error) {
 	return hashstructure.Hash(config, nil)
 }
 
+func (r *RunnerList) unregisterHealth(hash uint64) {
+	if reporter, found := r.reporters[hash]; found {
+		reporter.Unregister()
+		delete(r.reporters, hash)
+	}
+}
+
 func (r *RunnerList) copyRunnerList() map[uint64]Runner {
 	list := make(map[uint64]Runner, len(r.runners))
 	for k, v := range r.runners {
@@ -241,6 +273,48 @@ func createRunner(factory RunnerFactory, pipeline beat.PipelineConnector, cfg *r
 	return factory.Create(pipetool.WithDynamicFields(pipeline, cfg.Meta), c)
 }
 
+// NewHealthReporter registers the runner created from the given
+// configuration in the health registry. The component ID is the configured
+// ID, it defaults to the runner description. The component type is the
+// configured input type or module.
+func NewHealthReporter(registry *health.Registry, c *config.C, runner Runner) *health.Reporter {
+	var info struct {
+		ID     string `config:"id"`
+		Type   string `config:"type"`
+		Module string `config:"module"`
+	}
+	if c != nil {
+		_ = c.Unpack(&info)
+	}
+
+	id, typ := info.ID, info.Type
+	if typ == "" {
+		typ = info.Module
+	}
+	if id == "" {
+		id = runner.String()
+	}
+	return registry.NewReporter(id, typ)
+}
+
+// healthStatusReporter forwards the status updates to a health reporter and
+// to the next status reporter, if any.
+type healthStatusReporter struct {
+	health *health.Reporter
+	next   status.StatusReporter
+}
+
+func withHealthReporter(reporter *health.Reporter, next status.StatusReporter) status.StatusReporter {
+	return &healthStatusReporter{health: reporter, next: next}
+}
+
+func (r *healthStatusReporter) UpdateStatus(s status.Status, msg string) {
+	r.health.UpdateStatus(s, msg)
+	if r.next != nil {
+		r.next.UpdateStatus(s, msg)
+	}
+}
+
 type UnitError struct {
 	UnitID string
 	Err    error
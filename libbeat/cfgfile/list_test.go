@@ -27,6 +27,8 @@ import (
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/diagnostics"
 	"github.com/elastic/beats/v7/libbeat/common/reload"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/libbeat/monitoring/health"
 	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp/logptest"
@@ -336,6 +338,68 @@ func TestCreateRunnerAddsDynamicMeta(t *testing.T) {
 	}
 }
 
+type statusRunner struct {
+	runner
+	reporter status.StatusReporter
+}
+
+func (r *statusRunner) SetStatusReporter(reporter status.StatusReporter) {
+	r.reporter = reporter
+}
+
+type statusRecorder struct {
+	status status.Status
+	msg    string
+}
+
+func (r *statusRecorder) UpdateStatus(s status.Status, msg string) {
+	r.status = s
+	r.msg = msg
+}
+
+type statusRunnerFactory struct {
+	created []*statusRunner
+}
+
+func (f *statusRunnerFactory) Create(_ beat.PipelineConnector, _ *conf.C) (Runner, error) {
+	r := &statusRunner{}
+	f.created = append(f.created, r)
+	return r, nil
+}
+
+func (f *statusRunnerFactory) CheckConfig(_ *conf.C) error {
+	return nil
+}
+
+func TestHealthRegistry(t *testing.T) {
+	factory := &statusRunnerFactory{}
+	registry := health.NewRegistry()
+	list := NewRunnerList("", factory, nil, logptest.NewTestingLogger(t, ""))
+	list.SetHealthRegistry(registry)
+
+	agent := &statusRecorder{}
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{"id": "my-input", "type": "filestream"})
+	require.NoError(t, list.Reload([]*reload.ConfigWithMeta{{Config: cfg, StatusReporter: agent}}))
+	require.Len(t, factory.created, 1)
+
+	components := registry.Components()
+	require.Len(t, components, 1)
+	assert.Equal(t, "my-input", components[0].ID)
+	assert.Equal(t, "filestream", components[0].Type)
+	assert.Equal(t, health.Healthy, components[0].State)
+
+	factory.created[0].reporter.UpdateStatus(status.Degraded, "connection refused")
+	assert.Equal(t, health.Degraded, registry.State())
+	assert.Equal(t, "connection refused", registry.Components()[0].Reason)
+
+	// The status is still forwarded to the configured status reporter.
+	assert.Equal(t, status.Degraded, agent.status)
+	assert.Equal(t, "connection refused", agent.msg)
+
+	require.NoError(t, list.Reload(nil))
+	assert.Empty(t, registry.Components())
+}
+
 func createConfig(id int64) *reload.ConfigWithMeta {
 	c := conf.NewConfig()
 	_ = c.SetInt("id", -1, id)
@@ -27,6 +27,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/beats/v7/libbeat/common/reload"
+	"github.com/elastic/beats/v7/libbeat/monitoring/health"
 	"github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/monitoring"
@@ -100,6 +101,7 @@ type Reloader struct {
 	done     chan struct{}
 	wg       sync.WaitGroup
 	logger   *logp.Logger
+	health   *health.Registry
 }
 
 // NewReloader creates new Reloader instance for the given config
@@ -121,6 +123,12 @@ func NewReloader(logger *logp.Logger, pipeline beat.PipelineConnector, cfg *conf
 	}
 }
 
+// SetHealthRegistry sets the registry tracking the health of the runners
+// started by the reloader.
+func (rl *Reloader) SetHealthRegistry(registry *health.Registry) {
+	rl.health = registry
+}
+
 // Check configs are valid (only if reload is disabled)
 func (rl *Reloader) Check(runnerFactory RunnerFactory) error {
 	// If config reload is enabled we ignore errors (as they may be fixed afterwards)
@@ -163,6 +171,7 @@ func (rl *Reloader) Run(runnerFactory RunnerFactory) {
 	rl.logger.Info("Config reloader started")
 
 	list := NewRunnerList("reload", runnerFactory, rl.pipeline, rl.logger)
+	list.SetHealthRegistry(rl.health)
 
 	rl.wg.Add(1)
 	defer rl.wg.Done()
@@ -237,6 +246,7 @@ func (rl *Reloader) Run(runnerFactory RunnerFactory) {
 // Load loads configuration files once.
 func (rl *Reloader) Load(runnerFactory RunnerFactory) {
 	list := NewRunnerList("load", runnerFactory, rl.pipeline, rl.logger)
+	list.SetHealthRegistry(rl.health)
 
 	rl.wg.Add(1)
 	defer rl.wg.Done()
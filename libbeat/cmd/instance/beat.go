@@ -382,6 +382,9 @@ func (b *Beat) createBeater(bt beat.Creator) (beat.Beater, error) {
 		Processors:     b.processors,
 		InputQueueSize: b.InputQueueSize,
 	}
+	for _, out := range b.additionalOutputs() {
+		settings.AdditionalOutputs = append(settings.AdditionalOutputs, b.MakeOutputFactory(out))
+	}
 	publisher, err = pipeline.LoadWithSettings(b.Info, monitors, b.Config.Pipeline, outputFactory, settings)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing publisher: %w", err)
@@ -585,6 +588,9 @@ func (b *Beat) RegisterMetrics() {
 
 	// state.beat
 	monitoring.NewString(stateRegistry, "beat.name").Set(b.Info.Name)
+
+	// state.health
+	monitoring.NewFunc(stateRegistry, "health", b.Monitoring.Health().Report, monitoring.Report)
 }
 
 func (b *Beat) RegisterHostname(useFQDN bool) {
@@ -788,6 +794,9 @@ func (b *Beat) configure(settings Settings) error {
 	if err != nil {
 		return fmt.Errorf("error unpacking config data: %w", err)
 	}
+	if len(b.Config.Outputs) > 0 {
+		b.Config.Output = b.Config.Outputs[0]
+	}
 
 	b.Info.Logger, err = configure.LoggingWithTypedOutputsLocal(b.Info.Beat, b.Config.Logging, b.Config.EventLogging, logp.TypeKey, logp.EventType)
 	if err != nil {
@@ -1172,6 +1181,21 @@ func (b *Beat) MakeOutputFactory(
 	}
 }
 
+// additionalOutputs returns the enabled outputs configured under `outputs`
+// after the first one.
+func (b *Beat) additionalOutputs() []config.Namespace {
+	if len(b.Config.Outputs) < 2 {
+		return nil
+	}
+	var outs []config.Namespace
+	for _, out := range b.Config.Outputs[1:] {
+		if out.IsSet() && out.Config().Enabled() {
+			outs = append(outs, out)
+		}
+	}
+	return outs
+}
+
 func (b *Beat) reloadOutputOnCertChange(cfg config.Namespace) error {
 	logger := b.Info.Logger.Named("ssl.cert.reloader")
 	// Here the output is created and we have access to the Beat struct (with the manager)
@@ -1329,9 +1353,15 @@ func (b *Beat) setupMonitoring(settings Settings) (report.Reporter, error) {
 			return nil, err
 		}
 
+		detailed, err := report.IsDetailed(monitoringCfg)
+		if err != nil {
+			return nil, err
+		}
+
 		settings := report.Settings{
 			DefaultUsername: settings.Monitoring.DefaultUsername,
 			ClusterUUID:     monitoringClusterUUID,
+			Detailed:        detailed,
 		}
 		reporter, err := report.New(b.Info, b.Monitoring, settings, monitoringCfg, b.Config.Output)
 		if err != nil {
@@ -1549,9 +1579,35 @@ func PromoteOutputQueueSettings(b *Beat) error {
 }
 
 func (bc *beatConfig) Validate() error {
-	if bc.Output.IsSet() && bc.Output.Config().Enabled() {
+	output := bc.Output
+	if len(bc.Outputs) > 0 {
+		if bc.Output.IsSet() {
+			return fmt.Errorf("output and outputs settings defined, only one is allowed")
+		}
+		if bc.Management.Enabled() {
+			return fmt.Errorf("outputs is not supported when management is enabled")
+		}
+		for i, out := range bc.Outputs {
+			if !out.IsSet() {
+				return fmt.Errorf("outputs entry %d does not configure an output", i)
+			}
+		}
+		output = bc.Outputs[0]
+	}
+
+	if output.IsSet() && output.Config().Enabled() {
+		// The main output is never allowed to drop events, only the
+		// additional outputs are.
+		dropIfFull, err := outputs.LoadBackpressure(output.Config())
+		if err != nil {
+			return fmt.Errorf("invalid backpressure for output %v: %w", output.Name(), err)
+		}
+		if dropIfFull {
+			return fmt.Errorf("backpressure drop is not supported by the main output, only by the additional outputs")
+		}
+
 		outputPC := pipeline.Config{}
-		err := bc.Output.Config().Unpack(&outputPC)
+		err = output.Config().Unpack(&outputPC)
 		if err != nil {
 			return fmt.Errorf("error unpacking output queue settings: %w", err)
 		}
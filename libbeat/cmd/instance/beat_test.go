@@ -435,6 +435,103 @@ output:
 `),
 			expectValidationError: "",
 		},
+		"multipleOutputs": {
+			input: []byte(`
+name: mockbeat
+outputs:
+  - elasticsearch:
+      hosts:
+        - "localhost:9200"
+  - kafka:
+      hosts:
+        - "localhost:9092"
+      queue:
+        mem:
+          events: 8096
+`),
+			expectValidationError: "",
+		},
+		"outputAndOutputs": {
+			input: []byte(`
+name: mockbeat
+output:
+  elasticsearch:
+    hosts:
+      - "localhost:9200"
+outputs:
+  - kafka:
+      hosts:
+        - "localhost:9092"
+`),
+			expectValidationError: "output and outputs settings defined, only one is allowed accessing config",
+		},
+		"outputsTopLevelAndOutputLevelQueue": {
+			input: []byte(`
+name: mockbeat
+queue:
+  mem:
+    events: 2048
+outputs:
+  - elasticsearch:
+      hosts:
+        - "localhost:9200"
+      queue:
+        mem:
+          events: 8096
+`),
+			expectValidationError: "top level queue and output level queue settings defined, only one is allowed accessing config",
+		},
+		"mainOutputBackpressureDrop": {
+			input: []byte(`
+name: mockbeat
+output:
+  elasticsearch:
+    hosts:
+      - "localhost:9200"
+    backpressure: drop
+`),
+			expectValidationError: "backpressure drop is not supported by the main output, only by the additional outputs accessing config",
+		},
+		"outputsBackpressureDrop": {
+			input: []byte(`
+name: mockbeat
+outputs:
+  - elasticsearch:
+      hosts:
+        - "localhost:9200"
+  - kafka:
+      hosts:
+        - "localhost:9092"
+      backpressure: drop
+`),
+			expectValidationError: "",
+		},
+		"outputsMainBackpressureDrop": {
+			input: []byte(`
+name: mockbeat
+outputs:
+  - elasticsearch:
+      hosts:
+        - "localhost:9200"
+      backpressure: drop
+  - kafka:
+      hosts:
+        - "localhost:9092"
+`),
+			expectValidationError: "backpressure drop is not supported by the main output, only by the additional outputs accessing config",
+		},
+		"managementOutputs": {
+			input: []byte(`
+name: mockbeat
+management:
+  enabled: true
+outputs:
+  - elasticsearch:
+      hosts:
+        - "localhost:9200"
+`),
+			expectValidationError: "outputs is not supported when management is enabled accessing config",
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -22,6 +22,7 @@ package instance
 import (
 	_ "github.com/elastic/beats/v7/libbeat/autodiscover/providers/docker" // Register autodiscover providers
 	_ "github.com/elastic/beats/v7/libbeat/autodiscover/providers/kubernetes"
+	_ "github.com/elastic/beats/v7/libbeat/processors/add_container_metadata"
 	_ "github.com/elastic/beats/v7/libbeat/processors/add_docker_metadata"
 	_ "github.com/elastic/beats/v7/libbeat/processors/add_kubernetes_metadata"
 )
@@ -19,6 +19,7 @@ package instance
 
 import (
 	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
 	"github.com/elastic/beats/v7/libbeat/idxmgmt"
@@ -53,4 +54,8 @@ type Settings struct {
 
 	// Initialize functions that are called in-order to initialize unique items for the beat.
 	Initialize []func()
+
+	// TracerProvider creates the spans of traced publisher pipeline events.
+	// If nil, the global OpenTelemetry tracer provider is used.
+	TracerProvider trace.TracerProvider
 }
@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package match
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CompileGlob compiles a shell-style wildcard pattern to a string matcher
+// matching whole strings. In the pattern, * matches any sequence of
+// characters except /, ** matches any sequence of characters, ? matches any
+// single character except /, and [...] matches a character class, negated
+// with [!...] or [^...]. Special characters are escaped with a backslash.
+func CompileGlob(pattern string, ignoreCase bool) (Matcher, error) {
+	regex, err := globToRegexp(pattern)
+	if err != nil {
+		return Matcher{}, err
+	}
+	if ignoreCase {
+		regex = "(?i)" + regex
+	}
+	return Compile(regex)
+}
+
+// globToRegexp translates a wildcard pattern to an anchored regular
+// expression.
+func globToRegexp(pattern string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end, class, err := globClass(pattern, i)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(class)
+			i = end
+		case '\\':
+			if i+1 == len(pattern) {
+				return "", fmt.Errorf("invalid glob pattern %q: trailing backslash", pattern)
+			}
+			i++
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String(), nil
+}
+
+// globClass translates the character class starting at pattern[start] and
+// returns the index of its closing bracket.
+func globClass(pattern string, start int) (int, string, error) {
+	var sb strings.Builder
+	sb.WriteString("[")
+	i := start + 1
+	if i < len(pattern) && (pattern[i] == '!' || pattern[i] == '^') {
+		sb.WriteString("^")
+		i++
+	}
+	// A closing bracket first in the class is a literal.
+	if i < len(pattern) && pattern[i] == ']' {
+		sb.WriteString(`\]`)
+		i++
+	}
+	for ; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case ']':
+			sb.WriteString("]")
+			return i, sb.String(), nil
+		case '\\':
+			if i+1 == len(pattern) {
+				return 0, "", fmt.Errorf("invalid glob pattern %q: trailing backslash", pattern)
+			}
+			i++
+			if isASCIIAlnum(pattern[i]) {
+				sb.WriteByte(pattern[i])
+			} else {
+				sb.WriteString(`\`)
+				sb.WriteByte(pattern[i])
+			}
+		case '[', '^':
+			sb.WriteString(`\`)
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return 0, "", fmt.Errorf("invalid glob pattern %q: unterminated character class", pattern)
+}
+
+func isASCIIAlnum(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
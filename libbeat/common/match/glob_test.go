@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package match
+
+import "testing"
+
+func TestCompileGlob(t *testing.T) {
+	tests := []struct {
+		pattern    string
+		ignoreCase bool
+		matches    []string
+		noMatches  []string
+	}{
+		{
+			pattern:   "/var/log/app-*/error*.log",
+			matches:   []string{"/var/log/app-1/error.log", "/var/log/app-web/error-2024.log"},
+			noMatches: []string{"/var/log/app-1/sub/error.log", "/var/log/app-1/error.log.1", "x/var/log/app-1/error.log"},
+		},
+		{
+			pattern:   "/var/log/**.log",
+			matches:   []string{"/var/log/a.log", "/var/log/a/b/c.log"},
+			noMatches: []string{"/var/log/a.txt"},
+		},
+		{
+			pattern:   "file?.txt",
+			matches:   []string{"file1.txt", "fileA.txt"},
+			noMatches: []string{"file.txt", "file10.txt", "file/.txt"},
+		},
+		{
+			pattern:   "file[0-9].txt",
+			matches:   []string{"file1.txt"},
+			noMatches: []string{"fileA.txt"},
+		},
+		{
+			pattern:   "file[!0-9].txt",
+			matches:   []string{"fileA.txt"},
+			noMatches: []string{"file1.txt"},
+		},
+		{
+			pattern:   "[]a]",
+			matches:   []string{"]", "a"},
+			noMatches: []string{"b"},
+		},
+		{
+			pattern:   `a\*b[\]\d]`,
+			matches:   []string{"a*b]", "a*bd"},
+			noMatches: []string{"axb]", "a*b1"},
+		},
+		{
+			pattern:   "a.b+c",
+			matches:   []string{"a.b+c"},
+			noMatches: []string{"aXb+c", "a.bbc"},
+		},
+		{
+			pattern:    "*.LOG",
+			ignoreCase: true,
+			matches:    []string{"error.log", "ERROR.LOG"},
+			noMatches:  []string{"error.txt"},
+		},
+		{
+			pattern:   "*.LOG",
+			matches:   []string{"ERROR.LOG"},
+			noMatches: []string{"error.log"},
+		},
+	}
+
+	for _, test := range tests {
+		m, err := CompileGlob(test.pattern, test.ignoreCase)
+		if err != nil {
+			t.Errorf("failed to compile %q: %v", test.pattern, err)
+			continue
+		}
+		for _, s := range test.matches {
+			if !m.MatchString(s) {
+				t.Errorf("%q (ignore case=%v) does not match %q", test.pattern, test.ignoreCase, s)
+			}
+		}
+		for _, s := range test.noMatches {
+			if m.MatchString(s) {
+				t.Errorf("%q (ignore case=%v) unexpectedly matches %q", test.pattern, test.ignoreCase, s)
+			}
+		}
+	}
+}
+
+func TestCompileGlobInvalid(t *testing.T) {
+	for _, pattern := range []string{"file[0-9", `file\`, `[a\`} {
+		if _, err := CompileGlob(pattern, false); err == nil {
+			t.Errorf("expected an error compiling %q", pattern)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// DecodeBase64 is a condition that base64-decodes the event values before
+// passing them to its inner condition. Values that are not valid base64
+// strings are treated as missing, so the inner condition does not match.
+type DecodeBase64 struct {
+	inner Condition
+}
+
+// NewDecodeBase64Condition builds a new DecodeBase64 condition wrapping the
+// provided Condition.
+func NewDecodeBase64Condition(c Condition) DecodeBase64 {
+	return DecodeBase64{inner: c}
+}
+
+// Check determines whether the given event matches this condition.
+func (c DecodeBase64) Check(event ValuesMap) bool {
+	return c.inner.Check(base64ValuesMap{event})
+}
+
+func (c DecodeBase64) String() string {
+	return "decode_base64(" + c.inner.String() + ")"
+}
+
+// base64ValuesMap decodes the values read from the wrapped ValuesMap.
+type base64ValuesMap struct {
+	ValuesMap
+}
+
+func (m base64ValuesMap) GetValue(key string) (interface{}, error) {
+	value, err := m.ValuesMap.GetValue(key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case string:
+		return decodeBase64String(key, v)
+	case []string:
+		decoded := make([]string, len(v))
+		for i, s := range v {
+			if decoded[i], err = decodeBase64String(key, s); err != nil {
+				return nil, err
+			}
+		}
+		return decoded, nil
+	case []interface{}:
+		decoded := make([]interface{}, len(v))
+		for i, elem := range v {
+			s, ok := elem.(string)
+			if !ok {
+				return nil, fmt.Errorf("field '%v' contains a non-string value of type %T", key, elem)
+			}
+			if decoded[i], err = decodeBase64String(key, s); err != nil {
+				return nil, err
+			}
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("field '%v' has type %T, expected a base64 string", key, value)
+	}
+}
+
+func decodeBase64String(key, s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("failed to base64 decode field '%v': %w", key, err)
+	}
+	return string(b), nil
+}
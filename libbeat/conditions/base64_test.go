@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var base64TestEvent = &beat.Event{
+	Timestamp: time.Now(),
+	Fields: mapstr.M{
+		"payload": "aGVsbG8gd29ybGQ=", // hello world
+		"invalid": "not base64!",
+		"count":   3,
+		"tags":    []string{"YWxwaGE=", "YmV0YQ=="}, // alpha, beta
+	},
+}
+
+func TestDecodeBase64Create(t *testing.T) {
+	config := Config{
+		DecodeBase64: true,
+		HasFields:    []string{"payload"},
+	}
+
+	_, err := NewCondition(&config, logptest.NewTestingLogger(t, ""))
+	assert.Error(t, err)
+}
+
+func TestDecodeBase64EqualsPositiveMatch(t *testing.T) {
+	testConfig(t, true, base64TestEvent, &Config{
+		DecodeBase64: true,
+		Equals: &Fields{fields: map[string]interface{}{
+			"payload": "hello world",
+		}},
+	})
+}
+
+func TestDecodeBase64EqualsNegativeMatch(t *testing.T) {
+	testConfig(t, false, base64TestEvent, &Config{
+		DecodeBase64: true,
+		Equals: &Fields{fields: map[string]interface{}{
+			"payload": "aGVsbG8gd29ybGQ=",
+		}},
+	})
+}
+
+func TestDecodeBase64ContainsPositiveMatch(t *testing.T) {
+	testConfig(t, true, base64TestEvent, &Config{
+		DecodeBase64: true,
+		Contains: &Fields{fields: map[string]interface{}{
+			"payload": "world",
+		}},
+	})
+}
+
+func TestDecodeBase64ContainsArrayPositiveMatch(t *testing.T) {
+	testConfig(t, true, base64TestEvent, &Config{
+		DecodeBase64: true,
+		Contains: &Fields{fields: map[string]interface{}{
+			"tags": "beta",
+		}},
+	})
+}
+
+func TestDecodeBase64RegexpPositiveMatch(t *testing.T) {
+	testConfig(t, true, base64TestEvent, &Config{
+		DecodeBase64: true,
+		Regexp: &Fields{fields: map[string]interface{}{
+			"payload": "^hello w.*d$",
+		}},
+	})
+}
+
+func TestDecodeBase64InvalidValue(t *testing.T) {
+	configs := []Config{
+		{
+			DecodeBase64: true,
+			Equals: &Fields{fields: map[string]interface{}{
+				"invalid": "not base64!",
+			}},
+		},
+		{
+			DecodeBase64: true,
+			Contains: &Fields{fields: map[string]interface{}{
+				"invalid": "base64",
+			}},
+		},
+		{
+			DecodeBase64: true,
+			Regexp: &Fields{fields: map[string]interface{}{
+				"invalid": ".*",
+			}},
+		},
+		{
+			DecodeBase64: true,
+			Equals: &Fields{fields: map[string]interface{}{
+				"count": 3,
+			}},
+		},
+	}
+
+	for _, cond := range GetConditions(t, configs) {
+		assert.False(t, cond.Check(base64TestEvent), cond.String())
+	}
+}
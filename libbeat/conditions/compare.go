@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// comparandKind is the kind of value a field is compared to.
+type comparandKind uint8
+
+const (
+	numberComparand comparandKind = iota
+	durationComparand
+	timestampComparand
+)
+
+// comparand is a value a field is compared to. Numbers are compared to
+// numeric fields, durations to durations and to numeric fields holding
+// nanoseconds like event.duration, and timestamps to dates.
+type comparand struct {
+	kind     comparandKind
+	number   float64
+	duration time.Duration
+	time     time.Time
+}
+
+// Compare is a Condition type for comparing fields with numbers, durations
+// or timestamps using the gt, gte, lt or lte operator.
+type Compare struct {
+	op     string
+	fields map[string]comparand
+	logger *logp.Logger
+}
+
+// NewCompareCondition builds a new Compare condition for the given operator
+// from a map of fields to values. Values are numbers, duration strings like
+// "5s", or RFC 3339 timestamps.
+func NewCompareCondition(op string, fields map[string]interface{}, log *logp.Logger) (*Compare, error) {
+	switch op {
+	case "gt", "gte", "lt", "lte":
+	default:
+		return nil, fmt.Errorf("unexpected comparison operator %s", op)
+	}
+
+	c := &Compare{op: op, fields: make(map[string]comparand, len(fields)), logger: log.Named(logName)}
+	for field, value := range fields {
+		v, err := newComparand(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s condition on field %s: %w", op, field, err)
+		}
+		c.fields[field] = v
+	}
+	return c, nil
+}
+
+func newComparand(value interface{}) (comparand, error) {
+	switch v := value.(type) {
+	case time.Duration:
+		return comparand{kind: durationComparand, duration: v}, nil
+	case time.Time:
+		return comparand{kind: timestampComparand, time: v}, nil
+	case common.Time:
+		return comparand{kind: timestampComparand, time: time.Time(v)}, nil
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return comparand{kind: numberComparand, number: f}, nil
+		}
+		if d, err := time.ParseDuration(v); err == nil {
+			return comparand{kind: durationComparand, duration: d}, nil
+		}
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return comparand{kind: timestampComparand, time: t}, nil
+		}
+		return comparand{}, fmt.Errorf("value %q is not a number, a duration or an RFC 3339 timestamp", v)
+	}
+
+	f, err := ExtractFloat(value)
+	if err != nil {
+		return comparand{}, err
+	}
+	return comparand{kind: numberComparand, number: f}, nil
+}
+
+// compare compares a field value to the comparand, returning -1, 0 or +1
+// as cmp.Compare.
+func (c comparand) compare(value interface{}) (int, error) {
+	switch c.kind {
+	case durationComparand:
+		d, err := extractDuration(value)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(d, c.duration), nil
+	case timestampComparand:
+		t, err := extractTime(value)
+		if err != nil {
+			return 0, err
+		}
+		return t.Compare(c.time), nil
+	default:
+		if d, ok := value.(time.Duration); ok {
+			return cmp.Compare(float64(d), c.number), nil
+		}
+		f, err := ExtractFloat(value)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(f, c.number), nil
+	}
+}
+
+func (c comparand) String() string {
+	switch c.kind {
+	case durationComparand:
+		return c.duration.String()
+	case timestampComparand:
+		return c.time.Format(time.RFC3339Nano)
+	default:
+		return strconv.FormatFloat(c.number, 'g', -1, 64)
+	}
+}
+
+// extractDuration extracts a duration from a time.Duration, a duration
+// string, or a number of nanoseconds.
+func extractDuration(unk interface{}) (time.Duration, error) {
+	switch v := unk.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		if d, err := time.ParseDuration(v); err == nil {
+			return d, nil
+		}
+	}
+	f, err := ExtractFloat(unk)
+	if err != nil {
+		return 0, fmt.Errorf("unknown type %T or format passed to extractDuration", unk)
+	}
+	return time.Duration(f), nil
+}
+
+// extractTime extracts a time from a time.Time, a common.Time or an RFC 3339
+// timestamp string.
+func extractTime(unk interface{}) (time.Time, error) {
+	switch v := unk.(type) {
+	case time.Time:
+		return v, nil
+	case common.Time:
+		return time.Time(v), nil
+	case string:
+		return time.Parse(time.RFC3339Nano, v)
+	default:
+		return time.Time{}, fmt.Errorf("unknown type %T passed to extractTime", unk)
+	}
+}
+
+// Check determines whether all the fields of the event compare to their
+// values with the operator. Missing fields and fields that cannot be
+// compared to their value do not match.
+func (c *Compare) Check(event ValuesMap) bool {
+	for field, want := range c.fields {
+		value, err := event.GetValue(field)
+		if err != nil {
+			return false
+		}
+
+		r, err := want.compare(value)
+		if err != nil {
+			c.logger.Warnf("%s condition on field %s: %v", c.op, field, err)
+			return false
+		}
+
+		var match bool
+		switch c.op {
+		case "gt":
+			match = r > 0
+		case "gte":
+			match = r >= 0
+		case "lt":
+			match = r < 0
+		case "lte":
+			match = r <= 0
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Compare) String() string {
+	return fmt.Sprintf("%s: %v", c.op, c.fields)
+}
@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var compareTestEvent = &beat.Event{
+	Timestamp: time.Now(),
+	Fields: mapstr.M{
+		"event": mapstr.M{
+			"start":    "2015-06-11T09:51:23.642Z",
+			"duration": int64(7 * time.Second),
+			"created":  common.Time(time.Date(2015, 6, 11, 9, 51, 24, 0, time.UTC)),
+		},
+		"http": mapstr.M{
+			"code": 404,
+		},
+		"timeout":      "1m30s",
+		"elapsed":      1500 * time.Millisecond,
+		"responsetime": "30",
+		"status":       "OK",
+	},
+}
+
+func TestCompareCondition(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config *Config
+		want   bool
+	}{
+		{"number gt", &Config{GT: &Fields{fields: map[string]interface{}{"http.code": 399}}}, true},
+		{"number gte equal", &Config{GTE: &Fields{fields: map[string]interface{}{"http.code": 404}}}, true},
+		{"number lt", &Config{LT: &Fields{fields: map[string]interface{}{"http.code": 400}}}, false},
+		{"number lte equal", &Config{LTE: &Fields{fields: map[string]interface{}{"http.code": 404.0}}}, true},
+		{"numeric string field", &Config{GT: &Fields{fields: map[string]interface{}{"responsetime": "29.5"}}}, true},
+		{"duration nanoseconds field", &Config{GT: &Fields{fields: map[string]interface{}{"event.duration": "5s"}}}, true},
+		{"duration nanoseconds field below", &Config{GT: &Fields{fields: map[string]interface{}{"event.duration": "10s"}}}, false},
+		{"duration string field", &Config{LTE: &Fields{fields: map[string]interface{}{"timeout": "90s"}}}, true},
+		{"duration field", &Config{LT: &Fields{fields: map[string]interface{}{"elapsed": "2s"}}}, true},
+		{"timestamp string field", &Config{LT: &Fields{fields: map[string]interface{}{"event.start": "2015-06-11T10:00:00Z"}}}, true},
+		{"event timestamp", &Config{GT: &Fields{fields: map[string]interface{}{"@timestamp": "2015-06-11T10:00:00Z"}}}, true},
+		{"timestamp field", &Config{GT: &Fields{fields: map[string]interface{}{"event.created": "2015-06-11T09:51:23.642Z"}}}, true},
+		{"all fields must match", &Config{GT: &Fields{fields: map[string]interface{}{"http.code": 399, "event.duration": "10s"}}}, false},
+		{"missing field", &Config{GT: &Fields{fields: map[string]interface{}{"bytes_out": 0}}}, false},
+		{"incomparable field", &Config{GT: &Fields{fields: map[string]interface{}{"status": "5s"}}}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testConfig(t, tc.want, compareTestEvent, tc.config)
+		})
+	}
+}
+
+func TestCompareConditionFromConfig(t *testing.T) {
+	c, err := conf.NewConfigFrom(`
+gt:
+  event.duration: 5s
+  http.code: 399
+`)
+	require.NoError(t, err)
+	var config Config
+	require.NoError(t, c.Unpack(&config))
+
+	cond, err := NewCondition(&config, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	assert.True(t, cond.Check(compareTestEvent))
+	assert.Equal(t, "gt: map[event.duration:5s http.code:399]", cond.String())
+}
+
+func TestCompareConditionInvalidValue(t *testing.T) {
+	for _, value := range []interface{}{"fdfdd", true, []string{"5s"}} {
+		_, err := NewCondition(&Config{GT: &Fields{fields: map[string]interface{}{"event.duration": value}}}, logptest.NewTestingLogger(t, ""))
+		assert.Error(t, err, value)
+	}
+
+	_, err := NewCompareCondition("gtr", map[string]interface{}{"event.duration": 5}, logptest.NewTestingLogger(t, ""))
+	assert.ErrorContains(t, err, "unexpected comparison operator gtr")
+}
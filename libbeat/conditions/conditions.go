@@ -28,15 +28,32 @@ const logName = "conditions"
 
 // Config represents a configuration for a condition, as you would find it in the config files.
 type Config struct {
-	Equals    *Fields                `config:"equals"`
-	Contains  *Fields                `config:"contains"`
-	Regexp    *Fields                `config:"regexp"`
-	Range     *Fields                `config:"range"`
-	HasFields []string               `config:"has_fields"`
-	Network   map[string]interface{} `config:"network"`
-	OR        []Config               `config:"or"`
-	AND       []Config               `config:"and"`
-	NOT       *Config                `config:"not"`
+	Equals         *Fields                `config:"equals"`
+	Contains       *Fields                `config:"contains"`
+	Regexp         *Fields                `config:"regexp"`
+	Glob           *Fields                `config:"glob"`
+	Range          *Fields                `config:"range"`
+	GT             *Fields                `config:"gt"`
+	GTE            *Fields                `config:"gte"`
+	LT             *Fields                `config:"lt"`
+	LTE            *Fields                `config:"lte"`
+	HasFields      []string               `config:"has_fields"`
+	MissingFields  []string               `config:"missing_fields"`
+	Network        map[string]interface{} `config:"network"`
+	InFile         *InFileConfig          `config:"in_file"`
+	ReverseDNS     *ReverseDNSConfig      `config:"reverse_dns"`
+	ThresholdCross *ThresholdCrossConfig  `config:"threshold_cross"`
+	Expr           string                 `config:"expr"`
+	OR             []Config               `config:"or"`
+	AND            []Config               `config:"and"`
+	NOT            *Config                `config:"not"`
+
+	// DecodeBase64 makes equals, contains, regexp and glob conditions decode
+	// the field values from base64 before matching.
+	DecodeBase64 bool `config:"decode_base64"`
+
+	// IgnoreCase makes glob conditions match case-insensitively.
+	IgnoreCase bool `config:"ignore_case"`
 }
 
 // Condition is the interface for all defined conditions
@@ -67,12 +84,35 @@ func NewCondition(config *Config, logger *logp.Logger) (Condition, error) {
 		condition, err = NewMatcherCondition("contains", config.Contains.fields, match.CompileString, logger)
 	case config.Regexp != nil:
 		condition, err = NewMatcherCondition("regexp", config.Regexp.fields, match.Compile, logger)
+	case config.Glob != nil:
+		compile := func(pattern string) (match.Matcher, error) {
+			return match.CompileGlob(pattern, config.IgnoreCase)
+		}
+		condition, err = NewMatcherCondition("glob", config.Glob.fields, compile, logger)
 	case config.Range != nil:
 		condition, err = NewRangeCondition(config.Range.fields, logger)
+	case config.GT != nil:
+		condition, err = NewCompareCondition("gt", config.GT.fields, logger)
+	case config.GTE != nil:
+		condition, err = NewCompareCondition("gte", config.GTE.fields, logger)
+	case config.LT != nil:
+		condition, err = NewCompareCondition("lt", config.LT.fields, logger)
+	case config.LTE != nil:
+		condition, err = NewCompareCondition("lte", config.LTE.fields, logger)
 	case config.HasFields != nil:
 		condition = NewHasFieldsCondition(config.HasFields)
+	case config.MissingFields != nil:
+		condition = NewMissingFieldsCondition(config.MissingFields)
 	case config.Network != nil && len(config.Network) > 0:
 		condition, err = NewNetworkCondition(config.Network, logger)
+	case config.InFile != nil:
+		condition, err = NewInFileCondition(*config.InFile, logger)
+	case config.ReverseDNS != nil:
+		condition, err = NewReverseDNSCondition(*config.ReverseDNS, logger)
+	case config.ThresholdCross != nil:
+		condition, err = NewThresholdCrossCondition(*config.ThresholdCross, logger)
+	case config.Expr != "":
+		condition, err = NewExprCondition(config.Expr, logger)
 	case len(config.OR) > 0:
 		var conditionsList []Condition
 		conditionsList, err = NewConditionList(config.OR, logger)
@@ -94,6 +134,17 @@ func NewCondition(config *Config, logger *logp.Logger) (Condition, error) {
 		return nil, err
 	}
 
+	if config.IgnoreCase && config.Glob == nil {
+		return nil, errors.New("ignore_case is only supported by glob conditions")
+	}
+
+	if config.DecodeBase64 {
+		if config.Equals == nil && config.Contains == nil && config.Regexp == nil && config.Glob == nil {
+			return nil, errors.New("decode_base64 is only supported by equals, contains, regexp and glob conditions")
+		}
+		condition = NewDecodeBase64Condition(condition)
+	}
+
 	logger.Named(logName).Debugf("New condition %v", condition)
 	return condition, nil
 }
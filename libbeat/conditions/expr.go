@@ -0,0 +1,708 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// Expr is a Condition defined by a boolean expression in a single string,
+// like `http.response.status_code >= 500 && url.path startsWith "/api"`.
+//
+// Operands are field names, quoted strings, numbers, true, false, null and
+// lists of literals like ["a", "b"]. The operators are, by increasing
+// precedence, || (or), && (and), ! (not) and the comparisons ==, !=, <, <=,
+// >, >=, contains, startsWith, endsWith, matches (regular expression), glob
+// (wildcard pattern) and in (list). Parentheses group expressions. The
+// functions exists(field) and network(field, network...) check for the
+// presence of a field and whether it is in one of the networks of the
+// network condition.
+type Expr struct {
+	source string
+	root   exprNode
+}
+
+// NewExprCondition builds a new Expr condition by parsing the expression.
+func NewExprCondition(source string, log *logp.Logger) (*Expr, error) {
+	tokens, err := lexExpr(source)
+	if err != nil {
+		return nil, fmt.Errorf("expr condition: %w", err)
+	}
+	p := &exprParser{tokens: tokens, log: log.Named(logName)}
+	root, err := p.parseOr()
+	if err == nil && !p.at(tokenEOF) {
+		err = p.unexpected()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("expr condition: %w", err)
+	}
+	return &Expr{source: source, root: root}, nil
+}
+
+// Check determines whether the given event matches this condition.
+func (c *Expr) Check(event ValuesMap) bool {
+	return c.root.eval(event)
+}
+
+func (c *Expr) String() string {
+	return "expr: " + c.source
+}
+
+type exprNode interface {
+	eval(event ValuesMap) bool
+}
+
+type (
+	orNode  []exprNode
+	andNode []exprNode
+	notNode struct{ exprNode }
+
+	// truthyNode is an operand used as a boolean, it is true when the
+	// operand is the boolean true.
+	truthyNode struct{ operand }
+
+	// compareNode applies a comparison operator to two operands. Missing
+	// fields only compare to null.
+	compareNode struct {
+		op          string
+		left, right operand
+		check       func(left, right interface{}) bool
+	}
+)
+
+func (n orNode) eval(event ValuesMap) bool {
+	for _, sub := range n {
+		if sub.eval(event) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n andNode) eval(event ValuesMap) bool {
+	for _, sub := range n {
+		if !sub.eval(event) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n notNode) eval(event ValuesMap) bool {
+	return !n.exprNode.eval(event)
+}
+
+func (n truthyNode) eval(event ValuesMap) bool {
+	v, _ := n.value(event)
+	b, ok := v.(bool)
+	return ok && b
+}
+
+func (n *compareNode) eval(event ValuesMap) bool {
+	left, leftOK := n.left.value(event)
+	right, rightOK := n.right.value(event)
+	switch {
+	case isNullOperand(n.right) || isNullOperand(n.left):
+		isNull := !leftOK || left == nil
+		if isNullOperand(n.left) {
+			isNull = !rightOK || right == nil
+		}
+		if n.op == "!=" {
+			return !isNull
+		}
+		return isNull
+	case !leftOK || !rightOK:
+		return false
+	}
+	return n.check(left, right)
+}
+
+// operand is a field or a literal value.
+type operand interface {
+	value(event ValuesMap) (interface{}, bool)
+}
+
+type fieldOperand string
+
+func (f fieldOperand) value(event ValuesMap) (interface{}, bool) {
+	v, err := event.GetValue(string(f))
+	return v, err == nil
+}
+
+type literalOperand struct {
+	v interface{}
+}
+
+func (l literalOperand) value(ValuesMap) (interface{}, bool) {
+	return l.v, true
+}
+
+// existsNode is the exists(field) function.
+type existsNode fieldOperand
+
+func (n existsNode) eval(event ValuesMap) bool {
+	_, ok := fieldOperand(n).value(event)
+	return ok
+}
+
+// networkNode is the network(field, network...) function.
+type networkNode struct {
+	field   fieldOperand
+	network networkMatcher
+}
+
+func (n networkNode) eval(event ValuesMap) bool {
+	v, ok := n.field.value(event)
+	if !ok {
+		return false
+	}
+	for _, ip := range extractIP(v) {
+		if n.network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNullOperand(o operand) bool {
+	l, ok := o.(literalOperand)
+	return ok && l.v == nil
+}
+
+// exprEqual compares a value to another, numerically when both are numbers
+// or numeric strings. A list is equal to a value if any element is.
+func exprEqual(left, right interface{}) bool {
+	if list, ok := exprList(left); ok {
+		for _, elem := range list {
+			if exprEqual(elem, right) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch r := right.(type) {
+	case string:
+		if l, ok := left.(string); ok {
+			return l == r
+		}
+	case bool:
+		if l, ok := left.(bool); ok {
+			return l == r
+		}
+		return false
+	}
+	l, err := ExtractFloat(left)
+	if err != nil {
+		return false
+	}
+	r, err := ExtractFloat(right)
+	return err == nil && l == r
+}
+
+// exprList returns the elements of a list value.
+func exprList(v interface{}) ([]interface{}, bool) {
+	switch v := v.(type) {
+	case []interface{}:
+		return v, true
+	case []string:
+		list := make([]interface{}, len(v))
+		for i, s := range v {
+			list[i] = s
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+// exprStrings returns the strings of a string or a list of strings.
+func exprStrings(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		var strs []string
+		for _, elem := range v {
+			if s, ok := elem.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+		return strs
+	}
+	return nil
+}
+
+// anyString returns whether the predicate is true for the string, or any
+// string of the list, of a value.
+func anyString(v interface{}, pred func(string) bool) bool {
+	for _, s := range exprStrings(v) {
+		if pred(s) {
+			return true
+		}
+	}
+	return false
+}
+
+type tokenKind uint8
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// lexExpr splits an expression into tokens.
+func lexExpr(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '"' || r == '\'':
+			end, text, err := lexString(s, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{tokenString, text, i})
+			i = end
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(s) && s[i+1] >= '0' && s[i+1] <= '9'):
+			start := i
+			for i++; i < len(s) && strings.ContainsRune("0123456789.eE+-", rune(s[i])); i++ {
+				if (s[i] == '+' || s[i] == '-') && s[i-1] != 'e' && s[i-1] != 'E' {
+					break
+				}
+			}
+			tokens = append(tokens, token{tokenNumber, s[start:i], start})
+		case unicode.IsLetter(r) || r == '_' || r == '@':
+			start := i
+			for i < len(s) {
+				r, size := utf8.DecodeRuneInString(s[i:])
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("_@.-", r) {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, token{tokenIdent, s[start:i], start})
+		default:
+			op := ""
+			for _, candidate := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "[", "]", ","} {
+				if strings.HasPrefix(s[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+			}
+			tokens = append(tokens, token{tokenPunct, op, i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(s)}), nil
+}
+
+// lexString reads the quoted string starting at s[start]. Backslash escapes
+// the quote and backslash characters.
+func lexString(s string, start int) (int, string, error) {
+	quote := s[start]
+	var sb strings.Builder
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case quote:
+			return i + 1, sb.String(), nil
+		case '\\':
+			if i+1 < len(s) {
+				i++
+			}
+			sb.WriteByte(s[i])
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return 0, "", fmt.Errorf("unterminated string at position %d", start)
+}
+
+type exprParser struct {
+	tokens []token
+	pos    int
+	log    *logp.Logger
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *exprParser) at(kind tokenKind, texts ...string) bool {
+	t := p.peek()
+	if t.kind != kind {
+		return false
+	}
+	if len(texts) == 0 {
+		return true
+	}
+	for _, text := range texts {
+		if t.text == text {
+			return true
+		}
+	}
+	return false
+}
+
+// accept consumes the next token if it is one of the operators or keywords.
+func (p *exprParser) accept(texts ...string) bool {
+	if p.at(tokenPunct, texts...) || p.at(tokenIdent, texts...) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) expect(text string) error {
+	if !p.accept(text) {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *exprParser) unexpected() error {
+	t := p.peek()
+	if t.kind == tokenEOF {
+		return fmt.Errorf("unexpected end of expression")
+	}
+	return fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	or := orNode{node}
+	for p.accept("||", "or") {
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, node)
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	node, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	and := andNode{node}
+	for p.accept("&&", "and") {
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, node)
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.accept("!", "not") {
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{node}, nil
+	}
+	if p.accept("(") {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return node, p.expect(")")
+	}
+	if p.at(tokenIdent, "exists", "network") && p.tokens[p.pos+1].text == "(" {
+		return p.parseFunction()
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseFunction() (exprNode, error) {
+	name := p.next().text
+	p.next() // (
+	field, err := p.parseField()
+	if err != nil {
+		return nil, err
+	}
+
+	var node exprNode = existsNode(field)
+	if name == "network" {
+		var networks multiNetworkMatcher
+		for p.accept(",") {
+			t := p.next()
+			if t.kind != tokenString {
+				return nil, fmt.Errorf("network() expects network strings, got %q at position %d", t.text, t.pos)
+			}
+			m, err := makeMatcher(t.text)
+			if err != nil {
+				return nil, err
+			}
+			networks = append(networks, m)
+		}
+		if len(networks) == 0 {
+			return nil, fmt.Errorf("network() expects at least one network")
+		}
+		node = networkNode{field: field, network: networks}
+	}
+	return node, p.expect(")")
+}
+
+func (p *exprParser) parseField() (fieldOperand, error) {
+	if !p.at(tokenIdent) || isExprKeyword(p.peek().text) {
+		return "", p.unexpected()
+	}
+	return fieldOperand(p.next().text), nil
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	op := t.text
+	switch {
+	case t.kind == tokenPunct && (op == "==" || op == "!=" || op == "<" || op == "<=" || op == ">" || op == ">="):
+	case t.kind == tokenIdent && isExprOperator(op):
+	default:
+		return truthyNode{left}, nil
+	}
+	p.next()
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if (isNullOperand(left) || isNullOperand(right)) && op != "==" && op != "!=" {
+		return nil, fmt.Errorf("%s at position %d: null can only be compared with == and !=", op, t.pos)
+	}
+	check, err := p.comparison(op, right)
+	if err != nil {
+		return nil, fmt.Errorf("%s at position %d: %w", op, t.pos, err)
+	}
+	return &compareNode{op: op, left: left, right: right, check: check}, nil
+}
+
+// comparison returns the function comparing the left operand to the right
+// one. Patterns and lists must be literals, so they are compiled once.
+func (p *exprParser) comparison(op string, right operand) (func(left, right interface{}) bool, error) {
+	literal, isLiteral := right.(literalOperand)
+
+	switch op {
+	case "==":
+		return exprEqual, nil
+	case "!=":
+		return func(left, right interface{}) bool { return !exprEqual(left, right) }, nil
+	case "<", "<=", ">", ">=":
+		ops := map[string]string{"<": "lt", "<=": "lte", ">": "gt", ">=": "gte"}
+		if isLiteral {
+			c, err := newComparand(literal.v)
+			if err != nil {
+				return nil, err
+			}
+			return func(left, _ interface{}) bool { return p.compare(ops[op], c, left) }, nil
+		}
+		return func(left, right interface{}) bool {
+			c, err := newComparand(right)
+			if err != nil {
+				p.log.Debugf("expr condition: %s: %v", op, err)
+				return false
+			}
+			return p.compare(ops[op], c, left)
+		}, nil
+	case "contains":
+		return func(left, right interface{}) bool {
+			if list, ok := exprList(left); ok {
+				return exprEqual(list, right)
+			}
+			r, ok := right.(string)
+			return ok && anyString(left, func(s string) bool { return strings.Contains(s, r) })
+		}, nil
+	case "startsWith", "endsWith":
+		has := strings.HasPrefix
+		if op == "endsWith" {
+			has = strings.HasSuffix
+		}
+		return func(left, right interface{}) bool {
+			r, ok := right.(string)
+			return ok && anyString(left, func(s string) bool { return has(s, r) })
+		}, nil
+	case "matches", "glob":
+		pattern, ok := literal.v.(string)
+		if !isLiteral || !ok {
+			return nil, fmt.Errorf("expects a pattern string")
+		}
+		var m match.Matcher
+		var err error
+		if op == "matches" {
+			m, err = match.Compile(pattern)
+		} else {
+			m, err = match.CompileGlob(pattern, false)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return func(left, _ interface{}) bool { return anyString(left, m.MatchString) }, nil
+	case "in":
+		list, ok := exprList(literal.v)
+		if !isLiteral || !ok {
+			return nil, fmt.Errorf("expects a list")
+		}
+		return func(left, _ interface{}) bool {
+			for _, elem := range list {
+				if exprEqual(left, elem) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown operator")
+}
+
+// compare compares a value to a comparand, like the gt, gte, lt and lte
+// conditions.
+func (p *exprParser) compare(op string, c comparand, value interface{}) bool {
+	r, err := c.compare(value)
+	if err != nil {
+		p.log.Debugf("expr condition: %s: %v", op, err)
+		return false
+	}
+	switch op {
+	case "gt":
+		return r > 0
+	case "gte":
+		return r >= 0
+	case "lt":
+		return r < 0
+	default:
+		return r <= 0
+	}
+}
+
+func (p *exprParser) parseOperand() (operand, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokenString:
+		p.next()
+		return literalOperand{t.text}, nil
+	case t.kind == tokenNumber:
+		p.next()
+		if i, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return literalOperand{i}, nil
+		}
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.pos)
+		}
+		return literalOperand{f}, nil
+	case t.kind == tokenPunct && t.text == "[":
+		return p.parseList()
+	case t.kind == tokenIdent:
+		switch t.text {
+		case "true", "false":
+			p.next()
+			return literalOperand{t.text == "true"}, nil
+		case "null":
+			p.next()
+			return literalOperand{nil}, nil
+		}
+		f, err := p.parseField()
+		return f, err
+	}
+	return nil, p.unexpected()
+}
+
+func (p *exprParser) parseList() (operand, error) {
+	p.next() // [
+	list := []interface{}{}
+	for !p.accept("]") {
+		if len(list) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		o, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		l, ok := o.(literalOperand)
+		if !ok {
+			return nil, fmt.Errorf("lists can only contain literals, got field %q", o)
+		}
+		list = append(list, l.v)
+	}
+	return literalOperand{list}, nil
+}
+
+func isExprOperator(s string) bool {
+	switch s {
+	case "contains", "startsWith", "endsWith", "matches", "glob", "in":
+		return true
+	}
+	return false
+}
+
+func isExprKeyword(s string) bool {
+	switch s {
+	case "and", "or", "not", "true", "false", "null":
+		return true
+	}
+	return isExprOperator(s)
+}
@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var exprTestEvent = &beat.Event{
+	Timestamp: time.Now(),
+	Fields: mapstr.M{
+		"http": mapstr.M{
+			"request":  mapstr.M{"method": "POST"},
+			"response": mapstr.M{"status_code": 503},
+		},
+		"url":    mapstr.M{"path": "/api/v1/users"},
+		"event":  mapstr.M{"duration": int64(7 * time.Second), "outcome": "failure"},
+		"source": mapstr.M{"ip": "10.1.2.3"},
+		"tags":   []string{"prod", "eu-west-1"},
+		"retry":  true,
+		"count":  "12",
+		"user":   mapstr.M{"name": "O'Brien"},
+	},
+}
+
+func TestExprCondition(t *testing.T) {
+	tests := map[string]bool{
+		`http.response.status_code >= 500 && url.path startsWith "/api"`:  true,
+		`http.response.status_code >= 500 and url.path startsWith "/web"`: false,
+		`http.response.status_code < 500 || url.path endsWith "/users"`:   true,
+		`http.response.status_code == 503`:                                true,
+		`http.response.status_code != 503`:                                false,
+		`count == 12 && count > 11.5`:                                     true,
+		`http.request.method in ["GET", "POST"]`:                          true,
+		`http.request.method in ["GET", "HEAD"]`:                          false,
+		`event.duration > "5s"`:                                           true,
+		`event.duration > "10s"`:                                          false,
+		`url.path matches "^/api/v[0-9]+/"`:                               true,
+		`url.path glob "/api/*/users"`:                                    true,
+		`url.path glob "/api/*"`:                                          false,
+		`url.path contains "v1"`:                                          true,
+		`tags contains "prod"`:                                            true,
+		`tags contains "pro"`:                                             false,
+		`tags == "eu-west-1"`:                                             true,
+		`tags glob "eu-*"`:                                                true,
+		`!(event.outcome == "success")`:                                   true,
+		`not event.outcome == "failure"`:                                  false,
+		`retry`:                                                           true,
+		`retry == false`:                                                  false,
+		`exists(url.path) && !exists(url.query)`:                          true,
+		`url.query == null`:                                               true,
+		`url.path != null`:                                                true,
+		`url.query == "a"`:                                                false,
+		`url.query != "a"`:                                                false,
+		`network(source.ip, "private") && !network(source.ip, "loopback", "192.168.0.0/16")`: true,
+		`user.name == 'O\'Brien'`: true,
+		`(http.response.status_code >= 500 || event.outcome == "failure") && (tags contains "dev" || tags contains "prod")`: true,
+		`http.response.status_code > count`: true,
+	}
+	for source, expected := range tests {
+		t.Run(source, func(t *testing.T) {
+			testConfig(t, expected, exprTestEvent, &Config{Expr: source})
+		})
+	}
+}
+
+func TestExprConditionFromConfig(t *testing.T) {
+	c, err := conf.NewConfigWithYAML([]byte(`expr: 'http.response.status_code >= 500 && url.path startsWith "/api"'`), "test")
+	require.NoError(t, err)
+	var config Config
+	require.NoError(t, c.Unpack(&config))
+
+	cond, err := NewCondition(&config, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	assert.True(t, cond.Check(exprTestEvent))
+	assert.Equal(t, `expr: http.response.status_code >= 500 && url.path startsWith "/api"`, cond.String())
+}
+
+func TestExprConditionInvalid(t *testing.T) {
+	tests := map[string]string{
+		`http.response.status_code >=`:  "unexpected end of expression",
+		`a == 1 b == 2`:                 `unexpected "b" at position 7`,
+		`(a == 1`:                       "unexpected end of expression",
+		`a == "b`:                       "unterminated string",
+		`a = 1`:                         `unexpected character '='`,
+		`a matches "("`:                 "missing closing )",
+		`a matches b`:                   "expects a pattern string",
+		`a in "b"`:                      "expects a list",
+		`a in [b]`:                      `lists can only contain literals`,
+		`a > "soon"`:                    `value "soon" is not a number, a duration or an RFC 3339 timestamp`,
+		`a < null`:                      "null can only be compared with == and !=",
+		`network(source.ip)`:            "expects at least one network",
+		`network(source.ip, "nowhere")`: "failed to parse CIDR",
+		`exists("a")`:                   `unexpected "a"`,
+		`a == 1 and`:                    "unexpected end of expression",
+		`network(source.ip, private)`:   `expects network strings`,
+	}
+	for source, wantErr := range tests {
+		t.Run(source, func(t *testing.T) {
+			_, err := NewCondition(&Config{Expr: source}, logptest.NewTestingLogger(t, ""))
+			assert.ErrorContains(t, err, wantErr)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// fileReloader holds a value loaded from a file and reloads it when the
+// file changes. The file is checked for changes at most once per reload
+// interval, reloading is disabled when the interval is zero.
+//
+// Getting the value doesn't take any lock, so it can be used on the hot path
+// of conditions. Only the goroutine that finds a check due stats and loads
+// the file, others keep using the current value in the meantime.
+type fileReloader[T any] struct {
+	path           string
+	reloadInterval time.Duration
+	load           func(path string) (T, error)
+	name           string
+	log            *logp.Logger
+
+	value     atomic.Pointer[T]
+	nextCheck atomic.Int64 // Unix time in nanoseconds.
+
+	mu      sync.Mutex // Serializes reloads, protects modTime and size.
+	modTime time.Time
+	size    int64
+}
+
+// newFileReloader loads the value from the file at path with load. name is
+// the name of the condition, used in errors and logs.
+func newFileReloader[T any](path string, reloadInterval time.Duration, load func(string) (T, error), name string, log *logp.Logger) (*fileReloader[T], error) {
+	r := &fileReloader[T]{
+		path:           path,
+		reloadInterval: reloadInterval,
+		load:           load,
+		name:           name,
+		log:            log,
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed to open file '%v': %w", name, path, err)
+	}
+	value, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+	r.value.Store(&value)
+	r.modTime = info.ModTime()
+	r.size = info.Size()
+	r.nextCheck.Store(time.Now().Add(reloadInterval).UnixNano())
+	return r, nil
+}
+
+// get returns the current value, reloading it first if a check of the file
+// is due.
+func (r *fileReloader[T]) get() T {
+	if r.reloadInterval > 0 && time.Now().UnixNano() >= r.nextCheck.Load() {
+		r.maybeReload()
+	}
+	return *r.value.Load()
+}
+
+// maybeReload reloads the value if the file changed. On failure the
+// previously loaded value is kept.
+func (r *fileReloader[T]) maybeReload() {
+	if !r.mu.TryLock() {
+		// Another goroutine is checking the file.
+		return
+	}
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if now.UnixNano() < r.nextCheck.Load() {
+		return
+	}
+	r.nextCheck.Store(now.Add(r.reloadInterval).UnixNano())
+
+	info, err := os.Stat(r.path)
+	if err != nil {
+		r.log.Warnf("%s failed to check file '%v', keeping previous values: %v", r.name, r.path, err)
+		return
+	}
+	if info.ModTime().Equal(r.modTime) && info.Size() == r.size {
+		return
+	}
+
+	value, err := r.load(r.path)
+	if err != nil {
+		r.log.Warnf("%s failed to reload file '%v', keeping previous values: %v", r.name, r.path, err)
+		return
+	}
+	r.value.Store(&value)
+	r.modTime = info.ModTime()
+	r.size = info.Size()
+	r.log.Debugf("%s reloaded file '%v'", r.name, r.path)
+}
@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+func TestFileReloaderConcurrentGet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "value.txt")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o644))
+
+	var loads atomic.Int32
+	load := func(path string) (string, error) {
+		loads.Add(1)
+		b, err := os.ReadFile(path)
+		return strings.TrimSpace(string(b)), err
+	}
+	r, err := newFileReloader(path, time.Millisecond, load, "test condition", logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	assert.Equal(t, "v1", r.get())
+
+	require.NoError(t, os.WriteFile(path, []byte("v2-updated"), 0o644))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			deadline := time.Now().Add(time.Second)
+			for time.Now().Before(deadline) {
+				if r.get() == "v2-updated" {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, "v2-updated", r.get())
+	// The file is loaded once at creation and once after it changed, checks
+	// of an unchanged file don't load it again.
+	assert.EqualValues(t, 2, loads.Load())
+}
+
+func TestFileReloaderDisabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "value.txt")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o644))
+
+	load := func(path string) (string, error) {
+		b, err := os.ReadFile(path)
+		return string(b), err
+	}
+	r, err := newFileReloader(path, 0, load, "test condition", logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("v2-updated"), 0o644))
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, "v1", r.get())
+}
@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// InFileConfig is the configuration of the in_file condition.
+type InFileConfig struct {
+	Field string `config:"field" validate:"required"`
+	Path  string `config:"path" validate:"required"`
+
+	// ReloadInterval is the minimum time between two checks of the file
+	// for changes. Reloading is disabled when it is zero.
+	ReloadInterval time.Duration `config:"reload_interval" validate:"min=0"`
+}
+
+// InFile is a Condition for testing whether a field value is part of a
+// list of values loaded from a file. The file contains one value per line,
+// empty lines and lines starting with # are ignored.
+type InFile struct {
+	field  string
+	path   string
+	values *fileReloader[map[string]struct{}]
+}
+
+// NewInFileCondition builds a new InFile condition loading the values from
+// the configured file.
+func NewInFileCondition(config InFileConfig, log *logp.Logger) (*InFile, error) {
+	if config.Field == "" {
+		return nil, errors.New("in_file condition requires a field")
+	}
+	if config.Path == "" {
+		return nil, errors.New("in_file condition requires a path")
+	}
+
+	values, err := newFileReloader(config.Path, config.ReloadInterval, readValuesFile, "in_file condition", log.Named(logName))
+	if err != nil {
+		return nil, err
+	}
+	return &InFile{field: config.Field, path: config.Path, values: values}, nil
+}
+
+// Check determines whether the given event matches this condition.
+func (c *InFile) Check(event ValuesMap) bool {
+	value, err := event.GetValue(c.field)
+	if err != nil {
+		return false
+	}
+
+	values := c.values.get()
+	switch v := value.(type) {
+	case []string:
+		for _, s := range v {
+			if _, found := values[s]; found {
+				return true
+			}
+		}
+		return false
+	case []interface{}:
+		for _, elem := range v {
+			if _, found := values[fmt.Sprint(elem)]; found {
+				return true
+			}
+		}
+		return false
+	default:
+		_, found := values[fmt.Sprint(v)]
+		return found
+	}
+}
+
+func (c *InFile) String() string {
+	return fmt.Sprintf("in_file: %v:%v", c.field, c.path)
+}
+
+// readValuesFile reads the set of values from the given file, one value
+// per line.
+func readValuesFile(path string) (map[string]struct{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("in_file condition failed to open file '%v': %w", path, err)
+	}
+	defer f.Close()
+
+	values := map[string]struct{}{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		values[line] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("in_file condition failed to read file '%v': %w", path, err)
+	}
+	return values, nil
+}
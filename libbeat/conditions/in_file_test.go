@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestInFileCreate(t *testing.T) {
+	_, err := NewCondition(&Config{
+		InFile: &InFileConfig{Field: "source.ip", Path: filepath.Join("testdata", "missing.txt")},
+	}, logptest.NewTestingLogger(t, ""))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing.txt")
+}
+
+func TestInFileConfig(t *testing.T) {
+	c, err := conf.NewConfigFrom(map[string]interface{}{
+		"in_file.field": "source.ip",
+		"in_file.path":  filepath.Join("testdata", "in_file.txt"),
+	})
+	require.NoError(t, err)
+
+	var config Config
+	require.NoError(t, c.Unpack(&config))
+
+	testConfig(t, true, sourceIPEvent("10.0.0.1"), &config)
+}
+
+func TestInFileMatch(t *testing.T) {
+	config := &Config{
+		InFile: &InFileConfig{Field: "source.ip", Path: filepath.Join("testdata", "in_file.txt")},
+	}
+
+	tests := map[string]struct {
+		value    interface{}
+		expected bool
+	}{
+		"present":                {value: "192.168.1.10", expected: true},
+		"absent":                 {value: "192.168.1.11", expected: false},
+		"comments are ignored":   {value: "# blocked addresses", expected: false},
+		"string array match":     {value: []string{"127.0.0.1", "10.0.0.1"}, expected: true},
+		"string array no match":  {value: []string{"127.0.0.1"}, expected: false},
+		"interface array match":  {value: []interface{}{"evil.example.com"}, expected: true},
+		"interface array absent": {value: []interface{}{"example.com"}, expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			testConfig(t, test.expected, sourceIPEvent(test.value), config)
+		})
+	}
+
+	t.Run("missing field", func(t *testing.T) {
+		testConfig(t, false, &beat.Event{Fields: mapstr.M{}}, config)
+	})
+}
+
+func TestInFileReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "list.txt")
+	require.NoError(t, os.WriteFile(path, []byte("foo\n"), 0o644))
+
+	cond, err := NewInFileCondition(InFileConfig{
+		Field:          "source.ip",
+		Path:           path,
+		ReloadInterval: time.Millisecond,
+	}, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	assert.True(t, cond.Check(sourceIPEvent("foo")))
+	assert.False(t, cond.Check(sourceIPEvent("bar")))
+
+	require.NoError(t, os.WriteFile(path, []byte("foo\nbar\n"), 0o644))
+	assert.Eventually(t, func() bool {
+		return cond.Check(sourceIPEvent("bar"))
+	}, time.Second, 5*time.Millisecond)
+
+	// Values are kept when the file disappears.
+	require.NoError(t, os.Remove(path))
+	time.Sleep(5 * time.Millisecond)
+	assert.True(t, cond.Check(sourceIPEvent("bar")))
+}
+
+func sourceIPEvent(value interface{}) *beat.Event {
+	return &beat.Event{
+		Timestamp: time.Now(),
+		Fields: mapstr.M{
+			"source": mapstr.M{
+				"ip": value,
+			},
+		},
+	}
+}
@@ -126,3 +126,60 @@ func TestRegexpCondition(t *testing.T) {
 	assert.True(t, conds[1].Check(event1))
 	assert.False(t, conds[2].Check(event1))
 }
+
+func TestGlobCondition(t *testing.T) {
+	event := &beat.Event{
+		Timestamp: time.Now(),
+		Fields: mapstr.M{
+			"log": mapstr.M{
+				"file": mapstr.M{
+					"path": "/var/log/app-web/Error-1.log",
+				},
+			},
+			"tags": []string{"prod", "eu-west-1"},
+		},
+	}
+
+	tests := map[string]struct {
+		config   Config
+		expected bool
+	}{
+		"match": {
+			config:   Config{Glob: &Fields{fields: map[string]interface{}{"log.file.path": "/var/log/app-*/*.log"}}},
+			expected: true,
+		},
+		"star does not match slash": {
+			config:   Config{Glob: &Fields{fields: map[string]interface{}{"log.file.path": "/var/log/*.log"}}},
+			expected: false,
+		},
+		"case sensitive": {
+			config:   Config{Glob: &Fields{fields: map[string]interface{}{"log.file.path": "/var/log/app-*/error*.log"}}},
+			expected: false,
+		},
+		"ignore case": {
+			config:   Config{Glob: &Fields{fields: map[string]interface{}{"log.file.path": "/var/log/app-*/error*.log"}}, IgnoreCase: true},
+			expected: true,
+		},
+		"array": {
+			config:   Config{Glob: &Fields{fields: map[string]interface{}{"tags": "eu-*-[0-9]"}}},
+			expected: true,
+		},
+		"missing field": {
+			config:   Config{Glob: &Fields{fields: map[string]interface{}{"message": "*"}}},
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			testConfig(t, test.expected, event, &test.config)
+		})
+	}
+}
+
+func TestGlobCreate(t *testing.T) {
+	_, err := NewCondition(&Config{Glob: &Fields{fields: map[string]interface{}{"path": "[a-"}}}, logptest.NewTestingLogger(t, ""))
+	assert.ErrorContains(t, err, "unterminated character class")
+
+	_, err = NewCondition(&Config{Regexp: &Fields{fields: map[string]interface{}{"path": "a"}}, IgnoreCase: true}, logptest.NewTestingLogger(t, ""))
+	assert.ErrorContains(t, err, "ignore_case is only supported by glob conditions")
+}
@@ -0,0 +1,42 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import "fmt"
+
+// MissingFields is a Condition for checking field absence.
+type MissingFields []string
+
+// NewMissingFieldsCondition builds a new MissingFields checking the given list of fields.
+func NewMissingFieldsCondition(fields []string) MissingFields {
+	return MissingFields(fields)
+}
+
+// Check determines whether the given event matches this condition
+func (c MissingFields) Check(event ValuesMap) bool {
+	for _, field := range c {
+		if _, err := event.GetValue(field); err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (c MissingFields) String() string {
+	return fmt.Sprintf("missing_fields: %v", []string(c))
+}
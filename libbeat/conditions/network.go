@@ -22,6 +22,7 @@ import (
 	"net"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -120,7 +121,42 @@ func NewNetworkCondition(fields map[string]interface{}, logger *logp.Logger) (*N
 		log:    logger.Named(logName),
 	}
 
-	for field, value := range mapstr.M(fields).Flatten() {
+	networks := mapstr.M(fields).Flatten()
+	for key, value := range networks {
+		field, ok := strings.CutSuffix(key, "."+networkFromFileKey)
+		if !ok {
+			continue
+		}
+		path, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("network condition attempted to set "+
+				"'%v' -> '%v' and encountered unexpected type '%T', only "+
+				"a file path string is allowed", key, value, value)
+		}
+
+		var reloadInterval time.Duration
+		reloadKey := field + "." + networkReloadIntervalKey
+		if v, found := networks[reloadKey]; found {
+			var err error
+			if reloadInterval, err = parseReloadInterval(v); err != nil {
+				return nil, fmt.Errorf("network condition invalid '%v': %w", reloadKey, err)
+			}
+			delete(networks, reloadKey)
+		}
+
+		m, err := newFileNetworkMatcher(path, reloadInterval, cond.log)
+		if err != nil {
+			return nil, err
+		}
+		cond.fields[field] = m
+		delete(networks, key)
+	}
+
+	for field, value := range networks {
+		if strings.HasSuffix(field, "."+networkReloadIntervalKey) {
+			return nil, fmt.Errorf("network condition '%v' requires '%v.%v'", field,
+				strings.TrimSuffix(field, "."+networkReloadIntervalKey), networkFromFileKey)
+		}
 		switch v := value.(type) {
 		case string:
 			m, err := makeMatcher(v)
@@ -192,6 +228,27 @@ func (c *Network) String() string {
 	return sb.String()
 }
 
+// parseReloadInterval parses a duration string, or a number of seconds.
+func parseReloadInterval(value interface{}) (time.Duration, error) {
+	var d time.Duration
+	if s, ok := value.(string); ok {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, err
+		}
+	} else {
+		seconds, err := ExtractFloat(value)
+		if err != nil {
+			return 0, err
+		}
+		d = time.Duration(seconds * float64(time.Second))
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("negative duration %v", d)
+	}
+	return d, nil
+}
+
 // parseCIDR parses a network CIDR.
 func parseCIDR(value string) (*net.IPNet, error) {
 	_, mask, err := net.ParseCIDR(value)
@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	networkFromFileKey       = "from_file"
+	networkReloadIntervalKey = "reload_interval"
+)
+
+// prefixSet is a set of network prefixes that is looked up in a time
+// proportional to the number of distinct prefix lengths, so that large sets
+// of networks can be matched.
+type prefixSet struct {
+	bits4, bits6 []int
+	prefixes     map[netip.Prefix]struct{}
+}
+
+func (s *prefixSet) add(p netip.Prefix) {
+	p = p.Masked()
+	if _, found := s.prefixes[p]; found {
+		return
+	}
+	s.prefixes[p] = struct{}{}
+	bits := &s.bits6
+	if p.Addr().Is4() {
+		bits = &s.bits4
+	}
+	if !slices.Contains(*bits, p.Bits()) {
+		*bits = append(*bits, p.Bits())
+	}
+}
+
+func (s *prefixSet) contains(ip net.IP) bool {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return false
+	}
+	addr = addr.Unmap()
+	bits := s.bits6
+	if addr.Is4() {
+		bits = s.bits4
+	}
+	for _, b := range bits {
+		p, err := addr.Prefix(b)
+		if err != nil {
+			continue
+		}
+		if _, found := s.prefixes[p]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// fileNetworkMatcher matches IP addresses against the networks listed in a
+// file, one CIDR or IP address per line. Empty lines and lines starting
+// with # are ignored.
+type fileNetworkMatcher struct {
+	path     string
+	networks *fileReloader[*prefixSet]
+}
+
+// newFileNetworkMatcher builds a matcher for the networks of a file. The
+// file is checked for changes at most once per reload interval, reloading
+// is disabled when it is zero.
+func newFileNetworkMatcher(path string, reloadInterval time.Duration, log *logp.Logger) (*fileNetworkMatcher, error) {
+	networks, err := newFileReloader(path, reloadInterval, readNetworksFile, "network condition", log)
+	if err != nil {
+		return nil, err
+	}
+	return &fileNetworkMatcher{path: path, networks: networks}, nil
+}
+
+func (m *fileNetworkMatcher) Contains(ip net.IP) bool {
+	return m.networks.get().contains(ip)
+}
+
+func (m *fileNetworkMatcher) String() string {
+	return "file:" + m.path
+}
+
+// readNetworksFile reads the set of networks from the given file, one CIDR
+// or IP address per line.
+func readNetworksFile(path string) (*prefixSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("network condition failed to open file '%v': %w", path, err)
+	}
+	defer f.Close()
+
+	networks := &prefixSet{prefixes: map[netip.Prefix]struct{}{}}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p, err := netip.ParsePrefix(line)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(line)
+			if addrErr != nil {
+				return nil, fmt.Errorf("network condition failed to parse line %d of file '%v', "+
+					"values must be a CIDR like '192.0.2.0/24' or an IP address: %w", n, path, err)
+			}
+			p = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen())
+		}
+		networks.add(p)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("network condition failed to read file '%v': %w", path, err)
+	}
+	return networks, nil
+}
@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+func TestNetworkFromFileConfig(t *testing.T) {
+	c, err := conf.NewConfigWithYAML([]byte(`
+network:
+  source.ip.from_file: testdata/networks.txt
+`), "test")
+	require.NoError(t, err)
+
+	var config Config
+	require.NoError(t, c.Unpack(&config))
+
+	tests := map[string]struct {
+		value    interface{}
+		expected bool
+	}{
+		"in CIDR":              {value: "192.0.2.33", expected: true},
+		"in wider CIDR":        {value: "198.51.103.255", expected: true},
+		"outside CIDR":         {value: "198.51.104.0", expected: false},
+		"single address":       {value: "203.0.113.7", expected: true},
+		"next address":         {value: "203.0.113.8", expected: false},
+		"IPv6":                 {value: "2001:db8::1", expected: true},
+		"IPv4-mapped IPv6":     {value: "::ffff:192.0.2.1", expected: true},
+		"IP list":              {value: []string{"10.0.0.1", "192.0.2.1"}, expected: true},
+		"invalid IP":           {value: "not-an-ip", expected: false},
+		"comments are ignored": {value: "# Example cloud provider ranges", expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			testConfig(t, test.expected, sourceIPEvent(test.value), &config)
+		})
+	}
+}
+
+func TestNetworkFromFileCreate(t *testing.T) {
+	tests := map[string]struct {
+		network map[string]interface{}
+		err     string
+	}{
+		"missing file": {
+			network: map[string]interface{}{"source.ip.from_file": filepath.Join("testdata", "missing.txt")},
+			err:     "missing.txt",
+		},
+		"invalid file": {
+			network: map[string]interface{}{"source.ip.from_file": filepath.Join("testdata", "in_file.txt")},
+			err:     "failed to parse line 5",
+		},
+		"invalid reload interval": {
+			network: map[string]interface{}{
+				"source.ip.from_file":       filepath.Join("testdata", "networks.txt"),
+				"source.ip.reload_interval": "often",
+			},
+			err: "invalid 'source.ip.reload_interval'",
+		},
+		"reload interval without file": {
+			network: map[string]interface{}{"source.ip.reload_interval": "1m"},
+			err:     "requires 'source.ip.from_file'",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewNetworkCondition(test.network, logptest.NewTestingLogger(t, ""))
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestNetworkFromFileReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "networks.txt")
+	require.NoError(t, os.WriteFile(path, []byte("192.0.2.0/24\n"), 0o644))
+
+	cond, err := NewNetworkCondition(map[string]interface{}{
+		"source": map[string]interface{}{
+			"ip": map[string]interface{}{
+				"from_file":       path,
+				"reload_interval": "1ms",
+			},
+		},
+	}, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	assert.Equal(t, "network:(source.ip:file:"+path+")", cond.String())
+
+	assert.True(t, cond.Check(sourceIPEvent("192.0.2.1")))
+	assert.False(t, cond.Check(sourceIPEvent("198.51.100.1")))
+
+	require.NoError(t, os.WriteFile(path, []byte("192.0.2.0/24\n198.51.100.0/24\n"), 0o644))
+	assert.Eventually(t, func() bool {
+		return cond.Check(sourceIPEvent("198.51.100.1"))
+	}, time.Second, 5*time.Millisecond)
+
+	// Networks are kept when the file becomes invalid.
+	require.NoError(t, os.WriteFile(path, []byte("192.0.2.0/33\n"), 0o644))
+	time.Sleep(5 * time.Millisecond)
+	assert.True(t, cond.Check(sourceIPEvent("198.51.100.1")))
+}
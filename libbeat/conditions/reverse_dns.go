@@ -0,0 +1,278 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	defaultReverseDNSTimeout         = 500 * time.Millisecond
+	defaultReverseDNSCacheTTL        = time.Hour
+	defaultReverseDNSCacheFailureTTL = time.Minute
+	defaultReverseDNSCacheMaxSize    = 10000
+
+	// reverseDNSMaxLookups is the maximum number of lookups in flight.
+	reverseDNSMaxLookups = 64
+)
+
+// ReverseDNSConfig is the configuration of the reverse_dns condition.
+type ReverseDNSConfig struct {
+	Field   string        `config:"field" validate:"required"`
+	Pattern string        `config:"pattern" validate:"required"`
+	Timeout time.Duration `config:"timeout" validate:"min=0"`
+	Cache   struct {
+		TTL        time.Duration `config:"ttl" validate:"min=0"`
+		FailureTTL time.Duration `config:"failure_ttl" validate:"min=0"`
+		MaxSize    int           `config:"max_size" validate:"min=0"`
+	} `config:"cache"`
+}
+
+// lookupAddrFunc performs a reverse lookup of the given address.
+type lookupAddrFunc func(ctx context.Context, addr string) ([]string, error)
+
+// ReverseDNS is a Condition that performs a reverse DNS lookup of an IP
+// address field and matches the resulting hostnames against a pattern.
+//
+// An event waits at most for the lookup timeout for the hostnames of an
+// address that is not cached. Concurrent lookups of the same address are
+// deduplicated. A lookup that doesn't complete in time keeps running in the
+// background for the next events, the events that didn't wait for it don't
+// match, as do timeouts and failures.
+type ReverseDNS struct {
+	field      string
+	pattern    string
+	matcher    match.Matcher
+	timeout    time.Duration
+	lookupAddr lookupAddrFunc
+	cache      *reverseDNSCache
+	log        *logp.Logger
+
+	lookups  singleflight.Group
+	inflight chan struct{}
+}
+
+// NewReverseDNSCondition builds a new ReverseDNS condition using the given
+// configuration.
+func NewReverseDNSCondition(config ReverseDNSConfig, log *logp.Logger) (*ReverseDNS, error) {
+	if config.Field == "" {
+		return nil, errors.New("reverse_dns condition requires a field")
+	}
+	if config.Pattern == "" {
+		return nil, errors.New("reverse_dns condition requires a pattern")
+	}
+	matcher, err := match.Compile(config.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("reverse_dns condition failed to compile pattern '%v': %w", config.Pattern, err)
+	}
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultReverseDNSTimeout
+	}
+	ttl := config.Cache.TTL
+	if ttl == 0 {
+		ttl = defaultReverseDNSCacheTTL
+	}
+	failureTTL := config.Cache.FailureTTL
+	if failureTTL == 0 {
+		failureTTL = defaultReverseDNSCacheFailureTTL
+	}
+	maxSize := config.Cache.MaxSize
+	if maxSize == 0 {
+		maxSize = defaultReverseDNSCacheMaxSize
+	}
+
+	return &ReverseDNS{
+		field:      config.Field,
+		pattern:    config.Pattern,
+		matcher:    matcher,
+		timeout:    timeout,
+		lookupAddr: net.DefaultResolver.LookupAddr,
+		cache:      newReverseDNSCache(ttl, failureTTL, maxSize),
+		log:        log.Named(logName),
+		inflight:   make(chan struct{}, reverseDNSMaxLookups),
+	}, nil
+}
+
+// Check determines whether the given event matches this condition.
+func (c *ReverseDNS) Check(event ValuesMap) bool {
+	value, err := event.GetValue(c.field)
+	if err != nil {
+		return false
+	}
+
+	for _, ip := range extractIP(value) {
+		if ip == nil {
+			continue
+		}
+		for _, name := range c.lookup(ip.String()) {
+			if c.matcher.MatchString(name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookup returns the cached hostnames of the given address. On a cache miss
+// the address is looked up, waiting at most for the lookup timeout. No
+// hostnames are returned if the lookup doesn't complete in time.
+func (c *ReverseDNS) lookup(addr string) []string {
+	if names, found := c.cache.get(addr, time.Now()); found {
+		return names
+	}
+	// The result channel is buffered, it doesn't need to be read when the
+	// wait times out.
+	result := c.lookups.DoChan(addr, func() (interface{}, error) {
+		return c.resolve(addr), nil
+	})
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+	select {
+	case res := <-result:
+		names, _ := res.Val.([]string)
+		return names
+	case <-timer.C:
+		c.log.Debugf("reverse_dns condition lookup of %v didn't complete in time", addr)
+		return nil
+	}
+}
+
+// resolve looks up the hostnames of the given address and caches the result.
+// Failed lookups are cached as an address without hostnames.
+func (c *ReverseDNS) resolve(addr string) []string {
+	select {
+	case c.inflight <- struct{}{}:
+		defer func() { <-c.inflight }()
+	default:
+		// Too many lookups in flight, the address is looked up again by the
+		// next event that contains it.
+		c.log.Debugf("reverse_dns condition lookup of %v skipped, too many lookups in flight", addr)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	names, err := c.lookupAddr(ctx, addr)
+	if err != nil {
+		c.log.Debugf("reverse_dns condition lookup of %v failed: %v", addr, err)
+		c.cache.set(addr, nil, false, time.Now())
+		return nil
+	}
+	for i, name := range names {
+		names[i] = strings.TrimSuffix(name, ".")
+	}
+	c.cache.set(addr, names, true, time.Now())
+	return names
+}
+
+func (c *ReverseDNS) String() string {
+	return fmt.Sprintf("reverse_dns: %v:%v", c.field, c.pattern)
+}
+
+type reverseDNSCacheEntry struct {
+	names   []string
+	expires time.Time
+	// elem is the element of the address in the insertion order list.
+	elem *list.Element
+}
+
+// reverseDNSCache is a size bounded cache of reverse lookup results.
+// Successful and failed lookups expire after different durations. When the
+// cache is full, the oldest entry is evicted.
+type reverseDNSCache struct {
+	mu         sync.Mutex
+	entries    map[string]*reverseDNSCacheEntry
+	order      *list.List // Addresses, from the oldest to the newest entry.
+	ttl        time.Duration
+	failureTTL time.Duration
+	maxSize    int
+}
+
+func newReverseDNSCache(ttl, failureTTL time.Duration, maxSize int) *reverseDNSCache {
+	return &reverseDNSCache{
+		entries:    map[string]*reverseDNSCacheEntry{},
+		order:      list.New(),
+		ttl:        ttl,
+		failureTTL: failureTTL,
+		maxSize:    maxSize,
+	}
+}
+
+func (c *reverseDNSCache) get(addr string, now time.Time) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, found := c.entries[addr]
+	if !found {
+		return nil, false
+	}
+	if now.After(entry.expires) {
+		c.remove(addr, entry)
+		return nil, false
+	}
+	return entry.names, true
+}
+
+func (c *reverseDNSCache) set(addr string, names []string, success bool, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, found := c.entries[addr]; found {
+		c.remove(addr, entry)
+	}
+	for len(c.entries) >= c.maxSize {
+		oldest := c.order.Front()
+		oldestAddr, _ := oldest.Value.(string)
+		c.remove(oldestAddr, c.entries[oldestAddr])
+	}
+
+	ttl := c.ttl
+	if !success {
+		ttl = c.failureTTL
+	}
+	c.entries[addr] = &reverseDNSCacheEntry{
+		names:   names,
+		expires: now.Add(ttl),
+		elem:    c.order.PushBack(addr),
+	}
+}
+
+func (c *reverseDNSCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *reverseDNSCache) remove(addr string, entry *reverseDNSCacheEntry) {
+	c.order.Remove(entry.elem)
+	delete(c.entries, addr)
+}
@@ -0,0 +1,211 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestReverseDNSCreate(t *testing.T) {
+	logger := logptest.NewTestingLogger(t, "")
+
+	_, err := NewCondition(&Config{ReverseDNS: &ReverseDNSConfig{Field: "source.ip"}}, logger)
+	assert.Error(t, err)
+
+	_, err = NewCondition(&Config{ReverseDNS: &ReverseDNSConfig{Field: "source.ip", Pattern: "("}}, logger)
+	assert.Error(t, err)
+
+	c, err := conf.NewConfigFrom(map[string]interface{}{
+		"reverse_dns.field":             "source.ip",
+		"reverse_dns.pattern":           `\.example\.com$`,
+		"reverse_dns.timeout":           "100ms",
+		"reverse_dns.cache.ttl":         "5m",
+		"reverse_dns.cache.failure_ttl": "10s",
+	})
+	require.NoError(t, err)
+	var config Config
+	require.NoError(t, c.Unpack(&config))
+
+	cond, err := NewCondition(&config, logger)
+	require.NoError(t, err)
+	rdns, ok := cond.(*ReverseDNS)
+	require.True(t, ok)
+	assert.Equal(t, 100*time.Millisecond, rdns.timeout)
+	assert.Equal(t, 5*time.Minute, rdns.cache.ttl)
+	assert.Equal(t, 10*time.Second, rdns.cache.failureTTL)
+	assert.Equal(t, defaultReverseDNSCacheMaxSize, rdns.cache.maxSize)
+}
+
+func TestReverseDNSCheck(t *testing.T) {
+	var lookups atomic.Int32
+	cond := newTestReverseDNS(t, func(ctx context.Context, addr string) ([]string, error) {
+		lookups.Add(1)
+		switch addr {
+		case "192.0.2.1":
+			return []string{"host-1.example.com."}, nil
+		case "192.0.2.2":
+			return []string{"host-2.example.org."}, nil
+		case "192.0.2.3":
+			// simulates a resolver that does not answer in time
+			<-ctx.Done()
+			return nil, ctx.Err()
+		default:
+			return nil, errors.New("no such host")
+		}
+	})
+
+	// The first event containing an address waits for its lookup.
+	assert.True(t, cond.Check(sourceIPEvent("192.0.2.1")))
+
+	for _, ip := range []string{"192.0.2.2", "192.0.2.3", "192.0.2.4"} {
+		assert.False(t, cond.Check(sourceIPEvent(ip)))
+	}
+	assert.Eventually(t, func() bool {
+		return lookups.Load() == 4 && cond.cache.len() == 4
+	}, time.Second, time.Millisecond)
+	assert.False(t, cond.Check(sourceIPEvent("192.0.2.2")))
+	assert.False(t, cond.Check(sourceIPEvent("192.0.2.3")))
+	assert.False(t, cond.Check(sourceIPEvent("192.0.2.4")))
+	assert.False(t, cond.Check(sourceIPEvent("not an ip")))
+	assert.False(t, cond.Check(&beat.Event{Fields: mapstr.M{}}))
+	assert.True(t, cond.Check(sourceIPEvent([]string{"192.0.2.2", "192.0.2.1"})))
+
+	// Successful and failed lookups are cached.
+	assert.True(t, cond.Check(sourceIPEvent("192.0.2.1")))
+	assert.False(t, cond.Check(sourceIPEvent("192.0.2.3")))
+	assert.Equal(t, int32(4), lookups.Load())
+}
+
+func TestReverseDNSLookupDeduplication(t *testing.T) {
+	var lookups atomic.Int32
+	release := make(chan struct{})
+	cond := newTestReverseDNS(t, func(ctx context.Context, addr string) ([]string, error) {
+		lookups.Add(1)
+		<-release
+		return []string{"host-1.example.com."}, nil
+	})
+
+	// A burst of events for the same address triggers a single lookup, and
+	// the events wait for it at most for the lookup timeout.
+	var wg sync.WaitGroup
+	var matches atomic.Int32
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if cond.Check(sourceIPEvent("192.0.2.1")) {
+				matches.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(0), matches.Load())
+
+	// The lookup keeps running in the background for the next events.
+	close(release)
+	assert.Eventually(t, func() bool {
+		return cond.Check(sourceIPEvent("192.0.2.1"))
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(1), lookups.Load())
+}
+
+func TestReverseDNSNot(t *testing.T) {
+	release := make(chan struct{})
+	cond := newTestReverseDNS(t, func(ctx context.Context, addr string) ([]string, error) {
+		if addr == "192.0.2.2" {
+			<-release
+		}
+		return []string{"host.example.com."}, nil
+	})
+	not, err := NewNotCondition(cond)
+	require.NoError(t, err)
+
+	// A lookup that completes in time is used by the first event.
+	assert.False(t, not.Check(sourceIPEvent("192.0.2.1")))
+
+	// The events that don't wait for a slow lookup don't match the
+	// reverse_dns condition, so they match its negation.
+	start := time.Now()
+	assert.True(t, not.Check(sourceIPEvent("192.0.2.2")))
+	assert.GreaterOrEqual(t, time.Since(start), cond.timeout)
+	close(release)
+	assert.Eventually(t, func() bool {
+		return !not.Check(sourceIPEvent("192.0.2.2"))
+	}, time.Second, time.Millisecond)
+}
+
+func TestReverseDNSCacheExpiration(t *testing.T) {
+	cache := newReverseDNSCache(time.Minute, time.Second, 2)
+	now := time.Now()
+
+	cache.set("192.0.2.1", []string{"host-1"}, true, now)
+	cache.set("192.0.2.2", nil, false, now)
+
+	names, found := cache.get("192.0.2.1", now.Add(30*time.Second))
+	assert.True(t, found)
+	assert.Equal(t, []string{"host-1"}, names)
+
+	_, found = cache.get("192.0.2.2", now.Add(2*time.Second))
+	assert.False(t, found, "failed lookups expire after the failure ttl")
+
+	// The cache is full, the oldest entry is evicted.
+	cache.set("192.0.2.2", nil, false, now)
+	cache.set("192.0.2.3", []string{"host-3"}, true, now)
+	_, found = cache.get("192.0.2.3", now)
+	assert.True(t, found)
+	_, found = cache.get("192.0.2.1", now)
+	assert.False(t, found)
+	_, found = cache.get("192.0.2.2", now)
+	assert.True(t, found)
+	assert.Equal(t, 2, cache.len())
+
+	// Setting an address again refreshes its entry.
+	later := now.Add(2 * time.Minute)
+	cache.set("192.0.2.2", []string{"host-2"}, true, later)
+	cache.set("192.0.2.4", []string{"host-4"}, true, later)
+	_, found = cache.get("192.0.2.3", later)
+	assert.False(t, found)
+	names, found = cache.get("192.0.2.2", later)
+	assert.True(t, found)
+	assert.Equal(t, []string{"host-2"}, names)
+}
+
+func newTestReverseDNS(t *testing.T, lookup lookupAddrFunc) *ReverseDNS {
+	t.Helper()
+	cond, err := NewReverseDNSCondition(ReverseDNSConfig{
+		Field:   "source.ip",
+		Pattern: `\.example\.com$`,
+		Timeout: 10 * time.Millisecond,
+	}, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	cond.lookupAddr = lookup
+	return cond
+}
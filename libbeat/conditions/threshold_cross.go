@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const defaultThresholdCrossMaxGroups = 10000
+
+// ThresholdCrossConfig is the configuration of the threshold_cross condition.
+type ThresholdCrossConfig struct {
+	Field     string   `config:"field" validate:"required"`
+	Threshold *float64 `config:"threshold" validate:"required"`
+	GroupBy   string   `config:"group_by"`
+	MaxGroups int      `config:"max_groups" validate:"min=0"`
+}
+
+// ThresholdCross is a stateful Condition that matches when a numeric field
+// goes from a value at or below the threshold to a value above it. The
+// previous value is tracked per value of the group_by field, and the number
+// of tracked groups is bounded.
+type ThresholdCross struct {
+	field     string
+	threshold float64
+	groupBy   string
+	maxGroups int
+	log       *logp.Logger
+
+	mu       sync.Mutex
+	previous map[string]float64
+}
+
+// NewThresholdCrossCondition builds a new ThresholdCross condition using the
+// given configuration.
+func NewThresholdCrossCondition(config ThresholdCrossConfig, log *logp.Logger) (*ThresholdCross, error) {
+	if config.Field == "" {
+		return nil, errors.New("threshold_cross condition requires a field")
+	}
+	if config.Threshold == nil {
+		return nil, errors.New("threshold_cross condition requires a threshold")
+	}
+	maxGroups := config.MaxGroups
+	if maxGroups == 0 {
+		maxGroups = defaultThresholdCrossMaxGroups
+	}
+
+	return &ThresholdCross{
+		field:     config.Field,
+		threshold: *config.Threshold,
+		groupBy:   config.GroupBy,
+		maxGroups: maxGroups,
+		log:       log.Named(logName),
+		previous:  map[string]float64{},
+	}, nil
+}
+
+// Check determines whether the given event crosses the threshold. The first
+// value seen for a group never matches, since there is nothing to compare it to.
+func (c *ThresholdCross) Check(event ValuesMap) bool {
+	value, err := event.GetValue(c.field)
+	if err != nil {
+		return false
+	}
+	current, err := ExtractFloat(value)
+	if err != nil {
+		c.log.Warnf("threshold_cross condition: %v", err)
+		return false
+	}
+
+	var group string
+	if c.groupBy != "" {
+		groupValue, err := event.GetValue(c.groupBy)
+		if err != nil {
+			return false
+		}
+		group = fmt.Sprint(groupValue)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	previous, found := c.previous[group]
+	if !found && len(c.previous) >= c.maxGroups {
+		// evict an arbitrary group to keep the state bounded, the evicted
+		// group starts over the next time it is seen
+		for k := range c.previous {
+			delete(c.previous, k)
+			break
+		}
+	}
+	c.previous[group] = current
+
+	return found && previous <= c.threshold && current > c.threshold
+}
+
+func (c *ThresholdCross) String() string {
+	if c.groupBy == "" {
+		return fmt.Sprintf("threshold_cross: %v > %v", c.field, c.threshold)
+	}
+	return fmt.Sprintf("threshold_cross: %v > %v by %v", c.field, c.threshold, c.groupBy)
+}
@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conditions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestThresholdCrossCreate(t *testing.T) {
+	logger := logptest.NewTestingLogger(t, "")
+
+	_, err := NewCondition(&Config{ThresholdCross: &ThresholdCrossConfig{Field: "cpu.pct"}}, logger)
+	assert.Error(t, err)
+
+	c, err := conf.NewConfigFrom(map[string]interface{}{
+		"threshold_cross.field":     "cpu.pct",
+		"threshold_cross.threshold": 0.9,
+		"threshold_cross.group_by":  "host.name",
+	})
+	require.NoError(t, err)
+	var config Config
+	require.NoError(t, c.Unpack(&config))
+
+	cond, err := NewCondition(&config, logger)
+	require.NoError(t, err)
+	tc, ok := cond.(*ThresholdCross)
+	require.True(t, ok)
+	assert.Equal(t, 0.9, tc.threshold)
+	assert.Equal(t, "host.name", tc.groupBy)
+	assert.Equal(t, defaultThresholdCrossMaxGroups, tc.maxGroups)
+	assert.Equal(t, "threshold_cross: cpu.pct > 0.9 by host.name", tc.String())
+}
+
+func TestThresholdCrossCheck(t *testing.T) {
+	cond := newTestThresholdCross(t, 80, "host.name", 0)
+
+	// the value rises through the threshold and stays above it
+	for i, tc := range []struct {
+		value    interface{}
+		expected bool
+	}{
+		{value: 70, expected: false},
+		{value: 80, expected: false},
+		{value: 85, expected: true},
+		{value: 90, expected: false},
+		{value: 95.5, expected: false},
+		{value: 60, expected: false},
+		{value: "81", expected: true},
+	} {
+		assert.Equal(t, tc.expected, cond.Check(cpuEvent("host-a", tc.value)), "value %d: %v", i, tc.value)
+	}
+
+	// the state of each group is tracked independently
+	assert.False(t, cond.Check(cpuEvent("host-b", 50)))
+	assert.False(t, cond.Check(cpuEvent("host-a", 99)))
+	assert.True(t, cond.Check(cpuEvent("host-b", 99)))
+
+	// the first value of a group has nothing to compare to
+	assert.False(t, cond.Check(cpuEvent("host-c", 99)))
+
+	// events without a numeric value or group are ignored
+	assert.False(t, cond.Check(&beat.Event{Fields: mapstr.M{"host": mapstr.M{"name": "host-b"}}}))
+	assert.False(t, cond.Check(cpuEvent("host-b", "high")))
+	assert.False(t, cond.Check(&beat.Event{Fields: mapstr.M{"cpu": mapstr.M{"pct": 99}}}))
+}
+
+func TestThresholdCrossWithoutGroup(t *testing.T) {
+	cond := newTestThresholdCross(t, 80, "", 0)
+
+	assert.False(t, cond.Check(cpuEvent("host-a", 10)))
+	assert.True(t, cond.Check(cpuEvent("host-b", 90)))
+	assert.False(t, cond.Check(cpuEvent("host-a", 95)))
+}
+
+func TestThresholdCrossMaxGroups(t *testing.T) {
+	cond := newTestThresholdCross(t, 80, "host.name", 2)
+
+	assert.False(t, cond.Check(cpuEvent("host-a", 10)))
+	assert.False(t, cond.Check(cpuEvent("host-b", 10)))
+	assert.False(t, cond.Check(cpuEvent("host-c", 10)))
+	assert.Len(t, cond.previous, 2)
+	assert.Contains(t, cond.previous, "host-c")
+}
+
+func newTestThresholdCross(t *testing.T, threshold float64, groupBy string, maxGroups int) *ThresholdCross {
+	t.Helper()
+	cond, err := NewThresholdCrossCondition(ThresholdCrossConfig{
+		Field:     "cpu.pct",
+		Threshold: &threshold,
+		GroupBy:   groupBy,
+		MaxGroups: maxGroups,
+	}, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	return cond
+}
+
+func cpuEvent(host string, value interface{}) *beat.Event {
+	return &beat.Event{
+		Fields: mapstr.M{
+			"host": mapstr.M{"name": host},
+			"cpu":  mapstr.M{"pct": value},
+		},
+	}
+}
@@ -0,0 +1,228 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package health keeps track of the health state reported by the inputs and
+// modules of a Beat, together with a human readable reason, and exposes it
+// through the monitoring registries.
+package health
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// State is the health state of a component.
+type State int
+
+const (
+	// Healthy is the state of a component working as expected.
+	Healthy State = iota
+	// Degraded is the state of a component that is running but has
+	// problems, for example failing to collect part of the data.
+	Degraded
+	// Failed is the state of a component that cannot recover from an error.
+	Failed
+)
+
+var stateNames = map[State]string{
+	Healthy:  "healthy",
+	Degraded: "degraded",
+	Failed:   "failed",
+}
+
+func (s State) String() string {
+	if name, found := stateNames[s]; found {
+		return name
+	}
+	return "unknown"
+}
+
+// FromStatus maps a unit status to a health state. It returns false for the
+// statuses that do not describe the health of a running component, such as
+// Stopping or Stopped.
+func FromStatus(s status.Status) (State, bool) {
+	switch s {
+	case status.Starting, status.Configuring, status.Running:
+		return Healthy, true
+	case status.Degraded:
+		return Degraded, true
+	case status.Failed:
+		return Failed, true
+	default:
+		return Healthy, false
+	}
+}
+
+// Component is a snapshot of the health of a single component.
+type Component struct {
+	ID     string
+	Type   string
+	State  State
+	Reason string
+	Since  time.Time
+}
+
+// Registry keeps track of the health of all the components of a Beat. The
+// zero value is not usable, use NewRegistry instead. All methods are safe
+// to be called on a nil Registry, in which case they do nothing.
+type Registry struct {
+	mu         sync.RWMutex
+	components map[*Reporter]*Component
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{components: map[*Reporter]*Component{}}
+}
+
+// NewReporter registers a new component identified by id and its type, for
+// example the input ID and the input type. The component is reported as
+// healthy until the returned Reporter is updated.
+func (r *Registry) NewReporter(id, typ string) *Reporter {
+	if r == nil {
+		return nil
+	}
+
+	rep := &Reporter{registry: r}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.components[rep] = &Component{ID: id, Type: typ, State: Healthy, Since: time.Now()}
+	return rep
+}
+
+// State returns the overall health, which is the worst state of all the
+// registered components.
+func (r *Registry) State() State {
+	if r == nil {
+		return Healthy
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	state := Healthy
+	for _, c := range r.components {
+		if c.State > state {
+			state = c.State
+		}
+	}
+	return state
+}
+
+// Components returns a snapshot of all the registered components, sorted by
+// ID and type.
+func (r *Registry) Components() []Component {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	components := make([]Component, 0, len(r.components))
+	for _, c := range r.components {
+		components = append(components, *c)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(components, func(i, j int) bool {
+		if components[i].ID != components[j].ID {
+			return components[i].ID < components[j].ID
+		}
+		return components[i].Type < components[j].Type
+	})
+	return components
+}
+
+// Report reports the health of the components to the monitoring visitor. It
+// can be registered in a monitoring registry using monitoring.NewFunc.
+func (r *Registry) Report(_ monitoring.Mode, V monitoring.Visitor) {
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+
+	components := r.Components()
+	monitoring.ReportString(V, "status", r.State().String())
+	monitoring.ReportNamespace(V, "components", func() {
+		for _, c := range components {
+			name := c.ID
+			if name == "" {
+				name = c.Type
+			}
+			monitoring.ReportNamespace(V, name, func() {
+				monitoring.ReportString(V, "id", c.ID)
+				monitoring.ReportString(V, "type", c.Type)
+				monitoring.ReportString(V, "status", c.State.String())
+				monitoring.ReportString(V, "reason", c.Reason)
+				monitoring.ReportString(V, "since", c.Since.UTC().Format(time.RFC3339Nano))
+			})
+		}
+	})
+}
+
+func (r *Registry) update(rep *Reporter, state State, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, found := r.components[rep]
+	if !found {
+		return
+	}
+	if c.State != state {
+		c.Since = time.Now()
+	}
+	c.State = state
+	c.Reason = reason
+}
+
+func (r *Registry) remove(rep *Reporter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.components, rep)
+}
+
+// Reporter updates the health of a single component. It implements
+// status.StatusReporter so it can be used wherever the unit status is
+// reported. All methods are safe to be called on a nil Reporter.
+type Reporter struct {
+	registry *Registry
+}
+
+// Update sets the health state of the component and the reason for it.
+func (rep *Reporter) Update(state State, reason string) {
+	if rep == nil {
+		return
+	}
+	rep.registry.update(rep, state, reason)
+}
+
+// UpdateStatus updates the health of the component from a unit status.
+// Statuses not describing the health of a running component are ignored.
+func (rep *Reporter) UpdateStatus(s status.Status, msg string) {
+	if state, ok := FromStatus(s); ok {
+		rep.Update(state, msg)
+	}
+}
+
+// Unregister removes the component from the registry. Further updates are
+// ignored.
+func (rep *Reporter) Unregister() {
+	if rep == nil {
+		return
+	}
+	rep.registry.remove(rep)
+}
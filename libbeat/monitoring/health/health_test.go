@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestRegistryState(t *testing.T) {
+	r := NewRegistry()
+	assert.Equal(t, Healthy, r.State())
+
+	a := r.NewReporter("a", "filestream")
+	b := r.NewReporter("b", "system")
+	assert.Equal(t, Healthy, r.State())
+
+	a.Update(Degraded, "slow")
+	assert.Equal(t, Degraded, r.State())
+
+	b.UpdateStatus(status.Failed, "cannot open socket")
+	assert.Equal(t, Failed, r.State())
+
+	components := r.Components()
+	require.Len(t, components, 2)
+	assert.Equal(t, "a", components[0].ID)
+	assert.Equal(t, Degraded, components[0].State)
+	assert.Equal(t, "slow", components[0].Reason)
+	assert.Equal(t, "b", components[1].ID)
+	assert.Equal(t, "system", components[1].Type)
+	assert.Equal(t, Failed, components[1].State)
+
+	b.Unregister()
+	assert.Equal(t, Degraded, r.State())
+	b.Update(Failed, "ignored after unregister")
+	assert.Equal(t, Degraded, r.State())
+}
+
+func TestReporterUpdateStatus(t *testing.T) {
+	tests := map[status.Status]State{
+		status.Starting:    Healthy,
+		status.Configuring: Healthy,
+		status.Running:     Healthy,
+		status.Degraded:    Degraded,
+		status.Failed:      Failed,
+	}
+	for s, expected := range tests {
+		t.Run(s.String(), func(t *testing.T) {
+			r := NewRegistry()
+			rep := r.NewReporter("id", "type")
+			rep.Update(Failed, "previous")
+			rep.UpdateStatus(s, "msg")
+			assert.Equal(t, expected, r.Components()[0].State)
+			assert.Equal(t, "msg", r.Components()[0].Reason)
+		})
+	}
+
+	t.Run("stopping is ignored", func(t *testing.T) {
+		r := NewRegistry()
+		rep := r.NewReporter("id", "type")
+		rep.Update(Degraded, "reason")
+		rep.UpdateStatus(status.Stopping, "")
+		assert.Equal(t, Degraded, r.Components()[0].State)
+		assert.Equal(t, "reason", r.Components()[0].Reason)
+	})
+}
+
+func TestNilRegistry(t *testing.T) {
+	var r *Registry
+	rep := r.NewReporter("id", "type")
+	assert.Nil(t, rep)
+
+	// None of these must panic.
+	rep.Update(Failed, "reason")
+	rep.UpdateStatus(status.Failed, "reason")
+	rep.Unregister()
+	assert.Equal(t, Healthy, r.State())
+	assert.Empty(t, r.Components())
+}
+
+func TestRegistryReport(t *testing.T) {
+	r := NewRegistry()
+	r.NewReporter("my-input", "filestream").Update(Degraded, "too many open files")
+
+	reg := monitoring.NewRegistry()
+	monitoring.NewFunc(reg, "health", r.Report, monitoring.Report)
+
+	snapshot := mapstr.M(monitoring.CollectStructSnapshot(reg, monitoring.Full, false))
+	state, err := snapshot.GetValue("health.status")
+	require.NoError(t, err)
+	assert.Equal(t, "degraded", state)
+
+	components, err := snapshot.GetValue("health.components")
+	require.NoError(t, err)
+	component, ok := components.(map[string]interface{})["my-input"].(map[string]interface{})
+	require.True(t, ok, "missing component in %v", components)
+	assert.Equal(t, "filestream", component["type"])
+	assert.Equal(t, "degraded", component["status"])
+	assert.Equal(t, "too many open files", component["reason"])
+	assert.NotEmpty(t, component["since"])
+}
@@ -115,12 +115,19 @@ func findInputRegistryWithID(reg *monitoring.Registry, inputID string) *monitori
 	return nil
 }
 
+// MetricSnapshot returns a snapshot of the input metric values from the
+// global 'dataset' monitoring namespace and from the reg parameter, one
+// entry per input. It's safe to pass in a nil reg.
+func MetricSnapshot(reg *monitoring.Registry) []map[string]any {
+	return filteredSnapshot(globalRegistry(), reg, "")
+}
+
 // MetricSnapshotJSON returns a snapshot of the input metric values from the
 // global 'dataset' monitoring namespace and from the reg parameter
 // encoded as a JSON array (pretty formatted). It's safe to pass in a nil
 // reg.
 func MetricSnapshotJSON(reg *monitoring.Registry) ([]byte, error) {
-	return json.MarshalIndent(filteredSnapshot(globalRegistry(), reg, ""), "", "  ")
+	return json.MarshalIndent(MetricSnapshot(reg), "", "  ")
 }
 
 // NewMetricsRegistry creates a monitoring.Registry for an input.
@@ -50,6 +50,10 @@ type reporter struct {
 	beatMeta mapstr.M
 	tags     []string
 
+	// detailed adds the per-input and per-output breakdown to the metrics
+	// snapshots.
+	detailed bool
+
 	// pipeline
 	pipeline *pipeline.Pipeline
 	client   beat.Client
@@ -190,6 +194,7 @@ func makeReporter(beat beat.Info, mon beat.Monitoring, settings report.Settings,
 		done:       newStopper(),
 		beatMeta:   makeMeta(beat),
 		tags:       config.Tags,
+		detailed:   settings.Detailed,
 		checkRetry: checkRetry,
 		pipeline:   pipeline,
 		client:     pipeConn,
@@ -268,6 +273,9 @@ func (r *reporter) snapshotLoop(registry *monitoring.Registry, namespace, prefix
 			log.Debug("Empty snapshot.")
 			continue
 		}
+		if r.detailed && namespace == "stats" {
+			addDetailedSnapshot(snapshot, r.monitoring)
+		}
 
 		fields := mapstr.M{
 			"beat": r.beatMeta,
@@ -18,6 +18,8 @@
 package elasticsearch
 
 import (
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/monitoring"
 )
@@ -26,3 +28,49 @@ func makeSnapshot(R *monitoring.Registry) mapstr.M {
 	mode := monitoring.Full
 	return mapstr.M(monitoring.CollectStructSnapshot(R, mode, false))
 }
+
+// addDetailedSnapshot adds the per-input metrics under "inputs" and the
+// metrics of the configured outputs under "outputs" to the stats snapshot.
+// The main output is keyed by its type, the additional outputs of a beat
+// publishing to multiple outputs by their type and index, for example
+// "kafka_1".
+func addDetailedSnapshot(snapshot mapstr.M, mon beat.Monitoring) {
+	if inputs := inputmon.MetricSnapshot(mon.InputsRegistry()); len(inputs) > 0 {
+		snapshot["inputs"] = inputs
+	}
+
+	outputs := mapstr.M{}
+	if typ, metrics, ok := outputSnapshot(snapshot, "libbeat.output"); ok {
+		outputs[typ] = metrics
+	}
+	if additional, err := snapshot.GetValue("libbeat.outputs"); err == nil {
+		if byIndex, ok := additional.(map[string]interface{}); ok {
+			for index := range byIndex {
+				if typ, metrics, ok := outputSnapshot(snapshot, "libbeat.outputs."+index+".output"); ok {
+					outputs[typ+"_"+index] = metrics
+				}
+			}
+		}
+	}
+	if len(outputs) > 0 {
+		snapshot["outputs"] = outputs
+	}
+}
+
+// outputSnapshot returns the type and metrics of the output at key in the
+// snapshot, if the output type is known.
+func outputSnapshot(snapshot mapstr.M, key string) (string, map[string]interface{}, bool) {
+	output, err := snapshot.GetValue(key)
+	if err != nil {
+		return "", nil, false
+	}
+	metrics, ok := output.(map[string]interface{})
+	if !ok {
+		return "", nil, false
+	}
+	typ, ok := metrics["type"].(string)
+	if !ok || typ == "" {
+		return "", nil, false
+	}
+	return typ, metrics, true
+}
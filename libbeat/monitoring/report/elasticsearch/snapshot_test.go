@@ -0,0 +1,70 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestAddDetailedSnapshot(t *testing.T) {
+	mon := beat.NewMonitoring()
+
+	output := mon.StatsRegistry().NewRegistry("libbeat.output")
+	monitoring.NewString(output, "type").Set("elasticsearch")
+	monitoring.NewUint(output, "events.acked").Set(42)
+
+	additional := mon.StatsRegistry().NewRegistry("libbeat.outputs.1.output")
+	monitoring.NewString(additional, "type").Set("kafka")
+	monitoring.NewUint(additional, "events.acked").Set(40)
+
+	input := inputmon.NewMetricsRegistry("my-input", "filestream", mon.InputsRegistry(), logptest.NewTestingLogger(t, ""))
+	monitoring.NewUint(input, "events_processed_total").Set(7)
+
+	snapshot := makeSnapshot(mon.StatsRegistry())
+	addDetailedSnapshot(snapshot, mon)
+
+	inputs, ok := snapshot["inputs"].([]map[string]any)
+	require.True(t, ok, "inputs must be a list of input metrics")
+	require.Len(t, inputs, 1)
+	assert.Equal(t, "my-input", inputs[0]["id"])
+	assert.Equal(t, "filestream", inputs[0]["input"])
+	assert.EqualValues(t, 7, inputs[0]["events_processed_total"])
+
+	acked, err := snapshot.GetValue("outputs.elasticsearch.events.acked")
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, acked)
+
+	acked, err = snapshot.GetValue("outputs.kafka_1.events.acked")
+	require.NoError(t, err)
+	assert.EqualValues(t, 40, acked)
+}
+
+func TestAddDetailedSnapshotEmpty(t *testing.T) {
+	snapshot := mapstr.M{}
+	addDetailedSnapshot(snapshot, beat.NewMonitoring())
+	assert.Empty(t, snapshot)
+}
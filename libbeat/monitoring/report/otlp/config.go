@@ -0,0 +1,56 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+type config struct {
+	Endpoint    string            `config:"endpoint" validate:"required"`
+	Insecure    bool              `config:"insecure"`
+	Headers     map[string]string `config:"headers"`
+	Compression string            `config:"compression"`
+	TLS         *tlscommon.Config `config:"ssl"`
+	Period      time.Duration     `config:"period" validate:"positive,nonzero"`
+	Timeout     time.Duration     `config:"timeout" validate:"positive,nonzero"`
+}
+
+func defaultConfig() config {
+	return config{
+		Endpoint:    "localhost:4317",
+		Compression: "gzip",
+		Period:      10 * time.Second,
+		Timeout:     10 * time.Second,
+	}
+}
+
+func (c *config) Validate() error {
+	switch c.Compression {
+	case "gzip", "none":
+	default:
+		return fmt.Errorf("invalid compression '%v', must be one of gzip or none", c.Compression)
+	}
+	if c.Insecure && c.TLS.IsEnabled() {
+		return fmt.Errorf("cannot set both insecure and ssl for the otlp monitoring reporter")
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package otlp provides a monitoring reporter exporting the beat metrics to
+// an OpenTelemetry collector using the OTLP/gRPC protocol.
+package otlp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/monitoring/metrictype"
+	"github.com/elastic/beats/v7/libbeat/monitoring/report"
+	"github.com/elastic/beats/v7/libbeat/version"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+const scopeName = "github.com/elastic/beats/v7/libbeat/monitoring/report/otlp"
+
+type reporter struct {
+	config
+	logger   *logp.Logger
+	registry *monitoring.Registry
+	exporter *otlpmetricgrpc.Exporter
+	resource *resource.Resource
+	start    time.Time
+
+	wg   sync.WaitGroup
+	done chan struct{}
+}
+
+func init() {
+	report.RegisterReporterFactory("otlp", makeReporter)
+}
+
+func makeReporter(beat beat.Info, mon beat.Monitoring, _ report.Settings, cfg *conf.C) (report.Reporter, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(config.Endpoint),
+		otlpmetricgrpc.WithTimeout(config.Timeout),
+	}
+	if len(config.Headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(config.Headers))
+	}
+	if config.Compression == "gzip" {
+		opts = append(opts, otlpmetricgrpc.WithCompressor("gzip"))
+	}
+	switch {
+	case config.Insecure:
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	case config.TLS.IsEnabled():
+		tlsConfig, err := tlscommon.LoadTLSConfig(config.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS configuration: %w", err)
+		}
+		opts = append(opts, otlpmetricgrpc.WithTLSCredentials(
+			credentials.NewTLS(tlsConfig.BuildModuleClientConfig(config.Endpoint))))
+	}
+
+	// The exporter connects lazily, an unavailable collector is reported
+	// when exporting.
+	exporter, err := otlpmetricgrpc.New(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metrics exporter: %w", err)
+	}
+
+	r := &reporter{
+		config:   config,
+		logger:   beat.Logger.Named("monitoring"),
+		registry: mon.StatsRegistry(),
+		exporter: exporter,
+		resource: makeResource(beat),
+		start:    time.Now(),
+		done:     make(chan struct{}),
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.snapshotLoop()
+	}()
+	return r, nil
+}
+
+func (r *reporter) Stop() {
+	close(r.done)
+	r.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.Timeout)
+	defer cancel()
+	if err := r.exporter.Shutdown(ctx); err != nil {
+		r.logger.Warnf("Failed to shut down OTLP metrics exporter: %v", err)
+	}
+}
+
+func (r *reporter) snapshotLoop() {
+	r.logger.Infof("Start exporting metrics to OTLP endpoint %v every %v.", r.Endpoint, r.Period)
+	defer r.logger.Info("Stop exporting metrics to OTLP endpoint.")
+
+	ticker := time.NewTicker(r.Period)
+	defer ticker.Stop()
+
+	for {
+		var ts time.Time
+
+		select {
+		case <-r.done:
+			return
+		case ts = <-ticker.C:
+		}
+
+		snapshot := monitoring.CollectFlatSnapshot(r.registry, monitoring.Full, false)
+		rm := r.makeResourceMetrics(snapshot, ts)
+		if len(rm.ScopeMetrics[0].Metrics) == 0 {
+			r.logger.Debug("Empty snapshot.")
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), r.Timeout)
+		err := r.exporter.Export(ctx, rm)
+		cancel()
+		if err != nil {
+			r.logger.Warnf("Failed to export metrics to OTLP endpoint %v: %v", r.Endpoint, err)
+		}
+	}
+}
+
+// makeResourceMetrics converts the numeric values of the snapshot to OTLP
+// metrics. Values known to be gauges are exported as gauges, all other
+// values are counters exported as cumulative monotonic sums.
+func (r *reporter) makeResourceMetrics(snapshot monitoring.FlatSnapshot, ts time.Time) *metricdata.ResourceMetrics {
+	metrics := make([]metricdata.Metrics, 0, len(snapshot.Ints)+len(snapshot.Floats))
+	for _, name := range sortedKeys(snapshot.Ints) {
+		metrics = append(metrics, metricdata.Metrics{
+			Name: name,
+			Data: makeAggregation(name, r.start, ts, snapshot.Ints[name]),
+		})
+	}
+	for _, name := range sortedKeys(snapshot.Floats) {
+		metrics = append(metrics, metricdata.Metrics{
+			Name: name,
+			Data: makeAggregation(name, r.start, ts, snapshot.Floats[name]),
+		})
+	}
+
+	return &metricdata.ResourceMetrics{
+		Resource: r.resource,
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Scope: instrumentation.Scope{
+				Name:    scopeName,
+				Version: version.GetDefaultVersion(),
+			},
+			Metrics: metrics,
+		}},
+	}
+}
+
+func makeAggregation[N int64 | float64](name string, start, ts time.Time, value N) metricdata.Aggregation {
+	if metrictype.IsGauge(name) {
+		return metricdata.Gauge[N]{
+			DataPoints: []metricdata.DataPoint[N]{{Time: ts, Value: value}},
+		}
+	}
+	return metricdata.Sum[N]{
+		DataPoints:  []metricdata.DataPoint[N]{{StartTime: start, Time: ts, Value: value}},
+		Temporality: metricdata.CumulativeTemporality,
+		IsMonotonic: true,
+	}
+}
+
+func makeResource(beat beat.Info) *resource.Resource {
+	return resource.NewSchemaless(
+		attribute.String("service.name", beat.Beat),
+		attribute.String("service.version", beat.Version),
+		attribute.String("service.instance.id", beat.ID.String()),
+		attribute.String("host.name", beat.Hostname),
+		attribute.String("beat.name", beat.Name),
+	)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
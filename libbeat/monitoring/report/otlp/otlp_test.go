@@ -0,0 +1,193 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/monitoring/report"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+type fakeCollector struct {
+	collectormetrics.UnimplementedMetricsServiceServer
+
+	mu       sync.Mutex
+	requests []*collectormetrics.ExportMetricsServiceRequest
+}
+
+func (c *fakeCollector) Export(_ context.Context, req *collectormetrics.ExportMetricsServiceRequest) (*collectormetrics.ExportMetricsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, req)
+	return &collectormetrics.ExportMetricsServiceResponse{}, nil
+}
+
+func (c *fakeCollector) last() *collectormetrics.ExportMetricsServiceRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.requests) == 0 {
+		return nil
+	}
+	return c.requests[len(c.requests)-1]
+}
+
+func startCollector(t *testing.T) (*fakeCollector, string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	collector := &fakeCollector{}
+	server := grpc.NewServer()
+	collectormetrics.RegisterMetricsServiceServer(server, collector)
+	go server.Serve(lis) //nolint:errcheck // stopped on cleanup
+	t.Cleanup(server.Stop)
+
+	return collector, lis.Addr().String()
+}
+
+func TestReporterExport(t *testing.T) {
+	collector, endpoint := startCollector(t)
+
+	mon := beat.NewMonitoring()
+	reg := mon.StatsRegistry()
+	monitoring.NewInt(reg, "libbeat.pipeline.events.total").Set(42)
+	monitoring.NewInt(reg, "libbeat.pipeline.events.active").Set(3)
+	monitoring.NewFloat(reg, "system.load.1").Set(1.5)
+	monitoring.NewString(reg, "beat.info.name").Set("ignored")
+
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"endpoint": endpoint,
+		"insecure": true,
+		"period":   "10ms",
+	})
+	info := beat.Info{
+		Beat:     "testbeat",
+		Name:     "test",
+		Version:  "9.0.0",
+		Hostname: "host",
+		Logger:   logptest.NewTestingLogger(t, ""),
+	}
+	r, err := makeReporter(info, mon, report.Settings{}, cfg)
+	require.NoError(t, err)
+	defer r.Stop()
+
+	require.Eventually(t, func() bool {
+		return collector.last() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	req := collector.last()
+	require.Len(t, req.ResourceMetrics, 1)
+	rm := req.ResourceMetrics[0]
+
+	attrs := map[string]string{}
+	for _, kv := range rm.Resource.Attributes {
+		attrs[kv.Key] = kv.Value.GetStringValue()
+	}
+	assert.Equal(t, "testbeat", attrs["service.name"])
+	assert.Equal(t, "9.0.0", attrs["service.version"])
+	assert.Equal(t, "test", attrs["beat.name"])
+
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := map[string]*metricspb.Metric{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+	require.Len(t, metrics, 3)
+
+	total := metrics["libbeat.pipeline.events.total"].GetSum()
+	require.NotNil(t, total)
+	assert.True(t, total.IsMonotonic)
+	assert.Equal(t, metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, total.AggregationTemporality)
+	assert.EqualValues(t, 42, total.DataPoints[0].GetAsInt())
+
+	active := metrics["libbeat.pipeline.events.active"].GetGauge()
+	require.NotNil(t, active)
+	assert.EqualValues(t, 3, active.DataPoints[0].GetAsInt())
+
+	load := metrics["system.load.1"].GetGauge()
+	require.NotNil(t, load)
+	assert.Equal(t, 1.5, load.DataPoints[0].GetAsDouble())
+}
+
+func TestReporterUnavailableCollector(t *testing.T) {
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"endpoint": "127.0.0.1:1",
+		"insecure": true,
+		"period":   "10ms",
+		"timeout":  "10ms",
+	})
+	info := beat.Info{Logger: logptest.NewTestingLogger(t, "")}
+	mon := beat.NewMonitoring()
+	monitoring.NewInt(mon.StatsRegistry(), "count").Set(1)
+
+	r, err := makeReporter(info, mon, report.Settings{}, cfg)
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+	r.Stop()
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		config map[string]interface{}
+		err    string
+	}{
+		"defaults": {
+			config: map[string]interface{}{},
+		},
+		"invalid compression": {
+			config: map[string]interface{}{"compression": "zstd"},
+			err:    "invalid compression 'zstd'",
+		},
+		"insecure with ssl": {
+			config: map[string]interface{}{
+				"insecure":                    true,
+				"ssl.certificate_authorities": []string{"ca.pem"},
+			},
+			err: "cannot set both insecure and ssl",
+		},
+		"zero period": {
+			config: map[string]interface{}{"period": 0},
+			err:    "period",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := defaultConfig()
+			err := conf.MustNewConfigFrom(test.config).Unpack(&config)
+			if test.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
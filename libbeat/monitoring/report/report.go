@@ -33,6 +33,10 @@ type config struct {
 type Settings struct {
 	DefaultUsername string
 	ClusterUUID     string
+
+	// Detailed enables the per-input and per-output breakdown in the
+	// reported metrics documents.
+	Detailed bool
 }
 
 type Reporter interface {
@@ -124,6 +128,22 @@ func getReporterConfig(
 	return "", nil, errors.New("No monitoring reporter configured")
 }
 
+// IsDetailed returns the value of the monitoring.detailed setting.
+func IsDetailed(monitoringCfg *conf.C) (bool, error) {
+	if monitoringCfg == nil {
+		return false, nil
+	}
+
+	var config struct {
+		Detailed bool `config:"detailed"`
+	}
+	if err := monitoringCfg.Unpack(&config); err != nil {
+		return false, err
+	}
+
+	return config.Detailed, nil
+}
+
 func collectSubObject(cfg *conf.C) *conf.C {
 	out := conf.NewConfig()
 	for _, field := range cfg.GetFields() {
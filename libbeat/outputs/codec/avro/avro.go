@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package avro provides a codec serializing events to Apache Avro, with
+// optional Confluent Schema Registry integration.
+package avro
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/linkedin/goavro/v2"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/schemaregistry"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// timestampField is the record field the event timestamp is available as,
+// unless the event has a field with the same name.
+const timestampField = "timestamp"
+
+// Config is the configuration of the avro codec.
+type Config struct {
+	Schema     string    `config:"schema"`
+	SchemaFile string    `config:"schema_file"`
+	Registry   *config.C `config:"registry"`
+}
+
+func (c *Config) Validate() error {
+	if (c.Schema == "") == (c.SchemaFile == "") {
+		return errors.New("exactly one of schema or schema_file must be set")
+	}
+	return nil
+}
+
+// Encoder serializes events to Avro binary. If a schema registry is
+// configured, the payload is prefixed with the schema ID in the Confluent
+// wire format.
+type Encoder struct {
+	schema     string
+	codec      *goavro.Codec
+	converter  *converter
+	parsed     interface{}
+	recordName string
+	registry   *schemaregistry.Client
+
+	buf []byte
+}
+
+func init() {
+	codec.RegisterType("avro", func(info beat.Info, cfg *config.C) (codec.Codec, error) {
+		var avroConfig Config
+		if cfg == nil {
+			cfg = config.NewConfig()
+		}
+		if err := cfg.Unpack(&avroConfig); err != nil {
+			return nil, err
+		}
+		return New(avroConfig, info.Logger)
+	})
+}
+
+// New creates a new avro Encoder.
+func New(config Config, log *logp.Logger) (*Encoder, error) {
+	schema := config.Schema
+	if config.SchemaFile != "" {
+		data, err := os.ReadFile(config.SchemaFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read avro schema: %w", err)
+		}
+		schema = string(data)
+	}
+
+	avroCodec, err := goavro.NewCodec(schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid avro schema: %w", err)
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(schema), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid avro schema: %w", err)
+	}
+	recordDef, ok := parsed.(map[string]interface{})
+	if !ok || recordDef["type"] != "record" {
+		return nil, errors.New("the avro schema must be a record")
+	}
+	recordName, _ := fullName(recordDef, "")
+
+	e := &Encoder{
+		schema:     avroCodec.CanonicalSchema(),
+		codec:      avroCodec,
+		converter:  newConverter(parsed),
+		parsed:     parsed,
+		recordName: recordName,
+	}
+
+	if config.Registry != nil {
+		registryConfig := schemaregistry.DefaultConfig()
+		if err := config.Registry.Unpack(&registryConfig); err != nil {
+			return nil, err
+		}
+		e.registry, err = schemaregistry.NewClient(registryConfig, log)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Encode serializes a beat event to Avro. Schema registries using a topic
+// based subject name strategy require EncodeTopic.
+func (e *Encoder) Encode(index string, event *beat.Event) ([]byte, error) {
+	return e.EncodeTopic("", index, event)
+}
+
+// EncodeTopic serializes a beat event to Avro, resolving the schema ID for
+// the subject of topic if a schema registry is configured. The record is
+// built from the event fields, fields not in the schema are ignored.
+func (e *Encoder) EncodeTopic(topic, _ string, event *beat.Event) ([]byte, error) {
+	e.buf = e.buf[:0]
+	if e.registry != nil {
+		subject, err := e.registry.Subject(topic, e.recordName)
+		if err != nil {
+			return nil, err
+		}
+		id, err := e.registry.SchemaID(subject, schemaregistry.TypeAvro, e.schema)
+		if err != nil {
+			return nil, err
+		}
+		e.buf = schemaregistry.AppendHeader(e.buf, id)
+	}
+
+	fields := make(map[string]interface{}, len(event.Fields)+1)
+	for k, v := range event.Fields {
+		fields[k] = v
+	}
+	if _, exists := fields[timestampField]; !exists {
+		fields[timestampField] = event.Timestamp
+	}
+
+	native, err := e.converter.convert(e.parsed, "", fields)
+	if err != nil {
+		return nil, fmt.Errorf("event does not match avro schema: %w", err)
+	}
+	e.buf, err = e.codec.BinaryFromNative(e.buf, native)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event to avro: %w", err)
+	}
+	return e.buf, nil
+}
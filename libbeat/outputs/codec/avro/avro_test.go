@@ -0,0 +1,189 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package avro
+
+import (
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const testSchema = `{
+  "type": "record",
+  "name": "Event",
+  "namespace": "com.example",
+  "fields": [
+    {"name": "timestamp", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+    {"name": "message", "type": "string"},
+    {"name": "count", "type": ["null", "long"], "default": null},
+    {"name": "level", "type": {"type": "enum", "name": "Level", "symbols": ["info", "error"]}, "default": "info"},
+    {"name": "host", "type": ["null", {
+      "type": "record",
+      "name": "Host",
+      "fields": [
+        {"name": "name", "type": "string"},
+        {"name": "ip", "type": {"type": "array", "items": "string"}, "default": []}
+      ]
+    }], "default": null},
+    {"name": "labels", "type": {"type": "map", "values": "string"}, "default": {}},
+    {"name": "ratio", "type": "double", "default": 0}
+  ]
+}`
+
+func TestEncode(t *testing.T) {
+	encoder, err := New(Config{Schema: testSchema}, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	ts := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	event := &beat.Event{
+		Timestamp: ts,
+		Fields: mapstr.M{
+			"message": "hello",
+			"count":   42,
+			"level":   "error",
+			"host": mapstr.M{
+				"name":         "host-a",
+				"ip":           []string{"10.0.0.1"},
+				"architecture": "x86_64",
+			},
+			"labels":  map[string]interface{}{"env": "prod"},
+			"ratio":   1,
+			"ignored": "not in schema",
+		},
+	}
+
+	data, err := encoder.Encode("test", event)
+	require.NoError(t, err)
+
+	native := decode(t, data)
+	assert.Equal(t, ts, native["timestamp"].(time.Time).UTC())
+	assert.Equal(t, "hello", native["message"])
+	assert.Equal(t, map[string]interface{}{"long": int64(42)}, native["count"])
+	assert.Equal(t, "error", native["level"])
+	assert.Equal(t, map[string]interface{}{
+		"com.example.Host": map[string]interface{}{
+			"name": "host-a",
+			"ip":   []interface{}{"10.0.0.1"},
+		},
+	}, native["host"])
+	assert.Equal(t, map[string]interface{}{"env": "prod"}, native["labels"])
+	assert.Equal(t, float64(1), native["ratio"])
+}
+
+func TestEncodeDefaults(t *testing.T) {
+	encoder, err := New(Config{Schema: testSchema}, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	data, err := encoder.Encode("test", &beat.Event{
+		Timestamp: time.Now(),
+		Fields:    mapstr.M{"message": "hello"},
+	})
+	require.NoError(t, err)
+
+	native := decode(t, data)
+	assert.Nil(t, native["count"])
+	assert.Equal(t, "info", native["level"])
+	assert.Nil(t, native["host"])
+}
+
+func TestEncodeMismatch(t *testing.T) {
+	encoder, err := New(Config{Schema: testSchema}, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	tests := map[string]mapstr.M{
+		"missing required field": {},
+		"wrong type":             {"message": 42},
+		"unknown enum symbol":    {"message": "hello", "level": "debug"},
+	}
+	for name, fields := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := encoder.Encode("test", &beat.Event{Timestamp: time.Now(), Fields: fields})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEncodeWithRegistry(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"id": 7}`))
+	}))
+	defer server.Close()
+
+	cfg := config.MustNewConfigFrom(map[string]interface{}{
+		"schema": testSchema,
+		"registry": map[string]interface{}{
+			"url":           server.URL,
+			"auto_register": true,
+		},
+	})
+	var avroConfig Config
+	require.NoError(t, cfg.Unpack(&avroConfig))
+	encoder, err := New(avroConfig, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	event := &beat.Event{Timestamp: time.Now(), Fields: mapstr.M{"message": "hello"}}
+	_, err = encoder.Encode("test", event)
+	assert.Error(t, err, "the default topic_name strategy requires a topic")
+
+	data, err := encoder.EncodeTopic("events", "test", event)
+	require.NoError(t, err)
+	require.Greater(t, len(data), 5)
+	assert.Equal(t, byte(0), data[0], "payload must start with the magic byte")
+	assert.Equal(t, uint32(7), binary.BigEndian.Uint32(data[1:5]))
+	assert.Equal(t, "hello", decode(t, data[5:])["message"])
+	assert.Equal(t, []string{"/subjects/events-value/versions"}, paths)
+}
+
+func TestConfigValidate(t *testing.T) {
+	var avroConfig Config
+	assert.Error(t, config.NewConfig().Unpack(&avroConfig), "a schema is required")
+
+	cfg := config.MustNewConfigFrom(map[string]interface{}{
+		"schema":      testSchema,
+		"schema_file": "event.avsc",
+	})
+	assert.Error(t, cfg.Unpack(&avroConfig), "schema and schema_file are exclusive")
+}
+
+func TestNewRequiresRecord(t *testing.T) {
+	_, err := New(Config{Schema: `"string"`}, logptest.NewTestingLogger(t, ""))
+	assert.Error(t, err)
+}
+
+func decode(t *testing.T, data []byte) map[string]interface{} {
+	t.Helper()
+	codec, err := goavro.NewCodec(testSchema)
+	require.NoError(t, err)
+	native, rest, err := codec.NativeFromBinary(data)
+	require.NoError(t, err)
+	require.Empty(t, rest)
+	return native.(map[string]interface{})
+}
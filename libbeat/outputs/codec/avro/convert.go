@@ -0,0 +1,377 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package avro
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// converter converts event values to the native representation expected by
+// goavro for a schema: unions are wrapped in single-key maps, numbers are
+// converted to the exact Go type and fields not in the schema are dropped.
+type converter struct {
+	// named types declared in the schema, by full name
+	names map[string]map[string]interface{}
+}
+
+func newConverter(schema interface{}) *converter {
+	c := &converter{names: map[string]map[string]interface{}{}}
+	c.collectNames(schema, "")
+	return c
+}
+
+// collectNames registers all named types declared in the schema, so they
+// can be referenced independently of the declaration order.
+func (c *converter) collectNames(schema interface{}, namespace string) {
+	switch s := schema.(type) {
+	case []interface{}:
+		for _, member := range s {
+			c.collectNames(member, namespace)
+		}
+	case map[string]interface{}:
+		typ, _ := s["type"].(string)
+		switch typ {
+		case "record", "error", "enum", "fixed":
+			fullName, ns := fullName(s, namespace)
+			c.names[fullName] = s
+			if fields, ok := s["fields"].([]interface{}); ok {
+				for _, f := range fields {
+					if field, ok := f.(map[string]interface{}); ok {
+						c.collectNames(field["type"], ns)
+					}
+				}
+			}
+		case "array":
+			c.collectNames(s["items"], namespace)
+		case "map":
+			c.collectNames(s["values"], namespace)
+		default:
+			c.collectNames(s["type"], namespace)
+		}
+	}
+}
+
+// fullName returns the full name of a named type and the namespace for the
+// types it encloses.
+func fullName(s map[string]interface{}, namespace string) (string, string) {
+	name, _ := s["name"].(string)
+	if strings.Contains(name, ".") {
+		return name, name[:strings.LastIndex(name, ".")]
+	}
+	if ns, ok := s["namespace"].(string); ok {
+		namespace = ns
+	}
+	if namespace == "" {
+		return name, ""
+	}
+	return namespace + "." + name, namespace
+}
+
+func (c *converter) resolve(name, namespace string) (map[string]interface{}, bool) {
+	if !strings.Contains(name, ".") && namespace != "" {
+		if s, ok := c.names[namespace+"."+name]; ok {
+			return s, true
+		}
+	}
+	s, ok := c.names[name]
+	return s, ok
+}
+
+func (c *converter) convert(schema interface{}, namespace string, v interface{}) (interface{}, error) {
+	switch s := schema.(type) {
+	case string:
+		if named, ok := c.resolve(s, namespace); ok {
+			return c.convertComplex(named, namespace, v)
+		}
+		return convertPrimitive(s, "", v)
+	case []interface{}:
+		return c.convertUnion(s, namespace, v)
+	case map[string]interface{}:
+		return c.convertComplex(s, namespace, v)
+	}
+	return nil, fmt.Errorf("invalid schema %v", schema)
+}
+
+func (c *converter) convertUnion(members []interface{}, namespace string, v interface{}) (interface{}, error) {
+	if v == nil {
+		for _, m := range members {
+			if m == "null" {
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("null is not allowed by union %v", members)
+	}
+
+	for _, m := range members {
+		if m == "null" {
+			continue
+		}
+		converted, err := c.convert(m, namespace, v)
+		if err == nil {
+			return map[string]interface{}{c.typeName(m, namespace): converted}, nil
+		}
+	}
+	return nil, fmt.Errorf("value of type %T matches no member of union %v", v, members)
+}
+
+// typeName returns the name goavro identifies union members by.
+func (c *converter) typeName(schema interface{}, namespace string) string {
+	switch s := schema.(type) {
+	case string:
+		if named, ok := c.resolve(s, namespace); ok {
+			name, _ := fullName(named, namespace)
+			return name
+		}
+		return s
+	case map[string]interface{}:
+		typ, _ := s["type"].(string)
+		switch typ {
+		case "record", "error", "enum", "fixed":
+			name, _ := fullName(s, namespace)
+			return name
+		}
+		if logical, ok := s["logicalType"].(string); ok {
+			return typ + "." + logical
+		}
+		return typ
+	}
+	return ""
+}
+
+func (c *converter) convertComplex(s map[string]interface{}, namespace string, v interface{}) (interface{}, error) {
+	typ, ok := s["type"].(string)
+	if !ok {
+		// {"type": [...]} or {"type": {...}}
+		return c.convert(s["type"], namespace, v)
+	}
+
+	switch typ {
+	case "record", "error":
+		return c.convertRecord(s, namespace, v)
+	case "enum":
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string for enum, got %T", v)
+		}
+		symbols, _ := s["symbols"].([]interface{})
+		for _, sym := range symbols {
+			if sym == str {
+				return str, nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not a symbol of enum %v", str, s["name"])
+	case "fixed":
+		return convertPrimitive("bytes", "", v)
+	case "array":
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("expected array, got %T", v)
+		}
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			item, err := c.convert(s["items"], namespace, rv.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("array item %d: %w", i, err)
+			}
+			items[i] = item
+		}
+		return items, nil
+	case "map":
+		m, ok := toMap(v)
+		if !ok {
+			return nil, fmt.Errorf("expected map, got %T", v)
+		}
+		out := make(map[string]interface{}, len(m))
+		for k, value := range m {
+			converted, err := c.convert(s["values"], namespace, value)
+			if err != nil {
+				return nil, fmt.Errorf("map value %q: %w", k, err)
+			}
+			out[k] = converted
+		}
+		return out, nil
+	default:
+		logical, _ := s["logicalType"].(string)
+		return convertPrimitive(typ, logical, v)
+	}
+}
+
+func (c *converter) convertRecord(s map[string]interface{}, namespace string, v interface{}) (interface{}, error) {
+	m, ok := toMap(v)
+	if !ok {
+		return nil, fmt.Errorf("expected record %v, got %T", s["name"], v)
+	}
+	_, ns := fullName(s, namespace)
+
+	fields, _ := s["fields"].([]interface{})
+	out := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		field, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := field["name"].(string)
+		value, present := m[name]
+		if !present || value == nil {
+			if _, hasDefault := field["default"]; hasDefault {
+				// goavro uses the field default for missing values
+				continue
+			}
+		}
+		converted, err := c.convert(field["type"], ns, value)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+		out[name] = converted
+	}
+	return out, nil
+}
+
+func toMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case mapstr.M:
+		return m, true
+	}
+	return nil, false
+}
+
+func convertPrimitive(typ, logical string, v interface{}) (interface{}, error) {
+	switch typ {
+	case "null":
+		if v != nil {
+			return nil, fmt.Errorf("expected null, got %T", v)
+		}
+		return nil, nil
+	case "boolean":
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case "int":
+		if logical == "date" {
+			if t, ok := toTime(v); ok {
+				return t, nil
+			}
+		}
+		if i, ok := toInt(v); ok && i >= math.MinInt32 && i <= math.MaxInt32 {
+			return int32(i), nil
+		}
+	case "long":
+		if strings.HasPrefix(logical, "timestamp-") {
+			if t, ok := toTime(v); ok {
+				return t, nil
+			}
+		}
+		if i, ok := toInt(v); ok {
+			return i, nil
+		}
+	case "float":
+		if f, ok := toFloat(v); ok {
+			return float32(f), nil
+		}
+	case "double":
+		if f, ok := toFloat(v); ok {
+			return f, nil
+		}
+	case "string":
+		switch s := v.(type) {
+		case string:
+			return s, nil
+		case time.Time:
+			return s.UTC().Format(time.RFC3339Nano), nil
+		case common.Time:
+			return time.Time(s).UTC().Format(time.RFC3339Nano), nil
+		case fmt.Stringer:
+			return s.String(), nil
+		}
+	case "bytes":
+		switch b := v.(type) {
+		case []byte:
+			return b, nil
+		case string:
+			return []byte(b), nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown type %q", typ)
+	}
+	return nil, fmt.Errorf("cannot convert %T to %s", v, typ)
+}
+
+func toTime(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case common.Time:
+		return time.Time(t), true
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, t)
+		return parsed, err == nil
+	}
+	return time.Time{}, false
+}
+
+func toInt(v interface{}) (int64, bool) {
+	switch i := v.(type) {
+	case int:
+		return int64(i), true
+	case int8:
+		return int64(i), true
+	case int16:
+		return int64(i), true
+	case int32:
+		return int64(i), true
+	case int64:
+		return i, true
+	case uint:
+		return int64(i), i <= math.MaxInt64
+	case uint8:
+		return int64(i), true
+	case uint16:
+		return int64(i), true
+	case uint32:
+		return int64(i), true
+	case uint64:
+		return int64(i), i <= math.MaxInt64
+	case float64:
+		return int64(i), i == math.Trunc(i) && i >= math.MinInt64 && i <= math.MaxInt64
+	case float32:
+		return int64(i), float64(i) == math.Trunc(float64(i))
+	}
+	return 0, false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch f := v.(type) {
+	case float64:
+		return f, true
+	case float32:
+		return float64(f), true
+	}
+	if i, ok := toInt(v); ok {
+		return float64(i), true
+	}
+	return 0, false
+}
@@ -22,3 +22,12 @@ import "github.com/elastic/beats/v7/libbeat/beat"
 type Codec interface {
 	Encode(index string, event *beat.Event) ([]byte, error)
 }
+
+// TopicCodec is implemented by codecs whose encoding depends on the topic
+// the event is published to, like codecs using a schema registry with a
+// topic based subject name strategy. Outputs publishing to topics should
+// prefer EncodeTopic over Encode if the codec implements it.
+type TopicCodec interface {
+	Codec
+	EncodeTopic(topic, index string, event *beat.Event) ([]byte, error)
+}
@@ -0,0 +1,183 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package protobuf provides a codec serializing events to Protocol Buffers
+// messages described by a compiled descriptor set, with optional Confluent
+// Schema Registry integration.
+package protobuf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/schemaregistry"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// timestampField is the message field the event timestamp is available as,
+// unless the event has a field with the same name.
+const timestampField = "timestamp"
+
+// Config is the configuration of the protobuf codec.
+type Config struct {
+	// DescriptorFile is a FileDescriptorSet, as produced by
+	// `protoc --include_imports --descriptor_set_out`.
+	DescriptorFile string    `config:"descriptor_file" validate:"required"`
+	Message        string    `config:"message" validate:"required"`
+	Registry       *config.C `config:"registry"`
+}
+
+// Encoder serializes events to protobuf messages. If a schema registry is
+// configured, the payload is prefixed with the schema ID and message
+// indexes in the Confluent wire format.
+type Encoder struct {
+	desc     protoreflect.MessageDescriptor
+	indexes  []int
+	registry *schemaregistry.Client
+
+	buf []byte
+}
+
+func init() {
+	codec.RegisterType("protobuf", func(info beat.Info, cfg *config.C) (codec.Codec, error) {
+		var protoConfig Config
+		if cfg == nil {
+			cfg = config.NewConfig()
+		}
+		if err := cfg.Unpack(&protoConfig); err != nil {
+			return nil, err
+		}
+		return New(protoConfig, info.Logger)
+	})
+}
+
+// New creates a new protobuf Encoder.
+func New(config Config, log *logp.Logger) (*Encoder, error) {
+	data, err := os.ReadFile(config.DescriptorFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor file: %w", err)
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid descriptor file: %w", err)
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor file: %w", err)
+	}
+	d, err := files.FindDescriptorByName(protoreflect.FullName(config.Message))
+	if err != nil {
+		return nil, fmt.Errorf("message %s not found in descriptor file: %w", config.Message, err)
+	}
+	desc, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a message", config.Message)
+	}
+
+	e := &Encoder{
+		desc:    desc,
+		indexes: messageIndexes(desc),
+	}
+
+	if config.Registry != nil {
+		registryConfig := schemaregistry.DefaultConfig()
+		if err := config.Registry.Unpack(&registryConfig); err != nil {
+			return nil, err
+		}
+		if registryConfig.AutoRegister {
+			return nil, errors.New("auto_register is not supported by the protobuf codec, the schema must be registered in advance")
+		}
+		e.registry, err = schemaregistry.NewClient(registryConfig, log)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// messageIndexes returns the path to the message through the nested
+// message declarations of its file.
+func messageIndexes(desc protoreflect.MessageDescriptor) []int {
+	var indexes []int
+	for d := protoreflect.Descriptor(desc); ; d = d.Parent() {
+		if _, ok := d.(protoreflect.MessageDescriptor); !ok {
+			break
+		}
+		indexes = append([]int{d.Index()}, indexes...)
+	}
+	return indexes
+}
+
+// Encode serializes a beat event to protobuf. Schema registries using a
+// topic based subject name strategy require EncodeTopic.
+func (e *Encoder) Encode(index string, event *beat.Event) ([]byte, error) {
+	return e.EncodeTopic("", index, event)
+}
+
+// EncodeTopic serializes a beat event to protobuf, resolving the schema ID
+// of the latest schema version of the subject of topic if a schema registry
+// is configured. Fields are mapped to the message using the protobuf JSON
+// mapping, fields not in the message are ignored.
+func (e *Encoder) EncodeTopic(topic, _ string, event *beat.Event) ([]byte, error) {
+	e.buf = e.buf[:0]
+	if e.registry != nil {
+		subject, err := e.registry.Subject(topic, string(e.desc.FullName()))
+		if err != nil {
+			return nil, err
+		}
+		id, err := e.registry.SchemaID(subject, schemaregistry.TypeProtobuf, "")
+		if err != nil {
+			return nil, err
+		}
+		e.buf = schemaregistry.AppendHeader(e.buf, id)
+		e.buf = schemaregistry.AppendMessageIndexes(e.buf, e.indexes)
+	}
+
+	fields := make(map[string]interface{}, len(event.Fields)+1)
+	for k, v := range event.Fields {
+		fields[k] = v
+	}
+	if _, exists := fields[timestampField]; !exists {
+		fields[timestampField] = event.Timestamp
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := dynamicpb.NewMessage(e.desc)
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("event does not match message %s: %w", e.desc.FullName(), err)
+	}
+	e.buf, err = proto.MarshalOptions{}.MarshalAppend(e.buf, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event to protobuf: %w", err)
+	}
+	return e.buf, nil
+}
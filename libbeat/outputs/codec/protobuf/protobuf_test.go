@@ -0,0 +1,204 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package protobuf
+
+import (
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// writeDescriptorFile writes a descriptor set for:
+//
+//	package example;
+//	message Other {}
+//	message Event {
+//	  message Host { string name = 1; }
+//	  string timestamp = 1;
+//	  string message = 2;
+//	  int64 count = 3;
+//	  Host host = 4;
+//	}
+func writeDescriptorFile(t *testing.T) string {
+	t.Helper()
+
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("event.proto"),
+		Package: proto.String("example"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Other")},
+			{
+				Name: proto.String("Event"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("timestamp", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					field("message", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					field("count", 3, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+					field("host", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".example.Event.Host"),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name: proto.String("Host"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					},
+				}},
+			},
+		},
+	}}}
+
+	data, err := proto.Marshal(set)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "event.desc")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestEncode(t *testing.T) {
+	encoder, err := New(Config{
+		DescriptorFile: writeDescriptorFile(t),
+		Message:        "example.Event",
+	}, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	ts := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	data, err := encoder.Encode("test", &beat.Event{
+		Timestamp: ts,
+		Fields: mapstr.M{
+			"message": "hello",
+			"count":   42,
+			"host":    mapstr.M{"name": "host-a", "architecture": "x86_64"},
+			"ignored": "not in message",
+		},
+	})
+	require.NoError(t, err)
+
+	msg := decode(t, encoder.desc, data)
+	get := func(name string) protoreflect.Value {
+		return msg.Get(encoder.desc.Fields().ByName(protoreflect.Name(name)))
+	}
+	assert.Equal(t, "2025-03-01T12:00:00Z", get("timestamp").String())
+	assert.Equal(t, "hello", get("message").String())
+	assert.Equal(t, int64(42), get("count").Int())
+	host := get("host").Message()
+	assert.Equal(t, "host-a", host.Get(host.Descriptor().Fields().ByName("name")).String())
+}
+
+func TestEncodeMismatch(t *testing.T) {
+	encoder, err := New(Config{
+		DescriptorFile: writeDescriptorFile(t),
+		Message:        "example.Event",
+	}, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	_, err = encoder.Encode("test", &beat.Event{
+		Timestamp: time.Now(),
+		Fields:    mapstr.M{"count": "not a number"},
+	})
+	assert.Error(t, err)
+}
+
+func TestEncodeWithRegistry(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"id": 9, "version": 1}`))
+	}))
+	defer server.Close()
+
+	cfg := config.MustNewConfigFrom(map[string]interface{}{
+		"descriptor_file": writeDescriptorFile(t),
+		"message":         "example.Event.Host",
+		"registry": map[string]interface{}{
+			"url":                   server.URL,
+			"subject_name_strategy": "topic_record_name",
+		},
+	})
+	var protoConfig Config
+	require.NoError(t, cfg.Unpack(&protoConfig))
+	encoder, err := New(protoConfig, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	data, err := encoder.EncodeTopic("events", "test", &beat.Event{
+		Timestamp: time.Now(),
+		Fields:    mapstr.M{"name": "host-a"},
+	})
+	require.NoError(t, err)
+
+	require.Greater(t, len(data), 8)
+	assert.Equal(t, byte(0), data[0], "payload must start with the magic byte")
+	assert.Equal(t, uint32(9), binary.BigEndian.Uint32(data[1:5]))
+	assert.Equal(t, []byte{4, 2, 0}, data[5:8], "message indexes of the nested message [1, 0]")
+	msg := decode(t, encoder.desc, data[8:])
+	assert.Equal(t, "host-a", msg.Get(encoder.desc.Fields().ByName("name")).String())
+	assert.Equal(t, []string{"GET /subjects/events-example.Event.Host/versions/latest"}, paths)
+}
+
+func TestNewErrors(t *testing.T) {
+	descriptorFile := writeDescriptorFile(t)
+	logger := logptest.NewTestingLogger(t, "")
+
+	_, err := New(Config{DescriptorFile: descriptorFile, Message: "example.Unknown"}, logger)
+	assert.Error(t, err, "unknown message")
+
+	_, err = New(Config{DescriptorFile: filepath.Join(t.TempDir(), "missing"), Message: "example.Event"}, logger)
+	assert.Error(t, err, "missing descriptor file")
+
+	registry := config.MustNewConfigFrom(map[string]interface{}{
+		"url":           "http://localhost:8081",
+		"auto_register": true,
+	})
+	_, err = New(Config{DescriptorFile: descriptorFile, Message: "example.Event", Registry: registry}, logger)
+	assert.Error(t, err, "auto_register is not supported")
+}
+
+func decode(t *testing.T, desc protoreflect.MessageDescriptor, data []byte) protoreflect.Message {
+	t.Helper()
+	msg := dynamicpb.NewMessage(desc)
+	require.NoError(t, proto.Unmarshal(data, msg))
+	return msg
+}
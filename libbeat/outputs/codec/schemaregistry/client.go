@@ -0,0 +1,345 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package schemaregistry provides a client for the Confluent Schema Registry
+// used by codecs serializing events with a registered schema and by inputs
+// decoding them, and the helpers to produce and parse the matching wire
+// format.
+package schemaregistry
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+// Schema types as known by the schema registry.
+const (
+	TypeAvro     = "AVRO"
+	TypeProtobuf = "PROTOBUF"
+)
+
+// magicByte prefixes all payloads in the schema registry wire format.
+const magicByte = 0
+
+// headerSize is the size of the wire format header, the magic byte and the
+// schema ID.
+const headerSize = 5
+
+// ErrNotWireFormat is returned when parsing a payload that is not in the
+// schema registry wire format.
+var ErrNotWireFormat = errors.New("payload is not in the schema registry wire format")
+
+// Client resolves schema IDs from a schema registry. IDs are cached per
+// subject, so the registry is only queried once per subject. Schemas
+// fetched by ID or subject version are cached as well.
+type Client struct {
+	config Config
+	client *http.Client
+	log    *logp.Logger
+
+	mu      sync.Mutex
+	ids     map[string]int
+	schemas map[string]Schema
+}
+
+// Schema is a schema registered in the schema registry.
+type Schema struct {
+	// Type is TypeAvro or TypeProtobuf.
+	Type       string
+	Schema     string
+	References []Reference
+}
+
+// Reference is a schema imported by another schema, such as a protobuf
+// file imported by a protobuf schema.
+type Reference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// NewClient creates a schema registry client.
+func NewClient(config Config, log *logp.Logger) (*Client, error) {
+	client, err := config.Transport.Client()
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		config:  config,
+		client:  client,
+		log:     log.Named("schema_registry"),
+		ids:     map[string]int{},
+		schemas: map[string]Schema{},
+	}, nil
+}
+
+// Subject returns the subject for a record produced to topic, according to
+// the configured subject name strategy.
+func (c *Client) Subject(topic, recordName string) (string, error) {
+	return SubjectNameStrategy(c.config.SubjectNameStrategy).Subject(topic, recordName)
+}
+
+// SchemaID returns the ID of the schema in subject. The schema is
+// registered if auto_register is enabled, otherwise it must already be
+// registered under the subject. If schema is empty, the ID of the latest
+// version of the subject is used.
+func (c *Client) SchemaID(subject, schemaType, schema string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if id, ok := c.ids[subject]; ok {
+		return id, nil
+	}
+
+	var (
+		id  int
+		err error
+	)
+	switch {
+	case schema == "":
+		id, err = c.latest(subject)
+	case c.config.AutoRegister:
+		id, err = c.register(subject, schemaType, schema)
+	default:
+		id, err = c.lookup(subject, schemaType, schema)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	c.log.Debugf("Using schema ID %d for subject %s", id, subject)
+	c.ids[subject] = id
+	return id, nil
+}
+
+// SchemaByID returns the schema registered with id. Format selects the
+// representation of the schema if not empty, for example "serialized" for
+// protobuf schemas encoded as a base64 FileDescriptorProto.
+func (c *Client) SchemaByID(id int, format string) (Schema, error) {
+	return c.schema("/schemas/ids/"+strconv.Itoa(id), format)
+}
+
+// SchemaByVersion returns the schema registered in subject with version.
+// Format is handled as in SchemaByID.
+func (c *Client) SchemaByVersion(subject string, version int, format string) (Schema, error) {
+	v := "latest"
+	if version > 0 {
+		v = strconv.Itoa(version)
+	}
+	return c.schema("/subjects/"+url.PathEscape(subject)+"/versions/"+v, format)
+}
+
+func (c *Client) schema(path, format string) (Schema, error) {
+	if format != "" {
+		path += "?format=" + url.QueryEscape(format)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if schema, ok := c.schemas[path]; ok {
+		return schema, nil
+	}
+
+	var resp schemaResponse
+	if err := c.do(http.MethodGet, path, nil, &resp); err != nil {
+		return Schema{}, fmt.Errorf("failed to get schema %s: %w", path, err)
+	}
+	schema := Schema{
+		Type:       resp.SchemaType,
+		Schema:     resp.Schema,
+		References: resp.References,
+	}
+	// AVRO is the default type and is omitted by the registry.
+	if schema.Type == "" {
+		schema.Type = TypeAvro
+	}
+
+	c.log.Debugf("Fetched %s schema %s", schema.Type, path)
+	c.schemas[path] = schema
+	return schema, nil
+}
+
+type schemaRequest struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType,omitempty"`
+}
+
+type schemaResponse struct {
+	ID         int         `json:"id"`
+	SchemaType string      `json:"schemaType"`
+	Schema     string      `json:"schema"`
+	References []Reference `json:"references"`
+}
+
+func (c *Client) register(subject, schemaType, schema string) (int, error) {
+	path := "/subjects/" + url.PathEscape(subject) + "/versions"
+	id, err := c.id(http.MethodPost, path, newSchemaRequest(schemaType, schema))
+	if err != nil {
+		return 0, fmt.Errorf("failed to register schema for subject %s: %w", subject, err)
+	}
+	return id, nil
+}
+
+func (c *Client) lookup(subject, schemaType, schema string) (int, error) {
+	path := "/subjects/" + url.PathEscape(subject)
+	id, err := c.id(http.MethodPost, path, newSchemaRequest(schemaType, schema))
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up schema for subject %s: %w", subject, err)
+	}
+	return id, nil
+}
+
+func (c *Client) latest(subject string) (int, error) {
+	path := "/subjects/" + url.PathEscape(subject) + "/versions/latest"
+	id, err := c.id(http.MethodGet, path, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest schema for subject %s: %w", subject, err)
+	}
+	return id, nil
+}
+
+func newSchemaRequest(schemaType, schema string) *schemaRequest {
+	// AVRO is the default type and is omitted for compatibility with older
+	// registries that don't support other types.
+	if schemaType == TypeAvro {
+		schemaType = ""
+	}
+	return &schemaRequest{Schema: schema, SchemaType: schemaType}
+}
+
+// id sends a request returning a schema ID.
+func (c *Client) id(method, path string, body interface{}) (int, error) {
+	var resp schemaResponse
+	if err := c.do(method, path, body, &resp); err != nil {
+		return 0, err
+	}
+	return resp.ID, nil
+}
+
+func (c *Client) do(method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.config.URL, "/")+path, reader) //nolint:noctx // bounded by the transport timeout
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", contentType)
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.config.Username != "" || c.config.Password != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: %s", resp.Status, respBody)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// AppendHeader appends the wire format header, the magic byte followed by
+// the big-endian schema ID, to buf.
+func AppendHeader(buf []byte, id int) []byte {
+	buf = append(buf, magicByte)
+	return binary.BigEndian.AppendUint32(buf, uint32(id)) //nolint:gosec // schema IDs are positive 32-bit integers
+}
+
+// AppendMessageIndexes appends the indexes identifying a protobuf message
+// within its schema, as required after the header for protobuf payloads.
+// The indexes are the path to the message through the nested message
+// declarations, starting with the top-level message.
+func AppendMessageIndexes(buf []byte, indexes []int) []byte {
+	// The common case of the first top-level message is encoded as a
+	// single 0.
+	if len(indexes) == 1 && indexes[0] == 0 {
+		return append(buf, 0)
+	}
+	buf = binary.AppendVarint(buf, int64(len(indexes)))
+	for _, i := range indexes {
+		buf = binary.AppendVarint(buf, int64(i))
+	}
+	return buf
+}
+
+// ParseHeader parses the wire format header of data, returning the schema
+// ID and the remaining payload. ErrNotWireFormat is returned if data does
+// not start with the header.
+func ParseHeader(data []byte) (int, []byte, error) {
+	if len(data) < headerSize || data[0] != magicByte {
+		return 0, nil, ErrNotWireFormat
+	}
+	return int(binary.BigEndian.Uint32(data[1:headerSize])), data[headerSize:], nil
+}
+
+// ParseMessageIndexes parses the protobuf message indexes following the
+// header, returning them and the remaining payload.
+func ParseMessageIndexes(data []byte) ([]int, []byte, error) {
+	n, size := binary.Varint(data)
+	if size <= 0 || n < 0 || n > int64(len(data)) {
+		return nil, nil, errors.New("invalid protobuf message indexes")
+	}
+	data = data[size:]
+	if n == 0 {
+		return []int{0}, data, nil
+	}
+
+	indexes := make([]int, n)
+	for i := range indexes {
+		idx, size := binary.Varint(data)
+		if size <= 0 || idx < 0 {
+			return nil, nil, errors.New("invalid protobuf message indexes")
+		}
+		indexes[i] = int(idx)
+		data = data[size:]
+	}
+	return indexes, data, nil
+}
@@ -0,0 +1,243 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schemaregistry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+type fakeRegistry struct {
+	mu       sync.Mutex
+	requests []string
+	bodies   []schemaRequest
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests = append(r.requests, req.Method+" "+req.URL.RequestURI())
+	if req.Body != nil && req.Method == http.MethodPost {
+		var body schemaRequest
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		r.bodies = append(r.bodies, body)
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	switch req.URL.Path {
+	case "/subjects/events-value/versions":
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	case "/subjects/events-value":
+		_, _ = w.Write([]byte(`{"subject": "events-value", "id": 2, "version": 1}`))
+	case "/subjects/events-value/versions/latest":
+		_, _ = w.Write([]byte(`{"subject": "events-value", "id": 3, "version": 4}`))
+	case "/schemas/ids/1":
+		_, _ = w.Write([]byte(`{"schema": "\"string\""}`))
+	case "/schemas/ids/3":
+		_, _ = w.Write([]byte(`{"schemaType": "PROTOBUF", "schema": "syntax = \"proto3\";", "references": [{"name": "common.proto", "subject": "common", "version": 2}]}`))
+	case "/subjects/common/versions/2":
+		_, _ = w.Write([]byte(`{"subject": "common", "id": 4, "version": 2, "schemaType": "PROTOBUF", "schema": "c2VyaWFsaXplZA=="}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code": 40401, "message": "Subject not found."}`))
+	}
+}
+
+func newTestClient(t *testing.T, url string, autoRegister bool) *Client {
+	config := DefaultConfig()
+	config.URL = url
+	config.AutoRegister = autoRegister
+	client, err := NewClient(config, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	return client
+}
+
+func TestSchemaID(t *testing.T) {
+	tests := map[string]struct {
+		autoRegister bool
+		schemaType   string
+		schema       string
+		wantID       int
+		wantRequest  string
+		wantBody     *schemaRequest
+	}{
+		"auto register": {
+			autoRegister: true,
+			schemaType:   TypeAvro,
+			schema:       `"string"`,
+			wantID:       1,
+			wantRequest:  "POST /subjects/events-value/versions",
+			wantBody:     &schemaRequest{Schema: `"string"`},
+		},
+		"lookup": {
+			schemaType:  TypeAvro,
+			schema:      `"string"`,
+			wantID:      2,
+			wantRequest: "POST /subjects/events-value",
+			wantBody:    &schemaRequest{Schema: `"string"`},
+		},
+		"latest": {
+			schemaType:  TypeProtobuf,
+			wantID:      3,
+			wantRequest: "GET /subjects/events-value/versions/latest",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			registry := &fakeRegistry{}
+			server := httptest.NewServer(registry)
+			defer server.Close()
+
+			client := newTestClient(t, server.URL, test.autoRegister)
+			for i := 0; i < 2; i++ {
+				id, err := client.SchemaID("events-value", test.schemaType, test.schema)
+				require.NoError(t, err)
+				assert.Equal(t, test.wantID, id)
+			}
+
+			registry.mu.Lock()
+			defer registry.mu.Unlock()
+			assert.Equal(t, []string{test.wantRequest}, registry.requests, "schema IDs must be cached")
+			if test.wantBody != nil {
+				require.Len(t, registry.bodies, 1)
+				assert.Equal(t, *test.wantBody, registry.bodies[0])
+			}
+		})
+	}
+}
+
+func TestSchemaByID(t *testing.T) {
+	registry := &fakeRegistry{}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, false)
+	for i := 0; i < 2; i++ {
+		schema, err := client.SchemaByID(1, "")
+		require.NoError(t, err)
+		assert.Equal(t, Schema{Type: TypeAvro, Schema: `"string"`}, schema)
+
+		schema, err = client.SchemaByID(3, "")
+		require.NoError(t, err)
+		assert.Equal(t, Schema{
+			Type:       TypeProtobuf,
+			Schema:     `syntax = "proto3";`,
+			References: []Reference{{Name: "common.proto", Subject: "common", Version: 2}},
+		}, schema)
+
+		schema, err = client.SchemaByVersion("common", 2, "serialized")
+		require.NoError(t, err)
+		assert.Equal(t, Schema{Type: TypeProtobuf, Schema: "c2VyaWFsaXplZA=="}, schema)
+	}
+
+	_, err := client.SchemaByID(5, "")
+	assert.ErrorContains(t, err, "not found")
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	assert.Equal(t, []string{
+		"GET /schemas/ids/1",
+		"GET /schemas/ids/3",
+		"GET /subjects/common/versions/2?format=serialized",
+		"GET /schemas/ids/5",
+	}, registry.requests, "schemas must be cached")
+}
+
+func TestSchemaIDError(t *testing.T) {
+	server := httptest.NewServer(&fakeRegistry{})
+	defer server.Close()
+
+	client := newTestClient(t, server.URL, false)
+	_, err := client.SchemaID("unknown-value", TypeAvro, `"string"`)
+	assert.ErrorContains(t, err, "Subject not found")
+}
+
+func TestSubjectNameStrategy(t *testing.T) {
+	tests := []struct {
+		strategy SubjectNameStrategy
+		topic    string
+		want     string
+		wantErr  bool
+	}{
+		{strategy: TopicNameStrategy, topic: "events", want: "events-value"},
+		{strategy: TopicNameStrategy, wantErr: true},
+		{strategy: RecordNameStrategy, topic: "events", want: "com.example.Event"},
+		{strategy: RecordNameStrategy, want: "com.example.Event"},
+		{strategy: TopicRecordNameStrategy, topic: "events", want: "events-com.example.Event"},
+		{strategy: TopicRecordNameStrategy, wantErr: true},
+	}
+
+	for _, test := range tests {
+		subject, err := test.strategy.Subject(test.topic, "com.example.Event")
+		if test.wantErr {
+			assert.Error(t, err, "strategy %s, topic %q", test.strategy, test.topic)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, test.want, subject, "strategy %s, topic %q", test.strategy, test.topic)
+	}
+}
+
+func TestWireFormat(t *testing.T) {
+	assert.Equal(t, []byte{0, 0, 0, 1, 2}, AppendHeader(nil, 258))
+	assert.Equal(t, []byte{0}, AppendMessageIndexes(nil, []int{0}))
+	assert.Equal(t, []byte{2, 2}, AppendMessageIndexes(nil, []int{1}))
+	assert.Equal(t, []byte{4, 0, 4}, AppendMessageIndexes(nil, []int{0, 2}))
+
+	id, payload, err := ParseHeader([]byte{0, 0, 0, 1, 2, 'a'})
+	require.NoError(t, err)
+	assert.Equal(t, 258, id)
+	assert.Equal(t, []byte{'a'}, payload)
+
+	for _, data := range [][]byte{nil, {0, 0, 1}, []byte(`{"message":"hello"}`)} {
+		_, _, err = ParseHeader(data)
+		assert.ErrorIs(t, err, ErrNotWireFormat)
+	}
+
+	for _, indexes := range [][]int{{0}, {1}, {0, 2}} {
+		data := append(AppendMessageIndexes(nil, indexes), 'a')
+		got, payload, err := ParseMessageIndexes(data)
+		require.NoError(t, err)
+		assert.Equal(t, indexes, got)
+		assert.Equal(t, []byte{'a'}, payload)
+	}
+
+	_, _, err = ParseMessageIndexes([]byte{4, 0})
+	assert.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	config := DefaultConfig()
+	assert.Error(t, config.Validate(), "url is required")
+
+	config.URL = "http://localhost:8081"
+	assert.NoError(t, config.Validate())
+
+	config.SubjectNameStrategy = "unknown"
+	assert.Error(t, config.Validate())
+}
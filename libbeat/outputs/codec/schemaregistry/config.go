@@ -0,0 +1,96 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package schemaregistry
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+// SubjectNameStrategy selects the subject a schema is registered under.
+type SubjectNameStrategy string
+
+const (
+	// TopicNameStrategy uses "<topic>-value".
+	TopicNameStrategy SubjectNameStrategy = "topic_name"
+	// RecordNameStrategy uses the fully-qualified record name.
+	RecordNameStrategy SubjectNameStrategy = "record_name"
+	// TopicRecordNameStrategy uses "<topic>-<fully-qualified record name>".
+	TopicRecordNameStrategy SubjectNameStrategy = "topic_record_name"
+)
+
+// Config is the configuration of the schema registry used by a codec or an
+// input.
+type Config struct {
+	URL                 string `config:"url"`
+	Username            string `config:"username"`
+	Password            string `config:"password"`
+	SubjectNameStrategy string `config:"subject_name_strategy"`
+	AutoRegister        bool   `config:"auto_register"`
+
+	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+// DefaultConfig returns the default schema registry configuration.
+func DefaultConfig() Config {
+	transport := httpcommon.DefaultHTTPTransportSettings()
+	transport.Timeout = 10 * time.Second
+	return Config{
+		SubjectNameStrategy: string(TopicNameStrategy),
+		Transport:           transport,
+	}
+}
+
+func (c *Config) Validate() error {
+	if c.URL == "" {
+		return errors.New("schema registry url is required")
+	}
+	if _, err := url.Parse(c.URL); err != nil {
+		return fmt.Errorf("invalid schema registry url: %w", err)
+	}
+
+	switch SubjectNameStrategy(c.SubjectNameStrategy) {
+	case TopicNameStrategy, RecordNameStrategy, TopicRecordNameStrategy:
+	default:
+		return fmt.Errorf("unsupported subject_name_strategy %q", c.SubjectNameStrategy)
+	}
+	return nil
+}
+
+// Subject returns the subject for the value schema of a record with the
+// given fully-qualified name produced to topic.
+func (s SubjectNameStrategy) Subject(topic, recordName string) (string, error) {
+	switch s {
+	case RecordNameStrategy:
+		return recordName, nil
+	case TopicRecordNameStrategy:
+		if topic == "" {
+			return "", fmt.Errorf("subject name strategy %q requires a topic", s)
+		}
+		return topic + "-" + recordName, nil
+	default:
+		if topic == "" {
+			return "", fmt.Errorf("subject name strategy %q requires a topic", s)
+		}
+		return topic + "-value", nil
+	}
+}
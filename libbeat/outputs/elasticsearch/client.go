@@ -61,6 +61,14 @@ type Client struct {
 	// forwarded to this index. Otherwise, they will be dropped.
 	deadLetterIndex string
 
+	// If deadLetterFile is set, events with bulk-ingest errors will be
+	// written to this file instead.
+	deadLetterFile *deadLetterFile
+
+	// If dataStreams is set, missing data streams targeted by routed events
+	// are created before the events are sent.
+	dataStreams *dataStreamCreator
+
 	log                    *logp.Logger
 	pLogIndex              *periodic.Doer
 	pLogIndexTryDeadLetter *periodic.Doer
@@ -81,6 +89,15 @@ type clientSettings struct {
 	// If deadLetterIndex is set, events with bulk-ingest errors will be
 	// forwarded to this index. Otherwise, they will be dropped.
 	deadLetterIndex string
+
+	// If deadLetterFile is set, events with bulk-ingest errors will be
+	// written to this file instead. It is shared by all clients of an output.
+	deadLetterFile *deadLetterFile
+
+	// If dataStreams is set, missing data streams targeted by routed events
+	// are created before the events are sent. It is shared by all clients
+	// of an output.
+	dataStreams *dataStreamCreator
 }
 
 type bulkResultStats struct {
@@ -88,7 +105,7 @@ type bulkResultStats struct {
 	duplicates   int // number of events failed with `create` due to ID already being indexed
 	fails        int // number of events with retryable failures.
 	nonIndexable int // number of events with permanent failures.
-	deadLetter   int // number of failed events ingested to the dead letter index.
+	deadLetter   int // number of failed events ingested to the dead letter index or file.
 	tooMany      int // number of events receiving HTTP 429 Too Many Requests
 }
 
@@ -194,6 +211,8 @@ func NewClient(
 		pipelineSelector: pipeline,
 		observer:         observer,
 		deadLetterIndex:  s.deadLetterIndex,
+		deadLetterFile:   s.deadLetterFile,
+		dataStreams:      s.dataStreams,
 
 		log:                    log,
 		pLogDeadLetter:         pLogDeadLetter,
@@ -237,6 +256,8 @@ func (client *Client) Clone() *Client {
 			indexSelector:    client.indexSelector,
 			pipelineSelector: client.pipelineSelector,
 			deadLetterIndex:  client.deadLetterIndex,
+			deadLetterFile:   client.deadLetterFile,
+			dataStreams:      client.dataStreams,
 		},
 		nil, // XXX: do not pass connection callback?
 		client.log,
@@ -303,6 +324,10 @@ func (client *Client) doBulkRequest(
 	result.events = resultEvents
 	client.observer.PermanentErrors(len(rawEvents) - len(resultEvents))
 
+	if client.dataStreams != nil {
+		client.ensureDataStreams(result.events)
+	}
+
 	// If we encoded any events, send the network request.
 	if len(result.events) > 0 {
 		begin := time.Now()
@@ -320,6 +345,24 @@ func (client *Client) doBulkRequest(
 	return result
 }
 
+// ensureDataStreams creates the data streams targeted by routed events
+// that don't exist yet. Failures are logged but don't prevent the events
+// from being sent: Elasticsearch may still create the data stream on
+// ingestion, and otherwise reports per-event errors.
+func (client *Client) ensureDataStreams(data []publisher.Event) {
+	checked := map[string]bool{}
+	for i := range data {
+		event, ok := data[i].EncodedEvent.(*encodedEvent)
+		if !ok || !event.dataStream || checked[event.index] {
+			continue
+		}
+		checked[event.index] = true
+		if err := client.dataStreams.ensure(&client.conn, client.log, event.index); err != nil {
+			client.log.Warn(err)
+		}
+	}
+}
+
 func (client *Client) handleBulkResultError(
 	ctx context.Context, batch publisher.Batch, bulkResult bulkResult,
 ) error {
@@ -524,6 +567,18 @@ func (client *Client) applyItemStatus(
 			stats.nonIndexable++
 			return false
 		}
+		if client.deadLetterFile != nil {
+			// Write the failure to the dead letter file, no retry needed.
+			if err := client.deadLetterFile.write(encodedEvent, itemStatus, string(itemMessage)); err != nil {
+				client.pLogDeadLetter.Add()
+				client.log.Errorw(fmt.Sprintf("Can't write to dead letter file event '%s' (status=%v): %s: %v", encodedEvent, itemStatus, itemMessage, err), logp.TypeKey, logp.EventType)
+				stats.nonIndexable++
+				return false
+			}
+			client.log.Warnw(fmt.Sprintf("Cannot index event '%s' (status=%v): %s, written to dead letter file", encodedEvent, itemStatus, itemMessage), logp.TypeKey, logp.EventType)
+			stats.deadLetter++
+			return false
+		}
 		if client.deadLetterIndex == "" {
 			// Fatal error and no dead letter index, drop.
 			client.pLogIndex.Add()
@@ -550,6 +605,11 @@ func (client *Client) Connect(ctx context.Context) error {
 }
 
 func (client *Client) Close() error {
+	if client.deadLetterFile != nil {
+		if err := client.deadLetterFile.close(); err != nil {
+			client.log.Warnf("Failed to close dead letter file: %v", err)
+		}
+	}
 	return client.conn.Close()
 }
 
@@ -28,6 +28,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -501,6 +503,68 @@ func TestCollectPublishFailDeadLetterIndex(t *testing.T) {
 	}
 }
 
+func TestCollectPublishFailDeadLetterFile(t *testing.T) {
+	logger := logptest.NewTestingLogger(t, "")
+	fileConfig := defaultDeadLetterFileConfig()
+	fileConfig.Path = t.TempDir()
+	deadLetterFile := &deadLetterFile{config: fileConfig, log: logger}
+	client, err := NewClient(
+		clientSettings{
+			observer:       outputs.NewNilObserver(),
+			indexSelector:  testIndexSelector{},
+			deadLetterFile: deadLetterFile,
+		},
+		nil,
+		logger,
+	)
+	assert.NoError(t, err)
+
+	const errorMessage = "test error message"
+	response := []byte(`
+{
+	"items": [
+		{"create": {"status": 200}},
+		{
+			"create": {
+				"error" : "` + errorMessage + `",
+				"status" : 400
+			}
+		},
+		{"create": {"status": 200}}
+	]
+}`)
+
+	event1 := encodeEvent(client, publisher.Event{Content: beat.Event{Fields: mapstr.M{"bar": 1}}})
+	event2 := encodeEvent(client, publisher.Event{Content: beat.Event{Fields: mapstr.M{"bar": 2}}})
+	eventFail := encodeEvent(client, publisher.Event{Content: beat.Event{Fields: mapstr.M{"bar": "bar1"}}})
+	events := []publisher.Event{event1, eventFail, event2}
+
+	res, stats := client.bulkCollectPublishFails(bulkResult{
+		events:   events,
+		status:   200,
+		response: response,
+	})
+	assert.Equal(t, bulkResultStats{acked: 2, deadLetter: 1}, stats)
+	assert.Empty(t, res, "events written to the dead letter file must not be retried")
+	require.NoError(t, client.Close())
+
+	// The rotator adds a date suffix to the configured file name.
+	files, err := filepath.Glob(filepath.Join(fileConfig.Path, "dead_letter-*.ndjson"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 1)
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &doc))
+	assert.Equal(t, "test", doc["index"])
+	assert.Equal(t, float64(400), doc["error.type"])
+	assert.Contains(t, doc["error.message"], errorMessage)
+	assert.Contains(t, doc["message"], "bar1")
+}
+
 func TestCollectPublishFailDrop(t *testing.T) {
 	logger := logptest.NewTestingLogger(t, "")
 	client, err := NewClient(
@@ -27,23 +27,24 @@ import (
 )
 
 type ElasticsearchConfig struct {
-	Protocol           string            `config:"protocol"`
-	Path               string            `config:"path"`
-	Params             map[string]string `config:"parameters"`
-	Headers            map[string]string `config:"headers"`
-	Username           string            `config:"username"`
-	Password           string            `config:"password"`
-	APIKey             string            `config:"api_key"`
-	LoadBalance        bool              `config:"loadbalance"`
-	CompressionLevel   int               `config:"compression_level" validate:"min=0, max=9"`
-	EscapeHTML         bool              `config:"escape_html"`
-	Kerberos           *kerberos.Config  `config:"kerberos"`
-	BulkMaxSize        int               `config:"bulk_max_size"`
-	MaxRetries         int               `config:"max_retries"`
-	Backoff            Backoff           `config:"backoff"`
-	NonIndexablePolicy *config.Namespace `config:"non_indexable_policy"`
-	AllowOlderVersion  bool              `config:"allow_older_versions"`
-	Queue              config.Namespace  `config:"queue"`
+	Protocol           string                  `config:"protocol"`
+	Path               string                  `config:"path"`
+	Params             map[string]string       `config:"parameters"`
+	Headers            map[string]string       `config:"headers"`
+	Username           string                  `config:"username"`
+	Password           string                  `config:"password"`
+	APIKey             string                  `config:"api_key"`
+	LoadBalance        bool                    `config:"loadbalance"`
+	CompressionLevel   int                     `config:"compression_level" validate:"min=0, max=9"`
+	EscapeHTML         bool                    `config:"escape_html"`
+	Kerberos           *kerberos.Config        `config:"kerberos"`
+	BulkMaxSize        int                     `config:"bulk_max_size"`
+	MaxRetries         int                     `config:"max_retries"`
+	Backoff            Backoff                 `config:"backoff"`
+	NonIndexablePolicy *config.Namespace       `config:"non_indexable_policy"`
+	AllowOlderVersion  bool                    `config:"allow_older_versions"`
+	Queue              config.Namespace        `config:"queue"`
+	DataStreamRouting  dataStreamRoutingConfig `config:"data_stream_routing"`
 
 	Transport httpcommon.HTTPTransportSettings `config:",inline"`
 }
@@ -74,8 +75,9 @@ var (
 			Init: 1 * time.Second,
 			Max:  60 * time.Second,
 		},
-		BulkMaxSize: defaultBulkSize,
-		Transport:   esDefaultTransportSettings(),
+		BulkMaxSize:       defaultBulkSize,
+		DataStreamRouting: defaultDataStreamRoutingConfig(),
+		Transport:         esDefaultTransportSettings(),
 	}
 )
 
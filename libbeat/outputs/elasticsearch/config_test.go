@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
 )
 
 func TestValidDropPolicyConfig(t *testing.T) {
@@ -58,6 +59,68 @@ non_indexable_policy.dead_letter_index:
 	assert.Equal(t, "my-dead-letter-index", index, "index should match config")
 }
 
+func TestDeadLetterFilePolicyConfig(t *testing.T) {
+	config := `
+non_indexable_policy.dead_letter_file:
+    path: "/var/lib/beat/dead_letter"
+    number_of_files: 3
+`
+	c := conf.MustNewConfigFrom(config)
+	elasticsearchOutputConfig, err := readConfig(c)
+	if err != nil {
+		t.Fatalf("Can't create test configuration from valid input")
+	}
+	index, err := deadLetterIndexForPolicy(elasticsearchOutputConfig.NonIndexablePolicy)
+	if err != nil {
+		t.Fatalf("Can't read non-indexable policy: %v", err.Error())
+	}
+	assert.Equal(t, "", index, "dead letter index should be empty string")
+
+	deadLetterFile, err := deadLetterFileForPolicy(elasticsearchOutputConfig.NonIndexablePolicy, logptest.NewTestingLogger(t, ""))
+	if err != nil {
+		t.Fatalf("Can't read non-indexable policy: %v", err.Error())
+	}
+	if assert.NotNil(t, deadLetterFile) {
+		assert.Equal(t, "/var/lib/beat/dead_letter", deadLetterFile.config.Path, "path should match config")
+		assert.Equal(t, "dead_letter", deadLetterFile.config.Filename, "filename should use default")
+		assert.Equal(t, uint(3), deadLetterFile.config.NumberOfFiles, "number_of_files should match config")
+		assert.Equal(t, uint(10*1024), deadLetterFile.config.RotateEveryKb, "rotate_every_kb should use default")
+	}
+}
+
+func TestInvalidDeadLetterFilePolicyConfig(t *testing.T) {
+	tests := map[string]string{
+		"dead_letter_file policy without properties": `
+non_indexable_policy.dead_letter_file: ~
+`,
+		"dead_letter_file policy without path": `
+non_indexable_policy.dead_letter_file:
+    foo: "bar"
+`,
+		"dead_letter_file policy with too few files": `
+non_indexable_policy.dead_letter_file:
+    path: "/var/lib/beat/dead_letter"
+    number_of_files: 1
+`,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := conf.MustNewConfigFrom(test)
+			elasticsearchOutputConfig, err := readConfig(c)
+			if err != nil {
+				t.Fatalf("Can't create test configuration from valid input")
+			}
+
+			_, err = deadLetterFileForPolicy(elasticsearchOutputConfig.NonIndexablePolicy, logptest.NewTestingLogger(t, ""))
+			if err == nil {
+				t.Fatalf("Invalid non-indexable policy config should produce an error")
+			}
+			t.Logf("error %s", err.Error())
+		})
+	}
+}
+
 func TestInvalidNonIndexablePolicyConfig(t *testing.T) {
 	tests := map[string]string{
 		"non_indexable_policy with invalid policy": `
@@ -0,0 +1,218 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	dataStreamTypeField      = "data_stream.type"
+	dataStreamDatasetField   = "data_stream.dataset"
+	dataStreamNamespaceField = "data_stream.namespace"
+
+	// Elasticsearch limits index names to 255 bytes; the data stream naming
+	// scheme further restricts dataset and namespace to 100 bytes each.
+	maxDataStreamPartLen = 100
+)
+
+// dataStreamRoutingConfig configures per-event routing of documents to data
+// streams named after the event's data_stream.* fields.
+type dataStreamRoutingConfig struct {
+	Enabled bool `config:"enabled"`
+
+	// Fallback values used when the event does not carry the respective
+	// field, or when the value is not a valid data stream name component.
+	Type      string `config:"type"`
+	Dataset   string `config:"dataset"`
+	Namespace string `config:"namespace"`
+
+	// Create enables creation of missing data streams before the first
+	// event is sent to them.
+	Create bool `config:"create"`
+}
+
+func defaultDataStreamRoutingConfig() dataStreamRoutingConfig {
+	return dataStreamRoutingConfig{
+		Enabled:   false,
+		Type:      "logs",
+		Dataset:   "generic",
+		Namespace: "default",
+		Create:    false,
+	}
+}
+
+func (c *dataStreamRoutingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if err := validateDataStreamType(c.Type); err != nil {
+		return fmt.Errorf("invalid data_stream_routing.type: %w", err)
+	}
+	if err := validateDataStreamPart(c.Dataset); err != nil {
+		return fmt.Errorf("invalid data_stream_routing.dataset: %w", err)
+	}
+	if err := validateDataStreamPart(c.Namespace); err != nil {
+		return fmt.Errorf("invalid data_stream_routing.namespace: %w", err)
+	}
+	return nil
+}
+
+func validateDataStreamType(s string) error {
+	if s == "" {
+		return errors.New("value is empty")
+	}
+	if strings.ContainsAny(s, `\/*?"<>| ,#:-`) {
+		return fmt.Errorf("%q contains invalid characters", s)
+	}
+	if s != strings.ToLower(s) {
+		return fmt.Errorf("%q must be lowercase", s)
+	}
+	return nil
+}
+
+func validateDataStreamPart(s string) error {
+	if err := validateDataStreamType(s); err != nil {
+		return err
+	}
+	if len(s) > maxDataStreamPartLen {
+		return fmt.Errorf("%q is longer than %d bytes", s, maxDataStreamPartLen)
+	}
+	return nil
+}
+
+// dataStreamRouter is an outputs.IndexSelector computing the target data
+// stream from the event's data_stream.type, data_stream.dataset and
+// data_stream.namespace fields. Events with an explicit index in their
+// metadata are passed to the wrapped selector unchanged.
+type dataStreamRouter struct {
+	config dataStreamRoutingConfig
+	next   outputs.IndexSelector
+}
+
+func newDataStreamRouter(config dataStreamRoutingConfig, next outputs.IndexSelector) *dataStreamRouter {
+	return &dataStreamRouter{config: config, next: next}
+}
+
+func (r *dataStreamRouter) Select(e *beat.Event) (string, error) {
+	index, _, err := r.route(e)
+	return index, err
+}
+
+// route returns the index for the event, reporting whether the index was
+// computed from the data_stream fields. Invalid or missing fields are
+// replaced with the configured fallbacks in the event itself, so the
+// document stays consistent with the data stream it is indexed into.
+func (r *dataStreamRouter) route(e *beat.Event) (string, bool, error) {
+	if hasCustomIndex(e) {
+		if r.next == nil {
+			return "", false, nil
+		}
+		index, err := r.next.Select(e)
+		return index, false, err
+	}
+
+	typ := routingValue(e, dataStreamTypeField, r.config.Type, validateDataStreamType)
+	dataset := routingValue(e, dataStreamDatasetField, r.config.Dataset, validateDataStreamPart)
+	namespace := routingValue(e, dataStreamNamespaceField, r.config.Namespace, validateDataStreamPart)
+
+	return typ + "-" + dataset + "-" + namespace, true, nil
+}
+
+func hasCustomIndex(e *beat.Event) bool {
+	if len(e.Meta) == 0 {
+		return false
+	}
+	for _, key := range []string{events.FieldMetaIndex, events.FieldMetaRawIndex, events.FieldMetaAlias} {
+		if _, err := events.GetMetaStringValue(*e, key); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func routingValue(e *beat.Event, field, fallback string, validate func(string) error) string {
+	if v, err := e.GetValue(field); err == nil {
+		if s, ok := v.(string); ok && validate(s) == nil {
+			return s
+		}
+	}
+	_, _ = e.PutValue(field, fallback)
+	return fallback
+}
+
+// dataStreamCreator creates missing data streams on first use. It is shared
+// by all clients of an output, so each data stream is only checked once.
+type dataStreamCreator struct {
+	mu    sync.Mutex
+	known map[string]struct{}
+}
+
+func newDataStreamCreator() *dataStreamCreator {
+	return &dataStreamCreator{known: map[string]struct{}{}}
+}
+
+func (c *dataStreamCreator) isKnown(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.known[name]
+	return ok
+}
+
+func (c *dataStreamCreator) markKnown(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.known[name] = struct{}{}
+}
+
+// ensure makes sure the data stream exists, creating it if necessary.
+// Data streams that were found or created are remembered and not checked
+// again.
+func (c *dataStreamCreator) ensure(conn *eslegclient.Connection, log *logp.Logger, name string) error {
+	if c.isKnown(name) {
+		return nil
+	}
+
+	path := "/_data_stream/" + name
+	status, _, err := conn.Request(http.MethodGet, path, "", nil, nil)
+	switch {
+	case err == nil:
+		c.markKnown(name)
+		return nil
+	case status != http.StatusNotFound:
+		return fmt.Errorf("could not check data stream %s: %w", name, err)
+	}
+
+	log.Infof("Creating data stream %s", name)
+	status, body, err := conn.Request(http.MethodPut, path, "", nil, nil)
+	if err != nil && !(status == http.StatusBadRequest && strings.Contains(string(body), "resource_already_exists_exception")) {
+		return fmt.Errorf("could not create data stream %s: %w", name, err)
+	}
+	c.markKnown(name)
+	return nil
+}
@@ -0,0 +1,194 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
+	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestDataStreamRouterRoute(t *testing.T) {
+	router := newDataStreamRouter(defaultDataStreamRoutingConfig(), testIndexSelector{})
+
+	tests := map[string]struct {
+		fields     mapstr.M
+		meta       mapstr.M
+		want       string
+		wantRouted bool
+		wantFields mapstr.M
+	}{
+		"fields set": {
+			fields: mapstr.M{"data_stream": mapstr.M{
+				"type": "metrics", "dataset": "nginx.access", "namespace": "prod",
+			}},
+			want:       "metrics-nginx.access-prod",
+			wantRouted: true,
+		},
+		"fields missing": {
+			fields:     mapstr.M{},
+			want:       "logs-generic-default",
+			wantRouted: true,
+			wantFields: mapstr.M{"data_stream": mapstr.M{
+				"type": "logs", "dataset": "generic", "namespace": "default",
+			}},
+		},
+		"invalid fields fall back": {
+			fields: mapstr.M{"data_stream": mapstr.M{
+				"type": "logs", "dataset": "Nginx-Access", "namespace": strings.Repeat("a", 101),
+			}},
+			want:       "logs-generic-default",
+			wantRouted: true,
+			wantFields: mapstr.M{"data_stream": mapstr.M{
+				"type": "logs", "dataset": "generic", "namespace": "default",
+			}},
+		},
+		"non-string field falls back": {
+			fields: mapstr.M{"data_stream": mapstr.M{
+				"type": "logs", "dataset": 42, "namespace": "prod",
+			}},
+			want:       "logs-generic-prod",
+			wantRouted: true,
+		},
+		"explicit index wins": {
+			fields: mapstr.M{"data_stream": mapstr.M{
+				"type": "logs", "dataset": "nginx", "namespace": "prod",
+			}},
+			meta:       mapstr.M{events.FieldMetaIndex: "custom"},
+			want:       "test",
+			wantRouted: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			event := &beat.Event{Fields: test.fields, Meta: test.meta}
+			index, routed, err := router.route(event)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, index)
+			assert.Equal(t, test.wantRouted, routed)
+			if test.wantFields != nil {
+				assert.Equal(t, test.wantFields, event.Fields)
+			}
+		})
+	}
+}
+
+func TestDataStreamRoutingConfigValidate(t *testing.T) {
+	config := defaultDataStreamRoutingConfig()
+	config.Enabled = true
+	require.NoError(t, config.Validate())
+
+	config.Dataset = "Invalid"
+	assert.Error(t, config.Validate())
+
+	config = defaultDataStreamRoutingConfig()
+	config.Enabled = true
+	config.Namespace = "a-b"
+	assert.Error(t, config.Validate())
+
+	config = defaultDataStreamRoutingConfig()
+	config.Type = ""
+	assert.NoError(t, config.Validate(), "disabled routing is not validated")
+}
+
+func TestDataStreamRoutingCreatesMissingDataStreams(t *testing.T) {
+	var mu sync.Mutex
+	existing := map[string]bool{"logs-existing-default": true}
+	var puts []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/":
+			fmt.Fprintln(w, `{"version":{"number":"8.15.0"}}`)
+		case strings.HasPrefix(r.URL.Path, "/_data_stream/"):
+			name := strings.TrimPrefix(r.URL.Path, "/_data_stream/")
+			if r.Method == http.MethodPut {
+				puts = append(puts, name)
+				existing[name] = true
+				fmt.Fprintln(w, `{"acknowledged":true}`)
+				return
+			}
+			if !existing[name] {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintln(w, `{}`)
+				return
+			}
+			fmt.Fprintln(w, `{"data_streams":[]}`)
+		case r.URL.Path == "/_bulk":
+			fmt.Fprintln(w, `{"items":[{"create":{"status":201}},{"create":{"status":201}},{"create":{"status":201}},{"create":{"status":201}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	config := defaultDataStreamRoutingConfig()
+	config.Enabled = true
+	config.Create = true
+
+	client, err := NewClient(
+		clientSettings{
+			connection:    eslegclient.ConnectionSettings{URL: ts.URL},
+			indexSelector: newDataStreamRouter(config, nil),
+			dataStreams:   newDataStreamCreator(),
+		},
+		nil,
+		logptest.NewTestingLogger(t, ""),
+	)
+	require.NoError(t, err)
+	require.NoError(t, client.Connect(context.Background()))
+
+	newEvent := func(dataset string) beat.Event {
+		return beat.Event{Fields: mapstr.M{
+			"data_stream": mapstr.M{"dataset": dataset},
+		}}
+	}
+
+	for i := 0; i < 2; i++ {
+		batch := encodeBatch(client, outest.NewBatch(
+			newEvent("nginx"),
+			newEvent("nginx"),
+			newEvent("existing"),
+			newEvent("apache"),
+		))
+		require.NoError(t, client.Publish(context.Background(), batch))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.ElementsMatch(t, []string{"logs-nginx-default", "logs-apache-default"}, puts,
+		"each missing data stream must be created exactly once")
+}
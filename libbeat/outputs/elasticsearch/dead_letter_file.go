@@ -0,0 +1,143 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/file"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const dead_letter_file = "dead_letter_file"
+
+type deadLetterFileConfig struct {
+	Path          string `config:"path"`
+	Filename      string `config:"filename"`
+	RotateEveryKb uint   `config:"rotate_every_kb" validate:"min=1"`
+	NumberOfFiles uint   `config:"number_of_files"`
+	Permissions   uint32 `config:"permissions"`
+}
+
+func defaultDeadLetterFileConfig() deadLetterFileConfig {
+	return deadLetterFileConfig{
+		Filename:      "dead_letter",
+		RotateEveryKb: 10 * 1024,
+		NumberOfFiles: 7,
+		Permissions:   0600,
+	}
+}
+
+func (c *deadLetterFileConfig) Validate() error {
+	if c.Path == "" {
+		return fmt.Errorf("%s policy requires a `path` to be specified", dead_letter_file)
+	}
+	if c.Filename == "" {
+		return fmt.Errorf("%s policy requires a `filename` to be specified", dead_letter_file)
+	}
+	if c.NumberOfFiles < 2 || c.NumberOfFiles > file.MaxBackupsLimit {
+		return fmt.Errorf("the number_of_files to keep should be between 2 and %v",
+			file.MaxBackupsLimit)
+	}
+	return nil
+}
+
+// deadLetterFile writes documents rejected by Elasticsearch to a local,
+// rotated file, one JSON object per line. The rotator appends the date and
+// the .ndjson extension to the configured file name. It is shared by all clients of an
+// output. The underlying file is opened on the first write and closed when
+// any client is closed, to be reopened on the next write.
+type deadLetterFile struct {
+	config deadLetterFileConfig
+	log    *logp.Logger
+
+	mu      sync.Mutex
+	rotator *file.Rotator
+}
+
+func deadLetterFileForPolicy(configNamespace *config.Namespace, log *logp.Logger) (*deadLetterFile, error) {
+	if configNamespace == nil || configNamespace.Name() != dead_letter_file {
+		return nil, nil
+	}
+	cfgwarn.Beta("The non_indexable_policy dead_letter_file is beta.")
+	if configNamespace.Config() == nil {
+		return nil, fmt.Errorf("%s policy requires a `path` to be specified", dead_letter_file)
+	}
+
+	fileConfig := defaultDeadLetterFileConfig()
+	if err := configNamespace.Config().Unpack(&fileConfig); err != nil {
+		return nil, err
+	}
+	return &deadLetterFile{config: fileConfig, log: log}, nil
+}
+
+// write appends the rejected event to the dead letter file, along with the
+// index it was sent to and the error returned by Elasticsearch.
+func (f *deadLetterFile) write(event *encodedEvent, errType int, errMsg string) error {
+	line, err := json.Marshal(map[string]interface{}{
+		"@timestamp":    event.timestamp,
+		"index":         event.index,
+		"message":       string(event.encoding),
+		"error.type":    errType,
+		"error.message": errMsg,
+	})
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.rotator == nil {
+		f.rotator, err = file.NewFileRotator(
+			filepath.Join(f.config.Path, f.config.Filename),
+			file.MaxSizeBytes(f.config.RotateEveryKb*1024),
+			file.MaxBackups(f.config.NumberOfFiles),
+			file.Permissions(os.FileMode(f.config.Permissions)),
+			file.RotateOnStartup(false),
+			file.WithLogger(f.log.Named("rotator").With(logp.Namespace("rotator"))),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to open dead letter file in %s: %w", f.config.Path, err)
+		}
+	}
+
+	if _, err := f.rotator.Write(line); err != nil {
+		return fmt.Errorf("failed to write to dead letter file in %s: %w", f.config.Path, err)
+	}
+	return nil
+}
+
+func (f *deadLetterFile) close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.rotator == nil {
+		return nil
+	}
+	err := f.rotator.Close()
+	f.rotator = nil
+	return err
+}
@@ -44,7 +44,7 @@ func deadLetterIndexForConfig(config *config.C) (string, error) {
 }
 
 func deadLetterIndexForPolicy(configNamespace *config.Namespace) (string, error) {
-	if configNamespace == nil || configNamespace.Name() == drop {
+	if configNamespace == nil || configNamespace.Name() == drop || configNamespace.Name() == dead_letter_file {
 		return "", nil
 	}
 	if configNamespace.Name() == dead_letter_index {
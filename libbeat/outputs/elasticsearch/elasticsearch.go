@@ -72,6 +72,12 @@ func makeES(
 		return outputs.Fail(err)
 	}
 
+	deadLetterFile, err := deadLetterFileForPolicy(esConfig.NonIndexablePolicy, log)
+	if err != nil {
+		log.Errorf("error in non_indexable_policy: %v", err)
+		return outputs.Fail(err)
+	}
+
 	hosts, err := outputs.ReadHostList(cfg)
 	if err != nil {
 		return outputs.Fail(err)
@@ -87,6 +93,14 @@ func makeES(
 		params = nil
 	}
 
+	var dataStreams *dataStreamCreator
+	if esConfig.DataStreamRouting.Enabled {
+		indexSelector = newDataStreamRouter(esConfig.DataStreamRouting, indexSelector)
+		if esConfig.DataStreamRouting.Create {
+			dataStreams = newDataStreamCreator()
+		}
+	}
+
 	encoderFactory := newEventEncoderFactory(
 		esConfig.EscapeHTML, indexSelector, pipelineSelector)
 
@@ -120,6 +134,8 @@ func makeES(
 			pipelineSelector: pipelineSelector,
 			observer:         observer,
 			deadLetterIndex:  deadLetterIndex,
+			deadLetterFile:   deadLetterFile,
+			dataStreams:      dataStreams,
 		}, &connectCallbackRegistry, log)
 		if err != nil {
 			return outputs.Fail(err)
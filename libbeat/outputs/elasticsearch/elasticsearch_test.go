@@ -18,14 +18,23 @@
 package elasticsearch
 
 import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
+	"github.com/elastic/beats/v7/libbeat/idxmgmt"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	libtesting "github.com/elastic/beats/v7/libbeat/testing"
 	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
 	"github.com/elastic/elastic-agent-libs/mapstr"
+	agenttesting "github.com/elastic/elastic-agent-libs/testing"
 )
 
 func TestConnectCallbacksManagement(t *testing.T) {
@@ -137,3 +146,37 @@ func TestPipelineSelection(t *testing.T) {
 		})
 	}
 }
+
+func TestFilteredOutputTest(t *testing.T) {
+	esMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{ "version": { "number": "8.0.0" } }`))
+	}))
+	defer esMock.Close()
+
+	info := beat.Info{Beat: "libbeat", Version: "8.0.0", Logger: logptest.NewTestingLogger(t, "")}
+	im, err := idxmgmt.DefaultSupport(info, nil)
+	require.NoError(t, err)
+
+	cfg := config.MustNewConfigFrom(mapstr.M{
+		"hosts":              []string{esMock.URL},
+		"when.contains.tags": "audit",
+	})
+	group, err := outputs.Load(im, info, nil, "elasticsearch", cfg)
+	require.NoError(t, err)
+	require.Len(t, group.Clients, 1)
+
+	// The filtered client is still connected by the pipeline and tested by
+	// `test output`.
+	_, isNetwork := group.Clients[0].(outputs.NetworkClient)
+	assert.True(t, isNetwork)
+	client, ok := group.Clients[0].(agenttesting.Testable)
+	require.True(t, ok)
+
+	var out bytes.Buffer
+	failed := false
+	client.Test(libtesting.NewConsoleDriverWithKiller(&out, func() { failed = true }))
+	assert.False(t, failed, out.String())
+	assert.Contains(t, out.String(), "talk to server... OK")
+	assert.Contains(t, out.String(), "version: 8.0.0")
+}
@@ -55,6 +55,10 @@ type encodedEvent struct {
 	// there's an ingestion error.
 	timestamp time.Time
 
+	// If dataStream is true, index was computed from the event's data_stream
+	// fields by the data stream router.
+	dataStream bool
+
 	// The meta fields from the original event (which aren't included in the
 	// encoding but may still need to be logged if there is an error).
 	meta mapstr.M
@@ -116,7 +120,13 @@ func (pe *eventEncoder) encodeRawEvent(e *beat.Event) *encodedEvent {
 		return &encodedEvent{err: fmt.Errorf("failed to select event pipeline: %w", err)}
 	}
 	var index string
-	if pe.indexSelector != nil {
+	var dataStream bool
+	if router, ok := pe.indexSelector.(*dataStreamRouter); ok {
+		index, dataStream, err = router.route(e)
+		if err != nil {
+			return &encodedEvent{err: fmt.Errorf("failed to select event index: %w", err)}
+		}
+	} else if pe.indexSelector != nil {
 		index, err = pe.indexSelector.Select(e)
 		if err != nil {
 			return &encodedEvent{err: fmt.Errorf("failed to select event index: %w", err)}
@@ -133,13 +143,14 @@ func (pe *eventEncoder) encodeRawEvent(e *beat.Event) *encodedEvent {
 	bytes := make([]byte, len(bufBytes))
 	copy(bytes, bufBytes)
 	return &encodedEvent{
-		id:        id,
-		meta:      e.Meta,
-		timestamp: e.Timestamp,
-		opType:    opType,
-		pipeline:  pipeline,
-		index:     index,
-		encoding:  bytes,
+		id:         id,
+		meta:       e.Meta,
+		timestamp:  e.Timestamp,
+		opType:     opType,
+		pipeline:   pipeline,
+		index:      index,
+		dataStream: dataStream,
+		encoding:   bytes,
 	}
 }
 
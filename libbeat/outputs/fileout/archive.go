@@ -0,0 +1,324 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fileout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/backoff"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/file"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// Uploader uploads rotated files to an object store.
+type Uploader interface {
+	// Upload stores the file at path under the given object key.
+	Upload(ctx context.Context, key, path string) error
+	Close() error
+}
+
+// UploaderFactory creates an Uploader from the object store settings under
+// `archive.<name>`.
+type UploaderFactory func(beat.Info, *config.C) (Uploader, error)
+
+var uploaders = map[string]UploaderFactory{}
+
+// RegisterUploader makes an object store available to the `archive` setting
+// of the file output.
+func RegisterUploader(name string, gen UploaderFactory) {
+	if _, exists := uploaders[name]; exists {
+		panic(fmt.Sprintf("file output uploader '%v' already registered ", name))
+	}
+	uploaders[name] = gen
+}
+
+type archiveConfig struct {
+	Prefix  *PathFormatString `config:"prefix"`
+	Backoff struct {
+		Init time.Duration `config:"init" validate:"nonzero"`
+		Max  time.Duration `config:"max" validate:"nonzero"`
+	} `config:"backoff"`
+}
+
+func defaultArchiveConfig() archiveConfig {
+	c := archiveConfig{Prefix: &PathFormatString{}}
+	c.Backoff.Init = time.Second
+	c.Backoff.Max = time.Minute
+	return c
+}
+
+func (c *archiveConfig) Validate() error {
+	if c.Backoff.Max < c.Backoff.Init {
+		return errors.New("archive.backoff.max must not be less than archive.backoff.init")
+	}
+	return nil
+}
+
+// archiver uploads the files rotated by the file output. The names of the
+// uploaded files are kept in a state file next to them, so files are not
+// uploaded again after a restart.
+type archiver struct {
+	log      *logp.Logger
+	uploader Uploader
+	prefix   *PathFormatString
+	backoff  backoff.Backoff
+
+	// filename is the path of the files without the rotation suffix and
+	// extension.
+	filename  string
+	statePath string
+	uploaded  map[string]bool
+
+	notify chan struct{}
+	done   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newArchiver(log *logp.Logger, info beat.Info, filename string, cfg *config.C) (*archiver, error) {
+	settings := defaultArchiveConfig()
+	if err := cfg.Unpack(&settings); err != nil {
+		return nil, err
+	}
+
+	var store string
+	for name := range uploaders {
+		if !cfg.HasField(name) {
+			continue
+		}
+		if store != "" {
+			return nil, fmt.Errorf("archive accepts only one object store, found '%v' and '%v'", store, name)
+		}
+		store = name
+	}
+	if store == "" {
+		return nil, errors.New("archive requires an object store to upload to")
+	}
+	storeCfg, err := cfg.Child(store, -1)
+	if err != nil {
+		return nil, err
+	}
+	uploader, err := uploaders[store](info, storeCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %v uploader: %w", store, err)
+	}
+
+	a := &archiver{
+		log:       log.Named("archive"),
+		uploader:  uploader,
+		prefix:    settings.Prefix,
+		filename:  filename,
+		statePath: filename + ".archived",
+		uploaded:  map[string]bool{},
+		notify:    make(chan struct{}, 1),
+		done:      make(chan struct{}),
+	}
+	a.backoff = backoff.NewExpBackoff(a.done, settings.Backoff.Init, settings.Backoff.Max)
+	if err := a.loadState(); err != nil {
+		_ = uploader.Close()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+	a.wg.Add(1)
+	go a.run(ctx)
+	a.trigger()
+
+	a.log.Infof("Archiving rotated files to %v.", store)
+	return a, nil
+}
+
+// trigger schedules a scan for rotated files without blocking.
+func (a *archiver) trigger() {
+	select {
+	case a.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (a *archiver) Close() error {
+	close(a.done)
+	a.cancel()
+	a.wg.Wait()
+	return a.uploader.Close()
+}
+
+func (a *archiver) run(ctx context.Context) {
+	defer a.wg.Done()
+	for {
+		select {
+		case <-a.done:
+			return
+		case <-a.notify:
+		}
+
+		pending, err := a.pending()
+		if err != nil {
+			a.log.Errorf("Failed to list rotated files: %v", err)
+			continue
+		}
+		for _, name := range pending {
+			if !a.upload(ctx, name) {
+				return
+			}
+		}
+	}
+}
+
+// upload uploads a single file, retrying with backoff until it succeeds or
+// the archiver is closed.
+func (a *archiver) upload(ctx context.Context, name string) bool {
+	for {
+		info, err := os.Stat(name)
+		if os.IsNotExist(err) {
+			// Removed by the rotator before it could be uploaded.
+			a.log.Warnf("Rotated file %v was removed before it was uploaded, increase number_of_files to keep more files", name)
+			return true
+		}
+		if err == nil {
+			var key string
+			key, err = a.key(name, info.ModTime())
+			if err == nil {
+				err = a.uploader.Upload(ctx, key, name)
+			}
+			if err == nil {
+				a.backoff.Reset()
+				a.uploaded[filepath.Base(name)] = true
+				if err := a.saveState(); err != nil {
+					a.log.Errorf("Failed to save archive state: %v", err)
+				}
+				a.log.Debugf("Uploaded %v to %v", name, key)
+				return true
+			}
+		}
+
+		a.log.Errorf("Failed to upload %v, retrying: %v", name, err)
+		if !a.backoff.Wait() {
+			return false
+		}
+	}
+}
+
+func (a *archiver) key(name string, ts time.Time) (string, error) {
+	var prefix string
+	if a.prefix != nil && a.prefix.efs != nil {
+		var err error
+		if prefix, err = a.prefix.Run(ts.UTC()); err != nil {
+			return "", fmt.Errorf("failed to format archive prefix: %w", err)
+		}
+	}
+	return path.Join(prefix, filepath.Base(name)), nil
+}
+
+// pending returns the rotated files that have not been uploaded yet, oldest
+// first. The newest file is the one written by the rotator and is skipped.
+func (a *archiver) pending() ([]string, error) {
+	files, err := filepath.Glob(a.filename + "-*.ndjson")
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return a.order(files[i]).before(a.order(files[j]))
+	})
+	if len(files) > 0 {
+		files = files[:len(files)-1]
+	}
+
+	pending := files[:0]
+	for _, name := range files {
+		if !a.uploaded[filepath.Base(name)] {
+			pending = append(pending, name)
+		}
+	}
+	return pending, nil
+}
+
+type fileOrder struct {
+	date  string
+	index int
+}
+
+func (o fileOrder) before(other fileOrder) bool {
+	if o.date != other.date {
+		return o.date < other.date
+	}
+	return o.index < other.index
+}
+
+// order parses the date and index the rotator adds to the file names, as in
+// `filebeat-20240102-3.ndjson`.
+func (a *archiver) order(name string) fileOrder {
+	suffix := strings.TrimSuffix(strings.TrimPrefix(name, a.filename+"-"), ".ndjson")
+	if len(suffix) < len(file.DateFormat) {
+		return fileOrder{date: suffix}
+	}
+	o := fileOrder{date: suffix[:len(file.DateFormat)]}
+	if idx, ok := strings.CutPrefix(suffix[len(file.DateFormat):], "-"); ok {
+		o.index, _ = strconv.Atoi(idx)
+	}
+	return o
+}
+
+func (a *archiver) loadState() error {
+	raw, err := os.ReadFile(a.statePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read archive state: %w", err)
+	}
+	for _, name := range strings.Split(string(raw), "\n") {
+		if name != "" {
+			a.uploaded[name] = true
+		}
+	}
+	return nil
+}
+
+// saveState writes the names of the uploaded files that still exist, so
+// the state does not grow past the files kept by the rotator.
+func (a *archiver) saveState() error {
+	names := make([]string, 0, len(a.uploaded))
+	for name := range a.uploaded {
+		if _, err := os.Stat(filepath.Join(filepath.Dir(a.filename), name)); os.IsNotExist(err) {
+			delete(a.uploaded, name)
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tmp := a.statePath + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strings.Join(names, "\n")+"\n"), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, a.statePath)
+}
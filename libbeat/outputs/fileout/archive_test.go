@@ -0,0 +1,151 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package fileout
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type fakeUploader struct {
+	mu       sync.Mutex
+	failures int
+	keys     []string
+	closed   bool
+}
+
+func (u *fakeUploader) Upload(_ context.Context, key, path string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.failures > 0 {
+		u.failures--
+		return errors.New("unavailable")
+	}
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	u.keys = append(u.keys, key)
+	return nil
+}
+
+func (u *fakeUploader) Close() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.closed = true
+	return nil
+}
+
+func (u *fakeUploader) uploaded() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return append([]string(nil), u.keys...)
+}
+
+var testUploader *fakeUploader
+
+func init() {
+	RegisterUploader("test", func(beat.Info, *config.C) (Uploader, error) {
+		return testUploader, nil
+	})
+}
+
+func writeFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("{}\n"), 0o600))
+	}
+}
+
+func TestArchiverUploadsRotatedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir,
+		"beat-20240102-1.ndjson",
+		"beat-20240101.ndjson",
+		"beat-20240102.ndjson",
+		"beat-20240102-2.ndjson", // active file
+		"other-20240101.ndjson",
+	)
+	mtime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	for _, name := range files {
+		require.NoError(t, os.Chtimes(name, mtime, mtime))
+	}
+
+	testUploader = &fakeUploader{failures: 2}
+	cfg := config.MustNewConfigFrom(mapstr.M{
+		"prefix":       "logs/%{+yyyy/MM/dd}",
+		"backoff.init": "1ms",
+		"backoff.max":  "1ms",
+		"test":         mapstr.M{},
+	})
+	a, err := newArchiver(logptest.NewTestingLogger(t, ""), beat.Info{}, filepath.Join(dir, "beat"), cfg)
+	require.NoError(t, err)
+
+	expected := []string{
+		"logs/2024/01/02/beat-20240101.ndjson",
+		"logs/2024/01/02/beat-20240102.ndjson",
+		"logs/2024/01/02/beat-20240102-1.ndjson",
+	}
+	require.Eventually(t, func() bool {
+		return len(testUploader.uploaded()) == len(expected)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, expected, testUploader.uploaded())
+	require.NoError(t, a.Close())
+	assert.True(t, testUploader.closed)
+
+	// A restart only uploads files rotated since.
+	writeFiles(t, dir, "beat-20240102-3.ndjson")
+	testUploader = &fakeUploader{}
+	a, err = newArchiver(logptest.NewTestingLogger(t, ""), beat.Info{}, filepath.Join(dir, "beat"), cfg)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(testUploader.uploaded()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, a.Close())
+	assert.Equal(t, []string{"logs/2024/01/02/beat-20240102-2.ndjson"}, testUploader.uploaded())
+}
+
+func TestArchiverConfig(t *testing.T) {
+	testUploader = &fakeUploader{}
+	for name, settings := range map[string]mapstr.M{
+		"no object store":      {"prefix": "logs"},
+		"unknown object store": {"unknown": mapstr.M{}},
+		"invalid backoff":      {"test": mapstr.M{}, "backoff.init": "10s", "backoff.max": "1s"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := newArchiver(logptest.NewTestingLogger(t, ""), beat.Info{}, filepath.Join(t.TempDir(), "beat"), config.MustNewConfigFrom(settings))
+			assert.Error(t, err)
+		})
+	}
+}
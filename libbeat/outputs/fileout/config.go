@@ -34,6 +34,7 @@ type fileOutConfig struct {
 	Permissions     uint32            `config:"permissions"`
 	RotateOnStartup bool              `config:"rotate_on_startup"`
 	Queue           config.Namespace  `config:"queue"`
+	Archive         *config.C         `config:"archive"`
 }
 
 func defaultConfig() fileOutConfig {
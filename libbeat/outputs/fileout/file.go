@@ -44,6 +44,7 @@ type fileOutput struct {
 	observer outputs.Observer
 	rotator  *file.Rotator
 	codec    codec.Codec
+	archiver *archiver
 }
 
 // makeFileout instantiates a new file output instance.
@@ -102,6 +103,14 @@ func (out *fileOutput) init(beat beat.Info, c fileOutConfig) error {
 		return err
 	}
 
+	if c.Archive.Enabled() {
+		out.archiver, err = newArchiver(out.log, beat, path, c.Archive)
+		if err != nil {
+			_ = out.rotator.Close()
+			return err
+		}
+	}
+
 	out.log.Infof("Initialized file output. "+
 		"path=%v max_size_bytes=%v max_backups=%v permissions=%v",
 		path, c.RotateEveryKb*1024, c.NumberOfFiles, os.FileMode(c.Permissions))
@@ -111,7 +120,13 @@ func (out *fileOutput) init(beat beat.Info, c fileOutConfig) error {
 
 // Implement Outputer
 func (out *fileOutput) Close() error {
-	return out.rotator.Close()
+	err := out.rotator.Close()
+	if out.archiver != nil {
+		if archiveErr := out.archiver.Close(); err == nil {
+			err = archiveErr
+		}
+	}
+	return err
 }
 
 func (out *fileOutput) Publish(_ context.Context, batch publisher.Batch) error {
@@ -163,6 +178,10 @@ func (out *fileOutput) Publish(_ context.Context, batch publisher.Batch) error {
 
 	st.AckedEvents(len(events) - dropped)
 
+	if out.archiver != nil {
+		out.archiver.trigger()
+	}
+
 	return nil
 }
 
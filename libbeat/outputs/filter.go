@@ -0,0 +1,181 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package outputs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/conditions"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/testing"
+)
+
+// filteredEvent replaces the encoded form of events that do not match the
+// output's condition, as the condition can not be checked on encoded events.
+type filteredEvent struct{}
+
+// WithFilter wraps the clients and encoder of the group, so only events
+// matching the condition are published by the output. Events that do not
+// match are acknowledged without being sent.
+func WithFilter(group Group, cond conditions.Condition) Group {
+	clients := make([]Client, len(group.Clients))
+	for i, client := range group.Clients {
+		fc := &filterClient{client: client, cond: cond}
+		if nc, ok := client.(NetworkClient); ok {
+			clients[i] = &filterNetworkClient{filterClient: fc, conn: nc}
+		} else {
+			clients[i] = fc
+		}
+	}
+	group.Clients = clients
+
+	if encoderFactory := group.EncoderFactory; encoderFactory != nil {
+		group.EncoderFactory = func() queue.Encoder {
+			return &filterEncoder{encoder: encoderFactory(), cond: cond}
+		}
+	}
+	return group
+}
+
+type filterEncoder struct {
+	encoder queue.Encoder
+	cond    conditions.Condition
+}
+
+func (fe *filterEncoder) EncodeEntry(entry queue.Entry) (queue.Entry, int) {
+	if e, ok := entry.(publisher.Event); ok && !fe.cond.Check(&e.Content) {
+		e.EncodedEvent = filteredEvent{}
+		e.Content = beat.Event{}
+		return e, 0
+	}
+	return fe.encoder.EncodeEntry(entry)
+}
+
+// filterClient forwards everything but the events that do not match the
+// condition to the wrapped client, including testing the output with
+// `test output`.
+type filterClient struct {
+	client Client
+	cond   conditions.Condition
+}
+
+// filterNetworkClient is the filterClient of a NetworkClient, so the
+// pipeline still connects the wrapped client before publishing.
+type filterNetworkClient struct {
+	*filterClient
+	conn NetworkClient
+}
+
+func (fc *filterClient) Close() error   { return fc.client.Close() }
+func (fc *filterClient) String() string { return fc.client.String() }
+
+func (fc *filterClient) Test(d testing.Driver) {
+	c, ok := fc.client.(testing.Testable)
+	if !ok {
+		d.Fatal("output", errors.New("client doesn't support testing"))
+	}
+
+	c.Test(d)
+}
+
+func (fnc *filterNetworkClient) Connect(ctx context.Context) error {
+	return fnc.conn.Connect(ctx)
+}
+
+func (fc *filterClient) Publish(ctx context.Context, batch publisher.Batch) error {
+	events := batch.Events()
+	matching := make([]publisher.Event, 0, len(events))
+	for _, e := range events {
+		if fc.matches(&e) {
+			matching = append(matching, e)
+		}
+	}
+
+	switch len(matching) {
+	case 0:
+		batch.ACK()
+		return nil
+	case len(events):
+		return fc.client.Publish(ctx, batch)
+	}
+	return fc.client.Publish(ctx, &filteredBatch{Batch: batch, events: matching})
+}
+
+func (fc *filterClient) matches(e *publisher.Event) bool {
+	if _, filtered := e.EncodedEvent.(filteredEvent); filtered {
+		return false
+	}
+	if e.EncodedEvent != nil {
+		// Encoded by an encoder created before the condition was set.
+		return true
+	}
+	return fc.cond.Check(&e.Content)
+}
+
+// filteredBatch exposes only the matching events of a batch to the output.
+// Retrying the batch retries the matching events only, acknowledging the
+// others.
+type filteredBatch struct {
+	publisher.Batch
+	events []publisher.Event
+}
+
+func (b *filteredBatch) Events() []publisher.Event {
+	return b.events
+}
+
+func (b *filteredBatch) Retry() {
+	b.Batch.RetryEvents(b.events)
+}
+
+// SplitRetry retries the matching events without splitting them. The
+// retried batch only holds matching events, so it is passed to the output
+// as is and can be split on the next attempt.
+func (b *filteredBatch) SplitRetry() bool {
+	if len(b.events) <= 1 {
+		return false
+	}
+	b.Batch.RetryEvents(b.events)
+	return true
+}
+
+// loadFilter returns the condition configured under `when` in the output
+// settings, or nil if it is not set.
+func loadFilter(cfg *config.C, logger *logp.Logger) (conditions.Condition, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	var settings struct {
+		When *conditions.Config `config:"when"`
+	}
+	if err := cfg.Unpack(&settings); err != nil {
+		return nil, err
+	}
+	if settings.When == nil {
+		return nil, nil
+	}
+	if logger == nil {
+		logger = logp.NewLogger("outputs")
+	}
+	return conditions.NewCondition(settings.When, logger)
+}
@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package outputs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type recordingClient struct {
+	published [][]publisher.Event
+}
+
+func (c *recordingClient) Close() error   { return nil }
+func (c *recordingClient) String() string { return "recording" }
+func (c *recordingClient) Publish(_ context.Context, batch publisher.Batch) error {
+	c.published = append(c.published, batch.Events())
+	batch.ACK()
+	return nil
+}
+
+type recordingNetworkClient struct {
+	recordingClient
+	connected bool
+}
+
+func (c *recordingNetworkClient) Connect(context.Context) error {
+	c.connected = true
+	return nil
+}
+
+type passthroughEncoder struct{}
+
+func (passthroughEncoder) EncodeEntry(entry queue.Entry) (queue.Entry, int) {
+	e := entry.(publisher.Event)
+	e.EncodedEvent = e.Content.Fields["message"]
+	e.Content = beat.Event{}
+	return e, 1
+}
+
+func auditFilter() *config.C {
+	return config.MustNewConfigFrom(mapstr.M{
+		"when.contains.tags": "audit",
+	})
+}
+
+func tagged(message string, tags ...string) beat.Event {
+	return beat.Event{Fields: mapstr.M{"message": message, "tags": tags}}
+}
+
+func TestFilterClient(t *testing.T) {
+	cond, err := loadFilter(auditFilter(), logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	client := &recordingClient{}
+	group := WithFilter(Group{Clients: []Client{client}}, cond)
+	filtered := group.Clients[0]
+	_, isNetwork := filtered.(NetworkClient)
+	assert.False(t, isNetwork)
+
+	t.Run("some events match", func(t *testing.T) {
+		client.published = nil
+		batch := outest.NewBatch(tagged("a", "audit"), tagged("b"), tagged("c", "audit", "x"))
+		require.NoError(t, filtered.Publish(context.Background(), batch))
+		require.Len(t, client.published, 1)
+		assert.Len(t, client.published[0], 2)
+		assert.Equal(t, []outest.BatchSignalTag{outest.BatchACK}, signals(batch))
+	})
+
+	t.Run("no event matches", func(t *testing.T) {
+		client.published = nil
+		batch := outest.NewBatch(tagged("a"), tagged("b"))
+		require.NoError(t, filtered.Publish(context.Background(), batch))
+		assert.Empty(t, client.published)
+		assert.Equal(t, []outest.BatchSignalTag{outest.BatchACK}, signals(batch))
+	})
+
+	t.Run("retry only retries matching events", func(t *testing.T) {
+		batch := outest.NewBatch(tagged("a", "audit"), tagged("b"))
+		fb := &filteredBatch{Batch: batch, events: batch.Events()[:1]}
+		fb.Retry()
+		require.Len(t, batch.Signals, 1)
+		assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+		assert.Len(t, batch.Signals[0].Events, 1)
+	})
+}
+
+func TestFilterNetworkClientAndEncoder(t *testing.T) {
+	cond, err := loadFilter(auditFilter(), logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	client := &recordingNetworkClient{}
+	group := WithFilter(Group{
+		Clients:        []Client{client},
+		EncoderFactory: func() queue.Encoder { return passthroughEncoder{} },
+	}, cond)
+
+	nc, ok := group.Clients[0].(NetworkClient)
+	require.True(t, ok)
+	require.NoError(t, nc.Connect(context.Background()))
+	assert.True(t, client.connected)
+
+	encoder := group.EncoderFactory()
+	batch := outest.NewBatch(tagged("a", "audit"), tagged("b"))
+	events := batch.Events()
+	for i := range events {
+		entry, _ := encoder.EncodeEntry(events[i])
+		events[i] = entry.(publisher.Event)
+	}
+	assert.Equal(t, filteredEvent{}, events[1].EncodedEvent)
+
+	require.NoError(t, nc.Publish(context.Background(), batch))
+	require.Len(t, client.published, 1)
+	require.Len(t, client.published[0], 1)
+	assert.Equal(t, "a", client.published[0][0].EncodedEvent)
+}
+
+func TestLoadFilter(t *testing.T) {
+	cond, err := loadFilter(config.MustNewConfigFrom(mapstr.M{"hosts": []string{"localhost"}}), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, cond)
+
+	_, err = loadFilter(config.MustNewConfigFrom(mapstr.M{"when.unknown.field": 1}), nil)
+	assert.Error(t, err)
+}
+
+func signals(batch *outest.Batch) []outest.BatchSignalTag {
+	var tags []outest.BatchSignalTag
+	for _, sig := range batch.Signals {
+		tags = append(tags, sig.Tag)
+	}
+	return tags
+}
@@ -66,6 +66,14 @@ type msgRef struct {
 	failed []publisher.Event
 	batch  publisher.Batch
 
+	// settled is only set for transactional batches. It is closed once all
+	// events have been processed by the producer, so the batch can be
+	// acknowledged after the transaction has been committed. acked collects
+	// the events written as part of the transaction, which need to be
+	// retried if the transaction is aborted.
+	settled chan struct{}
+	acked   []publisher.Event
+
 	err error
 }
 
@@ -162,10 +170,16 @@ func (c *client) Close() error {
 	}
 
 	close(c.done)
+	c.closeProducer()
+	return nil
+}
+
+// closeProducer shuts down the producer and waits for all pending results
+// to be processed. The caller must hold c.mux.
+func (c *client) closeProducer() {
 	c.producer.AsyncClose()
 	c.wg.Wait()
 	c.producer = nil
-	return nil
 }
 
 func (c *client) Publish(_ context.Context, batch publisher.Batch) error {
@@ -180,6 +194,72 @@ func (c *client) Publish(_ context.Context, batch publisher.Batch) error {
 		batch:  batch,
 	}
 
+	if c.producer.IsTransactional() {
+		return c.publishTransaction(ref)
+	}
+
+	c.publish(ref)
+	return nil
+}
+
+// publishTransaction writes all events of a batch in a single transaction.
+// The batch is only acknowledged once the transaction is committed, so
+// events are neither lost nor duplicated for read_committed consumers if a
+// broker or the network fails. If the transaction cannot be committed it
+// is aborted and all events that were not dropped are retried.
+func (c *client) publishTransaction(ref *msgRef) error {
+	if ref.total == 0 {
+		ref.batch.ACK()
+		return nil
+	}
+
+	if err := c.producer.BeginTxn(); err != nil {
+		ref.batch.Retry()
+		c.observer.RetryableErrors(ref.total)
+		return c.transactionFailed("begin", err)
+	}
+
+	ref.settled = make(chan struct{})
+	c.publish(ref)
+	<-ref.settled
+
+	err := c.producer.CommitTxn()
+	if err == nil {
+		ref.finish()
+		return nil
+	}
+	c.log.Errorf("Kafka: failed to commit transaction, aborting: %v", err)
+
+	retry := append(ref.failed, ref.acked...)
+	if len(retry) > 0 {
+		ref.batch.RetryEvents(retry)
+		c.observer.RetryableErrors(len(retry))
+	} else {
+		ref.batch.ACK()
+	}
+
+	if err := c.producer.AbortTxn(); err != nil {
+		return c.transactionFailed("abort", err)
+	}
+	return nil
+}
+
+// transactionFailed closes the producer after a transaction could not be
+// started or aborted. The transaction manager is left in an error state,
+// for example because another producer with the same transactional ID
+// fenced this one. Returning the error makes the output reconnect, which
+// initializes a new producer and aborts any pending transaction.
+func (c *client) transactionFailed(op string, err error) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.producer != nil {
+		c.closeProducer()
+	}
+	return fmt.Errorf("failed to %s kafka transaction: %w", op, err)
+}
+
+func (c *client) publish(ref *msgRef) {
+	events := ref.batch.Events()
 	ch := c.producer.Input()
 	for i := range events {
 		d := &events[i]
@@ -195,8 +275,6 @@ func (c *client) Publish(_ context.Context, batch publisher.Batch) error {
 		msg.initProducerMessage()
 		ch <- &msg.msg
 	}
-
-	return nil
 }
 
 func (c *client) String() string {
@@ -241,7 +319,12 @@ func (c *client) getEventMessage(data *publisher.Event) (*message, error) {
 		}
 	}
 
-	serializedEvent, err := c.codec.Encode(c.index, event)
+	var serializedEvent []byte
+	if topicCodec, ok := c.codec.(codec.TopicCodec); ok {
+		serializedEvent, err = topicCodec.EncodeTopic(msg.topic, c.index, event)
+	} else {
+		serializedEvent, err = c.codec.Encode(c.index, event)
+	}
 	if err != nil {
 		if c.log.IsDebug() {
 			c.log.Debug("failed event logged to event log file")
@@ -278,7 +361,7 @@ func (c *client) successWorker(ch <-chan *sarama.ProducerMessage) {
 			c.log.Debug("Failed to assert libMsg.Metadata to *message")
 			return
 		}
-		msg.ref.done()
+		msg.ref.succeeded(msg)
 	}
 }
 
@@ -380,6 +463,13 @@ func (r *msgRef) done() {
 	r.dec()
 }
 
+func (r *msgRef) succeeded(msg *message) {
+	if r.settled != nil {
+		r.acked = append(r.acked, msg.data)
+	}
+	r.dec()
+}
+
 func (r *msgRef) fail(msg *message, err error) {
 	switch {
 	case errors.Is(err, sarama.ErrInvalidMessage):
@@ -418,6 +508,14 @@ func (r *msgRef) dec() {
 		return
 	}
 
+	if r.settled != nil {
+		close(r.settled)
+		return
+	}
+	r.finish()
+}
+
+func (r *msgRef) finish() {
 	r.client.log.Debug("finished kafka batch")
 	stats := r.client.observer
 
@@ -0,0 +1,142 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/beats/v7/libbeat/outputs/outil"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/sarama"
+	"github.com/elastic/sarama/mocks"
+)
+
+// txnProducer records the transaction calls of a mock producer and allows
+// commits to fail.
+type txnProducer struct {
+	*mocks.AsyncProducer
+
+	calls     []string
+	commitErr error
+}
+
+func (p *txnProducer) BeginTxn() error {
+	p.calls = append(p.calls, "begin")
+	return p.AsyncProducer.BeginTxn()
+}
+
+func (p *txnProducer) CommitTxn() error {
+	p.calls = append(p.calls, "commit")
+	if p.commitErr != nil {
+		return p.commitErr
+	}
+	return p.AsyncProducer.CommitTxn()
+}
+
+func (p *txnProducer) AbortTxn() error {
+	p.calls = append(p.calls, "abort")
+	return p.AsyncProducer.AbortTxn()
+}
+
+func newTransactionalClient(t *testing.T) (*client, *txnProducer) {
+	t.Helper()
+
+	cfg, err := readConfig(config.MustNewConfigFrom(mapstr.M{
+		"hosts":          []string{"localhost:9092"},
+		"topic":          "test",
+		"transaction.id": "beats-test",
+	}))
+	require.NoError(t, err)
+	logger := logptest.NewTestingLogger(t, "")
+	libCfg, err := newSaramaConfig(logger, cfg)
+	require.NoError(t, err)
+
+	c, err := newKafkaClient(
+		outputs.NewNilObserver(),
+		cfg.Hosts,
+		"testbeat",
+		nil,
+		outil.MakeSelector(outil.ConstSelectorExpr("test", outil.SelectorKeepCase)),
+		nil,
+		json.New("1.2.3", json.Config{}),
+		libCfg,
+		logger,
+	)
+	require.NoError(t, err)
+
+	producer := &txnProducer{AsyncProducer: mocks.NewAsyncProducer(t, libCfg)}
+	c.producer = producer
+	c.wg.Add(2)
+	go c.successWorker(producer.Successes())
+	go c.errorWorker(producer.Errors())
+	t.Cleanup(func() { _ = c.Close() })
+	return c, producer
+}
+
+func TestPublishTransaction(t *testing.T) {
+	c, producer := newTransactionalClient(t)
+	producer.ExpectInputAndSucceed()
+	producer.ExpectInputAndSucceed()
+
+	batch := outest.NewBatch(
+		beat.Event{Fields: mapstr.M{"message": "a"}},
+		beat.Event{Fields: mapstr.M{"message": "b"}},
+	)
+	require.NoError(t, c.Publish(t.Context(), batch))
+
+	assert.Equal(t, []string{"begin", "commit"}, producer.calls)
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
+
+func TestPublishTransactionPartialFailure(t *testing.T) {
+	c, producer := newTransactionalClient(t)
+	producer.commitErr = errors.New("transaction in error state")
+	producer.ExpectInputAndSucceed()
+	producer.ExpectInputAndFail(sarama.ErrNotEnoughReplicas)
+	producer.ExpectInputAndFail(sarama.ErrInvalidMessage)
+
+	batch := outest.NewBatch(
+		beat.Event{Fields: mapstr.M{"message": "a"}},
+		beat.Event{Fields: mapstr.M{"message": "b"}},
+		beat.Event{Fields: mapstr.M{"message": "c"}},
+	)
+	require.NoError(t, c.Publish(t.Context(), batch))
+
+	assert.Equal(t, []string{"begin", "commit", "abort"}, producer.calls)
+	require.Len(t, batch.Signals, 1)
+	signal := batch.Signals[0]
+	assert.Equal(t, outest.BatchRetryEvents, signal.Tag)
+	// the event written before the transaction was aborted is retried
+	// together with the failed one, the invalid event is dropped.
+	var messages []interface{}
+	for _, e := range signal.Events {
+		messages = append(messages, e.Content.Fields["message"])
+	}
+	assert.ElementsMatch(t, []interface{}{"a", "b"}, messages)
+}
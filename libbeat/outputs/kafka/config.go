@@ -76,6 +76,8 @@ type kafkaConfig struct {
 	Codec              codec.Config              `config:"codec"`
 	Sasl               kafka.SaslConfig          `config:"sasl"`
 	EnableFAST         bool                      `config:"enable_krb5_fast"`
+	Idempotent         bool                      `config:"idempotent"`
+	Transaction        transactionConfig         `config:"transaction"`
 	Queue              config.Namespace          `config:"queue"`
 
 	// Currently only used for validation. Those values are later
@@ -84,6 +86,11 @@ type kafkaConfig struct {
 	Topics []any  `config:"topics"`
 }
 
+type transactionConfig struct {
+	ID      string        `config:"id"`
+	Timeout time.Duration `config:"timeout" validate:"min=1"`
+}
+
 type metaConfig struct {
 	Retry       metaRetryConfig `config:"retry"`
 	RefreshFreq time.Duration   `config:"refresh_frequency" validate:"min=0"`
@@ -146,6 +153,9 @@ func defaultConfig() kafkaConfig {
 		ChanBufferSize: 256,
 		Username:       "",
 		Password:       "",
+		Transaction: transactionConfig{
+			Timeout: 1 * time.Minute,
+		},
 	}
 }
 
@@ -181,6 +191,15 @@ func (c *kafkaConfig) Validate() error {
 		}
 	}
 
+	if c.Idempotent || c.Transaction.ID != "" {
+		if version, ok := c.Version.Get(); ok && !version.IsAtLeast(sarama.V0_11_0_0) {
+			return errors.New("idempotent and transactional producers require kafka version 0.11.0 or newer")
+		}
+		if c.RequiredACKs != nil && sarama.RequiredAcks(*c.RequiredACKs) != sarama.WaitForAll {
+			return errors.New("idempotent and transactional producers require 'required_acks' to be -1")
+		}
+	}
+
 	if c.Topic == "" && len(c.Topics) == 0 {
 		return errors.New("either 'topic' or 'topics' must be defined")
 	}
@@ -278,6 +297,19 @@ func newSaramaConfig(log *logp.Logger, config *kafkaConfig) (*sarama.Config, err
 	k.Producer.Return.Successes = true // enable return channel for signaling
 	k.Producer.Return.Errors = true
 
+	// a transactional producer is always idempotent. Idempotence requires
+	// all in-sync replicas to acknowledge and at most one in-flight request
+	// per broker to keep the sequence numbers ordered.
+	if config.Idempotent || config.Transaction.ID != "" {
+		k.Producer.Idempotent = true
+		k.Producer.RequiredAcks = sarama.WaitForAll
+		k.Net.MaxOpenRequests = 1
+	}
+	if config.Transaction.ID != "" {
+		k.Producer.Transaction.ID = config.Transaction.ID
+		k.Producer.Transaction.Timeout = config.Transaction.Timeout
+	}
+
 	// have retries being handled by libbeat, disable retries in sarama library
 	retryMax := config.MaxRetries
 	if retryMax < 0 {
@@ -43,6 +43,15 @@ func TestConfigAcceptValid(t *testing.T) {
 			"version":     "1.0.0",
 			"topic":       "foo",
 		},
+		"idempotent producer": mapstr.M{
+			"idempotent": true,
+			"topic":      "foo",
+		},
+		"transactional producer": mapstr.M{
+			"transaction.id": "beats-1",
+			"required_acks":  -1,
+			"topic":          "foo",
+		},
 	}
 
 	for name, test := range tests {
@@ -76,6 +85,21 @@ func TestConfigInvalid(t *testing.T) {
 		},
 		// The default config does not set `topic` nor `topics`.
 		"No topics or topic provided": mapstr.M{},
+		"idempotent producer with old version": mapstr.M{
+			"idempotent": true,
+			"version":    "0.10.2",
+			"topic":      "foo",
+		},
+		"idempotent producer without required acks from all replicas": mapstr.M{
+			"idempotent":    true,
+			"required_acks": 1,
+			"topic":         "foo",
+		},
+		"transactional producer with invalid timeout": mapstr.M{
+			"transaction.id":      "beats-1",
+			"transaction.timeout": 0,
+			"topic":               "foo",
+		},
 	}
 
 	for name, test := range tests {
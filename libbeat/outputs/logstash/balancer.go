@@ -0,0 +1,263 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package logstash
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/testing"
+)
+
+const (
+	// latencyDecay is the weight of a new latency sample in the moving
+	// average of the ACK latency of a host.
+	latencyDecay = 0.3
+
+	// minHostWeight is the lowest weight a host can get, so slow hosts that
+	// are not ejected still receive some batches and their latency keeps
+	// being measured.
+	minHostWeight = 0.1
+
+	// maxDeclineWait limits the time a host waits after it declined a batch.
+	maxDeclineWait = time.Second
+)
+
+// balancer tracks the ACK latency of the hosts of a load balanced Logstash
+// output. Batches are pulled from the queue by the output workers of all
+// hosts. A host declines a batch, handing it back to the queue for the
+// other hosts, if it is slower than the fastest host (weighted mode) or if
+// it has been ejected for exceeding the slow host threshold.
+type balancer struct {
+	log    *logp.Logger
+	config balancingConfig
+
+	mu    sync.Mutex
+	hosts []*hostHealth
+
+	now    func() time.Time
+	random func() float64
+}
+
+// hostHealth holds the state of a single host. All fields are protected by
+// balancer.mu.
+type hostHealth struct {
+	host string
+
+	latency      time.Duration // moving average of the ACK latency, 0 if unknown
+	ejectedUntil time.Time
+
+	published uint64
+	declined  uint64
+	ejections uint64
+}
+
+func newBalancer(log *logp.Logger, config balancingConfig) *balancer {
+	return &balancer{
+		log:    log,
+		config: config,
+		now:    time.Now,
+		random: rand.Float64,
+	}
+}
+
+func (b *balancer) addHost(host string) *hostHealth {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h := &hostHealth{host: host}
+	b.hosts = append(b.hosts, h)
+	return h
+}
+
+// observe updates the latency of a host with the ACK latency of a window of
+// events and ejects the host if it is slower than the configured threshold.
+func (b *balancer) observe(h *hostHealth, latency time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if h.latency == 0 {
+		h.latency = latency
+	} else {
+		h.latency = time.Duration(latencyDecay*float64(latency) + (1-latencyDecay)*float64(h.latency))
+	}
+
+	threshold := b.config.SlowHost.Threshold
+	if threshold <= 0 || h.latency <= threshold {
+		return
+	}
+
+	now := b.now()
+	if h.ejected(now) || b.ejectedCount(now) >= b.maxEjected() {
+		return
+	}
+
+	b.log.Warnf("Ejecting slow Logstash host %s for %v, ACK latency %v exceeds threshold %v",
+		h.host, b.config.SlowHost.EjectionTime, h.latency, threshold)
+	h.ejectedUntil = now.Add(b.config.SlowHost.EjectionTime)
+	h.ejections++
+	// Start with a fresh measurement once the host is back.
+	h.latency = 0
+}
+
+// admit reports whether a host should publish the next batch. If not, it
+// returns the duration the host should wait before pulling another batch.
+func (b *balancer) admit(h *hostHealth) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if h.ejected(now) {
+		h.declined++
+		return false, h.ejectedUntil.Sub(now)
+	}
+
+	if b.config.Weighted {
+		fastest := b.fastest(now)
+		if weight := h.weight(fastest); weight < 1 && b.random() >= weight {
+			h.declined++
+			return false, min(h.latency-fastest, maxDeclineWait)
+		}
+	}
+
+	h.published++
+	return true, 0
+}
+
+// fastest returns the lowest known latency of all hosts currently accepting
+// events.
+func (b *balancer) fastest(now time.Time) time.Duration {
+	var fastest time.Duration
+	for _, h := range b.hosts {
+		if h.ejected(now) || h.latency == 0 {
+			continue
+		}
+		if fastest == 0 || h.latency < fastest {
+			fastest = h.latency
+		}
+	}
+	return fastest
+}
+
+func (b *balancer) ejectedCount(now time.Time) int {
+	n := 0
+	for _, h := range b.hosts {
+		if h.ejected(now) {
+			n++
+		}
+	}
+	return n
+}
+
+func (b *balancer) maxEjected() int {
+	return len(b.hosts) * b.config.SlowHost.MaxEjectedPercent / 100
+}
+
+// Report reports the health of all hosts to the monitoring visitor.
+func (b *balancer) Report(_ monitoring.Mode, V monitoring.Visitor) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+
+	now := b.now()
+	fastest := b.fastest(now)
+	for _, h := range b.hosts {
+		monitoring.ReportNamespace(V, h.host, func() {
+			monitoring.ReportBool(V, "ejected", h.ejected(now))
+			monitoring.ReportInt(V, "latency_ms", h.latency.Milliseconds())
+			monitoring.ReportFloat(V, "weight", h.weight(fastest))
+			monitoring.ReportInt(V, "batches.published", int64(h.published)) //nolint:gosec // counters won't overflow
+			monitoring.ReportInt(V, "batches.declined", int64(h.declined))   //nolint:gosec // counters won't overflow
+			monitoring.ReportInt(V, "ejections", int64(h.ejections))         //nolint:gosec // counters won't overflow
+		})
+	}
+}
+
+func (h *hostHealth) ejected(now time.Time) bool {
+	return now.Before(h.ejectedUntil)
+}
+
+// weight returns the share of batches the host should accept relative to
+// the fastest host. Hosts without measurements get the full weight.
+func (h *hostHealth) weight(fastest time.Duration) float64 {
+	if h.latency == 0 || fastest == 0 || h.latency <= fastest {
+		return 1
+	}
+	return max(float64(fastest)/float64(h.latency), minHostWeight)
+}
+
+// hostObserver forwards the ACK latency reported by a client to the
+// balancer.
+type hostObserver struct {
+	outputs.Observer
+	balancer *balancer
+	host     *hostHealth
+}
+
+func (o *hostObserver) ReportLatency(latency time.Duration) {
+	o.Observer.ReportLatency(latency)
+	o.balancer.observe(o.host, latency)
+}
+
+// balancedClient hands batches back to the pipeline if the balancer does
+// not admit its host, so the workers of other hosts can publish them.
+type balancedClient struct {
+	client   outputs.NetworkClient
+	balancer *balancer
+	host     *hostHealth
+	done     chan struct{}
+}
+
+func (c *balancedClient) Connect(ctx context.Context) error {
+	return c.client.Connect(ctx)
+}
+
+func (c *balancedClient) Close() error {
+	close(c.done)
+	return c.client.Close()
+}
+
+func (c *balancedClient) Publish(ctx context.Context, batch publisher.Batch) error {
+	if ok, wait := c.balancer.admit(c.host); !ok {
+		batch.Cancelled()
+		select {
+		case <-time.After(wait):
+		case <-c.done:
+		}
+		return nil
+	}
+	return c.client.Publish(ctx, batch)
+}
+
+func (c *balancedClient) Test(d testing.Driver) {
+	if t, ok := c.client.(testing.Testable); ok {
+		t.Test(d)
+	}
+}
+
+func (c *balancedClient) String() string {
+	return c.client.String()
+}
@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package logstash
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func newTestBalancer(t *testing.T, config balancingConfig, hosts ...string) (*balancer, []*hostHealth, *time.Time) {
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	b := newBalancer(logptest.NewTestingLogger(t, ""), config)
+	b.now = func() time.Time { return now }
+	b.random = func() float64 { return 0.5 }
+
+	health := make([]*hostHealth, len(hosts))
+	for i, host := range hosts {
+		health[i] = b.addHost(host)
+	}
+	return b, health, &now
+}
+
+func TestBalancerWeighted(t *testing.T) {
+	b, hosts, _ := newTestBalancer(t, balancingConfig{Weighted: true}, "fast:5044", "slow:5044")
+	fast, slow := hosts[0], hosts[1]
+
+	// without measurements all hosts are admitted
+	ok, _ := b.admit(slow)
+	assert.True(t, ok)
+
+	b.observe(fast, 100*time.Millisecond)
+	b.observe(slow, 400*time.Millisecond)
+
+	ok, _ = b.admit(fast)
+	assert.True(t, ok, "the fastest host is always admitted")
+
+	// the slow host has a weight of 0.25, a random value of 0.5 declines
+	ok, wait := b.admit(slow)
+	assert.False(t, ok)
+	assert.Equal(t, 300*time.Millisecond, wait)
+
+	b.random = func() float64 { return 0.1 }
+	ok, _ = b.admit(slow)
+	assert.True(t, ok)
+
+	assert.Equal(t, uint64(2), slow.published)
+	assert.Equal(t, uint64(1), slow.declined)
+}
+
+func TestBalancerWeightLimit(t *testing.T) {
+	h := &hostHealth{latency: 10 * time.Second}
+	assert.Equal(t, minHostWeight, h.weight(time.Millisecond))
+	assert.Equal(t, 1.0, h.weight(0))
+}
+
+func TestBalancerMovingAverage(t *testing.T) {
+	b, hosts, _ := newTestBalancer(t, balancingConfig{}, "a:5044")
+	b.observe(hosts[0], 100*time.Millisecond)
+	b.observe(hosts[0], 200*time.Millisecond)
+	assert.Equal(t, 130*time.Millisecond, hosts[0].latency)
+}
+
+func TestBalancerEjectSlowHost(t *testing.T) {
+	config := balancingConfig{SlowHost: slowHostConfig{
+		Threshold:         time.Second,
+		EjectionTime:      30 * time.Second,
+		MaxEjectedPercent: 50,
+	}}
+	b, hosts, now := newTestBalancer(t, config, "a:5044", "b:5044", "c:5044", "d:5044")
+
+	b.observe(hosts[0], 500*time.Millisecond)
+	b.observe(hosts[1], 2*time.Second)
+	b.observe(hosts[2], 3*time.Second)
+	b.observe(hosts[3], 4*time.Second)
+
+	// at most half of the hosts can be ejected
+	assert.False(t, hosts[0].ejected(*now))
+	assert.True(t, hosts[1].ejected(*now))
+	assert.True(t, hosts[2].ejected(*now))
+	assert.False(t, hosts[3].ejected(*now))
+
+	ok, wait := b.admit(hosts[1])
+	assert.False(t, ok)
+	assert.Equal(t, 30*time.Second, wait)
+	ok, _ = b.admit(hosts[3])
+	assert.True(t, ok, "slow host must be admitted if too many hosts are ejected")
+
+	*now = now.Add(31 * time.Second)
+	ok, _ = b.admit(hosts[1])
+	assert.True(t, ok, "host must be admitted after the ejection time")
+	assert.Equal(t, uint64(1), hosts[1].ejections)
+	assert.Equal(t, time.Duration(0), hosts[1].latency, "latency is measured again after ejection")
+}
+
+func TestBalancerReport(t *testing.T) {
+	b, hosts, _ := newTestBalancer(t, balancingConfig{Weighted: true}, "a:5044", "b:5044")
+	b.observe(hosts[0], 100*time.Millisecond)
+	b.observe(hosts[1], 200*time.Millisecond)
+
+	reg := monitoring.NewRegistry()
+	monitoring.NewFunc(reg, "hosts", b.Report, monitoring.Report)
+	snapshot := monitoring.CollectStructSnapshot(reg, monitoring.Full, false)
+
+	require.Contains(t, snapshot, "hosts")
+	report := snapshot["hosts"].(map[string]interface{})
+	require.Contains(t, report, "b:5044")
+	host := report["b:5044"].(map[string]interface{})
+	assert.Equal(t, false, host["ejected"])
+	assert.Equal(t, int64(200), host["latency_ms"])
+	assert.Equal(t, 0.5, host["weight"])
+}
+
+func TestBalancedClientDeclines(t *testing.T) {
+	b, hosts, _ := newTestBalancer(t, balancingConfig{SlowHost: slowHostConfig{
+		Threshold:         time.Second,
+		EjectionTime:      time.Millisecond,
+		MaxEjectedPercent: 50,
+	}}, "a:5044", "b:5044")
+	b.now = time.Now
+	b.observe(hosts[1], 2*time.Second)
+
+	inner := &countingClient{}
+	client := &balancedClient{client: inner, balancer: b, host: hosts[1], done: make(chan struct{})}
+
+	// the ejected host hands the batch back to the pipeline
+	batch := outest.NewBatch(beat.Event{})
+	require.NoError(t, client.Publish(t.Context(), batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchCancelled, batch.Signals[0].Tag)
+	assert.Equal(t, 0, inner.published)
+
+	// once the ejection time passed the host publishes again
+	batch = outest.NewBatch(beat.Event{})
+	require.NoError(t, client.Publish(t.Context(), batch))
+	assert.Equal(t, 1, inner.published)
+}
+
+type countingClient struct {
+	published int
+}
+
+func (c *countingClient) Connect(context.Context) error { return nil }
+func (c *countingClient) Close() error                  { return nil }
+func (c *countingClient) String() string                { return "counting" }
+
+func (c *countingClient) Publish(_ context.Context, batch publisher.Batch) error {
+	c.published++
+	batch.ACK()
+	return nil
+}
+
+var _ outputs.NetworkClient = (*balancedClient)(nil)
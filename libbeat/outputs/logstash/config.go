@@ -32,6 +32,7 @@ import (
 type Config struct {
 	Index            string                `config:"index"`
 	LoadBalance      bool                  `config:"loadbalance"`
+	Balancing        balancingConfig       `config:"balancing"`
 	BulkMaxSize      int                   `config:"bulk_max_size"`
 	SlowStart        bool                  `config:"slow_start"`
 	Timeout          time.Duration         `config:"timeout"`
@@ -46,6 +47,23 @@ type Config struct {
 	Queue            config.Namespace      `config:"queue"`
 }
 
+// balancingConfig configures how batches are distributed between hosts if
+// loadbalance is enabled.
+type balancingConfig struct {
+	Weighted bool           `config:"weighted"`
+	SlowHost slowHostConfig `config:"slow_host"`
+}
+
+type slowHostConfig struct {
+	Threshold         time.Duration `config:"threshold"           validate:"min=0"`
+	EjectionTime      time.Duration `config:"ejection_time"       validate:"min=1"`
+	MaxEjectedPercent int           `config:"max_ejected_percent" validate:"min=0, max=100"`
+}
+
+func (c balancingConfig) enabled() bool {
+	return c.Weighted || c.SlowHost.Threshold > 0
+}
+
 type Backoff struct {
 	Init time.Duration
 	Max  time.Duration
@@ -66,6 +84,12 @@ func defaultConfig() Config {
 			Max:  60 * time.Second,
 		},
 		EscapeHTML: false,
+		Balancing: balancingConfig{
+			SlowHost: slowHostConfig{
+				EjectionTime:      30 * time.Second,
+				MaxEjectedPercent: 50,
+			},
+		},
 	}
 }
 
@@ -29,6 +29,12 @@ import (
 )
 
 func TestConfig(t *testing.T) {
+	defaultBalancing := balancingConfig{
+		SlowHost: slowHostConfig{
+			EjectionTime:      30 * time.Second,
+			MaxEjectedPercent: 50,
+		},
+	}
 
 	info := beat.Info{Beat: "testbeat", Name: "foo", IndexPrefix: "bar"}
 	for name, test := range map[string]struct {
@@ -51,6 +57,7 @@ func TestConfig(t *testing.T) {
 					Init: 1 * time.Second,
 					Max:  60 * time.Second,
 				},
+				Balancing:  defaultBalancing,
 				EscapeHTML: false,
 				Index:      "bar",
 			},
@@ -75,10 +82,46 @@ func TestConfig(t *testing.T) {
 					Init: 1 * time.Second,
 					Max:  60 * time.Second,
 				},
+				Balancing:  defaultBalancing,
 				EscapeHTML: false,
 				Index:      "beat-index",
 			},
 		},
+		"balancing given": {
+			config: config.MustNewConfigFrom(mapstr.M{
+				"loadbalance":                   true,
+				"balancing.weighted":            true,
+				"balancing.slow_host.threshold": "5s",
+			}),
+			expectedConfig: &Config{
+				LoadBalance: true,
+				Balancing: balancingConfig{
+					Weighted: true,
+					SlowHost: slowHostConfig{
+						Threshold:         5 * time.Second,
+						EjectionTime:      30 * time.Second,
+						MaxEjectedPercent: 50,
+					},
+				},
+				BulkMaxSize:      2048,
+				Pipelining:       2,
+				CompressionLevel: 3,
+				Timeout:          30 * time.Second,
+				MaxRetries:       3,
+				Backoff: Backoff{
+					Init: 1 * time.Second,
+					Max:  60 * time.Second,
+				},
+				Index: "bar",
+			},
+		},
+		"invalid max ejected percent": {
+			config: config.MustNewConfigFrom(mapstr.M{
+				"balancing.slow_host.max_ejected_percent": 101,
+			}),
+			expectedConfig: nil,
+			err:            true,
+		},
 		"removed config setting": {
 			config: config.MustNewConfigFrom(mapstr.M{
 				"port": "8080",
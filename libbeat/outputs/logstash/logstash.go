@@ -21,6 +21,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/monitoring"
 	"github.com/elastic/elastic-agent-libs/transport"
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 )
@@ -63,9 +64,24 @@ func makeLogstash(
 		Stats:   observer,
 	}
 
+	var bal *balancer
+	if lsConfig.LoadBalance && len(hosts) > 1 && lsConfig.Balancing.enabled() {
+		bal = newBalancer(beat.Logger.Named("logstash"), lsConfig.Balancing)
+		reg := monitoring.Default.GetOrCreateRegistry("libbeat.outputs.logstash")
+		reg.Remove("hosts")
+		monitoring.NewFunc(reg, "hosts", bal.Report, monitoring.Report)
+	}
+
 	clients := make([]outputs.NetworkClient, len(hosts))
 	for i, host := range hosts {
 		var client outputs.NetworkClient
+		var health *hostHealth
+
+		clientObserver := observer
+		if bal != nil {
+			health = bal.addHost(host)
+			clientObserver = &hostObserver{Observer: observer, balancer: bal, host: health}
+		}
 
 		conn, err := transport.NewClient(transp, "tcp", host, defaultPort)
 		if err != nil {
@@ -73,15 +89,18 @@ func makeLogstash(
 		}
 
 		if lsConfig.Pipelining > 0 {
-			client, err = newAsyncClient(beat, conn, observer, lsConfig)
+			client, err = newAsyncClient(beat, conn, clientObserver, lsConfig)
 		} else {
-			client, err = newSyncClient(beat, conn, observer, lsConfig)
+			client, err = newSyncClient(beat, conn, clientObserver, lsConfig)
 		}
 		if err != nil {
 			return outputs.Fail(err)
 		}
 
 		client = outputs.WithBackoff(client, lsConfig.Backoff.Init, lsConfig.Backoff.Max)
+		if bal != nil {
+			client = &balancedClient{client: client, balancer: bal, host: health, done: make(chan struct{})}
+		}
 		clients[i] = client
 	}
 
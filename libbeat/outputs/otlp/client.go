@@ -0,0 +1,146 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// exporter sends export requests to an OTLP endpoint over a specific
+// transport.
+type exporter interface {
+	connect(ctx context.Context) error
+	export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error)
+	close() error
+	String() string
+}
+
+// permanentError marks export errors that will fail again when retried,
+// for example because the endpoint rejected the request as invalid.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+type client struct {
+	log      *logp.Logger
+	observer outputs.Observer
+	info     beat.Info
+	timeout  time.Duration
+	exporter exporter
+}
+
+func newClient(info beat.Info, observer outputs.Observer, timeout time.Duration, exp exporter) *client {
+	return &client{
+		log:      info.Logger.Named("otlp"),
+		observer: observer,
+		info:     info,
+		timeout:  timeout,
+		exporter: exp,
+	}
+}
+
+func (c *client) Connect(ctx context.Context) error {
+	c.log.Debugf("connect to %v", c.exporter)
+	return c.exporter.connect(ctx)
+}
+
+func (c *client) Close() error {
+	c.log.Debugf("close connection to %v", c.exporter)
+	return c.exporter.close()
+}
+
+func (c *client) String() string {
+	return "otlp(" + c.exporter.String() + ")"
+}
+
+func (c *client) Publish(ctx context.Context, batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+	if len(events) == 0 {
+		batch.ACK()
+		return nil
+	}
+
+	contents := make([]beat.Event, len(events))
+	for i := range events {
+		contents[i] = events[i].Content
+	}
+	req := makeRequest(c.info, contents, time.Now())
+	c.observer.WriteBytes(proto.Size(req))
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	begin := time.Now()
+	resp, err := c.exporter.export(ctx, req)
+	if err != nil {
+		if errors.Is(err, errPayloadTooLarge) {
+			if batch.SplitRetry() {
+				c.observer.BatchSplit()
+				c.observer.RetryableErrors(len(events))
+			} else {
+				// The batch could not be split any further, drop it.
+				c.log.Errorf("Failed to export %d events to %v, dropping them: %v", len(events), c.exporter, err)
+				c.observer.PermanentErrors(len(events))
+				batch.Drop()
+			}
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			c.log.Errorf("Failed to export %d events to %v, dropping them: %v", len(events), c.exporter, err)
+			c.observer.PermanentErrors(len(events))
+			batch.Drop()
+			return nil
+		}
+
+		c.observer.WriteError(err)
+		c.observer.RetryableErrors(len(events))
+		batch.Retry()
+		return fmt.Errorf("failed to export events to %v: %w", c.exporter, err)
+	}
+	c.observer.ReportLatency(time.Since(begin))
+
+	rejected := 0
+	if partial := resp.GetPartialSuccess(); partial != nil && partial.GetRejectedLogRecords() > 0 {
+		rejected = int(partial.GetRejectedLogRecords())
+		if rejected > len(events) {
+			rejected = len(events)
+		}
+		c.log.Warnf("%v rejected %d of %d events: %s", c.exporter, rejected, len(events), partial.GetErrorMessage())
+		c.observer.PermanentErrors(rejected)
+	}
+	c.observer.AckedEvents(len(events) - rejected)
+	batch.ACK()
+	return nil
+}
@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+const (
+	protocolGRPC = "grpc"
+	protocolHTTP = "http"
+
+	compressionGzip = "gzip"
+	compressionNone = "none"
+)
+
+type otlpConfig struct {
+	Protocol    string            `config:"protocol"`
+	Path        string            `config:"path"`
+	Headers     map[string]string `config:"headers"`
+	Compression string            `config:"compression"`
+	Insecure    bool              `config:"insecure"`
+	TLS         *tlscommon.Config `config:"ssl"`
+	Timeout     time.Duration     `config:"timeout"`
+	LoadBalance bool              `config:"loadbalance"`
+	BulkMaxSize int               `config:"bulk_max_size"`
+	MaxRetries  int               `config:"max_retries"`
+	Backoff     backoff           `config:"backoff"`
+	Queue       config.Namespace  `config:"queue"`
+}
+
+type backoff struct {
+	Init time.Duration
+	Max  time.Duration
+}
+
+func defaultConfig() otlpConfig {
+	return otlpConfig{
+		Protocol:    protocolGRPC,
+		Path:        "/v1/logs",
+		Compression: compressionGzip,
+		Timeout:     30 * time.Second,
+		LoadBalance: true,
+		BulkMaxSize: 1600,
+		MaxRetries:  3,
+		Backoff: backoff{
+			Init: 1 * time.Second,
+			Max:  60 * time.Second,
+		},
+	}
+}
+
+func (c *otlpConfig) Validate() error {
+	switch c.Protocol {
+	case protocolGRPC, protocolHTTP:
+	default:
+		return fmt.Errorf("unsupported protocol %q, must be one of %q or %q", c.Protocol, protocolGRPC, protocolHTTP)
+	}
+
+	switch c.Compression {
+	case compressionGzip, compressionNone:
+	default:
+		return fmt.Errorf("unsupported compression %q, must be one of %q or %q", c.Compression, compressionGzip, compressionNone)
+	}
+
+	if c.Insecure && c.TLS.IsEnabled() {
+		return fmt.Errorf("insecure cannot be used together with ssl")
+	}
+
+	return nil
+}
+
+// defaultPort returns the default OTLP port for the configured protocol.
+func (c *otlpConfig) defaultPort() int {
+	if c.Protocol == protocolHTTP {
+		return 4318
+	}
+	return 4317
+}
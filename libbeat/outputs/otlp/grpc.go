@@ -0,0 +1,102 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"context"
+	"crypto/tls"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// grpcExporter sends export requests using OTLP/gRPC.
+type grpcExporter struct {
+	target   string
+	dialOpts []grpc.DialOption
+	callOpts []grpc.CallOption
+	headers  metadata.MD
+
+	conn   *grpc.ClientConn
+	client collogspb.LogsServiceClient
+}
+
+// newGRPCExporter creates an exporter for target (host:port). Plaintext is
+// used if tlsConfig is nil.
+func newGRPCExporter(target string, tlsConfig *tls.Config, headers map[string]string, compression string) *grpcExporter {
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	e := &grpcExporter{
+		target:   target,
+		dialOpts: []grpc.DialOption{grpc.WithTransportCredentials(creds)},
+		headers:  metadata.New(headers),
+	}
+	if compression == compressionGzip {
+		e.callOpts = append(e.callOpts, grpc.UseCompressor(gzip.Name))
+	}
+	return e
+}
+
+func (e *grpcExporter) connect(_ context.Context) error {
+	// The connection is established lazily on the first export, errors
+	// are reported from there.
+	conn, err := grpc.NewClient(e.target, e.dialOpts...)
+	if err != nil {
+		return err
+	}
+	e.conn = conn
+	e.client = collogspb.NewLogsServiceClient(conn)
+	return nil
+}
+
+func (e *grpcExporter) export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	if len(e.headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, e.headers)
+	}
+	resp, err := e.client.Export(ctx, req, e.callOpts...)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.Unimplemented, codes.FailedPrecondition:
+			return nil, &permanentError{err: err}
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (e *grpcExporter) close() error {
+	if e.conn == nil {
+		return nil
+	}
+	err := e.conn.Close()
+	e.conn, e.client = nil, nil
+	return err
+}
+
+func (e *grpcExporter) String() string {
+	return e.target
+}
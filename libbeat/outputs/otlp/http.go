@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var errPayloadTooLarge = errors.New("the payload is too large for the OTLP endpoint")
+
+// httpExporter sends export requests using OTLP/HTTP with binary protobuf
+// encoding.
+type httpExporter struct {
+	url       string
+	tlsConfig *tls.Config
+	headers   map[string]string
+	gzip      bool
+
+	client *http.Client
+}
+
+func newHTTPExporter(url string, tlsConfig *tls.Config, headers map[string]string, compression string) *httpExporter {
+	return &httpExporter{
+		url:       url,
+		tlsConfig: tlsConfig,
+		headers:   headers,
+		gzip:      compression == compressionGzip,
+	}
+}
+
+func (e *httpExporter) connect(_ context.Context) error {
+	e.client = &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: e.tlsConfig,
+		},
+	}
+	return nil
+}
+
+func (e *httpExporter) export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return nil, &permanentError{err: fmt.Errorf("failed to encode request: %w", err)}
+	}
+	if e.gzip {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(body); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		body = buf.Bytes()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range e.headers {
+		httpReq.Header.Set(k, v)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	if e.gzip {
+		httpReq.Header.Set("Content-Encoding", "gzip")
+	}
+
+	httpResp, err := e.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case httpResp.StatusCode >= 200 && httpResp.StatusCode < 300:
+		resp := &collogspb.ExportLogsServiceResponse{}
+		if len(respBody) > 0 && httpResp.Header.Get("Content-Type") == "application/x-protobuf" {
+			if err := proto.Unmarshal(respBody, resp); err != nil {
+				return nil, fmt.Errorf("failed to decode response: %w", err)
+			}
+		}
+		return resp, nil
+	case httpResp.StatusCode == http.StatusRequestEntityTooLarge:
+		return nil, errPayloadTooLarge
+	case httpResp.StatusCode == http.StatusTooManyRequests || httpResp.StatusCode >= 500:
+		return nil, fmt.Errorf("%s: %s", httpResp.Status, respBody)
+	default:
+		return nil, &permanentError{err: fmt.Errorf("%s: %s", httpResp.Status, respBody)}
+	}
+}
+
+func (e *httpExporter) close() error {
+	if e.client != nil {
+		e.client.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (e *httpExporter) String() string {
+	return e.url
+}
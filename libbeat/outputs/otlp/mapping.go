@@ -0,0 +1,323 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// resourceFields maps ECS fields describing the entity producing the event
+// to their OpenTelemetry semantic conventions resource attribute.
+var resourceFields = map[string]string{
+	"host.name":                 "host.name",
+	"host.id":                   "host.id",
+	"host.architecture":         "host.arch",
+	"host.os.type":              "os.type",
+	"host.os.name":              "os.name",
+	"host.os.version":           "os.version",
+	"service.name":              "service.name",
+	"service.version":           "service.version",
+	"service.environment":       "deployment.environment.name",
+	"cloud.provider":            "cloud.provider",
+	"cloud.region":              "cloud.region",
+	"cloud.availability_zone":   "cloud.availability_zone",
+	"cloud.account.id":          "cloud.account.id",
+	"container.id":              "container.id",
+	"container.name":            "container.name",
+	"container.image.name":      "container.image.name",
+	"orchestrator.cluster.name": "k8s.cluster.name",
+	"kubernetes.namespace":      "k8s.namespace.name",
+	"kubernetes.pod.name":       "k8s.pod.name",
+	"kubernetes.pod.uid":        "k8s.pod.uid",
+	"kubernetes.node.name":      "k8s.node.name",
+}
+
+// attributeFields maps ECS fields to their OpenTelemetry semantic
+// conventions log record attribute where the names differ. All other
+// fields are kept with their ECS name.
+var attributeFields = map[string]string{
+	"client.ip":         "client.address",
+	"server.ip":         "server.address",
+	"source.ip":         "source.address",
+	"destination.ip":    "destination.address",
+	"error.message":     "exception.message",
+	"error.type":        "exception.type",
+	"error.stack_trace": "exception.stacktrace",
+}
+
+// severities maps the ECS log.level values to OpenTelemetry severity numbers.
+var severities = map[string]logspb.SeverityNumber{
+	"trace":     logspb.SeverityNumber_SEVERITY_NUMBER_TRACE,
+	"debug":     logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG,
+	"info":      logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+	"notice":    logspb.SeverityNumber_SEVERITY_NUMBER_INFO2,
+	"warn":      logspb.SeverityNumber_SEVERITY_NUMBER_WARN,
+	"warning":   logspb.SeverityNumber_SEVERITY_NUMBER_WARN,
+	"error":     logspb.SeverityNumber_SEVERITY_NUMBER_ERROR,
+	"err":       logspb.SeverityNumber_SEVERITY_NUMBER_ERROR,
+	"critical":  logspb.SeverityNumber_SEVERITY_NUMBER_FATAL,
+	"crit":      logspb.SeverityNumber_SEVERITY_NUMBER_FATAL,
+	"alert":     logspb.SeverityNumber_SEVERITY_NUMBER_FATAL2,
+	"emergency": logspb.SeverityNumber_SEVERITY_NUMBER_FATAL3,
+	"emerg":     logspb.SeverityNumber_SEVERITY_NUMBER_FATAL3,
+	"fatal":     logspb.SeverityNumber_SEVERITY_NUMBER_FATAL,
+}
+
+// makeRequest converts the events to an OTLP export request. Events are
+// grouped by resource, all records of a resource share the beat as
+// instrumentation scope.
+func makeRequest(info beat.Info, events []beat.Event, observed time.Time) *collogspb.ExportLogsServiceRequest {
+	scope := &commonpb.InstrumentationScope{Name: info.Beat, Version: info.Version}
+
+	var resources []*logspb.ResourceLogs
+	byResource := map[string]*logspb.ScopeLogs{}
+	for i := range events {
+		resourceAttrs, record := makeLogRecord(info, &events[i], observed)
+
+		key := resourceKey(resourceAttrs)
+		scopeLogs, ok := byResource[key]
+		if !ok {
+			scopeLogs = &logspb.ScopeLogs{Scope: scope}
+			byResource[key] = scopeLogs
+			resources = append(resources, &logspb.ResourceLogs{
+				Resource:  &resourcepb.Resource{Attributes: resourceAttrs},
+				ScopeLogs: []*logspb.ScopeLogs{scopeLogs},
+			})
+		}
+		scopeLogs.LogRecords = append(scopeLogs.LogRecords, record)
+	}
+
+	return &collogspb.ExportLogsServiceRequest{ResourceLogs: resources}
+}
+
+// makeLogRecord converts a single event to a log record and the attributes
+// of the resource it belongs to. The message becomes the record body,
+// log.level its severity and trace.id/span.id its trace context. Fields
+// with a semantic conventions equivalent are renamed, all other fields are
+// added as attributes with their flattened ECS name.
+func makeLogRecord(info beat.Info, event *beat.Event, observed time.Time) ([]*commonpb.KeyValue, *logspb.LogRecord) {
+	record := &logspb.LogRecord{
+		TimeUnixNano:         unixNano(event.Timestamp),
+		ObservedTimeUnixNano: unixNano(observed),
+	}
+
+	fields := event.Fields.Flatten()
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var resourceAttrs []*commonpb.KeyValue
+	hasServiceName := false
+	for _, k := range keys {
+		v := fields[k]
+		switch k {
+		case "message":
+			record.Body = anyValue(v)
+			continue
+		case "log.level":
+			if level, ok := v.(string); ok {
+				record.SeverityText = level
+				record.SeverityNumber = severities[strings.ToLower(level)]
+				continue
+			}
+		case "trace.id":
+			if id, ok := decodeID(v, 16); ok {
+				record.TraceId = id
+				continue
+			}
+		case "span.id":
+			if id, ok := decodeID(v, 8); ok {
+				record.SpanId = id
+				continue
+			}
+		}
+
+		value := anyValue(v)
+		if value == nil {
+			continue
+		}
+		if name, ok := resourceFields[k]; ok {
+			hasServiceName = hasServiceName || name == "service.name"
+			resourceAttrs = append(resourceAttrs, &commonpb.KeyValue{Key: name, Value: value})
+			continue
+		}
+		if name, ok := attributeFields[k]; ok {
+			k = name
+		}
+		record.Attributes = append(record.Attributes, &commonpb.KeyValue{Key: k, Value: value})
+	}
+
+	if !hasServiceName {
+		resourceAttrs = append(resourceAttrs, &commonpb.KeyValue{
+			Key:   "service.name",
+			Value: stringValue(info.Beat),
+		})
+		sort.Slice(resourceAttrs, func(i, j int) bool {
+			return resourceAttrs[i].Key < resourceAttrs[j].Key
+		})
+	}
+
+	return resourceAttrs, record
+}
+
+// resourceKey returns a string identifying the set of resource attributes.
+// Attributes are expected to be sorted by key.
+func resourceKey(attrs []*commonpb.KeyValue) string {
+	var sb strings.Builder
+	for _, kv := range attrs {
+		sb.WriteString(kv.Key)
+		sb.WriteByte('=')
+		sb.WriteString(kv.Value.String())
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
+
+func decodeID(v interface{}, size int) ([]byte, bool) {
+	s, ok := v.(string)
+	if !ok || len(s) != 2*size {
+		return nil, false
+	}
+	id, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, false
+	}
+	return id, true
+}
+
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano()) //nolint:gosec // timestamps before 1970 are not expected
+}
+
+func stringValue(s string) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: s}}
+}
+
+// anyValue converts a field value to an OTLP value. Nil values are
+// returned as nil.
+func anyValue(v interface{}) *commonpb.AnyValue {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return stringValue(v)
+	case bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+	case int:
+		return intValue(int64(v))
+	case int8:
+		return intValue(int64(v))
+	case int16:
+		return intValue(int64(v))
+	case int32:
+		return intValue(int64(v))
+	case int64:
+		return intValue(v)
+	case uint:
+		return uintValue(uint64(v))
+	case uint8:
+		return intValue(int64(v))
+	case uint16:
+		return intValue(int64(v))
+	case uint32:
+		return intValue(int64(v))
+	case uint64:
+		return uintValue(v)
+	case float32:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: float64(v)}}
+	case float64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v}}
+	case []byte:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v}}
+	case time.Time:
+		return stringValue(v.UTC().Format(time.RFC3339Nano))
+	case common.Time:
+		return stringValue(time.Time(v).UTC().Format(time.RFC3339Nano))
+	case mapstr.M:
+		return kvlistValue(v)
+	case map[string]interface{}:
+		return kvlistValue(v)
+	case fmt.Stringer:
+		return stringValue(v.String())
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		values := make([]*commonpb.AnyValue, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			if value := anyValue(rv.Index(i).Interface()); value != nil {
+				values = append(values, value)
+			}
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{
+			ArrayValue: &commonpb.ArrayValue{Values: values},
+		}}
+	}
+	return stringValue(fmt.Sprint(v))
+}
+
+func intValue(v int64) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v}}
+}
+
+// uintValue converts unsigned values, values exceeding the int64 range are
+// converted to strings.
+func uintValue(v uint64) *commonpb.AnyValue {
+	if v > math.MaxInt64 {
+		return stringValue(fmt.Sprint(v))
+	}
+	return intValue(int64(v))
+}
+
+func kvlistValue(m map[string]interface{}) *commonpb.AnyValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]*commonpb.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		if value := anyValue(m[k]); value != nil {
+			kvs = append(kvs, &commonpb.KeyValue{Key: k, Value: value})
+		}
+	}
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{
+		KvlistValue: &commonpb.KeyValueList{Values: kvs},
+	}}
+}
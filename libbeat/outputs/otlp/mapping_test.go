@@ -0,0 +1,183 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestMakeRequest(t *testing.T) {
+	info := beat.Info{Beat: "testbeat", Version: "9.9.9"}
+	ts := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	observed := ts.Add(time.Second)
+
+	events := []beat.Event{
+		{
+			Timestamp: ts,
+			Fields: mapstr.M{
+				"message": "hello world",
+				"log": mapstr.M{
+					"level": "WARN",
+					"file":  mapstr.M{"path": "/var/log/app.log"},
+				},
+				"host":   mapstr.M{"name": "host-a", "architecture": "x86_64"},
+				"trace":  mapstr.M{"id": "0af7651916cd43dd8448eb211c80319c"},
+				"span":   mapstr.M{"id": "b7ad6b7169203331"},
+				"source": mapstr.M{"ip": "10.0.0.1", "port": 5000},
+				"tags":   []string{"a", "b"},
+				"empty":  nil,
+			},
+		},
+		{
+			Timestamp: ts,
+			Fields: mapstr.M{
+				"message": "second",
+				"host":    mapstr.M{"name": "host-b"},
+				"service": mapstr.M{"name": "my-service"},
+			},
+		},
+		{
+			Timestamp: ts,
+			Fields: mapstr.M{
+				"message": "third",
+				"host":    mapstr.M{"architecture": "x86_64", "name": "host-a"},
+			},
+		},
+	}
+
+	req := makeRequest(info, events, observed)
+	require.Len(t, req.ResourceLogs, 2, "events must be grouped by resource")
+
+	first := req.ResourceLogs[0]
+	assert.Equal(t, map[string]interface{}{
+		"host.arch":    "x86_64",
+		"host.name":    "host-a",
+		"service.name": "testbeat",
+	}, attributesMap(first.Resource.Attributes))
+	require.Len(t, first.ScopeLogs, 1)
+	assert.Equal(t, "testbeat", first.ScopeLogs[0].Scope.Name)
+	assert.Equal(t, "9.9.9", first.ScopeLogs[0].Scope.Version)
+	require.Len(t, first.ScopeLogs[0].LogRecords, 2)
+
+	record := first.ScopeLogs[0].LogRecords[0]
+	assert.Equal(t, uint64(ts.UnixNano()), record.TimeUnixNano)
+	assert.Equal(t, uint64(observed.UnixNano()), record.ObservedTimeUnixNano)
+	assert.Equal(t, "hello world", record.Body.GetStringValue())
+	assert.Equal(t, "WARN", record.SeverityText)
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_WARN, record.SeverityNumber)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", hex.EncodeToString(record.TraceId))
+	assert.Equal(t, "b7ad6b7169203331", hex.EncodeToString(record.SpanId))
+	assert.Equal(t, map[string]interface{}{
+		"log.file.path":  "/var/log/app.log",
+		"source.address": "10.0.0.1",
+		"source.port":    int64(5000),
+		"tags":           []interface{}{"a", "b"},
+	}, attributesMap(record.Attributes))
+
+	assert.Equal(t, "third", first.ScopeLogs[0].LogRecords[1].Body.GetStringValue())
+
+	second := req.ResourceLogs[1]
+	assert.Equal(t, map[string]interface{}{
+		"host.name":    "host-b",
+		"service.name": "my-service",
+	}, attributesMap(second.Resource.Attributes))
+}
+
+func TestMakeLogRecordInvalidTraceContext(t *testing.T) {
+	event := beat.Event{Fields: mapstr.M{
+		"trace": mapstr.M{"id": "not-a-trace-id"},
+		"log":   mapstr.M{"level": 3},
+	}}
+	_, record := makeLogRecord(beat.Info{Beat: "testbeat"}, &event, time.Now())
+
+	assert.Empty(t, record.TraceId)
+	assert.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED, record.SeverityNumber)
+	assert.Equal(t, map[string]interface{}{
+		"trace.id":  "not-a-trace-id",
+		"log.level": int64(3),
+	}, attributesMap(record.Attributes), "invalid values must be kept as attributes")
+}
+
+func TestAnyValue(t *testing.T) {
+	ts := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		in   interface{}
+		want interface{}
+	}{
+		"string":       {in: "a", want: "a"},
+		"bool":         {in: true, want: true},
+		"int":          {in: 42, want: int64(42)},
+		"uint32":       {in: uint32(42), want: int64(42)},
+		"large uint64": {in: uint64(1 << 63), want: "9223372036854775808"},
+		"float":        {in: 1.5, want: 1.5},
+		"time":         {in: ts, want: "2025-03-01T12:00:00Z"},
+		"bytes":        {in: []byte("ab"), want: []byte("ab")},
+		"slice":        {in: []int{1, 2}, want: []interface{}{int64(1), int64(2)}},
+		"map":          {in: mapstr.M{"b": 1, "a": "x"}, want: map[string]interface{}{"a": "x", "b": int64(1)}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, valueOf(anyValue(test.in)))
+		})
+	}
+
+	assert.Nil(t, anyValue(nil))
+}
+
+func attributesMap(kvs []*commonpb.KeyValue) map[string]interface{} {
+	m := make(map[string]interface{}, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = valueOf(kv.Value)
+	}
+	return m
+}
+
+func valueOf(v *commonpb.AnyValue) interface{} {
+	switch v := v.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return v.BoolValue
+	case *commonpb.AnyValue_IntValue:
+		return v.IntValue
+	case *commonpb.AnyValue_DoubleValue:
+		return v.DoubleValue
+	case *commonpb.AnyValue_BytesValue:
+		return v.BytesValue
+	case *commonpb.AnyValue_ArrayValue:
+		values := make([]interface{}, len(v.ArrayValue.Values))
+		for i, value := range v.ArrayValue.Values {
+			values[i] = valueOf(value)
+		}
+		return values
+	case *commonpb.AnyValue_KvlistValue:
+		return attributesMap(v.KvlistValue.Values)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package otlp provides an output sending events as OpenTelemetry log
+// records to an OTLP endpoint, such as an OpenTelemetry Collector, using
+// either OTLP/gRPC or OTLP/HTTP.
+package otlp
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+func init() {
+	outputs.RegisterType("otlp", makeOTLP)
+}
+
+func makeOTLP(
+	_ outputs.IndexManager,
+	beat beat.Info,
+	observer outputs.Observer,
+	cfg *config.C,
+) (outputs.Group, error) {
+	oConfig := defaultConfig()
+	if err := cfg.Unpack(&oConfig); err != nil {
+		return outputs.Fail(err)
+	}
+
+	hosts, err := outputs.ReadHostList(cfg)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	tlsConfig, err := tlscommon.LoadTLSConfig(oConfig.TLS)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tlscommon.TLSConfig{}
+	}
+
+	defaultScheme := "https"
+	if oConfig.Insecure {
+		defaultScheme = "http"
+	}
+
+	clients := make([]outputs.NetworkClient, len(hosts))
+	for i, host := range hosts {
+		hostURL, err := common.MakeURL(defaultScheme, oConfig.Path, host, oConfig.defaultPort())
+		if err != nil {
+			return outputs.Fail(fmt.Errorf("invalid host %v: %w", host, err))
+		}
+		u, err := url.Parse(hostURL)
+		if err != nil {
+			return outputs.Fail(fmt.Errorf("invalid host %v: %w", host, err))
+		}
+
+		var hostTLS *tls.Config
+		if u.Scheme == "https" {
+			hostTLS = tlsConfig.BuildModuleClientConfig(u.Hostname())
+		}
+
+		var exp exporter
+		switch oConfig.Protocol {
+		case protocolHTTP:
+			exp = newHTTPExporter(hostURL, hostTLS, oConfig.Headers, oConfig.Compression)
+		default:
+			exp = newGRPCExporter(u.Host, hostTLS, oConfig.Headers, oConfig.Compression)
+		}
+
+		client := newClient(beat, observer, oConfig.Timeout, exp)
+		clients[i] = outputs.WithBackoff(client, oConfig.Backoff.Init, oConfig.Backoff.Max)
+	}
+
+	return outputs.SuccessNet(oConfig.Queue, oConfig.LoadBalance, oConfig.BulkMaxSize, oConfig.MaxRetries, nil, clients)
+}
@@ -0,0 +1,268 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type fakeCollector struct {
+	collogspb.UnimplementedLogsServiceServer
+
+	mu       sync.Mutex
+	requests []*collogspb.ExportLogsServiceRequest
+	headers  metadata.MD
+	err      error
+}
+
+func (c *fakeCollector) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.requests = append(c.requests, req)
+	c.headers, _ = metadata.FromIncomingContext(ctx)
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+func startCollector(t *testing.T) (*fakeCollector, string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	collector := &fakeCollector{}
+	server := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(server, collector)
+	go server.Serve(lis) //nolint:errcheck // stopped on cleanup
+	t.Cleanup(server.Stop)
+
+	return collector, lis.Addr().String()
+}
+
+func makeTestClient(t *testing.T, settings map[string]interface{}) outputs.NetworkClient {
+	t.Helper()
+
+	info := beat.Info{Beat: "testbeat", Version: "9.9.9", Logger: logptest.NewTestingLogger(t, "")}
+	group, err := makeOTLP(nil, info, outputs.NewNilObserver(), conf.MustNewConfigFrom(settings))
+	require.NoError(t, err)
+	require.Len(t, group.Clients, 1)
+
+	client, ok := group.Clients[0].(outputs.NetworkClient)
+	require.True(t, ok, "client must be a NetworkClient")
+	require.NoError(t, client.Connect(context.Background()))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func testBatch() *outest.Batch {
+	return outest.NewBatch(
+		beat.Event{Fields: mapstr.M{"message": "one"}},
+		beat.Event{Fields: mapstr.M{"message": "two"}},
+	)
+}
+
+func TestPublishGRPC(t *testing.T) {
+	collector, addr := startCollector(t)
+	client := makeTestClient(t, map[string]interface{}{
+		"hosts":    []string{addr},
+		"insecure": true,
+		"headers":  map[string]string{"x-test": "value"},
+	})
+
+	batch := testBatch()
+	require.NoError(t, client.Publish(context.Background(), batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	require.Len(t, collector.requests, 1)
+	records := collector.requests[0].ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, records, 2)
+	assert.Equal(t, "one", records[0].Body.GetStringValue())
+	assert.Equal(t, "two", records[1].Body.GetStringValue())
+	assert.Equal(t, []string{"value"}, collector.headers.Get("x-test"))
+}
+
+func TestPublishGRPCErrors(t *testing.T) {
+	tests := map[string]struct {
+		err     error
+		wantTag outest.BatchSignalTag
+		wantErr bool
+	}{
+		"unavailable is retried": {
+			err:     status.Error(codes.Unavailable, "try later"),
+			wantTag: outest.BatchRetry,
+			wantErr: true,
+		},
+		"invalid argument is dropped": {
+			err:     status.Error(codes.InvalidArgument, "bad request"),
+			wantTag: outest.BatchDrop,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			collector, addr := startCollector(t)
+			collector.err = test.err
+			client := makeTestClient(t, map[string]interface{}{
+				"hosts":    []string{addr},
+				"insecure": true,
+			})
+
+			batch := testBatch()
+			err := client.Publish(context.Background(), batch)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.Len(t, batch.Signals, 1)
+			assert.Equal(t, test.wantTag, batch.Signals[0].Tag)
+		})
+	}
+}
+
+func TestPublishHTTP(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		requests   []*collogspb.ExportLogsServiceRequest
+		respStatus = http.StatusOK
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		assert.Equal(t, "/v1/logs", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+
+		gz, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(gz)
+		require.NoError(t, err)
+
+		req := &collogspb.ExportLogsServiceRequest{}
+		require.NoError(t, proto.Unmarshal(body, req))
+		requests = append(requests, req)
+
+		w.WriteHeader(respStatus)
+	}))
+	defer server.Close()
+
+	client := makeTestClient(t, map[string]interface{}{
+		"hosts":    []string{server.URL},
+		"protocol": "http",
+	})
+
+	tests := []struct {
+		status  int
+		wantTag outest.BatchSignalTag
+		wantErr bool
+	}{
+		{status: http.StatusOK, wantTag: outest.BatchACK},
+		{status: http.StatusServiceUnavailable, wantTag: outest.BatchRetry, wantErr: true},
+		{status: http.StatusBadRequest, wantTag: outest.BatchDrop},
+		{status: http.StatusRequestEntityTooLarge, wantTag: outest.BatchSplitRetry},
+	}
+	for _, test := range tests {
+		mu.Lock()
+		respStatus = test.status
+		mu.Unlock()
+
+		batch := testBatch()
+		err := client.Publish(context.Background(), batch)
+		if test.wantErr {
+			assert.Error(t, err, "status %d", test.status)
+		} else {
+			assert.NoError(t, err, "status %d", test.status)
+		}
+		require.Len(t, batch.Signals, 1)
+		assert.Equal(t, test.wantTag, batch.Signals[0].Tag, "status %d", test.status)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, requests, len(tests))
+	assert.Len(t, requests[0].ResourceLogs[0].ScopeLogs[0].LogRecords, 2)
+}
+
+func TestPublishHTTPPartialSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, err := proto.Marshal(&collogspb.ExportLogsServiceResponse{
+			PartialSuccess: &collogspb.ExportLogsPartialSuccess{
+				RejectedLogRecords: 1,
+				ErrorMessage:       "one record is invalid",
+			},
+		})
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		_, _ = io.Copy(w, bytes.NewReader(resp))
+	}))
+	defer server.Close()
+
+	client := makeTestClient(t, map[string]interface{}{
+		"hosts":       []string{server.URL},
+		"protocol":    "http",
+		"compression": "none",
+	})
+
+	batch := testBatch()
+	require.NoError(t, client.Publish(context.Background(), batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag, "partially rejected batches are not retried")
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"invalid protocol":    {"protocol": "udp"},
+		"invalid compression": {"compression": "zstd"},
+		"insecure with ssl":   {"insecure": true, "ssl.enabled": true},
+	}
+
+	for name, settings := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := defaultConfig()
+			assert.Error(t, conf.MustNewConfigFrom(settings).Unpack(&config))
+		})
+	}
+}
@@ -75,6 +75,11 @@ type Group struct {
 	//   and clear Content anyway. Metadata about the error should be saved in
 	//   EncodedEvent and reported when Publish is called.
 	EncoderFactory queue.EncoderFactory
+
+	// DropIfFull drops the events published to this output while its queue
+	// is full, instead of blocking, so a slow output does not hold back the
+	// inputs.
+	DropIfFull bool
 }
 
 // RegisterType registers a new output type.
@@ -106,5 +111,45 @@ func Load(
 	if stats == nil {
 		stats = NewNilObserver()
 	}
-	return factory(im, info, stats, config)
+
+	cond, err := loadFilter(config, info.Logger)
+	if err != nil {
+		return Group{}, fmt.Errorf("invalid when condition for output %v: %w", name, err)
+	}
+	dropIfFull, err := LoadBackpressure(config)
+	if err != nil {
+		return Group{}, fmt.Errorf("invalid backpressure for output %v: %w", name, err)
+	}
+
+	group, err := factory(im, info, stats, config)
+	if err != nil {
+		return group, err
+	}
+	group.DropIfFull = dropIfFull
+	if cond == nil {
+		return group, nil
+	}
+	return WithFilter(group, cond), nil
+}
+
+// LoadBackpressure reports whether the output is configured to drop events
+// with `backpressure: drop`, instead of blocking with the default `block`.
+func LoadBackpressure(cfg *config.C) (bool, error) {
+	if cfg == nil {
+		return false, nil
+	}
+	var settings struct {
+		Backpressure string `config:"backpressure"`
+	}
+	if err := cfg.Unpack(&settings); err != nil {
+		return false, err
+	}
+	switch settings.Backpressure {
+	case "", "block":
+		return false, nil
+	case "drop":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown value '%v', expected block or drop", settings.Backpressure)
+	}
 }
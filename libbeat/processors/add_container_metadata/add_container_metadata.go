@@ -0,0 +1,261 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux || darwin
+
+package add_container_metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/safemapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+const processorName = "add_container_metadata"
+
+// initCgroupPaths initializes a new cgroup reader. This enables
+// unit testing by allowing us to stub the OS interface.
+var initCgroupPaths processors.InitCgroupHandler = func(rootfsMountpoint resolve.Resolver, ignoreRootCgroups bool) (processors.CGReader, error) {
+	return cgroup.NewReader(rootfsMountpoint, ignoreRootCgroups)
+}
+
+// newRuntime connects to the container runtime, replaced in tests.
+var newRuntime = newRuntimeClient
+
+// containerIDRegex matches the 64-character container IDs of Docker,
+// containerd and CRI-O in cgroup paths.
+var containerIDRegex = regexp.MustCompile(`[0-9a-f]{64}`)
+
+func init() {
+	processors.RegisterPlugin(processorName, New)
+}
+
+type addContainerMetadata struct {
+	log      *logp.Logger
+	config   config
+	runtime  runtimeClient // nil if no container runtime is available.
+	cgreader processors.CGReader
+
+	containers *common.Cache // Cache of container ID (string) to cachedContainer.
+	cgroups    *common.Cache // Cache of PID (int) to container ID (string).
+}
+
+// cachedContainer is the cached metadata of a container, nil if the
+// container was not found.
+type cachedContainer struct {
+	meta *containerMetadata
+}
+
+// New constructs a new add_container_metadata processor.
+func New(cfg *conf.C, log *logp.Logger) (beat.Processor, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, fmt.Errorf("fail to unpack the %v configuration: %w", processorName, err)
+	}
+	log = log.Named(processorName)
+
+	runtime, err := newRuntime(config)
+	if err != nil {
+		// Like add_docker_metadata, the processor does nothing when there
+		// is no container runtime.
+		log.Debugf("%v: container runtime not detected: %v", processorName, err)
+	}
+
+	reader, err := initCgroupPaths(resolve.NewTestResolver(config.HostFS), false)
+	if errors.Is(err, cgroup.ErrCgroupsMissing) {
+		reader = &processors.NilCGReader{}
+	} else if err != nil {
+		if runtime != nil {
+			_ = runtime.close()
+		}
+		return nil, fmt.Errorf("error creating cgroup reader: %w", err)
+	}
+
+	p := &addContainerMetadata{
+		log:        log,
+		config:     config,
+		runtime:    runtime,
+		cgreader:   reader,
+		containers: common.NewCache(config.CacheTTL, 100),
+		cgroups:    common.NewCache(config.CacheTTL, 100),
+	}
+	p.containers.StartJanitor(config.CacheTTL)
+	p.cgroups.StartJanitor(config.CacheTTL)
+	return p, nil
+}
+
+func (p *addContainerMetadata) Run(event *beat.Event) (*beat.Event, error) {
+	if p.runtime == nil {
+		return event, nil
+	}
+
+	cid := p.containerIDFromFields(event)
+	if cid == "" {
+		cid = p.containerIDFromPIDs(event)
+	}
+	if cid == "" {
+		return event, nil
+	}
+
+	meta, err := p.lookup(cid)
+	if err != nil {
+		return event, fmt.Errorf("error looking up container %s: %w", cid, err)
+	}
+	if meta == nil {
+		p.log.Debugf("Container not found: cid=%s", cid)
+		return event, nil
+	}
+
+	event.Fields.DeepUpdate(p.fields(meta))
+	return event, nil
+}
+
+// containerIDFromFields returns the first container ID found in the
+// match_fields of the event.
+func (p *addContainerMetadata) containerIDFromFields(event *beat.Event) string {
+	for _, field := range p.config.Fields {
+		value, err := event.GetValue(field)
+		if err != nil {
+			continue
+		}
+		if cid, ok := value.(string); ok && cid != "" {
+			return cid
+		}
+	}
+	return ""
+}
+
+// containerIDFromPIDs returns the container ID found in the cgroups of the
+// first process of the match_pids of the event that runs in a container.
+func (p *addContainerMetadata) containerIDFromPIDs(event *beat.Event) string {
+	for _, field := range p.config.MatchPIDs {
+		v, err := event.GetValue(field)
+		if err != nil {
+			continue
+		}
+		pid, ok := common.TryToInt(v)
+		if !ok {
+			p.log.Debugf("field %v is not a PID (type=%T, value=%v)", field, v, v)
+			continue
+		}
+
+		if cid, ok := p.cgroups.Get(pid).(string); ok {
+			if cid != "" {
+				return cid
+			}
+			continue
+		}
+
+		cgroups, err := p.cgreader.ProcessCgroupPaths(pid)
+		if err != nil {
+			p.log.Debugf("failed to get cgroups for pid=%v: %v", pid, err)
+			continue
+		}
+		cid := containerIDFromCgroups(cgroups)
+		p.cgroups.Put(pid, cid)
+		if cid != "" {
+			return cid
+		}
+	}
+	return ""
+}
+
+// containerIDFromCgroups returns the container ID in the cgroup paths of a
+// process, or an empty string if the process does not run in a container.
+func containerIDFromCgroups(cgroups cgroup.PathList) string {
+	for _, path := range cgroups.Flatten() {
+		if cid := containerIDRegex.FindString(path.ControllerPath); cid != "" {
+			return cid
+		}
+	}
+	return ""
+}
+
+// lookup returns the metadata of a container from the cache or the runtime.
+// Errors are not cached, so the container is looked up again for the next
+// event.
+func (p *addContainerMetadata) lookup(cid string) (*containerMetadata, error) {
+	if cached, ok := p.containers.Get(cid).(cachedContainer); ok {
+		return cached.meta, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.Timeout)
+	defer cancel()
+	meta, err := p.runtime.container(ctx, cid)
+	if err != nil {
+		return nil, err
+	}
+	p.containers.Put(cid, cachedContainer{meta: meta})
+	return meta, nil
+}
+
+// fields returns the container fields added to the events.
+func (p *addContainerMetadata) fields(meta *containerMetadata) mapstr.M {
+	container := mapstr.M{
+		"id": meta.id,
+	}
+	if meta.name != "" {
+		container["name"] = meta.name
+	}
+	if meta.image != "" {
+		container["image"] = mapstr.M{"name": meta.image}
+	}
+	if meta.runtime != "" {
+		container["runtime"] = meta.runtime
+	}
+	if len(meta.labels) > 0 {
+		labels := mapstr.M{}
+		for k, v := range meta.labels {
+			if p.config.DeDot {
+				_, _ = labels.Put(common.DeDot(k), v)
+			} else {
+				_ = safemapstr.Put(labels, k, v)
+			}
+		}
+		container["labels"] = labels
+	}
+	return mapstr.M{"container": container}
+}
+
+func (p *addContainerMetadata) Close() error {
+	p.containers.StopJanitor()
+	p.cgroups.StopJanitor()
+	if p.runtime != nil {
+		if err := p.runtime.close(); err != nil {
+			return fmt.Errorf("closing container runtime client of %v: %w", processorName, err)
+		}
+	}
+	return nil
+}
+
+func (p *addContainerMetadata) String() string {
+	return fmt.Sprintf("%v=[runtime=%v host=%v match_fields=[%v] match_pids=[%v]]",
+		processorName, p.config.Runtime, p.config.Host,
+		strings.Join(p.config.Fields, ", "), strings.Join(p.config.MatchPIDs, ", "))
+}
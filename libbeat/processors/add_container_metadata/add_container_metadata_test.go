@@ -0,0 +1,216 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux || darwin
+
+package add_container_metadata
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+var testCID = strings.Repeat("b5285682fba7449c", 4)
+
+type fakeRuntime struct {
+	containers map[string]*containerMetadata
+	err        error
+	lookups    int
+}
+
+func (r *fakeRuntime) container(_ context.Context, id string) (*containerMetadata, error) {
+	r.lookups++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.containers[id], nil
+}
+
+func (r *fakeRuntime) close() error { return nil }
+
+type testCGReader map[int]string
+
+func (r testCGReader) ProcessCgroupPaths(pid int) (cgroup.PathList, error) {
+	path, ok := r[pid]
+	if !ok {
+		return cgroup.PathList{}, errors.New("process not found")
+	}
+	return cgroup.PathList{V2: map[string]cgroup.ControllerPath{
+		"cgroup": {ControllerPath: path},
+	}}, nil
+}
+
+func newTestProcessor(t *testing.T, cfg mapstr.M, runtime runtimeClient, cgroups testCGReader) *addContainerMetadata {
+	t.Helper()
+
+	origRuntime, origCgroups := newRuntime, initCgroupPaths
+	t.Cleanup(func() { newRuntime, initCgroupPaths = origRuntime, origCgroups })
+	newRuntime = func(config) (runtimeClient, error) {
+		if runtime == nil {
+			return nil, errNoRuntime
+		}
+		return runtime, nil
+	}
+	initCgroupPaths = func(resolve.Resolver, bool) (processors.CGReader, error) {
+		return cgroups, nil
+	}
+
+	c, err := conf.NewConfigFrom(cfg)
+	require.NoError(t, err)
+	p, err := New(c, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, processors.Close(p)) })
+	return p.(*addContainerMetadata)
+}
+
+func TestAddContainerMetadata(t *testing.T) {
+	runtime := &fakeRuntime{containers: map[string]*containerMetadata{
+		testCID: {
+			id:      testCID,
+			name:    "nginx",
+			image:   "docker.io/library/nginx:latest",
+			runtime: "containerd",
+			labels: map[string]string{
+				"io.kubernetes.pod.name": "nginx-7c5ddbdf54-6wqjl",
+				"app":                    "web",
+			},
+		},
+	}}
+	want := mapstr.M{
+		"id":      testCID,
+		"name":    "nginx",
+		"image":   mapstr.M{"name": "docker.io/library/nginx:latest"},
+		"runtime": "containerd",
+		"labels": mapstr.M{
+			"io_kubernetes_pod_name": "nginx-7c5ddbdf54-6wqjl",
+			"app":                    "web",
+		},
+	}
+
+	t.Run("match field", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{}, runtime, nil)
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"container": mapstr.M{"id": testCID}}})
+		require.NoError(t, err)
+		assert.Equal(t, mapstr.M{"container": want}, event.Fields)
+	})
+
+	t.Run("match pid", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{}, runtime, testCGReader{
+			10: "/kubepods.slice/kubepods-pod1.slice/cri-containerd-" + testCID + ".scope",
+			20: "/user.slice/user-1000.slice/session-1.scope",
+		})
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"process": mapstr.M{"pid": 20, "parent": mapstr.M{"pid": 10}}}})
+		require.NoError(t, err)
+		assert.Equal(t, want, event.Fields["container"])
+	})
+
+	t.Run("labels without dedot", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{"match_fields": []string{"cid"}, "labels.dedot": false}, runtime, nil)
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"cid": testCID}})
+		require.NoError(t, err)
+		v, err := event.GetValue("container.labels.io.kubernetes.pod.name")
+		require.NoError(t, err)
+		assert.Equal(t, "nginx-7c5ddbdf54-6wqjl", v)
+	})
+
+	t.Run("unknown container", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{}, runtime, nil)
+		fields := mapstr.M{"container": mapstr.M{"id": "unknown"}}
+		event, err := p.Run(&beat.Event{Fields: fields.Clone()})
+		require.NoError(t, err)
+		assert.Equal(t, fields, event.Fields)
+	})
+
+	t.Run("no runtime", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{}, nil, nil)
+		fields := mapstr.M{"container": mapstr.M{"id": testCID}}
+		event, err := p.Run(&beat.Event{Fields: fields.Clone()})
+		require.NoError(t, err)
+		assert.Equal(t, fields, event.Fields)
+	})
+}
+
+func TestAddContainerMetadataCache(t *testing.T) {
+	runtime := &fakeRuntime{containers: map[string]*containerMetadata{
+		testCID: {id: testCID, name: "nginx"},
+	}}
+	p := newTestProcessor(t, mapstr.M{}, runtime, nil)
+
+	for _, cid := range []string{testCID, testCID, "unknown", "unknown"} {
+		_, err := p.Run(&beat.Event{Fields: mapstr.M{"container": mapstr.M{"id": cid}}})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, runtime.lookups, "found and missing containers are cached")
+
+	runtime.err = errors.New("connection refused")
+	for i := 0; i < 2; i++ {
+		_, err := p.Run(&beat.Event{Fields: mapstr.M{"container": mapstr.M{"id": "other"}}})
+		assert.ErrorContains(t, err, "connection refused")
+	}
+	assert.Equal(t, 4, runtime.lookups, "errors are not cached")
+}
+
+func TestContainerIDFromCgroups(t *testing.T) {
+	for _, path := range []string{
+		"/docker/" + testCID,
+		"/system.slice/docker-" + testCID + ".scope",
+		"/kubepods/besteffort/pod1/" + testCID,
+		"/kubepods.slice/kubepods-besteffort.slice/crio-" + testCID + ".scope",
+	} {
+		cgroups := cgroup.PathList{V2: map[string]cgroup.ControllerPath{"cgroup": {ControllerPath: path}}}
+		assert.Equal(t, testCID, containerIDFromCgroups(cgroups), path)
+	}
+
+	cgroups := cgroup.PathList{V2: map[string]cgroup.ControllerPath{"cgroup": {ControllerPath: "/user.slice"}}}
+	assert.Empty(t, containerIDFromCgroups(cgroups))
+}
+
+func TestConfigValidate(t *testing.T) {
+	for _, tc := range []struct {
+		cfg     mapstr.M
+		wantErr string
+	}{
+		{cfg: mapstr.M{}},
+		{cfg: mapstr.M{"runtime": "cri", "host": "unix:///run/containerd/containerd.sock"}},
+		{cfg: mapstr.M{"runtime": "podman"}, wantErr: `invalid runtime "podman"`},
+		{cfg: mapstr.M{"host": "unix:///var/run/docker.sock"}, wantErr: "runtime must be set when host is set"},
+		{cfg: mapstr.M{"cache_ttl": 0}, wantErr: "zero value"},
+	} {
+		c, err := conf.NewConfigFrom(tc.cfg)
+		require.NoError(t, err)
+		config := defaultConfig()
+		err = c.Unpack(&config)
+		if tc.wantErr == "" {
+			assert.NoError(t, err, tc.cfg)
+		} else {
+			assert.ErrorContains(t, err, tc.wantErr, tc.cfg)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux || darwin
+
+package add_container_metadata
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	runtimeDocker = "docker"
+	runtimeCRI    = "cri"
+)
+
+// Config for the add_container_metadata processor.
+type config struct {
+	Runtime   string        `config:"runtime"`      // Container runtime API, docker or cri. Detected from the default sockets if not set.
+	Host      string        `config:"host"`         // Socket of the container runtime. Defaults to the default socket of the runtime.
+	Fields    []string      `config:"match_fields"` // A list of fields to match a container ID.
+	MatchPIDs []string      `config:"match_pids"`   // A list of fields containing process IDs (PIDs).
+	HostFS    string        `config:"hostfs"`       // Specifies the mount point of the host’s filesystem for use in monitoring a host from within a container.
+	DeDot     bool          `config:"labels.dedot"` // If set to true, replace dots in labels with `_`.
+	Timeout   time.Duration `config:"timeout" validate:"positive,nonzero"`
+	// The metadata of a container, or the absence of a container, is
+	// cached for cache_ttl before being looked up again.
+	CacheTTL time.Duration `config:"cache_ttl" validate:"positive,nonzero"`
+}
+
+func (c *config) Validate() error {
+	switch c.Runtime {
+	case "", runtimeDocker, runtimeCRI:
+	default:
+		return fmt.Errorf("invalid runtime %q, must be one of %s or %s", c.Runtime, runtimeDocker, runtimeCRI)
+	}
+	if c.Host != "" && c.Runtime == "" {
+		return fmt.Errorf("runtime must be set when host is set")
+	}
+	return nil
+}
+
+func defaultConfig() config {
+	return config{
+		Fields:    []string{"container.id"},
+		MatchPIDs: []string{"process.pid", "process.parent.pid"},
+		HostFS:    "/",
+		DeDot:     true,
+		Timeout:   5 * time.Second,
+		CacheTTL:  time.Minute,
+	}
+}
@@ -0,0 +1,197 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux || darwin
+
+package add_container_metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"github.com/elastic/elastic-agent-autodiscover/docker"
+)
+
+// errNoRuntime is returned when no container runtime socket is found.
+var errNoRuntime = errors.New("no container runtime socket found")
+
+// defaultSockets are the default sockets of the container runtimes, in the
+// order they are detected.
+var defaultSockets = []struct {
+	runtime string
+	path    string
+}{
+	{runtimeDocker, "/var/run/docker.sock"},
+	{runtimeCRI, "/run/containerd/containerd.sock"},
+	{runtimeCRI, "/var/run/crio/crio.sock"},
+}
+
+// containerMetadata is the metadata of a container reported by its runtime.
+type containerMetadata struct {
+	id, name, image, runtime string
+	labels                   map[string]string
+}
+
+// runtimeClient looks up containers in a container runtime.
+type runtimeClient interface {
+	// container returns the metadata of a container, or nil if the runtime
+	// does not know the container.
+	container(ctx context.Context, id string) (*containerMetadata, error)
+	close() error
+}
+
+// newRuntimeClient connects to the configured container runtime, or to the
+// first runtime whose default socket exists under hostfs.
+func newRuntimeClient(cfg config) (runtimeClient, error) {
+	runtime, host := cfg.Runtime, cfg.Host
+	if host == "" {
+		var ok bool
+		if runtime, host, ok = detectRuntime(cfg.HostFS, cfg.Runtime); !ok {
+			return nil, errNoRuntime
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+	if runtime == runtimeCRI {
+		return newCRIClient(ctx, host)
+	}
+	return newDockerClient(ctx, host)
+}
+
+// detectRuntime returns the runtime and host of the first default socket of
+// the runtime, or of any runtime if runtime is empty, that exists under hostfs.
+func detectRuntime(hostfs, runtime string) (string, string, bool) {
+	for _, s := range defaultSockets {
+		if runtime != "" && s.runtime != runtime {
+			continue
+		}
+		path := filepath.Join(hostfs, s.path)
+		if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+			return s.runtime, "unix://" + path, true
+		}
+	}
+	return "", "", false
+}
+
+// dockerClient looks up containers with the Docker Engine API.
+type dockerClient struct {
+	client *client.Client
+}
+
+func newDockerClient(ctx context.Context, host string) (*dockerClient, error) {
+	c, err := docker.NewClient(host, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.Ping(ctx); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to connect to docker at %s: %w", host, err)
+	}
+	return &dockerClient{client: c}, nil
+}
+
+func (d *dockerClient) container(ctx context.Context, id string) (*containerMetadata, error) {
+	info, err := d.client.ContainerInspect(ctx, id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	meta := &containerMetadata{
+		id:      info.ID,
+		name:    strings.TrimPrefix(info.Name, "/"),
+		runtime: runtimeDocker,
+	}
+	if info.Config != nil {
+		meta.image = info.Config.Image
+		meta.labels = info.Config.Labels
+	}
+	return meta, nil
+}
+
+func (d *dockerClient) close() error {
+	return d.client.Close()
+}
+
+// criClient looks up containers with the Kubernetes Container Runtime
+// Interface, served by containerd and CRI-O.
+type criClient struct {
+	conn    *grpc.ClientConn
+	client  runtimeapi.RuntimeServiceClient
+	runtime string
+}
+
+func newCRIClient(ctx context.Context, host string) (*criClient, error) {
+	conn, err := grpc.NewClient(host, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	c := runtimeapi.NewRuntimeServiceClient(conn)
+	version, err := c.Version(ctx, &runtimeapi.VersionRequest{})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to connect to CRI runtime at %s: %w", host, err)
+	}
+	return &criClient{conn: conn, client: c, runtime: version.RuntimeName}, nil
+}
+
+func (c *criClient) container(ctx context.Context, id string) (*containerMetadata, error) {
+	resp, err := c.client.ContainerStatus(ctx, &runtimeapi.ContainerStatusRequest{ContainerId: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	s := resp.GetStatus()
+	if s == nil {
+		return nil, nil
+	}
+
+	meta := &containerMetadata{
+		id:      s.Id,
+		name:    s.GetMetadata().GetName(),
+		runtime: c.runtime,
+		labels:  s.Labels,
+	}
+	// The image is resolved to its ID by some runtimes, prefer the
+	// image as specified by the user when it is reported.
+	meta.image = s.GetImage().GetUserSpecifiedImage()
+	if meta.image == "" {
+		meta.image = s.GetImage().GetImage()
+	}
+	return meta, nil
+}
+
+func (c *criClient) close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux || darwin
+
+package add_container_metadata
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+type fakeCRIServer struct {
+	runtimeapi.UnimplementedRuntimeServiceServer
+	containers map[string]*runtimeapi.ContainerStatus
+}
+
+func (s *fakeCRIServer) Version(context.Context, *runtimeapi.VersionRequest) (*runtimeapi.VersionResponse, error) {
+	return &runtimeapi.VersionResponse{RuntimeName: "containerd", RuntimeVersion: "v2.1.0"}, nil
+}
+
+func (s *fakeCRIServer) ContainerStatus(_ context.Context, req *runtimeapi.ContainerStatusRequest) (*runtimeapi.ContainerStatusResponse, error) {
+	c, ok := s.containers[req.ContainerId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "container %q not found", req.ContainerId)
+	}
+	return &runtimeapi.ContainerStatusResponse{Status: c}, nil
+}
+
+// listenUnix listens on a unix socket in a short temporary directory, as
+// the length of socket paths is limited.
+func listenUnix(t *testing.T, path string) net.Listener {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	return l
+}
+
+func shortTempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "acm")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestCRIClient(t *testing.T) {
+	socket := filepath.Join(shortTempDir(t), "cri.sock")
+	server := grpc.NewServer()
+	runtimeapi.RegisterRuntimeServiceServer(server, &fakeCRIServer{containers: map[string]*runtimeapi.ContainerStatus{
+		testCID: {
+			Id:       testCID,
+			Metadata: &runtimeapi.ContainerMetadata{Name: "nginx"},
+			Image:    &runtimeapi.ImageSpec{Image: "sha256:4e1b6bae1e48", UserSpecifiedImage: "nginx:latest"},
+			Labels:   map[string]string{"io.kubernetes.pod.name": "nginx"},
+		},
+		"resolved": {
+			Id:    "resolved",
+			Image: &runtimeapi.ImageSpec{Image: "docker.io/library/nginx:latest"},
+		},
+	}})
+	l := listenUnix(t, socket)
+	go server.Serve(l) //nolint:errcheck // stopped by the test
+	t.Cleanup(server.Stop)
+
+	c, err := newCRIClient(context.Background(), "unix://"+socket)
+	require.NoError(t, err)
+	defer c.close()
+
+	meta, err := c.container(context.Background(), testCID)
+	require.NoError(t, err)
+	assert.Equal(t, &containerMetadata{
+		id:      testCID,
+		name:    "nginx",
+		image:   "nginx:latest",
+		runtime: "containerd",
+		labels:  map[string]string{"io.kubernetes.pod.name": "nginx"},
+	}, meta)
+
+	meta, err = c.container(context.Background(), "resolved")
+	require.NoError(t, err)
+	assert.Equal(t, "docker.io/library/nginx:latest", meta.image)
+
+	meta, err = c.container(context.Background(), "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, meta)
+}
+
+func TestDetectRuntime(t *testing.T) {
+	hostfs := shortTempDir(t)
+
+	_, _, ok := detectRuntime(hostfs, "")
+	assert.False(t, ok)
+
+	// Only sockets are detected.
+	crio := filepath.Join(hostfs, "var/run/crio/crio.sock")
+	require.NoError(t, os.MkdirAll(filepath.Dir(crio), 0o755))
+	require.NoError(t, os.WriteFile(crio, nil, 0o600))
+	_, _, ok = detectRuntime(hostfs, "")
+	assert.False(t, ok)
+	require.NoError(t, os.Remove(crio))
+
+	listenUnix(t, crio)
+	runtime, host, ok := detectRuntime(hostfs, "")
+	require.True(t, ok)
+	assert.Equal(t, runtimeCRI, runtime)
+	assert.Equal(t, "unix://"+crio, host)
+
+	_, _, ok = detectRuntime(hostfs, runtimeDocker)
+	assert.False(t, ok)
+
+	docker := filepath.Join(hostfs, "var/run/docker.sock")
+	listenUnix(t, docker)
+	runtime, host, ok = detectRuntime(hostfs, "")
+	require.True(t, ok)
+	assert.Equal(t, runtimeDocker, runtime)
+	assert.Equal(t, "unix://"+docker, host)
+
+	runtime, _, ok = detectRuntime(hostfs, runtimeCRI)
+	require.True(t, ok)
+	assert.Equal(t, runtimeCRI, runtime)
+}
@@ -52,7 +52,7 @@ var (
 	// ErrNoProcess is returned when metadata for a process can't be collected.
 	ErrNoProcess = errors.New("process not found")
 
-	procCache = newProcessCache(cacheExpiration, cacheCapacity, cacheEvictionEffort, gosysinfoProvider{})
+	procCache = newProcessCache(cacheExpiration, cacheCapacity, cacheEvictionEffort, defaultProvider)
 
 	// cgroups resolver, turned to a stub function to make testing easier.
 	initCgroupPaths processors.InitCgroupHandler = func(rootfsMountpoint resolve.Resolver, ignoreRootCgroups bool) (processors.CGReader, error) {
@@ -81,6 +81,7 @@ type processMetadata struct {
 	pid, ppid                          int
 	groupname, groupid                 string
 	capEffective, capPermitted         []string
+	integrityLevel                     string
 	fields                             mapstr.M
 }
 
@@ -205,8 +206,38 @@ func containsValue(m mapstr.M, v string) bool {
 
 // Run enriches the given event with the host meta data.
 func (p *addProcessMetadata) Run(event *beat.Event) (*beat.Event, error) {
+	return p.run(event, p.lookup)
+}
+
+// RunBatch enriches the given events. It is equivalent to calling Run on each
+// event, but the metadata of each distinct PID is only collected once for the
+// whole batch. The returned events and errors match the input events by index.
+func (p *addProcessMetadata) RunBatch(events []*beat.Event) ([]*beat.Event, []error) {
+	type lookupResult struct {
+		meta mapstr.M
+		err  error
+	}
+	seen := make(map[int]lookupResult)
+	lookup := func(pid int) (mapstr.M, error) {
+		if r, ok := seen[pid]; ok {
+			return r.meta, r.err
+		}
+		meta, err := p.lookup(pid)
+		seen[pid] = lookupResult{meta: meta, err: err}
+		return meta, err
+	}
+
+	results := make([]*beat.Event, len(events))
+	errs := make([]error, len(events))
+	for i, event := range events {
+		results[i], errs[i] = p.run(event, lookup)
+	}
+	return results, errs
+}
+
+func (p *addProcessMetadata) run(event *beat.Event, lookup func(pid int) (mapstr.M, error)) (*beat.Event, error) {
 	for _, pidField := range p.config.MatchPIDs {
-		result, err := p.enrich(event, pidField)
+		result, err := p.enrich(event, pidField, lookup)
 		if err != nil {
 			switch {
 			case errors.Is(err, mapstr.ErrKeyNotFound):
@@ -253,7 +284,7 @@ func pidToInt(value interface{}) (pid int, err error) {
 	return pid, nil
 }
 
-func (p *addProcessMetadata) enrich(event *beat.Event, pidField string) (result *beat.Event, err error) {
+func (p *addProcessMetadata) enrich(event *beat.Event, pidField string, lookup func(pid int) (mapstr.M, error)) (result *beat.Event, err error) {
 	pidIf, err := event.GetValue(pidField)
 	if err != nil {
 		return nil, err
@@ -264,6 +295,20 @@ func (p *addProcessMetadata) enrich(event *beat.Event, pidField string) (result
 		return nil, fmt.Errorf("cannot parse pid field '%s': %w", pidField, err)
 	}
 
+	meta, err := lookup(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	result = event.Clone()
+	if err = p.apply(result, meta); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// lookup collects the metadata and container id of the process.
+func (p *addProcessMetadata) lookup(pid int) (mapstr.M, error) {
 	var meta mapstr.M
 
 	metaPtr, err := p.provider.GetProcessMetadata(pid)
@@ -273,6 +318,13 @@ func (p *addProcessMetadata) enrich(event *beat.Event, pidField string) (result
 		meta = mapstr.M{}
 	} else {
 		meta = metaPtr.fields
+		if p.config.IncludeAncestry > 0 {
+			// The fields are shared with the process cache.
+			meta = meta.Clone()
+			if err = p.putAncestry(meta, metaPtr); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	cid, err := p.getContainerID(pid)
@@ -289,16 +341,72 @@ func (p *addProcessMetadata) enrich(event *beat.Event, pidField string) (result
 		return nil, ErrNoProcess
 	}
 
-	result = event.Clone()
+	return meta, nil
+}
+
+// putAncestry adds the ancestors of the process to meta under process.ancestry,
+// and the details of its parent under process.parent.
+func (p *addProcessMetadata) putAncestry(meta mapstr.M, proc *processMetadata) error {
+	ancestors := p.ancestry(proc)
+	if len(ancestors) == 0 {
+		return nil
+	}
+	if _, err := meta.Put("process.ancestry", ancestors); err != nil {
+		return err
+	}
+	for _, key := range []string{"name", "executable", "start_time"} {
+		if v, ok := ancestors[0][key]; ok {
+			if _, err := meta.Put("process.parent."+key, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ancestry walks up the parent PIDs of the process and returns up to
+// include_ancestry ancestors, starting with its parent. The walk stops at
+// the first ancestor that cannot be found, or that started after its child,
+// which means its PID was reused.
+func (p *addProcessMetadata) ancestry(proc *processMetadata) []mapstr.M {
+	var ancestors []mapstr.M
+	seen := map[int]bool{proc.pid: true}
+	for child := proc; len(ancestors) < p.config.IncludeAncestry && child.ppid > 0 && !seen[child.ppid]; {
+		seen[child.ppid] = true
+		parent, err := p.provider.GetProcessMetadata(child.ppid)
+		if err != nil || parent == nil {
+			p.log.Debugf("failed to get process metadata for ancestor PID=%d: %v", child.ppid, err)
+			break
+		}
+		if !child.startTime.IsZero() && parent.startTime.After(child.startTime) {
+			break
+		}
+
+		ancestor := mapstr.M{
+			"pid":        parent.pid,
+			"name":       parent.name,
+			"executable": parent.exe,
+		}
+		if !parent.startTime.IsZero() {
+			ancestor["start_time"] = parent.startTime
+		}
+		ancestors = append(ancestors, ancestor)
+		child = parent
+	}
+	return ancestors
+}
+
+// apply copies the mapped metadata fields to the event.
+func (p *addProcessMetadata) apply(result *beat.Event, meta mapstr.M) error {
 	for dest, sourceIf := range p.mappings {
 		source, castOk := sourceIf.(string)
 		if !castOk {
 			// Should never happen, as source is generated by Config.prepareMappings()
-			return nil, errors.New("source is not a string")
+			return errors.New("source is not a string")
 		}
 		if !p.config.OverwriteKeys {
 			if _, err := result.GetValue(dest); err == nil {
-				return nil, fmt.Errorf("target field '%s' already exists and overwrite_keys is false", dest)
+				return fmt.Errorf("target field '%s' already exists and overwrite_keys is false", dest)
 			}
 		}
 
@@ -309,11 +417,11 @@ func (p *addProcessMetadata) enrich(event *beat.Event, pidField string) (result
 		}
 
 		if _, err = result.PutValue(dest, value); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return result, nil
+	return nil
 }
 
 func (p *addProcessMetadata) getContainerID(pid int) (string, error) {
@@ -340,9 +448,9 @@ func (p *addProcessMetadataCloser) Close() error {
 
 // String returns the processor representation formatted as a string
 func (p *addProcessMetadata) String() string {
-	return fmt.Sprintf("%v=[match_pids=%v, mappings=%v, ignore_missing=%v, overwrite_fields=%v, restricted_fields=%v, host_path=%v, cgroup_prefixes=%v]",
+	return fmt.Sprintf("%v=[match_pids=%v, mappings=%v, ignore_missing=%v, overwrite_fields=%v, restricted_fields=%v, host_path=%v, cgroup_prefixes=%v, include_ancestry=%v]",
 		processorName, p.config.MatchPIDs, p.mappings, p.config.IgnoreMissing,
-		p.config.OverwriteKeys, p.config.RestrictedFields, p.config.HostPath, p.config.CgroupPrefixes)
+		p.config.OverwriteKeys, p.config.RestrictedFields, p.config.HostPath, p.config.CgroupPrefixes, p.config.IncludeAncestry)
 }
 
 func (p *processMetadata) toMap() mapstr.M {
@@ -375,6 +483,9 @@ func (p *processMetadata) toMap() mapstr.M {
 	if len(p.capPermitted) > 0 {
 		process.Put("thread.capabilities.permitted", p.capPermitted)
 	}
+	if p.integrityLevel != "" {
+		process.Put("token.integrity_level_name", p.integrityLevel)
+	}
 	if p.groupname != "" || p.groupid != "" {
 		group := mapstr.M{}
 		if p.groupname != "" {
@@ -1313,3 +1313,152 @@ func TestDefaultCgroupRegex(t *testing.T) {
 		})
 	}
 }
+
+type countingProvider struct {
+	provider processMetadataProvider
+	calls    map[int]int
+}
+
+func (p *countingProvider) GetProcessMetadata(pid int) (*processMetadata, error) {
+	p.calls[pid]++
+	return p.provider.GetProcessMetadata(pid)
+}
+
+func TestRunBatch(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors(processorName))
+
+	provider := &countingProvider{
+		provider: testProvider{
+			1: {name: "systemd", pid: 1},
+			2: {name: "kthreadd", pid: 2},
+		},
+		calls: map[int]int{},
+	}
+
+	config := defaultConfig()
+	configC, err := conf.NewConfigFrom(mapstr.M{
+		"match_pids":     []string{"ppid"},
+		"include_fields": []string{"process.name"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, configC.Unpack(&config))
+
+	proc, err := newProcessMetadataProcessorWithProvider(config, provider, false)
+	require.NoError(t, err)
+	batchProc, ok := proc.(interface {
+		RunBatch([]*beat.Event) ([]*beat.Event, []error)
+	})
+	require.True(t, ok)
+
+	events := []*beat.Event{
+		{Fields: mapstr.M{"ppid": 1}},
+		{Fields: mapstr.M{"ppid": "1"}},
+		{Fields: mapstr.M{"ppid": 2}},
+		{Fields: mapstr.M{"ppid": 1, "process": mapstr.M{"name": "other"}}},
+		{Fields: mapstr.M{"ppid": 3}},
+		{Fields: mapstr.M{"ppid": 3}},
+	}
+
+	results, errs := batchProc.RunBatch(events)
+	require.Len(t, results, len(events))
+	require.Len(t, errs, len(events))
+
+	assert.Equal(t, map[int]int{1: 1, 2: 1, 3: 1}, provider.calls)
+
+	for i, event := range events[:3] {
+		assert.NoError(t, errs[i])
+		want := event.Fields.Clone()
+		name := "systemd"
+		if i == 2 {
+			name = "kthreadd"
+		}
+		want["process"] = mapstr.M{"name": name}
+		assert.Equal(t, want, results[i].Fields)
+	}
+
+	// overwrite_keys is checked for each event.
+	assert.ErrorContains(t, errs[3], "overwrite_keys is false")
+	assert.Equal(t, events[3], results[3])
+
+	for _, i := range []int{4, 5} {
+		assert.ErrorIs(t, errs[i], ErrNoProcess)
+		assert.Equal(t, events[i], results[i])
+	}
+}
+
+func TestIncludeAncestry(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors(processorName))
+
+	startTime := time.Now()
+	provider := testProvider{
+		1:   {name: "systemd", exe: "/usr/lib/systemd/systemd", pid: 1, startTime: startTime},
+		10:  {name: "sshd", exe: "/usr/sbin/sshd", pid: 10, ppid: 1, startTime: startTime.Add(time.Second)},
+		20:  {name: "bash", exe: "/usr/bin/bash", pid: 20, ppid: 10, startTime: startTime.Add(2 * time.Second)},
+		30:  {name: "curl", exe: "/usr/bin/curl", pid: 30, ppid: 20, startTime: startTime.Add(3 * time.Second)},
+		40:  {name: "orphan", exe: "/usr/bin/orphan", pid: 40, ppid: 99, startTime: startTime},
+		50:  {name: "reused", exe: "/usr/bin/reused", pid: 50, ppid: 60, startTime: startTime},
+		60:  {name: "newer", exe: "/usr/bin/newer", pid: 60, ppid: 1, startTime: startTime.Add(time.Hour)},
+		100: {name: "loop", exe: "/usr/bin/loop", pid: 100, ppid: 100},
+	}
+
+	run := func(t *testing.T, ancestry int, pid int) *beat.Event {
+		config := defaultConfig()
+		configC, err := conf.NewConfigFrom(mapstr.M{
+			"match_pids":       []string{"pid"},
+			"include_fields":   []string{"process.parent", "process.ancestry"},
+			"include_ancestry": ancestry,
+		})
+		require.NoError(t, err)
+		require.NoError(t, configC.Unpack(&config))
+
+		proc, err := newProcessMetadataProcessorWithProvider(config, provider, false)
+		require.NoError(t, err)
+		event, err := proc.Run(&beat.Event{Fields: mapstr.M{"pid": pid}})
+		require.NoError(t, err)
+		return event
+	}
+
+	t.Run("limited to include_ancestry", func(t *testing.T) {
+		event := run(t, 2, 30)
+		assert.Equal(t, mapstr.M{
+			"parent": mapstr.M{
+				"pid":        20,
+				"name":       "bash",
+				"executable": "/usr/bin/bash",
+				"start_time": startTime.Add(2 * time.Second),
+			},
+			"ancestry": []mapstr.M{
+				{"pid": 20, "name": "bash", "executable": "/usr/bin/bash", "start_time": startTime.Add(2 * time.Second)},
+				{"pid": 10, "name": "sshd", "executable": "/usr/sbin/sshd", "start_time": startTime.Add(time.Second)},
+			},
+		}, event.Fields["process"])
+	})
+
+	t.Run("stops at the root process", func(t *testing.T) {
+		event := run(t, 10, 30)
+		ancestry, err := event.GetValue("process.ancestry")
+		require.NoError(t, err)
+		require.Len(t, ancestry, 3)
+		assert.Equal(t, 1, ancestry.([]mapstr.M)[2]["pid"])
+	})
+
+	t.Run("stops at a missing ancestor", func(t *testing.T) {
+		event := run(t, 10, 40)
+		assert.Equal(t, mapstr.M{"parent": mapstr.M{"pid": 99}}, event.Fields["process"])
+	})
+
+	t.Run("stops at a reused PID", func(t *testing.T) {
+		event := run(t, 10, 50)
+		assert.Equal(t, mapstr.M{"parent": mapstr.M{"pid": 60}}, event.Fields["process"])
+	})
+
+	t.Run("stops at a loop", func(t *testing.T) {
+		event := run(t, 10, 100)
+		assert.Equal(t, mapstr.M{"parent": mapstr.M{"pid": 100}}, event.Fields["process"])
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		event := run(t, 0, 30)
+		assert.Equal(t, mapstr.M{"parent": mapstr.M{"pid": 20}}, event.Fields["process"])
+	})
+}
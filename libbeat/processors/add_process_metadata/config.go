@@ -60,6 +60,10 @@ type config struct {
 	// CgroupCacheExpireTime is the length of time before cgroup cache elements expire in seconds,
 	// set to 0 to disable the cgroup cache
 	CgroupCacheExpireTime time.Duration `config:"cgroup_cache_expire_time"`
+
+	// IncludeAncestry is the number of ancestors of the process, walking up
+	// the parent PIDs, to add under process.ancestry. Set to 0 to disable.
+	IncludeAncestry int `config:"include_ancestry" validate:"min=0"`
 }
 
 func (c *config) Validate() error {
@@ -78,8 +82,12 @@ var defaultFields = mapstr.M{
 		"args":       nil,
 		"pid":        nil,
 		"parent": mapstr.M{
-			"pid": nil,
+			"pid":        nil,
+			"name":       nil,
+			"executable": nil,
+			"start_time": nil,
 		},
+		"ancestry":   nil,
 		"entity_id":  nil,
 		"start_time": nil,
 		"owner": mapstr.M{
@@ -96,6 +104,9 @@ var defaultFields = mapstr.M{
 				"permitted": nil,
 			},
 		},
+		"token": mapstr.M{
+			"integrity_level_name": nil,
+		},
 	},
 	"container": mapstr.M{
 		"id": nil,
@@ -0,0 +1,213 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package add_process_metadata
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var defaultProvider processMetadataProvider = windowsProvider{}
+
+// Integrity levels are the last sub-authority of the mandatory label SID of
+// the process token.
+// See https://learn.microsoft.com/en-us/windows/win32/secauthz/mandatory-integrity-control
+const (
+	untrustedIntegrityLevel  = 0x0000
+	lowIntegrityLevel        = 0x1000
+	mediumIntegrityLevel     = 0x2000
+	mediumPlusIntegrityLevel = 0x2100
+	highIntegrityLevel       = 0x3000
+	systemIntegrityLevel     = 0x4000
+	protectedIntegrityLevel  = 0x5000
+)
+
+// windowsProvider completes the process metadata of go-sysinfo with the
+// owner and integrity level from the process token. Processes that go-sysinfo
+// cannot read, like protected processes and processes of other users when
+// running without SeDebugPrivilege, are described from the information
+// available with limited query access.
+type windowsProvider struct{}
+
+func (p windowsProvider) GetProcessMetadata(pid int) (*processMetadata, error) {
+	r, err := gosysinfoProvider{}.GetProcessMetadata(pid)
+	if err != nil {
+		r, err = limitedProcessMetadata(pid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if token, err := readProcessToken(pid); err == nil {
+		if r.userid == "" {
+			r.userid, r.username = token.userid, token.username
+		}
+		if r.groupid == "" {
+			r.groupid, r.groupname = token.groupid, token.groupname
+		}
+		r.integrityLevel = token.integrityLevel
+	}
+
+	r.fields = r.toMap()
+	return r, nil
+}
+
+// limitedProcessMetadata returns the metadata of a process that can be read
+// without access to its memory: the name and parent PID from a toolhelp
+// snapshot, and the executable and start time with limited query access.
+func limitedProcessMetadata(pid int) (*processMetadata, error) {
+	entry, err := processEntry(pid)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &processMetadata{
+		name: windows.UTF16ToString(entry.ExeFile[:]),
+		pid:  pid,
+		ppid: int(entry.ParentProcessID),
+	}
+
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err == nil {
+		defer windows.CloseHandle(h) //nolint:errcheck // nothing to do on close failure
+		buf := make([]uint16, windows.MAX_LONG_PATH)
+		size := uint32(len(buf))
+		if err := windows.QueryFullProcessImageName(h, 0, &buf[0], &size); err == nil {
+			r.exe = windows.UTF16ToString(buf[:size])
+		}
+		var creation, exit, kernel, user windows.Filetime
+		if err := windows.GetProcessTimes(h, &creation, &exit, &kernel, &user); err == nil {
+			r.startTime = time.Unix(0, creation.Nanoseconds())
+		}
+	}
+	r.entityID, _ = entityID(pid, r.startTime)
+	return r, nil
+}
+
+// processEntry returns the toolhelp snapshot entry of a process.
+func processEntry(pid int) (*windows.ProcessEntry32, error) {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create process snapshot: %w", err)
+	}
+	defer windows.CloseHandle(snapshot) //nolint:errcheck // nothing to do on close failure
+
+	entry := windows.ProcessEntry32{Size: uint32(unsafe.Sizeof(windows.ProcessEntry32{}))}
+	for err = windows.Process32First(snapshot, &entry); err == nil; err = windows.Process32Next(snapshot, &entry) {
+		if int(entry.ProcessID) == pid {
+			return &entry, nil
+		}
+	}
+	if errors.Is(err, windows.ERROR_NO_MORE_FILES) {
+		return nil, ErrNoProcess
+	}
+	return nil, fmt.Errorf("failed to read process snapshot: %w", err)
+}
+
+// processToken is the owner and integrity level of a process token.
+type processToken struct {
+	username, userid   string
+	groupname, groupid string
+	integrityLevel     string
+}
+
+// readProcessToken reads the user, primary group and integrity level of the
+// access token of a process.
+func readProcessToken(pid int) (processToken, error) {
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return processToken{}, fmt.Errorf("failed to open process %d: %w", pid, err)
+	}
+	defer windows.CloseHandle(h) //nolint:errcheck // nothing to do on close failure
+
+	var token windows.Token
+	if err := windows.OpenProcessToken(h, windows.TOKEN_QUERY, &token); err != nil {
+		return processToken{}, fmt.Errorf("failed to open token of process %d: %w", pid, err)
+	}
+	defer token.Close()
+
+	var t processToken
+	if u, err := token.GetTokenUser(); err == nil {
+		t.userid = u.User.Sid.String()
+		t.username = accountName(u.User.Sid)
+	}
+	if g, err := token.GetTokenPrimaryGroup(); err == nil {
+		t.groupid = g.PrimaryGroup.String()
+		t.groupname = accountName(g.PrimaryGroup)
+	}
+	if level, err := tokenIntegrityLevel(token); err == nil {
+		t.integrityLevel = integrityLevelName(level)
+	}
+	return t, nil
+}
+
+// accountName returns the DOMAIN\name of the account of a SID.
+func accountName(sid *windows.SID) string {
+	account, domain, _, err := sid.LookupAccount("")
+	if err != nil {
+		return ""
+	}
+	if domain == "" {
+		return account
+	}
+	return domain + `\` + account
+}
+
+// tokenIntegrityLevel returns the integrity level of an access token.
+func tokenIntegrityLevel(token windows.Token) (uint32, error) {
+	var size uint32
+	err := windows.GetTokenInformation(token, windows.TokenIntegrityLevel, nil, 0, &size)
+	if size == 0 {
+		return 0, err
+	}
+	buf := make([]byte, size)
+	if err := windows.GetTokenInformation(token, windows.TokenIntegrityLevel, &buf[0], size, &size); err != nil {
+		return 0, err
+	}
+	sid := (*windows.Tokenmandatorylabel)(unsafe.Pointer(&buf[0])).Label.Sid
+	count := sid.SubAuthorityCount()
+	if count == 0 {
+		return 0, errors.New("invalid mandatory label")
+	}
+	return sid.SubAuthority(uint32(count) - 1), nil
+}
+
+// integrityLevelName returns the name of an integrity level, rounded down to
+// the closest well-known level.
+func integrityLevelName(level uint32) string {
+	switch {
+	case level >= protectedIntegrityLevel:
+		return "protected"
+	case level >= systemIntegrityLevel:
+		return "system"
+	case level >= highIntegrityLevel:
+		return "high"
+	case level >= mediumPlusIntegrityLevel:
+		return "medium_plus"
+	case level >= mediumIntegrityLevel:
+		return "medium"
+	case level >= lowIntegrityLevel:
+		return "low"
+	default:
+		return "untrusted"
+	}
+}
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package add_process_metadata
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrityLevelName(t *testing.T) {
+	for level, want := range map[uint32]string{
+		untrustedIntegrityLevel:  "untrusted",
+		lowIntegrityLevel:        "low",
+		mediumIntegrityLevel:     "medium",
+		mediumPlusIntegrityLevel: "medium_plus",
+		highIntegrityLevel:       "high",
+		systemIntegrityLevel:     "system",
+		protectedIntegrityLevel:  "protected",
+		0x1500:                   "low",
+	} {
+		assert.Equal(t, want, integrityLevelName(level), "level %#x", level)
+	}
+}
+
+func TestWindowsProvider(t *testing.T) {
+	pid := os.Getpid()
+
+	t.Run("go-sysinfo and token", func(t *testing.T) {
+		r, err := windowsProvider{}.GetProcessMetadata(pid)
+		require.NoError(t, err)
+		assert.Equal(t, pid, r.pid)
+		assert.Equal(t, os.Getppid(), r.ppid)
+		assert.True(t, strings.HasPrefix(r.userid, "S-1-"), "user ID %q is not a SID", r.userid)
+		assert.NotEmpty(t, r.username)
+		assert.NotEmpty(t, r.integrityLevel)
+
+		v, err := r.fields.GetValue("process.token.integrity_level_name")
+		require.NoError(t, err)
+		assert.Equal(t, r.integrityLevel, v)
+	})
+
+	t.Run("limited access", func(t *testing.T) {
+		r, err := limitedProcessMetadata(pid)
+		require.NoError(t, err)
+		assert.Equal(t, os.Getppid(), r.ppid)
+		assert.NotEmpty(t, r.name)
+		assert.NotEmpty(t, r.exe)
+		assert.False(t, r.startTime.IsZero())
+	})
+
+	t.Run("missing process", func(t *testing.T) {
+		_, err := processEntry(-1)
+		assert.ErrorIs(t, err, ErrNoProcess)
+	})
+}
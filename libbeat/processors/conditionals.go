@@ -72,6 +72,31 @@ func (r *WhenProcessor) Run(event *beat.Event) (*beat.Event, error) {
 	return r.p.Run(event)
 }
 
+// RunBatch executes this WhenProcessor on the events matching the condition,
+// passing them to the wrapped processor together.
+func (r *WhenProcessor) RunBatch(events []*beat.Event) ([]*beat.Event, []error) {
+	results := append([]*beat.Event(nil), events...)
+	errs := make([]error, len(events))
+
+	var matched []int
+	var in []*beat.Event
+	for i, event := range events {
+		if r.condition.Check(event) {
+			matched = append(matched, i)
+			in = append(in, event)
+		}
+	}
+	if len(in) == 0 {
+		return results, errs
+	}
+
+	out, outErrs := RunBatch(r.p, in)
+	for j, i := range matched {
+		results[i], errs[i] = out[j], outErrs[j]
+	}
+	return results, errs
+}
+
 func (r *WhenProcessor) String() string {
 	return fmt.Sprintf("%v, condition=%v", r.p.String(), r.condition.String())
 }
@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ecs_translate
+
+import "time"
+
+type config struct {
+	MappingFile    string        `config:"mapping_file" validate:"required"` // Path to the YAML or JSON mapping file.
+	ReloadInterval time.Duration `config:"reload_interval" validate:"min=0"`
+	// Defaults of the mapping, the mapping file can override them.
+	IgnoreMissing bool   `config:"ignore_missing"`
+	FailOnError   bool   `config:"fail_on_error"`
+	Mode          string `config:"mode"`
+	Tag           string `config:"tag"`
+}
+
+func defaultConfig() config {
+	return config{
+		ReloadInterval: time.Minute,
+		IgnoreMissing:  true,
+		FailOnError:    true,
+		Mode:           "rename",
+	}
+}
@@ -0,0 +1,187 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ecs_translate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	"github.com/elastic/beats/v7/libbeat/processors/convert"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const procName = "ecs_translate"
+
+func init() {
+	processors.RegisterPlugin(procName, New)
+}
+
+// processor applies the renames and conversions of a mapping file with a
+// convert processor, which is replaced when the mapping file changes.
+type processor struct {
+	config
+	log *logp.Logger
+
+	mu      sync.RWMutex
+	mapping *mapping
+	done    chan struct{}
+	wg      sync.WaitGroup
+	closed  sync.Once
+}
+
+// mapping is a loaded mapping file.
+type mapping struct {
+	convert beat.Processor
+	modTime time.Time
+	size    int64
+}
+
+// New constructs a new ecs_translate processor.
+func New(cfg *conf.C, log *logp.Logger) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("fail to unpack the %v processor configuration: %w", procName, err)
+	}
+
+	log = log.Named(procName)
+	if c.Tag != "" {
+		log = log.With("instance_id", c.Tag)
+	}
+
+	p := &processor{config: c, log: log, done: make(chan struct{})}
+	m, err := p.loadMapping()
+	if err != nil {
+		return nil, err
+	}
+	p.mapping = m
+
+	if c.ReloadInterval > 0 {
+		p.wg.Add(1)
+		go p.watch()
+	}
+	return p, nil
+}
+
+// loadMapping reads the mapping file and builds the convert processor
+// applying it.
+func (p *processor) loadMapping() (*mapping, error) {
+	info, err := os.Stat(p.MappingFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mapping file: %w", err)
+	}
+	b, err := os.ReadFile(p.MappingFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+	// JSON is a subset of YAML, so both formats are read as YAML.
+	file, err := conf.NewConfigWithYAML(b, p.MappingFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file %v: %w", p.MappingFile, err)
+	}
+
+	cfg, err := conf.NewConfigFrom(map[string]interface{}{
+		"ignore_missing": p.IgnoreMissing,
+		"fail_on_error":  p.FailOnError,
+		"mode":           p.Mode,
+		"tag":            p.Tag,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Merge(file); err != nil {
+		return nil, fmt.Errorf("failed to merge mapping file %v: %w", p.MappingFile, err)
+	}
+	c, err := convert.New(cfg, p.log)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mapping file %v: %w", p.MappingFile, err)
+	}
+
+	return &mapping{convert: c, modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// watch reloads the mapping when its file changes, until the processor is
+// closed.
+func (p *processor) watch() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			if err := p.reload(); err != nil {
+				p.log.Warnw("Failed to reload mapping file, keeping the previous mapping.", "mapping_file", p.MappingFile, "error", err)
+			}
+		}
+	}
+}
+
+// reload reloads the mapping if the modification time or the size of its
+// file changed. A mapping that fails to load is retried on the next call.
+func (p *processor) reload() error {
+	info, err := os.Stat(p.MappingFile)
+	if err != nil {
+		return err
+	}
+
+	p.mu.RLock()
+	current := p.mapping
+	p.mu.RUnlock()
+	if info.ModTime().Equal(current.modTime) && info.Size() == current.size {
+		return nil
+	}
+
+	m, err := p.loadMapping()
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.mapping = m
+	p.mu.Unlock()
+	p.log.Infow("Reloaded mapping file.", "mapping_file", p.MappingFile)
+	return nil
+}
+
+func (p *processor) String() string {
+	json, _ := json.Marshal(p.config)
+	return procName + "=" + string(json)
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	p.mu.RLock()
+	m := p.mapping
+	p.mu.RUnlock()
+
+	return m.convert.Run(event)
+}
+
+func (p *processor) Close() error {
+	p.closed.Do(func() {
+		close(p.done)
+		p.wg.Wait()
+	})
+	return nil
+}
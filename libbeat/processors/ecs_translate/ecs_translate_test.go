@@ -0,0 +1,135 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ecs_translate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func newTestProcessor(t *testing.T, cfg mapstr.M) *processor {
+	t.Helper()
+	c, err := conf.NewConfigFrom(cfg)
+	require.NoError(t, err)
+	p, err := New(c, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, processors.Close(p)) })
+	return p.(*processor)
+}
+
+func TestECSTranslate(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{"mapping_file": "testdata/firewall.yml"})
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{
+			"fw": mapstr.M{"src": "10.0.0.1", "spt": "51234", "dst": "10.0.0.2", "dpt": "443"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, mapstr.M{
+			"fw":          mapstr.M{},
+			"source":      mapstr.M{"ip": "10.0.0.1", "port": int64(51234)},
+			"destination": mapstr.M{"ip": "10.0.0.2", "port": int64(443)},
+		}, event.Fields, "event.action is missing and ignored")
+	})
+
+	t.Run("json overrides mode", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{"mapping_file": "testdata/firewall.json"})
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"fw": mapstr.M{"src": "10.0.0.1", "spt": "51234"}}})
+		require.NoError(t, err)
+		assert.Equal(t, mapstr.M{
+			"fw":     mapstr.M{"src": "10.0.0.1", "spt": "51234"},
+			"source": mapstr.M{"ip": "10.0.0.1", "port": int64(51234)},
+		}, event.Fields)
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{"mapping_file": "testdata/firewall.yml", "ignore_missing": false})
+		_, err := p.Run(&beat.Event{Fields: mapstr.M{"fw": mapstr.M{"src": "10.0.0.1"}}})
+		assert.ErrorContains(t, err, "field [fw.spt] is missing")
+	})
+
+	t.Run("conversion failure", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{"mapping_file": "testdata/firewall.yml"})
+		fields := mapstr.M{"fw": mapstr.M{"src": "10.0.0.1", "spt": "http"}}
+		event, err := p.Run(&beat.Event{Fields: fields.Clone()})
+		assert.ErrorContains(t, err, "unable to convert value [http]")
+		assert.Equal(t, fields, event.Fields)
+	})
+}
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.yml")
+	writeMapping(t, path, "fields: [{from: msg, to: message}]")
+
+	p := newTestProcessor(t, mapstr.M{"mapping_file": path, "reload_interval": 0})
+	run := func() mapstr.M {
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"msg": "hello"}})
+		require.NoError(t, err)
+		return event.Fields
+	}
+	assert.Equal(t, mapstr.M{"message": "hello"}, run())
+
+	// An unchanged file is not reloaded.
+	m := p.mapping
+	require.NoError(t, p.reload())
+	assert.Same(t, m, p.mapping)
+
+	// An invalid mapping keeps the previous mapping.
+	writeMapping(t, path, "fields: [{to: message}]")
+	assert.ErrorContains(t, p.reload(), "invalid mapping file")
+	assert.Equal(t, mapstr.M{"message": "hello"}, run())
+
+	writeMapping(t, path, "fields: [{from: msg, to: event.original}]")
+	require.NoError(t, p.reload())
+	assert.Equal(t, mapstr.M{"event": mapstr.M{"original": "hello"}}, run())
+}
+
+func TestConfig(t *testing.T) {
+	for _, tc := range []struct {
+		cfg     mapstr.M
+		wantErr string
+	}{
+		{cfg: mapstr.M{}, wantErr: "string value is not set accessing 'mapping_file'"},
+		{cfg: mapstr.M{"mapping_file": "testdata/missing.yml"}, wantErr: "failed to open mapping file"},
+		{cfg: mapstr.M{"mapping_file": "testdata/firewall.yml", "mode": "move"}, wantErr: "invalid mode"},
+	} {
+		c, err := conf.NewConfigFrom(tc.cfg)
+		require.NoError(t, err)
+		_, err = New(c, logptest.NewTestingLogger(t, ""))
+		assert.ErrorContains(t, err, tc.wantErr, tc.cfg)
+	}
+}
+
+// writeMapping writes a mapping file with a new modification time, as
+// the file system may not have a fine enough resolution.
+func writeMapping(t *testing.T, path, mapping string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(mapping), 0o644))
+	mtime := time.Now().Add(time.Duration(len(mapping)) * time.Second)
+	require.NoError(t, os.Chtimes(path, mtime, mtime))
+}
@@ -0,0 +1,50 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import "time"
+
+type config struct {
+	Database       string        `config:"database" validate:"required"` // Path to the MMDB file.
+	Fields         []fromTo      `config:"fields"`                       // IP fields and the ECS objects enriched with their location.
+	ReloadInterval time.Duration `config:"reload_interval" validate:"min=0"`
+	IgnoreMissing  bool          `config:"ignore_missing"`
+	IgnoreFailure  bool          `config:"ignore_failure"`
+	ID             string        `config:"id"`
+}
+
+// fromTo maps an IP field to the ECS object, like source, under which its
+// geo and as fields are written.
+type fromTo struct {
+	From string `config:"from" validate:"required"`
+	To   string `config:"to" validate:"required"`
+}
+
+// defaultFields are used when no fields are set. They are not part of the
+// default config as configured lists are merged with the default lists.
+var defaultFields = []fromTo{
+	{From: "source.ip", To: "source"},
+	{From: "destination.ip", To: "destination"},
+}
+
+func defaultConfig() config {
+	return config{
+		ReloadInterval: time.Minute,
+		IgnoreMissing:  true,
+	}
+}
@@ -0,0 +1,238 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const procName = "geoip"
+
+func init() {
+	processors.RegisterPlugin(procName, New)
+}
+
+type processor struct {
+	config
+	log *logp.Logger
+
+	mu     sync.RWMutex
+	db     *database
+	done   chan struct{}
+	wg     sync.WaitGroup
+	closed sync.Once
+}
+
+// database is an opened MMDB file.
+type database struct {
+	reader  *maxminddb.Reader
+	ipinfo  bool // The database uses the IPinfo schema instead of the MaxMind schema.
+	modTime time.Time
+	size    int64
+}
+
+// New constructs a new geoip processor.
+func New(cfg *conf.C, log *logp.Logger) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("fail to unpack the %v processor configuration: %w", procName, err)
+	}
+	if len(c.Fields) == 0 {
+		c.Fields = defaultFields
+	}
+
+	log = log.Named(procName)
+	if c.ID != "" {
+		log = log.With("instance_id", c.ID)
+	}
+
+	db, err := openDatabase(c.Database)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &processor{config: c, log: log, db: db, done: make(chan struct{})}
+	if c.ReloadInterval > 0 {
+		p.wg.Add(1)
+		go p.watch()
+	}
+	return p, nil
+}
+
+// openDatabase reads an MMDB file into memory, so that the file can be
+// replaced while it is in use.
+func openDatabase(path string) (*database, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database: %w", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GeoIP database: %w", err)
+	}
+	reader, err := maxminddb.FromBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database %v: %w", path, err)
+	}
+	return &database{
+		reader:  reader,
+		ipinfo:  strings.Contains(strings.ToLower(reader.Metadata.DatabaseType), "ipinfo"),
+		modTime: info.ModTime(),
+		size:    info.Size(),
+	}, nil
+}
+
+// watch reloads the database when its file changes, until the processor
+// is closed.
+func (p *processor) watch() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			if err := p.reload(); err != nil {
+				p.log.Warnw("Failed to reload GeoIP database, keeping the previous version.", "database", p.Database, "error", err)
+			}
+		}
+	}
+}
+
+// reload reopens the database if the modification time or the size of its
+// file changed. A database that fails to open is retried on the next call.
+func (p *processor) reload() error {
+	info, err := os.Stat(p.Database)
+	if err != nil {
+		return err
+	}
+
+	p.mu.RLock()
+	current := p.db
+	p.mu.RUnlock()
+	if info.ModTime().Equal(current.modTime) && info.Size() == current.size {
+		return nil
+	}
+
+	db, err := openDatabase(p.Database)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.db = db
+	p.mu.Unlock()
+	p.log.Infow("Reloaded GeoIP database.", "database", p.Database, "type", db.reader.Metadata.DatabaseType)
+	return nil
+}
+
+func (p *processor) String() string {
+	json, _ := json.Marshal(p.config)
+	return procName + "=" + string(json)
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	p.mu.RLock()
+	db := p.db
+	p.mu.RUnlock()
+
+	for _, f := range p.Fields {
+		v, err := event.GetValue(f.From)
+		if err != nil {
+			if p.IgnoreMissing || p.IgnoreFailure {
+				continue
+			}
+			return event, fmt.Errorf("%v source field [%v] not found: %w", procName, f.From, err)
+		}
+
+		ip := toIP(v)
+		if ip == nil {
+			if p.IgnoreFailure {
+				continue
+			}
+			return event, fmt.Errorf("%v source field [%v] is not an IP address", procName, f.From)
+		}
+
+		fields, err := db.lookup(ip)
+		if err != nil {
+			if p.IgnoreFailure {
+				continue
+			}
+			return event, fmt.Errorf("failed to look up %v in GeoIP database: %w", ip, err)
+		}
+		for k, v := range fields {
+			if _, err := event.PutValue(f.To+"."+k, v); err != nil && !p.IgnoreFailure {
+				return event, fmt.Errorf("failed to write %v fields to target field [%v]: %w", k, f.To, err)
+			}
+		}
+	}
+	return event, nil
+}
+
+// lookup returns the geo and as fields of an IP address, or nil if the
+// database has no record for the address.
+func (db *database) lookup(ip net.IP) (mapstr.M, error) {
+	if db.ipinfo {
+		var r ipinfoRecord
+		_, ok, err := db.reader.LookupNetwork(ip, &r)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return r.fields(), nil
+	}
+
+	var r maxmindRecord
+	_, ok, err := db.reader.LookupNetwork(ip, &r)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return r.fields(), nil
+}
+
+func toIP(v interface{}) net.IP {
+	switch v := v.(type) {
+	case string:
+		return net.ParseIP(v)
+	case net.IP:
+		return v
+	}
+	return nil
+}
+
+func (p *processor) Close() error {
+	p.closed.Do(func() {
+		close(p.done)
+		p.wg.Wait()
+	})
+	return nil
+}
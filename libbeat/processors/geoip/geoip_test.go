@@ -0,0 +1,201 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func newTestProcessor(t *testing.T, cfg mapstr.M) *processor {
+	t.Helper()
+	c, err := conf.NewConfigFrom(cfg)
+	require.NoError(t, err)
+	p, err := New(c, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, processors.Close(p)) })
+	return p.(*processor)
+}
+
+func TestGeoIP(t *testing.T) {
+	london := mapstr.M{
+		"city_name":        "London",
+		"continent_code":   "EU",
+		"continent_name":   "Europe",
+		"country_iso_code": "GB",
+		"country_name":     "United Kingdom",
+		"location":         mapstr.M{"lat": 51.5142, "lon": -0.0931},
+		"region_iso_code":  "GB-ENG",
+		"region_name":      "England",
+		"timezone":         "Europe/London",
+	}
+
+	t.Run("city", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{"database": "testdata/GeoLite2-City.mmdb"})
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{
+			"source":      mapstr.M{"ip": "81.2.69.142"},
+			"destination": mapstr.M{"ip": "10.0.0.1"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, mapstr.M{
+			"source":      mapstr.M{"ip": "81.2.69.142", "geo": london},
+			"destination": mapstr.M{"ip": "10.0.0.1"},
+		}, event.Fields)
+	})
+
+	t.Run("asn", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{
+			"database": "testdata/GeoLite2-ASN.mmdb",
+			"fields":   []mapstr.M{{"from": "client.ip", "to": "client"}},
+		})
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"client": mapstr.M{"ip": "89.160.20.128"}}})
+		require.NoError(t, err)
+		assert.Equal(t, mapstr.M{
+			"number":       uint(29518),
+			"organization": mapstr.M{"name": "Bredband2 AB"},
+		}, event.Fields["client"].(mapstr.M)["as"])
+		assert.Equal(t, []fromTo{{From: "client.ip", To: "client"}}, p.Fields)
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{"database": "testdata/GeoLite2-City.mmdb", "ignore_missing": false})
+		_, err := p.Run(&beat.Event{Fields: mapstr.M{"source": mapstr.M{"ip": "81.2.69.142"}}})
+		assert.ErrorContains(t, err, "source field [destination.ip] not found")
+	})
+
+	t.Run("invalid ip", func(t *testing.T) {
+		p := newTestProcessor(t, mapstr.M{"database": "testdata/GeoLite2-City.mmdb"})
+		_, err := p.Run(&beat.Event{Fields: mapstr.M{"source": mapstr.M{"ip": "not-an-ip"}}})
+		assert.ErrorContains(t, err, "source field [source.ip] is not an IP address")
+
+		p = newTestProcessor(t, mapstr.M{"database": "testdata/GeoLite2-City.mmdb", "ignore_failure": true})
+		_, err = p.Run(&beat.Event{Fields: mapstr.M{"source": mapstr.M{"ip": "not-an-ip"}}})
+		assert.NoError(t, err)
+	})
+}
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "geoip.mmdb")
+	copyFile(t, "testdata/GeoLite2-Country.mmdb", path)
+
+	p := newTestProcessor(t, mapstr.M{"database": path, "reload_interval": 0})
+	run := func() mapstr.M {
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"source": mapstr.M{"ip": "81.2.69.142"}}})
+		require.NoError(t, err)
+		geo, _ := event.GetValue("source.geo")
+		return geo.(mapstr.M)
+	}
+	assert.NotContains(t, run(), "city_name")
+
+	// An unchanged file is not reopened.
+	db := p.db
+	require.NoError(t, p.reload())
+	assert.Same(t, db, p.db)
+
+	// A corrupt file keeps the previous database.
+	require.NoError(t, os.WriteFile(path, []byte("corrupt"), 0o644))
+	assert.Error(t, p.reload())
+	assert.NotContains(t, run(), "city_name")
+
+	copyFile(t, "testdata/GeoLite2-City.mmdb", path)
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	require.NoError(t, p.reload())
+	assert.Equal(t, "London", run()["city_name"])
+}
+
+func TestIPinfoRecord(t *testing.T) {
+	// IPinfo location database.
+	r := ipinfoRecord{
+		City:       "Mountain View",
+		Region:     "California",
+		RegionCode: "CA",
+		Country:    "US",
+		Lat:        "37.40599",
+		Lng:        "-122.078514",
+		PostalCode: "94043",
+		Timezone:   "America/Los_Angeles",
+	}
+	assert.Equal(t, mapstr.M{"geo": mapstr.M{
+		"city_name":        "Mountain View",
+		"country_iso_code": "US",
+		"region_iso_code":  "US-CA",
+		"region_name":      "California",
+		"location":         mapstr.M{"lat": 37.40599, "lon": -122.078514},
+		"postal_code":      "94043",
+		"timezone":         "America/Los_Angeles",
+	}}, r.fields())
+
+	// IPinfo lite database, where country and continent are names.
+	r = ipinfoRecord{
+		ASN:           "AS15169",
+		ASName:        "Google LLC",
+		Country:       "United States",
+		CountryCode:   "US",
+		Continent:     "North America",
+		ContinentCode: "NA",
+	}
+	assert.Equal(t, mapstr.M{
+		"geo": mapstr.M{
+			"continent_code":   "NA",
+			"continent_name":   "North America",
+			"country_iso_code": "US",
+			"country_name":     "United States",
+		},
+		"as": mapstr.M{"number": uint(15169), "organization": mapstr.M{"name": "Google LLC"}},
+	}, r.fields())
+}
+
+func TestConfig(t *testing.T) {
+	for _, tc := range []struct {
+		cfg     mapstr.M
+		wantErr string
+	}{
+		{cfg: mapstr.M{"database": "testdata/GeoLite2-City.mmdb"}},
+		{cfg: mapstr.M{}, wantErr: "string value is not set accessing 'database'"},
+		{cfg: mapstr.M{"database": "testdata/GeoLite2-City.mmdb", "fields": []mapstr.M{{"from": "source.ip"}}}, wantErr: "string value is not set accessing 'fields.0.to'"},
+		{cfg: mapstr.M{"database": "testdata/missing.mmdb"}, wantErr: "failed to open GeoIP database"},
+	} {
+		c, err := conf.NewConfigFrom(tc.cfg)
+		require.NoError(t, err)
+		p, err := New(c, logptest.NewTestingLogger(t, ""))
+		if tc.wantErr == "" {
+			assert.NoError(t, err, tc.cfg)
+			assert.NoError(t, processors.Close(p))
+		} else {
+			assert.ErrorContains(t, err, tc.wantErr, tc.cfg)
+		}
+	}
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+	b, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, b, 0o644))
+}
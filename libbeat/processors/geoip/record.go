@@ -0,0 +1,179 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// maxmindRecord is a record of the MaxMind GeoIP2 and GeoLite2 City,
+// Country and ASN databases.
+type maxmindRecord struct {
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Continent struct {
+		Code  string            `maxminddb:"code"`
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"continent"`
+	Country struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"country"`
+	Subdivisions []struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"subdivisions"`
+	Location struct {
+		Latitude  *float64 `maxminddb:"latitude"`
+		Longitude *float64 `maxminddb:"longitude"`
+		TimeZone  string   `maxminddb:"time_zone"`
+	} `maxminddb:"location"`
+	Postal struct {
+		Code string `maxminddb:"code"`
+	} `maxminddb:"postal"`
+	ASNumber       uint   `maxminddb:"autonomous_system_number"`
+	ASOrganization string `maxminddb:"autonomous_system_organization"`
+}
+
+func (r *maxmindRecord) fields() mapstr.M {
+	geo := mapstr.M{}
+	putString(geo, "city_name", r.City.Names["en"])
+	putString(geo, "continent_code", r.Continent.Code)
+	putString(geo, "continent_name", r.Continent.Names["en"])
+	putString(geo, "country_iso_code", r.Country.ISOCode)
+	putString(geo, "country_name", r.Country.Names["en"])
+	if len(r.Subdivisions) > 0 {
+		// ECS region ISO codes are prefixed with the country ISO code.
+		if r.Country.ISOCode != "" && r.Subdivisions[0].ISOCode != "" {
+			geo["region_iso_code"] = r.Country.ISOCode + "-" + r.Subdivisions[0].ISOCode
+		}
+		putString(geo, "region_name", r.Subdivisions[0].Names["en"])
+	}
+	putString(geo, "postal_code", r.Postal.Code)
+	putString(geo, "timezone", r.Location.TimeZone)
+	if r.Location.Latitude != nil && r.Location.Longitude != nil {
+		geo["location"] = mapstr.M{"lat": *r.Location.Latitude, "lon": *r.Location.Longitude}
+	}
+
+	as := mapstr.M{}
+	if r.ASNumber != 0 {
+		as["number"] = r.ASNumber
+	}
+	if r.ASOrganization != "" {
+		as["organization"] = mapstr.M{"name": r.ASOrganization}
+	}
+	return geoAndAS(geo, as)
+}
+
+// ipinfoRecord is a record of the IPinfo databases. Depending on the
+// database, country and continent hold either the ISO code or, when
+// country_code and continent_code are present, the name.
+type ipinfoRecord struct {
+	City          string      `maxminddb:"city"`
+	Region        string      `maxminddb:"region"`
+	RegionCode    string      `maxminddb:"region_code"`
+	Country       string      `maxminddb:"country"`
+	CountryCode   string      `maxminddb:"country_code"`
+	CountryName   string      `maxminddb:"country_name"`
+	Continent     string      `maxminddb:"continent"`
+	ContinentCode string      `maxminddb:"continent_code"`
+	ContinentName string      `maxminddb:"continent_name"`
+	Lat           interface{} `maxminddb:"lat"`
+	Lng           interface{} `maxminddb:"lng"`
+	PostalCode    string      `maxminddb:"postal_code"`
+	Timezone      string      `maxminddb:"timezone"`
+	ASN           string      `maxminddb:"asn"`
+	ASName        string      `maxminddb:"as_name"`
+	Name          string      `maxminddb:"name"`
+}
+
+func (r *ipinfoRecord) fields() mapstr.M {
+	countryCode, countryName := r.Country, r.CountryName
+	if r.CountryCode != "" {
+		countryCode, countryName = r.CountryCode, r.Country
+	}
+	continentCode, continentName := r.Continent, r.ContinentName
+	if r.ContinentCode != "" {
+		continentCode, continentName = r.ContinentCode, r.Continent
+	}
+
+	geo := mapstr.M{}
+	putString(geo, "city_name", r.City)
+	putString(geo, "continent_code", continentCode)
+	putString(geo, "continent_name", continentName)
+	putString(geo, "country_iso_code", countryCode)
+	putString(geo, "country_name", countryName)
+	if countryCode != "" && r.RegionCode != "" {
+		geo["region_iso_code"] = countryCode + "-" + r.RegionCode
+	}
+	putString(geo, "region_name", r.Region)
+	putString(geo, "postal_code", r.PostalCode)
+	putString(geo, "timezone", r.Timezone)
+	lat, latOK := toFloat(r.Lat)
+	lon, lonOK := toFloat(r.Lng)
+	if latOK && lonOK {
+		geo["location"] = mapstr.M{"lat": lat, "lon": lon}
+	}
+
+	as := mapstr.M{}
+	if n, err := strconv.ParseUint(strings.TrimPrefix(r.ASN, "AS"), 10, 32); err == nil {
+		as["number"] = uint(n)
+	}
+	// The ASN database names the organization name, the other databases as_name.
+	if name := r.ASName; name != "" {
+		as["organization"] = mapstr.M{"name": name}
+	} else if r.Name != "" && r.ASN != "" {
+		as["organization"] = mapstr.M{"name": r.Name}
+	}
+	return geoAndAS(geo, as)
+}
+
+func geoAndAS(geo, as mapstr.M) mapstr.M {
+	fields := mapstr.M{}
+	if len(geo) > 0 {
+		fields["geo"] = geo
+	}
+	if len(as) > 0 {
+		fields["as"] = as
+	}
+	return fields
+}
+
+func putString(m mapstr.M, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
+
+// toFloat converts IPinfo coordinates, stored as strings or doubles.
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -178,6 +178,62 @@ func (procs *Processors) Run(event *beat.Event) (*beat.Event, error) {
 	return event, nil
 }
 
+// RunBatch is equivalent to calling Run on each of the events, but lets
+// processors implementing beat.BatchProcessor process the events together.
+func (procs *Processors) RunBatch(events []*beat.Event) ([]*beat.Event, []error) {
+	results := append([]*beat.Event(nil), events...)
+	errs := make([]error, len(events))
+
+	// pending holds the indexes of the events not yet dropped or failed.
+	pending := make([]int, len(events))
+	for i := range pending {
+		pending[i] = i
+	}
+
+	in := make([]*beat.Event, 0, len(events))
+	for _, p := range procs.List {
+		if len(pending) == 0 {
+			break
+		}
+
+		in = in[:0]
+		for _, i := range pending {
+			in = append(in, results[i])
+		}
+		out, outErrs := RunBatch(p, in)
+
+		next := pending[:0]
+		for j, i := range pending {
+			results[i] = out[j]
+			switch {
+			case outErrs[j] != nil:
+				errs[i] = fmt.Errorf("failed applying processor %v: %w", p, outErrs[j])
+			case out[j] != nil:
+				next = append(next, i)
+			}
+		}
+		pending = next
+	}
+	return results, errs
+}
+
+// RunBatch runs p on the given events. Processors implementing
+// beat.BatchProcessor get all events at once, all others are run on each
+// event in turn. The returned events and errors match the input events by
+// index.
+func RunBatch(p beat.Processor, events []*beat.Event) ([]*beat.Event, []error) {
+	if batch, ok := p.(beat.BatchProcessor); ok {
+		return batch.RunBatch(events)
+	}
+
+	results := make([]*beat.Event, len(events))
+	errs := make([]error, len(events))
+	for i, event := range events {
+		results[i], errs[i] = p.Run(event)
+	}
+	return results, errs
+}
+
 func (procs Processors) String() string {
 	var s []string
 	for _, p := range procs.List {
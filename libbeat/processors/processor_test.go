@@ -423,6 +423,45 @@ func TestDropEvent(t *testing.T) {
 	assert.Nil(t, processedEvent)
 }
 
+func TestRunBatch(t *testing.T) {
+	yml := []map[string]interface{}{
+		{
+			"drop_event": map[string]interface{}{
+				"when": map[string]interface{}{
+					"equals": map[string]interface{}{"drop": true},
+				},
+			},
+		},
+		{
+			"add_fields": map[string]interface{}{
+				"target": "",
+				"fields": map[string]interface{}{"added": true},
+				"when": map[string]interface{}{
+					"equals": map[string]interface{}{"add": true},
+				},
+			},
+		},
+	}
+
+	processors := GetProcessors(t, yml)
+
+	events := []*beat.Event{
+		{Fields: mapstr.M{"n": 1, "add": true}},
+		{Fields: mapstr.M{"n": 2, "drop": true}},
+		{Fields: mapstr.M{"n": 3}},
+	}
+	results, errs := processors.RunBatch(events)
+	require.Len(t, results, len(events))
+	require.Len(t, errs, len(events))
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, mapstr.M{"n": 1, "add": true, "added": true}, results[0].Fields)
+	assert.Nil(t, results[1])
+	assert.Equal(t, mapstr.M{"n": 3}, results[2].Fields)
+}
+
 func TestEmptyCondition(t *testing.T) {
 	logp.TestingSetup()
 
@@ -23,14 +23,37 @@ import (
 	cfg "github.com/elastic/elastic-agent-libs/config"
 )
 
+const (
+	// actionDrop drops the events exceeding the rate limit.
+	actionDrop = "drop"
+	// actionTag adds the rateLimitedTag to the events exceeding the rate limit.
+	actionTag = "tag"
+
+	rateLimitedTag = "rate_limited"
+)
+
 // config for rate limit processor.
 type config struct {
 	Limit     rate          `config:"limit" validate:"required"`
 	Fields    []string      `config:"fields"`
 	Algorithm cfg.Namespace `config:"algorithm"`
+	Action    string        `config:"action"`
+}
+
+func (c *config) Validate() error {
+	switch c.Action {
+	case "", actionDrop, actionTag:
+		return nil
+	default:
+		return fmt.Errorf("invalid action '%v', must be one of %v or %v", c.Action, actionDrop, actionTag)
+	}
 }
 
 func (c *config) setDefaults() error {
+	if c.Action == "" {
+		c.Action = actionDrop
+	}
+
 	if c.Algorithm.Name() == "" {
 		cfg, err := cfg.NewConfigFrom(map[string]interface{}{
 			"token_bucket": map[string]interface{}{},
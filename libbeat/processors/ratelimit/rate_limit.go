@@ -47,6 +47,7 @@ func init() {
 
 type metrics struct {
 	Dropped *monitoring.Int
+	Tagged  *monitoring.Int
 }
 
 type rateLimit struct {
@@ -90,6 +91,7 @@ func new(cfg *c.C, log *logp.Logger) (beat.Processor, error) {
 		logger:    log,
 		metrics: metrics{
 			Dropped: monitoring.NewInt(reg, "dropped"),
+			Tagged:  monitoring.NewInt(reg, "tagged"),
 		},
 	}
 
@@ -99,7 +101,8 @@ func new(cfg *c.C, log *logp.Logger) (beat.Processor, error) {
 }
 
 // Run applies the configured rate limit to the given event. If the event is within the
-// configured rate limit, it is returned as-is. If not, nil is returned.
+// configured rate limit, it is returned as-is. If not, nil is returned, or the event
+// is returned with the rate_limited tag if the action is tag.
 func (p *rateLimit) Run(event *beat.Event) (*beat.Event, error) {
 	key, err := p.makeKey(event)
 	if err != nil {
@@ -110,6 +113,14 @@ func (p *rateLimit) Run(event *beat.Event) (*beat.Event, error) {
 		return event, nil
 	}
 
+	if p.config.Action == actionTag {
+		if err := mapstr.AddTags(event.Fields, []string{rateLimitedTag}); err != nil {
+			return event, fmt.Errorf("could not tag rate limited event: %w", err)
+		}
+		p.metrics.Tagged.Inc()
+		return event, nil
+	}
+
 	p.logger.Debugf("event [%v] dropped by rate_limit processor", event)
 	p.metrics.Dropped.Inc()
 	return nil, nil
@@ -117,8 +128,8 @@ func (p *rateLimit) Run(event *beat.Event) (*beat.Event, error) {
 
 func (p *rateLimit) String() string {
 	return fmt.Sprintf(
-		"%v=[limit=[%v],fields=[%v],algorithm=[%v]]",
-		processorName, p.config.Limit, p.config.Fields, p.config.Algorithm.Name(),
+		"%v=[limit=[%v],fields=[%v],algorithm=[%v],action=[%v]]",
+		processorName, p.config.Limit, p.config.Fields, p.config.Algorithm.Name(), p.config.Action,
 	)
 }
 
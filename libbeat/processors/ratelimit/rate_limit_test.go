@@ -40,6 +40,13 @@ func TestNew(t *testing.T) {
 			mapstr.M{},
 			"",
 		},
+		"invalid_action": {
+			mapstr.M{
+				"limit":  "1/s",
+				"action": "delay",
+			},
+			"invalid action 'delay', must be one of drop or tag",
+		},
 		"unknown_algo": {
 			mapstr.M{
 				"algorithm": mapstr.M{
@@ -150,6 +157,31 @@ func TestRateLimit(t *testing.T) {
 			inEvents:  inEvents,
 			outEvents: inEvents,
 		},
+		"with_token_bucket_burst": {
+			config: mapstr.M{
+				"limit": "1/m",
+				"algorithm": mapstr.M{
+					"token_bucket": mapstr.M{
+						"burst": 4,
+					},
+				},
+			},
+			inEvents:  inEvents,
+			outEvents: inEvents[0:4],
+		},
+		"with_tag_action": {
+			config: mapstr.M{
+				"limit":  "2/m",
+				"action": "tag",
+			},
+			inEvents: inEvents[0:4],
+			outEvents: []beat.Event{
+				inEvents[0],
+				inEvents[1],
+				withField(inEvents[2], "tags", []string{"rate_limited"}),
+				withField(inEvents[3], "tags", []string{"rate_limited"}),
+			},
+		},
 	}
 
 	for name, test := range cases {
@@ -179,6 +211,39 @@ func TestRateLimit(t *testing.T) {
 	}
 }
 
+func TestTokenBucketBurst(t *testing.T) {
+	p, err := new(conf.MustNewConfigFrom(mapstr.M{
+		"limit": "1/s",
+		"algorithm": mapstr.M{
+			"token_bucket": mapstr.M{
+				"burst": 3,
+			},
+		},
+	}), logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	fakeClock := clockwork.NewFakeClock()
+	p.(*rateLimit).setClock(fakeClock)
+
+	allowed := func() int {
+		n := 0
+		for i := 0; i < 10; i++ {
+			o, err := p.Run(&beat.Event{Fields: mapstr.M{"event_number": i}})
+			require.NoError(t, err)
+			if o != nil {
+				n++
+			}
+		}
+		return n
+	}
+
+	require.Equal(t, 3, allowed())
+
+	// Tokens do not accumulate beyond the burst while no events are received.
+	fakeClock.Advance(time.Hour)
+	require.Equal(t, 3, allowed())
+}
+
 func TestAllocs(t *testing.T) {
 	p, err := new(conf.MustNewConfigFrom(mapstr.M{
 		"limit": "100/s",
@@ -19,6 +19,7 @@ package ratelimit
 
 import (
 	"fmt"
+	"math"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -53,14 +54,16 @@ func (b *bucket) withdraw() bool {
 	return true
 }
 
-func (b *bucket) replenish(rate rate, clock clockwork.Clock) float64 {
+// replenish adds the tokens accumulated at the given rate since the last
+// replenishment, up to depth tokens.
+func (b *bucket) replenish(rate rate, depth float64, clock clockwork.Clock) float64 {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
 	secsSinceLastReplenish := clock.Now().Sub(b.lastReplenish).Seconds()
 	tokensToReplenish := secsSinceLastReplenish * rate.valuePerSecond()
 
-	b.tokens += tokensToReplenish
+	b.tokens = math.Min(b.tokens+tokensToReplenish, depth)
 	b.lastReplenish = clock.Now()
 	return b.tokens
 }
@@ -93,6 +96,10 @@ type tokenBucketGCConfig struct {
 type tokenBucketConfig struct {
 	BurstMultiplier float64 `config:"burst_multiplier"`
 
+	// Burst is the number of events allowed at once after a quiet
+	// period. If set, it takes precedence over BurstMultiplier.
+	Burst uint `config:"burst"`
+
 	// GC governs when completely filled token buckets must be deleted
 	// to free up memory. GC is performed when _any_ of the GC conditions
 	// below are met. After each GC, counters corresponding to _each_ of
@@ -112,9 +119,14 @@ func newTokenBucket(config algoConfig) (algorithm, error) {
 		return nil, fmt.Errorf("could not unpack token_bucket algorithm configuration: %w", err)
 	}
 
+	depth := config.limit.value * cfg.BurstMultiplier
+	if cfg.Burst > 0 {
+		depth = float64(cfg.Burst)
+	}
+
 	return &tokenBucket{
 		limit:   config.limit,
-		depth:   config.limit.value * cfg.BurstMultiplier,
+		depth:   depth,
 		buckets: sync.Map{},
 		gc: struct {
 			thresholds tokenBucketGCConfig
@@ -152,7 +164,7 @@ func (t *tokenBucket) getBucket(key uint64) *bucket {
 	if exists {
 		//nolint:errcheck // ignore
 		b := v.(*bucket)
-		b.replenish(t.limit, t.clock)
+		b.replenish(t.limit, t.depth, t.clock)
 		return b
 	}
 
@@ -188,7 +200,7 @@ func (t *tokenBucket) runGC() {
 			//nolint:errcheck // ignore
 			b := v.(*bucket)
 
-			tokens := b.replenish(t.limit, t.clock)
+			tokens := b.replenish(t.limit, t.depth, t.clock)
 			if tokens >= t.depth {
 				toDelete = append(toDelete, key)
 			}
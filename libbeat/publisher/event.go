@@ -18,6 +18,8 @@
 package publisher
 
 import (
+	"sync/atomic"
+
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
@@ -65,6 +67,22 @@ type Event struct {
 	// to free the unencoded data. The updated event will be provided to
 	// output workers when calling Publish.
 	EncodedEvent interface{}
+
+	// If the pipeline sampled the event for tracing, Trace records when
+	// the event passed the stages of the pipeline.
+	Trace *EventTrace
+}
+
+// EventTrace records when a sampled event passed the stages of the
+// publisher pipeline. The times are Unix nanoseconds, as they are set and
+// read from different goroutines.
+type EventTrace struct {
+	// The event was received by the pipeline client.
+	Received atomic.Int64
+	// The event was accepted by the queue.
+	Enqueued atomic.Int64
+	// The event was read from the queue by the output.
+	Dequeued atomic.Int64
 }
 
 // EventFlags provides additional flags/option types  for used with the outputs.
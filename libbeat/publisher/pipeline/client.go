@@ -44,6 +44,7 @@ type client struct {
 	isOpen atomic.Bool // set to false during shutdown, such that no new events will be accepted anymore.
 
 	observer       observer
+	tracer         *eventTracer
 	eventListener  beat.EventListener
 	clientListener beat.ClientListener
 }
@@ -61,8 +62,36 @@ func (c *client) PublishAll(events []beat.Event) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
+	batch, ok := c.processors.(beat.BatchProcessor)
+	if !ok || len(events) < 2 {
+		for _, e := range events {
+			c.publish(e)
+		}
+		return
+	}
+
+	// Run the processors on all events at once, so that batch aware
+	// processors can share work between the events of this call.
+	in := make([]*beat.Event, 0, len(events))
+	traces := make([]*publisher.EventTrace, 0, len(events))
 	for _, e := range events {
-		c.publish(e)
+		c.onNewEvent()
+		trace := c.tracer.sample()
+		if !c.isOpen.Load() {
+			// client is closing down -> report event as dropped
+			c.onDroppedOnPublish(e)
+			continue
+		}
+		in = append(in, &e)
+		traces = append(traces, trace)
+	}
+	if len(in) == 0 {
+		return
+	}
+
+	out, errs := batch.RunBatch(in)
+	for i, event := range out {
+		c.enqueue(*in[i], event, errs[i], traces[i])
 	}
 }
 
@@ -74,12 +103,8 @@ func (c *client) Publish(e beat.Event) {
 }
 
 func (c *client) publish(e beat.Event) {
-	var (
-		event   = &e
-		publish = true
-	)
-
 	c.onNewEvent()
+	trace := c.tracer.sample()
 
 	if !c.isOpen.Load() {
 		// client is closing down -> report event as dropped and return
@@ -87,16 +112,23 @@ func (c *client) publish(e beat.Event) {
 		return
 	}
 
-	if c.processors != nil {
-		var err error
+	if c.processors == nil {
+		c.enqueue(e, &e, nil, trace)
+		return
+	}
+
+	event, err := c.processors.Run(&e)
+	c.enqueue(e, event, err, trace)
+}
 
-		event, err = c.processors.Run(event)
-		publish = event != nil
-		if err != nil {
-			// If we introduce a dead-letter queue, this is where we should
-			// route the event to it.
-			c.logger.Errorf("Failed to publish event: %v", err)
-		}
+// enqueue publishes the result of running the processors on e. A nil event
+// means the processors filtered e out.
+func (c *client) enqueue(e beat.Event, event *beat.Event, err error, trace *publisher.EventTrace) {
+	publish := event != nil
+	if err != nil {
+		// If we introduce a dead-letter queue, this is where we should
+		// route the event to it.
+		c.logger.Errorf("Failed to publish event: %v", err)
 	}
 
 	if event != nil {
@@ -109,10 +141,10 @@ func (c *client) publish(e beat.Event) {
 		return
 	}
 
-	e = *event
 	pubEvent := publisher.Event{
 		Content: e,
 		Flags:   c.eventFlags,
+		Trace:   trace,
 	}
 
 	var published bool
@@ -123,6 +155,9 @@ func (c *client) publish(e beat.Event) {
 	}
 
 	if published {
+		if trace != nil {
+			trace.Enqueued.Store(time.Now().UnixNano())
+		}
 		c.onPublished()
 	} else {
 		c.onDroppedOnPublish(e)
@@ -189,6 +189,63 @@ func TestClient(t *testing.T) {
 		<-done
 		require.Equal(t, expected, received)
 	})
+
+	t.Run("PublishAll runs batch processors once per call", func(t *testing.T) {
+		l := logptest.NewTestingLogger(t, "")
+		q := memqueue.NewQueue(l, nil, memqueue.Settings{
+			Events:        5,
+			MaxGetRequest: 1,
+			FlushTimeout:  time.Millisecond,
+		}, 5, nil)
+
+		p := &testBatchProcessor{}
+		pipeline := makePipeline(t, Settings{
+			WaitClose:     100 * time.Millisecond,
+			WaitCloseMode: WaitOnPipelineClose,
+			Processors:    testProcessorSupporter{Processor: p},
+		}, q)
+		client, err := pipeline.Connect()
+		require.NoError(t, err)
+		defer client.Close()
+
+		var received []beat.Event
+		done := make(chan struct{})
+		go func() {
+			for {
+				batch, err := q.Get(2)
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				assert.NoError(t, err)
+				if batch == nil {
+					continue
+				}
+				for i := 0; i < batch.Count(); i++ {
+					//nolint:errcheck // it always succeeds
+					e := batch.Entry(i).(publisher.Event)
+					received = append(received, e.Content)
+				}
+				batch.Done()
+			}
+			close(done)
+		}()
+
+		client.PublishAll([]beat.Event{
+			{Fields: mapstr.M{"number": 1}},
+			{Fields: mapstr.M{"number": 2, "drop": true}},
+			{Fields: mapstr.M{"number": 3}},
+		})
+
+		require.NoError(t, client.Close(), "failed closing pipeline client")
+		require.NoError(t, pipeline.Close(), "failed closing pipeline")
+		<-done
+
+		assert.Equal(t, 1, p.batches)
+		assert.Equal(t, []beat.Event{
+			{Fields: mapstr.M{"number": 1, "batch": 1}},
+			{Fields: mapstr.M{"number": 3, "batch": 1}},
+		}, received)
+	})
 }
 
 func TestClientWaitClose(t *testing.T) {
@@ -468,6 +525,34 @@ func (p processorList) Run(in *beat.Event) (event *beat.Event, err error) {
 	return in, nil
 }
 
+// testBatchProcessor tags each event with the number of the RunBatch call
+// that processed it and drops events having the drop field set.
+type testBatchProcessor struct {
+	batches int
+}
+
+func (p *testBatchProcessor) String() string {
+	return "testBatchProcessor"
+}
+
+func (p *testBatchProcessor) Run(in *beat.Event) (*beat.Event, error) {
+	events, errs := p.RunBatch([]*beat.Event{in})
+	return events[0], errs[0]
+}
+
+func (p *testBatchProcessor) RunBatch(in []*beat.Event) ([]*beat.Event, []error) {
+	p.batches++
+	out := make([]*beat.Event, len(in))
+	for i, event := range in {
+		if event.Fields["drop"] == true {
+			continue
+		}
+		event.Fields["batch"] = p.batches
+		out[i] = event
+	}
+	return out, make([]error, len(in))
+}
+
 type testProcessorSupporter struct {
 	beat.Processor
 }
@@ -35,6 +35,9 @@ type Config struct {
 
 	// Event queue
 	Queue config.Namespace `config:"queue"`
+
+	// Sampled event tracing, see TracingConfig
+	Tracing *config.C `config:"pipeline.tracing"`
 }
 
 // validateClientConfig checks a ClientConfig can be used with (*Pipeline).ConnectWith.
@@ -34,6 +34,10 @@ type eventConsumer struct {
 	// eventConsumer calls the retryObserver methods eventsRetry and eventsDropped.
 	retryObserver retryObserver
 
+	// tracer traces the sampled events of the batches read from the queue,
+	// nil if tracing is disabled.
+	tracer *eventTracer
+
 	// When the output changes, the new target is sent to the worker routine
 	// on this channel. Clients should call eventConsumer.setTarget().
 	targetChan chan consumerTarget
@@ -74,10 +78,12 @@ type retryRequest struct {
 func newEventConsumer(
 	log *logp.Logger,
 	observer retryObserver,
+	tracer *eventTracer,
 ) *eventConsumer {
 	c := &eventConsumer{
 		logger:        log,
 		retryObserver: observer,
+		tracer:        tracer,
 		queueReader:   makeQueueReader(),
 
 		targetChan: make(chan consumerTarget),
@@ -134,6 +140,7 @@ outerLoop:
 			c.queueReader.req <- queueReaderRequest{
 				queue:      target.queue,
 				retryer:    c,
+				tracer:     c.tracer,
 				batchSize:  target.batchSize,
 				timeToLive: target.timeToLive,
 			}
@@ -69,6 +69,10 @@ type outputController struct {
 	// configuration reloading which doesn't have access to this
 	// setting.
 	inputQueueSize int
+
+	// dropIfFull is set for additional outputs whose events are dropped when
+	// their queue is full. It is never set for the main output.
+	dropIfFull bool
 }
 
 type producerRequest struct {
@@ -86,6 +90,7 @@ func newOutputController(
 	beat beat.Info,
 	monitors Monitors,
 	retryObserver retryObserver,
+	tracer *eventTracer,
 	queueFactory queue.QueueFactory,
 	inputQueueSize int,
 ) (*outputController, error) {
@@ -94,7 +99,7 @@ func newOutputController(
 		monitors:       monitors,
 		queueFactory:   queueFactory,
 		workerChan:     make(chan publisher.Batch),
-		consumer:       newEventConsumer(monitors.Logger, retryObserver),
+		consumer:       newEventConsumer(monitors.Logger, retryObserver, tracer),
 		inputQueueSize: inputQueueSize,
 	}
 
@@ -208,9 +213,18 @@ func (c *outputController) closeQueue(timeout time.Duration) {
 	}
 }
 
-// queueProducer creates a queue producer with the given config, blocking
-// until the queue is created if it does not yet exist.
-func (c *outputController) queueProducer(config queue.ProducerConfig) queue.Producer {
+// queueProducer creates a producer for the queue of the output with the
+// given config, blocking until the queue is created if it does not yet
+// exist. It returns nil if the output is closed before that.
+func (c *outputController) queueProducer(config queue.ProducerConfig) *outputProducer {
+	producer := c.newQueueProducer(config)
+	if producer == nil {
+		return nil
+	}
+	return &outputProducer{Producer: producer, dropIfFull: c.dropIfFull}
+}
+
+func (c *outputController) newQueueProducer(config queue.ProducerConfig) queue.Producer {
 	if publishDisabled {
 		// If publishDisabled is set ("-N" command line flag), then no output
 		// will ever be set, and no queue will ever be created. In this case,
@@ -292,6 +306,22 @@ func (c *outputController) createQueueIfNeeded(outGrp outputs.Group) {
 	c.pendingRequests = nil
 }
 
+// outputProducer publishes events to the queue of an output, applying the
+// back-pressure mode of the output.
+type outputProducer struct {
+	queue.Producer
+	dropIfFull bool
+}
+
+// Publish adds the entry to the queue, blocking while the queue is full
+// unless the output drops events when its queue is full.
+func (p *outputProducer) Publish(entry queue.Entry) (queue.EntryID, bool) {
+	if p.dropIfFull {
+		return p.Producer.TryPublish(entry)
+	}
+	return p.Producer.Publish(entry)
+}
+
 // emptyProducer is a placeholder queue producer that is used only when
 // publishDisabled is set, so beats don't block forever waiting for
 // a producer for a nonexistent queue.
@@ -0,0 +1,301 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+)
+
+// fanoutProducer publishes events to the queues of all outputs. An event is
+// acknowledged to the client once every output that accepted it has
+// acknowledged it, keeping the order the events were published in.
+//
+// Events are published to the queue of the main output by the client. Every
+// additional output has its own worker publishing the events to its queue, so
+// the client only waits for an additional output once its queue and the
+// buffer of its worker are full, and never if the output drops events.
+type fanoutProducer struct {
+	main    queue.Producer
+	outputs []*fanoutOutput
+	acks    *fanoutACKer
+}
+
+// fanoutOutputBufferSize is the number of events buffered for the worker of
+// an additional output.
+const fanoutOutputBufferSize = 64
+
+// fanoutOutput is the worker publishing events to the queue of an additional
+// output.
+type fanoutOutput struct {
+	index    int
+	producer *outputProducer
+	acks     *fanoutACKer
+	entries  chan fanoutEntry
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// fanoutEntry is an event to publish to an additional output, along with its
+// number in the order the events were published.
+type fanoutEntry struct {
+	id    uint64
+	entry queue.Entry
+}
+
+// fanoutACKer merges the acknowledgements of the output queues. Events are
+// numbered in the order they are published. For each output it tracks the
+// number of events that are resolved, that is acknowledged or not accepted by
+// the output.
+type fanoutACKer struct {
+	mu       sync.Mutex
+	ack      func(int)
+	next     uint64
+	reported uint64
+	outputs  []fanoutOutputState
+}
+
+type fanoutOutputState struct {
+	resolved uint64
+
+	// dropped holds the numbers of the events the output did not accept.
+	dropped []uint64
+}
+
+func newFanoutACKer(outputs int, ack func(int)) *fanoutACKer {
+	return &fanoutACKer{
+		ack:     ack,
+		outputs: make([]fanoutOutputState, outputs),
+	}
+}
+
+// producerConfig returns the config for the producer of the output at index.
+func (a *fanoutACKer) producerConfig(index int) queue.ProducerConfig {
+	return queue.ProducerConfig{
+		ACK: func(count int) { a.acked(index, count) },
+	}
+}
+
+func (a *fanoutACKer) acked(index, count int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	st := &a.outputs[index]
+	for ; count > 0; count-- {
+		st.skipDropped()
+		st.resolved++
+	}
+	st.skipDropped()
+	a.report()
+}
+
+// published registers an event accepted by at least one output. rejected
+// lists the outputs that did not accept it.
+func (a *fanoutACKer) published(rejected []int) {
+	id := a.register()
+	for _, index := range rejected {
+		a.dropped(index, id)
+	}
+}
+
+// register numbers a new event. The outputs that don't accept it must report
+// it with dropped.
+func (a *fanoutACKer) register() uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	id := a.next
+	a.next++
+	return id
+}
+
+// unregister cancels the registration of the last event, when no output
+// accepted it.
+func (a *fanoutACKer) unregister() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.next--
+}
+
+// dropped reports that the output at index did not accept the event id.
+func (a *fanoutACKer) dropped(index int, id uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	st := &a.outputs[index]
+	// The workers of additional outputs report the events they drop while
+	// later events may already be reported, keep the list sorted.
+	i, _ := slices.BinarySearch(st.dropped, id)
+	st.dropped = slices.Insert(st.dropped, i, id)
+	st.skipDropped()
+	a.report()
+}
+
+func (a *fanoutACKer) report() {
+	resolved := a.outputs[0].resolved
+	for _, st := range a.outputs[1:] {
+		resolved = min(resolved, st.resolved)
+	}
+	if resolved > a.reported {
+		count := int(resolved - a.reported)
+		a.reported = resolved
+		if a.ack != nil {
+			a.ack(count)
+		}
+	}
+}
+
+func (st *fanoutOutputState) skipDropped() {
+	for len(st.dropped) > 0 && st.dropped[0] == st.resolved {
+		st.dropped = st.dropped[1:]
+		st.resolved++
+	}
+}
+
+func newFanoutProducer(main queue.Producer, outputs []*outputProducer, acks *fanoutACKer) *fanoutProducer {
+	p := &fanoutProducer{main: main, acks: acks}
+	for i, producer := range outputs {
+		out := &fanoutOutput{
+			index:    i + 1,
+			producer: producer,
+			acks:     acks,
+			entries:  make(chan fanoutEntry, fanoutOutputBufferSize),
+			done:     make(chan struct{}),
+		}
+		out.wg.Add(1)
+		go out.run()
+		p.outputs = append(p.outputs, out)
+	}
+	return p
+}
+
+func (p *fanoutProducer) Publish(entry queue.Entry) (queue.EntryID, bool) {
+	return p.publish(entry, false)
+}
+
+func (p *fanoutProducer) TryPublish(entry queue.Entry) (queue.EntryID, bool) {
+	return p.publish(entry, true)
+}
+
+func (p *fanoutProducer) publish(entry queue.Entry, canDrop bool) (queue.EntryID, bool) {
+	id := p.acks.register()
+
+	// The additional outputs are handed the event first, so they don't wait
+	// for the main output while its queue is full.
+	var rejected []int
+	for _, out := range p.outputs {
+		if !out.forward(fanoutEntry{id: id, entry: outputEntry(entry, out.index)}, canDrop) {
+			rejected = append(rejected, out.index)
+		}
+	}
+
+	var ok bool
+	if canDrop {
+		_, ok = p.main.TryPublish(entry)
+	} else {
+		_, ok = p.main.Publish(entry)
+	}
+	if !ok {
+		if len(rejected) == len(p.outputs) {
+			p.acks.unregister()
+			return 0, false
+		}
+		rejected = append(rejected, 0)
+	}
+	for _, index := range rejected {
+		p.acks.dropped(index, id)
+	}
+	return 0, true
+}
+
+// forward hands the entry to the worker of the output. It blocks while the
+// buffer of the worker is full, unless the entry can be dropped or the output
+// drops events. It reports whether the worker accepted the entry.
+func (o *fanoutOutput) forward(e fanoutEntry, canDrop bool) bool {
+	select {
+	case <-o.done:
+		return false
+	default:
+	}
+	if canDrop || o.producer.dropIfFull {
+		select {
+		case o.entries <- e:
+			return true
+		default:
+			return false
+		}
+	}
+	select {
+	case o.entries <- e:
+		return true
+	case <-o.done:
+		return false
+	}
+}
+
+// run publishes the entries handed to the worker to the queue of the output,
+// until the output is closed. Entries not accepted by the queue are reported
+// as dropped.
+func (o *fanoutOutput) run() {
+	defer o.wg.Done()
+	for {
+		select {
+		case e := <-o.entries:
+			if _, ok := o.producer.Publish(e.entry); !ok {
+				o.acks.dropped(o.index, e.id)
+			}
+		case <-o.done:
+			for {
+				select {
+				case e := <-o.entries:
+					o.acks.dropped(o.index, e.id)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// outputEntry returns the entry to publish to the output at index.
+func outputEntry(entry queue.Entry, index int) queue.Entry {
+	event, ok := entry.(publisher.Event)
+	if !ok || index == 0 {
+		return entry
+	}
+	// Outputs may modify the events they publish, give each queue its own
+	// copy.
+	event.Content = *event.Content.Clone()
+	// Only events sent to the main output are traced.
+	event.Trace = nil
+	return event
+}
+
+func (p *fanoutProducer) Close() {
+	for _, out := range p.outputs {
+		close(out.done)
+		// Unblocks the worker if it is waiting for room in the queue.
+		out.producer.Close()
+	}
+	for _, out := range p.outputs {
+		out.wg.Wait()
+	}
+	p.main.Close()
+}
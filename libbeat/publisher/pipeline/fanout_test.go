@@ -0,0 +1,312 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFanoutACKer(t *testing.T) {
+	var acked []int
+	a := newFanoutACKer(2, func(n int) { acked = append(acked, n) })
+
+	t.Run("events are acknowledged once all outputs acknowledged them", func(t *testing.T) {
+		acked = nil
+		a.published(nil)
+		a.published(nil)
+		a.published(nil)
+
+		a.acked(0, 2)
+		assert.Empty(t, acked)
+		a.acked(1, 1)
+		assert.Equal(t, []int{1}, acked)
+		a.acked(1, 2)
+		assert.Equal(t, []int{1, 1}, acked)
+		a.acked(0, 1)
+		assert.Equal(t, []int{1, 1, 1}, acked)
+	})
+
+	t.Run("events not accepted by an output are skipped", func(t *testing.T) {
+		acked = nil
+		a.published(nil)
+		a.published([]int{1})
+		a.published(nil)
+
+		a.acked(1, 1)
+		a.acked(0, 2)
+		assert.Equal(t, []int{2}, acked)
+		a.acked(1, 1)
+		a.acked(0, 1)
+		assert.Equal(t, []int{2, 1}, acked)
+	})
+
+	t.Run("acknowledgements may arrive before the event is registered", func(t *testing.T) {
+		acked = nil
+		a.acked(0, 1)
+		a.published([]int{1})
+		assert.Equal(t, []int{1}, acked)
+	})
+}
+
+// gatedProducer accepts entries once its gate is closed.
+type gatedProducer struct {
+	gate   chan struct{}
+	closed chan struct{}
+	once   sync.Once
+
+	mu      sync.Mutex
+	entries []queue.Entry
+}
+
+func newGatedProducer(open bool) *gatedProducer {
+	p := &gatedProducer{gate: make(chan struct{}), closed: make(chan struct{})}
+	if open {
+		close(p.gate)
+	}
+	return p
+}
+
+func (p *gatedProducer) Publish(entry queue.Entry) (queue.EntryID, bool) {
+	select {
+	case <-p.gate:
+		return p.add(entry)
+	case <-p.closed:
+		return 0, false
+	}
+}
+
+func (p *gatedProducer) TryPublish(entry queue.Entry) (queue.EntryID, bool) {
+	select {
+	case <-p.gate:
+		return p.add(entry)
+	default:
+		return 0, false
+	}
+}
+
+func (p *gatedProducer) Close() {
+	p.once.Do(func() { close(p.closed) })
+}
+
+func (p *gatedProducer) add(entry queue.Entry) (queue.EntryID, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.entries = append(p.entries, entry)
+	return queue.EntryID(len(p.entries)), true
+}
+
+func (p *gatedProducer) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.entries)
+}
+
+func newTestFanoutProducer(acks *fanoutACKer, dropIfFull []bool, main *gatedProducer, others ...*gatedProducer) *fanoutProducer {
+	if acks == nil {
+		acks = newFanoutACKer(len(others)+1, nil)
+	}
+	var producers []*outputProducer
+	for i, producer := range others {
+		producers = append(producers, &outputProducer{Producer: producer, dropIfFull: dropIfFull[i]})
+	}
+	return newFanoutProducer(main, producers, acks)
+}
+
+func TestFanoutProducer(t *testing.T) {
+	t.Run("a blocked main output does not hold back the others", func(t *testing.T) {
+		main, other := newGatedProducer(false), newGatedProducer(true)
+		fanout := newTestFanoutProducer(nil, []bool{false}, main, other)
+		defer fanout.Close()
+
+		published := make(chan bool, 1)
+		go func() {
+			_, ok := fanout.Publish(publisher.Event{})
+			published <- ok
+		}()
+
+		require.Eventually(t, func() bool {
+			return other.count() == 1
+		}, 10*time.Second, time.Millisecond)
+		assert.Equal(t, 0, main.count())
+		assert.Empty(t, published)
+
+		close(main.gate)
+		assert.True(t, <-published)
+		assert.Equal(t, 1, main.count())
+	})
+
+	t.Run("a blocked additional output does not hold back the client", func(t *testing.T) {
+		main, other := newGatedProducer(true), newGatedProducer(false)
+		fanout := newTestFanoutProducer(nil, []bool{false}, main, other)
+		defer fanout.Close()
+
+		for i := 0; i < fanoutOutputBufferSize; i++ {
+			_, ok := fanout.Publish(publisher.Event{})
+			require.True(t, ok)
+		}
+		assert.Equal(t, fanoutOutputBufferSize, main.count())
+		assert.Equal(t, 0, other.count())
+
+		close(other.gate)
+		require.Eventually(t, func() bool {
+			return other.count() == fanoutOutputBufferSize
+		}, 10*time.Second, time.Millisecond)
+	})
+
+	t.Run("a blocked additional output holds back the client once its buffer is full", func(t *testing.T) {
+		main, other := newGatedProducer(true), newGatedProducer(false)
+		fanout := newTestFanoutProducer(nil, []bool{false}, main, other)
+		defer fanout.Close()
+
+		published := make(chan struct{})
+		go func() {
+			defer close(published)
+			// One event is held by the worker, the others by its buffer.
+			for i := 0; i < fanoutOutputBufferSize+2; i++ {
+				fanout.Publish(publisher.Event{})
+			}
+		}()
+		require.Eventually(t, func() bool {
+			return main.count() == fanoutOutputBufferSize+1
+		}, 10*time.Second, time.Millisecond)
+		assert.Never(t, func() bool {
+			return main.count() > fanoutOutputBufferSize+1
+		}, 50*time.Millisecond, time.Millisecond)
+
+		close(other.gate)
+		<-published
+		assert.Equal(t, fanoutOutputBufferSize+2, main.count())
+	})
+
+	t.Run("an additional output drops events while full", func(t *testing.T) {
+		var acked atomic.Int64
+		acks := newFanoutACKer(2, func(n int) { acked.Add(int64(n)) })
+		main, other := newGatedProducer(true), newGatedProducer(false)
+		fanout := newTestFanoutProducer(acks, []bool{true}, main, other)
+		defer fanout.Close()
+
+		_, ok := fanout.Publish(publisher.Event{})
+		assert.True(t, ok)
+		assert.Equal(t, 1, main.count())
+
+		// The event is acknowledged once the main output acknowledged it and
+		// the additional output dropped it.
+		acks.acked(0, 1)
+		require.Eventually(t, func() bool {
+			return acked.Load() == 1
+		}, 10*time.Second, time.Millisecond)
+		assert.Equal(t, 0, other.count())
+	})
+
+	t.Run("events are dropped if no output accepts them", func(t *testing.T) {
+		main, other := newGatedProducer(false), newGatedProducer(false)
+		fanout := newTestFanoutProducer(nil, []bool{false}, main, other)
+		defer fanout.Close()
+
+		// The buffer of the blocked additional output fills up.
+		require.Eventually(t, func() bool {
+			_, ok := fanout.TryPublish(publisher.Event{})
+			return !ok
+		}, 10*time.Second, time.Millisecond)
+		assert.Equal(t, 0, main.count())
+	})
+}
+
+func TestPipelineMultipleOutputs(t *testing.T) {
+	const events = 100
+
+	var (
+		mu        sync.Mutex
+		published [2]int
+	)
+	makeOutput := func(index int) outputs.Group {
+		return outputs.Group{
+			BatchSize: 10,
+			Clients: []outputs.Client{newMockClient(func(batch publisher.Batch) error {
+				mu.Lock()
+				published[index] += len(batch.Events())
+				mu.Unlock()
+				batch.ACK()
+				return nil
+			})},
+		}
+	}
+
+	logger := logptest.NewTestingLogger(t, "")
+	p, err := New(beat.Info{Logger: logger}, Monitors{Logger: logger}, conf.Namespace{}, makeOutput(0), Settings{
+		AdditionalOutputs: []func(outputs.Observer) (string, outputs.Group, error){
+			func(outputs.Observer) (string, outputs.Group, error) {
+				return "test", makeOutput(1), nil
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer p.Close()
+	require.Len(t, p.additionalOutputs, 1)
+
+	var acked atomic.Int64
+	client, err := p.ConnectWith(beat.ClientConfig{
+		EventListener: acker.RawCounting(func(n int) { acked.Add(int64(n)) }),
+	})
+	require.NoError(t, err)
+	for i := 0; i < events; i++ {
+		client.Publish(beat.Event{Fields: mapstr.M{"count": i}})
+	}
+
+	require.Eventually(t, func() bool {
+		return acked.Load() == events
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, client.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, [2]int{events, events}, published)
+}
+
+func TestPipelineAdditionalOutputDiskQueue(t *testing.T) {
+	logger := logptest.NewTestingLogger(t, "")
+	queueCfg := conf.Namespace{}
+	require.NoError(t, conf.MustNewConfigFrom(mapstr.M{
+		"disk.path":     t.TempDir(),
+		"disk.max_size": "10MB",
+	}).Unpack(&queueCfg))
+
+	_, err := New(beat.Info{Logger: logger}, Monitors{Logger: logger}, queueCfg, outputs.Group{}, Settings{
+		AdditionalOutputs: []func(outputs.Observer) (string, outputs.Group, error){
+			func(outputs.Observer) (string, outputs.Group, error) {
+				return "test", outputs.Group{Clients: []outputs.Client{newMockClient(nil)}}, nil
+			},
+		},
+	})
+	assert.ErrorContains(t, err, "can not share the disk queue")
+}
@@ -21,6 +21,7 @@ import (
 	"flag"
 
 	"go.elastic.co/apm/v2"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/outputs"
@@ -43,6 +44,10 @@ type Monitors struct {
 	Telemetry *monitoring.Registry
 	Logger    *logp.Logger
 	Tracer    *apm.Tracer
+
+	// TracerProvider creates the spans of traced events. If nil, the
+	// global OpenTelemetry tracer provider is used.
+	TracerProvider trace.TracerProvider
 }
 
 // OutputFactory is used by the publisher pipeline to create an output instance.
@@ -97,6 +102,7 @@ func LoadWithSettings(
 		return nil, err
 	}
 
+	settings.Tracing = config.Tracing
 	p, err := New(beatInfo, monitors, config.Queue, out, settings)
 	if err != nil {
 		return nil, err
@@ -22,6 +22,8 @@ package pipeline
 
 import (
 	"fmt"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
@@ -60,8 +62,16 @@ type Pipeline struct {
 
 	outputController *outputController
 
+	// additionalOutputs hold the outputs configured after the first one, each
+	// with its own queue.
+	additionalOutputs []*outputController
+
 	observer observer
 
+	// tracer samples events to trace their latency through the pipeline,
+	// nil if tracing is disabled.
+	tracer *eventTracer
+
 	// If waitCloseTimeout is positive, then the pipeline will wait up to the
 	// specified time when it is closed for pending events to be acknowledged.
 	waitCloseTimeout time.Duration
@@ -80,6 +90,14 @@ type Settings struct {
 	Processors processing.Supporter
 
 	InputQueueSize int
+
+	// AdditionalOutputs creates the outputs events are published to in
+	// addition to the main output. Each output gets its own queue.
+	AdditionalOutputs []func(outputs.Observer) (string, outputs.Group, error)
+
+	// Tracing configures the tracing of sampled events, see TracingConfig.
+	// Only events sent to the main output are traced.
+	Tracing *conf.C
 }
 
 // WaitCloseMode enumerates the possible behaviors of WaitClose in a pipeline.
@@ -134,6 +152,12 @@ func New(
 		p.observer = newMetricsObserver(monitors.Metrics)
 	}
 
+	tracer, err := newEventTracer(settings.Tracing, monitors)
+	if err != nil {
+		return nil, err
+	}
+	p.tracer = tracer
+
 	// Convert the raw queue config to a parsed Settings object that will
 	// be used during queue creation. This lets us fail immediately on startup
 	// if there's a configuration problem.
@@ -146,16 +170,63 @@ func New(
 		return nil, err
 	}
 
-	output, err := newOutputController(beat, monitors, p.observer, queueFactory, settings.InputQueueSize)
+	output, err := newOutputController(beat, monitors, p.observer, p.tracer, queueFactory, settings.InputQueueSize)
 	if err != nil {
 		return nil, err
 	}
 	p.outputController = output
 	p.outputController.Set(out)
 
+	for i, makeOutput := range settings.AdditionalOutputs {
+		controller, err := p.newAdditionalOutput(i+1, queueType, queueFactory, makeOutput, settings.InputQueueSize)
+		if err != nil {
+			p.outputController.WaitClose(0)
+			for _, c := range p.additionalOutputs {
+				c.WaitClose(0)
+			}
+			return nil, err
+		}
+		p.additionalOutputs = append(p.additionalOutputs, controller)
+	}
+
 	return p, nil
 }
 
+// newAdditionalOutput creates the controller of an additional output. Its
+// metrics are reported under `outputs.<index>`, using the same layout as
+// the metrics of the main output.
+func (p *Pipeline) newAdditionalOutput(
+	index int,
+	queueType string,
+	queueFactory queue.QueueFactory,
+	makeOutput outputFactory,
+	inputQueueSize int,
+) (*outputController, error) {
+	monitors := Monitors{
+		Logger: p.monitors.Logger.With("output_index", index),
+		Tracer: p.monitors.Tracer,
+	}
+	if p.monitors.Metrics != nil {
+		monitors.Metrics = p.monitors.Metrics.GetOrCreateRegistry("outputs").NewRegistry(strconv.Itoa(index))
+	}
+
+	out, err := loadOutput(monitors, makeOutput)
+	if err != nil {
+		return nil, err
+	}
+	if out.QueueFactory == nil && queueType == diskqueue.QueueType {
+		return nil, fmt.Errorf("output %d can not share the disk queue with other outputs, configure a queue in its output settings", index)
+	}
+
+	controller, err := newOutputController(p.beatInfo, monitors, p.observer, nil, queueFactory, inputQueueSize)
+	if err != nil {
+		return nil, err
+	}
+	controller.dropIfFull = out.DropIfFull
+	controller.Set(out)
+	return controller, nil
+}
+
 // Close stops the pipeline, outputs and queue.
 // If WaitClose with WaitOnPipelineClose mode is configured, Close will block
 // for a duration of WaitClose, if there are still active events in the pipeline.
@@ -166,7 +237,16 @@ func (p *Pipeline) Close() error {
 	log.Debug("close pipeline")
 
 	// Note: active clients are not closed / disconnected.
+	var wg sync.WaitGroup
+	for _, c := range p.additionalOutputs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.WaitClose(p.waitCloseTimeout)
+		}()
+	}
 	p.outputController.WaitClose(p.waitCloseTimeout)
+	wg.Wait()
 
 	p.observer.cleanup()
 	return nil
@@ -220,6 +300,7 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 		eventFlags:     eventFlags,
 		canDrop:        canDrop,
 		observer:       p.observer,
+		tracer:         p.tracer,
 	}
 
 	client.isOpen.Store(true)
@@ -251,7 +332,7 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 
 	client.eventListener = ackHandler
 	client.waiter = waiter
-	client.producer = p.outputController.queueProducer(producerCfg)
+	client.producer = p.queueProducer(producerCfg)
 	if client.producer == nil {
 		// This can only happen if the pipeline was shut down while clients
 		// were still waiting to connect.
@@ -262,6 +343,38 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 	return client, nil
 }
 
+// queueProducer returns a producer for the queue of the output, or a
+// producer publishing to the queues of all outputs if there are additional
+// outputs. Every output has its own producer, applying the back-pressure
+// mode of the output.
+func (p *Pipeline) queueProducer(cfg queue.ProducerConfig) queue.Producer {
+	if len(p.additionalOutputs) == 0 {
+		if producer := p.outputController.queueProducer(cfg); producer != nil {
+			return producer
+		}
+		return nil
+	}
+
+	acks := newFanoutACKer(len(p.additionalOutputs)+1, cfg.ACK)
+	main := p.outputController.queueProducer(acks.producerConfig(0))
+	if main == nil {
+		return nil
+	}
+	var producers []*outputProducer
+	for i, c := range p.additionalOutputs {
+		producer := c.queueProducer(acks.producerConfig(i + 1))
+		if producer == nil {
+			main.Close()
+			for _, producer := range producers {
+				producer.Close()
+			}
+			return nil
+		}
+		producers = append(producers, producer)
+	}
+	return newFanoutProducer(main, producers, acks)
+}
+
 func (p *Pipeline) createEventProcessing(cfg beat.ProcessingConfig, noPublish bool) (beat.Processor, error) {
 	if p.processors == nil {
 		return nil, nil
@@ -33,6 +33,7 @@ type queueReader struct {
 type queueReaderRequest struct {
 	queue      queue.Queue
 	retryer    retryer
+	tracer     *eventTracer
 	batchSize  int
 	timeToLive int
 }
@@ -58,6 +59,7 @@ func (qr *queueReader) run(logger *logp.Logger) {
 		var batch *ttlBatch
 		if queueBatch != nil {
 			batch = newBatch(req.retryer, queueBatch, req.timeToLive)
+			req.tracer.traceBatch(batch)
 		}
 		select {
 		case qr.resp <- batch:
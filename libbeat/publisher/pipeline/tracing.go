@@ -0,0 +1,191 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/monitoring/adapter"
+)
+
+// TracingConfig configures the tracing of a sampled subset of events
+// through the pipeline.
+type TracingConfig struct {
+	Enabled bool `config:"enabled"`
+
+	// The fraction of events to trace.
+	SampleRate float64 `config:"sample_rate" validate:"min=0,max=1"`
+
+	// If set, an OpenTelemetry span is created for every traced event.
+	Spans bool `config:"spans"`
+}
+
+var defaultTracingConfig = TracingConfig{
+	SampleRate: 0.01,
+}
+
+const tracerName = "github.com/elastic/beats/v7/libbeat/publisher/pipeline"
+
+// eventTracer samples events published to the pipeline, and reports the
+// time they spend in every stage of the pipeline once they are
+// acknowledged:
+//   - publish: from the pipeline client to the queue, including
+//     processors and waiting for space in the queue.
+//   - queue: from the queue to the output.
+//   - output: from the output to the acknowledgment, including retries.
+type eventTracer struct {
+	sampleRate float64
+
+	// If non-nil, a span is created for every traced event.
+	tracer trace.Tracer
+
+	events *monitoring.Uint
+
+	publishLatency metrics.Sample
+	queueLatency   metrics.Sample
+	outputLatency  metrics.Sample
+	totalLatency   metrics.Sample
+}
+
+// newEventTracer returns an eventTracer for the given configuration, or nil
+// if tracing is disabled.
+func newEventTracer(cfg *conf.C, monitors Monitors) (*eventTracer, error) {
+	config := defaultTracingConfig
+	if cfg != nil {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, fmt.Errorf("invalid pipeline tracing configuration: %w", err)
+		}
+	}
+	if !config.Enabled {
+		return nil, nil
+	}
+
+	t := &eventTracer{
+		sampleRate:     config.SampleRate,
+		publishLatency: metrics.NewUniformSample(1024),
+		queueLatency:   metrics.NewUniformSample(1024),
+		outputLatency:  metrics.NewUniformSample(1024),
+		totalLatency:   metrics.NewUniformSample(1024),
+	}
+	if config.Spans {
+		provider := monitors.TracerProvider
+		if provider == nil {
+			provider = otel.GetTracerProvider()
+		}
+		t.tracer = provider.Tracer(tracerName)
+	}
+
+	// Without a metrics registry the metrics are still kept, but not
+	// reported.
+	reg := monitoring.NewRegistry()
+	if monitors.Metrics != nil {
+		reg = monitors.Metrics.GetOrCreateRegistry("pipeline").GetOrCreateRegistry("tracing")
+	}
+	t.events = monitoring.NewUint(reg, "events")
+	for name, sample := range map[string]metrics.Sample{
+		"publish": t.publishLatency,
+		"queue":   t.queueLatency,
+		"output":  t.outputLatency,
+		"total":   t.totalLatency,
+	} {
+		_ = adapter.NewGoMetrics(reg, "latency."+name, adapter.Accept).Register("histogram", metrics.NewHistogram(sample))
+	}
+	return t, nil
+}
+
+// sample returns a new trace if the event received now should be traced.
+func (t *eventTracer) sample() *publisher.EventTrace {
+	if t == nil || rand.Float64() >= t.sampleRate { //nolint:gosec // No need for a secure random number to sample events
+		return nil
+	}
+	et := &publisher.EventTrace{}
+	et.Received.Store(time.Now().UnixNano())
+	return et
+}
+
+// traceBatch marks the traced events of a batch read from the queue as
+// dequeued, and records them when the batch is done.
+func (t *eventTracer) traceBatch(batch *ttlBatch) {
+	if t == nil {
+		return
+	}
+	var traces []*publisher.EventTrace
+	now := time.Now().UnixNano()
+	for _, event := range batch.events {
+		if event.Trace != nil {
+			event.Trace.Dequeued.Store(now)
+			traces = append(traces, event.Trace)
+		}
+	}
+	if len(traces) == 0 {
+		return
+	}
+	done := batch.done
+	batch.done = func() {
+		done()
+		acked := time.Now()
+		for _, et := range traces {
+			t.record(et, acked)
+		}
+	}
+}
+
+// record reports the latencies of a trace whose event was acknowledged.
+func (t *eventTracer) record(et *publisher.EventTrace, acked time.Time) {
+	received := time.Unix(0, et.Received.Load())
+	dequeued := time.Unix(0, et.Dequeued.Load())
+	// The client marks the event as enqueued once the queue returns, which
+	// may happen after the output already read it.
+	enqueued := dequeued
+	if nanos := et.Enqueued.Load(); nanos != 0 && nanos < dequeued.UnixNano() {
+		enqueued = time.Unix(0, nanos)
+	}
+
+	t.events.Inc()
+	t.publishLatency.Update(enqueued.Sub(received).Milliseconds())
+	t.queueLatency.Update(dequeued.Sub(enqueued).Milliseconds())
+	t.outputLatency.Update(acked.Sub(dequeued).Milliseconds())
+	t.totalLatency.Update(acked.Sub(received).Milliseconds())
+
+	if t.tracer == nil {
+		return
+	}
+	ctx, span := t.tracer.Start(context.Background(), "publish event", trace.WithTimestamp(received))
+	for _, stage := range []struct {
+		name       string
+		start, end time.Time
+	}{
+		{"publish", received, enqueued},
+		{"queue", enqueued, dequeued},
+		{"output", dequeued, acked},
+	} {
+		_, child := t.tracer.Start(ctx, stage.name, trace.WithTimestamp(stage.start))
+		child.End(trace.WithTimestamp(stage.end))
+	}
+	span.End(trace.WithTimestamp(acked))
+}
@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestNewEventTracer(t *testing.T) {
+	tracer, err := newEventTracer(nil, Monitors{})
+	require.NoError(t, err)
+	assert.Nil(t, tracer, "tracing must be disabled by default")
+	assert.Nil(t, tracer.sample(), "a disabled tracer must not sample events")
+
+	tracer, err = newEventTracer(conf.MustNewConfigFrom(mapstr.M{"enabled": true}), Monitors{})
+	require.NoError(t, err)
+	require.NotNil(t, tracer)
+	assert.Equal(t, defaultTracingConfig.SampleRate, tracer.sampleRate)
+	assert.Nil(t, tracer.tracer, "spans must be disabled by default")
+
+	_, err = newEventTracer(conf.MustNewConfigFrom(mapstr.M{"enabled": true, "sample_rate": 2}), Monitors{})
+	assert.Error(t, err)
+}
+
+func TestEventTracerRecord(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	reg := monitoring.NewRegistry()
+	tracer, err := newEventTracer(
+		conf.MustNewConfigFrom(mapstr.M{"enabled": true, "sample_rate": 1, "spans": true}),
+		Monitors{
+			Metrics:        reg,
+			TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		})
+	require.NoError(t, err)
+
+	received := time.Now()
+	et := &publisher.EventTrace{}
+	et.Received.Store(received.UnixNano())
+	et.Enqueued.Store(received.Add(10 * time.Millisecond).UnixNano())
+	et.Dequeued.Store(received.Add(30 * time.Millisecond).UnixNano())
+	tracer.record(et, received.Add(60*time.Millisecond))
+
+	assert.Equal(t, uint64(1), tracer.events.Get())
+	assert.Equal(t, []int64{10}, tracer.publishLatency.Values())
+	assert.Equal(t, []int64{20}, tracer.queueLatency.Values())
+	assert.Equal(t, []int64{30}, tracer.outputLatency.Values())
+	assert.Equal(t, []int64{60}, tracer.totalLatency.Values())
+
+	snapshot := monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	assert.Equal(t, int64(1), snapshot.Ints["pipeline.tracing.events"])
+	assert.Equal(t, int64(1), snapshot.Ints["pipeline.tracing.latency.total.histogram.count"])
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4)
+	stages := map[string]time.Duration{}
+	for _, span := range spans {
+		stages[span.Name()] = span.EndTime().Sub(span.StartTime())
+	}
+	assert.Equal(t, map[string]time.Duration{
+		"publish":       10 * time.Millisecond,
+		"queue":         20 * time.Millisecond,
+		"output":        30 * time.Millisecond,
+		"publish event": 60 * time.Millisecond,
+	}, stages)
+}
+
+func TestPipelineTracing(t *testing.T) {
+	const events = 20
+
+	output := outputs.Group{
+		BatchSize: 5,
+		Clients: []outputs.Client{newMockClient(func(batch publisher.Batch) error {
+			for _, event := range batch.Events() {
+				assert.NotNil(t, event.Trace, "all events must be traced with a sample rate of 1")
+			}
+			batch.ACK()
+			return nil
+		})},
+	}
+
+	logger := logptest.NewTestingLogger(t, "")
+	p, err := New(beat.Info{Logger: logger}, Monitors{Logger: logger}, conf.Namespace{}, output, Settings{
+		Tracing: conf.MustNewConfigFrom(mapstr.M{"enabled": true, "sample_rate": 1}),
+	})
+	require.NoError(t, err)
+	defer p.Close()
+
+	var acked atomic.Int64
+	client, err := p.ConnectWith(beat.ClientConfig{
+		EventListener: acker.RawCounting(func(n int) { acked.Add(int64(n)) }),
+	})
+	require.NoError(t, err)
+	for i := 0; i < events; i++ {
+		client.Publish(beat.Event{Fields: mapstr.M{"count": i}})
+	}
+
+	require.Eventually(t, func() bool {
+		return p.tracer.events.Get() == events
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, client.Close())
+	assert.Len(t, p.tracer.totalLatency.Values(), events)
+}
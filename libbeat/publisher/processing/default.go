@@ -385,7 +385,9 @@ func (b *builder) Create(cfg beat.ProcessingConfig, drop bool) (beat.Processor,
 	// setup 8: pipeline processors list
 	if b.processors != nil {
 		// Add the global pipeline as a function processor, so clients cannot close it
-		processors.add(newProcessor(b.processors.title, b.processors.Run))
+		global := newProcessor(b.processors.title, b.processors.Run)
+		global.batch = b.processors.RunBatch
+		processors.add(global)
 	}
 
 	// setup 9: time series metadata
@@ -39,8 +39,9 @@ type group struct {
 }
 
 type processorFn struct {
-	name string
-	fn   func(event *beat.Event) (*beat.Event, error)
+	name  string
+	fn    func(event *beat.Event) (*beat.Event, error)
+	batch func(events []*beat.Event) ([]*beat.Event, []error)
 }
 
 func newGeneralizeProcessor(keepNull bool, logger *logp.Logger) *processorFn {
@@ -142,6 +143,49 @@ func (p *group) Run(event *beat.Event) (*beat.Event, error) {
 	return event, nil
 }
 
+func (p *group) RunBatch(events []*beat.Event) ([]*beat.Event, []error) {
+	if p == nil || len(p.list) == 0 {
+		return events, make([]error, len(events))
+	}
+
+	results := append([]*beat.Event(nil), events...)
+	errs := make([]error, len(events))
+
+	// pending holds the indexes of the events not dropped yet.
+	pending := make([]int, len(events))
+	for i := range pending {
+		pending[i] = i
+	}
+
+	in := make([]*beat.Event, 0, len(events))
+	for _, sub := range p.list {
+		if len(pending) == 0 {
+			break
+		}
+
+		in = in[:0]
+		for _, i := range pending {
+			in = append(in, results[i])
+		}
+		out, outErrs := processors.RunBatch(sub, in)
+
+		next := pending[:0]
+		for j, i := range pending {
+			results[i] = out[j]
+			if outErrs[j] != nil {
+				p.log.Debugf("Fail to apply processor %s: %s", p, outErrs[j])
+			}
+			if out[j] == nil {
+				errs[i] = outErrs[j]
+				continue
+			}
+			next = append(next, i)
+		}
+		pending = next
+	}
+	return results, errs
+}
+
 func newProcessor(name string, fn func(*beat.Event) (*beat.Event, error)) *processorFn {
 	return &processorFn{name: name, fn: fn}
 }
@@ -156,6 +200,18 @@ func newAnnotateProcessor(name string, fn func(*beat.Event)) *processorFn {
 func (p *processorFn) String() string                         { return p.name }
 func (p *processorFn) Run(e *beat.Event) (*beat.Event, error) { return p.fn(e) }
 
+func (p *processorFn) RunBatch(events []*beat.Event) ([]*beat.Event, []error) {
+	if p.batch != nil {
+		return p.batch(events)
+	}
+	results := make([]*beat.Event, len(events))
+	errs := make([]error, len(events))
+	for i, event := range events {
+		results[i], errs[i] = p.fn(event)
+	}
+	return results, errs
+}
+
 func clientEventMeta(meta mapstr.M, needsCopy bool) *processorFn {
 	fn := func(event *beat.Event) { addMeta(event, meta) }
 	if needsCopy {
@@ -18,62 +18,185 @@
 package diskqueue
 
 import (
+	"fmt"
 	"io"
 
+	"github.com/klauspost/compress/zstd"
 	lz4V4 "github.com/pierrec/lz4/v4"
 )
 
-// CompressionReader allows reading a stream compressed with LZ4
+// Compression codecs that can be used for segment files.
+const (
+	CompressionNone = "none"
+	CompressionLZ4  = "lz4"
+	CompressionZstd = "zstd"
+)
+
+// lz4Levels maps the user facing LZ4 compression levels 0-9 to the
+// levels of the LZ4 library, 0 being the fast default.
+var lz4Levels = []lz4V4.CompressionLevel{
+	lz4V4.Fast,
+	lz4V4.Level1, lz4V4.Level2, lz4V4.Level3,
+	lz4V4.Level4, lz4V4.Level5, lz4V4.Level6,
+	lz4V4.Level7, lz4V4.Level8, lz4V4.Level9,
+}
+
+// maxZstdLevel is the highest zstd compression level.
+const maxZstdLevel = 22
+
+// validateCompression checks that the codec is known and the level is
+// valid for it. A level of 0 selects the codec's default level.
+func validateCompression(codec string, level int) error {
+	switch codec {
+	case "", CompressionNone:
+		return nil
+	case CompressionLZ4:
+		if level < 0 || level >= len(lz4Levels) {
+			return fmt.Errorf("lz4 compression_level (%d) must be between 0 and %d", level, len(lz4Levels)-1)
+		}
+	case CompressionZstd:
+		if level < 0 || level > maxZstdLevel {
+			return fmt.Errorf("zstd compression_level (%d) must be between 0 and %d", level, maxZstdLevel)
+		}
+	default:
+		return fmt.Errorf("unknown compression codec '%s'", codec)
+	}
+	return nil
+}
+
+// decompressor is implemented by the stream decoders of all codecs.
+type decompressor interface {
+	io.Reader
+	Reset(r io.Reader) error
+}
+
+// compressor is implemented by the stream encoders of all codecs.
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// lz4Reader adapts the LZ4 reader to the decompressor interface.
+type lz4Reader struct {
+	*lz4V4.Reader
+}
+
+func (r lz4Reader) Reset(src io.Reader) error {
+	r.Reader.Reset(src)
+	return nil
+}
+
+// zstdReader adapts the zstd decoder to the decompressor interface.
+type zstdReader struct {
+	*zstd.Decoder
+}
+
+// CompressionReader allows reading a compressed stream
 type CompressionReader struct {
-	src        io.ReadCloser
-	pLZ4Reader *lz4V4.Reader
+	src          io.ReadCloser
+	decompressor decompressor
 }
 
 // NewCompressionReader returns a new LZ4 frame decoder
 func NewCompressionReader(r io.ReadCloser) *CompressionReader {
-	zr := lz4V4.NewReader(r)
 	return &CompressionReader{
-		src:        r,
-		pLZ4Reader: zr,
+		src:          r,
+		decompressor: lz4Reader{lz4V4.NewReader(r)},
 	}
 }
 
+// newCompressionReader returns a decoder for the given codec.
+func newCompressionReader(r io.ReadCloser, codec string) (*CompressionReader, error) {
+	switch codec {
+	case CompressionLZ4:
+		return NewCompressionReader(r), nil
+	case CompressionZstd:
+		// A concurrency of 1 decodes synchronously, without background
+		// goroutines.
+		zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("could not create zstd decoder: %w", err)
+		}
+		return &CompressionReader{
+			src:          r,
+			decompressor: zstdReader{zr},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown compression codec '%s'", codec)
+}
+
 func (r *CompressionReader) Read(buf []byte) (int, error) {
-	return r.pLZ4Reader.Read(buf)
+	return r.decompressor.Read(buf)
 }
 
 func (r *CompressionReader) Close() error {
+	if zr, ok := r.decompressor.(zstdReader); ok {
+		zr.Decoder.Close()
+	}
 	return r.src.Close()
 }
 
 // Reset Sets up compression again, assumes that caller has already set
 // the src to the correct position
 func (r *CompressionReader) Reset() error {
-	r.pLZ4Reader.Reset(r.src)
-	return nil
+	return r.decompressor.Reset(r.src)
 }
 
-// CompressionWriter allows writing an LZ4 stream
+// CompressionWriter allows writing a compressed stream
 type CompressionWriter struct {
 	dst        WriteCloseSyncer
-	pLZ4Writer *lz4V4.Writer
+	compressor compressor
 }
 
 // NewCompressionWriter returns a new LZ4 frame encoder
 func NewCompressionWriter(w WriteCloseSyncer) *CompressionWriter {
-	zw := lz4V4.NewWriter(w)
 	return &CompressionWriter{
 		dst:        w,
-		pLZ4Writer: zw,
+		compressor: lz4V4.NewWriter(w),
 	}
 }
 
+// newCompressionWriter returns an encoder for the given codec and
+// level. A level of 0 selects the codec's default level.
+func newCompressionWriter(w WriteCloseSyncer, codec string, level int) (*CompressionWriter, error) {
+	if err := validateCompression(codec, level); err != nil {
+		return nil, err
+	}
+	switch codec {
+	case CompressionLZ4:
+		zw := lz4V4.NewWriter(w)
+		if level > 0 {
+			if err := zw.Apply(lz4V4.CompressionLevelOption(lz4Levels[level])); err != nil {
+				return nil, fmt.Errorf("could not set lz4 compression level: %w", err)
+			}
+		}
+		return &CompressionWriter{
+			dst:        w,
+			compressor: zw,
+		}, nil
+	case CompressionZstd:
+		opts := []zstd.EOption{zstd.WithEncoderConcurrency(1)}
+		if level > 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+		zw, err := zstd.NewWriter(w, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not create zstd encoder: %w", err)
+		}
+		return &CompressionWriter{
+			dst:        w,
+			compressor: zw,
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown compression codec '%s'", codec)
+}
+
 func (w *CompressionWriter) Write(p []byte) (int, error) {
-	return w.pLZ4Writer.Write(p)
+	return w.compressor.Write(p)
 }
 
 func (w *CompressionWriter) Close() error {
-	err := w.pLZ4Writer.Close()
+	err := w.compressor.Close()
 	if err != nil {
 		return err
 	}
@@ -81,6 +204,8 @@ func (w *CompressionWriter) Close() error {
 }
 
 func (w *CompressionWriter) Sync() error {
-	w.pLZ4Writer.Flush()
+	if err := w.compressor.Flush(); err != nil {
+		return err
+	}
 	return w.dst.Sync()
 }
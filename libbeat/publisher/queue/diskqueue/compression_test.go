@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type nopWriteCloser struct {
@@ -171,3 +172,70 @@ func TestCompressionSync(t *testing.T) {
 		assert.Equal(t, tc.plaintext, dst.Bytes()[len(tc.plaintext):], name)
 	}
 }
+
+func TestCompressionCodecs(t *testing.T) {
+	plaintext := bytes.Repeat([]byte("abcdefghijklmnopqrstuvwxzy01234567890"), 100)
+	tests := map[string]struct {
+		codec string
+		level int
+	}{
+		"lz4 default level": {codec: CompressionLZ4},
+		"lz4 level 9":       {codec: CompressionLZ4, level: 9},
+		"zstd default":      {codec: CompressionZstd},
+		"zstd level 1":      {codec: CompressionZstd, level: 1},
+		"zstd level 19":     {codec: CompressionZstd, level: 19},
+	}
+	for name, tc := range tests {
+		var compressed bytes.Buffer
+		cw, err := newCompressionWriter(NopWriteCloseSyncer(NopWriteCloser(&compressed)), tc.codec, tc.level)
+		require.NoError(t, err, name)
+		_, err = cw.Write(plaintext)
+		require.NoError(t, err, name)
+		// Data written before a Sync must be readable before the writer
+		// is closed.
+		require.NoError(t, cw.Sync(), name)
+		assert.Less(t, compressed.Len(), len(plaintext), name)
+
+		cr, err := newCompressionReader(io.NopCloser(bytes.NewReader(compressed.Bytes())), tc.codec)
+		require.NoError(t, err, name)
+		dst := make([]byte, len(plaintext))
+		_, err = io.ReadFull(cr, dst)
+		require.NoError(t, err, name)
+		assert.Equal(t, plaintext, dst, name)
+
+		_, err = cw.Write(plaintext)
+		require.NoError(t, err, name)
+		require.NoError(t, cw.Close(), name)
+
+		require.NoError(t, cr.Close(), name)
+		cr, err = newCompressionReader(io.NopCloser(bytes.NewReader(compressed.Bytes())), tc.codec)
+		require.NoError(t, err, name)
+		all, err := io.ReadAll(cr)
+		require.NoError(t, err, name)
+		assert.Equal(t, append(plaintext, plaintext...), all, name)
+	}
+}
+
+func TestValidateCompression(t *testing.T) {
+	tests := map[string]struct {
+		codec string
+		level int
+		valid bool
+	}{
+		"unset":              {valid: true},
+		"none":               {codec: CompressionNone, valid: true},
+		"lz4":                {codec: CompressionLZ4, level: 9, valid: true},
+		"lz4 level too high": {codec: CompressionLZ4, level: 10},
+		"zstd":               {codec: CompressionZstd, level: 22, valid: true},
+		"zstd level too low": {codec: CompressionZstd, level: -1},
+		"unknown codec":      {codec: "gzip"},
+	}
+	for name, tc := range tests {
+		err := validateCompression(tc.codec, tc.level)
+		if tc.valid {
+			assert.NoError(t, err, name)
+		} else {
+			assert.Error(t, err, name)
+		}
+	}
+}
@@ -66,6 +66,15 @@ type Settings struct {
 
 	// UseCompression enables or disables LZ4 compression
 	UseCompression bool
+
+	// Compression selects the codec segment files are compressed with,
+	// CompressionNone, CompressionLZ4 or CompressionZstd. It takes
+	// precedence over UseCompression.
+	Compression string
+
+	// CompressionLevel is the codec specific compression level. A value
+	// of 0 selects the codec's default level.
+	CompressionLevel int
 }
 
 // userConfig holds the parameters for a disk queue that are configurable
@@ -80,6 +89,9 @@ type userConfig struct {
 
 	RetryInterval    *time.Duration `config:"retry_interval" validate:"positive"`
 	MaxRetryInterval *time.Duration `config:"max_retry_interval" validate:"positive"`
+
+	Compression      string `config:"compression"`
+	CompressionLevel int    `config:"compression_level"`
 }
 
 func (c *userConfig) Validate() error {
@@ -112,6 +124,10 @@ func (c *userConfig) Validate() error {
 			*c.MaxRetryInterval, *c.RetryInterval)
 	}
 
+	if err := validateCompression(c.Compression, c.CompressionLevel); err != nil {
+		return fmt.Errorf("disk queue %w", err)
+	}
+
 	return nil
 }
 
@@ -163,6 +179,9 @@ func SettingsForUserConfig(config *config.C) (Settings, error) {
 		settings.MaxRetryInterval = *userConfig.MaxRetryInterval
 	}
 
+	settings.Compression = userConfig.Compression
+	settings.CompressionLevel = userConfig.CompressionLevel
+
 	return settings, nil
 }
 
@@ -187,6 +206,17 @@ func (settings Settings) segmentPath(segmentID segmentID) string {
 		fmt.Sprintf("%v.seg", segmentID))
 }
 
+// compression returns the codec new segment files are compressed with.
+func (settings Settings) compression() string {
+	if settings.Compression != "" {
+		return settings.Compression
+	}
+	if settings.UseCompression {
+		return CompressionLZ4
+	}
+	return CompressionNone
+}
+
 // maxValidFrameSize returns the size of the largest possible frame that
 // can be stored with the current queue settings.
 func (settings Settings) maxValidFrameSize() uint64 {
@@ -121,7 +121,8 @@ type queueSegment struct {
 }
 
 type segmentHeader struct {
-	// The schema version for this segment file. Current schema version is 2.
+	// The schema version for this segment file. Current schema version is 3
+	// for zstd compressed segments and 2 for all others.
 	version uint32
 
 	// If the segment file has been completely written, this field contains
@@ -133,6 +134,13 @@ type segmentHeader struct {
 
 	// options holds flags to enable features, for example compression.
 	options uint32
+
+	// If the zstd compressed segment file has been completely written, this
+	// field contains the size of its uncompressed data, which positions in
+	// the segment refer to. If the segment file has not been completely
+	// written, this field is zero.
+	// Only present in schema version >= 3.
+	dataSize uint64
 }
 
 type WriteCloseSyncer interface {
@@ -141,20 +149,53 @@ type WriteCloseSyncer interface {
 	Sync() error
 }
 
-const currentSegmentVersion = 2
+const currentSegmentVersion = 3
 
 // Segment headers are currently a 4-byte version, a 4-byte frame count and 1-byte options.
 // In contexts where the segment may have been created by an earlier version,
 // instead use (queueSegment).headerSize() which accounts for the schema
 // version of the target segment.
+//
+// The headers of zstd compressed segments additionally hold the 8-byte size
+// of the uncompressed data. Positions in compressed segments still count a
+// header of segmentHeaderSize bytes, followed by the uncompressed data.
 const segmentHeaderSize = 12
 
+// compressedSegmentHeaderSize is the size on disk of the header of zstd
+// compressed segments, which is followed by the compressed data.
+const compressedSegmentHeaderSize = segmentHeaderSize + 8
+
 const (
 	_                  uint32 = 1 << iota // 0x1
 	ENABLE_COMPRESSION                    // 0x2
 	ENABLE_PROTOBUF                       // 0x4
+	ENABLE_ZSTD                           // 0x8
 )
 
+// compression returns the codec the segment data is compressed with.
+func (header *segmentHeader) compression() string {
+	switch {
+	case (header.options & ENABLE_COMPRESSION) == ENABLE_COMPRESSION:
+		return CompressionLZ4
+	case (header.options & ENABLE_ZSTD) == ENABLE_ZSTD:
+		return CompressionZstd
+	}
+	return CompressionNone
+}
+
+// size returns the size on disk of the header.
+func (header *segmentHeader) size() int64 {
+	switch header.version {
+	case 0:
+		return 4
+	case 1:
+		return 8
+	case 2:
+		return segmentHeaderSize
+	}
+	return compressedSegmentHeaderSize
+}
+
 // Sort order: we store loaded segments in ascending order by their id.
 type bySegmentID []*queueSegment
 
@@ -197,11 +238,28 @@ func scanExistingSegments(logger *logp.Logger, pathStr string) ([]*queueSegment,
 						"error loading segment file '%v', data may be incomplete: %v",
 						fullPath, err)
 				}
+				// Positions in compressed segments refer to the uncompressed
+				// data, whose size is stored in the header once the segment
+				// is completely written. It is only computed by decompressing
+				// segments that were not closed cleanly, or written before
+				// the size was stored.
+				byteCount := uint64(file.Size())
+				switch {
+				case header.dataSize > 0:
+					byteCount = segmentHeaderSize + header.dataSize
+				case header.compression() != CompressionNone:
+					byteCount, err = uncompressedSegmentSize(fullPath, header)
+					if err != nil {
+						logger.Warnf(
+							"error reading compressed segment file '%v', data may be incomplete: %v",
+							fullPath, err)
+					}
+				}
 				segments = append(segments, &queueSegment{
 					id:            segmentID(id),
 					schemaVersion: &header.version,
 					frameCount:    header.frameCount,
-					byteCount:     uint64(file.Size()),
+					byteCount:     byteCount,
 				})
 			}
 		}
@@ -244,6 +302,7 @@ func (segment *queueSegment) getReader(queueSettings Settings) (*segmentReader,
 
 	sr := &segmentReader{}
 	sr.src = file
+	sr.dataOffset = header.size()
 
 	if header.version == 0 {
 		sr.serializationFormat = SerializationJSON
@@ -255,8 +314,13 @@ func (segment *queueSegment) getReader(queueSettings Settings) (*segmentReader,
 		sr.serializationFormat = SerializationCBOR
 	}
 
-	if (header.options & ENABLE_COMPRESSION) == ENABLE_COMPRESSION {
-		sr.cr = NewCompressionReader(sr.src)
+	if codec := header.compression(); codec != CompressionNone {
+		sr.cr, err = newCompressionReader(sr.src, codec)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf(
+				"couldn't set up decompression for segment %d: %w", segment.id, err)
+		}
 	}
 	return sr, nil
 }
@@ -272,8 +336,12 @@ func (segment *queueSegment) getWriter(queueSettings Settings) (*segmentWriter,
 		return nil, err
 	}
 
-	if queueSettings.UseCompression {
+	codec := queueSettings.compression()
+	switch codec {
+	case CompressionLZ4:
 		options = options | ENABLE_COMPRESSION
+	case CompressionZstd:
+		options = options | ENABLE_ZSTD
 	}
 
 	sw := &segmentWriter{}
@@ -283,8 +351,12 @@ func (segment *queueSegment) getWriter(queueSettings Settings) (*segmentWriter,
 		return nil, err
 	}
 
-	if (options & ENABLE_COMPRESSION) == ENABLE_COMPRESSION {
-		sw.cw = NewCompressionWriter(sw.dst)
+	if codec != CompressionNone {
+		sw.cw, err = newCompressionWriter(sw.dst, codec, queueSettings.CompressionLevel)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
 	}
 
 	return sw, nil
@@ -343,9 +415,21 @@ func readSegmentHeaderWithFrameCount(path string) (*segmentHeader, error) {
 	//   and still has the placeholder value of 0.
 	// In either case, the right thing to do is to scan the file
 	// and fill in the frame count manually.
+	// Compressed segments are scanned through the decompressor, as the
+	// frames are only readable after decompression.
+	var data io.Reader = reader
+	codec := header.compression()
+	if codec != CompressionNone {
+		cr, err := newCompressionReader(file, codec)
+		if err != nil {
+			return nil, err
+		}
+		defer cr.Close()
+		data = autoRetryReader{cr}
+	}
 	for {
 		var frameLength uint32
-		err = binary.Read(reader, binary.LittleEndian, &frameLength)
+		err = binary.Read(data, binary.LittleEndian, &frameLength)
 		if err != nil {
 			// EOF at a frame boundary means we successfully scanned all frames.
 			if errors.Is(err, io.EOF) && header.frameCount > 0 {
@@ -359,12 +443,16 @@ func readSegmentHeaderWithFrameCount(path string) (*segmentHeader, error) {
 		// the current frame to make sure the trailing length matches before
 		// advancing to the next frame (otherwise we might accept an impossible
 		// length).
-		_, err = file.Seek(int64(frameLength-8), io.SeekCurrent)
+		if codec == CompressionNone {
+			_, err = file.Seek(int64(frameLength-8), io.SeekCurrent)
+		} else {
+			_, err = io.CopyN(io.Discard, data, int64(frameLength-8))
+		}
 		if err != nil {
 			break
 		}
 		var duplicateLength uint32
-		err = binary.Read(reader, binary.LittleEndian, &duplicateLength)
+		err = binary.Read(data, binary.LittleEndian, &duplicateLength)
 		if err != nil {
 			break
 		}
@@ -385,6 +473,30 @@ func readSegmentHeaderWithFrameCount(path string) (*segmentHeader, error) {
 	return nil, err
 }
 
+// uncompressedSegmentSize returns the logical size of a compressed
+// segment file, that is its header plus its decompressed data. If the
+// data can't be fully decompressed, for example because the segment
+// was not closed cleanly, it returns the size up to the error along
+// with the error.
+func uncompressedSegmentSize(path string, header *segmentHeader) (uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"couldn't open segment file '%s': %w", path, err)
+	}
+	defer file.Close()
+	if _, err := file.Seek(header.size(), io.SeekStart); err != nil {
+		return 0, fmt.Errorf("could not seek past segment header: %w", err)
+	}
+	cr, err := newCompressionReader(file, header.compression())
+	if err != nil {
+		return 0, err
+	}
+	defer cr.Close()
+	n, err := io.Copy(io.Discard, cr)
+	return segmentHeaderSize + uint64(n), err
+}
+
 // readSegmentHeader decodes a raw header from the given reader and
 // returns it as a struct.
 func readSegmentHeader(in io.Reader) (*segmentHeader, error) {
@@ -410,6 +522,12 @@ func readSegmentHeader(in io.Reader) (*segmentHeader, error) {
 			return nil, fmt.Errorf("could not read segment options: %w", err)
 		}
 	}
+	if header.version >= 3 {
+		err = binary.Read(in, binary.LittleEndian, &header.dataSize)
+		if err != nil {
+			return nil, fmt.Errorf("could not read segment data size: %w", err)
+		}
+	}
 
 	return header, nil
 }
@@ -445,6 +563,9 @@ type segmentReader struct {
 	src                 io.ReadSeekCloser
 	cr                  *CompressionReader
 	serializationFormat SerializationFormat
+
+	// dataOffset is the size on disk of the segment header.
+	dataOffset int64
 }
 
 func (r *segmentReader) Read(p []byte) (int, error) {
@@ -467,7 +588,7 @@ func (r *segmentReader) Seek(offset int64, whence int) (int64, error) {
 		if (offset + int64(whence)) < segmentHeaderSize {
 			return 0, fmt.Errorf("illegal seek offset %d, whence %d", offset, whence)
 		}
-		if _, err := r.src.Seek(segmentHeaderSize, io.SeekStart); err != nil {
+		if _, err := r.src.Seek(r.dataOffset, io.SeekStart); err != nil {
 			return 0, fmt.Errorf("could not seek past segment header: %w", err)
 		}
 		if err := r.cr.Reset(); err != nil {
@@ -489,11 +610,20 @@ func (r *segmentReader) Seek(offset int64, whence int) (int64, error) {
 type segmentWriter struct {
 	dst *os.File
 	cw  *CompressionWriter
+
+	// version is the schema version of the segment header.
+	version uint32
+
+	// dataSize is the number of uncompressed bytes written to a compressed
+	// segment.
+	dataSize uint64
 }
 
 func (w *segmentWriter) Write(p []byte) (int, error) {
 	if w.cw != nil {
-		return w.cw.Write(p)
+		n, err := w.cw.Write(p)
+		w.dataSize += uint64(n)
+		return n, err
 	}
 	return w.dst.Write(p)
 }
@@ -518,8 +648,16 @@ func (w *segmentWriter) WriteHeader(options uint32) error {
 		return fmt.Errorf("could not seek to beginning of segment: %w", err)
 	}
 
+	// Only zstd compressed segments use version 3. Uncompressed and LZ4
+	// segments are still written as version 2, so they can be read after a
+	// downgrade to a version that doesn't know version 3.
+	w.version = 2
+	if (&segmentHeader{options: options}).compression() == CompressionZstd {
+		w.version = 3
+	}
+
 	//write version
-	err = binary.Write(w.dst, binary.LittleEndian, uint32(2))
+	err = binary.Write(w.dst, binary.LittleEndian, w.version)
 	if err != nil {
 		return fmt.Errorf("could not write version to segment: %w", err)
 	}
@@ -536,9 +674,19 @@ func (w *segmentWriter) WriteHeader(options uint32) error {
 		return fmt.Errorf("could not write options to segment: %w", err)
 	}
 
+	if w.version >= 3 {
+		//write data size
+		err = binary.Write(w.dst, binary.LittleEndian, uint64(0))
+		if err != nil {
+			return fmt.Errorf("could not write data size to segment: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// UpdateCount writes the frame count to the segment header, along with the
+// size of the uncompressed data for zstd compressed segments.
 func (w *segmentWriter) UpdateCount(count uint32) error {
 	//get current offset
 	offset, err := w.dst.Seek(0, io.SeekCurrent)
@@ -555,6 +703,17 @@ func (w *segmentWriter) UpdateCount(count uint32) error {
 	if err != nil {
 		return fmt.Errorf("cound not write count: %w", err)
 	}
+	if w.version >= 3 {
+		// Skip the options and write the data size
+		_, err = w.dst.Seek(segmentHeaderSize, io.SeekStart)
+		if err != nil {
+			return fmt.Errorf("cound not seek to data size position: %w", err)
+		}
+		err = binary.Write(w.dst, binary.LittleEndian, w.dataSize)
+		if err != nil {
+			return fmt.Errorf("cound not write data size: %w", err)
+		}
+	}
 	// Return to previous location
 	_, err = w.dst.Seek(offset, io.SeekStart)
 	if err != nil {
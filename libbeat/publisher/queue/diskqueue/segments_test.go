@@ -18,17 +18,23 @@
 package diskqueue
 
 import (
+	"encoding/binary"
 	"io"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
 )
 
 func TestSegmentsRoundTrip(t *testing.T) {
 	tests := map[string]struct {
-		id        segmentID
-		compress  bool
-		plaintext []byte
+		id          segmentID
+		compress    bool
+		compression string
+		plaintext   []byte
 	}{
 		"No Compression": {
 			id:        0,
@@ -40,6 +46,11 @@ func TestSegmentsRoundTrip(t *testing.T) {
 			compress:  true,
 			plaintext: []byte("compression only"),
 		},
+		"With Zstd Compression": {
+			id:          3,
+			compression: CompressionZstd,
+			plaintext:   []byte("zstd compression only"),
+		},
 	}
 	dir := t.TempDir()
 	for name, tc := range tests {
@@ -47,6 +58,7 @@ func TestSegmentsRoundTrip(t *testing.T) {
 		settings := DefaultSettings()
 		settings.Path = dir
 		settings.UseCompression = tc.compress
+		settings.Compression = tc.compression
 		qs := &queueSegment{
 			id: tc.id,
 		}
@@ -82,9 +94,10 @@ func TestSegmentsRoundTrip(t *testing.T) {
 
 func TestSegmentReaderSeek(t *testing.T) {
 	tests := map[string]struct {
-		id         segmentID
-		compress   bool
-		plaintexts [][]byte
+		id          segmentID
+		compress    bool
+		compression string
+		plaintexts  [][]byte
 	}{
 		"No Compression": {
 			id:         0,
@@ -96,12 +109,18 @@ func TestSegmentReaderSeek(t *testing.T) {
 			compress:   true,
 			plaintexts: [][]byte{[]byte("abc"), []byte("defg")},
 		},
+		"With Zstd Compression": {
+			id:          3,
+			compression: CompressionZstd,
+			plaintexts:  [][]byte{[]byte("abc"), []byte("defg")},
+		},
 	}
 	dir := t.TempDir()
 	for name, tc := range tests {
 		settings := DefaultSettings()
 		settings.Path = dir
 		settings.UseCompression = tc.compress
+		settings.Compression = tc.compression
 
 		qs := &queueSegment{
 			id: tc.id,
@@ -175,3 +194,66 @@ func TestSegmentReaderSeekLocations(t *testing.T) {
 		assert.NotNil(t, err, name)
 	}
 }
+
+func TestScanExistingCompressedSegments(t *testing.T) {
+	frames := [][]byte{[]byte("first event"), []byte("second event")}
+	dataSize := uint64(0)
+	for _, frame := range frames {
+		dataSize += uint64(len(frame)) + frameMetadataSize
+	}
+
+	for _, codec := range []string{CompressionNone, CompressionLZ4, CompressionZstd} {
+		for _, closed := range []bool{true, false} {
+			dir := t.TempDir()
+			settings := DefaultSettings()
+			settings.Path = dir
+			settings.Compression = codec
+			settings.CompressionLevel = 3
+
+			qs := &queueSegment{id: 1}
+			sw, err := qs.getWriter(settings)
+			require.NoError(t, err)
+			for _, frame := range frames {
+				frameLength := uint32(len(frame)) + frameMetadataSize
+				require.NoError(t, binary.Write(sw, binary.LittleEndian, frameLength))
+				_, err = sw.Write(frame)
+				require.NoError(t, err)
+				require.NoError(t, binary.Write(sw, binary.LittleEndian, computeChecksum(frame)))
+				require.NoError(t, binary.Write(sw, binary.LittleEndian, frameLength))
+			}
+			require.NoError(t, sw.Sync())
+			// A segment that was not closed cleanly keeps a frame count of 0,
+			// which makes the frame count get computed by scanning the frames.
+			if closed {
+				require.NoError(t, sw.UpdateCount(uint32(len(frames))))
+			}
+			require.NoError(t, sw.Close())
+
+			// Closed zstd segments store the size of their uncompressed data,
+			// so it is not computed by decompressing them. LZ4 segments are
+			// still written as version 2, and their size is computed by
+			// decompressing them.
+			f, err := os.Open(settings.segmentPath(qs.id))
+			require.NoError(t, err)
+			header, err := readSegmentHeader(f)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+			if codec == CompressionZstd {
+				assert.Equal(t, uint32(3), header.version, "codec %s", codec)
+			} else {
+				assert.Equal(t, uint32(2), header.version, "codec %s", codec)
+			}
+			if codec == CompressionZstd && closed {
+				assert.Equal(t, dataSize, header.dataSize, "codec %s", codec)
+			} else {
+				assert.Zero(t, header.dataSize, "codec %s, closed %v", codec, closed)
+			}
+
+			segments, err := scanExistingSegments(logptest.NewTestingLogger(t, ""), dir)
+			require.NoError(t, err)
+			require.Len(t, segments, 1, "codec %s, closed %v", codec, closed)
+			assert.Equal(t, uint32(len(frames)), segments[0].frameCount, "codec %s, closed %v", codec, closed)
+			assert.Equal(t, segmentHeaderSize+dataSize, segments[0].byteCount, "codec %s, closed %v", codec, closed)
+		}
+	}
+}
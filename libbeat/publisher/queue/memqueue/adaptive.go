@@ -0,0 +1,189 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package memqueue
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-sysinfo"
+)
+
+// AdaptiveSettings configures the adaptive mode of the queue, in which the
+// number of events the queue holds and returns from one Get request scale
+// with the memory use of the process.
+type AdaptiveSettings struct {
+	// The fewest events the queue will hold. The most it will hold is
+	// Settings.Events.
+	MinEvents int
+
+	// The memory available to the process in bytes. If 0, it is detected
+	// from the cgroup memory limit or the host's total memory.
+	MemoryLimit uint64
+
+	// The fraction of MemoryLimit the resident memory of the process
+	// should stay under.
+	MemoryTarget float64
+
+	// How often the memory use of the process is checked.
+	CheckInterval time.Duration
+}
+
+var defaultAdaptiveSettings = AdaptiveSettings{
+	MinEvents:     256,
+	MemoryTarget:  0.75,
+	CheckInterval: time.Second,
+}
+
+// adaptiveEventLimit returns the number of events the queue should accept,
+// given the resident memory of the process and the size of the events in
+// the queue. If the average event size is known, the limit is the number
+// of events fitting in the memory left below the target. Otherwise the
+// current limit is halved while memory use is above the target, and
+// doubled while it is below. The limit grows at most by a factor of 2 per
+// call to give memory use time to settle.
+func adaptiveEventLimit(
+	settings AdaptiveSettings,
+	maxEvents int,
+	currentLimit int,
+	rss uint64,
+	queueBytes uint64,
+	avgEventSize uint64,
+) int {
+	target := uint64(float64(settings.MemoryLimit) * settings.MemoryTarget)
+
+	limit := uint64(currentLimit) * 2
+	switch {
+	case avgEventSize > 0:
+		// The memory used by everything but the queued events
+		other := rss - min(rss, queueBytes)
+		if other >= target {
+			limit = 0
+		} else {
+			limit = min(limit, (target-other)/avgEventSize)
+		}
+	case rss > target:
+		limit = uint64(currentLimit) / 2
+	}
+
+	return int(max(min(limit, uint64(maxEvents)), uint64(settings.MinEvents)))
+}
+
+// memoryMonitor periodically reads the resident memory of the process and
+// sends it to the run loop, which adjusts the queue limits accordingly.
+type memoryMonitor struct {
+	broker *broker
+
+	// readMemory returns the resident memory of the process in bytes.
+	readMemory func() (uint64, error)
+
+	// The run loop receives memory readings from this channel.
+	memoryChan chan uint64
+}
+
+func newMemoryMonitor(broker *broker) *memoryMonitor {
+	return &memoryMonitor{
+		broker:     broker,
+		readMemory: processMemory,
+		memoryChan: make(chan uint64),
+	}
+}
+
+func (m *memoryMonitor) run() {
+	ticker := time.NewTicker(m.broker.settings.Adaptive.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.broker.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		rss, err := m.readMemory()
+		if err != nil {
+			m.broker.logger.Debugf("Could not read process memory: %v", err)
+			continue
+		}
+
+		select {
+		case <-m.broker.ctx.Done():
+			return
+		case m.memoryChan <- rss:
+		}
+	}
+}
+
+// processMemory returns the resident memory of the current process.
+func processMemory() (uint64, error) {
+	self, err := sysinfo.Self()
+	if err != nil {
+		return 0, err
+	}
+	mem, err := self.Memory()
+	if err != nil {
+		return 0, err
+	}
+	return mem.Resident, nil
+}
+
+// cgroup memory limit files, for cgroup v2 and v1.
+var cgroupMemoryLimitFiles = []string{
+	"/sys/fs/cgroup/memory.max",
+	"/sys/fs/cgroup/memory/memory.limit_in_bytes",
+}
+
+// detectMemoryLimit returns the cgroup memory limit of the process, or
+// the total memory of the host if there is no cgroup limit.
+func detectMemoryLimit() (uint64, error) {
+	host, err := sysinfo.Host()
+	if err != nil {
+		return 0, fmt.Errorf("could not read host info: %w", err)
+	}
+	hostMemory, err := host.Memory()
+	if err != nil {
+		return 0, fmt.Errorf("could not read host memory: %w", err)
+	}
+
+	for _, path := range cgroupMemoryLimitFiles {
+		limit, err := readCgroupMemoryLimit(path)
+		// cgroup v1 reports a huge value if there is no limit.
+		if err == nil && limit > 0 && limit < hostMemory.Total {
+			return limit, nil
+		}
+	}
+	if hostMemory.Total == 0 {
+		return 0, errors.New("host memory is unknown")
+	}
+	return hostMemory.Total, nil
+}
+
+func readCgroupMemoryLimit(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "max" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
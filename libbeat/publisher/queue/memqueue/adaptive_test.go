@@ -0,0 +1,177 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package memqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	c "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+func TestAdaptiveEventLimit(t *testing.T) {
+	settings := AdaptiveSettings{
+		MinEvents:    100,
+		MemoryLimit:  1000 * 1000,
+		MemoryTarget: 0.5,
+	}
+	tests := map[string]struct {
+		currentLimit int
+		rss          uint64
+		queueBytes   uint64
+		avgEventSize uint64
+		expected     int
+	}{
+		"unknown event size, memory below target grows": {
+			currentLimit: 200,
+			rss:          100 * 1000,
+			expected:     400,
+		},
+		"unknown event size, memory above target shrinks": {
+			currentLimit: 400,
+			rss:          600 * 1000,
+			expected:     200,
+		},
+		"growth is capped at max events": {
+			currentLimit: 800,
+			rss:          100 * 1000,
+			expected:     1000,
+		},
+		"shrinking is capped at min events": {
+			currentLimit: 150,
+			rss:          600 * 1000,
+			expected:     100,
+		},
+		"known event size fills the memory left": {
+			currentLimit: 1000,
+			rss:          400 * 1000,
+			queueBytes:   100 * 1000,
+			avgEventSize: 1000,
+			// 500KB target - 300KB used outside the queue = 200 events
+			expected: 200,
+		},
+		"known event size grows at most twice": {
+			currentLimit: 200,
+			rss:          100 * 1000,
+			queueBytes:   100 * 1000,
+			avgEventSize: 100,
+			expected:     400,
+		},
+		"memory used outside the queue above target": {
+			currentLimit: 500,
+			rss:          900 * 1000,
+			queueBytes:   100 * 1000,
+			avgEventSize: 1000,
+			expected:     100,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			limit := adaptiveEventLimit(
+				settings, 1000, tc.currentLimit, tc.rss, tc.queueBytes, tc.avgEventSize)
+			assert.Equal(t, tc.expected, limit)
+		})
+	}
+}
+
+func TestAdaptiveQueueScalesLimits(t *testing.T) {
+	broker := newQueue(
+		logptest.NewTestingLogger(t, ""),
+		nil,
+		Settings{
+			Events:        1000,
+			MaxGetRequest: 500,
+			FlushTimeout:  10 * time.Second,
+			Adaptive: &AdaptiveSettings{
+				MinEvents:     100,
+				MemoryLimit:   1000 * 1000,
+				MemoryTarget:  0.5,
+				CheckInterval: time.Second,
+			},
+		},
+		10, nil)
+	rl := broker.runLoop
+
+	// The queue starts at the minimum size
+	assert.Equal(t, 100, rl.eventLimit)
+	assert.Equal(t, 50, rl.maxGetRequest)
+
+	// And grows while memory is below the target
+	rl.handleMemoryReading(100 * 1000)
+	assert.Equal(t, 200, rl.eventLimit)
+	assert.Equal(t, 100, rl.maxGetRequest)
+
+	// Fill the queue up to its limit, push requests must then be disabled
+	producer := newProducer(broker, nil, nil)
+	for i := 0; i < 200; i++ {
+		// Pair each publish call with an iteration of the run loop, and
+		// wait for it to finish before the next one.
+		done := make(chan struct{})
+		go func() {
+			rl.runIteration()
+			close(done)
+		}()
+		_, ok := producer.Publish(i)
+		require.True(t, ok, "Queue publish call must succeed")
+		<-done
+	}
+	assert.Equal(t, 200, rl.eventCount)
+	go func() {
+		_, _ = producer.Publish("one too many")
+	}()
+
+	// Shrink when memory is above the target. The run loop must handle the
+	// memory reading, since it doesn't accept the pending event.
+	go func() {
+		broker.memoryMonitor.memoryChan <- 600 * 1000
+	}()
+	rl.runIteration()
+	assert.Equal(t, 200, rl.eventCount, "Queue must not accept events above its limit")
+	assert.Equal(t, 100, rl.eventLimit)
+	assert.Equal(t, 50, rl.maxGetRequest)
+}
+
+func TestAdaptiveUserConfig(t *testing.T) {
+	cfg := c.MustNewConfigFrom(map[string]interface{}{
+		"events":                10000,
+		"adaptive.enabled":      true,
+		"adaptive.min_events":   512,
+		"adaptive.memory_limit": "512MiB",
+	})
+	settings, err := SettingsForUserConfig(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Adaptive)
+	assert.Equal(t, 512, settings.Adaptive.MinEvents)
+	assert.Equal(t, uint64(512*1024*1024), settings.Adaptive.MemoryLimit)
+	assert.Equal(t, defaultAdaptiveSettings.MemoryTarget, settings.Adaptive.MemoryTarget)
+	assert.Equal(t, defaultAdaptiveSettings.CheckInterval, settings.Adaptive.CheckInterval)
+
+	settings, err = SettingsForUserConfig(c.MustNewConfigFrom(map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.Nil(t, settings.Adaptive, "adaptive mode must be disabled by default")
+
+	_, err = SettingsForUserConfig(c.MustNewConfigFrom(map[string]interface{}{
+		"adaptive.enabled":       true,
+		"adaptive.memory_target": 1.5,
+	}))
+	assert.Error(t, err)
+}
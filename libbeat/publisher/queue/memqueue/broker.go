@@ -93,6 +93,10 @@ type broker struct {
 
 	// The goroutine that manages ack notifications and callbacks
 	ackLoop *ackLoop
+
+	// The goroutine that reads the process memory in adaptive mode, nil
+	// otherwise
+	memoryMonitor *memoryMonitor
 }
 
 type Settings struct {
@@ -105,6 +109,10 @@ type Settings struct {
 	// If positive, the amount of time the queue will wait to fill up
 	// a batch if a Get request asks for more events than we have.
 	FlushTimeout time.Duration
+
+	// If set, the queue scales the number of events it holds, and
+	// MaxGetRequest with it, based on the memory use of the process.
+	Adaptive *AdaptiveSettings
 }
 
 type queueEntry struct {
@@ -171,6 +179,13 @@ func NewQueue(
 		defer b.wg.Done()
 		b.ackLoop.run()
 	}()
+	if b.memoryMonitor != nil {
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			b.memoryMonitor.run()
+		}()
+	}
 
 	return b
 }
@@ -209,6 +224,19 @@ func newQueue(
 		logger = logp.NewLogger("memqueue")
 	}
 
+	// The adaptive mode needs a memory limit to scale against.
+	if settings.Adaptive != nil && settings.Adaptive.MemoryLimit == 0 {
+		limit, err := detectMemoryLimit()
+		if err != nil {
+			logger.Warnf("Disabling adaptive memory queue, could not detect the memory limit: %v", err)
+			settings.Adaptive = nil
+		} else {
+			adaptive := *settings.Adaptive
+			adaptive.MemoryLimit = limit
+			settings.Adaptive = &adaptive
+		}
+	}
+
 	b := &broker{
 		settings: settings,
 		logger:   logger,
@@ -229,10 +257,13 @@ func newQueue(
 	}
 	b.ctx, b.ctxCancel = context.WithCancel(context.Background())
 
+	if settings.Adaptive != nil {
+		b.memoryMonitor = newMemoryMonitor(b)
+	}
 	b.runLoop = newRunLoop(b, observer)
 	b.ackLoop = newACKLoop(b)
 
-	observer.MaxEvents(settings.Events)
+	observer.MaxEvents(b.runLoop.eventLimit)
 
 	return b
 }
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
 	c "github.com/elastic/elastic-agent-libs/config"
 )
 
@@ -33,18 +34,43 @@ type config struct {
 	// since it used to control buffer size in the internal buffer chain.
 	MaxGetRequest int           `config:"flush.min_events" validate:"min=0"`
 	FlushTimeout  time.Duration `config:"flush.timeout"`
+
+	Adaptive adaptiveConfig `config:"adaptive"`
+}
+
+// adaptiveConfig holds the user settings of the adaptive mode, see
+// AdaptiveSettings.
+type adaptiveConfig struct {
+	Enabled       bool             `config:"enabled"`
+	MinEvents     int              `config:"min_events" validate:"min=32"`
+	MemoryLimit   cfgtype.ByteSize `config:"memory_limit"`
+	MemoryTarget  float64          `config:"memory_target"`
+	CheckInterval time.Duration    `config:"check_interval" validate:"positive"`
 }
 
 var defaultConfig = config{
 	Events:        3200,
 	MaxGetRequest: 1600,
 	FlushTimeout:  10 * time.Second,
+	Adaptive: adaptiveConfig{
+		MinEvents:     defaultAdaptiveSettings.MinEvents,
+		MemoryTarget:  defaultAdaptiveSettings.MemoryTarget,
+		CheckInterval: defaultAdaptiveSettings.CheckInterval,
+	},
 }
 
 func (c *config) Validate() error {
 	if c.MaxGetRequest > c.Events {
 		return errors.New("flush.min_events must be less events")
 	}
+	if c.Adaptive.Enabled {
+		if c.Adaptive.MinEvents > c.Events {
+			return errors.New("adaptive.min_events must be less than events")
+		}
+		if c.Adaptive.MemoryTarget <= 0 || c.Adaptive.MemoryTarget > 1 {
+			return fmt.Errorf("adaptive.memory_target (%v) must be between 0 and 1", c.Adaptive.MemoryTarget)
+		}
+	}
 	return nil
 }
 
@@ -57,10 +83,20 @@ func SettingsForUserConfig(cfg *c.C) (Settings, error) {
 			return Settings{}, fmt.Errorf("couldn't unpack memory queue config: %w", err)
 		}
 	}
+	var adaptive *AdaptiveSettings
+	if config.Adaptive.Enabled {
+		adaptive = &AdaptiveSettings{
+			MinEvents:     config.Adaptive.MinEvents,
+			MemoryLimit:   uint64(config.Adaptive.MemoryLimit),
+			MemoryTarget:  config.Adaptive.MemoryTarget,
+			CheckInterval: config.Adaptive.CheckInterval,
+		}
+	}
 	//nolint:gosimple // Actually want this conversion to be explicit since the types aren't definitionally equal.
 	return Settings{
 		Events:        config.Events,
 		MaxGetRequest: config.MaxGetRequest,
 		FlushTimeout:  config.FlushTimeout,
+		Adaptive:      adaptive,
 	}, nil
 }
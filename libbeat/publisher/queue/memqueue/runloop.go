@@ -40,6 +40,20 @@ type runLoop struct {
 	// The total number of events in the queue.
 	eventCount int
 
+	// The total size of the events in the queue, as reported by the
+	// producers' encoders.
+	eventBytes int
+
+	// The number of events the queue accepts, and the most events returned
+	// from one Get request. These are the configured Events and
+	// MaxGetRequest, unless the adaptive mode scaled them down.
+	eventLimit    int
+	maxGetRequest int
+
+	// The average size of the events seen in adaptive mode, kept to size
+	// the queue while it is empty.
+	avgEventSize int
+
 	// The number of consumed events waiting for acknowledgment. The next Get
 	// request will return events starting at position
 	// (bufPos + consumedCount) % len(buf).
@@ -82,11 +96,18 @@ func newRunLoop(broker *broker, observer queue.Observer) *runLoop {
 			<-timer.C
 		}
 	}
-	return &runLoop{
-		broker:   broker,
-		observer: observer,
-		getTimer: timer,
+	l := &runLoop{
+		broker:        broker,
+		observer:      observer,
+		getTimer:      timer,
+		eventLimit:    len(broker.buf),
+		maxGetRequest: broker.settings.MaxGetRequest,
+	}
+	// In adaptive mode, start small and grow while memory allows.
+	if adaptive := broker.settings.Adaptive; adaptive != nil {
+		l.setEventLimit(min(adaptive.MinEvents, len(broker.buf)))
 	}
+	return l
 }
 
 func (l *runLoop) run() {
@@ -100,7 +121,7 @@ func (l *runLoop) run() {
 func (l *runLoop) runIteration() {
 	var pushChan chan pushRequest
 	// Push requests are enabled if the queue isn't full or closing.
-	if l.eventCount < len(l.broker.buf) && !l.closing {
+	if l.eventCount < l.eventLimit && !l.closing {
 		pushChan = l.broker.pushChan
 	}
 
@@ -124,6 +145,12 @@ func (l *runLoop) runIteration() {
 		timeoutChan = l.getTimer.C
 	}
 
+	var memoryChan chan uint64
+	// Memory readings are only sent in adaptive mode
+	if l.broker.memoryMonitor != nil {
+		memoryChan = l.broker.memoryMonitor.memoryChan
+	}
+
 	select {
 	case <-l.broker.closeChan:
 		l.closing = true
@@ -154,6 +181,9 @@ func (l *runLoop) runIteration() {
 		l.getTimer.Stop()
 		l.handleGetReply(l.pendingGetRequest)
 		l.pendingGetRequest = nil
+
+	case rss := <-memoryChan:
+		l.handleMemoryReading(rss)
 	}
 
 	// Check for final shutdown (if we are closing and the event buffer is
@@ -164,8 +194,8 @@ func (l *runLoop) runIteration() {
 }
 
 func (l *runLoop) handleGetRequest(req *getRequest) {
-	if req.entryCount <= 0 || req.entryCount > l.broker.settings.MaxGetRequest {
-		req.entryCount = l.broker.settings.MaxGetRequest
+	if req.entryCount <= 0 || req.entryCount > l.maxGetRequest {
+		req.entryCount = l.maxGetRequest
 	}
 	if l.getRequestShouldBlock(req) {
 		l.pendingGetRequest = req
@@ -219,9 +249,40 @@ func (l *runLoop) handleDelete(count int) {
 	l.bufPos = (l.bufPos + count) % len(l.broker.buf)
 	l.eventCount -= count
 	l.consumedCount -= count
+	l.eventBytes -= byteCount
 	l.observer.RemoveEvents(count, byteCount)
 }
 
+// handleMemoryReading scales the queue limits to the resident memory of
+// the process in adaptive mode.
+func (l *runLoop) handleMemoryReading(rss uint64) {
+	if l.eventCount > 0 && l.eventBytes > 0 {
+		l.avgEventSize = l.eventBytes / l.eventCount
+	}
+	limit := adaptiveEventLimit(
+		*l.broker.settings.Adaptive,
+		len(l.broker.buf),
+		l.eventLimit,
+		rss,
+		uint64(l.eventBytes),
+		uint64(l.avgEventSize))
+	if limit == l.eventLimit {
+		return
+	}
+	l.broker.logger.Debugf(
+		"Adaptive memory queue: resident memory is %d bytes, changing the event limit from %d to %d",
+		rss, l.eventLimit, limit)
+	l.setEventLimit(limit)
+}
+
+// setEventLimit sets the number of events the queue accepts, and scales
+// the most events returned from one Get request by the same ratio.
+func (l *runLoop) setEventLimit(limit int) {
+	l.eventLimit = limit
+	l.maxGetRequest = max(1, l.broker.settings.MaxGetRequest*limit/len(l.broker.buf))
+	l.observer.MaxEvents(limit)
+}
+
 func (l *runLoop) handleInsert(req *pushRequest) {
 	l.insert(req, l.nextEntryID)
 	// Send back the new event id.
@@ -258,5 +319,6 @@ func (l *runLoop) insert(req *pushRequest, id queue.EntryID) {
 		producer:   req.producer,
 		producerID: req.producerID,
 	}
+	l.eventBytes += req.eventSize
 	l.observer.AddEvent(req.eventSize)
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
 	"github.com/elastic/beats/v7/libbeat/management"
+	"github.com/elastic/beats/v7/libbeat/management/status"
 	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/mb/module"
@@ -204,8 +205,13 @@ func (bt *Metricbeat) Run(b *beat.Beat) error {
 
 	factory := module.NewFactory(b.Info, b.Monitoring, bt.registry, moduleOptions...)
 
+	// The health of the modules is tracked from their status updates. When
+	// running as a beat receiver the status is reported by the status
+	// factory wrapper instead.
+	healthRegistry := b.Monitoring.Health()
 	if bt.otelStatusFactoryWrapper != nil {
 		factory = bt.otelStatusFactoryWrapper(factory)
+		healthRegistry = nil
 	}
 
 	runners := make(map[uint64]cfgfile.Runner) // Active list of module runners.
@@ -229,6 +235,9 @@ func (bt *Metricbeat) Run(b *beat.Beat) error {
 		if err != nil {
 			return err
 		}
+		if runnerWithStatus, ok := runner.(status.WithStatusReporter); ok && healthRegistry != nil {
+			runnerWithStatus.SetStatusReporter(cfgfile.NewHealthReporter(healthRegistry, moduleCfg, runner))
+		}
 
 		runners[id] = runner
 	}
@@ -270,6 +279,7 @@ func (bt *Metricbeat) Run(b *beat.Beat) error {
 	// Centrally managed modules
 	factory = module.NewFactory(b.Info, b.Monitoring, bt.registry, bt.moduleOptions...)
 	modules := cfgfile.NewRunnerList(management.DebugK, factory, b.Publisher, bt.logger)
+	modules.SetHealthRegistry(b.Monitoring.Health())
 	b.Registry.MustRegisterInput(modules)
 	wg.Add(1)
 	go func() {
@@ -288,6 +298,7 @@ func (bt *Metricbeat) Run(b *beat.Beat) error {
 	// Dynamic file based modules (metricbeat.config.modules)
 	if bt.config.ConfigModules.Enabled() {
 		moduleReloader := cfgfile.NewReloader(bt.logger.Named("module.reload"), b.Publisher, bt.config.ConfigModules)
+		moduleReloader.SetHealthRegistry(b.Monitoring.Health())
 
 		if err := moduleReloader.Check(factory); err != nil {
 			return err
@@ -32,6 +32,12 @@ type Config struct {
 	Transport httpcommon.HTTPTransportSettings `config:",inline"`
 }
 
+// DefaultConfig returns the default configuration of the HTTP helper, to be
+// completed with the configuration of the module
+func DefaultConfig() Config {
+	return defaultConfig()
+}
+
 func defaultConfig() Config {
 	transport := httpcommon.DefaultHTTPTransportSettings()
 	transport.Timeout = 10 * time.Second
@@ -103,6 +103,16 @@ func OpMultiplyBuckets(multiplier float64) MetricOption {
 	}
 }
 
+// OpMultiplyBucketsRounded multiplies bucket labels in histograms like OpMultiplyBuckets,
+// and rounds the multiplied labels to 15 significant digits to discard the floating point
+// error of the multiplication, as in 9.999999999999999e-05 * 1e9 = 99999.99999999999
+func OpMultiplyBucketsRounded(multiplier float64) MetricOption {
+	return opMultiplyBuckets{
+		multiplier: multiplier,
+		round:      true,
+	}
+}
+
 // OpSetSuffix extends the field's name with the given suffix if the value of the metric
 // is numeric (and not histogram or quantile), otherwise does nothing
 func OpSetNumericMetricSuffix(suffix string) MetricOption {
@@ -365,6 +375,7 @@ func (o opLowercaseValue) Process(field string, value interface{}, labels mapstr
 
 type opMultiplyBuckets struct {
 	multiplier float64
+	round      bool
 }
 
 // Process will multiply the bucket labels if it is an histogram with numeric labels
@@ -384,7 +395,11 @@ func (o opMultiplyBuckets) Process(field string, value interface{}, labels mapst
 	multiplied := mapstr.M{}
 	for k, v := range bucket {
 		if f, err := strconv.ParseFloat(k, 64); err == nil {
-			key := strconv.FormatFloat(f*o.multiplier, 'f', -1, 64)
+			m := f * o.multiplier
+			if o.round {
+				m, _ = strconv.ParseFloat(strconv.FormatFloat(m, 'g', 15, 64), 64)
+			}
+			key := strconv.FormatFloat(m, 'f', -1, 64)
 			multiplied[key] = v
 		} else {
 			multiplied[k] = v
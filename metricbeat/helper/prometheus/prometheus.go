@@ -33,6 +33,10 @@ import (
 
 const acceptHeader = `text/plain;version=0.0.4;q=0.5,*/*;q=0.1`
 
+// ProtobufAcceptHeader requests the protobuf exposition format, that is needed
+// to receive native histograms, and falls back to the text format.
+const ProtobufAcceptHeader = ProtobufType + `;proto=` + protobufMessage + `;encoding=delimited,` + acceptHeader
+
 // Prometheus helper retrieves prometheus formatted metrics
 type Prometheus interface {
 	// GetHttp returns the HTTP Client that handles the connection towards remote endpoint
@@ -64,9 +68,14 @@ func NewPrometheusClient(base mb.BaseMetricSet) (Prometheus, error) {
 		return nil, err
 	}
 
+	return NewPrometheusClientFromHTTP(http, base.Logger()), nil
+}
+
+// NewPrometheusClientFromHTTP creates new prometheus helper fetching metrics with the given HTTP helper
+func NewPrometheusClientFromHTTP(http *helper.HTTP, logger *logp.Logger) Prometheus {
 	http.SetHeaderDefault("Accept", acceptHeader)
 	http.SetHeaderDefault("Accept-Encoding", "gzip")
-	return &prometheus{http, base.Logger()}, nil
+	return &prometheus{http, logger}
 }
 
 // GetHttp returns HTTP Client
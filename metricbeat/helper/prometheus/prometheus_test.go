@@ -62,6 +62,12 @@ histogram_decimal_metric_bucket{le="1"} 3
 histogram_decimal_metric_bucket{le="+Inf"} 5
 histogram_decimal_metric_sum 4.31
 histogram_decimal_metric_count 5
+# TYPE histogram_seconds_metric histogram
+histogram_seconds_metric_bucket{le="1e-08"} 1
+histogram_seconds_metric_bucket{le="9.999999999999999e-05"} 2
+histogram_seconds_metric_bucket{le="+Inf"} 3
+histogram_seconds_metric_sum 0.5
+histogram_seconds_metric_count 3
 
 `
 
@@ -518,6 +524,52 @@ func TestPrometheus(t *testing.T) {
 				},
 			},
 		},
+		{
+			msg: "Histogram seconds metric not rounded",
+			mapping: &MetricsMapping{
+				Metrics: map[string]MetricMap{
+					"histogram_seconds_metric": Metric("histogram.metric", OpMultiplyBuckets(1000000000)),
+				},
+			},
+			expected: []mapstr.M{
+				mapstr.M{
+					"histogram": mapstr.M{
+						"metric": mapstr.M{
+							"count": uint64(3),
+							"bucket": mapstr.M{
+								"10":                uint64(1),
+								"99999.99999999999": uint64(2),
+								"+Inf":              uint64(3),
+							},
+							"sum": 500000000.0,
+						},
+					},
+				},
+			},
+		},
+		{
+			msg: "Histogram seconds metric",
+			mapping: &MetricsMapping{
+				Metrics: map[string]MetricMap{
+					"histogram_seconds_metric": Metric("histogram.metric", OpMultiplyBucketsRounded(1000000000)),
+				},
+			},
+			expected: []mapstr.M{
+				mapstr.M{
+					"histogram": mapstr.M{
+						"metric": mapstr.M{
+							"count": uint64(3),
+							"bucket": mapstr.M{
+								"10":     uint64(1),
+								"100000": uint64(2),
+								"+Inf":   uint64(3),
+							},
+							"sum": 500000000.0,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -29,6 +29,7 @@ import (
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/textparse"
 	"github.com/prometheus/prometheus/model/timestamp"
@@ -42,6 +43,8 @@ const (
 	TextVersion                  = "0.0.4"
 	OpenMetricsType              = `application/openmetrics-text`
 	ContentTypeTextFormat string = `text/plain; version=` + TextVersion + `; charset=utf-8`
+	ProtobufType                 = `application/vnd.google.protobuf`
+	protobufMessage              = `io.prometheus.client.MetricFamily`
 )
 
 type Gauge struct {
@@ -561,6 +564,18 @@ func ParseMetricFamilies(b []byte, contentType string, ts time.Time, logger *log
 			continue
 		case textparse.EntryComment:
 			continue
+		case textparse.EntryHistogram:
+			metric := nativeHistogramMetric(parser)
+			if metric == nil {
+				continue
+			}
+			fam, ok = metricFamiliesByName[*metric.Name]
+			if !ok {
+				fam = &MetricFamily{Name: metric.Name, Type: model.MetricTypeHistogram}
+				metricFamiliesByName[*metric.Name] = fam
+			}
+			fam.Metric = append(fam.Metric, metric)
+			continue
 		default:
 		}
 
@@ -750,6 +765,104 @@ func ParseMetricFamilies(b []byte, contentType string, ts time.Time, logger *log
 	return families, nil
 }
 
+// nativeHistogramMetric converts the native histogram in the current entry of
+// the parser into a histogram with cumulative buckets, as classic histograms
+// are reported. The exemplars of the native histogram are attached to the
+// buckets their values fall into.
+func nativeHistogramMetric(parser textparse.Parser) *OpenMetric {
+	_, tp, h, fh := parser.Histogram()
+	if fh == nil {
+		if h == nil {
+			return nil
+		}
+		fh = h.ToFloat(nil)
+	}
+
+	var lset labels.Labels
+	parser.Metric(&lset)
+	name := lset.Get(labels.MetricName)
+	if name == "" {
+		return nil
+	}
+
+	var labelPairs = []*labels.Label{}
+	for _, l := range lset.Copy() {
+		if l.Name == labels.MetricName {
+			continue
+		}
+		labelPairs = append(labelPairs, &labels.Label{
+			Name:  l.Name,
+			Value: l.Value,
+		})
+	}
+
+	count := uint64(math.Round(fh.Count))
+	sum := fh.Sum
+	buckets := nativeHistogramBuckets(fh)
+
+	var e exemplar.Exemplar
+	for parser.Exemplar(&e) {
+		ex := e
+		for _, bkt := range buckets {
+			if ex.Value <= bkt.GetUpperBound() {
+				if bkt.Exemplar == nil {
+					bkt.Exemplar = &ex
+				}
+				break
+			}
+		}
+	}
+
+	metric := &OpenMetric{
+		Name:  &name,
+		Label: labelPairs,
+		Histogram: &Histogram{
+			SampleCount:      &count,
+			SampleSum:        &sum,
+			Bucket:           buckets,
+			IsGaugeHistogram: fh.CounterResetHint == histogram.GaugeType,
+		},
+	}
+	if tp != nil {
+		t := *tp
+		metric.TimestampMs = &t
+	}
+	return metric
+}
+
+// nativeHistogramBuckets returns the sparse buckets of a native histogram as
+// cumulative buckets. As only the upper bound of each bucket is kept, an empty
+// bucket is added at the lower bound of a bucket when it doesn't follow the
+// previous one, so the bounds of each bucket can still be known. The last
+// bucket is always +Inf.
+func nativeHistogramBuckets(fh *histogram.FloatHistogram) []*Bucket {
+	var (
+		buckets    []*Bucket
+		cumulative float64
+		lastUpper  float64
+	)
+	add := func(upper, count float64) {
+		u := upper
+		c := uint64(math.Round(count))
+		buckets = append(buckets, &Bucket{UpperBound: &u, CumulativeCount: &c})
+	}
+
+	it := fh.AllBucketIterator()
+	for it.Next() {
+		b := it.At()
+		if len(buckets) == 0 || b.Lower != lastUpper {
+			add(b.Lower, cumulative)
+		}
+		cumulative += b.Count
+		add(b.Upper, cumulative)
+		lastUpper = b.Upper
+	}
+	if len(buckets) == 0 || !math.IsInf(lastUpper, 1) {
+		add(math.Inf(1), math.Max(fh.Count, cumulative))
+	}
+	return buckets
+}
+
 func GetContentType(h http.Header) string {
 	ct := h.Get(hdrContentType)
 
@@ -772,6 +885,12 @@ func GetContentType(h http.Header) string {
 			return ""
 		}
 		return ContentTypeTextFormat
+
+	case ProtobufType:
+		if params["proto"] != protobufMessage || params["encoding"] != "delimited" {
+			return ""
+		}
+		return ProtobufType
 	}
 
 	return ""
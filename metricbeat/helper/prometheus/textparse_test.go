@@ -18,11 +18,19 @@
 package prometheus
 
 import (
+	"bytes"
+	"math"
+	"net/http"
 	"testing"
 	"time"
 
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/prometheus/model/exemplar"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/elastic/elastic-agent-libs/logp/logptest"
 )
@@ -788,6 +796,114 @@ http_server_requests_seconds_created{exception="None",uri="/actuator/prometheus"
 	require.ElementsMatch(t, expected, result)
 }
 
+func TestNativeHistogramProtobuf(t *testing.T) {
+	exemplarTs := time.UnixMilli(1700000000000)
+	family := &dto.MetricFamily{
+		Name: proto.String("rpc_duration_seconds"),
+		Help: proto.String("RPC latency"),
+		Type: dto.MetricType_HISTOGRAM.Enum(),
+		Metric: []*dto.Metric{
+			{
+				Label: []*dto.LabelPair{
+					{Name: proto.String("service"), Value: proto.String("checkout")},
+				},
+				Histogram: &dto.Histogram{
+					SampleCount:   proto.Uint64(7),
+					SampleSum:     proto.Float64(20),
+					Schema:        proto.Int32(0),
+					ZeroThreshold: proto.Float64(0.001),
+					ZeroCount:     proto.Uint64(1),
+					// Buckets (0.5,1], (1,2] and (4,8]
+					PositiveSpan: []*dto.BucketSpan{
+						{Offset: proto.Int32(0), Length: proto.Uint32(2)},
+						{Offset: proto.Int32(1), Length: proto.Uint32(1)},
+					},
+					PositiveDelta: []int64{2, 1, -2},
+					Exemplars: []*dto.Exemplar{
+						{
+							Label: []*dto.LabelPair{
+								{Name: proto.String("trace_id"), Value: proto.String("4bf92f3577b34da6a3ce929d0e0e4736")},
+							},
+							Value:     proto.Float64(1.5),
+							Timestamp: timestamppb.New(exemplarTs),
+						},
+					},
+				},
+			},
+		},
+	}
+	var buf bytes.Buffer
+	enc := expfmt.NewEncoder(&buf, expfmt.NewFormat(expfmt.TypeProtoDelim))
+	require.NoError(t, enc.Encode(family))
+
+	expected := []*MetricFamily{
+		{
+			Name: stringp("rpc_duration_seconds"),
+			Help: stringp("RPC latency"),
+			Type: "histogram",
+			Unit: nil,
+			Metric: []*OpenMetric{
+				{
+					Label: []*labels.Label{
+						{
+							Name:  "service",
+							Value: "checkout",
+						},
+					},
+					Name: stringp("rpc_duration_seconds"),
+					Histogram: &Histogram{
+						IsGaugeHistogram: false,
+						SampleCount:      uint64p(7),
+						SampleSum:        float64p(20),
+						Bucket: []*Bucket{
+							{CumulativeCount: uint64p(0), UpperBound: float64p(-0.001)},
+							{CumulativeCount: uint64p(1), UpperBound: float64p(0.001)},
+							{CumulativeCount: uint64p(1), UpperBound: float64p(0.5)},
+							{CumulativeCount: uint64p(3), UpperBound: float64p(1)},
+							{
+								CumulativeCount: uint64p(6),
+								UpperBound:      float64p(2),
+								Exemplar: &exemplar.Exemplar{
+									Labels: labels.FromStrings("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"),
+									Value:  1.5,
+									Ts:     exemplarTs.UnixMilli(),
+									HasTs:  true,
+								},
+							},
+							{CumulativeCount: uint64p(6), UpperBound: float64p(4)},
+							{CumulativeCount: uint64p(7), UpperBound: float64p(8)},
+							{CumulativeCount: uint64p(7), UpperBound: float64p(math.Inf(1))},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := ParseMetricFamilies(buf.Bytes(), ProtobufType, time.Now(), nil)
+	if err != nil {
+		t.Fatalf("ParseMetricFamilies for content type %s returned an error.", ProtobufType)
+	}
+	require.ElementsMatch(t, expected, result)
+}
+
+func TestGetContentType(t *testing.T) {
+	for _, tc := range []struct {
+		contentType string
+		expected    string
+	}{
+		{"text/plain; version=0.0.4; charset=utf-8", ContentTypeTextFormat},
+		{"application/openmetrics-text; version=1.0.0; charset=utf-8", OpenMetricsType},
+		{"application/vnd.google.protobuf; proto=io.prometheus.client.MetricFamily; encoding=delimited", ProtobufType},
+		{"application/vnd.google.protobuf; proto=io.prometheus.client.MetricFamily; encoding=text", ""},
+		{"application/json", ""},
+	} {
+		h := http.Header{}
+		h.Set("Content-Type", tc.contentType)
+		require.Equal(t, tc.expected, GetContentType(h), tc.contentType)
+	}
+}
+
 func TestGaugeHistogramOpenMetrics(t *testing.T) {
 	input := `
 # TYPE ggh gaugehistogram
@@ -124,6 +124,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/mysql/query"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mysql/status"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats"
+	_ "github.com/elastic/beats/v7/metricbeat/module/nats/account"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/connection"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/connections"
 	_ "github.com/elastic/beats/v7/metricbeat/module/nats/jetstream"
@@ -142,6 +143,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/activity"
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/bgwriter"
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/database"
+	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/replication"
 	_ "github.com/elastic/beats/v7/metricbeat/module/postgresql/statement"
 	_ "github.com/elastic/beats/v7/metricbeat/module/prometheus"
 	_ "github.com/elastic/beats/v7/metricbeat/module/prometheus/collector"
@@ -158,12 +160,14 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/key"
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/keyspace"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system/cgroups"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/core"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/cpu"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/diskio"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/entropy"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/filesystem"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/fsstat"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system/gpu"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/load"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/memory"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/network"
@@ -187,7 +191,9 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/host"
 	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/network"
 	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/resourcepool"
+	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/virtualdisk"
 	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/virtualmachine"
+	_ "github.com/elastic/beats/v7/metricbeat/module/vsphere/virtualnic"
 	_ "github.com/elastic/beats/v7/metricbeat/module/windows"
 	_ "github.com/elastic/beats/v7/metricbeat/module/windows/perfmon"
 	_ "github.com/elastic/beats/v7/metricbeat/module/windows/service"
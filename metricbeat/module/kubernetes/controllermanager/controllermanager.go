@@ -46,6 +46,8 @@ var mapping = &prometheus.MetricsMapping{
 		"workqueue_adds_total":                        prometheus.Metric("workqueue.adds.count"),
 		"workqueue_depth":                             prometheus.Metric("workqueue.depth.count"),
 		"workqueue_retries_total":                     prometheus.Metric("workqueue.retries.count"),
+		"workqueue_queue_duration_seconds":            prometheus.Metric("workqueue.queue.duration.ns", prometheus.OpMultiplyBucketsRounded(1000000000)),
+		"workqueue_work_duration_seconds":             prometheus.Metric("workqueue.work.duration.ns", prometheus.OpMultiplyBucketsRounded(1000000000)),
 
 		"node_collector_evictions_total":         prometheus.Metric("node.collector.eviction.count"),
 		"node_collector_unhealthy_nodes_in_zone": prometheus.Metric("node.collector.unhealthy.count"),
@@ -85,7 +87,7 @@ type MetricSet struct {
 // Part of new is also setting up the configuration by processing additional
 // configuration entries if needed.
 func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
-	pc, err := prometheus.NewPrometheusClient(base)
+	pc, err := util.NewControlPlaneClient(base)
 	if err != nil {
 		return nil, err
 	}
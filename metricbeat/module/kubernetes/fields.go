@@ -32,5 +32,5 @@ func init() {
 // AssetKubernetes returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/kubernetes.
 func AssetKubernetes() string {
-	return "eJzsfU9z2ziT9z2fAuXLm7zlUe05tfVUZZzn2fEmk9Ha8cxha0uBSEjCmAQ4AGhHU/vht/CPBEmApERQdmzpMDWxre4fGo1Go9Hd+Anco/17cF+uESNIIP4GAIFFht6Di0/VDy/eAJAinjBcCEzJe/CPNwAAUP8ByJFgOJHfZihDkKP3YAvfAMCREJhs+Xvw3xecZxeX4GInRHHxP/J3O8rEKqFkg7fvwQZmHL0BYINRlvL3isFPgMActeDJj9gXkgOjZWF+4oEnP9dkQ1kO5Y8BJCngAgrMBU44oBtQ0JSDHBK4RSlY7x0+C0PBoqkIWkiwwByxB8Sq3/hQ9SBrCfDD8hpogo4s7acpU/txJdWGl8M/KVs8IMYxJY2/sDDv0f6RsrT1ux6w8iNR3hqUkgMwHBZ+EJjMDUJy6AfB0F8l4mLBEKclS1A8HDeaMkqBl3YbAC/Xc2IIke/ASGgRHwBQZMHbJCu5QOxSMeUFTNBlJZ13vbgeEFvHg/XL169L0CHZ5pnQNKIoFM8OyS5PIhARK8ko/jQYDIoF6LBoY0nZfsXKiEvzDyR2iAGxQ5YHKDniIGV70GbUBnOPSZvbBCSfMEmliTfUB6YkLyhBRMRjf2VJgh0kaYbJ1hVKL5r2/jERibSWiiTYUDszI8xEdKttCFYousNsQ1CSa2ywEyHYReIj3GaeI7GjEfVRLUwP0c6gKY+ohtWI21Qt24LRBHHu5ehTRJ/P4dJLinLBUdL5vaWZ0nKdte1eZyBXyzvAUUJJ2kbmOBcop2wvt3WcIiIW633tHnb5ZpRsPb/UzuF7EPpyA9XP8o8AJsDyNBiGID5gJkqYnRKhYTkEcJPyBS0QWSS07Fi/QWgN1l/KfI2YtLiSINjgDFV/QFl4GrmATKA0gtLcaoUBHJMEKRNjlNvy8C6ARyiSXTT1Rw+ICL7g+G+kp3uxLpN7JBb/Pzg4uv4TJT7Z61+sxk/BH3IoGgKQCECKuWB4XarTDyYBHQpj52U+q7relrlUmMcaN1fA+TFgY6qwi2gIgsdtAQNGu8O5a7iBMt56nwb3xpeROu1AC+wjvKCE+13LY1T6aXQ5KBE1uB71Vv4FgslOD/bS+h3qf9b1YeTSPTBdmuMLJCmonMHFGJGcaInYWR29PmZaGDUOQa3Lwr16mGRYyrB2sbsgggCCzDU/ALkh3+dJxXNposrPIzKXWVoyFaxalEfpVoOtdf4sTanyuVSdHCeMDvlXLpLpIuhiIa7DoH84DswJrVBlbzIoEEn2DZNzCXaYC7plMAcaUxh/UjIml8N0QV6TTYa3OzGsSpIaKwnBZBvZBthlmAj8gNS3gWHUbxOQSNKFnoQoBqEO15qp5QAKxcXLHpYpFgu1dUZhr+j5vIQmQ4YkNJRG5GlJtpnXJosIiMm04LQj3YpelNi0csdXAud+JyWFov2LAdfgVhIEHYLOmXT0ZjAU1lnegZLDLfIIIjRsF4r6bnAd+gD1UW0MkjIf4WHiQwxcJh4L3WYTPKvZzwg3z36uKrWTcr+iDBnhE0iC+1cDLyRUCiYEewTkkXC1YqB0gGUFjKZoUXg3qRoXT2CG0tUmozD0h9aXLBBLuvHLo8Yg5Qs5gJam/Lc5ewgqYKawA5hlNIECrjMkv9c72AznWPx4o03RBhOUavhV2LI2hW/lT4ISAXgDSqK+i9J3C3C9aX1d/o36NQeQIZBjzuUGKo8g8g+/SaLf1D+/cQEFWukfGLuDzNfWVOykVyLZpoASIHZQKECXQOywvZgFjzjLwLpmg4jADGV7/51ZRrfjQ4ID8v5Mt/K8sqEHmkr4AHEG/QtzurkMHb7AOKswdIYD4/VQyacaLEhgARMs9sNHPPuXr0E+ep2Nl400xa9BLmrLGS8WLA1DOAQ9zf/wnzBA1G32q9KDerUEB+TEuBnqd4zi4ZKsxkAKaOcckJSCeCA1ryiixUpei9Fu6+HAPct8bv9zE4kWRHDAz9wD/tVBf6ATHNAA8Oz94DFjnuAKG4UY8oYNiufmELvTxzrX5OCFLeGb29v+BVxdmlJ2j8mWo3BM8WVI5A89UMCRGG/anuc6Dw3lRGs+qEsF3KINLDNPJPuw+3//0OvQqWQEApwaabMnQ6RTaEO4KrtDqdhEzNB5HcfGG0qFykLhey5QfvAJ8rV4sn45uSes81HbLyNztHq6I/dJjpF3ngOke83EaJYhpmsoJl03XVXETEVGnMumU6aVnzKd/NT5qXHzUhW3YFKq/G88Xl9gjsblPv9NSUS+12TDIBesTETJUJf4807BrcJHTNdNact3tbxT96yAF4gIaf3OaboTEP44aboSQg6/R0DwuTpPPFmicJUuYM86VaKwSh0uCf4OUEGTXUjBm0lu0VZuX7LcYUKup1lZ2iplR1Cn0PASrBm9RwSk9FG6Mao4suRqz7k0e4Fa/B7b76klipi5VmU9Gtg2/aqVu9YZgNyZj0M8Q4JUlbTmJLgNTMDx+E+aFNealXYebictbuI4T5huW41NJRibdEn1zXhjmCMXLx66p1AkbzZ3PC3SOZzzTYFJiY6Ib341d9PJ4+h5dxRPkKg7gy65Me+/SlTGq6yQA0PygKBzdKc7Nr/QR3l23lufBewgV/6N4VRl6Rp/hzKwRojYH3eEUg3ZdyJz4hBkgwnmuyjOWWcMdKNwqLGklCB9oYOV/1wwumXScVMzBzn5f0KPKKFE+v5MR2h6RXDsqGGaxjAkf1TcYJrqLIpjEaWoELuokEzauqZ8LCyGBMNRjG4NjDjmV1E/BFzjCjqhWYYSQf1lxsfVAOJEuWUxdxl1x2Ipd0friVy463OHYCZ2+6iIKqoK24GQYovmQPYaeeCKbjyGZePS7EBxVLsAgiliC8xXOeQiUO6+pjRDsF2NP9SMYVd3Y0i60VVMuIDyvIu5AVFReNMG2a43PDi6+3WH3G43ptizursHZhlWv1FGHjIEtojIw5Puz2OrPIwRb3DAyuLLifjU7hYEDogchzU0oBe9k3AlqWgugKGEslRvyLX9EjhH+mcFZAInZQaZqeGVOx5NlA1OPQjVNwXMCw/Krt3qi5FvMONiZViRQHuaw8syvlqAcpyKB6h5yJ+Fq1gzODsgyWIATx0g5J1Lf41BoO9ivDb8qukYTUBp3QsDPyDiEUdCi/1KUB+CeneFvNWmIxyz7kV3oyiNBVdpYbvHzJHcv+6LKhzXz9ETwA8pfT9HZRZtmxaGCsqE7tOCuWcu+hbQrA1kNozm4HGHk50SjrYNmNeW0Qsp7n3NF+l+SMKAkrFYnJsqmEIBp8/Yr4YSgJzTBKtd4RGLXe8a6ps3vwk93Pmr9IChzoSAPoM14hq2YbQUAxVS7FspNSA7L6u4V2r/YcgaldjUyuB3tOPf543iqfqPxWWsSMozpV4EegE8wqHVaK8dV9HbKv1u2iq5Aum/5SxxxGvjO4L/KhFQ92x4g6W3SR0gnghPZcZRtlllmNxHBHPzWdpxhrhEY1puhbYRTB5o9oDSlQfjXNbJ8vTJpc9OwQLH15wPy+uqKZfRnp7pitudTfJ2u5r0MI5rPFyD1cN0vvVqKR8g+rgL9u764wBvNz4y5cznFJCr8MW5dvxcOx74xK8dVx7rj142fq7S8v/NuUqr84lXpXWud+lAPte7hKCfqzcGqjcIElJ7otlu9v2Fq+ANShB+UPF+1diJ2GokKWOAiUBsAxOkagg7PwVY+prCFi9dVmV+OrwnN1oqpL/DUCLAA8xKBL7927de0SDGfHmJo2UzdtzfDacnGnIVE3vpCvaVQcJzLMTr07GvT6hj1WXTuZTNfkbO2r/OVWwHi+hcwOZ+OuJ5HbVrTrJKoA1KG9ZputjUuJ5L/5oaUaiHTeV/liQYfTvGhuNc+usz9SQK7w/DDIaYgJErHYwPb41Z8eCwMNh1rs4nh+8gYOQuAl63IEfsM+AQs/cqhejfjaoQQ6MidcotREHTH/IS4hxDsJ+jYgjP4eRVne7P527xnOblq3deXtXt37O57eoAe44t4g5pkvzCGyMnRWkbtnFdsKvSmGlad4niKp4Eyd5pHIV53dvNdJeS3zN92UykCWlDEXhXNO516PnerwP8WS69c3fG/vWYN3oonmZJglcYbGyspfCgW5fHq5d/e6wF89i5Q/4B192kbolPsfhefraG1q6qqY+cGVXsPZDVU8AtWs2YEKBhjU5PWJ0GTzg5wWk79X0/JbDilPMpWjGfrz93UjuIW7BpwSm73537mIGoTTrOfcx6WJ/7mJ37mJ37mJ37mNWfcx+zcx+zcx+zKVNw7mP2GvqY8T1pOx5HX2rdl2ukz37mBLgnyZH3W6zMEI+7+e5JspSwbiTpysof8gZ2F1TMFRcAOOI57C6uE+poAPagrgZHY9IbVDM3mOeYbOdwwgwX4LAJ+WPHIo3a1aoH7ggNGUB6QnX50jOQ8TpTWa9kh9Iym/bUgBO5quj9cI8NnDKM9JLDceGenkexu9UKJbW8S7lKbum0LYnFM9wQpWB0gztBhThcfbQdj6rMIg72gxAoL4ShKw+idg23MgVn7T8z6j2J07T2q01YT0c/cA7UerGdA7U+gOdA7TlQe5iQz4Hac6C2OYRzoPYcqB2D7hyoPQdqz4FazwjPD06ExnF+cCIw4vODE/lzfnCCVxGTaEu6QCSVa7mgUZSj3mXsTBgGQDLojFnquR6zaLcBb4BkCOWKw0K9YJGfNgBd95CqcACDY8KthWdQU08hyy4+H8keBDE1oCutMUigDs/FgGIya2sYlrY1sUlWcoEY4BRsYDu65hhOC+mpLszqQKmBMuEkY0KtagPyhlYHxh3ppMzDYxo6M08dQUwd7w7jILz1/YhJ5n/ThnNcoXpdHBDjVuro9sEdOV7VRQv+SLsO6MTb3XaQh6uJ/ANoD6Kv0rEajmIE3lZ+6SPEQv2PQCzHBAqUvuvxN2Aa7kHlj++PRFkjVEz88m0cigRkPYUFmAi07VxEHAFG8wn0y3EE036bxAUzaf6+qssX9WKJfd+oKqcxvkumbJGZSvC2gn+lHlGQs3vFIN99prT4GSb3dLO5BP9kTDWpWJZZdullXP3afOcdoMxRE8knLzIkUHpZS+wKEkLFTUkUB3kG+O23Xz/hLEPpOzWpKFzZo97CqRms5paqehjHK9pHqLfeerAK+rghSzHVgx4/3NAbHSBKtcxnOVhnQCpI/1bF4t/ZYL3Puh/XI2DwgK5q4UKV8ZpuqATuoAV8tbxTXb+5ZjniguAkkAw7lIKT9cLWIp//Cm5o+G7x7nBnNjsvT4+7njIzgmAfn4RR8iddx3KRNLUoDtKU/IArg2Po0D+ZgZeO44Cah8z8K2MMn5oEKGiGW5QqlzwR+AF5nfCg0gWcb01KRRaqfIqukjh+LF/xkheIpJ0mWqNTKRpvIhrJYnnM9tGtNVc96+QJNvZsQA22/1SXuzbCqG99XQhyZ/U9HtXYFK0GRMMhha52ewuDlcS/QND3mdhLyoPsUwTTDJMw5yGd+2gIVKzhRsUNbOKQRGIjuNJX3ECcOTMx5n/6/9n9n2psEOWUNHsDTElo/Kjo3aqy9RNbxnpzKjKcwPHHwIENxzs6w+TIbPPhVhVj4ghhH7oOF9cNAq1YQIFYPZAgxBRxzHo6004DaKg34toHwes/9MaSnj71HgitJKebXofXEEzn1iSj+3zig7qOK1QTjLLmC+jpUjkhV/GTF6nm4gtozBRLGtaJkSfn//Wqxcd6aB8qjbiiJMUqtmsynd4KVqJLsIEZR/JAXJJ7Qh9JOLZkLxy7d0YH43YQLjVVudUdB/EEpt7RlWphYbKhB077kBmdFBFzRFqbBGtaK9RveYGS8BQPK2YsjF1TNcmGxoLls6FhYEXqfWI1OijNpwuorr9g+G95DM4KmsJSUNWiKNYNQIB6n4FfI3GKA6/nWe3m2+6/VNDBkqbgQw0+eEae0Yz8svxQr8TjnMUchluzTlK3Fswcfsd5mddw1cvP0niEoeFwIDgqNEwOhWaC8CeBZ5MVWvD6L0tm3RlaADt7Qgjgkc7PYevB7PTHrYbEOgxT/ZFRgpOWQ7opXa4Wz5iQot8JHneg9gcZT2tdifNqrQqU9FUdFbRTHXO04izrjulViK4dnhvheqtA3wxnMW8sMQijFdWZA4Zm0Q+Dl0mCUDeGGReJ4sL5psy6aCySgx7yGG9ipIZWt3YHm5fAE/pVkNH3iP7og7u63oPCvN5ul1Mo+trAZa84T4rMz7WFyVMIHmsaaw6AIyEw2R46n/OGsRJKNnhbMnVjWEFVlxOuvQJvbztHsDrcwWCWoQzzdmJULCE6HJ69FF2szobTIz/62L4Zjyc5RVud+lkOPT7HkNi8acLREiXcXbl61l0li9NHwoHYYe7ZLGt0nif1I6Kzz+v3IANv0XYBLq4YJf9J1xfhEAXmq4QSwWiWeR3iCJB/e7TJOxUj8PZCsBJdXIILFY26uASUgYt/J5Sgf1z4tXE2v1ldwWq/+Xh9tOZ8HhG6d5uNzSMgSBPZ65n3AY8pKlrjOo2F6k44Wsn/5wVseMTxjwIVF+sTL0aeCKZfIHdleEfwd01YbnmVv+V/MSF2LL3foR+Z8uhqQC1azK0zryLRcvqVJvSkjNn8rb4g+VRMDo8wsGrfaTY3mRIIVE8ZRHq0KqYK9N//TbIMX+SQ3XzXw69NSmLu9nsD2JNScxXKBp+hgJNOkFoVDHFespk2AQVLvxqxNIyOlmKK+f0p4H7E/H4yWFqKFd2sJOYZof5Wit82Eu/xN3o4PYVMl9cfJ4vUNBFbjbkMmo7YNAq7c26DjrmSjJmP6zwhM18KrHTW3bdq6txcugFQbwDKd4cMOVd48nirUueCVYWOTMxznwODmBB3Ms9d1Q+LniyB152jp0qHbU+hSYWt588mxVa1xBPn8akG+kV7I2p0/W/IOtHoWaZaPfQ0lya7c9kby60mZFY46gkZ31vGVSShXKMMicUDYrx7Kp1w+fBJEwaGcNfTLeQvuEBEPNCszGN5vTVZoOnWtxCM5uovf1Inv5+eOv/7dw1PkvAfvXrX7Lj0VcOjVwV8pXKHDkJXwcEkoUzVfAvqzEngUEEZ3KJVksFAQ7kR3G81EaCIVGHbjj6BMfm1Ib1MMojz2ZRTUX+2Krr8/apHP/UQVlMY/IxJilIrjDArUyuyMlozYUXc1IVCdnnFXxVSboqAnzZUF2yrfEJv1A+KBJAkQnGb2dbX8vergMHy3oQF77iaIquWhjYpV2phVFGqxte7a/bZPfS9o1yscNtNMekhx8eSJTxJGlwvA7M+UxBbMp6U/DFjQXYLpqnIvrEV2UvT6GSxOLYQOya6adEpW3cyU2S9PeGWmw/v5TDaE1ZxS7i60RIWVRm3LTL+5wNOVImx9Ik/bDaYYLHX//pMubgEt7tSqKYNlIE7gr4XSP79h1S9rkiJqkIeUB7fo/4gSqHxDYIpJqp1VLLD6MHWGmOi239WAXxloKESRcdC2vSxadVCjqG0VTQ8Ur3QjImcLtRw8cBzKv1p1IPc6H88XcXP8bierNRnBDa6Vp1N5xLaFhFke6oaTrbvVQ0O9NyN1wWc8iA9/9xuyizbW26D0nT6hqgcgb9K2sghn2ZaHJpxHgWdrQz3xmD9L4V1qBi3LaVDEGgOOoMApeDtDrJU+Qwcpe/GdZWfclJrDjSYjy+63dxGs3BHaO6m9wW6BN/kUL/JsX6Tg/0W2D88Az9ifDo3R7enk3BgUWQYcSBo9/jR/8/wcUWaAzwi42DccjHUnryfwa3B0RPQ0l3XQueiETyuiUCMwAxcLyuVN+P3s0Tf9RcmRSnsyCwx8PHLbXgJVCyPH2aHYeC4l1GYrtYwgySZJNbPFKbgZ0OnUqgA0ylL3A6sQ6PKFyOqaHGSiug+qwH0loE8RU/RCcvmFx+dGAERKyp/CKSRxLQps3iOvaUYzbPvE8JQtM5f1NDNXaoaL0mjcGtG0Pb+TnDUaAiv8qGOOm3M7J/Wjl/lnjZ8vpAQwRMcOzo1UGMBPtn5Y0gF69PB3EronEOOzz6dWRcrDXTAPg8dtJo3Algr8N6Ou08zyW4Y/sn9vAaasLdXMPqAOaahJP8DbhdrSrXX56IIXRqp27WVpyfVQQcDfUenqSj+6Z7AHCdQHpjN7mausPx3neaibI1VIHrSvc+vNNWJHynawDITtWxUJ1qSAsMlvj/SmPYBr0Q9wRVL+/V7XlUvxTgrwN+acVLKQ93aLugQquanvlDxuDnwPKVXPRDlH+UBKW1Do1vegZJLDTjuAkh9N2imfYD6qDYGSb35kMPEhxi4TDwvprbZBIP89jNi07EfKe0rypAROYEk+MRsAyUkNJSpNxLoSJBaHVAaYDlTyt58ShRKbQPjpDaUIQfGS1Y3rzepcv4BVztc52ofvDBh3NzejhPFI2X3mGy5x1V8WRL5Qw/UHM9HSKaAW+0rhHVlyvWk8+yDtFQBTnVHlD8pOxkixc2Lq+maRfJUmgmHT+agd6XipBp6uW0iPunqSzUcItpHGDzLVfgvnCHjmKoE3oGcZnDQNfILFVGduT4oI08fQfDSxaO8qaBk3EEU3tdl6iGoplnpKlRZ4g6kQCzx91o6YChLTUSaWboBJsFCnRaDE4wJTXvqEKZMcUB3QFT3905PVmAQjmVlqP9sEgPMvxhCY8CE3r+Ji0b7ryPg/ABqbEbxfwEAAP//HenJyA=="
+	return "eJzsXeFv27iS/+6/gsiXaw+pcZ+LwwP60n23ve12g6bd/XA4eBlpHHMjkVqSSurF/fGHoUhJlkhJtmgn2/ht8dDG8cxvhsPhcDgcviH3sH1L7stbkBw0qAUhmukM3pKLn+ofXiwISUElkhWaCf6W/GNBCCHNL5ActGQJfltCBlTBW3JHF4Qo0JrxO/WW/M+FUtnFJbnYaF1c/C9+thFSrxLB1+zuLVnTTMGCkDWDLFVvDYM3hNMcOvDwA70tkIMUZWF/4oGHfz7wtZA5RdSE8pQoTTVTmiWKiDUpRKpITjm9g5Tcblt8lpaCQ1MTdJBowRTIB5D1Jz5UA8g6Cnx3/YFUBFu6dP/t6pSQXWzu3214Of1DyOUDSMUE3/kNB/Meto9Cpp3PBsDiH0R5Y1EiB2I5LP0gGD82CMbHQEj4swSllxKUKGUC8XB8rihDSry0uwBUeXtMDCHyPRiJKOIDIIYseZVkpdIgLw1TVdAELmvtvB7E9QDyNh6sH798uSY9kl2eiUgjqsLw7JHs8+QauF4ho3i83TBYDIYF6bHoYknldiXLiFPzN9AbkERvwPEgpQJFUrklXUZdMPeMp/GQ/MR4ii7eUh/knIi8EBy4jsf+ypEkG8rTjPG7tlIG0XTXj5lI0FsakmQt3MhMcBPRvbYlWKPoi9mFYDQHMh4EN0l8hLvMc9AbkcbjbSamh2hPaKF0PK61xF2qjm0hRQJKeTn6DNEXc7TpJUW5VJD0Pnc0U1HeZruW5xHk6vorUZAInqogpxxyIbe4rLMUuF7ebpvwsP2/im8m+J3nwyo4fEtCX95B9U/8JcI4cTwthjGID0zqkmanRGhZjgFcp2opCuDLRJRc7wtth/WnMr8FiR4XCZI1y6D+BSFVEILSVGpIIxjNTWUwRDGegHEx1rgdj4WP/yPVySaa+cMDcK2Wiv0F1XAvb8vkHvTy34PCids/IPHpvvpgNX0IfkNRKggEEZCUKS3ZbYneAa3Cb0Nh7KrM97WJvcz1pszRYB4b3MoAV4eAjWnCbURjEDxhy5jTnuC48c9nu04TZIGw0KZb0BY+VBJUIbiCaCb9NLYc1IgRbsC8TXwBNNlUwl66zaH5y22zGblsb5gu7fYFMwR1MLicopITTRE3qpPnx5EmRoNDCxeyqIUPQJIx1KELa3wgggCCzA0tRaiy5L2c7a9FmwBx9edRWZtZWkqTrFqWB9nWDlsX/DmaaPI5TpScJVKMxVdtJPNV0MfCa6WIAqofTgNzQi9U+5uMauDJdsflXJINU1rcSZqTClMYf1JKidNhviI/8HXG7jZ63JSQmiw5Z/xueQwbJjTR7AHMt4ll5EflEIFO0mU1CF5EQTQBJE261g6tIlQbLl72tEyZXpqlMwp7Q88XJewylIACQxqRpyPZZe4YY46JMr6zxfU7wIFFt6Xdml6U3LQJx1ea5f4gJaUahpTRDw1ukCDpEay1UZSTF4MRTrgxLRW9A48ipqwl5ru9T4cADVHdEVLIrtamER9j0GbCVfBXRvdqEzXc/u+qNjvU+5WQYJXPKQ+uXzt4KReJkKAGNTMIeSJcBFgqSEdY1sBECssi0YO4VEIzSFfrTNDQL7pYsgCZdOOhA2VA46aKUEcT/233HlpomhEuUiA0y0RCNb3NAL83KGzGcqb/ftKmsGYc0gp+nbZsXOErIQc0QtialNx8F9LXS/Jh3fk6/o75WBEqgeRMKcwS4xYEf/F3VPPv5szydzy0hFX1A+t3wH7tVugNRiU4ECkRnOgN1QbQJdEb5g5mySPLMnLbsAGumYRs6z8zy8SdWkx1IiP6/ijucL+yFotpPsdhoA+UZSjWImQxIY825M0c9dDma6JXcJY4RGaiHRr91MKShBY0YXo7vsVzv/kS9FN5num6QVf8EvSCcu6hFoaOQR1FMb7gdg/FTBT6i7GDZrYEBWqArSXAiXAhqymQAtZ5DEjIygfJQfEeEIStIWQJjlw9MouQaHNsbGjWnHbyde1w5JzleGH/c1NJpYjgPqcB/iwj4J9b6PcMggMW8Pzj4CkyzwiFrUGMRcMWxXMLiNvDJ3vH5N/bFP58czM8gR3kRyHvsaoT9Heukd8qQbGMdbpre57zPCTKieb8IqStgt7BmpZZJ4c6abQniN6kTpERCXByYEzZ7MkQGW5BXA6TFEKv1WLqJAtNMEfO7RaCsn0fM/ezENpUoait0pBbU52+VXopkaxfT01k+8K32mar7deR3VqNqCe4HZyvoJNsI796NpAOAC4GUmQZyOoOxazjpquamL2REecixCnLyk9ZTn7q+tS4damGW4+k44X/H4/XJ5pDHb+4ih8v378Ej8j3A19LqrQsE11K6BN/3iW4dfpImh2Y9Xx4aIHnrEQVWGXI+LlM92WU6WKlcE6/RUDwsd5PPFmhcF0u4PY6daGwKR0uOftGoBDJJmTgDpOnCm3OzLXFM1GH2XhaV5VDtGhdNLwkt1LcAyepeMQoD+ukdanMmnNp1wIz+T2+vw86ZuVaXfVoYbvyq07tWk8AXOwPQxxT5bJT69YqcBsZgMPxn7QorjMq7aI4nDG9sriZcp6w3LaWDXm6cknzzXgyHMHUIqJ7CkPyVnPHs6KqhnN57JLoiPiOb+aujDuenfelOKkp7QgU05achJjz/rOEEqIt9ziSgBuEqkZ3fmDzo3jEvfPWxSxkQ5XZ9VhOdZWujXeEJLcA3P24p5RaZN+OrJGj5GvGmdpAegwZ8IKQkPdGllRwqA50GNYUkUKKO4mBm5llVPF/05VEieAY+0uTuxhWwaFS0zSN4Uh+q7nRFC+flFwfiiiFQm+iQrJl6xXlQ2FJPHADFRVYc3nAUj8UnFF9E0nxk3qtL7gFYRpyhaH31plqA/0W1lj0egut0npID1gqD9bGASuRL5LHGagPlJbxdr1vPNFiBgHVIDaY11Lku9IdihtH7knt0zpKHJNKyr5sxzPInvSx7XGKdHEMsCdKfPuzwsy2PwcZjy6WCSb4Ey3kwgfzkLgHHliCAkSdgYi1ptwfJE9qtwFU8g3QTG+2URHVVE059p6QYqtmT/YV8kANw3QM1ztVBXuqw2HJgKYgl0ytcoq9hTpMKzS3QmRA+WIATD9D/9umaVeT9I+fGFeaYkKQKQuiprDoguxeyPbPjgE8XzbQbgdmb8PXxU3ETsP6ExMFY8HTHXDMLlUNzNw1OBvl7nBgJiRGg2j4HHC0FrbQgF0MDsIV2nnFhUhIhEyrHUsT4GHYUP2soFKzpMyotE0OcEsgEhOkph6E5pua5sViit/yeS1Hac2k0ivLindOjw6/t/bFAUQ5DQ8nDlqdWA9c88/o0QFldBSPQ5OD6lVFVRg0fNOLyQh+ruhYS4C0Pra7Yw/APepIRLFdaeFD4LBJoKrTxyh8qDeI7rOhNBWc499rwnUg9y/boq7NGuboOeEMGf0wxw003RCIhEJI7OVY3SvqMh+eQDWZnU+HdLFHhy0TkD5uWLIxyjHICFONZ/RC8qSzZqD5hOEHyop3sCZicUhy0DSlmi76WPYcsZ8tJUKVEglDfuSR6c2A0QyPm9+FhtH5qTX0Egm9ARl0WKOK7zgtwwB3IYMzpQHkxmXlid1nmMN/WbLWJNaNMXhRxGX+aTJP06AxLmNDEjMZ1SQw/MkjHZuNri5jZdu1xAP1q+0711ZIzc6LpWRpPPZfOfuzBGL6hbE1w+aIogXEs1l3MBRk61XG+H1EMJ8/oh+XoBANv/OaiOPP+IPIHiBdeTAeyzs5njaQXYx5FoeVFiy+5eBBrSXqppMHloMQt30l8m63fRpgHNd5tB3WANPjzVdHeQ/Vx52wXz+8H+Ht+PLd4jz/pJjWYQNJnZtrnJtrnK65holY/+59NRwgbwFdeGhCQ/LSiv/P11gPucZ6vhB4vhA4eiHwfL1t4vU2DvpRyPvFVJsJ2YujJ78Fxfo+TPAzJMAeTL4fI0dzxRlLqI2OCeMa5JomYFoO9X6KeTEutLvdeVnfg67Se9jASmiSCCkh0eSBZiWQ3//j90HVgJRCztDNVLm/WU5PJLITV3/vBvZFUq5ypvXLs7EvT2hjTtTzXd997/r+63zNd+yab09FTdD/wm/4mhu+/3qZl3ubWK30XPP1wQrVpRwL13Np8NUgCjX5cnBkyYPZN59BhIzB0WM5tqM4jpENrA/jDMaYtBkNTdGJAzV1xu8xqPjnA6qXrPdfQaauIi9ckRPWmb3c3otUon81cuIUO1f2/WqadgpRiPRveQhxziHMyiEMgj717n4RAvLy9t2DuE+9I174QLyI079nc9rVA/Z37yL/nXeOT4rStXhXzb0TXGTrNnrKpNDwvmPTWY+puislte33MBVlG1faTBNUjmJ5iuPQ402tIa85YVq5YR0iE+/crw383L72b9i+Nt9pMnuaKTl1m/jdzqWw0J3D49X3f3pcKeaxd4Y8CP87bCf7FJPv+6/WqKyr7nqGI2O6YQRszAmA9QCrIxYEVLAmlyesToMnXJxQ60WKb9vFmKkMMGxd5zO0bNcna7jzMiznVpNxWk2esj2ovZ69mOqBQt7H0Ts3ejw3ejw3ejw3ejw3ejw3ejw3ejw3ejw3ejw3ejw3epzd6FFteTJ5pR/ZEOAb2tXez+4Atzzx7P8mxQxlBmpnKTjAeHbg3mx5co2wPiPp2stbI2mvv3uAijnjAgCbVXYPXCe00QDsUVsNSmPLG0y3S5rn2Cw0qh1UQZjlQlpsQvHYoUhjGscQ3AkWMoL0hObyaUCQ6Tbj5FLJBtIy22mT5XdfA66rlbmq6UVJW50y3eJZxubyCq4Z33M6Ltz0+CB2N5VB4XTtU3Y8+21LYvHsU3Y8CykwR3EUrj7ajq8EVWYRhX2nNeSFtnRxI+rmcKdSsIFwhP4zrjmWa+fg5UsrqCoi73p9cLQJB8BuXW092GMnL6bTtBt0UAa7DJ6Tx+fk8Tl5fE4en5PH5+TxOXl8Th6fk8fn5PE5efysk8d1V/1oy719uMZ29D7GCzvnV4LOrwSdXwk6vxJ0fiXo/ErQ+ZWg8ytBe74SZFOZ3edG5sQ8BfAUg51CpHE14JYqywAT4v2hQo1UMvfebtgBKQGT1zhO5tmh/KSzrpGpwUEsjvGZto9QcyfTdR+fj+QAgpgWUPTQTEHiDhMiGKO9DtHAqA8q7FKXZCWeOxAlyJrKILoa0hMleFqnWxbKjFSPPR8zEbr3PGxE7kgOv3FlPZnGkopzJYhp430x5uHN7oRkepM/B1NzWKYb255yHcGUepg7xrQnwmOZSg0TvkFSDpfPFCJdqow9lUX8wNM3WrwBntY6xRa4IlV2Y2DlOmgb1Gwb3ezZSw2RDAimizjmm2YKFNPeMOBqkO+D1CGs74UuxiLNgeP4Vs+j5p5pjAKng1+i6HxEyFWNi6VeVtU5XLyYe0NVP9gdFmBEy35xDCPyqk4nPlKGb9FcEg0yZxyfhXkdRCmBptsgSn9ZxkSUDULDxJ5zDiBReEKsgmCwOewdyPlgKj6B1osDz9xFGz98m7KiXz+VWd/MtjuqzLznbIeSvKrhX5n3uHB0ryRVm49CFP+kyb1Yry/JD1KafmfXZZZdehnXH9vvvCZCtswE+eRFBho9ScOSci7055IbDpi6/eWXn39iWQbpazOoEL4kjm8erhoGq2NrFfn5VYtPZjHeFtZAnyYyqqkRerq4oefe9k3P+wX+iMK2BEJu5JUpoXjt0i21AhY+vPu0mxrzd6YRwTLUZKmiG+qmMEHYemxMYyvDpupdEARk84KngtSkIfsMHSRvxdFclYfOHuMdXI6Jb/tVGDj1kWYQtRuXp8fdDJmVoIfdYU6k4H+I28XYqE0MkSpqUQIkT/ZzyJfuALyyOMZSkbMZeOk4Jong9k3c7cF8GhKkEBlLtl5ONNHsAbypgaDRBeLuihSG96p+t7ZvJA1rplaqVJgmhfTQCtid57WtZpkifrqdF0I9Z8QDC9AO2x9wQakPhqtivTYEXFl975A6BGZRdBYQDQcqHSnXMGTJvew5fDsSe6Q8yj4FmmaMhzmP2dx7S6BmTdcmm2klMkjcwTvGimvKstZITPnL8D/7f6llo5Bj/Yee4xdbd2PeG3o3oE/vGd2XJBQZS6jyfrEr1YhkQeksE4+UIZn263rmMak9YuhWzsBxqtWCva4aQYIQU1Bs9w35mAAt9VZyY094w5veWNozXPbVXMlPN7wlnzzADl4KRSa2OfBZU74VCjUEo8z5gpbq8AV2cPq2kFZcfAkNh8Ob6wg7kflT3h8k9ST8P88vEPK+Ee1dbRFXgqcM9WKFIa+0LOGSrGmmADfEJb/n4pGHc0uuTqx/kr037hbC64oqLnWHQXTwnMlPHqWDbKWeWIyvxZ7DPuZGZ2XEWiptXIJzrTXqV6qA5PUMw4yFseYUGrf9fGgsWD4fGgZWpL0o+SigKj59QA7IRkj2FyZUskKktNTCdLuUi7FZMM2nB6gPOfhb0EcJ60Y1hytia+L+WEMn1yIl7xrwPbYOzxHdyI/X72ryHu1NcSE5/XYcc+vAzOk3lpd5A9fUQqHzCENj/DTQGN8Xmk3CnwSe5dWF5wsgTrQydAD21oQQQAfOiz3WfLAr/WGzIXEBQ1BxE+ORSYpDp4phSp+rwzMlpegPggfQtFD4k4w+LR3PuyJFdxqBeAYvsGM+LZbhXDeP79Qpum56btxkqkRf7+P5ezFvLjEIo5PVOQaMisUwDFUmibn+f1QkhotS6zLro3FIgo8hHWApnRlTn9qpPW3FlwUdeadpom6+uMppqsnjhiWbejqFsq87uNwR50mR+bl2MHmKomINY8OBKNBYErHveI6tsofbOo5nIvia3ZW4tLagmsOJtr8ir256WzAHsKCSZhlkTOVHUmKLw7PXYhurWE/Rn3jsnozH05yhbXb9eEW0H3OMqc2zEo+txnuorb0quxW5uuMnHvHC/IapTnSyi+6e8fR46JC6veUbQkZewd2SXOAR5n+L24vXQaRMrbAIQopsd4cbEfIvj654p2ZEXl1gLuriklyYbNTFJabMLv6TCw7/6KA9etyM5mjj5sPt0fqoI9lk+2xzZ/EIKNJm9i5eHxoxRUVrQ6epUB1EHBNY4d9VQXci4vhbgZqLi4mXi+Gx7wQ1M05O+zr8ir1eDGFM3tbx1tILYM9pMTOg9x8T9GRqW0CjWqZcMG+S5WipxhIGSsZc/dZQknwuphaPMDAHiIt03BIHxreVCERSztxav+M34yEbjGwCw+d/szzDJxS5Xe+6/7FJyW21wmACe6JNDKDc4eNXcQOqKpBaFXieU0o4ovJ+NpyuLaODtZgydX8KuO+Zup8NVpR6JdYrxHxEqL+U+pc14j0YZ8HSU+j0+sP72Sq1/WhXUw6D5iO2PWe/tk6D9gF+jHrc1muExyuB/bLZffawqc3FGrnqWUQTu1MJrSM83N62b674Fr/um/ojQhy+i/xkX05t3qjvczpSAW97jEIVrccuh+0OoS2FbcbPFcXWLWBmjuNTCWrG2UpXD3Wv4rcNudvSNNZQa7yyvK8GpkrYHsvBXG49IEeFY17ntZwWPhgYL2aglw8gVX9XOuSgRyDgBjgDTSzhfqRb4AdKA9cPIit3si3+cZ4W9TZkSUXXhcBVfwiU9w0uDvAmRmg858Dm1woeklh6idcm4pt2QfPw8XCUvGx8V+X2FcLQIDRJhMRWF9jysRkTL1elhcSnEZOMKnUo95uKCDFE6rRtz552ymc7/1x0gXXtMskoy49mnIb6szXR61+vBuyz0s9qDoN/MizJJw9jU8HeFVlZq5kxI2wDRmj84jL+rEC9GQJ+2tQcsK1ykR7M4Z0hQZDE8tTz6/rXq+VQssqfqOqfce2wuq6nRuUXr8zEqLNUO19fdBnvhgqzZmhzbD1r1mFLxRUrvKpgxZAi/EtpCx6SJh+uvWz3TNXsx9juqfrqCSljbD6N2eIEiB6Y9kb2Z3cj+9q2X1ouXz9FTqqDbl52ykb6kJ4Ea83Nh/dyHO0Jb3GjfVb9MfExfFfVZS8Z/4CdmvBWNcbE79bYuVNvq399FEpfkptNqU17CSHJVw7fCsDff5eah7oFNxevB8VMtytPScRImn6ipJ+Bpoyjt6fJhsGDu2vMeNW1vU7gGwdNzcgt+hir8jHQkRylJWhfTJ/vMB3AhU99E33X/3N3hTtOwzD4/56iD8Az8APQiZMAHZvu95at6VHUa0u7Ifb2yGmcZq3dJGvKjok/nG5nf/7iZHbiOIwr21D5ywPuJcxk3qNPTAQfAe7cF4IjGeo+yLr7YereyrKH/Nfjml5WF7zq44Gt2quG9EuR9iJL3QDfaMJufD0468iaxVkISKSXH9vsVBRn1OZkE9FhjcCvU3UU0ZYWS2aUxWX+KSp3DXet7f8O9jsv4w5ZCkHQaegqCOC0+4doUrUX30InEIzNkTtS/ZxM7dLQkSRUQfSY9FZhW6jPps+1fJfswNQd2LqDxXtHKiYNv8I+JU43zQQ4oq6LHLZf++tDAzHcj+P/IFZYDvKDjDRdtLQoE2WOh2w0Dj6L170gubzIQ8djeZRNKYrk8cm4vLafVin/dH+wjWEZCks+fdvwU8CozOtoCpl0r6hEut2LAl7cmkPrl0qkyQctB92TyzHnTHE0bCQDheclXC9sZ7mIksChRwWQRc/xCVTzmZIz+N6hV3zHhghSRW+BoAaV4WSnIl5gjxKjRfZTJBDBywRKDFrGtUum8RIsChttwTD6Q0AY2wxk06SFpRoX5JkY6qpsY+H4tA/8THhq8NaSJ/EWaYfWEw7wZvkHBw6B9dnB0k5o5SHXV58u7IvGAy2wb8MH0fM8gA023of77vTA+i7J9jb8zeO8CzR8tFc31e8cts9kc60qPF3sJfVRn42CBtBIdbq2JXpSBWBYd1J0ZyulPz2X4jU/CEiY9bebPsJqSSD6oGyfq43oWec+X6HIQGGQmYAHcQ03cOwK7a+1FhLIrHjkYtgdUYl6OTWW9ythfS/FODOAbs0YMBLvV3xrOzYgVM1Pqa1ivzEY6+zf9ST1hZS0uax7ek5OLSxDY/o5sm0o6m9Hv50CNCX1wsiKrId0C3cpsJWULfuRyT2cAIbtf1DK97FqpKbceotlNYlSlBVXqecJ1BMkAITdGEblQiV7yzkRVVEQwJqrQi6A2e5JDV0qRxuMoJu2vXMy1puNHxXwtA9cMpXH0UfuixF4qQ6+6yG492KmFi9drNCugrEHXRwHRQmjCcG8ip9V888QKW0kLsQTtfZQlzHdOkAfs6LjaDYsyVpSFzVhuMmCoshSQ5fQKcFvcxY+5IXUgakq4DV2uzswu4+R75QiY7ibI6KP4N3TAzbzzNhG1IfjpAnQ+kamW+5miW1ILZsD3WspwJSnTggss1WW6AILlS2uOBNyuJzSLjLEjO94jrCnyc9gJGtEDyZrpFwczEMjpQ8Y+s2N2Gi6+NUDzn/gxnlZpbJd/R0A7B5pZg=="
 }
@@ -46,26 +46,33 @@ var mapping = &prometheus.MetricsMapping{
 		"workqueue_adds_total":                        prometheus.Metric("workqueue.adds.count"),
 		"workqueue_depth":                             prometheus.Metric("workqueue.depth.count"),
 		"workqueue_retries_total":                     prometheus.Metric("workqueue.retries.count"),
+		"workqueue_queue_duration_seconds":            prometheus.Metric("workqueue.queue.duration.ns", prometheus.OpMultiplyBucketsRounded(1000000000)),
+		"workqueue_work_duration_seconds":             prometheus.Metric("workqueue.work.duration.ns", prometheus.OpMultiplyBucketsRounded(1000000000)),
 
 		"scheduler_pending_pods":              prometheus.Metric("scheduling.pending.pods.count"),
 		"scheduler_preemption_victims":        prometheus.Metric("scheduling.preemption.victims"),
 		"scheduler_preemption_attempts_total": prometheus.Metric("scheduling.preemption.attempts.count"),
 		"scheduler_scheduling_attempt_duration_seconds": prometheus.Metric("scheduling.attempts.duration.us",
 			prometheus.OpMultiplyBuckets(1000000)),
+		"scheduler_scheduling_algorithm_duration_seconds": prometheus.Metric("scheduling.algorithm.duration.us",
+			prometheus.OpMultiplyBucketsRounded(1000000)),
+		"scheduler_pod_scheduling_sli_duration_seconds": prometheus.Metric("scheduling.pod.sli.duration.us",
+			prometheus.OpMultiplyBucketsRounded(1000000)),
 
 		"leader_election_master_status": prometheus.BooleanMetric("leader.is_master"),
 	},
 
 	Labels: map[string]prometheus.LabelMap{
-		"verb":    prometheus.KeyLabel("verb"),
-		"host":    prometheus.KeyLabel("host"),
-		"code":    prometheus.KeyLabel("code"),
-		"method":  prometheus.KeyLabel("method"),
-		"queue":   prometheus.KeyLabel("queue"),
-		"event":   prometheus.KeyLabel("event"),
-		"profile": prometheus.KeyLabel("profile"),
-		"result":  prometheus.KeyLabel("result"),
-		"name":    prometheus.KeyLabel("name"),
+		"verb":     prometheus.KeyLabel("verb"),
+		"host":     prometheus.KeyLabel("host"),
+		"code":     prometheus.KeyLabel("code"),
+		"method":   prometheus.KeyLabel("method"),
+		"queue":    prometheus.KeyLabel("queue"),
+		"event":    prometheus.KeyLabel("event"),
+		"profile":  prometheus.KeyLabel("profile"),
+		"result":   prometheus.KeyLabel("result"),
+		"name":     prometheus.KeyLabel("name"),
+		"attempts": prometheus.KeyLabel("attempts"),
 	},
 }
 
@@ -89,7 +96,7 @@ type MetricSet struct {
 // Part of new is also setting up the configuration by processing additional
 // configuration entries if needed.
 func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
-	pc, err := prometheus.NewPrometheusClient(base)
+	pc, err := util.NewControlPlaneClient(base)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+// Credentials mounted in the pods running with a service account
+const (
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceAccountCAFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+// NewControlPlaneClient creates the Prometheus client of the metricsets of control plane
+// components, like the scheduler or the controller manager. These components only serve
+// their metrics over HTTPS, to authorized clients. When the endpoint is an in-cluster
+// HTTPS endpoint and no token or TLS settings are configured, the token and the certificate
+// authority of the service account of the pod are used. The token is read again when it is
+// rotated.
+func NewControlPlaneClient(base mb.BaseMetricSet) (prometheus.Prometheus, error) {
+	config := helper.DefaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+	config = controlPlaneConfig(config, base.HostData().URI, serviceAccountTokenFile, serviceAccountCAFile, isClusterHost)
+
+	http, err := helper.NewHTTPFromConfig(config, base.HostData())
+	if err != nil {
+		return nil, err
+	}
+	return prometheus.NewPrometheusClientFromHTTP(http, base.Logger()), nil
+}
+
+// controlPlaneConfig completes the HTTP config with the given token and certificate
+// authority files, when they exist and the endpoint is served over HTTPS by a host
+// of the cluster. The token is never sent to other hosts.
+func controlPlaneConfig(config helper.Config, uri, tokenFile, caFile string, inCluster func(host string) bool) helper.Config {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "https" || !inCluster(u.Hostname()) {
+		return config
+	}
+	if config.BearerTokenFile == "" && fileExists(tokenFile) {
+		config.BearerTokenFile = tokenFile
+	}
+	if config.Transport.TLS == nil && fileExists(caFile) {
+		config.Transport.TLS = &tlscommon.Config{
+			CAs: []string{caFile},
+		}
+	}
+	return config
+}
+
+// isClusterHost returns whether host is the node Metricbeat runs on or a service of
+// the cluster: a loopback address, an address of a local interface (the node IP when
+// running with the host network), the address of the API server service, or a
+// service DNS name.
+func isClusterHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || host == os.Getenv("KUBERNETES_SERVICE_HOST") {
+		return true
+	}
+	if strings.HasSuffix(host, ".svc") || strings.Contains(host, ".svc.") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() || ip.IsUnspecified() {
+		return true
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return false
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+
+	"github.com/elastic/beats/v7/metricbeat/helper"
+)
+
+func TestControlPlaneConfig(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token"), 0o600))
+	require.NoError(t, os.WriteFile(caFile, []byte("ca"), 0o600))
+
+	t.Run("https endpoint", func(t *testing.T) {
+		config := controlPlaneConfig(helper.DefaultConfig(), "https://localhost:10259/metrics", tokenFile, caFile, isClusterHost)
+		assert.Equal(t, tokenFile, config.BearerTokenFile)
+		require.NotNil(t, config.Transport.TLS)
+		assert.Equal(t, []string{caFile}, config.Transport.TLS.CAs)
+	})
+
+	t.Run("host outside of the cluster", func(t *testing.T) {
+		config := controlPlaneConfig(helper.DefaultConfig(), "https://metrics.example.com:10259/metrics", tokenFile, caFile, isClusterHost)
+		assert.Empty(t, config.BearerTokenFile)
+		assert.Nil(t, config.Transport.TLS)
+	})
+
+	t.Run("http endpoint", func(t *testing.T) {
+		config := controlPlaneConfig(helper.DefaultConfig(), "http://localhost:10251/metrics", tokenFile, caFile, isClusterHost)
+		assert.Empty(t, config.BearerTokenFile)
+		assert.Nil(t, config.Transport.TLS)
+	})
+
+	t.Run("configured credentials", func(t *testing.T) {
+		mode := tlscommon.VerifyNone
+		config := helper.DefaultConfig()
+		config.BearerTokenFile = "/etc/metricbeat/token"
+		config.Transport.TLS = &tlscommon.Config{VerificationMode: mode}
+
+		config = controlPlaneConfig(config, "https://localhost:10259/metrics", tokenFile, caFile, isClusterHost)
+		assert.Equal(t, "/etc/metricbeat/token", config.BearerTokenFile)
+		assert.Empty(t, config.Transport.TLS.CAs)
+		assert.Equal(t, mode, config.Transport.TLS.VerificationMode)
+	})
+
+	t.Run("outside of a pod", func(t *testing.T) {
+		config := controlPlaneConfig(helper.DefaultConfig(), "https://localhost:10259/metrics",
+			filepath.Join(dir, "missing"), filepath.Join(dir, "missing.crt"), isClusterHost)
+		assert.Empty(t, config.BearerTokenFile)
+		assert.Nil(t, config.Transport.TLS)
+	})
+}
+
+func TestIsClusterHost(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.96.0.1")
+
+	for host, want := range map[string]bool{
+		"localhost":                      true,
+		"127.0.0.1":                      true,
+		"::1":                            true,
+		"0.0.0.0":                        true,
+		"10.96.0.1":                      true,
+		"kube-scheduler.kube-system.svc": true,
+		"kube-scheduler.kube-system.svc.cluster.local": true,
+		"metrics.example.com":                          false,
+		"203.0.113.10":                                 false,
+	} {
+		assert.Equal(t, want, isClusterHost(host), host)
+	}
+}
@@ -0,0 +1,213 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !requirefips
+
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/icholy/digest"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const atlasAcceptHeader = "application/vnd.atlas.2023-01-01+json"
+
+// defaultAtlasMeasurements are the measurements collected from the Atlas
+// Administration API when none are configured.
+var defaultAtlasMeasurements = []string{
+	"CONNECTIONS",
+	"OPCOUNTER_CMD",
+	"OPCOUNTER_DELETE",
+	"OPCOUNTER_GETMORE",
+	"OPCOUNTER_INSERT",
+	"OPCOUNTER_QUERY",
+	"OPCOUNTER_UPDATE",
+	"NETWORK_BYTES_IN",
+	"NETWORK_BYTES_OUT",
+	"NETWORK_NUM_REQUESTS",
+	"PROCESS_CPU_USER",
+	"PROCESS_CPU_KERNEL",
+}
+
+// AtlasConfig contains the configuration to collect the measurements of the
+// monitored process from the MongoDB Atlas Administration API. Collection is
+// enabled when a project is configured.
+type AtlasConfig struct {
+	URL          string   `config:"url"`
+	GroupID      string   `config:"group_id"`
+	ProcessID    string   `config:"process_id"`
+	PublicKey    string   `config:"public_key"`
+	PrivateKey   string   `config:"private_key"`
+	Granularity  string   `config:"granularity"`
+	Period       string   `config:"period"`
+	Measurements []string `config:"measurements"`
+}
+
+func defaultAtlasConfig() AtlasConfig {
+	return AtlasConfig{
+		URL:         "https://cloud.mongodb.com",
+		Granularity: "PT1M",
+		Period:      "PT5M",
+	}
+}
+
+// Enabled returns true if the Atlas measurements have to be collected.
+func (c AtlasConfig) Enabled() bool {
+	return c.GroupID != ""
+}
+
+// Validate validates the Atlas configuration.
+func (c AtlasConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.PublicKey == "" || c.PrivateKey == "" {
+		return errors.New("atlas.public_key and atlas.private_key are required when atlas.group_id is set")
+	}
+	if _, err := url.Parse(c.URL); err != nil {
+		return fmt.Errorf("invalid atlas.url: %w", err)
+	}
+	return nil
+}
+
+// AtlasClient collects process measurements from the MongoDB Atlas
+// Administration API.
+type AtlasClient struct {
+	config AtlasConfig
+	client *http.Client
+}
+
+// NewAtlasClient creates a client of the Atlas Administration API, it
+// authenticates with the programmatic API keys of the configuration.
+func NewAtlasClient(config AtlasConfig, timeout time.Duration) *AtlasClient {
+	if len(config.Measurements) == 0 {
+		config.Measurements = defaultAtlasMeasurements
+	}
+	return &AtlasClient{
+		config: config,
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &digest.Transport{
+				Username: config.PublicKey,
+				Password: config.PrivateKey,
+			},
+		},
+	}
+}
+
+// ProcessID returns the Atlas process ID of the instance the database belongs
+// to, that is the configured one, or the host and port the instance reports
+// as its own.
+func (c *AtlasClient) ProcessID(ctx context.Context, db *mongo.Database) (string, error) {
+	if c.config.ProcessID != "" {
+		return c.config.ProcessID, nil
+	}
+
+	hello := map[string]interface{}{}
+	if err := db.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return "", fmt.Errorf("failed to retrieve 'hello': %w", err)
+	}
+	me, _ := hello["me"].(string)
+	if me == "" {
+		return "", errors.New("the instance doesn't report its host, atlas.process_id must be configured")
+	}
+	return me, nil
+}
+
+type atlasMeasurementsResponse struct {
+	Measurements []struct {
+		Name       string `json:"name"`
+		DataPoints []struct {
+			Value *float64 `json:"value"`
+		} `json:"dataPoints"`
+	} `json:"measurements"`
+}
+
+type atlasErrorResponse struct {
+	Detail    string `json:"detail"`
+	ErrorCode string `json:"errorCode"`
+}
+
+// Measurements returns the latest value of each measurement of the process.
+// Measurements without values in the configured period are not reported.
+func (c *AtlasClient) Measurements(ctx context.Context, processID string) (mapstr.M, error) {
+	query := url.Values{}
+	query.Set("granularity", c.config.Granularity)
+	query.Set("period", c.config.Period)
+	for _, m := range c.config.Measurements {
+		query.Add("m", m)
+	}
+	endpoint := fmt.Sprintf("%s/api/atlas/v2/groups/%s/processes/%s/measurements?%s",
+		strings.TrimSuffix(c.config.URL, "/"),
+		url.PathEscape(c.config.GroupID),
+		url.PathEscape(processID),
+		query.Encode(),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Atlas request: %w", err)
+	}
+	req.Header.Set("Accept", atlasAcceptHeader)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request Atlas measurements: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Atlas response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr atlasErrorResponse
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Detail != "" {
+			return nil, fmt.Errorf("unexpected Atlas API response %s: %s (%s)", resp.Status, apiErr.Detail, apiErr.ErrorCode)
+		}
+		return nil, fmt.Errorf("unexpected Atlas API response %s", resp.Status)
+	}
+
+	var data atlasMeasurementsResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode Atlas measurements: %w", err)
+	}
+
+	measurements := mapstr.M{}
+	for _, m := range data.Measurements {
+		for i := len(m.DataPoints) - 1; i >= 0; i-- {
+			if v := m.DataPoints[i].Value; v != nil {
+				measurements[strings.ToLower(m.Name)] = *v
+				break
+			}
+		}
+	}
+	return measurements, nil
+}
@@ -0,0 +1,158 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !requirefips
+
+package mongodb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const atlasMeasurementsResponseBody = `{
+  "end": "2026-10-15T10:05:00Z",
+  "granularity": "PT1M",
+  "groupId": "5e2211c17a3e5a48f5497de3",
+  "hostId": "cluster0-shard-00-00.abcde.mongodb.net:27017",
+  "measurements": [
+    {
+      "name": "CONNECTIONS",
+      "units": "SCALAR",
+      "dataPoints": [
+        {"timestamp": "2026-10-15T10:03:00Z", "value": 12},
+        {"timestamp": "2026-10-15T10:04:00Z", "value": 15},
+        {"timestamp": "2026-10-15T10:05:00Z", "value": null}
+      ]
+    },
+    {
+      "name": "OPCOUNTER_QUERY",
+      "units": "SCALAR_PER_SECOND",
+      "dataPoints": [
+        {"timestamp": "2026-10-15T10:04:00Z", "value": 0.25}
+      ]
+    },
+    {
+      "name": "NETWORK_BYTES_IN",
+      "units": "BYTES_PER_SECOND",
+      "dataPoints": []
+    }
+  ],
+  "processId": "cluster0-shard-00-00.abcde.mongodb.net:27017",
+  "start": "2026-10-15T10:00:00Z"
+}`
+
+func TestAtlasMeasurements(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), `Digest username="public"`) {
+			w.Header().Set("WWW-Authenticate", `Digest realm="MMS Public API", domain="", nonce="nonce", algorithm=MD5, qop="auth", stale=false`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "/api/atlas/v2/groups/5e2211c17a3e5a48f5497de3/processes/cluster0-shard-00-00.abcde.mongodb.net:27017/measurements", r.URL.Path)
+		assert.Equal(t, atlasAcceptHeader, r.Header.Get("Accept"))
+		assert.Equal(t, "PT1M", r.URL.Query().Get("granularity"))
+		assert.Equal(t, "PT5M", r.URL.Query().Get("period"))
+		assert.Equal(t, []string{"CONNECTIONS", "OPCOUNTER_QUERY", "NETWORK_BYTES_IN"}, r.URL.Query()["m"])
+		_, _ = w.Write([]byte(atlasMeasurementsResponseBody))
+	}))
+	defer server.Close()
+
+	config := defaultAtlasConfig()
+	config.URL = server.URL
+	config.GroupID = "5e2211c17a3e5a48f5497de3"
+	config.PublicKey = "public"
+	config.PrivateKey = "private"
+	config.Measurements = []string{"CONNECTIONS", "OPCOUNTER_QUERY", "NETWORK_BYTES_IN"}
+	client := NewAtlasClient(config, 5*time.Second)
+
+	measurements, err := client.Measurements(context.Background(), "cluster0-shard-00-00.abcde.mongodb.net:27017")
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{
+		"connections":     float64(15),
+		"opcounter_query": 0.25,
+	}, measurements)
+}
+
+func TestAtlasMeasurementsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail": "No host with ID unknown:27017 exists in group 5e2211c17a3e5a48f5497de3.", "error": 404, "errorCode": "HOST_NOT_FOUND"}`))
+	}))
+	defer server.Close()
+
+	config := defaultAtlasConfig()
+	config.URL = server.URL
+	config.GroupID = "5e2211c17a3e5a48f5497de3"
+	config.PublicKey = "public"
+	config.PrivateKey = "private"
+	client := NewAtlasClient(config, 5*time.Second)
+
+	_, err := client.Measurements(context.Background(), "unknown:27017")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HOST_NOT_FOUND")
+}
+
+func TestAtlasConfigValidation(t *testing.T) {
+	tests := []struct {
+		Name   string
+		Config AtlasConfig
+		Err    string
+	}{
+		{
+			Name:   "disabled",
+			Config: defaultAtlasConfig(),
+		},
+		{
+			Name: "missing keys",
+			Config: AtlasConfig{
+				URL:     "https://cloud.mongodb.com",
+				GroupID: "5e2211c17a3e5a48f5497de3",
+			},
+			Err: "atlas.public_key and atlas.private_key are required",
+		},
+		{
+			Name: "valid",
+			Config: AtlasConfig{
+				URL:        "https://cloud.mongodb.com",
+				GroupID:    "5e2211c17a3e5a48f5497de3",
+				PublicKey:  "public",
+				PrivateKey: "private",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		err := test.Config.Validate()
+		if test.Err == "" {
+			assert.NoError(t, err, test.Name)
+			continue
+		}
+		if assert.Error(t, err, test.Name) {
+			assert.Contains(t, err.Error(), test.Err, test.Name)
+		}
+	}
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetMongodb returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/mongodb.
 func AssetMongodb() string {
-	return "eJzsXV+TI7dxf79PgVIeJFXt8cpOKg9XjqpOlh0rpbMU3bn8kErNgjNNEloMMAYw5NGfPoUGMIMZYv7w7+0xy4fEuiUbv240Go1Gd+M1eYL9W1JKsZbF8hUhhhkOb8lX7+2//PD9V68IKUDnilWGSfGWfPeKEELeg1Es1ySXnENuoCArJUvif0Q0qC0ovXhFiN5IZbJcihVbvyUryjW8IkQBB6rhLVlT+x0whom1fkv+5yut+Vf/+4qQFQNe6Lc42msiaAkxSvsx+8oSULKu/L8kgCJYj6p0oBf+D/EI8SiWJ22o0c1fUmONjBeP6QXEpCCWJtPGiq2HxH66EgmfPsYYZyOILsgn2O+kKnp/G4FqPz9QQ5dUA5JuYSXHbVm63Ph/bMU0A4H9v5ccu1wyQXFwuSJFEAUVRTx9M3AZaShfGFbCotZJgFyK9XHoPlqaZEeZXSHE0iYrqQiX+ZMmTJCS5UpqyKUoOvrUR5XLWpiLYhJ1uQRlRWbBIEQCWxBGT4jJfj2JpL++yMAKiIkpoMWAyEdZnMEmsmoFHqRvBW/HmyP9A4ypCbgEwr8209BAmzEXMbydYgZuKUMc8FghOpTXl2IL7giV/kcNioG+9Nq3glO1EFZwfoh5az7gueSqb0UUoMAnyGsDxYRw1mBKqYY07CzhUP0UlMoOQfJaabtI5W6moAK26wjKcqwJDbCofrKeUgBrd5oJ0TGhQZlrSM5RtsITsCOFzOvS6vk8qXlY1xFawOJHmbcA66qgg0bsLEEhZSunI2XkEV1ZRm6UeTJSUMrtVWRUAIdTZOQRXVlGiG6mjHJZltTivYKUnLlEMQUfMww3T1wNuCsJ7ADVTAPfPy2Rszw6pLbQ7J99H3+Uyxmc2s/HDTiHmNQil2WlQGsoiB0N5wBKqfZWGJRzoiCXyk0OjY4Cwz6Kg34tH8Vib51uufwNcqOJVN1VZzZMHwGWbtc/L3/7cD1p0y0ougYnYitY4aE7sBCfsb5hgiz3BvS3U7C1kZbqFXE7LaGlnUyL249oFUPm1EBBjOwLG/f2xu6EX8znCsf8URTw6eqMNdPBOWF2RNDHAr0iRl2X6D/Z/9nONB7JuzI6AnNJP10B7YeN9TUt0JJ+YmVdRku0XZdmQw0p6Z4sgVib49wba1VoVUFxxHoVfrKubl+CUsj+Kl2Qd5xH/63Jhm6BUEM4UG2IFOB+HH6ascIZ/Za3NoZ1dqTNkyCVkltWWHdbELkFtWWwQwUnFVWG5TWnykUQm41uQT7a9RumqUOWaVJKbUguRQ5KQEF2zGzwp2QreW0de6TeEIt+fnwwj27XmVz+ltlluUB9nru9r6QqqXlLuj8a8B+aOUtSZ8LAGtQ4EcvvdWHODGwmf7tiHK6LDlX7BkPMoZz8tajLDD4Za3dOpOBdixN/7Q32dUUkNNLPyuWiTKvLNFDUZFSYLSg9FM8+xYUt6W9SzbPQwzSYOIlG+L1TgWylADLOdPq4cApzoi7PZG1QMwYIRdiSGtKIzF2rTO4mafM8ssd8aK9ucCdXsLKG1G2MtVJ4EvdXBnY3cp5VE7p7dBdXj4QJbajIu15TEw9iwrHHpHgghj4BoYRL+WS31o0xlX775k0hc73w92CLXJZvSipqyt8oWIECkcMbf3h74+7gLPJav/kXfyOH/7U4lNPY1hROg7MVaMLV+BUqqUzjWFi5pQ6fh46Q9U8cUHdUc3dozWGVKkCCFrVOn2ft/ADNN2RLeQ12j6fp3R8ZdDPtwJrGcW49pINjMqGa7IBz+/9Nx5laUcahCBEAKcbC8n3GFn/w/+u7hSejG59T9EKNzVfDiNIfCJ3KHMp0ZDB/TpgxVsP/+GhThoXpTANfDdqElK6N0Y1pO3EkvzJhcnoXaceTaFy89VrBmprhM9MXzuCyZrzIrBW7Vw6tA52lol13xKEQ7oiQVVLePbNjgbovnLlied+TV1iHTOR3O38rJlIA7oK3NZgsL4uMMwGZrO5XSS2jnGqTgVIjp7l74FKmhrgb9uwB7Z75q6iiJRi4Wx3dSG3u2jd12RJ3y53OSqrvWD9xlxgO0N8bn8mQ0v0wybTJQvjpbr045HLqOvIL57FiSfr3wZuSK8bv1q1RUHENJrPujVqyu12GMZ8uwH/vnG6AKrMEerfeTmDUZXdmldQsUW9zN+y6y6k7V143l/fK3W5DjS73tWL3wuGrFA2XyP9qLnuTlWfCUCa0yypSsKaqYGIdygXcTTIVBam76UVkxmWaYSUUmayvlYh6cBmKmP39OCaEIQIia0M0E7m7m3Ur3XoeOWhtOVSd9OI+F7KC463eDA6GJG/HC6wcopqnq0JmlvW06Mm0vs5igBzmA0fQXa6aFbhEKuSH7/+7BrVf/Iz/uRDyo0NINBhiJKkUZscTDzzFescnFqcuxhOZe3RjPk5O0NkG7QyQnSUQCoCZJiVlqG2hUinnbEYlwkBewhlpF5iz0qY7EprjIrQGppQFW7HcFcFW1BhQ4kC+UybH1VkM+9mXNThtcq0fd9iKuNDYZ0AWBh4rDTW1Sq+m60ILA5PlvqkWnPBdbg/SjztdXzh8yXLyerErxorIJb8gaeI81JCZXinIAbcNVzMqK1C+jFx0UsPaS/VjlxQSznaUmUV5rZTvfqGDceWBpGScM1+P5GwaZrljag/ZUE10ZZmrQK2kKq0Y1mB+otr8CWXVSCOFm7gdysnNp1iTb9gCFmT3LVkroAaUHVSQ302UBDvpXLPmpq+fA2w6lqgVS85WDIrLsOe35Osn/LusLpzoLvCIxZ5jRzWx3pOuuQmLYufxErNRoDeSY51MLLKJ1dyMdqmF/BfJC+1yPUBp3IE1bEFRjjRxOfsySrsVWmO4j3nubOcbKgoOmtTaKjxONeXRykeKx65ynVORUVFkUhUjNxyX1eJQxO3THK2pI1ra3cp/yf8pp0LIZpk7L14qE/HsZEGFy1uf0udcihVnh+nlN+EThNeDeHU6ODMq/PeZy3S83CYTcmHxGIItdKx8nQo2yaJE77WB8hStElCECP1NTaSr/2EGSh1wkKJWcR+DZq29rjgVBLaU13SiAspz1DgIN+WpdUtO5yipWgoq7n3vS+uVT+8lCnhbsgjxiOEsPpwaHH+ZVhVQhbnblPPgDIS0dv2ARdbEbKQGt+CoAvG1ISU4i4JdSpCcPXkebSgHlt+4qGaIi3TT0rdUMVnruGOT3TX6kgtoTg0UhC1p9JA6xNHUIPFAeLbPcnQ7hs7tZM6xuE/Sul6XoZdb/eFDojiSmIV1IVI630BRD8ceyayJIjMmKx5VgMnLsSFnckE6+aA7qfp9l86kCZ9yXmu2TYW1zyBrgWbJ250ziV6W4ooyXqvk3fcRVCMHox6kdTlbwERWKblWoKdXyIVV+vIz8Mx1ujEiHKAaNvRHUlNAi/1lSK0UjLE5U3NrodlaUA5F5nqsjCrxJLl2a5naGydJ6U2NnSWzQu5S9wgtqaWUHGj6Oz0VzpjdtVc0HxKdo5juRdhSo1XFU9N4SUfGeix2nOCxuGuCcLBtzhuxUyMrLtenejTUGCgrozMjsyXksoTMBZCoGtLYmTO5pCbfnGEeZwb0e7JDYXQkGG6u7KnMc2Zd/5n+bfjMNdZDx5su3xOmbSbrZODY4yXvpAAFobmSWqP7HzLcBvnsXkemQ5jX52UytJmIauLq9DeCB+tlcI3EUawTTddRd0/9uXL6GkH2kzZ8pF7Wq9UJSbUzMIaImRtBH2JLmh+9FznRslY5+F+SJaykgnhGLCEQJrRDpE5H0xOSOMUuPOG2YwjZ0T2ejRXNn6KV77541vnuBloQrhk6d8FBykzM4qdFXdKR7hbXgB/65oSORBauB+r6s0TF2rnEUIMJLaMDe279PmAIhmkiJF66rNi6VnTJD5InuhzflNswWTG3uRTYriP89/hktZ48M4zyzC6Z6/kRYRi/ModStOath7IauiEm810BlwuUBV/jfGIjZ7gZmU/DB5uLeXJ+CGyVsNzPj+DNm5ZbaX5/M/apN7SY3AnGl69v4Xpr39APG9naB7LbsHyDjSgU/KMGbVzgkBYF5m9S7i/L+r5EOq+s/VCNTay69mGmCpAvzN88lOuX5FzaeWfKRdp9NU90uXPI2/P0IhvvYWR5zliZCgpQeiLwfH1/2F2n+SUCmng8wztrpYBLenzBwPGH88dKwesVmHzzaPfWNVgbAgoa79ni0NHVEzo4vjMfE0aSX9+9t7rGSuvNdqfIbJSs15sqnc83Z2soZH5rs9qyalmHwnHp+7L6CzeffOME5+R2D5bvgPVhdqNAQ/rKtM/fxUxkrs81ke6AbVnsdozubXKncNy6xkNFaeSKqhtfeh+lvDNn80vSZSeLcEj30ljum0CZP9QnmqtfQA3Is/QNHvqRp4feUujK7EQ5vEoJwbdkXDTNABcaqMo3r1KSOKUn4LLOn8Bk8GlDa329TNFksloUu8s3kD9Z52sDeLeBJaqYlydrgx1VMFOJ6JoZuuR7wqlag+/zHTo7j6agBL/+RgzGcUmcMf9+BN1SxumSJ7CPpPH6vJWrYx9Dl2Jp6lkl08/jPzsvponvNxm7K+/h+tij70qIbf+M75A9dMqamRf/2dOWPA7nz8f9m33iasPoiGdMrQd9ow73YY0DWdL8yc62KJoLGPd0ROwaH8FWp86yH9JKK9fRPUNb2oOtqaOrIh9ZiyU/1pMTDzaXWhTulDQBsr0c9FBDtINhLYFisA2qVSwXazC/tr/7UazkNwf92SfzF9k/YdF02z9W29KdYmfLhAy9AlBRZx2KOBLn5zm0oh+xwJaneqwnxPXYSbPSe85ggp9BxlZMafdOkTa0TPnVZ9uGQDvYZRzSZ9UDVZyBNt9GJt1fDSTW+CAXnN6aCTui54FTcz4HOyYKubuSaS7YyncZJkswOwARTQQVheNmBP+UmwomExd92/Gj7wfc7u+NxZ3EgjXpiZptByfxh7kFeHFpEJ6IaW69JDwcyrhMFt3bELcqGgY+gPlPMK6zc2hCPFlUgVp9MX+/6eLDzHW8/R/jXthNANLuSZVk/kyFnYbxPPkQlfC660BtrO9pJRzVZrjSZHv4BIHp/8ZqsCTUNWxnZt/flf1xddig+wv2L1ICIaMjvGPjKI0ulT77hbtf/SLZjxTA/uQ3WStBeY/w8YaD04s5Zj8AxydjnKmlQ2WGlWIlVXsXGzbWJFR7p9ZDyVfT7xcc/VROcFCsRiQqGWZwixwf7jDOdNl5iPnUXO5Am0EOI17YcAeB58HLimqT5iWpYBughZKy/wjD6VqW3tU9wq9D5o7zLNwTTL27IE7XzWtNd6Bxp3E+PIsHvD4jjTxxlgdXX1Jj/S56KYX9JZxUowczCF3KeqjOyA3SHNmYb61ypIp6US028uAxFTKZ9Ttrfv5idy1aFAozTFfxMpxE5UzLdXD93Ddbw+fboBAopeEo6VlwfmLaHWDDYCQ92CGotBt8USnpGBlLjHWI6pxomhpD9MfmqD8Dj4JcbkExsb7F3LWjNWt2fBIjeNeKPjbiCoj8+fWxHfsxaTniUognIXfiFhL0IL92cmuwegRzgd5MlhiMnYsO+0LV1e9vIUef38j+OV8XG3g318Qw8pQAqVoyA+oW8vNDTcksILq6yAKe4TPrjRboY4GaPk+lipssxi6kYVsrOV/S/OmGZqy1tX7sKQ1vMN5+N5iJsBYboNxsbuIL9XYDtLZ+ePIfZEW5ngP06rJshjrQwci2zrmOGxk0pM75IKq/ywh1INFXj3+ldeydSMoZ7U9yRc3GhZJZDov0ryfE96PvrRDGTr8M74PERwIL99mJmPuMuHoIYQd5B2rkF6k1W3JwhRvuYT689cfHylNvw7V9xtLpOoP6N1d4jnA/AScNgsuc8ixxmjo18P+TJegj/dq/KNjeHzaNAj3WifAi1RqU0QsF65rTdCOa4wX11+bS3dP14+A9eqVkUectYrew0sIL8HZUicPW6efD83TPhVfqy0Mr9dmwan1Qi3c+Lkv0XGB2z7NHoEstzEjfAuVjICaxtl32LhZp+qHbHTa+uGtbATKRS2x/FwEgNPF4l0+LYtxfNGG6c3ijNDUL0zEpj+YKW3avtWnEmr8v6SH36Te+tSkW7iXpeppM5LwuoHsZqjfAOdGgcaMjf5RCswJc4x1XBChTr4MS8thknD3ixUdRuBd1sbDuk2luuApq6oM+WpG2BypXkGdPoLXAHJI2Uy6pRbGc01RzKkLmzjBfmPkxUoZxLm/vXMoxnr44T7PiR+/ecB+hJ12xhB7TrrN0zqWezCKET0bR1AtQp8ehOTXYE893fMxxro5dwhugVVZrur7ok9izmSCdRCesSg1pP3Y+LbpE0lOz+EcLz34WfB+puBTkb4J9evMTE/VojuEashWt+c1aBNoRiRsxtEPEKi5SMP0UF2uRX+g6vRD9r/EZbqvj/f6RgWLKcprUm2QktGfG6jGkwbT42nRMBqG5YVvwFQZTbXK5XFKecZn3i1UvkU5rybZvn8dszl0QfXuFR4FksfFkzv6sbH3UhDYvP1eyzctvGtY3c4WpTb5fPV6PNQZtA160P8n8aaggAQvZmSZYkcX3xKrDlnJMAZJh8Tl18IegEfn4/TLDllEj8hkucZktoGRVII5bkCXk1OdJU5z/NPPjZSudOR/4zqwajdkVGhMlj565HWUmNGzHRD2r35io9+A2sLr0Pd/bqVgooAW6slY/On/B1A2lD5Jg+2LwFG4miKQIQufGwAPfB6lYxvqSwRrR4env9X/9snhzGTdDzDV+I1rhzDu/t1qQ3iOCInjd0a099ex0E4Z06OLbbOQaDl8jiD/Pbu321i02uHJ7oOOj4ydGrclRJFIdSOT5rscjWZvN0edbhbM5Gp+kKE73dLGT/rv2WQzvrznPxhoCoPnG2f8/WPrfPTifLDg9fyhlAd8l5G65r0IsNPq9i4g6n+Gh8TAeotqVB1KCoTiKXcYD/aY69D0K13zigeweyK/427/7Mg0FulKg3TGbKige2k6H1gMy7V98kTn+S/OdkRoAnIiF421Bc3R03VXCwqEieiN37jSbqu5B0eyoJv63RbhqaTvpWzKHAugM3D4DMH9UHeC6CwoEgv/i3lvT3X7hya6ywQlqSJIdKCAb4IXrTRUajOP18kw2an3IQ16XNae4eux3ol5mrefa9VZiTiZGLYAWuMsdO22D0goUj66Wc+ti8OR3zitiXd0carcys3NQl1iq5OJkYr9ektjfzyMWLaszBRZROlNaEaUzRRVRuoScan0RIdX6IhKq9UXEU+tzZdOzLmeKqEftTEn1qJ0psB61E+TWUBoO+74YwhdD+GIIXwzh/w9D2J6KXkzhpYi9mMJ5lF5M4TCZF1N4c1NYgqGZdQxfLOGliL1YwnmUXizhMJkXS3hzS5jqf0RerOCLFXyxgi9W8G6t4KsUufT7A585qZGJz5rM2DY5C08nGEVXK5Y/NMmND9gehm1DKoTLDk4mrEXbTm2eP194x+s75szi6so9Tfu5Vs3TCN0JmJnlLyu94NSAyA+L2U9W+p+bljoN6eYqtckAxMfOdxuZek4GM137r+H6JILQTsgolh+7jhTQIvA7/FjcGfODc5PLcskEFJ77ff9aeUx1LL6rtQM90JxOpkuUzeQ7IbWxQp/C6eulJ94kf84S9gBvJeKRjLGTZexb1D1nKTcQbyXnMOAp8h20iwMPSp+eFNXtBNtYwkg9rOneV7NzvFv/QIO63WP0drDusyZ+52kTvZ3xTtIMBr2TAz6sS/jcyI1YC0/PDzLUBZ+kO8kQNum/Rk1UiiM32M0my/XEvhFvbrCb8eZfG7oRc4dvG12XO29Bb8RdY6+Z1nVbxtaW8lyAw0G7HrqwHRTvXdyyd5t9f1FWvhXSLIOfpJuymc/L4EdcTtr+S6yym9r+iLk528AFp/Cm20DE5pwd4YJs3nZHiPictTlckNHbbg4Ro7P2iSThIZ9t5j7hSkAvtT9g4wPXUjMu0PcvWWG1cqjo8JhP61+wTKXUnz9Jf2JmA4r8+78Rqci//v6BFFCBe/xHCl8QYahagyFU5RtmIDe1AixCaIoOkpSjR7w847ksK8YnnuFs4yWaFSDMolxeSTfbEOGv79670lZYU1fP/c377799iArfUhXdScKTfG2ZMjXlV2Gr5SrJjlyF0Vv1bNka3gkneSppVUFxi5lyI3n4SSZTM5Uu9Pre16OEtkK1Bu0pv/bjrBgH/eDCks3b2pw9Ace24csB+2T/MlS/3G0gIFdkL2sVBQzS1znh75OTkO2Y2WS+Q/vzmBHXmKKxBSnr6z44dSupQoN5JtbpejYMvWVLmj9pVzSc9vaXUnKgR3YR+6hqILuNa+2tAKvE+k/x09Ckq9l+fGGsBa/AqP0gdP++XAZizQSk+ok57Ce94fGOaOONrivMc2VsvjApetyjbc2DaIhHM75v7piCIjNsPdB56YTN84OhhmnDch1toX+343y0wwzic5/Jlj9ShNJyo6jQNNnyaJyBGUwMMhIPGu0lDHe/tTrjIXJcAAtZD71dOXG/Orsu9a9xCXVQGhf4jpib4qiPe6x50K3Qs/wJjG5bhMzBHZpt4E9vht3tFh3YU29J0+Jz6QbeO52kGoj6s2qGw36cYiDqz6kXMeiRQx7Nk4fG69g9HA1Lo7FdEGxZHm2ezTdONX4l/cTKuhxMLyBzxD2VZjCTf/t57/B4tvHdulH81s15NuA/RG2s4nZE3f1qcL6iuBBTZv8s2WoeiECIjjtfNj+DL6vCGtvV3Gxl45BuZeOr2EchxY3ixlDD+1DT67uPFm3DQADhenj9qJN4A9ZTMmhPNZ5uo6cb1ypo3ZxnW6/4VLuJlum5LFB3y49vpMZLdY4b9lxYaLXKWZmwCpo1m2xFQro7WbZiHLJnNTVhP7PIZmxnK17rzenAjxY2jjfZxaebFHQzcPOaJjUrMqfJ4+h1sNnR5kPbi/yG0PYiT0JLBiLaN64zVIah7tnnR/PDE9qdEKGRfppdv0mJvS9df1bvXBwKTnb7e66kipIfMVoTOuO8f//ul+3vzox8DK/Jc4N/oYNteHPV52AN975pIpubg9b6gRkEi0/f++XaCHWQQTz8HHa/vwSHqWuruBU++abU3zrmo7uN8Pasxm67ScK6CvEHJtb+JeOgv9/2lOn7pleSa4Z5EMV0H7rUktfG93x+sKfZwz7Q5NGrwyOeyR7pFjAEWerHoYaqvk8yWYKxMx2aQ7tE3zndoXGE682PH8B1dnJyDZIMMiQVKL9XwBbEyMus+JT19aA2kfKog2qrTQ+tJuEVZm93S5I1Uj5ZNnNZVhzMmONKtclWTDC7wAYZHMhbmNXO2iSf6Q7Iij4/E5a9DfxfyaC3A7xWwPEeOgruY8jCZZni+xLkv4bwkM6Lis66VxVQ5bLhD/LbEyb+gGLX5CMY7NzdYiYg7PZxcJs/HQgvy+tcH/faFsYB74FHg6Nr4cE8y/BdnG+PHtvsqS3lw9ruf3abm8gQRujedsVcH3KcJIsL5kSO3UWYkVjqjkcJ/Qx4x2N14MhNf5IokkHQfa04VR7W7ij3KMKVhBCNQNBsdIJmaZ0fT75hRmdMZG4qEy3HL4G8eVXKpc3joM4myRwDfQXZbexxj0Z9fDHHYwM8kTzkaDoyTHR/xUSBGT6ENm6zkAWQWljHhJIN0O2eDIepuPQteXPrEFpruqoVJqkUjK6F1Gwk0A1U8X12M3uHXm7g0ldrWU82aOwSVtK3stf5BoqaD8S6TtT38TdazwhN/XjwXjAeh9q9sZfT1FyETxi7LVVM1ppUG6pdYDba3XxQdtAAJCkOC2leVKwY8P3IBQ+6h34g5hT79KqgP/4o5RvlO91KVZS2n7+FEwIyaq1OcNw7z7kAkbh+HEn3Xffg2ESIVkGVcbnOlvVqBeqzyMk59xYJVd6791ehEyY2fD6UlHNQ4YW1xjr5o03Qs9jtmw4o2S23ybH5bFKhuakp5/vmTNmTCfkzG7xJJUTvtQEsXobCub4FbFkObkWtqHVbcyoIXa0gNycIKPZJPpuMItFE7gZdRXN/kKsTf/6MQdAriUpBSausUmxLDWRbBrvPKCkEU7nHiKr9ayleu4XmUwVdntcgWQteLy6+2vxO/hnFYh2QgGIO1K4v9zyAj7l6g4QHXMBX/xcAAP//bZsKPA=="
+	return "eJzsXV+PIzdyf9enIJyHsw/jXtwlyMPiYmDtveQ28J6d9R7uIQh6qO6SRE832UeypdF9+qD4p5tqsf9Io9bMypNdIOeVVPz9isUiWSwWvyUPsH9LSsHXIl8uCNFMF/CWfPUR/+X9918tCMlBZZJVmgn+lny3IISQj6AlyxTJRFFApiEnKylK4n5EFMgtSJUsCFEbIXWaCb5i67dkRQsFC0IkFEAVvCVrit8BrRlfq7fkf79Sqvjq/xaErBgUuXprWvuWcFpCiBL/6H2FAqSoK/cvEaD416MqLejEfRC2ELaCnJSmWjWfxNoaaC9s0ymICU5QJlMa1dZBQkhXI4TEMYY4G0X4PxbkA+x3Quadzwag4t/3VNMlVWAU3cKKtttSulz7P7RqmoAAv3LJtssl4xQ/JmJFcq8KynOSnYRLC02LRLMSklpFARaCr09D9xllkh1lOEIIyiYrIUkhsgdFGCcly6RQkAmeq2QAVSZqri+KidflEiSqDMEYiAS2wHWAIwoIvx5F0h1ffSMgFCaB5j0qH6Q4gSb+/YwK99pHxWN7U7R/hDHWAZdA+NemGxpoE/oihLeTTMM1dWgaPFWJ5kdX0GIL7gST/kcNkoHqUWIvuBFgxvhkzTkqzjUxrLAunpi6zkXTqshDgUfIag35iHLWoEshYQ7lUPXgjQqbIFktFQ5SsZuoKI9tHkUhY0Woh0XVA+QtWJxpRlTHuAKp59CclYzK47AjucjqEu18mtYcrHmU5rG4VqYNwLrKqYY5FGUko55O1JFDNLOObCvTdCShFNtZdJRDAefoyCGaWUcG3UQdZaIsKfbpDFqy7tKoya8xfXPT1NWAm0lhR6gmOvjubulpKzojLVHsn901/iDLCUzx7+cN2AUxqXkmykqCUpATbM30AZRC7lEZtCiIhExI2zk02AokI9BjvXMp7O2iWyx/hUwrIuThqNMbpk4AS7frn5a//jKftukWJF2DVTEqljvoFiwEWMnXjJPlXoP6Zgy20gKlzojbWgktsTNR365FQotCZBRDHlp0lU1WQW80v5jOyrT5gefwODuxpjuKgjBsEdQJ6jdAZ8So6hLBoXk4LWJjZkt+qKMTMJf0cQa0v2xwrYlAS/rIyroMhmg7LvWGalLSPVkCQZ+D5mG9Cq0qCOMMYyy466wZqBz6F28UojtKE/KuKIL/VmRDt0CoJhjB0kRwXF3m8Oh/mrLchvOSRZdTvnxypM2JIJUUW5aDMg5mC3LLYIc8KKmo1CyrC4qbBL4WzUSXkM84fn03HYhlipRCaZIJnoHkkJMd0xvzU7IVRY0LeyO9EfakYB7drlOx/DXFYZkYZ7GY2LkrIUuq35LDH0UbCfosKp1xDWuQzWdRIch3XpgTA5vR365YAfOiM6Z9hSamSI7+mtdlCo8a1wNnSnBLizN/7Rz2vCriyshPy2VSLsflR2UYSzYGg4cVffHsc5awJf1VyEXnwwnIDmQwfpYM/3trAulKAqQFU/pi5HhdngErlNBrGT2CxizEy3XHKosxlnH3PDDH/NIe3ZiZXMIKJz87MdZS4nReuyMDnI3sQrcJ3d2baSe/x4iGpjw7XDU18SDGLT0m+B3R9AEIJYUQDzi1brSu1Ns3b3KRqcSdgyWZKN+UlNe0eCNhBRJ4Bm/c5u2NPYND5LV68y/uRM78V3Ksp1ife6363eAi1k8xAxpQJP79BJWQullYoN5im8/jhRCuTyxQ3Do4Eon/PqESTIcgahXfz+L6EWi2IVta1EAYxuaisz/+dT1twepm4dyukI62yYQqsoOiwP+vDxZTK8oKyN0Xcc3Usjtqt0ss+ZP7X98lToxq1pxtCxivUKT5qm9RuA2hNZnklMbcPmFCWw3/4dbGHAtTqYJidfT5kK0NyQ1lW3VEvzLicloZRiGni/A/p+u1hDXVcKsElzUr8hS92K0yxAV0Got23RBDzu0WIa2EuHmyQ4G6L5xcvrztzssxl4ZnN9t/K8bzW+W2Bp1mZZ4WjEMqqts1UiRaUKVTkFLIm2Yp1rdMDzdot8yvopKWoEEefetGSG6E0je9NrXZEjfLTqUlVTdsn2aW6A/Q3xrPaEjpdkgypVMffspvmmX8rOJmOFaMr2+WmxR4+nGr9CRUhQKd4vJGLln+W+CJO/6jpLWbY7oBKvUSqL51oja7M62EYpH7NjdD1x5O3bjx2r68VXa7DdWq3NeS3QrDRUyGTeRfxH58xknoD4JryrgyJ5UED4dkjufF7roAjgd7plwfphcNaa1hy0rIU1HrxSS2kzH7g9ijw1CD2Z2Pm4QwPJHMiag1UYxn9mzWjnTM1cpAKUzMkwfpxV0WooLTvd4EBn2ax/Y8leRMW+UiRepx1Y+qfyIB3w1tB4TQba4aKlwYKeT99/9Tg9wnP5n/TLjAhHTTNaCJFpiTiDcIiAOeDBKsGOeQX5XcvW3zfrSDzh7uFwDplW+GgL8AjHmElBlr8zeVsoJNuInQk5dwtrP5ZHNW2nRHQjMzCNHBlCJnK5aZ3BNSUa1BcnWqy7H3LPLFifo+0+F4Gsq3m/QCs6GxZ0DmG+6HJkHXkj8DNN8wWe6b24K9KO3a5fogXbut+hYxdIOHLGePF0xUQhXheAZizm9wvtK18pnplYQMzIRt74yKCqS7Rs4PUsPaQ/VTh5QRnOI91KRUs6q/veiAc4BJsGJFwdx9JDutIyeb2kM2VBFVIbkKJKatoRrWoH+kSv/Z6KrRRgw3sTOUoedTrMnXLIGE7L4hawlUg8RGOflDMkU7c9656dpnD01LiaJaMrZikF+GnpuS5+r9lhY25CavQ+ABxc7CjmICnQRVF9oPip3DS/RGgtqIAm9sHKisJbyIsW5aW8TInjGQ/yKKHC/p1FyDVJhkTBTgpaTCyDTD2V2jxKkQneE+5HwwnW8ozwtQpFZo8KaradF+20o8dZSrjPKU8jwVMgc5U0d3rdhf4nZpjujqiBI4W7kvuY8yyrlohrnxa/i1gLPVBeX21kfSS9NYlSkFU7Dj9PKr8ATu7CAcnRZOi3sRA49i9qnNdBTy0rmwZgNoSuigfk1bbbIoUXuloTzHqjhgQp6J0M96LbGrdNMmYRpK5XGQvJZhHYNmrH1bFZQTwJRcOnIDyjFqFghX5dQuS85ntIjRwkC1W3svYjSeYFcuvRcvJLVXFiFs0e/Fj7XufpuEX8bbalSa3G28IOsWAz6tXd2ZS9ZEb4TC/T7VmJTNf6dJCWgZxtU6cVj06WRH2TP8hlU1scu9ygQnWyqZqFVYsQlnja7mPJpj1fUzCdn4KSn6pTFGY40cqA339mkmoWcRP2nUxETi0usy8jK0n0JdRhjCupAolW0gr/tjj9M6akpnha1y0Fk51OREFqHMfLkT8mEx8M3TZcJjVtSKbWHR+9VzxCLQNHq680Shl5WIhwq1jJ59nyDVS/tHDTXM7wsYTysp1lj3YDGG+cImffkeeOE27UWqAqDqd/QnSsMyX/vLiMLLgedL8lJqrtia0wLy1EwLavEkce3UAvKJotSmNpUl01zs+KCopRAFUD4ozZlwitek5YpmEP1233XlrjRaVcV+cerQO20hgysWWrUrFrFqt22q3W+EixpRFWJ97ooGA8dlpVWqRbqETJSQ2gASlfun9eSS6mzzBPc4QW8R3RllHGjQn1zhrswxw83MxPXtFF2GrPu2NxOVdxL1vm2P07zVAuSEYpUihWVQmguWKhllglGheAhzfi6joc1IVBPZ7t2J4NF46R0jLV9RqcWZPCdyjPWVARZCdp2WLPpwLuvV6oyk2gkYfcTMtqCOsUXdj9rzjChRywzcL8kSVlgtL+gRJAlc+3KI1NpovEMiu9jECW4rhpAd3Zu9saTZQzDy7ReTxWkjeMrovaQV+GOG0A4aLTM+iU+LuqSP/aUb5oDv6+b4ikSofgfU1mcJLmtnwoQatC8Z7WtI2fF7Z0IwTBEMWGKcka1rSZcFDDO+KlvfWSHbTHCs1dAcLA13lofNONOMFikOmTkGsJ0LfTMEm3EHYGePh7LqOyGeoGYvBrddkKd+rfF0YQN7uAEpnVXhfD2gfBOmVMJyPz2CN61brmX53cnYpd7QfHQmSAbxuxKuwxT6euH8taFrNvC1d2S3YdkGY55Ewj9qUNoeN9E8N/mbtHCHZd21hMto6W2aKlPE6tA/TDSBMTOYOmNNMocT1Nm3hjnW6yjsF7S4xH5n0kba3W2e4HDnmNvLXEV6dEPDc8LIlJCDVCOB53mZ4EAxp0x+iIAiDk+y6MNdSSgEzRenOpMTXLpzIveVhG9XoLPNPc6ta0AfAhKa82bE0aZs2VMzd6BHGNeCfHr3EW2NlbiaPewivZGiXm+qWp87NWC1ocFuu7xbbakidUC2Wvi6rO7AzSXfWMVZvd2C5zui3k83CDTEj0y7/C7mIjP1VBdp04aQ4mHF6M4kdw5jz9aNkCubbnjofZLxTuzNL8mWrS78Jt1pY7lvAmVuUx8prn4BM5jF8FX2RMO/60ae7jpD4VBnZ+phEVOCK8mYNMUAEwVUZptFTBOxgdFner6BZZ09gE7hcUNrFZ/rB9X8lGS1IHaXbSDDJxZwjCFXc0XV5OVhRhpWVMERSImqmabLYk8KKtfg6nz7ys4H6uwS9ev6KxEM45Kmx9z7EXRLWYGRjWPsqh+8y1uZHfsQuhilFvEiBlvrYrKhjmDteOxmpetHmQQXe3RVCfHftHYVsvt2WRPz4p89bcnhsHvPoNSAT1xtiCa9VCqKK+hZ8R+PcSBLmj1gb/Pc94F7OiJcGp9Ay9NBD3oU0rpQzdBWdm9paufAMZjpImvJYtisPHCzsVnElH/GoDDCxkC2h4MOqo92MIXZo5LB1ptWvkzWoD+1v/vAV+Lrb04dNhghTZzngPwylWIn66RvIlcVtd4hDyNxrp99KfpkmFOtnoVOnEqj4DA3sJdPL7EVk8q+U6Q0LatT6U3pDS/b+2XTpMuqByoLBkp/0wYv/NFAy6cR28uioNcmUdCGQ0H10xnsGM/Fbg7kGyA5W7kqw2QJegfAg47AyIRhM4C/x5A8eKzWcNm3HT+7esBe3YHHHcWCN1XTyJ1ta8iRD6ZewAuvBuGIwYt4wt4/1SK8JmsyaN2E51a1SOAX0P8F2lZ29kWIR9jgNdAS1OQZY8w3N1V8mJ5ntf8hrIXdBCDxTYdKMLenwrNBu5+8C67wonbNyw14O4uH5uiuJiuyRMvF2wgaLVgQagu2M73vzspuu5osOvAaRbgD9i9SAw57846NJTs4VLr0c3u++kXSDwwA+f4qaslp0RF8uuMo6HryMBvRw3sozJMx1tVSd2Ol5eN2MZVkJZV7GxvWeNWp2mOnUtKXfDU2vM94KscvUPI6entrYq+/P55hrOvCfgh5qkLsQOlehgEXxl84lxUWtotyWcQIbYDmUojyYlZ2jDOwqt/5zB27sjBGFgwI7GpS0LUfJ7dgcecx7+/FI66Mf+Fc+0ffIkrYuNLpq48RKj/7nWrwYAahS1E383V3GjeNNFs25kqrnGiiTlUJVhLt7b++rN9J/fMXnLVonmNyvle1a3UUlXUt8+D6qeu2etE0BmG0pOaB8yMGdcWqbYzEGzsGFV8GX1RLKkTGYAqqp0TT5BCiH5qt/gQ8GKrdAh44XqPv2taaMTvciQG8uaKPjbo8Ird/vW/bvo96jhZlzR+42PFraNCB/J01/garQzAV6NV0aWLMU9GZulB19cdr6NHlN7J/TrfFBt7VLdG3PKZAKpd4feYa+nNNjenMI5pdZR5PL5L8SgP0Hhu6n2hS+VUG4yGkXjBSFAUeuVxDS10L922PWXiDcXalnYuw5hughd7sn2E2MN7WNU/+g6xooaYAnV2XTVMe8KILphPo7tsmDDTqU+dcENWdZfh7IKNHerFtgAc39E4kLRgNceOfiuoNrj/llmWQxH89or4P5sJDBr7tJArMBYlPBOZ+lURi7iOowpsMXt9eGvlZKMXw9N9c3FAmH9qc+pvHymNvw3kadRVP1+m1v6nKq6vY1a84CDwPK9LIburcwP+PKNBF+vGwFFMQ2vNDrz5fRCMOSoJC+z0Otg9dXx3B9VnWgOmmPnvN6Yq5ok6uPZJDVYg9nrHfkYI9AHmnC8xH2VCT58zQN5qaSjjcCty6elHqzoZccwEKq4DgWePOLWSC1ynv/SEGmocomVZECbvfNP/bnecn5CdeWJ1VIEsM3uZE1Uvc4Pu4hDu3bLUc1yZFBokz2PSoNPiQXx7RqdWNk0w+vPfIGu3uTG0U+7USqKoluLw3CZNQhz9Kft8BYJHb93s7H9l/TJ0Ri/o4bB98Iy1phaXv3de/+v1Xp6nhR3OW6W5uORUcsHV5GhBkl1udvMtLxpnSLsT97ucPdzhSMOAkM4rn7oEco5hWWXGtKazBiBcA1/gEeFRhp3uWvzZZKk6ua8cknlRS5HXWDnGcAED2dKqDt6OSH7818HR4Tu5T4ZXq8tBK9WRYtQJ5cVwo9KnAcJEotseL7KejaySfAjGKtS1LebHQ7PvDcsrhSbfzzWbHnYkSPw4AYHT9SJrLI2SFO5lFcf7OebQXxoO4Ds3R5wM9M4F2k2rQ9FJITYsYcpev5moBm5uuUbkuH47xrKhzUIc63UBREAXKrAzJD4IrlpvCmW7xRUTsOV1C7psUzXtzUphjCWDpbqI+6uZIOKe6LpNebTZSZtBnR6E1x7SptsW4FYV6jkvNKPepbv28TKrUwL2lp3J7Z8tR4nDGOgpRKq51bz3O3KfbyaFafFF2W4o9K4QKVxmLmBLgUUsaezLtbO/wc0E1ntj4EqmZGROnDuEN0CqtFeaz92XDTTpLu0hmoLnG7fPksD8RXSRLsBn8bmmYxI8QcIHbmrjg5G+cPb75kfE6yF7tKqSia0hXtC70ybo4iWs7GLFFYlv09UPNtUeSM/XQJlzgXpCu4wPR/dq8W4823v7oUGLMc2qxiN6StfXM8dtWBrO7jsBlEJpptgV3JadV6SKm13UhlrRIC5E9XGoEBPnnKNZsWJoFsqc5dUC0SK2/wh1mUqvI90asYJIdOEtoL7JgHZjmIgvjWaevTC6ge+ABZ3d/7dJ8y6r2R5E9JItoW8bP4QP95gpjsSd4r3ZLC5z10CMiYW8OLmowoB83X6amxlq0wf4ePVFB7SAJLNq0m5MlZNRdLKCk6CXf399Hfd7zndH+nkypzwMck8OCXf6FA+xi5GczWzEKAETVpXskoe2KBO8Xm6UszzufmFwnqY6yxrtqcBKupoioCnypU8+h2HutUH6sGYQ80P0tN6eDL4mbgdxLzhOzXjh1D2Fca0C6FRHkftUdpLlQR+cww075stfNRK6gWbJ/GWO3M26xj9wcaHkcrBMdW+xQY6RCHmkkGaH2fOPxRGqTGT3fKJzMaLiTPBEclWoxdZiNYH7X3Lzy6zW7skFHADTbGB9A/oTyv7szq4Jm0fOnUuTwXUTvyL7yhwfB721w1K4Z7poVxl1w2euOlKApfmKGcU+BtgP5DoURLe/I7o58Mr/9u7vXJEFVEhQStKHuu7Y0KF7l1e0nriqD+ZfmO23zRziQmkqsbhKamYWuPfxNLCqiNmKHrjd+HQ5/T3b4+ELmqo24s8n26QkUkww33L6bMb1V5eHaEz38JwvCPlCoDgvsR8sw+0VQI5LssODFBgpDgzYV+dELTaVRq2MOWV3WBTWjB6kGxf/alWszbx0xGWk1B5rjP5zcbb3a8hKPxkXflOKHtR0Xiz7H1DeFDs1UXrTrbEeyG2md6ADjwnaXFPbpksL+/jRhwbCSF5O0u5ikTxeTdAk91eoiSqrVRTRUq4uop1ZP1U3Hu8iLSttdVNqni0o7Q2+NJLcseHWEr47w1RG+OsLfrCNsd0WvrvDVFb66wldX+Jt1hRgUwhIOYX7vqyd89YSvnvDVE/62PGGsYNirF3z1gq9e8NUL3q4XXMTExR/sOPsg9DJJjYw/azJjWxXQvzWiJV2tWHbXJDdija0M2NanQjDVhFyTXlqi1i+flznjdddQJrGauQhwN9fKN9fpgCYnuBG0iIEVlUqwtCDPjqs/nG30P/nsCdKIbo5Svf4IHlCT3UbE3l8yV7lcKeDmuphLIvD1t/AC2qnjCNNPPN/9HP1j+iYT5ZLhC+Cuoe6xcjKCb657tt1sn06mi08ZgdyXDmtjhS6F0xUY6CdgUk1esoYdwGupuJt6cwkdu+uQL1nLDcRr6dk3eI5+FzEG6Bdd9sXF3OK7w9LJjScMzANd976CU/0a43ivbA49RyYc21ho1M3M0yZ6W+cdldlcdg1zwJNebuZ9nitRw7YYDBBqb+oi+KjcUULmVQu4EiPb2NU6yxaRvxI329jVuLnnua5E7vgxsHnZOQ96JXaNv2ZK1e01tsYlXoLhIkYT/bovWwj53J7dt4QlCL8wL98qybU7bIlRuTGf+bIcfsBy1PefY4Ndblf1/QG5KdPABbvwqtNAQHPKjHBBmtedEQKekyaHCxK97uQQEJ00T0QFt+N2jOwixtheAV3EyJ4xP5jCB7YGbXhB3z39Zm4r+xsdDnM3eDNthljGUuqf3kl/ZnoDkvz7v2EJnn/94x0W/QH7Wpbg7kKExreyNMEnm5iGTNcSzCWE5tJBVHLw6p0jnomyYsXIu7WerwSsaMB1Ui5noH0YIvz07qMJC5awpibuSL7++P03d8HFt9iN7qjgUV5bJnVNi1lotayidMTKt96aZ0urfyYc5YQ1gyC/Rk/Zlhz8KMlYTx0jxz/fu/sovg5XrfDdNSP5W9fOihUYJ9YHj9FjKSwsRyXIMm4D5pO++8uNU/TvwO9FLRukpOctaP/5aCekWAAwdU8avIwesQVMGl8Q8772j+k6DCY7+FiReBGjakJvKZZiVPbS8Fy10SQQejgXm9MC6qvaNdOPuxiL4PFNrH0vdPcgYwp8zTjECvA9pQYZwVpxRtH2Yp69xuYuJgWv4fhZyqEhDk0jMAp9h89bp5qtQV5q8sSnbJjSLFOujDsC/Tu28xmb6cU3bcrMBHdEUy0pVzRa8miYwAQSvUTCRoO5hJk6NGsZdan9rA66AgdAIup4mGpkiE+kdFh8qtWlC3wH5MYYdXE3dU6eET3DZ0tVWyJkCm5fbMP89GrYTauHsIfB4tnPc9kGtn2eaeAvn9cyEMGphoG/eVa7CEEniz6YGc02cDW/Z1ozV6NNuSDYMucDm4qP5hvnOr+SPrKyLnvTCyapeyzN4IQu+WjxWFJmWZcM4sdlzosB/0tQxsqvDnCxeThf9fZXyypnUu9fJK3mRRUD0Sy1/bX5CbzQhDGuTLtrscm0Th7ZpklbrIJxLU5DaiaKK0P1D6qNj+8uWuMbIL8yXtfqKF6P9ZwM2nOdJ+oSCMW3vgi+QeX3s+2q+Fy/aTzTSxmg9pQfCR4U0ksGGRjdvBgf01qVEda8K9mM2WgpkpBPSR9TDDGkL6pr/HyGyCZMZ6uiVpvzgZ+sbNNesCUfRoedckVww/V4uuDwWf7rYcPWpkPb8+yK0PY8i0JbxLC1j8Knxhj6qmfHXPNp0XwX+WxK9ZsQoRbOSZuVhBam9mVi6xaaf4rUaRWH9T0xTuRjR67Qmq+M8/Hju5+3f3hi5KN/TA723ZR+cxVs/SPFLgerv/ZNE9ncUBWXaMHmpiCuUawpnGyU2kvQbLmScg6GsWOr8O0I8nWpvrHkg7MNZymgTLXdqGBV+fgDxkJNgUTi7febjjH52LR2xTApjxc+XSpR1NrVfMa6WJE60OTemcO92ZPd0y2gaaWluu8rqOrqJJMlaA2yKQ6NZjutOrRpYb7+cQ3Yyk5Wr16TXoeYLmjVS2ALfOApYzw6nBFqEykPKqi21uQeymhehO/MblGxWogHpInnaXgoPkwtXTHO1Ca6yO592GQiwXdER9+198jyLp8W6iKGtw38L2JAn+7Q2wa+lYBJtgdZ0jg8XJYpeuaE/HcfHnLwBKn17lUFVNo6eM4pDLr4I4mHLt+MVfQlAWYCHENS+anTgX2vfS4LD7x+G/hrN4VahJoPj4V78yz9d01/mzdimDZl9uSWFkkvTfez65xE+jDC4WlXyPqYcVQsauFcxtgapFqYq+5mK6FeAHezrfaMbPdHhRoxBnTXKs7VRyZKLN0YeWzrUkoIWiDGpTXBJSQTt/lBvDgwU8ZT/CnESo5f9Bk2ROoatROPyEygL8dUjwKap+abQpNYnfEYP/75wYlh7QP15leM5ybDh9Bm2cxFDqTm+EAFJRug2z3pD1MVwpXkzXBBiN50VUuTpJIzuuZCMdWvUKCy2KeO4QyK7Pg7nPwalu62FtZ+djomS1gJV8peZRvI66In1nWmvQ8/ahybLify/BDMbsF2qJ0bvTF1ZroxZ7elkolakWpDcSslVn6M4I8YH3YAUYn9SuqfF0MV5j1rv1FrmajJY++JnWZyil16lbcft5VyhfLxWyp6o7T9v7/5HYIhinOOX7gfPOcCRJjxY0Xa75oNg0oGVVNJqNJCrNNljc/HP4ue7OIekVDpVvfuKHTExfo/v5S0KED6Jwkb7+S2Nt7OwmXfoFKaKde3/HxaoRlmkhX7Zk/ZmXbIf7Lek1RC1F5pMJeXIccNZk5yjIvjsYkGuaK4bMWIEV2tINNnKChckzybjgLVBMsNutLQn2YU/h+qcDZVSShplVaSbamGFC+PXU1Tx5oyYPDpP5KJav+t4N+i7nzClsvz6mmbEASvkouPNjeTP6NacAHiUUyBeriWexnAh5Z6vYJ7loCL/x8Ao4wEmg=="
 }
@@ -23,8 +23,6 @@ import (
 	"context"
 	"fmt"
 
-	"go.mongodb.org/mongo-driver/bson"
-
 	"github.com/elastic/beats/v7/libbeat/common/schema"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/metricbeat/module/mongodb"
@@ -72,15 +70,21 @@ func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
 	}()
 
 	db := client.Database("admin")
-
-	result := map[string]interface{}{}
-	res := db.RunCommand(context.Background(), bson.D{bson.E{Key: "serverStatus", Value: 1}})
-	if err = res.Err(); err != nil {
-		return fmt.Errorf("failed to retrieve data from 'serverStatus' command: %w", err)
+	result, restricted, err := mongodb.ServerStatus(context.Background(), db)
+	if err != nil {
+		return err
 	}
 
-	if err = res.Decode(&result); err != nil {
-		reporter.Error(fmt.Errorf("could not decode mongodb 'serverStatus' response: %w", err))
+	// Restricted deployments only report a subset of the metrics, collect the
+	// ones available instead of failing.
+	if restricted {
+		data, _ := schemaMetrics.Apply(result)
+		if len(data) == 0 {
+			m.Logger().Debug("no metrics available in restricted deployment")
+			return nil
+		}
+		reporter.Event(mb.Event{MetricSetFields: data})
+		return nil
 	}
 
 	data, err := schemaMetrics.Apply(result, schema.FailOnRequired)
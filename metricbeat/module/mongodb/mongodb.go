@@ -59,11 +59,17 @@ type ModuleConfig struct {
 		AuthSource              string            `config:"auth_source"`
 		PasswordSet             bool              `config:"password_set"`
 	} `config:"credentials"`
+
+	Atlas AtlasConfig `config:"atlas"`
 }
 
 type Metricset struct {
 	mb.BaseMetricSet
 	Config ModuleConfig
+
+	// Atlas is the client of the Atlas Administration API, nil if the
+	// collection of Atlas measurements is not enabled.
+	Atlas *AtlasClient
 }
 
 type module struct {
@@ -78,12 +84,16 @@ func NewModule(base mb.BaseModule) (mb.Module, error) {
 
 func NewMetricset(base mb.BaseMetricSet) (*Metricset, error) {
 	// Validate that at least one host has been specified.
-	config := ModuleConfig{}
+	config := ModuleConfig{Atlas: defaultAtlasConfig()}
 	if err := base.Module().UnpackConfig(&config); err != nil {
 		return nil, fmt.Errorf("could not read config: %w", err)
 	}
 
-	return &Metricset{Config: config, BaseMetricSet: base}, nil
+	ms := &Metricset{Config: config, BaseMetricSet: base}
+	if config.Atlas.Enabled() {
+		ms.Atlas = NewAtlasClient(config.Atlas, base.Module().Config().Timeout)
+	}
+	return ms, nil
 }
 
 // ParseURL parses valid MongoDB URL strings into an mb.HostData instance
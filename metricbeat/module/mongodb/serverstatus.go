@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !requirefips
+
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Error codes returned by restricted deployments, like Atlas shared tiers and
+// serverless instances, for commands they don't allow.
+const (
+	errCodeUnauthorized        = 13
+	errCodeCommandNotFound     = 59
+	errCodeCommandNotSupported = 115
+	errCodeAtlasError          = 8000
+)
+
+// coreStatusSections are the serverStatus sections reported by any
+// unrestricted mongod or mongos instance.
+var coreStatusSections = []string{"asserts", "connections", "metrics", "network", "opcounters"}
+
+// IsRestrictedError returns true if err is the error returned by a deployment
+// that doesn't allow to run a command.
+func IsRestrictedError(err error) bool {
+	var cmdErr mongo.CommandError
+	if !errors.As(err, &cmdErr) {
+		return false
+	}
+	switch cmdErr.Code {
+	case errCodeUnauthorized, errCodeCommandNotFound, errCodeCommandNotSupported, errCodeAtlasError:
+		return true
+	}
+	return false
+}
+
+// ServerStatus runs the serverStatus command. Restricted deployments, like
+// Atlas shared tiers and serverless instances, don't allow the command, or
+// omit some of its sections. For them the permitted subset of the status is
+// returned, falling back to the buildInfo command when serverStatus is not
+// allowed at all, and restricted is true.
+func ServerStatus(ctx context.Context, db *mongo.Database) (status map[string]interface{}, restricted bool, err error) {
+	status = map[string]interface{}{}
+	err = db.RunCommand(ctx, bson.D{{Key: "serverStatus", Value: 1}}).Decode(&status)
+	if err == nil {
+		return status, missingCoreSections(status), nil
+	}
+	if !IsRestrictedError(err) {
+		return nil, false, fmt.Errorf("failed to retrieve 'serverStatus': %w", err)
+	}
+
+	buildInfo := map[string]interface{}{}
+	if err := db.RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&buildInfo); err != nil {
+		return nil, true, fmt.Errorf("'serverStatus' is not allowed and failed to retrieve 'buildInfo': %w", err)
+	}
+	status = map[string]interface{}{}
+	if version, found := buildInfo["version"]; found {
+		status["version"] = version
+	}
+	return status, true, nil
+}
+
+// missingCoreSections returns true if any of the core sections is missing
+// from the serverStatus response.
+func missingCoreSections(status map[string]interface{}) bool {
+	for _, section := range coreStatusSections {
+		if _, found := status[section]; !found {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !requirefips
+
+package mongodb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestIsRestrictedError(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Err        error
+		Restricted bool
+	}{
+		{
+			Name:       "atlas command not allowed",
+			Err:        mongo.CommandError{Code: 8000, Name: "AtlasError", Message: "CMD_NOT_ALLOWED: serverStatus"},
+			Restricted: true,
+		},
+		{
+			Name:       "unauthorized",
+			Err:        mongo.CommandError{Code: 13, Name: "Unauthorized"},
+			Restricted: true,
+		},
+		{
+			Name:       "wrapped command not found",
+			Err:        fmt.Errorf("running command: %w", mongo.CommandError{Code: 59, Name: "CommandNotFound"}),
+			Restricted: true,
+		},
+		{
+			Name: "other command error",
+			Err:  mongo.CommandError{Code: 11600, Name: "InterruptedAtShutdown"},
+		},
+		{
+			Name: "not a command error",
+			Err:  errors.New("connection refused"),
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.Restricted, IsRestrictedError(test.Err), test.Name)
+	}
+}
+
+func TestMissingCoreSections(t *testing.T) {
+	status := map[string]interface{}{
+		"version":     "7.0.12",
+		"asserts":     map[string]interface{}{},
+		"connections": map[string]interface{}{},
+		"metrics":     map[string]interface{}{},
+		"network":     map[string]interface{}{},
+		"opcounters":  map[string]interface{}{},
+	}
+	assert.False(t, missingCoreSections(status))
+
+	delete(status, "metrics")
+	assert.True(t, missingCoreSections(status))
+}
@@ -23,8 +23,8 @@ import (
 	"context"
 	"fmt"
 
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
@@ -74,15 +74,11 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		}
 	}()
 
+	ctx := context.Background()
 	db := client.Database("admin")
-	res := db.RunCommand(context.Background(), bson.M{"serverStatus": 1})
-	if err = res.Err(); err != nil {
-		return fmt.Errorf("failed to retrieve 'serverStatus': %w", err)
-	}
-
-	result := map[string]interface{}{}
-	if err = res.Decode(&result); err != nil {
-		return fmt.Errorf("could not decode 'serverStatus' response: %w", err)
+	result, restricted, err := mongodb.ServerStatus(ctx, db)
+	if err != nil {
+		return err
 	}
 
 	t, ok := result["localTime"]
@@ -107,7 +103,38 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 		_, _ = event.RootFields.Put("process.name", v)
 		_ = event.MetricSetFields.Delete("process")
 	}
+	if restricted {
+		event.MetricSetFields["restricted"] = true
+	}
+
+	var atlasErr error
+	if m.Atlas != nil {
+		var atlas mapstr.M
+		atlas, atlasErr = m.fetchAtlas(ctx, db)
+		if atlasErr == nil {
+			event.MetricSetFields["atlas"] = atlas
+		}
+	}
 	r.Event(event)
 
+	if atlasErr != nil {
+		return fmt.Errorf("failed to fetch Atlas measurements: %w", atlasErr)
+	}
 	return nil
 }
+
+// fetchAtlas returns the Atlas measurements of the monitored process.
+func (m *MetricSet) fetchAtlas(ctx context.Context, db *mongo.Database) (mapstr.M, error) {
+	processID, err := m.Atlas.ProcessID(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	measurements, err := m.Atlas.Measurements(ctx, processID)
+	if err != nil {
+		return nil, err
+	}
+	return mapstr.M{
+		"process_id":   processID,
+		"measurements": measurements,
+	}, nil
+}
@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package account
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/helper"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	defaultScheme = "http"
+	// Ref: https://docs.nats.io/running-a-nats-service/nats_admin/monitoring#account-information
+	defaultPath = "/accountz"
+)
+
+var (
+	hostParser = parse.URLHostParserBuilder{
+		DefaultScheme: defaultScheme,
+		DefaultPath:   defaultPath,
+		PathConfigKey: "account.metrics_path",
+	}.Build()
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("nats", "account", New,
+		mb.WithHostParser(hostParser),
+	)
+}
+
+// Config holds the configuration of the account metricset.
+type Config struct {
+	Account struct {
+		// Names of the accounts to report, all accounts are reported if empty.
+		Names []string `config:"names"`
+	} `config:"account"`
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	mb.BaseMetricSet
+	http  *helper.HTTP
+	Log   *logp.Logger
+	names []string
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta(fmt.Sprintf("The nats %s metricset is beta.", base.Name()))
+
+	var config Config
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	http, err := helper.NewHTTP(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{
+		BaseMetricSet: base,
+		http:          http,
+		Log:           base.Logger().Named("nats"),
+		names:         config.Account.Names,
+	}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	// The list of accounts is fetched first, then the details of each account.
+	m.http.SetURI(m.HostData().SanitizedURI)
+	content, err := m.http.FetchContent()
+	if err != nil {
+		return fmt.Errorf("error in fetch: %w", err)
+	}
+	accounts, err := accountNames(content)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range filterByName(accounts, m.names) {
+		uri, err := accountURI(m.HostData().SanitizedURI, name)
+		if err != nil {
+			return err
+		}
+		m.http.SetURI(uri)
+		content, err := m.http.FetchContent()
+		if err != nil {
+			r.Error(fmt.Errorf("error fetching details of account %s: %w", name, err))
+			continue
+		}
+		if err := eventMapping(r, content); err != nil {
+			r.Error(fmt.Errorf("error in mapping account %s: %w", name, err))
+		}
+	}
+	return nil
+}
+
+// accountURI returns the URI of the details of an account.
+func accountURI(listURI, name string) (string, error) {
+	u, err := url.Parse(listURI)
+	if err != nil {
+		return "", fmt.Errorf("error parsing accountz URI: %w", err)
+	}
+	q := u.Query()
+	q.Set("acc", name)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// filterByName returns the names that are allowed, or all names if no
+// allowed names are configured.
+func filterByName(names []string, allowed []string) []string {
+	if len(allowed) == 0 {
+		return names
+	}
+	var filtered []string
+	for _, name := range names {
+		for _, a := range allowed {
+			if name == a {
+				filtered = append(filtered, name)
+				break
+			}
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package account
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestEventMapping(t *testing.T) {
+	content, err := os.ReadFile("./_meta/test/accountz_G.json")
+	require.NoError(t, err)
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventMapping(reporter, content)
+	require.NoError(t, err)
+
+	events := reporter.GetEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, mapstr.M{
+		"name":        "$G",
+		"expired":     false,
+		"complete":    true,
+		"last_update": "2025-03-16T13:31:20.841672129Z",
+		"jetstream":   mapstr.M{"enabled": true},
+		"connections": mapstr.M{
+			"client":   int64(3),
+			"leafnode": int64(1),
+		},
+		"subscriptions": int64(42),
+		"sublist": mapstr.M{
+			"cache": mapstr.M{
+				"entries":  int64(12),
+				"hit_rate": 0.93,
+			},
+			"inserts": int64(57),
+			"removes": int64(15),
+			"matches": int64(1820),
+			"fanout": mapstr.M{
+				"max": int64(4),
+				"avg": 1.5,
+			},
+		},
+	}, events[0].MetricSetFields)
+	assert.Equal(t, mapstr.M{
+		"server": mapstr.M{"id": "NCLTOCCWWFT55ANXSNIMXTP2Q4CEL42V2K2BNDXGM5YNMTBMULQO5V7L"},
+	}, events[0].ModuleFields)
+}
+
+func TestEventMappingWithoutDetails(t *testing.T) {
+	content, err := os.ReadFile("./_meta/test/accountz.json")
+	require.NoError(t, err)
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventMapping(reporter, content)
+	assert.Error(t, err)
+}
+
+func accountzServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/accountz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		file := "./_meta/test/accountz.json"
+		if acc := r.URL.Query().Get("acc"); acc != "" {
+			file = "./_meta/test/accountz_" + strings.TrimPrefix(acc, "$") + ".json"
+		}
+		response, err := os.ReadFile(file)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json;")
+		w.WriteHeader(200)
+		_, _ = w.Write(response)
+	}))
+}
+
+func TestFetchEventContent(t *testing.T) {
+	server := accountzServer()
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     "nats",
+		"metricsets": []string{"account"},
+		"hosts":      []string{server.URL},
+	}
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	sys := mbtest.StandardizeEvent(metricSet, events[0])
+	name, _ := sys.GetValue("nats.account.name")
+	system, _ := sys.GetValue("nats.account.system")
+	assert.Equal(t, "$SYS", name)
+	assert.Equal(t, true, system)
+
+	g := mbtest.StandardizeEvent(metricSet, events[1])
+	name, _ = g.GetValue("nats.account.name")
+	assert.Equal(t, "$G", name)
+	t.Logf("%s/%s event: %+v", metricSet.Module().Name(), metricSet.Name(), g.Fields.StringToPrint())
+}
+
+func TestFetchFilteredAccounts(t *testing.T) {
+	server := accountzServer()
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":        "nats",
+		"metricsets":    []string{"account"},
+		"hosts":         []string{server.URL},
+		"account.names": []string{"$G", "unknown"},
+	}
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	name, _ := events[0].MetricSetFields.GetValue("name")
+	assert.Equal(t, "$G", name)
+}
+
+func TestAccountURI(t *testing.T) {
+	uri, err := accountURI("http://localhost:8222/accountz", "$G")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8222/accountz?acc=%24G", uri)
+}
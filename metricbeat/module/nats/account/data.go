@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstriface"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+)
+
+var (
+	moduleSchema = s.Schema{
+		"server": s.Object{
+			"id": c.Str("server_id"),
+		},
+	}
+	accountSchema = s.Schema{
+		"name":        c.Str("account_name"),
+		"system":      c.Bool("is_system", s.Optional),
+		"expired":     c.Bool("expired"),
+		"complete":    c.Bool("complete"),
+		"last_update": c.Str("update_time", s.Optional),
+		"jetstream": s.Object{
+			"enabled": c.Bool("jetstream_enabled"),
+		},
+		"connections": s.Object{
+			"client":   c.Int("client_connections"),
+			"leafnode": c.Int("leafnode_connections"),
+		},
+		"subscriptions": c.Int("subscriptions"),
+		"sublist": s.Object{
+			"cache": s.Object{
+				"entries":  c.Int("num_cache", s.Optional),
+				"hit_rate": c.Float("cache_hit_rate", s.Optional),
+			},
+			"inserts": c.Int("num_inserts", s.Optional),
+			"removes": c.Int("num_removes", s.Optional),
+			"matches": c.Int("num_matches", s.Optional),
+			"fanout": s.Object{
+				"max": c.Int("max_fanout", s.Optional),
+				"avg": c.Float("avg_fanout", s.Optional),
+			},
+		},
+	}
+)
+
+// Accountz stores the response of the accountz endpoint, which either lists
+// the accounts or describes the account given by the acc parameter.
+type Accountz struct {
+	Now           time.Time              `json:"now"`
+	ServerID      string                 `json:"server_id"`
+	SystemAccount string                 `json:"system_account,omitempty"`
+	Accounts      []string               `json:"accounts,omitempty"`
+	Account       map[string]interface{} `json:"account_detail,omitempty"`
+}
+
+// accountNames returns the names of the accounts listed by the accountz endpoint
+func accountNames(content []byte) ([]string, error) {
+	var accountz Accountz
+	if err := json.Unmarshal(content, &accountz); err != nil {
+		return nil, fmt.Errorf("failure parsing NATS accountz API response: %w", err)
+	}
+	return accountz.Accounts, nil
+}
+
+// eventMapping maps the details of an account to a Metricbeat event
+func eventMapping(r mb.ReporterV2, content []byte) error {
+	var accountz Accountz
+	if err := json.Unmarshal(content, &accountz); err != nil {
+		return fmt.Errorf("failure parsing NATS accountz API response: %w", err)
+	}
+	if accountz.Account == nil {
+		return fmt.Errorf("no account details in NATS accountz API response")
+	}
+
+	account := accountz.Account
+	// Flatten the sublist stats so the schema can be applied to a single level
+	if sublist, ok := account["sublist_stats"].(map[string]interface{}); ok {
+		for k, v := range sublist {
+			account[k] = v
+		}
+	}
+	// The system account is only flagged by recent servers
+	if accountz.SystemAccount != "" && account["account_name"] == accountz.SystemAccount {
+		account["is_system"] = true
+	}
+
+	fields, err := accountSchema.Apply(account)
+	if err != nil {
+		return fmt.Errorf("error applying account schema: %w", err)
+	}
+
+	moduleFields, err := moduleSchema.Apply(map[string]interface{}{
+		"server_id": accountz.ServerID,
+	})
+	if err != nil {
+		return fmt.Errorf("error applying module schema: %w", err)
+	}
+
+	r.Event(mb.Event{
+		MetricSetFields: fields,
+		ModuleFields:    moduleFields,
+		Timestamp:       accountz.Now,
+	})
+	return nil
+}
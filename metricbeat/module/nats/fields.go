@@ -32,5 +32,5 @@ func init() {
 // AssetNats returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/nats.
 func AssetNats() string {
-	return "eJzsXUlvI7n1P//7Uzz05d8G3J7JLfAhgDGDZBxkBoPpCXIIAg1V9aRim0VWkyzJ6k8fkCzWytqk0tKB+9AH2eb7vYVvJamP8IKHR+BEq3cAmmqGj/D+l6ffP71/BxCjiiTNNBX8Ef7yDgDsb8LPIs4ZvgOQyJAofIQteQegUGvKt+oR/v1eKfb+Ht4nWmfv//MOYEORxerRrvEROEmxpGr+6UNmVpEiz4pPArTNvz/MH/0BkeCaUK5AaaKp0jRSoBOiYY8SQSKJYSNFCr9UJOoI6igUyh3KBxqXP/FwXvCwF7L+eQ8o8+/3BIul4PnHPiKaptghExONDRqZxIhojB/hzw/fP3w/jf4nR9tQALGBFLWkEUQSifntDqBIcI5R40chLYwQ/cGrwZpFtaYxDMNBAUPV/qhpMv5fWzl1qA3NDGlnBKzX0vOPRkA6wbAQ6vbZ0NYCpM2KE4m/UL4w32ZFQ3yEsKGyLGHz5xMI0yxItvPxFBX/CiSOJSo1UdqZkDpMnWvcopwPwaw4kTgjfLusxM2KOdlWtsYocj2GY4dStX92MpRi0XlIlCY9+mg5y4kgrFdsagL2RDk6GPdoRekViTTdUX1YEItZF/y6pb+eYCYqX5eLq2WNlefpGqXB0SAClINOqBrdPchjyrer9UFjGBkTHRPfCJkS/QihP5oFuaDuFnKyHIecZ61YPAlrnEvSWXKaO0BJRdy2Qaogz+CDwuiuJ/QxXN0IUINlCGrYb7STiYkoSCpybv0n5ZFIjXpjokmb20DWUMeUolJk27GuQQlOADgAspeihxSy9lE8Q1vlNLzdFT1QkYcd8MkKFbneiltXaAnyG1Foibe5YjfbVzef7muhCTtXeItyKZFrdnAR2OcjXYl9Rq20RJKOyqvkcI0Nex7A9cydARjRZSiNTI3utAACpvaGv6P+ZKkXheNE0ZmqcStkOFs5OoPzq1ZlpQJpilSF3CjdJwm4M5ndB1ORq3sgUWSM8x6cGO9BSGMxKk9R3j30ZX06nD4c4XT+hhwlYW5RIGuRaxvUgvJ9mOmHHE/nckN2B9RzMkcNBLccjIFvbHsr70sBLenNh3pmz95G6snNB3pNf9/Dhc9858s87TqLy/JSRTAHBj7YZe8gVxjD+jCTI+OT5A7j1VVZ63Ljcc3nSGkhybad/1+Kk7aSCjRLaem2mTteaUXsu5Qz8+SOChAbGkLSH3Yn4vzBrlxUob7BMQ6sP/TWYafktX+Hw5iUYYIJTeQSCo2k5JWmedrvzkLcQ0S42UEhKTR57d8nt8Fse+ccz61ZCVcxbSf5TV7DqewRrGREJ2bXxFS9wD5BiW3sBWeM7lCNQD/waGVKFLnr1C9N+AOqmoF9Ywh+Z/4DT9VoGjKDNyJRgi4zR9hQhlaw4bS7cB9LJd714sal3Z2ipqA4N+vuDIJggkVM9KnVUGgU5uCAaEFI9WHRDFCjwSdcRs8B1o08uTIFbIWyiERj8Seh22RFdoQysqaM6sOKKIXnwv7MY2qKWWWxVVxUsA2ej3U88OGnpztwoIAwJuxg1u4zwsd1cdU0tBOMKC8qhaoL4hO4SgSGNVvOjzJ33eytG30WZu82agkfbAsVfsmFJlVm2uYGPngh3N18+u05s7HX63AJ/khGz5HRPv36XDSTmi27YWualtCGep5N4MvkDO3KodoXhruIMKYgJTG6kmeYrwo9SimCPabLwncwALmFjNJXbn1sNJt5F0x/HMG52c81ko0w0lr9KJG05+d1WIEJ+QxM5q+/K0f3Dosd2xdkB3CxXOlgmnB6YeuWBlrTs3NT2CuuaU6AIYmDmGH5wqdKdx1VXyoUghsK+mGVnixXe37OLO6BnSbMgX4yLOyV+rvK/SyM95MnoVy4sq84Kc9SHMOSHwOsQnXlZN6Owt2ZQEzBy/N0pfL1Z4yClcf50Hqic8HGyDDsds+HtTSEgrg7XzsV9YZKpVcKv1yiMWJogcIvOfKoYmG6iB3YEVvoCW0zsVYhLhJSosqEO8ukRSWyYbD2mNplBGtPrp0gVwv12mL18hptoJwjyHWTQyM7X9yU1c5poS/YJoNz5hCTqoSe/PUMwOq57GiZf74RSF3ZErdEWhNMxL6ezrqTinZUEkpop2m8BmZQwhpf+8LxnM1V/ep4rlZvO2jkoxAXM4KSHGSC0egwIUlvBlmJGaMRGXZX/f3IGXB/EntICT+AJ2n81AtiZjEjiZJy0k85EJ+kY1xVauNTlbH50WKC960ce+rfMFBYekw0GR91DR1ZgYXjme9AhdJHwpjY1ztQU8zGziXV9tzYf+7gLpMzO+aJfTVXYIanOEGJ1qx+pCoiMoZf7aa4B4mp2Bm/JFiMSoOQIHHj2vkc99XK1O30ojGArxFirJxoujjGxXQrZU9Xlq70uYogFf06YRp8wiR4plCKGyTGNdUcUCWQe8DXTKJSTlKccKEwEjxWD/83aaOsMpS+9DozT/+gKdUqsOcrxdRZMyqWqAm1JzR9pTaNrZXR4w3YtsvZ5daYo9dfNaInGvaUMVjbJAkzXZ108eZu3Tn96oygMgEFBFSe2rsX5MAEiYHwGBLbSRqbkk+psxeLRYwq3Si01yLnsevahzOBdg/jgm1hT/J2G8N9CK/TEPZoprWEg11+OF9Rp1rzxn7ZTcvxr1M+jQ4ArtNoP1WY30SrnUQvqw0T4izS7Rqtd+8keuFizzDeYoq8M+58il7+akDdwz6hUQK0cagiodvEhJtIcE23uchVp2FkI09CFKwROWxyZi9jlDSPLoLLrvOFmmFi32K09EfDHI/x2nZZV+NH+eN7U7nx6cPQ7gzdrx32a8t0BE3UUJqkWbkZ3TXcGvpiA/RvyBgZ3Zm6+zIbsiQ3WFbd4NYIDw0KXvyJCY/p3l3CNt7FThT+BPsE+YwAD1fbMbYdHt4kjYS5xXxtg8APZXta+R9umVgTVq3YW6Lc/vYaF0B/UpunKxMAi8vdvTvutJP0AUst3NsOnX/rhQ7rXAMXGg6oR3x6s6s47kXOxZPlYY3gbu7BGiOSK5tLH9y7OZnEHRW5Yoca357Phq/cU50UxvgUvcC/CNXWBoaZ3xOqL6HMLGcMpNlAStePAxb0bRpZCcfKpOR3mIOLm6PEtMi9Wu0Jo9KGPZqQ7O2xYbWEH4prrv281bxF6L0BWKCA+81fGVX1+Bt+BmPEOVx/QndqAfI2o7u9GV2l7qhxeekbL+GnZeNxLsma4epyGAuKYazwvAGF+t7fz7e3h2xUbj5Qs6a8G5kJj02My1OEnGvKmj+lCvA1YzSi2sU5HE0rC3e6cpPEy4gnE5TrltO3CbIru217OkK6w4lDlw1lemKzfREennzT1YUqsUPJSKZs5uB4GujJuvMvBrCX/cF8VOh+PdpllpgxcriovqopfkG9PXeetg9tznsd2CR6OR6zSa0uUHP5d43sRYDavOmuSoiK64Z2tmFQVZd1fP6aIvefHmz/akfjnLCyXBGm7KK1JkcznWp6qe7K1OVhxfZ0M0Sa4r1F52n40UutHhif/BW/egE5d8dktsKrFbUBBoBuOpXCOFP9RcH5mWoWCpVmq3ATKhkmjGgHK9fz81Wr/DDAFOUfN4xuEw0fmnVezuu6uwOi7R4JV3ZwzIGZpTtM5ZGZtsv6f1UcJ7dHZmpTtnbbvYwZItfdx1mPfh3JLrfow0i3+/6fxFRoXC39UKtb1tYw3Kc95mMr2QpVz/uN/lHCwDC+1xSPenfQ4gk/onbeZ0XbFnuWp1RpNoHc29t/385TcW9v/40I6H/s7b+Oe7q5wHbWF/8kbmlxWrYlib5n506Uz47IZcO+rWdCjZkTw2u98WKPInXeGrzs09BjGK74ZG9RU44915ti+nDJN5CLyYZ/HCBXxYFNK8lMighV2MFHQvaAXGDHMbGlEWGOSKXZAg/InCvoSSSjLA+iUhFhGK82TJB2ZPIizFBG2JkJzBBilOUNCapBCVqftQq/qwqLpZiWCjv44XfntVLoJOFn02rdjwYovaWCb6ngWyo4E8+lUsE6UMXEvveWzTJey5CoXaWp5u+C21AQxJVoHS5aj7Q0s175XLGPiYHsYtzaJH5ZhZ4mHoY4ASaUfY6iAVl9mVHIWCZMGHMZevpmHOhEsC3AEFOlJV3n7iogh1RwqoU0BvjP354/9awxxAi0C5evvb82oaM4gy3oWHFC3cV8B6POW7+UGyNrfn3sFsVc6Dsir4/cgJgLXOVrdX3kFsVc6FKIvvHZ5ZAbEHOBf74BiX+eL+/iyMn1sXsgRzBgosVNMGCBzGVgSzTuyeH6DHggcxlIkDCdXB9/gWMufIZkc33wFkUf9LHB14mNuuao65tpaBZfXNI/DaxKcYWy5ws1Tk3x3dogMnTdNTsqbAqUUaV7GxW76T2ymV0Ks/ZxuFKio+RMuMrTE4YGUAUbe+jJHkJp4Au3xUiU4EPvIHOR7o7KmQb7zK2/n2aIDsBJqF7J7mng8zfsDFGvLCASYY12DGs2Lu78y1Rj8DeEi1w/pOR1WZn6wxhufai+QmAiILILfxdkLPI1O+LrBskO7XMcPXj+GwAA//+lemdf"
+	return "eJzsnd2P2zaQwJ/PfwWRl0uAjdt7O+zDAUGKu25xLYqmhz4cDi4tjW1mKVIhKTvuX38YitSHRUqU1x8bIGiRh7XN+c0MP4YzFPWePMPxkQhq9IIQwwyHR/Lmtw9/fnqzICQHnSlWGibFI/mPBSHEfpP8KvOKw4IQBRyohkeypQtCNBjDxFY/kv99ozV/80De7Iwp3/zfgpANA57rR9vGeyJoAY1U/JM5ltiKklXp/hKQjf//jT/6m2RSGMqEJtpQw7RhmSZmRw05gAKigOZko2RBfmtFdAm6FBrUHtSS5c0nHucZjgepun+PQOH/f+7ANUWefooJMayAzq9qMTk13T/mUCrIqIH8kfz78sflj2nyP1kJBCUQuSEFGMUykimg+O0BEM0yWQnTaWLoAkI6Hl6DoWkkH71v0FVeEHYV1MmBeaeEHNPFxH97H8R9M0HlfYQtooHMDjxbULI+agPFSRO17LWUHKiYJ/uvHZgdqK5cwrDTghM1SgNfS6Ygvy7OL3/9SXZUB4V5kEwWJQcD1yVhmmwqzo9EgZZ8H2HhVJtVVeY0ghP4YIIFO4gdQV2YA9VWFKlFhVk+g9FGAS2WIOiaQx4kepGBfgHzyYrAfuOkkI3sWS7IlkkhIEP76yDV6ZhPNFSn2bEBFR7gPT7OYPCjFo8JA1tQgc8nID2oqIo1KGSsJaWTt4wc6EbIHG5BibIICksF9ZC6Wjei9CKdcoKwT9cTkkjFmTZBnjO63qd2xXfCu0QERb2wO9JsB0sQRjE4teKUJc/ytxNFmBiqU1CT7WqkCeAdMys1nPFaYp1RDvlqwyU9NQn+t5GqoOaRlKCy8HBMVGzHDEGQoHtS9GFCg2ritu5/tSJciu35eLGeXEuFPIqloJB7uDVWLTVOZQ16G6rPkJmoPM+zoUJWZlnQr9cbOgX9yoqqiNqsZszJ+kioB58CpvuQmVwgIas1h/N56R4U3cJ5vJ6zXQsWU7NocnDetjkSn/c3eWOzqEft7aUuELM//eTnkqARbrVdmBD+zER+WeHYIgqfEIxSLisYW0wQzMpFcHyX8yU+/U5onivQzao+IbuUyoSlB2eXBARsMVE4p2J7WYtjixXOER7gNFINcuxB6dPPXoziGp1Hog1V5hq7sFau3YhZOZAHGXCTtqKZYXtmjhdkwXaJb7fJsCR0k94sf9nOGltKbAzJujN7kKwEkTOxXa2PZrCWR+MGHyGGfjQL2UmvG0IdUpCr8iR7lsSaV6qfAEvGLUExmZ/2QaZJVZK3GrJ3QUqWc1i9ElBkGUM9lRUOJhIpaGGTJXJDmMhkge7NqaEz914FaE23g941asEEwBHIqESPFOrtkzxjQ+VlvMMWPaiszHUcKiuzla/doQ3kN+LQhrffoodsR7JeTDn03uG+kYbyRciYF1jeskopEIYf6xXYxyN6cUrRZGAn7XVGTeNJ1B0ATVeCQpvigDCSUILVsk5yti4CJZoO6zxbqY6XjeB8q20hSBOFZSUNAp3ugwTYY4z5Fmto+sFnyx5Inch+IFJhj9FVAerdMohvf3mCELZ5Avl/gQBFuS3paULXsjJ2UQvat88TNnKfFH/c7edJw34C2RvcjoBOp3XSiERDwyR8i+ntfSvQRt581CvP7KekXtx80NiUfYv5PqKFj3zn27wYTha31aVdwWoY8tbq8o5Uus5gzdMI5yS1h3x1V9WG2niu+RppIzHZdydNTp3kaC7lpdet3PlOc2ufnqvYmdOAFzd/Bsik2LAQSXzZTeT8aFt2u1Cf4JgGiy+9XeyCfo2P8Ekrp3ShRC1PSwjR6SykPcmowBG0nNQ1Pk5eh7KnI+d8bbElWOXsNMhPCWXPUKWkZoejJmf6mRx2eNrrhN1pxtke9AT6UWQr3KKo/WD/kuyqGewbfRTZD/gP8VLR06RET9iSqC8BbxgHa9jlIgTupo9F6gwwwdjd3NRh92BT4yQuF2lDP1oISukRCRbtF4UmMUcLRBdE6haLZkC5L4QG+tg2eg7YcOWpNG5gW0q3Ek2tPzu23a3onjJO14wzc1xRreFa7E8iZ7iZrc/JtVq02MjzvstD3v784R2poQjlXOLvczvOqJj2xV3D0MFixITbKbRZEB/AtSZA1WwCfVK5+Kp0W+386nNh9Zoo9a5O9Iutc+GXShraRqan2pC33gjvEhS7rwO9Znbt9T68hH60ZNeIaD/8/uSSSf2UXZfv3IA2lPNM8kUie2zn0I4L1C6jnOPxkRxwSpjSq6UHpaTSd8evMQgI21VAQT6uhscf5HnHu8vLwx93tHeR1k/uGWyESVsm+0AA5FGsQIV8BhP++oemdF+z2LK9EzvCxSttQF1jGvhYN01Yx8/+2HTMXGmTAAeaB5lT3JxIPwx3a6l+q5DV2sUtq03YpS+2K1LZxj3Yy4zpE7yj5rzMrDTIxzaJmLgKLWhsFU2inFqKz9akOUtxjkq+DLAK7SuTdTuLe1CBSOEVVbFy5zr1TWm90LmwOXAIT7s36NtOeP1EXCr1hiltVhq+3IDZyiIavlQgslaFdBPbBlZGj7JGlraZrO0Sl0mlQJeyPstkZGuycVhOb2ZYTl9mV05fgVk5jVnVY7pg8RqL3DA47DxY0u52Xrb0sXzUwleIIZwCy1GsSPx6BbBuLBtFu34JpOtsBVuq7MjeyUNnwODDd5krlUB+rsc7MKMWNvA1thzPMG/nq97KKaNfgQExiXixTtCII6XkLDu60ZXGiousgpKzjI5PV/F85Azcn+WBFFTg86m1SEwrPAOUlhlotvOrL4bp1AfpkLc7tXF14omeKxjep3KwWauA6+l43G8cE0tdTQB3g/XMZ6BC4SPmeg/dDFRKt0EFCr29NvuvA+4mOEPj+yNZTVcnH3Isbdlu9RPTGVU5+d0Oiof6gTCclyTPAZ91VETBpk7nCzi0YR+rR7pLDMDXDCDHRDrTAY5pM72Wbc/QlpbsPobU7J+EavDUSL5cB3NPkODU5PD7BnnAh/vxKZvaUoIKqSGTItfLf5lUAwfKqgTlt15X1um/WcGMJsXA361juqrhWFGAJzPxEVa/U0tTa4V+PFedC2/pOVVbHNfef22JHq83YZyTtQ2SoDTtSRff3TGURl3QTLRpAvOpRFcF/rWkRy5pTqjIyc5mkqaq5Cn77IutRf7xbS+VrGUlcvRtR81FiNOvBIvUoHCCKiEt7EUuF2kR4ERgPWXKMxLDMcL7JIQ9TVpKOJjlH3fpGXF+u6lz00gTP8Rtlxbj32f7FBoiXSoXil7DrGOJ9pca85tItdPsGa9XkFex7rDT+umdZs9CHjjkWyhADMqdH7Ln/0SoB3LYsWxHWO9QBR6gwOUGb9Ri20pWepAwsisPXgy0BhDuYp6OzPxcn/o+catkmDycKOoBJjSe0rXVqB58d9PHzWDp2vjwwRtiXLv2+Vq4QUYQy4ja0KJsBiMmM3v0bgAsFzHiHPAY4PDirCsNyEacH5r6Gxkagdi61cWfmPBM+IAQVXjTX11R+Dc8h+lmyKQF/n4jBntQZJD0AuYT5TsDhHxs0tPaf7jlck1522J0i/L6h9e0ARYxfMzA4QLoHu5ezHRlAnqkp7rpbQ/1/BZFJ+vKECENOUJ/HhnXSUHT4s11sjqs8UJLfHKPrCGjlbax9LG+6bJUsGey0vzY0dvr2ZsrD8zsXGf8kD2Tvygz9iqDceUPlJlbOLOsOCcKB5A23eOATj7mjzrGkbgFbvQd1+Dm3VFB4WKv3tivY4pef8QAw/fH1nv4TKk4usdcl4uE2SJ038DkdJGg4B/+kVFNzNQ1GBOTgyvq3GYZ7lSR2mxwnPF7je5brdG17vYVue6m85vdwo9Rt2x4Yciaw+p2jE5imJU8bfAC6Af/fL59emhH9/37/zRZszad539sk5EKsCFSCcN4/1O87vQr1tkYHoB3p1kmjFNPp6u6kngb85SSCXMy6dsAud522/R0BniFgV8rxnXYMG4Sk+0X0eGDT7rWS5Xcg+K01DZy8Bc5RnOyGKjUwN72R/yT8/16MsuMhVR6vKm/2iq+LePS42nd2XfAcXIb894Hm2bP5zNjaBURNBoZzcT19xqRt/16kytotM8c1bUNpGof1vHxK6a13F+PNn+1Z3lFuR9IROK2i3WSHP1wqrUKzlLDlrEiKo0fnrYyhuHNg+3fXoYvvXT2A+NGxqKp++oN7Dwsk6EKmtAxBQjbDHYK00rFNwXXV6q/UWg92y43oS3DtE7jO9fr6+VJe4O4VYqJ9xvOtjtD3rbOw31eJbq+e0eosWMkvLM768DMZTRuNW2OzJxOWf+q3XFye2SmU2U7Tbt7FZSselubcGyZfDuSbe6iFyP1Ip9FyLjh00gTdu3b86z7//AQiYHVYFMztlYlUNXN2nOGwlUb0MO1ZVsqyINQbvyFivHRrjjLVE5AzaPDDFe9VvS0x7ZyL3iVKisTxIlF6r4sQWLgmrrvd/99v/vv+91/5939N5iewr6848J21Rv/FGyZOy17Ygkv/vTauRfaZ0/VZZd9fFhbhRIzL1xeu4kXexRpcNfgba+GnmK445W9bk85dV1vAcUyNKwnIUM/SiB0lQ1/21il3YFNa8lSyQy0DoJmUoG+1ojjcssyymshrWcdD1EVvn5GhLnKajHzZSPjLxqZYcSsrHoW1J44SGrnrFUbFye7fJYprRR+9MVvnw0NEtXR8tW82p1HA5K+h4LfQ8HvoeArDQW7oJrLQ/QpmyjtrKkCRTR5mG79XQq7FAS58N2el+xp2J6Pv5o1MRBdTPc2BV9WpzHiNGICpkdtEpDt60cD341hdlErFbr6Zho0EfYEmORMG8XWFfoBHwQupGBGKhzV//PH06dIG2OK9OyO4fo/0a+N9NYz1Br24h0+LoKvMrIYXd3iVm7hMTK4P7ulmIu+p+r+5AgxFxxzl/cntxRz0ZWUZhH8xg3JEWIu+OdXYPHP8+3tjpzcn92DnKEArhavQgELMleBLTVwoMf7K+BB5iqwA8rN7v78jmMuPr4W9v7wliKG7lF7NanFVEyTnKjrNfvtJDTdi0tiRpl68WnUr7Mg6raJLKHOrtlSYd+gg7f0jr/59DJcddvncYXfRXoZrub0BMrASz829kFULFrTHl+QzN7mvIwWMi+S3dEVN3jkrnnIYPhW3aQ3BF8/YYdCvbMIVXhQH2cOhQMX9v5mqin86JtlX2hTfxijbp+4i0zXx1Sg4ZtjR94am8Dj3xYb4fn/AQBOcm+f"
 }
@@ -0,0 +1 @@
+select pg_create_physical_replication_slot('metricbeat', true);
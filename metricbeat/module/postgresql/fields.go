@@ -32,5 +32,5 @@ func init() {
 // AssetPostgresql returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/postgresql.
 func AssetPostgresql() string {
-	return "eJzUWk+P47oNv8+nIN7l7RbZoL3OoUCxr0AX6O7bh92ix4CR6ViILHklOZn00xeU5D+xncwkY8+2Oc0kFvkTSZE/Uv4Aezo9QmWc31lyP9QDgJde0SP88jV++e2Pf/7yAJCRE1ZWXhr9CH99AAD4TN5K4UAYpUh4yiC3poRuHTiyB7Ju/QDgCmP9Rhidy90j5KgcPQBYUoSOHmGHDwC5JJW5xyD8A2gsaQCNP/5U8fPW1FX6ZgIaf3o4yoh0nX7r6+nrQuHlQfpT+8OUtisa+fO7JsiMqEvSHiqyyQZQWSPIuRUb4ij1DqTOjS2RZbAZkO3nDfiCQNTWkvZnchtsYHLwBfqewFoUgA6cR0+AOmvWw4+a7GkNH1v/bE9nMsPvjKXabXj1plGy7j127qLmMzRh34wZetyio7WR2dkDjTmV0bvBD1csGqz66be4cWqlgy+kgy2KPekMJIeh1nGb3qyvA+N/J5Ht6XQ0doj6GXBfsKQZ0FWzWetrDA1ojNYhmdZcO7LrJXzFgkGZ3Y4ykDpE94uwTPjnBh/coRWrSkkRDuPmdcp7kuI5Hfj+BWCEkqT9GrPMknO3Qfn0FdK6BlCUdieGwjh/uz3+YZwPcloMrfIod8X5ylJlbMxKgGCJKwXBb1++gTJmX1e8OD6+4S1dxcmSZgrf7x+/AosDXZdbstGJPUNKB7XjpJkbC8KUZa0bfx+lL4JtR0KTrVdgLHz4C8gcEP6l5RM4I/aUhNIFX6TFG97RjXs5VZ0PUlFo3M710cmtomApB2gJsPbmgKKuS1BYa1GQXfW/PBq7J7sa6VFmJwUqdmkb/J2AqV+TJKjQolKk2i8YHpdbPUxHAEcrPT+SHNHaVBQk9pWROvzqPFpfVys4orIkSB742yMTDp2RDQXyiCoKW4+U/P3Jk3bSaAclnsDSTjpPNuFz0ceYZZK3gapN8cGI1/0XkE1GaYb+Vs/KkuBYkI5nOZEBOEYewMdqBXJN61Xz0GQiGInl5yJhmd6Kt6gdswSj32A7v7ZB29Pb3+M0yEBrFoPXniR1iqSMosJz2xvLhzxSMulAmyGUxOio5yCFzo9lTe8xSN6IAvVumsm8dpMROuMIsKKmC2COKL0c5dmIY2uMItQ3QrE1sf1GJKq1fFIJRgOCMmJ/xUy36f6YQs4ciFNTMsSQR3XZ84CqjumzY8MjoQB/Sv5+hO8F9fdETyTqsBdMhH1ytczUeG1jBS5FCJqO3SEvSxzW9r4okLp/qEaSJdu198AKtrW/FMn86Vxzw4YGKOAdbgMleM94pOvOj5OlVGiZu6R1kyDOANOToMqD0W0JDOK4MQv742/6ygVyCZ6UixrIWjNRLngnOTpfoS8gr3UjSqmrjuYlH87WTIvOpMOtomxoj5Y78SGxKPZN6ybJ8e/NurjP58he8NKNJ5Se/PBQ/OqgZObHVbfrPj/10mDKl2FR6CBHcrk7doMs2xmuEamBT6bxBffXLNytQPpu8ZigdKk18DnOa1HstZy2ocOwDX/WMP9G6SGsiwxYxtC7lsSeA3AP8SsirWMXRTCcIo6FFMUQzghES1t2kSO9ZhryzaOXzkvhALem9q3ySPESpWvrvWvHHP2pRaTbQ7c2M4sG5jjX3D656JikWztRUFarUUa4t6/4EtsJk0Mrua8vxmWBB4ItkYaKbG5seSk8+0gt/ajJ+QWQtpJnQuplSW4d/LUuh11ShJsrgzceue/GowIsTa1jTmIik0C6iNFVoXWLSZ9TJ2dp7i9bcCOpKSY59I4FWYJcqtQm8QY88yTDiXa/YsGlVEo6EkZnF9qAsSHcSYv/Zzsw/sIaLf8Txwo3GGNb5zlZt+4ZZfboTTpad2W15V30/XAd2wRxnR/V9jSdFF+AbZPXSs0OMMTmhUTtvKkqygAhAGBzOoEathTYE8hx/BSY9Q6MgRL1qd3G1T2mIrV8XEhLgutxGERdZUoDaJucj8BCHmihBBN60xAWkN6BOWoIygPXhHfa2BKVGrI4uODHAnWmgpeNo8AQus6v0ZoZ5pJR1/Qs5v1VG6FSRuASZalxYKvhcvPnNpYczToHYPrYECkXm4pEcY6cHgPVDErHlKq5HXgNpfpdE1hzDHdLjbzuVqn55sNRZn1s57dA7c3PJKOaQHk7lfp5dz9h+PLnQHhdgZYy9oaprbg0n/vJt0EroLLyp1sAh5OwMfkmiVygdibBvW5lfIPQ3qc9Nyt0a2HKclQcZsiUPR1tr9uz+hlRjRguposzvNYoxTb4uYgZBR9YvDTf2ioj9twB4PxZltlcUgCsYIT2KqRiCW+HutjHFTJuHiobKgZ5ashtrBEgUBScHqdG3ejDvRPzEwyDLdDEVBftCd6FnRqtWKBQdUYOCtkNjrqXC0aCzzWzWF5gKrIYhhju5DyVv7pApNN/8en3Vy3Kuw+evtQyZKbeqnsqWuwKWHRTQiK2ZOTtqUsHwxhYTY3iXkD/e1u62g6+ck8s+632ZM2RT6KvrV6iFTdHPoZRelPCw7TvRacygMvJi2IpbEn4ndCkdmQXGWEwtkb6neDqKluExwZsSfid0DJStBi0JPx2aMLoXEmxQE/f4BCoBXFtzGpiPtJqjBe0loQ5kD01eEcSn6EuVFbGoj2tw7Bj/kLWyE/DFGHp2RiAv416fRgJQksgTK3DHaSlHVpu88K7IMciDhrOl3DpG0lt4Lyj9W7NxdPGuzbuG10h9e79KlyjnysIV5hmt2EFmym7ATjylwfendG3Jz+b0YdDsVAJegO8oQXHLrgcO+ySO1zwjBcDG0kuWMLOGWEWiuD8jLGRDBn52Cq8LF/873Tqo5a9vTN8bc9+9lJovIriv8LLatO9/MQbomdSpT6Y9DpN81JokNu9EiqqGmqHu/haqA9HIXCuG94J7S5NX3e38pYvGXZDrGAVi5G0T9xGv/k7q/23RWytYXjze3YnO5+9OiytP6/p9fR04+XDH/FydbjuXKxApRaopu3AOpq2ve2w9VXjMseZtcro62z9Qugli/OxDCd09huhDla/iWm6vlrr7mr9OYCl1DPC+yy1LOtyVoD4NCdAfJodIOFFE94ed58J9ZzonM8yOsyH76upahVLlPOoM7QZZHSQXdXqzR/6SF94jRihl1Qae1rH4emMk6fh8UnT2TBCiBObOBNKV3jPmvgc54xDuxcADXOu+4Bm0no5W4/5AqxJ4Z1wE7l/O7iDm9yXwlVGoFowWoP8VwdrRLlgrI5h3hOqEeaykTpGemegRrDLxukY7J1hym3mkv5n+a92fwC5rEFHOKft+d8AAAD//5VSM/M="
+	return "eJzcXEuv47aS3p9fUcgm3QO3MbM9iwEayQATIEl3bvdFlgYtlS3iUKSapOyj++svig+Jevl1ZKdz0WeRWGbVVw+yHiz5A7xg8wyVMnav0XwTTwCWW4HP8MNn/+GXP3794QkgR5NpXlmu5DP87xMAwG9oNc8MZEoIzCzmsNOqhG4dGNQH1Gb9BGAKpe0mU3LH98+wY8LgE4BGgczgM+zZE8COo8jNsyP+ASQrcQCNHtimou9rVVfhkwlo9JfgKD3SdXiW8kl5sczyA7dN+2CK2wmO9PdJIuQqq0uUFirUQQdQaZWhMStSxJHLPXC5U7pkpFBSAyP9WQW2QMhqrVHaHt2IDdQObMFsQrDOCmAGjGUWgck8rodvNepmDT+19tmmooF/Tliq/YZWbyKTqCiAoYkAplWYqjFnlm2ZwbXiee8LUZ1Cyf3gwQmN0t+nX372gmNLHWzBDWxZ9oIyB05uKKV3Q6vWp4HR/w54eGQv2ByVzq8D9zsrcQF01WLa+uxdA6LSOiTTnGuDen0PWxFhEGq/xxy4tOpSLBP2ucIGN3BlVSV45jbj5m3ME0p+nw5sfwGYTHCUds3yXKMx10H55TOEdRGQp3YjhkIZe70+/l8ZCzJRSsfc013ReaWxUpo+2zbAQCNFCoSff/8CQqmXuiIB/Nc3JNJJnERpIff9+tNnIHIg63KL2hsxUSQ3UBs6NHdKQ6bKspbR3kduC2ffEdGg6xUoDR/+B/gOGPxT8lcwKnvBQBRnbBEWb+iIulKWpupsEIJCoLamOG34VqDTlAGmEVht1YFldV2CYLXMCtSr9MOj0i+oVyM+Qu15xgRo7Jy/IzD1NFCCimkmBIr2A4JH4VYOjyOAo+aW1gRDBEFWkBWYvVSKS/fUWKZtXa3gyITGDPmBPj1SwiFz1C5AHpnwxPoKp3//92pRGq6kgZI1oHHPjUUd8BlvY5bnnHTORNxFXomn7eeQDRjSMheYrrUsLxGOBUrnbzEZgKPPA2hbrYCvcb2KX5o8CEZk6Xs+YZkWxWomDWUJSj5AnB9bp034pjJOg3Rpzd3gtTtJNECaOKDPo/q6V5o2OSVVSJtbqiGUkNFhYiDBjB3TmpbRUd5kBZN7vIuQjoGTycHynGYUfmTc8tE563FslRLI5JVQdI2kvzROkRo7zQeWoCQwECp7OaGm63j/FFxOHZCOpqCIYR7VnZ4HJmp/fHbZ8IgowH8Fez/D1wJTmfAVs5rUBywk7JOreS7Ga6MWKBQxkHjsNnlZMpnPkwIu0808osxJr8kXVrCt7Zwn019nmisEGqCAd2zrUoL3hIfHkob+g5dcME25S1g3CaIHGF8zrCwo2YZAR44KM+MYF9hjnrHa4Djq0D8mAbVWE+GC9LljxlbMFrCrZSQlxElD05IPvTXTpHNu2FZgPtRHmzvRJtEse4mlG0dDz+M6L2fit5O7xFnpul3yFV/tsLj40UBJmR9F3a76/CU5BsN56Ra5CnJEl6pjMzhlO8VFkhJoZypbUH1NOjEr4LZbPCKbHK0un6NzzZM9daZt8DAsw88q5k/GLbh1Trn+FOv5wegQOwfglsSPfDskfx4MHRHHgmcF2MkzZP00BLDd+xzpLd2QL5ZZbix1idhW1bZl7lO8kNK18T54CLe9roVPt4dmjT2LCDM4x5s6F10madYmKzCvBeYL1RW/+3JC7aClnGSu5PPMQsEOCFtESa0j6g/NuWeKVOO3Go29A9KW8kJILS/RrJ291uWwsiUvf4adUOzKLfdVWSaAlaqmsL0D4hJBGo/RVLQHwqFPRyed0mqXgBtRDT5JrncsUCPsuPBx3nmtpaRNQc7Ny4pO2ZILwQ1mSubmUkWYRmZ/Zz0Q/kIryf+F+ZXK2Na7HXWGE6Us7r2BR2uuvNYkRcLyDLaJxHV5VNtm+lC8ANtmV4vYFl8OIG0fM3NQG6uqCnNg4ACQOk3GJGzRZU/Ax/5TsLyV1SoFJZNNK8ZJGUOQWlzAoQVyrjGjeOwaUYHrRdA2O9oCiwP0FmihOBVaFRMW4NaAOkpwzF2uCe8k3SUI0UxW9GM7FkzmtIttoQy6dKWr/CLXXFEu6XmNyJLu8P1JHTEhVMbuEZYCB2g5TBuL0k6z0WjQLlkiM9slUsYXyiHFOdLx6FJNx3T9NEQUbwfeklJ9kghaHSlBaOl1t0rxkw9HnqfY+rdA7c3PZEYVabwplfrr7n5c8+W/XcJrCqYxB41G1Tqb68/9xbdBK8Cyss01gN1O2KjdJpA0C6k62WKBcFKGpZi9QFG8aZRJbW3WmSpLbheHmfJoa91E671E1WOYPS56eLUSgpT71yImFHRBwub6W1tqfVEFwPLFkVI2FxgAMRihPQmpuIe1XVxMcbkTd+ciG3X7Wd7E5NbHCMhYVuAKjBqRdYkx3TtRfsJcixYkUqrLdAPvnKRKCiKYiTpHAwXvGkfdcMGIcJ8zkSU8qkLNqNAG0xiL5Y/GFRTh//y335/UKGUFztJzJUOu6q3A65TrIpqvCoh0DCEeW1DytumOg6EPrKZacRek/4lIJ8vBN8pEtB8lk1ZH2om21vIepTj1xSL1GMI5jtGfALdDS62H+2ALxG+ExqVBfZcWBmGL1G8EV1f5XfJYIg6B+I3QchR4N2iB+PXQaMxL8OwONX3EkTGZIbXQ8hqp9dBy9Be0GjO6vwnRYOJC/jR+i2WlNNPNms7B5aVo6YdmSqbxrA/Ax1GtDyNC1JXJqA9Gd5Aa90xTmWeI57HwjYb+Egp9I6oRzjtc79cUPLULXIrqRlNwuX+/ctfofQZEXKj9hhhspvQGYNDON7xbYOttYxdT+rApRvpM+hEDdZgJE8z7DpnkBhOMCPZJkEmiCe6h5xxZ7sLvQhru3LqlDDlaXyqM3HgS0vdTqT8NoSVDK09DWG8aC7VM5ttm1ZuKMUJZZ/GJkZkeOVNvW1Zd8d/d2zsabE9GMVYjK+lxQrhHLGEy0yZoWwPJV/tTQNU+fbYhQYyTJK5MEa+nOgtbtJf2FoLuJl1laJMLfOUj/PnxVwjjQaHdnajNKmCRZZjmXflJ5wmtpKLNiXF66PPkVrxAmqkBUJyQcD2LaW4E81xj5EJwZ0Y1r8N6ZnDzDpDTAc9BSySFPg85jCxOj3cuBHg8Bhod+CbIoVSanKk6eUpfCHc0enQ71KmJn4WUGieDkomgFFM6iR/PDhoRZTYr6uoE4kZmmzvC/hJu6VRt+tCDnp+B0UXCCiplUVrOxAroA2qjfquVrssz2CvNle6/NrHcQeZJDyC7XukWScMZ3WBI0rotuhvJICu50Tx4wfZrQ5PWU/nmWfR0O8/sM8wtvkC6j21WSl5EnSKCE709ytqk+cmUDJTN4nckRJJd+15dc4s85bwwk02hC+G6owYFqwzVW2iP1GfdidrVNXF0icxBl1eCZjxdoUNzxOMRR/9PKst3MSS04+JRXJpBiBrh9sz1+FAVDth3ZFqHB/ObTevWr8t5Yf52po0audq0lDSy5juyrQeE+dVGDZL8J1nVJXmXWDXqgQqe5eqRpKBwlLt6I3myoSfm2oLjMUnyUID1LJ5K1Hsu74PoU22r2gYWBI2176KcBjUxcLkQpPSNHMKwgqpojIOkdEQ3D6xtozzGjJHdQHF0486MURl3XUvquJ7QZWxuTXA79YrAhXD/LNCNAUeFErZchyklv7Hpzj2qnCb6lJxHSzezB3wc1O563aez47fAxug2j2oaBG2F990i7BXNNHPbzXPP49VoGZeYb45MfCeRLkJqo5zbhC60Z0xSDr6l46tUB5o8lpaPm+5xrCpT0tRl6OgHUr7cQwOnKj66rOC6xHzj4tiGMr3vQzskf4suqiiVc3AQjHKDKGLa7lsuLkbevfCScBr05eZ6jpfEyfH80llrXKDtZJbpFLhHh+vLsDzs2EnQuEysCW9fzkOjVv6mpNmNPVJNn29otGA+H51utlyA9gsFE8s71RFnCJxD2hl/CIMeV/VWcFNcjN1RqOy94P/Dk3+EBDk+uiggu8d7jrYAGIl4VZlm0djNHd3p69AOsZAJPwsS3vKy6lJTVF4H302wnfUmV3yHstMLmZRdZ0vOR7iV4TLDGcsc098v8NaZJNiKe8Lrolzt24lP52LlNfeMdJnduGFhd9cyPTU88Vs0PapcHqLk8ednHN2u5Z1VNdR0gjm7WnfpTnumf6HYIzq6XGwVYCYvCC8ePX7kz5l04/IuhdSM2kQ4fhetQ/ewX8cJKNw4ga4lKDkNyH1rOX11WFp7nuJr8dVex+APwgujdX2y1AoyC0k09WqM49K9V6Xrk8qlaaqFwPh5Ftkf1BrOBTYnNU7b0u3Qxd8962ClR12YxdS1lN1LvOcAllwuCO83LnlZl4sCZK9LAmSviwNENqvC6/3uN2RySXTG5jkelsP3WVW1CBUhteeZziHHA++iVjLpnCI9kw/2oZdYKt2s/XslC864D7dPeA/EDSv72XA/fR4q8rMq7uNc8PWAC4AStxuB5lxbjg/EGhjeCDdc6z0ObrxHvBIuFRTijt7q6L/ZWR2Ve/rqGOYtruqo3NlTx0hvdFRH6M5+OgZ7o5vSQOs97U/032x+InJnhY5wTuvz3wMAoqKwdQ=="
 }
@@ -0,0 +1,129 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !requirefips
+
+package replication
+
+import (
+	"time"
+
+	s "github.com/elastic/beats/v7/libbeat/common/schema"
+	c "github.com/elastic/beats/v7/libbeat/common/schema/mapstrstr"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Based on: https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-REPLICATION-VIEW
+var standbySchema = s.Schema{
+	"pid": c.Int("pid"),
+	"user": s.Object{
+		"name": c.Str("usename"),
+	},
+	"application_name": c.Str("application_name", s.Optional),
+	"client": s.Object{
+		"address": c.Str("client_addr", s.Optional),
+	},
+	"backend_start": c.Time(time.RFC3339Nano, "backend_start"),
+	"state":         c.Str("state"),
+	"sync_state":    c.Str("sync_state", s.Optional),
+	"sync_priority": c.Int("sync_priority", s.Optional),
+	"lag": s.Object{
+		"sent": s.Object{
+			"bytes": c.Int("sent_lag_bytes", s.Optional),
+		},
+		"write": s.Object{
+			"bytes": c.Int("write_lag_bytes", s.Optional),
+			"ms":    c.Float("write_lag_ms", s.Optional),
+		},
+		"flush": s.Object{
+			"bytes": c.Int("flush_lag_bytes", s.Optional),
+			"ms":    c.Float("flush_lag_ms", s.Optional),
+		},
+		"replay": s.Object{
+			"bytes": c.Int("replay_lag_bytes", s.Optional),
+			"ms":    c.Float("replay_lag_ms", s.Optional),
+		},
+	},
+}
+
+// Based on: https://www.postgresql.org/docs/current/view-pg-replication-slots.html
+var slotSchema = s.Schema{
+	"name":   c.Str("slot_name"),
+	"plugin": c.Str("plugin", s.Optional),
+	"type":   c.Str("slot_type"),
+	"database": s.Object{
+		"name": c.Str("database", s.Optional),
+	},
+	"temporary":  c.Bool("temporary"),
+	"active":     c.Bool("active"),
+	"active_pid": c.Int("active_pid", s.Optional),
+	"retained_wal": s.Object{
+		"bytes": c.Int("retained_wal_bytes", s.Optional),
+	},
+	"confirmed_flush_lag": s.Object{
+		"bytes": c.Int("confirmed_flush_lag_bytes", s.Optional),
+	},
+}
+
+// Based on: https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-SUBSCRIPTION
+var subscriptionSchema = s.Schema{
+	"oid":  c.Int("subid"),
+	"name": c.Str("subname"),
+	"pid":  c.Int("pid", s.Optional),
+	"last_message": s.Object{
+		"send_time":    c.Time(time.RFC3339Nano, "last_msg_send_time", s.Optional),
+		"receipt_time": c.Time(time.RFC3339Nano, "last_msg_receipt_time", s.Optional),
+		"receipt_delay": s.Object{
+			"ms": c.Float("receipt_delay_ms", s.Optional),
+		},
+	},
+	"latest_end_time": c.Time(time.RFC3339Nano, "latest_end_time", s.Optional),
+	"pending": s.Object{
+		"bytes": c.Int("pending_bytes", s.Optional),
+	},
+	"lag": s.Object{
+		"ms": c.Float("lag_ms", s.Optional),
+	},
+}
+
+var schemas = map[string]s.Schema{
+	"standby":      standbySchema,
+	"slot":         slotSchema,
+	"subscription": subscriptionSchema,
+}
+
+// eventMapping maps a row of one of the replication views to an event with
+// the fields grouped under the given kind.
+func eventMapping(kind string, result map[string]interface{}) (mb.Event, error) {
+	// NULL values are returned as empty strings, they are removed so optional
+	// fields are left out of the event.
+	row := make(map[string]interface{}, len(result))
+	for k, v := range result {
+		if v != "" {
+			row[k] = v
+		}
+	}
+
+	data, err := schemas[kind].Apply(row)
+	if err != nil {
+		return mb.Event{}, err
+	}
+	return mb.Event{
+		MetricSetFields: mapstr.M{kind: data},
+	}, nil
+}
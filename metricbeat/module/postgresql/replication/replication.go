@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !requirefips
+
+package replication
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/postgresql"
+)
+
+// currentLSN is the WAL location the replication positions are compared with.
+// pg_current_wal_lsn cannot be used while the server is in recovery, so the
+// last replayed location is used on standbys.
+const currentLSN = `WITH current AS (
+	SELECT CASE WHEN pg_is_in_recovery() THEN pg_last_wal_replay_lsn() ELSE pg_current_wal_lsn() END AS lsn
+)
+`
+
+const standbysQuery = currentLSN + `SELECT pid, usename, application_name, client_addr, backend_start, state, sync_state, sync_priority,
+	pg_wal_lsn_diff(current.lsn, sent_lsn)::bigint AS sent_lag_bytes,
+	pg_wal_lsn_diff(current.lsn, write_lsn)::bigint AS write_lag_bytes,
+	pg_wal_lsn_diff(current.lsn, flush_lsn)::bigint AS flush_lag_bytes,
+	pg_wal_lsn_diff(current.lsn, replay_lsn)::bigint AS replay_lag_bytes,
+	EXTRACT(EPOCH FROM write_lag) * 1000 AS write_lag_ms,
+	EXTRACT(EPOCH FROM flush_lag) * 1000 AS flush_lag_ms,
+	EXTRACT(EPOCH FROM replay_lag) * 1000 AS replay_lag_ms
+FROM pg_stat_replication, current`
+
+const slotsQuery = currentLSN + `SELECT slot_name, plugin, slot_type, database, temporary, active, active_pid,
+	pg_wal_lsn_diff(current.lsn, restart_lsn)::bigint AS retained_wal_bytes,
+	pg_wal_lsn_diff(current.lsn, confirmed_flush_lsn)::bigint AS confirmed_flush_lag_bytes
+FROM pg_replication_slots, current`
+
+// Only the apply workers of the subscriptions are reported, table
+// synchronization workers have a relid.
+const subscriptionsQuery = `SELECT subid, subname, pid, last_msg_send_time, last_msg_receipt_time, latest_end_time,
+	pg_wal_lsn_diff(received_lsn, latest_end_lsn)::bigint AS pending_bytes,
+	EXTRACT(EPOCH FROM (last_msg_receipt_time - last_msg_send_time)) * 1000 AS receipt_delay_ms,
+	EXTRACT(EPOCH FROM (now() - latest_end_time)) * 1000 AS lag_ms
+FROM pg_stat_subscription
+WHERE relid IS NULL`
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("postgresql", "replication", New,
+		mb.WithHostParser(postgresql.ParseURL),
+	)
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	*postgresql.MetricSet
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The postgresql replication metricset is beta.")
+
+	ms, err := postgresql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes one event per standby, replication slot and logical
+// subscription. A failing query is reported without preventing the others
+// from being collected.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	ctx := context.Background()
+
+	for _, q := range []struct {
+		name  string
+		query string
+		kind  string
+	}{
+		{"pg_stat_replication", standbysQuery, "standby"},
+		{"pg_replication_slots", slotsQuery, "slot"},
+		{"pg_stat_subscription", subscriptionsQuery, "subscription"},
+	} {
+		results, err := m.QueryStats(ctx, q.query)
+		if err != nil {
+			reporter.Error(fmt.Errorf("error in QueryStats for %s: %w", q.name, err))
+			continue
+		}
+
+		for _, result := range results {
+			event, err := eventMapping(q.kind, result)
+			if err != nil {
+				reporter.Error(fmt.Errorf("error mapping %s row: %w", q.name, err))
+				continue
+			}
+			if !reporter.Event(event) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
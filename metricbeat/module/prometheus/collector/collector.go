@@ -84,6 +84,7 @@ type MetricSet struct {
 	host            string
 	eventGenStarted bool
 	metricsCount    bool
+	enableExemplars bool
 	xPack           bool
 }
 
@@ -99,6 +100,14 @@ func MetricSetBuilder(namespace string, genFactory PromEventsGeneratorFactory) f
 		if err != nil {
 			return nil, err
 		}
+		if config.EnableNativeHistograms {
+			// Native histograms are only exposed in the protobuf format
+			http, err := prometheus.GetHttp()
+			if err != nil {
+				return nil, err
+			}
+			http.SetHeader("Accept", p.ProtobufAcceptHeader)
+		}
 
 		promEventsGen, err := genFactory(base)
 		if err != nil {
@@ -119,6 +128,7 @@ func MetricSetBuilder(namespace string, genFactory PromEventsGeneratorFactory) f
 			promEventsGen:   promEventsGen,
 			eventGenStarted: false,
 			metricsCount:    config.MetricsCount,
+			enableExemplars: config.EnableExemplars,
 			xPack:           !nonXPack,
 		}
 
@@ -182,6 +192,9 @@ func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
 				}
 			}
 
+			if m.enableExemplars && len(promEvent.Exemplars) > 0 {
+				eventList[labelsHash]["exemplar"] = promEvent.Exemplars
+			}
 			// Accumulate metrics in the event
 			eventList[labelsHash].DeepUpdate(promEvent.Data)
 		}
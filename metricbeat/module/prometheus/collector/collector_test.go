@@ -20,16 +20,21 @@
 package collector
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
 	"github.com/prometheus/common/model"
 	pl "github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/elastic/elastic-agent-libs/mapstr"
 
@@ -445,6 +450,91 @@ func TestFetchEventForCountingMetrics(t *testing.T) {
 		})
 	}
 }
+func TestFetchNativeHistogramWithExemplars(t *testing.T) {
+	family := &dto.MetricFamily{
+		Name: proto.String("rpc_duration_seconds"),
+		Help: proto.String("RPC latency"),
+		Type: dto.MetricType_HISTOGRAM.Enum(),
+		Metric: []*dto.Metric{
+			{
+				Histogram: &dto.Histogram{
+					SampleCount:   proto.Uint64(3),
+					SampleSum:     proto.Float64(2.5),
+					Schema:        proto.Int32(0),
+					ZeroThreshold: proto.Float64(0.001),
+					// Buckets (0.5,1] and (1,2]
+					PositiveSpan:  []*dto.BucketSpan{{Offset: proto.Int32(0), Length: proto.Uint32(2)}},
+					PositiveDelta: []int64{1, 1},
+					Exemplars: []*dto.Exemplar{
+						{
+							Label: []*dto.LabelPair{
+								{Name: proto.String("trace_id"), Value: proto.String("4bf92f3577b34da6a3ce929d0e0e4736")},
+							},
+							Value:     proto.Float64(1.5),
+							Timestamp: timestamppb.New(time.UnixMilli(1700000000000)),
+						},
+					},
+				},
+			},
+		},
+	}
+	var buf bytes.Buffer
+	enc := expfmt.NewEncoder(&buf, expfmt.NewFormat(expfmt.TypeProtoDelim))
+	assert.NoError(t, enc.Encode(family))
+
+	var accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		w.Header().Set("Content-Type", "application/vnd.google.protobuf; proto=io.prometheus.client.MetricFamily; encoding=delimited")
+		w.WriteHeader(http.StatusOK)
+		w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":                   "prometheus",
+		"metricsets":               []string{"collector"},
+		"hosts":                    []string{server.URL},
+		"enable_native_histograms": true,
+		"enable_exemplars":         true,
+	}
+
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	for _, err := range errs {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	assert.True(t, strings.HasPrefix(accept, p.ProtobufType), "native histograms must be requested in the protobuf format")
+
+	buckets := map[string]interface{}{}
+	var exemplar mapstr.M
+	for _, event := range events {
+		le, err := event.RootFields.GetValue("prometheus.labels.le")
+		if err != nil {
+			continue
+		}
+		count, err := event.RootFields.GetValue("prometheus.metrics.rpc_duration_seconds_bucket")
+		assert.NoError(t, err)
+		buckets[le.(string)] = count
+		if e, err := event.RootFields.GetValue("prometheus.exemplar"); err == nil {
+			exemplar = e.(mapstr.M)
+		}
+	}
+	assert.Equal(t, map[string]interface{}{
+		"0.5":  uint64(0),
+		"1":    uint64(1),
+		"2":    uint64(3),
+		"+Inf": uint64(3),
+	}, buckets)
+	assert.Equal(t, mapstr.M{
+		"rpc_duration_seconds_bucket": 1.5,
+		"timestamp":                   int64(1700000000000),
+		"labels": mapstr.M{
+			"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+	}, exemplar)
+}
+
 func validateEvent(t *testing.T, event mb.Event, expectedLabels mapstr.M, expectedMetricsCount int) {
 	t.Helper()
 
@@ -18,8 +18,10 @@
 package collector
 
 type metricsetConfig struct {
-	MetricsCount   bool          `config:"metrics_count"`
-	MetricsFilters MetricFilters `config:"metrics_filters" yaml:"metrics_filters,omitempty"`
+	MetricsCount           bool          `config:"metrics_count"`
+	MetricsFilters         MetricFilters `config:"metrics_filters" yaml:"metrics_filters,omitempty"`
+	EnableExemplars        bool          `config:"enable_exemplars" yaml:"enable_exemplars,omitempty"`
+	EnableNativeHistograms bool          `config:"enable_native_histograms" yaml:"enable_native_histograms,omitempty"`
 }
 
 type MetricFilters struct {
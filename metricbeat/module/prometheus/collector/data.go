@@ -21,6 +21,8 @@ import (
 	"math"
 	"strconv"
 
+	"github.com/prometheus/prometheus/model/exemplar"
+
 	"github.com/elastic/beats/v7/metricbeat/helper/labelhash"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -30,8 +32,9 @@ import (
 
 // PromEvent stores a set of one or more metrics with the same labels
 type PromEvent struct {
-	Data   mapstr.M
-	Labels mapstr.M
+	Data      mapstr.M
+	Labels    mapstr.M
+	Exemplars mapstr.M
 }
 
 // LabelsHash returns a repeatable string that is unique for the set of labels in this event
@@ -77,7 +80,8 @@ func (p *promEventGenerator) GeneratePromEvents(mf *p.MetricFamily) []PromEvent
 							name: counter.GetValue(),
 						},
 					},
-					Labels: labels,
+					Labels:    labels,
+					Exemplars: exemplarFields(name, metric.Exemplar),
 				})
 			}
 		}
@@ -156,7 +160,8 @@ func (p *promEventGenerator) GeneratePromEvents(mf *p.MetricFamily) []PromEvent
 							name + "_bucket": bucket.GetCumulativeCount(),
 						},
 					},
-					Labels: bucketLabels,
+					Labels:    bucketLabels,
+					Exemplars: exemplarFields(name+"_bucket", bucket.Exemplar),
 				})
 			}
 		}
@@ -177,3 +182,21 @@ func (p *promEventGenerator) GeneratePromEvents(mf *p.MetricFamily) []PromEvent
 	}
 	return events
 }
+
+// exemplarFields returns the value, timestamp and labels of an exemplar, such
+// as the trace ID of the request it was recorded for.
+func exemplarFields(name string, e *exemplar.Exemplar) mapstr.M {
+	if e == nil {
+		return nil
+	}
+	fields := mapstr.M{name: e.Value}
+	if e.HasTs {
+		fields["timestamp"] = e.Ts
+	}
+	for _, label := range e.Labels {
+		if label.Name != "" && label.Value != "" {
+			_, _ = fields.Put("labels."+label.Name, label.Value)
+		}
+	}
+	return fields
+}
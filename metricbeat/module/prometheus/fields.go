@@ -32,5 +32,5 @@ func init() {
 // AssetPrometheus returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/prometheus.
 func AssetPrometheus() string {
-	return "eJzMk7mO20AMhns9xQ+lW3j3AVSkSxfkQMogEMYjSprsXCGpNfz2gU7LsYNcTabkP0N+/Dl8xDOdK2ROgbSnQQpAnXqqUH7YgmUBNCSWXVaXYoXXBQB8UqMCsWwyNWg5BRhcXoFik5OL+lQA0ifW2qbYuq5Ca7xQATB5MkIVOjPeIVUXO6nwuRTx5QFlr5rLLwXQOvKNVFPdR0QTqEIgZWeltmmIOimAnjNV8Cl2S+AO93jeDeFIjNSuWZCJ8cYbUWeFDNseTbJDoJn/UvXKq0vJjtOQl8gedjyv8J4bYjiBCzmxmqjoiekAb47kBSfnPYJR26N1LHqA9gQmURgmNGk4etryrSjz46eHTVhh0vErWd2F50A9q890PiVudvJPTBrPbp6zU0vVG5jFxz+m+aG3K7UOJmcXu+Vq+VD+JfQN7beB+Py/sb4YP0xTH7yuGzXKH9/u+K935k5XNz3tv+YvYKYE6yhp78O9src/fQVhCkmpPrFT+heeOQ+mPNumbr4stgnxC/Fvs34PAAD//zFqb0I="
+	return "eJzMlMmO2zAMhu9+ih/ubZDMA/jQW29FF/RYFIEi07Y62krSk+btC2+JZ5KgG9AOpBMp8f/4E9IWD3SskDkF0o56KQB16qlC+eEULAugJrHssroUK7wuAOCTGhWIZZOpRsMpwOB8CxTrnFzU+wKQLrHubIqNays0xgsVAJMnI1ShNcMZUnWxlQqfSxFfblB2qrn8UgCNI19LNepuEU2gCoGUnZWdTX3UMQPoMVMFn2I7B65wD/tdH/bESM1SBZkYb7wRdVbIsO1QJ9sHmvjPqk+8Oku2nPo8R9aww3qF91wTwwlcyInVREVHTBt4sycvODjvEYzaDo1j0Q20IzCJwjChTv3e06negjJdvr87JRaYtP9KdrFkWFNgN2Uf6HhIXK/SN0wa9mqek1Oz6gXM7ONv0zzr7Ul2F0zOLrbz0fKu/EPoC1r6TiF7w//MvEVQbqP8n3Eu8rfm+q0nPr60qT4a34/vo/e6/D1Dax/fnm5sn/0uV7q66Gn9iH8CMxaYPRRa+3BN9vJPWECYQlLaHdgp/Q3PVAdjnQXr7MtsmxA/Ev8y648BAHtLw00="
 }
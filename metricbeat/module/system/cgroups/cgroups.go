@@ -0,0 +1,205 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package cgroups
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+// v1Subsystems are the cgroup v1 subsystems the metrics are read from.
+var v1Subsystems = []string{"cpu", "cpuacct", "memory", "blkio", "pids"}
+
+// Config stores the config of the metricset.
+type Config struct {
+	ContainersOnly bool `config:"cgroups.containers_only"`
+}
+
+func init() {
+	mb.Registry.MustAddMetricSet("system", "cgroups", New,
+		mb.WithHostParser(parse.EmptyHostParser),
+	)
+}
+
+// MetricSet reports the metrics of each cgroup of the host.
+type MetricSet struct {
+	mb.BaseMetricSet
+	mod    resolve.Resolver
+	config Config
+}
+
+// New creates a new instance of the cgroups metricset.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The system cgroups metricset is beta.")
+
+	sys, ok := base.Module().(resolve.Resolver)
+	if !ok {
+		return nil, fmt.Errorf("unexpected module type: %T", base.Module())
+	}
+
+	var config Config
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		mod:           sys,
+		config:        config,
+	}, nil
+}
+
+// Fetch reports an event for each cgroup, except the root ones, whose metrics
+// are the ones of the whole host.
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	subsystems, err := cgroup.SupportedSubsystems(m.mod)
+	if err != nil {
+		return fmt.Errorf("error reading supported cgroup subsystems: %w", err)
+	}
+	mountpoints, err := cgroup.SubsystemMountpoints(m.mod, subsystems)
+	if err != nil {
+		return fmt.Errorf("error reading cgroup mountpoints: %w", err)
+	}
+
+	var cgroups []*cgroupStats
+	v1Mounts := make(map[string]string)
+	for _, name := range v1Subsystems {
+		if mount, found := mountpoints.V1Mounts[name]; found {
+			v1Mounts[name] = mount
+		}
+	}
+	switch {
+	case len(v1Mounts) > 0:
+		// The unified hierarchy of hybrid hosts has no controllers, their
+		// metrics are only in the v1 hierarchies.
+		cgroups, err = m.readV1(v1Mounts)
+	case mountpoints.V2Loc != "":
+		cgroups, err = m.readV2(mountpoints.V2Loc)
+	default:
+		return errors.New("no cgroup hierarchy found")
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, cg := range cgroups {
+		c, isContainer := containerFromPath(cg.path)
+		if m.config.ContainersOnly && !isContainer {
+			continue
+		}
+
+		event := mb.Event{MetricSetFields: cg.fields()}
+		if isContainer {
+			fields := mapstr.M{"id": c.ID}
+			if c.Name != "" {
+				fields["name"] = c.Name
+			}
+			if c.Runtime != "" {
+				fields["runtime"] = c.Runtime
+			}
+			event.RootFields = mapstr.M{"container": fields}
+		}
+		if !r.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// readV1 reads the metrics of the cgroups of each v1 hierarchy, grouped by
+// their path.
+func (m *MetricSet) readV1(mounts map[string]string) ([]*cgroupStats, error) {
+	byPath := map[string]*cgroupStats{}
+	for _, name := range v1Subsystems {
+		mount, found := mounts[name]
+		if !found {
+			continue
+		}
+		err := walkCgroups(mount, func(path, dir string) {
+			cg, found := byPath[path]
+			if !found {
+				cg = &cgroupStats{path: path, version: cgroup.CgroupsV1}
+				byPath[path] = cg
+			}
+			if err := cg.readV1(name, dir); err != nil {
+				m.Logger().Debugf("error reading %s metrics of cgroup %s: %v", name, path, err)
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s cgroups: %w", name, err)
+		}
+	}
+
+	cgroups := make([]*cgroupStats, 0, len(byPath))
+	for _, cg := range byPath {
+		cgroups = append(cgroups, cg)
+	}
+	sort.Slice(cgroups, func(i, j int) bool { return cgroups[i].path < cgroups[j].path })
+	return cgroups, nil
+}
+
+// readV2 reads the metrics of the cgroups of the unified hierarchy.
+func (m *MetricSet) readV2(mount string) ([]*cgroupStats, error) {
+	var cgroups []*cgroupStats
+	err := walkCgroups(mount, func(path, dir string) {
+		cg := &cgroupStats{path: path, version: cgroup.CgroupsV2}
+		if err := cg.readV2(dir); err != nil {
+			m.Logger().Debugf("error reading metrics of cgroup %s: %v", path, err)
+		}
+		cgroups = append(cgroups, cg)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading cgroups: %w", err)
+	}
+	return cgroups, nil
+}
+
+// walkCgroups calls fn for each cgroup below the root of the hierarchy, with its
+// path relative to the root and its directory. Cgroups removed while walking the
+// hierarchy are ignored.
+func walkCgroups(root string, fn func(path, dir string)) error {
+	return filepath.WalkDir(root, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && dir != root {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() || dir == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return err
+		}
+		fn("/"+filepath.ToSlash(rel), dir)
+		return nil
+	})
+}
@@ -0,0 +1,270 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package cgroups
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const dockerID = "5f3b2bd0c3f4e3b2c1f2d6a6e2a37a9b9e3c8d2ed0b2f7f5c8e8a1f7e4f3a2b1"
+
+// writeFiles writes the files in the root directory, creating their parent
+// directories.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+// cgroupV2HostFS returns a host filesystem with a unified cgroup hierarchy
+// with a system service and a docker container.
+func cgroupV2HostFS(t *testing.T) string {
+	hostfs := t.TempDir()
+	cgroupfs := filepath.Join(hostfs, "sys/fs/cgroup")
+
+	cgroup := func(controllers string, usage uint64) map[string]string {
+		files := map[string]string{
+			"cgroup.controllers": controllers,
+			"cpu.stat":           "usage_usec 3000\nuser_usec 2000\nsystem_usec 1000\n",
+		}
+		if controllers != "" {
+			files["cpu.stat"] += "nr_periods 10\nnr_throttled 2\nthrottled_usec 500\n"
+			files["memory.current"] = "1048576\n"
+			files["memory.high"] = "max\n"
+			files["memory.low"] = "0\n"
+			files["memory.max"] = "2097152\n"
+			files["memory.events"] = "low 0\nhigh 0\nmax 0\noom 0\noom_kill 0\n"
+			files["memory.stat"] = "anon 524288\nfile 262144\n"
+			files["io.stat"] = "8:0 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0\n" +
+				"8:16 rbytes=4096 wbytes=0 rios=1 wios=0 dbytes=0 dios=0\n"
+			files["pids.current"] = "3\n"
+			files["pids.max"] = "max\n"
+		}
+		return files
+	}
+
+	writeFiles(t, hostfs, map[string]string{
+		"proc/cgroups": "#subsys_name\thierarchy\tnum_cgroups\tenabled\n" +
+			"cpu\t0\t10\t1\nmemory\t0\t10\t1\nio\t0\t10\t1\npids\t0\t10\t1\n",
+		"proc/self/mountinfo": "30 23 0:26 / " + cgroupfs + " rw,nosuid - cgroup2 cgroup2 rw\n",
+	})
+	for dir, files := range map[string]map[string]string{
+		"system.slice":                               cgroup("", 0),
+		"system.slice/sshd.service":                  cgroup("", 0),
+		"system.slice/docker-" + dockerID + ".scope": cgroup("cpu memory io pids", 0),
+	} {
+		prefixed := make(map[string]string, len(files))
+		for name, content := range files {
+			prefixed[filepath.Join("sys/fs/cgroup", dir, name)] = content
+		}
+		writeFiles(t, hostfs, prefixed)
+	}
+	return hostfs
+}
+
+// cgroupV1HostFS returns a host filesystem with cgroup v1 hierarchies with a
+// podman container.
+func cgroupV1HostFS(t *testing.T) string {
+	hostfs := t.TempDir()
+	cgroupfs := filepath.Join(hostfs, "sys/fs/cgroup")
+	container := "machine.slice/libpod-" + dockerID + ".scope"
+
+	writeFiles(t, hostfs, map[string]string{
+		"proc/cgroups": "#subsys_name\thierarchy\tnum_cgroups\tenabled\n" +
+			"cpu\t2\t10\t1\ncpuacct\t2\t10\t1\nmemory\t3\t10\t1\npids\t4\t10\t1\n",
+		"proc/self/mountinfo": "31 23 0:27 / " + cgroupfs + "/cpu,cpuacct rw,nosuid - cgroup cgroup rw,cpu,cpuacct\n" +
+			"32 23 0:28 / " + cgroupfs + "/memory rw,nosuid - cgroup cgroup rw,memory\n" +
+			"33 23 0:29 / " + cgroupfs + "/pids rw,nosuid - cgroup cgroup rw,pids\n",
+
+		"sys/fs/cgroup/cpu,cpuacct/" + container + "/cpu.stat":             "nr_periods 10\nnr_throttled 2\nthrottled_time 500000\n",
+		"sys/fs/cgroup/cpu,cpuacct/" + container + "/cpuacct.stat":         "user 200\nsystem 100\n",
+		"sys/fs/cgroup/cpu,cpuacct/" + container + "/cpuacct.usage":        "3000000000\n",
+		"sys/fs/cgroup/cpu,cpuacct/" + container + "/cpuacct.usage_percpu": "2000000000 1000000000\n",
+		"sys/fs/cgroup/memory/" + container + "/memory.usage_in_bytes":     "1048576\n",
+		"sys/fs/cgroup/memory/" + container + "/memory.limit_in_bytes":     "9223372036854771712\n",
+		"sys/fs/cgroup/memory/" + container + "/memory.stat":               "cache 262144\nrss 524288\n",
+		"sys/fs/cgroup/pids/" + container + "/pids.current":                "3\n",
+		"sys/fs/cgroup/pids/" + container + "/pids.max":                    "100\n",
+	})
+	return hostfs
+}
+
+func TestFetchV2(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(cgroupV2HostFS(t)))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 3)
+
+	byPath := map[string]mapstr.M{}
+	for _, event := range events {
+		path, err := event.MetricSetFields.GetValue("path")
+		require.NoError(t, err)
+		byPath[path.(string)] = event.MetricSetFields
+	}
+
+	assert.Equal(t, mapstr.M{
+		"id":      "sshd.service",
+		"path":    "/system.slice/sshd.service",
+		"version": 2,
+		"cpu": mapstr.M{
+			"usage":  mapstr.M{"ns": uint64(3000000)},
+			"user":   mapstr.M{"ns": uint64(2000000)},
+			"system": mapstr.M{"ns": uint64(1000000)},
+		},
+	}, byPath["/system.slice/sshd.service"])
+
+	containerPath := "/system.slice/docker-" + dockerID + ".scope"
+	require.Contains(t, byPath, containerPath)
+	fields := byPath[containerPath]
+	assert.Equal(t, mapstr.M{
+		"usage":     mapstr.M{"ns": uint64(3000000)},
+		"user":      mapstr.M{"ns": uint64(2000000)},
+		"system":    mapstr.M{"ns": uint64(1000000)},
+		"periods":   uint64(10),
+		"throttled": mapstr.M{"periods": uint64(2), "ns": uint64(500000)},
+	}, fields["cpu"])
+	assert.Equal(t, mapstr.M{
+		"usage": mapstr.M{"bytes": uint64(1048576)},
+		"rss":   mapstr.M{"bytes": uint64(524288)},
+		"cache": mapstr.M{"bytes": uint64(262144)},
+		"limit": mapstr.M{"bytes": uint64(2097152)},
+	}, fields["memory"])
+	assert.Equal(t, mapstr.M{
+		"read":  mapstr.M{"bytes": uint64(8192), "ops": uint64(2)},
+		"write": mapstr.M{"bytes": uint64(8192), "ops": uint64(2)},
+	}, fields["io"])
+	assert.Equal(t, mapstr.M{"current": uint64(3)}, fields["pids"])
+}
+
+func TestFetchV1(t *testing.T) {
+	config := getConfig(cgroupV1HostFS(t))
+	config["cgroups.containers_only"] = true
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+
+	fields := events[0].MetricSetFields
+	assert.Equal(t, "/machine.slice/libpod-"+dockerID+".scope", fields["path"])
+	assert.Equal(t, 1, fields["version"])
+	assert.Equal(t, mapstr.M{
+		"usage":     mapstr.M{"ns": uint64(3000000000)},
+		"user":      mapstr.M{"ns": uint64(2000000000)},
+		"system":    mapstr.M{"ns": uint64(1000000000)},
+		"periods":   uint64(10),
+		"throttled": mapstr.M{"periods": uint64(2), "ns": uint64(500000)},
+	}, fields["cpu"])
+	assert.Equal(t, mapstr.M{
+		"usage": mapstr.M{"bytes": uint64(1048576)},
+		"rss":   mapstr.M{"bytes": uint64(524288)},
+		"cache": mapstr.M{"bytes": uint64(262144)},
+	}, fields["memory"])
+	assert.Equal(t, mapstr.M{"current": uint64(3), "max": uint64(100)}, fields["pids"])
+
+	assert.Equal(t, mapstr.M{
+		"container": mapstr.M{"id": dockerID, "runtime": "podman"},
+	}, events[0].RootFields)
+}
+
+func TestContainerFromPath(t *testing.T) {
+	tests := map[string]struct {
+		path      string
+		container container
+		found     bool
+	}{
+		"docker systemd driver": {
+			path:      "/system.slice/docker-" + dockerID + ".scope",
+			container: container{ID: dockerID, Runtime: "docker"},
+			found:     true,
+		},
+		"docker cgroupfs driver": {
+			path:      "/docker/" + dockerID,
+			container: container{ID: dockerID, Runtime: "docker"},
+			found:     true,
+		},
+		"podman": {
+			path:      "/machine.slice/libpod-" + dockerID + ".scope/container",
+			container: container{ID: dockerID, Runtime: "podman"},
+			found:     true,
+		},
+		"containerd": {
+			path:      "/kubepods.slice/kubepods-pod1.slice/cri-containerd-" + dockerID + ".scope",
+			container: container{ID: dockerID, Runtime: "containerd"},
+			found:     true,
+		},
+		"cri-o": {
+			path:      "/kubepods.slice/crio-" + dockerID + ".scope",
+			container: container{ID: dockerID, Runtime: "cri-o"},
+			found:     true,
+		},
+		"systemd-nspawn machine": {
+			path:      `/machine.slice/machine-test\x2dvm.scope/payload`,
+			container: container{ID: "test-vm", Name: "test-vm", Runtime: "systemd-nspawn"},
+			found:     true,
+		},
+		"systemd-nspawn service": {
+			path:      "/machine.slice/systemd-nspawn@debian.service",
+			container: container{ID: "debian", Name: "debian", Runtime: "systemd-nspawn"},
+			found:     true,
+		},
+		"service": {
+			path: "/system.slice/sshd.service",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, found := containerFromPath(test.path)
+			assert.Equal(t, test.found, found)
+			assert.Equal(t, test.container, c)
+		})
+	}
+}
+
+func TestData(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(cgroupV2HostFS(t)))
+	err := mbtest.WriteEventsReporterV2ErrorCond(f, t, ".", func(e mapstr.M) bool {
+		_, err := e.GetValue("container.id")
+		return err == nil
+	})
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func getConfig(hostfs string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "system",
+		"metricsets": []string{"cgroups"},
+		"hostfs":     hostfs,
+	}
+}
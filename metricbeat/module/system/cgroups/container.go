@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package cgroups
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// container is the container a cgroup belongs to.
+type container struct {
+	ID      string
+	Name    string
+	Runtime string
+}
+
+var (
+	// containerIDRegexp matches the cgroups of the containers created by the
+	// systemd and cgroupfs drivers of container runtimes, like
+	// docker-<id>.scope or docker/<id>.
+	containerIDRegexp = regexp.MustCompile(`^(?:([a-z-]+)-)?([0-9a-f]{64})(?:\.scope)?$`)
+
+	// nspawnRegexp matches the cgroups of the machines started by systemd-nspawn,
+	// either as machine-<name>.scope or systemd-nspawn@<name>.service.
+	nspawnRegexp = regexp.MustCompile(`^(?:machine-(.+)\.scope|systemd-nspawn@(.+)\.service)$`)
+
+	// runtimes maps the prefixes and parent directories of the container cgroups
+	// to their runtime.
+	runtimes = map[string]string{
+		"docker":         "docker",
+		"libpod":         "podman",
+		"cri-containerd": "containerd",
+		"crio":           "cri-o",
+	}
+)
+
+// containerFromPath returns the container of the cgroup with the given path,
+// if any. Cgroups nested in the cgroup of a container belong to the container.
+func containerFromPath(path string) (container, bool) {
+	var (
+		c     container
+		found bool
+	)
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range parts {
+		if m := containerIDRegexp.FindStringSubmatch(part); m != nil {
+			prefix := m[1]
+			if prefix == "" && i > 0 {
+				prefix = parts[i-1]
+			}
+			c = container{ID: m[2], Runtime: runtimes[prefix]}
+			found = true
+			continue
+		}
+		if m := nspawnRegexp.FindStringSubmatch(part); m != nil {
+			name := unescapeUnitName(m[1] + m[2])
+			c = container{ID: name, Name: name, Runtime: "systemd-nspawn"}
+			found = true
+		}
+	}
+	return c, found
+}
+
+// unescapeUnitName reverts the escaping of systemd unit names, where characters
+// like dashes are written as \x2d.
+func unescapeUnitName(name string) string {
+	var sb strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\\' && i+3 < len(name) && name[i+1] == 'x' {
+			if b, err := strconv.ParseUint(name[i+2:i+4], 16, 8); err == nil {
+				sb.WriteByte(byte(b))
+				i += 3
+				continue
+			}
+		}
+		sb.WriteByte(name[i])
+	}
+	return sb.String()
+}
@@ -0,0 +1,240 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package cgroups
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup/cgcommon"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup/cgv1"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup/cgv2"
+)
+
+// unlimitedMemory is the lowest limit reported by cgroups v1 when the memory
+// of a cgroup is not limited, rounded down to the page size.
+const unlimitedMemory = 1 << 62
+
+// cgroupStats are the metrics of a cgroup, collected from its subsystems.
+type cgroupStats struct {
+	path    string
+	version cgroup.CgroupsVersion
+
+	cpu    mapstr.M
+	memory mapstr.M
+	io     mapstr.M
+	pids   mapstr.M
+}
+
+// readV1 reads the metrics of a v1 subsystem from the directory of the cgroup.
+func (cg *cgroupStats) readV1(subsystem, dir string) error {
+	switch subsystem {
+	case "cpu":
+		var cpu cgv1.CPUSubsystem
+		if err := cpu.Get(dir); err != nil {
+			return err
+		}
+		// cgroups v1 report the throttled time in nanoseconds, it is stored
+		// as is in Throttled.Us.
+		cg.cpuFields().DeepUpdate(mapstr.M{
+			"periods": cpu.Stats.Periods,
+			"throttled": mapstr.M{
+				"periods": cpu.Stats.Throttled.Periods,
+				"ns":      cpu.Stats.Throttled.Us,
+			},
+		})
+	case "cpuacct":
+		var cpuacct cgv1.CPUAccountingSubsystem
+		if err := cpuacct.Get(dir); err != nil {
+			return err
+		}
+		cg.cpuFields().DeepUpdate(mapstr.M{
+			"usage":  mapstr.M{"ns": cpuacct.Total.NS},
+			"user":   mapstr.M{"ns": cpuacct.Stats.User.NS},
+			"system": mapstr.M{"ns": cpuacct.Stats.System.NS},
+		})
+	case "memory":
+		var mem cgv1.MemorySubsystem
+		if err := mem.Get(dir); err != nil {
+			return err
+		}
+		cg.memory = mapstr.M{
+			"usage": mapstr.M{"bytes": mem.Mem.Usage.Bytes},
+			"rss":   mapstr.M{"bytes": mem.Stats.RSS.Bytes},
+			"cache": mapstr.M{"bytes": mem.Stats.Cache.Bytes},
+		}
+		if mem.Mem.Limit.Bytes < unlimitedMemory {
+			cg.memory["limit"] = mapstr.M{"bytes": mem.Mem.Limit.Bytes}
+		}
+	case "blkio":
+		var blkio cgv1.BlockIOSubsystem
+		if err := blkio.Get(dir); err != nil {
+			return err
+		}
+		cg.io = ioFields(blkio.Reads.Bytes, blkio.Reads.Ios, blkio.Writes.Bytes, blkio.Writes.Ios)
+	case "pids":
+		return cg.readPids(dir)
+	}
+	return nil
+}
+
+// readV2 reads the metrics of the controllers enabled in the cgroup from its
+// directory. CPU usage is always available.
+func (cg *cgroupStats) readV2(dir string) error {
+	if err := cg.readCPUStatV2(dir); err != nil {
+		return err
+	}
+
+	controllers, err := os.ReadFile(filepath.Join(dir, "cgroup.controllers"))
+	if err != nil {
+		return err
+	}
+	enabled := strings.Fields(string(controllers))
+
+	if slices.Contains(enabled, "memory") {
+		var mem cgv2.MemorySubsystem
+		if err := mem.Get(dir); err != nil {
+			return err
+		}
+		cg.memory = mapstr.M{
+			"usage": mapstr.M{"bytes": mem.Mem.Usage.Bytes},
+			"rss":   mapstr.M{"bytes": mem.Stats.Anon.Bytes},
+			"cache": mapstr.M{"bytes": mem.Stats.File.Bytes},
+		}
+		if mem.Mem.Max.Bytes.Exists() {
+			cg.memory["limit"] = mapstr.M{"bytes": mem.Mem.Max.Bytes.ValueOr(0)}
+		}
+	}
+
+	if slices.Contains(enabled, "io") {
+		var io cgv2.IOSubsystem
+		if err := io.Get(dir, false); err != nil {
+			return err
+		}
+		var readBytes, readOps, writeBytes, writeOps uint64
+		for _, stat := range io.Stats {
+			readBytes += stat.Read.Bytes
+			readOps += stat.Read.IOs
+			writeBytes += stat.Write.Bytes
+			writeOps += stat.Write.IOs
+		}
+		cg.io = ioFields(readBytes, readOps, writeBytes, writeOps)
+	}
+
+	if slices.Contains(enabled, "pids") {
+		return cg.readPids(dir)
+	}
+	return nil
+}
+
+// readCPUStatV2 reads the CPU usage of a v2 cgroup. cpu.stat is read here
+// because cgv2.CPUSubsystem doesn't read it on hosts without pressure stall
+// information.
+func (cg *cgroupStats) readCPUStatV2(dir string) error {
+	stat, err := os.ReadFile(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return err
+	}
+
+	values := map[string]uint64{}
+	for _, line := range strings.Split(strings.TrimSpace(string(stat)), "\n") {
+		key, value, err := cgcommon.ParseCgroupParamKeyValue(line)
+		if err != nil {
+			return err
+		}
+		values[key] = value
+	}
+
+	cg.cpu = mapstr.M{
+		"usage":  mapstr.M{"ns": values["usage_usec"] * 1000},
+		"user":   mapstr.M{"ns": values["user_usec"] * 1000},
+		"system": mapstr.M{"ns": values["system_usec"] * 1000},
+	}
+	// Throttling is only reported when the cpu controller is enabled.
+	if periods, found := values["nr_periods"]; found {
+		cg.cpu["periods"] = periods
+		cg.cpu["throttled"] = mapstr.M{
+			"periods": values["nr_throttled"],
+			"ns":      values["throttled_usec"] * 1000,
+		}
+	}
+	return nil
+}
+
+// readPids reads the number of processes of the cgroup and their limit, which is
+// the same in cgroups v1 and v2.
+func (cg *cgroupStats) readPids(dir string) error {
+	current, err := cgcommon.ParseUintFromFile(dir, "pids.current")
+	if err != nil {
+		return err
+	}
+	cg.pids = mapstr.M{"current": current}
+
+	limit, err := os.ReadFile(filepath.Join(dir, "pids.max"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if value := bytes.TrimSpace(limit); string(value) != "max" {
+		limitValue, err := cgcommon.ParseUint(value)
+		if err != nil {
+			return err
+		}
+		cg.pids["max"] = limitValue
+	}
+	return nil
+}
+
+func (cg *cgroupStats) cpuFields() mapstr.M {
+	if cg.cpu == nil {
+		cg.cpu = mapstr.M{}
+	}
+	return cg.cpu
+}
+
+func ioFields(readBytes, readOps, writeBytes, writeOps uint64) mapstr.M {
+	return mapstr.M{
+		"read":  mapstr.M{"bytes": readBytes, "ops": readOps},
+		"write": mapstr.M{"bytes": writeBytes, "ops": writeOps},
+	}
+}
+
+// fields returns the fields of the event of the cgroup.
+func (cg *cgroupStats) fields() mapstr.M {
+	fields := mapstr.M{
+		"id":      path.Base(cg.path),
+		"path":    cg.path,
+		"version": int(cg.version),
+	}
+	for name, value := range map[string]mapstr.M{"cpu": cg.cpu, "memory": cg.memory, "io": cg.io, "pids": cg.pids} {
+		if value != nil {
+			fields[name] = value
+		}
+	}
+	return fields
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetSystem returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/system.
 func AssetSystem() string {
-	return "eJzsfXtvIzmS5//9KYg6DNq1J8uP7umd9R8HVJe374yrGhvlqtkFFgsVlRmSOGaS2SRTsvrTL/jIN/MlpWS5YWPQ021LwV8Eg8FgRDB4jp5ge4PkViqIfkBIEUXhBr17NL949wNCIchAkFgRzm7Q//kBIYTsH5FUWCUSRaAECeQEUfIE6OPDN4RZiCKIuNiiROIlTJBaYYWwABRwSiFQEKKF4BFSK0A8BoEVYUuHYvoDQnLFhZoFnC3I8gYpkcAPCAmggCXcoCX+AaEFARrKGwPoHDEcwQ2KBQ9ASvM7hNQ21h8WPIndbzy86J8H+7WUk6n7Q3GE4iiab8h+m47zBNsNF2Hh9w2j6Z+vK0jBWnJT9BsXCJ5xFBv5i4QxwpbvprXRgziZxoGqjS8DTCGcLSjHxT8uuIiwukExiACYGgDPfgEvAfGFmVZFIkAyBqbQfGumLmOBsADMbyiWCsEamJpWKBKJ1pgmgIhETIOi5A8IU0osieYg0pECLkAaNSIKCcyWIEvUjO5cIsXRlV9AUmGhZhpwTU5hefI6pGB43qyAlfjdYDNtQkFYH99q/gvMkVtyRaA8CJKYQIgIQxHW/7CfOfvy4fP7aWntZCYADVk63+3XvqOAM4UJk4jyAFNHre+K0vNdE1Zx9A5ZOBTnmk4BilYlh0DLGGGtqEsKZjwtMYyihCpivlewPulP2eBks1VhosgICUu/TlmhnC0rf2jhRv9o6B81KrswclSlT/4v9JBpgPQCUlxhWtFF1KWPqFUne6D/qkdFOFBkDR6zUZpuL+xEgjg+6i6rR5gBhmSMA2iYkhIHigRPchyN0OBwxBOm9gTm1PwUhfsEggEdwsWIAu6U8AB0jARwehLmDFG+OY8F4YKobbpJgOzDzdEkvStKEtITlLlB1QP48RS5ByC+wUSdoCwZ0sDQGWcoJPLpfT8+jmkjhuETv5+ekCWINQn0aUy73yvMQqr/Y4VFuNEHOMIUCJHEqnM9it+PJ/rRUEu+UK9pXjTe3Th86bnZAbmCl/Ble5glwtacJkxhsbUmwDm6ayJUgqn5xmZFqD0jr7axFonkojaYOVgW5MXVCkS6BXIxrX3hwxoTiucUEGd0qzfPb4w89xLkMe3iKxNQxEOgM3v08kqoHuzpISRzHNWU00MduteQsEWoAaJPhCXPvbDhUmhjPGQ4gh1xrf7wAvItyB5wzJE8SITQGhRQHjztBkvTmTUcy3eWVbzaShJgalHe3WpoLqoQkeVKuX/CMwSJAhtkiI1yC8ChnKAVsABQpL+gVpjVBuEM0qDGVJOdkvA7CjBDcoWFXSQSR5XPOFa/7yaolKfRhWXEkknMCqsVXiGQ90MVxZCgUBAntbiUBiQVLu41u8R5TIByRNvpC3/GAqQ7EBmjyKWa2g9zdp5HUGv08s1Kog2hFK3wGhBGEX4mURK5KCxfoO9Xl5d/Qf9ih/tuaNeIFSK1RbqYakXeIoWftDbmsV2mOMJBYHYCu92v60Q9WDSUnYNcryFahO5ZPdgoJzWyW56YhW4mrSjyLIWyFIAVCGM4rNyKuYMJIgv0U42si6gLQFihXy7/oqFNtF5Z5crsSJxMU2l+t9ozB3T1t8bJ+XNFlf5ccZvXGxH5swQgXtFB8u2o7OHw7cA5znnqhbJQPQRpku8SWbbNjnoXUjCKc3f/H9oKNTklf889o17+ifakTlIEQzNHJ8vI0I3+NBnZa7c/TZb6b/knin+Hff80ORl9839VbO7qAZwmk6/VDTg1afbxAiZpIET6StbM4drDe8Vj+FoLuL+WYpFTLrN4HYUJJ5jfP+m8+EtnJ3ffEV8a+a6b3Fs6sCgTraeE/1AVxZD0gyZRyD/o/0R391lBas9K+PRneI5iYIowqz2XIb4aPt2GPZM97FA2EASPn1tNIfwo3QhZjtVUnkd4ixhXaG5Ko9cktNs4pjQXeo2mi9F3MCQAh1OT8Bhx8RhPqeBhmLQhCrieIa0yMgm0hi8SSrcd+DaCKDg4QDPKjgiNBOdb1T+jlrqCvi/tAN6QMTDKsNG9S03aFBepDoUqfqCEQHHhKLmkL3GaxhCWMom0ZMynkCR/GD/0r1fXvWbw5QWkcShg48goJdZTTDWq3WIzalW5AtIqtB0EExGqzwQBZ6HMa9y1WTErttfEvhhEu2Y7ncVDA/RjDLneB+8u7mV5E28CyeMxnZcqRo1DOy6x4EsBUtYdBmBK8Hi7j8eQ+ybu9kyd5nAvAKdEZ3OiRvXvMrSasBZSHW6huuTFz/s5XodzoqWJ7RUVbm9NcU4zu/zz5b/9UpvlBaFQuiiFdnINczK1ApX8T2PUqWRMH2njMF6gOboX5K24tusJiwVZEwpLCG0AgjA7zNQLPYQ1CWDkQreyp1q+c/n9IoT1hf7r1XcvIj3uAaBoGlUo8Kx+/j5FdwxJHgEKsARzg+0/CAv5RqL7R3teMuUzaZnG94RlQv+OsETY7NNWu/XezOzsEs7sJUyltwG+gRCdwfMUwbMCwTA1sOR7/7SYU90s5qTBndxZFoawtvmGdm1u/FNiVstYRq1i8wnjIci0/MquyYk+lgarTORYe7xzwqxQ+cICmqAFpyEIOUFyG1HCnuTEHNKtTjcoPDfI/NzsLFVHtFpMVrAyRu5+RAsBL2o42g2ERjcbVQFKjqomn+oAyaXmOZpXAtxH9dELcmuXViLhuIcsPeBAeC/vJFRAt8X0MzWUep/eyx1wKmUpFTyC4ip1USO8XApY4ixspP14s4IrhaD5V/eudN01cPD3fCnl60aiBU9Y2LJ89ljSR7Tg6O/cGFO3Ibfxox3KAUvLrz516YI0SpCLFoU8kNWIp29qUbuJb5V3F/oazvqqQ9lea0xsZaFVAeoV+WIAjTnoAOiz+cdDaG3rmQEa00Qamb6vH08px+E+NurjwzdDA+E1CLyEPa3Ku6t3Qy29/hNhy9kCB4qLG3R1eTnM2n8qwDfOdNavJCIsUeBfw+/+ekpI/+qwNhicd1cnhfbKA9eP29QEvJROeHQBhSRLEvTL9dfZeamp8CvMGBy9mHY1aNWePJkP+Tk67C2emnW2XXn28iEtiVo4yfX7GSGUdLSzjdnXXBOl9hk86pnmm95ie8E64rHZHgLzVLvxqNycZweuYvOtkION/BAW0CTMPhxwZtMu823qTgY4WNkuXLWh58liAUKiMwnZgdiJBgcqwXRacUP8Rws9QO+GSXur1EczXMowZ7WT/MkfTHtpn52A3Q4VdSQfDLW8mx+E5hikp7frqNHq0nuXLTqC2+wYKsizsFDuFBLgLLY09jokWtPNbd05qA24+3Ju3bHQ/FcesXIz5L1KqX+qn0QhxMDC7Ix6/2gDn+ZmcAgKEyonKDa2GgUrCJ6yaEFhoX1vUAn08gc9J26/XbpTiEgUYBok1IQ05lhPS0EW5eRyOdz9GaI8Y2aCIRex4MFFBBFhCz6py0L/cFEc0HytCM6coXLLl1k6sihTzwLoKYL6AVH/3DN0//ifiBhGMZJJVLXSqQ4R5tqlpSp0nwUXJu778Ht9YbtZ5JlauK/3VYsG84b6mDjUaeZQz6NsPVlXW6VdZUUbXLVszTYvFrAgzzfo3X8Ztv676gOWg0pa8wyV3LfS7hSRigTS5hAhTFOIGkep92k5+jQ0PPPCwYWcmb6q9FJm3Xhnw/C+lEXMs/zDxXt6KzVpFHxWhwhqw8XTXqcbR6NwvHG/KVZUllp8pn83VbCLSgTveMWUoFYDD7zmIFkF36eykifqqAmeanmYLDX99UIk7EURCgiArIs9exsFGePgCUatl8nBONo9BXY4JCJD0lMwhE1BCC4OIxZL2tV9W0SELXvM1bEwSWBhNyLCpqHgcdz/ZDsIEWEBj0yZhJs70xdkA/q8YIftIbFDAuSJWvJ2gJVu4Jhu8La+m15q1+kWiw1hxkv+9fEWzSHAiQTnEGsHXEDMhcpjgM019JX9aCaTKMI9om7ZZjEHhfvtV5/djmQLzNhSe4dLyueYZqbdePtEbXvuPySe/ot3uvj8n1BzCjom7O7BpnVBNDSNDsYc7evHjuGScMzhvt12DzejRMHIY34iCtoHJkE06ix+/OzhNCu4LD2FgHbyuhyNgtcV5y8l4BArPCn2WJ8UH36odH5H43pdmBJctRgxVquM76nnqxFZCmwZdS9K1Eesvu2A9i/BGvjOQxFN3NCLrZv9+jf7cB/vMSAW2p3Zcdzl7uPWv9pnRJZEM9eNzzuw9ryXtQtKHbOdV7w40mnhWH0xFqLgUUgJG1nnFgmlKOBRhFl4rsnbc5ri9tmKIqiJSwWYbcoTtsRimUQmHiohxgK7vdZbFUGWjAuY4Tlfww26vvz5b34LLEHssLRtG5Xd1nWw2VXB9G5N2HIWEmFupGx3GB3Yur/Zt7+c7akBwNZEcKZnDq2xIHhOQTZrge0sqE267woPLtyaRL8JgF8fbyc2MmuN/v0j+k+/CSs3cUTjxeU+Pnw7lzEEZEGCYkAuzi+ADo22NV7DR11xoZ6hE8+d2NIzOm3386tgbTMF40QfCG3WnFGDtcFM+9CP0R5nL5pk3d38Cb1w5GrQk0ZJHJrd+04VDi6SRIRi4WK/3mH/okfJBFkcICQypnibn1wUj1OTnd5Lrl9B9Qu3oaXGq5Kw59GonPKYj0dlTOePSJWaj1ZF3NIDAx3ZLvh7Y1QBW504JF6b/myd3hZ5+t7mKqML6074EHT93+xCbUU4qH/i/LP57tD9qGu/69qvPJXF6BjJoUwD0n4NnpfHVlgWc5g2gVtJrn/kUUQU+rjCYgnoTHkKWjLK2Lor6ekSM7wEoUcxNpMok801CQB3pEqRvM9aE7sosC0mI7JbU4WUL5bQ0kL+ApKEemk9gkKP5A+YVqzF4BffumYkbbjunF4kQRktmzRc36hK6/T2oN0exfPxZ1qvv5AmmLFDHzOJbCoGLrUvQf1t2a1pVHJxn0YxJwibRbPBIkxLJmwtxH893N3+9wXh01JbFVexwVmx8YOTRodBCzALgGotMXfTZzstvkr2vp4GKi7+fMiJLQ0xJyTGlfYL5pC2KWjResDhgXAuQJmKtaw/jFTcFFlrt9HT26bUVuBAmNK2DWm5Qz9ERkjBCtcb+OyLx7TaMALS/3Z2/d44fqljLrfa6NAWo2VldRBopuxXn7UdCLPx6PFKOSSvqOrNDIbDCdJNQlM8T8tU+kpkVASGZAeErCrDH6fZoXTxN0Ih+wwX7gSbxqXtoULvb2aTsPkaIguhjoWn35Q1bVrFXBRkbJeOx1B/DWR3TVyUZSAN/d6eOiURUVPJF82b+n7nCE3ajpKWSXVAz858XpKlWFaBdoCZtuXBSh8Gw+o5FCuE2dacGrpEscK1UNxYotCkDyWKAm0tCtP5bA5I4LSdpeC84Qp44Ft4Oy/JNC+qF5DBI/PNzY5kzIW5oGlOA1g+2crJCMrP4KY/7lvZ9VgBeUa4dgTUpwVLSK5IrN0/XCPIODvX4nCUjQAllAbghedrCh7PUGenlrdAHXH/HgJGTpvubs2xiJuNGlPquJEIS8kDYuzwhqiV9YW0mP2RlzsTsxKAiGQ/KntZVlO9u7WhZNdJKKVuqBm+0ypdL1U8byl9KYooxmp1OCFp6qkj4/So2hHG/VomcxsF+lHa29a2R8UgkZnRjiE0R3e2BiEJ3383cXTMtSoHOVti6cWsaoKrGVwtHYBaDUoNUfN0BnGSTxSS2nVOKNhXwF0nFAMXy6csMecWuZfmhyA9IJjNgzMluD4oGLO74Vk6LBtKyAn6+NujsW5fvvqJ6r9LhVlowaT98ugWLTAROSlnBGPBtaQJZ5jSamzKScfcgXMBlTQil95VSCcsK6zfAFmu1BR9+VqA4aUrAFMX3quAkqBk4Q0nb/DSe8RHec/c8gQYIbsrSChMhGnmiZZkDUwf5wkPG9Yas7kJFoLIeP3H9aRImmibL5apwYv0Xu9C8c6oN0eHgzjBQaBMIx4chkRPxEQjOs9lUtwZlpyZQNM/mnrTOdoNOwPq2h1QD+OHqivm7jblt6rtrQAabO9OEPyLVv887GKDG6n5bHMrk+ZeZCuXTUapxqOFub5updk286iUicRLmNaaAdXhNRjxFoQSra/dWYgwxDDjrvFeT1D+EF8ZVVOMrxesvsJpznmNA6aQZyrj6gEQxAEnT290JkOctq8fNonet/Lq4HYVWwldT1EdcSr98Dpxpm/fHGxSnduyy5Q2vr1XB7erAAvoeovqiJPqg9dq94OFnDrHImm3/y3T2XeLM+OYPdhNbkQCkc6ucQNXfIMELBOKhT5aNpKy3P9Y7Oyn/R8BkiciAInkiic0NId7wJTyAKvGgnCfTH5PuMKHF8nXSpavUTDW4cTUf+3RQErdeVz0JUXCUj9Su2R2qtEZliiEBbGxk2YpF5Wj6RK7T3omS3No2X1gaXGiS2ya3KjLPIN2zDMHyj4WXHDMG4mWmnWmkZeSWKeFkqB0sNB58c2SjBMnFBvDihJperBfI630ZLkqhnRaxSvUCa/XbF02y7dhvZoM7NCFKtRUJMzEK09BGKaCh7MlSGVOyYQlPJFuzTUSJqwS5ysvYvtmsV9qQ3x7pzWHFlNe+etMjbm5t8ZUGqNTWjB6UZRNTLNx00vbiAIojmVvDbGsq5XgSlEIjy4ErSuyaVbntomCw4bODJPE8xRy+lNsVKu4se3pPSC1gq0T0PMKJ6YlnnnUYdFqlwrmTmt1aYZsUJ0IZPbCvua/KvHDb6FZsYxhIUxsETI6qyzR94V9NJ+QDg/DN1GmDKB428q5YuvrQYJp9KCPIZiCVz2aXPyRqfwaDkiZeOtG0PAYw4Ojhs5SY2gsLjD95/f1Lk/Fn76hB8lb/IQ+iGuoH1M3JN1CnFenEAUslW1xbedAf1AqbMKsnGUxwJTblhG72CuyeHXZcTBBvQ8nqFflUvHHP6F8DQJdXaKuM1+RjV9OlI1fhrHx0+WJ8vHT5TBGmpp11NnoiBHsyYW1fo96VaXmp9/JfZG0HLRGXfqUmkQrCWnPlY8kiRKqMAOeyIZMiBPcmyE4FTbeDEEXGz0MgWdp/5ZQ2rC0a8QKiVcctFTn9kq+ulRq+lKD545v+vOW4sp+XjjF5W4IHSekV3xUt+Bsl9pLauNfTMJ3+NyD2Gw2H71q0Afwm3FaUjzz6kqlAl2bEM1z+o1h89YeRT8driaVe1n2JlbClKu0JBItEhakpQzmpTfXMzp1D+w1IqzPrlo3TGGDew10WMynPf82TOMziRRVOFNfk0mKeNh3Ugv4xp7U7NZYakzql0knvoks3+UaLuZDKOg4vDSr5BAuuzJ/o6vTmb3l9364XnUmAnebja9Ns1G8jjiybvVLIr4sP/vql6btLYdD7Q0C0p9So4D9d/BMNWuRskxXOUOAg5X5aGUnb4lhE9m9lbdewkTDvFR7GTMtMLY9ad4c1QM5qsMd0giiqS3habqihPqY1a6bdQMYL7acd6VR821jreJZehWyb24gZzjCz6fD9AqyEs5ie++xObc3J06R67z+wLpx5fbUmtu0L86C1F7QzH9Mt+j37opyvbjYRLYK6atE9t3UtfQWmNDk8EUF5VtDLn1XuXNtL5CcVeb0PdrUGujkPwJM0/0h6sI3R1aWlD2+MW2b5GrFqd+SFnGuyHL1MkD1yEOQHt/oZJf0n304OwGbTiAjVeXWlbpQyi+QEiSOIczCzdb4U1hDU/yubxqN8k1LYG94qWCunk1zXhxd68iowxeUrs/4EX4edfhclfqMznk06ujcXFEbMPrsifTIpAyFoIlqne2NRG8eo6LQBPUuVkPQe3HLzan5fitIn2VIm1+UHEHzIITpb26f2nM+UiO9MX2nTFin6jfWOpGYy/c1YZWE0nJQ3FdYJ+9qpuVyTuGqQmu//nAQB1NL7XV4XHLzenwuuXl1XpfcnNJZo2p1zcpupHhWW/7mVFI/fTTz/uZzehh58znffM5X4HP6YDydapTR5RgOFmx8Ov1oY1UEnUHHRqrDJXPyLiJfVOTT5vY1Et7JHXw6zYBjdd7GjDhq2jMVxCdpKko2Qh8dvn58yB8krPVjHMLoqZqGok2ocuyxEY0097GeRkyvwU44YVXlVDMYXVLa+fyYSes0jUZ1IpvvWXkPC62s2yqF6mMuZYZbk/cllu1Lr3mDz7v7li4SFQg9lHREID0Q2XdMZ5hx/2tvZWzHWUAfGGfbiCcyD7aYtJ0pNbfvrpq7KOcCAmCKbs+NCTr79OVbs9ZQIlXp9YwoXkh0JlcRRO99HXP7C29B6LF3o98IhfM5Dp5Kb2Q74Xz68i1jdweujKyPzM+D3jXNwGPP0YqAwCJYkQDTmRXV7LT2i2IFTBZ0TGE7lzJ706lgPO2G0HwTcxRxyc1pSiuPOfWWWyPJsjx3k1v6IvTrsaTZG9ZFc1Faec0hvOqK3ElSL2A2myXlN6heGe2gHRGOYwhPi+NH8keh5/q5hYjc/2mkstkUj2tzYryE2QIntCOme4BL79pG4KzlaPnIrgRZLkGY4G/clusx0Afqwz+5mL0Cvg3QDsbRu8/6U+/sf0q00irE8oauLkJiX4mnW9PYVVXb3ec/pkm4St+3Mp39QlJsedpTo+SsMRZ1gEYSekDzT9NNgqfva2fXKXD6+OMOfPCkvaT6UIzwpHBy3ZeVtuc5erFyjG3RVRHqFSIwk/aZRLRKlmDk8n6CGG/ObI3ruAopZ3rkk5Ha3yst8/kC4UyQXnkNuw+xwfHJ8PqYZfh3nL2EwZoECs9PaMf/XIhRB5gxrmzvsYBiEkHYi9OUyzl9qj1ZgoZV/v9KefCE7u7fCv4PVfC/Q72/vch4KhprQ+u1J2G0rVmAEDYEqm24cRMwpWhulCrUi69xcNSSwRokJsKPc1E3F8DdxX36aDpnpm2XlrbrwkDpHoybvkqQvzfhWuaYt7k4JUHteaCWt4vQMEPgAPzjWhuD7B0jATHFgR7f2Jo363Ac6+BjYdz4eQzi3Oqpnm/3VGnvAPrOKGqERo3F78lUpyk5NJLX1/rqeP1v3lpfvXW8eet40wfXzs2sjtfHLrsF/baE35bwvnz8ORZl7gPYZ8hkEkW4dK9fEUVBc2jjxY/1D3gXaIvv6khkD7JU3iYCmb0M7B5YW3FZ8lIF6E1ZD1rsA+pbmm0SbpRoh9tdPZflsA1cImt4Cx2hLWMjIcmjcpWnlHthIWHtrvT+QEyjwCEoJAWIDyGSlPAwNIrHMfhfttwLjKU7CMsfPJqT8WfIkh2EJAQ8vkg00SYU6E79KNEaxBYljJInoC50SZR9eg3HMWCB5olpB2Occ9MYGlMkiUpciIQoFOGtS0r5WdvgJ/DU4+/PXkr4XB94GsWN7hndogVPmHFEOA1BuLdL/79NnblH3RoeYdWjHAT6YWHHWDwdYJVZsmNBN+9MuxfLQChMzMtn9m2xBr4S9sT4ppp6HIGxjJdCL+yVPdub53tpyH50EX0lCKy1XysQkSkiP1y1EoBrPkplw//q/1CjV17uosUTpky6qNAmzg1rPW/XP6pCpOtJTv8+2ipsP7bCdWK6zbZRh7A57GlCzTUN3nH8VB6OKJpv0d3F/bTmowlcCmgO9rsq3x/uRel/evXAH8bsUm+cty+0QaumPRmrWpf+PcY1ZT1EbZ2d6IHAd+VvDwBfV+ZJ39DSLQPwS2DLghk2LRnHQ/HRXaLXxJElPkHEYvny4e4WYSHw1j58EiYsxEwhv3dA5FNaDzuS5SuYPleEZQdpGf+QHr4ZoTBJps8akcawtWEySfHxRWLIht0isRfyxh/fXfTrHN+sr3ruoCWoXWqUt5NWowibp6kF3hgUdouUXpTG3o6rOYU6CUO8qDQrTkNTV4euLq9/Pp9vFaQQ2uDp9XkAX8nhcydsB9HWhgkTM9PjdqDN7BOIiu3y703ZjjMHhfvtWcUYQfpgsB1NHmHTMvdYCttUndHinW4czoy27TOapoJKG1PbmHsPl+6FA4ZM5vtzKZP5+TAmZ5KwWmDWjhnWwdQGNKWPCkdxOiA1SR3rPq8wW8LUvQeeQjHVbnbvwSxMPcOJPUno/ymJkrjcJrqIGp4hmAU83EtOj3f/9+P/+3SLNJ38AX6H8EeJIn0oqT7pjUoHEqJs6fH+c1acL0233myvPuoaWMjFLBYgoWrsB40egqkwHYIie4Zst6OLszbZq9G19/j9Wtt9dgniZNrybPCAA8TDt37vA5d7tnZcoe0/funaa+06QHVwk3mZmvLRvUYt3Cu0uZzeE1PYE0BtuHhqxNGrdMURyV8+aeoK1KtU5dgXR2w5VUOZdAFVjIMn2L1IfTAuN14XMp6ofaF5h22qty6Ou9dEeSahOGbdt+Ia2D7H/q8fHxwVmTt4dmvbL6kSEgHNh1JMSe32f4zVKls406bvR2Rpi9xukBJJQzfyvI1JRGrPDPVFoD+3z+CUB5hOSdVU2OFrv4ZnHMUUbtDVv11PL6fX0yvEBbq+vLy6ubz99W83H37999ubv/31p19ubq6GufWfNA5094BwGAqQ0hXsB5ihufZf0N3D+mc92N3D+pfsQ314i7nw79seFc/4u66+NNgLvh6qA5OAiCs4AYF/MUBGlrjj7igidwz0l/mKyyEOXAbsX385v766Or+6+tfzn36Zss3U/WUa8FrUtwPzw9cvSEDARejd9EU6J1N0p7SLzucKmyek1wQjAWsQsr493z0gyvlTY0lnRQygaDiLaSJnnA1xpzN57My+9oJhsYDA1XXG5zZ8GHJzCjiDr59u36eesZOFnjR7XZYzQBGv1/hRPAc6Rb9xkSKbGAKa2v++MsfudwvOp3MspktOMVtOuVhO32n5viv+ohYr/5pd4+IChaBARMQF1y15FPAIpCvfZgiiOYQhhCjg8TYLimJVe+rTfGGlVHxzcREnc0oCmSwW5Nng6K3LMxCidr1tj8DTv2ty7kPzlE379m02J0YDnboh14qjA3FalxHXapm79rjmbw7a4lIyAY8izHYF4QnC7IYiCikZtPA6ps280OZ4QyXSrTjg2Y+hWxLwDEFi7gbtIw/zos9glfB/a/jAjSG1jqEXCaWzAapQ9oGba5Mezd+R5+/7libxBeIxsMx/JnlBkgsQ7OVB44bCg87gRF2RPxg9Zsx61NVJ6IxJtB7LCVOwBN9d4B43FDQwI8NmdAWnk0gFrZndvbFkQxjnxx82U4Hfw9xxXvQJbPe56Xhpp1kgXWfvHgL7XG79VzxKpgGfCZpjaeto89AMpvr4oN0ze6nY3LW1AbXYXEogf8AUfeRCgIzNw4OKp+8ASTBFPRfaYl7IrbxgoC5IvP75QgXxLILI1XDkb8NzZis4ps13FusrDQ2L9jQrVPvsop4BIC7iFW6/tN480z3RGsR2rbtJcsNCqFU+ndpm+bZy0GRDxmYgtSfdcu9nVw6AT0NrszNVeCC1R0DkyltSMjLAPAdYGHaQNAPKJcw2uLHT80HQVhBqGzHLkcy8ybAybkWi04CdAemDWm7ZTDZXyh8NdIqjL2YBwfoUMGscfTAvCDNzUg0FHR10BmQI6mr858VQX/dBTbFUMxz4MjBHBZ3i6INZ25qj7CDdJo+wpQ9xdkgLR3Vfv93+SdxXzcgLuq9JeIrua/vsop7u67GdvybULf+SrY64ci1xcJTguyXxvdya0fVmYMtUVeynXCxhz1RbYgMk08hfzeBJDaTLJ/1q5c+ExYmapR+KCKXEXz7Qo5j1/jHllbASqXqpWCJByE7Z71Ao9okvlxCeZ09Pg5SEs2oAuU3GDeG0nUt8854RDox3VAm1m4Z7jPuBFVMjlC+JtlzVIVraU+zJ8+2viXRVnIZ6Hwl4krB7otBfz2qECtrQMAG+WpF95iBTvr6lKeX0hBfJnHMKtfhAJxL9NXP1IrCWCaeZoVaJ7FMq5p+R9MW2StFfC4aAj60VhdmwBjr0jJKX/OOwtlntXE++AiQ4V+ihn02wczQbmHLt3EI/lNKCLiedP3VWAZT/y/8EAAD//yjo1H4="
+	return "eJzsXf9vGzey/91/BZHDofZB2Sa5a3EvPzwgTdCDgaYJmqR3wOFgU7uUxPMuuSW5ktW//mFmyf3K/SavZLkvcNAmtjX8zHA4HM4Mh8/JHdu/JnqvDUsuCDHcxOw1efYJv/HsgpCI6VDx1HApXpP/vSCEkPyHRBtqMk0SZhQP9YLE/I6Rtx+/ECoikrBEqj3JNF2zBTEbaghVjIQyjlloWERWSibEbBiRKVPUcLG2KIILQvRGKnMTSrHi69fEqIxdEKJYzKhmr8maXhCy4iyO9GsE9JwImrDXJFUyZFrj9wgx+xR+Wckstd/x8AJ/PuYfc5wE9gfVEaqjAN+s+K4b547td1JFle93jAZ/Pm+YA4tiZAH5USrC7mmSovxVJgQX62dBa/QwzYI0NBVy+fg6pDGLblaxpNUfrqRKqHlNUqZCJswEePkH6JoRucJpNTxhRKdMGLLcE1NlgYuQ4Xdiqg1hWyZMiRy+Pm+4JlsaZ4xwTQSAivnvLHKURJYsmXIjhVIxjWrEDVFUrJmbU8sU6M4LYiR56ReQNlSZGwBc+Vwup6g+eQNSABJkt2Gixu+O4rQpw6L2+LnmP8Ic2SVXBSrDMEs5iwgXJKHwn/x3Ln958/4qqK2dwgRMWjq3+cduSSiFoVxoEsuQxpba2BUV4kjVOW4DIKRiAZbM0HFiep8jAM0CwdiR3D83UhtruOCfUrDyN3OGmEJFrNHMmYuIZmrLQ1aw52OxyiavWoc+yzHAE/y5flcARZYW5fILZZJKActUrgg3mqTUbAIvIvjJfJg+UrNpolIspoZvGSxW+L6SssC14UxRFW72fnBbpjSXojFMLrNYivU0cL/m1GDsphI4GAvykkhFXvnhhGnWIOpX0hFYwMbhzuggGKrvSs1DinUQfsWqwkN6gagbygGRjYAKfz5LQ+NyCwil0FlS2m4P+AUYHEGF1CyUItJBD2ymjoN6Cl6Am2mmSCIjBuC9FEcxZJ2Ys2DpjinB4hmYSpniMjoGSz8XGz8TK6lCloDhsuMRFtNUVzfZJjCzUdKYmEXBKSA6WFyQ3YaHm6q4wSco0YwAfBQNyZcqqn2XXuyYYiWM/pXqUOeuxVzm733lbODAeZAeZgGXe8MmS9Z5YF0fHiH5gqneVQniDuMsguMO/EoKIghpuGFBJ19K60fj6o2QYp9IPOaN4a+bi5zJx+LjYyHnwzmIecLNY+sXgmggJtcmP1UZolgq4VhSnlnsvMmVl2pJxVHAAZoWzImAy7lswA+xDO/I9QfHimcmFkRnCezbK6kIjWP8UcRaLvcY26AYjR5t6n6AcRFCHvVYIu8dnDQwy3Qy4klbGgziQjBSaCLFWHg7xc3jLehcpoDBwOFcTkN9bKniKOPF6sClPNJzLbASzAxba5gpVY9MzC+zHCYXPTBLQAm9PwKY9/SeJ1lCRK/s+sytl2yTXJelLaQtFbsYUoEedmxQ5jmE0SqRGTgQ2YAMrEBCIW63juE0oTCAREmSxYbj52wEJ/BFX9b0ol91BgIengkamByA/hZQ5aIsUdV+80/kYxEQ015ABtzjRmhuMDzXH6IbgT53ymmYx0FaUdTadHthw9H09KiHgsDuzKxTGlbc124ODA/v9DwaAQF0mshMmAcCs2p+jsK1p/cJXMwo4EEJT0AneMjOT32lILHcPU8Vl4qbvYuZV/fmbm5OJulDUfIoPkOZI6riY93AT6fIIwDJHeXmDGUpCAAjl1KQiOu7q3F8nE60U/Gp385PyHmKB6I1kI3cUBHF8I8NVdEO8tlcGKZUlprB9ah+O51Wz4Zay5V5SvMCeA/j8LHn5gDkhtH4/GaGC8LFVsaZMFTtcxNgHd0tVyazmaTdhscQhmZks09BJFqq1mA7qmvykmbDlNsCpQpaH3izpTymyxhSufEeghlfBL8fJciTKcDTExDkj+Kb/OjlldBB2WIQC1J2hzryASDRHCHMIPmJi6w85/dhowk7CjKasANxbX73AvItyBFw4IzoojAkhEjSYbCAzs2cdQggq3Sz1xyqPoA6uX4H0GxUIeHrjbH/ZfcszAzLgwwpKjdEH/WCbBjUDyXwabOh7TylFMwFNQIgG/DoloRUEL0BYw9WRNOk8TuW1dvDBOV4ml1YAKuU2PW7QXj++oPpQaEwzVplOjB7UH+mHxbnAZ7mtJ1lwMxJTJNUMW0PRK5yJ8DdSEjxvCwoa9ErNytNdjyOyYZuGaEksbG+vChNrsjtyxcv/kz+gnqrb5F2i1ilcK1Kl8agyHtiKBYSlaVuwkhCwxB3gtwV2zbtFPFhASgdRnk4yPUUokXkg2gHG/WiRXYvM1zoILgKfV1WlK4Vo4Yp+IbI5VYtpVwQviJ/bZHFOcaPU0O+f/FngIYVVHn0ubAjaRY4ad7m2rNk5OXfOyfHTYH9/BOPKv2x4jZPNyLyRwlA/KEP+P8PjspfD5zzHDgfKQs1QpDgC0IJNJ6zcUe9jmKGinP94Z9ghQqyNfp/Ij+XntEo/wQ8qXN3UorPe9mwe/zZMjJ1oz9PRh6025/p3Ize8s8U/wH7/nlyMvvm/6TYPNQDOE8mn6obcG7SHOMFLNwNvkopbRmzwcO1h/fiL/DnT+RzK+D+VIpFTpkqmLqLnwzbgzbm00lw9F57OkgHbJ8nAzf7jvjYyA/d5E6G+6z3LScTqC/h8kHpByBRyT/AP6H83xWkjmwMcHiOYmKKsLiKryP6cvp0I3swZAnai0ozxen8uVUH4RvUB07LHCtexE/oHiuQl3hTfMujfBuHOxaF0Fs0bYx+gCFIhASY8PByc9jiQU+pkhWCQUBlIMIPKqOzECoCVlkcV+4Se/FhZf7RAeIoByLsvKrSCbDvOsUB4JfldZUabMjZYEY+T3Hx5lCk4QdqFhqpLCWb9OVW0wShOr+Um/8W0fx39EO/e/lq1Aw+voDc3ZNZZOSIjRRTi+qw2GAWgkZHjF6hHSCYhMdwJsDLxHZ7s2YFRh/aeEEG7PEg4vBDGLk8NkA/xkjCjn797YcKwD6Q7QtOc2IEHODHpkquFdMVTA4CE0bJdP8Qj6H0TWwzkTbN6V6ALbOI2c2SmzlFVBAmQBiE1IZbwnj8836J1+Jst+pIpYwLu/y3F//z/UWTjRWPWa1vzEETfVuSaRWolD+ao06lYNor/Pk3DnDB8qN7Rd5QEiJIJlLFtzxmaxblOQcu8mECL/T8tujMhW4FRiDbaEF1+23Ett/CT1/eehHBuEeAAmSbUNi9+dttQK4F0RIaelDNYGrIP7mI5E6TD59wW81LeVyZxm0mCqHfEqqhEAd6ImEjGg43DXO/iUvhbuTROJY7FpFLdh8Qdm+YEjTGQ7q+CrxCwArsm1RyYeaVBRIGm4+0W3PjnxJcLWN1ewhEw+ZzISNIDeYVMvmaXNg2GE7kFDzeJRe5UOUqB7QgKxlHTOkF0fsk5uJOL/CQ3nsXViIyPa9ULdFmMVnFyqDc/YhWirGxwj2G4eg3EIDuZlYFqDmqQN7pgL2ju+LF3uyHhG7DWDQPlBiOVZVbv7SgCcRJzxAw4ER4j+8kNECXWNt/cchXGvbpiybmKe6AVamcUsUjqK5SGzWi67Via1qEjSCCgSu4UQhafvSBHsThgYPyontl3WiykpnoaLaBKv2AJX1CC05+hq5uwm3IffyAQ9mhtd3rsak+bekyjUpQipZEsmw82De1Aya+V95D6AdU3X3lMwWDNxdaEyCsyEcDCIMPAfTZ/NMhRHDkEoGmcaZRphXXzaFcj6ilf3izyX98/FL8HerVc6/nH3DvpdUsihdx+vqe0GeMuIjY/cVIOQ/I8BpoVYAvwGPObUeZ440U31a7L1TBZNmcNyW+CP4bFNBHTBi+4rZ7Rg4tmCO+PzD+RyWjLDSoLoNDb5mIpJpv8F+RXmXYBfn51+t312+gO+Wb9+/8KDLDY/47Gu5g3WoSfHz3oSz7Q+SuBR2o+45qssz0nkSZcp3PsFWpxgON7as3zJa3te6jcmYXMjAIIU6YIBfKbfDaojqC93nb7tUMD7TKm7g5omn3Os29hqcq/q4PjzbwJQ9BJ85O3/4UML/A1jMaZVuTR2nzsEaPRNvQ7LpSZ72dN2FnfTQp/6hYFWpw4UNoWILJzAwuA7JY80zPdSXzc0m6Zqa5IBFbK8Y0eZuP6IeWyh1TQd6wckeNmQ3YRyBMIkV3wm3aDhiO0wcHO/8dA06tZ+EYOPkFb2fufXrSqWB9yjUAtqH6Tn4OjW37hRfLi75fNhSCHe+1LzFnPw1nqPykRWMtbRPQfIO8dQOkcKsVPMTiOzA79UhfaaeDi6bYYkmji6F9okcGUJgCNAjdMgWX7AKfNzz6qPzs5TPvpPXYNfgRF+ubFYWc8Gu4zjdtCn+qwAdrXnoaCReZYYEf6XfnhPQ7i1V3gH15VmhfeuD6cUPJaPBYOlHDnAMmES8qX8YVsLbZ+e4c2ClmYA6OXp4FSy/n4gl/6dnYzWPGq+kXTSgtX36yfb7NSbRypHbPmiE/erKAPYzT8t28kDqd+WPgQud9FKwT5oJwUir1ozC081OKLEL1gZ1IMo3lfc7LsL9cfTTAxkixI3b7gRP4WmarFTx+cqmZC6k6h4yGUJYaNGJrXjnhANEpJIVSeovDOYalcMCLX/eCPKdsyyjtyyfAC7dpT0YgeYPUnNBAGKAQGDoNmhw3jEfjxw2R+qaxd/6HdGAEMxWGKvKsLJRrbJKeW2xoIwFlB6Dp2IJmycyO2cbpdt2JCP9VpmHtDHn7g8Cf5m+SiKVMREXi5cOnPJufQOOLiBnKY70gKbrXJNyw8K5IgVUW2m0wLPRHyl5YcfvtUn5oCmkcZjHm6ZYUpqUii3rFZL2G4z1LyjIwzPB9C2ejbxOWQPnGoi0L+JKqOiB+rAoOEwOl5SssHV/VqRdVIQ5BczXkXx8E+fDpX4QjoxR61jettNMhLmwPYKdCH4qM2cJ+nv3WXth2FmWhFvbjY9XiLCJNbXNHW6u0gxfHh97RdLTNSxVb8fvX5Nm/ka3/PLvogYybJ1IpfStwp7g2cDcBC+NY5OriAAdJam+YVFOqwUVDfB6Ha8jpOsWytRmzkpkO8Z+NWUfvbBrex7KIhc2aBvdMV2rWKXgHXDCzk+ruYmhl9gx/a2lUjjf2O9VrQrW+9e7neClt1UhLTz/9TMwgFtV4zGwmHng/bzzgx1wXkpn/AZpOhe5T5kmnXSRAdO1hTy9ELh4VoWIh49tq4sKLEgSZ0vCOmdFAJ4GxtEcK7HhIVIFkpGC4CJhSUh1HLDlpe5kxR8TFegASzNWpMGkmomFEXASRkmnKoqMg4iKUCeTJ3dxhszt8t80OO0JixwQoM7OW/QAbL/7SeEf3zfkj5AXEzN5RteMCXfEfPr0jSxbSTDPC26+sLPcDF0OdAKxxvYEHpOiIqNsDan3QOQRZGEnWsVzSuDDt6O1zsx+5//A0+It3uuTyvyw00ybs+mNeq8iU9g5mwjlH+/x2YLgsmnO4L++Gh7uJ4TbZvGP+BK869Q7Mw2RORq/fvvdw6gazacJB7e4ZxKUaK16X/Q7U2NKIGrqoPhy0qD7ubr8X+BbSw70uGnNaFzLB94rL/LDnowlfg2mQong1vj1i8/32IUdvYI4OeMu9iibl0YHstz85hvv0AQNSeATs0HHXh4+7PmhEkSU3tsV049M5w+B5r5maNtuV98py0u7FMgvWiyRMoBkF86I4WOfgtjvct0moiJ4D+TyUYiTclFOmCqrx6miLHFXrDN7dhW4BKVXU7rXeUl++FtBYmy7llr0mr1787e9eluFK2wFLGz526LoOd9HE0ax4AtitISkbcYXXrPcHjM7EdrzZz/eCmwdqABNbrqSAmSNbqjhEHHS3FmBLawIm3XcvvQxbS4H1VT98erfII7O50f/wifzr4S+jT4rLvf345blOWchXPKwG5NKyq0lTPX1bzajeUr3e8YgJ6Wn0UpmD/qZTTbAYGgzQiT4S2qLjePkkveaQCUEb4upXO2TdBHp+katGr53lvsqO5bQoOsjSCHfva1M5uGie8JgqG/v1DvtnGKUQZHWAiOs0pvvy5GJk6ky2a7ZjzzCDwu3oE/ekJIxlcm1G4at+XKz02bcUfXUXYJW4IYqKdSt0ZJmG3M8LmLx6R/2miO058hzsgr/hWxNwvuCOiRdH6J/eHnmC9bjxdMoo0UVtJ3wKOsBUvHHthIjvBcHQ1QhJbxFO/2ZVA5M/8D51Pxra74b2K891ucEZ7gupTpExaoBrQmbPfFVxb6iu5jDzBG4juf5WJlD8+3ZD1ZqRy0pivVgPBWVqqi+WJ1TQNVNkQ7GRUgK3Kuxb4MQeqRySK2c5bNrZFpNx3TUr5/G4PiyzX5jmESytT8yQT/x3FjSshUfuMgyzFDopcUESCv/Jf+fylzfvrwZnxL0iZJ1eopnBLh+LMmvfK63z24Mmi6iTP3xP6JE0AceOfMxkta6ac7zH/w7udF9/+8FFMfG230qqHVVQEVuptPj3x+t3//mWy6DWK9BWbEAtfDDRoIVUhCyGLCZclGI3By2+GjP12DTSqy3+csgFJEQwuhxhYNlIKDKxF7aCTshwzj8SzhUz4aba9FAbiSXh4DaqbkjHlJ27wGbkFEQopHBD1ex4gHIuIPjb5asrPI46x1zvwejEPUYL+GHHgQbpPfAuHQiwcDheLYfUBARs3LQ7dE2HE7pNAig+d2UqYyUyKwIkOQDBDb+KDrNabdv5I+Ts3O9IZU+wLi6d56Bgf0MvA78Jp4sy1LHyNFHNTRuomI2CzO3SyZSJBwu+1MRVXQYa0mPF5S9rAoNOMHg3K4AuuQ+C1LOtyZVxN8AEMcPQizOfl6RjqEkbHglbMhJu4DAYNdgn8FaY2OOpYUgU8LTvkUQBpI8ligptEAXszLC3Kep6tCspK5UIVb5D38I7eEm6vCgsIORVl5tbPhKwm9+FAxEYqu9wUZKEgTDa82M/5RYwtKEuMsKtIyCcFnJCesNTcGmp5xU/8RzEYSmjAHVhNvBZOJRfLSSKZiGYuNR5tyr5o74TtOn6HR6LoJJWYgPLnBsNLUNlyNEO77gBKXONYm6LFr6uIZUBtQVci28MoY7q9bviGmSVugGbiny7Kl0vVbrsKX2piggi8ccTElB3jozVo2abQ/ttnS3zKNA3muDRNG+8NklkONophGbp3myZ0lw+fDexdGBlOsjFEnMXs+wKK8h1g0uzTkA+gzJhOsM0KyeKaHCdM8h5QJjKtvdDuFTfFYk5u8i9NN+E7oAAxhJS4Ar6kKPSm50s0mHFUNDE7u2Pn9AD/eWzXzvg59pQKPkHMK4JdLwnK8pVScoawVRJkDSXgsZxMzZlpYN34GxAxUXk3F0FN2FFYf2OwXO7AfnlcwWGl65iNLbhvQYoDWVH5cOk3uAlNX3bUtknzi4wELK9guR6Y1Cy5lsmvD0w3Ne1wCBkJiKmCl5/fbWokoaW5kytncFLqNi7ULw16l7SuC+FaUbD0GB3SRpFHBbFAhA9LyequjOs4TFiLsivXQ2X+3eGwd1hjPFrrZjrd47fprb3AuiwvQdB8C9a+Pp4iA3upOazzb1MQpy2i1y/UWrxmMPcvuql2TfzVVx4VglEF5lBI96DUJPtK3sW4oIIKqTtJj0SlD/EN9wNYjSskTh6cl7zgKnkmeq4RgBk6oiTBxsdZojdm0zTJtH7APR8YquhGwfnlFPphzeI0yYBjzep1m05ZEottmMKsIJuLJxTTqoP3kUfxnClA+tYtBr/jJ7OsVscjlN0B4OkAw+Vm12MCm7kjii2zmKq4GjZSSrn/ptqu2rwfxTTMlMhxBw3Mosj8FfBfYtlSGvpzkGZ/JZJQ48vks+NLF+nYFxbHP+1R/gq3HlqdQMi+pADdH4kuGT5VJNLqknEVjyPnXSSrClH1yV2n/QwS3Ns2b0RrjjRJjbxAoDNPDNwzAsHCvFUHfNOomU0wzpdLbEGlZIgN5hrcdRJNkwzKxTsFUuSTBtQzleQa9nw9aYa0ukVrzJnvF6tiHoc0671yvUBC1WZQEED+ISdhTDAR4eBmDZYKM5FJjNt11wnYS4acb76It7QLeuyciPFhH641Zpji6ms/LWmBpao2tJYo9GpLRhYFHUT00kWlzaKgsU0rbXcG8G62ShpTMyikwsBdEV3zeoSAhMFNij7oHCbZ9FJt/r6gpFo2909ILNh+5wqu9/QDPs8Q2xNrnrtUsXcwRKvzVDRchf3wqsDJX70hVkW4YCwYbPDImRy2ViiV5V9tJyQTrLdE4VlAEXgb7m3c6q3ryYJRjymYCpe9WxyCS58H3DsQ3WNzhTrZXp0jOGjpUYunTFEiwt9kKW4srJ+YOgBXlHp/KUxiFuoPzk3xG0h1qszBO4DmfzdlnwO4Be1oTEYBimKGKDjtmfEIfaqLL58MXAwGX84GVe51CmaYkLlliny8gWx2jmKje/PlI3vp7Hx1xdnysdfX0xjpKtZxyQrNwMXiIN8glXlzE+/9+8YgFtDp1n6cYyJVh7FI1c+0TzJYkMFk5nuyIR8NQRfDcEfzxDUcOVL+0e43edf2hddmGwq66ILSdeyrg1vU6nu+TGb+goupq3CrymuU6a47A0hfXGgHo7k8nP9Skjd2Xa53aL2ofC4e6NHU+ay75bZ6Br0CfwWnNYUD85ItFGBDiaExqVsps1bfxT9fLhaVOPxy729iQXv5eWVllDjkonQlTLAM5euZ7RzD/JrRBTCS6Ab2LDYPnE/LebTn3+bpvGFRKoqXKgvjEQSGbHp+Oae1OLWmJ0zz2XShW8i63e5ipHGsnEUBZ2Hl26VLDCM4NKls06mTpf5Lb+r6Xploc49G5+7ZqN6HXFm3XJSl+p8+XmofgHtNOtlzdsgwNsoYKwCjrD9rUhZoatSEEbDDc5oYyfvJIvljYNuWe8lzIlean4Z0xUYQzXeV0f1aI7qdIc0YYl93abritIos+pOd31ERjJebTlvS6OW+85axUt3FfJqMsMJvT8fpjesKOEsWGfR7JzjMjxLrsv6A9x3rRAcj+Sy7IsDyetOktgt+speUXY7eUVqENmqpK8yPXZTB71ZUR5nxy8qqN8asum7xp1rnEhy2ZjTK7JrNdApvxRsF6PTlkha7k6sLI49uYNkF9ObjYyjQZxQvfA4QGHkKUhPb3Qs0ITe+3AOAsZOIP1ou/yAFpS2UldK+RUxikNHyiLcjGaaxGzLuuJ3ff5ClZFY7jp/Z4TMW4yU6tk159XRQUdmHb6idGPGT+j9hfcXDhy+VKUxo0uZzDq6lMm00W/ueBzPDgGIMjUBCWwes6IAgizyIGj/xYcnYYnenZvvB1dF82cZXPMLa74QZ/4gBHZqx2Oh85E66c3pO+ndmfuNpQdlZQaHrbawag5lJ+GHC+vsXU1XLmcVrim0/usPR3Ew9e6peFx693R8Lr17cl6X3p3TWaNpdXFld1K8bC1/PJW0Tx/dvH/1Ob/6nF99zqfpc/pg3J1rlNHmGI4WbKwwfq5eY1MEg0HHTqrTJXP2LqJcNeTT5/Z1Ej7IHbw7z4Dj3REjjkD7Bp67OEdTYcWA0IA6vqRRPEho7cJhjJ6raShYZlGLY4+N6KT5EOuJ+vAU7IQVVlNOLYMxJKWDz4+FtM7TaDQnsvuelfew0Mt6XnDRfMxlQvK+xnL+0mv5Iu31h54uEg0II5R0RiAjEOHLRuyGCul/7W20Vsy4gN4IKfYJXEssgi2YtoNea/bdVbyL8hzeHRMm3j9Ht+Typ1++dGtNzLWpvZ6RpCt4E3uTsOTK1zF3vPAg4Xhi4UG7xOdLeLOrmP1SOD/98qVg9wCuUNYn5ucj7Jo48NxztOFMURVueEjjm1xUN+e1X1QrYIo7ug62dSmLN50qxjPfELpvYs4iLr07T2mVMafRcuskWZfnYXLj4qlZUi485qK28jrJtlZk8ZtTJPUIZrNbUn6D6pXRAdqRUAginhfH0KK+dEyf5xCJ/R8g1d2muJPoQdJJ6ZrdrGgWm4Plcuild/BHqTupWA/cedpG8fWaKQz+pn25HoQ+UR/+K9XNE+A7of+VaoBx8uw9/Naz/J/wmEQKfYuLhq42QpK/Eh9DBwDoEtFJFDpg4+fwfSvs7BfxasvTEfIFyeobLk4mVhwQ/wtdI4y0q6q8TkHd448H8CEz8yiMyKxycn0oK33Pc5za9HVui7aKECyDokLnzySSTbZmIBF9BUXkXXNBOq3lYXuG0voGRj4bqZVKgsTgL7QQpFdek/iFaTgbXj8VGf4DZy8TbMtDA69snJvrjMY/pAI6tWDvsTCmPGHRKE4dl8v4rvVkyXD2tQb0h1iGd+T6w9eC/2MV/Pv7X/fygpdmzkZj89B660kYsDUrpsA1MxIrctBNgBuOS1SqCBZf5+CkJ4M1SUxcHiykAwUAL/3YR9MlvPDMFEjbdmGI4wcwDjeoNCvfm7Atc2A3S2XMw9bzQD1vF000BBbAr6/IdeUdI8XSmIYwPtqar9bhNNbBx8K88XPoyJ3rKcy3fap0dAD9YBQtQiOs3MmYCrh8XCSuQUXHCP2rubsjxxFbX52u/83X1ldfW1+de+ur/2Pv/Hobx40A/u5PQexLW/SixLnt3jVv2c21NbrtBvvn2UeLjENEIgVSctb76YvhH0mWKEqyZCdFCywOh1ji/DikyOFwyHlNN94ccZnV+e6xS5Jhd1gF5PSNUP+/tOp/5dKq/6JrqAIfpUOwK4NIFWmKD8715yxPKHQh/QD60n7A+4EGbFdbhFv7l/t31erEZQa2CdYehTqwUiWFSRmE4kX40wxpuFOjAXTfuqzC1rhMtXgrDluxmUgqr5xT2BgWRhI6OwgUOopCJZRmp1CJK3gcTS5gD2x+GFPuKJYfIt2w+VvIFDuKhFA8v0qg0C4KtMr/oNCOQoQhT9gTTazrkuUm9RrsVGKJNoW+DgYsCLjsJmY4QYrlhXWRsByleG83pfxVe8ZP1BOPP716ruALWPB0qht9ghSFD6Lg2o0jEsiPpZMUon+arTOb1E11458E/bTYGZZPJ/jKTLFzoes80zZjGZUQSgIhJSa3WEe9Cv7ExTOfv2JlXWp3YcN5WF3TGFKJQApD7dHPJaM7sGslbNhZIj8unD7DLRulMeF/9T/UaZUfVOoDhMAqqELtmjgrFtoFuyQ0i2FmdngeDSrbz1Y7Tpzsy2nUEkad8rWrmZJ55Dt92ELBQ726/BQtmkIlZmSK3dV4f7wVBf9d+Krrd2MGUODfv3F1faFxWkVeqfA5F2o+uTqsh+V7O04MIPAd+ZsAAEHi0BbmKOGhCrzy1Z7Ha8AWfD6KD/YQPRSOTOE/IWZYPt+u7hCWEu9hDJGUFJxgniMvHURouHjYxcCPoYetNvTZICwjJCD/lBa+Fl5rJJgHFFN6YAsx6U3xmZhqKtHFEisjIB6C/SmZX7496NcrX39f7b2DgFP74KK8o3o1BLEBo8TPmsJMkcpLqcfbeXtOpSRTeL3TwJlIHVeHllfXby9gB8IhhPDg+6TkVHyC1xG1jQ2+MzCq9zzuoXWkisrG2OWfm8oZZ0NzvBgEXfcR2ORxVpo6w6QFIzWvTVPtilZyEoHJWve2KdKgFGvWDZA5WZybC0eILDbTa6mKzcVwifDgWjEe+2WSNkxLIPi8VI7TzAlM9KYOlIziR8y3NEKrAxSY+OzcA0EG1jKEhMUmEg5urCyyw2ui69T0O43XsSCT9PRl9fcP//gIKeIJrRLwW0JIcQ6LErvQ8VIUnOUm9Hh6m9XbC8ptX7bXlrqjnEC0q6SK5lOkE6ojTMdQOG++8sptjk0tqXa0cQNQOx9/2RCNUnyDUZ0L0ooF0gZ3Du0tQrjcdVB+YCfZRnOGj9AOl29j5UsEf4lOuL6KO9Lho5OkVmaZ3csZ3DAVC6f5s5BPHlHdnaMNYgop+1nnrUBdHaJOxM59cERL67rlqUaVwbmI/HxcVl4fmSjyqWhesV3x1nW5obqMk2qDaWsyF02BCnwC+aKvhwZMKDhybEpxwf/STvw08hlng90BhEnavSjFCWud/ofrkcsPJ+p6P2VbE+R2g3JZVF+UF+IBpyzZH0kApFOEQzrQJGLNoQKKvUGtP9PvOM1gL2351+voKrqOluCku766Wt5c3b3/9eb2/W93N7/+5ed3NzfLxquB5oV/H4EDre4RJgS2+WzAPiTY3VDI4LC6370FYav73bvyobKYQN0gi6C3dp4uXtbv+voYfBBVdUgvk6SpyOkrUPhnDTKzxm3tzqJyW4HhOoftCi+V34ArwX55d3G9XF4sl79c/Pwu4s+R/SWKRRqNY77/+hki1oUk3klfujaJ0ArylCKxAac9JWjHINcy+PWbXzuCJkyEeCqyYWqgeULWcAB1LTg9Rh9HVx/WTfThAUZcHdeZXRj3IRF6FfBH+vXj3Z+cZWx1AY1mLsaE5NqpaMf4JXhDkwj9TUiHCEsciqC0Py/BrEBvHoSINlhGW5Fgvo2E3EZvQL9v6n9oVsZY7foYl5CI0JzKlFnnuikexQLOo+llDeaIphtKCCUoFtne1QPOgTUL1i885nl2c3mZFZuExap4eGDfNUf5cKgRQS1rKqWQI1qwp3P+BsXZJty4aprct2Wb6B5ouxuyV3FUevMS28VdlDHiZe2e47rfHDXFuWJikaaYHwvhccIcR5GShHE6X7PpDG22buig6CAH/U6P1AT4BQp9NmiKPuAe/Gh0l/C/NV5wp0utRzSE7a5HdAUn1Fiv3bFJX/TvyPP71NAkyAoGB1qd/WyPTcAAYt2Rkyxo3BF40CQe0JFvdT/mHOYH0XIs+CDqIOFlOeRI31LfWeAeKAemddhNV3HA1SU0uLM7maUUoY0ftfBh5HE2Z7vACuz4tunJtNOtkL619wCF/evw6r/6UtI5fH5CGww/C17bMcMJLI3APNOHQfU5LetQgz8gxX7QCH0QUlKVgc8KDqrYPECK6qCeSxgxL9VeXXKaX7Js9/YyjzO4xsXGcFS54QU3ERwR6lRi+0sLt+pA/fS3bqiF64BCZo+4uRIe2tIDaeHfrTmMbhvJioXDdHHmmrZbv8EadI0hc1fAjSf9eh82rpyAD9BC40wTjyqwCJh6bG30nQCw2gOsiR2lzTgRiq6fMcvPSdsghDFiXZGskW+H45AbdmteBXYJMoRa7flaUf7i0I5jKLOk8e41MAPHEOYHxnWbNF1BZ4cuQcZQN/0/L0Z9PYQatl/XOH56aWjHMYQZxpqzzCBhZIvhI3akBckWQw2dHiYwcL7dHVAshhk3r9B8/Xb3ouZrQV6j+frtbg7z9dzGXxd14H8cqonaWDT5mmoMEP1uivj94IpBdzcD37quYp6yvoRokqOAFFC04FGqhm4NuM/Hvdr4mfGsyNfuoZQlCfOHD/S0DLh5P31xdWX8oKho0awI+IFUr+6PCBT7KLZbSi7K1NNUKSZ404Ec0jEj87kVQSvVnREWxitVUZzPJ/eW17dGErFlnLRFBK6nmFjnu/eFsqGd2uc4RAOeTdiJFPC6k1zvDV7x/liRCQS3Ttzg0BSHYnZtGgUako0QCcV8LAm8pjP0x2ZkwlZGWCMeU2hii7iMbQfhW0GGWMzdK2qtYQZo4pHi5CcUEyqHjrUDpEshcnQ/bEwwbbQeueXaAwHdob4taPeky9O3TaAFQgghhNDiPwMAA1/zUg=="
 }
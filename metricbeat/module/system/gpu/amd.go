@@ -0,0 +1,259 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package gpu
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/opt"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+// amdVendorID is the PCI vendor ID of AMD.
+const amdVendorID = "0x1002"
+
+var cardRegexp = regexp.MustCompile(`^card(\d+)$`)
+
+// amdBackend reads the metrics of AMD GPUs from the sysfs interface of the
+// amdgpu driver, which is the source of the metrics reported by AMD SMI, and the
+// GPU memory of the processes from their DRM file descriptors.
+type amdBackend struct {
+	hostfs resolve.Resolver
+}
+
+func newAMDBackend(hostfs resolve.Resolver) (backend, error) {
+	b := &amdBackend{hostfs: hostfs}
+	cards, err := b.cards()
+	if err != nil {
+		return nil, err
+	}
+	if len(cards) == 0 {
+		return nil, errors.New("no AMD GPU found")
+	}
+	return b, nil
+}
+
+// amdCard is a DRM card of an AMD GPU.
+type amdCard struct {
+	index int
+	// dir is the sysfs directory of the PCI device of the card.
+	dir string
+	// pciAddress identifies the card in the DRM file descriptors.
+	pciAddress string
+}
+
+// cards returns the DRM cards of AMD GPUs, sorted by index.
+func (b *amdBackend) cards() ([]amdCard, error) {
+	drmDir := b.hostfs.ResolveHostFS("/sys/class/drm")
+	entries, err := os.ReadDir(drmDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing DRM cards: %w", err)
+	}
+
+	var cards []amdCard
+	for _, entry := range entries {
+		m := cardRegexp.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		dir := filepath.Join(drmDir, entry.Name(), "device")
+		if vendor, _ := readString(dir, "vendor"); vendor != amdVendorID {
+			continue
+		}
+		index, _ := strconv.Atoi(m[1])
+		card := amdCard{index: index, dir: dir}
+		if target, err := filepath.EvalSymlinks(dir); err == nil {
+			card.pciAddress = filepath.Base(target)
+		}
+		cards = append(cards, card)
+	}
+	sort.Slice(cards, func(i, j int) bool { return cards[i].index < cards[j].index })
+	return cards, nil
+}
+
+func (b *amdBackend) devices(processes bool) ([]device, error) {
+	cards, err := b.cards()
+	if err != nil {
+		return nil, err
+	}
+
+	var memoryByCard map[string]map[int]uint64
+	if processes {
+		memoryByCard = b.processesMemory()
+	}
+
+	devices := make([]device, 0, len(cards))
+	for _, card := range cards {
+		d := card.device()
+		for pid, memory := range memoryByCard[card.pciAddress] {
+			d.processes = append(d.processes, gpuProcess{pid: pid, memory: memory})
+		}
+		sort.Slice(d.processes, func(i, j int) bool { return d.processes[i].pid < d.processes[j].pid })
+		devices = append(devices, d)
+	}
+	return devices, nil
+}
+
+// device reads the metrics of the card. Metrics whose files don't exist, as
+// with older kernels or some GPUs, are not set.
+func (c amdCard) device() device {
+	d := device{index: c.index, vendor: "AMD"}
+	d.uuid, _ = readString(c.dir, "unique_id")
+	d.name, _ = readString(c.dir, "product_name")
+
+	if busy, err := readUint(c.dir, "gpu_busy_percent"); err == nil {
+		d.gpuUtilization = opt.FloatWith(float64(busy) / 100)
+	}
+	if busy, err := readUint(c.dir, "mem_busy_percent"); err == nil {
+		d.memoryUtilization = opt.FloatWith(float64(busy) / 100)
+	}
+	if total, err := readUint(c.dir, "mem_info_vram_total"); err == nil {
+		d.memoryTotal = opt.UintWith(total)
+	}
+	if used, err := readUint(c.dir, "mem_info_vram_used"); err == nil {
+		d.memoryUsed = opt.UintWith(used)
+	}
+
+	// The hardware monitor reports the temperature in millidegrees and the
+	// power in microwatts.
+	hwmons, _ := filepath.Glob(filepath.Join(c.dir, "hwmon", "hwmon*"))
+	for _, hwmon := range hwmons {
+		if temperature, err := readUint(hwmon, "temp1_input"); err == nil {
+			d.temperatureCelsius = opt.FloatWith(float64(temperature) / 1000)
+		}
+		// power1_average is replaced by power1_input in recent GPUs.
+		for _, name := range []string{"power1_average", "power1_input"} {
+			if usage, err := readUint(hwmon, name); err == nil {
+				d.powerUsageWatts = opt.FloatWith(float64(usage) / 1e6)
+				break
+			}
+		}
+		if limit, err := readUint(hwmon, "power1_cap"); err == nil {
+			d.powerLimitWatts = opt.FloatWith(float64(limit) / 1e6)
+		}
+	}
+	return d
+}
+
+// processesMemory returns the VRAM used by each process, by PCI address of the
+// card, from the DRM file descriptors of the processes. Clients with several
+// file descriptors are counted once.
+func (b *amdBackend) processesMemory() map[string]map[int]uint64 {
+	memoryByCard := map[string]map[int]uint64{}
+	fdinfos, _ := filepath.Glob(b.hostfs.ResolveHostFS("/proc/[0-9]*/fdinfo/*"))
+	seen := map[string]bool{}
+	for _, path := range fdinfos {
+		info, err := readFDInfo(path)
+		if err != nil || info["drm-driver"] != "amdgpu" {
+			continue
+		}
+		pid, err := strconv.Atoi(filepath.Base(filepath.Dir(filepath.Dir(path))))
+		if err != nil {
+			continue
+		}
+		pciAddress := info["drm-pdev"]
+		client := pciAddress + "/" + info["drm-client-id"]
+		if seen[client] {
+			continue
+		}
+		seen[client] = true
+
+		memory, err := parseDRMMemory(info["drm-memory-vram"])
+		if err != nil {
+			continue
+		}
+		if memoryByCard[pciAddress] == nil {
+			memoryByCard[pciAddress] = map[int]uint64{}
+		}
+		memoryByCard[pciAddress][pid] += memory
+	}
+	return memoryByCard
+}
+
+// readFDInfo reads the key-value pairs of the fdinfo file of a file descriptor.
+func readFDInfo(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info := map[string]string{}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		key, value, found := strings.Cut(sc.Text(), ":")
+		if found {
+			info[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return info, sc.Err()
+}
+
+// parseDRMMemory parses the memory reported in DRM fdinfo files, like
+// "1024 KiB", in bytes.
+func parseDRMMemory(s string) (uint64, error) {
+	value, unit, _ := strings.Cut(s, " ")
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	switch unit {
+	case "", "B":
+		return v, nil
+	case "KiB":
+		return v << 10, nil
+	case "MiB":
+		return v << 20, nil
+	case "GiB":
+		return v << 30, nil
+	}
+	return 0, fmt.Errorf("unknown memory unit '%s'", unit)
+}
+
+func readString(dir, name string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+func readUint(dir, name string) (uint64, error) {
+	s, err := readString(dir, name)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func (b *amdBackend) close() error {
+	return nil
+}
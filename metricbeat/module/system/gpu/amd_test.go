@@ -0,0 +1,134 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package gpu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/opt"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+// writeFiles writes the files in the root directory, creating their parent
+// directories.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+// amdHostFS returns a host filesystem with an AMD GPU used by a process, and a
+// GPU of another vendor.
+func amdHostFS(t *testing.T) string {
+	hostfs := t.TempDir()
+	writeAMDHostFS(t, hostfs)
+	return hostfs
+}
+
+func writeAMDHostFS(t *testing.T, hostfs string) {
+	amdDevice := "sys/devices/pci0000:00/0000:00:01.1/0000:03:00.0"
+	otherDevice := "sys/devices/pci0000:00/0000:00:02.0"
+
+	writeFiles(t, hostfs, map[string]string{
+		amdDevice + "/vendor":                      "0x1002\n",
+		amdDevice + "/unique_id":                   "4c2a0e3a1b2d3f4e\n",
+		amdDevice + "/product_name":                "AMD Instinct MI210\n",
+		amdDevice + "/gpu_busy_percent":            "42\n",
+		amdDevice + "/mem_busy_percent":            "10\n",
+		amdDevice + "/mem_info_vram_total":         "68702699520\n",
+		amdDevice + "/mem_info_vram_used":          "17175674880\n",
+		amdDevice + "/hwmon/hwmon3/temp1_input":    "45000\n",
+		amdDevice + "/hwmon/hwmon3/power1_average": "41000000\n",
+		amdDevice + "/hwmon/hwmon3/power1_cap":     "300000000\n",
+		otherDevice + "/vendor":                    "0x8086\n",
+		"proc/1234/comm":                           "python3\n",
+		"proc/1234/fdinfo/0":                       "pos:\t0\nflags:\t02\n",
+		"proc/1234/fdinfo/5":                       "drm-driver:\tamdgpu\ndrm-client-id:\t24\ndrm-pdev:\t0000:03:00.0\ndrm-memory-vram:\t1048576 KiB\n",
+		"proc/1234/fdinfo/6":                       "drm-driver:\tamdgpu\ndrm-client-id:\t24\ndrm-pdev:\t0000:03:00.0\ndrm-memory-vram:\t1048576 KiB\n",
+		"proc/1234/fdinfo/7":                       "drm-driver:\tamdgpu\ndrm-client-id:\t25\ndrm-pdev:\t0000:03:00.0\ndrm-memory-vram:\t512 MiB\n",
+		"proc/5678/fdinfo/3":                       "drm-driver:\ti915\ndrm-client-id:\t3\ndrm-pdev:\t0000:00:02.0\n",
+	})
+	for card, device := range map[string]string{"card1": amdDevice, "card0": otherDevice} {
+		drmDir := filepath.Join(hostfs, "sys/class/drm", card)
+		require.NoError(t, os.MkdirAll(drmDir, 0755))
+		require.NoError(t, os.Symlink(filepath.Join(hostfs, device), filepath.Join(drmDir, "device")))
+	}
+}
+
+func TestAMDDevices(t *testing.T) {
+	b, err := newAMDBackend(resolve.NewTestResolver(amdHostFS(t)))
+	require.NoError(t, err)
+
+	devices, err := b.devices(true)
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, device{
+		index:              1,
+		uuid:               "4c2a0e3a1b2d3f4e",
+		name:               "AMD Instinct MI210",
+		vendor:             "AMD",
+		gpuUtilization:     opt.FloatWith(0.42),
+		memoryUtilization:  opt.FloatWith(0.1),
+		memoryTotal:        opt.UintWith(68702699520),
+		memoryUsed:         opt.UintWith(17175674880),
+		temperatureCelsius: opt.FloatWith(45),
+		powerUsageWatts:    opt.FloatWith(41),
+		powerLimitWatts:    opt.FloatWith(300),
+		processes: []gpuProcess{
+			{pid: 1234, memory: 1536 << 20},
+		},
+	}, devices[0])
+
+	devices, err = b.devices(false)
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Empty(t, devices[0].processes)
+}
+
+func TestAMDNoGPU(t *testing.T) {
+	_, err := newAMDBackend(resolve.NewTestResolver(t.TempDir()))
+	assert.ErrorContains(t, err, "no AMD GPU found")
+}
+
+func TestParseDRMMemory(t *testing.T) {
+	for s, expected := range map[string]uint64{
+		"512":   512,
+		"512 B": 512,
+		"2 KiB": 2048,
+		"3 MiB": 3 << 20,
+		"1 GiB": 1 << 30,
+		"0 KiB": 0,
+	} {
+		value, err := parseDRMMemory(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, value, s)
+	}
+
+	_, err := parseDRMMemory("1 TB")
+	assert.Error(t, err)
+}
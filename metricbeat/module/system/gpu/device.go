@@ -0,0 +1,96 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package gpu
+
+import (
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/opt"
+)
+
+// device are the metrics of a GPU. Metrics not supported by the GPU are not
+// set.
+type device struct {
+	index  int
+	uuid   string
+	name   string
+	vendor string
+
+	// Utilizations are ratios between 0 and 1.
+	gpuUtilization    opt.Float
+	memoryUtilization opt.Float
+
+	memoryTotal opt.Uint
+	memoryUsed  opt.Uint
+
+	temperatureCelsius opt.Float
+	powerUsageWatts    opt.Float
+	powerLimitWatts    opt.Float
+
+	processes []gpuProcess
+}
+
+// gpuProcess is a process using a GPU, with the GPU memory it uses.
+type gpuProcess struct {
+	pid    int
+	memory uint64
+}
+
+// identity returns the fields identifying the GPU.
+func (d device) identity() mapstr.M {
+	fields := mapstr.M{
+		"index":  d.index,
+		"vendor": d.vendor,
+	}
+	if d.uuid != "" {
+		fields["uuid"] = d.uuid
+	}
+	if d.name != "" {
+		fields["name"] = d.name
+	}
+	return fields
+}
+
+// fields returns the fields of the event of the GPU.
+func (d device) fields() mapstr.M {
+	fields := d.identity()
+	putFloat := func(key string, value opt.Float) {
+		if value.Exists() {
+			_, _ = fields.Put(key, value.ValueOr(0))
+		}
+	}
+	putUint := func(key string, value opt.Uint) {
+		if value.Exists() {
+			_, _ = fields.Put(key, value.ValueOr(0))
+		}
+	}
+
+	putFloat("utilization.gpu.pct", d.gpuUtilization)
+	putFloat("utilization.memory.pct", d.memoryUtilization)
+	putUint("memory.total.bytes", d.memoryTotal)
+	putUint("memory.used.bytes", d.memoryUsed)
+	if total, used := d.memoryTotal.ValueOr(0), d.memoryUsed.ValueOr(0); d.memoryUsed.Exists() && total > 0 && used <= total {
+		_, _ = fields.Put("memory.free.bytes", total-used)
+		_, _ = fields.Put("memory.used.pct", float64(used)/float64(total))
+	}
+	putFloat("temperature.celsius", d.temperatureCelsius)
+	putFloat("power.usage.watts", d.powerUsageWatts)
+	putFloat("power.limit.watts", d.powerLimitWatts)
+	return fields
+}
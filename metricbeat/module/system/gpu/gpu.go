@@ -0,0 +1,201 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package gpu
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+// backend reads the metrics of the GPUs of a vendor.
+type backend interface {
+	// devices returns the metrics of the GPUs, and of their processes if
+	// processes is true.
+	devices(processes bool) ([]device, error)
+	close() error
+}
+
+// backends are the constructors of the backends, by name. They return an error
+// when the backend is not available on the host.
+var backends = map[string]func(resolve.Resolver) (backend, error){
+	"nvml": newNVMLBackend,
+	"amd":  newAMDBackend,
+}
+
+// defaultBackends are the backends used when none is configured.
+var defaultBackends = []string{"nvml", "amd"}
+
+// Config stores the config of the metricset.
+type Config struct {
+	// Backends used to read the metrics, all of them if empty.
+	Backends  []string `config:"gpu.backends"`
+	Processes bool     `config:"gpu.processes.enabled"`
+}
+
+// Validate checks that the configured backends exist.
+func (c *Config) Validate() error {
+	for _, name := range c.Backends {
+		if _, found := backends[name]; !found {
+			return fmt.Errorf("unknown GPU backend '%s', expected nvml or amd", name)
+		}
+	}
+	return nil
+}
+
+func defaultConfig() Config {
+	return Config{
+		Processes: true,
+	}
+}
+
+func init() {
+	mb.Registry.MustAddMetricSet("system", "gpu", New,
+		mb.WithHostParser(parse.EmptyHostParser),
+	)
+}
+
+// MetricSet reports the metrics of the GPUs of the host.
+type MetricSet struct {
+	mb.BaseMetricSet
+	mod      resolve.Resolver
+	config   Config
+	backends []backend
+}
+
+// New creates a new instance of the gpu metricset.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The system gpu metricset is beta.")
+
+	sys, ok := base.Module().(resolve.Resolver)
+	if !ok {
+		return nil, fmt.Errorf("unexpected module type: %T", base.Module())
+	}
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	m := &MetricSet{
+		BaseMetricSet: base,
+		mod:           sys,
+		config:        config,
+	}
+	if err := m.initBackends(); err != nil {
+		m.Logger().Warnf("%v, it will be retried on the next fetch", err)
+	}
+	return m, nil
+}
+
+// initBackends initializes the configured backends, and returns an error if
+// none of them is available on the host.
+func (m *MetricSet) initBackends() error {
+	names := m.config.Backends
+	if len(names) == 0 {
+		names = defaultBackends
+	}
+	var errs []error
+	for _, name := range names {
+		b, err := backends[name](m.mod)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		m.backends = append(m.backends, b)
+	}
+	if len(m.backends) == 0 {
+		return fmt.Errorf("no GPU backend available: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Fetch reports an event for each GPU, and for each process using a GPU. The
+// backends are initialized again until one is available, as drivers can be
+// loaded after Metricbeat is started.
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	if len(m.backends) == 0 {
+		if err := m.initBackends(); err != nil {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, b := range m.backends {
+		devices, err := b.devices(m.config.Processes)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, d := range devices {
+			if !r.Event(mb.Event{MetricSetFields: d.fields()}) {
+				return nil
+			}
+			for _, p := range d.processes {
+				if !r.Event(m.processEvent(d, p)) {
+					return nil
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close releases the resources of the backends.
+func (m *MetricSet) Close() error {
+	var errs []error
+	for _, b := range m.backends {
+		errs = append(errs, b.close())
+	}
+	return errors.Join(errs...)
+}
+
+func (m *MetricSet) processEvent(d device, p gpuProcess) mb.Event {
+	fields := d.identity()
+	fields["process"] = mapstr.M{"memory": mapstr.M{"bytes": p.memory}}
+
+	process := mapstr.M{"pid": p.pid}
+	if name := m.processName(p.pid); name != "" {
+		process["name"] = name
+	}
+	return mb.Event{
+		MetricSetFields: fields,
+		RootFields:      mapstr.M{"process": process},
+	}
+}
+
+// processName returns the name of the process, or an empty string if the
+// process is gone.
+func (m *MetricSet) processName(pid int) string {
+	comm, err := os.ReadFile(m.mod.ResolveHostFS(filepath.Join("/proc", strconv.Itoa(pid), "comm")))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(comm))
+}
@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package gpu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(amdHostFS(t)))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, mapstr.M{
+		"index":  1,
+		"uuid":   "4c2a0e3a1b2d3f4e",
+		"name":   "AMD Instinct MI210",
+		"vendor": "AMD",
+		"utilization": mapstr.M{
+			"gpu":    mapstr.M{"pct": 0.42},
+			"memory": mapstr.M{"pct": 0.1},
+		},
+		"memory": mapstr.M{
+			"total": mapstr.M{"bytes": uint64(68702699520)},
+			"used":  mapstr.M{"bytes": uint64(17175674880), "pct": 0.25},
+			"free":  mapstr.M{"bytes": uint64(51527024640)},
+		},
+		"temperature": mapstr.M{"celsius": 45.0},
+		"power": mapstr.M{
+			"usage": mapstr.M{"watts": 41.0},
+			"limit": mapstr.M{"watts": 300.0},
+		},
+	}, events[0].MetricSetFields)
+
+	assert.Equal(t, mapstr.M{
+		"index":   1,
+		"uuid":    "4c2a0e3a1b2d3f4e",
+		"name":    "AMD Instinct MI210",
+		"vendor":  "AMD",
+		"process": mapstr.M{"memory": mapstr.M{"bytes": uint64(1536 << 20)}},
+	}, events[1].MetricSetFields)
+	assert.Equal(t, mapstr.M{
+		"process": mapstr.M{"pid": 1234, "name": "python3"},
+	}, events[1].RootFields)
+}
+
+func TestFetchWithoutProcesses(t *testing.T) {
+	config := getConfig(amdHostFS(t))
+	config["gpu.processes.enabled"] = false
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	assert.Len(t, events, 1)
+}
+
+func TestFetchNoBackendAvailable(t *testing.T) {
+	hostfs := t.TempDir()
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(hostfs))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "no GPU backend available: amd: no AMD GPU found")
+	assert.Empty(t, events)
+
+	// The backends are initialized once the GPU is available.
+	writeAMDHostFS(t, hostfs)
+	events, errs = mbtest.ReportingFetchV2Error(f)
+	require.Empty(t, errs)
+	assert.Len(t, events, 2)
+}
+
+func TestUnknownBackend(t *testing.T) {
+	config := getConfig(t.TempDir())
+	config["gpu.backends"] = []string{"amd", "intel"}
+	c, err := conf.NewConfigFrom(config)
+	require.NoError(t, err)
+
+	_, _, err = mb.NewModule(c, mb.Registry, logptest.NewTestingLogger(t, ""))
+	assert.ErrorContains(t, err, "unknown GPU backend 'intel'")
+}
+
+func TestData(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(amdHostFS(t)))
+	err := mbtest.WriteEventsReporterV2ErrorCond(f, t, ".", func(e mapstr.M) bool {
+		_, err := e.GetValue("system.gpu.utilization")
+		return err == nil
+	})
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func getConfig(hostfs string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":       "system",
+		"metricsets":   []string{"gpu"},
+		"hostfs":       hostfs,
+		"gpu.backends": []string{"amd"},
+	}
+}
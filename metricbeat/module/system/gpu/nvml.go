@@ -0,0 +1,268 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux && (amd64 || arm64)
+
+package gpu
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ebitengine/purego"
+
+	"github.com/elastic/elastic-agent-libs/opt"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+// nvmlLibraryName is the NVML library, installed with the NVIDIA driver.
+const nvmlLibraryName = "libnvidia-ml.so.1"
+
+// NVML return codes and constants used by the backend, as defined in nvml.h.
+const (
+	nvmlSuccess           nvmlReturn = 0
+	nvmlInsufficientSize  nvmlReturn = 7
+	nvmlTemperatureGPU               = 0
+	nvmlDeviceUUIDSize               = 96
+	nvmlDeviceNameSize               = 96
+	nvmlValueNotAvailable            = ^uint64(0)
+)
+
+// nvmlReturn is the status returned by the NVML functions.
+type nvmlReturn int32
+
+type nvmlUtilization struct {
+	GPU    uint32
+	Memory uint32
+}
+
+type nvmlMemory struct {
+	Total uint64
+	Free  uint64
+	Used  uint64
+}
+
+// nvmlProcessInfo is nvmlProcessInfo_v2_t, used by the v3 process functions.
+type nvmlProcessInfo struct {
+	PID               uint32
+	UsedGPUMemory     uint64
+	GPUInstanceID     uint32
+	ComputeInstanceID uint32
+}
+
+// nvmlLibrary are the NVML functions used by the backend. The library is
+// loaded at runtime, so the backend doesn't need cgo and Metricbeat runs on
+// hosts without NVIDIA drivers.
+type nvmlLibrary struct {
+	Init                        func() nvmlReturn
+	Shutdown                    func() nvmlReturn
+	ErrorString                 func(nvmlReturn) string
+	DeviceGetCount              func(*uint32) nvmlReturn
+	DeviceGetHandleByIndex      func(uint32, *uintptr) nvmlReturn
+	DeviceGetUUID               func(uintptr, *byte, uint32) nvmlReturn
+	DeviceGetName               func(uintptr, *byte, uint32) nvmlReturn
+	DeviceGetUtilizationRates   func(uintptr, *nvmlUtilization) nvmlReturn
+	DeviceGetMemoryInfo         func(uintptr, *nvmlMemory) nvmlReturn
+	DeviceGetTemperature        func(uintptr, uint32, *uint32) nvmlReturn
+	DeviceGetPowerUsage         func(uintptr, *uint32) nvmlReturn
+	DeviceGetEnforcedPowerLimit func(uintptr, *uint32) nvmlReturn
+	DeviceGetComputeProcesses   func(uintptr, *uint32, *nvmlProcessInfo) nvmlReturn
+	DeviceGetGraphicsProcesses  func(uintptr, *uint32, *nvmlProcessInfo) nvmlReturn
+}
+
+// loadNVML loads the NVML library and its functions.
+func loadNVML() (*nvmlLibrary, error) {
+	handle, err := purego.Dlopen(nvmlLibraryName, purego.RTLD_NOW|purego.RTLD_GLOBAL)
+	if err != nil {
+		return nil, fmt.Errorf("error loading %s: %w", nvmlLibraryName, err)
+	}
+
+	lib := &nvmlLibrary{}
+	for name, fptr := range map[string]interface{}{
+		"nvmlInit_v2":                              &lib.Init,
+		"nvmlShutdown":                             &lib.Shutdown,
+		"nvmlErrorString":                          &lib.ErrorString,
+		"nvmlDeviceGetCount_v2":                    &lib.DeviceGetCount,
+		"nvmlDeviceGetHandleByIndex_v2":            &lib.DeviceGetHandleByIndex,
+		"nvmlDeviceGetUUID":                        &lib.DeviceGetUUID,
+		"nvmlDeviceGetName":                        &lib.DeviceGetName,
+		"nvmlDeviceGetUtilizationRates":            &lib.DeviceGetUtilizationRates,
+		"nvmlDeviceGetMemoryInfo":                  &lib.DeviceGetMemoryInfo,
+		"nvmlDeviceGetTemperature":                 &lib.DeviceGetTemperature,
+		"nvmlDeviceGetPowerUsage":                  &lib.DeviceGetPowerUsage,
+		"nvmlDeviceGetEnforcedPowerLimit":          &lib.DeviceGetEnforcedPowerLimit,
+		"nvmlDeviceGetComputeRunningProcesses_v3":  &lib.DeviceGetComputeProcesses,
+		"nvmlDeviceGetGraphicsRunningProcesses_v3": &lib.DeviceGetGraphicsProcesses,
+	} {
+		sym, err := purego.Dlsym(handle, name)
+		if err != nil {
+			// The v3 process functions are missing in drivers older
+			// than 510, processes are not reported with them.
+			if name == "nvmlDeviceGetComputeRunningProcesses_v3" || name == "nvmlDeviceGetGraphicsRunningProcesses_v3" {
+				continue
+			}
+			return nil, fmt.Errorf("error loading NVML function %s: %w", name, err)
+		}
+		purego.RegisterFunc(fptr, sym)
+	}
+	return lib, nil
+}
+
+// nvmlBackend reads the metrics of NVIDIA GPUs with NVML.
+type nvmlBackend struct {
+	lib *nvmlLibrary
+}
+
+func newNVMLBackend(_ resolve.Resolver) (backend, error) {
+	lib, err := loadNVML()
+	if err != nil {
+		return nil, err
+	}
+	return initNVML(lib)
+}
+
+func initNVML(lib *nvmlLibrary) (*nvmlBackend, error) {
+	b := &nvmlBackend{lib: lib}
+	if ret := lib.Init(); ret != nvmlSuccess {
+		return nil, fmt.Errorf("error initializing NVML: %w", b.error(ret))
+	}
+	return b, nil
+}
+
+func (b *nvmlBackend) error(ret nvmlReturn) error {
+	return errors.New(b.lib.ErrorString(ret))
+}
+
+func (b *nvmlBackend) devices(processes bool) ([]device, error) {
+	var count uint32
+	if ret := b.lib.DeviceGetCount(&count); ret != nvmlSuccess {
+		return nil, fmt.Errorf("error getting the number of NVIDIA GPUs: %w", b.error(ret))
+	}
+
+	devices := make([]device, 0, count)
+	for i := uint32(0); i < count; i++ {
+		var handle uintptr
+		if ret := b.lib.DeviceGetHandleByIndex(i, &handle); ret != nvmlSuccess {
+			return nil, fmt.Errorf("error getting NVIDIA GPU %d: %w", i, b.error(ret))
+		}
+		d := b.device(handle)
+		d.index = int(i)
+		if processes {
+			d.processes = b.processes(handle)
+		}
+		devices = append(devices, d)
+	}
+	return devices, nil
+}
+
+// device reads the metrics of the GPU. Metrics that are not supported by the
+// GPU, or that can't be read, are not set.
+func (b *nvmlBackend) device(handle uintptr) device {
+	d := device{vendor: "NVIDIA"}
+
+	buf := make([]byte, max(nvmlDeviceUUIDSize, nvmlDeviceNameSize))
+	if ret := b.lib.DeviceGetUUID(handle, &buf[0], nvmlDeviceUUIDSize); ret == nvmlSuccess {
+		d.uuid = cString(buf)
+	}
+	if ret := b.lib.DeviceGetName(handle, &buf[0], nvmlDeviceNameSize); ret == nvmlSuccess {
+		d.name = cString(buf)
+	}
+
+	var rates nvmlUtilization
+	if ret := b.lib.DeviceGetUtilizationRates(handle, &rates); ret == nvmlSuccess {
+		d.gpuUtilization = opt.FloatWith(float64(rates.GPU) / 100)
+		d.memoryUtilization = opt.FloatWith(float64(rates.Memory) / 100)
+	}
+	var memory nvmlMemory
+	if ret := b.lib.DeviceGetMemoryInfo(handle, &memory); ret == nvmlSuccess {
+		d.memoryTotal = opt.UintWith(memory.Total)
+		d.memoryUsed = opt.UintWith(memory.Used)
+	}
+
+	var value uint32
+	if ret := b.lib.DeviceGetTemperature(handle, nvmlTemperatureGPU, &value); ret == nvmlSuccess {
+		d.temperatureCelsius = opt.FloatWith(float64(value))
+	}
+	// Power is reported in milliwatts.
+	if ret := b.lib.DeviceGetPowerUsage(handle, &value); ret == nvmlSuccess {
+		d.powerUsageWatts = opt.FloatWith(float64(value) / 1000)
+	}
+	if ret := b.lib.DeviceGetEnforcedPowerLimit(handle, &value); ret == nvmlSuccess {
+		d.powerLimitWatts = opt.FloatWith(float64(value) / 1000)
+	}
+	return d
+}
+
+// processes returns the compute and graphics processes using the GPU. Processes
+// doing both are reported once.
+func (b *nvmlBackend) processes(handle uintptr) []gpuProcess {
+	var processes []gpuProcess
+	seen := map[uint32]bool{}
+	for _, get := range []func(uintptr, *uint32, *nvmlProcessInfo) nvmlReturn{
+		b.lib.DeviceGetComputeProcesses,
+		b.lib.DeviceGetGraphicsProcesses,
+	} {
+		if get == nil {
+			continue
+		}
+		for _, info := range runningProcesses(get, handle) {
+			// The memory of the processes is not available in some
+			// configurations, like with MIG devices.
+			if seen[info.PID] || info.UsedGPUMemory == nvmlValueNotAvailable {
+				continue
+			}
+			seen[info.PID] = true
+			processes = append(processes, gpuProcess{pid: int(info.PID), memory: info.UsedGPUMemory})
+		}
+	}
+	return processes
+}
+
+// runningProcesses calls a function returning the processes running in a GPU,
+// first to get their number, then to get them.
+func runningProcesses(get func(uintptr, *uint32, *nvmlProcessInfo) nvmlReturn, handle uintptr) []nvmlProcessInfo {
+	// No processes are running when the first call succeeds.
+	var count uint32
+	if ret := get(handle, &count, nil); ret != nvmlInsufficientSize {
+		return nil
+	}
+
+	// Leave room for processes started between the calls.
+	count += 8
+	infos := make([]nvmlProcessInfo, count)
+	if ret := get(handle, &count, &infos[0]); ret != nvmlSuccess {
+		return nil
+	}
+	return infos[:count]
+}
+
+func (b *nvmlBackend) close() error {
+	if ret := b.lib.Shutdown(); ret != nvmlSuccess {
+		return fmt.Errorf("error shutting down NVML: %w", b.error(ret))
+	}
+	return nil
+}
+
+// cString returns the NUL-terminated string in the buffer.
+func cString(buf []byte) string {
+	for i, b := range buf {
+		if b == 0 {
+			return string(buf[:i])
+		}
+	}
+	return string(buf)
+}
@@ -0,0 +1,182 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux && (amd64 || arm64)
+
+package gpu
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/opt"
+)
+
+const (
+	nvmlErrorNotSupported    nvmlReturn = 3
+	nvmlErrorLibraryNotFound nvmlReturn = 12
+)
+
+// fakeNVML returns an NVML library with two GPUs. The second one doesn't
+// support power readings and has no processes.
+func fakeNVML(shutdowns *int) *nvmlLibrary {
+	putString := func(s string, buf *byte, size uint32) nvmlReturn {
+		copy(unsafe.Slice(buf, size), s+"\x00")
+		return nvmlSuccess
+	}
+	processes := func(running []nvmlProcessInfo) func(uintptr, *uint32, *nvmlProcessInfo) nvmlReturn {
+		return func(handle uintptr, count *uint32, infos *nvmlProcessInfo) nvmlReturn {
+			if handle == 2 {
+				*count = 0
+				return nvmlSuccess
+			}
+			if *count < uint32(len(running)) {
+				*count = uint32(len(running))
+				return nvmlInsufficientSize
+			}
+			*count = uint32(copy(unsafe.Slice(infos, *count), running))
+			return nvmlSuccess
+		}
+	}
+
+	return &nvmlLibrary{
+		Init: func() nvmlReturn { return nvmlSuccess },
+		Shutdown: func() nvmlReturn {
+			*shutdowns++
+			return nvmlSuccess
+		},
+		ErrorString: func(ret nvmlReturn) string {
+			if ret == nvmlErrorLibraryNotFound {
+				return "NVML Shared Library Not Found"
+			}
+			return "Unknown Error"
+		},
+		DeviceGetCount: func(count *uint32) nvmlReturn {
+			*count = 2
+			return nvmlSuccess
+		},
+		DeviceGetHandleByIndex: func(i uint32, handle *uintptr) nvmlReturn {
+			*handle = uintptr(i + 1)
+			return nvmlSuccess
+		},
+		DeviceGetUUID: func(handle uintptr, buf *byte, size uint32) nvmlReturn {
+			if handle == 2 {
+				return putString("GPU-1a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d", buf, size)
+			}
+			return putString("GPU-0a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d", buf, size)
+		},
+		DeviceGetName: func(_ uintptr, buf *byte, size uint32) nvmlReturn {
+			return putString("NVIDIA A100-SXM4-40GB", buf, size)
+		},
+		DeviceGetUtilizationRates: func(_ uintptr, rates *nvmlUtilization) nvmlReturn {
+			*rates = nvmlUtilization{GPU: 75, Memory: 30}
+			return nvmlSuccess
+		},
+		DeviceGetMemoryInfo: func(_ uintptr, memory *nvmlMemory) nvmlReturn {
+			*memory = nvmlMemory{Total: 40 << 30, Free: 30 << 30, Used: 10 << 30}
+			return nvmlSuccess
+		},
+		DeviceGetTemperature: func(_ uintptr, _ uint32, temperature *uint32) nvmlReturn {
+			*temperature = 60
+			return nvmlSuccess
+		},
+		DeviceGetPowerUsage: func(handle uintptr, power *uint32) nvmlReturn {
+			if handle == 2 {
+				return nvmlErrorNotSupported
+			}
+			*power = 250500
+			return nvmlSuccess
+		},
+		DeviceGetEnforcedPowerLimit: func(handle uintptr, power *uint32) nvmlReturn {
+			if handle == 2 {
+				return nvmlErrorNotSupported
+			}
+			*power = 400000
+			return nvmlSuccess
+		},
+		DeviceGetComputeProcesses: processes([]nvmlProcessInfo{
+			{PID: 1234, UsedGPUMemory: 8 << 30},
+			{PID: 1235, UsedGPUMemory: nvmlValueNotAvailable},
+		}),
+		DeviceGetGraphicsProcesses: processes([]nvmlProcessInfo{
+			{PID: 1234, UsedGPUMemory: 8 << 30},
+			{PID: 4321, UsedGPUMemory: 2 << 30},
+		}),
+	}
+}
+
+func TestNVMLDevices(t *testing.T) {
+	var shutdowns int
+	b, err := initNVML(fakeNVML(&shutdowns))
+	require.NoError(t, err)
+
+	devices, err := b.devices(true)
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+
+	assert.Equal(t, device{
+		index:              0,
+		uuid:               "GPU-0a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d",
+		name:               "NVIDIA A100-SXM4-40GB",
+		vendor:             "NVIDIA",
+		gpuUtilization:     opt.FloatWith(0.75),
+		memoryUtilization:  opt.FloatWith(0.3),
+		memoryTotal:        opt.UintWith(40 << 30),
+		memoryUsed:         opt.UintWith(10 << 30),
+		temperatureCelsius: opt.FloatWith(60),
+		powerUsageWatts:    opt.FloatWith(250.5),
+		powerLimitWatts:    opt.FloatWith(400),
+		processes: []gpuProcess{
+			{pid: 1234, memory: 8 << 30},
+			{pid: 4321, memory: 2 << 30},
+		},
+	}, devices[0])
+
+	assert.Equal(t, 1, devices[1].index)
+	assert.Equal(t, "GPU-1a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d", devices[1].uuid)
+	assert.False(t, devices[1].powerUsageWatts.Exists())
+	assert.False(t, devices[1].powerLimitWatts.Exists())
+	assert.Empty(t, devices[1].processes)
+
+	require.NoError(t, b.close())
+	assert.Equal(t, 1, shutdowns)
+}
+
+func TestNVMLWithoutProcessFunctions(t *testing.T) {
+	var shutdowns int
+	lib := fakeNVML(&shutdowns)
+	lib.DeviceGetComputeProcesses = nil
+	lib.DeviceGetGraphicsProcesses = nil
+	b, err := initNVML(lib)
+	require.NoError(t, err)
+
+	devices, err := b.devices(true)
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Empty(t, devices[0].processes)
+}
+
+func TestNVMLInitError(t *testing.T) {
+	var shutdowns int
+	lib := fakeNVML(&shutdowns)
+	lib.Init = func() nvmlReturn { return nvmlErrorLibraryNotFound }
+	_, err := initNVML(lib)
+	assert.EqualError(t, err, "error initializing NVML: NVML Shared Library Not Found")
+}
@@ -0,0 +1,225 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// Inventory keeps the properties of the managed objects of a type up to date
+// between fetches. It keeps a session open with vSphere, and a property collector
+// reports the objects that were created, modified or removed since the previous
+// update, instead of retrieving the whole inventory on every fetch.
+type Inventory struct {
+	logger   *logp.Logger
+	hostURL  *url.URL
+	insecure bool
+	kind     string
+	props    []string
+
+	client    *govmomi.Client
+	collector *property.Collector
+	view      *view.ContainerView
+	version   string
+	objects   map[types.ManagedObjectReference]mo.Reference
+}
+
+// NewInventory creates an inventory of the managed objects of the given kind, like
+// VirtualMachine, with the given properties. The session is opened on the first update.
+func NewInventory(logger *logp.Logger, hostURL *url.URL, insecure bool, kind string, props []string) *Inventory {
+	return &Inventory{
+		logger:   logger,
+		hostURL:  hostURL,
+		insecure: insecure,
+		kind:     kind,
+		props:    props,
+	}
+}
+
+// Update applies the changes of the inventory since the previous update. The
+// session is opened again on the next update if it fails.
+func (i *Inventory) Update(ctx context.Context) error {
+	if i.client == nil {
+		if err := i.connect(ctx); err != nil {
+			i.Close()
+			return err
+		}
+	}
+
+	if err := i.waitForUpdates(ctx); err != nil {
+		i.Close()
+		return err
+	}
+	return nil
+}
+
+// Client returns the client of the session of the inventory.
+func (i *Inventory) Client() *vim25.Client {
+	if i.client == nil {
+		return nil
+	}
+	return i.client.Client
+}
+
+// Objects returns the managed objects of the inventory, sorted by their ID. The
+// objects are pointers to the types of the vim25/mo package, like *mo.VirtualMachine.
+func (i *Inventory) Objects() []mo.Reference {
+	objects := make([]mo.Reference, 0, len(i.objects))
+	for _, obj := range i.objects {
+		objects = append(objects, obj)
+	}
+	sort.Slice(objects, func(a, b int) bool {
+		return objects[a].Reference().Value < objects[b].Reference().Value
+	})
+	return objects
+}
+
+// Close destroys the property collector and the view of the inventory, and
+// closes its session.
+func (i *Inventory) Close() error {
+	if i.client == nil {
+		return nil
+	}
+
+	ctx := context.Background()
+	if i.collector != nil {
+		if err := i.collector.Destroy(ctx); err != nil {
+			i.logger.Debugf("error destroying property collector from vsphere: %v", err)
+		}
+	}
+	if i.view != nil {
+		if err := i.view.Destroy(ctx); err != nil {
+			i.logger.Debugf("error destroying view from vsphere: %v", err)
+		}
+	}
+	err := Logout(ctx, i.client)
+
+	i.client = nil
+	i.collector = nil
+	i.view = nil
+	i.version = ""
+	i.objects = nil
+	return err
+}
+
+func (i *Inventory) connect(ctx context.Context) error {
+	client, err := govmomi.NewClient(ctx, i.hostURL, i.insecure)
+	if err != nil {
+		return fmt.Errorf("error in NewClient: %w", err)
+	}
+	i.client = client
+	c := client.Client
+
+	// Updates are reported by a collector of the session, so they are not
+	// consumed by the other users of the default collector.
+	i.collector, err = property.DefaultCollector(c).Create(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating property collector: %w", err)
+	}
+
+	i.view, err = view.NewManager(c).CreateContainerView(ctx, c.ServiceContent.RootFolder, []string{i.kind}, true)
+	if err != nil {
+		return fmt.Errorf("error in CreateContainerView: %w", err)
+	}
+
+	filter := new(property.WaitFilter).Add(i.view.Reference(), i.kind, i.props, i.view.TraversalSpec())
+	if _, err := i.collector.CreateFilter(ctx, filter.CreateFilter); err != nil {
+		return fmt.Errorf("error creating property filter: %w", err)
+	}
+
+	i.objects = make(map[types.ManagedObjectReference]mo.Reference)
+	return nil
+}
+
+func (i *Inventory) waitForUpdates(ctx context.Context) error {
+	// Only pending updates are returned, without waiting for new ones.
+	maxWait := int32(0)
+	req := types.WaitForUpdatesEx{
+		This:    i.collector.Reference(),
+		Version: i.version,
+		Options: &types.WaitOptions{MaxWaitSeconds: &maxWait},
+	}
+
+	for {
+		res, err := methods.WaitForUpdatesEx(ctx, i.client.Client, &req)
+		if err != nil {
+			return fmt.Errorf("error waiting for updates: %w", err)
+		}
+
+		set := res.Returnval
+		if set == nil {
+			return nil
+		}
+
+		for _, fs := range set.FilterSet {
+			if err := i.apply(fs.ObjectSet); err != nil {
+				return err
+			}
+		}
+
+		i.version = set.Version
+		req.Version = set.Version
+		if set.Truncated == nil || !*set.Truncated {
+			return nil
+		}
+	}
+}
+
+func (i *Inventory) apply(updates []types.ObjectUpdate) error {
+	for _, update := range updates {
+		// The container view is part of the filter to find the objects, but
+		// only its objects are part of the inventory.
+		if update.Obj.Type != i.kind {
+			continue
+		}
+
+		switch update.Kind {
+		case types.ObjectUpdateKindEnter:
+			obj, err := mo.ObjectContentToType(types.ObjectContent{Obj: update.Obj}, true)
+			if err != nil {
+				return fmt.Errorf("error creating %s %s: %w", i.kind, update.Obj.Value, err)
+			}
+			ref, ok := obj.(mo.Reference)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for %s %s", obj, i.kind, update.Obj.Value)
+			}
+			mo.ApplyPropertyChange(ref, update.ChangeSet)
+			i.objects[update.Obj] = ref
+		case types.ObjectUpdateKindModify:
+			if ref, ok := i.objects[update.Obj]; ok {
+				mo.ApplyPropertyChange(ref, update.ChangeSet)
+			}
+		case types.ObjectUpdateKindLeave:
+			delete(i.objects, update.Obj)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+func TestInventoryUpdate(t *testing.T) {
+	model := simulator.ESX()
+	require.NoError(t, model.Create())
+	defer model.Remove()
+
+	ts := model.Service.NewServer()
+	defer ts.Close()
+
+	ctx := context.Background()
+	inventory := NewInventory(logptest.NewTestingLogger(t, ""), ts.URL, true, "VirtualMachine", []string{"name", "runtime.powerState"})
+	defer inventory.Close()
+
+	require.NoError(t, inventory.Update(ctx))
+	objects := inventory.Objects()
+	require.Len(t, objects, 2)
+	for _, obj := range objects {
+		vm, ok := obj.(*mo.VirtualMachine)
+		require.True(t, ok)
+		assert.Contains(t, vm.Name, "ha-host_VM")
+		assert.Equal(t, types.VirtualMachinePowerStatePoweredOn, vm.Runtime.PowerState)
+	}
+
+	// Changes made after the first update are applied on the next one.
+	first := objects[0].(*mo.VirtualMachine)
+	vm := object.NewVirtualMachine(inventory.Client(), first.Reference())
+	task, err := vm.PowerOff(ctx)
+	require.NoError(t, err)
+	require.NoError(t, task.Wait(ctx))
+
+	require.NoError(t, inventory.Update(ctx))
+	objects = inventory.Objects()
+	require.Len(t, objects, 2)
+	assert.Equal(t, types.VirtualMachinePowerStatePoweredOff, objects[0].(*mo.VirtualMachine).Runtime.PowerState)
+	assert.Equal(t, types.VirtualMachinePowerStatePoweredOn, objects[1].(*mo.VirtualMachine).Runtime.PowerState)
+
+	task, err = vm.Destroy(ctx)
+	require.NoError(t, err)
+	require.NoError(t, task.Wait(ctx))
+
+	require.NoError(t, inventory.Update(ctx))
+	objects = inventory.Objects()
+	require.Len(t, objects, 1)
+	assert.NotEqual(t, first.Reference(), objects[0].Reference())
+}
+
+func TestInventoryReconnect(t *testing.T) {
+	model := simulator.ESX()
+	require.NoError(t, model.Create())
+	defer model.Remove()
+
+	ts := model.Service.NewServer()
+	defer ts.Close()
+
+	ctx := context.Background()
+	inventory := NewInventory(logptest.NewTestingLogger(t, ""), ts.URL, true, "VirtualMachine", []string{"name"})
+	defer inventory.Close()
+
+	require.NoError(t, inventory.Update(ctx))
+	require.Len(t, inventory.Objects(), 2)
+
+	// The session is opened again, with the whole inventory, after it is closed.
+	require.NoError(t, inventory.Close())
+	assert.Nil(t, inventory.Client())
+	assert.Empty(t, inventory.Objects())
+
+	require.NoError(t, inventory.Update(ctx))
+	assert.Len(t, inventory.Objects(), 2)
+}
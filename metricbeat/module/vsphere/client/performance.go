@@ -56,6 +56,61 @@ func (p *PerformanceDataFetcher) GetPerfMetrics(ctx context.Context,
 
 	metricMap = make(map[string]interface{})
 
+	results, err := p.queryMetricSeries(ctx, period, objectReference, metrics, metricSet)
+	if err != nil || len(results) == 0 {
+		return metricMap, err
+	}
+
+	for _, result := range results[0].Value {
+		if value, ok := metricValue(result); ok {
+			metricMap[result.Name] = value
+			continue
+		}
+		p.logger.Debugf("For %s %s, Metric %s: No result found", objectType, objectName, result.Name)
+	}
+
+	return metricMap, nil
+}
+
+// GetPerfMetricsByInstance returns the metrics of each instance of the object, like
+// the virtual disks or the network interfaces of a virtual machine. Metrics
+// aggregated for the whole object are reported with an empty instance.
+func (p *PerformanceDataFetcher) GetPerfMetricsByInstance(ctx context.Context,
+	period int32,
+	objectType string,
+	objectName string,
+	objectReference types.ManagedObjectReference,
+	metrics map[string]*types.PerfCounterInfo,
+	metricSet map[string]struct{}) (map[string]map[string]interface{}, error) {
+
+	instanceMap := make(map[string]map[string]interface{})
+
+	results, err := p.queryMetricSeries(ctx, period, objectReference, metrics, metricSet)
+	if err != nil || len(results) == 0 {
+		return instanceMap, err
+	}
+
+	for _, result := range results[0].Value {
+		value, ok := metricValue(result)
+		if !ok {
+			p.logger.Debugf("For %s %s, Metric %s of instance %q: No result found", objectType, objectName, result.Name, result.Instance)
+			continue
+		}
+		if _, found := instanceMap[result.Instance]; !found {
+			instanceMap[result.Instance] = make(map[string]interface{})
+		}
+		instanceMap[result.Instance][result.Name] = value
+	}
+
+	return instanceMap, nil
+}
+
+func (p *PerformanceDataFetcher) queryMetricSeries(ctx context.Context,
+	period int32,
+	objectReference types.ManagedObjectReference,
+	metrics map[string]*types.PerfCounterInfo,
+	metricSet map[string]struct{}) ([]performance.EntityMetric, error) {
+
 	availableMetric, err := p.perfManager.AvailableMetric(ctx, objectReference, period)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get available metrics: %w", err)
@@ -89,39 +144,38 @@ func (p *PerformanceDataFetcher) GetPerfMetrics(ctx context.Context,
 	samples, err := p.perfManager.Query(ctx, []types.PerfQuerySpec{spec})
 	if err != nil {
 		if strings.Contains(err.Error(), "ServerFaultCode: A specified parameter was not correct: querySpec.interval") {
-			return metricMap, fmt.Errorf("failed to query performance data: use one of the system's supported interval. consider adjusting period: %w", err)
+			return nil, fmt.Errorf("failed to query performance data: use one of the system's supported interval. consider adjusting period: %w", err)
 		}
 
-		return metricMap, fmt.Errorf("failed to query performance data: %w", err)
+		return nil, fmt.Errorf("failed to query performance data: %w", err)
 	}
 
 	if len(samples) == 0 {
 		p.logger.Debug("No samples returned from performance manager")
-		return metricMap, nil
+		return nil, nil
 	}
 
 	results, err := p.perfManager.ToMetricSeries(ctx, samples)
 	if err != nil {
-		return metricMap, fmt.Errorf("failed to convert performance data to metric series: %w", err)
+		return nil, fmt.Errorf("failed to convert performance data to metric series: %w", err)
 	}
 
 	if len(results) == 0 {
 		p.logger.Debug("No results returned from metric series conversion")
-		return metricMap, nil
 	}
 
-	for _, result := range results[0].Value {
-		if len(result.Value) > 0 {
-			value := result.Value[0]
-			if result.Unit == string(types.PerformanceManagerUnitPercent) {
-				metricMap[result.Name] = float64(value) / 100.0
-			} else {
-				metricMap[result.Name] = value
-			}
-			continue
-		}
-		p.logger.Debugf("For %s %s, Metric %s: No result found", objectType, objectName, result.Name)
-	}
+	return results, nil
+}
 
-	return metricMap, nil
+// metricValue returns the latest value of the series, percentages are converted from
+// hundredths of a percent.
+func metricValue(series performance.MetricSeries) (interface{}, bool) {
+	if len(series.Value) == 0 {
+		return nil, false
+	}
+	value := series.Value[0]
+	if series.Unit == string(types.PerformanceManagerUnitPercent) {
+		return float64(value) / 100.0, true
+	}
+	return value, true
 }
@@ -244,3 +244,74 @@ func TestGetPerfMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPerfMetricsByInstance(t *testing.T) {
+	var tPeriod int32 = 20
+	tObjRef := types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-1"}
+	metrics := map[string]*types.PerfCounterInfo{
+		"virtualDisk.read.average": {
+			Key: 111,
+		},
+	}
+	metricSet := map[string]struct{}{
+		"virtualDisk.read.average": {},
+	}
+
+	mSamples := []types.BasePerfEntityMetricBase{
+		&types.PerfEntityMetric{},
+	}
+
+	ctrl := gomock.NewController(t)
+	mPerfManager := NewMockPerfManager(ctrl)
+	mPerfManager.EXPECT().AvailableMetric(gomock.Any(), tObjRef, tPeriod).Return([]types.PerfMetricId{
+		{
+			CounterId: 111,
+			Instance:  "scsi0:0",
+		},
+	}, nil)
+	mPerfManager.EXPECT().Query(gomock.Any(), []types.PerfQuerySpec{
+		{
+			Entity: tObjRef,
+			MetricId: []types.PerfMetricId{
+				{
+					CounterId: 111,
+					Instance:  "*",
+				},
+			},
+			MaxSample:  1,
+			IntervalId: tPeriod,
+		},
+	}).Return(mSamples, nil)
+	mPerfManager.EXPECT().ToMetricSeries(gomock.Any(), mSamples).Return([]performance.EntityMetric{
+		{
+			Entity: tObjRef,
+			Value: []performance.MetricSeries{
+				{
+					Name:     "virtualDisk.read.average",
+					Unit:     string(types.PerformanceManagerUnitKiloBytesPerSecond),
+					Instance: "scsi0:0",
+					Value:    []int64{10},
+				},
+				{
+					Name:     "virtualDisk.read.average",
+					Unit:     string(types.PerformanceManagerUnitKiloBytesPerSecond),
+					Instance: "scsi0:1",
+					Value:    []int64{20},
+				},
+				{
+					Name:     "virtualDisk.read.average",
+					Unit:     string(types.PerformanceManagerUnitKiloBytesPerSecond),
+					Instance: "scsi0:2",
+				},
+			},
+		},
+	}, nil)
+
+	manager := NewPerformanceDataFetcher(logptest.NewTestingLogger(t, ""), mPerfManager)
+	instanceMap, err := manager.GetPerfMetricsByInstance(context.Background(), tPeriod, "virtualMachine", "vm", tObjRef, metrics, metricSet)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"scsi0:0": {"virtualDisk.read.average": int64(10)},
+		"scsi0:1": {"virtualDisk.read.average": int64(20)},
+	}, instanceMap)
+}
@@ -32,5 +32,5 @@ func init() {
 // AssetVsphere returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/vsphere.
 func AssetVsphere() string {
-	return "eJzUXEtv4zgSvudXFOay3Yu0957DAr0JZtLApnuQpLPHgKbKFjcUqSEpG+5fPyCpl62HZZlS4hxycBzWV08Wi1X8Am+4u4GNTmNUeAVgmOF4A79tntwnv10BRKipYqlhUtzAv68AAPK/QiKjjNt/U8iRaLyBNbkCWDHkkb5xX/0CgiRYJ2F/zC61X1YyS/NPWqjsL1RfjPJMG1Tl520L2p8S1hINqX3eSsz/3PqlgYmVVAmxX1nUvnCIqI4qIoZoI0sm+7F1rVZf0f7Wjb8WK77hbitV1PL3Hv6Kn/8ybUCugHAOJsYKvCcKRGtJGTEYwZaZ2H0nF/uiEy+VmTCdeLkU63Fgv2fJEpWFW8I8AWGlH/1KpVixQxTjFUSihGltbYRKYZTkCxRkybFNKZ7KUkqORIyTwzcRMUoMatjGaGJUoI1i1FQ4IMcBTEMOpVtdM2Mtosb9V1ghMZnCTpQFwljqQ3sar6zprdPCHek6M7q6RXmqlxcw2SGGPnxHsP0U7K+spAff7tpJ2t/hiBbx3a7aQQ/NVqq3CzK7HPHHt7wc6FjjM4qt16gweiWcqEQv/tmqJLn8P9JDifsPX8+wnENmSjSQo2mkKW0JQbsd9ZBuW2Q/3Sp++nITSlJCmdktVgpxsdyZhso7zfGIWH5XiOAWtMLZSyUObc5nVDfQJN+AaaQhPCjOZ7tieKCZxigozp8ao4lgpg2v8CA1JRyj1xWX5PALR8D+iYqiMGSNe8lZSRcc3Q7Yqf/n9lyN6bdFiT6kfG9dBphZv9XsFwYQ8j7WTJN1WA97jhFIYncRC9aCtPIuRUwzpVAYvoMlMrG2GaDOEmdEZ3OVKrlhNq8MbORfG+xoNEA0i9BCs2Zj4ZNzGFhpiy5c5vA746h32mDiVulOWBdtO/5YSR0klz1U2/bxgNtdlTXOlhlW8WSe3PBu7wzcTlEhCesKj8S4IGQXtu5rmYaVksm5YUkbYrKABvHk1jsSLy8tVatj3iQTee7LQzfFib325aHPZ7eKmbB7VWHNduXSmo0cY8uNLHrCql/l+bcNIn1p9aRBb96D8XPcRrs7EE6SnPkkvUxtXKxhukwSUhldAxPeUEZkBArxVaeEBs7PHGi7OLjFQYqgsCu/mThYDKkBN0ykq9ZaYJ4mpNbqwEycAuxSNqaOCujJ5YN7m7bVLjN8alGUYlFsmJIiQWEW59UX0syfMZP4V8iD8O2fP62CH+5/dUShNMtrBeEIe38eQtkVU8IRdqWUY3TfKxq8u59LUZ5DOrB83HO4VAV8FMaiG3ccj3DDKHJiUNDdgmzQIlgkwU7lfsEak4YlCMyAIW+obR5HZZJyNAhEwNPt0zf7QUJE5MNKGu80o4SDB2rtOGGcM41UiqjLhCxnBU+5L4fi6J6tY9QG8uVhQ3iGQKiSWjtDt8S1q1JZNZXn3OHAg58HCyWI0gd8MdASqp0LmX4DJDQGD3GkQYU/AHTBt5QMivIsEAZ/+DLxU5a4uGPxOZFb43ZiAuVuM2ugz8jpJjg4OMPtOiskmEg1VfX/wS1+hjRydFMV/UPhm6bWfza6sOfA+6LO13s7+ho4+fhevxnspTx12iOGI5km5Slvc48mPAWOJRHRlkUmXhhFhE5spJ1mU7IhEIiBbcxo7Is7W6KhRhaiTNnsxyJnwqDaEL6AZ3scVpgq1CiMdn8tURclxYKd0x2gIQeFFNlmXiEUND+GBCbbGQv/qOvc7pEl+36bLMJZqzRG85cS+oZG79n5NF6YU6qz2e+EBbTS9KbFVZIZBAqVkmpOsbl6kad6ughztDNJsg51nFSdq4UE6dMW0Qd1GMIk44ZRos0Mqi9pjfedCu7Eum9iPU3zNblOrvwG1mEQIyXTFKMZFF/fCQpxFtRPgjqx0ssd6iyQ83l7L7zQt7vPMYK0CQ7nECPhJs5JuNy4rIrYbfxI8fjyau11zFlqWMdxalzF0NlLvuy+LPPikovqp4r2vW+OJ7sr3zBlMsIhITRmoqvs292rG+xKOD+RflGu3hqNGQghlKLWbMnb7am9vf6IpJpt9cSAZc6AFEX1UgOtutyMBNfGFaG/lWw9ZFTNyvb/FqGjSxO11WneTRUTDREapO4oUUL311VM66yn16oV4gcbtfnuLlPzI54fEaBSCM+vq4d2aQXeYXphILQJC5nFCXOevgernd7z91Q77h8o7HZb7LZDIIRtaHzepYecwydcrBfXRfD79GSIiIiKPl/DHdNGsWVmMHrxMTqVynzu2h4uzjEbG8+H9NGxKC8tJavaLbXMFMVUSj7FLv+Yrw+WwMl9Ekc6IoL5gL/LbjYaQADb+g/RjLqugxSVY19QdMGOacOovm7tRqijizAhoq3z42PAczfuHJPDUYM6vjalQI9i6uu3832U94H8Q6PxwMqicIuC+2swMTHe/18e/Fil49qGhWsgGijhNPOZ7HIHd49PTYHBwWbHaIdOQ/Pmie0xVteamwggh6FvtnRE7YWI/gvWsB4/ibmuM9Sm9WJgn8b5qv3DUsoFA5lhnP3yofXAe1uvPUuWOu8GDhlzJ8Q5+HI3pJOyNVfomkNa+8HLi62ST7DAdYql5MFtLu7z8JZbzPAIF8qKUsU2pKPN5yivA/i0PNqzgPWBbhUzDWtFRJ6pNpj1+ZaQ4ouOic0I48rL2tgfxrpf6xI4r7Fb/lcuiSWaLaJojpGcJIktccXkCxBFUSb5h3aofVP9eM6XhHPZOcEYivf6LGlOESLFNu4pmVN9exhjcoMqRtL9fsclbg1VfPxUSOizt50t4xyWWDZrNq2HLOUGgRntk5xqxb8yplygDZBmFG29s20flYQKhbd4V2fzsZGgMmuALw/n7SJUJqlC3dUCd5TrgX5UcVsRLBRZMVnvrX55GOtM0w1SiVpRce/8cLmXeQFLeu9QJstvkXp0ATPXHEdAvNQiXs5qzunRMl4P/c6VTp9YCnp71Cg7QBhr8dNbPU94WS5awldA8vVQ1t2KOkHd5XB3n+c66OWAancntAwYsA+p/khRETdA/eTvSHvmcCeevItnn7x7RI1qMxBC4BG8rxvCOFlyHETc1+Xb3rE5/2kdS9+tD0QDKR7LsfRa4dRHFLpLfGdZRJ6FyVVe2QszT9FZtguENR+ADTKaEl6w3uKDS9Z5RXi0btpnAjOYzovy4ZrSkXIqbkPzbw1UjTJSASndv63yVLp+po1MXn1SMGfqdesI59nIR5vPuajnb/eu/qtJ59qLB8V1+n7K+fHevh2Cs7tdDi5CQW0PXH48/YxFOVUV4Md+BYCIaK8KMOg+NXg7bNUImzeZVo2wHVIRJNWxDHhWEyvZODFDf+iGYeG7IYM7NITx0o4LXnT5ss181uvKUqWtnobk0goPcDAnHsx2iicXpqqAfl2vFa5d5HAT6N/+9cPN03Ubhnt/YGpYj82JR1Z/kcDNxBfxxAHPi1m9MQYaL5G9Dx+1pwnOZ8S72KPVyvQ7jg2luj6BSnpKRR7Z/5ygZ0hWrPCcVvXi6u8AAAD//yhmoqY="
+	return "eJzcXN1v2zgSf/dfQezL7R5aXRf3locDegl2U9xmt2ja7GNASxOLF4nUkpRz7l9/GH7IsiVZH6aUukjRByfm/OaTw5kh35Jn2F2RrSpSkLAiRDOdwRX5YXtvPvlhRUgCKpas0EzwK/KvFSGEuN+SXCRlhl+TkAFVcEU2dEXIE4MsUVfmT98STnOok8AfvSvwj6UoC/dJC5XDheqLxVmpNMjq87YFCanBWoOmtc9bidl/13ZpwviTkDlFrqPaHxwjqqNKqKZKi4rJ09i6VquviP+rxm/9is+wexEyafn9Cf78z29MaSKeCM0yolPYgzf6UoQqJWJGNSTkhenU/I0Te9SJNxYl1y3kLN5M8M00sL+X+Rokwq1gjkDo0SVUPcaCP7FNMAXRJGdKoY3Egmspsgg4XWfQphRLZS1EBpRPk8MHnrCYalDkJQWdgiRKSxbrPQ7icBCmiIPSra6FsfqocfuePAHVpYROlB5hKpQOpqz5rRPhTnSdBV0dUY71cg+TJavh+HqwfeHsr7KiRz7ctJPE/8MR9fEdV+2gB/pFyOcLMjuH+Nu3PAd0qvFpyTYbkJA80ozKXEV/P6JqjVGs/wvxscTth49nWM4xMxUa4tCsjvFWm9Wqz45OkG5b5DDdOmWIHkpMCxozvYueJEC03umGyjvNsUcsv0gAYhZE4RykEsc2ZzOqK9Ik34CphaZZUJyfccXwQEsFSVCcXxQkM8EsGl5hla5imkHy+JQJqseB/QgyBq7pBg6Ss4ouMXQ7YBf2y63AE6aeowp9SPlemwywRL9V7CsEEPIh1lLRTVgP+5wCoTnuIggWBYzy9gRJXEoJXGc7sgbGN5gBqjI3RnQ2V4UUW4Z5ZWAjf99gR4EmVLEE0DzQbBA+PYeBJ4UCP/raGfH/F5aB2ikNuWE7aiWKuVXUtuNPldRRcnmCats+HnC722eNi2WGlfIXyg1vKnrd2aEEGtYVPlFtghAujO6LTJMnKfJzw5LSVJcqnHTuzXo98fLSUrU65m0+k+c+3HVTnNlrH+5O+eyLZBpmsWZcubJmLabYsgdZfc2dDXqT6QlVv73nu/PhwLR61qC37MH4c11DJO49JlcJT0jzsUl6ldqYWMNUlSQUInlDGLcJ7zArqkPGs8+jKmgcOD8zoHFxYhYnggeFXWll7mCxV3/3Ab1hIlEP5nlCarW+QsGOAHYpG1NHBXR0+eAW07ZaM8OmFr4UC3zLpOA5cB21RdDh9YWijMxZL0+/tgp0vLLNQfj64xdU8N3t16iTrq0VhCNsg9AQyujzAQmbUkof3deKBq/u58L6OTpEdGnncCE9fOAa0Y3fBLDIkMCWxZBRDTzeRXQLuCtGeSgG39sFa0xqlgNhmmj6DIpoQWKRFxloIJST++v7D/hBTnliw0qR7hSLaUYsUPTcnGUZUxALnnSZEHLmeXK+HIqjW7ZJQWnilidbmpVAaCyFUsbQ0WYUlhuMmrx9jQAe/DzolcArHzA2TJBQ7VzI1DMBGqfEynaiQYU/AHTBR0oaONEiJP7wZeL7MkfQuLoVORo3ggciTTezBvqMnG6Gg4Mx3K6zQg65kHNV/+/M4mdIw6Gbq+gfCt88tf6z0YU9B976Ol/UTsy28B4DJx+/1zuDJynPnfbw4UjmSXnc6gMSHo9jTXnywhKdRlpSrnKMtPNsShgCCdXkJWVxajJE8kIVqZElSSmx8IOiZFyD3NIsIp/xOCyhkKCAa2X4qlD7kqJnZ7wDNOQgIQa2XVYInua3IYHZdkZH6kDnuEdW7KONqCqctUpjMn8FjZ9BqwM7n8cLHaU6m9EgaF4OM+OqyAwCBVIKuaTYzECHpTpehA7tQpKsQ50mVeNqIUHatIWfgjoMYV5mmsVU6QVUX9Gq4I5W/B7uzLpvYh2n+T3Q+ZXfwDoMYiJFUUCygOJrFCpxeuqjoM6sdL/8eSCX8/aT8EJ3d7G4JTDByTKSAs106hrIiIhWVRHcxnuKx5dXa69jLguseYXSbArE2Itb9lCWrrhkeitjRfvanePZeuVbJnVJM5LTOGW8q+x75KS9DZIJLWF3In0rTb01mXIhhMYxKMXWGayGj9f3SKo5Vk81QeY0EdxXLxWJ91NuWhAzxpWA7Ur6wNYK2X4vCh1dmqhRp26aKqWKJKAhxg2EVtBtu4opVcIClwPavKmP6QGM47/fcWl/xEMFGfVwy68WJ7RSB9jmbiddbii6yvVGQZuxkOnoLTT3gNo5ef6ea8f9FThut363HQIh7EDj511xzDn5EaJN9MYHvx/vNeUJlclPb8gNw7tG61JD8mBjdCGk/qkd6Da/OMdsbDzfpI9ORXlpKZnHK0GJUsZQCJGt+sxpwi7/ya1PkMDoOYk22/XI46JsFe0UH7C97OagQQjb+jdVLDZTBwVIU2nkMZjcnynNYvWmdRqhji4B7AB/s/BMxz2D/PiqQZ9STimmvn473728D+S/OXiAqvJu4bl/Q3RKtY1SD3eYADquMXi9IVSRmGZxaTPZ9Y7cfLqPTjJl9B9Hy/BmiR0w5vGj1rA+TOhx6ItWbbhnSEcqSCZEnG6wroYa1yCPb/w2hLluSlC6tTEQWrW/IiUnGFJqlrGvNqc/8t7Wtmd/b+CYsVQsxNetmJstz1LNCWaxhSWkdRi8rDXs5RMscI2xFBfcluLehTdnMcMjXCgrKiTb0o4xn15eB/CJPOJZAH2gW8VMkY2kHDcgLZrM2nyLC/5WpRQzwnTvZdFqKut2rUvgvMZu9S0niTXoFwDevEYyShIvtCguQxS+TPI3RdQLLexQ/XTO1zTLhOAz816/S+ookkSyrXlKpsnpXjLTGcNyfQo06eToEreGfQb4o5fQT9YhXliWkTVUw5pN66FrscVxVUU29dRDwl8lk2YXbZV2v8TrgvFjvdHyEvIKb/GuzuFjLYgsOaHk4W4a73u+cxzf6RqB6+V6AMeH3O4JekXumazYW+/Iw91UZ5qnoIhc8FpRsTJoPD9ErQAuoZkXsKT3CmUywft0sXTNcQLESy3iOVZxpnrVZz8TaniuHG0uEnSWc6PVafucJxb8RteQHUNCnFErccaVxvpWOADX9jmwDCShPCElZ9pNGjQkhbBMqYhxomLF3l393I7yiWUBEX6k++YHrkzWNH72E4N1bBjkcW/fXzlqRadTxh9r71aEa73+WWtdHgLDcU7GSf21jFZs1eWkth20M/qd2p97IF/7y0ktuu7YNGeI942iXJ/JDOCsVqD7cNPh9P7jpsP1eHwgfPXW4mBoHhaegKKQ5cy9mHBpzAr5sZs5cO2AZPOoMd0k/N21Nps+6Qx9DjFQNZ+aM9zs+I6Vlwqazz7FxD6EG92JetnDowcoPZrDARz4afT93BgqyN/HU3ZwIqmh9Vev6mx1c9B7yTAkDzhyZe4YYkQitE8P/VfzzIWt79xYj27UXZq1Inz4PszVsqLFGGv1wN2fu8i76jPXEyg7Vxp/mz/oZNVcu79pw7ATZo1czLe537rV65tqtGrDMUNP8mjDXmhU6uGIavctQaHmo/pHAZJqTF3u7fxgN4y5X6VIv3bTnedVik+gQG4HQgj8PMX7LWUZPtY8iLjpYEdtbzye/+wk0jfr43GVEkcE6bXCqV/f7W5/TxXLl1qFUjy5rrfff6PV0GygDWwq5sV6K0JAtZYeXrDW4oNL1nhFeLTm8ZbgYOf0InfxvHIkRwXRG5XWh8ixP125v8PWijsulRb5o80ulixLXhvCLhtpx/Z6d9ermlWwrGqJEvXhK0C118D8qOlhyhl1gp2/4F/BVGNxdl8luQwFOdyj+V5WP1NRztUh++OwO0Z5ctAh2x7mnAtdFdtfEnMXsPaXxDqkwmmhUqGDGS0OQDe6ST2he2D4bsjgBjRlWWXHnhf3waLW+zmtVybGIbm0plwd81FH7jzb8aWXtqRqnDqa7OHP+81GwsbEN8RNPvzjD/MUR9SJCMuI0dyw+irJ5r0oZ0CmKOQbvf6zXvs25aVX4qNWZDyfEetin1Ar8+84GEpV/XUWeqJUZJH9aQQ9PzRjBSjZerrooThpchav+rzyjF6524/tkyxPNN7nE0faPIgcbSFgnlLTQeO8ATZqRfAMu9VAlfVQv7HPFz7DrhNCNT9zKK7XurdHE1pokL5/v83/x0H/kwhJ4Od379514Mpp/EiTBKeswsG7e39N3KKd0muHU91lm6dn30DhLkrb+3PtkNx3gm2SLV7SJ+4RhwIvb+WubVYsD9NDHSgOdJss4LHpVoEQ/2fvXsn+cqmZIrayHQj7O5xYGKGoZSxqGs5veJbBPgUTzGDOys66q4FnZm/VizfrXbu6RjaK/Vs486dI/g2cXg66wfrnc+YHW4E8erlnIGgPuPaO0ndul/UXoy7WNIcw8S1YZx3ncAP9/wAt5NEp"
 }
@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package virtualdisk
+
+import (
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func (m *MetricSet) mapEvent(data diskData) mapstr.M {
+	const kilobytesToBytesMultiplier = int64(1024)
+
+	event := mapstr.M{
+		"capacity": mapstr.M{"bytes": data.Disk.CapacityInBytes},
+		"vm": mapstr.M{
+			"id":   data.VM.Self.Value,
+			"name": data.VM.Name,
+		},
+	}
+	if info := data.Disk.DeviceInfo; info != nil {
+		event["name"] = info.GetDescription().Label
+	}
+	if data.Instance != "" {
+		event["instance"] = data.Instance
+	}
+	if host := data.VM.Runtime.Host; host != nil {
+		event.Put("host.id", host.Value)
+	}
+	if backing, ok := data.Disk.Backing.(types.BaseVirtualDeviceFileBackingInfo); ok {
+		event["file"] = backing.GetVirtualDeviceFileBackingInfo().FileName
+	}
+	if backing, ok := data.Disk.Backing.(*types.VirtualDiskFlatVer2BackingInfo); ok && backing.ThinProvisioned != nil {
+		event["thin_provisioned"] = *backing.ThinProvisioned
+	}
+
+	if val, ok := data.PerformanceData["virtualDisk.read.average"].(int64); ok {
+		event.Put("read.average.bytes", val*kilobytesToBytesMultiplier)
+	}
+	if val, ok := data.PerformanceData["virtualDisk.write.average"].(int64); ok {
+		event.Put("write.average.bytes", val*kilobytesToBytesMultiplier)
+	}
+	if val, ok := data.PerformanceData["virtualDisk.numberReadAveraged.average"]; ok {
+		event.Put("read.average.requests", val)
+	}
+	if val, ok := data.PerformanceData["virtualDisk.numberWriteAveraged.average"]; ok {
+		event.Put("write.average.requests", val)
+	}
+	if val, ok := data.PerformanceData["virtualDisk.totalReadLatency.average"]; ok {
+		event.Put("read.latency.average.ms", val)
+	}
+	if val, ok := data.PerformanceData["virtualDisk.totalWriteLatency.average"]; ok {
+		event.Put("write.latency.average.ms", val)
+	}
+
+	return event
+}
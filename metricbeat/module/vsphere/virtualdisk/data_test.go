@@ -0,0 +1,154 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package virtualdisk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestEventMapping(t *testing.T) {
+	var m MetricSet
+
+	vm := &mo.VirtualMachine{
+		ManagedEntity: mo.ManagedEntity{
+			ExtensibleManagedObject: mo.ExtensibleManagedObject{
+				Self: types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-1"},
+			},
+			Name: "test-vm",
+		},
+		Runtime: types.VirtualMachineRuntimeInfo{
+			Host: &types.ManagedObjectReference{Type: "HostSystem", Value: "host-1"},
+		},
+	}
+	disk := &types.VirtualDisk{
+		VirtualDevice: types.VirtualDevice{
+			DeviceInfo: &types.Description{Label: "Hard disk 1"},
+			Backing: &types.VirtualDiskFlatVer2BackingInfo{
+				VirtualDeviceFileBackingInfo: types.VirtualDeviceFileBackingInfo{
+					FileName: "[datastore1] test-vm/test-vm.vmdk",
+				},
+				ThinProvisioned: types.NewBool(true),
+			},
+		},
+		CapacityInBytes: 10 * 1024 * 1024 * 1024,
+	}
+
+	event := m.mapEvent(diskData{
+		VM:       vm,
+		Disk:     disk,
+		Instance: "scsi0:0",
+		PerformanceData: map[string]interface{}{
+			"virtualDisk.read.average":                int64(100),
+			"virtualDisk.write.average":               int64(200),
+			"virtualDisk.numberReadAveraged.average":  int64(5),
+			"virtualDisk.numberWriteAveraged.average": int64(10),
+			"virtualDisk.totalReadLatency.average":    int64(2),
+			"virtualDisk.totalWriteLatency.average":   int64(3),
+		},
+	})
+
+	assert.Exactly(t, mapstr.M{
+		"name":             "Hard disk 1",
+		"instance":         "scsi0:0",
+		"file":             "[datastore1] test-vm/test-vm.vmdk",
+		"thin_provisioned": true,
+		"capacity":         mapstr.M{"bytes": int64(10 * 1024 * 1024 * 1024)},
+		"vm": mapstr.M{
+			"id":   "vm-1",
+			"name": "test-vm",
+		},
+		"host": mapstr.M{"id": "host-1"},
+		"read": mapstr.M{
+			"average": mapstr.M{
+				"bytes":    int64(100 * 1024),
+				"requests": int64(5),
+			},
+			"latency": mapstr.M{"average": mapstr.M{"ms": int64(2)}},
+		},
+		"write": mapstr.M{
+			"average": mapstr.M{
+				"bytes":    int64(200 * 1024),
+				"requests": int64(10),
+			},
+			"latency": mapstr.M{"average": mapstr.M{"ms": int64(3)}},
+		},
+	}, event)
+}
+
+func TestDiskInstance(t *testing.T) {
+	devices := object.VirtualDeviceList{
+		&types.ParaVirtualSCSIController{
+			VirtualSCSIController: types.VirtualSCSIController{
+				VirtualController: types.VirtualController{
+					VirtualDevice: types.VirtualDevice{Key: 1000},
+					BusNumber:     0,
+				},
+			},
+		},
+		&types.VirtualAHCIController{
+			VirtualSATAController: types.VirtualSATAController{
+				VirtualController: types.VirtualController{
+					VirtualDevice: types.VirtualDevice{Key: 15000},
+					BusNumber:     1,
+				},
+			},
+		},
+		&types.VirtualIDEController{
+			VirtualController: types.VirtualController{
+				VirtualDevice: types.VirtualDevice{Key: 200},
+				BusNumber:     0,
+			},
+		},
+		&types.VirtualNVMEController{
+			VirtualController: types.VirtualController{
+				VirtualDevice: types.VirtualDevice{Key: 31000},
+				BusNumber:     2,
+			},
+		},
+	}
+
+	tests := []struct {
+		controllerKey int32
+		unitNumber    *int32
+		expected      string
+	}{
+		{controllerKey: 1000, unitNumber: types.NewInt32(1), expected: "scsi0:1"},
+		{controllerKey: 15000, unitNumber: types.NewInt32(0), expected: "sata1:0"},
+		{controllerKey: 200, unitNumber: types.NewInt32(1), expected: "ide0:1"},
+		{controllerKey: 31000, unitNumber: types.NewInt32(3), expected: "nvme2:3"},
+		{controllerKey: 1000, unitNumber: nil, expected: ""},
+		{controllerKey: 4000, unitNumber: types.NewInt32(0), expected: ""},
+	}
+
+	for _, test := range tests {
+		disk := &types.VirtualDisk{
+			VirtualDevice: types.VirtualDevice{
+				ControllerKey: test.controllerKey,
+				UnitNumber:    test.unitNumber,
+			},
+		}
+		assert.Equal(t, test.expected, diskInstance(devices, disk))
+	}
+}
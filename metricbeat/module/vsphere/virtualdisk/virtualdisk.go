@@ -0,0 +1,166 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package virtualdisk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/performance"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/vsphere"
+	vSphereClientUtil "github.com/elastic/beats/v7/metricbeat/module/vsphere/client"
+	"github.com/elastic/beats/v7/metricbeat/module/vsphere/security"
+)
+
+var metricSet = map[string]struct{}{
+	"virtualDisk.read.average":  {},
+	"virtualDisk.write.average": {},
+
+	"virtualDisk.numberReadAveraged.average":  {},
+	"virtualDisk.numberWriteAveraged.average": {},
+
+	"virtualDisk.totalReadLatency.average":  {},
+	"virtualDisk.totalWriteLatency.average": {},
+}
+
+func init() {
+	mb.Registry.MustAddMetricSet("vsphere", "virtualdisk", New,
+		mb.WithHostParser(vsphere.HostParser),
+	)
+}
+
+// MetricSet type defines all fields of the MetricSet.
+type MetricSet struct {
+	*vsphere.MetricSet
+	inventory *vSphereClientUtil.Inventory
+}
+
+type diskData struct {
+	VM              *mo.VirtualMachine
+	Disk            *types.VirtualDisk
+	Instance        string
+	PerformanceData map[string]interface{}
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := vsphere.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	security.WarnIfInsecure(ms.Logger(), "virtualdisk", ms.Insecure)
+	return &MetricSet{
+		MetricSet: ms,
+		inventory: vSphereClientUtil.NewInventory(ms.Logger(), ms.HostURL, ms.Insecure, "VirtualMachine",
+			[]string{"name", "runtime.host", "config.hardware.device"}),
+	}, nil
+}
+
+// Fetch reports an event for each virtual disk of the virtual machines.
+func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := m.inventory.Update(ctx); err != nil {
+		return fmt.Errorf("virtualdisk: error updating inventory: %w", err)
+	}
+
+	perfManager := performance.NewManager(m.inventory.Client())
+	metrics, err := perfManager.CounterInfoByName(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve metrics: %w", err)
+	}
+	perfFetcher := vSphereClientUtil.NewPerformanceDataFetcher(m.Logger(), perfManager)
+
+	for _, obj := range m.inventory.Objects() {
+		vm, ok := obj.(*mo.VirtualMachine)
+		if !ok || vm.Config == nil {
+			continue
+		}
+
+		devices := object.VirtualDeviceList(vm.Config.Hardware.Device)
+		disks := devices.SelectByType((*types.VirtualDisk)(nil))
+		if len(disks) == 0 {
+			continue
+		}
+
+		instanceMap, err := perfFetcher.GetPerfMetricsByInstance(ctx, int32(m.Module().Config().Period.Seconds()), "virtualMachine", vm.Name, vm.Reference(), metrics, metricSet)
+		if err != nil {
+			m.Logger().Errorf("Failed to retrieve performance metrics from virtual machine %s: %v", vm.Name, err)
+		}
+
+		for _, device := range disks {
+			disk, ok := device.(*types.VirtualDisk)
+			if !ok {
+				continue
+			}
+			instance := diskInstance(devices, disk)
+
+			reporter.Event(mb.Event{
+				MetricSetFields: m.mapEvent(diskData{
+					VM:              vm,
+					Disk:            disk,
+					Instance:        instance,
+					PerformanceData: instanceMap[instance],
+				}),
+			})
+		}
+	}
+
+	return nil
+}
+
+// Close closes the session of the inventory of virtual machines.
+func (m *MetricSet) Close() error {
+	return m.inventory.Close()
+}
+
+// diskInstance returns the instance of the performance metrics of the disk,
+// named after its controller and unit numbers, as in scsi0:1.
+func diskInstance(devices object.VirtualDeviceList, disk *types.VirtualDisk) string {
+	if disk.UnitNumber == nil {
+		return ""
+	}
+
+	controller, ok := devices.FindByKey(disk.ControllerKey).(types.BaseVirtualController)
+	if !ok {
+		return ""
+	}
+
+	var prefix string
+	switch controller.(type) {
+	case types.BaseVirtualSCSIController:
+		prefix = "scsi"
+	case types.BaseVirtualSATAController:
+		prefix = "sata"
+	case *types.VirtualIDEController:
+		prefix = "ide"
+	case *types.VirtualNVMEController:
+		prefix = "nvme"
+	default:
+		return ""
+	}
+
+	return fmt.Sprintf("%s%d:%d", prefix, controller.GetVirtualController().BusNumber, *disk.UnitNumber)
+}
@@ -30,11 +30,9 @@ import (
 	"github.com/elastic/beats/v7/metricbeat/module/vsphere/security"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 
-	"github.com/vmware/govmomi"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/performance"
 	"github.com/vmware/govmomi/property"
-	"github.com/vmware/govmomi/view"
 	"github.com/vmware/govmomi/vim25"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
@@ -65,6 +63,7 @@ func init() {
 type MetricSet struct {
 	*vsphere.MetricSet
 	GetCustomFields bool
+	inventory       *vSphereClientUtil.Inventory
 }
 
 type triggeredAlarm struct {
@@ -117,6 +116,8 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 	return &MetricSet{
 		MetricSet:       ms,
 		GetCustomFields: config.GetCustomFields,
+		inventory: vSphereClientUtil.NewInventory(ms.Logger(), ms.HostURL, ms.Insecure, "VirtualMachine",
+			[]string{"summary", "datastore", "triggeredAlarmState", "snapshot"}),
 	}, nil
 }
 
@@ -127,20 +128,11 @@ func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	client, err := govmomi.NewClient(ctx, m.HostURL, m.Insecure)
-	if err != nil {
-		return fmt.Errorf("virtualmachine: error in NewClient: %w", err)
+	if err := m.inventory.Update(ctx); err != nil {
+		return fmt.Errorf("virtualmachine: error updating inventory: %w", err)
 	}
 
-	defer func() {
-		err := vSphereClientUtil.Logout(ctx, client)
-
-		if err != nil {
-			m.Logger().Errorf("error trying to logout from vSphere: %v", err)
-		}
-	}()
-
-	c := client.Client
+	c := m.inventory.Client()
 
 	// Get custom fields (attributes) names if get_custom_fields is true.
 	customFieldsMap := make(map[int32]string)
@@ -152,27 +144,6 @@ func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
 		}
 	}
 
-	// Create view of VirtualMachine objects
-	mgr := view.NewManager(c)
-
-	v, err := mgr.CreateContainerView(ctx, c.ServiceContent.RootFolder, []string{"VirtualMachine"}, true)
-	if err != nil {
-		return fmt.Errorf("virtualmachine: error in CreateContainerView: %w", err)
-	}
-
-	defer func() {
-		if err := v.Destroy(ctx); err != nil {
-			m.Logger().Debugf("Error destroying view from vsphere %v", err)
-		}
-	}()
-
-	// Retrieve summary property for all machines
-	var vmt []mo.VirtualMachine
-	err = v.Retrieve(ctx, []string{"VirtualMachine"}, []string{"summary", "datastore", "triggeredAlarmState", "snapshot"}, &vmt)
-	if err != nil {
-		return fmt.Errorf("virtualmachine: error in Retrieve: %w", err)
-	}
-
 	// Create a performance manager
 	perfManager := performance.NewManager(c)
 
@@ -183,7 +154,12 @@ func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
 	}
 
 	pc := property.DefaultCollector(c)
-	for _, vm := range vmt {
+	for _, obj := range m.inventory.Objects() {
+		vm, ok := obj.(*mo.VirtualMachine)
+		if !ok {
+			continue
+		}
+
 		var hostID, hostName string
 		var networkNames, datastoreNames []string
 		var customFields mapstr.M
@@ -248,7 +224,7 @@ func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
 		}
 
 		data := VMData{
-			VM:              vm,
+			VM:              *vm,
 			HostID:          hostID,
 			HostName:        hostName,
 			NetworkNames:    networkNames,
@@ -267,6 +243,11 @@ func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
 	return nil
 }
 
+// Close closes the session of the inventory of virtual machines.
+func (m *MetricSet) Close() error {
+	return m.inventory.Close()
+}
+
 func getCustomFields(customFields []types.BaseCustomFieldValue, customFieldsMap map[int32]string) mapstr.M {
 	outputFields := mapstr.M{}
 	for _, v := range customFields {
@@ -0,0 +1,87 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package virtualnic
+
+import (
+	"strings"
+
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func (m *MetricSet) mapEvent(data nicData) mapstr.M {
+	const kilobytesToBytesMultiplier = int64(1024)
+
+	card := data.NIC.GetVirtualEthernetCard()
+	event := mapstr.M{
+		"key":  card.Key,
+		"type": nicType(data.Type),
+		"vm": mapstr.M{
+			"id":   data.VM.Self.Value,
+			"name": data.VM.Name,
+		},
+	}
+	if info := card.DeviceInfo; info != nil {
+		event["name"] = info.GetDescription().Label
+	}
+	if card.MacAddress != "" {
+		event["mac_address"] = card.MacAddress
+	}
+	if card.Connectable != nil {
+		event["connected"] = card.Connectable.Connected
+	}
+	if host := data.VM.Runtime.Host; host != nil {
+		event.Put("host.id", host.Value)
+	}
+	switch backing := card.Backing.(type) {
+	case *types.VirtualEthernetCardNetworkBackingInfo:
+		event.Put("network.name", backing.DeviceName)
+	case *types.VirtualEthernetCardDistributedVirtualPortBackingInfo:
+		event.Put("network.portgroup_key", backing.Port.PortgroupKey)
+	}
+
+	if val, ok := data.PerformanceData["net.received.average"].(int64); ok {
+		event.Put("received.average.bytes", val*kilobytesToBytesMultiplier)
+	}
+	if val, ok := data.PerformanceData["net.transmitted.average"].(int64); ok {
+		event.Put("transmitted.average.bytes", val*kilobytesToBytesMultiplier)
+	}
+	if val, ok := data.PerformanceData["net.packetsRx.summation"]; ok {
+		event.Put("received.packets.count", val)
+	}
+	if val, ok := data.PerformanceData["net.packetsTx.summation"]; ok {
+		event.Put("transmitted.packets.count", val)
+	}
+	if val, ok := data.PerformanceData["net.droppedRx.summation"]; ok {
+		event.Put("received.dropped.count", val)
+	}
+	if val, ok := data.PerformanceData["net.droppedTx.summation"]; ok {
+		event.Put("transmitted.dropped.count", val)
+	}
+
+	return event
+}
+
+// nicType returns the adapter type from the name of its device type, as in
+// vmxnet3 for VirtualVmxnet3.
+func nicType(typeName string) string {
+	typeName = strings.TrimPrefix(typeName, "Virtual")
+	typeName = strings.TrimSuffix(typeName, "EthernetCard")
+	return strings.ToLower(typeName)
+}
@@ -0,0 +1,144 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package virtualnic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestEventMapping(t *testing.T) {
+	var m MetricSet
+
+	vm := &mo.VirtualMachine{
+		ManagedEntity: mo.ManagedEntity{
+			ExtensibleManagedObject: mo.ExtensibleManagedObject{
+				Self: types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-1"},
+			},
+			Name: "test-vm",
+		},
+		Runtime: types.VirtualMachineRuntimeInfo{
+			Host: &types.ManagedObjectReference{Type: "HostSystem", Value: "host-1"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		nic      types.BaseVirtualEthernetCard
+		typeName string
+		perf     map[string]interface{}
+		expected mapstr.M
+	}{
+		{
+			name: "standard network",
+			nic: &types.VirtualVmxnet3{
+				VirtualVmxnet: types.VirtualVmxnet{
+					VirtualEthernetCard: types.VirtualEthernetCard{
+						VirtualDevice: types.VirtualDevice{
+							Key:         4000,
+							DeviceInfo:  &types.Description{Label: "Network adapter 1"},
+							Backing:     &types.VirtualEthernetCardNetworkBackingInfo{VirtualDeviceDeviceBackingInfo: types.VirtualDeviceDeviceBackingInfo{DeviceName: "VM Network"}},
+							Connectable: &types.VirtualDeviceConnectInfo{Connected: true},
+						},
+						MacAddress: "00:50:56:aa:bb:cc",
+					},
+				},
+			},
+			typeName: "VirtualVmxnet3",
+			perf: map[string]interface{}{
+				"net.received.average":    int64(10),
+				"net.transmitted.average": int64(20),
+				"net.packetsRx.summation": int64(100),
+				"net.packetsTx.summation": int64(200),
+				"net.droppedRx.summation": int64(1),
+				"net.droppedTx.summation": int64(2),
+			},
+			expected: mapstr.M{
+				"key":         int32(4000),
+				"name":        "Network adapter 1",
+				"type":        "vmxnet3",
+				"mac_address": "00:50:56:aa:bb:cc",
+				"connected":   true,
+				"vm": mapstr.M{
+					"id":   "vm-1",
+					"name": "test-vm",
+				},
+				"host":    mapstr.M{"id": "host-1"},
+				"network": mapstr.M{"name": "VM Network"},
+				"received": mapstr.M{
+					"average": mapstr.M{"bytes": int64(10 * 1024)},
+					"packets": mapstr.M{"count": int64(100)},
+					"dropped": mapstr.M{"count": int64(1)},
+				},
+				"transmitted": mapstr.M{
+					"average": mapstr.M{"bytes": int64(20 * 1024)},
+					"packets": mapstr.M{"count": int64(200)},
+					"dropped": mapstr.M{"count": int64(2)},
+				},
+			},
+		},
+		{
+			name: "distributed port group",
+			nic: &types.VirtualE1000e{
+				VirtualEthernetCard: types.VirtualEthernetCard{
+					VirtualDevice: types.VirtualDevice{
+						Key: 4001,
+						Backing: &types.VirtualEthernetCardDistributedVirtualPortBackingInfo{
+							Port: types.DistributedVirtualSwitchPortConnection{PortgroupKey: "dvportgroup-1"},
+						},
+					},
+				},
+			},
+			typeName: "VirtualE1000e",
+			expected: mapstr.M{
+				"key":  int32(4001),
+				"type": "e1000e",
+				"vm": mapstr.M{
+					"id":   "vm-1",
+					"name": "test-vm",
+				},
+				"host":    mapstr.M{"id": "host-1"},
+				"network": mapstr.M{"portgroup_key": "dvportgroup-1"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := m.mapEvent(nicData{
+				VM:              vm,
+				NIC:             test.nic,
+				Type:            test.typeName,
+				PerformanceData: test.perf,
+			})
+			assert.Exactly(t, test.expected, event)
+		})
+	}
+}
+
+func TestNICType(t *testing.T) {
+	assert.Equal(t, "vmxnet3", nicType("VirtualVmxnet3"))
+	assert.Equal(t, "e1000", nicType("VirtualE1000"))
+	assert.Equal(t, "pcnet32", nicType("VirtualPCNet32"))
+	assert.Equal(t, "sriov", nicType("VirtualSriovEthernetCard"))
+}
@@ -0,0 +1,139 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package virtualnic
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/performance"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/vsphere"
+	vSphereClientUtil "github.com/elastic/beats/v7/metricbeat/module/vsphere/client"
+	"github.com/elastic/beats/v7/metricbeat/module/vsphere/security"
+)
+
+var metricSet = map[string]struct{}{
+	"net.received.average":    {},
+	"net.transmitted.average": {},
+
+	"net.packetsRx.summation": {},
+	"net.packetsTx.summation": {},
+
+	"net.droppedRx.summation": {},
+	"net.droppedTx.summation": {},
+}
+
+func init() {
+	mb.Registry.MustAddMetricSet("vsphere", "virtualnic", New,
+		mb.WithHostParser(vsphere.HostParser),
+	)
+}
+
+// MetricSet type defines all fields of the MetricSet.
+type MetricSet struct {
+	*vsphere.MetricSet
+	inventory *vSphereClientUtil.Inventory
+}
+
+type nicData struct {
+	VM              *mo.VirtualMachine
+	NIC             types.BaseVirtualEthernetCard
+	Type            string
+	PerformanceData map[string]interface{}
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := vsphere.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	security.WarnIfInsecure(ms.Logger(), "virtualnic", ms.Insecure)
+	return &MetricSet{
+		MetricSet: ms,
+		inventory: vSphereClientUtil.NewInventory(ms.Logger(), ms.HostURL, ms.Insecure, "VirtualMachine",
+			[]string{"name", "runtime.host", "config.hardware.device"}),
+	}, nil
+}
+
+// Fetch reports an event for each virtual network interface of the virtual machines.
+func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := m.inventory.Update(ctx); err != nil {
+		return fmt.Errorf("virtualnic: error updating inventory: %w", err)
+	}
+
+	perfManager := performance.NewManager(m.inventory.Client())
+	metrics, err := perfManager.CounterInfoByName(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve metrics: %w", err)
+	}
+	perfFetcher := vSphereClientUtil.NewPerformanceDataFetcher(m.Logger(), perfManager)
+
+	for _, obj := range m.inventory.Objects() {
+		vm, ok := obj.(*mo.VirtualMachine)
+		if !ok || vm.Config == nil {
+			continue
+		}
+
+		devices := object.VirtualDeviceList(vm.Config.Hardware.Device)
+		nics := devices.SelectByType((*types.VirtualEthernetCard)(nil))
+		if len(nics) == 0 {
+			continue
+		}
+
+		instanceMap, err := perfFetcher.GetPerfMetricsByInstance(ctx, int32(m.Module().Config().Period.Seconds()), "virtualMachine", vm.Name, vm.Reference(), metrics, metricSet)
+		if err != nil {
+			m.Logger().Errorf("Failed to retrieve performance metrics from virtual machine %s: %v", vm.Name, err)
+		}
+
+		for _, device := range nics {
+			nic, ok := device.(types.BaseVirtualEthernetCard)
+			if !ok {
+				continue
+			}
+			// The metrics of the network interfaces are reported with their device key as instance.
+			instance := strconv.Itoa(int(device.GetVirtualDevice().Key))
+
+			reporter.Event(mb.Event{
+				MetricSetFields: m.mapEvent(nicData{
+					VM:              vm,
+					NIC:             nic,
+					Type:            devices.TypeName(device),
+					PerformanceData: instanceMap[instance],
+				}),
+			})
+		}
+	}
+
+	return nil
+}
+
+// Close closes the session of the inventory of virtual machines.
+func (m *MetricSet) Close() error {
+	return m.inventory.Close()
+}
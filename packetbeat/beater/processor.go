@@ -45,15 +45,17 @@ type processor struct {
 	publisher       *publish.TransactionPublisher
 	flows           *flows.Flows
 	sniffer         *sniffer.Sniffer
+	watcher         *procs.ProcessesWatcher
 	shutdownTimeout time.Duration
 	err             chan error
 }
 
-func newProcessor(shutdownTimeout time.Duration, publisher *publish.TransactionPublisher, flows *flows.Flows, sniffer *sniffer.Sniffer, err chan error) *processor {
+func newProcessor(shutdownTimeout time.Duration, publisher *publish.TransactionPublisher, flows *flows.Flows, sniffer *sniffer.Sniffer, watcher *procs.ProcessesWatcher, err chan error) *processor {
 	return &processor{
 		publisher:       publisher,
 		flows:           flows,
 		sniffer:         sniffer,
+		watcher:         watcher,
 		err:             err,
 		shutdownTimeout: shutdownTimeout,
 	}
@@ -86,6 +88,9 @@ func (p *processor) Stop() {
 		p.flows.Stop()
 	}
 	p.wg.Wait()
+	// flows are enriched when reported, so the process watcher
+	// is stopped after the final flow reports.
+	p.watcher.Stop()
 	// wait for shutdownTimeout to let the publisher flush
 	// whatever pending events
 	if p.shutdownTimeout > 0 {
@@ -174,7 +179,7 @@ func (p *processorFactory) Create(pipeline beat.PipelineConnector, cfg *conf.C)
 		return nil, err
 	}
 
-	return newProcessor(config.ShutdownTimeout, publisher, flows, sniffer, p.err), nil
+	return newProcessor(config.ShutdownTimeout, publisher, flows, sniffer, &watch, p.err), nil
 }
 
 // setupFlows returns a *flows.Flows that will publish to the provided pipeline,
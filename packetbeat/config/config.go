@@ -30,7 +30,10 @@ import (
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
-var errFanoutGroupAFPacketOnly = errors.New("fanout_group is only valid with af_packet type")
+var (
+	errFanoutGroupAFPacketOnly = errors.New("fanout_group is only valid with af_packet type")
+	errBusyPollAFXDPOnly       = errors.New("busy_poll is only valid with af_xdp type")
+)
 
 type Config struct {
 	Interface          *InterfaceConfig   `config:"interfaces"`
@@ -128,7 +131,9 @@ type InterfaceConfig struct {
 	BufferSizeMb          int           `config:"buffer_size_mb"`
 	EnableAutoPromiscMode bool          `config:"auto_promisc_mode"`
 	InternalNetworks      []string      `config:"internal_networks"`
-	FanoutGroup           *uint16       `config:"fanout_group"` // Fanout group ID for AF_PACKET.
+	FanoutGroup           *uint16       `config:"fanout_group"`     // Fanout group ID for AF_PACKET.
+	BusyPoll              time.Duration `config:"busy_poll"`        // Busy-polling duration for AF_XDP.
+	BusyPollBudget        int           `config:"busy_poll_budget"` // Packets handled per busy-poll for AF_XDP.
 	TopSpeed              bool
 	Dumpfile              string // Dumpfile is the basename of pcap dumpfiles. The file names will have a creation time stamp and .pcap extension appended.
 	OneAtATime            bool
@@ -163,5 +168,8 @@ func (i InterfaceConfig) Validate() error {
 	if i.Type != "af_packet" && i.FanoutGroup != nil {
 		return errFanoutGroupAFPacketOnly
 	}
+	if i.Type != "af_xdp" && i.BusyPoll != 0 {
+		return errBusyPollAFXDPOnly
+	}
 	return nil
 }
@@ -472,6 +472,38 @@ interfaces:
 interfaces:
   device: any
   fanout_group: 1
+`,
+	},
+	{
+		name: "af_xdp_busy_poll",
+		want: Config{
+			Interfaces: []InterfaceConfig{
+				{
+					Device:         "eth0",
+					Type:           "af_xdp",
+					BufferSizeMb:   64,
+					BusyPoll:       20 * time.Microsecond,
+					BusyPollBudget: 128,
+				},
+			},
+		},
+		config: `
+interfaces:
+  device: eth0
+  type: af_xdp
+  buffer_size_mb: 64
+  busy_poll: 20us
+  busy_poll_budget: 128
+`,
+	},
+	{
+		name:    "invalid_type_busy_poll",
+		wantErr: fmt.Errorf("%w accessing 'interfaces'", errBusyPollAFXDPOnly),
+		config: `
+interfaces:
+  device: eth0
+  type: af_packet
+  busy_poll: 20us
 `,
 	},
 }
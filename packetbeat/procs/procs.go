@@ -18,6 +18,7 @@
 package procs
 
 import (
+	"maps"
 	"net"
 	"strings"
 	"sync"
@@ -32,7 +33,19 @@ import (
 // This controls how often process info for a running process is reloaded
 // A big value means less unnecessary refreshes at a higher risk of missing
 // a PID being recycled by the OS
-const processCacheExpiration = 30 * time.Second
+const (
+	processCacheExpiration = 30 * time.Second
+
+	// portProcMappingExpiration is how long a port->pid mapping is trusted
+	// after it was last seen in the socket tables.
+	portProcMappingExpiration = 10 * time.Second
+
+	// snapshotMappingExpiration replaces portProcMappingExpiration when the
+	// socket tables are snapshotted in the background. Port reuse by another
+	// process is detected by the next snapshot, so mappings can be kept long
+	// enough to attribute flows reported after the socket was closed.
+	snapshotMappingExpiration = processCacheExpiration
+)
 
 var (
 	anyIPv4 = net.IPv4zero.String()
@@ -51,6 +64,9 @@ type ProcessesWatcher struct {
 
 	// watcher is the OS-dependent engine for the ProcessWatcher.
 	watcher processWatcher
+
+	mappingExpiration time.Duration // mappingExpiration is the lifetime of port->pid mappings.
+	done              chan struct{} // done stops the background snapshots when closed.
 }
 
 // endpoint is a network address/port number complex.
@@ -81,9 +97,22 @@ type process struct {
 
 // Init initializes the ProcessWatcher with the provided configuration.
 func (proc *ProcessesWatcher) Init(config ProcsConfig) error {
+	if config.RefreshPidsFreq == 0 {
+		config.RefreshPidsFreq = defaultRefreshPidsFreq
+	}
 	return proc.init(config, proc)
 }
 
+// Stop stops the background snapshots of the socket tables, if running.
+func (proc *ProcessesWatcher) Stop() {
+	proc.mu.Lock()
+	defer proc.mu.Unlock()
+	if proc.done != nil {
+		close(proc.done)
+		proc.done = nil
+	}
+}
+
 // processWatcher allows the OS-dependent implementation to be replaced by a mock for testing
 type processWatcher interface {
 	// GetLocalPortToPIDMapping returns the list of local port numbers and the PID
@@ -124,9 +153,91 @@ func (proc *ProcessesWatcher) init(config ProcsConfig, watcher processWatcher) e
 
 	proc.monitored = config.Monitored
 
+	proc.mappingExpiration = portProcMappingExpiration
+	if proc.enabled && config.RefreshPidsFreq > 0 {
+		// Snapshotting the socket tables periodically resolves the owners of
+		// sockets while they are still running, so that short-lived processes
+		// are attributed even if their packets are looked up after they exit.
+		logp.Info("Process watcher refreshing sockets every %v", config.RefreshPidsFreq)
+		proc.mappingExpiration = snapshotMappingExpiration
+		proc.done = make(chan struct{})
+		go proc.snapshot(config.RefreshPidsFreq, proc.done)
+	}
+
 	return nil
 }
 
+// snapshot updates the port->pid mappings for all transports every interval
+// until done is closed.
+func (proc *ProcessesWatcher) snapshot(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for transport := range proc.portProcMap {
+			proc.snapshotTransport(transport)
+		}
+
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// snapshotTransport reads the socket table of the given transport and
+// resolves the owners of the sockets that were not seen before. The OS is
+// walked without holding proc.mu, so lookups of the enrichment path are not
+// blocked by a snapshot. The new port->pid mappings replace the current ones
+// under the lock.
+func (proc *ProcessesWatcher) snapshotTransport(transport applayer.Transport) {
+	if logp.HasSelector("procsdetailed") {
+		start := time.Now()
+		defer func() {
+			logp.Debug("procsdetailed", "snapshotTransport() took %v", time.Since(start))
+		}()
+	}
+
+	endpoints, err := proc.watcher.GetLocalPortToPIDMapping(transport)
+	if err != nil {
+		logp.Err("unable to list local ports: %v", err)
+	}
+
+	proc.mu.Lock()
+	procMap := maps.Clone(proc.portProcMap[transport])
+	cache := maps.Clone(proc.processCache)
+	proc.mu.Unlock()
+
+	now := time.Now()
+	resolved := make(map[int]*process)
+	getProcess := func(pid int) *process {
+		if p, ok := cache[pid]; ok && !now.After(p.expires) {
+			return p
+		}
+		if p, ok := resolved[pid]; ok {
+			return p
+		}
+		p := proc.resolveProcess(pid)
+		if p != nil {
+			resolved[pid] = p
+		}
+		return p
+	}
+	for e, pid := range endpoints {
+		proc.setMapping(procMap, transport, e, pid, getProcess)
+	}
+
+	proc.mu.Lock()
+	defer proc.mu.Unlock()
+	proc.expireProcessCache()
+	for pid, p := range resolved {
+		if _, ok := proc.processCache[pid]; !ok {
+			proc.processCache[pid] = p
+		}
+	}
+	proc.portProcMap[transport] = procMap
+}
+
 // FindProcessesTupleTCP looks up local process information for the source and
 // destination addresses of TCP tuple
 func (proc *ProcessesWatcher) FindProcessesTupleTCP(tuple *common.IPPortTuple) (procTuple *common.ProcessTuple) {
@@ -280,13 +391,21 @@ func (proc *ProcessesWatcher) expireProcessCache() {
 
 // proc.mu must be locked
 func (proc *ProcessesWatcher) updateMappingEntry(transport applayer.Transport, e endpoint, pid int) {
-	prev, ok := proc.portProcMap[transport][e]
+	proc.setMapping(proc.portProcMap[transport], transport, e, pid, proc.getProcessInfo)
+}
+
+// setMapping maps e to pid in procMap, using getProcess to resolve the
+// process when the mapping is new.
+func (proc *ProcessesWatcher) setMapping(procMap map[endpoint]portProcMapping, transport applayer.Transport, e endpoint, pid int, getProcess func(pid int) *process) {
+	prev, ok := procMap[e]
 	if ok && prev.pid == pid {
-		// This port->pid mapping already exists
+		// This port->pid mapping already exists and is still current.
+		prev.expires = time.Now().Add(proc.mappingExpiration)
+		procMap[e] = prev
 		return
 	}
 
-	p := proc.getProcessInfo(pid)
+	p := getProcess(pid)
 	if p == nil {
 		return
 	}
@@ -298,17 +417,17 @@ func (proc *ProcessesWatcher) updateMappingEntry(transport applayer.Transport, e
 	// fine to have old entries lingering, as long as we don't
 	// trust them on subsequent connections.
 	//
-	// If the source port is re-used within the hardcoded 10
-	// seconds window, we might end up hitting an old mapping.
-	proc.portProcMap[transport][e] = portProcMapping{
+	// If the source port is re-used within the expiration
+	// window, we might end up hitting an old mapping.
+	procMap[e] = portProcMapping{
 		endpoint: e,
 		pid:      pid,
 		proc:     p,
-		expires:  time.Now().Add(10 * time.Second),
+		expires:  time.Now().Add(proc.mappingExpiration),
 	}
 
 	if logp.IsDebug("procsdetailed") {
-		logp.Debug("procsdetailed", "updateMappingEntry(): local=%s:%d/%s pid=%d process='%s'",
+		logp.Debug("procsdetailed", "setMapping(): local=%s:%d/%s pid=%d process='%s'",
 			e.address, e.port, transport, pid, p.name)
 	}
 }
@@ -316,15 +435,28 @@ func (proc *ProcessesWatcher) updateMappingEntry(transport applayer.Transport, e
 // getProcessInfo returns a potentially cached process corresponding to the
 // provided process ID.
 //
-// If any part of the process's argv contains a substring in proc.monitored.CmdlineGrep,
-// the name of the process is replaced with the corresponding proc.monitored.Process.
-// This behaviour is not recommended to be used and is not available to integrations
-// packages by design.
+// proc.mu must be locked
 func (proc *ProcessesWatcher) getProcessInfo(pid int) *process {
 	if p, ok := proc.processCache[pid]; ok {
 		return p
 	}
 	// Not in cache, resolve process info
+	p := proc.resolveProcess(pid)
+	if p == nil {
+		return nil
+	}
+	proc.processCache[pid] = p
+	return p
+}
+
+// resolveProcess returns the process corresponding to the provided process
+// ID. It doesn't access the process cache, so proc.mu doesn't need to be locked.
+//
+// If any part of the process's argv contains a substring in proc.monitored.CmdlineGrep,
+// the name of the process is replaced with the corresponding proc.monitored.Process.
+// This behaviour is not recommended to be used and is not available to integrations
+// packages by design.
+func (proc *ProcessesWatcher) resolveProcess(pid int) *process {
 	p := proc.watcher.GetProcess(pid)
 	if p == nil {
 		return nil
@@ -338,7 +470,6 @@ func (proc *ProcessesWatcher) getProcessInfo(pid int) *process {
 			break
 		}
 	}
-	proc.processCache[pid] = p
 	return p
 }
 
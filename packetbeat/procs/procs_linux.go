@@ -38,6 +38,10 @@ import (
 	"github.com/elastic/gosigar"
 )
 
+// defaultRefreshPidsFreq is zero because walking the file descriptors of
+// every process is too expensive to do in the background by default.
+const defaultRefreshPidsFreq = 0
+
 // procName makes a best effort attempt to get a good name for the process. It
 // uses /proc/<pid>/comm if it is less than 16 bytes long (TASK_COMM_LEN) and
 // otherwise uses argv[0] if it is available, but falling back to the comm string
@@ -24,6 +24,9 @@ import (
 	"github.com/elastic/go-sysinfo/types"
 )
 
+// defaultRefreshPidsFreq is zero because there are no socket tables to snapshot.
+const defaultRefreshPidsFreq = 0
+
 // procName returns the name for the process.
 func procName(info types.ProcessInfo) string {
 	return info.Name
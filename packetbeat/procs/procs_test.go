@@ -22,7 +22,9 @@ package procs
 import (
 	"net"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -32,6 +34,7 @@ import (
 )
 
 type mockWatcher struct {
+	mu           sync.Mutex
 	localIPs     []net.IP
 	portToPID    map[applayer.Transport]map[endpoint]int
 	pidToProcess map[int]*process
@@ -63,10 +66,18 @@ func newMockWatcher(localIPs []net.IP, processes []runningProcess) *mockWatcher
 }
 
 func (w *mockWatcher) GetLocalPortToPIDMapping(transport applayer.Transport) (ports map[endpoint]int, err error) {
-	return w.portToPID[transport], nil
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ports = make(map[endpoint]int, len(w.portToPID[transport]))
+	for e, pid := range w.portToPID[transport] {
+		ports[e] = pid
+	}
+	return ports, nil
 }
 
 func (w *mockWatcher) GetProcess(pid int) *process {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	if cmdline, ok := w.pidToProcess[pid]; ok {
 		return cmdline
 	}
@@ -304,3 +315,130 @@ func TestFindProcessTuple(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotShortLivedProcess(t *testing.T) {
+	logp.TestingSetup()
+	config := ProcsConfig{
+		Enabled:         true,
+		RefreshPidsFreq: 10 * time.Millisecond,
+	}
+	e := endpoint{address: "127.0.0.1", port: 40000}
+	w := newMockWatcher(
+		[]net.IP{net.ParseIP("127.0.0.1")},
+		[]runningProcess{
+			{
+				process: process{
+					name: "short_lived",
+					args: strings.Fields("short_lived.exe --once"),
+					pid:  700,
+				},
+				ports: []endpoint{e},
+				proto: applayer.TransportTCP,
+			},
+		})
+	procs := ProcessesWatcher{}
+	err := procs.init(config, w)
+	assert.NoError(t, err)
+	defer procs.Stop()
+
+	assert.Eventually(t, func() bool {
+		procs.mu.Lock()
+		defer procs.mu.Unlock()
+		_, ok := procs.portProcMap[applayer.TransportTCP][e]
+		return ok
+	}, time.Second, config.RefreshPidsFreq, "socket was not picked up by a snapshot")
+
+	// The process exits and its socket is closed before its
+	// packets are looked up.
+	w.mu.Lock()
+	delete(w.portToPID[applayer.TransportTCP], e)
+	delete(w.pidToProcess, 700)
+	w.mu.Unlock()
+
+	input := common.IPPortTuple{
+		BaseTuple: common.BaseTuple{
+			SrcIP:   net.ParseIP("127.0.0.1"),
+			SrcPort: e.port,
+			DstIP:   net.ParseIP("1.2.3.4"),
+			DstPort: 443,
+		},
+	}
+	result := procs.FindProcessesTuple(&input, applayer.TransportTCP)
+	assert.Equal(t, "short_lived", result.Src.Name)
+	assert.Equal(t, 700, result.Src.PID)
+	assert.Equal(t, "", result.Dst.Name)
+}
+
+// blockingWatcher is a mockWatcher whose socket table listing blocks
+// until release is closed.
+type blockingWatcher struct {
+	*mockWatcher
+	listing chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWatcher) GetLocalPortToPIDMapping(transport applayer.Transport) (map[endpoint]int, error) {
+	if w.release != nil {
+		close(w.listing)
+		<-w.release
+	}
+	return w.mockWatcher.GetLocalPortToPIDMapping(transport)
+}
+
+func TestSnapshotDoesNotBlockLookups(t *testing.T) {
+	logp.TestingSetup()
+	e := endpoint{address: "127.0.0.1", port: 40000}
+	w := &blockingWatcher{mockWatcher: newMockWatcher(
+		[]net.IP{net.ParseIP("127.0.0.1")},
+		[]runningProcess{
+			{
+				process: process{
+					name: "curl",
+					args: strings.Fields("curl -o /dev/null http://example.net/"),
+					pid:  800,
+				},
+				ports: []endpoint{e},
+				proto: applayer.TransportTCP,
+			},
+		})}
+	procs := ProcessesWatcher{}
+	err := procs.init(ProcsConfig{Enabled: true}, w)
+	assert.NoError(t, err)
+	procs.snapshotTransport(applayer.TransportTCP)
+
+	// The next snapshot blocks while it walks the OS.
+	w.listing = make(chan struct{})
+	w.release = make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		procs.snapshotTransport(applayer.TransportTCP)
+	}()
+	<-w.listing
+
+	input := common.IPPortTuple{
+		BaseTuple: common.BaseTuple{
+			SrcIP:   net.ParseIP("127.0.0.1"),
+			SrcPort: e.port,
+			DstIP:   net.ParseIP("1.2.3.4"),
+			DstPort: 80,
+		},
+	}
+	found := make(chan *common.ProcessTuple)
+	go func() {
+		found <- procs.FindProcessesTuple(&input, applayer.TransportTCP)
+	}()
+	select {
+	case result := <-found:
+		assert.Equal(t, "curl", result.Src.Name)
+		assert.Equal(t, 800, result.Src.PID)
+	case <-time.After(time.Second):
+		t.Error("lookup blocked by the snapshot")
+	}
+
+	close(w.release)
+	<-done
+	procs.mu.Lock()
+	defer procs.mu.Unlock()
+	assert.Equal(t, 800, procs.portProcMap[applayer.TransportTCP][e].pid)
+}
@@ -25,6 +25,7 @@ import (
 	"math/bits"
 	"net"
 	"syscall"
+	"time"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
@@ -33,6 +34,11 @@ import (
 	"github.com/elastic/go-sysinfo/types"
 )
 
+// defaultRefreshPidsFreq is the default interval between snapshots of the
+// socket tables. Reading the tables is cheap on Windows, and sockets owned
+// by short-lived processes are often closed before their packets are looked up.
+const defaultRefreshPidsFreq = time.Second
+
 // procName returns the name for the process.
 func procName(info types.ProcessInfo) string {
 	return info.Name
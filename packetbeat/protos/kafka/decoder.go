@@ -0,0 +1,218 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	errShortMessage  = errors.New("message too short")
+	errInvalidLength = errors.New("invalid length")
+)
+
+// decoder reads the primitive types of the Kafka protocol from a message.
+// The first error encountered is sticky, all subsequent reads return zero
+// values.
+//
+// Flexible versions of the API messages use compact encodings for strings,
+// byte arrays and arrays, and carry tagged fields at the end of structures.
+type decoder struct {
+	buf      []byte
+	off      int
+	flexible bool
+	err      error
+}
+
+func newDecoder(buf []byte) *decoder {
+	return &decoder{buf: buf}
+}
+
+func (d *decoder) remaining() int {
+	return len(d.buf) - d.off
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 {
+		d.err = errInvalidLength
+		return nil
+	}
+	if d.remaining() < n {
+		d.err = errShortMessage
+		return nil
+	}
+	b := d.buf[d.off : d.off+n]
+	d.off += n
+	return b
+}
+
+func (d *decoder) skip(n int) {
+	d.next(n)
+}
+
+func (d *decoder) int8() int8 {
+	b := d.next(1)
+	if b == nil {
+		return 0
+	}
+	return int8(b[0])
+}
+
+func (d *decoder) bool() bool {
+	return d.int8() != 0
+}
+
+func (d *decoder) int16() int16 {
+	b := d.next(2)
+	if b == nil {
+		return 0
+	}
+	return int16(binary.BigEndian.Uint16(b))
+}
+
+func (d *decoder) int32() int32 {
+	b := d.next(4)
+	if b == nil {
+		return 0
+	}
+	return int32(binary.BigEndian.Uint32(b))
+}
+
+func (d *decoder) int64() int64 {
+	b := d.next(8)
+	if b == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(b))
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf[d.off:])
+	if n <= 0 {
+		d.err = errShortMessage
+		return 0
+	}
+	d.off += n
+	return v
+}
+
+// uuid returns a UUID, as used for topic IDs, in the URL-safe base64
+// encoding used by Kafka tools.
+func (d *decoder) uuid() string {
+	b := d.next(16)
+	if b == nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// length reads the length of a string, byte array or array. A length of -1
+// is returned for null values.
+func (d *decoder) length(legacySize int) int {
+	var n int
+	if d.flexible {
+		n = int(d.uvarint()) - 1
+	} else if legacySize == 2 {
+		n = int(d.int16())
+	} else {
+		n = int(d.int32())
+	}
+	if d.err != nil {
+		return 0
+	}
+	if n < -1 {
+		d.err = errInvalidLength
+		return 0
+	}
+	return n
+}
+
+// string reads a string or nullable string. Null strings are returned
+// as empty strings.
+func (d *decoder) string() string {
+	n := d.length(2)
+	if n <= 0 {
+		return ""
+	}
+	return string(d.next(n))
+}
+
+// legacyString reads a nullable string that is never compact encoded, like
+// the client ID of request headers.
+func (d *decoder) legacyString() string {
+	flexible := d.flexible
+	d.flexible = false
+	s := d.string()
+	d.flexible = flexible
+	return s
+}
+
+// bytes reads a byte array or nullable byte array. The returned slice is
+// truncated when the array exceeds the end of a partially captured message.
+func (d *decoder) bytes() []byte {
+	n := d.length(4)
+	if n <= 0 {
+		return nil
+	}
+	if n > d.remaining() {
+		b := d.buf[d.off:]
+		d.off = len(d.buf)
+		d.err = errShortMessage
+		return b
+	}
+	return d.next(n)
+}
+
+// arrayLength reads the number of elements of an array or nullable array.
+// A length of -1 is returned for null arrays.
+func (d *decoder) arrayLength() int {
+	n := d.length(4)
+	// Every element takes at least one byte.
+	if n > d.remaining() {
+		d.err = errInvalidLength
+		return 0
+	}
+	return n
+}
+
+func (d *decoder) skipInt32Array() {
+	n := d.arrayLength()
+	if n > 0 {
+		d.skip(4 * n)
+	}
+}
+
+// taggedFields skips the tagged fields of a structure in flexible versions.
+func (d *decoder) taggedFields() {
+	if !d.flexible {
+		return
+	}
+	n := d.uvarint()
+	for i := uint64(0); i < n && d.err == nil; i++ {
+		d.uvarint() // tag
+		d.skip(int(d.uvarint()))
+	}
+}
@@ -0,0 +1,36 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by beats/dev-tools/cmd/asset/asset.go - DO NOT EDIT.
+
+package kafka
+
+import (
+	"github.com/elastic/beats/v7/libbeat/asset"
+)
+
+func init() {
+	if err := asset.SetFields("packetbeat", "kafka", asset.ModuleFieldsPri, AssetKafka); err != nil {
+		panic(err)
+	}
+}
+
+// AssetKafka returns asset data.
+// This is the base64 encoded zlib format compressed contents of protos/kafka.
+func AssetKafka() string {
+	return "eJy0V09v28gPvftTEDnXRnv5HXz4AcUmAYLNnyIb7B4VakTbA88MVc4ojvvpF6M/lhRJtpNu4AK1J9Z7j48ckp7DlvZL2OJqizOAoIOhJVz8GT9fzAAy8kp0HjS7JZSnc5+T0iutgF7IBVhpMplfzKB+t5wBAMzBoaUWOL7CPqclrIWLvD7pod+4FYvFSAWYchEqPhD6WZAPHtBlIORzdp5KQoA+aZcYc73Y0v5w3tAbduvOIb2izWPIXzuHPVn3hSXRKvoEOiMX9Gqv3RrChuD7jxvgVfm2VrmYjSqJmjoElZQt7Xcs2ZiaH8JZoWhSE1pqiM/W8ELiNbtTjvSI/q6e6XIVnjJI9ycIFYuQKZOZ6OxdnP/v/AHgprJck4DasCfXcCujY/kFBotBbdrKgJ0OmyhPSw+pFuvH1JZYY0KP5CivciTzb1OR/FHCws0l+Pi/dl3TYEOYkYyoCZxr5d8jhSUj8VMynkq8HrfQisRD4Cn6RGfnKDiWuEvflE04IuALrFgOx3WNeggbDD245uo1YOkebi5H1OcoQUf7/buK7r6wKUkpOOJ3YM72jURYEsUZnWI+5O1/U3L6Vl5r8QEcu/kvEq6IIBI1Bje1/wUwNI6DoRcywBIN7sFhHSNLG+ZUPGfUwCGa+4en5Pbq++XVY/LwmFw/3N4+/HP1OBVit4d1ItIOnsuhsWj9fJ5S598j76K6JfNvU0ovpqT2s3FVMoNQzhIoa691lYNod1OkLINyGgklbIRDMJQEbSmx7yvcvrYnbSkKstoY7Umxy/oFvMP4ueLLICWFhS9HCcLPgkMzqqvXi+aqhY+Irrtf5/vD8T4Qe11uCJGuHnCNrsM4HxvpXVpU264/kx6d8Cn+a688qq3jnaFsbckFX4rS0o65ptMv4CtYQufB8QDukH/ty3b/Bebf6m+jMaDd3O+dimVjtMLuFOrGF0uAi5DYj0U5nn+GHepQFeaG3oY7pUTQeVQRF01/NB67bkNJXZw4C3nVN3WcXkixZH6U9ZQLbWZrlDIhR4mSdB/obLZqUV3C2EM9JX/pX50mHbVAGrcVGkhq5KwoqM1Hb9V1fPjEwnzqhll8TWK5JPa/umh3+KptYSFMNadUeEtSFqkvq/TZaldl5HmAFhhSAnxBbTA1tIBbdmvI2Zi4lb9xIOCWoMjjFQgb/TYgKHffcl+MPze0y7TCEGEwlmdqyE4UzUHfuRadXTF32pVmoeXChZjUDEOc2CAUCnFTgvD1swTh60cEefJ+sPwfVdRjbfuEdkoo9mU01eVooD+hcRwbEk0rqdJA2QIenKnmw6rczyytMboZv2xQ1jQ5IjxoD9rFH9KBsqNxfFJSh4Ee6VTnRDzAKx04HXETraWAsdA/2vnu6ud/s/mhMcng51crJmU2hO6Ys09SEOw2VC2FTVhRYtwC6r1wh74ROpn/qimenfqJAVijvF1SJziVKXwg+Y1p397bGmuKiV0QNmaa7PwO0WJBKrwlWcz+HQAhRlyd"
+}
@@ -0,0 +1,373 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+const (
+	// maxMessageSize is the largest message size considered valid. Larger
+	// sizes indicate that the stream is not in sync with message boundaries.
+	maxMessageSize = 1 << 30
+
+	// maxBufferedMessage is the largest amount of data buffered for a
+	// single message. Larger messages, usually Fetch responses, are decoded
+	// from their first maxBufferedMessage bytes and the rest is skipped.
+	maxBufferedMessage = 1 << 20
+)
+
+var (
+	unmatchedRequests  = monitoring.NewInt(nil, "kafka.unmatched_requests")
+	unmatchedResponses = monitoring.NewInt(nil, "kafka.unmatched_responses")
+)
+
+type kafkaPlugin struct {
+	ports []int
+
+	// Requests waiting for a response.
+	requests           *common.Cache
+	transactionTimeout time.Duration
+
+	results protos.Reporter
+	watcher *procs.ProcessesWatcher
+	log     *logp.Logger
+}
+
+type transactionKey struct {
+	tcp common.HashableTCPTuple
+	id  int32
+}
+
+type connection struct {
+	streams [2]*stream
+}
+
+type stream struct {
+	data []byte
+	// skip is the number of bytes of a partially decoded message still to
+	// be skipped.
+	skip int
+}
+
+func init() {
+	protos.Register("kafka", New)
+}
+
+// New constructs a new Kafka protocol plugin.
+func New(
+	testMode bool,
+	results protos.Reporter,
+	watcher *procs.ProcessesWatcher,
+	cfg *conf.C,
+) (protos.Plugin, error) {
+	p := &kafkaPlugin{}
+	config := defaultConfig
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	p.init(results, watcher, &config)
+	return p, nil
+}
+
+func (kafka *kafkaPlugin) init(results protos.Reporter, watcher *procs.ProcessesWatcher, config *kafkaConfig) {
+	kafka.ports = config.Ports
+	kafka.transactionTimeout = config.TransactionTimeout
+	kafka.results = results
+	kafka.watcher = watcher
+	kafka.log = logp.NewLogger("kafka")
+
+	kafka.requests = common.NewCacheWithRemovalListener(
+		kafka.transactionTimeout,
+		protos.DefaultTransactionHashSize,
+		func(k common.Key, v common.Value) {
+			req, ok := v.(*message)
+			if !ok {
+				kafka.log.Error("Expired value is not a *message.")
+				return
+			}
+			kafka.expireRequest(req)
+		})
+	kafka.requests.StartJanitor(kafka.transactionTimeout)
+}
+
+func (kafka *kafkaPlugin) GetPorts() []int {
+	return kafka.ports
+}
+
+func (kafka *kafkaPlugin) ConnectionTimeout() time.Duration {
+	return kafka.transactionTimeout
+}
+
+func (kafka *kafkaPlugin) Parse(
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
+	conn, ok := private.(*connection)
+	if !ok || conn == nil {
+		conn = &connection{}
+	}
+
+	st := conn.streams[dir]
+	if st == nil {
+		st = &stream{}
+		conn.streams[dir] = st
+	}
+	payload := pkt.Payload
+	if st.skip > 0 {
+		n := min(st.skip, len(payload))
+		st.skip -= n
+		payload = payload[n:]
+	}
+	st.data = append(st.data, payload...)
+
+	// Messages sent from a broker port are responses.
+	isResponse := kafka.isBrokerPort(pkt.Tuple.SrcPort)
+
+	for len(st.data) >= 4 {
+		size := int(int32(binary.BigEndian.Uint32(st.data)))
+		if size < 4 || size > maxMessageSize {
+			kafka.log.Debugf("Invalid message size %d, dropping stream", size)
+			conn.streams[dir] = nil
+			return conn
+		}
+
+		total := 4 + size
+		var body []byte
+		switch {
+		case len(st.data) >= total:
+			body = st.data[4:total]
+		case len(st.data) >= maxBufferedMessage:
+			body = st.data[4:]
+		default:
+			// Wait for more data.
+			return conn
+		}
+
+		m := &message{
+			ts:        pkt.Ts,
+			size:      total,
+			tcpTuple:  *tcptuple,
+			direction: dir,
+		}
+		var valid bool
+		if isResponse {
+			valid = kafka.onResponse(body, m)
+		} else {
+			valid = kafka.onRequest(body, m)
+		}
+		if !valid {
+			// Drop the stream, parsing is retried with the next segment.
+			conn.streams[dir] = nil
+			return conn
+		}
+
+		if len(body) < size {
+			st.skip = total - len(st.data)
+			st.data = nil
+		} else {
+			st.data = st.data[total:]
+		}
+	}
+
+	return conn
+}
+
+func (kafka *kafkaPlugin) isBrokerPort(port uint16) bool {
+	for _, p := range kafka.ports {
+		if p == int(port) {
+			return true
+		}
+	}
+	return false
+}
+
+func (kafka *kafkaPlugin) onRequest(body []byte, m *message) bool {
+	d := newDecoder(body)
+	if !decodeRequestHeader(d, m) {
+		kafka.log.Debugf("Invalid request header")
+		return false
+	}
+	decodeRequestBody(d, m)
+	if d.err != nil {
+		kafka.log.Debugf("Failed to decode %s v%d request: %v", apiName(m.apiKey), m.apiVersion, d.err)
+	}
+	m.cmdlineTuple = kafka.watcher.FindProcessesTupleTCP(m.tcpTuple.IPPort())
+
+	if m.noResponse {
+		kafka.publishTransaction(m, nil, nil)
+		return true
+	}
+
+	key := transactionKey{tcp: m.tcpTuple.Hashable(), id: m.correlationID}
+	if old := kafka.requests.Put(key, m); old != nil {
+		kafka.log.Debugf("Duplicate correlation ID %d, dropping old request", m.correlationID)
+		unmatchedRequests.Add(1)
+	}
+	return true
+}
+
+func (kafka *kafkaPlugin) onResponse(body []byte, m *message) bool {
+	d := newDecoder(body)
+	m.correlationID = d.int32()
+	if d.err != nil {
+		return false
+	}
+
+	key := transactionKey{tcp: m.tcpTuple.Hashable(), id: m.correlationID}
+	v := kafka.requests.Delete(key)
+	if v == nil {
+		// Responses can only be decoded with the request they answer.
+		kafka.log.Debugf("Response without request, correlation ID %d", m.correlationID)
+		unmatchedResponses.Add(1)
+		return false
+	}
+	req := v.(*message)
+
+	decodeResponseBody(d, req, m)
+	if d.err != nil {
+		kafka.log.Debugf("Failed to decode %s v%d response: %v", apiName(req.apiKey), req.apiVersion, d.err)
+	}
+	kafka.publishTransaction(req, m, nil)
+	return true
+}
+
+func (kafka *kafkaPlugin) expireRequest(req *message) {
+	kafka.log.Debugf("No response to %s request, correlation ID %d", apiName(req.apiKey), req.correlationID)
+	kafka.publishTransaction(req, nil, []string{"no response received"})
+	unmatchedRequests.Add(1)
+}
+
+func (kafka *kafkaPlugin) GapInStream(tcptuple *common.TCPTuple, dir uint8,
+	nbytes int, private protos.ProtocolData,
+) (priv protos.ProtocolData, drop bool) {
+	return private, true
+}
+
+func (kafka *kafkaPlugin) ReceivedFin(tcptuple *common.TCPTuple, dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
+	return private
+}
+
+func (kafka *kafkaPlugin) publishTransaction(req, resp *message, notes []string) {
+	if kafka.results == nil {
+		return
+	}
+
+	evt, pbf := pb.NewBeatEvent(req.ts)
+	src, dst := common.MakeEndpointPair(req.tcpTuple.BaseTuple, req.cmdlineTuple)
+	if req.direction == tcp.TCPDirectionReverse {
+		src, dst = dst, src
+	}
+	pbf.SetSource(&src)
+	pbf.AddIP(src.IP)
+	pbf.SetDestination(&dst)
+	pbf.AddIP(dst.IP)
+	pbf.Source.Bytes = int64(req.size)
+	pbf.Event.Dataset = "kafka"
+	pbf.Event.Start = req.ts
+	pbf.Network.Transport = "tcp"
+	pbf.Network.Protocol = pbf.Event.Dataset
+	pbf.Error.Message = notes
+
+	name := apiName(req.apiKey)
+	kafkaFields := mapstr.M{
+		"api": mapstr.M{
+			"key":     req.apiKey,
+			"version": req.apiVersion,
+		},
+		"correlation_id": req.correlationID,
+	}
+	if name != "" {
+		kafkaFields.Put("api.name", name)
+	}
+	if req.clientID != "" {
+		kafkaFields["client_id"] = req.clientID
+	}
+	if len(req.topics) > 0 {
+		kafkaFields["topics"] = req.topics
+	}
+	if len(req.topicIDs) > 0 {
+		kafkaFields["topic_ids"] = req.topicIDs
+	}
+	if req.partitions > 0 {
+		kafkaFields["partitions"] = req.partitions
+	}
+
+	// API specific fields are grouped under the lowercase API name.
+	var apiFields mapstr.M
+	if req.fields != nil {
+		apiFields = req.fields.Clone()
+	}
+
+	status := common.OK_STATUS
+	if resp != nil {
+		pbf.Destination.Bytes = int64(resp.size)
+		pbf.Event.End = resp.ts
+		if resp.errorCode != 0 {
+			status = common.ERROR_STATUS
+			kafkaFields["error_code"] = resp.errorCode
+			kafkaFields["error"] = errorName(resp.errorCode)
+		}
+		if len(resp.errors) > 0 {
+			kafkaFields["errors"] = resp.errors
+		}
+		if resp.hasThrottle {
+			kafkaFields["throttle_time_ms"] = resp.throttleTimeMs
+		}
+		if resp.fields != nil {
+			if apiFields == nil {
+				apiFields = mapstr.M{}
+			}
+			apiFields.DeepUpdate(resp.fields)
+		}
+	} else if !req.noResponse {
+		status = common.ERROR_STATUS
+	}
+	if apiFields != nil && name != "" {
+		kafkaFields[strings.ToLower(name)] = apiFields
+	}
+
+	fields := evt.Fields
+	fields["type"] = pbf.Event.Dataset
+	fields["status"] = status
+	if name != "" {
+		fields["method"] = name
+	}
+	fields["kafka"] = kafkaFields
+
+	kafka.results(evt)
+}
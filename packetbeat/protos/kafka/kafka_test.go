@@ -0,0 +1,666 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package kafka
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tcp"
+	"github.com/elastic/beats/v7/packetbeat/publish"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var _ protos.TCPPlugin = &kafkaPlugin{}
+
+func TestProduce(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store, defaultConfig)
+	conn := newTestConnection(p)
+
+	req := request(apiProduce, 7, 42, "producer-1", func(e *encoder) {
+		e.string("")   // transactional ID
+		e.int16(-1)    // acks
+		e.int32(30000) // timeout
+		e.array(1)
+		e.string("orders")
+		e.array(2)
+		for partition := int32(0); partition < 2; partition++ {
+			e.int32(partition)
+			e.bytes(recordBatch(3))
+		}
+	})
+	conn.request(req)
+	assert.Empty(t, store.events)
+
+	conn.response(response(42, false, func(e *encoder) {
+		e.array(1)
+		e.string("orders")
+		e.array(2)
+		for partition, code := range []int16{0, 6} {
+			e.int32(int32(partition))
+			e.int16(code)
+			e.int64(100) // base offset
+			e.int64(-1)  // log append time
+			e.int64(0)   // log start offset
+		}
+		e.int32(5) // throttle time
+	}))
+
+	require.Len(t, store.events, 1)
+	fields := store.events[0].Fields
+	assert.Equal(t, "kafka", mapValue(t, fields, "type"))
+	assert.Equal(t, common.ERROR_STATUS, mapValue(t, fields, "status"))
+	assert.Equal(t, "Produce", mapValue(t, fields, "method"))
+	assert.Equal(t, "Produce", mapValue(t, fields, "kafka.api.name"))
+	assert.Equal(t, int16(7), mapValue(t, fields, "kafka.api.version"))
+	assert.Equal(t, int32(42), mapValue(t, fields, "kafka.correlation_id"))
+	assert.Equal(t, "producer-1", mapValue(t, fields, "kafka.client_id"))
+	assert.Equal(t, []string{"orders"}, mapValue(t, fields, "kafka.topics"))
+	assert.Equal(t, 2, mapValue(t, fields, "kafka.partitions"))
+	assert.Equal(t, int16(6), mapValue(t, fields, "kafka.error_code"))
+	assert.Equal(t, "NOT_LEADER_OR_FOLLOWER", mapValue(t, fields, "kafka.error"))
+	assert.Equal(t, []string{"orders-1: NOT_LEADER_OR_FOLLOWER"}, mapValue(t, fields, "kafka.errors"))
+	assert.Equal(t, int32(5), mapValue(t, fields, "kafka.throttle_time_ms"))
+	assert.Equal(t, int16(-1), mapValue(t, fields, "kafka.produce.acks"))
+	assert.Equal(t, int32(30000), mapValue(t, fields, "kafka.produce.timeout_ms"))
+	assert.Equal(t, 6, mapValue(t, fields, "kafka.produce.records"))
+	assert.Equal(t, int64(len(req)), mapValue(t, fields, "source.bytes"))
+	assert.Equal(t, "tcp", mapValue(t, fields, "network.transport"))
+	assert.Equal(t, "kafka", mapValue(t, fields, "network.protocol"))
+	assert.Equal(t, time.Second, mapValue(t, fields, "event.duration"))
+}
+
+func TestProduceFlexible(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store, defaultConfig)
+	conn := newTestConnection(p)
+
+	conn.request(request(apiProduce, 9, 1, "producer-1", func(e *encoder) {
+		e.string("txn-1")
+		e.int16(1)
+		e.int32(1000)
+		e.array(1)
+		e.string("orders")
+		e.array(1)
+		e.int32(3)
+		e.bytes(recordBatch(2))
+		e.tags()
+		e.tags()
+		e.tags()
+	}))
+	conn.response(response(1, true, func(e *encoder) {
+		e.array(1)
+		e.string("orders")
+		e.array(1)
+		e.int32(3)
+		e.int16(0)
+		e.int64(100)
+		e.int64(-1)
+		e.int64(0)
+		e.array(0) // record errors
+		e.string("")
+		e.tags()
+		e.tags()
+		e.int32(0)
+		e.tags()
+	}))
+
+	require.Len(t, store.events, 1)
+	fields := store.events[0].Fields
+	assert.Equal(t, common.OK_STATUS, mapValue(t, fields, "status"))
+	assert.Equal(t, "txn-1", mapValue(t, fields, "kafka.produce.transactional_id"))
+	assert.Equal(t, 2, mapValue(t, fields, "kafka.produce.records"))
+	assert.Equal(t, int32(0), mapValue(t, fields, "kafka.throttle_time_ms"))
+	assertNoValue(t, fields, "kafka.error")
+}
+
+func TestProduceWithoutAcks(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store, defaultConfig)
+	conn := newTestConnection(p)
+
+	conn.request(request(apiProduce, 3, 1, "producer-1", func(e *encoder) {
+		e.string("")
+		e.int16(0)
+		e.int32(1000)
+		e.array(1)
+		e.string("logs")
+		e.array(1)
+		e.int32(0)
+		e.bytes(recordBatch(10))
+	}))
+
+	require.Len(t, store.events, 1)
+	fields := store.events[0].Fields
+	assert.Equal(t, common.OK_STATUS, mapValue(t, fields, "status"))
+	assert.Equal(t, 10, mapValue(t, fields, "kafka.produce.records"))
+	assertNoValue(t, fields, "destination.bytes")
+	assert.Zero(t, p.requests.Size())
+}
+
+func TestFetch(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store, defaultConfig)
+	conn := newTestConnection(p)
+
+	conn.request(request(apiFetch, 11, 7, "consumer-1", func(e *encoder) {
+		e.int32(-1)      // replica ID
+		e.int32(500)     // max wait
+		e.int32(1)       // min bytes
+		e.int32(1 << 20) // max bytes
+		e.int8(0)        // isolation level
+		e.int32(0)       // session ID
+		e.int32(-1)      // session epoch
+		e.array(1)
+		e.string("orders")
+		e.array(1)
+		e.int32(0)
+		e.int32(-1) // current leader epoch
+		e.int64(10) // fetch offset
+		e.int64(0)  // log start offset
+		e.int32(1 << 20)
+		e.array(0) // forgotten topics
+		e.string("")
+	}))
+	conn.response(response(7, false, func(e *encoder) {
+		e.int32(0) // throttle time
+		e.int16(0) // error code
+		e.int32(0) // session ID
+		e.array(1)
+		e.string("orders")
+		e.array(1)
+		e.int32(0)
+		e.int16(1)  // OFFSET_OUT_OF_RANGE
+		e.int64(20) // high watermark
+		e.int64(20) // last stable offset
+		e.int64(0)  // log start offset
+		e.array(-1) // aborted transactions
+		e.int32(-1) // preferred read replica
+		e.bytes(append(recordBatch(4), recordBatch(1)...))
+	}))
+
+	require.Len(t, store.events, 1)
+	fields := store.events[0].Fields
+	assert.Equal(t, common.ERROR_STATUS, mapValue(t, fields, "status"))
+	assert.Equal(t, []string{"orders"}, mapValue(t, fields, "kafka.topics"))
+	assert.Equal(t, int32(500), mapValue(t, fields, "kafka.fetch.max_wait_ms"))
+	assert.Equal(t, int32(1), mapValue(t, fields, "kafka.fetch.min_bytes"))
+	assert.Equal(t, 5, mapValue(t, fields, "kafka.fetch.records"))
+	assert.Equal(t, "OFFSET_OUT_OF_RANGE", mapValue(t, fields, "kafka.error"))
+	assert.Equal(t, []string{"orders-0: OFFSET_OUT_OF_RANGE"}, mapValue(t, fields, "kafka.errors"))
+}
+
+func TestFetchTopicIDs(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store, defaultConfig)
+	conn := newTestConnection(p)
+
+	topicID := []byte{0x8b, 0x3e, 0x62, 0x4c, 0x9f, 0x02, 0x4b, 0x8a, 0x96, 0x4b, 0x1d, 0x5c, 0x7a, 0x2e, 0x66, 0x01}
+	conn.request(request(apiFetch, 13, 8, "consumer-1", func(e *encoder) {
+		e.int32(-1)
+		e.int32(500)
+		e.int32(1)
+		e.int32(1 << 20)
+		e.int8(0)
+		e.int32(0)
+		e.int32(-1)
+		e.array(1)
+		e.raw(topicID)
+		e.array(2)
+		for partition := int32(0); partition < 2; partition++ {
+			e.int32(partition)
+			e.int32(-1)
+			e.int64(10)
+			e.int32(-1) // last fetched epoch
+			e.int64(0)
+			e.int32(1 << 20)
+			e.tags()
+		}
+		e.tags()
+		e.array(0)
+		e.string("")
+		e.tags()
+	}))
+	conn.response(response(8, true, func(e *encoder) {
+		e.int32(0)
+		e.int16(0)
+		e.int32(0)
+		e.array(1)
+		e.raw(topicID)
+		e.array(1)
+		e.int32(1)
+		e.int16(6)
+		e.int64(20)
+		e.int64(20)
+		e.int64(0)
+		e.array(-1)
+		e.int32(-1)
+		e.bytes(nil)
+		e.tags()
+		e.tags()
+		e.tags()
+	}))
+
+	require.Len(t, store.events, 1)
+	fields := store.events[0].Fields
+	assert.Equal(t, []string{"iz5iTJ8CS4qWSx1cei5mAQ"}, mapValue(t, fields, "kafka.topic_ids"))
+	assert.Equal(t, 2, mapValue(t, fields, "kafka.partitions"))
+	assert.Equal(t, []string{"iz5iTJ8CS4qWSx1cei5mAQ-1: NOT_LEADER_OR_FOLLOWER"}, mapValue(t, fields, "kafka.errors"))
+}
+
+func TestMetadata(t *testing.T) {
+	for _, version := range []int16{1, 9} {
+		flexible := version >= 9
+		store := &eventStore{}
+		p := newTestPlugin(t, store, defaultConfig)
+		conn := newTestConnection(p)
+
+		conn.request(request(apiMetadata, version, 3, "admin", func(e *encoder) {
+			e.array(2)
+			for _, topic := range []string{"orders", "missing"} {
+				e.string(topic)
+				e.tags()
+			}
+			if version >= 4 {
+				e.int8(1) // allow auto topic creation
+			}
+			if version >= 8 {
+				e.int8(0) // include topic authorized operations
+			}
+			e.tags()
+		}))
+		conn.response(response(3, flexible, func(e *encoder) {
+			if version >= 3 {
+				e.int32(0)
+			}
+			e.array(1)
+			e.int32(1)
+			e.string("broker-1")
+			e.int32(9092)
+			e.string("")
+			e.tags()
+			if version >= 2 {
+				e.string("cluster-1")
+			}
+			e.int32(1) // controller ID
+			e.array(2)
+			for _, topic := range []struct {
+				name string
+				code int16
+			}{{"orders", 0}, {"missing", 3}} {
+				e.int16(topic.code)
+				e.string(topic.name)
+				e.int8(0) // is internal
+				if topic.code == 0 {
+					e.array(1)
+					e.int16(5) // LEADER_NOT_AVAILABLE
+					e.int32(0)
+					e.int32(-1)
+					if version >= 7 {
+						e.int32(0)
+					}
+					e.array(1)
+					e.int32(1)
+					e.array(0)
+					if version >= 5 {
+						e.array(0)
+					}
+					e.tags()
+				} else {
+					e.array(0)
+				}
+				if version >= 8 {
+					e.int32(0)
+				}
+				e.tags()
+			}
+			e.tags()
+		}))
+
+		require.Len(t, store.events, 1, "version %d", version)
+		fields := store.events[0].Fields
+		assert.Equal(t, []string{"orders", "missing"}, mapValue(t, fields, "kafka.topics"))
+		assert.Equal(t, 1, mapValue(t, fields, "kafka.metadata.brokers"))
+		assert.Equal(t, int32(1), mapValue(t, fields, "kafka.metadata.controller_id"))
+		assert.Equal(t, "LEADER_NOT_AVAILABLE", mapValue(t, fields, "kafka.error"))
+		assert.Equal(t, []string{"orders-0: LEADER_NOT_AVAILABLE", "missing: UNKNOWN_TOPIC_OR_PARTITION"}, mapValue(t, fields, "kafka.errors"))
+	}
+}
+
+func TestUndecodedAPI(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store, defaultConfig)
+	conn := newTestConnection(p)
+
+	// ApiVersions v3 uses request header v2 and response header v0.
+	conn.request(request(18, 3, 1, "client", func(e *encoder) {
+		e.string("apache-kafka-java")
+		e.string("3.7.0")
+		e.tags()
+	}))
+	conn.response(response(1, false, func(e *encoder) {
+		e.int16(35) // UNSUPPORTED_VERSION
+	}))
+
+	require.Len(t, store.events, 1)
+	fields := store.events[0].Fields
+	assert.Equal(t, "ApiVersions", mapValue(t, fields, "method"))
+	assert.Equal(t, "client", mapValue(t, fields, "kafka.client_id"))
+	assert.Equal(t, common.OK_STATUS, mapValue(t, fields, "status"))
+}
+
+func TestPipelinedAndSplitMessages(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store, defaultConfig)
+	conn := newTestConnection(p)
+
+	metadata := func(id int32) []byte {
+		return request(apiMetadata, 1, id, "client", func(e *encoder) {
+			e.array(-1)
+		})
+	}
+	conn.request(append(metadata(1), metadata(2)...))
+
+	metadataResponse := func(id int32) []byte {
+		return response(id, false, func(e *encoder) {
+			e.array(0)
+			e.int32(1)
+			e.array(0)
+		})
+	}
+	responses := append(metadataResponse(1), metadataResponse(2)...)
+	for _, b := range []byte(responses) {
+		conn.response([]byte{b})
+	}
+
+	require.Len(t, store.events, 2)
+	for i, evt := range store.events {
+		assert.Equal(t, int32(i+1), mapValue(t, evt.Fields, "kafka.correlation_id"))
+		assert.Equal(t, true, mapValue(t, evt.Fields, "kafka.metadata.all_topics"))
+		assert.Equal(t, common.OK_STATUS, mapValue(t, evt.Fields, "status"))
+	}
+}
+
+func TestLargeResponse(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store, defaultConfig)
+	conn := newTestConnection(p)
+
+	fetch := func(id int32) []byte {
+		return request(apiFetch, 4, id, "consumer-1", func(e *encoder) {
+			e.int32(-1)
+			e.int32(500)
+			e.int32(1)
+			e.int32(1 << 24)
+			e.int8(0)
+			e.array(1)
+			e.string("orders")
+			e.array(1)
+			e.int32(0)
+			e.int64(10)
+			e.int32(1 << 24)
+		})
+	}
+	fetchResponse := func(id int32, records []byte) []byte {
+		return response(id, false, func(e *encoder) {
+			e.int32(0)
+			e.array(1)
+			e.string("orders")
+			e.array(1)
+			e.int32(0)
+			e.int16(0)
+			e.int64(20)
+			e.int64(20)
+			e.array(0)
+			e.bytes(records)
+		})
+	}
+	conn.request(append(fetch(1), fetch(2)...))
+
+	var records []byte
+	for len(records) < 2*maxBufferedMessage {
+		records = append(records, recordBatch(1)...)
+	}
+	data := append(fetchResponse(1, records), fetchResponse(2, recordBatch(1))...)
+	for len(data) > 0 {
+		n := min(len(data), 1460)
+		conn.response(data[:n])
+		data = data[n:]
+	}
+
+	require.Len(t, store.events, 2)
+	fields := store.events[0].Fields
+	// Only the first bytes of the first response are decoded.
+	assert.Less(t, mapValue(t, fields, "kafka.fetch.record_bytes"), len(records))
+	assert.Greater(t, mapValue(t, fields, "kafka.fetch.records"), 0)
+	assert.Equal(t, int64(len(fetchResponse(1, records))), mapValue(t, fields, "destination.bytes"))
+	assert.Equal(t, 1, mapValue(t, store.events[1].Fields, "kafka.fetch.records"))
+}
+
+func TestRequestWithoutResponse(t *testing.T) {
+	store := &eventStore{}
+	config := defaultConfig
+	config.TransactionTimeout = time.Millisecond
+	p := newTestPlugin(t, store, config)
+	conn := newTestConnection(p)
+
+	conn.request(request(12, 4, 5, "consumer-1", func(e *encoder) {
+		e.string("group")
+	}))
+	time.Sleep(10 * time.Millisecond)
+	p.requests.CleanUp()
+
+	require.Len(t, store.events, 1)
+	fields := store.events[0].Fields
+	assert.Equal(t, "Heartbeat", mapValue(t, fields, "method"))
+	assert.Equal(t, common.ERROR_STATUS, mapValue(t, fields, "status"))
+	assert.Equal(t, "no response received", mapValue(t, fields, "error.message"))
+}
+
+func TestNotKafka(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store, defaultConfig)
+	conn := newTestConnection(p)
+
+	conn.request([]byte("GET / HTTP/1.1\r\nHost: example.com\r\n\r\n"))
+	assert.Nil(t, conn.private.(*connection).streams[tcp.TCPDirectionOriginal])
+
+	// Response without a request.
+	conn.response(response(1, false, func(e *encoder) { e.int16(0) }))
+	assert.Nil(t, conn.private.(*connection).streams[tcp.TCPDirectionReverse])
+	assert.Empty(t, store.events)
+}
+
+func TestCountRecords(t *testing.T) {
+	batches := append(recordBatch(3), recordBatch(7)...)
+	assert.Equal(t, 10, countRecords(batches))
+	assert.Equal(t, 3, countRecords(batches[:len(batches)-10]))
+	assert.Equal(t, 0, countRecords(batches[:40]))
+	assert.Equal(t, 0, countRecords(nil))
+}
+
+func TestErrorName(t *testing.T) {
+	assert.Equal(t, "UNKNOWN_SERVER_ERROR", errorName(-1))
+	assert.Equal(t, "NONE", errorName(0))
+	assert.Equal(t, "NOT_LEADER_OR_FOLLOWER", errorName(6))
+	assert.Equal(t, "ERROR_CODE_1000", errorName(1000))
+}
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	publish.MarshalPacketbeatFields(&event, nil, nil)
+	e.events = append(e.events, event)
+}
+
+func newTestPlugin(t *testing.T, store *eventStore, config kafkaConfig) *kafkaPlugin {
+	logp.TestingSetup()
+	p := &kafkaPlugin{}
+	p.init(store.publish, &procs.ProcessesWatcher{}, &config)
+	t.Cleanup(p.requests.StopJanitor)
+	return p
+}
+
+// testConnection feeds the messages of a client connection to the plugin.
+type testConnection struct {
+	plugin  *kafkaPlugin
+	tcp     common.TCPTuple
+	ts      time.Time
+	private protos.ProtocolData
+}
+
+func newTestConnection(p *kafkaPlugin) *testConnection {
+	tuple := common.TCPTuple{
+		BaseTuple: common.BaseTuple{
+			SrcIP: net.IPv4(192, 168, 0, 1), SrcPort: 50000,
+			DstIP: net.IPv4(192, 168, 0, 2), DstPort: 9092,
+		},
+		IPLength: 4,
+	}
+	tuple.ComputeHashables()
+	return &testConnection{plugin: p, tcp: tuple, ts: time.Now()}
+}
+
+func (c *testConnection) request(payload []byte) {
+	pkt := &protos.Packet{Ts: c.ts, Tuple: *c.tcp.IPPort(), Payload: payload}
+	c.private = c.plugin.Parse(pkt, &c.tcp, tcp.TCPDirectionOriginal, c.private)
+}
+
+func (c *testConnection) response(payload []byte) {
+	rev := common.NewIPPortTuple(4, c.tcp.DstIP, c.tcp.DstPort, c.tcp.SrcIP, c.tcp.SrcPort)
+	pkt := &protos.Packet{Ts: c.ts.Add(time.Second), Tuple: rev, Payload: payload}
+	c.private = c.plugin.Parse(pkt, &c.tcp, tcp.TCPDirectionReverse, c.private)
+}
+
+// encoder writes Kafka protocol primitive types, using compact encodings
+// when flexible is set.
+type encoder struct {
+	buf      []byte
+	flexible bool
+}
+
+func (e *encoder) raw(b []byte) { e.buf = append(e.buf, b...) }
+func (e *encoder) int8(v int8)  { e.buf = append(e.buf, byte(v)) }
+func (e *encoder) int16(v int16) {
+	e.buf = binary.BigEndian.AppendUint16(e.buf, uint16(v))
+}
+
+func (e *encoder) int32(v int32) {
+	e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(v))
+}
+
+func (e *encoder) int64(v int64) {
+	e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(v))
+}
+
+func (e *encoder) length(n int, legacySize int) {
+	switch {
+	case e.flexible:
+		e.buf = binary.AppendUvarint(e.buf, uint64(n+1))
+	case legacySize == 2:
+		e.int16(int16(n))
+	default:
+		e.int32(int32(n))
+	}
+}
+
+// string writes a string, empty strings are written as null.
+func (e *encoder) string(s string) {
+	if s == "" {
+		e.length(-1, 2)
+		return
+	}
+	e.length(len(s), 2)
+	e.raw([]byte(s))
+}
+
+func (e *encoder) bytes(b []byte) {
+	if b == nil {
+		e.length(-1, 4)
+		return
+	}
+	e.length(len(b), 4)
+	e.raw(b)
+}
+
+func (e *encoder) array(n int) { e.length(n, 4) }
+
+func (e *encoder) tags() {
+	if e.flexible {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func request(apiKey, version int16, correlationID int32, clientID string, body func(e *encoder)) []byte {
+	e := &encoder{}
+	e.int16(apiKey)
+	e.int16(version)
+	e.int32(correlationID)
+	e.string(clientID)
+	if a, ok := apis[apiKey]; (ok && version >= a.firstFlexible) || (apiKey == 18 && version >= 3) {
+		e.flexible = true
+		e.tags()
+	}
+	body(e)
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(e.buf))), e.buf...)
+}
+
+func response(correlationID int32, flexible bool, body func(e *encoder)) []byte {
+	e := &encoder{flexible: flexible}
+	e.int32(correlationID)
+	e.tags()
+	body(e)
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(e.buf))), e.buf...)
+}
+
+// recordBatch returns a v2 record batch of count records.
+func recordBatch(count int) []byte {
+	b := make([]byte, recordBatchV2HeaderSize+count*16)
+	binary.BigEndian.PutUint32(b[8:], uint32(len(b)-12))
+	b[16] = 2
+	binary.BigEndian.PutUint32(b[57:], uint32(count))
+	return b
+}
+
+func mapValue(t testing.TB, m mapstr.M, key string) interface{} {
+	t.Helper()
+	v, err := m.GetValue(key)
+	require.NoError(t, err, "key %s", key)
+	return v
+}
+
+func assertNoValue(t testing.TB, m mapstr.M, key string) {
+	t.Helper()
+	_, err := m.GetValue(key)
+	assert.Error(t, err, "key %s", key)
+}
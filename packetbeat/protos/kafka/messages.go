@@ -0,0 +1,469 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kafka
+
+import (
+	"encoding/binary"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	// maxListLength limits the number of topics and errors reported per
+	// transaction.
+	maxListLength = 100
+
+	// recordBatchV2HeaderSize is the size of the header of a v2 record batch,
+	// up to and including the number of records.
+	recordBatchV2HeaderSize = 61
+)
+
+// api describes how the analyzer decodes the messages of an API.
+type api struct {
+	// firstFlexible is the first version using the flexible encoding.
+	firstFlexible int16
+	// maxVersion is the latest version the analyzer can decode.
+	maxVersion int16
+
+	decodeRequest  func(d *decoder, version int16, m *message)
+	decodeResponse func(d *decoder, version int16, m *message)
+}
+
+var apis = map[int16]api{
+	apiProduce:  {firstFlexible: 9, maxVersion: 11, decodeRequest: decodeProduceRequest, decodeResponse: decodeProduceResponse},
+	apiFetch:    {firstFlexible: 12, maxVersion: 16, decodeRequest: decodeFetchRequest, decodeResponse: decodeFetchResponse},
+	apiMetadata: {firstFlexible: 9, maxVersion: 12, decodeRequest: decodeMetadataRequest, decodeResponse: decodeMetadataResponse},
+}
+
+// message is a Kafka request or response.
+type message struct {
+	ts   time.Time
+	size int // Size of the message including the length prefix.
+
+	tcpTuple     common.TCPTuple
+	direction    uint8
+	cmdlineTuple *common.ProcessTuple
+
+	correlationID int32
+
+	// Request header.
+	apiKey     int16
+	apiVersion int16
+	clientID   string
+
+	// noResponse is set for requests the broker does not respond to.
+	noResponse bool
+
+	// Fields decoded from the message body.
+	topics         []string
+	topicIDs       []string
+	partitions     int
+	errorCode      int16 // First error code found.
+	errors         []string
+	throttleTimeMs int32
+	hasThrottle    bool
+	fields         mapstr.M // API specific fields.
+}
+
+// decodeRequestHeader decodes the header of a request message. It returns
+// false if the header is not valid.
+func decodeRequestHeader(d *decoder, m *message) bool {
+	m.apiKey = d.int16()
+	m.apiVersion = d.int16()
+	m.correlationID = d.int32()
+	if d.err != nil || m.apiKey < 0 || m.apiKey >= 128 || m.apiVersion < 0 || m.apiVersion >= 64 {
+		return false
+	}
+	// Only ControlledShutdown v0 uses request header v0 without a client ID.
+	if m.apiKey != 7 || m.apiVersion != 0 {
+		m.clientID = d.legacyString()
+	}
+	return d.err == nil
+}
+
+// decodeRequestBody decodes the body of requests of the supported APIs.
+func decodeRequestBody(d *decoder, m *message) {
+	a, ok := apis[m.apiKey]
+	if !ok || m.apiVersion > a.maxVersion {
+		return
+	}
+	d.flexible = m.apiVersion >= a.firstFlexible
+	d.taggedFields() // Request header v2.
+	a.decodeRequest(d, m.apiVersion, m)
+}
+
+// decodeResponse decodes the body of the response to the request req.
+func decodeResponseBody(d *decoder, req, m *message) {
+	a, ok := apis[req.apiKey]
+	if !ok || req.apiVersion > a.maxVersion {
+		return
+	}
+	d.flexible = req.apiVersion >= a.firstFlexible
+	d.taggedFields() // Response header v1.
+	a.decodeResponse(d, req.apiVersion, m)
+}
+
+func (m *message) setField(key string, value interface{}) {
+	if m.fields == nil {
+		m.fields = mapstr.M{}
+	}
+	m.fields[key] = value
+}
+
+func (m *message) addTopic(topic string) {
+	if len(m.topics) < maxListLength && !contains(m.topics, topic) {
+		m.topics = append(m.topics, topic)
+	}
+}
+
+func (m *message) addTopicID(id string) {
+	if len(m.topicIDs) < maxListLength && !contains(m.topicIDs, id) {
+		m.topicIDs = append(m.topicIDs, id)
+	}
+}
+
+// addError records a non-zero error code of the given resource, a topic or
+// a topic partition.
+func (m *message) addError(resource string, code int16) {
+	if code == 0 {
+		return
+	}
+	if m.errorCode == 0 {
+		m.errorCode = code
+	}
+	if resource != "" && len(m.errors) < maxListLength {
+		m.errors = append(m.errors, resource+": "+errorName(code))
+	}
+}
+
+func (m *message) setThrottle(ms int32) {
+	m.throttleTimeMs = ms
+	m.hasThrottle = true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// topicPartition formats a topic partition the way Kafka does.
+func topicPartition(topic string, partition int32) string {
+	return topic + "-" + strconv.Itoa(int(partition))
+}
+
+// countRecords returns the number of records in the complete batches of a
+// record set. The record set may be truncated.
+func countRecords(b []byte) int {
+	var n int
+	for len(b) >= 17 {
+		// baseOffset int64, batchLength int32, partitionLeaderEpoch int32, magic int8
+		// for v2 record batches, offset int64, messageSize int32, crc int32, magic int8
+		// for legacy message sets.
+		size := int(int32(binary.BigEndian.Uint32(b[8:])))
+		if size <= 0 {
+			break
+		}
+		if len(b) < 12+size {
+			break
+		}
+		if magic := b[16]; magic >= 2 {
+			if size < recordBatchV2HeaderSize-12 {
+				break
+			}
+			count := int(int32(binary.BigEndian.Uint32(b[57:])))
+			if count > 0 {
+				n += count
+			}
+		} else {
+			n++
+		}
+		b = b[12+size:]
+	}
+	return n
+}
+
+func decodeProduceRequest(d *decoder, version int16, m *message) {
+	if version >= 3 {
+		if id := d.string(); id != "" {
+			m.setField("transactional_id", id)
+		}
+	}
+	acks := d.int16()
+	timeout := d.int32()
+	if d.err != nil {
+		return
+	}
+	m.setField("acks", acks)
+	m.setField("timeout_ms", timeout)
+	m.noResponse = acks == 0
+
+	var records, recordBytes int
+	defer func() {
+		m.setField("records", records)
+		m.setField("record_bytes", recordBytes)
+	}()
+	for i, n := 0, d.arrayLength(); i < n && d.err == nil; i++ {
+		topic := d.string()
+		m.addTopic(topic)
+		for j, n := 0, d.arrayLength(); j < n && d.err == nil; j++ {
+			d.int32() // partition index
+			b := d.bytes()
+			m.partitions++
+			records += countRecords(b)
+			recordBytes += len(b)
+			d.taggedFields()
+		}
+		d.taggedFields()
+	}
+}
+
+func decodeProduceResponse(d *decoder, version int16, m *message) {
+	for i, n := 0, d.arrayLength(); i < n && d.err == nil; i++ {
+		topic := d.string()
+		for j, n := 0, d.arrayLength(); j < n && d.err == nil; j++ {
+			partition := d.int32()
+			code := d.int16()
+			d.int64() // base offset
+			if version >= 2 {
+				d.int64() // log append time
+			}
+			if version >= 5 {
+				d.int64() // log start offset
+			}
+			if version >= 8 {
+				for k, n := 0, d.arrayLength(); k < n && d.err == nil; k++ {
+					d.int32()  // batch index
+					d.string() // batch index error message
+					d.taggedFields()
+				}
+				d.string() // error message
+			}
+			d.taggedFields()
+			if d.err == nil {
+				m.addError(topicPartition(topic, partition), code)
+			}
+		}
+		d.taggedFields()
+	}
+	if version >= 1 {
+		if ms := d.int32(); d.err == nil {
+			m.setThrottle(ms)
+		}
+	}
+}
+
+func decodeFetchRequest(d *decoder, version int16, m *message) {
+	if version <= 14 {
+		d.int32() // replica ID
+	}
+	maxWait := d.int32()
+	minBytes := d.int32()
+	if d.err != nil {
+		return
+	}
+	m.setField("max_wait_ms", maxWait)
+	m.setField("min_bytes", minBytes)
+	if version >= 3 {
+		if maxBytes := d.int32(); d.err == nil {
+			m.setField("max_bytes", maxBytes)
+		}
+	}
+	if version >= 4 {
+		d.int8() // isolation level
+	}
+	if version >= 7 {
+		if sessionID := d.int32(); d.err == nil && sessionID != 0 {
+			m.setField("session_id", sessionID)
+		}
+		d.int32() // session epoch
+	}
+	for i, n := 0, d.arrayLength(); i < n && d.err == nil; i++ {
+		if version >= 13 {
+			m.addTopicID(d.uuid())
+		} else {
+			m.addTopic(d.string())
+		}
+		for j, n := 0, d.arrayLength(); j < n && d.err == nil; j++ {
+			d.int32() // partition
+			if version >= 9 {
+				d.int32() // current leader epoch
+			}
+			d.int64() // fetch offset
+			if version >= 12 {
+				d.int32() // last fetched epoch
+			}
+			if version >= 5 {
+				d.int64() // log start offset
+			}
+			d.int32() // partition max bytes
+			d.taggedFields()
+			m.partitions++
+		}
+		d.taggedFields()
+	}
+}
+
+func decodeFetchResponse(d *decoder, version int16, m *message) {
+	if version >= 1 {
+		if ms := d.int32(); d.err == nil {
+			m.setThrottle(ms)
+		}
+	}
+	if version >= 7 {
+		m.addError("", d.int16())
+		d.int32() // session ID
+	}
+
+	var records, recordBytes int
+	defer func() {
+		m.setField("records", records)
+		m.setField("record_bytes", recordBytes)
+	}()
+	for i, n := 0, d.arrayLength(); i < n && d.err == nil; i++ {
+		var topic string
+		if version >= 13 {
+			topic = d.uuid()
+		} else {
+			topic = d.string()
+		}
+		for j, n := 0, d.arrayLength(); j < n && d.err == nil; j++ {
+			partition := d.int32()
+			code := d.int16()
+			d.int64() // high watermark
+			if version >= 4 {
+				d.int64() // last stable offset
+			}
+			if version >= 5 {
+				d.int64() // log start offset
+			}
+			if version >= 4 {
+				for k, n := 0, d.arrayLength(); k < n && d.err == nil; k++ {
+					d.int64() // producer ID
+					d.int64() // first offset
+					d.taggedFields()
+				}
+			}
+			if version >= 11 {
+				d.int32() // preferred read replica
+			}
+			if d.err != nil {
+				break
+			}
+			m.addError(topicPartition(topic, partition), code)
+			b := d.bytes()
+			records += countRecords(b)
+			recordBytes += len(b)
+			d.taggedFields()
+		}
+		d.taggedFields()
+	}
+}
+
+func decodeMetadataRequest(d *decoder, version int16, m *message) {
+	n := d.arrayLength()
+	if d.err == nil && n < 0 {
+		m.setField("all_topics", true)
+	}
+	for i := 0; i < n && d.err == nil; i++ {
+		if version >= 10 {
+			if id := d.uuid(); id != "AAAAAAAAAAAAAAAAAAAAAA" {
+				m.addTopicID(id)
+			}
+		}
+		if topic := d.string(); topic != "" {
+			m.addTopic(topic)
+		}
+		d.taggedFields()
+	}
+	if d.err == nil && version == 0 && n == 0 {
+		m.setField("all_topics", true)
+	}
+}
+
+func decodeMetadataResponse(d *decoder, version int16, m *message) {
+	if version >= 3 {
+		if ms := d.int32(); d.err == nil {
+			m.setThrottle(ms)
+		}
+	}
+	brokers := d.arrayLength()
+	for i := 0; i < brokers && d.err == nil; i++ {
+		d.int32()  // node ID
+		d.string() // host
+		d.int32()  // port
+		if version >= 1 {
+			d.string() // rack
+		}
+		d.taggedFields()
+	}
+	if d.err != nil {
+		return
+	}
+	m.setField("brokers", brokers)
+	if version >= 2 {
+		if id := d.string(); id != "" {
+			m.setField("cluster_id", id)
+		}
+	}
+	if version >= 1 {
+		if id := d.int32(); d.err == nil {
+			m.setField("controller_id", id)
+		}
+	}
+	for i, n := 0, d.arrayLength(); i < n && d.err == nil; i++ {
+		code := d.int16()
+		topic := d.string()
+		if version >= 10 {
+			d.uuid() // topic ID
+		}
+		if version >= 1 {
+			d.bool() // is internal
+		}
+		if d.err == nil {
+			m.addError(topic, code)
+		}
+		for j, n := 0, d.arrayLength(); j < n && d.err == nil; j++ {
+			code := d.int16()
+			partition := d.int32()
+			d.int32() // leader ID
+			if version >= 7 {
+				d.int32() // leader epoch
+			}
+			d.skipInt32Array() // replica nodes
+			d.skipInt32Array() // in-sync replica nodes
+			if version >= 5 {
+				d.skipInt32Array() // offline replicas
+			}
+			d.taggedFields()
+			if d.err == nil {
+				m.addError(topicPartition(topic, partition), code)
+			}
+		}
+		if version >= 8 {
+			d.int32() // topic authorized operations
+		}
+		d.taggedFields()
+	}
+}
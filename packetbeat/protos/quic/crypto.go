@@ -0,0 +1,155 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quic
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+// Salts used to derive the Initial secrets from the destination connection
+// ID chosen by the client (RFC 9001 section 5.2, RFC 9369 section 3.3.1).
+var (
+	initialSaltV1 = []byte{
+		0x38, 0x76, 0x2c, 0xf7, 0xf5, 0x59, 0x34, 0xb3, 0x4d, 0x17,
+		0x9a, 0xe6, 0xa4, 0xc8, 0x0c, 0xad, 0xcc, 0xbb, 0x7f, 0x0a,
+	}
+	initialSaltV2 = []byte{
+		0x0d, 0xed, 0xe3, 0xde, 0xf7, 0x00, 0xa6, 0xdb, 0x81, 0x93,
+		0x81, 0xbe, 0x6e, 0x26, 0x9d, 0xcb, 0xf9, 0xbd, 0x2e, 0xd9,
+	}
+)
+
+var errDecrypt = errors.New("failed to decrypt packet")
+
+// initialKeys are the keys protecting the Initial packets sent by one of the
+// endpoints.
+type initialKeys struct {
+	aead cipher.AEAD
+	iv   []byte
+	hp   cipher.Block
+}
+
+// newInitialKeys derives the keys protecting the Initial packets of a
+// connection from the destination connection ID of the first Initial packet
+// sent by the client.
+func newInitialKeys(version uint32, dcid []byte, isClient bool) (*initialKeys, error) {
+	key, iv, hpKey, err := deriveInitialSecrets(version, dcid, isClient)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	hp, err := aes.NewCipher(hpKey)
+	if err != nil {
+		return nil, err
+	}
+	return &initialKeys{aead: aead, iv: iv, hp: hp}, nil
+}
+
+// deriveInitialSecrets returns the packet protection key, IV and header
+// protection key of the Initial packets (RFC 9001 section 5.2).
+func deriveInitialSecrets(version uint32, dcid []byte, isClient bool) (key, iv, hp []byte, err error) {
+	salt, labelPrefix := initialSaltV1, "quic "
+	if version == versionV2 {
+		salt, labelPrefix = initialSaltV2, "quicv2 "
+	}
+	initialSecret, err := hkdf.Extract(sha256.New, dcid, salt)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	label := "server in"
+	if isClient {
+		label = "client in"
+	}
+	secret, err := expandLabel(initialSecret, label, sha256.Size)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if key, err = expandLabel(secret, labelPrefix+"key", 16); err != nil {
+		return nil, nil, nil, err
+	}
+	if iv, err = expandLabel(secret, labelPrefix+"iv", 12); err != nil {
+		return nil, nil, nil, err
+	}
+	if hp, err = expandLabel(secret, labelPrefix+"hp", 16); err != nil {
+		return nil, nil, nil, err
+	}
+	return key, iv, hp, nil
+}
+
+// expandLabel implements HKDF-Expand-Label from RFC 8446 section 7.1 with an
+// empty context.
+func expandLabel(secret []byte, label string, length int) ([]byte, error) {
+	info := make([]byte, 0, 4+len("tls13 ")+len(label))
+	info = binary.BigEndian.AppendUint16(info, uint16(length))
+	info = append(info, byte(len("tls13 ")+len(label)))
+	info = append(info, "tls13 "...)
+	info = append(info, label...)
+	info = append(info, 0)
+	return hkdf.Expand(sha256.New, secret, string(info), length)
+}
+
+// open removes the header protection of the long header packet in data and
+// decrypts its payload. pnOffset is the offset of the packet number and
+// length the value of the Length field, which covers the packet number and
+// the payload. The packet number and the decrypted payload are returned. The
+// packet in data is modified.
+func (k *initialKeys) open(data []byte, pnOffset, length int) (uint64, []byte, error) {
+	const sampleLen = 16
+	if pnOffset+4+sampleLen > len(data) || pnOffset+length > len(data) {
+		return 0, nil, errDecrypt
+	}
+	var mask [aes.BlockSize]byte
+	k.hp.Encrypt(mask[:], data[pnOffset+4:pnOffset+4+sampleLen])
+
+	data[0] ^= mask[0] & 0x0f
+	pnLen := int(data[0]&0x03) + 1
+	if pnLen > length {
+		return 0, nil, errDecrypt
+	}
+	var pn uint64
+	for i := 0; i < pnLen; i++ {
+		data[pnOffset+i] ^= mask[1+i]
+		pn = pn<<8 | uint64(data[pnOffset+i])
+	}
+
+	nonce := make([]byte, len(k.iv))
+	copy(nonce, k.iv)
+	for i := 0; i < 8; i++ {
+		nonce[len(nonce)-1-i] ^= byte(pn >> (8 * i))
+	}
+
+	header := data[:pnOffset+pnLen]
+	ciphertext := data[pnOffset+pnLen : pnOffset+length]
+	payload, err := k.aead.Open(nil, nonce, ciphertext, header)
+	if err != nil {
+		return 0, nil, errDecrypt
+	}
+	return pn, payload, nil
+}
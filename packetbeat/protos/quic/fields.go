@@ -0,0 +1,36 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Code generated by beats/dev-tools/cmd/asset/asset.go - DO NOT EDIT.
+
+package quic
+
+import (
+	"github.com/elastic/beats/v7/libbeat/asset"
+)
+
+func init() {
+	if err := asset.SetFields("packetbeat", "quic", asset.ModuleFieldsPri, AssetQuic); err != nil {
+		panic(err)
+	}
+}
+
+// AssetQuic returns asset data.
+// This is the base64 encoded zlib format compressed contents of protos/quic.
+func AssetQuic() string {
+	return "eJy8lE9r3DAUxO/+FMOeu4bS2x4KIT1koZS2NOeglcaxsKynSto//vbF9m4it5uSUshtmbXf/Gb05DU6Dhv83FtdAdlmxw1W3+63t6sKMEw62pCt+A1GcZ0CtW2sBg/0GY2lM6mucP61qQBgDa96Pk0dpTwEbvAYZR/OymL21jcSezUaQe1kn5FbolXepFZ1hDSTPbR4Tz0+NpkCS+PS/MCYrPgn/cLQcThKNIXOk+rDlPv9qpAXgB+LPzCznA1GthF26222yiEo3TGnGve+83L0l+cSVCQig8TM0h5QCQotT8pQ2145+H2/Y6yrP0IZpmz91NPDcxcP1rwi5l/y3PEEei2GprQo6sb205WUCFHyzLCY13GY4xpGe6BBE6Wvsc2wCbqVRI/dMA3UztLnd5B4URLjgXEx79jSw2ao1KXiLWRBZI7DlabmufV/lVTWsqziWoYrEHOUt4E4e73YhHLhny5D++ElouXu3ITgrJ5WcloH0eISpGkYaZYNwfrp9G4+f/0CnjL9bzcUl8t0O53eHZ2TK7VOZ168NmfZiTgq/zrqH3HPea2euxvXi6bkzYKUVZy/RkX56lHZJffR5hYK30cyBKU75rr6NQDXWJv8"
+}
@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Frame types allowed in Initial packets (RFC 9000 section 12.4).
+const (
+	framePadding         = 0x00
+	framePing            = 0x01
+	frameAck             = 0x02
+	frameAckECN          = 0x03
+	frameCrypto          = 0x06
+	frameConnectionClose = 0x1c
+)
+
+var errUnexpectedFrame = errors.New("unexpected frame type in initial packet")
+
+type cryptoFrame struct {
+	offset uint64
+	data   []byte
+}
+
+// connectionClose is a CONNECTION_CLOSE frame signalling a transport error.
+type connectionClose struct {
+	errorCode uint64
+	reason    string
+}
+
+func (c *connectionClose) String() string {
+	if c.reason == "" {
+		return fmt.Sprintf("connection closed with error 0x%x", c.errorCode)
+	}
+	return fmt.Sprintf("connection closed with error 0x%x: %s", c.errorCode, c.reason)
+}
+
+// initialFrames are the frames of interest in the payload of an Initial
+// packet.
+type initialFrames struct {
+	crypto []cryptoFrame
+	close  *connectionClose
+}
+
+// parseInitialFrames parses the frames in the decrypted payload of an
+// Initial packet.
+func parseInitialFrames(payload []byte) (*initialFrames, error) {
+	frames := &initialFrames{}
+	for pos := 0; pos < len(payload); {
+		typ, n, err := readVarint(payload[pos:])
+		if err != nil {
+			return nil, err
+		}
+		pos += n
+
+		switch typ {
+		case framePadding, framePing:
+		case frameAck, frameAckECN:
+			// Largest Acknowledged, ACK Delay, ACK Range Count and First ACK Range.
+			var fields [4]uint64
+			for i := range fields {
+				if fields[i], n, err = readVarint(payload[pos:]); err != nil {
+					return nil, err
+				}
+				pos += n
+			}
+			// Gap and ACK Range Length for each additional range, followed by
+			// the ECN counts.
+			count := 2 * fields[2]
+			if typ == frameAckECN {
+				count += 3
+			}
+			for i := uint64(0); i < count; i++ {
+				if _, n, err = readVarint(payload[pos:]); err != nil {
+					return nil, err
+				}
+				pos += n
+			}
+		case frameCrypto:
+			offset, n, err := readVarint(payload[pos:])
+			if err != nil {
+				return nil, err
+			}
+			pos += n
+			length, n, err := readVarint(payload[pos:])
+			if err != nil {
+				return nil, err
+			}
+			pos += n
+			if uint64(len(payload)-pos) < length {
+				return nil, errTruncated
+			}
+			frames.crypto = append(frames.crypto, cryptoFrame{offset: offset, data: payload[pos : pos+int(length)]})
+			pos += int(length)
+		case frameConnectionClose:
+			var cc connectionClose
+			if cc.errorCode, n, err = readVarint(payload[pos:]); err != nil {
+				return nil, err
+			}
+			pos += n
+			// Frame Type.
+			if _, n, err = readVarint(payload[pos:]); err != nil {
+				return nil, err
+			}
+			pos += n
+			length, n, err := readVarint(payload[pos:])
+			if err != nil {
+				return nil, err
+			}
+			pos += n
+			if uint64(len(payload)-pos) < length {
+				return nil, errTruncated
+			}
+			cc.reason = string(payload[pos : pos+int(length)])
+			pos += int(length)
+			frames.close = &cc
+		default:
+			return nil, fmt.Errorf("%w: 0x%x", errUnexpectedFrame, typ)
+		}
+	}
+	return frames, nil
+}
+
+// maxCryptoStreamSize bounds the handshake data buffered for each endpoint.
+// It is much larger than any ClientHello or ServerHello.
+const maxCryptoStreamSize = 64 * 1024
+
+// cryptoStream reassembles the CRYPTO frames sent by an endpoint in Initial
+// packets. Frames can arrive out of order and be retransmitted.
+type cryptoStream struct {
+	data    []byte
+	pending map[uint64][]byte
+}
+
+func (s *cryptoStream) add(offset uint64, data []byte) {
+	if offset+uint64(len(data)) > maxCryptoStreamSize {
+		return
+	}
+	if offset > uint64(len(s.data)) {
+		if s.pending == nil {
+			s.pending = map[uint64][]byte{}
+		}
+		s.pending[offset] = append([]byte(nil), data...)
+		return
+	}
+	s.append(offset, data)
+
+	for progress := true; progress; {
+		progress = false
+		for off, buf := range s.pending {
+			if off <= uint64(len(s.data)) {
+				delete(s.pending, off)
+				s.append(off, buf)
+				progress = true
+			}
+		}
+	}
+}
+
+// append adds the part of data, starting at offset, that extends the stream.
+func (s *cryptoStream) append(offset uint64, data []byte) {
+	if end := offset + uint64(len(data)); end > uint64(len(s.data)) {
+		s.data = append(s.data, data[uint64(len(s.data))-offset:]...)
+	}
+}
+
+// firstMessage returns the type and body of the first handshake message in
+// the stream, once it has been received completely.
+func (s *cryptoStream) firstMessage() (typ uint8, body []byte, ok bool) {
+	if len(s.data) < 4 {
+		return 0, nil, false
+	}
+	length := int(s.data[1])<<16 | int(s.data[2])<<8 | int(s.data[3])
+	if len(s.data) < 4+length {
+		return 0, nil, false
+	}
+	return s.data[0], s.data[4 : 4+length], true
+}
+
+func (s *cryptoStream) reset() {
+	s.data = nil
+	s.pending = nil
+}
@@ -0,0 +1,212 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quic
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// QUIC versions with known Initial packet protection.
+const (
+	versionNegotiation uint32 = 0x00000000
+	versionV1          uint32 = 0x00000001 // RFC 9000
+	versionV2          uint32 = 0x6b3343cf // RFC 9369
+)
+
+const (
+	maxConnectionIDLength = 20
+	retryIntegrityTagSize = 16
+)
+
+var (
+	errTruncated          = errors.New("truncated packet")
+	errShortHeader        = errors.New("not a long header packet")
+	errInvalidFixedBit    = errors.New("fixed bit not set")
+	errInvalidCIDLength   = errors.New("connection ID too long")
+	errUnsupportedVersion = errors.New("unsupported QUIC version")
+)
+
+type packetType uint8
+
+const (
+	packetInitial packetType = iota
+	packetZeroRTT
+	packetHandshake
+	packetRetry
+	packetVersionNegotiation
+)
+
+func (t packetType) String() string {
+	switch t {
+	case packetInitial:
+		return "initial"
+	case packetZeroRTT:
+		return "0-rtt"
+	case packetHandshake:
+		return "handshake"
+	case packetRetry:
+		return "retry"
+	case packetVersionNegotiation:
+		return "version_negotiation"
+	default:
+		return "unknown"
+	}
+}
+
+// versionString returns the name of a QUIC version as reported in events.
+func versionString(version uint32) string {
+	switch version {
+	case versionV1:
+		return "1"
+	case versionV2:
+		return "2"
+	default:
+		return fmt.Sprintf("0x%08x", version)
+	}
+}
+
+// longHeader is the header of a QUIC long header packet.
+type longHeader struct {
+	typ     packetType
+	version uint32
+	dcid    []byte
+	scid    []byte
+
+	// versions is the list of versions supported by the server in a version
+	// negotiation packet.
+	versions []uint32
+
+	// pnOffset is the offset of the packet number and length the value of
+	// the Length field in Initial, 0-RTT and Handshake packets.
+	pnOffset int
+	length   int
+
+	// size is the size of the whole packet in the datagram. Packets sharing
+	// a datagram are coalesced.
+	size int
+}
+
+// parseLongHeader parses the header of the long header packet at the start
+// of data.
+func parseLongHeader(data []byte) (*longHeader, error) {
+	if len(data) < 7 {
+		return nil, errTruncated
+	}
+	if data[0]&0x80 == 0 {
+		return nil, errShortHeader
+	}
+	hdr := &longHeader{version: binary.BigEndian.Uint32(data[1:5])}
+
+	pos := 5
+	var err error
+	if hdr.dcid, pos, err = readConnectionID(data, pos); err != nil {
+		return nil, err
+	}
+	if hdr.scid, pos, err = readConnectionID(data, pos); err != nil {
+		return nil, err
+	}
+
+	if hdr.version == versionNegotiation {
+		hdr.typ = packetVersionNegotiation
+		for ; pos+4 <= len(data); pos += 4 {
+			hdr.versions = append(hdr.versions, binary.BigEndian.Uint32(data[pos:]))
+		}
+		hdr.size = len(data)
+		return hdr, nil
+	}
+	if data[0]&0x40 == 0 {
+		return nil, errInvalidFixedBit
+	}
+
+	switch hdr.version {
+	case versionV1:
+		hdr.typ = packetType((data[0] >> 4) & 0x03)
+	case versionV2:
+		// Version 2 rotates the packet type values.
+		hdr.typ = packetType(((data[0]>>4)&0x03 + 3) & 0x03)
+	default:
+		return hdr, errUnsupportedVersion
+	}
+
+	if hdr.typ == packetRetry {
+		if len(data)-pos < retryIntegrityTagSize {
+			return nil, errTruncated
+		}
+		hdr.size = len(data)
+		return hdr, nil
+	}
+
+	if hdr.typ == packetInitial {
+		tokenLen, n, err := readVarint(data[pos:])
+		if err != nil {
+			return nil, err
+		}
+		pos += n
+		if uint64(len(data)-pos) < tokenLen {
+			return nil, errTruncated
+		}
+		pos += int(tokenLen)
+	}
+
+	length, n, err := readVarint(data[pos:])
+	if err != nil {
+		return nil, err
+	}
+	pos += n
+	if uint64(len(data)-pos) < length {
+		return nil, errTruncated
+	}
+	hdr.pnOffset = pos
+	hdr.length = int(length)
+	hdr.size = pos + int(length)
+	return hdr, nil
+}
+
+func readConnectionID(data []byte, pos int) ([]byte, int, error) {
+	if pos >= len(data) {
+		return nil, pos, errTruncated
+	}
+	n := int(data[pos])
+	pos++
+	if n > maxConnectionIDLength {
+		return nil, pos, errInvalidCIDLength
+	}
+	if pos+n > len(data) {
+		return nil, pos, errTruncated
+	}
+	return data[pos : pos+n], pos + n, nil
+}
+
+// readVarint reads a variable-length integer (RFC 9000 section 16). It
+// returns the value and the number of bytes read.
+func readVarint(data []byte) (uint64, int, error) {
+	if len(data) == 0 {
+		return 0, 0, errTruncated
+	}
+	n := 1 << (data[0] >> 6)
+	if len(data) < n {
+		return 0, 0, errTruncated
+	}
+	v := uint64(data[0] & 0x3f)
+	for i := 1; i < n; i++ {
+		v = v<<8 | uint64(data[i])
+	}
+	return v, n, nil
+}
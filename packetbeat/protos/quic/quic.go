@@ -0,0 +1,400 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quic
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/ecs"
+	"github.com/elastic/beats/v7/packetbeat/pb"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/protos/tls"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+var (
+	metricParseFailures      = monitoring.NewUint(nil, "quic.parse_failures")
+	metricUnmatchedHandshake = monitoring.NewUint(nil, "quic.unmatched_handshakes")
+)
+
+// TLS handshake message types.
+const (
+	handshakeClientHello = 1
+	handshakeServerHello = 2
+)
+
+func init() {
+	protos.Register("quic", New)
+}
+
+// New constructs a new QUIC protocol plugin.
+func New(
+	testMode bool,
+	results protos.Reporter,
+	watcher *procs.ProcessesWatcher,
+	cfg *conf.C,
+) (protos.Plugin, error) {
+	return newPlugin(testMode, results, watcher, cfg)
+}
+
+func newPlugin(testMode bool, results protos.Reporter, watcher *procs.ProcessesWatcher, cfg *conf.C) (*quicPlugin, error) {
+	config := defaultConfig
+
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &quicPlugin{
+		ports:              config.Ports,
+		transactionTimeout: config.TransactionTimeout,
+		results:            results,
+		watcher:            watcher,
+		log:                logp.NewLogger("quic"),
+	}
+	p.transactions = common.NewCacheWithRemovalListener(
+		p.transactionTimeout,
+		protos.DefaultTransactionHashSize,
+		func(k common.Key, v common.Value) {
+			trans, ok := v.(*transaction)
+			if !ok {
+				p.log.Error("Expired value is not a *transaction.")
+				return
+			}
+			p.expireTransaction(trans)
+		})
+	p.transactions.StartJanitor(p.transactionTimeout)
+	return p, nil
+}
+
+type quicPlugin struct {
+	ports []int
+
+	// Cache of the handshakes in progress. The key is the hashable tuple of
+	// the packets sent by the client.
+	transactions       *common.Cache
+	transactionTimeout time.Duration
+
+	results protos.Reporter
+	watcher *procs.ProcessesWatcher
+	log     *logp.Logger
+}
+
+// transaction is the handshake of a QUIC connection, as seen in its Initial
+// packets.
+type transaction struct {
+	ts    time.Time // Time of the first Initial packet of the client.
+	endTs time.Time // Time of the last packet of the handshake.
+	tuple common.IPPortTuple
+	src   common.Endpoint
+	dst   common.Endpoint
+	notes []string
+
+	version uint32
+	// dcid is the destination connection ID the Initial keys are derived
+	// from. It is chosen by the client, or by the server with a Retry.
+	dcid       []byte
+	clientSCID []byte
+	serverSCID []byte
+	retried    bool
+
+	clientKeys, serverKeys *initialKeys
+	client, server         cryptoStream
+	clientBytes            int
+	serverBytes            int
+
+	clientHello *tls.ClientHello
+	serverHello *tls.ServerHello
+}
+
+func (p *quicPlugin) GetPorts() []int {
+	return p.ports
+}
+
+func (p *quicPlugin) ConnectionTimeout() time.Duration {
+	return p.transactionTimeout
+}
+
+func (p *quicPlugin) ParseUDP(pkt *protos.Packet) {
+	p.log.Debugf("Parsing packet addressed with %s of length %d.", &pkt.Tuple, len(pkt.Payload))
+
+	fromClient := true
+	trans := p.getTransaction(pkt.Tuple.Hashable())
+	if trans == nil {
+		if trans = p.getTransaction(pkt.Tuple.RevHashable()); trans != nil {
+			fromClient = false
+		}
+	}
+
+	isNew := false
+	for data := pkt.Payload; len(data) > 0; {
+		// Short header packets are protected with keys unknown to Packetbeat.
+		// They also end the datagram.
+		if data[0]&0x80 == 0 {
+			break
+		}
+		hdr, err := parseLongHeader(data)
+		if err != nil {
+			metricParseFailures.Inc()
+			p.log.Debugf("Dropping packet: %v", err)
+			break
+		}
+		packet := data[:hdr.size]
+		data = data[hdr.size:]
+
+		if trans == nil {
+			// Only the first Initial packet of a client starts a handshake.
+			if hdr.typ != packetInitial {
+				break
+			}
+			trans = newTransaction(pkt, p.watcher.FindProcessesTupleUDP(&pkt.Tuple))
+			isNew = true
+		}
+
+		if err := p.handlePacket(trans, fromClient, hdr, packet); err != nil {
+			p.log.Debugf("Failed handling %v packet: %v", hdr.typ, err)
+			if isNew {
+				// Not the start of a QUIC connection.
+				metricParseFailures.Inc()
+				return
+			}
+		}
+	}
+	if trans == nil {
+		return
+	}
+
+	trans.endTs = pkt.Ts
+	if fromClient {
+		trans.clientBytes += len(pkt.Payload)
+	} else {
+		trans.serverBytes += len(pkt.Payload)
+	}
+
+	if trans.serverHello != nil {
+		p.publishTransaction(trans)
+		p.transactions.Delete(trans.tuple.Hashable())
+		return
+	}
+	if isNew {
+		p.transactions.Put(trans.tuple.Hashable(), trans)
+	}
+}
+
+func newTransaction(pkt *protos.Packet, cmdline *common.ProcessTuple) *transaction {
+	trans := &transaction{
+		ts:    pkt.Ts,
+		tuple: pkt.Tuple,
+	}
+	trans.src, trans.dst = common.MakeEndpointPair(pkt.Tuple.BaseTuple, cmdline)
+	return trans
+}
+
+func (p *quicPlugin) getTransaction(k common.HashableIPPortTuple) *transaction {
+	v := p.transactions.Get(k)
+	if v != nil {
+		return v.(*transaction)
+	}
+	return nil
+}
+
+func (p *quicPlugin) handlePacket(trans *transaction, fromClient bool, hdr *longHeader, packet []byte) error {
+	switch hdr.typ {
+	case packetVersionNegotiation:
+		// The client starts again with one of the versions supported by the
+		// server.
+		trans.notes = append(trans.notes, "server requested version negotiation")
+		trans.resetKeys()
+		trans.dcid = nil
+		return nil
+	case packetRetry:
+		// The client starts again using the connection ID chosen by the
+		// server, that the Initial keys are derived from.
+		trans.retried = true
+		trans.resetKeys()
+		trans.dcid = append([]byte(nil), hdr.scid...)
+		return nil
+	case packetInitial:
+	default:
+		return nil
+	}
+
+	if fromClient {
+		if trans.dcid == nil {
+			trans.dcid = append([]byte(nil), hdr.dcid...)
+		}
+		if trans.version != hdr.version {
+			trans.version = hdr.version
+			trans.resetKeys()
+		}
+		trans.clientSCID = append(trans.clientSCID[:0], hdr.scid...)
+	} else {
+		trans.serverSCID = append(trans.serverSCID[:0], hdr.scid...)
+	}
+
+	keys, err := trans.keys(fromClient)
+	if err != nil {
+		return err
+	}
+	// Decryption modifies the packet in place.
+	packet = append([]byte(nil), packet...)
+	_, payload, err := keys.open(packet, hdr.pnOffset, hdr.length)
+	if err != nil {
+		return err
+	}
+	frames, err := parseInitialFrames(payload)
+	if err != nil {
+		return err
+	}
+	if frames.close != nil {
+		trans.notes = append(trans.notes, frames.close.String())
+	}
+
+	stream := &trans.server
+	if fromClient {
+		stream = &trans.client
+	}
+	for _, f := range frames.crypto {
+		stream.add(f.offset, f.data)
+	}
+
+	typ, body, ok := stream.firstMessage()
+	if !ok {
+		return nil
+	}
+	switch {
+	case fromClient && typ == handshakeClientHello && trans.clientHello == nil:
+		if trans.clientHello, ok = tls.ParseClientHello(body); !ok {
+			trans.notes = append(trans.notes, "failed parsing ClientHello")
+		}
+	case !fromClient && typ == handshakeServerHello && trans.serverHello == nil:
+		if trans.serverHello, ok = tls.ParseServerHello(body); !ok {
+			trans.notes = append(trans.notes, "failed parsing ServerHello")
+		}
+	}
+	return nil
+}
+
+// keys returns the keys protecting the Initial packets of the client or the
+// server.
+func (t *transaction) keys(client bool) (*initialKeys, error) {
+	var err error
+	if client {
+		if t.clientKeys == nil {
+			t.clientKeys, err = newInitialKeys(t.version, t.dcid, true)
+		}
+		return t.clientKeys, err
+	}
+	if t.serverKeys == nil {
+		t.serverKeys, err = newInitialKeys(t.version, t.dcid, false)
+	}
+	return t.serverKeys, err
+}
+
+// resetKeys discards the keys and the handshake data protected by them when
+// the client starts the connection again.
+func (t *transaction) resetKeys() {
+	t.clientKeys, t.serverKeys = nil, nil
+	t.client.reset()
+	t.server.reset()
+	t.clientHello, t.serverHello = nil, nil
+}
+
+func (p *quicPlugin) publishTransaction(t *transaction) {
+	if p.results == nil {
+		return
+	}
+
+	p.log.Debugf("Publishing transaction. %s", &t.tuple)
+
+	evt, pbf := pb.NewBeatEvent(t.ts)
+
+	pbf.SetSource(&t.src)
+	pbf.SetDestination(&t.dst)
+	pbf.Source.Bytes = int64(t.clientBytes)
+	if t.serverBytes > 0 {
+		pbf.Destination.Bytes = int64(t.serverBytes)
+	}
+	pbf.Event.Start = t.ts
+	pbf.Event.End = t.endTs
+	pbf.Network.Transport = "udp"
+	pbf.Network.Protocol = "quic"
+	pbf.Error.Message = t.notes
+
+	fields := evt.Fields
+	fields["type"] = pbf.Network.Protocol
+	fields["status"] = common.ERROR_STATUS
+	if t.clientHello != nil && t.serverHello != nil {
+		fields["status"] = common.OK_STATUS
+	}
+
+	quic := mapstr.M{
+		"version": versionString(t.version),
+	}
+	if len(t.dcid) > 0 {
+		quic["destination_connection_id"] = hex.EncodeToString(t.dcid)
+	}
+	if len(t.clientSCID) > 0 {
+		quic.Put("client.connection_id", hex.EncodeToString(t.clientSCID))
+	}
+	if len(t.serverSCID) > 0 {
+		quic.Put("server.connection_id", hex.EncodeToString(t.serverSCID))
+	}
+	if t.retried {
+		quic["retry"] = true
+	}
+
+	var tlsFields ecs.Tls
+	if hello := t.clientHello; hello != nil {
+		if hello.ServerName != "" {
+			pbf.Destination.Domain = hello.ServerName
+			tlsFields.ClientServerName = hello.ServerName
+		}
+		if len(hello.ALPN) > 0 {
+			quic.Put("client.alpn", hello.ALPN)
+		}
+		tlsFields.ClientJa3 = hello.JA3
+		tlsFields.ClientSupportedCiphers = hello.SupportedCiphers
+	}
+	if hello := t.serverHello; hello != nil {
+		tlsFields.Cipher = hello.Cipher
+		tlsFields.ServerJa3s = hello.JA3S
+		tlsFields.VersionProtocol, tlsFields.Version = hello.VersionProtocol, hello.Version
+	}
+	pb.MarshalStruct(fields, "tls", tlsFields)
+	if len(tlsFields.ClientSupportedCiphers) > 0 {
+		fields.Put("tls.client.supported_ciphers", tlsFields.ClientSupportedCiphers)
+	}
+	fields["quic"] = quic
+
+	p.results(evt)
+}
+
+func (p *quicPlugin) expireTransaction(t *transaction) {
+	t.notes = append(t.notes, "no ServerHello received")
+	p.log.Debugf("Handshake without ServerHello %s", &t.tuple)
+	p.publishTransaction(t)
+	metricUnmatchedHandshake.Inc()
+}
@@ -0,0 +1,342 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package quic
+
+import (
+	"crypto/aes"
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+	"github.com/elastic/beats/v7/packetbeat/protos"
+	"github.com/elastic/beats/v7/packetbeat/publish"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+var _ protos.UDPPlugin = &quicPlugin{}
+
+var (
+	// ClientHello handshake message for example.org offering h3.
+	clientHello = mustDecodeHex(
+		"010000a303033367dfae0d46ec0651e49cca2ae47317e8989df710ee7570a88b" +
+			"9a7d5d56b3af0000061301130213030100007400000010000e00000b6578616d" +
+			"706c652e6f7267001000050003026833000a00040002001d000d000800060403" +
+			"08040401002b0003020304003300260024001d0020a0a1a2a3a4a5a6a7a8a9aa" +
+			"abacadaeafb0b1b2b3b4b5b6b7b8b9babbbcbdbebf0039000e01048000753003" +
+			"04800000000f00",
+	)
+
+	// ServerHello handshake message selecting TLS_AES_128_GCM_SHA256 and TLS 1.3.
+	serverHello = mustDecodeHex(
+		"0200002e03037806e1be0c363bcc1fe14a906d1ff1b11dc5369d91c631ed660d" +
+			"6c0f156f4207001301000006002b00020304",
+	)
+
+	clientDCID = mustDecodeHex("8394c8f03e515708")
+	clientSCID = mustDecodeHex("c0ffee01")
+	serverSCID = mustDecodeHex("5e7e70000a")
+)
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// RFC 9001 appendix A.1 and RFC 9369 appendix A.1.
+func TestInitialSecrets(t *testing.T) {
+	for _, tc := range []struct {
+		version     uint32
+		client      bool
+		key, iv, hp string
+	}{
+		{versionV1, true, "1f369613dd76d5467730efcbe3b1a22d", "fa044b2f42a3fd3b46fb255c", "9f50449e04a0e810283a1e9933adedd2"},
+		{versionV1, false, "cf3a5331653c364c88f0f379b6067e37", "0ac1493ca1905853b0bba03e", "c206b8d9b9f0f37644430b490eeaa314"},
+		{versionV2, true, "8b1a0bc121284290a29e0971b5cd045d", "91f73e2351d8fa91660e909f", "45b95e15235d6f45a6b19cbcb0294ba9"},
+		{versionV2, false, "82db637861d55e1d011f19ea71d5d2a7", "dd13c276499c0249d3310652", "edf6d05c83121201b436e16877593c3a"},
+	} {
+		key, iv, hp, err := deriveInitialSecrets(tc.version, clientDCID, tc.client)
+		require.NoError(t, err)
+		assert.Equal(t, tc.key, hex.EncodeToString(key), "version %v client %v", tc.version, tc.client)
+		assert.Equal(t, tc.iv, hex.EncodeToString(iv), "version %v client %v", tc.version, tc.client)
+		assert.Equal(t, tc.hp, hex.EncodeToString(hp), "version %v client %v", tc.version, tc.client)
+	}
+}
+
+// RFC 9000 appendix A.1.
+func TestReadVarint(t *testing.T) {
+	for _, tc := range []struct {
+		in    string
+		value uint64
+	}{
+		{"c2197c5eff14e88c", 151288809941952652},
+		{"9d7f3e7d", 494878333},
+		{"7bbd", 15293},
+		{"25", 37},
+		{"4025", 37},
+	} {
+		v, n, err := readVarint(mustDecodeHex(tc.in))
+		require.NoError(t, err)
+		assert.Equal(t, tc.value, v)
+		assert.Equal(t, len(tc.in)/2, n)
+	}
+
+	_, _, err := readVarint(mustDecodeHex("9d7f"))
+	assert.ErrorIs(t, err, errTruncated)
+}
+
+func TestCryptoStream(t *testing.T) {
+	var s cryptoStream
+	s.add(6, []byte("world"))
+	s.add(0, []byte("hel"))
+	assert.Equal(t, "hel", string(s.data))
+	s.add(2, []byte("llo "))
+	assert.Equal(t, "hello world", string(s.data))
+	s.add(0, []byte("he"))
+	assert.Equal(t, "hello world", string(s.data))
+	assert.Empty(t, s.pending)
+}
+
+func TestHandshake(t *testing.T) {
+	for _, version := range []uint32{versionV1, versionV2} {
+		t.Run(versionString(version), func(t *testing.T) {
+			store := &eventStore{}
+			p := newTestPlugin(t, store)
+
+			// The ClientHello is split in CRYPTO frames sent out of order.
+			clientPayload := append(encodeCryptoFrame(100, clientHello[100:]), encodeCryptoFrame(0, clientHello[:100])...)
+			client := sealInitial(t, version, clientDCID, clientDCID, clientSCID, 0, padding(clientPayload, 1162), true)
+			p.ParseUDP(newPacket(clientTuple(), client))
+			require.Empty(t, store.events)
+
+			// The server Initial is coalesced with a Handshake packet.
+			serverPayload := append([]byte{frameAck, 0, 0, 0, 0}, encodeCryptoFrame(0, serverHello)...)
+			server := sealInitial(t, version, clientDCID, clientSCID, serverSCID, 0, serverPayload, false)
+			server = append(server, handshakePacket(version, clientSCID, serverSCID)...)
+			p.ParseUDP(newPacket(serverTuple(), server))
+
+			require.Len(t, store.events, 1)
+			fields := store.events[0].Fields
+			for k, want := range map[string]interface{}{
+				"type":                           "quic",
+				"status":                         common.OK_STATUS,
+				"network.protocol":               "quic",
+				"network.transport":              "udp",
+				"destination.domain":             "example.org",
+				"source.bytes":                   int64(len(client)),
+				"destination.bytes":              int64(len(server)),
+				"quic.version":                   versionString(version),
+				"quic.destination_connection_id": "8394c8f03e515708",
+				"quic.client.connection_id":      "c0ffee01",
+				"quic.server.connection_id":      "5e7e70000a",
+				"quic.client.alpn":               []string{"h3"},
+				"tls.client.server_name":         "example.org",
+				"tls.client.supported_ciphers":   []string{"TLS_AES_128_GCM_SHA256", "TLS_AES_256_GCM_SHA384", "TLS_CHACHA20_POLY1305_SHA256"},
+				"tls.cipher":                     "TLS_AES_128_GCM_SHA256",
+				"tls.version":                    "1.3",
+				"tls.version_protocol":           "tls",
+			} {
+				got, err := fields.GetValue(k)
+				if assert.NoError(t, err, k) {
+					assert.Equal(t, want, got, k)
+				}
+			}
+			assert.Nil(t, p.getTransaction(clientTuple().Hashable()))
+		})
+	}
+}
+
+func TestHandshakeWithoutServerHello(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store)
+
+	client := sealInitial(t, versionV1, clientDCID, clientDCID, clientSCID, 0, padding(encodeCryptoFrame(0, clientHello), 1162), true)
+	p.ParseUDP(newPacket(clientTuple(), client))
+	trans := p.getTransaction(clientTuple().Hashable())
+	require.NotNil(t, trans)
+
+	p.expireTransaction(trans)
+	require.Len(t, store.events, 1)
+	fields := store.events[0].Fields
+	assert.Equal(t, common.ERROR_STATUS, fields["status"])
+	serverName, _ := fields.GetValue("tls.client.server_name")
+	assert.Equal(t, "example.org", serverName)
+	msg, _ := fields.GetValue("error.message")
+	assert.Equal(t, "no ServerHello received", msg)
+}
+
+func TestRetry(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store)
+
+	client := sealInitial(t, versionV1, clientDCID, clientDCID, clientSCID, 0, padding(encodeCryptoFrame(0, clientHello), 1162), true)
+	p.ParseUDP(newPacket(clientTuple(), client))
+
+	// The server asks the client to start again with a token and the
+	// connection ID it chose.
+	retryDCID := mustDecodeHex("0badc0de")
+	retry := []byte{0xf0, 0, 0, 0, 1}
+	retry = append(retry, byte(len(clientSCID)))
+	retry = append(retry, clientSCID...)
+	retry = append(retry, byte(len(retryDCID)))
+	retry = append(retry, retryDCID...)
+	retry = append(retry, []byte("token")...)
+	retry = append(retry, make([]byte, retryIntegrityTagSize)...)
+	p.ParseUDP(newPacket(serverTuple(), retry))
+
+	client = sealInitial(t, versionV1, retryDCID, retryDCID, clientSCID, 1, padding(encodeCryptoFrame(0, clientHello), 1162), true)
+	p.ParseUDP(newPacket(clientTuple(), client))
+	server := sealInitial(t, versionV1, retryDCID, clientSCID, serverSCID, 0, encodeCryptoFrame(0, serverHello), false)
+	p.ParseUDP(newPacket(serverTuple(), server))
+
+	require.Len(t, store.events, 1)
+	fields := store.events[0].Fields
+	assert.Equal(t, common.OK_STATUS, fields["status"])
+	for k, want := range map[string]interface{}{
+		"quic.retry":                     true,
+		"quic.destination_connection_id": "0badc0de",
+	} {
+		got, err := fields.GetValue(k)
+		if assert.NoError(t, err, k) {
+			assert.Equal(t, want, got, k)
+		}
+	}
+}
+
+func TestNotQUIC(t *testing.T) {
+	store := &eventStore{}
+	p := newTestPlugin(t, store)
+
+	// A long header packet that can't be decrypted.
+	data := sealInitial(t, versionV1, clientDCID, clientDCID, clientSCID, 0, padding(nil, 1162), true)
+	data[len(data)-1] ^= 0xff
+	p.ParseUDP(newPacket(clientTuple(), data))
+
+	// Garbage.
+	p.ParseUDP(newPacket(clientTuple(), []byte("GET / HTTP/1.1\r\n\r\n")))
+
+	assert.Nil(t, p.getTransaction(clientTuple().Hashable()))
+	assert.Empty(t, store.events)
+}
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	publish.MarshalPacketbeatFields(&event, nil, nil)
+	e.events = append(e.events, event)
+}
+
+func newTestPlugin(t *testing.T, store *eventStore) *quicPlugin {
+	logp.TestingSetup()
+	p, err := newPlugin(true, store.publish, &procs.ProcessesWatcher{}, nil)
+	require.NoError(t, err)
+	return p
+}
+
+func clientTuple() *common.IPPortTuple {
+	tuple := common.NewIPPortTuple(4, net.IPv4(192, 168, 0, 1), 51000, net.IPv4(192, 168, 0, 2), 443)
+	return &tuple
+}
+
+func serverTuple() *common.IPPortTuple {
+	tuple := common.NewIPPortTuple(4, net.IPv4(192, 168, 0, 2), 443, net.IPv4(192, 168, 0, 1), 51000)
+	return &tuple
+}
+
+func newPacket(tuple *common.IPPortTuple, payload []byte) *protos.Packet {
+	return &protos.Packet{Ts: time.Now(), Tuple: *tuple, Payload: payload}
+}
+
+func encodeCryptoFrame(offset int, data []byte) []byte {
+	frame := []byte{frameCrypto, 0x40 | byte(offset>>8), byte(offset), 0x40 | byte(len(data)>>8), byte(len(data))}
+	return append(frame, data...)
+}
+
+func padding(payload []byte, size int) []byte {
+	if len(payload) >= size {
+		return payload
+	}
+	return append(payload, make([]byte, size-len(payload))...)
+}
+
+// sealInitial builds an Initial packet protected with the keys derived from
+// keysDCID, with a 2 bytes packet number.
+func sealInitial(t *testing.T, version uint32, keysDCID, dcid, scid []byte, pn uint16, payload []byte, isClient bool) []byte {
+	t.Helper()
+	keys, err := newInitialKeys(version, keysDCID, isClient)
+	require.NoError(t, err)
+
+	typeBits := byte(0)
+	if version == versionV2 {
+		typeBits = 1
+	}
+	const pnLen = 2
+	packet := []byte{0xc0 | typeBits<<4 | (pnLen - 1)}
+	packet = binary.BigEndian.AppendUint32(packet, version)
+	packet = append(packet, byte(len(dcid)))
+	packet = append(packet, dcid...)
+	packet = append(packet, byte(len(scid)))
+	packet = append(packet, scid...)
+	packet = append(packet, 0) // Token Length.
+	length := pnLen + len(payload) + keys.aead.Overhead()
+	packet = append(packet, 0x40|byte(length>>8), byte(length))
+	pnOffset := len(packet)
+	packet = binary.BigEndian.AppendUint16(packet, pn)
+
+	nonce := append([]byte(nil), keys.iv...)
+	nonce[len(nonce)-2] ^= byte(pn >> 8)
+	nonce[len(nonce)-1] ^= byte(pn)
+	packet = keys.aead.Seal(packet, nonce, payload, packet)
+
+	var mask [aes.BlockSize]byte
+	keys.hp.Encrypt(mask[:], packet[pnOffset+4:pnOffset+4+16])
+	packet[0] ^= mask[0] & 0x0f
+	packet[pnOffset] ^= mask[1]
+	packet[pnOffset+1] ^= mask[2]
+	return packet
+}
+
+// handshakePacket returns a Handshake packet with a payload that can't be
+// decrypted with the Initial keys.
+func handshakePacket(version uint32, dcid, scid []byte) []byte {
+	typeBits := byte(2)
+	if version == versionV2 {
+		typeBits = 3
+	}
+	packet := []byte{0xc0 | typeBits<<4 | 1}
+	packet = binary.BigEndian.AppendUint32(packet, version)
+	packet = append(packet, byte(len(dcid)))
+	packet = append(packet, dcid...)
+	packet = append(packet, byte(len(scid)))
+	packet = append(packet, scid...)
+	payload := make([]byte, 40)
+	packet = append(packet, 0x40, byte(len(payload)))
+	return append(packet, payload...)
+}
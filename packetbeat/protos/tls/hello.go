@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tls
+
+import (
+	"github.com/elastic/beats/v7/libbeat/common/streambuf"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// ClientHello is the information extracted from a TLS ClientHello message
+// that is relevant to protocols carrying TLS handshakes outside of TLS
+// records, like QUIC.
+type ClientHello struct {
+	ServerName       string
+	ALPN             []string
+	SupportedCiphers []string
+	JA3              string
+	Extensions       mapstr.M
+}
+
+// ServerHello is the information extracted from a TLS ServerHello message.
+type ServerHello struct {
+	Cipher          string
+	JA3S            string
+	VersionProtocol string
+	Version         string
+}
+
+// ParseClientHello parses the body of a ClientHello handshake message, that
+// is, without the handshake header.
+func ParseClientHello(body []byte) (*ClientHello, bool) {
+	hello := parseClientHello(*newBufferView(streambuf.New(body), 0, len(body)))
+	if hello == nil {
+		return nil, false
+	}
+	result := &ClientHello{
+		SupportedCiphers: hello.supportedCiphers(),
+		Extensions:       hello.extensions.Parsed,
+	}
+	result.JA3, _ = getJa3Fingerprint(hello)
+	if list, ok := hello.extensions.Parsed["server_name_indication"].([]string); ok && len(list) > 0 {
+		result.ServerName = list[0]
+	}
+	if list, ok := hello.extensions.Parsed["application_layer_protocol_negotiation"].([]string); ok {
+		result.ALPN = list
+	}
+	return result, true
+}
+
+// ParseServerHello parses the body of a ServerHello handshake message, that
+// is, without the handshake header.
+func ParseServerHello(body []byte) (*ServerHello, bool) {
+	hello := parseServerHello(*newBufferView(streambuf.New(body), 0, len(body)))
+	if hello == nil {
+		return nil, false
+	}
+	result := &ServerHello{
+		Cipher: hello.selected.cipherSuite.String(),
+	}
+	result.JA3S, _ = getJa3Fingerprint(hello)
+
+	version := hello.version
+	const supportedVersionsExt = 43
+	if raw, ok := hello.extensions.Raw[supportedVersionsExt]; ok && len(raw) == 2 {
+		version = tlsVersion{major: raw[0], minor: raw[1]}
+	}
+	pVer := version.GetProtocolVersion()
+	result.VersionProtocol, result.Version = pVer.Protocol, pVer.Version
+	return result, true
+}
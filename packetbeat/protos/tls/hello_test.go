@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tls
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// handshakeBody returns the body of the handshake message in a raw record,
+// skipping the record and handshake headers.
+func handshakeBody(t *testing.T, raw string) []byte {
+	data, err := hex.DecodeString(raw)
+	require.NoError(t, err)
+	return data[recordHeaderSize+handshakeHeaderSize:]
+}
+
+func TestParseClientHello(t *testing.T) {
+	hello, ok := ParseClientHello(handshakeBody(t, rawClientHello))
+	require.True(t, ok)
+	assert.Equal(t, "example.org", hello.ServerName)
+	assert.Equal(t, []string{"h2", "http/1.1"}, hello.ALPN)
+	assert.Equal(t, "94c485bca29d5392be53f2b8cf7f4304", hello.JA3)
+	assert.Contains(t, hello.SupportedCiphers, "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256")
+
+	_, ok = ParseClientHello([]byte{3, 3, 0})
+	assert.False(t, ok)
+}
+
+func TestParseServerHello(t *testing.T) {
+	hello, ok := ParseServerHello(handshakeBody(t, rawServerHello))
+	require.True(t, ok)
+	assert.Equal(t, "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", hello.Cipher)
+	assert.Equal(t, "tls", hello.VersionProtocol)
+	assert.Equal(t, "1.2", hello.Version)
+}
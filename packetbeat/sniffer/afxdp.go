@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sniffer
+
+import (
+	"fmt"
+	"time"
+)
+
+type afXDPConfig struct {
+	// ID is the AF_XDP identifier for metric collection.
+	ID string
+	// Device name (e.g. eth0). The 'any' device is not supported.
+	Device string
+	// Size of a UMEM frame. Must be a power of two between 2048 and the OS page size.
+	FrameSize int
+	// Number of UMEM frames allocated for each receive queue. Must be a power of two.
+	NumFrames       int
+	Snaplen         int           // Maximum number of bytes of each packet to capture.
+	MetricsInterval time.Duration // Metrics polling interval.
+	PollTimeout     time.Duration // Duration that poll() should block waiting for data.
+	BusyPoll        time.Duration // Busy-polling duration, busy-polling is disabled when zero.
+	BusyPollBudget  int           // Maximum number of packets handled per busy-poll.
+	Promiscuous     bool          // Put device into promiscuous mode.
+}
+
+// afxdpComputeSize computes the frame_size and the num_frames in such a way
+// that the UMEM area allocated for each receive queue is close to but smaller
+// than target_size_mb. AF_XDP frames can not span pages and the rings require
+// the number of frames to be a power of two.
+func afxdpComputeSize(targetSizeMb, snaplen, pageSize int) (frameSize, numFrames int, err error) {
+	const minFrameSize = 2048
+	frameSize = minFrameSize
+	for frameSize < snaplen && frameSize < pageSize {
+		frameSize *= 2
+	}
+
+	n := (targetSizeMb * 1024 * 1024) / frameSize
+	if n == 0 {
+		return 0, 0, fmt.Errorf("buffer size too small")
+	}
+	numFrames = 1
+	for numFrames*2 <= n {
+		numFrames *= 2
+	}
+
+	return frameSize, numFrames, nil
+}
@@ -0,0 +1,539 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package sniffer
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/link"
+	"github.com/cilium/ebpf/rlimit"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"golang.org/x/net/bpf"
+	"golang.org/x/sys/unix"
+
+	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+var errAFXDPTimeout = errors.New("af_xdp: timeout waiting for packets")
+
+// afxdpHandle captures packets from all the receive queues of a device. An XDP
+// program attached to the device redirects every packet to the AF_XDP socket
+// bound to the queue the packet was received on.
+type afxdpHandle struct {
+	queues      []*xdpQueue
+	pollFds     []unix.PollFd
+	next        int // Index of the next queue to read from.
+	snaplen     int
+	pollTimeout int // poll() timeout in milliseconds.
+	filter      *bpf.VM
+
+	xsks *ebpf.Map
+	prog *ebpf.Program
+	link link.Link
+
+	promiscPreviousState         bool
+	promiscPreviousStateDetected bool
+	device                       string
+	log                          *logp.Logger
+	metrics                      *xdpMetrics
+}
+
+func newAfxdpHandle(c afXDPConfig) (*afxdpHandle, error) {
+	var err error
+	var promiscEnabled bool
+	log := logp.NewLogger("sniffer")
+
+	iface, err := net.InterfaceByName(c.Device)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device '%s': %w", c.Device, err)
+	}
+	numQueues, err := rxQueueCount(c.Device)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receive queues for device '%s': %w", c.Device, err)
+	}
+
+	if c.Promiscuous {
+		promiscEnabled, err = isPromiscEnabled(c.Device)
+		if err != nil {
+			log.Errorf("Failed to get promiscuous mode for device '%s': %v", c.Device, err)
+		}
+
+		if !promiscEnabled {
+			if setPromiscErr := setPromiscMode(c.Device, true); setPromiscErr != nil {
+				log.Warnf("Failed to set promiscuous mode for device '%s'. "+
+					"Packetbeat may be unable to see any network traffic. Please follow packetbeat "+
+					"FAQ to learn about mitigation: Error: %v", c.Device, setPromiscErr)
+			}
+		}
+	}
+
+	h := &afxdpHandle{
+		snaplen:                      c.Snaplen,
+		pollTimeout:                  int(c.PollTimeout.Milliseconds()),
+		promiscPreviousState:         promiscEnabled,
+		promiscPreviousStateDetected: c.Promiscuous && err == nil,
+		device:                       c.Device,
+		log:                          log,
+	}
+
+	// Kernels before 5.11 account BPF maps and programs against RLIMIT_MEMLOCK.
+	if err = rlimit.RemoveMemlock(); err != nil {
+		log.Debugw("Failed to remove memlock rlimit", "error", err)
+	}
+
+	h.xsks, err = ebpf.NewMap(&ebpf.MapSpec{
+		Name:       "pb_xsks",
+		Type:       ebpf.XSKMap,
+		KeySize:    4,
+		ValueSize:  4,
+		MaxEntries: uint32(numQueues),
+	})
+	if err != nil {
+		h.Close()
+		return nil, fmt.Errorf("failed creating XSKMAP: %w", err)
+	}
+
+	for id := 0; id < numQueues; id++ {
+		q, err := newXDPQueue(iface.Index, id, c)
+		if err != nil {
+			h.Close()
+			return nil, fmt.Errorf("failed creating af_xdp socket for queue %d: %w", id, err)
+		}
+		h.queues = append(h.queues, q)
+		h.pollFds = append(h.pollFds, unix.PollFd{Fd: int32(q.fd), Events: unix.POLLIN})
+
+		if err = h.xsks.Update(uint32(id), uint32(q.fd), ebpf.UpdateAny); err != nil {
+			h.Close()
+			return nil, fmt.Errorf("failed registering af_xdp socket for queue %d: %w", id, err)
+		}
+	}
+
+	h.prog, err = ebpf.NewProgram(&ebpf.ProgramSpec{
+		Name:         "pb_xdp_redirect",
+		Type:         ebpf.XDP,
+		License:      "Apache-2.0",
+		Instructions: xdpRedirectProgram(h.xsks),
+	})
+	if err != nil {
+		h.Close()
+		return nil, fmt.Errorf("failed loading XDP program: %w", err)
+	}
+	h.link, err = link.AttachXDP(link.XDPOptions{
+		Program:   h.prog,
+		Interface: iface.Index,
+	})
+	if err != nil {
+		h.Close()
+		return nil, fmt.Errorf("failed attaching XDP program to device '%s': %w", c.Device, err)
+	}
+	log.Infof("Capturing from %d receive queues of device '%s' with af_xdp", numQueues, c.Device)
+
+	h.metrics = newXDPMetrics(c.ID, c.Device, c.MetricsInterval, h.queues, log)
+
+	return h, nil
+}
+
+// xdpRedirectProgram returns the instructions of an XDP program equivalent to
+//
+//	return bpf_redirect_map(&xsks, ctx->rx_queue_index, XDP_PASS);
+//
+// Packets received on a queue without a registered socket are passed on to
+// the network stack.
+func xdpRedirectProgram(xsks *ebpf.Map) asm.Instructions {
+	const (
+		rxQueueIndexOffset = 16 // offsetof(struct xdp_md, rx_queue_index)
+		xdpPass            = 2
+	)
+	return asm.Instructions{
+		asm.LoadMem(asm.R2, asm.R1, rxQueueIndexOffset, asm.Word),
+		asm.LoadMapPtr(asm.R1, xsks.FD()),
+		asm.Mov.Imm(asm.R3, xdpPass),
+		asm.FnRedirectMap.Call(),
+		asm.Return(),
+	}
+}
+
+// rxQueueCount returns the number of receive queues of device.
+func rxQueueCount(device string) (int, error) {
+	entries, err := os.ReadDir(filepath.Join("/sys/class/net", device, "queues"))
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "rx-") {
+			n++
+		}
+	}
+	if n == 0 {
+		return 1, nil
+	}
+	return n, nil
+}
+
+func (h *afxdpHandle) ReadPacketData() (data []byte, ci gopacket.CaptureInfo, err error) {
+	for {
+		for range h.queues {
+			q := h.queues[h.next]
+			h.next = (h.next + 1) % len(h.queues)
+
+			desc, ok := q.receive()
+			if !ok {
+				continue
+			}
+			frame := q.umem[desc.Addr : desc.Addr+uint64(desc.Len)]
+			if h.filter != nil {
+				if n, err := h.filter.Run(frame); err != nil || n == 0 {
+					q.release(desc.Addr)
+					continue
+				}
+			}
+			data = make([]byte, min(len(frame), h.snaplen))
+			copy(data, frame)
+			q.release(desc.Addr)
+
+			h.metrics.packets.Inc()
+			ci = gopacket.CaptureInfo{
+				Timestamp:     time.Now(),
+				CaptureLength: len(data),
+				Length:        len(frame),
+			}
+			return data, ci, nil
+		}
+
+		h.metrics.polls.Inc()
+		n, err := unix.Poll(h.pollFds, h.pollTimeout)
+		if err != nil {
+			if errors.Is(err, unix.EINTR) {
+				continue
+			}
+			return nil, ci, fmt.Errorf("af_xdp poll failed: %w", err)
+		}
+		if n == 0 {
+			return nil, ci, errAFXDPTimeout
+		}
+	}
+}
+
+// SetBPFFilter sets the packet filter. AF_XDP sockets do not support socket
+// filters, so the filter is evaluated in user space.
+func (h *afxdpHandle) SetBPFFilter(expr string) error {
+	if expr == "" {
+		h.filter = nil
+		return nil
+	}
+	prog, err := pcap.CompileBPFFilter(layers.LinkTypeEthernet, h.snaplen, expr)
+	if err != nil {
+		return err
+	}
+	insns := make([]bpf.Instruction, len(prog))
+	for i, ins := range prog {
+		insns[i] = bpf.RawInstruction{
+			Op: ins.Code,
+			Jt: ins.Jt,
+			Jf: ins.Jf,
+			K:  ins.K,
+		}.Disassemble()
+	}
+	h.filter, err = bpf.NewVM(insns)
+	return err
+}
+
+func (h *afxdpHandle) LinkType() layers.LinkType {
+	return layers.LinkTypeEthernet
+}
+
+func (h *afxdpHandle) Close() {
+	h.metrics.close()
+
+	// Detach the program first so that no more packets are redirected
+	// to the sockets.
+	if h.link != nil {
+		h.link.Close()
+	}
+	if h.prog != nil {
+		h.prog.Close()
+	}
+	if h.xsks != nil {
+		h.xsks.Close()
+	}
+	for _, q := range h.queues {
+		q.close()
+	}
+	// previous state detected only if auto mode was on
+	if h.promiscPreviousStateDetected {
+		if err := setPromiscMode(h.device, h.promiscPreviousState); err != nil {
+			h.log.Warnf("Failed to reset promiscuous mode for device '%s'. Your device might be in promiscuous mode.: %v", h.device, err)
+		}
+	}
+}
+
+// isAfxdpErrTimeout returns whether err is errAFXDPTimeout.
+func isAfxdpErrTimeout(err error) bool {
+	return err == errAFXDPTimeout //nolint:errorlint // errAFXDPTimeout is never wrapped.
+}
+
+// xdpQueue is an AF_XDP socket bound to a single receive queue together with
+// its UMEM area and the fill and RX rings shared with the kernel.
+type xdpQueue struct {
+	fd        int
+	umem      []byte
+	frameMask uint64
+	fill      xdpRing
+	rx        xdpRing
+}
+
+// xdpRing is a single-producer single-consumer ring shared with the kernel.
+type xdpRing struct {
+	mem      []byte
+	producer *uint32
+	consumer *uint32
+	desc     unsafe.Pointer
+	mask     uint32
+}
+
+func newXDPRing(mem []byte, off unix.XDPRingOffset, size int) xdpRing {
+	return xdpRing{
+		mem:      mem,
+		producer: (*uint32)(unsafe.Pointer(&mem[off.Producer])),
+		consumer: (*uint32)(unsafe.Pointer(&mem[off.Consumer])),
+		desc:     unsafe.Pointer(&mem[off.Desc]),
+		mask:     uint32(size - 1),
+	}
+}
+
+func newXDPQueue(ifindex, id int, c afXDPConfig) (q *xdpQueue, err error) {
+	fd, err := unix.Socket(unix.AF_XDP, unix.SOCK_RAW|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("socket: %w", err)
+	}
+	q = &xdpQueue{fd: fd, frameMask: ^uint64(c.FrameSize - 1)}
+	defer func() {
+		if err != nil {
+			q.close()
+		}
+	}()
+
+	q.umem, err = unix.Mmap(-1, 0, c.FrameSize*c.NumFrames,
+		unix.PROT_READ|unix.PROT_WRITE, unix.MAP_PRIVATE|unix.MAP_ANONYMOUS|unix.MAP_POPULATE)
+	if err != nil {
+		return q, fmt.Errorf("failed allocating UMEM: %w", err)
+	}
+	reg := unix.XDPUmemReg{
+		Addr: uint64(uintptr(unsafe.Pointer(&q.umem[0]))),
+		Len:  uint64(len(q.umem)),
+		Size: uint32(c.FrameSize),
+	}
+	if err = setsockopt(fd, unix.SOL_XDP, unix.XDP_UMEM_REG, unsafe.Pointer(&reg), unsafe.Sizeof(reg)); err != nil {
+		return q, fmt.Errorf("failed registering UMEM: %w", err)
+	}
+	// The completion ring is only used for transmission, but it must
+	// exist for the socket to be bound.
+	for _, opt := range []int{unix.XDP_UMEM_FILL_RING, unix.XDP_UMEM_COMPLETION_RING, unix.XDP_RX_RING} {
+		if err = unix.SetsockoptInt(fd, unix.SOL_XDP, opt, c.NumFrames); err != nil {
+			return q, fmt.Errorf("failed setting ring size: %w", err)
+		}
+	}
+
+	var off unix.XDPMmapOffsets
+	if err = getsockopt(fd, unix.SOL_XDP, unix.XDP_MMAP_OFFSETS, unsafe.Pointer(&off), unsafe.Sizeof(off)); err != nil {
+		return q, fmt.Errorf("failed getting ring offsets: %w", err)
+	}
+	mem, err := unix.Mmap(fd, unix.XDP_UMEM_PGOFF_FILL_RING, int(off.Fr.Desc)+c.NumFrames*8,
+		unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED|unix.MAP_POPULATE)
+	if err != nil {
+		return q, fmt.Errorf("failed mapping fill ring: %w", err)
+	}
+	q.fill = newXDPRing(mem, off.Fr, c.NumFrames)
+	mem, err = unix.Mmap(fd, unix.XDP_PGOFF_RX_RING, int(off.Rx.Desc)+c.NumFrames*int(unsafe.Sizeof(unix.XDPDesc{})),
+		unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED|unix.MAP_POPULATE)
+	if err != nil {
+		return q, fmt.Errorf("failed mapping RX ring: %w", err)
+	}
+	q.rx = newXDPRing(mem, off.Rx, c.NumFrames)
+
+	// Hand all frames over to the kernel.
+	for i := 0; i < c.NumFrames; i++ {
+		*(*uint64)(unsafe.Add(q.fill.desc, i*8)) = uint64(i * c.FrameSize)
+	}
+	atomic.StoreUint32(q.fill.producer, uint32(c.NumFrames))
+
+	if c.BusyPoll > 0 {
+		for _, opt := range []struct{ name, value int }{
+			{unix.SO_PREFER_BUSY_POLL, 1},
+			{unix.SO_BUSY_POLL, int(c.BusyPoll.Microseconds())},
+			{unix.SO_BUSY_POLL_BUDGET, c.BusyPollBudget},
+		} {
+			if err = unix.SetsockoptInt(fd, unix.SOL_SOCKET, opt.name, opt.value); err != nil {
+				return q, fmt.Errorf("failed enabling busy-polling: %w", err)
+			}
+		}
+	}
+
+	err = unix.Bind(fd, &unix.SockaddrXDP{Ifindex: uint32(ifindex), QueueID: uint32(id)})
+	if err != nil {
+		return q, fmt.Errorf("bind: %w", err)
+	}
+	return q, nil
+}
+
+// receive returns the next descriptor from the RX ring.
+func (q *xdpQueue) receive() (unix.XDPDesc, bool) {
+	cons := atomic.LoadUint32(q.rx.consumer)
+	if cons == atomic.LoadUint32(q.rx.producer) {
+		return unix.XDPDesc{}, false
+	}
+	desc := *(*unix.XDPDesc)(unsafe.Add(q.rx.desc, uintptr(cons&q.rx.mask)*unsafe.Sizeof(unix.XDPDesc{})))
+	atomic.StoreUint32(q.rx.consumer, cons+1)
+	return desc, true
+}
+
+// release returns the frame holding addr to the kernel through the fill ring.
+// The fill ring can hold all frames, so it is never full.
+func (q *xdpQueue) release(addr uint64) {
+	prod := atomic.LoadUint32(q.fill.producer)
+	*(*uint64)(unsafe.Add(q.fill.desc, uintptr(prod&q.fill.mask)*8)) = addr & q.frameMask
+	atomic.StoreUint32(q.fill.producer, prod+1)
+}
+
+func (q *xdpQueue) stats() (unix.XDPStatistics, error) {
+	var stats unix.XDPStatistics
+	err := getsockopt(q.fd, unix.SOL_XDP, unix.XDP_STATISTICS, unsafe.Pointer(&stats), unsafe.Sizeof(stats))
+	return stats, err
+}
+
+func (q *xdpQueue) close() {
+	unix.Close(q.fd)
+	for _, mem := range [][]byte{q.rx.mem, q.fill.mem, q.umem} {
+		if mem != nil {
+			_ = unix.Munmap(mem)
+		}
+	}
+}
+
+func setsockopt(fd, level, name int, val unsafe.Pointer, size uintptr) error {
+	_, _, errno := unix.Syscall6(unix.SYS_SETSOCKOPT, uintptr(fd), uintptr(level), uintptr(name), uintptr(val), size, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func getsockopt(fd, level, name int, val unsafe.Pointer, size uintptr) error {
+	l := uint32(size)
+	_, _, errno := unix.Syscall6(unix.SYS_GETSOCKOPT, uintptr(fd), uintptr(level), uintptr(name), uintptr(val), uintptr(unsafe.Pointer(&l)), 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+type xdpMetrics struct {
+	unregister func()
+	done       chan struct{} // used to signal to polling goroutine to stop
+
+	device               *monitoring.String // name of the device being monitored
+	queues               *monitoring.Uint   // number of receive queues captured from
+	rxDropped            *monitoring.Uint   // number of packets dropped by kernel for reasons other than a full RX ring
+	rxInvalidDescs       *monitoring.Uint   // number of invalid descriptors
+	rxRingFull           *monitoring.Uint   // number of packets dropped because the RX ring was full
+	rxFillRingEmptyDescs *monitoring.Uint   // number of times the fill ring had no frames available
+	packets              *monitoring.Uint   // number of packets read off the RX rings by packetbeat
+	polls                *monitoring.Uint   // number of blocking syscalls made by packetbeat waiting for packets
+}
+
+func (m *xdpMetrics) close() {
+	if m == nil {
+		return
+	}
+	m.unregister()
+	if m.done != nil {
+		close(m.done)
+		m.done = nil
+	}
+}
+
+func newXDPMetrics(id, device string, interval time.Duration, queues []*xdpQueue, log *logp.Logger) *xdpMetrics {
+	devID := fmt.Sprintf("%s-af_xdp::%s", id, device)
+	reg, unreg := inputmon.NewInputRegistry("af_xdp", devID, nil)
+	out := &xdpMetrics{
+		unregister:           unreg,
+		device:               monitoring.NewString(reg, "device"),
+		queues:               monitoring.NewUint(reg, "queues"),
+		rxDropped:            monitoring.NewUint(reg, "rx_dropped"),
+		rxInvalidDescs:       monitoring.NewUint(reg, "rx_invalid_descs"),
+		rxRingFull:           monitoring.NewUint(reg, "rx_ring_full"),
+		rxFillRingEmptyDescs: monitoring.NewUint(reg, "rx_fill_ring_empty_descs"),
+		packets:              monitoring.NewUint(reg, "packets"),
+		polls:                monitoring.NewUint(reg, "polls"),
+		done:                 make(chan struct{}),
+	}
+
+	out.device.Set(device)
+	out.queues.Set(uint64(len(queues)))
+
+	go func() {
+		log.Debugf("Starting stats collection goroutine, collection interval: %v", interval)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-out.done:
+				log.Debug("Shutting down stats collection goroutine")
+				return
+			case <-ticker.C:
+				var total unix.XDPStatistics
+				for _, q := range queues {
+					stats, err := q.stats()
+					if err != nil {
+						log.Debugw("Error getting socket stats", "error", err)
+						continue
+					}
+					total.Rx_dropped += stats.Rx_dropped
+					total.Rx_invalid_descs += stats.Rx_invalid_descs
+					total.Rx_ring_full += stats.Rx_ring_full
+					total.Rx_fill_ring_empty_descs += stats.Rx_fill_ring_empty_descs
+				}
+				out.rxDropped.Set(total.Rx_dropped)
+				out.rxInvalidDescs.Set(total.Rx_invalid_descs)
+				out.rxRingFull.Set(total.Rx_ring_full)
+				out.rxFillRingEmptyDescs.Set(total.Rx_fill_ring_empty_descs)
+			}
+		}
+	}()
+
+	return out
+}
@@ -149,6 +149,9 @@ func New(id string, testMode bool, _ string, decoders map[string]Decoders, inter
 				if iface.MetricsInterval <= 0 {
 					iface.MetricsInterval = 5 * time.Second
 				}
+				if iface.BusyPollBudget <= 0 {
+					iface.BusyPollBudget = 64
+				}
 
 				if t := iface.Type; t == "autodetect" || t == "" {
 					iface.Type = "pcap"
@@ -183,6 +186,8 @@ func validateConfig(filter string, cfg *config.InterfaceConfig) error {
 		return nil
 	case "af_packet":
 		return validateAfPacketConfig(cfg)
+	case "af_xdp":
+		return validateAfXDPConfig(cfg)
 	default:
 		return fmt.Errorf("unknown sniffer type for %s: %q", cfg.Device, cfg.Type)
 	}
@@ -201,6 +206,14 @@ func validateAfPacketConfig(cfg *config.InterfaceConfig) error {
 	return err
 }
 
+func validateAfXDPConfig(cfg *config.InterfaceConfig) error {
+	if cfg.Device == "any" {
+		return fmt.Errorf("af_xdp does not support the 'any' device")
+	}
+	_, _, err := afxdpComputeSize(cfg.BufferSizeMb, cfg.Snaplen, os.Getpagesize())
+	return err
+}
+
 // Run opens the sniffing device and processes packets being read from that device.
 // Worker instances are instantiated as needed.
 func (s *Sniffer) Run() error {
@@ -401,7 +414,7 @@ func (s *sniffer) sniffHandle(ctx context.Context, handle snifferHandle, dec *de
 		}
 
 		data, ci, err := handle.ReadPacketData()
-		if err == pcap.NextErrorTimeoutExpired || isAfpacketErrTimeout(err) { //nolint:errorlint // pcap.NextErrorTimeoutExpired is not wrapped.
+		if err == pcap.NextErrorTimeoutExpired || isAfpacketErrTimeout(err) || isAfxdpErrTimeout(err) { //nolint:errorlint // pcap.NextErrorTimeoutExpired is not wrapped.
 			// If we have timed out too many times, and we are following
 			// a default route, request a new default route interface.
 			const maxTimeouts = 10 // Place-holder until we have a sensible notion of how big this should be.
@@ -473,6 +486,8 @@ func (s *sniffer) open(device string) (snifferHandle, error) {
 		return openPcap(device, s.filter, &s.config)
 	case "af_packet":
 		return openAFPacket(fmt.Sprintf("%s_%d", s.id, s.idx), device, s.filter, &s.config)
+	case "af_xdp":
+		return openAFXDP(fmt.Sprintf("%s_%d", s.id, s.idx), device, s.filter, &s.config)
 	default:
 		return nil, fmt.Errorf("unknown sniffer type for %s: %q", device, s.config.Type)
 	}
@@ -539,3 +554,35 @@ func openAFPacket(id, device, filter string, cfg *config.InterfaceConfig) (sniff
 
 	return h, nil
 }
+
+func openAFXDP(id, device, filter string, cfg *config.InterfaceConfig) (snifferHandle, error) {
+	szFrame, numFrames, err := afxdpComputeSize(cfg.BufferSizeMb, cfg.Snaplen, os.Getpagesize())
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := 500 * time.Millisecond
+	h, err := newAfxdpHandle(afXDPConfig{
+		ID:              id,
+		Device:          device,
+		FrameSize:       szFrame,
+		NumFrames:       numFrames,
+		Snaplen:         cfg.Snaplen,
+		PollTimeout:     timeout,
+		MetricsInterval: cfg.MetricsInterval,
+		BusyPoll:        cfg.BusyPoll,
+		BusyPollBudget:  cfg.BusyPollBudget,
+		Promiscuous:     cfg.EnableAutoPromiscMode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.SetBPFFilter(filter)
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+
+	return h, nil
+}
@@ -74,6 +74,36 @@ func TestSniffer_afpacketComputeSize(t *testing.T) {
 	}
 }
 
+func TestSniffer_afxdpComputeSize(t *testing.T) {
+	tests := []struct {
+		targetSizeMb, snaplen, pageSize int
+		frameSize, numFrames            int
+	}{
+		{targetSizeMb: 24, snaplen: 65535, pageSize: 4096, frameSize: 4096, numFrames: 4096},
+		{targetSizeMb: 30, snaplen: 1514, pageSize: 4096, frameSize: 2048, numFrames: 8192},
+		{targetSizeMb: 1, snaplen: 2049, pageSize: 4096, frameSize: 4096, numFrames: 256},
+		{targetSizeMb: 24, snaplen: 65535, pageSize: 16384, frameSize: 16384, numFrames: 1024},
+	}
+	for _, test := range tests {
+		frameSize, numFrames, err := afxdpComputeSize(test.targetSizeMb, test.snaplen, test.pageSize)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if frameSize != test.frameSize || numFrames != test.numFrames {
+			t.Error("Bad result", test, frameSize, numFrames)
+		}
+		if frameSize*numFrames > test.targetSizeMb*1024*1024 {
+			t.Error("Value too big", frameSize, numFrames)
+		}
+	}
+
+	_, _, err := afxdpComputeSize(0, 1514, 4096)
+	if err == nil {
+		t.Error("Expected an error")
+	}
+}
+
 func Test_deviceNameFromIndex(t *testing.T) {
 	devs := []string{"lo", "eth0", "eth1"}
 
@@ -125,9 +125,15 @@ func (eb *Winlogbeat) init(b *beat.Beat) error {
 func (eb *Winlogbeat) setup(b *beat.Beat) error {
 	config := &eb.config
 
-	var err error
-	eb.checkpoint, err = checkpoint.NewCheckpoint(config.RegistryFile, config.RegistryFlush)
+	remote, err := eb.newRemoteStore(b)
 	if err != nil {
+		return fmt.Errorf("failed to initialize remote checkpoint registry: %w", err)
+	}
+	eb.checkpoint, err = checkpoint.NewCheckpoint(config.RegistryFile, config.RegistryFlush, remote)
+	if err != nil {
+		if remote != nil {
+			_ = remote.Close()
+		}
 		return fmt.Errorf("failed to initialize checkpoint registry: %w", err)
 	}
 
@@ -135,6 +141,36 @@ func (eb *Winlogbeat) setup(b *beat.Beat) error {
 	return nil
 }
 
+// newRemoteStore returns the configured remote store of the registry, or nil
+// if there is none.
+func (eb *Winlogbeat) newRemoteStore(b *beat.Beat) (checkpoint.RemoteStore, error) {
+	rc := eb.config.RegistryRemote
+	if rc == nil {
+		return nil, nil
+	}
+
+	key := rc.Key
+	if key == "" {
+		key = b.Info.Hostname
+	}
+
+	if rc.Path != "" {
+		eb.log.Infof("State will also be read from and persisted to %s on %s", key, rc.Path)
+		return checkpoint.NewFileStore(rc.Path, key), nil
+	}
+
+	index := rc.Index
+	if index == "" {
+		index = config.DefaultRegistryRemoteIndex
+	}
+	esClient, err := eslegclient.NewConnectedClient(context.Background(), rc.Elasticsearch, "Winlogbeat", b.Info.Logger)
+	if err != nil {
+		return nil, err
+	}
+	eb.log.Infof("State will also be read from and persisted to %s in the %s Elasticsearch index", key, index)
+	return checkpoint.NewElasticsearchStore(esClient, index, key), nil
+}
+
 // Run is used within the beats interface to execute the Winlogbeat workers.
 func (eb *Winlogbeat) Run(b *beat.Beat) error {
 	if err := eb.setup(b); err != nil {
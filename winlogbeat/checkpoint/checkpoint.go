@@ -44,6 +44,7 @@ type Checkpoint struct {
 	numUpdates    int            // Number of updates received since last persisting to disk.
 	flushInterval time.Duration  // Maximum time interval that can pass before persisting to disk.
 	sort          []string       // Slice used for sorting states map (store to save on mallocs).
+	remote        RemoteStore    // Optional store where the state is also persisted.
 
 	lock   sync.RWMutex
 	states map[string]EventLogState
@@ -53,16 +54,16 @@ type Checkpoint struct {
 
 // PersistedState represents the format of the data persisted to disk.
 type PersistedState struct {
-	UpdateTime time.Time       `yaml:"update_time"`
-	States     []EventLogState `yaml:"event_logs"`
+	UpdateTime time.Time       `yaml:"update_time" json:"update_time"`
+	States     []EventLogState `yaml:"event_logs" json:"event_logs"`
 }
 
 // EventLogState represents the state of an individual event log.
 type EventLogState struct {
-	Name         string    `yaml:"name"`
-	RecordNumber uint64    `yaml:"record_number"`
-	Timestamp    time.Time `yaml:"timestamp"`
-	Bookmark     string    `yaml:"bookmark,omitempty"`
+	Name         string    `yaml:"name" json:"name"`
+	RecordNumber uint64    `yaml:"record_number" json:"record_number"`
+	Timestamp    time.Time `yaml:"timestamp" json:"timestamp"`
+	Bookmark     string    `yaml:"bookmark,omitempty" json:"bookmark,omitempty"`
 }
 
 // NewCheckpoint creates and returns a new Checkpoint. This method loads state
@@ -72,12 +73,16 @@ type EventLogState struct {
 //
 // file is the name of the file where event log state is persisted as YAML.
 // interval is maximum amount of time that can pass since the last flush
-// before triggering a flush to disk (minimum value is 1s).
-func NewCheckpoint(file string, interval time.Duration) (*Checkpoint, error) {
+// before triggering a flush to disk (minimum value is 1s). remote is an
+// optional store where the state is also persisted. At creation time, the
+// state of each event log is taken from whichever of the file or the remote
+// store holds the most recent state.
+func NewCheckpoint(file string, interval time.Duration, remote RemoteStore) (*Checkpoint, error) {
 	c := &Checkpoint{
 		done:          make(chan struct{}),
 		file:          file,
 		flushInterval: interval,
+		remote:        remote,
 		sort:          make([]string, 0, 10),
 		states:        make(map[string]EventLogState),
 		save:          make(chan EventLogState, 1),
@@ -100,6 +105,20 @@ func NewCheckpoint(file string, interval time.Duration) (*Checkpoint, error) {
 		}
 	}
 
+	if c.remote != nil {
+		ps, err = c.remote.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read state from remote store: %w", err)
+		}
+		if ps != nil {
+			for _, state := range ps.States {
+				if local, ok := c.states[state.Name]; !ok || state.Timestamp.After(local.Timestamp) {
+					c.states[state.Name] = state
+				}
+			}
+		}
+	}
+
 	// Write the state file to verify we have have permissions.
 	err = c.flush()
 	if err != nil {
@@ -159,6 +178,11 @@ func (c *Checkpoint) Shutdown() {
 	c.once.Do(func() {
 		close(c.done)
 		c.wg.Wait()
+		if c.remote != nil {
+			if err := c.remote.Close(); err != nil {
+				logp.Err("Failed to close remote checkpoint store: %v", err)
+			}
+		}
 	})
 }
 
@@ -258,7 +282,17 @@ func (c *Checkpoint) flush() error {
 
 	file.Close()
 	err = os.Rename(tempFile, c.file)
-	return err
+	if err != nil {
+		return err
+	}
+
+	if c.remote != nil {
+		err = c.remote.Save(&ps)
+		if err != nil {
+			return fmt.Errorf("failed to flush state to remote store. %w", err)
+		}
+	}
+	return nil
 }
 
 // read loads the persisted state from disk. If the file does not exists then
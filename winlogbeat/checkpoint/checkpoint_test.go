@@ -75,7 +75,7 @@ func TestWriteTimedFlush(t *testing.T) {
 	}
 
 	const timeout = 5 * time.Second
-	cp, err := NewCheckpoint(file, timeout)
+	cp, err := NewCheckpoint(file, timeout, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
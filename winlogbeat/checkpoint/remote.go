@@ -0,0 +1,142 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package checkpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// RemoteStore persists event log state outside of the local registry file so
+// that it survives the loss of the local disk, for example when a host is
+// reimaged.
+type RemoteStore interface {
+	// Load returns the persisted state or nil if no state was persisted.
+	Load() (*PersistedState, error)
+	// Save persists the state.
+	Save(*PersistedState) error
+	// Close releases the resources held by the store.
+	Close() error
+}
+
+// fileStore is a RemoteStore persisting state as YAML to a file in a
+// directory that is typically on a file share.
+type fileStore struct {
+	file string
+}
+
+// NewFileStore returns a RemoteStore that persists state to the file named
+// key.yml in dir.
+func NewFileStore(dir, key string) RemoteStore {
+	return &fileStore{file: filepath.Join(dir, key+".yml")}
+}
+
+func (s *fileStore) Load() (*PersistedState, error) {
+	contents, err := os.ReadFile(s.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return nil, err
+	}
+
+	ps := &PersistedState{}
+	err = yaml.Unmarshal(contents, ps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read persisted state from %s: %w", s.file, err)
+	}
+	return ps, nil
+}
+
+func (s *fileStore) Save(ps *PersistedState) error {
+	data, err := yaml.Marshal(ps)
+	if err != nil {
+		return fmt.Errorf("could not marshal data to YAML: %w", err)
+	}
+
+	// Write to a temporary file first so that readers never see a partially
+	// written state.
+	tempFile := s.file + ".new"
+	err = os.WriteFile(tempFile, data, 0o600)
+	if err != nil {
+		return fmt.Errorf("could not write to %s: %w", tempFile, err)
+	}
+	return os.Rename(tempFile, s.file)
+}
+
+func (s *fileStore) Close() error { return nil }
+
+// ESConnection is the subset of an Elasticsearch connection used by the
+// Elasticsearch store. It is implemented by eslegclient.Connection.
+type ESConnection interface {
+	Request(method, path string, pipeline string, params map[string]string, body interface{}) (int, []byte, error)
+	Close() error
+}
+
+// esStore is a RemoteStore persisting state as a document in an
+// Elasticsearch index.
+type esStore struct {
+	conn ESConnection
+	path string
+}
+
+// NewElasticsearchStore returns a RemoteStore that persists state as the
+// document with ID key in index.
+func NewElasticsearchStore(conn ESConnection, index, key string) RemoteStore {
+	return &esStore{
+		conn: conn,
+		path: "/" + url.PathEscape(index) + "/_doc/" + url.PathEscape(key),
+	}
+}
+
+func (s *esStore) Load() (*PersistedState, error) {
+	status, body, err := s.conn.Request(http.MethodGet, s.path, "", nil, nil)
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get persisted state from Elasticsearch: %w", err)
+	}
+
+	var doc struct {
+		Source PersistedState `json:"_source"`
+	}
+	err = json.Unmarshal(body, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read persisted state from Elasticsearch: %w", err)
+	}
+	return &doc.Source, nil
+}
+
+func (s *esStore) Save(ps *PersistedState) error {
+	_, _, err := s.conn.Request(http.MethodPut, s.path, "", nil, ps)
+	if err != nil {
+		return fmt.Errorf("failed to persist state to Elasticsearch: %w", err)
+	}
+	return nil
+}
+
+func (s *esStore) Close() error {
+	return s.conn.Close()
+}
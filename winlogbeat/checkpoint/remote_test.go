@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package checkpoint
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test that the state persisted to a remote store is restored when the local
+// registry file is lost.
+func TestRemoteStore(t *testing.T) {
+	ts := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	shared := t.TempDir()
+
+	cp, err := NewCheckpoint(filepath.Join(t.TempDir(), ".winlogbeat.yml"), time.Second, NewFileStore(shared, "host1"))
+	require.NoError(t, err)
+	cp.Persist("Security", 100, ts, "<BookmarkList/>")
+	cp.Persist("Application", 10, ts, "")
+	eventually(t, func() (bool, error) { return len(cp.States()) == 2, nil }, time.Second)
+	cp.Shutdown()
+	assert.FileExists(t, filepath.Join(shared, "host1.yml"))
+
+	// A reimaged host has an empty registry file.
+	cp, err = NewCheckpoint(filepath.Join(t.TempDir(), ".winlogbeat.yml"), time.Second, NewFileStore(shared, "host1"))
+	require.NoError(t, err)
+	cp.Shutdown()
+	assert.Equal(t, map[string]EventLogState{
+		"Security":    {Name: "Security", RecordNumber: 100, Timestamp: ts, Bookmark: "<BookmarkList/>"},
+		"Application": {Name: "Application", RecordNumber: 10, Timestamp: ts},
+	}, cp.States())
+
+	// Other hosts have their own state.
+	cp, err = NewCheckpoint(filepath.Join(t.TempDir(), ".winlogbeat.yml"), time.Second, NewFileStore(shared, "host2"))
+	require.NoError(t, err)
+	cp.Shutdown()
+	assert.Empty(t, cp.States())
+}
+
+// Test that the most recent of the local and remote states is used for each
+// event log.
+func TestRemoteStoreMerge(t *testing.T) {
+	ts := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	file := filepath.Join(t.TempDir(), ".winlogbeat.yml")
+
+	cp, err := NewCheckpoint(file, time.Second, nil)
+	require.NoError(t, err)
+	cp.Persist("Security", 200, ts.Add(time.Hour), "")
+	cp.Persist("Application", 10, ts, "")
+	eventually(t, func() (bool, error) { return len(cp.States()) == 2, nil }, time.Second)
+	cp.Shutdown()
+
+	remote := &fakeESConnection{}
+	store := NewElasticsearchStore(remote, "winlogbeat-checkpoints", "host1")
+	err = store.Save(&PersistedState{States: []EventLogState{
+		{Name: "Security", RecordNumber: 100, Timestamp: ts},
+		{Name: "Application", RecordNumber: 20, Timestamp: ts.Add(time.Hour)},
+		{Name: "System", RecordNumber: 30, Timestamp: ts},
+	}})
+	require.NoError(t, err)
+
+	cp, err = NewCheckpoint(file, time.Second, store)
+	require.NoError(t, err)
+	cp.Shutdown()
+	assert.Equal(t, map[string]EventLogState{
+		"Security":    {Name: "Security", RecordNumber: 200, Timestamp: ts.Add(time.Hour)},
+		"Application": {Name: "Application", RecordNumber: 20, Timestamp: ts.Add(time.Hour)},
+		"System":      {Name: "System", RecordNumber: 30, Timestamp: ts},
+	}, cp.States())
+	assert.True(t, remote.closed)
+
+	// The merged state is persisted to the remote store.
+	ps, err := store.Load()
+	require.NoError(t, err)
+	assert.Len(t, ps.States, 3)
+}
+
+func TestElasticsearchStoreNotFound(t *testing.T) {
+	store := NewElasticsearchStore(&fakeESConnection{}, "winlogbeat-checkpoints", "host1")
+	ps, err := store.Load()
+	assert.NoError(t, err)
+	assert.Nil(t, ps)
+}
+
+// fakeESConnection stores documents in memory.
+type fakeESConnection struct {
+	docs   map[string][]byte
+	closed bool
+}
+
+func (c *fakeESConnection) Request(method, path string, _ string, _ map[string]string, body interface{}) (int, []byte, error) {
+	switch method {
+	case http.MethodGet:
+		doc, ok := c.docs[path]
+		if !ok {
+			return http.StatusNotFound, nil, errors.New("404 Not Found")
+		}
+		resp, err := json.Marshal(map[string]json.RawMessage{"_source": doc})
+		return http.StatusOK, resp, err
+	case http.MethodPut:
+		doc, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, err
+		}
+		if c.docs == nil {
+			c.docs = make(map[string][]byte)
+		}
+		c.docs[path] = doc
+		return http.StatusOK, nil, nil
+	}
+	return http.StatusMethodNotAllowed, nil, errors.New("405 Method Not Allowed")
+}
+
+func (c *fakeESConnection) Close() error {
+	c.closed = true
+	return nil
+}
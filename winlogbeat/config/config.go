@@ -29,6 +29,10 @@ import (
 const (
 	// DefaultRegistryFile specifies the default filename of the registry file.
 	DefaultRegistryFile = ".winlogbeat.yml"
+
+	// DefaultRegistryRemoteIndex specifies the default index of the remote
+	// registry when it is stored in Elasticsearch.
+	DefaultRegistryRemoteIndex = "winlogbeat-checkpoints"
 )
 
 var DefaultSettings = WinlogbeatConfig{
@@ -41,6 +45,7 @@ type WinlogbeatConfig struct {
 	EventLogs          []*conf.C     `config:"event_logs"`
 	RegistryFile       string        `config:"registry_file"`
 	RegistryFlush      time.Duration `config:"registry_flush"`
+	RegistryRemote     *RemoteConfig `config:"registry_remote"`
 	ShutdownTimeout    time.Duration `config:"shutdown_timeout"`
 	OverwritePipelines bool          `config:"overwrite_pipelines"`
 }
@@ -55,5 +60,29 @@ func (ebc WinlogbeatConfig) Validate() error {
 			"configured as part of event_logs"))
 	}
 
+	if ebc.RegistryRemote != nil {
+		if err := ebc.RegistryRemote.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid registry_remote: %w", err))
+		}
+	}
+
 	return errors.Join(errs...)
 }
+
+// RemoteConfig configures a remote store where the registry is persisted in
+// addition to the registry file.
+type RemoteConfig struct {
+	Key           string  `config:"key"`           // Key of the registry in the store. Defaults to the host name.
+	Path          string  `config:"path"`          // Directory, typically on a file share, holding the registry files.
+	Elasticsearch *conf.C `config:"elasticsearch"` // Elasticsearch connection settings.
+	Index         string  `config:"index"`         // Elasticsearch index holding the registry documents.
+}
+
+// Validate validates the RemoteConfig data and returns an error describing
+// the problem or nil.
+func (rc *RemoteConfig) Validate() error {
+	if (rc.Path == "") == (rc.Elasticsearch == nil) {
+		return errors.New("exactly one of path or elasticsearch must be set")
+	}
+	return nil
+}
@@ -62,6 +62,42 @@ func TestConfigValidate(t *testing.T) {
 			WinlogbeatConfig{},
 			"at least one event log must be configured as part of event_logs",
 		},
+		{
+			WinlogbeatConfig{
+				EventLogs: []*conf.C{
+					newConfig(map[string]interface{}{
+						"Name": "App",
+					}),
+				},
+				RegistryRemote: &RemoteConfig{Path: `\\\\fileserver\\winlogbeat`},
+			},
+			"", // No Error
+		},
+		{
+			WinlogbeatConfig{
+				EventLogs: []*conf.C{
+					newConfig(map[string]interface{}{
+						"Name": "App",
+					}),
+				},
+				RegistryRemote: &RemoteConfig{},
+			},
+			"invalid registry_remote: exactly one of path or elasticsearch must be set",
+		},
+		{
+			WinlogbeatConfig{
+				EventLogs: []*conf.C{
+					newConfig(map[string]interface{}{
+						"Name": "App",
+					}),
+				},
+				RegistryRemote: &RemoteConfig{
+					Path:          `\\\\fileserver\\winlogbeat`,
+					Elasticsearch: newConfig(map[string]interface{}{"hosts": []string{"localhost:9200"}}),
+				},
+			},
+			"invalid registry_remote: exactly one of path or elasticsearch must be set",
+		},
 	}
 
 	for _, test := range testCases {
@@ -23,9 +23,12 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/elastic/beats/v7/winlogbeat/sys"
 	conf "github.com/elastic/elastic-agent-libs/config"
 )
 
@@ -56,7 +59,8 @@ type config struct {
 	Forwarded     *bool              `config:"forwarded"`
 	SimpleQuery   query              `config:",inline"`
 	NoMoreEvents  NoMoreEventsAction `config:"no_more_events"` // Action to take when no more events are available - wait or stop.
-	EventLanguage uint32             `config:"language"`
+	EventLanguage eventLanguage      `config:"language"`       // Locale to render events in, as an LCID or a locale name.
+	RenderWorkers int                `config:"render_workers"` // Number of events of a batch rendered concurrently. Zero means one.
 
 	// FIXME: This is for a WS2025 known issue so we can bypass the workaround
 	// and will be removed in the future.
@@ -103,6 +107,40 @@ func (a *NoMoreEventsAction) Unpack(v string) error {
 // String returns the name of the action.
 func (a NoMoreEventsAction) String() string { return noMoreEventsActionNames[a] }
 
+// eventLanguage is the locale identifier (LCID) of the language events are
+// rendered in. Zero means the system language.
+type eventLanguage uint32
+
+// Unpack sets the language from an LCID (e.g. 0x0409) or a locale name (e.g.
+// en-US).
+func (l *eventLanguage) Unpack(v interface{}) error {
+	switch v := v.(type) {
+	case int64:
+		if v < 0 || v > math.MaxUint32 {
+			return fmt.Errorf("invalid language ID: %d", v)
+		}
+		*l = eventLanguage(v)
+	case uint64:
+		if v > math.MaxUint32 {
+			return fmt.Errorf("invalid language ID: %d", v)
+		}
+		*l = eventLanguage(v)
+	case string:
+		if id, err := strconv.ParseUint(v, 0, 32); err == nil {
+			*l = eventLanguage(id)
+			return nil
+		}
+		id, err := sys.LocaleNameToLCID(v)
+		if err != nil {
+			return fmt.Errorf("invalid language: %w", err)
+		}
+		*l = eventLanguage(id)
+	default:
+		return fmt.Errorf("invalid language type %T: must be a language ID or a locale name", v)
+	}
+	return nil
+}
+
 // Validate validates the winEventLogConfig data and returns an error describing
 // any problems or nil.
 func (c *config) Validate() error {
@@ -134,5 +172,9 @@ func (c *config) Validate() error {
 		errs = append(errs, fmt.Errorf("event log is missing a 'name'"))
 	}
 
+	if c.RenderWorkers < 0 {
+		errs = append(errs, fmt.Errorf("render_workers cannot be negative"))
+	}
+
 	return errors.Join(errs...)
 }
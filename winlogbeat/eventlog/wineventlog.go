@@ -121,14 +121,16 @@ func newWinEventLog(options *conf.C) (EventLog, error) {
 		l.renderer = win.NewXMLRenderer(
 			win.RenderConfig{
 				IsForwarded: l.isForwarded(),
-				Locale:      c.EventLanguage,
+				Locale:      uint32(c.EventLanguage),
+				Workers:     c.RenderWorkers,
 			},
 			win.NilHandle, l.log)
 	case false:
 		l.renderer, err = win.NewRenderer(
 			win.RenderConfig{
 				IsForwarded: l.isForwarded(),
-				Locale:      c.EventLanguage,
+				Locale:      uint32(c.EventLanguage),
+				Workers:     c.RenderWorkers,
 			},
 			win.NilHandle, l.log)
 		if err != nil {
@@ -279,8 +281,19 @@ func (l *winEventLog) Read() ([]Record, error) {
 		l.metrics.log(records)
 	}()
 
-	for h, ok := l.iterator.Next(); ok; h, ok = l.iterator.Next() {
-		record, err := l.processHandle(h)
+	// Collect up to the maximum requested number of events, so they can be
+	// rendered together.
+	var handles []win.EvtHandle
+	for len(handles) < l.maxRead {
+		h, ok := l.iterator.Next()
+		if !ok {
+			break
+		}
+		handles = append(handles, h)
+	}
+
+	for i, res := range l.renderer.RenderBatch(handles) {
+		record, err := l.processHandle(handles[i], res)
 		if err != nil {
 			l.metrics.logError(err)
 			l.log.Warnw("Dropping event due to rendering error.", "error", err)
@@ -289,11 +302,11 @@ func (l *winEventLog) Read() ([]Record, error) {
 			continue
 		}
 		records = append(records, *record)
+	}
 
-		// It has read the maximum requested number of events.
-		if len(records) >= l.maxRead {
-			return records, nil
-		}
+	// It has read the maximum requested number of events.
+	if len(handles) >= l.maxRead {
+		return records, nil
 	}
 
 	// An error occurred while retrieving more events.
@@ -310,11 +323,13 @@ func (l *winEventLog) Read() ([]Record, error) {
 	return records, nil
 }
 
-func (l *winEventLog) processHandle(h win.EvtHandle) (*Record, error) {
+// processHandle builds the record of the event handle h from its rendering
+// result and closes h.
+func (l *winEventLog) processHandle(h win.EvtHandle, res win.RenderResult) (*Record, error) {
 	defer h.Close()
 
-	// NOTE: Render can return an error and a partial event.
-	evt, xml, err := l.renderer.Render(h)
+	// NOTE: Rendering can return an error and a partial event.
+	evt, err := res.Event, res.Err
 	if evt == nil {
 		return nil, err
 	}
@@ -327,7 +342,7 @@ func (l *winEventLog) processHandle(h win.EvtHandle) (*Record, error) {
 	}
 
 	if l.config.IncludeXML {
-		r.XML = xml
+		r.XML = res.XML
 	}
 
 	if l.file {
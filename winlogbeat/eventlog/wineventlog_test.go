@@ -145,6 +145,22 @@ func TestWinEventLogConfig_Validate(t *testing.T) {
 			WantErr: true,
 			Desc:    "missing name",
 		},
+		{
+			In: config{
+				Name:          "test",
+				RenderWorkers: 4,
+			},
+			WantErr: false,
+			Desc:    "render workers",
+		},
+		{
+			In: config{
+				Name:          "test",
+				RenderWorkers: -1,
+			},
+			WantErr: true,
+			Desc:    "negative render workers",
+		},
 	}
 
 	for _, tc := range tests {
@@ -158,6 +174,37 @@ func TestWinEventLogConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestWinEventLogConfig_Language(t *testing.T) {
+	tests := []struct {
+		In      interface{}
+		Want    eventLanguage
+		WantErr bool
+	}{
+		{In: 0x0409, Want: 0x0409},
+		{In: "0x0409", Want: 0x0409},
+		{In: "en-US", Want: 0x0409},
+		{In: "de-DE", Want: 0x0407},
+		{In: "not-a-locale", WantErr: true},
+		{In: -1, WantErr: true},
+	}
+
+	for _, tc := range tests {
+		var c config
+		err := readConfig(conf.MustNewConfigFrom(map[string]interface{}{
+			"name":     "Application",
+			"language": tc.In,
+		}), &c)
+
+		if tc.WantErr {
+			assert.Error(t, err, "language %v", tc.In)
+			continue
+		}
+		if assert.NoError(t, err, "language %v", tc.In) {
+			assert.Equal(t, tc.Want, c.EventLanguage, "language %v", tc.In)
+		}
+	}
+}
+
 func TestWindowsEventLogAPI(t *testing.T) {
 	testWindowsEventLog(t, true)
 	testWindowsEventLog(t, false)
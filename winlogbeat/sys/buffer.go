@@ -62,6 +62,11 @@ func (b *ByteBuffer) Len() int {
 	return b.offset
 }
 
+// Cap returns the number of bytes the buffer can hold without allocating.
+func (b *ByteBuffer) Cap() int {
+	return cap(b.buf)
+}
+
 // PtrAt returns a pointer to the given offset of the buffer.
 func (b *ByteBuffer) PtrAt(offset int) *byte {
 	if offset > b.offset-1 {
@@ -70,6 +70,25 @@ func TestByteBufferGrow(t *testing.T) {
 	assert.Equal(t, 2*length, buf.Len())
 }
 
+func TestByteBufferReserveCap(t *testing.T) {
+	buf := NewByteBuffer(16)
+	assert.Equal(t, 16, buf.Cap())
+
+	// Reserving the capacity does not allocate.
+	buf.Reserve(buf.Cap())
+	assert.Equal(t, 16, buf.Len())
+	assert.Equal(t, 16, buf.Cap())
+
+	buf.Reserve(32)
+	assert.Equal(t, 32, buf.Len())
+	assert.Equal(t, 32, buf.Cap())
+
+	// Capacity is retained after a reset.
+	buf.Reset()
+	assert.Equal(t, 0, buf.Len())
+	assert.Equal(t, 32, buf.Cap())
+}
+
 func BenchmarkByteBuffer(b *testing.B) {
 	input := []byte("test writing this sentence to a buffer")
 
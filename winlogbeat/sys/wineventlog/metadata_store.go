@@ -28,6 +28,7 @@ import (
 	"text/template"
 	"text/template/parse"
 
+	lru "github.com/hashicorp/golang-lru/v2"
 	"go.uber.org/multierr"
 
 	"github.com/elastic/beats/v7/winlogbeat/sys"
@@ -35,6 +36,9 @@ import (
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
+// messageCacheSize is the number of rendered messages cached per publisher.
+const messageCacheSize = 1024
+
 var (
 	// eventDataNameTransform removes spaces from parameter names.
 	eventDataNameTransform = strings.NewReplacer(" ", "_")
@@ -65,6 +69,10 @@ type PublisherMetadataStore struct {
 	// from that point after.
 	MessagesByID map[uint32]string
 
+	// Cache of messages rendered with EvtFormatMessage, keyed by a hash of
+	// the values the message depends on. See messageKey.
+	messages *lru.Cache[uint64, string]
+
 	mutex sync.RWMutex
 	log   *logp.Logger
 }
@@ -78,6 +86,7 @@ func NewPublisherMetadataStore(session EvtHandle, provider string, locale uint32
 		Metadata:          md,
 		EventFingerprints: map[uint32]map[uint64]*EventMetadata{},
 		MessagesByID:      map[uint32]string{},
+		messages:          newMessageCache(),
 		log:               log.With("publisher", provider),
 	}
 
@@ -111,6 +120,7 @@ func NewEmptyPublisherMetadataStore(provider string, log *logp.Logger) *Publishe
 		EventsByVersion:   map[uint32]*EventMetadata{},
 		EventFingerprints: map[uint32]map[uint64]*EventMetadata{},
 		MessagesByID:      map[uint32]string{},
+		messages:          newMessageCache(),
 		log:               log.With("publisher", provider, "empty", true),
 	}
 }
@@ -336,6 +346,30 @@ func (s *PublisherMetadataStore) getMessageByID(messageID uint32) string {
 	return message
 }
 
+// getRenderedMessage returns the cached message for key. On a cache miss it
+// calls render and caches the message it returns.
+func (s *PublisherMetadataStore) getRenderedMessage(key uint64, render func() (string, error)) (string, error) {
+	if s.messages == nil {
+		return render()
+	}
+	if msg, ok := s.messages.Get(key); ok {
+		return msg, nil
+	}
+
+	msg, err := render()
+	if err != nil {
+		return msg, err
+	}
+	s.messages.Add(key, msg)
+	return msg, nil
+}
+
+func newMessageCache() *lru.Cache[uint64, string] {
+	// New only fails for a non-positive size.
+	c, _ := lru.New[uint64, string](messageCacheSize)
+	return c
+}
+
 func (s *PublisherMetadataStore) Close() error {
 	if s.Metadata != nil {
 		s.mutex.Lock()
@@ -27,6 +27,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"text/template"
 	"time"
@@ -44,13 +46,72 @@ import (
 type RenderConfig struct {
 	IsForwarded bool
 	Locale      uint32
+	Workers     int // Number of events rendered concurrently by RenderBatch.
+}
+
+// RenderResult holds the outcome of rendering one event handle. Event may be
+// set even if Err is not nil.
+type RenderResult struct {
+	Event *winevent.Event
+	XML   string
+	Err   error
 }
 
 type EventRenderer interface {
 	Render(handle EvtHandle) (event *winevent.Event, xml string, err error)
+	// RenderBatch renders the handles. The results match the handles by index.
+	RenderBatch(handles []EvtHandle) []RenderResult
 	Close() error
 }
 
+// renderBatch calls render for each handle, spreading the handles over up
+// to workers goroutines.
+func renderBatch(handles []EvtHandle, workers int, render func(EvtHandle) (*winevent.Event, string, error)) []RenderResult {
+	results := make([]RenderResult, len(handles))
+	renderAt := func(i int) {
+		res := &results[i]
+		res.Event, res.XML, res.Err = render(handles[i])
+	}
+
+	workers = min(workers, len(handles))
+	if workers <= 1 {
+		for i := range handles {
+			renderAt(i)
+		}
+		return results
+	}
+
+	var (
+		wg   sync.WaitGroup
+		next atomic.Int64
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < len(handles); i = int(next.Add(1) - 1) {
+				renderAt(i)
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// messageKey returns the key of an event's message in the message cache of
+// its publisher. Besides the event data values, the key includes the
+// computer, as forwarded events carry messages rendered on their source.
+func messageKey(event *winevent.Event, values []interface{}) uint64 {
+	h := xxhash.New()
+	binary.Write(h, binary.LittleEndian, event.EventIdentifier.ID) //nolint:errcheck // Hash writes never fail.
+	binary.Write(h, binary.LittleEndian, uint8(event.Version))     //nolint:errcheck // Hash writes never fail.
+	h.WriteString(event.Computer)                                  //nolint:errcheck // Hash writes never fail.
+	for _, v := range values {
+		fmt.Fprintf(h, "\x00%v", v)
+	}
+	return h.Sum64()
+}
+
 // Renderer is used for converting event log handles into complete events.
 type Renderer struct {
 	conf          RenderConfig
@@ -131,7 +192,7 @@ func (r *Renderer) Render(handle EvtHandle) (*winevent.Event, string, error) {
 	// Associate key names with the event data values.
 	r.addEventData(eventMeta, eventData, event)
 
-	if event.Message, err = r.formatMessage(md.Metadata, eventMeta, handle, eventData, uint16(event.EventIdentifier.ID)); err != nil {
+	if event.Message, err = r.formatMessage(md, eventMeta, event, handle, eventData); err != nil {
 		errs = append(errs, fmt.Errorf("failed to get the event message string: %w", err))
 	}
 
@@ -141,6 +202,12 @@ func (r *Renderer) Render(handle EvtHandle) (*winevent.Event, string, error) {
 	return event, "", nil
 }
 
+// RenderBatch renders the handles using up to the configured number of
+// workers.
+func (r *Renderer) RenderBatch(handles []EvtHandle) []RenderResult {
+	return renderBatch(handles, r.conf.Workers, r.Render)
+}
+
 // renderSystem writes all the system context properties into the event.
 func (r *Renderer) renderSystem(handle EvtHandle, event *winevent.Event) error {
 	bb, propertyCount, err := r.render(r.systemContext, handle)
@@ -273,24 +340,29 @@ func expandMessageIDs(mds *PublisherMetadataStore, v string) string {
 func (r *Renderer) render(context EvtHandle, eventHandle EvtHandle) (*sys.PooledByteBuffer, int, error) {
 	var bufferUsed, propertyCount uint32
 
-	err := _EvtRender(context, eventHandle, EvtRenderEventValues, 0, nil, &bufferUsed, &propertyCount)
-	if err != nil && err != windows.ERROR_INSUFFICIENT_BUFFER { //nolint:errorlint // This is an errno or nil.
+	// Render into all the capacity of the pooled buffer and only query the
+	// required size when it is too small. Pooled buffers keep the size of
+	// the largest events rendered, so most events need a single EvtRender
+	// call.
+	bb := sys.NewPooledByteBuffer()
+	bb.Reserve(bb.Cap())
+
+	err := _EvtRender(context, eventHandle, EvtRenderEventValues, uint32(bb.Len()), bb.PtrAt(0), &bufferUsed, &propertyCount)
+	if err == windows.ERROR_INSUFFICIENT_BUFFER { //nolint:errorlint // This is an errno or nil.
+		bb.Reserve(int(bufferUsed))
+		err = _EvtRender(context, eventHandle, EvtRenderEventValues, uint32(bb.Len()), bb.PtrAt(0), &bufferUsed, &propertyCount)
+	}
+	if err != nil {
+		bb.Free()
 		return nil, 0, fmt.Errorf("failed in EvtRender: %w", err)
 	}
 
 	if propertyCount == 0 {
+		bb.Free()
 		return nil, 0, nil
 	}
 
-	bb := sys.NewPooledByteBuffer()
 	bb.Reserve(int(bufferUsed))
-
-	err = _EvtRender(context, eventHandle, EvtRenderEventValues, uint32(bb.Len()), bb.PtrAt(0), &bufferUsed, &propertyCount)
-	if err != nil {
-		bb.Free()
-		return nil, 0, fmt.Errorf("failed in EvtRender: %w", err)
-	}
-
 	return bb, int(propertyCount), nil
 }
 
@@ -355,9 +427,9 @@ func (r *Renderer) addEventData(evtMeta *EventMetadata, values []interface{}, ev
 }
 
 // formatMessage adds the message to the event.
-func (r *Renderer) formatMessage(publisherMeta *PublisherMetadata,
-	eventMeta *EventMetadata, eventHandle EvtHandle, values []interface{},
-	eventID uint16) (string, error,
+func (r *Renderer) formatMessage(mds *PublisherMetadataStore,
+	eventMeta *EventMetadata, event *winevent.Event, eventHandle EvtHandle,
+	values []interface{}) (string, error,
 ) {
 	if eventMeta != nil {
 		if eventMeta.MsgStatic != "" {
@@ -370,12 +442,14 @@ func (r *Renderer) formatMessage(publisherMeta *PublisherMetadata,
 	// Fallback to the trying EvtFormatMessage mechanism.
 	// This is the path for forwarded events in RenderedText mode where the
 	// local publisher metadata is not present.
-	r.log.Debugf("Falling back to EvtFormatMessage for event ID %d.", eventID)
-	metadata := publisherMeta
+	metadata := mds.Metadata
 	if r.conf.IsForwarded {
 		metadata = nil
 	}
-	return getMessageString(metadata, eventHandle, 0, nil)
+	return mds.getRenderedMessage(messageKey(event, values), func() (string, error) {
+		r.log.Debugf("Falling back to EvtFormatMessage for event ID %d.", event.EventIdentifier.ID)
+		return getMessageString(metadata, eventHandle, 0, nil)
+	})
 }
 
 // formatMessageFromTemplate creates the message by executing the stored Go
@@ -395,35 +469,45 @@ func (r *Renderer) formatMessageFromTemplate(msgTmpl *template.Template, values
 type XMLRenderer struct {
 	conf          RenderConfig
 	metadataCache *publisherMetadataCache
-	renderBuf     []byte
-	outBuf        *sys.ByteBuffer
+	buffers       chan *xmlRenderBuffers // One set of buffers per worker.
 
-	render func(event EvtHandle, out io.Writer) error // Function for rendering the event to XML.
+	render func(event EvtHandle, renderBuf []byte, out io.Writer) error // Function for rendering the event to XML.
 
 	log *logp.Logger
 }
 
+type xmlRenderBuffers struct {
+	renderBuf []byte
+	outBuf    *sys.ByteBuffer
+}
+
 // NewXMLRenderer returns a new Renderer.
 func NewXMLRenderer(conf RenderConfig, session EvtHandle, log *logp.Logger) *XMLRenderer {
 	const renderBufferSize = 1 << 19 // 512KB, 256K wide characters
 	rlog := log.Named("xml_renderer")
+	workers := max(conf.Workers, 1)
 	r := &XMLRenderer{
 		conf:          conf,
-		renderBuf:     make([]byte, renderBufferSize),
-		outBuf:        sys.NewByteBuffer(renderBufferSize),
+		buffers:       make(chan *xmlRenderBuffers, workers),
 		metadataCache: newPublisherMetadataCache(session, conf.Locale, rlog),
 		log:           rlog,
 	}
+	for range workers {
+		r.buffers <- &xmlRenderBuffers{
+			renderBuf: make([]byte, renderBufferSize),
+			outBuf:    sys.NewByteBuffer(renderBufferSize),
+		}
+	}
 	// Forwarded events should be rendered using RenderEventXML. It is more
 	// efficient and does not attempt to use local message files for rendering
 	// the event's message.
 	switch conf.IsForwarded {
 	case true:
-		r.render = func(event EvtHandle, out io.Writer) error {
-			return RenderEventXML(event, r.renderBuf, out)
+		r.render = func(event EvtHandle, renderBuf []byte, out io.Writer) error {
+			return RenderEventXML(event, renderBuf, out)
 		}
 	case false:
-		r.render = func(event EvtHandle, out io.Writer) error {
+		r.render = func(event EvtHandle, renderBuf []byte, out io.Writer) error {
 			get := func(providerName string) EvtHandle {
 				md, _ := r.metadataCache.getPublisherStore(providerName)
 				if md.Metadata != nil {
@@ -431,7 +515,7 @@ func NewXMLRenderer(conf RenderConfig, session EvtHandle, log *logp.Logger) *XML
 				}
 				return NilHandle
 			}
-			return RenderEvent(event, conf.Locale, r.renderBuf, get, out)
+			return RenderEvent(event, conf.Locale, renderBuf, get, out)
 		}
 	}
 	return r
@@ -451,13 +535,16 @@ func (r *XMLRenderer) Render(handle EvtHandle) (*winevent.Event, string, error)
 	// critical to not drop data.
 	var errs []error
 
-	r.outBuf.Reset()
-	err := r.render(handle, r.outBuf)
+	bufs := <-r.buffers
+	bufs.outBuf.Reset()
+	err := r.render(handle, bufs.renderBuf, bufs.outBuf)
 	if err != nil {
 		errs = append(errs, err)
 	}
-	outBytes := r.outBuf.Bytes()
+	outBytes := bufs.outBuf.Bytes()
 	event := r.buildEventFromXML(outBytes, err)
+	xml := string(outBytes)
+	r.buffers <- bufs
 
 	// This always returns a non-nil value (even on error).
 	md, err := r.metadataCache.getPublisherStore(event.Provider.Name)
@@ -473,15 +560,39 @@ func (r *XMLRenderer) Render(handle EvtHandle) (*winevent.Event, string, error)
 	}
 
 	if event.Message == "" && !r.conf.IsForwarded {
-		if event.Message, err = getMessageString(md.Metadata, handle, 0, nil); err != nil {
+		key := messageKey(event, eventDataValues(event))
+		event.Message, err = md.getRenderedMessage(key, func() (string, error) {
+			return getMessageString(md.Metadata, handle, 0, nil)
+		})
+		if err != nil {
 			errs = append(errs, fmt.Errorf("failed to get the event message string: %w", err))
 		}
 	}
 
 	if len(errs) > 0 {
-		return event, string(outBytes), multierr.Combine(errs...)
+		return event, xml, multierr.Combine(errs...)
+	}
+	return event, xml, nil
+}
+
+// RenderBatch renders the handles using up to the configured number of
+// workers.
+func (r *XMLRenderer) RenderBatch(handles []EvtHandle) []RenderResult {
+	return renderBatch(handles, r.conf.Workers, r.Render)
+}
+
+// eventDataValues returns the event data or user data values of an event
+// built from its XML.
+func eventDataValues(event *winevent.Event) []interface{} {
+	pairs := event.EventData.Pairs
+	if len(pairs) == 0 {
+		pairs = event.UserData.Pairs
+	}
+	values := make([]interface{}, len(pairs))
+	for i, pair := range pairs {
+		values[i] = pair.Value
 	}
-	return event, string(outBytes), nil
+	return values
 }
 
 func (r *XMLRenderer) buildEventFromXML(x []byte, recoveredErr error) *winevent.Event {
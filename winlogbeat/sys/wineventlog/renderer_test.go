@@ -226,6 +226,54 @@ func TestTemplateFunc(t *testing.T) {
 	assert.Equal(t, "Hello world! Foo %2.", buf.String())
 }
 
+func TestRenderBatch(t *testing.T) {
+	handles := make([]EvtHandle, 100)
+	for i := range handles {
+		handles[i] = EvtHandle(i + 1)
+	}
+	render := func(h EvtHandle) (*winevent.Event, string, error) {
+		return &winevent.Event{RecordID: uint64(h)}, strconv.Itoa(int(h)), nil
+	}
+
+	for _, workers := range []int{0, 1, 8, 200} {
+		results := renderBatch(handles, workers, render)
+		if assert.Len(t, results, len(handles), "workers=%d", workers) {
+			for i, res := range results {
+				assert.NoError(t, res.Err)
+				assert.EqualValues(t, handles[i], res.Event.RecordID, "workers=%d", workers)
+				assert.Equal(t, strconv.Itoa(int(handles[i])), res.XML, "workers=%d", workers)
+			}
+		}
+	}
+}
+
+func TestMessageCache(t *testing.T) {
+	md := NewEmptyPublisherMetadataStore("test", logp.NewLogger("test"))
+
+	var calls int
+	render := func() (string, error) {
+		calls++
+		return "message " + strconv.Itoa(calls), nil
+	}
+
+	event := &winevent.Event{EventIdentifier: winevent.EventIdentifier{ID: 4624}, Computer: "host-a"}
+	key := messageKey(event, []interface{}{"alice", uint32(1)})
+
+	msg, err := md.getRenderedMessage(key, render)
+	assert.NoError(t, err)
+	assert.Equal(t, "message 1", msg)
+	msg, err = md.getRenderedMessage(key, render)
+	assert.NoError(t, err)
+	assert.Equal(t, "message 1", msg)
+	assert.Equal(t, 1, calls)
+
+	// Messages differ by values and by the computer that rendered them.
+	assert.NotEqual(t, key, messageKey(event, []interface{}{"bob", uint32(1)}))
+	other := *event
+	other.Computer = "host-b"
+	assert.NotEqual(t, key, messageKey(&other, []interface{}{"alice", uint32(1)}))
+}
+
 // renderAllEvents reads all events and renders them.
 func renderAllEvents(t *testing.T, log EvtHandle, renderer *Renderer, ignoreMissingMetadataError bool) []*winevent.Event {
 	t.Helper()
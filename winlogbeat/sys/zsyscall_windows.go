@@ -29,6 +29,7 @@ var (
 	modkernel = windows.NewLazySystemDLL("Kernel32.dll")
 
 	procSystemTimeToFileTime = modkernel.NewProc("SystemTimeToFileTime")
+	procLocaleNameToLCID     = modkernel.NewProc("LocaleNameToLCID")
 )
 
 func SystemTimeToFileTime(systemTime *windows.Systemtime, fileTime *windows.Filetime) error {
@@ -38,3 +39,17 @@ func SystemTimeToFileTime(systemTime *windows.Systemtime, fileTime *windows.File
 	}
 	return nil
 }
+
+// LocaleNameToLCID returns the locale identifier (LCID) of a locale name
+// such as "en-US".
+func LocaleNameToLCID(name string) (uint32, error) {
+	p, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, err
+	}
+	r1, _, err := syscall.SyscallN(procLocaleNameToLCID.Addr(), uintptr(unsafe.Pointer(p)), 0)
+	if r1 == 0 {
+		return 0, fmt.Errorf("error converting locale name %q to locale identifier: %w", name, err)
+	}
+	return uint32(r1), nil
+}
@@ -12,6 +12,7 @@ import (
 
 	// Import packages that perform 'func init()'.
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system"
+	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/container"
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/host"
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/login"
 	_ "github.com/elastic/beats/v7/x-pack/auditbeat/module/system/package"
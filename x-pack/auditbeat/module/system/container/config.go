@@ -0,0 +1,45 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+
+package container
+
+import (
+	"errors"
+	"time"
+)
+
+// config defines the metricset's configuration options.
+type config struct {
+	StatePeriod          time.Duration `config:"state.period"`
+	ContainerStatePeriod time.Duration `config:"container.state.period"`
+
+	// Endpoint is the address of the CRI runtime service, for example
+	// unix:///run/containerd/containerd.sock. It is detected when empty.
+	Endpoint string        `config:"container.endpoint"`
+	Timeout  time.Duration `config:"container.timeout"`
+}
+
+// Validate validates the config.
+func (c *config) Validate() error {
+	if c.Timeout <= 0 {
+		return errors.New("container.timeout must be greater than 0")
+	}
+	return nil
+}
+
+func (c *config) effectiveStatePeriod() time.Duration {
+	if c.ContainerStatePeriod != 0 {
+		return c.ContainerStatePeriod
+	}
+	return c.StatePeriod
+}
+
+func defaultConfig() config {
+	return config{
+		StatePeriod: 12 * time.Hour,
+		Timeout:     10 * time.Second,
+	}
+}
@@ -0,0 +1,366 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/elastic/beats/v7/auditbeat/ab"
+	"github.com/elastic/beats/v7/auditbeat/datastore"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/auditbeat/cache"
+	"github.com/elastic/beats/v7/x-pack/auditbeat/module/system"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	metricsetName = "container"
+	namespace     = "system.audit.container"
+
+	bucketName              = "auditbeat.container.v1"
+	bucketKeyStateTimestamp = "state_timestamp"
+
+	eventTypeState = "state"
+	eventTypeEvent = "event"
+
+	// Labels set by the kubelet on the containers of pods.
+	labelPodName      = "io.kubernetes.pod.name"
+	labelPodNamespace = "io.kubernetes.pod.namespace"
+)
+
+type eventAction uint8
+
+const (
+	eventActionExistingContainer eventAction = iota
+	eventActionContainerStarted
+	eventActionContainerStopped
+)
+
+func (action eventAction) String() string {
+	switch action {
+	case eventActionExistingContainer:
+		return "existing_container"
+	case eventActionContainerStarted:
+		return "container_started"
+	case eventActionContainerStopped:
+		return "container_stopped"
+	default:
+		return ""
+	}
+}
+
+func (action eventAction) Type() string {
+	switch action {
+	case eventActionExistingContainer:
+		return "info"
+	case eventActionContainerStarted:
+		return "start"
+	case eventActionContainerStopped:
+		return "end"
+	default:
+		return "info"
+	}
+}
+
+// Container represents a container of a CRI runtime.
+type Container struct {
+	ID       string
+	Name     string
+	Image    string
+	ImageID  string
+	Runtime  string
+	Labels   map[string]string
+	Created  time.Time
+	Started  time.Time
+	Finished time.Time
+	ExitCode *int32
+}
+
+// Hash creates a hash for Container.
+func (c Container) Hash() uint64 {
+	return xxhash.Sum64String(c.ID)
+}
+
+func (c Container) toMapStr() mapstr.M {
+	evt := mapstr.M{
+		"created": c.Created,
+	}
+	if !c.Started.IsZero() {
+		evt.Put("started", c.Started)
+	}
+	if !c.Finished.IsZero() {
+		evt.Put("finished", c.Finished)
+	}
+	if c.ExitCode != nil {
+		evt.Put("exit_code", *c.ExitCode)
+	}
+	return evt
+}
+
+func init() {
+	ab.Registry.MustAddMetricSet(system.ModuleName, metricsetName, New,
+		mb.DefaultMetricSet(),
+		mb.WithNamespace(namespace),
+	)
+}
+
+// MetricSet collects data about the containers running on a host.
+type MetricSet struct {
+	system.SystemMetricSet
+	config    config
+	log       *logp.Logger
+	cache     *cache.Cache[*Container]
+	bucket    datastore.Bucket
+	lastState time.Time
+
+	// client is connected on the first fetch, so that Auditbeat can start
+	// before the container runtime.
+	client runtimeClient
+}
+
+// New constructs a new MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The %v/%v dataset is beta", system.ModuleName, metricsetName)
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, fmt.Errorf("failed to unpack the %v/%v config: %w", system.ModuleName, metricsetName, err)
+	}
+
+	bucket, err := datastore.OpenBucket(bucketName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open persistent datastore: %w", err)
+	}
+
+	ms := &MetricSet{
+		SystemMetricSet: system.NewSystemMetricSet(base),
+		config:          config,
+		log:             base.Logger().Named(metricsetName),
+		cache:           cache.New[*Container](),
+		bucket:          bucket,
+	}
+
+	// Load from disk: Time when state was last sent
+	err = bucket.Load(bucketKeyStateTimestamp, func(blob []byte) error {
+		if len(blob) > 0 {
+			return ms.lastState.UnmarshalBinary(blob)
+		}
+		return nil
+	})
+	if err != nil {
+		bucket.Close()
+		return nil, err
+	}
+	if !ms.lastState.IsZero() {
+		ms.log.Debugf("Last state was sent at %v. Next state update by %v.", ms.lastState, ms.lastState.Add(ms.config.effectiveStatePeriod()))
+	} else {
+		ms.log.Debug("No state timestamp found")
+	}
+
+	return ms, nil
+}
+
+// Close cleans up the MetricSet when it finishes.
+func (ms *MetricSet) Close() error {
+	var errs []error
+	if ms.client != nil {
+		errs = append(errs, ms.client.close())
+	}
+	if ms.bucket != nil {
+		errs = append(errs, ms.bucket.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Fetch collects container information. It is invoked periodically.
+func (ms *MetricSet) Fetch(report mb.ReporterV2) {
+	ctx, cancel := context.WithTimeout(context.Background(), ms.config.Timeout)
+	defer cancel()
+
+	if ms.client == nil {
+		client, err := newRuntimeClient(ctx, ms.config.Endpoint)
+		if err != nil {
+			err = fmt.Errorf("failed to connect to the container runtime: %w", err)
+			ms.log.Error(err)
+			report.Error(err)
+			return
+		}
+		ms.client = client
+	}
+
+	needsStateUpdate := time.Since(ms.lastState) > ms.config.effectiveStatePeriod()
+	if needsStateUpdate || ms.cache.IsEmpty() {
+		ms.log.Debugf("State update needed (needsStateUpdate=%v, cache.IsEmpty()=%v)", needsStateUpdate, ms.cache.IsEmpty())
+		err := ms.reportState(ctx, report)
+		if err != nil {
+			ms.log.Error(err)
+			report.Error(err)
+		}
+		ms.log.Debugf("Next state update by %v", ms.lastState.Add(ms.config.effectiveStatePeriod()))
+	}
+
+	err := ms.reportChanges(ctx, report)
+	if err != nil {
+		ms.log.Error(err)
+		report.Error(err)
+	}
+}
+
+// reportState reports all running containers on the host.
+func (ms *MetricSet) reportState(ctx context.Context, report mb.ReporterV2) error {
+	// Only update lastState if this state update was regularly scheduled,
+	// i.e. not caused by an Auditbeat restart (when the cache would be empty).
+	if !ms.cache.IsEmpty() {
+		ms.lastState = time.Now()
+	}
+
+	containers, err := ms.client.runningContainers(ctx)
+	if err != nil {
+		return err
+	}
+	ms.log.Debugf("Found %v containers", len(containers))
+
+	stateID, err := uuid.NewV4()
+	if err != nil {
+		return fmt.Errorf("error generating state ID: %w", err)
+	}
+	for _, c := range containers {
+		ms.updateStatus(ctx, c)
+		event := containerEvent(c, eventTypeState, eventActionExistingContainer)
+		event.RootFields.Put("event.id", stateID.String())
+		report.Event(event)
+	}
+
+	// This will initialize the cache with the current containers
+	ms.cache.DiffAndUpdateCache(containers)
+
+	// Save time so we know when to send the state again (config.StatePeriod)
+	timeBytes, err := ms.lastState.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	err = ms.bucket.Store(bucketKeyStateTimestamp, timeBytes)
+	if err != nil {
+		return fmt.Errorf("error writing state timestamp to disk: %w", err)
+	}
+
+	return nil
+}
+
+// reportChanges detects and reports containers that started or stopped since
+// the last call.
+func (ms *MetricSet) reportChanges(ctx context.Context, report mb.ReporterV2) error {
+	containers, err := ms.client.runningContainers(ctx)
+	if err != nil {
+		return err
+	}
+	ms.log.Debugf("Found %v containers", len(containers))
+
+	started, stopped := ms.cache.DiffAndUpdateCache(containers)
+
+	for _, c := range started {
+		ms.updateStatus(ctx, c)
+		report.Event(containerEvent(c, eventTypeEvent, eventActionContainerStarted))
+	}
+
+	for _, c := range stopped {
+		// The runtime may already have removed the container, in which case
+		// its end time and exit code are unknown.
+		ms.updateStatus(ctx, c)
+		report.Event(containerEvent(c, eventTypeEvent, eventActionContainerStopped))
+	}
+
+	return nil
+}
+
+// updateStatus completes c with its status. Errors are logged since the event
+// is still worth reporting without the status.
+func (ms *MetricSet) updateStatus(ctx context.Context, c *Container) {
+	found, err := ms.client.status(ctx, c)
+	if err != nil {
+		ms.log.Warn(err)
+	} else if !found {
+		ms.log.Debugf("Container %v not found in the runtime", c.ID)
+	}
+}
+
+func containerEvent(c *Container, eventType string, action eventAction) mb.Event {
+	event := mb.Event{
+		RootFields: mapstr.M{
+			"event": mapstr.M{
+				"kind":     eventType,
+				"category": []string{"process"},
+				"type":     []string{action.Type()},
+				"action":   action.String(),
+			},
+			"container": mapstr.M{
+				"id":      c.ID,
+				"runtime": c.Runtime,
+			},
+			"message": containerMessage(c, action),
+		},
+		MetricSetFields: c.toMapStr(),
+	}
+
+	if c.Name != "" {
+		event.RootFields.Put("container.name", c.Name)
+	}
+	if c.Image != "" {
+		event.RootFields.Put("container.image.name", c.Image)
+	}
+	if c.ImageID != "" {
+		event.RootFields.Put("container.image.hash.all", []string{c.ImageID})
+	}
+	if len(c.Labels) > 0 {
+		labels := mapstr.M{}
+		for k, v := range c.Labels {
+			labels[common.DeDot(k)] = v
+		}
+		event.RootFields.Put("container.labels", labels)
+	}
+	if name := c.Labels[labelPodName]; name != "" {
+		event.RootFields.Put("orchestrator.type", "kubernetes")
+		event.RootFields.Put("orchestrator.resource.type", "pod")
+		event.RootFields.Put("orchestrator.resource.name", name)
+		if ns := c.Labels[labelPodNamespace]; ns != "" {
+			event.RootFields.Put("orchestrator.namespace", ns)
+		}
+	}
+
+	return event
+}
+
+func containerMessage(c *Container, action eventAction) string {
+	var actionString string
+	switch action {
+	case eventActionExistingContainer:
+		actionString = "Existing"
+	case eventActionContainerStarted:
+		actionString = "Started"
+	case eventActionContainerStopped:
+		actionString = "Stopped"
+	}
+
+	name := c.Name
+	if name == "" {
+		name = c.ID
+	}
+	msg := fmt.Sprintf("%v container %v (image: %v)", actionString, name, c.Image)
+	if c.ExitCode != nil {
+		msg += fmt.Sprintf(" with exit code %d", *c.ExitCode)
+	}
+	return msg
+}
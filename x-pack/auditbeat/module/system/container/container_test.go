@@ -0,0 +1,180 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/auditbeat/ab"
+	"github.com/elastic/beats/v7/auditbeat/core"
+	abtest "github.com/elastic/beats/v7/auditbeat/testing"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/auditbeat/module/system"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestData(t *testing.T) {
+	defer abtest.SetupDataDir(t)()
+
+	client := &fakeClient{running: []*Container{testContainer()}}
+	f := newTestMetricSet(t, client)
+
+	events, errs := mbtest.ReportingFetchV2(f)
+	require.Empty(t, errs)
+	require.NotEmpty(t, events)
+
+	fullEvent := mbtest.StandardizeEvent(f, events[len(events)-1], core.AddDatasetToEvent)
+	mbtest.WriteEventToDataJSON(t, fullEvent, "")
+}
+
+func TestLifecycle(t *testing.T) {
+	defer abtest.SetupDataDir(t)()
+
+	c := testContainer()
+	client := &fakeClient{running: []*Container{c}}
+	ms := newTestMetricSet(t, client)
+	ms.lastState = time.Now()
+
+	// The first fetch reports the running containers.
+	events, errs := mbtest.ReportingFetchV2(ms)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	assertEvent(t, events[0], "state", "existing_container", "info")
+
+	// No changes.
+	events, errs = mbtest.ReportingFetchV2(ms)
+	require.Empty(t, errs)
+	assert.Empty(t, events)
+
+	// A new container is started.
+	started := testContainer()
+	started.ID = "2f3c1b0a9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b"
+	started.Name = "sidecar"
+	client.running = []*Container{c, started}
+	events, errs = mbtest.ReportingFetchV2(ms)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	assertEvent(t, events[0], "event", "container_started", "start")
+	name, _ := events[0].RootFields.GetValue("container.name")
+	assert.Equal(t, "sidecar", name)
+
+	// The first container exits.
+	client.running = []*Container{started}
+	client.exitCode = 137
+	events, errs = mbtest.ReportingFetchV2(ms)
+	require.Empty(t, errs)
+	require.Len(t, events, 1)
+	assertEvent(t, events[0], "event", "container_stopped", "end")
+	assert.Equal(t, "Stopped container nginx (image: docker.io/library/nginx:1.27) with exit code 137", events[0].RootFields["message"])
+	exitCode, _ := events[0].MetricSetFields.GetValue("exit_code")
+	assert.Equal(t, int32(137), exitCode)
+	_, err := events[0].MetricSetFields.GetValue("finished")
+	assert.NoError(t, err)
+}
+
+func TestContainerEvent(t *testing.T) {
+	event := containerEvent(testContainer(), eventTypeState, eventActionExistingContainer)
+
+	for k, want := range map[string]interface{}{
+		"container.id":                            "b5b8d6e8d1c2a3f4e5d6c7b8a9f0e1d2c3b4a5f6e7d8c9b0a1f2e3d4c5b6a7f8",
+		"container.name":                          "nginx",
+		"container.runtime":                       "containerd",
+		"container.image.name":                    "docker.io/library/nginx:1.27",
+		"container.labels.app":                    "web",
+		"container.labels.io_kubernetes_pod_name": "web-5d4f8",
+		"orchestrator.type":                       "kubernetes",
+		"orchestrator.resource.type":              "pod",
+		"orchestrator.resource.name":              "web-5d4f8",
+		"orchestrator.namespace":                  "default",
+		"message":                                 "Existing container nginx (image: docker.io/library/nginx:1.27)",
+	} {
+		got, err := event.RootFields.GetValue(k)
+		if assert.NoError(t, err, k) {
+			assert.Equal(t, want, got, k)
+		}
+	}
+	_, err := event.MetricSetFields.GetValue("exit_code")
+	assert.ErrorIs(t, err, mapstr.ErrKeyNotFound)
+}
+
+func assertEvent(t *testing.T, event mb.Event, kind, action, typ string) {
+	t.Helper()
+	for k, want := range map[string]interface{}{
+		"event.kind":   kind,
+		"event.action": action,
+		"event.type":   []string{typ},
+	} {
+		got, err := event.RootFields.GetValue(k)
+		if assert.NoError(t, err, k) {
+			assert.Equal(t, want, got, k)
+		}
+	}
+}
+
+func newTestMetricSet(t *testing.T, client runtimeClient) *MetricSet {
+	config := map[string]interface{}{
+		"module":   system.ModuleName,
+		"datasets": []string{"container"},
+	}
+	ms, ok := mbtest.NewReportingMetricSetV2WithRegistry(t, config, ab.Registry).(*MetricSet)
+	require.True(t, ok)
+	ms.client = client
+	return ms
+}
+
+func testContainer() *Container {
+	return &Container{
+		ID:      "b5b8d6e8d1c2a3f4e5d6c7b8a9f0e1d2c3b4a5f6e7d8c9b0a1f2e3d4c5b6a7f8",
+		Name:    "nginx",
+		Image:   "docker.io/library/nginx:1.27",
+		ImageID: "sha256:9bea9f2796e236cb18c2b3ad561ff29f655d1001f9ec7247a0bc5e08d25652a1",
+		Runtime: "containerd",
+		Labels: map[string]string{
+			"app":                         "web",
+			"io.kubernetes.pod.name":      "web-5d4f8",
+			"io.kubernetes.pod.namespace": "default",
+		},
+		Created: time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// fakeClient reports the running containers set by the test. Containers that
+// are not running have exited with exitCode.
+type fakeClient struct {
+	running  []*Container
+	exitCode int32
+}
+
+func (c *fakeClient) runningContainers(context.Context) ([]*Container, error) {
+	containers := make([]*Container, 0, len(c.running))
+	for _, ctr := range c.running {
+		cp := *ctr
+		containers = append(containers, &cp)
+	}
+	return containers, nil
+}
+
+func (c *fakeClient) status(_ context.Context, ctr *Container) (bool, error) {
+	ctr.Started = ctr.Created.Add(time.Second)
+	for _, r := range c.running {
+		if r.ID == ctr.ID {
+			return true, nil
+		}
+	}
+	ctr.Finished = ctr.Created.Add(time.Hour)
+	exitCode := c.exitCode
+	ctr.ExitCode = &exitCode
+	return true, nil
+}
+
+func (c *fakeClient) close() error { return nil }
@@ -0,0 +1,144 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// errNoRuntime is returned when no container runtime socket is found.
+var errNoRuntime = errors.New("no container runtime socket found")
+
+// defaultSockets are the default sockets of the CRI runtimes, in the order
+// they are detected.
+var defaultSockets = []string{
+	"/run/containerd/containerd.sock",
+	"/var/run/crio/crio.sock",
+}
+
+// runtimeClient lists containers in a container runtime.
+type runtimeClient interface {
+	// runningContainers returns the running containers.
+	runningContainers(ctx context.Context) ([]*Container, error)
+	// status completes c with its start and end times and its exit code.
+	// It returns false if the runtime does not know the container anymore.
+	status(ctx context.Context, c *Container) (bool, error)
+	close() error
+}
+
+// newRuntimeClient connects to the CRI runtime service at endpoint, or at the
+// first default socket that exists if endpoint is empty.
+func newRuntimeClient(ctx context.Context, endpoint string) (runtimeClient, error) {
+	if endpoint == "" {
+		for _, path := range defaultSockets {
+			if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+				endpoint = "unix://" + path
+				break
+			}
+		}
+		if endpoint == "" {
+			return nil, errNoRuntime
+		}
+	}
+	return newCRIClient(ctx, endpoint)
+}
+
+// criClient lists containers with the Kubernetes Container Runtime
+// Interface, served by containerd and CRI-O.
+type criClient struct {
+	conn    *grpc.ClientConn
+	client  runtimeapi.RuntimeServiceClient
+	runtime string
+}
+
+func newCRIClient(ctx context.Context, endpoint string) (*criClient, error) {
+	conn, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	c := runtimeapi.NewRuntimeServiceClient(conn)
+	version, err := c.Version(ctx, &runtimeapi.VersionRequest{})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to connect to CRI runtime at %s: %w", endpoint, err)
+	}
+	return &criClient{conn: conn, client: c, runtime: version.RuntimeName}, nil
+}
+
+func (c *criClient) runningContainers(ctx context.Context) ([]*Container, error) {
+	resp, err := c.client.ListContainers(ctx, &runtimeapi.ListContainersRequest{
+		Filter: &runtimeapi.ContainerFilter{
+			State: &runtimeapi.ContainerStateValue{State: runtimeapi.ContainerState_CONTAINER_RUNNING},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	containers := make([]*Container, 0, len(resp.Containers))
+	for _, rc := range resp.Containers {
+		containers = append(containers, &Container{
+			ID:      rc.Id,
+			Name:    rc.GetMetadata().GetName(),
+			Image:   imageName(rc.GetImage()),
+			ImageID: rc.ImageRef,
+			Runtime: c.runtime,
+			Labels:  rc.Labels,
+			Created: time.Unix(0, rc.CreatedAt).UTC(),
+		})
+	}
+	return containers, nil
+}
+
+func (c *criClient) status(ctx context.Context, ctr *Container) (bool, error) {
+	resp, err := c.client.ContainerStatus(ctx, &runtimeapi.ContainerStatusRequest{ContainerId: ctr.ID})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get status of container %s: %w", ctr.ID, err)
+	}
+	s := resp.GetStatus()
+	if s == nil {
+		return false, nil
+	}
+
+	if s.StartedAt != 0 {
+		ctr.Started = time.Unix(0, s.StartedAt).UTC()
+	}
+	if s.State == runtimeapi.ContainerState_CONTAINER_EXITED {
+		if s.FinishedAt != 0 {
+			ctr.Finished = time.Unix(0, s.FinishedAt).UTC()
+		}
+		exitCode := s.ExitCode
+		ctr.ExitCode = &exitCode
+	}
+	return true, nil
+}
+
+func (c *criClient) close() error {
+	return c.conn.Close()
+}
+
+// imageName returns the image as specified by the user when it is reported,
+// as some runtimes resolve the image to its ID.
+func imageName(spec *runtimeapi.ImageSpec) string {
+	if name := spec.GetUserSpecifiedImage(); name != "" {
+		return name
+	}
+	return spec.GetImage()
+}
@@ -0,0 +1,45 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package system
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// containerIDRegex matches the container ID at the end of the cgroup path of
+// a process running in a container, for example
+// /kubepods/besteffort/pod1/<id> or /system.slice/docker-<id>.scope.
+var containerIDRegex = regexp.MustCompile(`[-/:]([0-9a-f]{64})(\.scope)?$`)
+
+// procRoot is the mount point of procfs. It is a variable for testing.
+var procRoot = "/proc"
+
+// ContainerID returns the ID of the container the process with the given PID
+// runs in, or an empty string if the process does not run in a container or
+// its cgroups cannot be read.
+func ContainerID(pid int) string {
+	f, err := os.Open(procRoot + "/" + strconv.Itoa(pid) + "/cgroup")
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		// Each line is hierarchy-ID:controller-list:cgroup-path.
+		parts := strings.SplitN(s.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if m := containerIDRegex.FindStringSubmatch(parts[2]); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,55 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerID(t *testing.T) {
+	const id = "b5b8d6e8d1c2a3f4e5d6c7b8a9f0e1d2c3b4a5f6e7d8c9b0a1f2e3d4c5b6a7f8"
+
+	for name, test := range map[string]struct {
+		cgroup string
+		want   string
+	}{
+		"cgroup v2 systemd": {
+			cgroup: "0::/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod1.slice/cri-containerd-" + id + ".scope\n",
+			want:   id,
+		},
+		"cgroup v1 docker": {
+			cgroup: "12:pids:/docker/" + id + "\n11:memory:/docker/" + id + "\n",
+			want:   id,
+		},
+		"cgroup v1 kubepods": {
+			cgroup: "12:pids:/kubepods/besteffort/pod1/" + id + "\n",
+			want:   id,
+		},
+		"host process": {
+			cgroup: "0::/user.slice/user-1000.slice/session-2.scope\n",
+		},
+		"truncated ID": {
+			cgroup: "0::/docker/" + id[:12] + "\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(root, "42"), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(root, "42", "cgroup"), []byte(test.cgroup), 0o644))
+
+			old := procRoot
+			procRoot = root
+			defer func() { procRoot = old }()
+
+			assert.Equal(t, test.want, ContainerID(42))
+			assert.Empty(t, ContainerID(43))
+		})
+	}
+}
@@ -19,5 +19,5 @@ func init() {
 // AssetSystem returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/system.
 func AssetSystem() string {
-	return "eJy8Wm1v27oV/u5fcRAMaILrKotvExT+MMBt7pZg7W0wp0C3uzubEo8lLhSpkVQSFfvxA6kXSzZlW4k7A0VjmXyec57z4kPJb+EBiynoQhtMRwCGGY5TOJm7CycjAIo6UiwzTIop/GkEAHCfoEYgCsEkCCuGnGqIUaAiBimEhbteYkIqac4xGAEo5Eg0TiFEQ0ZQbZyORgBvQZAUp4CPKIzjMEWGU4iVzDP3vl5s/65XS8ViJtylesMDFk9S0eqax3b7+uL2gVw5Ox1nAPcJ0xARASECgRXjCBkxCZxiEAewPH8k6pzL2P4LLpZn4wZNKgdjTaohK9cjmWZSoDBgEmJA51nGGVK3hBJDamyBhjPxsDwL2lrkGtXBUqAwzBQLRoercXsNuWD/yZEXwKgFWhVMxM5KawNIAQQSqU0AtwasSjLNchtpooHA/Gb2dnJ5BQnRyVqUUgi7C26vxyWQ/YMIWr6xdgcdHwyqlAnCh7twX+2saS1BR8tMyQi1PlhOkygkNIhIRkLGmWGoA1ytMDLsEStajo/Ip4DPBgXFXcKzWEiFCxLKR5zCxR8n73zuuARkukwgNNaXNr91qqmtB1QCORgJGaqVVKn9P2VaMykaVaIEowcNqypBK5+qj/GZpJkt9Te/nXyc3S0+3P35ZAzuz/nf54vZ9efbX09+f1OtzogxqMQU/nVqV/w2e/uPxe8//fef9KezPzS+rEjOzcLJOYUV4Rr3auqsNqZR70dpSoCzlBmb1jrPUFl9a12auHbltiXbSLnWD1JSANE6r7P3/yVlR8tWrW2mc2+R3BCdoG663jNGuSEhR9v6bEYV2rV0wmOpmElSR6VdwdoNj4Tn6JZ0VEnwGVBEkiIFymLUploZjKp17fpaexBy8oCTcDG5vFrjeeK84c6HT7O//jIJm4bjcWfUw/Tz+3cvYfr5/buhTJcXk5cwXV5MDmXSCZlMBrkzv5lNJgd7ohMyUK75zWyAUhZ/MdwDt2cYx7D0KjkOzy3H8QKlFkO1GphSjmNYPl1eTF4QkcuLyfmwmDiewVFxPIfH5fk5uRrkyrdvVzudaBxwk11Acsr8c6qn+XYbYKuJS73+hvE18h68+rW0AEuIpDCEiXoC5+XQxYQdC4jdF7R2bc7g9WvTxtYYmhmWYoe4tJRLEXcul4RToLlyvJ0Pmchys6iXCCKkxkgKqjurZG7ay4i+JoV3RaYwYtqJctH5fIde9vXVeQNMtE0IPG6HUpoexykxOITzg5QGLJaPp4oeKvYdqYcslJIjEUP45miArao0sLNPw+EzwBr2XQoM7FuPAZtlc4ABv7aOQjV8+0QwBnfu+TC/32mQXK00mkBjdEj27bHpfm2HRbUZsCP61srj6XFTofmYmC/oL+SA22sfBVFRwgxGJldHdKgDW51kn99fLa7enfmMSIkvii/g/jz7CIRShVqjN3Ys8xBtXNzDcXu3m0JqD8Vm597DspS61btb7RpIKHPjikVmaFupPbWU3zvdfrvVs9ttheJWAu9Sfa8mX+YN6Ni2FyKKKuraKDRRchZ4Lck4Mda3o1pSg1YWRCiM1GPIw1yYfAxPTFD5pHssOrou7tZPaclnEtkr33qoVyRlvDgqeQlZ0SukCTFjoBgyIsawUoihpvsUeUSlN7+wX2tXheknLO9fHI/v3lMsb3R9m2SnKZb1qI7b3XCqEeGXj3OQOrAXWsI3hUGiBxLjqybACmNnIyECmNCGcI4UpAKFqXxEWvO/bjrcvO+4T8Cd8u26E1lbu/cWZDVobEWmuRVZIUHZyYholPDlSW/PeKGLdy1yH4+vEl9JtcOrKt7HZKsg++aQY1K1BxAfH2cRiuN6V0F6x46yxo5yZqjpKszew4Nm3w86mR1EZsG8JHmaElW8ALDc6MPMFT9mWL7+7dN2f22en7QphjRXC7B3RLOLdPmIZHtGO7yf/qjpBOBr92HLlkpsE/H1bN1jyJorPi7XX2wse8koU8d27I2GRKZooTEyspva7ZtcyI842wDcKRkrkoKRoHIBxACXMeuZZ2xCLlq5elTFqztM7gFe+w4TfBHwiYn8eQym9RgrxkjqMtt7MmLrzFRbKMN/Y2SGGbh0cHuGoaIk1evnm0xDRpR74HYaYiGrBx55GfFMMdvFyl0b87O/kmF3Ne+LwkGRgCb/t0sbdpbcmp4JgzFuVslA+r7yy4jWHuf6jsr7Y1sD7g5vE7VqNZwKaaoBsrrCjEa+GhxJzzkBjhXJ2ZbZFjaAO6k1C3n74RssdUKofFo0evRgnnacdpOxLUxRPl92GO5XDmfjtbYLyjQJOdLluAd1KeSa2XKUxU6JiFHJXLt5XBRSoPstBZcxMHHmxuw+xEgVmWmDPiUouiFzsbG2n6OJzt1lChox1T2gRtZZYo8/KByHO/OUiFvRb02NRJtFlFiH+ktna5wrXwcF+979+qPo9Jja0SeinQFQGRCM/hcAAP//VUidAg=="
+	return "eJy8Wm1v27oV/u5fcRAMSILrKoubBIU/XMBtuptg7U0wp0C3u86mxWOJi0RqJJVYxX78cCjJlh36Rak6wEVjiXqe57xRh6TfwCMWQzCFsZj2AKywCQ7haOwuHPUAOJpQi8wKJYfwaw8A4CFGg8A0go0R5gITbiBCiZpZ5DAr3PUSE1LF8wSDHoDGBJnBIczQsh5UDw57PYA3IFmKQ8AnlNZx2CLDIURa5Zn7Xg8GWI1WWkRCutv1A49YPCvNq2se7fS5c8+BmjudjjOAh1gYCJmEGQKDuUgQMmZjOMEgCmB69sT0WaIi+hecT0/7SzSlHQxJqiEr00OVZkqitGBjZsHkWZYI5G44Z5bV2BJtIuTj9DRo+iI3qA92BUorbDERvL03bq8hl+I/OSYFCE5A80LIyKkkDaAkMIiVsQHcWiAvqTTLKdLMAIPxzejN4PIKYmbiJWjlCHoKbq/7JRD9wSQvv5CRwZoNFnUqJEvam/BQPVn7nwjWfJlpFaIxB7vTxhoZD0KWsZlIhBVoApzPMbTiCSvaBJ8wGQIuLEqOfIdqEUmlccJm6gmHcP7nwYXPHJeAwji/GbRkS5OfvLasrUfUEhOwCjLUc6VT+j8VxghV1wNAGGP4aGBeJWhlU3UbFyzNqNSP/zj6MLqfvL//y1Ef3J/jv48no+vPt78ffTuuRmfMWtRyCP86oRF/jN78Y/Ltl//+k/9y+qdqCMc5yxM7cYU6hDlLDO71qVNtLfKf7FMGiUiFpbQ2eYaa/Fv7ZRnXdXdTyVaQ0PAfpKwAZkye4v/XlWu+bNTaZjpvLZIbZmI0dYngAsPcslmCNPUhede4KZ0lkdLCxqmjMq5g6YEnluTohiwR6XKMC0AZKo4cuIjQ2Gqkq7/N+lpZMEvYIw5mk8HlVXXHH+cNc95/Gv3142C2nHA85vS2ML19d/EaprfvLtoyXZ4PXsN0eT44lMnEbDC4aEMyvhkNBgdbYmLW0l3jm1ELTxH+pL0FbyctbWibXmTFpEVuOY5XeGrS1lctU8rZ0S6fLs8Hr4jI5fngrF1MHE/rqDiew+OyWMRXrUz5+vVqpxFLA1xnF7CcC3+fuob664sGoykyVNIyIasuD8AHt3NGp890iTKtAQ0IST0BI+8Bm6mcGlBc8RnQuZT0Mly1dg3QzT4dwDeRr1mika1e4k1LOLN1v7TXFPo8iBThOUa5rhiemalZAo8AY5n++QIqFp+AuZDCxD9VAS7EFnK6M6FX8BpWaX+iZNSG/eNCWCCsuhCWAo4NpEzIupcOepsyqNn/oVQmgEYWlyvKxOVnM6V/LFfzzIr0IE+VNTQEnmtXSms3hcxyO6mHSCaVwVBJbtZGqdw2hzFzzQrviExjKKh7H8J5m3B9cdaAkE0JgcfsmVJ2i+FtE/S9UhYIy8ezTBfx3VsNM6USZLIN3xgtiHmVBrTiXHL4BJCw70piQF/XsPyvgQME/N5Y2tfw9XdS1Qe3jn8/ftgpSM3nBm1gMDwk+/ZoeljpIFTKgB3RJ5Xd+eOmQvMxCd4dB9xe+yiYDmNhMbS5xg7JmrDVzszi3dXk6uLUJyJlYTfcn0cfgHGu0Rj0xk5kHiKRteG4vd9NodbnJP/MvYdlqszeDkRltEVIjUe5Q9bU4puzVwrpjfQigXd5fa9P7sZL0D5NL0wWVdSN1WjD+DTwKskSZmk+71RJDVopCFFaZfqQz3Jp8z48C8nVs9miqHO/EGCl5DML4W4MX7dQz1kqkqJT8hKyotfIY2b7wHEmmOzDXCPODN/nkSfUazthXeiqMP2E5X5cd3wPnmI5NhXNbilkZXdC7sbuaTgxiPDxwxiUCehCw/E1ccbCRxZhb988soNxWmHsnEiYBCGNZUmCHJQGjal6Ql7z/1h3uLmPvs+BO923a2e9Vrt3S71qNDagoWyHaEe9QnJRKPfX6yuePNk6Z7zSxPsGuY+nktAl1Q6rqnh3yVZB+tioYeiSqtmA+PgSEaLs1roK0sdW1Vgna4aarsLcungw4jv+cG9ckxGYlyRPU6aLVwCWD/owc510GZYvf/sU9F5w1OeBr51c6VRsb4tGg0x55PeyRzt8Pt0x0+zyzh7/0OfL+uHhJl0ueOdst9d+rqhbrt9oq2QrGRe6SzIy7NhArFIELjSGVunCz2xiTDrsbQDutYo0S+n4UucSmIVERUL62SkhJ41c7VLIb9UOE3E06yGAOwmfhMwXfbCNY9kIQ2XKdYpf7GY5rhSq2b8xtO0ETh3cnmaoKLfYzLJ66YQ+Y9odIJ/MsFDVAR7dOzaQaUGzWPnURv/sr+Q91bwvCgdFYpX/L0t7d8mt6IW0GKH23G9Bv638MmaMxzj/LHwA57QG3B3eZdSq0XAiafMtxtUVYQ0m89aR9KwTOovk6IVsgg3gXhkjZknzMBmmJmZcPU/qodMtmCdrRrujGirMcp++xHC/2jntr3w74cLQEQ6f9nteUJhKtWKGk7rYOZMRapUb14/LQkl0vw1KVARCnro2extiqIvMNkGXe/n1tTI2pP0MbXjmLnMwiKnpeRApKqrOEmCSztuJw615SsTTYGucE2bsJIzJIF88t7RzLYJNa1XOirU5pjaUTk1IAIQxkxHyoPe/AQCiq1/8"
 }
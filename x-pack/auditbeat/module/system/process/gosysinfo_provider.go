@@ -20,6 +20,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/capabilities"
 	"github.com/elastic/beats/v7/metricbeat/mb"
 	"github.com/elastic/beats/v7/x-pack/auditbeat/cache"
+	"github.com/elastic/beats/v7/x-pack/auditbeat/module/system"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/go-sysinfo"
 	"github.com/elastic/go-sysinfo/types"
@@ -50,6 +51,7 @@ type Process struct {
 	CapEffective []string
 	CapPermitted []string
 	Hashes       map[hasher.HashType]hasher.Digest
+	ContainerID  string
 	Error        error
 }
 
@@ -232,9 +234,11 @@ func (ms *SysInfoMetricSet) reportChanges(report mb.ReporterV2) error {
 	return nil
 }
 
-// enrichProcess enriches a process with user lookup information
-// and executable file hash.
+// enrichProcess enriches a process with user lookup information,
+// the ID of its container and executable file hash.
 func (ms *SysInfoMetricSet) enrichProcess(process *Process) {
+	process.ContainerID = system.ContainerID(process.Info.PID)
+
 	if process.UserInfo != nil {
 		goUser, err := user.LookupId(process.UserInfo.UID)
 		if err == nil {
@@ -320,6 +324,10 @@ func (ms *SysInfoMetricSet) processEvent(process *Process, eventType string, act
 		}
 	}
 
+	if process.ContainerID != "" {
+		event.RootFields.Put("container.id", process.ContainerID)
+	}
+
 	if process.Error != nil {
 		event.RootFields.Put("error.message", process.Error.Error())
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/elastic/beats/v7/auditbeat/helper/tty"
 	"github.com/elastic/beats/v7/libbeat/common/capabilities"
 	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/auditbeat/module/system"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/monitoring"
 
@@ -222,6 +223,11 @@ func (ms *QuarkMetricSet) toEvent(quarkEvent quark.Event) (mb.Event, bool) {
 		event.RootFields.Put("process.tty.char_device.major", process.Proc.TtyMajor)
 		event.RootFields.Put("process.tty.char_device.minor", process.Proc.TtyMinor)
 	}
+	// Quark does not report cgroups, look the container up while the
+	// process is still around.
+	if containerID := system.ContainerID(int(process.Pid)); containerID != "" {
+		event.RootFields.Put("container.id", containerID)
+	}
 	// Capabilities
 	capEffective, _ := capabilities.FromUint64(process.Proc.CapEffective)
 	if len(capEffective) > 0 {
@@ -19,6 +19,7 @@ import (
 	"golang.org/x/sys/unix"
 
 	"github.com/elastic/beats/v7/auditbeat/tracing"
+	"github.com/elastic/beats/v7/x-pack/auditbeat/module/system"
 )
 
 const (
@@ -864,8 +865,9 @@ type execveCall struct {
 
 func (e *execveCall) getProcess() *process {
 	p := &process{
-		pid:     e.Meta.PID,
-		created: kernelTime(e.Meta.Timestamp),
+		pid:         e.Meta.PID,
+		created:     kernelTime(e.Meta.Timestamp),
+		containerID: system.ContainerID(int(e.Meta.PID)),
 	}
 
 	if idx := bytes.IndexByte(e.Path[:], 0); idx >= 0 {
@@ -137,3 +137,79 @@ func (lo *IPv6Loopback) Cleanup() error {
 	}
 	return errors.Join(errs...)
 }
+
+// IPv4Loopback is a helper to make the 127.0.0.0/8 addresses available in the
+// loopback interface of hosts that don't have IPv4 configured on it.
+type IPv4Loopback struct {
+	fd         int
+	deviceName string
+	added      bool
+}
+
+// NewIPv4Loopback detects the loopback interface and creates an IPv4Loopback.
+func NewIPv4Loopback() (lo IPv4Loopback, err error) {
+	lo.fd = -1
+	devs, err := net.Interfaces()
+	if err != nil {
+		return lo, fmt.Errorf("cannot list interfaces: %w", err)
+	}
+	for _, dev := range devs {
+		if dev.Flags&net.FlagLoopback == 0 || len(dev.Name) >= ifnamsiz {
+			continue
+		}
+		lo.deviceName = dev.Name
+		lo.fd, err = unix.Socket(unix.AF_INET, unix.SOCK_DGRAM, unix.IPPROTO_IP)
+		if err != nil {
+			lo.fd = -1
+			return lo, fmt.Errorf("ipv4 socket failed: %w", err)
+		}
+		return lo, nil
+	}
+	return lo, errors.New("no loopback interface detected")
+}
+
+// EnsureAddress adds 127.0.0.1/8 to the loopback interface when 127.0.0.1 is
+// not available to bind, as happens in IPv6-only hosts. It returns whether the
+// address had to be added.
+func (lo *IPv4Loopback) EnsureAddress() (added bool, err error) {
+	addr := unix.SockaddrInet4{Addr: [4]byte{127, 0, 0, 1}}
+	if err = unix.Bind(lo.fd, &addr); err == nil || !errors.Is(err, unix.EADDRNOTAVAIL) {
+		return false, err
+	}
+	if err = lo.setAddress(addr.Addr); err != nil {
+		return false, err
+	}
+	lo.added = true
+	return true, nil
+}
+
+// setAddress sets the primary IPv4 address of the loopback interface. The
+// netmask is derived by the kernel from the address class, so that 127.0.0.1
+// is assigned as 127.0.0.1/8. Setting 0.0.0.0 removes the address.
+func (lo *IPv4Loopback) setAddress(addr [4]byte) error {
+	ifr, err := unix.NewIfreq(lo.deviceName)
+	if err != nil {
+		return err
+	}
+	if err = ifr.SetInet4Addr(addr[:]); err != nil {
+		return err
+	}
+	if err = unix.IoctlIfreq(lo.fd, unix.SIOCSIFADDR, ifr); err != nil {
+		return fmt.Errorf("ioctl SIOCSIFADDR failed: %w", err)
+	}
+	return nil
+}
+
+// Cleanup removes the address added by EnsureAddress, if any.
+func (lo *IPv4Loopback) Cleanup() error {
+	var err error
+	if lo.added {
+		err = lo.setAddress([4]byte{})
+		lo.added = false
+	}
+	if lo.fd != -1 {
+		unix.Close(lo.fd)
+		lo.fd = -1
+	}
+	return err
+}
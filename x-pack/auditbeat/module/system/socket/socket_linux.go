@@ -203,6 +203,7 @@ func (m *MetricSet) Run(r mb.PushReporterV2) {
 					args:        i.Args,
 					createdTime: i.StartTime,
 					path:        i.Exe,
+					containerID: system.ContainerID(i.PID),
 				}
 
 				if user, err := p.User(); err == nil {
@@ -411,6 +412,27 @@ func (m *MetricSet) Setup() (err error) {
 		}
 	}
 
+	//
+	// Guessing relies on IPv4 loopback addresses, which are missing in
+	// IPv6-only hosts. Add them for the duration of the guesses.
+	//
+	loopback, err := helper.NewIPv4Loopback()
+	if err != nil {
+		return fmt.Errorf("unable to setup IPv4 loopback: %w", err)
+	}
+	defer func() {
+		if cerr := loopback.Cleanup(); cerr != nil {
+			m.log.Warnf("Failed to remove IPv4 address from loopback interface: %v", cerr)
+		}
+	}()
+	added, err := loopback.EnsureAddress()
+	if err != nil {
+		return fmt.Errorf("unable to make IPv4 loopback addresses available: %w", err)
+	}
+	if added {
+		m.log.Info("IPv4 is not configured in the loopback interface. Added 127.0.0.1/8 temporarily to guess kernel parameters.")
+	}
+
 	//
 	// Guess all the required parameters
 	//
@@ -212,6 +212,7 @@ type process struct {
 	created              kernelTime
 	uid, gid, euid, egid uint32
 	hasCreds             bool
+	containerID          string
 
 	// populated by state from created
 	createdTime time.Time
@@ -563,6 +564,7 @@ func (s *state) ForkProcess(parentPID, childPID uint32, ts kernelTime) error {
 			euid:        parent.euid,
 			egid:        parent.egid,
 			hasCreds:    parent.hasCreds,
+			containerID: parent.containerID,
 			createdTime: s.kernTimestampToTime(ts),
 		}
 		child.resolvedDomains = make(map[string]string, len(parent.resolvedDomains))
@@ -1022,6 +1024,9 @@ func (f *flow) toEvent(final bool) (ev mb.Event, err error) {
 			if f.process.entityID != "" {
 				process["entity_id"] = f.process.entityID
 			}
+			if f.process.containerID != "" {
+				rootPut("container.id", f.process.containerID)
+			}
 
 			if f.process.hasCreds {
 				uid := strconv.Itoa(int(f.process.uid))
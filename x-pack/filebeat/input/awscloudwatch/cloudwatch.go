@@ -22,6 +22,10 @@ type cloudwatchPoller struct {
 	metrics      *inputMetrics
 	stateHandler *stateHandler
 
+	// resumeFrom overrides the start position when set, so polling
+	// continues where a previous Live Tail session stopped.
+	resumeFrom time.Time
+
 	workersListingMap    *sync.Map
 	workersProcessingMap *sync.Map
 
@@ -110,6 +114,10 @@ func (p *cloudwatchPoller) receive(ctx context.Context, logGroupIDs []string, cl
 		}
 	}
 
+	if !p.resumeFrom.IsZero() {
+		startTime = p.resumeFrom
+	}
+
 	for ctx.Err() == nil {
 		p.stateHandler.WorkRegister(endTime.UnixMilli(), len(logGroupIDs))
 
@@ -34,9 +34,15 @@ type config struct {
 	APISleep                           time.Duration       `config:"api_sleep" validate:"min=0,nonzero"`
 	Latency                            time.Duration       `config:"latency"`
 	NumberOfWorkers                    int                 `config:"number_of_workers"`
+	LiveTail                           liveTailConfig      `config:"live_tail"`
 	AWSConfig                          awscommon.ConfigAWS `config:",inline"`
 }
 
+// liveTailConfig configures streaming log events with CloudWatch Logs Live Tail.
+type liveTailConfig struct {
+	Enabled bool `config:"enabled"`
+}
+
 func defaultConfig() config {
 	return config{
 		ForwarderConfig: harvester.ForwarderConfig{
@@ -6,6 +6,7 @@ package awscloudwatch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -21,6 +22,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/statestore"
 	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
 	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/go-concert/unison"
 )
 
@@ -119,14 +121,25 @@ func (in *cloudwatchInput) Run(inputContext v2.Context, pipeline beat.Pipeline)
 
 	in.metrics = newInputMetrics(inputContext.ID, nil)
 	defer in.metrics.Close()
+	in.metrics.logGroupsTotal.Add(uint64(len(logGroupIDs)))
+
+	var resumeFrom time.Time
+	if in.config.LiveTail.Enabled {
+		var fallback bool
+		resumeFrom, fallback, err = in.runLiveTail(ctx, log, svc, pipeline, region, logGroupIDs)
+		if err != nil || !fallback {
+			return err
+		}
+	}
+
 	cwPoller := newCloudwatchPoller(
 		log.Named("cloudwatch_poller"),
 		in.metrics,
 		region,
 		in.config,
 		handler)
+	cwPoller.resumeFrom = resumeFrom
 
-	cwPoller.metrics.logGroupsTotal.Add(uint64(len(logGroupIDs)))
 	cwPoller.startWorkers(ctx, svc, pipeline)
 
 	log.Debugf("Config latency = %f", cwPoller.config.Latency)
@@ -139,6 +152,29 @@ func (in *cloudwatchInput) Run(inputContext v2.Context, pipeline beat.Pipeline)
 // fromConfig is a helper to parse input configurations and derive logGroupIDs & aws region
 // Returned logGroupIDs could be empty, which require other fallback mechanisms to derive them.
 // See getLogGroupNames for example.
+// runLiveTail follows the log groups with Live Tail sessions. It reports whether
+// the input should fall back to polling, and the time polling should start from.
+func (in *cloudwatchInput) runLiveTail(ctx context.Context, log *logp.Logger, svc *cloudwatchlogs.Client, pipeline beat.Pipeline, region string, logGroupIDs []string) (time.Time, bool, error) {
+	if ok, reason := liveTailSupported(in.config, logGroupIDs); !ok {
+		log.Warnf("live tail cannot be used, falling back to polling: %s", reason)
+		return time.Time{}, true, nil
+	}
+
+	client, err := pipeline.Connect()
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to create pipeline client: %w", err)
+	}
+	defer client.Close()
+
+	tailer := newLiveTailer(log.Named("cloudwatch_live_tail"), in.metrics, region, in.config, newStartLiveTailFunc(svc), client)
+	err = tailer.run(ctx, logGroupIDs)
+	if errors.Is(err, errLiveTailLimitExceeded) {
+		log.Warnf("falling back to polling: %v", err)
+		return tailer.lastEventTime, true, nil
+	}
+	return time.Time{}, false, err
+}
+
 func fromConfig(cfg config, awsCfg awssdk.Config) (logGroupIDs []string, region string, err error) {
 	// LogGroupARN has precedence over LogGroupName & RegionName
 	if cfg.LogGroupARN != "" {
@@ -0,0 +1,199 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// liveTailMaxLogGroups is the maximum number of log groups a single
+// Live Tail session can include.
+const liveTailMaxLogGroups = 10
+
+// errLiveTailLimitExceeded is returned by the live tailer when no further
+// Live Tail session can be started because the account's concurrent session
+// quota is used up. The input falls back to polling when this happens.
+var errLiveTailLimitExceeded = errors.New("live tail session limit exceeded")
+
+// liveTailStream is the subset of cloudwatchlogs.StartLiveTailEventStream used
+// by the live tailer.
+type liveTailStream interface {
+	Events() <-chan types.StartLiveTailResponseStream
+	Close() error
+	Err() error
+}
+
+// startLiveTailFunc starts a new Live Tail session.
+type startLiveTailFunc func(ctx context.Context, input *cloudwatchlogs.StartLiveTailInput) (liveTailStream, error)
+
+func newStartLiveTailFunc(svc *cloudwatchlogs.Client) startLiveTailFunc {
+	return func(ctx context.Context, input *cloudwatchlogs.StartLiveTailInput) (liveTailStream, error) {
+		out, err := svc.StartLiveTail(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		return out.GetStream(), nil
+	}
+}
+
+// liveTailer streams log events with the CloudWatch Logs StartLiveTail API.
+type liveTailer struct {
+	config    config
+	region    string
+	log       *logp.Logger
+	metrics   *inputMetrics
+	start     startLiveTailFunc
+	processor *logProcessor
+
+	// lastEventTime is the timestamp of the most recent event received.
+	lastEventTime time.Time
+}
+
+func newLiveTailer(log *logp.Logger, metrics *inputMetrics, region string, config config, start startLiveTailFunc, client beat.Client) *liveTailer {
+	if metrics == nil {
+		metrics = newInputMetrics("", nil)
+	}
+	return &liveTailer{
+		config:    config,
+		region:    region,
+		log:       log,
+		metrics:   metrics,
+		start:     start,
+		processor: newLogProcessor(log, metrics, client),
+	}
+}
+
+// liveTailSupported reports whether the given log groups can be followed with a
+// single Live Tail session, and the reason if they cannot.
+func liveTailSupported(cfg config, logGroupIDs []string) (bool, string) {
+	if len(logGroupIDs) == 0 {
+		return false, "no log groups found"
+	}
+	if len(logGroupIDs) > liveTailMaxLogGroups {
+		return false, fmt.Sprintf("%d log groups found, a session can include at most %d", len(logGroupIDs), liveTailMaxLogGroups)
+	}
+	for _, id := range logGroupIDs {
+		if !arn.IsARN(id) {
+			return false, fmt.Sprintf("log group %q is not an ARN, use log_group_arn or log_group_name_prefix", id)
+		}
+	}
+	if len(logGroupIDs) > 1 && (len(cfg.LogStreams) > 0 || cfg.LogStreamPrefix != "") {
+		return false, "log_streams and log_stream_prefix can only be used with a single log group"
+	}
+	return true, ""
+}
+
+// run follows the log groups until the context is cancelled, starting a new
+// session whenever the current one ends. It returns errLiveTailLimitExceeded
+// if a session cannot be started because of the session quota.
+func (t *liveTailer) run(ctx context.Context, logGroupIDs []string) error {
+	input := t.constructStartLiveTailInput(logGroupIDs)
+	for ctx.Err() == nil {
+		t.log.Debugf("starting live tail session for log groups %v", logGroupIDs)
+		stream, err := t.start(ctx, input)
+		t.metrics.apiCallsTotal.Inc()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			var errLimitExceeded *types.LimitExceededException
+			if errors.As(err, &errLimitExceeded) {
+				return fmt.Errorf("%w: %w", errLiveTailLimitExceeded, err)
+			}
+			return fmt.Errorf("error starting live tail session: %w", err)
+		}
+		t.metrics.liveTailSessionsTotal.Inc()
+
+		err = t.consume(ctx, stream)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var errTimeout *types.SessionTimeoutException
+		switch {
+		case err == nil, errors.As(err, &errTimeout):
+			// Sessions end after three hours, start a new one right away.
+			t.log.Debug("live tail session ended, starting a new session")
+		default:
+			t.log.Warnf("live tail session failed, starting a new session in %v: %v", t.config.ScanFrequency, err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(t.config.ScanFrequency):
+			}
+		}
+	}
+	return nil
+}
+
+// consume publishes the events received on stream until the session ends or
+// the context is cancelled. It returns the error that ended the session, if any.
+func (t *liveTailer) consume(ctx context.Context, stream liveTailStream) error {
+	defer stream.Close()
+
+	events := stream.Events()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return stream.Err()
+			}
+
+			switch v := e.(type) {
+			case *types.StartLiveTailResponseStreamMemberSessionStart:
+				t.log.Infof("live tail session %v started for log groups %v", awssdk.ToString(v.Value.SessionId), v.Value.LogGroupIdentifiers)
+			case *types.StartLiveTailResponseStreamMemberSessionUpdate:
+				t.processUpdate(v.Value)
+			default:
+				t.log.Debugf("ignoring unknown live tail event %T", e)
+			}
+		}
+	}
+}
+
+func (t *liveTailer) processUpdate(update types.LiveTailSessionUpdate) {
+	if update.SessionMetadata != nil && update.SessionMetadata.Sampled {
+		t.metrics.liveTailSampledUpdatesTotal.Inc()
+		t.log.Warn("live tail session update was sampled, some log events were not delivered")
+	}
+
+	t.metrics.logEventsReceivedTotal.Add(uint64(len(update.SessionResults)))
+	t.processor.processLiveTailEvents(update.SessionResults, t.region)
+	for _, e := range update.SessionResults {
+		if e.Timestamp == nil {
+			continue
+		}
+		if ts := time.UnixMilli(*e.Timestamp); ts.After(t.lastEventTime) {
+			t.lastEventTime = ts
+		}
+	}
+}
+
+func (t *liveTailer) constructStartLiveTailInput(logGroupIDs []string) *cloudwatchlogs.StartLiveTailInput {
+	input := &cloudwatchlogs.StartLiveTailInput{
+		LogGroupIdentifiers: logGroupIDs,
+	}
+
+	for _, stream := range t.config.LogStreams {
+		input.LogStreamNames = append(input.LogStreamNames, *stream)
+	}
+
+	if t.config.LogStreamPrefix != "" {
+		input.LogStreamNamePrefixes = []string{t.config.LogStreamPrefix}
+	}
+	return input
+}
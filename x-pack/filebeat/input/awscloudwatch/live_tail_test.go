@@ -0,0 +1,172 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type fakeLiveTailStream struct {
+	events chan types.StartLiveTailResponseStream
+	err    error
+}
+
+func newFakeLiveTailStream(err error, events ...types.StartLiveTailResponseStream) *fakeLiveTailStream {
+	s := &fakeLiveTailStream{
+		events: make(chan types.StartLiveTailResponseStream, len(events)),
+		err:    err,
+	}
+	for _, e := range events {
+		s.events <- e
+	}
+	close(s.events)
+	return s
+}
+
+func (s *fakeLiveTailStream) Events() <-chan types.StartLiveTailResponseStream { return s.events }
+func (s *fakeLiveTailStream) Close() error                                     { return nil }
+func (s *fakeLiveTailStream) Err() error                                       { return s.err }
+
+func TestLiveTailSupported(t *testing.T) {
+	const groupARN = "arn:aws:logs:us-east-1:123456789012:log-group:test"
+	manyGroups := make([]string, liveTailMaxLogGroups+1)
+	for i := range manyGroups {
+		manyGroups[i] = groupARN
+	}
+
+	tests := []struct {
+		name        string
+		cfg         config
+		logGroupIDs []string
+		supported   bool
+	}{
+		{
+			name:        "single log group ARN",
+			logGroupIDs: []string{groupARN},
+			supported:   true,
+		},
+		{
+			name:        "log group name",
+			logGroupIDs: []string{"test"},
+			supported:   false,
+		},
+		{
+			name:        "too many log groups",
+			logGroupIDs: manyGroups,
+			supported:   false,
+		},
+		{
+			name:        "log stream prefix with single log group",
+			cfg:         config{LogStreamPrefix: "stream"},
+			logGroupIDs: []string{groupARN},
+			supported:   true,
+		},
+		{
+			name:        "log stream prefix with multiple log groups",
+			cfg:         config{LogStreamPrefix: "stream"},
+			logGroupIDs: []string{groupARN, groupARN},
+			supported:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			supported, reason := liveTailSupported(tt.cfg, tt.logGroupIDs)
+			assert.Equal(t, tt.supported, supported)
+			assert.Equal(t, tt.supported, reason == "", reason)
+		})
+	}
+}
+
+func TestLiveTailerRun(t *testing.T) {
+	const groupARN = "arn:aws:logs:us-east-1:123456789012:log-group:test"
+	logEvent := func(msg string, ts int64) types.LiveTailSessionLogEvent {
+		return types.LiveTailSessionLogEvent{
+			IngestionTime:      awssdk.Int64(ts),
+			LogGroupIdentifier: awssdk.String(groupARN),
+			LogStreamName:      awssdk.String("stream"),
+			Message:            awssdk.String(msg),
+			Timestamp:          awssdk.Int64(ts),
+		}
+	}
+
+	// The first session times out, the second fails to start because
+	// the session limit has been reached.
+	sessions := []*fakeLiveTailStream{
+		newFakeLiveTailStream(&types.SessionTimeoutException{},
+			&types.StartLiveTailResponseStreamMemberSessionStart{
+				Value: types.LiveTailSessionStart{SessionId: awssdk.String("session-1")},
+			},
+			&types.StartLiveTailResponseStreamMemberSessionUpdate{
+				Value: types.LiveTailSessionUpdate{
+					SessionResults: []types.LiveTailSessionLogEvent{
+						logEvent("message-1", 1600000000000),
+						logEvent("message-2", 1600000001000),
+					},
+				},
+			},
+		),
+	}
+	var inputs []*cloudwatchlogs.StartLiveTailInput
+	start := func(_ context.Context, input *cloudwatchlogs.StartLiveTailInput) (liveTailStream, error) {
+		inputs = append(inputs, input)
+		if len(sessions) == 0 {
+			return nil, &types.LimitExceededException{}
+		}
+		s := sessions[0]
+		sessions = sessions[1:]
+		return s, nil
+	}
+
+	var published []beat.Event
+	client := &pubtest.FakeClient{
+		PublishFunc: func(e beat.Event) { published = append(published, e) },
+	}
+
+	cfg := defaultConfig()
+	cfg.LogStreamPrefix = "stream"
+	metrics := newInputMetrics("", nil)
+	tailer := newLiveTailer(logp.NewLogger("test"), metrics, "us-east-1", cfg, start, client)
+
+	err := tailer.run(context.Background(), []string{groupARN})
+	require.ErrorIs(t, err, errLiveTailLimitExceeded)
+
+	require.Len(t, inputs, 2)
+	assert.Equal(t, &cloudwatchlogs.StartLiveTailInput{
+		LogGroupIdentifiers:   []string{groupARN},
+		LogStreamNamePrefixes: []string{"stream"},
+	}, inputs[0])
+
+	require.Len(t, published, 2)
+	assert.Equal(t, "message-1", published[0].Fields["message"])
+	assert.Equal(t, "message-2", published[1].Fields["message"])
+	assert.Equal(t, time.UnixMilli(1600000001000), tailer.lastEventTime)
+	assert.Equal(t, uint64(1), metrics.liveTailSessionsTotal.Get())
+	assert.Equal(t, uint64(2), metrics.logEventsReceivedTotal.Get())
+}
+
+func TestLiveTailerRunStartError(t *testing.T) {
+	start := func(context.Context, *cloudwatchlogs.StartLiveTailInput) (liveTailStream, error) {
+		return nil, errors.New("access denied")
+	}
+	tailer := newLiveTailer(logp.NewLogger("test"), nil, "us-east-1", defaultConfig(), start, &pubtest.FakeClient{})
+
+	err := tailer.run(context.Background(), []string{"arn:aws:logs:us-east-1:123456789012:log-group:test"})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, errLiveTailLimitExceeded)
+}
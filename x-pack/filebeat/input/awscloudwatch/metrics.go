@@ -16,6 +16,8 @@ type inputMetrics struct {
 	logGroupsTotal               *monitoring.Uint // Logs collected from number of CloudWatch log groups.
 	cloudwatchEventsCreatedTotal *monitoring.Uint // Number of events created from processing logs from CloudWatch.
 	apiCallsTotal                *monitoring.Uint // Number of API calls made total.
+	liveTailSessionsTotal        *monitoring.Uint // Number of Live Tail sessions started.
+	liveTailSampledUpdatesTotal  *monitoring.Uint // Number of Live Tail session updates that were sampled.
 }
 
 // Close removes the metrics from the registry.
@@ -32,6 +34,8 @@ func newInputMetrics(id string, optionalParent *monitoring.Registry) *inputMetri
 		logGroupsTotal:               monitoring.NewUint(reg, "log_groups_total"),
 		cloudwatchEventsCreatedTotal: monitoring.NewUint(reg, "cloudwatch_events_created_total"),
 		apiCallsTotal:                monitoring.NewUint(reg, "api_calls_total"),
+		liveTailSessionsTotal:        monitoring.NewUint(reg, "live_tail_sessions_total"),
+		liveTailSampledUpdatesTotal:  monitoring.NewUint(reg, "live_tail_sampled_updates_total"),
 	}
 	return out
 }
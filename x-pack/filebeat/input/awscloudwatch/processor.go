@@ -39,6 +39,21 @@ func (p *logProcessor) processLogEvents(logEvents []types.FilteredLogEvent, logG
 	}
 }
 
+// processLiveTailEvents publishes the log events received from a Live Tail session.
+func (p *logProcessor) processLiveTailEvents(logEvents []types.LiveTailSessionLogEvent, regionName string) {
+	for _, logEvent := range logEvents {
+		filtered := types.FilteredLogEvent{
+			IngestionTime: logEvent.IngestionTime,
+			LogStreamName: logEvent.LogStreamName,
+			Message:       logEvent.Message,
+			Timestamp:     logEvent.Timestamp,
+		}
+		event := createEvent(filtered, *logEvent.LogGroupIdentifier, regionName)
+		p.metrics.cloudwatchEventsCreatedTotal.Inc()
+		p.publisher.Publish(event)
+	}
+}
+
 func createEvent(logEvent types.FilteredLogEvent, logGroupId string, regionName string) beat.Event {
 	event := beat.Event{
 		Timestamp: time.UnixMilli(*logEvent.Timestamp).UTC(),
@@ -50,7 +65,6 @@ func createEvent(logEvent types.FilteredLogEvent, logGroupId string, regionName
 				},
 			},
 			"event": mapstr.M{
-				"id":       *logEvent.EventId,
 				"ingested": time.Now(),
 			},
 			"aws.cloudwatch": mapstr.M{
@@ -64,7 +78,11 @@ func createEvent(logEvent types.FilteredLogEvent, logGroupId string, regionName
 			},
 		},
 	}
-	event.SetID(*logEvent.EventId)
+	// Live Tail events don't carry an event ID.
+	if logEvent.EventId != nil {
+		_, _ = event.Fields.Put("event.id", *logEvent.EventId)
+		event.SetID(*logEvent.EventId)
+	}
 
 	return event
 }
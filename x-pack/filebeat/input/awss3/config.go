@@ -29,6 +29,7 @@ type config struct {
 	APITimeout         time.Duration        `config:"api_timeout"`
 	AWSConfig          awscommon.ConfigAWS  `config:",inline"`
 	AccessPointARN     string               `config:"access_point_arn"`
+	Backfill           *backfillConfig      `config:"backfill"` // S3 Inventory backfill to run before reading notifications from SQS.
 	BackupConfig       backupConfig         `config:",inline"`
 	BucketARN          string               `config:"bucket_arn"`
 	BucketListInterval time.Duration        `config:"bucket_list_interval"`
@@ -40,10 +41,14 @@ type config struct {
 	PathStyle          bool                 `config:"path_style"`
 	ProviderOverride   string               `config:"provider"`
 	QueueURL           string               `config:"queue_url"`
-	ReaderConfig       readerConfig         `config:",inline"` // Reader options to apply when no file_selectors are used.
+	QueueURLs          []string             `config:"queue_urls"`       // Multiple queues polled with shared workers.
+	QueueURLPrefix     string               `config:"queue_url_prefix"` // Prefix of the URLs of queues to discover and poll.
+	ReaderConfig       readerConfig         `config:",inline"`          // Reader options to apply when no file_selectors are used.
 	RegionName         string               `config:"region"`
 	SQSMaxReceiveCount int                  `config:"sqs.max_receive_count"` // The max number of times a message should be received (retried) before deleting it.
 	SQSScript          *scriptConfig        `config:"sqs.notification_parsing_script"`
+	SQSQueueWeights    []queueWeightConfig  `config:"sqs.queue_weights"`       // Relative polling weights of queues when polling multiple queues.
+	SQSDiscoveryPeriod time.Duration        `config:"sqs.discovery_interval"`  // The interval between discoveries of queues matching queue_url_prefix.
 	SQSWaitTime        time.Duration        `config:"sqs.wait_time"`           // The max duration for which the SQS ReceiveMessage call waits for a message to arrive in the queue before returning.
 	SQSGraceTime       time.Duration        `config:"sqs.shutdown_grace_time"` // The time that the processing loop will wait for messages before shutting down.
 	StartTimestamp     string               `config:"start_timestamp"`
@@ -59,6 +64,7 @@ func defaultConfig() config {
 		SQSWaitTime:        20 * time.Second,
 		SQSGraceTime:       20 * time.Second,
 		SQSMaxReceiveCount: 5,
+		SQSDiscoveryPeriod: 5 * time.Minute,
 		NumberOfWorkers:    5,
 		PathStyle:          false,
 	}
@@ -67,7 +73,7 @@ func defaultConfig() config {
 }
 
 func (c *config) Validate() error {
-	configs := []bool{c.QueueURL != "", c.BucketARN != "", c.AccessPointARN != "", c.NonAWSBucketName != ""}
+	configs := []bool{c.sqsEnabled(), c.BucketARN != "", c.AccessPointARN != "", c.NonAWSBucketName != ""}
 	enabled := []bool{}
 	for i := range configs {
 		if configs[i] {
@@ -93,21 +99,53 @@ func (c *config) Validate() error {
 		return fmt.Errorf("invalid format for access_point_arn <%v>", c.AccessPointARN)
 	}
 
-	if c.QueueURL != "" && (c.VisibilityTimeout <= 0 || c.VisibilityTimeout.Hours() > 12) {
+	if c.QueueURL != "" && (len(c.QueueURLs) != 0 || c.QueueURLPrefix != "") {
+		return errors.New("queue_url cannot be used with queue_urls or queue_url_prefix")
+	}
+
+	seen := make(map[string]bool)
+	for _, u := range c.QueueURLs {
+		if u == "" {
+			return errors.New("queue_urls must not contain empty URLs")
+		}
+		if seen[u] {
+			return fmt.Errorf("queue_urls contains duplicate URL <%v>", u)
+		}
+		seen[u] = true
+	}
+
+	if c.QueueURLPrefix != "" {
+		if _, _, err := splitQueueURLPrefix(c.QueueURLPrefix); err != nil {
+			return err
+		}
+		if c.SQSDiscoveryPeriod <= 0 {
+			return fmt.Errorf("sqs.discovery_interval <%v> must be greater than 0", c.SQSDiscoveryPeriod)
+		}
+	}
+
+	if len(c.SQSQueueWeights) != 0 && len(c.QueueURLs) == 0 && c.QueueURLPrefix == "" {
+		return errors.New("sqs.queue_weights can only be used with queue_urls or queue_url_prefix")
+	}
+
+	if c.Backfill != nil && !c.sqsEnabled() {
+		return errors.New("backfill can only be used with queue_url, queue_urls or queue_url_prefix")
+	}
+
+	if c.sqsEnabled() && (c.VisibilityTimeout <= 0 || c.VisibilityTimeout.Hours() > 12) {
 		return fmt.Errorf("visibility_timeout <%v> must be greater than 0 and "+
 			"less than or equal to 12h", c.VisibilityTimeout)
 	}
 
-	if c.QueueURL != "" && (c.SQSWaitTime <= 0 || c.SQSWaitTime.Seconds() > 20) {
+	if c.sqsEnabled() && (c.SQSWaitTime <= 0 || c.SQSWaitTime.Seconds() > 20) {
 		return fmt.Errorf("wait_time <%v> must be greater than 0 and "+
 			"less than or equal to 20s", c.SQSWaitTime)
 	}
 
-	if c.QueueURL != "" && c.SQSGraceTime < 0 {
+	if c.sqsEnabled() && c.SQSGraceTime < 0 {
 		return fmt.Errorf("shutdown_grace_time <%v> must not be negative", c.SQSGraceTime)
 	}
 
-	if c.QueueURL != "" && c.APITimeout < c.SQSWaitTime {
+	if c.sqsEnabled() && c.APITimeout < c.SQSWaitTime {
 		return fmt.Errorf("api_timeout <%v> must be greater than the sqs.wait_time <%v",
 			c.APITimeout, c.SQSWaitTime)
 	}
@@ -115,7 +153,7 @@ func (c *config) Validate() error {
 	if c.AWSConfig.FIPSEnabled && c.NonAWSBucketName != "" {
 		return errors.New("fips_enabled cannot be used with a non-AWS S3 bucket")
 	}
-	if c.PathStyle && c.NonAWSBucketName == "" && c.QueueURL == "" {
+	if c.PathStyle && c.NonAWSBucketName == "" && !c.sqsEnabled() {
 		return errors.New("path_style can only be used when polling non-AWS S3 services or SQS/SNS QueueURL")
 	}
 	if c.ProviderOverride != "" && c.NonAWSBucketName == "" {
@@ -137,7 +175,7 @@ func (c *config) Validate() error {
 	if c.BackupConfig.BackupToBucketArn != "" && c.BackupConfig.NonAWSBackupToBucketName != "" {
 		return errors.New("backup_to_bucket_arn and non_aws_backup_to_bucket_name cannot be used together")
 	}
-	if c.BackupConfig.GetBucketName() != "" && c.QueueURL == "" {
+	if c.BackupConfig.GetBucketName() != "" && !c.sqsEnabled() {
 		if (c.BackupConfig.BackupToBucketArn != "" &&
 			(c.BackupConfig.BackupToBucketArn == c.BucketARN || c.BackupConfig.BackupToBucketArn == c.AccessPointARN)) ||
 			(c.BackupConfig.NonAWSBackupToBucketName != "" && c.BackupConfig.NonAWSBackupToBucketName == c.NonAWSBucketName) {
@@ -160,6 +198,61 @@ func (c *config) Validate() error {
 	return nil
 }
 
+// sqsEnabled returns whether the input reads S3 notifications from SQS.
+func (c *config) sqsEnabled() bool {
+	return c.QueueURL != "" || len(c.QueueURLs) != 0 || c.QueueURLPrefix != ""
+}
+
+// queueWeightConfig sets the relative polling weight of a queue.
+type queueWeightConfig struct {
+	QueueURL string `config:"queue_url"`
+	Weight   int    `config:"weight"`
+}
+
+func (c *queueWeightConfig) Validate() error {
+	if c.QueueURL == "" {
+		return errors.New("queue_url is required")
+	}
+	if c.Weight <= 0 {
+		return fmt.Errorf("weight <%v> must be greater than 0", c.Weight)
+	}
+	return nil
+}
+
+// splitQueueURLPrefix returns the URL of the account holding the queues
+// matching the queue URL prefix, and the prefix of their names.
+func splitQueueURLPrefix(prefix string) (account, name string, err error) {
+	u, err := url.Parse(prefix)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse queue_url_prefix: %w", err)
+	}
+	path := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	if u.Host == "" || len(path) != 2 || path[0] == "" || u.RawQuery != "" {
+		return "", "", fmt.Errorf("queue_url_prefix <%v> is not in format: https://sqs.{REGION_ENDPOINT}.{ENDPOINT}/{ACCOUNT_NUMBER}/{QUEUE_NAME_PREFIX}", prefix)
+	}
+	idx := strings.LastIndex(prefix, "/")
+	return prefix[:idx], prefix[idx+1:], nil
+}
+
+// backfillConfig defines the S3 Inventory report listing the objects to
+// process before reading notifications from SQS.
+type backfillConfig struct {
+	InventoryBucketARN string `config:"inventory_bucket_arn"` // Destination bucket of the inventory reports.
+	InventoryPrefix    string `config:"inventory_prefix"`     // Prefix of the inventory reports, the latest of which is used.
+	ManifestKey        string `config:"manifest_key"`         // Key of the manifest of the inventory report to use.
+	KeyPrefix          string `config:"key_prefix"`           // Prefix of the keys of the objects to process.
+}
+
+func (c *backfillConfig) Validate() error {
+	if c.InventoryBucketARN == "" {
+		return errors.New("inventory_bucket_arn is required")
+	}
+	if (c.InventoryPrefix == "") == (c.ManifestKey == "") {
+		return errors.New("exactly one of inventory_prefix or manifest_key must be set")
+	}
+	return nil
+}
+
 type backupConfig struct {
 	BackupToBucketArn        string `config:"backup_to_bucket_arn"`
 	NonAWSBackupToBucketName string `config:"non_aws_backup_to_bucket_name"`
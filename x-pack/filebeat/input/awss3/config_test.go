@@ -40,6 +40,7 @@ func TestConfig(t *testing.T) {
 			SQSMaxReceiveCount: 5,
 			SQSWaitTime:        20 * time.Second,
 			SQSGraceTime:       20 * time.Second,
+			SQSDiscoveryPeriod: 5 * time.Minute,
 			BucketListInterval: 120 * time.Second,
 			BucketListPrefix:   "",
 			PathStyle:          false,
@@ -630,6 +631,128 @@ func TestConfig(t *testing.T) {
 			},
 			expectedErr: "invalid input for start_timestamp: parsing time \"2024-11-20 20:20:00\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \" 20:20:00\" as \"T\" accessing config",
 		},
+		{
+			name: "multiple queues with weights",
+			config: mapstr.M{
+				"queue_urls": []string{
+					"https://sqs.us-east-1.amazonaws.com/111111111111/a",
+					"https://sqs.us-east-1.amazonaws.com/222222222222/b",
+				},
+				"queue_url_prefix": "https://sqs.us-east-1.amazonaws.com/333333333333/notifications-",
+				"sqs.queue_weights": []mapstr.M{
+					{"queue_url": "https://sqs.us-east-1.amazonaws.com/111111111111/a", "weight": 3},
+				},
+			},
+			expectedCfg: func(queueURL, s3Bucket, s3AccessPoint, nonAWSS3Bucket string) config {
+				c := makeConfig("", "", "", "")
+				c.QueueURLs = []string{
+					"https://sqs.us-east-1.amazonaws.com/111111111111/a",
+					"https://sqs.us-east-1.amazonaws.com/222222222222/b",
+				}
+				c.QueueURLPrefix = "https://sqs.us-east-1.amazonaws.com/333333333333/notifications-"
+				c.SQSQueueWeights = []queueWeightConfig{
+					{QueueURL: "https://sqs.us-east-1.amazonaws.com/111111111111/a", Weight: 3},
+				}
+				return c
+			},
+		},
+		{
+			name: "error on queue_url with queue_urls",
+			config: mapstr.M{
+				"queue_url":  queueURL,
+				"queue_urls": []string{"https://sqs.us-east-1.amazonaws.com/111111111111/a"},
+			},
+			expectedErr: "queue_url cannot be used with queue_urls or queue_url_prefix",
+		},
+		{
+			name: "error on queue_urls with bucket_arn",
+			config: mapstr.M{
+				"queue_urls": []string{"https://sqs.us-east-1.amazonaws.com/111111111111/a"},
+				"bucket_arn": s3Bucket,
+			},
+			expectedErr: "cannot be set at the same time",
+		},
+		{
+			name: "error on duplicate queue_urls",
+			config: mapstr.M{
+				"queue_urls": []string{
+					"https://sqs.us-east-1.amazonaws.com/111111111111/a",
+					"https://sqs.us-east-1.amazonaws.com/111111111111/a",
+				},
+			},
+			expectedErr: "queue_urls contains duplicate URL <https://sqs.us-east-1.amazonaws.com/111111111111/a>",
+		},
+		{
+			name: "error on queue_url_prefix without account",
+			config: mapstr.M{
+				"queue_url_prefix": "https://sqs.us-east-1.amazonaws.com/notifications-",
+			},
+			expectedErr: "queue_url_prefix <https://sqs.us-east-1.amazonaws.com/notifications-> is not in format",
+		},
+		{
+			name: "error on invalid queue weight",
+			config: mapstr.M{
+				"queue_urls": []string{"https://sqs.us-east-1.amazonaws.com/111111111111/a"},
+				"sqs.queue_weights": []mapstr.M{
+					{"queue_url": "https://sqs.us-east-1.amazonaws.com/111111111111/a", "weight": 0},
+				},
+			},
+			expectedErr: "weight <0> must be greater than 0",
+		},
+		{
+			name: "error on queue weights with queue_url",
+			config: mapstr.M{
+				"queue_url": queueURL,
+				"sqs.queue_weights": []mapstr.M{
+					{"queue_url": queueURL, "weight": 2},
+				},
+			},
+			expectedErr: "sqs.queue_weights can only be used with queue_urls or queue_url_prefix",
+		},
+		{
+			name:     "backfill from inventory",
+			queueURL: queueURL,
+			config: mapstr.M{
+				"queue_url": queueURL,
+				"backfill": mapstr.M{
+					"inventory_bucket_arn": "arn:aws:s3:::inventory",
+					"inventory_prefix":     "source/daily",
+					"key_prefix":           "logs/",
+				},
+			},
+			expectedCfg: func(queueURL, s3Bucket, s3AccessPoint, nonAWSS3Bucket string) config {
+				c := makeConfig(queueURL, "", "", "")
+				c.Backfill = &backfillConfig{
+					InventoryBucketARN: "arn:aws:s3:::inventory",
+					InventoryPrefix:    "source/daily",
+					KeyPrefix:          "logs/",
+				}
+				return c
+			},
+		},
+		{
+			name: "error on backfill with bucket_arn",
+			config: mapstr.M{
+				"bucket_arn": s3Bucket,
+				"backfill": mapstr.M{
+					"inventory_bucket_arn": "arn:aws:s3:::inventory",
+					"inventory_prefix":     "source/daily",
+				},
+			},
+			expectedErr: "backfill can only be used with queue_url, queue_urls or queue_url_prefix",
+		},
+		{
+			name: "error on backfill with inventory_prefix and manifest_key",
+			config: mapstr.M{
+				"queue_url": queueURL,
+				"backfill": mapstr.M{
+					"inventory_bucket_arn": "arn:aws:s3:::inventory",
+					"inventory_prefix":     "source/daily",
+					"manifest_key":         "source/daily/2024-01-01T01-00Z/manifest.json",
+				},
+			},
+			expectedErr: "exactly one of inventory_prefix or manifest_key must be set",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -52,8 +52,11 @@ func (im *s3InputManager) Create(cfg *conf.C) (v2.Input, error) {
 		awsConfig.Region = config.RegionName
 	}
 
-	if config.QueueURL != "" {
-		return newSQSReaderInput(config, awsConfig), nil
+	if config.sqsEnabled() {
+		in := newSQSReaderInput(config, awsConfig)
+		// The registry is only used to track the progress of a backfill.
+		in.store = im.store
+		return in, nil
 	}
 
 	if config.BucketARN != "" || config.AccessPointARN != "" || config.NonAWSBucketName != "" {
@@ -27,7 +27,7 @@ import (
 )
 
 // Run 'go generate' to create mocks that are used in tests.
-//go:generate go run go.uber.org/mock/mockgen -source=interfaces.go -destination=mock_interfaces_test.go -package awss3 -mock_names=sqsAPI=MockSQSAPI,sqsProcessor=MockSQSProcessor,s3API=MockS3API,s3Pager=MockS3Pager,s3ObjectHandlerFactory=MockS3ObjectHandlerFactory,s3ObjectHandler=MockS3ObjectHandler
+//go:generate go run go.uber.org/mock/mockgen -source=interfaces.go -destination=mock_interfaces_test.go -package awss3 -mock_names=sqsAPI=MockSQSAPI,sqsLister=MockSQSLister,sqsProcessor=MockSQSProcessor,s3API=MockS3API,s3Pager=MockS3Pager,s3ObjectHandlerFactory=MockS3ObjectHandlerFactory,s3ObjectHandler=MockS3ObjectHandler
 //go:generate go run go.uber.org/mock/mockgen -destination=mock_publisher_test.go -package=awss3 -mock_names=Client=MockBeatClient,Pipeline=MockBeatPipeline github.com/elastic/beats/v7/libbeat/beat Client,Pipeline
 //go:generate go run github.com/elastic/go-licenser -license Elastic .
 //go:generate go run golang.org/x/tools/cmd/goimports -w -local github.com/elastic .
@@ -45,6 +45,12 @@ type sqsAPI interface {
 	GetQueueAttributes(ctx context.Context, attr []types.QueueAttributeName) (map[string]string, error)
 }
 
+type sqsLister interface {
+	// ListQueues returns the URLs of the queues whose names start with
+	// the given prefix.
+	ListQueues(ctx context.Context, namePrefix string) ([]string, error)
+}
+
 type sqsProcessor interface {
 	// ProcessSQS processes and SQS message. It takes fully ownership of the
 	// given message and is responsible for updating the message's visibility
@@ -180,6 +186,32 @@ func (a *awsSQSAPI) ChangeMessageVisibility(ctx context.Context, msg *types.Mess
 	return nil
 }
 
+type awsSQSLister struct {
+	client     *sqs.Client
+	apiTimeout time.Duration
+}
+
+func (a *awsSQSLister) ListQueues(ctx context.Context, namePrefix string) ([]string, error) {
+	var urls []string
+	pager := sqs.NewListQueuesPaginator(a.client, &sqs.ListQueuesInput{
+		QueueNamePrefix: awssdk.String(namePrefix),
+		MaxResults:      awssdk.Int32(1000),
+	})
+	for pager.HasMorePages() {
+		pageCtx, cancel := context.WithTimeout(ctx, a.apiTimeout)
+		page, err := pager.NextPage(pageCtx)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = fmt.Errorf("api_timeout exceeded: %w", err)
+			}
+			return nil, fmt.Errorf("sqs ListQueues failed: %w", err)
+		}
+		urls = append(urls, page.QueueUrls...)
+	}
+	return urls, nil
+}
+
 func (a *awsSQSAPI) GetQueueAttributes(ctx context.Context, attr []types.QueueAttributeName) (map[string]string, error) {
 	ctx, cancel := context.WithTimeout(ctx, a.apiTimeout)
 	defer cancel()
@@ -7,7 +7,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=interfaces.go -destination=mock_interfaces_test.go -package awss3 -mock_names=sqsAPI=MockSQSAPI,sqsProcessor=MockSQSProcessor,s3API=MockS3API,s3Pager=MockS3Pager,s3ObjectHandlerFactory=MockS3ObjectHandlerFactory,s3ObjectHandler=MockS3ObjectHandler
+//	mockgen -source=interfaces.go -destination=mock_interfaces_test.go -package awss3 -mock_names=sqsAPI=MockSQSAPI,sqsLister=MockSQSLister,sqsProcessor=MockSQSProcessor,s3API=MockS3API,s3Pager=MockS3Pager,s3ObjectHandlerFactory=MockS3ObjectHandlerFactory,s3ObjectHandler=MockS3ObjectHandler
 //
 
 // Package awss3 is a generated GoMock package.
@@ -108,6 +108,45 @@ func (mr *MockSQSAPIMockRecorder) ReceiveMessage(ctx, maxMessages any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveMessage", reflect.TypeOf((*MockSQSAPI)(nil).ReceiveMessage), ctx, maxMessages)
 }
 
+// MockSQSLister is a mock of sqsLister interface.
+type MockSQSLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockSQSListerMockRecorder
+	isgomock struct{}
+}
+
+// MockSQSListerMockRecorder is the mock recorder for MockSQSLister.
+type MockSQSListerMockRecorder struct {
+	mock *MockSQSLister
+}
+
+// NewMockSQSLister creates a new mock instance.
+func NewMockSQSLister(ctrl *gomock.Controller) *MockSQSLister {
+	mock := &MockSQSLister{ctrl: ctrl}
+	mock.recorder = &MockSQSListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSQSLister) EXPECT() *MockSQSListerMockRecorder {
+	return m.recorder
+}
+
+// ListQueues mocks base method.
+func (m *MockSQSLister) ListQueues(ctx context.Context, namePrefix string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueues", ctx, namePrefix)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueues indicates an expected call of ListQueues.
+func (mr *MockSQSListerMockRecorder) ListQueues(ctx, namePrefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueues", reflect.TypeOf((*MockSQSLister)(nil).ListQueues), ctx, namePrefix)
+}
+
 // MockSQSProcessor is a mock of sqsProcessor interface.
 type MockSQSProcessor struct {
 	ctrl     *gomock.Controller
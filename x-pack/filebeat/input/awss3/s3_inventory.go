@@ -0,0 +1,455 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/backoff"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	awsS3BackfillStatePrefix = "filebeat::aws-s3::backfill::"
+
+	// backfillDownloadAttempts is the number of attempts made to download
+	// an object listed in an inventory report. Objects may have been
+	// deleted since the report was generated.
+	backfillDownloadAttempts = 3
+)
+
+// inventoryManifestKey matches the keys of S3 Inventory report manifests
+// relative to the prefix of the inventory configuration.
+var inventoryManifestKey = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}-\d{2}Z/manifest\.json$`)
+
+// inventoryManifest is the manifest of an S3 Inventory report.
+// See https://docs.aws.amazon.com/AmazonS3/latest/userguide/storage-inventory-location.html.
+type inventoryManifest struct {
+	SourceBucket string `json:"sourceBucket"`
+	FileFormat   string `json:"fileFormat"`
+	FileSchema   string `json:"fileSchema"`
+	Files        []struct {
+		Key string `json:"key"`
+	} `json:"files"`
+}
+
+// backfillState is the persisted progress of a backfill.
+type backfillState struct {
+	// Manifest is the key of the manifest of the inventory report.
+	Manifest string `json:"manifest" struct:"manifest"`
+	// Completed are the keys of the data files of the report whose
+	// objects have all been processed and acknowledged.
+	Completed []string `json:"completed" struct:"completed"`
+	// Done is true when all the objects of the report have been
+	// processed and acknowledged.
+	Done bool `json:"done" struct:"done"`
+}
+
+// inventoryBackfill processes the objects listed in an S3 Inventory
+// report.
+type inventoryBackfill struct {
+	config   config
+	log      *logp.Logger
+	s3       s3API
+	region   string
+	metrics  *inputMetrics
+	handlers s3ObjectHandlerFactory
+	pipeline beat.Pipeline
+	store    *statestore.Store
+
+	// objects tracks the objects sent to workers that are not yet
+	// acknowledged.
+	objects sync.WaitGroup
+
+	mu    sync.Mutex
+	state backfillState
+	files map[string]*inventoryFile
+}
+
+// inventoryFile tracks the progress of a data file of an inventory
+// report.
+type inventoryFile struct {
+	pending int  // Number of objects not yet acknowledged.
+	listed  bool // All the objects of the file have been sent to workers.
+}
+
+// inventoryObject is an object listed in a data file of an inventory
+// report.
+type inventoryObject struct {
+	file  string
+	state state
+}
+
+// runBackfill processes the objects listed in the configured S3 Inventory
+// report, unless this has already been completed. Failures are logged so
+// that reading notifications from SQS is not held up.
+func (in *sqsReaderInput) runBackfill(ctx context.Context) {
+	store, err := in.store.StoreFor("")
+	if err != nil {
+		in.log.Errorw("S3 Inventory backfill failed: can't access persistent store.", "error", err)
+		return
+	}
+	defer store.Close()
+
+	b := &inventoryBackfill{
+		config:   in.config,
+		log:      in.log.Named("backfill"),
+		s3:       in.s3,
+		region:   in.awsConfig.Region,
+		metrics:  in.metrics,
+		handlers: newS3ObjectProcessorFactory(in.metrics, in.s3, in.config.getFileSelectors(), in.config.BackupConfig),
+		pipeline: in.pipeline,
+		store:    store,
+	}
+	if err := b.run(ctx); err != nil && ctx.Err() == nil {
+		in.log.Errorw("S3 Inventory backfill failed.", "error", err)
+	}
+}
+
+// stateKey returns the registry key of the backfill progress.
+func (b *inventoryBackfill) stateKey() string {
+	cfg := b.config.Backfill
+	return awsS3BackfillStatePrefix + getBucketNameFromARN(cfg.InventoryBucketARN) + "/" + cfg.InventoryPrefix + cfg.ManifestKey
+}
+
+func (b *inventoryBackfill) run(ctx context.Context) error {
+	cfg := b.config.Backfill
+	bucket := getBucketNameFromARN(cfg.InventoryBucketARN)
+
+	key := b.stateKey()
+	if ok, err := b.store.Has(key); err != nil {
+		return fmt.Errorf("failed to read backfill state: %w", err)
+	} else if ok {
+		if err := b.store.Get(key, &b.state); err != nil {
+			return fmt.Errorf("failed to read backfill state: %w", err)
+		}
+	}
+	if b.state.Done {
+		b.log.Infow("S3 Inventory backfill already completed.", "manifest", b.state.Manifest)
+		return nil
+	}
+
+	manifestKey := cfg.ManifestKey
+	switch {
+	case manifestKey != "":
+	case b.state.Manifest != "":
+		// Resume the interrupted backfill rather than starting over
+		// with a more recent report.
+		manifestKey = b.state.Manifest
+	default:
+		var err error
+		manifestKey, err = b.latestManifest(ctx, bucket, cfg.InventoryPrefix)
+		if err != nil {
+			return err
+		}
+	}
+	if b.state.Manifest != manifestKey {
+		b.state = backfillState{Manifest: manifestKey}
+	}
+
+	manifest, err := b.manifest(ctx, bucket, manifestKey)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(manifest.FileFormat, "CSV") {
+		return fmt.Errorf("unsupported inventory report format %q: only CSV is supported", manifest.FileFormat)
+	}
+	columns, err := inventoryColumns(manifest.FileSchema)
+	if err != nil {
+		return err
+	}
+	b.log.Infow("Starting S3 Inventory backfill.", "manifest", manifestKey, "source_bucket", manifest.SourceBucket, "files", len(manifest.Files), "completed_files", len(b.state.Completed))
+
+	completed := make(map[string]bool)
+	for _, f := range b.state.Completed {
+		completed[f] = true
+	}
+	b.files = make(map[string]*inventoryFile)
+
+	var workerWg sync.WaitGroup
+	workChan := make(chan inventoryObject)
+	for i := 0; i < b.config.NumberOfWorkers; i++ {
+		workerWg.Add(1)
+		go func() {
+			defer workerWg.Done()
+			b.workerLoop(ctx, workChan)
+		}()
+	}
+	for _, f := range manifest.Files {
+		if completed[f.Key] {
+			continue
+		}
+		err = b.readFile(ctx, bucket, f.Key, columns, workChan)
+		if err != nil {
+			break
+		}
+	}
+	close(workChan)
+	workerWg.Wait()
+	if err != nil {
+		return err
+	}
+
+	// Wait for the remaining objects to be acknowledged.
+	acked := make(chan struct{})
+	go func() {
+		b.objects.Wait()
+		close(acked)
+	}()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-acked:
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state.Done = true
+	if err := b.store.Set(key, b.state); err != nil {
+		return fmt.Errorf("failed to save backfill state: %w", err)
+	}
+	b.log.Infow("Completed S3 Inventory backfill.", "manifest", manifestKey)
+	return nil
+}
+
+// latestManifest returns the key of the manifest of the most recent
+// inventory report under prefix.
+func (b *inventoryBackfill) latestManifest(ctx context.Context, bucket, prefix string) (string, error) {
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	var latest string
+	paginator := b.s3.ListObjectsPaginator(bucket, prefix)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to list inventory reports: %w", err)
+		}
+		for _, obj := range page.Contents {
+			key := *obj.Key
+			// Report timestamps sort lexically.
+			if inventoryManifestKey.MatchString(strings.TrimPrefix(key, prefix)) && key > latest {
+				latest = key
+			}
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no inventory report manifest found in bucket %q under prefix %q", bucket, prefix)
+	}
+	return latest, nil
+}
+
+// manifest returns the inventory report manifest with the given key.
+func (b *inventoryBackfill) manifest(ctx context.Context, bucket, key string) (*inventoryManifest, error) {
+	obj, err := b.s3.GetObject(ctx, b.region, bucket, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory manifest %q: %w", key, err)
+	}
+	defer obj.Body.Close()
+	var m inventoryManifest
+	if err := json.NewDecoder(obj.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to decode inventory manifest %q: %w", key, err)
+	}
+	return &m, nil
+}
+
+// inventoryColumns returns the indexes of the columns of inventory
+// report data files described by the given CSV schema.
+func inventoryColumns(schema string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, name := range strings.Split(schema, ",") {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"Bucket", "Key"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("inventory report schema %q has no %s column", schema, required)
+		}
+	}
+	return columns, nil
+}
+
+// readFile sends the objects listed in the inventory report data file
+// with the given key to workChan.
+func (b *inventoryBackfill) readFile(ctx context.Context, bucket, key string, columns map[string]int, workChan chan<- inventoryObject) error {
+	obj, err := b.s3.GetObject(ctx, b.region, bucket, key)
+	if err != nil {
+		return fmt.Errorf("failed to get inventory file %q: %w", key, err)
+	}
+	defer obj.Body.Close()
+	var r io.Reader = obj.Body
+	if strings.HasSuffix(key, ".gz") {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return fmt.Errorf("failed to read inventory file %q: %w", key, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	file := &inventoryFile{}
+	b.mu.Lock()
+	b.files[key] = file
+	b.mu.Unlock()
+
+	isStateValid := newFilterProvider(&b.config).getApplierFunc()
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(columns)
+	cr.ReuseRecord = true
+	field := func(rec []string, name string) string {
+		if i, ok := columns[name]; ok {
+			return rec[i]
+		}
+		return ""
+	}
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF { //nolint:errorlint // This will never be a wrapped error.
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read inventory file %q: %w", key, err)
+		}
+		if field(rec, "IsLatest") == "false" || field(rec, "IsDeleteMarker") == "true" {
+			continue
+		}
+		objKey, err := url.QueryUnescape(field(rec, "Key"))
+		if err != nil {
+			b.log.Warnw("Skipping object with invalid key in inventory file.", "file", key, "key", field(rec, "Key"), "error", err)
+			continue
+		}
+		if !strings.HasPrefix(objKey, b.config.Backfill.KeyPrefix) {
+			continue
+		}
+		var lastModified time.Time
+		if s := field(rec, "LastModifiedDate"); s != "" {
+			lastModified, err = time.Parse(time.RFC3339, s)
+			if err != nil {
+				b.log.Warnw("Invalid last modified date in inventory file.", "file", key, "key", objKey, "error", err)
+			}
+		}
+		st := newState(field(rec, "Bucket"), objKey, field(rec, "ETag"), lastModified)
+		b.metrics.s3ObjectsListedTotal.Inc()
+		if !isStateValid(b.log, st) {
+			continue
+		}
+
+		b.mu.Lock()
+		file.pending++
+		b.mu.Unlock()
+		b.objects.Add(1)
+		select {
+		case <-ctx.Done():
+			b.objects.Done()
+			return ctx.Err()
+		case workChan <- inventoryObject{file: key, state: st}:
+			b.metrics.s3ObjectsProcessedTotal.Inc()
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	file.listed = true
+	if file.pending == 0 {
+		return b.completeFile(key)
+	}
+	return nil
+}
+
+// objectDone records that an object listed in the given data file has
+// been processed and acknowledged.
+func (b *inventoryBackfill) objectDone(key string) {
+	defer b.objects.Done()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	file := b.files[key]
+	file.pending--
+	if file.pending == 0 && file.listed {
+		if err := b.completeFile(key); err != nil {
+			b.log.Errorw("Failed to save backfill state.", "error", err)
+		}
+	}
+}
+
+// completeFile persists the completion of the data file with the given
+// key. b.mu must be held.
+func (b *inventoryBackfill) completeFile(key string) error {
+	delete(b.files, key)
+	b.state.Completed = append(b.state.Completed, key)
+	return b.store.Set(b.stateKey(), b.state)
+}
+
+func (b *inventoryBackfill) workerLoop(ctx context.Context, workChan <-chan inventoryObject) {
+	acks := newAWSACKHandler()
+	// Create client for publishing events and receive notification of their ACKs.
+	client, err := createPipelineClient(b.pipeline, acks)
+	if err != nil {
+		b.log.Errorf("failed to create pipeline client: %v", err.Error())
+		// Leave the objects unacknowledged so that the backfill is
+		// not recorded as complete.
+		for range workChan {
+		}
+		return
+	}
+	defer client.Close()
+	defer acks.Close()
+
+	rateLimitWaiter := backoff.NewEqualJitterBackoff(ctx.Done(), 1, 120)
+
+	for obj := range workChan {
+		publishCount := 0
+		event := b.s3EventForState(obj.state)
+		if objHandler := b.handlers.Create(ctx, event); objHandler != nil {
+			for attempt := 1; ; attempt++ {
+				err = objHandler.ProcessS3Object(b.log, func(e beat.Event) {
+					b.metrics.s3EventsCreatedTotal.Inc()
+					client.Publish(e)
+					publishCount++
+				})
+				if !errors.Is(err, errS3DownloadFailed) || attempt == backfillDownloadAttempts || publishCount != 0 || ctx.Err() != nil {
+					break
+				}
+				rateLimitWaiter.Wait()
+				objHandler = b.handlers.Create(ctx, event)
+			}
+			rateLimitWaiter.Reset()
+			b.metrics.s3EventsPerObject.Update(int64(publishCount))
+			if err != nil {
+				b.log.Errorf("failed processing S3 event for object key %q in bucket %q: %v",
+					obj.state.Key, obj.state.Bucket, err.Error())
+			}
+		} else {
+			b.log.Debugw("empty s3 processor (no matching reader configs).", "state", obj.state)
+		}
+
+		file := obj.file
+		acks.Add(publishCount, func() {
+			b.objectDone(file)
+			b.metrics.s3ObjectsAckedTotal.Inc()
+		})
+	}
+}
+
+func (b *inventoryBackfill) s3EventForState(state state) s3EventV2 {
+	event := s3EventV2{}
+	event.AWSRegion = b.region
+	event.S3.Bucket.Name = state.Bucket
+	event.S3.Bucket.ARN = "arn:aws:s3:::" + state.Bucket
+	event.S3.Object.Key = state.Key
+	event.S3.Object.LastModified = state.LastModified
+	return event
+}
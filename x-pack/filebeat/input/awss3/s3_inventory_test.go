@@ -0,0 +1,229 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const testInventoryManifest = `{
+  "sourceBucket": "source",
+  "destinationBucket": "arn:aws:s3:::inventory",
+  "version": "2016-11-30",
+  "fileFormat": "CSV",
+  "fileSchema": "Bucket, Key, VersionId, IsLatest, IsDeleteMarker, Size, LastModifiedDate, ETag",
+  "files": [
+    {"key": "inventory/source/daily/data/one.csv.gz", "size": 1, "MD5checksum": "x"},
+    {"key": "inventory/source/daily/data/two.csv.gz", "size": 1, "MD5checksum": "x"}
+  ]
+}`
+
+func TestInventoryBackfill(t *testing.T) {
+	err := logp.TestingSetup()
+	require.NoError(t, err)
+
+	newBucket := func() *fakeS3Bucket {
+		return &fakeS3Bucket{objects: map[string][]byte{
+			"inventory/inventory/source/daily/2024-01-01T01-00Z/manifest.json":     []byte(`{"fileFormat": "ORC"}`),
+			"inventory/inventory/source/daily/2024-01-02T01-00Z/manifest.json":     []byte(testInventoryManifest),
+			"inventory/inventory/source/daily/2024-01-02T01-00Z/manifest.checksum": nil,
+			"inventory/inventory/source/daily/data/one.csv.gz": gzipped(t,
+				`"source","logs/one.log","v1","true","false","5","2024-01-01T00:00:00.000Z","etag1"`,
+				`"source","logs/old.log","v0","false","false","5","2024-01-01T00:00:00.000Z","etag0"`,
+				`"source","logs/deleted.log","v2","true","true","5","2024-01-01T00:00:00.000Z","etag2"`,
+				`"source","logs/a%20b.log","v3","true","false","5","2024-01-01T00:00:00.000Z","etag3"`,
+			),
+			"inventory/inventory/source/daily/data/two.csv.gz": gzipped(t,
+				`"source","logs/two.log","v4","true","false","5","2024-01-01T00:00:00.000Z","etag4"`,
+				`"source","other/three.log","v5","true","false","5","2024-01-01T00:00:00.000Z","etag5"`,
+			),
+			"source/logs/one.log":    []byte("one\n"),
+			"source/logs/a b.log":    []byte("a\nb\n"),
+			"source/logs/two.log":    []byte("two\n"),
+			"source/other/three.log": []byte("three\n"),
+		}}
+	}
+	cfg := config{
+		NumberOfWorkers: 2,
+		Backfill: &backfillConfig{
+			InventoryBucketARN: "arn:aws:s3:::inventory",
+			InventoryPrefix:    "inventory/source/daily",
+			KeyPrefix:          "logs/",
+		},
+	}
+	run := func(t *testing.T, bucket *fakeS3Bucket, store statestore.States) *inventoryBackfill {
+		t.Helper()
+		ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+		defer cancel()
+		s, err := store.StoreFor("")
+		require.NoError(t, err)
+		defer s.Close()
+		metrics := newInputMetrics("", nil, 0)
+		t.Cleanup(metrics.Close)
+		b := &inventoryBackfill{
+			config:   cfg,
+			log:      logp.NewLogger(inputName),
+			s3:       bucket,
+			metrics:  metrics,
+			handlers: newS3ObjectProcessorFactory(metrics, bucket, nil, backupConfig{}),
+			pipeline: newFakePipeline(),
+			store:    s,
+		}
+		require.NoError(t, b.run(ctx))
+		return b
+	}
+
+	t.Run("process latest report", func(t *testing.T) {
+		bucket := newBucket()
+		store := openTestStatestore()
+		b := run(t, bucket, store)
+
+		assert.Equal(t, []string{"logs/a b.log", "logs/one.log", "logs/two.log"}, bucket.sourceGets())
+		assert.EqualValues(t, 4, b.metrics.s3EventsCreatedTotal.Get())
+		assert.EqualValues(t, 3, b.metrics.s3ObjectsAckedTotal.Get())
+		assert.Equal(t, "inventory/source/daily/2024-01-02T01-00Z/manifest.json", b.state.Manifest)
+		assert.ElementsMatch(t, []string{"inventory/source/daily/data/one.csv.gz", "inventory/source/daily/data/two.csv.gz"}, b.state.Completed)
+		assert.True(t, b.state.Done)
+
+		// A completed backfill is not repeated.
+		bucket = newBucket()
+		run(t, bucket, store)
+		assert.Empty(t, bucket.gets)
+	})
+
+	t.Run("resume interrupted backfill", func(t *testing.T) {
+		store := openTestStatestore()
+		s, err := store.StoreFor("")
+		require.NoError(t, err)
+		err = s.Set(awsS3BackfillStatePrefix+"inventory/inventory/source/daily", backfillState{
+			Manifest:  "inventory/source/daily/2024-01-02T01-00Z/manifest.json",
+			Completed: []string{"inventory/source/daily/data/one.csv.gz"},
+		})
+		require.NoError(t, err)
+		s.Close()
+
+		bucket := newBucket()
+		b := run(t, bucket, store)
+		assert.Equal(t, []string{"logs/two.log"}, bucket.sourceGets())
+		assert.True(t, b.state.Done)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		store := openTestStatestore()
+		s, err := store.StoreFor("")
+		require.NoError(t, err)
+		defer s.Close()
+		metrics := newInputMetrics("", nil, 0)
+		defer metrics.Close()
+		bucket := newBucket()
+		cfg := cfg
+		cfg.Backfill = &backfillConfig{
+			InventoryBucketARN: "arn:aws:s3:::inventory",
+			ManifestKey:        "inventory/source/daily/2024-01-01T01-00Z/manifest.json",
+		}
+		b := &inventoryBackfill{
+			config:  cfg,
+			log:     logp.NewLogger(inputName),
+			s3:      bucket,
+			metrics: metrics,
+			store:   s,
+		}
+		err = b.run(context.Background())
+		assert.ErrorContains(t, err, `unsupported inventory report format "ORC"`)
+	})
+}
+
+func gzipped(t *testing.T, lines ...string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// fakeS3Bucket is an s3API holding objects keyed by bucket and key. All
+// objects are listed in a single page.
+type fakeS3Bucket struct {
+	objects map[string][]byte
+
+	mu   sync.Mutex
+	gets []string
+}
+
+var _ s3API = (*fakeS3Bucket)(nil)
+
+func (b *fakeS3Bucket) GetObject(_ context.Context, _, bucket, key string) (*s3.GetObjectOutput, error) {
+	b.mu.Lock()
+	b.gets = append(b.gets, bucket+"/"+key)
+	b.mu.Unlock()
+	data, ok := b.objects[bucket+"/"+key]
+	if !ok {
+		return nil, errors.New("no such key")
+	}
+	return newS3GetObjectResponse(key, data, "text/plain"), nil
+}
+
+// sourceGets returns the sorted keys of the objects read from the source
+// bucket.
+func (b *fakeS3Bucket) sourceGets() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var keys []string
+	for _, k := range b.gets {
+		if key, ok := strings.CutPrefix(k, "source/"); ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (*fakeS3Bucket) CopyObject(context.Context, string, string, string, string, string) (*s3.CopyObjectOutput, error) {
+	return nil, nil
+}
+
+func (*fakeS3Bucket) DeleteObject(context.Context, string, string, string) (*s3.DeleteObjectOutput, error) {
+	return nil, nil
+}
+
+func (b *fakeS3Bucket) ListObjectsPaginator(bucket, prefix string) s3Pager {
+	page := &s3.ListObjectsV2Output{}
+	for k := range b.objects {
+		if key, ok := strings.CutPrefix(k, bucket+"/"); ok && strings.HasPrefix(key, prefix) {
+			page.Contents = append(page.Contents, types.Object{Key: aws.String(key)})
+		}
+	}
+	return &fakeS3Pager{pages: []*s3.ListObjectsV2Output{page}}
+}
+
+type fakeS3Pager struct {
+	pages []*s3.ListObjectsV2Output
+}
+
+func (p *fakeS3Pager) HasMorePages() bool { return len(p.pages) != 0 }
+
+func (p *fakeS3Pager) NextPage(context.Context, ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	page := p.pages[0]
+	p.pages = p.pages[1:]
+	return page, nil
+}
@@ -18,6 +18,7 @@ import (
 
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/statestore"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -37,10 +38,17 @@ type sqsReaderInput struct {
 	// The expected region based on the queue URL
 	detectedRegion string
 
+	// store is the registry holding the progress of the backfill.
+	store statestore.States
+
+	// queues are the queues polled when reading from more than a single
+	// queue_url, in which case sqs and msgHandler are not used.
+	queues *sqsQueues
+
 	// Workers send on workRequestChan to indicate they're ready for the next
 	// message, and the reader loop replies on workResponseChan.
 	workRequestChan  chan struct{}
-	workResponseChan chan types.Message
+	workResponseChan chan sqsWork
 
 	// workerWg is used to wait on worker goroutines during shutdown
 	workerWg sync.WaitGroup
@@ -52,10 +60,17 @@ func newSQSReaderInput(config config, awsConfig awssdk.Config) *sqsReaderInput {
 		config:           config,
 		awsConfig:        awsConfig,
 		workRequestChan:  make(chan struct{}, config.NumberOfWorkers),
-		workResponseChan: make(chan types.Message),
+		workResponseChan: make(chan sqsWork),
 	}
 }
 
+// sqsWork is a message to be processed by a worker, along with the
+// processor of the queue it was received from.
+type sqsWork struct {
+	msg       types.Message
+	processor sqsProcessor
+}
+
 func (in *sqsReaderInput) Name() string { return inputName }
 
 func (in *sqsReaderInput) Test(ctx v2.TestContext) error {
@@ -74,6 +89,11 @@ func (in *sqsReaderInput) Run(
 
 	// Start the main run loop
 	ctx := v2.GoContextFromCanceler(inputContext.Cancelation)
+	if in.config.Backfill != nil {
+		// Notifications for objects created during the backfill are
+		// retained in SQS until it completes.
+		in.runBackfill(ctx)
+	}
 	in.run(ctx)
 	in.cleanup()
 
@@ -87,6 +107,10 @@ func (in *sqsReaderInput) setup(
 	inputContext v2.Context,
 	pipeline beat.Pipeline,
 ) error {
+	if in.config.QueueURL == "" {
+		return in.setupQueues(inputContext, pipeline)
+	}
+
 	in.log = inputContext.Logger.With("queue_url", in.config.QueueURL)
 	in.pipeline = pipeline
 
@@ -151,6 +175,14 @@ func (in *sqsReaderInput) run(ctx context.Context) {
 		defer cancel()
 	}
 
+	if in.queues != nil {
+		go in.queues.monitorMessageCount(graceCtx)
+		in.startWorkers(ctx, graceCtx)
+		in.queues.readerLoop(ctx)
+		in.workerWg.Wait()
+		return
+	}
+
 	// Poll metrics periodically in the background.
 	//
 	// Use the graceCtx here also to ensure that all metrics have
@@ -195,7 +227,7 @@ func (in *sqsReaderInput) readerLoop(ctx context.Context) {
 			select {
 			case <-ctx.Done():
 				return
-			case in.workResponseChan <- msg:
+			case in.workResponseChan <- sqsWork{msg: msg, processor: in.msgHandler}:
 				requestCount--
 			}
 		}
@@ -268,8 +300,8 @@ func (w *sqsWorker) run(ctx, graceCtx context.Context) {
 		select {
 		case <-graceCtx.Done():
 			return
-		case msg := <-w.input.workResponseChan:
-			w.processMessage(graceCtx, msg)
+		case work := <-w.input.workResponseChan:
+			w.processMessage(graceCtx, work)
 		case <-ctx.Done():
 			// We're shutting down, so spin in the
 			// loop until we have exceeded our
@@ -279,10 +311,10 @@ func (w *sqsWorker) run(ctx, graceCtx context.Context) {
 	}
 }
 
-func (w *sqsWorker) processMessage(ctx context.Context, msg types.Message) {
+func (w *sqsWorker) processMessage(ctx context.Context, work sqsWork) {
 	publishCount := 0
 	id := w.input.metrics.beginSQSWorker()
-	result := w.input.msgHandler.ProcessSQS(ctx, &msg, func(e beat.Event) {
+	result := work.processor.ProcessSQS(ctx, &work.msg, func(e beat.Event) {
 		w.client.Publish(e)
 		publishCount++
 	})
@@ -327,6 +359,9 @@ func (in *sqsReaderInput) logConfigSummary() {
 	}
 	log.Infof("AWS SQS visibility_timeout is set to %v.", in.config.VisibilityTimeout)
 	log.Infof("AWS SQS number_of_workers is set to %v.", in.config.NumberOfWorkers)
+	if in.queues != nil {
+		log.Infof("AWS SQS is polling %d queues.", len(in.queues.queues))
+	}
 
 	if in.config.BackupConfig.GetBucketName() != "" {
 		log.Warnf("You have the backup_to_bucket functionality activated with SQS. Please make sure to set appropriate destination buckets " +
@@ -0,0 +1,446 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// sqsMaxReceiveMessages is the maximum number of messages returned by
+// a single ReceiveMessage call.
+const sqsMaxReceiveMessages = 10
+
+// sqsQueues polls the queues of an input configured with queue_urls or
+// queue_url_prefix, sharing the input's workers between them.
+//
+// Workers waiting for a message are granted to the queues that had
+// messages on their last poll by smooth weighted round-robin, so each
+// queue receives a share of the workers proportional to its weight.
+// Queues that had no messages on their last poll are long polled for a
+// single message without holding a worker, so that idle queues do not
+// hold up queues with messages.
+type sqsQueues struct {
+	config  config
+	log     *logp.Logger
+	metrics *inputMetrics
+
+	// requests and responses are the work channels of the input's
+	// workers.
+	requests  chan struct{}
+	responses chan sqsWork
+
+	// newQueue returns the API and the message processor of the queue
+	// with the given URL.
+	newQueue func(url string) (sqsAPI, sqsProcessor, error)
+	// lister discovers the queues matching queue_url_prefix. It is nil
+	// if queue_url_prefix is not set.
+	lister sqsLister
+
+	mu     sync.Mutex
+	queues []*sqsQueue // Sorted by URL.
+}
+
+// sqsQueue is a queue polled by sqsQueues.
+type sqsQueue struct {
+	url       string
+	weight    int
+	sqs       sqsAPI
+	processor sqsProcessor
+
+	// Scheduling state owned by the reader loop.
+	credit  int       // Smooth weighted round-robin credit.
+	polls   int       // Number of ReceiveMessage calls in progress.
+	idle    bool      // The last ReceiveMessage call returned no messages.
+	retryAt time.Time // The queue is not polled before this time.
+}
+
+// sqsPoll is the result of a ReceiveMessage call on a queue.
+type sqsPoll struct {
+	queue *sqsQueue
+	// reserved is the number of workers held by the call.
+	reserved int
+	msgs     []types.Message
+	err      error
+}
+
+// setupQueues is the counterpart of setup for inputs reading from
+// queue_urls or queue_url_prefix.
+func (in *sqsReaderInput) setupQueues(
+	inputContext v2.Context,
+	pipeline beat.Pipeline,
+) error {
+	in.log = inputContext.Logger
+	in.pipeline = pipeline
+
+	// S3 objects are fetched and queues are discovered using the region
+	// of the first queue.
+	first := in.config.QueueURLPrefix
+	if len(in.config.QueueURLs) != 0 {
+		first = in.config.QueueURLs[0]
+	}
+	in.detectedRegion = getRegionFromQueueURL(first)
+	in.awsConfig.Region = in.config.queueRegion(first)
+	if in.awsConfig.Region == "" {
+		return fmt.Errorf("region not specified and failed to get AWS region from %s: %w", first, errBadQueueURL)
+	}
+
+	in.s3 = newAWSs3API(s3.NewFromConfig(in.awsConfig, in.config.s3ConfigModifier))
+
+	in.metrics = newInputMetrics(inputContext.ID, nil, in.config.NumberOfWorkers)
+
+	script, err := newScriptFromConfig(in.log.Named("sqs_script"), in.config.SQSScript)
+	if err != nil {
+		return fmt.Errorf("failed to initialize sqs reader: %w", err)
+	}
+	s3EventHandlerFactory := newS3ObjectProcessorFactory(in.metrics, in.s3, in.config.getFileSelectors(), in.config.BackupConfig)
+
+	// Queues in the same region share a client.
+	clients := make(map[string]*sqs.Client)
+	client := func(region string) *sqs.Client {
+		c, ok := clients[region]
+		if !ok {
+			c = sqs.NewFromConfig(in.awsConfig, in.config.sqsConfigModifier, func(o *sqs.Options) {
+				o.Region = region
+			})
+			clients[region] = c
+		}
+		return c
+	}
+
+	in.queues = &sqsQueues{
+		config:    in.config,
+		log:       in.log,
+		metrics:   in.metrics,
+		requests:  in.workRequestChan,
+		responses: in.workResponseChan,
+		newQueue: func(url string) (sqsAPI, sqsProcessor, error) {
+			region := in.config.queueRegion(url)
+			if region == "" {
+				// Discovered queues are in the region they were listed in.
+				if in.config.QueueURLPrefix == "" {
+					return nil, nil, fmt.Errorf("region not specified and failed to get AWS region from %s: %w", url, errBadQueueURL)
+				}
+				region = in.awsConfig.Region
+			}
+			api := &awsSQSAPI{
+				client: client(region),
+
+				queueURL:          url,
+				apiTimeout:        in.config.APITimeout,
+				visibilityTimeout: in.config.VisibilityTimeout,
+				longPollWaitTime:  in.config.SQSWaitTime,
+			}
+			log := in.log.With("queue_url", url)
+			return api, newSQSS3EventProcessor(log.Named("sqs_s3_event"), in.metrics, api, script, in.config.VisibilityTimeout, in.config.SQSMaxReceiveCount, s3EventHandlerFactory), nil
+		},
+	}
+	if in.config.QueueURLPrefix != "" {
+		in.queues.lister = &awsSQSLister{
+			client:     client(in.awsConfig.Region),
+			apiTimeout: in.config.APITimeout,
+		}
+	}
+	return in.queues.update(nil)
+}
+
+// queueRegion returns the region of the queue with the given URL, or
+// an empty string if it can not be determined.
+func (c config) queueRegion(queueURL string) string {
+	if c.RegionName != "" {
+		// Configured region always takes precedence.
+		return c.RegionName
+	}
+	if region := getRegionFromQueueURL(queueURL); region != "" {
+		return region
+	}
+	return c.AWSConfig.DefaultRegion
+}
+
+// queueWeight returns the polling weight of the queue with the given URL.
+func (c config) queueWeight(queueURL string) int {
+	for _, w := range c.SQSQueueWeights {
+		if w.QueueURL == queueURL {
+			return w.Weight
+		}
+	}
+	return 1
+}
+
+// update sets the polled queues to the configured queue_urls and the
+// given discovered queues. Queues that fail to be set up are not polled.
+func (s *sqsQueues) update(discovered []string) error {
+	urls := make(map[string]bool)
+	for _, u := range s.config.QueueURLs {
+		urls[u] = true
+	}
+	for _, u := range discovered {
+		urls[u] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	queues := s.queues[:0:0]
+	for _, q := range s.queues {
+		if urls[q.url] {
+			queues = append(queues, q)
+			delete(urls, q.url)
+		} else {
+			s.log.Infow("Stopped polling SQS queue.", "queue_url", q.url)
+		}
+	}
+	var errs []error
+	for u := range urls {
+		api, processor, err := s.newQueue(u)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		queues = append(queues, &sqsQueue{
+			url:       u,
+			weight:    s.config.queueWeight(u),
+			sqs:       api,
+			processor: processor,
+			// New queues start out idle so that they do not hold
+			// workers until they are known to have messages.
+			idle: true,
+		})
+		s.log.Infow("Started polling SQS queue.", "queue_url", u)
+	}
+	sort.Slice(queues, func(i, j int) bool {
+		return queues[i].url < queues[j].url
+	})
+	s.queues = queues
+	s.metrics.sqsQueues.Set(uint64(len(queues)))
+	return errors.Join(errs...)
+}
+
+// readerLoop polls the queues and hands the received messages to the
+// workers until ctx is cancelled.
+func (s *sqsQueues) readerLoop(ctx context.Context) {
+	var discovered chan []string
+	if s.lister != nil {
+		discovered = make(chan []string)
+		go s.discover(ctx, discovered)
+	}
+
+	var (
+		// free is the number of workers waiting for a message that
+		// are not held by a poll.
+		free int
+		// pending are the received messages waiting for a worker.
+		pending []sqsWork
+
+		results = make(chan sqsPoll)
+		polls   sync.WaitGroup
+	)
+	defer polls.Wait()
+	for ctx.Err() == nil {
+		for free > 0 && len(pending) != 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case s.responses <- pending[0]:
+				pending = pending[1:]
+				free--
+			}
+		}
+
+		now := time.Now()
+		grants, reserved := s.grant(now, free, len(pending))
+		free -= reserved
+		for q, n := range grants {
+			q.polls++
+			polls.Add(1)
+			go func() {
+				defer polls.Done()
+				msgs, err := q.sqs.ReceiveMessage(ctx, n.count)
+				select {
+				case <-ctx.Done():
+				case results <- sqsPoll{queue: q, reserved: n.reserved, msgs: msgs, err: err}:
+				}
+			}()
+		}
+
+		var retry <-chan time.Time
+		if d, ok := s.nextRetry(now); ok {
+			retry = time.After(d)
+		}
+		select {
+		case <-ctx.Done():
+		case <-s.requests:
+			free += 1 + channelRequestCount(ctx, s.requests, false)
+		case r := <-results:
+			q := r.queue
+			q.polls--
+			free += r.reserved
+			if r.err != nil {
+				s.log.Warnw("SQS ReceiveMessage returned an error. Will retry after a short delay.", "queue_url", q.url, "error", r.err)
+				q.retryAt = time.Now().Add(sqsRetryDelay)
+				break
+			}
+			q.idle = len(r.msgs) == 0
+			s.log.Debugw("Received SQS messages.", "queue_url", q.url, "count", len(r.msgs))
+			s.metrics.sqsMessagesReceivedTotal.Add(uint64(len(r.msgs)))
+			for _, msg := range r.msgs {
+				pending = append(pending, sqsWork{msg: msg, processor: q.processor})
+			}
+		case urls := <-discovered:
+			if err := s.update(urls); err != nil {
+				s.log.Errorw("Failed to update discovered SQS queues.", "error", err)
+			}
+		case <-retry:
+		}
+	}
+}
+
+// sqsGrant is the number of messages requested from a queue, and the
+// number of workers held by the request.
+type sqsGrant struct {
+	count    int
+	reserved int
+}
+
+// grant returns the queues to poll given the number of free workers and
+// pending messages, along with the number of workers held by the polls.
+func (s *sqsQueues) grant(now time.Time, free, pending int) (map[*sqsQueue]sqsGrant, int) {
+	grants := make(map[*sqsQueue]sqsGrant)
+	var active []*sqsQueue
+	s.mu.Lock()
+	for _, q := range s.queues {
+		if now.Before(q.retryAt) {
+			continue
+		}
+		if !q.idle {
+			// Queues with messages may be polled concurrently so that
+			// they get their share of the workers while being polled.
+			active = append(active, q)
+			continue
+		}
+		// Idle queues are polled for a single message without holding
+		// a worker, as long as there are not more messages waiting for
+		// a worker than there are workers.
+		if q.polls == 0 && pending+len(grants) < s.config.NumberOfWorkers {
+			grants[q] = sqsGrant{count: 1}
+		}
+	}
+	s.mu.Unlock()
+
+	reserved := 0
+	for ; free > 0 && len(active) != 0; free-- {
+		total := 0
+		var best *sqsQueue
+		for _, q := range active {
+			q.credit += q.weight
+			total += q.weight
+			if best == nil || q.credit > best.credit {
+				best = q
+			}
+		}
+		best.credit -= total
+		g := grants[best]
+		g.count++
+		g.reserved++
+		grants[best] = g
+		reserved++
+		if g.count == sqsMaxReceiveMessages {
+			for i, q := range active {
+				if q == best {
+					active = append(active[:i], active[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	return grants, reserved
+}
+
+// nextRetry returns the time until the earliest retry of a failed
+// queue poll, and whether there is any.
+func (s *sqsQueues) nextRetry(now time.Time) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var next time.Time
+	for _, q := range s.queues {
+		if q.polls == 0 && q.retryAt.After(now) && (next.IsZero() || q.retryAt.Before(next)) {
+			next = q.retryAt
+		}
+	}
+	return next.Sub(now), !next.IsZero()
+}
+
+// discover sends the URLs of the queues matching queue_url_prefix to out
+// every sqs.discovery_interval until ctx is cancelled.
+func (s *sqsQueues) discover(ctx context.Context, out chan<- []string) {
+	_, namePrefix, _ := splitQueueURLPrefix(s.config.QueueURLPrefix)
+	for {
+		urls, err := s.lister.ListQueues(ctx, namePrefix)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.log.Warnw("Failed to discover SQS queues.", "queue_url_prefix", s.config.QueueURLPrefix, "error", err)
+		} else {
+			if len(urls) == 0 {
+				s.log.Warnw("No SQS queues match queue_url_prefix.", "queue_url_prefix", s.config.QueueURLPrefix)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- urls:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.config.SQSDiscoveryPeriod):
+		}
+	}
+}
+
+// monitorMessageCount periodically sets the sqsMessagesWaiting metric to
+// the number of messages waiting in all the queues.
+func (s *sqsQueues) monitorMessageCount(ctx context.Context) {
+	t := time.NewTicker(time.Minute)
+	defer t.Stop()
+	for {
+		s.mu.Lock()
+		queues := append([]*sqsQueue(nil), s.queues...)
+		s.mu.Unlock()
+
+		total, collected := 0, false
+		for _, q := range queues {
+			count, err := messageCountMonitor{sqs: q.sqs}.getApproximateMessageCount(ctx)
+			if err != nil {
+				s.log.Debugw("Failed to get SQS queue message count.", "queue_url", q.url, "error", err)
+				continue
+			}
+			total += count
+			collected = true
+		}
+		if collected {
+			s.metrics.sqsMessagesWaiting.Set(int64(total))
+		} else {
+			s.metrics.sqsMessagesWaiting.Set(-1)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
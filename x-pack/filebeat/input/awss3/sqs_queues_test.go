@@ -0,0 +1,260 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestSQSQueuesGrant(t *testing.T) {
+	now := time.Now()
+	busy := &sqsQueue{url: "busy", weight: 3}
+	quiet := &sqsQueue{url: "quiet", weight: 1}
+	idle := &sqsQueue{url: "idle", weight: 1, idle: true}
+	polling := &sqsQueue{url: "polling", weight: 1, idle: true, polls: 1}
+	failed := &sqsQueue{url: "failed", weight: 1, retryAt: now.Add(time.Second)}
+	s := &sqsQueues{
+		config: config{NumberOfWorkers: 8},
+		queues: []*sqsQueue{busy, failed, idle, polling, quiet},
+	}
+
+	grants, reserved := s.grant(now, 8, 0)
+	assert.Equal(t, 8, reserved)
+	assert.Equal(t, map[*sqsQueue]sqsGrant{
+		busy:  {count: 6, reserved: 6},
+		quiet: {count: 2, reserved: 2},
+		idle:  {count: 1},
+	}, grants)
+
+	// Idle queues are not polled while enough messages are pending.
+	grants, reserved = s.grant(now, 1, 8)
+	assert.Equal(t, 1, reserved)
+	assert.NotContains(t, grants, idle)
+
+	// A single poll requests at most sqsMaxReceiveMessages.
+	s.queues = []*sqsQueue{busy}
+	grants, reserved = s.grant(now, 25, 0)
+	assert.Equal(t, sqsMaxReceiveMessages, reserved)
+	assert.Equal(t, sqsGrant{count: sqsMaxReceiveMessages, reserved: sqsMaxReceiveMessages}, grants[busy])
+}
+
+func TestSQSQueuesReaderLoop(t *testing.T) {
+	err := logp.TestingSetup()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	const workers = 3
+	apis := map[string]*fakeSQSQueue{
+		"busy":  newFakeSQSQueue("busy", 40),
+		"quiet": newFakeSQSQueue("quiet", 20),
+		"empty": newFakeSQSQueue("empty", 0),
+	}
+	metrics := newInputMetrics("", nil, 0)
+	defer metrics.Close()
+	s := &sqsQueues{
+		config: config{
+			NumberOfWorkers: workers,
+			QueueURLs:       []string{"busy", "empty", "quiet"},
+			SQSQueueWeights: []queueWeightConfig{{QueueURL: "busy", Weight: 2}},
+		},
+		log:       logp.NewLogger(inputName),
+		metrics:   metrics,
+		requests:  make(chan struct{}, workers),
+		responses: make(chan sqsWork),
+		newQueue: func(url string) (sqsAPI, sqsProcessor, error) {
+			return apis[url], fakeSQSProcessor(url), nil
+		},
+	}
+	require.NoError(t, s.update(nil))
+	// Start out with the queues known to have messages, so that workers
+	// are shared by weight from the start.
+	for _, q := range s.queues {
+		q.idle = q.url == "empty"
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.readerLoop(ctx)
+	}()
+
+	var (
+		mu       sync.Mutex
+		received = map[string]int{}
+		first    = map[string]int{}
+		wg       sync.WaitGroup
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case s.requests <- struct{}{}:
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case work := <-s.responses:
+					queue := string(work.processor.(fakeSQSProcessor))
+					assert.Equal(t, queue, *work.msg.Body, "message processed by the processor of another queue")
+					mu.Lock()
+					received[queue]++
+					if received["busy"]+received["quiet"] <= 30 {
+						first[queue]++
+					}
+					total := received["busy"] + received["quiet"]
+					mu.Unlock()
+					if total == 60 {
+						cancel()
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	<-done
+
+	assert.Equal(t, map[string]int{"busy": 40, "quiet": 20}, received)
+	// Workers are shared in proportion to the weights of the queues
+	// while both have messages.
+	assert.Greater(t, first["busy"], first["quiet"])
+	assert.EqualValues(t, 60, metrics.sqsMessagesReceivedTotal.Get())
+	assert.EqualValues(t, 3, metrics.sqsQueues.Get())
+}
+
+func TestSQSQueuesDiscovery(t *testing.T) {
+	err := logp.TestingSetup()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	ctrl, ctx := gomock.WithContext(ctx, t)
+	defer ctrl.Finish()
+
+	const prefix = "https://sqs.us-east-1.amazonaws.com/123456789012/notifications-"
+	lister := NewMockSQSLister(ctrl)
+	gomock.InOrder(
+		lister.EXPECT().
+			ListQueues(gomock.Any(), gomock.Eq("notifications-")).
+			Return([]string{prefix + "a", prefix + "b"}, nil),
+		lister.EXPECT().
+			ListQueues(gomock.Any(), gomock.Eq("notifications-")).
+			Return([]string{prefix + "b", prefix + "c"}, nil).
+			AnyTimes(),
+	)
+
+	metrics := newInputMetrics("", nil, 0)
+	defer metrics.Close()
+	s := &sqsQueues{
+		config: config{
+			QueueURLs:          []string{"https://sqs.us-east-1.amazonaws.com/123456789012/static"},
+			QueueURLPrefix:     prefix,
+			SQSDiscoveryPeriod: time.Millisecond,
+		},
+		log:     logp.NewLogger(inputName),
+		metrics: metrics,
+		newQueue: func(url string) (sqsAPI, sqsProcessor, error) {
+			return newFakeSQSQueue(url, 0), fakeSQSProcessor(url), nil
+		},
+		lister: lister,
+	}
+	require.NoError(t, s.update(nil))
+	assert.Equal(t, []string{"https://sqs.us-east-1.amazonaws.com/123456789012/static"}, s.urls())
+
+	discovered := make(chan []string)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.discover(ctx, discovered)
+	}()
+	for _, want := range [][]string{
+		{prefix + "a", prefix + "b", "https://sqs.us-east-1.amazonaws.com/123456789012/static"},
+		{prefix + "b", prefix + "c", "https://sqs.us-east-1.amazonaws.com/123456789012/static"},
+	} {
+		require.NoError(t, s.update(<-discovered))
+		assert.Equal(t, want, s.urls())
+	}
+	cancel()
+	<-done
+}
+
+// urls returns the URLs of the polled queues.
+func (s *sqsQueues) urls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var urls []string
+	for _, q := range s.queues {
+		urls = append(urls, q.url)
+	}
+	return urls
+}
+
+// fakeSQSQueue is an sqsAPI holding a fixed set of messages. Polls of an
+// empty queue wait briefly to simulate long polling.
+type fakeSQSQueue struct {
+	mu   sync.Mutex
+	msgs []types.Message
+}
+
+func newFakeSQSQueue(url string, count int) *fakeSQSQueue {
+	q := &fakeSQSQueue{}
+	for i := 0; i < count; i++ {
+		q.msgs = append(q.msgs, types.Message{
+			MessageId: awssdk.String(url + "-" + strconv.Itoa(i)),
+			Body:      awssdk.String(url),
+		})
+	}
+	return q
+}
+
+func (q *fakeSQSQueue) ReceiveMessage(ctx context.Context, maxMessages int) ([]types.Message, error) {
+	q.mu.Lock()
+	n := min(maxMessages, len(q.msgs))
+	msgs := q.msgs[:n]
+	q.msgs = q.msgs[n:]
+	q.mu.Unlock()
+	if n == 0 {
+		select {
+		case <-ctx.Done():
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return msgs, nil
+}
+
+func (q *fakeSQSQueue) DeleteMessage(context.Context, *types.Message) error { return nil }
+
+func (q *fakeSQSQueue) ChangeMessageVisibility(context.Context, *types.Message, time.Duration) error {
+	return nil
+}
+
+func (q *fakeSQSQueue) GetQueueAttributes(context.Context, []types.QueueAttributeName) (map[string]string, error) {
+	return nil, nil
+}
+
+// fakeSQSProcessor is an sqsProcessor identified by the URL of its queue.
+type fakeSQSProcessor string
+
+func (fakeSQSProcessor) ProcessSQS(context.Context, *types.Message, func(beat.Event)) sqsProcessingResult {
+	return sqsProcessingResult{}
+}
@@ -6,15 +6,19 @@ package azureblobstorage
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/sync/errgroup"
 
@@ -187,3 +191,184 @@ func Test_OAuth2(t *testing.T) {
 		})
 	}
 }
+
+// staticCredential is a token credential that always returns the same token.
+type staticCredential struct{}
+
+func (staticCredential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "mock_access_token_123", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func Test_KeyVault(t *testing.T) {
+	logp.TestingSetup()
+
+	const sasToken = "sv=2022-11-02&ss=b&srt=co&sp=rl&sig=c2lnbmF0dXJl%3D"
+
+	var kvRequests atomic.Int32
+	kvServ := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kvRequests.Add(1)
+		if r.URL.Path != "/secrets/blob-sas" || r.Header.Get("Authorization") != "Bearer mock_access_token_123" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"value": sasToken})
+	}))
+	t.Cleanup(kvServ.Close)
+
+	var unsigned atomic.Int32
+	storage := mock.AzureStorageServer()
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sig") != "c2lnbmF0dXJl=" {
+			unsigned.Add(1)
+		}
+		storage.ServeHTTP(w, r)
+	}))
+	t.Cleanup(serv.Close)
+
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"account_name": "beatsblobnew",
+		"auth.key_vault": map[string]interface{}{
+			"uri":         kvServ.URL,
+			"secret_name": "blob-sas",
+			"oauth2": map[string]interface{}{
+				"client_id":     "12345678-90ab-cdef-1234-567890abcdef",
+				"client_secret": "abcdefg1234567890!@#$%^&*()-_=+",
+				"tenant_id":     "87654321-abcd-ef90-1234-fedcba098765",
+			},
+		},
+		"max_workers":   2,
+		"poll":          false,
+		"poll_interval": "30s",
+		"containers": []map[string]interface{}{
+			{
+				"name": beatsContainer,
+			},
+		},
+	})
+	conf := config{}
+	assert.NoError(t, cfg.Unpack(&conf))
+
+	// inject a static credential & client options
+	conf.Auth.KeyVault.clientOptions = azcore.ClientOptions{InsecureAllowCredentialWithHTTP: true}
+	conf.Auth.KeyVault.credential = staticCredential{}
+
+	input := newStatelessInput(conf, serv.URL+"/")
+	expected := map[string]bool{
+		mock.Beatscontainer_blob_ata_json:      true,
+		mock.Beatscontainer_blob_data3_json:    true,
+		mock.Beatscontainer_blob_docs_ata_json: true,
+	}
+	chanClient := beattest.NewChanClient(len(expected))
+	t.Cleanup(func() { _ = chanClient.Close() })
+
+	ctx, cancel := newV2Context(t)
+	t.Cleanup(cancel)
+
+	var g errgroup.Group
+	g.Go(func() error {
+		return input.Run(ctx, chanClient)
+	})
+
+	timeout := time.NewTimer(10 * time.Second)
+	t.Cleanup(func() { timeout.Stop() })
+	for range expected {
+		select {
+		case <-timeout.C:
+			t.Fatalf("timed out waiting for %d events", len(expected))
+		case got := <-chanClient.Channel:
+			val, err := got.Fields.GetValue("message")
+			assert.NoError(t, err)
+			assert.True(t, expected[val.(string)])
+		}
+	}
+	cancel()
+	assert.NoError(t, g.Wait())
+
+	// the secret is fetched once and reused until the refresh interval elapses
+	assert.EqualValues(t, 1, kvRequests.Load())
+	assert.Zero(t, unsigned.Load(), "storage requests without SAS token")
+}
+
+func TestKeyVaultSecretRefresh(t *testing.T) {
+	var (
+		fail  atomic.Bool
+		count atomic.Int32
+	)
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := count.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"value": fmt.Sprintf("sv=2022-11-02&sig=%d", n)})
+	}))
+	t.Cleanup(serv.Close)
+
+	cfg := &keyVaultConfig{
+		URI:             serv.URL,
+		SecretName:      "blob-sas",
+		RefreshInterval: time.Hour,
+		clientOptions: azcore.ClientOptions{
+			InsecureAllowCredentialWithHTTP: true,
+			Retry:                           policy.RetryOptions{MaxRetries: -1},
+		},
+		credential: staticCredential{},
+	}
+	ctx := context.Background()
+	secret, err := newKeyVaultSecret(ctx, cfg, logp.NewLogger("azure-blob-storage-test"))
+	assert.NoError(t, err)
+
+	token, err := secret.token(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "sv=2022-11-02&sig=1", token)
+
+	// the token is refreshed once it is due
+	secret.refreshAt = time.Now()
+	token, err = secret.token(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "sv=2022-11-02&sig=2", token)
+
+	// a failed refresh keeps the previous token
+	fail.Store(true)
+	secret.refreshAt = time.Now()
+	token, err = secret.token(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "sv=2022-11-02&sig=2", token)
+	assert.WithinDuration(t, time.Now().Add(keyVaultRetryInterval), secret.refreshAt, time.Second)
+}
+
+func TestSASTokenFromSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		want    string
+		wantErr bool
+	}{
+		{name: "sas_token", secret: "sv=2022-11-02&sig=abc", want: "sv=2022-11-02&sig=abc"},
+		{name: "sas_token_with_question_mark", secret: "?sv=2022-11-02&sig=abc\n", want: "sv=2022-11-02&sig=abc"},
+		{
+			name:   "connection_string",
+			secret: "BlobEndpoint=https://account.blob.core.windows.net/;SharedAccessSignature=sv=2022-11-02&sig=abc",
+			want:   "sv=2022-11-02&sig=abc",
+		},
+		{
+			name:    "connection_string_with_account_key",
+			secret:  "DefaultEndpointsProtocol=https;AccountName=account;AccountKey=a2V5;EndpointSuffix=core.windows.net",
+			wantErr: true,
+		},
+		{name: "empty", secret: " ", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := sasTokenFromSecret(tc.secret)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -5,9 +5,11 @@
 package azureblobstorage
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
@@ -25,6 +27,8 @@ func fetchServiceClientAndCreds(cfg config, url string, log *logp.Logger) (*serv
 		return fetchServiceClientWithConnectionString(cfg.Auth.ConnectionString, log)
 	case cfg.Auth.OAuth2 != nil:
 		return fetchServiceClientWithOAuth2(url, cfg.Auth.OAuth2)
+	case cfg.Auth.KeyVault != nil:
+		return fetchServiceClientWithKeyVault(url, cfg.Auth.KeyVault, log)
 	}
 
 	return nil, nil, fmt.Errorf("no valid auth specified")
@@ -75,6 +79,24 @@ func fetchServiceClientWithOAuth2(url string, cfg *OAuth2Config) (*service.Clien
 	return client.ServiceClient(), &serviceCredentials{oauth2Creds: creds, cType: oauth2Type}, nil
 }
 
+func fetchServiceClientWithKeyVault(url string, cfg *keyVaultConfig, log *logp.Logger) (*service.Client, *serviceCredentials, error) {
+	secret, err := newKeyVaultSecret(context.Background(), cfg, log)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get SAS token from key vault: %w", err)
+	}
+
+	client, err := service.NewClientWithNoCredential(url, &service.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			PerCallPolicies: []policy.Policy{sasPolicy{secret: secret}},
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create azblob service client: %w", err)
+	}
+
+	return client, &serviceCredentials{keyVaultSecret: secret, cType: keyVaultType}, nil
+}
+
 // fetchBlobClient, generic function that returns a BlobClient based on the credential type
 func fetchBlobClient(url string, credential *blobCredentials, cfg config, log *logp.Logger) (*blob.Client, error) {
 	if credential == nil {
@@ -88,6 +110,8 @@ func fetchBlobClient(url string, credential *blobCredentials, cfg config, log *l
 		return fetchBlobClientWithConnectionString(credential.serviceCreds.connectionStrCreds, credential.containerName, credential.blobName, log)
 	case oauth2Type:
 		return fetchBlobClientWithOAuth2(url, credential.serviceCreds.oauth2Creds, cfg.Auth.OAuth2)
+	case keyVaultType:
+		return fetchBlobClientWithKeyVault(url, credential.serviceCreds.keyVaultSecret)
 	default:
 		return nil, fmt.Errorf("no valid service credential 'type' found: %s", credential.serviceCreds.cType)
 	}
@@ -140,6 +164,19 @@ func fetchBlobClientWithOAuth2(url string, credential *azidentity.ClientSecretCr
 	return blobClient, nil
 }
 
+func fetchBlobClientWithKeyVault(url string, secret *keyVaultSecret) (*blob.Client, error) {
+	blobClient, err := blob.NewClientWithNoCredential(url, &blob.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			PerCallPolicies: []policy.Policy{sasPolicy{secret: secret}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blob client for %s: %w", url, err)
+	}
+
+	return blobClient, nil
+}
+
 func fetchContainerClient(serviceClient *service.Client, containerName string, log *logp.Logger) (*azcontainer.Client, error) {
 	return serviceClient.NewContainerClient(containerName), nil
 }
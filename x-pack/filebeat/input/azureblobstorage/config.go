@@ -125,6 +125,25 @@ type authConfig struct {
 	ConnectionString *connectionStringConfig `config:"connection_string"`
 	// OAuth2 uses OAuth 2.0 for authentication, typically with Azure Active Directory.
 	OAuth2 *OAuth2Config `config:"oauth2"`
+	// KeyVault uses a SAS token stored in Azure Key Vault, which is refreshed periodically.
+	KeyVault *keyVaultConfig `config:"key_vault"`
+}
+
+// keyVaultConfig holds the details for fetching a SAS token from Azure Key Vault.
+type keyVaultConfig struct {
+	// URI is the URI of the key vault, e.g. https://myvault.vault.azure.net.
+	URI string `config:"uri" validate:"required"`
+	// SecretName is the name of the secret holding either a SAS token or a
+	// connection string with a SharedAccessSignature.
+	SecretName string `config:"secret_name" validate:"required"`
+	// RefreshInterval is how often the secret is fetched again, defaults to 1 hour.
+	RefreshInterval time.Duration `config:"refresh_interval" validate:"positive"`
+	// OAuth2 holds the credentials used to access the key vault. If it is not
+	// set, the Azure default credential chain is used, e.g. a managed identity.
+	OAuth2 *OAuth2Config `config:"oauth2"`
+	// clientOptions and credential are used internally for testing purposes only and should not be configured by users.
+	clientOptions azcore.ClientOptions
+	credential    azcore.TokenCredential
 }
 
 // connectionStringConfig holds the details for connection string-based authentication.
@@ -161,5 +180,8 @@ func (c config) Validate() error {
 	if c.Auth.OAuth2 != nil && (c.Auth.OAuth2.ClientID == "" || c.Auth.OAuth2.ClientSecret == "" || c.Auth.OAuth2.TenantID == "") {
 		return errors.New("client_id, client_secret and tenant_id are required for OAuth2 auth")
 	}
+	if kv := c.Auth.KeyVault; kv != nil && kv.OAuth2 != nil && (kv.OAuth2.ClientID == "" || kv.OAuth2.ClientSecret == "" || kv.OAuth2.TenantID == "") {
+		return errors.New("client_id, client_secret and tenant_id are required for key vault OAuth2 auth")
+	}
 	return nil
 }
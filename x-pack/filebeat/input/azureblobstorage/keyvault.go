@@ -0,0 +1,186 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package azureblobstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	keyVaultAPIVersion = "7.4"
+	keyVaultScope      = "https://vault.azure.net/.default"
+
+	defaultKeyVaultRefreshInterval = time.Hour
+	// keyVaultRetryInterval is the time to wait before fetching the secret
+	// again after a failed refresh.
+	keyVaultRetryInterval = time.Minute
+
+	sasConnectionStringKey = "SharedAccessSignature"
+)
+
+// keyVaultSecret holds a SAS token fetched from Azure Key Vault and
+// refreshes it once it is older than the configured refresh interval.
+type keyVaultSecret struct {
+	pipeline runtime.Pipeline
+	endpoint string
+	interval time.Duration
+	log      *logp.Logger
+
+	mu        sync.Mutex
+	sasToken  string
+	refreshAt time.Time
+}
+
+// newKeyVaultSecret creates a keyVaultSecret and fetches the secret for the
+// first time, so that configuration errors are reported on startup.
+func newKeyVaultSecret(ctx context.Context, cfg *keyVaultConfig, log *logp.Logger) (*keyVaultSecret, error) {
+	clientOptions := cfg.clientOptions
+	cred := cfg.credential
+	if cred == nil {
+		var err error
+		if cfg.OAuth2 != nil {
+			cred, err = azidentity.NewClientSecretCredential(cfg.OAuth2.TenantID, cfg.OAuth2.ClientID, cfg.OAuth2.ClientSecret, &azidentity.ClientSecretCredentialOptions{
+				ClientOptions: clientOptions,
+			})
+		} else {
+			cred, err = azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+				ClientOptions: clientOptions,
+			})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create key vault credential: %w", err)
+		}
+	}
+
+	interval := cfg.RefreshInterval
+	if interval == 0 {
+		interval = defaultKeyVaultRefreshInterval
+	}
+	s := &keyVaultSecret{
+		pipeline: runtime.NewPipeline("azureblobstorage", "v1", runtime.PipelineOptions{
+			PerRetry: []policy.Policy{
+				runtime.NewBearerTokenPolicy(cred, []string{keyVaultScope}, &policy.BearerTokenOptions{
+					InsecureAllowCredentialWithHTTP: clientOptions.InsecureAllowCredentialWithHTTP,
+				}),
+			},
+		}, &clientOptions),
+		endpoint: runtime.JoinPaths(cfg.URI, "secrets", url.PathEscape(cfg.SecretName)),
+		interval: interval,
+		log:      log,
+	}
+	if _, err := s.token(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// token returns the current SAS token, fetching it from Key Vault if it is
+// due for a refresh. If a refresh fails, the previous token is kept until
+// the next attempt.
+func (s *keyVaultSecret) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.sasToken != "" && now.Before(s.refreshAt) {
+		return s.sasToken, nil
+	}
+
+	sasToken, err := s.fetch(ctx)
+	if err != nil {
+		if s.sasToken == "" {
+			return "", err
+		}
+		s.log.Warnw("failed to refresh SAS token from key vault, using the previous token", "error", err)
+		s.refreshAt = now.Add(keyVaultRetryInterval)
+		return s.sasToken, nil
+	}
+	s.log.Debug("fetched SAS token from key vault")
+	s.sasToken = sasToken
+	s.refreshAt = now.Add(s.interval)
+	return s.sasToken, nil
+}
+
+// fetch gets the latest version of the secret from Key Vault and extracts
+// the SAS token from it.
+func (s *keyVaultSecret) fetch(ctx context.Context) (string, error) {
+	req, err := runtime.NewRequest(ctx, http.MethodGet, s.endpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to create key vault request: %w", err)
+	}
+	q := req.Raw().URL.Query()
+	q.Set("api-version", keyVaultAPIVersion)
+	req.Raw().URL.RawQuery = q.Encode()
+
+	resp, err := s.pipeline.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch secret from key vault: %w", err)
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return "", fmt.Errorf("failed to fetch secret from key vault: %w", runtime.NewResponseError(resp))
+	}
+	var secret struct {
+		Value string `json:"value"`
+	}
+	if err := runtime.UnmarshalAsJSON(resp, &secret); err != nil {
+		return "", fmt.Errorf("failed to decode key vault secret: %w", err)
+	}
+	return sasTokenFromSecret(secret.Value)
+}
+
+// sasTokenFromSecret returns the SAS token held by the secret, which is either
+// a SAS token or a connection string with a SharedAccessSignature.
+func sasTokenFromSecret(secret string) (string, error) {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return "", errors.New("key vault secret is empty")
+	}
+	if !strings.Contains(secret, ";") && !strings.HasPrefix(secret, sasConnectionStringKey+"=") {
+		return strings.TrimPrefix(secret, "?"), nil
+	}
+	for _, part := range strings.Split(secret, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if ok && strings.EqualFold(strings.TrimSpace(key), sasConnectionStringKey) {
+			return strings.TrimPrefix(value, "?"), nil
+		}
+	}
+	return "", errors.New("key vault secret is a connection string without a SharedAccessSignature")
+}
+
+// sasPolicy adds the current SAS token of the secret to every request.
+type sasPolicy struct {
+	secret *keyVaultSecret
+}
+
+func (p sasPolicy) Do(req *policy.Request) (*http.Response, error) {
+	sasToken, err := p.secret.token(req.Raw().Context())
+	if err != nil {
+		return nil, err
+	}
+	values, err := url.ParseQuery(sasToken)
+	if err != nil {
+		// the token is not included in the error, since it is a secret
+		return nil, errors.New("failed to parse SAS token from key vault")
+	}
+	q := req.Raw().URL.Query()
+	for k, v := range values {
+		q[k] = v
+	}
+	req.Raw().URL.RawQuery = q.Encode()
+	return req.Next()
+}
@@ -34,6 +34,7 @@ const (
 	sharedKeyType        = "sharedKeyType"
 	connectionStringType = "connectionStringType"
 	oauth2Type           = "oauth2Type"
+	keyVaultType         = "keyVaultType"
 	jsonType             = "application/json"
 	octetType            = "application/octet-stream"
 	ndJsonType           = "application/x-ndjson"
@@ -47,6 +48,7 @@ type serviceCredentials struct {
 	oauth2Creds        *azidentity.ClientSecretCredential
 	sharedKeyCreds     *azblob.SharedKeyCredential
 	connectionStrCreds string
+	keyVaultSecret     *keyVaultSecret
 	cType              string
 }
 
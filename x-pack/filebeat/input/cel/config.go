@@ -66,6 +66,11 @@ type config struct {
 	// RecordCoverage indicates whether a program should
 	// record and log execution coverage.
 	RecordCoverage bool `config:"record_coverage"`
+
+	// PersistentStore indicates whether a key-value store
+	// persisted in the registry is made available to the
+	// program as state.store.
+	PersistentStore bool `config:"persistent_store"`
 }
 
 type redact struct {
@@ -181,6 +181,15 @@ func (i input) run(env v2.Context, src *source, cursor map[string]interface{}, p
 		return err
 	}
 
+	var store *persistentStore
+	if cfg.PersistentStore {
+		store, err = openPersistentStore(src)
+		if err != nil {
+			return err
+		}
+		defer store.close()
+	}
+
 	var state map[string]interface{}
 	if cfg.State == nil {
 		state = make(map[string]interface{})
@@ -218,7 +227,9 @@ func (i input) run(env v2.Context, src *source, cursor map[string]interface{}, p
 	// absent. Only the cursor is persisted over restarts, but
 	// all fields in state are retained between iterations of
 	// the processing loop except for the produced events array,
-	// see discussion below.
+	// see discussion below. When persistent_store is enabled,
+	// the store field holds a key-value store that is persisted
+	// in the registry; it is replaced before each evaluation.
 	//
 	// If the cursor is present the program should perform and
 	// process requests based on its value. If cursor is not
@@ -269,7 +280,15 @@ func (i input) run(env v2.Context, src *source, cursor map[string]interface{}, p
 			log.Debugw("request state", logp.Namespace("cel"), "state", redactor{state: state, cfg: cfg.Redact})
 			metrics.executions.Add(1)
 			start := i.now().In(time.UTC)
+			if store != nil {
+				state[storeField] = storeVal{store: store}
+			}
 			state, err = evalWith(ctx, prg, ast, state, start, wantDump)
+			if store != nil {
+				// The store is provided for each evaluation and
+				// is not part of the state.
+				delete(state, storeField)
+			}
 			log.Debugw("response state", logp.Namespace("cel"), "state", redactor{state: state, cfg: cfg.Redact})
 			if err != nil {
 				var dump dumpError
@@ -1075,6 +1094,7 @@ func newProgram(ctx context.Context, src, root string, vars map[string]string, c
 		lib.MIME(mimetypes),
 		lib.HTTPWithContextOpts(ctx, client, httpOptions),
 		lib.Limit(limitPolicies),
+		storeLib(),
 		lib.Globals(map[string]interface{}{
 			"useragent": userAgent,
 			"env":       vars,
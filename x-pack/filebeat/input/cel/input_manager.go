@@ -29,20 +29,36 @@ func NewInputManager(log *logp.Logger, store statestore.States) InputManager {
 			Logger:     log,
 			StateStore: store,
 			Type:       inputName,
-			Configure:  cursorConfigure,
+			Configure: func(cfg *conf.C) ([]inputcursor.Source, inputcursor.Input, error) {
+				return cursorConfigure(cfg, store)
+			},
 		},
 	}
 }
 
-func cursorConfigure(cfg *conf.C) ([]inputcursor.Source, inputcursor.Input, error) {
-	src := &source{cfg: defaultConfig()}
+func cursorConfigure(cfg *conf.C, store statestore.States) ([]inputcursor.Source, inputcursor.Input, error) {
+	src := &source{cfg: defaultConfig(), store: store}
 	if err := cfg.Unpack(&src.cfg); err != nil {
 		return nil, nil, err
 	}
+	var id struct {
+		ID string `config:"id"`
+	}
+	if err := cfg.Unpack(&id); err != nil {
+		return nil, nil, err
+	}
+	src.id = id.ID
 	return []inputcursor.Source{src}, input{}, nil
 }
 
-type source struct{ cfg config }
+type source struct {
+	cfg config
+
+	// id and store are used to open the persistent store
+	// of the program.
+	id    string
+	store statestore.States
+}
 
 func (s *source) Name() string { return s.cfg.Resource.URL.String() }
 
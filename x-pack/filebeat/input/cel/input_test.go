@@ -1992,7 +1992,7 @@ func TestInput(t *testing.T) {
 			if name != "cel" {
 				t.Errorf(`unexpected input name: got:%q want:"cel"`, name)
 			}
-			src := &source{conf}
+			src := &source{cfg: conf}
 			err = input{}.Test(src, v2.TestContext{})
 			if err != nil {
 				t.Fatalf("unexpected error running test: %v", err)
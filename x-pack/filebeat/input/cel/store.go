@@ -0,0 +1,198 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cel
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/elastic/beats/v7/libbeat/statestore"
+)
+
+const (
+	// storeField is the state field holding the persistent store
+	// when persistent_store is enabled.
+	storeField = "store"
+
+	// storeKeyPrefix is the prefix of the registry keys of persistent
+	// stores. It is distinct from the prefix of cursor keys so that
+	// stores are not treated as cursor resources.
+	storeKeyPrefix = "cel-store::"
+)
+
+// persistentStore is a key-value store that is persisted in the input
+// registry.
+type persistentStore struct {
+	store *statestore.Store
+	key   string
+
+	mu   sync.Mutex
+	data map[string]interface{}
+}
+
+// openPersistentStore opens the persistent store of the program of src.
+func openPersistentStore(src *source) (*persistentStore, error) {
+	if src.store == nil {
+		return nil, errors.New("persistent store is not available")
+	}
+	store, err := src.store.StoreFor("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open registry: %w", err)
+	}
+	// Keys follow the form of cursor keys.
+	key := storeKeyPrefix + src.Name()
+	if src.id != "" {
+		key = storeKeyPrefix + src.id + "::" + src.Name()
+	}
+	s := &persistentStore{store: store, key: key, data: make(map[string]interface{})}
+	ok, err := store.Has(key)
+	if err == nil && ok {
+		err = store.Get(key, &s.data)
+	}
+	if err != nil {
+		store.Close()
+		return nil, fmt.Errorf("failed to read persistent store: %w", err)
+	}
+	return s, nil
+}
+
+func (s *persistentStore) get(key string) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.data[key]
+	return v, ok
+}
+
+// put sets the value of key and persists the store.
+func (s *persistentStore) put(key string, val interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = val
+	return s.store.Set(s.key, s.data)
+}
+
+func (s *persistentStore) close() {
+	s.store.Close()
+}
+
+// storeType is the CEL type of the persistent store.
+var storeType = cel.OpaqueType("store")
+
+// storeVal is the CEL value of a persistent store.
+type storeVal struct {
+	store *persistentStore
+}
+
+var jsonValueType = reflect.TypeOf((*structpb.Value)(nil))
+
+func (v storeVal) ConvertToNative(typ reflect.Type) (any, error) {
+	switch {
+	case typ == jsonValueType:
+		// The store may be retained in the state returned by the
+		// program, but its content is not part of the state.
+		return structpb.NewNullValue(), nil
+	case reflect.TypeOf(v).AssignableTo(typ):
+		return v, nil
+	}
+	return nil, fmt.Errorf("type conversion error from store to '%v'", typ)
+}
+
+func (v storeVal) ConvertToType(typ ref.Type) ref.Val {
+	if typ == types.TypeType {
+		return storeType
+	}
+	return types.NewErr("type conversion error from store to '%v'", typ)
+}
+
+func (v storeVal) Equal(other ref.Val) ref.Val {
+	o, ok := other.(storeVal)
+	return types.Bool(ok && o.store == v.store)
+}
+
+func (v storeVal) Type() ref.Type { return storeType }
+func (v storeVal) Value() any     { return v.store }
+
+// storeLib returns the declarations of the methods of the persistent store:
+//
+//	<store>.get(<string>) -> <dyn>
+//	<store>.put(<string>, <dyn>) -> <dyn>
+//
+// get returns the value of the key, or null if it is not set. put sets the
+// value of the key, persists the store and returns the value. Values are
+// stored as JSON, so numbers are returned as doubles.
+func storeLib() cel.EnvOption {
+	return cel.Lib(storeLibrary{})
+}
+
+type storeLibrary struct{}
+
+func (storeLibrary) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.Function("get",
+			cel.MemberOverload(
+				"store_get_string",
+				[]*cel.Type{storeType, cel.StringType},
+				cel.DynType,
+				cel.BinaryBinding(storeGet),
+			),
+		),
+		cel.Function("put",
+			cel.MemberOverload(
+				"store_put_string_dyn",
+				[]*cel.Type{storeType, cel.StringType, cel.DynType},
+				cel.DynType,
+				cel.FunctionBinding(storePut),
+			),
+		),
+	}
+}
+
+func (storeLibrary) ProgramOptions() []cel.ProgramOption { return nil }
+
+func storeGet(arg0, arg1 ref.Val) ref.Val {
+	s, ok := arg0.(storeVal)
+	if !ok {
+		return types.NoSuchOverloadErr()
+	}
+	key, ok := arg1.(types.String)
+	if !ok {
+		return types.NoSuchOverloadErr()
+	}
+	v, ok := s.store.get(string(key))
+	if !ok {
+		return types.NullValue
+	}
+	return types.DefaultTypeAdapter.NativeToValue(v)
+}
+
+func storePut(args ...ref.Val) ref.Val {
+	if len(args) != 3 {
+		return types.NoSuchOverloadErr()
+	}
+	s, ok := args[0].(storeVal)
+	if !ok {
+		return types.NoSuchOverloadErr()
+	}
+	key, ok := args[1].(types.String)
+	if !ok {
+		return types.NoSuchOverloadErr()
+	}
+	v, err := args[2].ConvertToNative(jsonValueType)
+	if err != nil {
+		return types.NewErr("failed to convert store value: %v", err)
+	}
+	err = s.store.put(string(key), v.(*structpb.Value).AsInterface())
+	if err != nil {
+		return types.NewErr("failed to persist store: %v", err)
+	}
+	return args[2]
+}
@@ -0,0 +1,117 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/libbeat/statestore/storetest"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestPersistentStore(t *testing.T) {
+	states := &testInputStore{registry: statestore.NewRegistry(storetest.NewMemoryStoreBackend())}
+	t.Cleanup(func() { _ = states.registry.Close() })
+
+	// Each run counts the runs so far in the store and returns the
+	// state, which holds the store.
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"id":               "counter",
+		"interval":         1,
+		"persistent_store": true,
+		"program": `
+			state.with({
+				"events": [{
+					"message": string(state.store.put("runs", (state.store.get("runs") == null ? 0.0 : state.store.get("runs")) + 1.0)),
+					"previous": has(state.previous) ? state.previous : "none",
+				}],
+				"previous": "set",
+			})
+		`,
+		"resource": map[string]interface{}{
+			"url": "",
+		},
+	})
+	run := func() []mapstr.M {
+		t.Helper()
+		srcs, inp, err := cursorConfigure(cfg, states)
+		if err != nil {
+			t.Fatalf("unexpected error configuring input: %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		v2Ctx := v2.Context{
+			Logger:        logp.NewLogger("cel_test"),
+			ID:            "test_id:" + t.Name(),
+			IDWithoutName: "test_id:" + t.Name(),
+			Cancelation:   ctx,
+		}
+		var client publisher
+		client.done = func() {
+			if len(client.published) >= 2 {
+				cancel()
+			}
+		}
+		err = inp.(input).run(v2Ctx, srcs[0].(*source), nil, &client)
+		if err != nil {
+			t.Fatalf("unexpected error from running input: %v", err)
+		}
+		var got []mapstr.M
+		for _, e := range client.published {
+			got = append(got, e.Fields)
+		}
+		return got
+	}
+
+	want := []mapstr.M{
+		{"message": "1", "previous": "none"},
+		{"message": "2", "previous": "set"},
+	}
+	if got := run(); !cmp.Equal(got, want) {
+		t.Errorf("unexpected events for first run:\n--- want\n+++ got\n%s", cmp.Diff(want, got))
+	}
+	// The store is retained over restarts, unlike the rest of the state.
+	want = []mapstr.M{
+		{"message": "3", "previous": "none"},
+		{"message": "4", "previous": "set"},
+	}
+	if got := run(); !cmp.Equal(got, want) {
+		t.Errorf("unexpected events after restart:\n--- want\n+++ got\n%s", cmp.Diff(want, got))
+	}
+
+	store, err := states.StoreFor("")
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	defer store.Close()
+	var got map[string]interface{}
+	err = store.Get(storeKeyPrefix+"counter::", &got)
+	if err != nil {
+		t.Fatalf("failed to get persisted store: %v", err)
+	}
+	if got["runs"] != 4.0 {
+		t.Errorf("unexpected persisted store: got:%v want runs:4", got)
+	}
+}
+
+type testInputStore struct {
+	registry *statestore.Registry
+}
+
+func (s *testInputStore) StoreFor(typ string) (*statestore.Store, error) {
+	return s.registry.Get(typ)
+}
+
+func (s *testInputStore) CleanupInterval() time.Duration {
+	return 24 * time.Hour
+}
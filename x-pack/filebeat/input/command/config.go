@@ -0,0 +1,75 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+)
+
+// Output formats of the command's stdout.
+const (
+	formatLines  = "lines"  // One event per non-empty line.
+	formatNDJSON = "ndjson" // One event per line, decoded as a JSON object.
+	formatJSON   = "json"   // One event per object of the JSON value of the whole output.
+	formatRaw    = "raw"    // One event holding the whole output.
+)
+
+type config struct {
+	Command          string           `config:"command" validate:"required"` // Path or name of the executable.
+	Args             []string         `config:"args"`                        // Arguments passed to the command.
+	Interval         time.Duration    `config:"interval"`                    // Time between the starts of command runs.
+	Timeout          time.Duration    `config:"timeout"`                     // Maximum duration of a command run.
+	WorkingDirectory string           `config:"working_directory"`           // Working directory of the command.
+	Env              []string         `config:"env"`                         // Additional KEY=VALUE environment variables.
+	InheritEnv       bool             `config:"inherit_env"`                 // Whether the command inherits the Filebeat environment.
+	Format           string           `config:"format"`                      // Format of the command output.
+	Target           string           `config:"target"`                      // Field that decoded JSON is written to, "." for the event root.
+	MaxOutputSize    cfgtype.ByteSize `config:"max_output_size"`             // Maximum size of the command output.
+}
+
+func defaultConfig() config {
+	return config{
+		Interval:      time.Minute,
+		Timeout:       30 * time.Second,
+		InheritEnv:    true,
+		Format:        formatLines,
+		Target:        "json",
+		MaxOutputSize: 10 * 1024 * 1024,
+	}
+}
+
+func (c *config) Validate() error {
+	if c.Interval <= 0 {
+		return errors.New("interval must be greater than zero")
+	}
+	if c.Timeout <= 0 {
+		return errors.New("timeout must be greater than zero")
+	}
+	if c.Timeout > c.Interval {
+		return fmt.Errorf("timeout %v must not be greater than interval %v", c.Timeout, c.Interval)
+	}
+	for _, kv := range c.Env {
+		if k, _, ok := strings.Cut(kv, "="); !ok || k == "" {
+			return fmt.Errorf("env entry %q is not of the form KEY=VALUE", kv)
+		}
+	}
+	switch c.Format {
+	case formatLines, formatNDJSON, formatJSON, formatRaw:
+	default:
+		return fmt.Errorf("unknown format %q: must be one of %s, %s, %s or %s", c.Format, formatLines, formatNDJSON, formatJSON, formatRaw)
+	}
+	if c.Target == "" {
+		return errors.New("target must not be empty")
+	}
+	if c.MaxOutputSize <= 0 {
+		return errors.New("max_output_size must be greater than zero")
+	}
+	return nil
+}
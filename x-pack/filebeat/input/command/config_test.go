@@ -0,0 +1,62 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package command
+
+import (
+	"strings"
+	"testing"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestConfig(t *testing.T) {
+	tests := map[string]struct {
+		cfg     map[string]interface{}
+		wantErr string
+	}{
+		"defaults": {
+			cfg: map[string]interface{}{"command": "date"},
+		},
+		"missing command": {
+			cfg:     map[string]interface{}{},
+			wantErr: "string value is not set accessing 'command'",
+		},
+		"timeout exceeds interval": {
+			cfg:     map[string]interface{}{"command": "date", "interval": "10s", "timeout": "1m"},
+			wantErr: "timeout 1m0s must not be greater than interval 10s",
+		},
+		"invalid env": {
+			cfg:     map[string]interface{}{"command": "date", "env": []string{"A=b", "C"}},
+			wantErr: `env entry "C" is not of the form KEY=VALUE`,
+		},
+		"unknown format": {
+			cfg:     map[string]interface{}{"command": "date", "format": "xml"},
+			wantErr: `unknown format "xml"`,
+		},
+		"max output size": {
+			cfg: map[string]interface{}{"command": "date", "max_output_size": "1KiB", "format": "json", "target": "."},
+		},
+		"zero max output size": {
+			cfg:     map[string]interface{}{"command": "date", "max_output_size": 0},
+			wantErr: "max_output_size must be greater than zero",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := defaultConfig()
+			err := conf.MustNewConfigFrom(tc.cfg).Unpack(&c)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("unexpected error: got=%v want=%q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	stateless "github.com/elastic/beats/v7/filebeat/input/v2/input-stateless"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/jsontransform"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/go-concert/ctxtool"
+	"github.com/elastic/go-concert/timed"
+)
+
+const (
+	inputName = "command"
+
+	// maxStderrSize is the amount of stderr retained for
+	// reporting failed runs.
+	maxStderrSize = 4096
+	// waitDelay is the time allowed for the command's output
+	// to be closed after it has been killed on timeout.
+	waitDelay = 5 * time.Second
+)
+
+func Plugin() v2.Plugin {
+	return v2.Plugin{
+		Name:      inputName,
+		Stability: feature.Beta,
+		Info:      "Periodically runs a command and ingests its output.",
+		Manager:   stateless.NewInputManager(configure),
+	}
+}
+
+func configure(cfg *conf.C) (stateless.Input, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+	return &commandInput{config: c}, nil
+}
+
+type commandInput struct {
+	config config
+}
+
+func (*commandInput) Name() string { return inputName }
+
+func (i *commandInput) Test(_ v2.TestContext) error {
+	_, err := exec.LookPath(i.config.Command)
+	return err
+}
+
+func (i *commandInput) Run(ctx v2.Context, pub stateless.Publisher) error {
+	ctx.UpdateStatus(status.Starting, "")
+	ctx.Logger.Info("Starting " + inputName + " input")
+	defer ctx.Logger.Info(inputName + " input stopped")
+
+	metrics := newInputMetrics(ctx.MetricsRegistry)
+	run := func() error {
+		i.run(ctx, pub, metrics)
+		return nil
+	}
+	// Run immediately and then on every interval.
+	_ = run()
+	err := timed.Periodic(ctx.Cancelation, i.config.Interval, run)
+	if ctx.Cancelation.Err() != nil {
+		ctx.UpdateStatus(status.Stopped, "")
+		return nil
+	}
+	return err
+}
+
+// run runs the command once and publishes its output.
+func (i *commandInput) run(ctx v2.Context, pub stateless.Publisher, metrics *inputMetrics) {
+	log := ctx.Logger
+	cfg := i.config
+
+	runCtx, cancel := context.WithTimeout(ctxtool.FromCanceller(ctx.Cancelation), cfg.Timeout)
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, cfg.Command, cfg.Args...)
+	cmd.Dir = cfg.WorkingDirectory
+	cmd.Env = i.environ()
+	cmd.WaitDelay = waitDelay
+	stdout := &boundedBuffer{max: int(cfg.MaxOutputSize)}
+	stderr := &boundedBuffer{max: maxStderrSize}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	log.Debugw("running command", "command", cfg.Command, "args", cfg.Args)
+	err := cmd.Run()
+	duration := time.Since(start)
+	if ctx.Cancelation.Err() != nil {
+		// The input is stopping; the run is incomplete.
+		return
+	}
+	metrics.runs.Add(1)
+	metrics.runDuration.Update(duration.Nanoseconds())
+
+	res := result{
+		start:      start,
+		duration:   duration,
+		exitCode:   -1,
+		executable: cfg.Command,
+		args:       append([]string{cfg.Command}, cfg.Args...),
+		workingDir: cfg.WorkingDirectory,
+	}
+	if cmd.Process != nil {
+		res.pid = cmd.Process.Pid
+	}
+	if cmd.ProcessState != nil {
+		res.exitCode = cmd.ProcessState.ExitCode()
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(runCtx.Err(), context.DeadlineExceeded):
+		res.err = fmt.Errorf("command timed out after %v", cfg.Timeout)
+	case errors.As(err, &exitErr):
+		res.err = fmt.Errorf("command exited with status %d", res.exitCode)
+	case err != nil:
+		res.err = fmt.Errorf("failed to run command: %w", err)
+	case stdout.truncated:
+		res.err = fmt.Errorf("command output exceeded max_output_size of %d bytes", cfg.MaxOutputSize)
+	}
+	if res.err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			res.err = fmt.Errorf("%w: %s", res.err, msg)
+		}
+		metrics.runFailures.Add(1)
+		log.Warnw("command run failed", "command", cfg.Command, "error", res.err)
+		ctx.UpdateStatus(status.Degraded, res.err.Error())
+	} else {
+		ctx.UpdateStatus(status.Running, "")
+	}
+
+	var events []beat.Event
+	if !stdout.truncated {
+		events = i.decode(stdout.Bytes(), res)
+	}
+	if len(events) == 0 {
+		// Record the run even when it produced no output.
+		events = []beat.Event{res.event()}
+	}
+	for _, e := range events {
+		pub.Publish(e)
+	}
+	metrics.eventsPublished.Add(uint64(len(events)))
+}
+
+// environ returns the environment of the command.
+func (i *commandInput) environ() []string {
+	if i.config.InheritEnv {
+		// Later entries take precedence when keys are repeated.
+		return append(os.Environ(), i.config.Env...)
+	}
+	return append([]string{}, i.config.Env...)
+}
+
+// decode returns the events described by the command output.
+func (i *commandInput) decode(out []byte, res result) []beat.Event {
+	var events []beat.Event
+	switch i.config.Format {
+	case formatRaw:
+		msg := strings.TrimRight(string(out), "\r\n")
+		if msg == "" {
+			return nil
+		}
+		e := res.event()
+		e.Fields["message"] = msg
+		events = append(events, e)
+	case formatLines:
+		for _, line := range lines(out) {
+			e := res.event()
+			e.Fields["message"] = line
+			events = append(events, e)
+		}
+	case formatNDJSON:
+		for _, line := range lines(out) {
+			events = append(events, i.decodeJSON([]byte(line), res)...)
+		}
+	case formatJSON:
+		if len(bytes.TrimSpace(out)) != 0 {
+			events = i.decodeJSON(out, res)
+		}
+	}
+	return events
+}
+
+// decodeJSON returns an event for each object in the JSON stream in
+// data, where arrays are taken to hold a sequence of objects. If data
+// does not hold objects, an event holding it as the message and the
+// decoding error is returned.
+func (i *commandInput) decodeJSON(data []byte, res result) []beat.Event {
+	var objs []mapstr.M
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	for {
+		var v interface{}
+		err := dec.Decode(&v)
+		if err == io.EOF { //nolint:errorlint // This will never be a wrapped error.
+			break
+		}
+		if err == nil {
+			objs, err = appendObjects(objs, v)
+		}
+		if err != nil {
+			e := res.event()
+			e.Fields["message"] = strings.TrimRight(string(data), "\r\n")
+			_, _ = e.Fields.Put("error.message", fmt.Sprintf("failed to decode JSON output: %v", err))
+			return []beat.Event{e}
+		}
+	}
+
+	events := make([]beat.Event, 0, len(objs))
+	for _, obj := range objs {
+		jsontransform.TransformNumbers(obj)
+		e := res.event()
+		if i.config.Target == "." {
+			obj.DeepUpdate(e.Fields)
+			e.Fields = obj
+		} else {
+			_, _ = e.Fields.Put(i.config.Target, obj)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func appendObjects(dst []mapstr.M, v interface{}) ([]mapstr.M, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return append(dst, v), nil
+	case []interface{}:
+		for _, e := range v {
+			obj, ok := e.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("array element is not an object: %T", e)
+			}
+			dst = append(dst, obj)
+		}
+		return dst, nil
+	default:
+		return nil, fmt.Errorf("value is not an object: %T", v)
+	}
+}
+
+// lines returns the non-empty lines of out.
+func lines(out []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// result is the outcome of a command run.
+type result struct {
+	start    time.Time
+	duration time.Duration
+	pid      int
+	exitCode int
+	err      error
+
+	executable string
+	args       []string
+	workingDir string
+}
+
+// event returns an event recording the run.
+func (r result) event() beat.Event {
+	outcome := "success"
+	if r.err != nil {
+		outcome = "failure"
+	}
+	fields := mapstr.M{
+		"event": mapstr.M{
+			"duration": r.duration.Nanoseconds(),
+			"outcome":  outcome,
+		},
+		"process": mapstr.M{
+			"executable": r.executable,
+			"args":       r.args,
+			"exit_code":  r.exitCode,
+		},
+	}
+	if r.workingDir != "" {
+		_, _ = fields.Put("process.working_directory", r.workingDir)
+	}
+	if r.pid != 0 {
+		_, _ = fields.Put("process.pid", r.pid)
+	}
+	if r.err != nil {
+		fields["error"] = mapstr.M{"message": r.err.Error()}
+	}
+	return beat.Event{
+		Timestamp: r.start,
+		Fields:    fields,
+	}
+}
+
+// boundedBuffer is an io.Writer that retains at most max bytes,
+// discarding the remainder of what is written. The buffer is not
+// embedded so that io.Copy cannot bypass Write with ReadFrom.
+type boundedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *boundedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if rem := b.max - b.buf.Len(); len(p) > rem {
+		p = p[:rem]
+		b.truncated = true
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+func (b *boundedBuffer) Bytes() []byte  { return b.buf.Bytes() }
+func (b *boundedBuffer) String() string { return b.buf.String() }
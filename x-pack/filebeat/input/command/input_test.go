@@ -0,0 +1,197 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package command
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+type publisher struct {
+	events []beat.Event
+}
+
+func (p *publisher) Publish(e beat.Event) {
+	p.events = append(p.events, e)
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test commands require a POSIX shell")
+	}
+
+	shell := func(script string) []string { return []string{"-c", script} }
+	process := func(script string, code int) mapstr.M {
+		return mapstr.M{
+			"executable": "sh",
+			"args":       []string{"sh", "-c", script},
+			"exit_code":  code,
+		}
+	}
+	success := mapstr.M{"outcome": "success"}
+	failure := mapstr.M{"outcome": "failure"}
+
+	tests := []struct {
+		name string
+		cfg  func(*config)
+		want []mapstr.M
+	}{
+		{
+			name: "lines",
+			cfg: func(c *config) {
+				c.Args = shell(`printf 'one\r\n\ntwo\n'`)
+			},
+			want: []mapstr.M{
+				{"message": "one", "event": success, "process": process(`printf 'one\r\n\ntwo\n'`, 0)},
+				{"message": "two", "event": success, "process": process(`printf 'one\r\n\ntwo\n'`, 0)},
+			},
+		},
+		{
+			name: "raw",
+			cfg: func(c *config) {
+				c.Format = formatRaw
+				c.Args = shell(`printf 'one\ntwo\n'`)
+			},
+			want: []mapstr.M{
+				{"message": "one\ntwo", "event": success, "process": process(`printf 'one\ntwo\n'`, 0)},
+			},
+		},
+		{
+			name: "ndjson",
+			cfg: func(c *config) {
+				c.Format = formatNDJSON
+				c.Args = shell(`printf '{"a":1}\n{"b":"x"}\nnot json\n'`)
+			},
+			want: []mapstr.M{
+				{"json": mapstr.M{"a": int64(1)}, "event": success, "process": process(`printf '{"a":1}\n{"b":"x"}\nnot json\n'`, 0)},
+				{"json": mapstr.M{"b": "x"}, "event": success, "process": process(`printf '{"a":1}\n{"b":"x"}\nnot json\n'`, 0)},
+				{
+					"message": "not json",
+					"error":   mapstr.M{"message": "failed to decode JSON output: invalid character 'o' in literal null (expecting 'u')"},
+					"event":   success,
+					"process": process(`printf '{"a":1}\n{"b":"x"}\nnot json\n'`, 0),
+				},
+			},
+		},
+		{
+			name: "json_array_root",
+			cfg: func(c *config) {
+				c.Format = formatJSON
+				c.Target = "."
+				c.Args = shell(`echo '[{"a":1.5},{"event":{"kind":"metric"}}]'`)
+			},
+			want: []mapstr.M{
+				{"a": 1.5, "event": success, "process": process(`echo '[{"a":1.5},{"event":{"kind":"metric"}}]'`, 0)},
+				{"event": mapstr.M{"kind": "metric", "outcome": "success"}, "process": process(`echo '[{"a":1.5},{"event":{"kind":"metric"}}]'`, 0)},
+			},
+		},
+		{
+			name: "exit_status",
+			cfg: func(c *config) {
+				c.Args = shell(`echo partial; echo broken >&2; exit 3`)
+			},
+			want: []mapstr.M{
+				{
+					"message": "partial",
+					"error":   mapstr.M{"message": "command exited with status 3: broken"},
+					"event":   failure,
+					"process": process(`echo partial; echo broken >&2; exit 3`, 3),
+				},
+			},
+		},
+		{
+			name: "no_output",
+			cfg: func(c *config) {
+				c.Args = shell(`true`)
+			},
+			want: []mapstr.M{
+				{"event": success, "process": process(`true`, 0)},
+			},
+		},
+		{
+			name: "env",
+			cfg: func(c *config) {
+				c.InheritEnv = false
+				c.Env = []string{"GREETING=hello", "GREETING=hi"}
+				c.Args = shell(`echo "$GREETING${HOME:-}"`)
+			},
+			want: []mapstr.M{
+				{"message": "hi", "event": success, "process": process(`echo "$GREETING${HOME:-}"`, 0)},
+			},
+		},
+		{
+			name: "timeout",
+			cfg: func(c *config) {
+				c.Timeout = 100 * time.Millisecond
+				c.Args = shell(`exec sleep 10`)
+			},
+			want: []mapstr.M{
+				{
+					"error":   mapstr.M{"message": "command timed out after 100ms"},
+					"event":   failure,
+					"process": process(`exec sleep 10`, -1),
+				},
+			},
+		},
+		{
+			name: "output_too_large",
+			cfg: func(c *config) {
+				c.MaxOutputSize = 4
+				c.Args = shell(`echo 0123456789`)
+			},
+			want: []mapstr.M{
+				{
+					"error":   mapstr.M{"message": "command output exceeded max_output_size of 4 bytes"},
+					"event":   failure,
+					"process": process(`echo 0123456789`, 0),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := defaultConfig()
+			cfg.Command = "sh"
+			test.cfg(&cfg)
+			if err := cfg.Validate(); err != nil {
+				t.Fatalf("invalid test config: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			reg := monitoring.NewRegistry()
+			inp := &commandInput{config: cfg}
+			var pub publisher
+			inp.run(v2.Context{
+				Logger:          logp.NewLogger(inputName),
+				ID:              test.name,
+				Cancelation:     ctx,
+				MetricsRegistry: reg,
+			}, &pub, newInputMetrics(reg))
+
+			var got []mapstr.M
+			for _, e := range pub.events {
+				// Remove values that vary between runs.
+				_ = e.Fields.Delete("event.duration")
+				_ = e.Fields.Delete("process.pid")
+				got = append(got, e.Fields)
+			}
+			if !cmp.Equal(test.want, got) {
+				t.Errorf("unexpected events:\n--- want\n+++ got\n%s", cmp.Diff(test.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package command
+
+import (
+	"github.com/rcrowley/go-metrics"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/monitoring/adapter"
+)
+
+type inputMetrics struct {
+	runs            *monitoring.Uint // Number of command runs.
+	runFailures     *monitoring.Uint // Number of command runs that failed, timed out or exceeded the output limit.
+	eventsPublished *monitoring.Uint // Number of events published.
+	runDuration     metrics.Sample   // Histogram of the command run durations in nanoseconds.
+}
+
+func newInputMetrics(reg *monitoring.Registry) *inputMetrics {
+	out := &inputMetrics{
+		runs:            monitoring.NewUint(reg, "runs_total"),
+		runFailures:     monitoring.NewUint(reg, "run_failures_total"),
+		eventsPublished: monitoring.NewUint(reg, "events_published_total"),
+		runDuration:     metrics.NewUniformSample(1024),
+	}
+	_ = adapter.NewGoMetrics(reg, "run_duration", adapter.Accept).
+		Register("histogram", metrics.NewHistogram(out.runDuration))
+
+	return out
+}
@@ -13,8 +13,9 @@ import (
 )
 
 func Init(info beat.Info, log *logp.Logger, store statestore.States) []v2.Plugin {
-	return append(
+	plugins := append(
 		xpackInputs(info, log, store),
-		ossinputs.Init(info, log, store)...,
+		nonFIPSInputs()...,
 	)
+	return append(plugins, ossinputs.Init(info, log, store)...)
 }
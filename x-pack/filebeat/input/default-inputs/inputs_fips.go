@@ -0,0 +1,17 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build requirefips
+
+package inputs
+
+import (
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+)
+
+// nonFIPSInputs returns the inputs that are not compiled into FIPS
+// distributions.
+func nonFIPSInputs() []v2.Plugin {
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package gcplogging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2/google"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+)
+
+const (
+	modeTail = "tail"
+	modePoll = "poll"
+
+	// loggingReadScope is the OAuth2 scope required to read log entries.
+	loggingReadScope = "https://www.googleapis.com/auth/logging.read"
+)
+
+type config struct {
+	// Google Cloud project ID. Used as the resource name when
+	// resource_names is empty.
+	ProjectID string `config:"project_id"`
+
+	// Names of the projects, folders, organizations, billing accounts or
+	// log views to read log entries from.
+	ResourceNames []string `config:"resource_names"`
+
+	// Logging query language filter selecting the log entries to collect.
+	Filter string `config:"filter"`
+
+	// Collection mode. In tail mode new entries are streamed with the
+	// tailLogEntries API, in poll mode entries.list is called each interval.
+	Mode string `config:"mode"`
+
+	// Time between entries.list calls in poll mode.
+	Interval time.Duration `config:"interval" validate:"positive"`
+
+	// How far back to read log entries when no cursor is stored.
+	InitialInterval time.Duration `config:"initial_interval" validate:"min=0"`
+
+	// Maximum number of entries returned by each entries.list call.
+	PageSize int `config:"page_size" validate:"min=1,max=1000"`
+
+	// Time the tailLogEntries API waits to return entries in order.
+	BufferWindow time.Duration `config:"buffer_window" validate:"min=0"`
+
+	// JSON file containing authentication credentials and key.
+	CredentialsFile string `config:"credentials_file"`
+
+	// JSON blob containing authentication credentials and key.
+	CredentialsJSON common.JSONBlob `config:"credentials_json"`
+
+	// Overrides the default Cloud Logging service address and disables TLS. For testing.
+	AlternativeHost string `config:"alternative_host"`
+}
+
+func (c *config) Validate() error {
+	if c.ProjectID == "" && len(c.ResourceNames) == 0 {
+		return errors.New("at least one of project_id or resource_names must be configured")
+	}
+	for _, name := range c.ResourceNames {
+		if !validResourceName(name) {
+			return fmt.Errorf("invalid resource name %q", name)
+		}
+	}
+
+	switch c.Mode {
+	case modeTail, modePoll:
+	default:
+		return fmt.Errorf("invalid mode %q, must be one of %s or %s", c.Mode, modeTail, modePoll)
+	}
+	if c.BufferWindow > time.Minute {
+		return errors.New("buffer_window must not be greater than 1m")
+	}
+
+	if c.AlternativeHost != "" {
+		return nil
+	}
+
+	// credentials_file
+	if c.CredentialsFile != "" {
+		if _, err := os.Stat(c.CredentialsFile); os.IsNotExist(err) {
+			return fmt.Errorf("credentials_file is configured, but the file %q cannot be found", c.CredentialsFile)
+		}
+		return nil
+	}
+
+	// credentials_json
+	if len(c.CredentialsJSON) > 0 {
+		return nil
+	}
+
+	// Application Default Credentials (ADC)
+	ctx := context.Background()
+	if _, err := google.FindDefaultCredentials(ctx, loggingReadScope); err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("no authentication credentials were configured or detected " +
+		"(credentials_file, credentials_json, and application default credentials (ADC))")
+}
+
+// validResourceName returns whether name is a resource name accepted by
+// the entries.list and tailLogEntries APIs.
+func validResourceName(name string) bool {
+	for _, prefix := range []string{"projects/", "organizations/", "folders/", "billingAccounts/"} {
+		id, ok := strings.CutPrefix(name, prefix)
+		if ok {
+			return id != "" && !strings.HasPrefix(id, "/")
+		}
+	}
+	return false
+}
+
+// resourceNames returns the configured resource names, defaulting to the
+// project of project_id.
+func (c *config) resourceNames() []string {
+	if len(c.ResourceNames) != 0 {
+		return c.ResourceNames
+	}
+	return []string{"projects/" + c.ProjectID}
+}
+
+func defaultConfig() config {
+	return config{
+		Mode:            modeTail,
+		Interval:        time.Minute,
+		InitialInterval: time.Hour,
+		PageSize:        1000,
+		BufferWindow:    2 * time.Second,
+	}
+}
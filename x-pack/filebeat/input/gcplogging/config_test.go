@@ -0,0 +1,92 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package gcplogging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		cfg     map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "project_id",
+			cfg:  map[string]interface{}{"project_id": "a-project"},
+		},
+		{
+			name: "resource_names",
+			cfg: map[string]interface{}{
+				"resource_names": []string{"organizations/123", "folders/456", "billingAccounts/789", "projects/a-project/locations/global/buckets/a-bucket/views/a-view"},
+				"mode":           "poll",
+			},
+		},
+		{
+			name:    "missing_resource",
+			cfg:     map[string]interface{}{},
+			wantErr: "at least one of project_id or resource_names must be configured",
+		},
+		{
+			name:    "invalid_resource_name",
+			cfg:     map[string]interface{}{"resource_names": []string{"a-project"}},
+			wantErr: `invalid resource name "a-project"`,
+		},
+		{
+			name:    "invalid_mode",
+			cfg:     map[string]interface{}{"project_id": "a-project", "mode": "stream"},
+			wantErr: `invalid mode "stream", must be one of tail or poll`,
+		},
+		{
+			name:    "buffer_window",
+			cfg:     map[string]interface{}{"project_id": "a-project", "buffer_window": "2m"},
+			wantErr: "buffer_window must not be greater than 1m",
+		},
+		{
+			name:    "page_size",
+			cfg:     map[string]interface{}{"project_id": "a-project", "page_size": 5000},
+			wantErr: "requires value <= 1000",
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			test.cfg["credentials_json"] = `{}`
+			c := defaultConfig()
+			err := conf.MustNewConfigFrom(test.cfg).Unpack(&c)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigResourceNames(t *testing.T) {
+	c := defaultConfig()
+	c.ProjectID = "a-project"
+	assert.Equal(t, []string{"projects/a-project"}, c.resourceNames())
+
+	c.ResourceNames = []string{"folders/123"}
+	assert.Equal(t, []string{"folders/123"}, c.resourceNames())
+}
+
+func TestSourceName(t *testing.T) {
+	c := defaultConfig()
+	c.ResourceNames = []string{"projects/a", "folders/123"}
+	assert.Equal(t, "projects/a,folders/123", newSource(c).Name())
+
+	c.Filter = "severity>=ERROR"
+	withFilter := newSource(c).Name()
+	assert.Regexp(t, `^projects/a,folders/123::[0-9a-f]{16}$`, withFilter)
+
+	c.Filter = "severity>=WARNING"
+	assert.NotEqual(t, withFilter, newSource(c).Name())
+}
@@ -0,0 +1,394 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package gcplogging
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	logging "cloud.google.com/go/logging/apiv2"
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"golang.org/x/time/rate"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	// Register the audit log payload types so that protoPayload fields of
+	// audit log entries can be rendered as JSON.
+	_ "google.golang.org/genproto/googleapis/cloud/audit"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	inputcursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/libbeat/version"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/useragent"
+	"github.com/elastic/go-concert/timed"
+)
+
+const (
+	inputName = "gcp-logging"
+
+	// retryInterval is the minimum duration between Cloud Logging client retries.
+	retryInterval = 30 * time.Second
+)
+
+// Plugin returns the GCP Cloud Logging input plugin.
+func Plugin(log *logp.Logger, store statestore.States) v2.Plugin {
+	return v2.Plugin{
+		Name:       inputName,
+		Stability:  feature.Beta,
+		Deprecated: false,
+		Info:       "Google Cloud Logging",
+		Doc:        "Collect log entries from the Google Cloud Logging API",
+		Manager: &inputcursor.InputManager{
+			Logger:     log,
+			StateStore: store,
+			Type:       inputName,
+			Configure:  configure,
+		},
+	}
+}
+
+func configure(cfg *conf.C) ([]inputcursor.Source, inputcursor.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, nil, err
+	}
+	return []inputcursor.Source{newSource(config)}, &loggingInput{config: config}, nil
+}
+
+// source identifies the log entries selected by the resource names and
+// filter of an input, so changing either starts a new cursor.
+type source struct {
+	name string
+}
+
+func newSource(cfg config) *source {
+	name := strings.Join(cfg.resourceNames(), ",")
+	if cfg.Filter != "" {
+		h := sha256.Sum256([]byte(cfg.Filter))
+		name += "::" + hex.EncodeToString(h[:])[:16]
+	}
+	return &source{name: name}
+}
+
+func (s *source) Name() string { return s.name }
+
+// cursorState is the state persisted for each source. IDs holds the IDs of
+// the entries already published with the cursor timestamp, since entries
+// are selected starting at that timestamp.
+type cursorState struct {
+	Timestamp time.Time `json:"timestamp" struct:"timestamp"`
+	IDs       []string  `json:"ids" struct:"ids"`
+}
+
+type loggingInput struct {
+	config config
+}
+
+func (in *loggingInput) Name() string { return inputName }
+
+func (in *loggingInput) Test(inputcursor.Source, v2.TestContext) error { return nil }
+
+// Run collects log entries until the input is cancelled. Errors of the
+// collector are logged and the collector is restarted from the last cursor.
+func (in *loggingInput) Run(inputCtx v2.Context, src inputcursor.Source, crsr inputcursor.Cursor, pub inputcursor.Publisher) error {
+	stat := inputCtx.StatusReporter
+	if stat == nil {
+		stat = noopReporter{}
+	}
+	stat.UpdateStatus(status.Starting, "")
+	stat.UpdateStatus(status.Configuring, "")
+
+	log := inputCtx.Logger.Named("gcp.logging").With("resource_names", in.config.resourceNames())
+
+	metrics := newInputMetrics(inputCtx.ID, nil)
+	defer metrics.Close()
+	metrics.resourceNames.Set(strings.Join(in.config.resourceNames(), ","))
+
+	c := &collector{
+		config:  in.config,
+		pub:     pub,
+		status:  stat,
+		log:     log,
+		metrics: metrics,
+	}
+	if !crsr.IsNew() {
+		if err := crsr.Unpack(&c.cursor); err != nil {
+			log.Warnw("Failed to read cursor state, starting from initial_interval.", "error", err)
+			c.cursor = cursorState{}
+		}
+	}
+	if c.cursor.Timestamp.IsZero() {
+		c.cursor.Timestamp = time.Now().Add(-in.config.InitialInterval).UTC()
+	}
+
+	ctx := v2.GoContextFromCanceler(inputCtx.Cancelation)
+	stop := context.AfterFunc(ctx, func() {
+		stat.UpdateStatus(status.Stopping, "")
+	})
+	defer stop()
+
+	log.Infow("Cloud Logging input worker has started.", "mode", in.config.Mode, "cursor", c.cursor.Timestamp)
+	defer func() {
+		log.Info("Cloud Logging input worker has stopped.")
+		stat.UpdateStatus(status.Stopped, "")
+	}()
+
+	// Throttle client restarts.
+	rt := rate.NewLimiter(rate.Every(retryInterval), 1)
+
+	// Watchdog to keep the collector operating after an error.
+	for ctx.Err() == nil {
+		if err := rt.Wait(ctx); err != nil {
+			continue
+		}
+
+		err := c.run(ctx)
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			metrics.apiErrors.Inc()
+			stat.UpdateStatus(status.Degraded, err.Error())
+			log.Warnw("Restarting failed Cloud Logging input worker.", "error", err)
+		}
+	}
+	return nil
+}
+
+type noopReporter struct{}
+
+func (noopReporter) UpdateStatus(status.Status, string) {}
+
+// collector reads log entries from the Cloud Logging API and publishes them.
+type collector struct {
+	config  config
+	client  *logging.Client
+	pub     inputcursor.Publisher
+	status  status.StatusReporter
+	log     *logp.Logger
+	metrics *inputMetrics
+
+	cursor cursorState
+}
+
+func (c *collector) run(ctx context.Context) error {
+	client, err := c.newClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create cloud logging client: %w", err)
+	}
+	defer client.Close()
+	c.client = client
+
+	if c.config.Mode == modePoll {
+		return c.poll(ctx)
+	}
+	return c.tail(ctx)
+}
+
+// poll lists the entries written since the cursor each interval.
+func (c *collector) poll(ctx context.Context) error {
+	if err := c.list(ctx); err != nil {
+		return err
+	}
+	c.status.UpdateStatus(status.Running, "")
+	return timed.Periodic(ctx, c.config.Interval, func() error {
+		err := c.list(ctx)
+		if err != nil && ctx.Err() == nil {
+			c.metrics.apiErrors.Inc()
+			c.status.UpdateStatus(status.Degraded, err.Error())
+			c.log.Warnw("Failed to list log entries.", "error", err)
+			return nil
+		}
+		c.status.UpdateStatus(status.Running, "")
+		return err
+	})
+}
+
+// tail streams new entries with a tail session. Entries written since the
+// cursor are listed once the session is open so that no entries are missed
+// between the previous run and the start of the session.
+func (c *collector) tail(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.TailLogEntries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start tailing log entries: %w", err)
+	}
+	err = stream.Send(&loggingpb.TailLogEntriesRequest{
+		ResourceNames: c.config.resourceNames(),
+		Filter:        c.config.Filter,
+		BufferWindow:  durationpb.New(c.config.BufferWindow),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start tailing log entries: %w", err)
+	}
+
+	if err := c.list(ctx); err != nil {
+		return err
+	}
+	c.status.UpdateStatus(status.Running, "")
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				c.log.Debug("Tail session ended.")
+				return nil
+			}
+			return fmt.Errorf("failed to tail log entries: %w", err)
+		}
+		for _, s := range resp.GetSuppressionInfo() {
+			c.metrics.suppressedEntries.Add(uint64(s.GetSuppressedCount()))
+			c.log.Warnw("Log entries were suppressed by the tail session.", "reason", s.GetReason().String(), "count", s.GetSuppressedCount())
+		}
+		for _, e := range resp.GetEntries() {
+			if err := c.publish(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// list publishes the entries with a timestamp at or after the cursor.
+func (c *collector) list(ctx context.Context) error {
+	it := c.client.ListLogEntries(ctx, &loggingpb.ListLogEntriesRequest{
+		ResourceNames: c.config.resourceNames(),
+		Filter:        listFilter(c.config.Filter, c.cursor.Timestamp),
+		OrderBy:       "timestamp asc",
+		PageSize:      int32(c.config.PageSize), //nolint:gosec // page_size is validated to be at most 1000.
+	})
+	for {
+		e, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list log entries: %w", err)
+		}
+		if err := c.publish(e); err != nil {
+			return err
+		}
+	}
+}
+
+// listFilter returns filter restricted to entries with a timestamp at or
+// after since.
+func listFilter(filter string, since time.Time) string {
+	ts := fmt.Sprintf("timestamp >= %q", since.UTC().Format(time.RFC3339Nano))
+	if filter == "" {
+		return ts
+	}
+	return "(" + filter + ") AND " + ts
+}
+
+// publish publishes e and advances the cursor. Entries that were already
+// published with the cursor timestamp are skipped.
+func (c *collector) publish(e *loggingpb.LogEntry) error {
+	c.metrics.receivedEntries.Inc()
+
+	id := entryID(e)
+	ts := e.GetTimestamp().AsTime().UTC()
+	switch {
+	case ts.Before(c.cursor.Timestamp):
+		// Late entry delivered by a tail session, the cursor is not moved back.
+	case ts.Equal(c.cursor.Timestamp):
+		if slices.Contains(c.cursor.IDs, id) {
+			return nil
+		}
+		c.cursor.IDs = append(c.cursor.IDs, id)
+	default:
+		c.cursor = cursorState{Timestamp: ts, IDs: []string{id}}
+	}
+
+	err := c.pub.Publish(makeEvent(id, ts, e), cursorState{
+		Timestamp: c.cursor.Timestamp,
+		IDs:       slices.Clone(c.cursor.IDs),
+	})
+	if err != nil {
+		return err
+	}
+	c.metrics.publishedEvents.Inc()
+	return nil
+}
+
+// entryID returns a unique ID for e. Entries are unique by log name,
+// timestamp and insert ID.
+func entryID(e *loggingpb.LogEntry) string {
+	h := sha256.New()
+	h.Write([]byte(e.GetLogName()))
+	h.Write([]byte(e.GetTimestamp().AsTime().UTC().Format(time.RFC3339Nano)))
+	h.Write([]byte(e.GetInsertId()))
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// makeEvent returns an event holding e in the JSON form used by log sinks
+// exporting to Pub/Sub, so that the same processing can be used for both.
+func makeEvent(id string, ts time.Time, e *loggingpb.LogEntry) beat.Event {
+	event := beat.Event{
+		Timestamp: ts,
+		Fields: mapstr.M{
+			"event": mapstr.M{
+				"id":      id,
+				"created": time.Now().UTC(),
+			},
+		},
+	}
+	event.SetID(id)
+
+	msg, err := protojson.Marshal(e)
+	if err != nil {
+		// The payload type is not known, keep the remaining entry fields.
+		e = proto.Clone(e).(*loggingpb.LogEntry) //nolint:errcheck // Clone returns the type of its argument.
+		e.Payload = nil
+		msg, _ = protojson.Marshal(e)
+		_, _ = event.PutValue("error.message", "failed to render log entry payload: "+err.Error())
+	}
+	event.Fields["message"] = string(msg)
+	return event
+}
+
+func (c *collector) newClient(ctx context.Context) (*logging.Client, error) {
+	opts := make([]option.ClientOption, 0, 3)
+
+	if c.config.AlternativeHost != "" {
+		// This will be typically set because we want to point the input to a testing server.
+		conn, err := grpc.NewClient(c.config.AlternativeHost, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot connect to alternative host %q: %w", c.config.AlternativeHost, err)
+		}
+		opts = append(opts, option.WithGRPCConn(conn), option.WithTelemetryDisabled())
+	}
+
+	if c.config.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(c.config.CredentialsFile))
+	} else if len(c.config.CredentialsJSON) > 0 {
+		opts = append(opts, option.WithCredentialsJSON(c.config.CredentialsJSON))
+	}
+
+	userAgent := useragent.UserAgent("Filebeat", version.GetDefaultVersion(), version.Commit(), version.BuildTime().String())
+	opts = append(opts, option.WithUserAgent(userAgent))
+
+	return logging.NewClient(ctx, opts...)
+}
@@ -0,0 +1,286 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package gcplogging
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging/apiv2/loggingpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	auditpb "google.golang.org/genproto/googleapis/cloud/audit"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+var testStart = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+// fakeLoggingServer serves a fixed set of log entries from entries.list
+// and streams tail entries to tail sessions.
+type fakeLoggingServer struct {
+	loggingpb.UnimplementedLoggingServiceV2Server
+
+	pageSize int
+	entries  []*loggingpb.LogEntry
+	tail     []*loggingpb.LogEntry
+
+	mu      sync.Mutex
+	filters []string
+	tails   []*loggingpb.TailLogEntriesRequest
+}
+
+func (s *fakeLoggingServer) ListLogEntries(_ context.Context, req *loggingpb.ListLogEntriesRequest) (*loggingpb.ListLogEntriesResponse, error) {
+	s.mu.Lock()
+	s.filters = append(s.filters, req.GetFilter())
+	s.mu.Unlock()
+
+	// Entries are filtered with the timestamp restriction added by the input.
+	var since time.Time
+	if i := len(req.GetFilter()) - len(`"2006-01-02T15:04:05Z"`); i >= 0 {
+		since, _ = time.Parse(`"`+time.RFC3339Nano+`"`, req.GetFilter()[i:])
+	}
+	var matching []*loggingpb.LogEntry
+	for _, e := range s.entries {
+		if !e.GetTimestamp().AsTime().Before(since) {
+			matching = append(matching, e)
+		}
+	}
+
+	start := 0
+	if req.GetPageToken() != "" {
+		start, _ = strconv.Atoi(req.GetPageToken())
+	}
+	end := min(start+s.pageSize, len(matching))
+	resp := &loggingpb.ListLogEntriesResponse{Entries: matching[start:end]}
+	if end < len(matching) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+	return resp, nil
+}
+
+func (s *fakeLoggingServer) TailLogEntries(stream loggingpb.LoggingServiceV2_TailLogEntriesServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.tails = append(s.tails, req)
+	s.mu.Unlock()
+	err = stream.Send(&loggingpb.TailLogEntriesResponse{
+		Entries: s.tail,
+		SuppressionInfo: []*loggingpb.TailLogEntriesResponse_SuppressionInfo{{
+			Reason:          loggingpb.TailLogEntriesResponse_SuppressionInfo_RATE_LIMIT,
+			SuppressedCount: 3,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+func newFakeServer(t *testing.T, srv *fakeLoggingServer) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	loggingpb.RegisterLoggingServiceV2Server(s, srv)
+	go func() { _ = s.Serve(l) }()
+	t.Cleanup(s.Stop)
+	return l.Addr().String()
+}
+
+func testEntry(insertID string, offset time.Duration) *loggingpb.LogEntry {
+	return &loggingpb.LogEntry{
+		LogName:   "projects/a-project/logs/cloudaudit.googleapis.com%2Factivity",
+		InsertId:  insertID,
+		Timestamp: timestamppb.New(testStart.Add(offset)),
+		Payload:   &loggingpb.LogEntry_TextPayload{TextPayload: "entry " + insertID},
+	}
+}
+
+// publisher records published events and the last cursor.
+type publisher struct {
+	mu     sync.Mutex
+	events []beat.Event
+	cursor cursorState
+}
+
+func (p *publisher) Publish(event beat.Event, cursor interface{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	p.cursor = cursor.(cursorState)
+	return nil
+}
+
+func (p *publisher) len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.events)
+}
+
+func newTestCollector(t *testing.T, cfg config, cursor cursorState, pub *publisher) *collector {
+	t.Helper()
+	c := &collector{
+		config:  cfg,
+		pub:     pub,
+		status:  noopReporter{},
+		log:     logp.NewLogger("gcplogging_test"),
+		metrics: newInputMetrics("test", monitoring.NewRegistry()),
+		cursor:  cursor,
+	}
+	client, err := c.newClient(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	c.client = client
+	return c
+}
+
+func messages(events []beat.Event) []string {
+	var msgs []string
+	for _, e := range events {
+		m, _ := e.Fields.GetValue("message")
+		msgs = append(msgs, m.(string))
+	}
+	return msgs
+}
+
+func TestListResumesFromCursor(t *testing.T) {
+	srv := &fakeLoggingServer{
+		pageSize: 2,
+		entries: []*loggingpb.LogEntry{
+			testEntry("a", 0),
+			testEntry("b", time.Second),
+			testEntry("c", time.Second),
+			testEntry("d", 2*time.Second),
+		},
+	}
+	cfg := defaultConfig()
+	cfg.ProjectID = "a-project"
+	cfg.Filter = `logName:"cloudaudit.googleapis.com"`
+	cfg.AlternativeHost = newFakeServer(t, srv)
+
+	pub := &publisher{}
+	c := newTestCollector(t, cfg, cursorState{Timestamp: testStart}, pub)
+	require.NoError(t, c.list(context.Background()))
+	require.Len(t, pub.events, 4)
+	assert.Equal(t, cursorState{Timestamp: testStart.Add(2 * time.Second), IDs: []string{entryID(srv.entries[3])}}, pub.cursor)
+	assert.Equal(t, `(logName:"cloudaudit.googleapis.com") AND timestamp >= "2026-10-15T12:00:00Z"`, srv.filters[0])
+
+	e := pub.events[1]
+	assert.Equal(t, testStart.Add(time.Second), e.Timestamp)
+	assert.Equal(t, entryID(srv.entries[1]), e.Meta["_id"])
+	assert.JSONEq(t, `{"logName":"projects/a-project/logs/cloudaudit.googleapis.com%2Factivity","insertId":"b","timestamp":"2026-10-15T12:00:01Z","textPayload":"entry b"}`, messages(pub.events)[1])
+
+	// A restarted collector skips the entries published with the cursor
+	// timestamp and only publishes new entries.
+	srv.entries = append(srv.entries, testEntry("e", 2*time.Second), testEntry("f", 3*time.Second))
+	pub = &publisher{}
+	c = newTestCollector(t, cfg, c.cursor, pub)
+	require.NoError(t, c.list(context.Background()))
+	require.Len(t, pub.events, 2)
+	assert.Contains(t, messages(pub.events)[0], `"insertId":"e"`)
+	assert.Contains(t, messages(pub.events)[1], `"insertId":"f"`)
+	assert.Equal(t, `(logName:"cloudaudit.googleapis.com") AND timestamp >= "2026-10-15T12:00:02Z"`, srv.filters[len(srv.filters)-1])
+}
+
+func TestTail(t *testing.T) {
+	srv := &fakeLoggingServer{
+		pageSize: 10,
+		entries: []*loggingpb.LogEntry{
+			testEntry("a", 0),
+			testEntry("b", time.Second),
+		},
+		tail: []*loggingpb.LogEntry{
+			// Already listed while the tail session started.
+			testEntry("b", time.Second),
+			testEntry("c", 2*time.Second),
+			// Late entry, published without moving the cursor back.
+			testEntry("late", 500*time.Millisecond),
+		},
+	}
+	cfg := defaultConfig()
+	cfg.ResourceNames = []string{"projects/a-project", "folders/123"}
+	cfg.Filter = "severity>=WARNING"
+	cfg.AlternativeHost = newFakeServer(t, srv)
+
+	pub := &publisher{}
+	c := newTestCollector(t, cfg, cursorState{Timestamp: testStart}, pub)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- c.tail(ctx) }()
+
+	require.Eventually(t, func() bool { return pub.len() == 4 }, 10*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	msgs := messages(pub.events)
+	for i, id := range []string{"a", "b", "c", "late"} {
+		assert.Contains(t, msgs[i], `"insertId":"`+id+`"`)
+	}
+	assert.Equal(t, testStart.Add(2*time.Second), pub.cursor.Timestamp)
+
+	require.Len(t, srv.tails, 1)
+	assert.Equal(t, []string{"projects/a-project", "folders/123"}, srv.tails[0].GetResourceNames())
+	assert.Equal(t, "severity>=WARNING", srv.tails[0].GetFilter())
+	assert.Equal(t, 2*time.Second, srv.tails[0].GetBufferWindow().AsDuration())
+	assert.Equal(t, uint64(3), c.metrics.suppressedEntries.Get())
+}
+
+func TestMakeEventPayload(t *testing.T) {
+	audit, err := anypb.New(&auditpb.AuditLog{MethodName: "storage.buckets.create"})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name    string
+		payload *anypb.Any
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "audit_log",
+			payload: audit,
+			want:    `"protoPayload":{"@type":"type.googleapis.com/google.cloud.audit.AuditLog","methodName":"storage.buckets.create"}`,
+		},
+		{
+			name:    "unknown_type",
+			payload: &anypb.Any{TypeUrl: "type.googleapis.com/an.unknown.Type"},
+			wantErr: true,
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			e := testEntry("a", 0)
+			e.Payload = &loggingpb.LogEntry_ProtoPayload{ProtoPayload: test.payload}
+			event := makeEvent("an-id", testStart, e)
+
+			msg, err := event.Fields.GetValue("message")
+			require.NoError(t, err)
+			assert.Contains(t, msg, `"insertId":"a"`)
+			if test.want != "" {
+				assert.Contains(t, strings.ReplaceAll(msg.(string), " ", ""), test.want)
+			}
+			_, err = event.Fields.GetValue("error.message")
+			assert.Equal(t, test.wantErr, err == nil)
+			assert.Equal(t, "an-id", event.Fields["event"].(mapstr.M)["id"])
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package gcplogging
+
+import (
+	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// inputMetrics handles the input's metric reporting.
+type inputMetrics struct {
+	unregister func()
+
+	resourceNames     *monitoring.String // Resource names the input reads from.
+	receivedEntries   *monitoring.Uint   // Number of log entries received from the API.
+	publishedEvents   *monitoring.Uint   // Number of events published.
+	suppressedEntries *monitoring.Uint   // Number of log entries the tailLogEntries API reported as suppressed.
+	apiErrors         *monitoring.Uint   // Number of failed API calls.
+}
+
+func newInputMetrics(id string, optionalParent *monitoring.Registry) *inputMetrics {
+	reg, unreg := inputmon.NewInputRegistry(inputName, id, optionalParent)
+
+	return &inputMetrics{
+		unregister:        unreg,
+		resourceNames:     monitoring.NewString(reg, "resource_names"),
+		receivedEntries:   monitoring.NewUint(reg, "received_entries_total"),
+		publishedEvents:   monitoring.NewUint(reg, "published_events_total"),
+		suppressedEntries: monitoring.NewUint(reg, "suppressed_entries_total"),
+		apiErrors:         monitoring.NewUint(reg, "api_errors_total"),
+	}
+}
+
+func (m *inputMetrics) Close() {
+	if m == nil {
+		return
+	}
+	m.unregister()
+}
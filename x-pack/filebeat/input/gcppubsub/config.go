@@ -12,7 +12,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/elastic/beats/v7/filebeat/harvester"
 	"github.com/elastic/beats/v7/libbeat/common"
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
 
@@ -21,7 +20,6 @@ import (
 )
 
 type config struct {
-	harvester.ForwarderConfig `config:",inline"`
 	// Google Cloud project name.
 	ProjectID string `config:"project_id" validate:"required"`
 
@@ -34,6 +32,18 @@ type config struct {
 		NumGoroutines          int    `config:"num_goroutines"`
 		MaxOutstandingMessages int    `config:"max_outstanding_messages"`
 		Create                 bool   `config:"create"`
+
+		// Maximum number of times a message is delivered before it is
+		// removed from the subscription. Zero means no limit.
+		MaxDeliveryAttempts int `config:"max_delivery_attempts" validate:"min=0"`
+
+		// Topic that messages exceeding max_delivery_attempts are forwarded
+		// to. If empty, those messages are dropped.
+		DeadLetterTopic string `config:"dead_letter_topic"`
+
+		// Publish the messages of an ordering key one at a time, in the
+		// order they were received.
+		EnableMessageOrdering bool `config:"enable_message_ordering"`
 	} `config:"subscription"`
 
 	// JSON file containing authentication credentials and key.
@@ -42,6 +52,9 @@ type config struct {
 	// JSON blob containing authentication credentials and key.
 	CredentialsJSON common.JSONBlob `config:"credentials_json"`
 
+	// Decoding of message attributes and data into event fields.
+	Decoding decodingConfig `config:"decoding"`
+
 	// Overrides the default Pub/Sub service address and disables TLS. For testing.
 	AlternativeHost string `config:"alternative_host"`
 
@@ -60,6 +73,10 @@ func (c *config) Validate() error {
 		return errors.New("alternative_host may not be configured with a proxy")
 	}
 
+	if c.Subscription.DeadLetterTopic != "" && c.Subscription.MaxDeliveryAttempts == 0 {
+		return errors.New("subscription.dead_letter_topic requires subscription.max_delivery_attempts to be set")
+	}
+
 	// credentials_file
 	if c.CredentialsFile != "" {
 		if _, err := os.Stat(c.CredentialsFile); os.IsNotExist(err) {
@@ -86,9 +103,6 @@ func (c *config) Validate() error {
 
 func defaultConfig() config {
 	var c config
-	c.ForwarderConfig = harvester.ForwarderConfig{
-		Type: "gcp-pubsub",
-	}
 	c.Subscription.NumGoroutines = 1
 	// The input gets blocked until flush.min_events or flush.timeout is reached.
 	// Hence max_outstanding_message has to be at least flush.min_events to avoid this blockage.
@@ -35,3 +35,13 @@ func TestConfigValidateGoogleAppDefaultCreds(t *testing.T) {
 	c := defaultConfig()
 	assert.NoError(t, c.Validate())
 }
+
+func TestConfigValidateDeadLetterTopic(t *testing.T) {
+	c := defaultConfig()
+	c.CredentialsJSON = []byte(`{}`)
+	c.Subscription.DeadLetterTopic = "dead-letters"
+	assert.ErrorContains(t, c.Validate(), "subscription.dead_letter_topic requires subscription.max_delivery_attempts")
+
+	c.Subscription.MaxDeliveryAttempts = 5
+	assert.NoError(t, c.Validate())
+}
@@ -0,0 +1,154 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package gcppubsub
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+)
+
+const (
+	// maxTrackedDeliveries bounds the number of message IDs whose delivery
+	// attempts are counted locally.
+	maxTrackedDeliveries = 100000
+
+	deadLetterReasonMaxDeliveryAttempts = "max_delivery_attempts_exceeded"
+)
+
+// deliveryTracker counts the delivery attempts of messages received from a
+// subscription that has no dead-letter policy. Pub/Sub only reports delivery
+// attempts for subscriptions with a dead-letter policy, so the count is kept
+// for as long as the message has not been ACKed.
+type deliveryTracker struct {
+	mu       sync.Mutex
+	attempts map[string]int
+}
+
+func newDeliveryTracker() *deliveryTracker {
+	return &deliveryTracker{attempts: make(map[string]int)}
+}
+
+// attempt records a delivery of the message with the given ID and returns
+// the number of times it has been delivered.
+func (t *deliveryTracker) attempt(id string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n, ok := t.attempts[id]
+	if !ok && len(t.attempts) >= maxTrackedDeliveries {
+		// Evict an arbitrary entry. Its message starts counting again
+		// from one if it is redelivered.
+		for k := range t.attempts {
+			delete(t.attempts, k)
+			break
+		}
+	}
+	n++
+	t.attempts[id] = n
+	return n
+}
+
+// forget stops tracking the message with the given ID.
+func (t *deliveryTracker) forget(id string) {
+	t.mu.Lock()
+	delete(t.attempts, id)
+	t.mu.Unlock()
+}
+
+// deliveryAttempt returns the number of times msg has been delivered. The
+// attempt count reported by Pub/Sub is preferred over the local count.
+func (in *pubsubInput) deliveryAttempt(msg *pubsub.Message) int {
+	if msg.DeliveryAttempt != nil {
+		return *msg.DeliveryAttempt
+	}
+	return in.deliveries.attempt(msg.ID)
+}
+
+// exceededDeliveryAttempts returns the number of delivery attempts of msg and
+// whether it exceeds subscription.max_delivery_attempts.
+func (in *pubsubInput) exceededDeliveryAttempts(msg *pubsub.Message) (int, bool) {
+	if in.Subscription.MaxDeliveryAttempts <= 0 {
+		return 0, false
+	}
+	n := in.deliveryAttempt(msg)
+	return n, n > in.Subscription.MaxDeliveryAttempts
+}
+
+// deadLetterTopic returns the topic that messages exceeding the maximum
+// delivery attempts are forwarded to, or nil if none is configured. The topic
+// may be given as a name in the input's project or as a fully qualified
+// projects/{project}/topics/{topic} path.
+func (in *pubsubInput) deadLetterTopic(client *pubsub.Client) *pubsub.Topic {
+	name := in.Subscription.DeadLetterTopic
+	if name == "" {
+		return nil
+	}
+	if project, topic, ok := parseTopicPath(name); ok {
+		return client.TopicInProject(topic, project)
+	}
+	return client.Topic(name)
+}
+
+func parseTopicPath(path string) (project, topic string, ok bool) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 4 || parts[0] != "projects" || parts[2] != "topics" || parts[1] == "" || parts[3] == "" {
+		return "", "", false
+	}
+	return parts[1], parts[3], true
+}
+
+// deadLetter removes msg from the subscription after it exceeded the maximum
+// delivery attempts. If dlt is not nil, msg is first forwarded to it along
+// with failure metadata, and it is NACKed if that fails so that it will be
+// forwarded on the next delivery.
+func (in *pubsubInput) deadLetter(ctx context.Context, dlt *pubsub.Topic, msg *pubsub.Message, attempts int) {
+	log := in.log.With("message_id", msg.ID, "delivery_attempts", attempts)
+
+	if dlt == nil {
+		msg.Ack()
+		in.deliveries.forget(msg.ID)
+		in.metrics.droppedMessageCount.Inc()
+		log.Warn("Dropped Pub/Sub message that exceeded the maximum delivery attempts.")
+		return
+	}
+
+	res := dlt.Publish(ctx, in.makeDeadLetterMessage(msg, attempts))
+	if _, err := res.Get(ctx); err != nil {
+		msg.Nack()
+		in.metrics.nackedMessageCount.Inc()
+		log.Errorw("Failed to forward Pub/Sub message to dead-letter topic.", "dead_letter_topic", dlt.String(), "error", err)
+		return
+	}
+	msg.Ack()
+	in.deliveries.forget(msg.ID)
+	in.metrics.deadLetteredMessageCount.Inc()
+	log.Warnw("Forwarded Pub/Sub message that exceeded the maximum delivery attempts to dead-letter topic.", "dead_letter_topic", dlt.String())
+}
+
+// makeDeadLetterMessage returns a copy of msg that carries its original
+// attributes and the reason it was dead-lettered.
+func (in *pubsubInput) makeDeadLetterMessage(msg *pubsub.Message, attempts int) *pubsub.Message {
+	attrs := make(map[string]string, len(msg.Attributes)+5)
+	for k, v := range msg.Attributes {
+		attrs[k] = v
+	}
+	attrs["dead_letter_reason"] = deadLetterReasonMaxDeliveryAttempts
+	attrs["dead_letter_delivery_attempts"] = strconv.Itoa(attempts)
+	attrs["dead_letter_source_subscription"] = "projects/" + in.ProjectID + "/subscriptions/" + in.Subscription.Name
+	attrs["dead_letter_source_message_id"] = msg.ID
+	attrs["dead_letter_source_publish_time"] = msg.PublishTime.UTC().Format(time.RFC3339Nano)
+
+	return &pubsub.Message{
+		Data:       msg.Data,
+		Attributes: attrs,
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package gcppubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func newDeadLetterTestInput(t *testing.T, maxAttempts int) *pubsubInput {
+	t.Helper()
+
+	in := &pubsubInput{
+		config:     defaultConfig(),
+		log:        logptest.NewTestingLogger(t, ""),
+		metrics:    newInputMetrics("", monitoring.NewRegistry()),
+		deliveries: newDeliveryTracker(),
+	}
+	in.ProjectID = "test-project"
+	in.Subscription.Name = "test-subscription"
+	in.Subscription.MaxDeliveryAttempts = maxAttempts
+	return in
+}
+
+func TestExceededDeliveryAttempts(t *testing.T) {
+	t.Run("unlimited", func(t *testing.T) {
+		in := newDeadLetterTestInput(t, 0)
+		for i := 0; i < 10; i++ {
+			_, exceeded := in.exceededDeliveryAttempts(&pubsub.Message{ID: "1"})
+			assert.False(t, exceeded)
+		}
+	})
+
+	t.Run("tracked locally", func(t *testing.T) {
+		in := newDeadLetterTestInput(t, 2)
+		msg := &pubsub.Message{ID: "1"}
+		for want := 1; want <= 2; want++ {
+			n, exceeded := in.exceededDeliveryAttempts(msg)
+			assert.Equal(t, want, n)
+			assert.False(t, exceeded)
+		}
+		n, exceeded := in.exceededDeliveryAttempts(msg)
+		assert.Equal(t, 3, n)
+		assert.True(t, exceeded)
+
+		// ACKed messages are no longer tracked.
+		in.deliveries.forget(msg.ID)
+		n, exceeded = in.exceededDeliveryAttempts(msg)
+		assert.Equal(t, 1, n)
+		assert.False(t, exceeded)
+	})
+
+	t.Run("reported by pub/sub", func(t *testing.T) {
+		in := newDeadLetterTestInput(t, 2)
+		attempt := 5
+		n, exceeded := in.exceededDeliveryAttempts(&pubsub.Message{ID: "1", DeliveryAttempt: &attempt})
+		assert.Equal(t, 5, n)
+		assert.True(t, exceeded)
+	})
+}
+
+func TestParseTopicPath(t *testing.T) {
+	project, topic, ok := parseTopicPath("projects/other-project/topics/dead-letters")
+	assert.True(t, ok)
+	assert.Equal(t, "other-project", project)
+	assert.Equal(t, "dead-letters", topic)
+
+	for _, path := range []string{"dead-letters", "projects//topics/dead-letters", "projects/p/subscriptions/s"} {
+		_, _, ok = parseTopicPath(path)
+		assert.False(t, ok, path)
+	}
+}
+
+func TestMakeDeadLetterMessage(t *testing.T) {
+	in := newDeadLetterTestInput(t, 1)
+	msg := &pubsub.Message{
+		ID:          "42",
+		Data:        []byte("poison"),
+		Attributes:  map[string]string{"origin": "test"},
+		PublishTime: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	got := in.makeDeadLetterMessage(msg, 2)
+	assert.Equal(t, "poison", string(got.Data))
+	assert.Equal(t, map[string]string{
+		"origin":                          "test",
+		"dead_letter_reason":              deadLetterReasonMaxDeliveryAttempts,
+		"dead_letter_delivery_attempts":   "2",
+		"dead_letter_source_subscription": "projects/test-project/subscriptions/test-subscription",
+		"dead_letter_source_message_id":   "42",
+		"dead_letter_source_publish_time": "2024-01-02T03:04:05Z",
+	}, got.Attributes)
+	// The original message is left untouched.
+	assert.Equal(t, map[string]string{"origin": "test"}, msg.Attributes)
+}
+
+func TestDeadLetterWithoutTopic(t *testing.T) {
+	in := newDeadLetterTestInput(t, 1)
+	in.deliveries.attempt("1")
+	in.deadLetter(context.Background(), nil, &pubsub.Message{ID: "1"}, 2)
+	assert.EqualValues(t, 1, in.metrics.droppedMessageCount.Get())
+	assert.EqualValues(t, 0, in.metrics.deadLetteredMessageCount.Get())
+	assert.Empty(t, in.deliveries.attempts)
+}
@@ -0,0 +1,228 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package gcppubsub
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/linkedin/goavro/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+const (
+	schemaTypeAvro     = "avro"
+	schemaTypeProtobuf = "protobuf"
+
+	schemaEncodingBinary = "binary"
+	schemaEncodingJSON   = "json"
+
+	// schemaEncodingAttribute is set by Pub/Sub on messages published to a
+	// topic with a schema. Its value is either BINARY or JSON.
+	schemaEncodingAttribute = "googclient_schemaencoding"
+)
+
+// decodingConfig configures how the messages are decoded into events.
+type decodingConfig struct {
+	// Attributes maps message attributes to event fields.
+	Attributes []attributeMapping `config:"attributes"`
+
+	// Schema decodes the message data with the schema it was published with.
+	Schema *schemaConfig `config:"schema"`
+}
+
+type attributeMapping struct {
+	Attribute string `config:"attribute" validate:"required"`
+	Field     string `config:"field" validate:"required"`
+}
+
+type schemaConfig struct {
+	// Type of the schema, avro or protobuf.
+	Type string `config:"type" validate:"required"`
+
+	// Encoding of the message data, binary or json. If not set, it is read
+	// from the googclient_schemaencoding message attribute and defaults to
+	// binary.
+	Encoding string `config:"encoding"`
+
+	// Definition is the Avro schema definition.
+	Definition string `config:"definition"`
+
+	// DescriptorSetFile is the path to a serialized FileDescriptorSet that
+	// contains the Protobuf message type.
+	DescriptorSetFile string `config:"descriptor_set_file"`
+
+	// MessageType is the fully qualified name of the Protobuf message type.
+	MessageType string `config:"message_type"`
+}
+
+func (c *schemaConfig) Validate() error {
+	switch c.Encoding {
+	case "", schemaEncodingBinary, schemaEncodingJSON:
+	default:
+		return fmt.Errorf("invalid schema encoding %q, must be one of %s or %s", c.Encoding, schemaEncodingBinary, schemaEncodingJSON)
+	}
+
+	switch c.Type {
+	case schemaTypeAvro:
+		if c.Definition == "" {
+			return errors.New("avro schema requires a definition")
+		}
+	case schemaTypeProtobuf:
+		if c.DescriptorSetFile == "" || c.MessageType == "" {
+			return errors.New("protobuf schema requires a descriptor_set_file and a message_type")
+		}
+	default:
+		return fmt.Errorf("invalid schema type %q, must be one of %s or %s", c.Type, schemaTypeAvro, schemaTypeProtobuf)
+	}
+	return nil
+}
+
+// schemaDecoder decodes message data into its JSON representation.
+type schemaDecoder interface {
+	decode(data []byte, encoding string) ([]byte, error)
+}
+
+// decoder applies the decoding configuration to the events of messages.
+type decoder struct {
+	attributes []attributeMapping
+	schema     schemaDecoder
+	encoding   string
+}
+
+func newDecoder(cfg decodingConfig) (*decoder, error) {
+	d := &decoder{attributes: cfg.Attributes}
+	if cfg.Schema == nil {
+		return d, nil
+	}
+
+	d.encoding = cfg.Schema.Encoding
+	var err error
+	switch cfg.Schema.Type {
+	case schemaTypeAvro:
+		d.schema, err = newAvroDecoder(cfg.Schema.Definition)
+	case schemaTypeProtobuf:
+		d.schema, err = newProtobufDecoder(cfg.Schema.DescriptorSetFile, cfg.Schema.MessageType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// apply decodes the data of msg into the message field of event and copies
+// the mapped attributes of msg into event. If the data cannot be decoded, the
+// message field is left as is and the error is added to event.
+func (d *decoder) apply(event *beat.Event, msg *pubsub.Message) {
+	for _, m := range d.attributes {
+		if v, ok := msg.Attributes[m.Attribute]; ok {
+			_, _ = event.PutValue(m.Field, v)
+		}
+	}
+
+	if d.schema == nil {
+		return
+	}
+	data, err := d.schema.decode(msg.Data, d.messageEncoding(msg))
+	if err != nil {
+		_, _ = event.PutValue("error.message", "failed to decode message data: "+err.Error())
+		return
+	}
+	event.Fields["message"] = string(data)
+}
+
+// messageEncoding returns the configured schema encoding or the encoding
+// reported by Pub/Sub for msg.
+func (d *decoder) messageEncoding(msg *pubsub.Message) string {
+	if d.encoding != "" {
+		return d.encoding
+	}
+	if strings.EqualFold(msg.Attributes[schemaEncodingAttribute], schemaEncodingJSON) {
+		return schemaEncodingJSON
+	}
+	return schemaEncodingBinary
+}
+
+type avroDecoder struct {
+	codec *goavro.Codec
+}
+
+func newAvroDecoder(definition string) (*avroDecoder, error) {
+	codec, err := goavro.NewCodec(definition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse avro schema: %w", err)
+	}
+	return &avroDecoder{codec: codec}, nil
+}
+
+func (d *avroDecoder) decode(data []byte, encoding string) ([]byte, error) {
+	var (
+		native interface{}
+		err    error
+	)
+	if encoding == schemaEncodingJSON {
+		native, _, err = d.codec.NativeFromTextual(data)
+	} else {
+		native, _, err = d.codec.NativeFromBinary(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d.codec.TextualFromNative(nil, native)
+}
+
+type protobufDecoder struct {
+	desc protoreflect.MessageDescriptor
+}
+
+func newProtobufDecoder(descriptorSetFile, messageType string) (*protobufDecoder, error) {
+	b, err := os.ReadFile(descriptorSetFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read protobuf descriptor set: %w", err)
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(b, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse protobuf descriptor set: %w", err)
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse protobuf descriptor set: %w", err)
+	}
+	d, err := files.FindDescriptorByName(protoreflect.FullName(messageType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find protobuf message type %s: %w", messageType, err)
+	}
+	desc, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("protobuf descriptor %s is not a message type", messageType)
+	}
+	return &protobufDecoder{desc: desc}, nil
+}
+
+func (d *protobufDecoder) decode(data []byte, encoding string) ([]byte, error) {
+	msg := dynamicpb.NewMessage(d.desc)
+	var err error
+	if encoding == schemaEncodingJSON {
+		err = protojson.Unmarshal(data, msg)
+	} else {
+		err = proto.Unmarshal(data, msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+}
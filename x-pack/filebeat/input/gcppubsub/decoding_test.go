@@ -0,0 +1,208 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package gcppubsub
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const testAvroSchema = `{
+	"type": "record",
+	"name": "LogEntry",
+	"fields": [
+		{"name": "severity", "type": "string"},
+		{"name": "code", "type": "int"}
+	]
+}`
+
+func TestDecodingConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cfg     map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "avro",
+			cfg:  map[string]interface{}{"schema.type": "avro", "schema.definition": testAvroSchema},
+		},
+		{
+			name:    "avro without definition",
+			cfg:     map[string]interface{}{"schema.type": "avro"},
+			wantErr: "avro schema requires a definition",
+		},
+		{
+			name:    "protobuf without message type",
+			cfg:     map[string]interface{}{"schema.type": "protobuf", "schema.descriptor_set_file": "schema.pb"},
+			wantErr: "protobuf schema requires a descriptor_set_file and a message_type",
+		},
+		{
+			name:    "invalid type",
+			cfg:     map[string]interface{}{"schema.type": "thrift"},
+			wantErr: `invalid schema type "thrift"`,
+		},
+		{
+			name:    "invalid encoding",
+			cfg:     map[string]interface{}{"schema.type": "avro", "schema.definition": testAvroSchema, "schema.encoding": "xml"},
+			wantErr: `invalid schema encoding "xml"`,
+		},
+		{
+			name:    "attribute without field",
+			cfg:     map[string]interface{}{"attributes": []map[string]interface{}{{"attribute": "severity"}}},
+			wantErr: "string value is not set accessing 'attributes.0.field'",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg decodingConfig
+			err := conf.MustNewConfigFrom(tc.cfg).Unpack(&cfg)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDecoderAttributes(t *testing.T) {
+	dec, err := newDecoder(decodingConfig{
+		Attributes: []attributeMapping{
+			{Attribute: "severity", Field: "log.level"},
+			{Attribute: "missing", Field: "event.missing"},
+		},
+	})
+	require.NoError(t, err)
+
+	msg := &pubsub.Message{
+		ID:         "1",
+		Data:       []byte("hello"),
+		Attributes: map[string]string{"severity": "ERROR"},
+	}
+	event := makeEvent("topic", msg)
+	dec.apply(&event, msg)
+
+	assert.Equal(t, "hello", event.Fields["message"])
+	level, err := event.GetValue("log.level")
+	assert.NoError(t, err)
+	assert.Equal(t, "ERROR", level)
+	_, err = event.GetValue("event.missing")
+	assert.ErrorIs(t, err, mapstr.ErrKeyNotFound)
+}
+
+func TestDecoderAvro(t *testing.T) {
+	codec, err := goavro.NewCodec(testAvroSchema)
+	require.NoError(t, err)
+	binary, err := codec.BinaryFromNative(nil, map[string]interface{}{"severity": "ERROR", "code": 42})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name       string
+		encoding   string
+		data       []byte
+		attributes map[string]string
+	}{
+		{name: "binary", data: binary},
+		{name: "json from attribute", data: []byte(`{"severity":"ERROR","code":42}`), attributes: map[string]string{schemaEncodingAttribute: "JSON"}},
+		{name: "json from config", encoding: schemaEncodingJSON, data: []byte(`{"severity":"ERROR","code":42}`)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dec, err := newDecoder(decodingConfig{
+				Schema: &schemaConfig{Type: schemaTypeAvro, Encoding: tc.encoding, Definition: testAvroSchema},
+			})
+			require.NoError(t, err)
+
+			msg := &pubsub.Message{ID: "1", Data: tc.data, Attributes: tc.attributes}
+			event := makeEvent("topic", msg)
+			dec.apply(&event, msg)
+			assert.JSONEq(t, `{"severity":"ERROR","code":42}`, event.Fields["message"].(string))
+		})
+	}
+}
+
+func TestDecoderProtobuf(t *testing.T) {
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("log_entry.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("LogEntry"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("severity"), JsonName: proto.String("severity"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+				{Name: proto.String("error_code"), JsonName: proto.String("errorCode"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()},
+			},
+		}},
+	}
+	b, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fd}})
+	require.NoError(t, err)
+	descriptorSetFile := filepath.Join(t.TempDir(), "log_entry.pb")
+	require.NoError(t, os.WriteFile(descriptorSetFile, b, 0o600))
+
+	file, err := protodesc.NewFile(fd, nil)
+	require.NoError(t, err)
+	entry := dynamicpb.NewMessage(file.Messages().ByName("LogEntry"))
+	entry.Set(entry.Descriptor().Fields().ByName("severity"), protoreflect.ValueOf("ERROR"))
+	entry.Set(entry.Descriptor().Fields().ByName("error_code"), protoreflect.ValueOf(int32(42)))
+	binary, err := proto.Marshal(entry)
+	require.NoError(t, err)
+
+	_, err = newDecoder(decodingConfig{
+		Schema: &schemaConfig{Type: schemaTypeProtobuf, DescriptorSetFile: descriptorSetFile, MessageType: "test.Missing"},
+	})
+	assert.ErrorContains(t, err, "failed to find protobuf message type test.Missing")
+
+	dec, err := newDecoder(decodingConfig{
+		Schema: &schemaConfig{Type: schemaTypeProtobuf, DescriptorSetFile: descriptorSetFile, MessageType: "test.LogEntry"},
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name       string
+		data       []byte
+		attributes map[string]string
+	}{
+		{name: "binary", data: binary},
+		{name: "json", data: []byte(`{"severity":"ERROR","errorCode":42}`), attributes: map[string]string{schemaEncodingAttribute: "JSON"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := &pubsub.Message{ID: "1", Data: tc.data, Attributes: tc.attributes}
+			event := makeEvent("topic", msg)
+			dec.apply(&event, msg)
+			assert.JSONEq(t, `{"severity":"ERROR","error_code":42}`, event.Fields["message"].(string))
+		})
+	}
+}
+
+func TestDecoderError(t *testing.T) {
+	dec, err := newDecoder(decodingConfig{
+		Schema: &schemaConfig{Type: schemaTypeAvro, Definition: testAvroSchema},
+	})
+	require.NoError(t, err)
+
+	msg := &pubsub.Message{ID: "1", Data: []byte("not avro")}
+	event := beat.Event{Fields: mapstr.M{"message": "not avro"}}
+	dec.apply(&event, msg)
+
+	assert.Equal(t, "not avro", event.Fields["message"])
+	errMsg, err := event.GetValue("error.message")
+	assert.NoError(t, err)
+	assert.Contains(t, errMsg, "failed to decode message data")
+}
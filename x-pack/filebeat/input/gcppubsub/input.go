@@ -13,7 +13,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"sync"
 	"time"
 
 	"cloud.google.com/go/pubsub"
@@ -22,12 +21,10 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
-	"github.com/mitchellh/hashstructure"
-
-	"github.com/elastic/beats/v7/filebeat/channel"
-	"github.com/elastic/beats/v7/filebeat/input"
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/acker"
+	"github.com/elastic/beats/v7/libbeat/feature"
 	"github.com/elastic/beats/v7/libbeat/management/status"
 	"github.com/elastic/beats/v7/libbeat/version"
 	conf "github.com/elastic/elastic-agent-libs/config"
@@ -45,118 +42,143 @@ const (
 	retryInterval = 30 * time.Second
 )
 
-func init() {
-	err := input.Register(inputName, NewInput)
-	if err != nil {
-		panic(fmt.Errorf("failed to register %v input: %w", inputName, err))
+// Plugin returns the GCP Pub/Sub input plugin.
+func Plugin() v2.Plugin {
+	return v2.Plugin{
+		Name:       inputName,
+		Stability:  feature.Stable,
+		Deprecated: false,
+		Info:       "Google Cloud Pub/Sub",
+		Doc:        "Collect logs from a Google Cloud Pub/Sub topic subscription",
+		Manager:    v2.ConfigureWith(configure),
 	}
+}
 
-	err = input.Register(oldInputName, NewInput)
-	if err != nil {
-		panic(fmt.Errorf("failed to register %v input: %w", oldInputName, err))
+// PluginGooglePubSubAlias returns the GCP Pub/Sub input plugin registered
+// under its deprecated google-pubsub name.
+func PluginGooglePubSubAlias() v2.Plugin {
+	return v2.Plugin{
+		Name:       oldInputName,
+		Stability:  feature.Stable,
+		Deprecated: true,
+		Info:       "Google Cloud Pub/Sub",
+		Doc:        "Deprecated name of the gcp-pubsub input",
+		Manager:    v2.ConfigureWith(configure),
 	}
 }
 
-func configID(config *conf.C) (string, error) {
-	var tmp struct {
-		ID string `config:"id"`
-	}
-	if err := config.Unpack(&tmp); err != nil {
-		return "", fmt.Errorf("error extracting ID: %w", err)
-	}
-	if tmp.ID != "" {
-		return tmp.ID, nil
+func configure(cfg *conf.C) (v2.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
 	}
-
-	var h map[string]interface{}
-	_ = config.Unpack(&h)
-	id, err := hashstructure.Hash(h, nil)
+	dec, err := newDecoder(config.Decoding)
 	if err != nil {
-		return "", fmt.Errorf("can not compute ID from configuration: %w", err)
+		return nil, err
 	}
-
-	return fmt.Sprintf("%16X", id), nil
+	return newInput(config, dec), nil
 }
 
 type pubsubInput struct {
 	config
 
-	status   status.StatusReporter
-	log      *logp.Logger
-	outlet   channel.Outleter // Output of received pubsub messages.
-	inputCtx context.Context  // Wraps the Done channel from parent input.Context.
+	status status.StatusReporter
+	log    *logp.Logger
+	client beat.Client // Publishes the events of received pubsub messages.
 
-	workerCtx    context.Context    // Worker goroutine context. It's cancelled when the input stops or the worker exits.
-	workerCancel context.CancelFunc // Used to signal that the worker should stop.
-	workerOnce   sync.Once          // Guarantees that the worker goroutine is only started once.
-	workerWg     sync.WaitGroup     // Waits on pubsub worker goroutine.
-
-	id      string // id is the ID for metrics registration.
 	metrics *inputMetrics
+	decoder *decoder // Maps attributes and decodes schema encoded data of messages.
+
+	deliveries   *deliveryTracker // Counts delivery attempts when Pub/Sub does not report them.
+	orderingKeys *keySerializer   // Serializes publishing of messages with the same ordering key.
 }
 
-// NewInput creates a new Google Cloud Pub/Sub input that consumes events from
-// a topic subscription.
-func NewInput(cfg *conf.C, connector channel.Connector, inputContext input.Context, logger *logp.Logger) (inp input.Input, err error) {
-	stat := getStatusReporter(inputContext)
-	stat.UpdateStatus(status.Starting, "")
-
-	// Extract and validate the input's configuration.
-	stat.UpdateStatus(status.Configuring, "")
-	conf := defaultConfig()
-	if err = cfg.Unpack(&conf); err != nil {
-		stat.UpdateStatus(status.Failed, "failed to configure input: "+err.Error())
-		return nil, err
+func newInput(config config, dec *decoder) *pubsubInput {
+	return &pubsubInput{
+		config:     config,
+		decoder:    dec,
+		deliveries: newDeliveryTracker(),
 	}
+}
 
-	id, err := configID(cfg)
-	if err != nil {
-		stat.UpdateStatus(status.Failed, "failed to get input ID: "+err.Error())
-		return nil, err
-	}
+func (in *pubsubInput) Name() string { return inputName }
 
-	logger = logger.Named("gcp.pubsub").With(
-		"pubsub_project", conf.ProjectID,
-		"pubsub_topic", conf.Topic,
-		"pubsub_subscription", conf.Subscription)
+func (in *pubsubInput) Test(v2.TestContext) error { return nil }
 
-	if conf.Type == oldInputName {
-		logger.Warnf("%s input name is deprecated, please use %s instead", oldInputName, inputName)
+// Run starts the pubsub worker and blocks until the input is cancelled. Errors
+// of the worker are logged and the worker is restarted.
+func (in *pubsubInput) Run(inputCtx v2.Context, pipeline beat.PipelineConnector) error {
+	if err := in.setup(inputCtx, pipeline); err != nil {
+		return err
 	}
+	defer in.close()
 
-	// Wrap input.Context's Done channel with a context.Context. This goroutine
-	// stops with the parent closes the Done channel.
-	inputCtx, cancelInputCtx := context.WithCancel(context.Background())
-	go func() {
-		defer cancelInputCtx()
-		select {
-		case <-inputContext.Done:
-		case <-inputCtx.Done():
-		}
-		stat.UpdateStatus(status.Stopping, "")
+	ctx := v2.GoContextFromCanceler(inputCtx.Cancelation)
+	stop := context.AfterFunc(ctx, func() {
+		in.status.UpdateStatus(status.Stopping, "")
+	})
+	defer stop()
+
+	in.log.Info("Pub/Sub input worker has started.")
+	defer func() {
+		in.log.Info("Pub/Sub input worker has stopped.")
+		in.status.UpdateStatus(status.Stopped, "")
 	}()
 
-	// If the input ever needs to be made restartable, then context would need
-	// to be recreated with each restart.
-	workerCtx, workerCancel := context.WithCancel(inputCtx)
-
-	in := &pubsubInput{
-		config:       conf,
-		status:       stat,
-		log:          logger,
-		inputCtx:     inputCtx,
-		workerCtx:    workerCtx,
-		workerCancel: workerCancel,
-		id:           id,
+	// Throttle pubsub client restarts.
+	rt := rate.NewLimiter(rate.Every(retryInterval), 1)
+
+	// Watchdog to keep the worker operating after an error.
+	for ctx.Err() == nil {
+		// Rate limit.
+		if err := rt.Wait(ctx); err != nil {
+			continue
+		}
+
+		if err := in.run(ctx); err != nil {
+			if ctx.Err() == nil {
+				in.log.Warnw("Restarting failed Pub/Sub input worker.", "error", err)
+				continue
+			}
+
+			// Log any non-cancellation error before stopping.
+			if !errors.Is(err, context.Canceled) {
+				in.log.Errorw("Pub/Sub input worker failed.", "error", err)
+			}
+		}
 	}
+	return nil
+}
 
-	// Build outlet for events.
-	in.outlet, err = connector.ConnectWith(cfg, beat.ClientConfig{
+// setup initializes the logger, status reporter and metrics of the input and
+// connects it to the publishing pipeline.
+func (in *pubsubInput) setup(inputCtx v2.Context, pipeline beat.PipelineConnector) error {
+	in.status = inputCtx.StatusReporter
+	if in.status == nil {
+		in.status = noopReporter{}
+	}
+	in.status.UpdateStatus(status.Starting, "")
+	in.status.UpdateStatus(status.Configuring, "")
+
+	in.log = inputCtx.Logger.Named("gcp.pubsub").With(
+		"pubsub_project", in.ProjectID,
+		"pubsub_topic", in.Topic,
+		"pubsub_subscription", in.Subscription)
+
+	in.metrics = newInputMetrics(inputCtx.ID, nil)
+	in.orderingKeys = newKeySerializer(orderingKeyTimeout, in.log)
+
+	var err error
+	in.client, err = pipeline.ConnectWith(beat.ClientConfig{
 		EventListener: acker.ConnectionOnly(
 			acker.EventPrivateReporter(func(_ int, privates []interface{}) {
 				for _, priv := range privates {
 					if msg, ok := priv.(*pubsub.Message); ok {
 						msg.Ack()
+						in.deliveries.forget(msg.ID)
+						if msg.OrderingKey != "" {
+							in.orderingKeys.release(msg.OrderingKey, msg.ID)
+						}
 
 						in.metrics.ackedMessageCount.Inc()
 						in.metrics.bytesProcessedTotal.Add(uint64(len(msg.Data)))
@@ -175,71 +197,28 @@ func NewInput(cfg *conf.C, connector channel.Connector, inputContext input.Conte
 		},
 	})
 	if err != nil {
-		stat.UpdateStatus(status.Failed, "failed to configure Elasticsearch connection: "+err.Error())
-		return nil, err
+		in.metrics.Close()
+		in.status.UpdateStatus(status.Failed, "failed to configure Elasticsearch connection: "+err.Error())
+		return err
 	}
 	in.log.Info("Initialized GCP Pub/Sub input.")
-	return in, nil
+	return nil
 }
 
-func getStatusReporter(ctx input.Context) status.StatusReporter {
-	if ctx.GetStatusReporter == nil {
-		return noopReporter{}
-	}
-	stat := ctx.GetStatusReporter()
-	if stat == nil {
-		stat = noopReporter{}
-	}
-	return stat
+// close disconnects the input from the publishing pipeline, releases the
+// held ordering keys and unregisters its metrics.
+func (in *pubsubInput) close() {
+	in.client.Close()
+	in.orderingKeys.close()
+	in.metrics.Close()
 }
 
 type noopReporter struct{}
 
 func (noopReporter) UpdateStatus(status.Status, string) {}
 
-// Run starts the pubsub input worker then returns. Only the first invocation
-// will ever start the pubsub worker.
-func (in *pubsubInput) Run() {
-	in.workerOnce.Do(func() {
-		in.metrics = newInputMetrics(in.id, nil)
-		in.workerWg.Add(1)
-		go func() {
-			in.log.Info("Pub/Sub input worker has started.")
-			defer func() {
-				in.workerCancel()
-				in.workerWg.Done()
-				in.log.Info("Pub/Sub input worker has stopped.")
-				in.status.UpdateStatus(status.Stopped, "")
-			}()
-
-			// Throttle pubsub client restarts.
-			rt := rate.NewLimiter(rate.Every(retryInterval), 1)
-
-			// Watchdog to keep the worker operating after an error.
-			for in.workerCtx.Err() == nil {
-				// Rate limit.
-				if err := rt.Wait(in.workerCtx); err != nil {
-					continue
-				}
-
-				if err := in.run(); err != nil {
-					if in.workerCtx.Err() == nil {
-						in.log.Warnw("Restarting failed Pub/Sub input worker.", "error", err)
-						continue
-					}
-
-					// Log any non-cancellation error before stopping.
-					if !errors.Is(err, context.Canceled) {
-						in.log.Errorw("Pub/Sub input worker failed.", "error", err)
-					}
-				}
-			}
-		}()
-	})
-}
-
-func (in *pubsubInput) run() error {
-	ctx, cancel := context.WithCancel(in.workerCtx)
+func (in *pubsubInput) run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	client, err := in.newPubsubClient(ctx)
@@ -261,15 +240,32 @@ func (in *pubsubInput) run() error {
 	sub.ReceiveSettings.NumGoroutines = in.Subscription.NumGoroutines
 	sub.ReceiveSettings.MaxOutstandingMessages = in.Subscription.MaxOutstandingMessages
 
+	dlt := in.deadLetterTopic(client)
+	if dlt != nil {
+		defer dlt.Stop()
+	}
+
 	// Start receiving messages.
 	topicID := makeTopicID(in.ProjectID, in.Topic)
 	err = sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
-		if ok := in.outlet.OnEvent(makeEvent(topicID, msg)); !ok {
-			msg.Nack()
-			in.metrics.nackedMessageCount.Inc()
-			in.log.Debug("OnEvent returned false. Stopping input worker.")
-			cancel()
+		in.metrics.receivedMessageCount.Inc()
+		if attempts, exceeded := in.exceededDeliveryAttempts(msg); exceeded {
+			in.deadLetter(ctx, dlt, msg, attempts)
+			return
 		}
+		if in.Subscription.EnableMessageOrdering && msg.OrderingKey != "" {
+			// Wait for the previous message with the same ordering key
+			// to be ACKed, or for its hold to time out, before publishing
+			// this one.
+			if err := in.orderingKeys.acquire(ctx, msg.OrderingKey, msg.ID); err != nil {
+				msg.Nack()
+				in.metrics.nackedMessageCount.Inc()
+				return
+			}
+		}
+		event := makeEvent(topicID, msg)
+		in.decoder.apply(&event, msg)
+		in.client.Publish(event)
 	})
 	if err != nil {
 		in.status.UpdateStatus(status.Degraded, fmt.Sprintf("failed to receive message from pub/sub topic %s/%s: %v", in.ProjectID, in.Topic, err))
@@ -277,18 +273,6 @@ func (in *pubsubInput) run() error {
 	return err
 }
 
-// Stop stops the pubsub input and waits for it to fully stop.
-func (in *pubsubInput) Stop() {
-	in.workerCancel()
-	in.workerWg.Wait()
-	in.metrics.Close()
-}
-
-// Wait is an alias for Stop.
-func (in *pubsubInput) Wait() {
-	in.Stop()
-}
-
 // makeTopicID returns a short sha256 hash of the project ID plus topic name.
 // This string can be joined with pub/sub message IDs that are unique within a
 // topic to create a unique _id for documents.
@@ -337,7 +321,8 @@ func (in *pubsubInput) getOrCreateSubscription(ctx context.Context, client *pubs
 	// Create subscription.
 	if in.Subscription.Create {
 		sub, err = client.CreateSubscription(ctx, in.Subscription.Name, pubsub.SubscriptionConfig{
-			Topic: client.Topic(in.Topic),
+			Topic:                 client.Topic(in.Topic),
+			EnableMessageOrdering: in.Subscription.EnableMessageOrdering,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create subscription: %w", err)
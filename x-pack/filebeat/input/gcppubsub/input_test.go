@@ -7,14 +7,35 @@
 package gcppubsub
 
 import (
+	"context"
 	"testing"
 
-	"github.com/elastic/beats/v7/filebeat/input/inputtest"
-	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
 )
 
-func TestNewInputDone(t *testing.T) {
-	config := mapstr.M{
+func TestPlugin(t *testing.T) {
+	for _, p := range []v2.Plugin{Plugin(), PluginGooglePubSubAlias()} {
+		t.Run(p.Name, func(t *testing.T) {
+			_, err := p.Manager.Create(conf.MustNewConfigFrom(map[string]interface{}{
+				"project_id":        "some-project",
+				"subscription.name": "subscription",
+				"credentials_json":  "{}",
+			}))
+			assert.ErrorContains(t, err, "string value is not set accessing 'topic'")
+		})
+	}
+	assert.True(t, PluginGooglePubSubAlias().Deprecated)
+}
+
+func TestRunCancelledInput(t *testing.T) {
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
 		"project_id":        "some-project",
 		"topic":             "sometopic",
 		"subscription.name": "subscription",
@@ -22,6 +43,29 @@ func TestNewInputDone(t *testing.T) {
 		// Provide some credentials to avoid trying to query GCP for them,
 		// what creates HTTP-related goroutines.
 		"credentials_json": "{}",
+	})
+	in, err := Plugin().Manager.Create(cfg)
+	require.NoError(t, err)
+
+	var closed bool
+	connector := pubtest.FakeConnector{
+		ConnectFunc: func(beat.ClientConfig) (beat.Client, error) {
+			return &pubtest.FakeClient{
+				CloseFunc: func() error {
+					closed = true
+					return nil
+				},
+			}, nil
+		},
 	}
-	inputtest.AssertNotStartedInputCanBeDone(t, NewInput, &config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = in.Run(v2.Context{
+		Logger:      logptest.NewTestingLogger(t, ""),
+		ID:          "test",
+		Cancelation: ctx,
+	}, connector)
+	assert.NoError(t, err)
+	assert.True(t, closed, "pipeline client was not closed")
 }
@@ -18,23 +18,29 @@ import (
 type inputMetrics struct {
 	unregister func()
 
-	ackedMessageCount       *monitoring.Uint // Number of successfully ACKed messages.
-	failedAckedMessageCount *monitoring.Uint // Number of failed ACKed messages.
-	nackedMessageCount      *monitoring.Uint // Number of NACKed messages.
-	bytesProcessedTotal     *monitoring.Uint // Number of bytes processed.
-	processingTime          metrics.Sample   // Histogram of the elapsed time for processing an event in nanoseconds.
+	receivedMessageCount     *monitoring.Uint // Number of messages received from the subscription.
+	ackedMessageCount        *monitoring.Uint // Number of successfully ACKed messages.
+	failedAckedMessageCount  *monitoring.Uint // Number of failed ACKed messages.
+	nackedMessageCount       *monitoring.Uint // Number of NACKed messages.
+	deadLetteredMessageCount *monitoring.Uint // Number of messages forwarded to the dead-letter topic.
+	droppedMessageCount      *monitoring.Uint // Number of messages dropped after exceeding the maximum delivery attempts.
+	bytesProcessedTotal      *monitoring.Uint // Number of bytes processed.
+	processingTime           metrics.Sample   // Histogram of the elapsed time for processing an event in nanoseconds.
 }
 
 func newInputMetrics(id string, optionalParent *monitoring.Registry) *inputMetrics {
 	reg, unreg := inputmon.NewInputRegistry(inputName, id, optionalParent)
 
 	out := &inputMetrics{
-		unregister:              unreg,
-		ackedMessageCount:       monitoring.NewUint(reg, "acked_message_total"),
-		failedAckedMessageCount: monitoring.NewUint(reg, "failed_acked_message_total"),
-		nackedMessageCount:      monitoring.NewUint(reg, "nacked_message_total"),
-		bytesProcessedTotal:     monitoring.NewUint(reg, "bytes_processed_total"),
-		processingTime:          metrics.NewUniformSample(1024),
+		unregister:               unreg,
+		receivedMessageCount:     monitoring.NewUint(reg, "received_message_total"),
+		ackedMessageCount:        monitoring.NewUint(reg, "acked_message_total"),
+		failedAckedMessageCount:  monitoring.NewUint(reg, "failed_acked_message_total"),
+		nackedMessageCount:       monitoring.NewUint(reg, "nacked_message_total"),
+		deadLetteredMessageCount: monitoring.NewUint(reg, "dead_lettered_message_total"),
+		droppedMessageCount:      monitoring.NewUint(reg, "dropped_message_total"),
+		bytesProcessedTotal:      monitoring.NewUint(reg, "bytes_processed_total"),
+		processingTime:           metrics.NewUniformSample(1024),
 	}
 	_ = adapter.NewGoMetrics(reg, "processing_time", adapter.Accept).
 		Register("histogram", metrics.NewHistogram(out.processingTime))
@@ -0,0 +1,111 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package gcppubsub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// orderingKeyTimeout is how long a message may hold its ordering key without
+// being ACKed before the next message of the key is published anyway.
+const orderingKeyTimeout = 5 * time.Minute
+
+// keySerializer allows a single in-flight message per ordering key, so that
+// the messages of a key are published in the order they were received while
+// messages with different keys are still published concurrently.
+type keySerializer struct {
+	timeout time.Duration // Maximum time a message holds its key.
+	log     *logp.Logger
+
+	mu       sync.Mutex
+	inflight map[string]*keyHold
+}
+
+// keyHold is the in-flight message of an ordering key.
+type keyHold struct {
+	id    string        // ID of the message holding the key.
+	since time.Time     // Time the key was acquired.
+	done  chan struct{} // Closed when the key is released.
+}
+
+func newKeySerializer(timeout time.Duration, log *logp.Logger) *keySerializer {
+	return &keySerializer{
+		timeout:  timeout,
+		log:      log,
+		inflight: make(map[string]*keyHold),
+	}
+}
+
+// acquire blocks until no message with the given ordering key is in flight
+// and then marks the key as held by the message with the given ID. If the
+// in-flight message holds the key for longer than the timeout, a warning is
+// logged and the key is taken over. It returns the context's error if ctx is
+// done before the key is acquired.
+func (s *keySerializer) acquire(ctx context.Context, key, id string) error {
+	for {
+		s.mu.Lock()
+		hold, busy := s.inflight[key]
+		if !busy {
+			s.inflight[key] = &keyHold{id: id, since: time.Now(), done: make(chan struct{})}
+			s.mu.Unlock()
+			return nil
+		}
+		s.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(hold.since.Add(s.timeout)))
+		select {
+		case <-hold.done:
+		case <-timer.C:
+			s.expire(key, hold)
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		timer.Stop()
+	}
+}
+
+// expire releases the key if it is still held by the given message.
+func (s *keySerializer) expire(key string, hold *keyHold) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.inflight[key] != hold {
+		return
+	}
+	s.log.Warnw("Message was not ACKed in time, publishing the next message of its ordering key.",
+		"pubsub_ordering_key", key, "pubsub_message_id", hold.id, "timeout", s.timeout)
+	close(hold.done)
+	delete(s.inflight, key)
+}
+
+// release marks the ordering key as no longer in flight, unless it has been
+// taken over from the message with the given ID after a timeout.
+func (s *keySerializer) release(key, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if hold, ok := s.inflight[key]; ok && hold.id == id {
+		close(hold.done)
+		delete(s.inflight, key)
+	}
+}
+
+// close releases all ordering keys.
+func (s *keySerializer) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, hold := range s.inflight {
+		close(hold.done)
+		delete(s.inflight, key)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package gcppubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+func TestKeySerializer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s := newKeySerializer(time.Hour, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, s.acquire(ctx, "a", "1"))
+
+	// Other keys are not blocked by an in-flight key.
+	require.NoError(t, s.acquire(ctx, "b", "2"))
+
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- s.acquire(ctx, "a", "3")
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquired key while it was in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	s.release("a", "1")
+	select {
+	case err := <-acquired:
+		assert.NoError(t, err)
+	case <-ctx.Done():
+		t.Fatal("key was not acquired after release")
+	}
+
+	// Releasing a key that is not in flight is a no-op.
+	s.release("c", "4")
+}
+
+func TestKeySerializerCancel(t *testing.T) {
+	s := newKeySerializer(time.Hour, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, s.acquire(context.Background(), "a", "1"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, s.acquire(ctx, "a", "2"), context.Canceled)
+}
+
+func TestKeySerializerUnACKed(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s := newKeySerializer(50*time.Millisecond, logptest.NewTestingLogger(t, ""))
+
+	// The first message is never ACKed, for example because it was dropped,
+	// so the next message of the key takes it over after the timeout.
+	require.NoError(t, s.acquire(ctx, "a", "1"))
+	start := time.Now()
+	require.NoError(t, s.acquire(ctx, "a", "2"))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// A late ACK of the first message does not release the key of the second.
+	s.release("a", "1")
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer timeoutCancel()
+	assert.ErrorIs(t, s.acquire(timeoutCtx, "a", "3"), context.DeadlineExceeded)
+
+	s.release("a", "2")
+	assert.NoError(t, s.acquire(ctx, "a", "3"))
+}
+
+func TestKeySerializerClose(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s := newKeySerializer(time.Hour, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, s.acquire(ctx, "a", "1"))
+
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- s.acquire(ctx, "a", "2")
+	}()
+
+	s.close()
+	select {
+	case err := <-acquired:
+		assert.NoError(t, err)
+	case <-ctx.Done():
+		t.Fatal("key was not acquired after close")
+	}
+}
@@ -23,9 +23,9 @@ import (
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/api/iterator"
 
-	"github.com/elastic/beats/v7/filebeat/channel"
-	"github.com/elastic/beats/v7/filebeat/input"
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
 	"github.com/elastic/beats/v7/libbeat/tests/compose"
 	"github.com/elastic/beats/v7/libbeat/tests/resources"
 	conf "github.com/elastic/elastic-agent-libs/config"
@@ -216,11 +216,11 @@ func isInDockerIntegTestEnv() bool {
 	return os.Getenv("BEATS_INSIDE_INTEGRATION_TEST_ENV") != ""
 }
 
-func runTest(t *testing.T, cfg *conf.C, run func(client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T)) {
+func runTest(t *testing.T, cfg *conf.C, run func(ctx context.Context, client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T)) {
 	runTestWithACKer(t, cfg, ackEvent, run)
 }
 
-func runTestWithACKer(t *testing.T, cfg *conf.C, onEvent eventHandler, run func(client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T)) {
+func runTestWithACKer(t *testing.T, cfg *conf.C, onEvent eventHandler, run func(ctx context.Context, client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T)) {
 	if !isInDockerIntegTestEnv() {
 		// Don't test goroutines when using our compose.EnsureUp.
 		defer resources.NewGoroutinesChecker().Check(t)
@@ -231,35 +231,38 @@ func runTestWithACKer(t *testing.T, cfg *conf.C, onEvent eventHandler, run func(
 	defer clientCancel()
 	defer client.Close()
 
-	// Simulate input.Context from Filebeat input runner.
-	inputCtx := newInputContext()
-	defer close(inputCtx.Done)
+	// Simulate v2.Context from Filebeat input runner.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	inputCtx := v2.Context{
+		Logger:      logptest.NewTestingLogger(t, ""),
+		ID:          "test",
+		Cancelation: ctx,
+	}
 
-	// Stub outlet for receiving events generated by the input.
+	// Stub client for receiving events generated by the input.
 	eventOutlet := newStubOutlet(onEvent)
 	defer eventOutlet.Close()
 
-	connector := channel.ConnectorFunc(func(_ *conf.C, cliCfg beat.ClientConfig) (channel.Outleter, error) {
-		eventOutlet.setClientConfig(cliCfg)
-		return eventOutlet, nil
-	})
+	connector := pubtest.FakeConnector{
+		ConnectFunc: func(cliCfg beat.ClientConfig) (beat.Client, error) {
+			eventOutlet.setClientConfig(cliCfg)
+			return eventOutlet, nil
+		},
+	}
 
-	logger := logptest.NewTestingLogger(t, "")
-	in, err := NewInput(cfg, connector, inputCtx, logger)
+	in, err := configure(cfg)
 	if err != nil {
 		t.Fatal(err)
 	}
 	//nolint:errcheck // ignore
 	pubsubInput := in.(*pubsubInput)
-	defer pubsubInput.Stop()
-
-	run(client, pubsubInput, eventOutlet, t)
-}
-
-func newInputContext() input.Context {
-	return input.Context{
-		Done: make(chan struct{}),
+	if err := pubsubInput.setup(inputCtx, connector); err != nil {
+		t.Fatal(err)
 	}
+	defer pubsubInput.close()
+
+	run(ctx, client, pubsubInput, eventOutlet, t)
 }
 
 type eventHandler func(beat.Event, beat.ClientConfig) bool
@@ -319,20 +322,27 @@ func (o *stubOutleter) Close() error {
 	o.Lock()
 	defer o.Unlock()
 	o.done = true
+	o.cond.Broadcast()
 	return nil
 }
 
-func (o *stubOutleter) Done() <-chan struct{} { return nil }
-
-func (o *stubOutleter) OnEvent(event beat.Event) bool {
+func (o *stubOutleter) Publish(event beat.Event) {
 	o.Lock()
 	defer o.Unlock()
+	if o.done {
+		return
+	}
 	acked := o.eventHandler(event, o.clientCfg)
 	if acked {
 		o.Events = append(o.Events, event)
 		o.cond.Broadcast()
 	}
-	return !o.done
+}
+
+func (o *stubOutleter) PublishAll(events []beat.Event) {
+	for _, event := range events {
+		o.Publish(event)
+	}
 }
 
 // --- Test Cases
@@ -340,8 +350,8 @@ func (o *stubOutleter) OnEvent(event beat.Event) bool {
 func TestTopicDoesNotExist(t *testing.T) {
 	cfg := defaultTestConfig()
 
-	runTest(t, cfg, func(client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T) {
-		err := input.run()
+	runTest(t, cfg, func(ctx context.Context, client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T) {
+		err := input.run(ctx)
 		if assert.Error(t, err) {
 			assert.Contains(t, err.Error(), "failed to subscribe to pub/sub topic")
 		}
@@ -352,10 +362,10 @@ func TestSubscriptionDoesNotExistError(t *testing.T) {
 	cfg := defaultTestConfig()
 	_ = cfg.SetBool("subscription.create", -1, false)
 
-	runTest(t, cfg, func(client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T) {
+	runTest(t, cfg, func(ctx context.Context, client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T) {
 		createTopic(t, client)
 
-		err := input.run()
+		err := input.run(ctx)
 		if assert.Error(t, err) {
 			assert.Contains(t, err.Error(), "no subscription exists and 'subscription.create' is not enabled")
 		}
@@ -365,20 +375,21 @@ func TestSubscriptionDoesNotExistError(t *testing.T) {
 func TestSubscriptionExists(t *testing.T) {
 	cfg := defaultTestConfig()
 
-	runTest(t, cfg, func(client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T) {
+	runTest(t, cfg, func(ctx context.Context, client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T) {
 		createTopic(t, client)
 		createSubscription(t, client)
 		publishMessages(t, client, 5)
 
+		runCtx, stop := context.WithCancel(ctx)
 		var group errgroup.Group
-		group.Go(input.run)
+		group.Go(func() error { return input.run(runCtx) })
 
 		time.AfterFunc(10*time.Second, func() { out.Close() })
 		events, ok := out.waitForEvents(5)
 		if !ok {
 			t.Fatalf("Expected 5 events, but got %d.", len(events))
 		}
-		input.Stop()
+		stop()
 
 		if err := group.Wait(); err != nil {
 			t.Fatal(err)
@@ -389,11 +400,12 @@ func TestSubscriptionExists(t *testing.T) {
 func TestSubscriptionCreate(t *testing.T) {
 	cfg := defaultTestConfig()
 
-	runTest(t, cfg, func(client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T) {
+	runTest(t, cfg, func(ctx context.Context, client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T) {
 		createTopic(t, client)
 
-		group, ctx := errgroup.WithContext(context.Background())
-		group.Go(input.run)
+		runCtx, stop := context.WithCancel(ctx)
+		group, ctx := errgroup.WithContext(runCtx)
+		group.Go(func() error { return input.run(ctx) })
 
 		time.AfterFunc(1*time.Second, ifNotDone(ctx, func() { publishMessages(t, client, 5) }))
 		time.AfterFunc(10*time.Second, func() { out.Close() })
@@ -402,7 +414,7 @@ func TestSubscriptionCreate(t *testing.T) {
 		if !ok {
 			t.Fatalf("Expected 5 events, but got %d.", len(events))
 		}
-		input.Stop()
+		stop()
 
 		if err := group.Wait(); err != nil {
 			t.Fatal(err)
@@ -413,11 +425,21 @@ func TestSubscriptionCreate(t *testing.T) {
 func TestRunStop(t *testing.T) {
 	cfg := defaultTestConfig()
 
-	runTest(t, cfg, func(client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T) {
-		input.Run()
-		input.Stop()
-		input.Run()
-		input.Stop()
+	runTest(t, cfg, func(ctx context.Context, client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T) {
+		runCtx, stop := context.WithCancel(ctx)
+		var group errgroup.Group
+		group.Go(func() error {
+			return input.Run(v2.Context{
+				Logger:      logptest.NewTestingLogger(t, ""),
+				ID:          "test-run-stop",
+				Cancelation: runCtx,
+			}, pubtest.ConstClient(out))
+		})
+		stop()
+
+		if err := group.Wait(); err != nil {
+			t.Fatal(err)
+		}
 	})
 }
 
@@ -439,12 +461,13 @@ func TestEndToEndACK(t *testing.T) {
 		return ackEvent(ev, clientConfig)
 	}
 
-	runTestWithACKer(t, cfg, halfAcker, func(client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T) {
+	runTestWithACKer(t, cfg, halfAcker, func(ctx context.Context, client *pubsub.Client, input *pubsubInput, out *stubOutleter, t *testing.T) {
 		createTopic(t, client)
 		createSubscription(t, client)
 
-		group, _ := errgroup.WithContext(context.Background())
-		group.Go(input.run)
+		runCtx, stop := context.WithCancel(ctx)
+		var group errgroup.Group
+		group.Go(func() error { return input.run(runCtx) })
 
 		const numMsgs = 10
 		publishMessages(t, client, numMsgs)
@@ -468,7 +491,7 @@ func TestEndToEndACK(t *testing.T) {
 
 		assert.EqualValues(t, input.metrics.ackedMessageCount.Get(), len(seen))
 
-		input.Stop()
+		stop()
 		out.Close()
 		if err := group.Wait(); err != nil {
 			t.Fatal(err)
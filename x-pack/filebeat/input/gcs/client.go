@@ -11,6 +11,7 @@ import (
 
 	"cloud.google.com/go/storage"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
 	"google.golang.org/api/option"
 )
 
@@ -24,10 +25,27 @@ func fetchStorageClient(ctx context.Context, cfg config) (*storage.Client, error
 		h.Path = "storage/v1/"
 		return storage.NewClient(ctx, option.WithEndpoint(h.String()), option.WithoutAuthentication())
 	}
+	var opts []option.ClientOption
 	if cfg.Auth.CredentialsJSON != nil {
-		return storage.NewClient(ctx, option.WithCredentialsJSON([]byte(cfg.Auth.CredentialsJSON.AccountKey)))
+		opts = append(opts, option.WithCredentialsJSON([]byte(cfg.Auth.CredentialsJSON.AccountKey)))
 	} else if cfg.Auth.CredentialsFile != nil {
-		return storage.NewClient(ctx, option.WithCredentialsFile(cfg.Auth.CredentialsFile.Path))
+		opts = append(opts, option.WithCredentialsFile(cfg.Auth.CredentialsFile.Path))
+	}
+	if cfg.Auth.Impersonate != nil {
+		// without explicit credentials, the impersonation falls back to the
+		// application default credentials
+		ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+			TargetPrincipal: cfg.Auth.Impersonate.TargetPrincipal,
+			Delegates:       cfg.Auth.Impersonate.Delegates,
+			Scopes:          []string{storage.ScopeReadOnly},
+		}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to impersonate service account %s: %w", cfg.Auth.Impersonate.TargetPrincipal, err)
+		}
+		return storage.NewClient(ctx, option.WithTokenSource(ts))
+	}
+	if len(opts) != 0 {
+		return storage.NewClient(ctx, opts...)
 	}
 	cred, err := google.FindDefaultCredentials(ctx, storage.ScopeReadOnly)
 	if err != nil {
@@ -0,0 +1,70 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package gcs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestFetchStorageClientAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "external_account",
+			auth: map[string]interface{}{
+				"credentials_file.path": "testdata/external_account.json",
+			},
+		},
+		{
+			name: "impersonate",
+			auth: map[string]interface{}{
+				"credentials_file.path":        "testdata/external_account.json",
+				"impersonate.target_principal": "reader@elastic-sa.iam.gserviceaccount.com",
+				"impersonate.delegates":        []string{"delegate@elastic-sa.iam.gserviceaccount.com"},
+			},
+		},
+		{
+			name: "impersonate_without_target_principal",
+			auth: map[string]interface{}{
+				"credentials_file.path": "testdata/external_account.json",
+				"impersonate.delegates": []string{"delegate@elastic-sa.iam.gserviceaccount.com"},
+			},
+			wantErr: "string value is not set accessing 'auth.impersonate.target_principal'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := conf.MustNewConfigFrom(map[string]interface{}{
+				"project_id": "elastic-sa",
+				"auth":       tc.auth,
+				"buckets": []map[string]interface{}{
+					{"name": "gcs-test"},
+				},
+			})
+			c := defaultConfig()
+			err := cfg.Unpack(&c)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			// no request is made until the credentials are needed
+			client, err := fetchStorageClient(context.Background(), c)
+			require.NoError(t, err)
+			assert.NoError(t, client.Close())
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"slices"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -19,8 +20,8 @@ import (
 	"github.com/elastic/beats/v7/libbeat/reader/parser"
 )
 
-// MaxWorkers, Poll, PollInterval, BucketTimeOut, ParseJSON, FileSelectors, TimeStampEpoch & ExpandEventListFromField
-// can be configured at a global level, which applies to all buckets, as well as at the bucket level.
+// MaxWorkers, Poll, PollInterval, BucketTimeOut, ParseJSON, FileSelectors, TimeStampEpoch, ExpandEventListFromField
+// & EmitHeartbeat can be configured at a global level, which applies to all buckets, as well as at the bucket level.
 // Bucket level configurations will always override global level values.
 type config struct {
 	// ProjectId - Defines the project id of the concerned gcs bucket in Google Cloud.
@@ -52,6 +53,20 @@ type config struct {
 	AlternativeHost string `config:"alternative_host"`
 	// Retry - Defines the retry configuration for the input.
 	Retry retryConfig `config:"retry"`
+	// QueueDiscipline - Defines the order in which the objects of a batch are dispatched to the workers,
+	// either "fifo" (listing order) or "lifo" (objects of each batch sorted by their updated time, newest first).
+	QueueDiscipline string `config:"queue_discipline"`
+	// SkipEmptyObjects - Defines if zero-byte objects should be filtered out while listing, before any read attempt.
+	// By default this is set to true, since empty objects such as directory markers are rarely useful.
+	SkipEmptyObjects bool `config:"skip_empty_objects"`
+	// ReplayObjects - Defines a list of objects, in the form bucket/object[@generation], that are read once
+	// when the input starts. If a generation is given, that exact generation of the object is read.
+	ReplayObjects []string `config:"replay_objects"`
+	// EmitHeartbeat - Defines if a heartbeat event should be published for each poll that finds no new objects.
+	EmitHeartbeat bool `config:"emit_heartbeat"`
+	// SkipMissingBuckets - Defines if buckets that do not exist should be skipped instead of stopping the input.
+	// Skipped buckets are checked again on every poll.
+	SkipMissingBuckets bool `config:"skip_missing_buckets"`
 }
 
 // bucket contains the config for each specific object storage bucket in the root account
@@ -66,6 +81,9 @@ type bucket struct {
 	ReaderConfig             readerConfig         `config:",inline"`
 	TimeStampEpoch           *int64               `config:"timestamp_epoch"`
 	ExpandEventListFromField string               `config:"expand_event_list_from_field"`
+	QueueDiscipline          string               `config:"queue_discipline"`
+	SkipEmptyObjects         *bool                `config:"skip_empty_objects"`
+	EmitHeartbeat            *bool                `config:"emit_heartbeat"`
 }
 
 // fileSelectorConfig helps filter out gcs objects based on a regex pattern
@@ -85,6 +103,8 @@ type readerConfig struct {
 type authConfig struct {
 	CredentialsJSON *jsonCredentialsConfig `config:"credentials_json,omitempty"`
 	CredentialsFile *fileCredentialsConfig `config:"credentials_file,omitempty"`
+	// Impersonate - Defines the service account to impersonate using the configured credentials.
+	Impersonate *impersonateConfig `config:"impersonate,omitempty"`
 }
 
 type fileCredentialsConfig struct {
@@ -94,6 +114,17 @@ type jsonCredentialsConfig struct {
 	AccountKey string `config:"account_key"`
 }
 
+// impersonateConfig defines the service account impersonation. The configured credentials,
+// or the application default credentials, are used to request short-lived tokens for the
+// target service account.
+type impersonateConfig struct {
+	// TargetPrincipal - Defines the email address of the service account to impersonate.
+	TargetPrincipal string `config:"target_principal" validate:"required"`
+	// Delegates - Defines the service accounts of the delegation chain, each of them must
+	// be granted the Service Account Token Creator role on the next one.
+	Delegates []string `config:"delegates"`
+}
+
 type retryConfig struct {
 	// MaxAttempts configures the maximum number of times an API call can be made in the case of retryable errors.
 	// For example, if you set MaxAttempts(5), the operation will be attempted up to 5 times total (initial call plus 4 retries).
@@ -107,6 +138,44 @@ type retryConfig struct {
 	BackOffMultiplier float64 `config:"backoff_multiplier" validate:"min=1.1"`
 }
 
+const (
+	queueDisciplineFIFO = "fifo"
+	queueDisciplineLIFO = "lifo"
+)
+
+func (c config) Validate() error {
+	if err := validateQueueDiscipline(c.QueueDiscipline); err != nil {
+		return err
+	}
+	for _, b := range c.Buckets {
+		if b.QueueDiscipline == "" {
+			continue
+		}
+		if err := validateQueueDiscipline(b.QueueDiscipline); err != nil {
+			return fmt.Errorf("bucket %s: %w", b.Name, err)
+		}
+	}
+	for _, e := range c.ReplayObjects {
+		ro, err := parseReplayObject(e)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(c.Buckets, func(b bucket) bool { return b.Name == ro.bucket }) {
+			return fmt.Errorf("replay object %q refers to bucket %s which is not configured", e, ro.bucket)
+		}
+	}
+	return nil
+}
+
+func validateQueueDiscipline(d string) error {
+	switch d {
+	case queueDisciplineFIFO, queueDisciplineLIFO:
+		return nil
+	default:
+		return fmt.Errorf("invalid queue_discipline %q, must be one of %q (listing order) or %q (objects of each batch sorted by updated time, newest first)", d, queueDisciplineFIFO, queueDisciplineLIFO)
+	}
+}
+
 func (c authConfig) Validate() error {
 	// credentials_file
 	if c.CredentialsFile != nil {
@@ -146,5 +215,7 @@ func defaultConfig() config {
 			MaxBackOffDuration:     30 * time.Second,
 			BackOffMultiplier:      2,
 		},
+		QueueDiscipline:  queueDisciplineFIFO,
+		SkipEmptyObjects: true,
 	}
 }
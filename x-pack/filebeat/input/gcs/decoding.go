@@ -41,6 +41,8 @@ func newDecoder(cfg decoderConfig, r io.Reader) (decoder, error) {
 		return nil, nil
 	case cfg.Codec.CSV != nil:
 		return newCSVDecoder(cfg, r)
+	case cfg.Codec.Parquet != nil:
+		return newParquetDecoder(cfg, r)
 	default:
 		return nil, fmt.Errorf("unsupported config value: %v", cfg)
 	}
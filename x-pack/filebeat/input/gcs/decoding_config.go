@@ -5,6 +5,7 @@
 package gcs
 
 import (
+	"errors"
 	"fmt"
 	"unicode/utf8"
 )
@@ -16,7 +17,15 @@ type decoderConfig struct {
 
 // codecConfig contains the configuration options for different codecs used by a decoder.
 type codecConfig struct {
-	CSV *csvCodecConfig `config:"csv"`
+	Parquet *parquetCodecConfig `config:"parquet"`
+	CSV     *csvCodecConfig     `config:"csv"`
+}
+
+func (c *codecConfig) Validate() error {
+	if c.Parquet != nil && c.CSV != nil {
+		return errors.New("more than one decoder configured")
+	}
+	return nil
 }
 
 // csvCodecConfig contains the configuration options for the CSV codec.
@@ -52,3 +61,10 @@ func (r *configRune) Unpack(s string) error {
 	*r = configRune(_r)
 	return nil
 }
+
+// parquetCodecConfig contains the configuration options for the parquet codec.
+type parquetCodecConfig struct {
+	Enabled         bool `config:"enabled"`
+	ProcessParallel bool `config:"process_parallel"`
+	BatchSize       int  `config:"batch_size" default:"1"`
+}
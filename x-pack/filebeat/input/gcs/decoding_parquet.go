@@ -0,0 +1,100 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package gcs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/elastic/beats/v7/x-pack/libbeat/reader/parquet"
+)
+
+// parquetDecoder is a decoder for parquet data. Each row of the parquet data
+// is decoded as a separate JSON object, regardless of the batch size used
+// to read the records.
+type parquetDecoder struct {
+	reader *parquet.BufferedReader
+
+	// rows holds the rows of the last record read that have not been decoded yet.
+	rows    []json.RawMessage
+	current json.RawMessage
+	// coming reports whether the reader holds another record.
+	coming bool
+
+	err error
+}
+
+// newParquetDecoder creates a new parquet decoder. It uses the libbeat parquet reader under the hood.
+// It returns an error if the parquet reader cannot be created.
+func newParquetDecoder(config decoderConfig, r io.Reader) (decoder, error) {
+	reader, err := parquet.NewBufferedReader(r, &parquet.Config{
+		ProcessParallel: config.Codec.Parquet.ProcessParallel,
+		BatchSize:       config.Codec.Parquet.BatchSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parquet decoder: %w", err)
+	}
+	return &parquetDecoder{
+		reader: reader,
+		coming: reader.Next(),
+	}, nil
+}
+
+func (pd *parquetDecoder) more() bool { return len(pd.rows) != 0 || pd.coming }
+
+// next advances the parquet decoder to the next row and returns true if
+// there is more data to be decoded.
+func (pd *parquetDecoder) next() bool {
+	for len(pd.rows) == 0 {
+		if !pd.coming {
+			return false
+		}
+		pd.rows, pd.err = pd.readRows()
+		if pd.err != nil {
+			// let decode report the error
+			pd.coming = false
+			pd.current = nil
+			return true
+		}
+	}
+	pd.current, pd.rows = pd.rows[0], pd.rows[1:]
+	return true
+}
+
+// readRows reads the current record and advances the reader. The record
+// is released by the reader when it advances, so it must be marshaled first.
+func (pd *parquetDecoder) readRows() ([]json.RawMessage, error) {
+	data, err := pd.reader.Record()
+	if err != nil {
+		return nil, err
+	}
+	var rows []json.RawMessage
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("failed to split parquet record into rows: %w", err)
+	}
+	pd.coming = pd.reader.Next()
+	return rows, nil
+}
+
+// decode returns the JSON encoded value of the current parquet row. next must
+// have been called before any calls to decode.
+func (pd *parquetDecoder) decode() ([]byte, error) {
+	if pd.err != nil {
+		return nil, pd.err
+	}
+	if pd.current == nil {
+		return nil, errors.New("decode called before next")
+	}
+	data := pd.current
+	pd.current = nil
+	return data, nil
+}
+
+// close closes the parquet decoder and releases the resources.
+func (pd *parquetDecoder) close() error {
+	return pd.reader.Close()
+}
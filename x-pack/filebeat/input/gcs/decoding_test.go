@@ -8,9 +8,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"cloud.google.com/go/storage"
@@ -37,6 +39,47 @@ func TestDecoding(t *testing.T) {
 		assertAgainst string
 		config        decoderConfig
 	}{
+		{
+			name:      "parquet_batch_size_1",
+			file:      "vpc-flow.gz.parquet",
+			numEvents: 1304,
+			config: decoderConfig{
+				Codec: &codecConfig{
+					Parquet: &parquetCodecConfig{
+						ProcessParallel: true,
+						BatchSize:       1,
+					},
+				},
+			},
+		},
+		{
+			name:      "parquet_batch_size_100",
+			file:      "vpc-flow.gz.parquet",
+			numEvents: 1304,
+			config: decoderConfig{
+				Codec: &codecConfig{
+					Parquet: &parquetCodecConfig{
+						ProcessParallel: true,
+						BatchSize:       100,
+					},
+				},
+			},
+		},
+		{
+			name:          "parquet_default_content_check",
+			file:          "cloudtrail.parquet",
+			numEvents:     1,
+			assertAgainst: "cloudtrail.json",
+			config: decoderConfig{
+				Codec: &codecConfig{
+					Parquet: &parquetCodecConfig{
+						Enabled:         true,
+						ProcessParallel: true,
+						BatchSize:       1,
+					},
+				},
+			},
+		},
 		{
 			name:          "gzip_csv",
 			file:          "txn.csv.gz",
@@ -87,6 +130,7 @@ func TestDecoding(t *testing.T) {
 			}
 
 			events := p.events
+			assert.Len(t, events, tc.numEvents)
 			if tc.assertAgainst != "" {
 				targetData := readJSONFromFile(t, filepath.Join(testDataPath, tc.assertAgainst))
 				assert.Equal(t, len(targetData), len(events))
@@ -101,6 +145,44 @@ func TestDecoding(t *testing.T) {
 	}
 }
 
+func TestDecodeErrorLocation(t *testing.T) {
+	logp.TestingSetup()
+	log := logp.L()
+
+	f, err := os.Open(filepath.Join(testDataPath, "malformed.ndjson"))
+	if err != nil {
+		t.Fatalf("failed to open test data: %v", err)
+	}
+	defer f.Close()
+
+	p := &pub{t: t}
+	j := newJob(&storage.BucketHandle{}, &storage.ObjectAttrs{Name: "malformed.ndjson"}, "gs://test_uri", newState(), &Source{}, p, noopReporter{}, nil, log, false)
+	err = j.decode(context.Background(), f, "test")
+
+	var decErr *decodeError
+	if !errors.As(err, &decErr) {
+		t.Fatalf("expected a decode error, got: %v", err)
+	}
+	assert.Equal(t, "malformed.ndjson", decErr.object)
+	assert.Equal(t, 3, decErr.line)
+	assert.Equal(t, int64(53), decErr.offset)
+	assert.ErrorContains(t, err, "object malformed.ndjson at line 3 (offset 53)")
+	assert.Len(t, p.events, 2)
+}
+
+func TestLineCountingReader(t *testing.T) {
+	r := newLineCountingReader(strings.NewReader("a\nbb\n\nccc"))
+	_, err := io.ReadAll(r)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, r.lineAt(0))
+	assert.Equal(t, 1, r.lineAt(1))
+	assert.Equal(t, 2, r.lineAt(2))
+	assert.Equal(t, 3, r.lineAt(5))
+	assert.Equal(t, 4, r.lineAt(6))
+	assert.Equal(t, 4, r.lineAt(9))
+}
+
 type pub struct {
 	t      *testing.T
 	events []beat.Event
@@ -178,6 +260,32 @@ codec:
 			},
 		}},
 	},
+	{
+		name: "parquet",
+		yaml: `
+codec:
+  parquet:
+    enabled: true
+    batch_size: 10
+`,
+		want: decoderConfig{&codecConfig{
+			Parquet: &parquetCodecConfig{
+				Enabled:   true,
+				BatchSize: 10,
+			},
+		}},
+	},
+	{
+		name: "parquet_and_csv",
+		yaml: `
+codec:
+  parquet:
+    enabled: true
+  csv:
+    enabled: true
+`,
+		wantErr: errors.New(`more than one decoder configured accessing 'codec'`),
+	},
 	{
 		name: "bad_rune",
 		yaml: `
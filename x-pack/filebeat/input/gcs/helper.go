@@ -102,3 +102,94 @@ func newJSONDecoder(r io.Reader) *json.Decoder {
 	dec.UseNumber()
 	return dec
 }
+
+// decodeError is returned when the JSON content of an object cannot be
+// decoded. It records where in the object the failure occurred so that
+// bad source data can be located.
+type decodeError struct {
+	object string
+	line   int
+	offset int64
+	err    error
+}
+
+func (e *decodeError) Error() string {
+	return fmt.Sprintf("failed to decode json in object %s at line %d (offset %d): %v", e.object, e.line, e.offset, e.err)
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+// lineCountingReader wraps a reader and keeps track of the position of the
+// newlines that have been read, so that a byte offset can be translated
+// into a line number.
+type lineCountingReader struct {
+	r io.Reader
+	// read is the number of bytes read so far.
+	read int64
+	// newlines holds the offsets of the newlines read but not yet
+	// accounted for in lines.
+	newlines []int64
+	// lines is the number of newlines before the last offset passed
+	// to lineAt.
+	lines int
+}
+
+func newLineCountingReader(r io.Reader) *lineCountingReader {
+	return &lineCountingReader{r: r}
+}
+
+func (r *lineCountingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	for i, b := range p[:n] {
+		if b == '\n' {
+			r.newlines = append(r.newlines, r.read+int64(i))
+		}
+	}
+	r.read += int64(n)
+	return n, err
+}
+
+// lineAt returns the 1-based line number of the byte at offset. Offsets
+// passed to successive calls must not decrease.
+func (r *lineCountingReader) lineAt(offset int64) int {
+	i := 0
+	for i < len(r.newlines) && r.newlines[i] < offset {
+		i++
+	}
+	r.lines += i
+	r.newlines = r.newlines[i:]
+	return r.lines + 1
+}
+
+// readError is returned when an object cannot be read from the bucket, as
+// opposed to its content failing to decode.
+type readError struct {
+	object string
+	err    error
+}
+
+func (e *readError) Error() string {
+	return e.err.Error()
+}
+
+func (e *readError) Unwrap() error {
+	return e.err
+}
+
+// errorRecordingReader wraps a reader and records the first error other
+// than io.EOF it returns, so that read failures surfacing through a decoder
+// can be told apart from malformed content.
+type errorRecordingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *errorRecordingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil && err != io.EOF && r.err == nil {
+		r.err = err
+	}
+	return n, err
+}
@@ -76,6 +76,11 @@ func configure(cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
 			ParseJSON:                *bucket.ParseJSON,
 			TimeStampEpoch:           bucket.TimeStampEpoch,
 			ExpandEventListFromField: bucket.ExpandEventListFromField,
+			QueueDiscipline:          bucket.QueueDiscipline,
+			SkipEmptyObjects:         *bucket.SkipEmptyObjects,
+			EmitHeartbeat:            *bucket.EmitHeartbeat,
+			ReplayObjects:            replayObjectsForBucket(config.ReplayObjects, bucket.Name),
+			SkipMissingBuckets:       config.SkipMissingBuckets,
 			FileSelectors:            bucket.FileSelectors,
 			ReaderConfig:             bucket.ReaderConfig,
 			Retry:                    config.Retry,
@@ -114,6 +119,15 @@ func tryOverrideOrDefault(cfg config, b bucket) bucket {
 	if b.ExpandEventListFromField == "" {
 		b.ExpandEventListFromField = cfg.ExpandEventListFromField
 	}
+	if b.QueueDiscipline == "" {
+		b.QueueDiscipline = cfg.QueueDiscipline
+	}
+	if b.SkipEmptyObjects == nil {
+		b.SkipEmptyObjects = &cfg.SkipEmptyObjects
+	}
+	if b.EmitHeartbeat == nil {
+		b.EmitHeartbeat = &cfg.EmitHeartbeat
+	}
 	if len(b.FileSelectors) == 0 && len(cfg.FileSelectors) != 0 {
 		b.FileSelectors = cfg.FileSelectors
 	}
@@ -122,6 +136,40 @@ func tryOverrideOrDefault(cfg config, b bucket) bucket {
 	return b
 }
 
+// logEffectiveConfig logs the configuration the bucket runs with, after the
+// bucket level values have been merged with the root level values.
+func logEffectiveConfig(log *logp.Logger, src *Source) {
+	fileSelectors := make([]string, 0, len(src.FileSelectors))
+	for _, fs := range src.FileSelectors {
+		if fs.Regex != nil {
+			fileSelectors = append(fileSelectors, fs.Regex.String())
+		}
+	}
+	replayObjects := make([]string, 0, len(src.ReplayObjects))
+	for _, ro := range src.ReplayObjects {
+		replayObjects = append(replayObjects, ro.String())
+	}
+	log.Infow("effective bucket configuration",
+		"batch_size", src.BatchSize,
+		"max_workers", src.MaxWorkers,
+		"poll", src.Poll,
+		"poll_interval", src.PollInterval,
+		"parse_json", src.ParseJSON,
+		"timestamp_epoch", src.TimeStampEpoch,
+		"expand_event_list_from_field", src.ExpandEventListFromField,
+		"queue_discipline", src.QueueDiscipline,
+		"skip_empty_objects", src.SkipEmptyObjects,
+		"emit_heartbeat", src.EmitHeartbeat,
+		"skip_missing_buckets", src.SkipMissingBuckets,
+		"file_selectors", fileSelectors,
+		"replay_objects", replayObjects,
+		"retry.max_attempts", src.Retry.MaxAttempts,
+		"retry.initial_backoff_duration", src.Retry.InitialBackOffDuration,
+		"retry.max_backoff_duration", src.Retry.MaxBackOffDuration,
+		"retry.backoff_multiplier", src.Retry.BackOffMultiplier,
+	)
+}
+
 // isValidUnixTimestamp checks if the timestamp is a valid Unix timestamp
 func isValidUnixTimestamp(timestamp int64) bool {
 	// checks if the timestamp is within the valid range
@@ -151,6 +199,7 @@ func (input *gcsInput) Run(inputCtx v2.Context, src cursor.Source,
 
 	log := inputCtx.Logger.With("project_id", currentSource.ProjectId).With("bucket", currentSource.BucketName)
 	log.Infof("Running google cloud storage for project: %s", input.config.ProjectId)
+	logEffectiveConfig(log, currentSource)
 	// create a new inputMetrics instance
 	metrics := newInputMetrics(inputCtx.ID+":"+currentSource.BucketName, nil)
 	metrics.url.Set("gs://" + currentSource.BucketName)
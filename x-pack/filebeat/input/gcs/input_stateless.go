@@ -69,6 +69,11 @@ func (in *statelessInput) Run(inputCtx v2.Context, publisher stateless.Publisher
 			ParseJSON:                *bucket.ParseJSON,
 			TimeStampEpoch:           bucket.TimeStampEpoch,
 			ExpandEventListFromField: bucket.ExpandEventListFromField,
+			QueueDiscipline:          bucket.QueueDiscipline,
+			SkipEmptyObjects:         *bucket.SkipEmptyObjects,
+			EmitHeartbeat:            *bucket.EmitHeartbeat,
+			ReplayObjects:            replayObjectsForBucket(in.config.ReplayObjects, bucket.Name),
+			SkipMissingBuckets:       in.config.SkipMissingBuckets,
 			FileSelectors:            bucket.FileSelectors,
 			ReaderConfig:             bucket.ReaderConfig,
 			Retry:                    in.config.Retry,
@@ -77,6 +82,7 @@ func (in *statelessInput) Run(inputCtx v2.Context, publisher stateless.Publisher
 		st := newState()
 		currentSource := source.(*Source)
 		log := inputCtx.Logger.With("project_id", currentSource.ProjectId).With("bucket", currentSource.BucketName)
+		logEffectiveConfig(log, currentSource)
 		metrics := newInputMetrics(inputCtx.ID+":"+currentSource.BucketName, nil)
 		defer metrics.Close()
 		metrics.url.Set("gs://" + currentSource.BucketName)
@@ -19,6 +19,9 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/api/option"
 
@@ -200,6 +203,49 @@ func Test_StorageClient(t *testing.T) {
 			expected:    map[string]bool{},
 			isError:     errors.New("storage: bucket doesn't exist"),
 		},
+		{
+			name: "TwoBucketsWithPoll_SkipMissingBuckets",
+			baseConfig: map[string]interface{}{
+				"project_id":                 "elastic-sa",
+				"auth.credentials_file.path": "testdata/gcs_creds.json",
+				"max_workers":                2,
+				"poll":                       true,
+				"poll_interval":              "5s",
+				"skip_missing_buckets":       true,
+				"buckets": []map[string]interface{}{
+					{
+						"name": "gcs-test",
+					},
+					{
+						"name": bucketGcsTestNew,
+					},
+				},
+			},
+			mockHandler: mock.GCSServer,
+			expected: map[string]bool{
+				mock.Gcs_test_new_object_ata_json:      true,
+				mock.Gcs_test_new_object_data3_json:    true,
+				mock.Gcs_test_new_object_docs_ata_json: true,
+			},
+		},
+		{
+			name: "SingleBucketWithoutPoll_SkipMissingBuckets",
+			baseConfig: map[string]interface{}{
+				"project_id":                 "elastic-sa",
+				"auth.credentials_file.path": "testdata/gcs_creds.json",
+				"max_workers":                2,
+				"poll":                       false,
+				"poll_interval":              "5s",
+				"skip_missing_buckets":       true,
+				"buckets": []map[string]interface{}{
+					{
+						"name": "gcs-test",
+					},
+				},
+			},
+			mockHandler: mock.GCSServer,
+			expected:    map[string]bool{},
+		},
 		{
 			name: "SingleBucketWithPoll_InvalidConfigValue",
 			baseConfig: map[string]interface{}{
@@ -749,6 +795,62 @@ func Test_StorageClient(t *testing.T) {
 	}
 }
 
+func Test_LogEffectiveConfig(t *testing.T) {
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"project_id":                 "elastic-sa",
+		"auth.credentials_file.path": "testdata/gcs_creds.json",
+		"max_workers":                2,
+		"poll":                       true,
+		"poll_interval":              "15s",
+		"file_selectors": []map[string]interface{}{
+			{"regex": "^logs/"},
+		},
+		"buckets": []map[string]interface{}{
+			{
+				"name": "bucket_1",
+			},
+			{
+				"name":          "bucket_2",
+				"max_workers":   3,
+				"poll_interval": "10s",
+				"file_selectors": []map[string]interface{}{
+					{"regex": "^audit/"},
+				},
+			},
+		},
+	})
+	sources, _, err := configure(cfg)
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+
+	observed, logs := observer.New(zapcore.InfoLevel)
+	log, err := logp.ConfigureWithCoreLocal(logp.Config{}, observed)
+	require.NoError(t, err)
+
+	for _, src := range sources {
+		logEffectiveConfig(log, src.(*Source))
+	}
+
+	entries := logs.FilterMessage("effective bucket configuration").AllUntimed()
+	require.Len(t, entries, 2)
+
+	bucket1 := entries[0].ContextMap()
+	assert.EqualValues(t, 2, bucket1["max_workers"])
+	assert.EqualValues(t, 2, bucket1["batch_size"])
+	assert.Equal(t, true, bucket1["poll"])
+	assert.Equal(t, 15*time.Second, bucket1["poll_interval"])
+	assert.Equal(t, []interface{}{"<prefix string 'logs/'>"}, bucket1["file_selectors"])
+
+	bucket2 := entries[1].ContextMap()
+	assert.EqualValues(t, 3, bucket2["max_workers"])
+	// the root batch_size defaults to the root max_workers, which takes priority
+	// over the bucket max_workers
+	assert.EqualValues(t, 2, bucket2["batch_size"])
+	assert.Equal(t, true, bucket2["poll"])
+	assert.Equal(t, 10*time.Second, bucket2["poll_interval"])
+	assert.Equal(t, []interface{}{"<prefix string 'audit/'>"}, bucket2["file_selectors"])
+}
+
 func newV2Context(t *testing.T) (v2.Context, func()) {
 	ctx, cancel := context.WithCancel(context.Background())
 	id, err := generateRandomID(8)
@@ -54,6 +54,10 @@ type job struct {
 	log *logp.Logger
 	// flag used to denote if this object has previously failed without being processed at all.
 	isFailed bool
+	// generation of the object to read, the live generation is read if it is zero.
+	generation int64
+	// replay list entry of the job, set for replay jobs only.
+	replayKey string
 }
 
 // newJob, returns an instance of a job, which is a unit of work that can be assigned to a go routine
@@ -107,8 +111,19 @@ func (j *job) do(ctx context.Context, id string) {
 		}
 		err := j.processAndPublishData(ctx, id)
 		if err != nil {
-			j.state.updateFailedJobs(j.object.Name, j.metrics)
-			j.log.Errorw("job encountered an error while publishing data and has been added to a failed jobs list", "gcs.jobId", id, "error", err)
+			j.updateFailedJobs()
+			keysAndValues := []interface{}{"gcs.jobId", id, "error", err}
+			var decErr *decodeError
+			if errors.As(err, &decErr) {
+				keysAndValues = append(keysAndValues, "gcs.object.name", decErr.object, "gcs.object.line", decErr.line, "gcs.object.offset", decErr.offset)
+			}
+			var readErr *readError
+			if errors.As(err, &readErr) {
+				// the object is retried on the next poll, the other objects of the poll are not affected
+				j.metrics.gcsObjectsReadFailedTotal.Inc()
+				keysAndValues = append(keysAndValues, "gcs.object.name", readErr.object)
+			}
+			j.log.Errorw("job encountered an error while publishing data and has been added to a failed jobs list", keysAndValues...)
 			j.metrics.gcsFailedJobsTotal.Inc()
 			j.metrics.errorsTotal.Inc()
 			return
@@ -129,7 +144,7 @@ func (j *job) do(ctx context.Context, id string) {
 		}
 		event.SetID(objectID(j.hash, 0))
 		// locks while data is being saved and published to avoid concurrent map read/writes
-		cp, done := j.state.saveForTx(j.object.Name, j.object.Updated, j.metrics)
+		cp, done := j.saveForTx()
 		err = j.publisher.Publish(event, cp)
 		if err != nil {
 			j.log.Errorw("job encountered an error while publishing event", "gcs.jobId", id, "error", err)
@@ -145,6 +160,24 @@ func (j *job) Name() string {
 	return j.object.Name
 }
 
+// saveForTx saves the state for the job, see state.saveForTx. Replay jobs
+// only record the replay list entry as read, and never move the checkpoint.
+func (j *job) saveForTx() (cp *Checkpoint, done func()) {
+	if j.replayKey != "" {
+		return j.state.saveReplayForTx(j.replayKey, j.metrics)
+	}
+	return j.state.saveForTx(j.object.Name, j.object.Updated, j.metrics)
+}
+
+// updateFailedJobs records the job as failed, so that it is retried.
+func (j *job) updateFailedJobs() {
+	if j.replayKey != "" {
+		j.state.updateFailedReplayJobs(j.replayKey, j.metrics)
+		return
+	}
+	j.state.updateFailedJobs(j.object.Name, j.metrics)
+}
+
 func (j *job) Source() *Source {
 	return j.src
 }
@@ -155,10 +188,16 @@ func (j *job) Timestamp() time.Time {
 
 func (j *job) processAndPublishData(ctx context.Context, id string) error {
 	obj := j.bucket.Object(j.object.Name)
+	if j.generation != 0 {
+		obj = obj.Generation(j.generation)
+	}
 	reader, err := obj.NewReader(ctx)
 	if err != nil {
 		j.status.UpdateStatus(status.Degraded, "could not open object to read: "+err.Error())
-		return fmt.Errorf("failed to open reader for object: %s, with error: %w", j.object.Name, err)
+		return &readError{
+			object: j.object.Name,
+			err:    fmt.Errorf("failed to open reader for object: %s, with error: %w", j.object.Name, err),
+		}
 	}
 	defer func() {
 		err = reader.Close()
@@ -171,8 +210,16 @@ func (j *job) processAndPublishData(ctx context.Context, id string) error {
 	// update the source lag time metric
 	j.metrics.sourceLagTime.Update(time.Since(j.object.Updated).Nanoseconds())
 
-	// calculate number of decode errors
-	if err := j.decode(ctx, reader, id); err != nil {
+	// errors returned by the reader itself are read failures, not decode errors
+	r := &errorRecordingReader{r: reader}
+	if err := j.decode(ctx, r, id); err != nil {
+		if r.err != nil {
+			return &readError{
+				object: j.object.Name,
+				err:    fmt.Errorf("failed to read object: %s, with error: %w", j.object.Name, r.err),
+			}
+		}
+		// calculate number of decode errors
 		j.metrics.decodeErrorsTotal.Inc()
 		return fmt.Errorf("failed to decode object: %s, with error: %w", j.object.Name, err)
 	}
@@ -264,7 +311,8 @@ func (j *job) readJsonAndPublish(ctx context.Context, r io.Reader, id string) er
 		return fmt.Errorf("failed to evaluate json for object: %s, with error: %w", j.object.Name, err)
 	}
 
-	dec := json.NewDecoder(r)
+	lines := newLineCountingReader(r)
+	dec := json.NewDecoder(lines)
 	// UseNumber causes the Decoder to unmarshal a number into an interface{} as a Number instead of as a float64.
 	dec.UseNumber()
 	// If array is present at root then read json token and advance decoder
@@ -281,10 +329,22 @@ func (j *job) readJsonAndPublish(ctx context.Context, r io.Reader, id string) er
 		var item json.RawMessage
 		offset := dec.InputOffset()
 		if err = dec.Decode(&item); err != nil {
-			err = fmt.Errorf("failed to decode json: %w", err)
+			errOffset := dec.InputOffset()
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				errOffset = syntaxErr.Offset
+			}
+			err = &decodeError{
+				object: j.object.Name,
+				line:   lines.lineAt(errOffset),
+				offset: errOffset,
+				err:    err,
+			}
 			j.status.UpdateStatus(status.Degraded, err.Error())
 			return err
 		}
+		// keeps the newline bookkeeping bounded to the decoder's read ahead
+		lines.lineAt(offset)
 
 		// if expand_event_list_from_field is set, then split the event list
 		if j.src.ExpandEventListFromField != "" {
@@ -317,7 +377,7 @@ func (j *job) readJsonAndPublish(ctx context.Context, r io.Reader, id string) er
 func (j *job) publish(evt beat.Event, last bool, id string) {
 	if last {
 		// if this is the last object, then perform a complete state save
-		cp, done := j.state.saveForTx(j.object.Name, j.object.Updated, j.metrics)
+		cp, done := j.saveForTx()
 		err := j.publisher.Publish(evt, cp)
 		if err != nil {
 			j.metrics.errorsTotal.Inc()
@@ -390,7 +450,7 @@ func (j *job) splitEventList(key string, raw json.RawMessage, offset int64, id s
 
 		if !dec.More() {
 			// if this is the last object, then perform a complete state save
-			cp, done := j.state.saveForTx(j.object.Name, j.object.Updated, j.metrics)
+			cp, done := j.saveForTx()
 			err := j.publisher.Publish(evt, cp)
 			if err != nil {
 				j.metrics.errorsTotal.Inc()
@@ -27,6 +27,7 @@ type inputMetrics struct {
 	gcsEventsCreatedTotal           *monitoring.Uint // Number of events created from processing GCS data.
 	gcsFailedJobsTotal              *monitoring.Uint // Number of failed jobs.
 	gcsExpiredFailedJobsTotal       *monitoring.Uint // Number of expired failed jobs that could not be recovered.
+	gcsObjectsReadFailedTotal       *monitoring.Uint // Number of GCS objects that could not be read after retries.
 	gcsObjectsInflight              *monitoring.Uint // Number of GCS objects inflight (gauge).
 	gcsObjectProcessingTime         metrics.Sample   // Histogram of the elapsed GCS object processing times in nanoseconds (start of download to completion of parsing).
 	gcsObjectSizeInBytes            metrics.Sample   // Histogram of processed GCS object size in bytes.
@@ -51,6 +52,7 @@ func newInputMetrics(id string, optionalParent *monitoring.Registry) *inputMetri
 		gcsEventsCreatedTotal:           monitoring.NewUint(reg, "gcs_events_created_total"),
 		gcsFailedJobsTotal:              monitoring.NewUint(reg, "gcs_failed_jobs_total"),
 		gcsExpiredFailedJobsTotal:       monitoring.NewUint(reg, "gcs_expired_failed_jobs_total"),
+		gcsObjectsReadFailedTotal:       monitoring.NewUint(reg, "gcs_objects_read_failed_total"),
 		gcsObjectsInflight:              monitoring.NewUint(reg, "gcs_objects_inflight_gauge"),
 		gcsObjectProcessingTime:         metrics.NewUniformSample(1024),
 		gcsObjectSizeInBytes:            metrics.NewUniformSample(1024),
@@ -44,6 +44,7 @@ func TestNewInputMetricsInstance(t *testing.T) {
 		metrics.gcsEventsCreatedTotal,
 		metrics.gcsFailedJobsTotal,
 		metrics.gcsExpiredFailedJobsTotal,
+		metrics.gcsObjectsReadFailedTotal,
 		metrics.gcsObjectsInflight,
 		metrics.gcsObjectProcessingTime,
 		metrics.gcsObjectSizeInBytes,
@@ -62,6 +63,7 @@ func TestNewInputMetricsInstance(t *testing.T) {
 	assert.Equal(t, uint64(0x0), metrics.gcsEventsCreatedTotal.Get())
 	assert.Equal(t, uint64(0x0), metrics.gcsFailedJobsTotal.Get())
 	assert.Equal(t, uint64(0x0), metrics.gcsExpiredFailedJobsTotal.Get())
+	assert.Equal(t, uint64(0x0), metrics.gcsObjectsReadFailedTotal.Get())
 	assert.Equal(t, uint64(0x0), metrics.gcsObjectsInflight.Get())
 
 }
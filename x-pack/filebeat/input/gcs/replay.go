@@ -0,0 +1,144 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package gcs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/elastic/beats/v7/libbeat/management/status"
+)
+
+// replayObject is an object that is read once when the input starts, in
+// addition to the objects found while listing the bucket. If generation is
+// set, that exact generation of the object is read instead of the live one.
+type replayObject struct {
+	bucket     string
+	name       string
+	generation int64
+}
+
+// parseReplayObject parses a replay list entry of the form
+// bucket/object or bucket/object@generation.
+func parseReplayObject(s string) (replayObject, error) {
+	bucketName, name, ok := strings.Cut(s, "/")
+	if !ok || bucketName == "" || name == "" {
+		return replayObject{}, fmt.Errorf("invalid replay object %q: expected bucket/object[@generation]", s)
+	}
+	ro := replayObject{bucket: bucketName, name: name}
+	if i := strings.LastIndex(name, "@"); i != -1 {
+		gen, err := strconv.ParseInt(name[i+1:], 10, 64)
+		if err != nil || gen <= 0 {
+			return replayObject{}, fmt.Errorf("invalid replay object %q: generation must be a positive integer", s)
+		}
+		ro.name = name[:i]
+		ro.generation = gen
+	}
+	if ro.name == "" {
+		return replayObject{}, fmt.Errorf("invalid replay object %q: expected bucket/object[@generation]", s)
+	}
+	return ro, nil
+}
+
+// replayObjectsForBucket returns the parsed replay list entries that
+// target the given bucket.
+func replayObjectsForBucket(entries []string, bucketName string) []replayObject {
+	var objects []replayObject
+	for _, e := range entries {
+		// entries are checked when the config is validated
+		ro, err := parseReplayObject(e)
+		if err != nil || ro.bucket != bucketName {
+			continue
+		}
+		objects = append(objects, ro)
+	}
+	return objects
+}
+
+func (ro replayObject) String() string {
+	if ro.generation == 0 {
+		return ro.bucket + "/" + ro.name
+	}
+	return ro.bucket + "/" + ro.name + "@" + strconv.FormatInt(ro.generation, 10)
+}
+
+// pendingReplayObjects returns the entries of the replay list of the bucket
+// that were not read yet.
+func (s *scheduler) pendingReplayObjects() []replayObject {
+	var objects []replayObject
+	for _, ro := range s.src.ReplayObjects {
+		if !s.state.isReplayed(ro.String()) {
+			objects = append(objects, ro)
+		}
+	}
+	return objects
+}
+
+// failedReplayObjects returns the entries of the replay list whose jobs
+// failed and have to be retried.
+func (s *scheduler) failedReplayObjects() []replayObject {
+	keys := s.state.failedReplayJobs()
+	objects := make([]replayObject, 0, len(keys))
+	for _, key := range keys {
+		ro, err := parseReplayObject(key)
+		if err != nil || ro.bucket != s.src.BucketName {
+			continue
+		}
+		objects = append(objects, ro)
+	}
+	return objects
+}
+
+// createReplayJobs creates the jobs for the given replay list entries. Objects
+// or generations that cannot be found are logged as errors and left out.
+func (s *scheduler) createReplayJobs(ctx context.Context, objects []replayObject) []*job {
+	//nolint:prealloc // No need to preallocate the slice
+	var jobs []*job
+	for _, ro := range objects {
+		handle := s.bucket.Object(ro.name)
+		if ro.generation != 0 {
+			handle = handle.Generation(ro.generation)
+		}
+		obj, err := handle.Attrs(ctx)
+		if err != nil {
+			s.metrics.errorsTotal.Inc()
+			if errors.Is(err, storage.ErrObjectNotExist) {
+				s.log.Errorf("scheduler: replay object %s not found in bucket %s", ro, s.src.BucketName)
+				s.status.UpdateStatus(status.Degraded, fmt.Sprintf("replay object %s not found", ro))
+			} else {
+				s.log.Errorf("scheduler: failed to get attributes of replay object %s: %v", ro, err)
+			}
+			continue
+		}
+
+		objectURI := "gs://" + s.src.BucketName + "/" + obj.Name
+		job := newJob(s.bucket, obj, objectURI, s.state, s.src, s.publisher, s.status, s.metrics, s.log, false)
+		job.replayKey = ro.String()
+		if ro.generation != 0 {
+			job.generation = ro.generation
+			// a historical generation must not share event ids with the live object
+			job.hash = gcsObjectGenerationHash(s.src, obj)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// gcsObjectGenerationHash returns a short sha256 hash of the bucket name, object name
+// and object generation.
+func gcsObjectGenerationHash(src *Source, object *storage.ObjectAttrs) string {
+	h := sha256.New()
+	h.Write([]byte(src.BucketName))
+	h.Write([]byte(object.Name))
+	h.Write([]byte(strconv.FormatInt(object.Generation, 10)))
+	return hex.EncodeToString(h.Sum(nil)[:5])
+}
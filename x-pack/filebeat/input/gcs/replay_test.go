@@ -0,0 +1,165 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package gcs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func Test_ParseReplayObject(t *testing.T) {
+	tests := []struct {
+		entry   string
+		want    replayObject
+		wantErr bool
+	}{
+		{entry: "bucket/object.json", want: replayObject{bucket: "bucket", name: "object.json"}},
+		{entry: "bucket/logs/object.json", want: replayObject{bucket: "bucket", name: "logs/object.json"}},
+		{entry: "bucket/object.json@1661343619910503", want: replayObject{bucket: "bucket", name: "object.json", generation: 1661343619910503}},
+		{entry: "bucket/user@example.com@42", want: replayObject{bucket: "bucket", name: "user@example.com", generation: 42}},
+		{entry: "bucket", wantErr: true},
+		{entry: "/object.json", wantErr: true},
+		{entry: "bucket/", wantErr: true},
+		{entry: "bucket/@42", wantErr: true},
+		{entry: "bucket/object.json@latest", wantErr: true},
+		{entry: "bucket/object.json@0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			got, err := parseReplayObject(tt.entry)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.entry, got.String())
+		})
+	}
+}
+
+func Test_ReplayObjectsConfig(t *testing.T) {
+	cfg := config{
+		QueueDiscipline: queueDisciplineFIFO,
+		Buckets:         []bucket{{Name: "bucket-a"}, {Name: "bucket-b"}},
+		ReplayObjects:   []string{"bucket-a/a.json@42", "bucket-b/b.json", "bucket-a/c.json"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	assert.Equal(t, []replayObject{
+		{bucket: "bucket-a", name: "a.json", generation: 42},
+		{bucket: "bucket-a", name: "c.json"},
+	}, replayObjectsForBucket(cfg.ReplayObjects, "bucket-a"))
+	assert.Empty(t, replayObjectsForBucket(cfg.ReplayObjects, "bucket-c"))
+
+	cfg.ReplayObjects = []string{"bucket-c/a.json"}
+	assert.ErrorContains(t, cfg.Validate(), "bucket-c which is not configured")
+
+	cfg.ReplayObjects = []string{"bucket-a/a.json@x"}
+	assert.ErrorContains(t, cfg.Validate(), "generation must be a positive integer")
+}
+
+func Test_CreateReplayJobs(t *testing.T) {
+	const attrs = `{"kind": "storage#object", "name": "data.json", "bucket": "bucket", "generation": "42", "contentType": "application/json", "size": "10"}`
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/b/bucket/o/data.json" && r.URL.Query().Get("generation") == "42" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(attrs))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(serv.Close)
+
+	client, err := storage.NewClient(context.Background(), option.WithEndpoint(serv.URL), option.WithoutAuthentication())
+	require.NoError(t, err)
+
+	src := &Source{
+		BucketName: "bucket",
+		MaxWorkers: 1,
+		ReplayObjects: []replayObject{
+			{bucket: "bucket", name: "data.json", generation: 42},
+			{bucket: "bucket", name: "data.json", generation: 7},
+		},
+	}
+	s := newScheduler(nil, client.Bucket("bucket"), src, &config{}, newState(), noopReporter{}, nil, logp.NewLogger("gcs_test"))
+
+	jobs := s.createReplayJobs(context.Background(), s.pendingReplayObjects())
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "data.json", jobs[0].Name())
+	assert.Equal(t, int64(42), jobs[0].generation)
+	assert.NotEqual(t, gcsObjectHash(src, jobs[0].object), jobs[0].hash)
+	assert.Equal(t, uint64(1), s.metrics.errorsTotal.Get())
+}
+
+func Test_ReplayKeepsCheckpoint(t *testing.T) {
+	const attrs = `{"kind": "storage#object", "name": "data.json", "bucket": "bucket", "generation": "42", "contentType": "application/json", "size": "19", "updated": "2024-01-01T10:00:00Z"}`
+	var failing atomic.Bool
+	failing.Store(true)
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/b/bucket/o/data.json" && r.URL.Query().Get("generation") == "42":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(attrs))
+		case r.URL.Path == "/bucket/data.json" && r.URL.Query().Get("generation") == "42":
+			if failing.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"object":"data@42"}`))
+		default:
+			// the live generation must never be read
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(serv.Close)
+
+	client, err := storage.NewClient(context.Background(), option.WithEndpoint(serv.URL), option.WithoutAuthentication())
+	require.NoError(t, err)
+	bkt := client.Bucket("bucket").Retryer(storage.WithMaxAttempts(1))
+
+	latest := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	st := newState()
+	st.setCheckpoint(&Checkpoint{ObjectName: "z.json", LatestEntryTime: latest})
+
+	src := &Source{
+		BucketName:    "bucket",
+		MaxWorkers:    1,
+		ReplayObjects: []replayObject{{bucket: "bucket", name: "data.json", generation: 42}},
+	}
+	publisher := &pub{t: t}
+	s := newScheduler(publisher, bkt, src, &config{}, st, noopReporter{}, nil, logp.NewLogger("gcs_test"))
+
+	// a failed replay job is tracked by its generation, apart from the failed jobs
+	s.scheduleReplay(context.Background(), s.pendingReplayObjects())
+	assert.Empty(t, publisher.events)
+	assert.Empty(t, st.checkpoint().FailedJobs)
+	assert.Equal(t, []replayObject{{bucket: "bucket", name: "data.json", generation: 42}}, s.failedReplayObjects())
+
+	// the retry reads the pinned generation and leaves the checkpoint untouched
+	failing.Store(false)
+	s.scheduleReplay(context.Background(), s.failedReplayObjects())
+	assert.Equal(t, []string{`{"object":"data@42"}`}, publishedMessages(publisher))
+	assert.Equal(t, "z.json", st.checkpoint().ObjectName)
+	assert.Equal(t, latest, st.checkpoint().LatestEntryTime)
+	assert.Empty(t, s.failedReplayObjects())
+
+	// the replay is recorded as done, so it is not replayed after a restart
+	restarted := newScheduler(publisher, bkt, src, &config{}, st, noopReporter{}, nil, logp.NewLogger("gcs_test"))
+	assert.True(t, st.checkpoint().ReplayedObjects["bucket/data.json@42"])
+	assert.Empty(t, restarted.pendingReplayObjects())
+}
@@ -12,13 +12,16 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"cloud.google.com/go/storage"
 	"google.golang.org/api/iterator"
 
 	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/management/status"
 	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/go-concert/timed"
 )
 
@@ -64,6 +67,8 @@ func newScheduler(publisher cursor.Publisher, bucket *storage.BucketHandle, src
 
 // Schedule, is responsible for fetching & scheduling jobs using the workerpool model
 func (s *scheduler) schedule(ctx context.Context) error {
+	s.scheduleReplay(ctx, s.pendingReplayObjects())
+
 	if !s.src.Poll {
 		return s.scheduleOnce(ctx)
 	}
@@ -78,6 +83,8 @@ func (s *scheduler) schedule(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+
+		s.scheduleReplay(ctx, s.failedReplayObjects())
 	}
 }
 
@@ -99,12 +106,19 @@ func (l *limiter) release() {
 
 func (s *scheduler) scheduleOnce(ctx context.Context) error {
 	defer s.limiter.wait()
+	pollTime := time.Now()
 	pager := s.fetchObjectPager(ctx, s.src.BatchSize)
 	var numObs, numJobs int
 	for {
 		var objects []*storage.ObjectAttrs
 		nextPageToken, err := pager.NextPage(&objects)
 		if err != nil {
+			if s.src.SkipMissingBuckets && errors.Is(err, storage.ErrBucketNotExist) {
+				// the bucket is listed again on the next poll, in case it gets created later
+				s.log.Warnw("skipping bucket that does not exist", "error", err)
+				s.status.UpdateStatus(status.Degraded, "skipping bucket that does not exist: "+s.src.BucketName)
+				return nil
+			}
 			s.metrics.errorsTotal.Inc()
 			s.status.UpdateStatus(status.Failed, "failed to get page token from storage: "+err.Error())
 			return err
@@ -121,6 +135,7 @@ func (s *scheduler) scheduleOnce(ctx context.Context) error {
 				jobs = s.addFailedJobs(ctx, jobs)
 			}
 		}
+		jobs = s.orderJobs(jobs)
 		s.log.Debugf("scheduler: %d jobs scheduled for current batch", len(jobs))
 		s.metrics.gcsJobsScheduledAfterValidation.Update(int64(len(jobs)))
 
@@ -146,9 +161,65 @@ func (s *scheduler) scheduleOnce(ctx context.Context) error {
 		}
 	}
 
+	if s.src.EmitHeartbeat && numJobs == 0 {
+		s.publishHeartbeat(pollTime)
+	}
+
 	return nil
 }
 
+// publishHeartbeat publishes an event signaling that the bucket was polled
+// successfully, but that no new objects were found.
+func (s *scheduler) publishHeartbeat(pollTime time.Time) {
+	event := beat.Event{
+		Timestamp: pollTime,
+		Fields: mapstr.M{
+			"message": "no new objects found in bucket " + s.src.BucketName,
+			"gcs": mapstr.M{
+				"heartbeat": mapstr.M{
+					"poll_time": pollTime,
+				},
+				"storage": mapstr.M{
+					"bucket": mapstr.M{
+						"name": s.src.BucketName,
+					},
+				},
+			},
+			"cloud": mapstr.M{
+				"provider": "google cloud",
+			},
+		},
+	}
+	// heartbeats carry no cursor state, so they do not move the checkpoint
+	if err := s.publisher.Publish(event, nil); err != nil {
+		s.log.Errorw("failed to publish heartbeat event", "error", err)
+		s.metrics.errorsTotal.Inc()
+	}
+}
+
+// scheduleReplay reads the given objects of the replay list, before the bucket
+// is listed. The replay list is read once when the input starts, and its
+// failed jobs are retried before each following poll. Objects that were read
+// are recorded in the checkpoint, so they are not read again after a restart.
+func (s *scheduler) scheduleReplay(ctx context.Context, objects []replayObject) {
+	if len(objects) == 0 {
+		return
+	}
+	defer s.limiter.wait()
+
+	jobs := s.createReplayJobs(ctx, objects)
+	s.log.Debugf("scheduler: %d replay jobs scheduled", len(jobs))
+	for i, job := range jobs {
+		id := fetchJobID(i, s.src.BucketName, job.Name())
+		job := job
+		s.limiter.acquire()
+		go func() {
+			defer s.limiter.release()
+			job.do(ctx, id)
+		}()
+	}
+}
+
 // fetchJobID returns a job id which is a combination of worker id, bucket name and object name
 func fetchJobID(workerId int, bucketName string, objectName string) string {
 	jobID := fmt.Sprintf("%s-%s-worker-%d", bucketName, objectName, workerId)
@@ -164,6 +235,11 @@ func (s *scheduler) createJobs(objects []*storage.ObjectAttrs, log *logp.Logger)
 		if len(s.src.FileSelectors) != 0 && !s.isFileSelected(obj.Name) {
 			continue
 		}
+		// zero-byte objects such as directory markers or in-progress uploads have nothing to read
+		if s.src.SkipEmptyObjects && obj.Size == 0 {
+			log.Debugf("scheduler: skipping empty object %s", obj.Name)
+			continue
+		}
 		// date filter is applied on last updated time of the object
 		if s.src.TimeStampEpoch != nil && obj.Updated.Unix() < *s.src.TimeStampEpoch {
 			continue
@@ -212,6 +288,21 @@ func (s *scheduler) moveToLastSeenJob(jobs []*job) []*job {
 	return jobs
 }
 
+// orderJobs, orders the jobs of the current batch according to the configured queue discipline.
+// With "lifo" the jobs are sorted by the updated time of their object, newest first, and jobs with
+// the same updated time keep the order in which they were listed. Only the jobs of the current batch
+// are reordered, objects of later batches are never dispatched before them. Otherwise the jobs keep
+// the order in which they were queued.
+func (s *scheduler) orderJobs(jobs []*job) []*job {
+	if s.src.QueueDiscipline != queueDisciplineLIFO {
+		return jobs
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Timestamp().After(jobs[j].Timestamp())
+	})
+	return jobs
+}
+
 func (s *scheduler) addFailedJobs(ctx context.Context, jobs []*job) []*job {
 	jobMap := make(map[string]bool)
 	for _, j := range jobs {
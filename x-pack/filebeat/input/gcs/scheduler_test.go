@@ -0,0 +1,305 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package gcs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func Test_OrderJobs(t *testing.T) {
+	now := time.Now()
+	objects := []*storage.ObjectAttrs{
+		{Name: "a.json", Updated: now.Add(-3 * time.Hour)},
+		{Name: "b.json", Updated: now.Add(-1 * time.Hour)},
+		{Name: "c.json", Updated: now.Add(-2 * time.Hour)},
+		{Name: "d.json", Updated: now},
+		{Name: "e.json", Updated: now.Add(-1 * time.Hour)},
+	}
+
+	tests := []struct {
+		name       string
+		discipline string
+		want       []string
+	}{
+		{
+			name:       "fifo",
+			discipline: queueDisciplineFIFO,
+			want:       []string{"a.json", "b.json", "c.json", "d.json", "e.json"},
+		},
+		{
+			name:       "lifo",
+			discipline: queueDisciplineLIFO,
+			// Objects with the same updated time keep their listing order.
+			want: []string{"d.json", "b.json", "e.json", "c.json", "a.json"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &Source{
+				BucketName:      "test-bucket",
+				MaxWorkers:      1,
+				QueueDiscipline: tt.discipline,
+			}
+			log := logp.NewLogger("gcs_test")
+			s := newScheduler(nil, nil, src, &config{}, newState(), noopReporter{}, nil, log)
+
+			jobs := s.orderJobs(s.createJobs(objects, log))
+
+			got := make([]string, 0, len(jobs))
+			for _, j := range jobs {
+				got = append(got, j.Name())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_CreateJobsSkipEmptyObjects(t *testing.T) {
+	objects := []*storage.ObjectAttrs{
+		{Name: "data.json", Size: 643},
+		{Name: "placeholder.json", Size: 0},
+		{Name: "logs/", Size: 0},
+		{Name: "logs/data.ndjson", Size: 434},
+	}
+
+	tests := []struct {
+		name             string
+		skipEmptyObjects bool
+		want             []string
+	}{
+		{
+			name:             "skip empty objects",
+			skipEmptyObjects: true,
+			want:             []string{"data.json", "logs/data.ndjson"},
+		},
+		{
+			name:             "keep empty objects",
+			skipEmptyObjects: false,
+			want:             []string{"data.json", "placeholder.json", "logs/data.ndjson"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &Source{
+				BucketName:       "test-bucket",
+				MaxWorkers:       1,
+				SkipEmptyObjects: tt.skipEmptyObjects,
+			}
+			log := logp.NewLogger("gcs_test")
+			s := newScheduler(nil, nil, src, &config{}, newState(), noopReporter{}, nil, log)
+
+			got := make([]string, 0, len(objects))
+			for _, j := range s.createJobs(objects, log) {
+				got = append(got, j.Name())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_QueueDisciplineConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config
+		wantErr bool
+	}{
+		{
+			name: "default",
+			cfg:  config{QueueDiscipline: queueDisciplineFIFO},
+		},
+		{
+			name: "bucket override",
+			cfg: config{
+				QueueDiscipline: queueDisciplineFIFO,
+				Buckets:         []bucket{{Name: "b", QueueDiscipline: queueDisciplineLIFO}},
+			},
+		},
+		{
+			name:    "invalid global",
+			cfg:     config{QueueDiscipline: "random"},
+			wantErr: true,
+		},
+		{
+			name: "invalid bucket",
+			cfg: config{
+				QueueDiscipline: queueDisciplineFIFO,
+				Buckets:         []bucket{{Name: "b", QueueDiscipline: "stack"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_ScheduleOnceContinuesPastReadErrors(t *testing.T) {
+	const objectList = `{"kind": "storage#objects", "items": [
+		{"kind": "storage#object", "name": "a.json", "bucket": "bucket", "contentType": "application/json", "size": "19", "updated": "2024-03-01T10:00:00Z"},
+		{"kind": "storage#object", "name": "b.json", "bucket": "bucket", "contentType": "application/json", "size": "19", "updated": "2024-03-01T10:01:00Z"},
+		{"kind": "storage#object", "name": "c.json", "bucket": "bucket", "contentType": "application/json", "size": "19", "updated": "2024-03-01T10:02:00Z"}
+	]}`
+	var failing atomic.Bool
+	var failedReads atomic.Int32
+	failing.Store(true)
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/b/bucket/o":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(objectList))
+		case "/b/bucket/o/b.json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind": "storage#object", "name": "b.json", "bucket": "bucket", "contentType": "application/json", "size": "19", "updated": "2024-03-01T10:01:00Z"}`))
+		case "/bucket/a.json", "/bucket/c.json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"object":"` + r.URL.Path[len("/bucket/"):] + `"}`))
+		case "/bucket/b.json":
+			if failing.Load() {
+				failedReads.Add(1)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"object":"b.json"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(serv.Close)
+
+	client, err := storage.NewClient(context.Background(), option.WithEndpoint(serv.URL), option.WithoutAuthentication())
+	require.NoError(t, err)
+	bkt := client.Bucket("bucket").Retryer(
+		storage.WithMaxAttempts(2),
+		storage.WithBackoff(gax.Backoff{Initial: time.Millisecond, Max: time.Millisecond}),
+		storage.WithPolicy(storage.RetryAlways),
+	)
+
+	src := &Source{
+		BucketName: "bucket",
+		BatchSize:  10,
+		MaxWorkers: 1,
+		ReaderConfig: readerConfig{
+			Decoding: decoderConfig{},
+		},
+	}
+	publisher := &pub{t: t}
+	s := newScheduler(publisher, bkt, src, &config{}, newState(), noopReporter{}, nil, logp.NewLogger("gcs_test"))
+
+	// the failing object is retried per the retry config and then skipped
+	require.NoError(t, s.scheduleOnce(context.Background()))
+	assert.Equal(t, int32(2), failedReads.Load())
+	assert.Equal(t, []string{`{"object":"a.json"}`, `{"object":"c.json"}`}, publishedMessages(publisher))
+	assert.Equal(t, uint64(1), s.metrics.gcsObjectsReadFailedTotal.Get())
+	assert.Equal(t, uint64(0), s.metrics.decodeErrorsTotal.Get())
+	assert.Contains(t, s.state.checkpoint().FailedJobs, "b.json")
+
+	// the failed object is read again on the next poll
+	failing.Store(false)
+	publisher.events = nil
+	require.NoError(t, s.scheduleOnce(context.Background()))
+	assert.Equal(t, []string{`{"object":"b.json"}`}, publishedMessages(publisher))
+	assert.NotContains(t, s.state.checkpoint().FailedJobs, "b.json")
+}
+
+func publishedMessages(p *pub) []string {
+	msgs := make([]string, 0, len(p.events))
+	for _, e := range p.events {
+		msg, _ := e.Fields.GetValue("message")
+		msgs = append(msgs, msg.(string))
+	}
+	return msgs
+}
+
+func Test_ScheduleOnceHeartbeat(t *testing.T) {
+	const objectList = `{"kind": "storage#objects", "items": [
+		{"kind": "storage#object", "name": "a.json", "bucket": "bucket", "contentType": "application/json", "size": "19", "updated": "2024-03-01T10:00:00Z"}
+	]}`
+	serv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/b/bucket/o":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(objectList))
+		case "/b/empty/o":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind": "storage#objects"}`))
+		case "/bucket/a.json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"object":"a.json"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(serv.Close)
+
+	client, err := storage.NewClient(context.Background(), option.WithEndpoint(serv.URL), option.WithoutAuthentication())
+	require.NoError(t, err)
+
+	newTestScheduler := func(bucket string, emitHeartbeat bool, publisher *pub) *scheduler {
+		src := &Source{
+			BucketName:    bucket,
+			BatchSize:     10,
+			MaxWorkers:    1,
+			EmitHeartbeat: emitHeartbeat,
+		}
+		return newScheduler(publisher, client.Bucket(bucket), src, &config{}, newState(), noopReporter{}, nil, logp.NewLogger("gcs_test"))
+	}
+
+	t.Run("no new objects", func(t *testing.T) {
+		publisher := &pub{t: t}
+		s := newTestScheduler("empty", true, publisher)
+		before := time.Now()
+		require.NoError(t, s.scheduleOnce(context.Background()))
+		require.Len(t, publisher.events, 1)
+
+		event := publisher.events[0]
+		name, err := event.Fields.GetValue("gcs.storage.bucket.name")
+		require.NoError(t, err)
+		assert.Equal(t, "empty", name)
+		pollTime, err := event.Fields.GetValue("gcs.heartbeat.poll_time")
+		require.NoError(t, err)
+		assert.Equal(t, event.Timestamp, pollTime)
+		assert.False(t, event.Timestamp.Before(before))
+	})
+	t.Run("objects processed", func(t *testing.T) {
+		publisher := &pub{t: t}
+		s := newTestScheduler("bucket", true, publisher)
+		require.NoError(t, s.scheduleOnce(context.Background()))
+		assert.Equal(t, []string{`{"object":"a.json"}`}, publishedMessages(publisher))
+
+		// the object was already read, so the next poll only finds old objects
+		publisher.events = nil
+		require.NoError(t, s.scheduleOnce(context.Background()))
+		require.Len(t, publisher.events, 1)
+		_, err := publisher.events[0].Fields.GetValue("gcs.heartbeat.poll_time")
+		assert.NoError(t, err)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		publisher := &pub{t: t}
+		s := newTestScheduler("empty", false, publisher)
+		require.NoError(t, s.scheduleOnce(context.Background()))
+		assert.Empty(t, publisher.events)
+	})
+}
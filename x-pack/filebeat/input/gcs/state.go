@@ -30,12 +30,20 @@ type Checkpoint struct {
 	LatestEntryTime time.Time
 	// list of failed jobs due to unexpected errors/download errors
 	FailedJobs map[string]int
+	// replay list entries that were read, so that they are not read again
+	// when the input restarts
+	ReplayedObjects map[string]bool
+	// list of failed replay jobs, by replay list entry, which includes the
+	// generation of the object
+	FailedReplayJobs map[string]int
 }
 
 func newState() *state {
 	return &state{
 		cp: &Checkpoint{
-			FailedJobs: make(map[string]int),
+			FailedJobs:       make(map[string]int),
+			ReplayedObjects:  make(map[string]bool),
+			FailedReplayJobs: make(map[string]int),
 		},
 	}
 }
@@ -66,6 +74,58 @@ func (s *state) saveForTx(name string, lastModifiedOn time.Time, metrics *inputM
 	return s.cp, func() { s.mu.Unlock() }
 }
 
+// saveReplayForTx records the replay list entry as read and returns the
+// current state checkpoint, locking the state like saveForTx. Replayed objects
+// are older than the checkpoint, so the object name and timestamp of the
+// checkpoint are left untouched.
+func (s *state) saveReplayForTx(key string, metrics *inputMetrics) (cp *Checkpoint, done func()) {
+	s.mu.Lock()
+	if _, ok := s.cp.FailedReplayJobs[key]; ok {
+		delete(s.cp.FailedReplayJobs, key)
+		metrics.gcsObjectsTracked.Dec()
+	}
+	s.cp.ReplayedObjects[key] = true
+	return s.cp, func() { s.mu.Unlock() }
+}
+
+// updateFailedReplayJobs adds a replay list entry to the failed replay jobs,
+// which are retried on the next polls. A failed replay job is re-tried a
+// maximum of 3 times, after which the entry is recorded as read so that it
+// is not replayed again.
+func (s *state) updateFailedReplayJobs(key string, metrics *inputMetrics) {
+	s.mu.Lock()
+	if _, ok := s.cp.FailedReplayJobs[key]; !ok {
+		metrics.gcsObjectsTracked.Inc()
+		metrics.gcsFailedJobsTotal.Inc()
+	}
+	s.cp.FailedReplayJobs[key]++
+	if s.cp.FailedReplayJobs[key] > maxFailedJobRetries {
+		delete(s.cp.FailedReplayJobs, key)
+		s.cp.ReplayedObjects[key] = true
+		metrics.gcsExpiredFailedJobsTotal.Inc()
+		metrics.gcsObjectsTracked.Dec()
+	}
+	s.mu.Unlock()
+}
+
+// isReplayed returns whether the replay list entry was already read.
+func (s *state) isReplayed(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cp.ReplayedObjects[key]
+}
+
+// failedReplayJobs returns the replay list entries of the failed replay jobs.
+func (s *state) failedReplayJobs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.cp.FailedReplayJobs))
+	for key := range s.cp.FailedReplayJobs {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
 // updateFailedJobs, adds a job name to a failedJobs map, which helps
 // in keeping track of failed jobs during edge cases when the state might
 // move ahead in timestamp & objectName due to successful operations from other workers.
@@ -104,6 +164,12 @@ func (s *state) setCheckpoint(chkpt *Checkpoint) {
 	if chkpt.FailedJobs == nil {
 		chkpt.FailedJobs = make(map[string]int)
 	}
+	if chkpt.ReplayedObjects == nil {
+		chkpt.ReplayedObjects = make(map[string]bool)
+	}
+	if chkpt.FailedReplayJobs == nil {
+		chkpt.FailedReplayJobs = make(map[string]int)
+	}
 	s.cp = chkpt
 }
 
@@ -23,6 +23,11 @@ type Source struct {
 	ReaderConfig             readerConfig
 	ExpandEventListFromField string
 	Retry                    retryConfig
+	QueueDiscipline          string
+	SkipEmptyObjects         bool
+	ReplayObjects            []replayObject
+	EmitHeartbeat            bool
+	SkipMissingBuckets       bool
 }
 
 func (s *Source) Name() string {
@@ -10,10 +10,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/textproto"
+	"slices"
 	"strings"
 
 	"gopkg.in/natefinch/lumberjack.v2"
 
+	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 )
 
@@ -54,8 +56,23 @@ type config struct {
 	IncludeHeaders        []string                `config:"include_headers"`
 	PreserveOriginalEvent bool                    `config:"preserve_original_event"`
 	Tracer                *tracerConfig           `config:"tracer"`
+	Async                 *asyncConfig            `config:"async"`
+	Routes                []*conf.C               `config:"routes"`
 }
 
+// routeConfig is the configuration of a single end point served by
+// the listener. Options not set for a route are inherited from the
+// top-level input configuration.
+type routeConfig struct {
+	Config  config   `config:",inline"`
+	Dataset string   `config:"dataset"`
+	Tags    []string `config:"tags"`
+}
+
+// listenerOptions are the options that apply to the listener as a
+// whole and so may not be set for individual routes.
+var listenerOptions = []string{"listen_address", "listen_port", "ssl", "tracer", "async", "routes"}
+
 type tracerConfig struct {
 	Enabled           *bool `config:"enabled"`
 	lumberjack.Logger `config:",inline"`
@@ -65,6 +82,19 @@ func (t *tracerConfig) enabled() bool {
 	return t != nil && (t.Enabled == nil || *t.Enabled)
 }
 
+// asyncConfig configures the asynchronous publication of requests. When
+// enabled, requests are written to a spool in Path holding at most
+// MaxBytes and are answered before their events are published.
+type asyncConfig struct {
+	Enabled  *bool  `config:"enabled"`
+	Path     string `config:"path"`
+	MaxBytes int64  `config:"max_bytes"`
+}
+
+func (a *asyncConfig) enabled() bool {
+	return a != nil && (a.Enabled == nil || *a.Enabled)
+}
+
 func defaultConfig() config {
 	return config{
 		Method:        http.MethodPost,
@@ -124,9 +154,60 @@ func (c *config) Validate() error {
 		return fmt.Errorf("max_body_bytes is negative: %d", *c.MaxBodySize)
 	}
 
+	if c.Async != nil && c.Async.MaxBytes < 0 {
+		return fmt.Errorf("async.max_bytes is negative: %d", c.Async.MaxBytes)
+	}
+
 	return nil
 }
 
+// routeConfigs returns the configurations of the end points defined in
+// the routes option. Each route is unpacked over a copy of c so that
+// it inherits the options it does not set. routeConfigs returns nil
+// if no routes are defined.
+func (c *config) routeConfigs() ([]routeConfig, error) {
+	if len(c.Routes) == 0 {
+		return nil, nil
+	}
+	routes := make([]routeConfig, 0, len(c.Routes))
+	seen := make(map[string]int)
+	for i, raw := range c.Routes {
+		for _, opt := range listenerOptions {
+			if raw.HasField(opt) {
+				return nil, fmt.Errorf("routes.%d: %s may only be set for the input", i, opt)
+			}
+		}
+		if !raw.HasField("url") {
+			return nil, fmt.Errorf("routes.%d: url is required", i)
+		}
+
+		base := *c
+		base.Routes = nil
+		// Arrays are merged element-wise by Unpack, so drop
+		// inherited values that the route replaces.
+		if raw.HasField("include_headers") {
+			base.IncludeHeaders = nil
+		} else {
+			base.IncludeHeaders = slices.Clone(c.IncludeHeaders)
+		}
+		if raw.HasField("options_headers") {
+			base.OptionsHeaders = nil
+		} else {
+			base.OptionsHeaders = c.OptionsHeaders.Clone()
+		}
+		r := routeConfig{Config: base}
+		if err := raw.Unpack(&r); err != nil {
+			return nil, fmt.Errorf("routes.%d: %w", i, err)
+		}
+		if j, ok := seen[r.Config.URL]; ok {
+			return nil, fmt.Errorf("routes.%d: url %s is already used by routes.%d", i, r.Config.URL, j)
+		}
+		seen[r.Config.URL] = i
+		routes = append(routes, r)
+	}
+	return routes, nil
+}
+
 func isValidCRCProvider(name string) bool {
 	_, exists := crcProviders[strings.ToLower(name)]
 	return exists
@@ -61,3 +61,135 @@ func Test_validateConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_routeConfigs(t *testing.T) {
+	testCases := []struct {
+		name      string
+		config    map[string]interface{}
+		want      []routeConfig
+		wantError string
+	}{
+		{
+			name: "no routes",
+			config: map[string]interface{}{
+				"url": "/",
+			},
+		},
+		{
+			name: "inherited options",
+			config: map[string]interface{}{
+				"prefix":          "body",
+				"include_headers": []string{"X-A", "X-B"},
+				"routes": []map[string]interface{}{
+					{
+						"url":     "/github",
+						"dataset": "github.audit",
+						"tags":    []string{"github"},
+						"hmac": map[string]interface{}{
+							"header": "X-Hub-Signature-256",
+							"key":    "secret",
+							"type":   "sha256",
+							"prefix": "sha256=",
+						},
+					},
+					{
+						"url":             "/okta",
+						"prefix":          "json",
+						"content_type":    "application/x-ndjson",
+						"include_headers": []string{"X-C"},
+						"secret": map[string]interface{}{
+							"header": "Authorization",
+							"value":  "token",
+						},
+					},
+				},
+			},
+			want: []routeConfig{
+				{
+					Config: func() config {
+						c := defaultConfig()
+						c.URL = "/github"
+						c.Prefix = "body"
+						c.IncludeHeaders = []string{"X-A", "X-B"}
+						c.HMACHeader = "X-Hub-Signature-256"
+						c.HMACKey = "secret"
+						c.HMACType = "sha256"
+						c.HMACPrefix = "sha256="
+						return c
+					}(),
+					Dataset: "github.audit",
+					Tags:    []string{"github"},
+				},
+				{
+					Config: func() config {
+						c := defaultConfig()
+						c.URL = "/okta"
+						c.ContentType = "application/x-ndjson"
+						c.IncludeHeaders = []string{"X-C"}
+						c.SecretHeader = "Authorization"
+						c.SecretValue = "token"
+						return c
+					}(),
+				},
+			},
+		},
+		{
+			name: "missing url",
+			config: map[string]interface{}{
+				"routes": []map[string]interface{}{
+					{"dataset": "a"},
+				},
+			},
+			wantError: "routes.0: url is required",
+		},
+		{
+			name: "duplicate url",
+			config: map[string]interface{}{
+				"routes": []map[string]interface{}{
+					{"url": "/a"},
+					{"url": "/b"},
+					{"url": "/a"},
+				},
+			},
+			wantError: "routes.2: url /a is already used by routes.0",
+		},
+		{
+			name: "listener option",
+			config: map[string]interface{}{
+				"routes": []map[string]interface{}{
+					{"url": "/a", "listen_port": "9000"},
+				},
+			},
+			wantError: "routes.0: listen_port may only be set for the input",
+		},
+		{
+			name: "invalid route",
+			config: map[string]interface{}{
+				"routes": []map[string]interface{}{
+					{"url": "/a", "hmac.header": "X-Signature"},
+				},
+			},
+			wantError: "both hmac.header and hmac.key must be set",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := confpkg.MustNewConfigFrom(tc.config)
+			config := defaultConfig()
+			err := c.Unpack(&config)
+			if err != nil {
+				t.Fatalf("unexpected error unpacking config: %v", err)
+			}
+			got, err := config.routeConfigs()
+			if tc.wantError != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.wantError)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -83,6 +83,14 @@ type handler struct {
 	includeHeaders        []string
 	preserveOriginalEvent bool
 	crc                   *crcValidator
+	dataset               string
+	tags                  []string
+
+	// route is the mux pattern of the handler.
+	route string
+	// spool holds requests for asynchronous publication
+	// when the input is in async mode.
+	spool *spool
 }
 
 func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -190,12 +198,17 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		headers = getIncludedHeaders(r, h.includeHeaders)
 	}
 
+	h.metrics.batchSize.Update(int64(len(objs)))
+	if h.spool != nil {
+		h.enqueue(txID, w, r, objs, headers)
+		return
+	}
+
 	var (
 		respCode int
 		respBody string
 	)
 
-	h.metrics.batchSize.Update(int64(len(objs)))
 	for _, obj := range objs {
 		var err error
 		if h.crc != nil {
@@ -252,6 +265,58 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 var errTookTooLong = errors.New("could not publish event within timeout")
 
+// enqueue handles a request in async mode. CRC requests are answered
+// directly, otherwise the request is appended to the spool for later
+// publication and answered with 202 Accepted.
+func (h *handler) enqueue(txID string, w http.ResponseWriter, r *http.Request, objs []mapstr.M, headers mapstr.M) {
+	if h.crc != nil {
+		for _, obj := range objs {
+			respCode, respBody, err := h.crc.validate(obj)
+			if err == nil {
+				h.sendResponse(w, respCode, respBody)
+				return
+			}
+			if !errors.Is(err, errNotCRC) {
+				h.metrics.apiErrors.Add(1)
+				h.status.UpdateStatus(status.Degraded, "request did not validate with CRC: "+err.Error())
+				h.sendAPIErrorResponse(txID, w, r, h.log, http.StatusBadRequest, err)
+				return
+			}
+		}
+	}
+
+	rec := spoolRecord{Route: h.route, Events: objs}
+	if len(headers) != 0 {
+		rec.Headers = make(map[string][]string, len(headers))
+		for k, v := range headers {
+			rec.Headers[k], _ = v.([]string)
+		}
+	}
+	data, err := json.Marshal(rec)
+	if err == nil {
+		err = h.spool.append(data)
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, errSpoolFull):
+		w.Header().Set("Retry-After", strconv.Itoa(h.retryAfter))
+		h.status.UpdateStatus(status.Degraded, err.Error())
+		h.sendAPIErrorResponse(txID, w, r, h.log, http.StatusServiceUnavailable, err)
+		return
+	default:
+		h.metrics.apiErrors.Add(1)
+		h.status.UpdateStatus(status.Degraded, "failed to spool request: "+err.Error())
+		h.sendAPIErrorResponse(txID, w, r, h.log, http.StatusInternalServerError, err)
+		return
+	}
+	h.metrics.batchesSpooled.Add(1)
+	h.status.UpdateStatus(status.Running, "")
+	h.sendResponse(w, http.StatusAccepted, h.responseBody)
+	if h.reqLogger != nil {
+		h.logRequest(txID, r, http.StatusAccepted, nil)
+	}
+}
+
 func getTimeoutWait(u *url.URL, log *logp.Logger) (time.Duration, error) {
 	q := u.Query()
 	switch len(q) {
@@ -391,6 +456,13 @@ func (h *handler) publishEvent(obj, headers mapstr.M, acker *batchACKTracker) er
 	if len(headers) > 0 {
 		event.Fields["headers"] = headers
 	}
+	if h.dataset != "" {
+		_, _ = event.Fields.Put("event.dataset", h.dataset)
+		_, _ = event.Fields.Put("data_stream.dataset", h.dataset)
+	}
+	if len(h.tags) > 0 {
+		_ = mapstr.AddTags(event.Fields, h.tags)
+	}
 
 	h.publish(event)
 	return nil
@@ -39,6 +39,7 @@ import (
 	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/monitoring"
 	"github.com/elastic/elastic-agent-libs/monitoring/adapter"
+	"github.com/elastic/elastic-agent-libs/paths"
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 	"github.com/elastic/go-concert/ctxtool"
 )
@@ -49,6 +50,7 @@ const (
 
 type httpEndpoint struct {
 	config    config
+	routes    []routeConfig
 	addr      string
 	tlsConfig *tls.Config
 }
@@ -75,6 +77,10 @@ func newHTTPEndpoint(config config) (*httpEndpoint, error) {
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
+	routes, err := config.routeConfigs()
+	if err != nil {
+		return nil, err
+	}
 
 	addr := net.JoinHostPort(config.ListenAddress, config.ListenPort)
 
@@ -89,6 +95,7 @@ func newHTTPEndpoint(config config) (*httpEndpoint, error) {
 
 	return &httpEndpoint{
 		config:    config,
+		routes:    routes,
 		tlsConfig: tlsConfig,
 		addr:      addr,
 	}, nil
@@ -116,6 +123,25 @@ func (e *httpEndpoint) Run(ctx v2.Context, pipeline beat.Pipeline) error {
 		e.config.Tracer.Filename = strings.ReplaceAll(e.config.Tracer.Filename, "*", id)
 	}
 
+	var spl *spool
+	if e.config.Async.enabled() {
+		dir := e.config.Async.Path
+		if dir == "" {
+			if ctx.IDWithoutName == "" {
+				ctx.UpdateStatus(status.Failed, "async.path is required for inputs without an id")
+				return errors.New("async.path is required for inputs without an id")
+			}
+			dir = paths.Resolve(paths.Data, filepath.Join(inputName, sanitizeFileName(ctx.IDWithoutName)))
+		}
+		var err error
+		spl, err = openSpool(dir, e.config.Async.MaxBytes, ctx.Logger)
+		if err != nil {
+			ctx.UpdateStatus(status.Failed, "failed to open async spool: "+err.Error())
+			return fmt.Errorf("failed to open async spool: %w", err)
+		}
+		defer spl.close()
+	}
+
 	client, err := pipeline.ConnectWith(beat.ClientConfig{
 		EventListener: newEventACKHandler(),
 	})
@@ -125,7 +151,7 @@ func (e *httpEndpoint) Run(ctx v2.Context, pipeline beat.Pipeline) error {
 	}
 	defer client.Close()
 
-	err = servers.serve(ctx, e, client.Publish, metrics)
+	err = servers.serve(ctx, e, client.Publish, spl, metrics)
 	if err != nil && !errors.Is(err, http.ErrServerClosed) {
 		return fmt.Errorf("unable to start server due to error: %w", err)
 	}
@@ -156,51 +182,87 @@ type pool struct {
 // publishing to pub. The server will run until either the context is
 // cancelled or the context of another end-point sharing the same address
 // has had its context cancelled. If an end-point is re-registered with
-// the same address and mux pattern, serve will return an error.
-func (p *pool) serve(ctx v2.Context, e *httpEndpoint, pub func(beat.Event), metrics *inputMetrics) error {
+// the same address and mux pattern, serve will return an error. If spl is
+// not nil, requests are written to it and published asynchronously.
+func (p *pool) serve(ctx v2.Context, e *httpEndpoint, pub func(beat.Event), spl *spool, metrics *inputMetrics) error {
 	log := ctx.Logger.With("address", e.addr)
-	pattern := e.config.URL
 
-	u, err := url.Parse(pattern)
-	if err != nil {
-		ctx.UpdateStatus(status.Failed, "configured URL is invalid: "+err.Error())
-		return err
+	routes := e.routes
+	if len(routes) == 0 {
+		routes = []routeConfig{{Config: e.config}}
 	}
-	metrics.route.Set(u.Path)
-	metrics.isTLS.Set(e.tlsConfig != nil)
-
-	var prg *program
-	if e.config.Program != "" {
-		prg, err = newProgram(e.config.Program, log)
+	patterns := make([]string, len(routes))
+	paths := make([]string, len(routes))
+	prgs := make([]*program, len(routes))
+	for i, r := range routes {
+		patterns[i] = r.Config.URL
+		u, err := url.Parse(r.Config.URL)
 		if err != nil {
-			ctx.UpdateStatus(status.Failed, "unable to compile CEL program: "+err.Error())
+			ctx.UpdateStatus(status.Failed, "configured URL is invalid: "+err.Error())
 			return err
 		}
+		paths[i] = u.Path
+		if r.Config.Program != "" {
+			prgs[i], err = newProgram(r.Config.Program, log)
+			if err != nil {
+				ctx.UpdateStatus(status.Failed, "unable to compile CEL program: "+err.Error())
+				return err
+			}
+		}
+	}
+	pattern := strings.Join(patterns, ", ")
+	metrics.route.Set(strings.Join(paths, ","))
+	metrics.isTLS.Set(e.tlsConfig != nil)
+
+	handlers := make(map[string]*handler, len(routes))
+	register := func(s *server) {
+		for i, r := range routes {
+			h := newHandler(s.ctx, r, prgs[i], pub, ctx.StatusReporter, log, metrics)
+			h.spool = spl
+			s.mux.Handle(r.Config.URL, h)
+			s.idOf[r.Config.URL] = ctx.ID
+			handlers[r.Config.URL] = h
+		}
+	}
+	// The spool replay is stopped when the end points are removed.
+	replayCtx, cancelReplay := context.WithCancel(ctxtool.FromCanceller(ctx.Cancelation))
+	defer cancelReplay()
+	startReplay := func() {
+		if spl == nil {
+			return
+		}
+		spl.readers.Add(1)
+		go func() {
+			defer spl.readers.Done()
+			replay(replayCtx, spl, handlers, log, metrics)
+		}()
 	}
 
 	p.mu.Lock()
 	s, ok := p.servers[e.addr]
 	if ok {
-		err = checkTLSConsistency(e.addr, s.tls, e.config.TLS)
+		err := checkTLSConsistency(e.addr, s.tls, e.config.TLS)
 		if err != nil {
 			p.mu.Unlock()
 			ctx.UpdateStatus(status.Failed, err.Error())
 			return err
 		}
 
-		if old, ok := s.idOf[pattern]; ok {
-			err = fmt.Errorf("pattern already exists for %s: %s old=%s new=%s",
-				e.addr, pattern, old, ctx.ID)
-			s.setErr(err)
-			s.cancel()
-			p.mu.Unlock()
-			ctx.UpdateStatus(status.Failed, err.Error())
-			return err
+		for _, pattern := range patterns {
+			if old, ok := s.idOf[pattern]; ok {
+				err = fmt.Errorf("pattern already exists for %s: %s old=%s new=%s",
+					e.addr, pattern, old, ctx.ID)
+				s.setErr(err)
+				s.cancel()
+				p.mu.Unlock()
+				ctx.UpdateStatus(status.Failed, err.Error())
+				return err
+			}
 		}
 		log.Infof("Adding %s end point to server on %s", pattern, e.addr)
-		s.mux.Handle(pattern, newHandler(s.ctx, e.config, prg, pub, ctx.StatusReporter, log, metrics))
-		s.idOf[pattern] = ctx.ID
+		register(s)
 		p.mu.Unlock()
+		startReplay()
 		<-s.ctx.Done()
 		return s.getErr()
 	}
@@ -208,15 +270,18 @@ func (p *pool) serve(ctx v2.Context, e *httpEndpoint, pub func(beat.Event), metr
 	mux := http.NewServeMux()
 	srv := &http.Server{Addr: e.addr, TLSConfig: e.tlsConfig, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
 	s = &server{
-		idOf: map[string]string{pattern: ctx.ID},
+		idOf: make(map[string]string),
 		tls:  e.config.TLS,
 		mux:  mux,
 		srv:  srv,
 	}
 	s.ctx, s.cancel = ctxtool.WithFunc(ctx.Cancelation, func() { srv.Close() })
-	mux.Handle(pattern, newHandler(s.ctx, e.config, prg, pub, ctx.StatusReporter, log, metrics))
+	register(s)
 	p.servers[e.addr] = s
 	p.mu.Unlock()
+	startReplay()
+
+	var err error
 
 	ctx.UpdateStatus(status.Running, "")
 	if e.tlsConfig != nil {
@@ -345,7 +410,8 @@ func (s *server) getErr() error {
 	return s.err
 }
 
-func newHandler(ctx context.Context, c config, prg *program, pub func(beat.Event), stat status.StatusReporter, log *logp.Logger, metrics *inputMetrics) http.Handler {
+func newHandler(ctx context.Context, r routeConfig, prg *program, pub func(beat.Event), stat status.StatusReporter, log *logp.Logger, metrics *inputMetrics) *handler {
+	c := r.Config
 	h := &handler{
 		ctx:      ctx,
 		log:      log,
@@ -379,6 +445,9 @@ func newHandler(ctx context.Context, c config, prg *program, pub func(beat.Event
 		includeHeaders:        canonicalizeHeaders(c.IncludeHeaders),
 		preserveOriginalEvent: c.PreserveOriginalEvent,
 		crc:                   newCRC(c.CRCProvider, c.CRCSecret),
+		dataset:               r.Dataset,
+		tags:                  r.Tags,
+		route:                 c.URL,
 	}
 	if h.status == nil {
 		h.status = noopReporter{}
@@ -461,6 +530,7 @@ type inputMetrics struct {
 	batchesReceived     *monitoring.Uint   // number of event arrays received
 	batchesPublished    *monitoring.Uint   // number of event arrays published
 	batchesACKedTotal   *monitoring.Uint   // Number of event arrays ACKed.
+	batchesSpooled      *monitoring.Uint   // number of event arrays written to the async spool
 	eventsPublished     *monitoring.Uint   // number of events published
 	contentLength       metrics.Sample     // histogram of request content lengths.
 	batchSize           metrics.Sample     // histogram of the received batch sizes.
@@ -479,6 +549,7 @@ func newInputMetrics(id string) *inputMetrics {
 		batchesReceived:     monitoring.NewUint(reg, "batches_received_total"),
 		batchesPublished:    monitoring.NewUint(reg, "batches_published_total"),
 		batchesACKedTotal:   monitoring.NewUint(reg, "batches_acked_total"),
+		batchesSpooled:      monitoring.NewUint(reg, "batches_spooled_total"),
 		eventsPublished:     monitoring.NewUint(reg, "events_published_total"),
 		contentLength:       metrics.NewUniformSample(1024),
 		batchSize:           metrics.NewUniformSample(1024),
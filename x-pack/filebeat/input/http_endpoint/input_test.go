@@ -394,6 +394,59 @@ var serverPoolTests = []struct {
 			{"json": mapstr.M{"c": int64(3)}},
 		},
 	},
+	{
+		name: "routes",
+		cfgs: []*httpEndpoint{{
+			addr: "127.0.0.1:9001",
+			routes: []routeConfig{
+				{
+					Config: config{
+						ResponseCode:  http.StatusOK,
+						ResponseBody:  `{"message": "success"}`,
+						ListenAddress: "127.0.0.1",
+						ListenPort:    "9001",
+						URL:           "/a/",
+						Prefix:        "json",
+						ContentType:   "application/json",
+					},
+					Dataset: "vendor_a.audit",
+					Tags:    []string{"vendor_a"},
+				},
+				{
+					Config: config{
+						ResponseCode:  http.StatusOK,
+						ResponseBody:  `{"message": "success"}`,
+						ListenAddress: "127.0.0.1",
+						ListenPort:    "9001",
+						URL:           "/b/",
+						Prefix:        "body",
+						ContentType:   "application/json",
+					},
+				},
+			},
+		}},
+		events: []target{
+			{url: "http://127.0.0.1:9001/a/", event: `{"a":1}`},
+			{url: "http://127.0.0.1:9001/b/", event: `{"b":2}`},
+			{url: "http://127.0.0.1:9001/a/", event: `{"c":3}`},
+		},
+		wantStatus: http.StatusOK,
+		want: []mapstr.M{
+			{
+				"json":        mapstr.M{"a": int64(1)},
+				"event":       mapstr.M{"dataset": "vendor_a.audit"},
+				"data_stream": mapstr.M{"dataset": "vendor_a.audit"},
+				"tags":        []string{"vendor_a"},
+			},
+			{"body": mapstr.M{"b": int64(2)}},
+			{
+				"json":        mapstr.M{"c": int64(3)},
+				"event":       mapstr.M{"dataset": "vendor_a.audit"},
+				"data_stream": mapstr.M{"dataset": "vendor_a.audit"},
+				"tags":        []string{"vendor_a"},
+			},
+		},
+	},
 }
 
 type target struct {
@@ -439,7 +492,7 @@ func TestServerPool(t *testing.T) {
 				wg.Add(1)
 				go func() {
 					defer wg.Done()
-					err := servers.serve(ctx, cfg, pub.Publish, metrics)
+					err := servers.serve(ctx, cfg, pub.Publish, nil, metrics)
 					if err != http.ErrServerClosed {
 						select {
 						case fails <- err:
@@ -496,7 +549,7 @@ func TestServerPool(t *testing.T) {
 				wg.Add(1)
 				go func() {
 					defer wg.Done()
-					err := servers.serve(ctx, cfg, pub.Publish, metrics)
+					err := servers.serve(ctx, cfg, pub.Publish, nil, metrics)
 					if err != nil && err != http.ErrServerClosed && test.wantErr == nil {
 						t.Errorf("failed to re-register %v: %v", cfg.addr, err)
 					}
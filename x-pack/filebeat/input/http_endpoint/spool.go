@@ -0,0 +1,493 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package http_endpoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/jsontransform"
+	"github.com/elastic/elastic-agent-libs/file"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	// defaultSpoolMaxBytes is the default bound on the size of the
+	// async mode spool.
+	defaultSpoolMaxBytes = 100 << 20
+
+	segmentExt     = ".wal"
+	checkpointName = "checkpoint.json"
+
+	// recordHeaderLen is the length of the record header holding the
+	// little-endian length and CRC-32 of the record payload.
+	recordHeaderLen = 8
+)
+
+var (
+	errSpoolFull   = errors.New("async spool is full")
+	errSpoolClosed = errors.New("async spool is closed")
+)
+
+// spoolRecord is a request received in async mode. Events are the
+// decoded objects of the request body.
+type spoolRecord struct {
+	Route   string              `json:"route"`
+	Events  []mapstr.M          `json:"events"`
+	Headers map[string][]string `json:"headers,omitempty"`
+}
+
+// spoolPos is a position in the spool.
+type spoolPos struct {
+	Segment uint64 `json:"segment"`
+	Offset  int64  `json:"offset"`
+}
+
+func (p spoolPos) before(q spoolPos) bool {
+	return p.Segment < q.Segment || (p.Segment == q.Segment && p.Offset < q.Offset)
+}
+
+type segment struct {
+	seq  uint64
+	size int64
+}
+
+// spool is a bounded write-ahead log holding the requests received in
+// async mode until their events have been published. Records are appended
+// to a sequence of segment files and are read back in order by a single
+// consumer that commits each record once its events have been ACKed.
+// Segments preceding the committed position are removed and the committed
+// position is persisted so that replay resumes from it after a restart.
+type spool struct {
+	dir     string
+	maxSize int64
+	segSize int64
+	log     *logp.Logger
+
+	mu        sync.Mutex
+	segs      []segment // Ordered oldest first, the last is being written.
+	size      int64     // Total size of segs.
+	w         *os.File
+	committed spoolPos
+	closed    bool
+	notify    chan struct{}
+
+	// readers tracks the consumer goroutine.
+	readers sync.WaitGroup
+
+	// r and rpos are the consumer's reader state.
+	r    *os.File
+	rpos spoolPos
+}
+
+// openSpool opens the spool in dir, creating it if necessary. Records
+// left from a previous run that have not been committed will be returned
+// by next. If maxSize is zero, defaultSpoolMaxBytes is used.
+func openSpool(dir string, maxSize int64, log *logp.Logger) (*spool, error) {
+	if maxSize == 0 {
+		maxSize = defaultSpoolMaxBytes
+	}
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+	s := &spool{
+		dir:     dir,
+		maxSize: maxSize,
+		segSize: max(maxSize/16, recordHeaderLen),
+		log:     log,
+		notify:  make(chan struct{}, 1),
+	}
+
+	s.committed, err = s.readCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spool directory: %w", err)
+	}
+	var seqs []uint64
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	for _, seq := range seqs {
+		if seq < s.committed.Segment {
+			err = os.Remove(s.segmentPath(seq))
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove committed spool segment: %w", err)
+			}
+			continue
+		}
+		size, err := s.recoverSegment(seq)
+		if err != nil {
+			return nil, err
+		}
+		s.segs = append(s.segs, segment{seq: seq, size: size})
+		s.size += size
+	}
+
+	next := uint64(1)
+	if n := len(s.segs); n != 0 {
+		next = s.segs[n-1].seq + 1
+		if s.segs[n-1].size == 0 {
+			// Reuse an empty final segment.
+			next--
+			s.segs = s.segs[:n-1]
+		}
+	}
+	err = s.create(next)
+	if err != nil {
+		return nil, err
+	}
+	s.rpos = spoolPos{Segment: s.segs[0].seq}
+	if s.segs[0].seq == s.committed.Segment {
+		s.rpos.Offset = min(s.committed.Offset, s.segs[0].size)
+	}
+	return s, nil
+}
+
+func (s *spool) segmentPath(seq uint64) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%020d%s", seq, segmentExt))
+}
+
+// recoverSegment returns the length of the valid records in the segment,
+// truncating any partially written record left at its end.
+func (s *spool) recoverSegment(seq uint64) (int64, error) {
+	path := s.segmentPath(seq)
+	f, err := os.OpenFile(path, os.O_RDWR, 0o600)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open spool segment: %w", err)
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat spool segment: %w", err)
+	}
+	var off int64
+	for off < fi.Size() {
+		n, err := readRecord(f, off, fi.Size(), nil)
+		if err != nil {
+			s.log.Warnw("truncating spool segment", "path", path, "offset", off, "error", err)
+			err = f.Truncate(off)
+			if err != nil {
+				return 0, fmt.Errorf("failed to truncate spool segment: %w", err)
+			}
+			break
+		}
+		off += n
+	}
+	return off, nil
+}
+
+// create starts a new segment for writing. It must be called with
+// s.mu held or before s is shared.
+func (s *spool) create(seq uint64) error {
+	f, err := os.OpenFile(s.segmentPath(seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create spool segment: %w", err)
+	}
+	s.w = f
+	s.segs = append(s.segs, segment{seq: seq})
+	return nil
+}
+
+// append durably writes data as a record at the end of the spool. It
+// returns errSpoolFull if the record would exceed the spool size bound.
+func (s *spool) append(data []byte) error {
+	n := int64(recordHeaderLen + len(data))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errSpoolClosed
+	}
+	if s.size+n > s.maxSize {
+		return errSpoolFull
+	}
+	cur := &s.segs[len(s.segs)-1]
+	if cur.size != 0 && cur.size+n > s.segSize {
+		err := s.w.Close()
+		if err != nil {
+			return fmt.Errorf("failed to close spool segment: %w", err)
+		}
+		err = s.create(cur.seq + 1)
+		if err != nil {
+			return err
+		}
+		cur = &s.segs[len(s.segs)-1]
+	}
+
+	buf := make([]byte, n)
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(data)))
+	binary.LittleEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(data))
+	copy(buf[recordHeaderLen:], data)
+	_, err := s.w.Write(buf)
+	if err == nil {
+		err = s.w.Sync()
+	}
+	if err != nil {
+		// Remove any partial write so the next append starts
+		// at a record boundary.
+		_ = s.w.Truncate(cur.size)
+		return fmt.Errorf("failed to write spool record: %w", err)
+	}
+	cur.size += n
+	s.size += n
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// next returns the next record in the spool and the position following
+// it, blocking until a record is available or ctx is cancelled. next
+// must only be called by the single consumer of the spool.
+func (s *spool) next(ctx context.Context) ([]byte, spoolPos, error) {
+	for {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return nil, spoolPos{}, errSpoolClosed
+		}
+		i := sort.Search(len(s.segs), func(i int) bool { return s.segs[i].seq >= s.rpos.Segment })
+		seg := s.segs[i]
+		last := i == len(s.segs)-1
+		var nextSeq uint64
+		if !last {
+			nextSeq = s.segs[i+1].seq
+		}
+		s.mu.Unlock()
+
+		if s.rpos.Segment != seg.seq {
+			s.closeReader()
+			s.rpos = spoolPos{Segment: seg.seq}
+		}
+		if s.rpos.Offset < seg.size {
+			var buf bytes.Buffer
+			n, err := s.read(seg, &buf)
+			if err == nil {
+				s.rpos.Offset += n
+				return buf.Bytes(), s.rpos, nil
+			}
+			s.log.Errorw("skipping unreadable spool segment remainder", "path", s.segmentPath(seg.seq), "offset", s.rpos.Offset, "error", err)
+			s.rpos.Offset = seg.size
+			continue
+		}
+		if !last {
+			s.closeReader()
+			s.rpos = spoolPos{Segment: nextSeq}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, spoolPos{}, ctx.Err()
+		case <-s.notify:
+		}
+	}
+}
+
+func (s *spool) read(seg segment, dst io.Writer) (int64, error) {
+	if s.r == nil {
+		f, err := os.Open(s.segmentPath(seg.seq))
+		if err != nil {
+			return 0, err
+		}
+		s.r = f
+	}
+	return readRecord(s.r, s.rpos.Offset, seg.size, dst)
+}
+
+func (s *spool) closeReader() {
+	if s.r != nil {
+		s.r.Close()
+		s.r = nil
+	}
+}
+
+// readRecord reads the record at off in f, which holds size bytes of
+// records, and writes its payload to dst if it is not nil. It returns
+// the length of the record.
+func readRecord(f io.ReaderAt, off, size int64, dst io.Writer) (int64, error) {
+	var hdr [recordHeaderLen]byte
+	_, err := f.ReadAt(hdr[:], off)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read record header: %w", err)
+	}
+	n := int64(binary.LittleEndian.Uint32(hdr[0:4]))
+	if off+recordHeaderLen+n > size {
+		return 0, fmt.Errorf("record length %d exceeds segment", n)
+	}
+	data := make([]byte, n)
+	_, err = f.ReadAt(data, off+recordHeaderLen)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read record: %w", err)
+	}
+	if crc32.ChecksumIEEE(data) != binary.LittleEndian.Uint32(hdr[4:8]) {
+		return 0, errors.New("record checksum mismatch")
+	}
+	if dst != nil {
+		_, err = dst.Write(data)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return recordHeaderLen + n, nil
+}
+
+// commit marks all records up to pos as published, removing segments that
+// no longer hold uncommitted records and persisting the position.
+func (s *spool) commit(pos spoolPos) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || !s.committed.before(pos) {
+		return nil
+	}
+	s.committed = pos
+	for len(s.segs) > 1 && s.segs[0].seq < pos.Segment {
+		err := os.Remove(s.segmentPath(s.segs[0].seq))
+		if err != nil {
+			return fmt.Errorf("failed to remove committed spool segment: %w", err)
+		}
+		s.size -= s.segs[0].size
+		s.segs = s.segs[1:]
+	}
+	return s.writeCheckpoint(pos)
+}
+
+func (s *spool) readCheckpoint() (spoolPos, error) {
+	var pos spoolPos
+	b, err := os.ReadFile(filepath.Join(s.dir, checkpointName))
+	if errors.Is(err, os.ErrNotExist) {
+		return pos, nil
+	}
+	if err != nil {
+		return pos, fmt.Errorf("failed to read spool checkpoint: %w", err)
+	}
+	err = json.Unmarshal(b, &pos)
+	if err != nil {
+		s.log.Warnw("ignoring invalid spool checkpoint", "error", err)
+		return spoolPos{}, nil
+	}
+	return pos, nil
+}
+
+func (s *spool) writeCheckpoint(pos spoolPos) error {
+	b, err := json.Marshal(pos)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(s.dir, checkpointName)
+	tmp := path + ".new"
+	err = os.WriteFile(tmp, b, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write spool checkpoint: %w", err)
+	}
+	err = file.SafeFileRotate(path, tmp)
+	if err != nil {
+		return fmt.Errorf("failed to write spool checkpoint: %w", err)
+	}
+	return nil
+}
+
+// close waits for the consumer to return and closes the spool.
+func (s *spool) close() error {
+	s.readers.Wait()
+	s.closeReader()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return s.w.Close()
+}
+
+// replay publishes the requests held in the spool using the handler for
+// the route each was received on, committing each record once all its
+// events have been ACKed. It returns when ctx is cancelled.
+func replay(ctx context.Context, spl *spool, handlers map[string]*handler, log *logp.Logger, metrics *inputMetrics) {
+	for {
+		data, pos, err := spl.next(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Errorw("failed to read async spool", "error", err)
+			}
+			return
+		}
+		commit := func() {
+			err := spl.commit(pos)
+			if err != nil {
+				log.Errorw("failed to commit async spool position", "error", err)
+			}
+		}
+
+		var rec spoolRecord
+		err = newJSONDecoder(bytes.NewReader(data)).Decode(&rec)
+		if err != nil {
+			log.Errorw("dropping undecodable spooled request", "error", err)
+			commit()
+			continue
+		}
+		h, ok := handlers[rec.Route]
+		if !ok {
+			log.Warnw("dropping spooled request for unknown route", "route", rec.Route)
+			commit()
+			continue
+		}
+		var headers mapstr.M
+		if len(rec.Headers) != 0 {
+			headers = make(mapstr.M, len(rec.Headers))
+			for k, v := range rec.Headers {
+				headers[k] = v
+			}
+		}
+
+		start := time.Now()
+		acker := newBatchACKTracker(func() {
+			metrics.batchACKTime.Update(time.Since(start).Nanoseconds())
+			metrics.batchesACKedTotal.Inc()
+			commit()
+		})
+		for _, obj := range rec.Events {
+			jsontransform.TransformNumbers(obj)
+			acker.Add()
+			err = h.publishEvent(obj, headers, acker)
+			if err != nil {
+				metrics.apiErrors.Add(1)
+				log.Errorw("failed to publish spooled event", "route", rec.Route, "error", err)
+				acker.ACK()
+				continue
+			}
+			metrics.eventsPublished.Add(1)
+		}
+		acker.Ready()
+		metrics.batchesPublished.Add(1)
+	}
+}
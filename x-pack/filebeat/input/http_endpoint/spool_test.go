@@ -0,0 +1,297 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package http_endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestSpool(t *testing.T) {
+	log := logp.NewLogger("spool_test")
+	dir := t.TempDir()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	spl, err := openSpool(dir, 1024, log)
+	if err != nil {
+		t.Fatalf("failed to open spool: %v", err)
+	}
+	var want []string
+	for i := 0; i < 20; i++ {
+		rec := fmt.Sprintf("record %d", i)
+		err = spl.append([]byte(rec))
+		if err != nil {
+			t.Fatalf("failed to append record %d: %v", i, err)
+		}
+		want = append(want, rec)
+	}
+	if len(spl.segs) < 2 {
+		t.Errorf("expected spool to rotate segments: got %d segments", len(spl.segs))
+	}
+
+	// Read and commit the first half.
+	var pos spoolPos
+	for i := 0; i < 10; i++ {
+		var data []byte
+		data, pos, err = spl.next(ctx)
+		if err != nil {
+			t.Fatalf("failed to read record %d: %v", i, err)
+		}
+		if string(data) != want[i] {
+			t.Errorf("unexpected record %d: got=%q want=%q", i, data, want[i])
+		}
+	}
+	err = spl.commit(pos)
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	// Read the next record without committing it.
+	_, _, err = spl.next(ctx)
+	if err != nil {
+		t.Fatalf("failed to read record: %v", err)
+	}
+	err = spl.close()
+	if err != nil {
+		t.Fatalf("failed to close spool: %v", err)
+	}
+
+	// Reopening resumes from the committed position.
+	spl, err = openSpool(dir, 1024, log)
+	if err != nil {
+		t.Fatalf("failed to reopen spool: %v", err)
+	}
+	for i := 10; i < 20; i++ {
+		var data []byte
+		data, pos, err = spl.next(ctx)
+		if err != nil {
+			t.Fatalf("failed to read record %d: %v", i, err)
+		}
+		if string(data) != want[i] {
+			t.Errorf("unexpected record %d after reopen: got=%q want=%q", i, data, want[i])
+		}
+	}
+	err = spl.commit(pos)
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	// Readers block until a record is appended.
+	got := make(chan string)
+	go func() {
+		data, _, err := spl.next(ctx)
+		if err != nil {
+			t.Errorf("failed to read record: %v", err)
+		}
+		got <- string(data)
+	}()
+	err = spl.append([]byte("late record"))
+	if err != nil {
+		t.Fatalf("failed to append record: %v", err)
+	}
+	if rec := <-got; rec != "late record" {
+		t.Errorf("unexpected late record: got=%q", rec)
+	}
+	err = spl.close()
+	if err != nil {
+		t.Fatalf("failed to close spool: %v", err)
+	}
+}
+
+func TestSpoolRecovery(t *testing.T) {
+	log := logp.NewLogger("spool_test")
+	dir := t.TempDir()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	spl, err := openSpool(dir, 1<<20, log)
+	if err != nil {
+		t.Fatalf("failed to open spool: %v", err)
+	}
+	for _, rec := range []string{"first", "second"} {
+		err = spl.append([]byte(rec))
+		if err != nil {
+			t.Fatalf("failed to append record: %v", err)
+		}
+	}
+	path := spl.segmentPath(spl.segs[len(spl.segs)-1].seq)
+	err = spl.close()
+	if err != nil {
+		t.Fatalf("failed to close spool: %v", err)
+	}
+
+	// Simulate a crash during a write.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatalf("failed to open segment: %v", err)
+	}
+	_, err = f.Write([]byte{0xff, 0, 0, 0, 1, 2})
+	f.Close()
+	if err != nil {
+		t.Fatalf("failed to write partial record: %v", err)
+	}
+
+	spl, err = openSpool(dir, 1<<20, log)
+	if err != nil {
+		t.Fatalf("failed to reopen spool: %v", err)
+	}
+	defer spl.close()
+	err = spl.append([]byte("third"))
+	if err != nil {
+		t.Fatalf("failed to append record: %v", err)
+	}
+	for _, want := range []string{"first", "second", "third"} {
+		data, _, err := spl.next(ctx)
+		if err != nil {
+			t.Fatalf("failed to read record: %v", err)
+		}
+		if string(data) != want {
+			t.Errorf("unexpected record: got=%q want=%q", data, want)
+		}
+	}
+}
+
+func TestSpoolFull(t *testing.T) {
+	log := logp.NewLogger("spool_test")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	spl, err := openSpool(t.TempDir(), 64, log)
+	if err != nil {
+		t.Fatalf("failed to open spool: %v", err)
+	}
+	defer spl.close()
+	rec := []byte(strings.Repeat("x", 24))
+	for i := 0; i < 2; i++ {
+		err = spl.append(rec)
+		if err != nil {
+			t.Fatalf("failed to append record %d: %v", i, err)
+		}
+	}
+	err = spl.append(rec)
+	if !errors.Is(err, errSpoolFull) {
+		t.Fatalf("unexpected error appending to full spool: got=%v want=%v", err, errSpoolFull)
+	}
+
+	// Committing records frees their segments.
+	var pos spoolPos
+	for i := 0; i < 2; i++ {
+		_, pos, err = spl.next(ctx)
+		if err != nil {
+			t.Fatalf("failed to read record %d: %v", i, err)
+		}
+	}
+	err = spl.append(rec)
+	if !errors.Is(err, errSpoolFull) {
+		t.Fatalf("unexpected error appending to uncommitted spool: got=%v want=%v", err, errSpoolFull)
+	}
+	err = spl.commit(pos)
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	err = spl.append(rec)
+	if err != nil {
+		t.Errorf("failed to append after commit: %v", err)
+	}
+}
+
+func TestServerPoolAsync(t *testing.T) {
+	servers := pool{servers: make(map[string]*server)}
+	spl, err := openSpool(t.TempDir(), 1<<20, logp.NewLogger("spool_test"))
+	if err != nil {
+		t.Fatalf("failed to open spool: %v", err)
+	}
+
+	cfg := &httpEndpoint{
+		addr: "127.0.0.1:9001",
+		config: config{
+			ResponseCode:   http.StatusOK,
+			ResponseBody:   `{"message": "success"}`,
+			ListenAddress:  "127.0.0.1",
+			ListenPort:     "9001",
+			URL:            "/",
+			Prefix:         "json",
+			ContentType:    "application/json",
+			IncludeHeaders: []string{"X-Test"},
+		},
+	}
+
+	var pub publisher
+	ctx, cancel := newCtx("server_pool_async_test", t.Name())
+	metrics := newInputMetrics("")
+	defer metrics.Close()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := servers.serve(ctx, cfg, pub.Publish, spl, metrics)
+		if err != http.ErrServerClosed {
+			t.Errorf("unexpected error calling serve: %v", err)
+		}
+	}()
+	time.Sleep(time.Second)
+
+	for _, body := range []string{`{"a":1}`, `{"b":2.5}`, `[{"c":"x"},{"d":4}]`} {
+		req, err := http.NewRequest(http.MethodPost, "http://127.0.0.1:9001/", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test", "value")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to post event: %v", err)
+		}
+		respBody := dump(resp.Body)
+		if resp.StatusCode != http.StatusAccepted {
+			t.Errorf("unexpected response status code: %s (%d), want: %d\nresp: %s",
+				resp.Status, resp.StatusCode, http.StatusAccepted, respBody)
+		}
+	}
+
+	want := []mapstr.M{
+		{"json": mapstr.M{"a": int64(1)}, "headers": mapstr.M{"X-Test": []string{"value"}}},
+		{"json": mapstr.M{"b": 2.5}, "headers": mapstr.M{"X-Test": []string{"value"}}},
+		{"json": mapstr.M{"c": "x"}, "headers": mapstr.M{"X-Test": []string{"value"}}},
+		{"json": mapstr.M{"d": int64(4)}, "headers": mapstr.M{"X-Test": []string{"value"}}},
+	}
+	var got []mapstr.M
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		pub.mu.Lock()
+		n := len(pub.events)
+		pub.mu.Unlock()
+		if n >= len(want) {
+			break
+		}
+	}
+	cancel()
+	wg.Wait()
+	err = spl.close()
+	if err != nil {
+		t.Errorf("failed to close spool: %v", err)
+	}
+	for _, e := range pub.events {
+		got = append(got, e.Fields)
+	}
+	if !cmp.Equal(want, got) {
+		t.Errorf("unexpected result:\n--- want\n+++ got\n%s", cmp.Diff(want, got))
+	}
+	last := spl.segs[len(spl.segs)-1]
+	if spl.committed != (spoolPos{Segment: last.seq, Offset: last.size}) {
+		t.Errorf("spool not fully committed: committed=%+v segments=%+v", spl.committed, spl.segs)
+	}
+}
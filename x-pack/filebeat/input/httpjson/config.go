@@ -38,6 +38,9 @@ func (c config) Validate() error {
 	if c.Interval <= 0 {
 		return errors.New("interval must be greater than 0")
 	}
+	if err := validateTLSClientAuth(c.Auth, c.Request); err != nil {
+		return err
+	}
 	for _, v := range c.Chain {
 		if v.Step == nil && v.While == nil {
 			return errors.New("both step & while blocks in a chain cannot be empty")
@@ -48,6 +51,28 @@ func (c config) Validate() error {
 		if v.While != nil && v.While.ReplaceWith != "" && len(strings.SplitN(v.While.ReplaceWith, ",", 3)) > 2 {
 			return fmt.Errorf("invalid number of parameters inside step replace_with: %q", v.While.ReplaceWith)
 		}
+		if v.Step != nil {
+			if err := validateTLSClientAuth(v.Step.Auth, v.Step.Request); err != nil {
+				return err
+			}
+		}
+		if v.While != nil {
+			if err := validateTLSClientAuth(v.While.Auth, v.While.Request); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateTLSClientAuth checks that a client certificate is configured for
+// requests when OAuth2 TLS client authentication is enabled.
+func validateTLSClientAuth(auth *authConfig, req *requestConfig) error {
+	if auth == nil || !auth.OAuth2.isEnabled() || !auth.OAuth2.TLSClientAuth {
+		return nil
+	}
+	if req == nil || req.Transport.TLS == nil || req.Transport.TLS.Certificate.Certificate == "" {
+		return errors.New("request.ssl.certificate and request.ssl.key must be provided when using oauth2 tls_client_auth")
 	}
 	return nil
 }
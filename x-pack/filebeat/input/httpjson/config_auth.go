@@ -20,6 +20,8 @@ import (
 	"golang.org/x/oauth2/google"
 
 	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/elastic-agent-libs/logp"
 )
 
 type authConfig struct {
@@ -77,6 +79,10 @@ func (p oAuth2Provider) canonical() oAuth2Provider {
 	return oAuth2Provider(strings.ToLower(string(p)))
 }
 
+// oAuth2GrantDeviceCode is the grant_type value selecting the OAuth2
+// device authorization grant.
+const oAuth2GrantDeviceCode = "device_code"
+
 type oAuth2Config struct {
 	Enabled *bool `config:"enabled"`
 
@@ -90,6 +96,16 @@ type oAuth2Config struct {
 	TokenURL       string              `config:"token_url"`
 	User           string              `config:"user"`
 
+	// GrantType selects a grant that can not be inferred from the
+	// provided credentials. Only device_code is supported.
+	GrantType     string `config:"grant_type"`
+	DeviceAuthURL string `config:"device_auth_url"`
+
+	// TLSClientAuth authenticates the client to the token endpoint using
+	// the TLS client certificate configured in request.ssl instead of a
+	// client secret, obtaining certificate-bound tokens (RFC 8705).
+	TLSClientAuth bool `config:"tls_client_auth"`
+
 	// google specific
 	GoogleCredentialsFile  string          `config:"google.credentials_file"`
 	GoogleCredentialsJSON  common.JSONBlob `config:"google.credentials_json"`
@@ -121,23 +137,39 @@ func (o *oAuth2Config) clientCredentialsGrant(ctx context.Context, _ *http.Clien
 		TokenURL:       o.getTokenURL(),
 		Scopes:         o.Scopes,
 		EndpointParams: o.getEndpointParams(),
+		AuthStyle:      o.authStyle(),
 	}
 	return creds.Client(ctx)
 }
 
+// authStyle returns how the client authenticates to the token endpoint.
+// With TLS client authentication the client ID is sent in the request
+// body and the client is identified by its certificate.
+func (o *oAuth2Config) authStyle() oauth2.AuthStyle {
+	if o.TLSClientAuth {
+		return oauth2.AuthStyleInParams
+	}
+	return oauth2.AuthStyleAutoDetect
+}
+
 // Client wraps the given http.Client and returns a new one that will use the oauth authentication.
-func (o *oAuth2Config) client(ctx context.Context, client *http.Client) (*http.Client, error) {
+// If tokens is not nil, it is used to persist refresh tokens obtained by grants requiring user
+// interaction.
+func (o *oAuth2Config) client(ctx context.Context, client *http.Client, tokens tokenStore, stat status.StatusReporter, log *logp.Logger) (*http.Client, error) {
 	ctx = context.WithValue(ctx, oauth2.HTTPClient, client)
 
 	switch o.getProvider() {
 	case oAuth2ProviderDefault:
+		if o.GrantType == oAuth2GrantDeviceCode {
+			return o.deviceCodeGrant(ctx, tokens, stat, log)
+		}
 		if o.User != "" || o.Password != "" {
 			conf := &oauth2.Config{
 				ClientID:     o.ClientID,
 				ClientSecret: maybeString(o.ClientSecret),
 				Endpoint: oauth2.Endpoint{
 					TokenURL:  o.TokenURL,
-					AuthStyle: oauth2.AuthStyleAutoDetect,
+					AuthStyle: o.authStyle(),
 				},
 			}
 			token, err := conf.PasswordCredentialsToken(ctx, o.User, o.Password)
@@ -230,6 +262,18 @@ func (o *oAuth2Config) Validate() error {
 		return nil
 	}
 
+	if o.getProvider() != oAuth2ProviderDefault {
+		if o.GrantType != "" {
+			return errors.New("grant_type can only be used without a provider")
+		}
+		if o.TLSClientAuth {
+			return errors.New("tls_client_auth can only be used without a provider")
+		}
+	}
+	if o.TLSClientAuth && o.ClientSecret != nil {
+		return errors.New("client.secret cannot be used with tls_client_auth")
+	}
+
 	switch o.getProvider() {
 	case oAuth2ProviderAzure:
 		return o.validateAzureProvider()
@@ -238,10 +282,20 @@ func (o *oAuth2Config) Validate() error {
 	case oAuth2ProviderOkta:
 		return o.validateOktaProvider()
 	case oAuth2ProviderDefault:
+		switch o.GrantType {
+		case "":
+			if o.DeviceAuthURL != "" {
+				return errors.New("device_auth_url can only be used with the device_code grant")
+			}
+		case oAuth2GrantDeviceCode:
+			return o.validateDeviceCodeGrant()
+		default:
+			return fmt.Errorf("unknown grant_type %q", o.GrantType)
+		}
 		if (o.User != "" && o.Password == "") || (o.User == "" && o.Password != "") {
 			return errors.New("both user and password credentials must be provided")
 		}
-		if o.TokenURL == "" || ((o.ClientID == "" || o.ClientSecret == nil) && (o.User == "" || o.Password == "")) {
+		if o.TokenURL == "" || ((o.ClientID == "" || (o.ClientSecret == nil && !o.TLSClientAuth)) && (o.User == "" || o.Password == "")) {
 			return errors.New("both token_url and client credentials must be provided")
 		}
 	default:
@@ -355,6 +409,16 @@ func populateJSONFromFile(file string, dst *common.JSONBlob) error {
 	return nil
 }
 
+func (o *oAuth2Config) validateDeviceCodeGrant() error {
+	if o.User != "" || o.Password != "" {
+		return errors.New("user and password cannot be used with the device_code grant")
+	}
+	if o.TokenURL == "" || o.DeviceAuthURL == "" || o.ClientID == "" {
+		return errors.New("token_url, device_auth_url and client.id must be provided for the device_code grant")
+	}
+	return nil
+}
+
 func (o *oAuth2Config) validateAzureProvider() error {
 	if o.TokenURL == "" && o.AzureTenantID == "" {
 		return errors.New("at least one of token_url or tenant_id must be provided")
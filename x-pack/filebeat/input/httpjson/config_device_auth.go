@@ -0,0 +1,91 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package httpjson
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// deviceCodeGrant returns an http client authorized with the OAuth2 device
+// authorization grant (RFC 8628). If tokens holds a refresh token from a
+// previous run it is used to obtain an access token, otherwise the user is
+// asked to authorize the input by visiting the verification URI. Tokens are
+// refreshed automatically and rotated refresh tokens are saved to tokens.
+func (o *oAuth2Config) deviceCodeGrant(ctx context.Context, tokens tokenStore, stat status.StatusReporter, log *logp.Logger) (*http.Client, error) {
+	conf := &oauth2.Config{
+		ClientID:     o.ClientID,
+		ClientSecret: maybeString(o.ClientSecret),
+		Endpoint: oauth2.Endpoint{
+			DeviceAuthURL: o.DeviceAuthURL,
+			TokenURL:      o.TokenURL,
+			AuthStyle:     o.authStyle(),
+		},
+		Scopes: o.Scopes,
+	}
+
+	var token *oauth2.Token
+	if tokens != nil {
+		refresh, err := tokens.load()
+		if err != nil {
+			log.Warnw("failed to load persisted oauth2 refresh token", "error", err)
+		}
+		if refresh != "" {
+			token, err = conf.TokenSource(ctx, &oauth2.Token{RefreshToken: refresh}).Token()
+			if err != nil {
+				log.Warnw("failed to refresh persisted oauth2 token, requesting new device authorization", "error", err)
+				token = nil
+			}
+		}
+	}
+	if token == nil {
+		opts := o.authCodeOptions()
+		auth, err := conf.DeviceAuth(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("oauth2 client: error requesting device authorization: %w", err)
+		}
+		log.Warnw("oauth2 device authorization required: visit the verification URI and enter the user code",
+			"verification_uri", auth.VerificationURI,
+			"verification_uri_complete", auth.VerificationURIComplete,
+			"user_code", auth.UserCode,
+			"expiry", auth.Expiry,
+		)
+		stat.UpdateStatus(status.Degraded, fmt.Sprintf("waiting for oauth2 device authorization: visit %s and enter code %s", auth.VerificationURI, auth.UserCode))
+		token, err = conf.DeviceAccessToken(ctx, auth, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("oauth2 client: error obtaining device access token: %w", err)
+		}
+		log.Info("oauth2 device authorization completed")
+		stat.UpdateStatus(status.Configuring, "")
+	}
+
+	src := conf.TokenSource(ctx, token)
+	if tokens != nil {
+		src = &persistingTokenSource{src: src, store: tokens, log: log}
+		// Persist the initial token before any request is made.
+		if _, err := src.Token(); err != nil {
+			return nil, fmt.Errorf("oauth2 client: error obtaining token: %w", err)
+		}
+	}
+	return oauth2.NewClient(ctx, oauth2.ReuseTokenSource(token, src)), nil
+}
+
+// authCodeOptions returns the receiver's endpoint params as options for
+// the device authorization and access token requests.
+func (o *oAuth2Config) authCodeOptions() []oauth2.AuthCodeOption {
+	var opts []oauth2.AuthCodeOption
+	for k, vals := range o.getEndpointParams() {
+		for _, v := range vals {
+			opts = append(opts, oauth2.SetAuthURLParam(k, v))
+		}
+	}
+	return opts
+}
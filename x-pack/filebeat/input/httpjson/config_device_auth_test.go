@@ -0,0 +1,150 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package httpjson
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestDeviceCodeGrant(t *testing.T) {
+	cases := []struct {
+		name         string
+		stored       string
+		wantDevice   bool
+		wantAccess   string
+		wantRefresh  string
+		wantStatuses []status.Status
+	}{
+		{
+			name:         "new_authorization",
+			wantDevice:   true,
+			wantAccess:   "device-access",
+			wantRefresh:  "device-refresh",
+			wantStatuses: []status.Status{status.Degraded, status.Configuring},
+		},
+		{
+			name:        "persisted_refresh_token",
+			stored:      "stored-refresh",
+			wantAccess:  "refreshed-access",
+			wantRefresh: "rotated-refresh",
+		},
+		{
+			name:         "revoked_refresh_token",
+			stored:       "revoked-refresh",
+			wantDevice:   true,
+			wantAccess:   "device-access",
+			wantRefresh:  "device-refresh",
+			wantStatuses: []status.Status{status.Degraded, status.Configuring},
+		},
+	}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			var deviceRequested bool
+			mux := http.NewServeMux()
+			mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+				deviceRequested = true
+				_ = r.ParseForm()
+				if r.Form.Get("client_id") != "a_client_id" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = io.WriteString(w, `{"device_code":"a_device_code","user_code":"ABCD-EFGH","verification_uri":"https://example.com/device","expires_in":60,"interval":1}`)
+			})
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				_ = r.ParseForm()
+				var resp map[string]interface{}
+				switch r.Form.Get("grant_type") {
+				case "urn:ietf:params:oauth:grant-type:device_code":
+					if r.Form.Get("device_code") != "a_device_code" {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					resp = map[string]interface{}{"access_token": "device-access", "refresh_token": "device-refresh", "token_type": "Bearer", "expires_in": 3600}
+				case "refresh_token":
+					if r.Form.Get("refresh_token") != "stored-refresh" {
+						w.Header().Set("Content-Type", "application/json")
+						w.WriteHeader(http.StatusBadRequest)
+						_, _ = io.WriteString(w, `{"error":"invalid_grant"}`)
+						return
+					}
+					resp = map[string]interface{}{"access_token": "refreshed-access", "refresh_token": "rotated-refresh", "token_type": "Bearer", "expires_in": 3600}
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(resp)
+			})
+			mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, r.Header.Get("Authorization"))
+			})
+			server := httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+
+			cfg := &oAuth2Config{
+				ClientID:      "a_client_id",
+				GrantType:     oAuth2GrantDeviceCode,
+				DeviceAuthURL: server.URL + "/device",
+				TokenURL:      server.URL + "/token",
+			}
+			tokens := &memTokenStore{refresh: test.stored}
+			stat := &statusRecorder{}
+
+			client, err := cfg.client(context.Background(), server.Client(), tokens, stat, logp.NewLogger("device_code_test"))
+			require.NoError(t, err)
+
+			resp, err := client.Get(server.URL + "/api")
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, "Bearer "+test.wantAccess, string(body))
+			assert.Equal(t, test.wantDevice, deviceRequested)
+			assert.Equal(t, test.wantRefresh, tokens.refresh)
+			assert.Equal(t, test.wantStatuses, stat.statuses)
+		})
+	}
+}
+
+type memTokenStore struct {
+	mu      sync.Mutex
+	refresh string
+}
+
+func (s *memTokenStore) load() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.refresh, nil
+}
+
+func (s *memTokenStore) save(refreshToken string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refresh = refreshToken
+	return nil
+}
+
+type statusRecorder struct {
+	statuses []status.Status
+}
+
+func (r *statusRecorder) UpdateStatus(s status.Status, _ string) {
+	r.statuses = append(r.statuses, s)
+}
@@ -6,9 +6,16 @@ package httpjson
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,6 +23,8 @@ import (
 	"golang.org/x/oauth2/google"
 
 	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/testing/certutil"
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
 )
 
@@ -550,6 +559,101 @@ LNV/bIgMHOMoxiGrwyjAhg==
 				},
 			},
 		},
+		{
+			name: "device_code grant with client id",
+			input: map[string]interface{}{
+				"auth.oauth2": map[string]interface{}{
+					"grant_type":      "device_code",
+					"device_auth_url": "localhost/device",
+					"token_url":       "localhost",
+					"client.id":       "a_client_id",
+				},
+			},
+		},
+		{
+			name:        "device_code grant requires device_auth_url",
+			expectedErr: "token_url, device_auth_url and client.id must be provided for the device_code grant accessing 'auth.oauth2'",
+			input: map[string]interface{}{
+				"auth.oauth2": map[string]interface{}{
+					"grant_type": "device_code",
+					"token_url":  "localhost",
+					"client.id":  "a_client_id",
+				},
+			},
+		},
+		{
+			name:        "device_code grant cannot use user and password",
+			expectedErr: "user and password cannot be used with the device_code grant accessing 'auth.oauth2'",
+			input: map[string]interface{}{
+				"auth.oauth2": map[string]interface{}{
+					"grant_type":      "device_code",
+					"device_auth_url": "localhost/device",
+					"token_url":       "localhost",
+					"client.id":       "a_client_id",
+					"user":            "a_user",
+					"password":        "a_password",
+				},
+			},
+		},
+		{
+			name:        "device_auth_url requires device_code grant",
+			expectedErr: "device_auth_url can only be used with the device_code grant accessing 'auth.oauth2'",
+			input: map[string]interface{}{
+				"auth.oauth2": map[string]interface{}{
+					"device_auth_url": "localhost/device",
+					"token_url":       "localhost",
+					"client.id":       "a_client_id",
+					"client.secret":   "a_client_secret",
+				},
+			},
+		},
+		{
+			name:        "unknown grant_type",
+			expectedErr: `unknown grant_type "implicit" accessing 'auth.oauth2'`,
+			input: map[string]interface{}{
+				"auth.oauth2": map[string]interface{}{
+					"grant_type": "implicit",
+					"token_url":  "localhost",
+					"client.id":  "a_client_id",
+				},
+			},
+		},
+		{
+			name:        "grant_type cannot be used with a provider",
+			expectedErr: "grant_type can only be used without a provider accessing 'auth.oauth2'",
+			input: map[string]interface{}{
+				"auth.oauth2": map[string]interface{}{
+					"provider":        "azure",
+					"grant_type":      "device_code",
+					"azure.tenant_id": "a_tenant_id",
+					"client.id":       "a_client_id",
+					"client.secret":   "a_client_secret",
+				},
+			},
+		},
+		{
+			name:        "tls_client_auth cannot be used with a client secret",
+			expectedErr: "client.secret cannot be used with tls_client_auth accessing 'auth.oauth2'",
+			input: map[string]interface{}{
+				"auth.oauth2": map[string]interface{}{
+					"tls_client_auth": true,
+					"token_url":       "localhost",
+					"client.id":       "a_client_id",
+					"client.secret":   "a_client_secret",
+				},
+			},
+		},
+		{
+			name:        "tls_client_auth requires a client certificate",
+			expectedErr: "request.ssl.certificate and request.ssl.key must be provided when using oauth2 tls_client_auth accessing config",
+			input: map[string]interface{}{
+				"auth.oauth2": map[string]interface{}{
+					"tls_client_auth": true,
+					"token_url":       "localhost",
+					"client.id":       "a_client_id",
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -583,6 +687,78 @@ LNV/bIgMHOMoxiGrwyjAhg==
 	}
 }
 
+func TestOAuth2TLSClientAuth(t *testing.T) {
+	dir := t.TempDir()
+	writePair := func(name string, pair certutil.Pair) (string, string) {
+		cert, key := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+		require.NoError(t, os.WriteFile(cert, pair.Cert, 0o600))
+		require.NoError(t, os.WriteFile(key, pair.Key, 0o600))
+		return cert, key
+	}
+	caKey, caCert, caPair, err := certutil.NewRootCA()
+	require.NoError(t, err)
+	serverCert, _, err := certutil.GenerateChildCert("localhost", []net.IP{net.ParseIP("127.0.0.1")}, caKey, caCert)
+	require.NoError(t, err)
+	_, clientPair, err := certutil.GenerateChildCert("a_client", nil, caKey, caCert, certutil.WithClientCert(true))
+	require.NoError(t, err)
+	caFile, _ := writePair("ca", caPair)
+	certFile, keyFile := writePair("client", clientPair)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Header.Get("Authorization") != "" || r.Form.Has("client_secret") || r.Form.Get("client_id") != "a_client_id" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Form.Get("grant_type") != "client_credentials" || len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"access_token":"bound-access","token_type":"Bearer","expires_in":3600}`)
+	})
+	mux.HandleFunc("/api", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Header.Get("Authorization"))
+	})
+	server := httptest.NewUnstartedServer(mux)
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{*serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"interval":                            "1m",
+		"request.url":                         server.URL + "/api",
+		"request.ssl.certificate_authorities": []string{caFile},
+		"request.ssl.certificate":             certFile,
+		"request.ssl.key":                     keyFile,
+		"auth.oauth2": map[string]interface{}{
+			"tls_client_auth": true,
+			"token_url":       server.URL + "/token",
+			"client.id":       "a_client_id",
+		},
+	})
+	config := defaultConfig()
+	require.NoError(t, cfg.Unpack(&config))
+
+	client, err := newHTTPClient(context.Background(), config, nil, noopReporter{}, logp.NewLogger("tls_client_auth_test"), nil)
+	require.NoError(t, err)
+
+	resp, err := client.client.Get(server.URL + "/api")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer bound-access", string(body))
+}
+
 func TestCursorEntryConfig(t *testing.T) {
 	in := map[string]interface{}{
 		"entry1": map[string]interface{}{
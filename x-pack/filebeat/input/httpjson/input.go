@@ -169,13 +169,13 @@ func test(url *url.URL) error {
 	return nil
 }
 
-func runWithMetrics(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcursor.Cursor) error {
+func runWithMetrics(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcursor.Cursor, store statestore.States) error {
 	reg, unreg := inputmon.NewInputRegistry("httpjson", ctx.ID, nil)
 	defer unreg()
-	return run(ctx, cfg, pub, crsr, reg)
+	return run(ctx, cfg, pub, crsr, reg, store)
 }
 
-func run(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcursor.Cursor, reg *monitoring.Registry) error {
+func run(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcursor.Cursor, reg *monitoring.Registry, store statestore.States) error {
 	stat := ctx.StatusReporter
 	if stat == nil {
 		stat = noopReporter{}
@@ -203,7 +203,20 @@ func run(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcurso
 
 	metrics := newInputMetrics(reg)
 
-	client, err := newHTTPClient(stdCtx, cfg, stat, log, reg)
+	// Refresh tokens obtained with the device code grant are persisted
+	// so that the user does not need to authorize the input on each start.
+	var tokens tokenStore
+	if store != nil && cfg.Auth.OAuth2.isEnabled() && cfg.Auth.OAuth2.GrantType == oAuth2GrantDeviceCode {
+		ts, err := newRegistryTokenStore(store, ctx.ID)
+		if err != nil {
+			stat.UpdateStatus(status.Failed, "failed to open oauth2 token store: "+err.Error())
+			return err
+		}
+		defer ts.close()
+		tokens = ts
+	}
+
+	client, err := newHTTPClient(stdCtx, cfg, tokens, stat, log, reg)
 	if err != nil {
 		stat.UpdateStatus(status.Failed, "failed to create HTTP client: "+err.Error())
 		return err
@@ -282,7 +295,7 @@ func sanitizeFileName(name string) string {
 	return strings.ReplaceAll(name, string(filepath.Separator), "_")
 }
 
-func newHTTPClient(ctx context.Context, config config, stat status.StatusReporter, log *logp.Logger, reg *monitoring.Registry) (*httpClient, error) {
+func newHTTPClient(ctx context.Context, config config, tokens tokenStore, stat status.StatusReporter, log *logp.Logger, reg *monitoring.Registry) (*httpClient, error) {
 	client, err := newNetHTTPClient(ctx, config.Request, log, reg)
 	if err != nil {
 		return nil, err
@@ -304,7 +317,7 @@ func newHTTPClient(ctx context.Context, config config, stat status.StatusReporte
 	limiter := newRateLimiterFromConfig(config.Request.RateLimit, stat, log)
 
 	if config.Auth.OAuth2.isEnabled() {
-		authClient, err := config.Auth.OAuth2.client(ctx, client)
+		authClient, err := config.Auth.OAuth2.client(ctx, client, tokens, stat, log)
 		if err != nil {
 			return nil, err
 		}
@@ -400,7 +413,7 @@ func newChainHTTPClient(ctx context.Context, authCfg *authConfig, requestCfg *re
 	limiter := newRateLimiterFromConfig(requestCfg.RateLimit, stat, log)
 
 	if authCfg != nil && authCfg.OAuth2.isEnabled() {
-		authClient, err := authCfg.OAuth2.client(ctx, client)
+		authClient, err := authCfg.OAuth2.client(ctx, client, nil, stat, log)
 		if err != nil {
 			return nil, err
 		}
@@ -7,10 +7,13 @@ package httpjson
 import (
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	inputcursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/statestore"
 	conf "github.com/elastic/elastic-agent-libs/config"
 )
 
-type cursorInput struct{}
+type cursorInput struct {
+	store statestore.States
+}
 
 func (cursorInput) Name() string {
 	return "httpjson-cursor"
@@ -24,19 +27,21 @@ func (src source) Name() string {
 	return src.config.Request.URL.String()
 }
 
-func cursorConfigure(cfg *conf.C) ([]inputcursor.Source, inputcursor.Input, error) {
-	conf := defaultConfig()
-	if err := cfg.Unpack(&conf); err != nil {
-		return nil, nil, err
+func cursorConfigure(store statestore.States) func(*conf.C) ([]inputcursor.Source, inputcursor.Input, error) {
+	return func(cfg *conf.C) ([]inputcursor.Source, inputcursor.Input, error) {
+		conf := defaultConfig()
+		if err := cfg.Unpack(&conf); err != nil {
+			return nil, nil, err
+		}
+		sources, inp := newCursorInput(conf, store)
+		return sources, inp, nil
 	}
-	sources, inp := newCursorInput(conf)
-	return sources, inp, nil
 }
 
-func newCursorInput(config config) ([]inputcursor.Source, inputcursor.Input) {
+func newCursorInput(config config, store statestore.States) ([]inputcursor.Source, inputcursor.Input) {
 	// we only allow one url per config, if we wanted to allow more than one
 	// each source should hold only one url
-	return []inputcursor.Source{&source{config: config}}, &cursorInput{}
+	return []inputcursor.Source{&source{config: config}}, &cursorInput{store: store}
 }
 
 func (in *cursorInput) Test(src inputcursor.Source, _ v2.TestContext) error {
@@ -47,5 +52,5 @@ func (in *cursorInput) Test(src inputcursor.Source, _ v2.TestContext) error {
 // It will return on context cancellation, any other error will be retried.
 func (in *cursorInput) Run(ctx v2.Context, src inputcursor.Source, crsr inputcursor.Cursor, pub inputcursor.Publisher) error {
 	s := src.(*source)
-	return runWithMetrics(ctx, s.config, pub, &crsr)
+	return runWithMetrics(ctx, s.config, pub, &crsr, in.store)
 }
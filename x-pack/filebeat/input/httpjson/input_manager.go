@@ -28,14 +28,14 @@ type InputManager struct {
 var _ v2.InputManager = InputManager{}
 
 func NewInputManager(log *logp.Logger, store statestore.States) InputManager {
-	sim := stateless.NewInputManager(statelessConfigure)
+	sim := stateless.NewInputManager(statelessConfigure(store))
 	return InputManager{
 		stateless: &sim,
 		cursor: &inputcursor.InputManager{
 			Logger:     log,
 			StateStore: store,
 			Type:       inputName,
-			Configure:  cursorConfigure,
+			Configure:  cursorConfigure(store),
 		},
 	}
 }
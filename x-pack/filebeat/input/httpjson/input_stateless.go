@@ -8,23 +8,29 @@ import (
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	stateless "github.com/elastic/beats/v7/filebeat/input/v2/input-stateless"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/statestore"
 	conf "github.com/elastic/elastic-agent-libs/config"
 )
 
 type statelessInput struct {
 	config config
+	store  statestore.States
 }
 
 func (statelessInput) Name() string {
 	return "httpjson-stateless"
 }
 
-func statelessConfigure(cfg *conf.C) (stateless.Input, error) {
-	conf := defaultConfig()
-	if err := cfg.Unpack(&conf); err != nil {
-		return nil, err
+func statelessConfigure(store statestore.States) func(*conf.C) (stateless.Input, error) {
+	return func(cfg *conf.C) (stateless.Input, error) {
+		conf := defaultConfig()
+		if err := cfg.Unpack(&conf); err != nil {
+			return nil, err
+		}
+		in := newStatelessInput(conf)
+		in.store = store
+		return in, nil
 	}
-	return newStatelessInput(conf), nil
 }
 
 func newStatelessInput(config config) *statelessInput {
@@ -48,5 +54,5 @@ func (pub statelessPublisher) Publish(event beat.Event, _ interface{}) error {
 // It will return on context cancellation, any other error will be retried.
 func (in *statelessInput) Run(ctx v2.Context, publisher stateless.Publisher) error {
 	pub := statelessPublisher{wrapped: publisher}
-	return runWithMetrics(ctx, in.config, pub, nil)
+	return runWithMetrics(ctx, in.config, pub, nil, in.store)
 }
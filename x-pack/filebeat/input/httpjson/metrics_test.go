@@ -131,7 +131,7 @@ func TestMetrics(t *testing.T) {
 			var g errgroup.Group
 			g.Go(func() error {
 				pub := statelessPublisher{wrapped: chanClient}
-				return run(ctx, conf, pub, nil, reg)
+				return run(ctx, conf, pub, nil, reg, nil)
 			})
 
 			timeout := time.NewTimer(5 * time.Second)
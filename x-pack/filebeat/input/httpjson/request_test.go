@@ -58,7 +58,7 @@ func TestCtxAfterDoRequest(t *testing.T) {
 
 	log := logp.NewLogger("")
 	ctx := context.Background()
-	client, err := newHTTPClient(ctx, config, noopReporter{}, log, nil)
+	client, err := newHTTPClient(ctx, config, nil, noopReporter{}, log, nil)
 	assert.NoError(t, err)
 
 	requestFactory, err := newRequestFactory(ctx, config, noopReporter{}, log, nil, nil)
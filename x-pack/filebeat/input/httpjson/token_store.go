@@ -0,0 +1,97 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package httpjson
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/oauth2"
+
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const tokenStatePrefix = "filebeat::httpjson::oauth2::"
+
+// tokenStore persists the OAuth2 refresh token of an input so that
+// authorization can be resumed after a restart without user interaction.
+type tokenStore interface {
+	load() (string, error)
+	save(refreshToken string) error
+}
+
+type storedToken struct {
+	RefreshToken string `json:"refresh_token" struct:"refresh_token"`
+}
+
+// registryTokenStore is a tokenStore backed by the Filebeat registry.
+type registryTokenStore struct {
+	store *statestore.Store
+	key   string
+}
+
+// newRegistryTokenStore returns a tokenStore holding the refresh token for
+// the input with the given ID. The returned store must be closed by the caller.
+func newRegistryTokenStore(states statestore.States, id string) (*registryTokenStore, error) {
+	st, err := states.StoreFor(inputName)
+	if err != nil {
+		return nil, fmt.Errorf("error accessing persistence store: %w", err)
+	}
+	return &registryTokenStore{store: st, key: tokenStatePrefix + id}, nil
+}
+
+func (s *registryTokenStore) load() (string, error) {
+	ok, err := s.store.Has(s.key)
+	if err != nil || !ok {
+		return "", err
+	}
+	var tok storedToken
+	err = s.store.Get(s.key, &tok)
+	if err != nil {
+		return "", err
+	}
+	return tok.RefreshToken, nil
+}
+
+func (s *registryTokenStore) save(refreshToken string) error {
+	return s.store.Set(s.key, storedToken{RefreshToken: refreshToken})
+}
+
+func (s *registryTokenStore) close() error {
+	return s.store.Close()
+}
+
+// persistingTokenSource is an oauth2.TokenSource that saves the refresh
+// token to a tokenStore each time the wrapped source rotates it.
+type persistingTokenSource struct {
+	src   oauth2.TokenSource
+	store tokenStore
+	log   *logp.Logger
+
+	mu   sync.Mutex
+	last string
+}
+
+func (s *persistingTokenSource) Token() (*oauth2.Token, error) {
+	tok, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tok.RefreshToken == "" || tok.RefreshToken == s.last {
+		return tok, nil
+	}
+	err = s.store.save(tok.RefreshToken)
+	if err != nil {
+		// Failing to persist only means that the user will need to
+		// authorize the input again after a restart.
+		s.log.Warnw("failed to persist oauth2 refresh token", "error", err)
+		return tok, nil
+	}
+	s.last = tok.RefreshToken
+	return tok, nil
+}
@@ -0,0 +1,56 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package httpjson
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/libbeat/statestore/storetest"
+)
+
+func TestRegistryTokenStore(t *testing.T) {
+	states := &testInputStore{registry: statestore.NewRegistry(storetest.NewMemoryStoreBackend())}
+	t.Cleanup(func() { _ = states.registry.Close() })
+
+	store, err := newRegistryTokenStore(states, "httpjson-a")
+	require.NoError(t, err)
+	got, err := store.load()
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	require.NoError(t, store.save("a_refresh_token"))
+	require.NoError(t, store.close())
+
+	// A store for another input must not see the token.
+	other, err := newRegistryTokenStore(states, "httpjson-b")
+	require.NoError(t, err)
+	got, err = other.load()
+	require.NoError(t, err)
+	require.Empty(t, got)
+	require.NoError(t, other.close())
+
+	store, err = newRegistryTokenStore(states, "httpjson-a")
+	require.NoError(t, err)
+	got, err = store.load()
+	require.NoError(t, err)
+	require.Equal(t, "a_refresh_token", got)
+	require.NoError(t, store.close())
+}
+
+type testInputStore struct {
+	registry *statestore.Registry
+}
+
+func (s *testInputStore) StoreFor(typ string) (*statestore.Store, error) {
+	return s.registry.Get(typ)
+}
+
+func (s *testInputStore) CleanupInterval() time.Duration {
+	return 24 * time.Hour
+}
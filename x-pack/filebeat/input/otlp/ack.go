@@ -0,0 +1,71 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package otlp
+
+import (
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+)
+
+// requestACKTracker tracks the events published for an export request and
+// signals once all of them have been acknowledged.
+type requestACKTracker struct {
+	done chan struct{}
+
+	mutex       sync.Mutex // mutex synchronizes access to pendingACKs.
+	pendingACKs int64      // Number of events of the request that are pending ACKs.
+}
+
+func newRequestACKTracker() *requestACKTracker {
+	return &requestACKTracker{
+		done:        make(chan struct{}),
+		pendingACKs: 1, // Ready() must be called to consume this "1".
+	}
+}
+
+// Ready marks that all events of the request have been published.
+func (t *requestACKTracker) Ready() {
+	t.ACK()
+}
+
+func (t *requestACKTracker) Add() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.pendingACKs++
+}
+
+func (t *requestACKTracker) ACK() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.pendingACKs <= 0 {
+		panic("misuse detected: negative ACK counter")
+	}
+
+	t.pendingACKs--
+	if t.pendingACKs == 0 {
+		close(t.done)
+	}
+}
+
+// Done returns a channel that is closed once all events are acknowledged.
+func (t *requestACKTracker) Done() <-chan struct{} {
+	return t.done
+}
+
+func newEventACKHandler() beat.EventListener {
+	return acker.ConnectionOnly(
+		acker.EventPrivateReporter(func(_ int, privates []interface{}) {
+			for _, private := range privates {
+				if ack, ok := private.(*requestACKTracker); ok {
+					ack.ACK()
+				}
+			}
+		}),
+	)
+}
@@ -0,0 +1,46 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package otlp
+
+import (
+	"errors"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+type config struct {
+	GRPC           protocolConfig          `config:"grpc"`             // OTLP/gRPC server options.
+	HTTP           protocolConfig          `config:"http"`             // OTLP/HTTP server options.
+	TLS            *tlscommon.ServerConfig `config:"ssl"`              // TLS options, shared by both servers.
+	MaxRequestSize cfgtype.ByteSize        `config:"max_request_size"` // Maximum size of an export request. Default is 4MiB.
+}
+
+type protocolConfig struct {
+	Enabled       bool   `config:"enabled"`        // Whether the server is started.
+	ListenAddress string `config:"listen_address"` // Bind address for the server (e.g. address:port).
+}
+
+func (c *config) InitDefaults() {
+	c.GRPC = protocolConfig{Enabled: true, ListenAddress: "localhost:4317"}
+	c.HTTP = protocolConfig{Enabled: true, ListenAddress: "localhost:4318"}
+	c.MaxRequestSize = 4 * 1024 * 1024
+}
+
+func (c *config) Validate() error {
+	if !c.GRPC.Enabled && !c.HTTP.Enabled {
+		return errors.New("at least one of grpc and http must be enabled")
+	}
+	if c.GRPC.Enabled && c.GRPC.ListenAddress == "" {
+		return errors.New("grpc.listen_address is required when grpc is enabled")
+	}
+	if c.HTTP.Enabled && c.HTTP.ListenAddress == "" {
+		return errors.New("http.listen_address is required when http is enabled")
+	}
+	if c.MaxRequestSize <= 0 {
+		return errors.New("max_request_size must be greater than 0")
+	}
+	return nil
+}
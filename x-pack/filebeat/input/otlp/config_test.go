@@ -0,0 +1,82 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package otlp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		userConfig  map[string]interface{}
+		expected    *config
+		expectedErr string
+	}{
+		{
+			"defaults",
+			map[string]interface{}{},
+			&config{
+				GRPC:           protocolConfig{Enabled: true, ListenAddress: "localhost:4317"},
+				HTTP:           protocolConfig{Enabled: true, ListenAddress: "localhost:4318"},
+				MaxRequestSize: 4 * 1024 * 1024,
+			},
+			"",
+		},
+		{
+			"http only",
+			map[string]interface{}{
+				"grpc.enabled":        false,
+				"http.listen_address": "0.0.0.0:4318",
+				"max_request_size":    "1MiB",
+			},
+			&config{
+				GRPC:           protocolConfig{Enabled: false, ListenAddress: "localhost:4317"},
+				HTTP:           protocolConfig{Enabled: true, ListenAddress: "0.0.0.0:4318"},
+				MaxRequestSize: 1024 * 1024,
+			},
+			"",
+		},
+		{
+			"validate protocols",
+			map[string]interface{}{
+				"grpc.enabled": false,
+				"http.enabled": false,
+			},
+			nil,
+			"at least one of grpc and http must be enabled",
+		},
+		{
+			"validate listen_address",
+			map[string]interface{}{
+				"grpc.listen_address": "",
+			},
+			nil,
+			"grpc.listen_address is required",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := conf.MustNewConfigFrom(tc.userConfig)
+
+			var otlpConf config
+			err := c.Unpack(&otlpConf)
+
+			if tc.expectedErr != "" {
+				require.Error(t, err, "expected error: %s", tc.expectedErr)
+				require.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, *tc.expected, otlpConf)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package otlp
+
+import (
+	"fmt"
+
+	inputv2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+const (
+	inputName = "otlp"
+)
+
+func Plugin() inputv2.Plugin {
+	return inputv2.Plugin{
+		Name:      inputName,
+		Stability: feature.Beta,
+		Info:      "Receives logs exported with the OpenTelemetry protocol (OTLP).",
+		Manager:   inputv2.ConfigureWith(configure),
+	}
+}
+
+func configure(cfg *conf.C) (inputv2.Input, error) {
+	var otlpConfig config
+	if err := cfg.Unpack(&otlpConfig); err != nil {
+		return nil, err
+	}
+
+	return newOTLPInput(otlpConfig)
+}
+
+type otlpInput struct {
+	config config
+}
+
+var _ inputv2.Input = (*otlpInput)(nil)
+
+func newOTLPInput(otlpConfig config) (*otlpInput, error) {
+	return &otlpInput{config: otlpConfig}, nil
+}
+
+func (i *otlpInput) Name() string { return inputName }
+
+func (i *otlpInput) Test(inputCtx inputv2.TestContext) error {
+	s, err := newServer(i.config, inputCtx.Logger, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	return s.Close()
+}
+
+func (i *otlpInput) Run(inputCtx inputv2.Context, pipeline beat.Pipeline) error {
+	inputCtx.UpdateStatus(status.Starting, "")
+	inputCtx.Logger.Info("Starting " + inputName + " input")
+	defer inputCtx.Logger.Info(inputName + " input stopped")
+
+	inputCtx.UpdateStatus(status.Configuring, "")
+	// Create client for publishing events and receive notification of their ACKs.
+	client, err := pipeline.ConnectWith(beat.ClientConfig{
+		EventListener: newEventACKHandler(),
+	})
+	if err != nil {
+		err := fmt.Errorf("failed to create pipeline client: %w", err)
+		inputCtx.UpdateStatus(status.Failed, err.Error())
+		return err
+	}
+	defer client.Close()
+
+	metrics := newInputMetrics(inputCtx.ID, nil)
+	defer metrics.Close()
+
+	s, err := newServer(i.config, inputCtx.Logger, client.Publish, inputCtx.StatusReporter, metrics)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	// Shutdown the servers when cancellation is signaled.
+	go func() {
+		<-inputCtx.Cancelation.Done()
+		inputCtx.UpdateStatus(status.Stopping, "")
+		s.Close()
+	}()
+
+	// Run servers until the cancellation signal.
+	return s.Run()
+}
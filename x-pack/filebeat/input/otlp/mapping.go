@@ -0,0 +1,192 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package otlp
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// resourceFields maps OpenTelemetry semantic conventions resource attributes
+// to the ECS field describing the same entity. This is the inverse of the
+// mapping done by the otlp output.
+var resourceFields = map[string]string{
+	"host.name":                   "host.name",
+	"host.id":                     "host.id",
+	"host.arch":                   "host.architecture",
+	"os.type":                     "host.os.type",
+	"os.name":                     "host.os.name",
+	"os.version":                  "host.os.version",
+	"os.description":              "host.os.full",
+	"service.name":                "service.name",
+	"service.version":             "service.version",
+	"deployment.environment":      "service.environment",
+	"deployment.environment.name": "service.environment",
+	"cloud.provider":              "cloud.provider",
+	"cloud.region":                "cloud.region",
+	"cloud.availability_zone":     "cloud.availability_zone",
+	"cloud.account.id":            "cloud.account.id",
+	"container.id":                "container.id",
+	"container.name":              "container.name",
+	"container.image.name":        "container.image.name",
+	"k8s.cluster.name":            "orchestrator.cluster.name",
+	"k8s.namespace.name":          "kubernetes.namespace",
+	"k8s.pod.name":                "kubernetes.pod.name",
+	"k8s.pod.uid":                 "kubernetes.pod.uid",
+	"k8s.node.name":               "kubernetes.node.name",
+}
+
+// attributeFields maps OpenTelemetry semantic conventions log record
+// attributes to ECS fields where the names differ. All other attributes
+// are kept with their name.
+var attributeFields = map[string]string{
+	"exception.message":    "error.message",
+	"exception.type":       "error.type",
+	"exception.stacktrace": "error.stack_trace",
+}
+
+// severityLevels maps the ranges of OpenTelemetry severity numbers to
+// ECS log.level values, indexed by (number-1)/4.
+var severityLevels = [...]string{"trace", "debug", "info", "warn", "error", "fatal"}
+
+// makeEvents converts the log records of an export request to events.
+// Resource attributes with an ECS equivalent are renamed, all other resource
+// and log record attributes are added as fields with their name. The record
+// body becomes the message and the instrumentation scope name the logger.
+func makeEvents(logs plog.Logs, received time.Time) []beat.Event {
+	events := make([]beat.Event, 0, logs.LogRecordCount())
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		resourceLogs := logs.ResourceLogs().At(i)
+		resource := mapAttributes(resourceLogs.Resource().Attributes(), resourceFields)
+
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			scopeLogs := resourceLogs.ScopeLogs().At(j)
+			scope := scopeLogs.Scope()
+
+			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
+				event := makeEvent(scopeLogs.LogRecords().At(k), received)
+				event.Fields.DeepUpdateNoOverwrite(resource.Clone())
+				if scope.Name() != "" {
+					_, _ = event.Fields.Put("log.logger", scope.Name())
+				}
+				events = append(events, event)
+			}
+		}
+	}
+	return events
+}
+
+// makeEvent converts a single log record to an event.
+func makeEvent(record plog.LogRecord, received time.Time) beat.Event {
+	fields := mapAttributes(record.Attributes(), attributeFields)
+
+	switch body := record.Body(); body.Type() {
+	case pcommon.ValueTypeEmpty:
+	case pcommon.ValueTypeStr:
+		fields["message"] = body.Str()
+	default:
+		fields["message"] = body.AsString()
+	}
+
+	if level := severityLevel(record); level != "" {
+		_, _ = fields.Put("log.level", level)
+	}
+	if record.SeverityNumber() != plog.SeverityNumberUnspecified {
+		_, _ = fields.Put("event.severity", int64(record.SeverityNumber()))
+	}
+	if traceID := record.TraceID(); !traceID.IsEmpty() {
+		_, _ = fields.Put("trace.id", traceID.String())
+	}
+	if spanID := record.SpanID(); !spanID.IsEmpty() {
+		_, _ = fields.Put("span.id", spanID.String())
+	}
+
+	timestamp := received
+	if observed := record.ObservedTimestamp(); observed != 0 {
+		timestamp = observed.AsTime()
+		_, _ = fields.Put("event.created", timestamp.UTC())
+	}
+	if ts := record.Timestamp(); ts != 0 {
+		timestamp = ts.AsTime()
+	}
+
+	return beat.Event{
+		Timestamp: timestamp.UTC(),
+		Fields:    fields,
+	}
+}
+
+// severityLevel returns the ECS log.level of the record, preferring the
+// severity text set by the producer.
+func severityLevel(record plog.LogRecord) string {
+	if text := record.SeverityText(); text != "" {
+		return text
+	}
+	n := int(record.SeverityNumber())
+	if n <= 0 || n > 4*len(severityLevels) {
+		return ""
+	}
+	return severityLevels[(n-1)/4]
+}
+
+// mapAttributes converts attributes to fields, renaming attributes found
+// in names.
+func mapAttributes(attrs pcommon.Map, names map[string]string) mapstr.M {
+	fields := mapstr.M{}
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		value := fieldValue(v)
+		if value == nil {
+			return true
+		}
+		if name, ok := names[k]; ok {
+			k = name
+		}
+		_, _ = fields.Put(k, value)
+		return true
+	})
+	return fields
+}
+
+// fieldValue converts an attribute value to a field value. Empty values
+// are returned as nil.
+func fieldValue(v pcommon.Value) interface{} {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		return v.Str()
+	case pcommon.ValueTypeBool:
+		return v.Bool()
+	case pcommon.ValueTypeInt:
+		return v.Int()
+	case pcommon.ValueTypeDouble:
+		return v.Double()
+	case pcommon.ValueTypeMap:
+		m := mapstr.M{}
+		v.Map().Range(func(k string, v pcommon.Value) bool {
+			if value := fieldValue(v); value != nil {
+				m[k] = value
+			}
+			return true
+		})
+		return m
+	case pcommon.ValueTypeSlice:
+		s := make([]interface{}, 0, v.Slice().Len())
+		for i := 0; i < v.Slice().Len(); i++ {
+			if value := fieldValue(v.Slice().At(i)); value != nil {
+				s = append(s, value)
+			}
+		}
+		return s
+	case pcommon.ValueTypeBytes:
+		// Bytes are base64 encoded.
+		return v.AsString()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package otlp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestMakeEvents(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	observed := ts.Add(time.Second)
+	received := ts.Add(time.Minute)
+
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceAttrs := resourceLogs.Resource().Attributes()
+	resourceAttrs.PutStr("service.name", "checkout")
+	resourceAttrs.PutStr("deployment.environment.name", "production")
+	resourceAttrs.PutStr("host.arch", "amd64")
+	resourceAttrs.PutStr("k8s.pod.name", "checkout-1")
+	resourceAttrs.PutStr("telemetry.sdk.language", "go")
+
+	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName("checkout.payments")
+
+	record := scopeLogs.LogRecords().AppendEmpty()
+	record.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(observed))
+	record.SetSeverityNumber(plog.SeverityNumberWarn)
+	record.Body().SetStr("payment declined")
+	record.SetTraceID(pcommon.TraceID{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0x03, 0x81, 0x03, 0xd2, 0x69, 0xb6, 0x33, 0x81, 0x3f, 0xc6, 0x0c})
+	record.SetSpanID(pcommon.SpanID{0xee, 0xe1, 0x9b, 0x7e, 0xc3, 0xc1, 0xb1, 0x74})
+	record.Attributes().PutStr("exception.type", "CardError")
+	record.Attributes().PutInt("http.response.status_code", 402)
+	record.Attributes().PutStr("service.name", "payments")
+
+	// A record without timestamps, severity text and a structured body.
+	record = scopeLogs.LogRecords().AppendEmpty()
+	record.SetSeverityText("DEBUG")
+	record.Body().SetEmptyMap().PutStr("user", "alice")
+
+	events := makeEvents(logs, received)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, ts, events[0].Timestamp)
+	assert.Equal(t, mapstr.M{
+		"message": "payment declined",
+		"log": mapstr.M{
+			"level":  "warn",
+			"logger": "checkout.payments",
+		},
+		"event": mapstr.M{
+			"severity": int64(plog.SeverityNumberWarn),
+			"created":  observed,
+		},
+		"trace": mapstr.M{"id": "5b8efff798038103d269b633813fc60c"},
+		"span":  mapstr.M{"id": "eee19b7ec3c1b174"},
+		"error": mapstr.M{"type": "CardError"},
+		"http": mapstr.M{
+			"response": mapstr.M{"status_code": int64(402)},
+		},
+		// Record attributes take precedence over resource attributes.
+		"service": mapstr.M{
+			"name":        "payments",
+			"environment": "production",
+		},
+		"host": mapstr.M{"architecture": "amd64"},
+		"kubernetes": mapstr.M{
+			"pod": mapstr.M{"name": "checkout-1"},
+		},
+		"telemetry": mapstr.M{
+			"sdk": mapstr.M{"language": "go"},
+		},
+	}, events[0].Fields)
+
+	assert.Equal(t, received, events[1].Timestamp)
+	msg, _ := events[1].Fields.GetValue("message")
+	assert.Equal(t, `{"user":"alice"}`, msg)
+	level, _ := events[1].Fields.GetValue("log.level")
+	assert.Equal(t, "DEBUG", level)
+	assert.NotContains(t, events[1].Fields, "trace")
+}
+
+func TestSeverityLevel(t *testing.T) {
+	testCases := map[plog.SeverityNumber]string{
+		plog.SeverityNumberUnspecified: "",
+		plog.SeverityNumberTrace:       "trace",
+		plog.SeverityNumberDebug4:      "debug",
+		plog.SeverityNumberInfo2:       "info",
+		plog.SeverityNumberWarn:        "warn",
+		plog.SeverityNumberError3:      "error",
+		plog.SeverityNumberFatal4:      "fatal",
+	}
+	for number, level := range testCases {
+		record := plog.NewLogRecord()
+		record.SetSeverityNumber(number)
+		assert.Equal(t, level, severityLevel(record), number.String())
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package otlp
+
+import (
+	"github.com/rcrowley/go-metrics"
+
+	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/monitoring/adapter"
+)
+
+type inputMetrics struct {
+	unregister func()
+
+	grpcBindAddress         *monitoring.String // Bind address of the OTLP/gRPC server.
+	httpBindAddress         *monitoring.String // Bind address of the OTLP/HTTP server.
+	requestsReceivedTotal   *monitoring.Uint   // Number of export requests received.
+	requestsFailedTotal     *monitoring.Uint   // Number of export requests that were rejected or not acknowledged.
+	logRecordsReceivedTotal *monitoring.Uint   // Number of log records received.
+	requestProcessingTime   metrics.Sample     // Histogram of the elapsed request processing times in nanoseconds (time of receipt to time of ACK for non-empty requests).
+}
+
+func (m *inputMetrics) Close() {
+	m.unregister()
+}
+
+func newInputMetrics(id string, optionalParent *monitoring.Registry) *inputMetrics {
+	reg, unreg := inputmon.NewInputRegistry(inputName, id, optionalParent)
+
+	out := &inputMetrics{
+		unregister:              unreg,
+		grpcBindAddress:         monitoring.NewString(reg, "grpc_bind_address"),
+		httpBindAddress:         monitoring.NewString(reg, "http_bind_address"),
+		requestsReceivedTotal:   monitoring.NewUint(reg, "requests_received_total"),
+		requestsFailedTotal:     monitoring.NewUint(reg, "requests_failed_total"),
+		logRecordsReceivedTotal: monitoring.NewUint(reg, "log_records_received_total"),
+		requestProcessingTime:   metrics.NewUniformSample(1024),
+	}
+	adapter.NewGoMetrics(reg, "request_processing_time", adapter.Accept).
+		Register("histogram", metrics.NewHistogram(out.requestProcessingTime)) //nolint:errcheck // A unique namespace is used so name collisions are impossible.
+
+	return out
+}
@@ -0,0 +1,366 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package otlp
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/encoding/gzip" // Register the gzip compressor for OTLP/gRPC.
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+const (
+	logsPath = "/v1/logs"
+
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeJSON     = "application/json"
+)
+
+// errServerClosed is returned for export requests that cannot be
+// acknowledged because the input is stopping. Clients are expected to retry.
+var errServerClosed = errors.New("otlp input is stopping")
+
+type server struct {
+	config  config
+	status  status.StatusReporter
+	log     *logp.Logger
+	publish func(beat.Event)
+	metrics *inputMetrics
+
+	grpcSrv      *grpc.Server
+	grpcListener net.Listener
+	httpSrv      *http.Server
+	httpListener net.Listener
+
+	done      chan struct{} // done is closed when the server is closed.
+	closeOnce sync.Once
+}
+
+func newServer(c config, log *logp.Logger, pub func(beat.Event), stat status.StatusReporter, metrics *inputMetrics) (*server, error) {
+	if stat == nil {
+		stat = noopReporter{}
+	}
+	if metrics == nil {
+		metrics = newInputMetrics("", monitoring.NewRegistry())
+	}
+
+	s := &server{
+		config:  c,
+		status:  stat,
+		log:     log,
+		publish: pub,
+		metrics: metrics,
+		done:    make(chan struct{}),
+	}
+	if err := s.listen(); err != nil {
+		s.closeListeners()
+		stat.UpdateStatus(status.Failed, "failed to start otlp server: "+err.Error())
+		return nil, err
+	}
+	return s, nil
+}
+
+type noopReporter struct{}
+
+func (noopReporter) UpdateStatus(status.Status, string) {}
+
+// listen binds the enabled servers to their listen address.
+func (s *server) listen() error {
+	// Setup optional TLS.
+	var tlsConfig *tls.Config
+	if s.config.TLS.IsEnabled() {
+		elasticTLSConfig, err := tlscommon.LoadTLSServerConfig(s.config.TLS)
+		if err != nil {
+			return err
+		}
+		tlsConfig = elasticTLSConfig.BuildServerConfig("")
+	}
+	scheme := "tcp://"
+	if tlsConfig != nil {
+		scheme = "tls://"
+	}
+
+	if s.config.GRPC.Enabled {
+		l, err := net.Listen("tcp", s.config.GRPC.ListenAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen for OTLP/gRPC: %w", err)
+		}
+		s.grpcListener = l
+
+		opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(int(s.config.MaxRequestSize))}
+		if tlsConfig != nil {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+		}
+		s.grpcSrv = grpc.NewServer(opts...)
+		plogotlp.RegisterGRPCServer(s.grpcSrv, &logsService{server: s})
+
+		bindURI := scheme + l.Addr().String()
+		s.log.Infof(inputName+" OTLP/gRPC server is listening at %v.", bindURI)
+		s.metrics.grpcBindAddress.Set(bindURI)
+	}
+
+	if s.config.HTTP.Enabled {
+		l, err := net.Listen("tcp", s.config.HTTP.ListenAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen for OTLP/HTTP: %w", err)
+		}
+		if tlsConfig != nil {
+			l = tls.NewListener(l, tlsConfig)
+		}
+		s.httpListener = l
+
+		mux := http.NewServeMux()
+		mux.HandleFunc(logsPath, s.handleHTTP)
+		s.httpSrv = &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+		bindURI := scheme + l.Addr().String()
+		s.log.Infof(inputName+" OTLP/HTTP server is listening at %v.", bindURI)
+		s.metrics.httpBindAddress.Set(bindURI)
+	}
+	return nil
+}
+
+func (s *server) closeListeners() {
+	if s.grpcListener != nil {
+		s.grpcListener.Close()
+	}
+	if s.httpListener != nil {
+		s.httpListener.Close()
+	}
+}
+
+func (s *server) Close() error {
+	s.status.UpdateStatus(status.Stopping, "")
+	var err error
+	s.closeOnce.Do(func() {
+		// Abort requests waiting for ACKs before stopping the servers.
+		close(s.done)
+		if s.grpcSrv != nil {
+			s.grpcSrv.GracefulStop()
+		}
+		if s.httpSrv != nil {
+			err = s.httpSrv.Close()
+		}
+		s.closeListeners()
+	})
+	s.status.UpdateStatus(status.Stopped, "")
+	return err
+}
+
+// Run serves requests until the server is closed.
+func (s *server) Run() error {
+	s.status.UpdateStatus(status.Running, "")
+
+	errs := make(chan error, 2)
+	var wg sync.WaitGroup
+	if s.grpcSrv != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.grpcSrv.Serve(s.grpcListener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+				errs <- fmt.Errorf("OTLP/gRPC server failed: %w", err)
+			}
+		}()
+	}
+	if s.httpSrv != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.httpSrv.Serve(s.httpListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errs <- fmt.Errorf("OTLP/HTTP server failed: %w", err)
+			}
+		}()
+	}
+
+	var err error
+	select {
+	case <-s.done:
+	case err = <-errs:
+		s.status.UpdateStatus(status.Failed, err.Error())
+		s.Close()
+	}
+	wg.Wait()
+	return err
+}
+
+// export publishes the log records and waits until all resulting events
+// have been acknowledged.
+func (s *server) export(ctx context.Context, logs plog.Logs) error {
+	s.metrics.requestsReceivedTotal.Inc()
+
+	events := makeEvents(logs, time.Now())
+	if len(events) == 0 {
+		return nil
+	}
+	s.metrics.logRecordsReceivedTotal.Add(uint64(len(events)))
+
+	// Track all the events of the request so that the response is only
+	// sent after the events are delivered successfully.
+	start := time.Now()
+	acker := newRequestACKTracker()
+	for _, event := range events {
+		acker.Add()
+		event.Private = acker
+		s.publish(event)
+	}
+	acker.Ready()
+
+	select {
+	case <-acker.Done():
+		s.metrics.requestProcessingTime.Update(time.Since(start).Nanoseconds())
+		return nil
+	case <-s.done:
+		return errServerClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// logsService implements the OTLP/gRPC logs service.
+type logsService struct {
+	plogotlp.UnimplementedGRPCServer
+	server *server
+}
+
+func (l *logsService) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	if err := l.server.export(ctx, req.Logs()); err != nil {
+		l.server.metrics.requestsFailedTotal.Inc()
+		if errors.Is(err, errServerClosed) {
+			return plogotlp.NewExportResponse(), grpcstatus.Error(codes.Unavailable, err.Error())
+		}
+		return plogotlp.NewExportResponse(), grpcstatus.FromContextError(err).Err()
+	}
+	return plogotlp.NewExportResponse(), nil
+}
+
+// handleHTTP handles OTLP/HTTP export requests encoded as binary protobuf
+// or JSON.
+func (s *server) handleHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeHTTPError(w, contentTypeJSON, http.StatusMethodNotAllowed, codes.Unimplemented, "method "+r.Method+" not allowed")
+		return
+	}
+
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (contentType != contentTypeProtobuf && contentType != contentTypeJSON) {
+		s.writeHTTPError(w, contentTypeJSON, http.StatusUnsupportedMediaType, codes.InvalidArgument, "unsupported content type "+r.Header.Get("Content-Type"))
+		return
+	}
+
+	body, err := s.readHTTPBody(w, r)
+	if err != nil {
+		var errTooLarge *http.MaxBytesError
+		if errors.As(err, &errTooLarge) {
+			s.writeHTTPError(w, contentType, http.StatusRequestEntityTooLarge, codes.InvalidArgument, err.Error())
+			return
+		}
+		s.writeHTTPError(w, contentType, http.StatusBadRequest, codes.InvalidArgument, err.Error())
+		return
+	}
+
+	req := plogotlp.NewExportRequest()
+	if contentType == contentTypeJSON {
+		err = req.UnmarshalJSON(body)
+	} else {
+		err = req.UnmarshalProto(body)
+	}
+	if err != nil {
+		s.writeHTTPError(w, contentType, http.StatusBadRequest, codes.InvalidArgument, "failed to decode request: "+err.Error())
+		return
+	}
+
+	if err := s.export(r.Context(), req.Logs()); err != nil {
+		s.writeHTTPError(w, contentType, http.StatusServiceUnavailable, codes.Unavailable, err.Error())
+		return
+	}
+
+	var resp []byte
+	if contentType == contentTypeJSON {
+		resp, err = plogotlp.NewExportResponse().MarshalJSON()
+	} else {
+		resp, err = plogotlp.NewExportResponse().MarshalProto()
+	}
+	if err != nil {
+		s.writeHTTPError(w, contentType, http.StatusInternalServerError, codes.Internal, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(resp)
+}
+
+func (s *server) readHTTPBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	var body io.Reader = http.MaxBytesReader(w, r.Body, int64(s.config.MaxRequestSize))
+	switch r.Header.Get("Content-Encoding") {
+	case "":
+	case "gzip":
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress request: %w", err)
+		}
+		defer gz.Close()
+		// Limit the decompressed size as well.
+		body = io.LimitReader(gz, int64(s.config.MaxRequestSize)+1)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %s", r.Header.Get("Content-Encoding"))
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > int(s.config.MaxRequestSize) {
+		return nil, &http.MaxBytesError{Limit: int64(s.config.MaxRequestSize)}
+	}
+	return data, nil
+}
+
+// writeHTTPError writes an OTLP/HTTP error response, the body is a
+// google.rpc.Status message encoded like the request.
+func (s *server) writeHTTPError(w http.ResponseWriter, contentType string, httpStatus int, code codes.Code, msg string) {
+	s.metrics.requestsFailedTotal.Inc()
+	s.log.Debugw("OTLP/HTTP export request failed", "status", httpStatus, "error", msg)
+
+	st := grpcstatus.New(code, msg).Proto()
+	var (
+		body []byte
+		err  error
+	)
+	if contentType == contentTypeProtobuf {
+		body, err = proto.Marshal(st)
+	} else {
+		body, err = protojson.Marshal(st)
+	}
+	if err != nil {
+		http.Error(w, msg, httpStatus)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(httpStatus)
+	_, _ = w.Write(body)
+}
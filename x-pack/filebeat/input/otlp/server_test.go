@@ -0,0 +1,181 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const testTimeout = 10 * time.Second
+
+// eventCollector collects published events, acknowledging them if ack is set.
+type eventCollector struct {
+	sync.Mutex
+	ack    bool
+	events []beat.Event
+}
+
+func (c *eventCollector) Publish(event beat.Event) {
+	c.Lock()
+	c.events = append(c.events, event)
+	c.Unlock()
+
+	if c.ack {
+		go event.Private.(*requestACKTracker).ACK()
+	}
+}
+
+func (c *eventCollector) messages() []string {
+	c.Lock()
+	defer c.Unlock()
+
+	var msgs []string
+	for _, e := range c.events {
+		msg, _ := e.Fields.GetValue("message")
+		msgs = append(msgs, msg.(string))
+	}
+	return msgs
+}
+
+func startTestServer(t *testing.T, collect *eventCollector) *server {
+	logp.TestingSetup()
+	log := logp.NewLogger(inputName).With("test_name", t.Name())
+
+	var c config
+	c.InitDefaults()
+	c.GRPC.ListenAddress = "localhost:0"
+	c.HTTP.ListenAddress = "localhost:0"
+
+	s, err := newServer(c, log, collect.Publish, nil, nil)
+	require.NoError(t, err)
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- s.Run() }()
+	t.Cleanup(func() {
+		s.Close()
+		require.NoError(t, <-runErr)
+	})
+	return s
+}
+
+func testLogs(messages ...string) plog.Logs {
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, msg := range messages {
+		records.AppendEmpty().Body().SetStr(msg)
+	}
+	return logs
+}
+
+func TestServerGRPC(t *testing.T) {
+	collect := &eventCollector{ack: true}
+	s := startTestServer(t, collect)
+
+	conn, err := grpc.NewClient(s.grpcListener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	client := plogotlp.NewGRPCClient(conn)
+	_, err = client.Export(ctx, plogotlp.NewExportRequestFromLogs(testLogs("one", "two")), grpc.UseCompressor("gzip"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"one", "two"}, collect.messages())
+	assert.Equal(t, uint64(1), s.metrics.requestsReceivedTotal.Get())
+	assert.Equal(t, uint64(2), s.metrics.logRecordsReceivedTotal.Get())
+}
+
+func TestServerHTTP(t *testing.T) {
+	collect := &eventCollector{ack: true}
+	s := startTestServer(t, collect)
+	url := "http://" + s.httpListener.Addr().String() + logsPath
+
+	req := plogotlp.NewExportRequestFromLogs(testLogs("hello"))
+	protoBody, err := req.MarshalProto()
+	require.NoError(t, err)
+	jsonBody, err := req.MarshalJSON()
+	require.NoError(t, err)
+	var gzipBody bytes.Buffer
+	gz := gzip.NewWriter(&gzipBody)
+	_, err = gz.Write(protoBody)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	testCases := []struct {
+		name        string
+		method      string
+		contentType string
+		encoding    string
+		body        []byte
+		status      int
+	}{
+		{"protobuf", http.MethodPost, contentTypeProtobuf, "", protoBody, http.StatusOK},
+		{"json", http.MethodPost, contentTypeJSON, "", jsonBody, http.StatusOK},
+		{"gzip", http.MethodPost, contentTypeProtobuf, "gzip", gzipBody.Bytes(), http.StatusOK},
+		{"invalid body", http.MethodPost, contentTypeProtobuf, "", []byte("not protobuf"), http.StatusBadRequest},
+		{"unsupported content type", http.MethodPost, "text/plain", "", protoBody, http.StatusUnsupportedMediaType},
+		{"unsupported method", http.MethodGet, contentTypeProtobuf, "", nil, http.StatusMethodNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpReq, err := http.NewRequest(tc.method, url, bytes.NewReader(tc.body))
+			require.NoError(t, err)
+			httpReq.Header.Set("Content-Type", tc.contentType)
+			if tc.encoding != "" {
+				httpReq.Header.Set("Content-Encoding", tc.encoding)
+			}
+
+			resp, err := http.DefaultClient.Do(httpReq)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tc.status, resp.StatusCode)
+		})
+	}
+
+	assert.Equal(t, []string{"hello", "hello", "hello"}, collect.messages())
+	assert.Equal(t, uint64(3), s.metrics.requestsFailedTotal.Get())
+}
+
+func TestServerCloseUnacknowledged(t *testing.T) {
+	// Events are never acknowledged, the request must fail once the
+	// server is closed so the client can retry.
+	collect := &eventCollector{}
+	s := startTestServer(t, collect)
+
+	conn, err := grpc.NewClient(s.grpcListener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	go func() {
+		assert.Eventually(t, func() bool { return len(collect.messages()) == 1 }, testTimeout, 10*time.Millisecond)
+		s.Close()
+	}()
+
+	_, err = plogotlp.NewGRPCClient(conn).Export(ctx, plogotlp.NewExportRequestFromLogs(testLogs("lost")))
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, grpcstatus.Code(err))
+}
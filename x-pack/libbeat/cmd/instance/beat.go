@@ -249,10 +249,11 @@ func NewBeatForReceiver(settings instance.Settings, receiverConfig map[string]an
 	reg := b.Monitoring.StatsRegistry().GetOrCreateRegistry("libbeat")
 
 	monitors := pipeline.Monitors{
-		Metrics:   reg,
-		Telemetry: b.Monitoring.StateRegistry(),
-		Logger:    logger.Named("publisher"),
-		Tracer:    b.Instrumentation.Tracer(),
+		Metrics:        reg,
+		Telemetry:      b.Monitoring.StateRegistry(),
+		Logger:         logger.Named("publisher"),
+		Tracer:         b.Instrumentation.Tracer(),
+		TracerProvider: settings.TracerProvider,
 	}
 
 	outputFactory := b.MakeOutputFactory(b.Config.Output)
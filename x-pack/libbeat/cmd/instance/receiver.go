@@ -6,6 +6,8 @@ package instance
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/elastic/beats/v7/libbeat/api"
 	"github.com/elastic/beats/v7/libbeat/beat"
@@ -14,6 +16,8 @@ import (
 	"github.com/elastic/beats/v7/libbeat/version"
 	"github.com/elastic/beats/v7/x-pack/libbeat/common/otelbeat/status"
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/include"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 	metricreport "github.com/elastic/elastic-agent-system-metrics/report"
 
 	"go.opentelemetry.io/collector/component"
@@ -62,6 +66,9 @@ func NewBeatReceiver(b *instance.Beat, creator beat.Creator, logger *zap.Logger)
 		if err != nil {
 			return BeatReceiver{}, fmt.Errorf("could not start the HTTP server for the API: %w", err)
 		}
+		if err := b.API.AttachHandler("/config", makeConfigHandler(b)); err != nil {
+			return BeatReceiver{}, fmt.Errorf("could not attach the config handler to the API: %w", err)
+		}
 		b.API.Start()
 	}
 
@@ -103,3 +110,82 @@ func (br *BeatReceiver) stopMonitoring() error {
 	}
 	return nil
 }
+
+// EffectiveConfig returns the effective configuration of the beat with the
+// values of the settings that might contain secrets masked, so that it can
+// be included in diagnostics and support bundles.
+func (br *BeatReceiver) EffectiveConfig() (mapstr.M, error) {
+	return effectiveConfig(br.beat)
+}
+
+// secretKeyParts are the parts of setting names whose values are masked in
+// the effective config, in addition to the ones masked for logging.
+var secretKeyParts = []string{
+	"api_key",
+	"secret",
+	"token",
+	"passphrase",
+	"password",
+	"credentials_json",
+	"account_key",
+	"private_key",
+}
+
+func effectiveConfig(b *instance.Beat) (mapstr.M, error) {
+	var content map[string]interface{}
+	if err := b.RawConfig.Unpack(&content); err != nil {
+		return nil, fmt.Errorf("error unpacking beat config: %w", err)
+	}
+	config.ApplyLoggingMask(content)
+	maskSecrets(content)
+	return content, nil
+}
+
+// maskSecrets masks the values of the settings whose name contains one of
+// secretKeyParts, as well as TLS private keys.
+func maskSecrets(c interface{}) {
+	switch cfg := c.(type) {
+	case map[string]interface{}:
+		for k, v := range cfg {
+			if isSecretKey(k) {
+				cfg[k] = "xxxxx"
+				continue
+			}
+			maskSecrets(v)
+		}
+	case []interface{}:
+		for _, elem := range cfg {
+			maskSecrets(elem)
+		}
+	}
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	if key == "key" {
+		return true
+	}
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// makeConfigHandler returns a handler serving the effective config of the beat.
+func makeConfigHandler(b *instance.Beat) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		content, err := effectiveConfig(b)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, ok := r.URL.Query()["pretty"]; ok {
+			fmt.Fprint(w, content.StringToPrint())
+		} else {
+			fmt.Fprint(w, content.String())
+		}
+	}
+}
@@ -18,5 +18,7 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/autodiscover/providers/nomad"
 
 	// register outputs
+	_ "github.com/elastic/beats/v7/x-pack/libbeat/outputs/cloudarchive"
 	_ "github.com/elastic/beats/v7/x-pack/libbeat/outputs/otelconsumer"
+	_ "github.com/elastic/beats/v7/x-pack/libbeat/outputs/parquet"
 )
@@ -0,0 +1,117 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudarchive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs/fileout"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func init() {
+	fileout.RegisterUploader("azure_blob_storage", newAzureUploader)
+}
+
+type azureConfig struct {
+	AccountName      string `config:"account_name"`
+	Container        string `config:"container" validate:"required"`
+	AccountKey       string `config:"account_key"`
+	ConnectionString string `config:"connection_string"`
+	OAuth2           *struct {
+		ClientID     string `config:"client_id" validate:"required"`
+		ClientSecret string `config:"client_secret" validate:"required"`
+		TenantID     string `config:"tenant_id" validate:"required"`
+	} `config:"oauth2"`
+	EncryptionScope string `config:"encryption_scope"`
+	AccessTier      string `config:"access_tier"`
+	// StorageURL overrides the service URL derived from the account name.
+	StorageURL string `config:"storage_url"`
+}
+
+func (c *azureConfig) Validate() error {
+	if c.ConnectionString == "" && c.AccountName == "" {
+		return errors.New("account_name is required unless connection_string is set")
+	}
+	if c.ConnectionString == "" && c.AccountKey == "" && c.OAuth2 == nil {
+		return errors.New("one of account_key, connection_string or oauth2 is required")
+	}
+	return nil
+}
+
+func (c *azureConfig) serviceURL() string {
+	if c.StorageURL != "" {
+		return c.StorageURL
+	}
+	return fmt.Sprintf("https://%s.blob.core.windows.net/", c.AccountName)
+}
+
+type azureUploader struct {
+	cfg    azureConfig
+	client *azblob.Client
+}
+
+func newAzureUploader(_ beat.Info, cfg *config.C) (fileout.Uploader, error) {
+	var c azureConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+
+	var (
+		client *azblob.Client
+		err    error
+	)
+	switch {
+	case c.ConnectionString != "":
+		client, err = azblob.NewClientFromConnectionString(c.ConnectionString, nil)
+	case c.AccountKey != "":
+		var cred *azblob.SharedKeyCredential
+		cred, err = azblob.NewSharedKeyCredential(c.AccountName, c.AccountKey)
+		if err == nil {
+			client, err = azblob.NewClientWithSharedKeyCredential(c.serviceURL(), cred, nil)
+		}
+	default:
+		var cred *azidentity.ClientSecretCredential
+		cred, err = azidentity.NewClientSecretCredential(c.OAuth2.TenantID, c.OAuth2.ClientID, c.OAuth2.ClientSecret, nil)
+		if err == nil {
+			client, err = azblob.NewClient(c.serviceURL(), cred, nil)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure Blob Storage client: %w", err)
+	}
+	return &azureUploader{cfg: c, client: client}, nil
+}
+
+func (u *azureUploader) Upload(ctx context.Context, key, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	opts := &azblob.UploadFileOptions{}
+	if u.cfg.EncryptionScope != "" {
+		opts.CPKScopeInfo = &blob.CPKScopeInfo{EncryptionScope: &u.cfg.EncryptionScope}
+	}
+	if u.cfg.AccessTier != "" {
+		tier := blob.AccessTier(u.cfg.AccessTier)
+		opts.AccessTier = &tier
+	}
+	_, err = u.client.UploadFile(ctx, u.cfg.Container, key, f, opts)
+	return err
+}
+
+func (u *azureUploader) Close() error {
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudarchive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestS3Upload(t *testing.T) {
+	var (
+		path, sse, kmsKey string
+		body              []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		sse = r.Header.Get("X-Amz-Server-Side-Encryption")
+		kmsKey = r.Header.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	file := filepath.Join(t.TempDir(), "beat-20240101.ndjson")
+	require.NoError(t, os.WriteFile(file, []byte("{}\n"), 0o600))
+
+	u, err := newS3Uploader(beat.Info{}, config.MustNewConfigFrom(mapstr.M{
+		"bucket":                 "archive",
+		"endpoint":               srv.URL,
+		"path_style":             true,
+		"access_key_id":          "id",
+		"secret_access_key":      "secret",
+		"server_side_encryption": "aws:kms",
+		"kms_key_id":             "key",
+	}))
+	require.NoError(t, err)
+	require.NoError(t, u.Upload(context.Background(), "logs/beat-20240101.ndjson", file))
+	require.NoError(t, u.Close())
+
+	assert.Equal(t, "/archive/logs/beat-20240101.ndjson", path)
+	assert.Equal(t, "aws:kms", sse)
+	assert.Equal(t, "key", kmsKey)
+	assert.Contains(t, string(body), "{}")
+}
+
+func TestS3ConfigValidate(t *testing.T) {
+	for name, test := range map[string]struct {
+		settings mapstr.M
+		valid    bool
+	}{
+		"defaults":         {settings: mapstr.M{"bucket": "b"}, valid: true},
+		"kms":              {settings: mapstr.M{"bucket": "b", "server_side_encryption": "aws:kms", "kms_key_id": "k"}, valid: true},
+		"missing bucket":   {settings: mapstr.M{}},
+		"unknown sse":      {settings: mapstr.M{"bucket": "b", "server_side_encryption": "rot13"}},
+		"kms key with aes": {settings: mapstr.M{"bucket": "b", "server_side_encryption": "AES256", "kms_key_id": "k"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var c s3Config
+			err := config.MustNewConfigFrom(test.settings).Unpack(&c)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestAzureConfigValidate(t *testing.T) {
+	for name, test := range map[string]struct {
+		settings mapstr.M
+		valid    bool
+	}{
+		"account key":       {settings: mapstr.M{"container": "c", "account_name": "a", "account_key": "k"}, valid: true},
+		"connection string": {settings: mapstr.M{"container": "c", "connection_string": "s"}, valid: true},
+		"no credentials":    {settings: mapstr.M{"container": "c", "account_name": "a"}},
+		"no account":        {settings: mapstr.M{"container": "c", "account_key": "k"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var c azureConfig
+			err := config.MustNewConfigFrom(test.settings).Unpack(&c)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cloudarchive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs/fileout"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func init() {
+	fileout.RegisterUploader("gcs", newGCSUploader)
+}
+
+type gcsConfig struct {
+	Bucket          string `config:"bucket" validate:"required"`
+	CredentialsFile string `config:"credentials_file"`
+	CredentialsJSON string `config:"credentials_json"`
+	KMSKeyName      string `config:"kms_key_name"`
+	StorageClass    string `config:"storage_class"`
+	// AlternativeHost is used for testing against an emulator.
+	AlternativeHost string `config:"alternative_host"`
+}
+
+type gcsUploader struct {
+	cfg    gcsConfig
+	client *storage.Client
+}
+
+func newGCSUploader(_ beat.Info, cfg *config.C) (fileout.Uploader, error) {
+	var c gcsConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+
+	var opts []option.ClientOption
+	switch {
+	case c.AlternativeHost != "":
+		opts = append(opts, option.WithEndpoint(c.AlternativeHost), option.WithoutAuthentication())
+	case c.CredentialsJSON != "":
+		opts = append(opts, option.WithCredentialsJSON([]byte(c.CredentialsJSON)))
+	case c.CredentialsFile != "":
+		opts = append(opts, option.WithCredentialsFile(c.CredentialsFile))
+	}
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+	return &gcsUploader{cfg: c, client: client}, nil
+}
+
+func (u *gcsUploader) Upload(ctx context.Context, key, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w := u.client.Bucket(u.cfg.Bucket).Object(key).NewWriter(ctx)
+	w.KMSKeyName = u.cfg.KMSKeyName
+	w.StorageClass = u.cfg.StorageClass
+	if _, err := io.Copy(w, f); err != nil {
+		// Cancelling the context discards the partial upload.
+		cancel()
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (u *gcsUploader) Close() error {
+	return u.client.Close()
+}
@@ -0,0 +1,105 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package cloudarchive registers the object stores the file output can
+// upload its rotated files to.
+package cloudarchive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/outputs/fileout"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func init() {
+	fileout.RegisterUploader("s3", newS3Uploader)
+}
+
+type s3Config struct {
+	AWSConfig            awscommon.ConfigAWS `config:",inline"`
+	Bucket               string              `config:"bucket" validate:"required"`
+	PathStyle            bool                `config:"path_style"`
+	StorageClass         string              `config:"storage_class"`
+	ServerSideEncryption string              `config:"server_side_encryption"`
+	KMSKeyID             string              `config:"kms_key_id"`
+}
+
+func (c *s3Config) Validate() error {
+	switch types.ServerSideEncryption(c.ServerSideEncryption) {
+	case "", types.ServerSideEncryptionAes256:
+		if c.KMSKeyID != "" {
+			return errors.New("kms_key_id requires server_side_encryption to be aws:kms or aws:kms:dsse")
+		}
+	case types.ServerSideEncryptionAwsKms, types.ServerSideEncryptionAwsKmsDsse:
+	default:
+		return fmt.Errorf("unsupported server_side_encryption '%v'", c.ServerSideEncryption)
+	}
+	return nil
+}
+
+type s3Uploader struct {
+	cfg      s3Config
+	uploader *manager.Uploader
+}
+
+func newS3Uploader(_ beat.Info, cfg *config.C) (fileout.Uploader, error) {
+	var c s3Config
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, err
+	}
+	awsConfig, err := awscommon.InitializeAWSConfig(c.AWSConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize AWS credentials: %w", err)
+	}
+	client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if c.AWSConfig.FIPSEnabled {
+			o.EndpointOptions.UseFIPSEndpoint = awssdk.FIPSEndpointStateEnabled
+		}
+		if c.AWSConfig.Endpoint != "" {
+			o.BaseEndpoint = awssdk.String(c.AWSConfig.Endpoint)
+		}
+		o.UsePathStyle = c.PathStyle
+	})
+	return &s3Uploader{cfg: c, uploader: manager.NewUploader(client)}, nil
+}
+
+func (u *s3Uploader) Upload(ctx context.Context, key, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	input := &s3.PutObjectInput{
+		Bucket: awssdk.String(u.cfg.Bucket),
+		Key:    awssdk.String(key),
+		Body:   f,
+	}
+	if u.cfg.StorageClass != "" {
+		input.StorageClass = types.StorageClass(u.cfg.StorageClass)
+	}
+	if u.cfg.ServerSideEncryption != "" {
+		input.ServerSideEncryption = types.ServerSideEncryption(u.cfg.ServerSideEncryption)
+	}
+	if u.cfg.KMSKeyID != "" {
+		input.SSEKMSKeyId = awssdk.String(u.cfg.KMSKeyID)
+	}
+	_, err = u.uploader.Upload(ctx, input)
+	return err
+}
+
+func (u *s3Uploader) Close() error {
+	return nil
+}
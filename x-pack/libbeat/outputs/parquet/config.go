@@ -0,0 +1,74 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v17/parquet/compress"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+type parquetConfig struct {
+	Path           string           `config:"path"            validate:"required"`
+	Filename       string           `config:"filename"`
+	RotateEveryKb  uint             `config:"rotate_every_kb" validate:"min=1"`
+	RotateInterval time.Duration    `config:"rotate_interval" validate:"min=0"`
+	RowGroupSize   int64            `config:"row_group_size"  validate:"min=1"`
+	Compression    string           `config:"compression"`
+	Permissions    uint32           `config:"permissions"`
+	Fields         []fieldConfig    `config:"fields"          validate:"required"`
+	BulkMaxSize    int              `config:"bulk_max_size"`
+	MaxRetries     int              `config:"max_retries"     validate:"min=-1"`
+	Queue          config.Namespace `config:"queue"`
+}
+
+// fieldConfig selects a field written as column. The type is taken from
+// the field definitions of the Beat, which include ECS, unless it is set.
+type fieldConfig struct {
+	Name string `config:"name" validate:"required"`
+	Type string `config:"type"`
+}
+
+var compressionCodecs = map[string]compress.Compression{
+	"none":   compress.Codecs.Uncompressed,
+	"snappy": compress.Codecs.Snappy,
+	"gzip":   compress.Codecs.Gzip,
+	"zstd":   compress.Codecs.Zstd,
+}
+
+func defaultConfig() parquetConfig {
+	return parquetConfig{
+		RotateEveryKb:  128 * 1024,
+		RotateInterval: time.Hour,
+		RowGroupSize:   64 * 1024,
+		Compression:    "snappy",
+		Permissions:    0600,
+		BulkMaxSize:    4096,
+		MaxRetries:     3,
+	}
+}
+
+func (c *parquetConfig) Validate() error {
+	if _, ok := compressionCodecs[strings.ToLower(c.Compression)]; !ok {
+		return fmt.Errorf("unsupported compression '%v'", c.Compression)
+	}
+
+	seen := make(map[string]bool, len(c.Fields))
+	for _, f := range c.Fields {
+		if seen[f.Name] {
+			return fmt.Errorf("field '%v' is configured more than once", f.Name)
+		}
+		seen[f.Name] = true
+	}
+	if len(c.Fields) == 0 {
+		return errors.New("at least one field must be configured")
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package parquet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/elastic/beats/v7/libbeat/asset"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/mapping"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func init() {
+	outputs.RegisterType("parquet", makeParquet)
+}
+
+type parquetOutput struct {
+	log      *logp.Logger
+	observer outputs.Observer
+	schema   *schema
+	mem      memory.Allocator
+
+	mu     sync.Mutex
+	writer *rotatingWriter
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func makeParquet(
+	_ outputs.IndexManager,
+	beat beat.Info,
+	observer outputs.Observer,
+	cfg *config.C,
+) (outputs.Group, error) {
+	pqConfig := defaultConfig()
+	if err := cfg.Unpack(&pqConfig); err != nil {
+		return outputs.Fail(err)
+	}
+	if pqConfig.Filename == "" {
+		pqConfig.Filename = beat.Beat
+	}
+
+	data, err := asset.GetFields(beat.Beat)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+	definitions, err := mapping.LoadFields(data)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	out, err := newParquetOutput(beat.Logger.Named("parquet"), observer, pqConfig, definitions)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	return outputs.Success(pqConfig.Queue, pqConfig.BulkMaxSize, pqConfig.MaxRetries, nil, out)
+}
+
+func newParquetOutput(log *logp.Logger, observer outputs.Observer, config parquetConfig, definitions mapping.Fields) (*parquetOutput, error) {
+	s, err := newSchema(config.Fields, definitions)
+	if err != nil {
+		return nil, err
+	}
+
+	mem := memory.DefaultAllocator
+	writer, err := newRotatingWriter(log, config, s.arrow, mem)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &parquetOutput{
+		log:      log,
+		observer: observer,
+		schema:   s,
+		mem:      mem,
+		writer:   writer,
+		done:     make(chan struct{}),
+	}
+
+	if config.RotateInterval > 0 {
+		out.wg.Add(1)
+		go out.rotateWorker(min(config.RotateInterval, time.Second))
+	}
+
+	log.Infof("Initialized parquet output. path=%v columns=%v compression=%v",
+		config.Path, len(s.columns), config.Compression)
+	return out, nil
+}
+
+// rotateWorker closes files older than the rotation interval, even if no
+// new events are published.
+func (out *parquetOutput) rotateWorker(period time.Duration) {
+	defer out.wg.Done()
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-out.done:
+			return
+		case <-ticker.C:
+			out.mu.Lock()
+			if err := out.writer.rotateIfExpired(); err != nil {
+				out.log.Errorf("Failed to rotate parquet file: %v", err)
+			}
+			out.mu.Unlock()
+		}
+	}
+}
+
+func (out *parquetOutput) Close() error {
+	close(out.done)
+	out.wg.Wait()
+
+	out.mu.Lock()
+	defer out.mu.Unlock()
+	return out.writer.close()
+}
+
+func (out *parquetOutput) Publish(_ context.Context, batch publisher.Batch) error {
+	st := out.observer
+	events := batch.Events()
+	st.NewBatch(len(events))
+
+	if len(events) == 0 {
+		batch.ACK()
+		return nil
+	}
+
+	rec := out.schema.record(out.mem, events)
+	defer rec.Release()
+
+	begin := time.Now()
+	out.mu.Lock()
+	before := out.writer.written()
+	err := out.writer.write(rec)
+	if err != nil {
+		// The state of the current file is unknown, finish it so the next
+		// attempt starts with a new file.
+		if cerr := out.writer.close(); cerr != nil {
+			out.log.Errorf("Failed to close parquet file after write error: %v", cerr)
+		}
+	}
+	written := out.writer.written() - before
+	out.mu.Unlock()
+
+	if err != nil {
+		out.log.Errorf("Writing events to parquet file failed with: %+v", err)
+		st.WriteError(err)
+		st.RetryableErrors(len(events))
+		batch.Retry()
+		return nil
+	}
+
+	if written > 0 {
+		st.WriteBytes(int(written))
+	}
+	st.ReportLatency(time.Since(begin))
+	st.AckedEvents(len(events))
+	batch.ACK()
+	return nil
+}
+
+func (out *parquetOutput) String() string {
+	return "parquet(" + out.writer.dir + ")"
+}
@@ -0,0 +1,271 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package parquet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/mapping"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/outputs/outest"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const testFields = `
+- key: ecs
+  title: ECS
+  fields:
+    - name: '@timestamp'
+      type: date
+    - name: message
+      type: match_only_text
+    - name: host
+      type: group
+      fields:
+        - name: name
+        - name: uptime
+          type: long
+        - name: cpu.usage
+          type: scaled_float
+    - name: event
+      type: group
+      fields:
+        - name: duration
+          type: long
+    - name: labels
+      type: object
+    - name: tls.established
+      type: boolean
+    - name: hostname
+      type: alias
+      path: host.name
+`
+
+func testDefinitions(t *testing.T) mapping.Fields {
+	t.Helper()
+	fields, err := mapping.LoadFields([]byte(testFields))
+	require.NoError(t, err)
+	return fields
+}
+
+func testConfig(t *testing.T, settings mapstr.M) parquetConfig {
+	t.Helper()
+	cfg := config.MustNewConfigFrom(mapstr.M{
+		"path":     t.TempDir(),
+		"filename": "test",
+		"fields": []mapstr.M{
+			{"name": "@timestamp"},
+			{"name": "message"},
+			{"name": "host.name"},
+			{"name": "host.uptime"},
+			{"name": "host.cpu.usage"},
+			{"name": "tls.established"},
+			{"name": "labels"},
+			{"name": "custom", "type": "long"},
+		},
+	})
+	require.NoError(t, cfg.Merge(settings))
+
+	pqConfig := defaultConfig()
+	require.NoError(t, cfg.Unpack(&pqConfig))
+	return pqConfig
+}
+
+func TestSchema(t *testing.T) {
+	s, err := newSchema([]fieldConfig{
+		{Name: "@timestamp"},
+		{Name: "message"},
+		{Name: "host.name"},
+		{Name: "host.cpu.usage"},
+		{Name: "hostname"},
+		{Name: "event.duration", Type: "keyword"},
+		{Name: "labels"},
+	}, testDefinitions(t))
+	require.NoError(t, err)
+
+	want := []column{
+		{name: "@timestamp", kind: timestampColumn},
+		{name: "message", kind: stringColumn},
+		{name: "host.name", kind: stringColumn},
+		{name: "host.cpu.usage", kind: doubleColumn},
+		{name: "hostname", kind: stringColumn},
+		{name: "event.duration", kind: stringColumn},
+		{name: "labels", kind: stringColumn},
+	}
+	assert.Equal(t, want, s.columns)
+	assert.Equal(t, len(want), s.arrow.NumFields())
+}
+
+func TestSchemaErrors(t *testing.T) {
+	definitions := testDefinitions(t)
+
+	_, err := newSchema([]fieldConfig{{Name: "unknown.field"}}, definitions)
+	assert.ErrorContains(t, err, "is not defined")
+
+	_, err = newSchema([]fieldConfig{{Name: "message", Type: "histogram"}}, definitions)
+	assert.ErrorContains(t, err, "unsupported type")
+}
+
+func TestConfigValidate(t *testing.T) {
+	for name, settings := range map[string]mapstr.M{
+		"no path":                {"fields": []mapstr.M{{"name": "message"}}},
+		"no fields":              {"path": "/tmp"},
+		"unknown compression":    {"path": "/tmp", "compression": "brotli", "fields": []mapstr.M{{"name": "message"}}},
+		"duplicate field":        {"path": "/tmp", "fields": []mapstr.M{{"name": "message"}, {"name": "message"}}},
+		"invalid row group size": {"path": "/tmp", "row_group_size": 0, "fields": []mapstr.M{{"name": "message"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pqConfig := defaultConfig()
+			assert.Error(t, config.MustNewConfigFrom(settings).Unpack(&pqConfig))
+		})
+	}
+}
+
+func TestPublish(t *testing.T) {
+	pqConfig := testConfig(t, nil)
+	out, err := newParquetOutput(logptest.NewTestingLogger(t, ""), outputs.NewNilObserver(), pqConfig, testDefinitions(t))
+	require.NoError(t, err)
+
+	ts := time.Date(2025, time.March, 1, 12, 0, 0, 123456000, time.UTC)
+	batch := outest.NewBatch(
+		beat.Event{Timestamp: ts, Fields: mapstr.M{
+			"message": "first",
+			"host": mapstr.M{
+				"name":   "host-a",
+				"uptime": 42,
+				"cpu":    mapstr.M{"usage": 0.5},
+			},
+			"tls":    mapstr.M{"established": true},
+			"labels": mapstr.M{"env": "prod"},
+			"custom": "17",
+		}},
+		beat.Event{Timestamp: ts.Add(time.Second), Fields: mapstr.M{
+			"message": "second",
+			"host":    mapstr.M{"uptime": "not a number"},
+		}},
+	)
+	require.NoError(t, out.Publish(context.Background(), batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	files, err := filepath.Glob(filepath.Join(pqConfig.Path, "test-*.parquet*"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, tmpSuffix, filepath.Ext(files[0]), "open file must not be visible as parquet file")
+
+	require.NoError(t, out.Close())
+
+	files = parquetFiles(t, pqConfig.Path)
+	require.Len(t, files, 1)
+	tbl := readTable(t, files[0])
+	defer tbl.Release()
+	require.Equal(t, int64(2), tbl.NumRows())
+
+	col := func(name string) arrow.Array {
+		idx := tbl.Schema().FieldIndices(name)
+		require.Len(t, idx, 1, name)
+		return tbl.Column(idx[0]).Data().Chunk(0)
+	}
+	assert.Equal(t, arrow.Timestamp(ts.UnixMicro()), col("@timestamp").(*array.Timestamp).Value(0))
+	assert.Equal(t, "first", col("message").(*array.String).Value(0))
+	assert.Equal(t, "second", col("message").(*array.String).Value(1))
+	assert.Equal(t, "host-a", col("host.name").(*array.String).Value(0))
+	assert.True(t, col("host.name").IsNull(1))
+	assert.Equal(t, int64(42), col("host.uptime").(*array.Int64).Value(0))
+	assert.True(t, col("host.uptime").IsNull(1), "values not matching the column type are null")
+	assert.Equal(t, 0.5, col("host.cpu.usage").(*array.Float64).Value(0))
+	assert.True(t, col("tls.established").(*array.Boolean).Value(0))
+	assert.Equal(t, `{"env":"prod"}`, col("labels").(*array.String).Value(0))
+	assert.Equal(t, int64(17), col("custom").(*array.Int64).Value(0))
+}
+
+func TestRotateBySize(t *testing.T) {
+	pqConfig := testConfig(t, mapstr.M{"rotate_every_kb": 1, "rotate_interval": 0, "compression": "none"})
+	out, err := newParquetOutput(logptest.NewTestingLogger(t, ""), outputs.NewNilObserver(), pqConfig, testDefinitions(t))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		events := make([]beat.Event, 100)
+		for j := range events {
+			events[j] = beat.Event{Timestamp: time.Now(), Fields: mapstr.M{"message": fmt.Sprintf("message %d of batch %d", j, i)}}
+		}
+		require.NoError(t, out.Publish(context.Background(), outest.NewBatch(events...)))
+	}
+	require.NoError(t, out.Close())
+
+	files := parquetFiles(t, pqConfig.Path)
+	require.Len(t, files, 3)
+	var rows int64
+	for _, f := range files {
+		tbl := readTable(t, f)
+		rows += tbl.NumRows()
+		tbl.Release()
+	}
+	assert.Equal(t, int64(300), rows)
+}
+
+func TestRotateByInterval(t *testing.T) {
+	pqConfig := testConfig(t, mapstr.M{"rotate_interval": "1m"})
+	writer, err := newRotatingWriter(logptest.NewTestingLogger(t, ""), pqConfig,
+		arrow.NewSchema([]arrow.Field{{Name: "message", Type: arrow.BinaryTypes.String, Nullable: true}}, nil),
+		memory.DefaultAllocator)
+	require.NoError(t, err)
+
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	writer.now = func() time.Time { return now }
+
+	s, err := newSchema([]fieldConfig{{Name: "message"}}, testDefinitions(t))
+	require.NoError(t, err)
+	rec := s.record(memory.DefaultAllocator, outest.NewBatch(beat.Event{Fields: mapstr.M{"message": "a"}}).Events())
+	defer rec.Release()
+
+	require.NoError(t, writer.write(rec))
+	require.NoError(t, writer.rotateIfExpired())
+	assert.Empty(t, parquetFiles(t, pqConfig.Path))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, writer.rotateIfExpired())
+	assert.Equal(t, []string{filepath.Join(pqConfig.Path, "test-20250301T120000Z-1.parquet")}, parquetFiles(t, pqConfig.Path))
+
+	// nothing to rotate without a new write
+	require.NoError(t, writer.rotateIfExpired())
+	require.NoError(t, writer.close())
+	assert.Len(t, parquetFiles(t, pqConfig.Path), 1)
+}
+
+func parquetFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*.parquet"))
+	require.NoError(t, err)
+	return files
+}
+
+func readTable(t *testing.T, path string) arrow.Table {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	tbl, err := pqarrow.ReadTable(context.Background(), f, parquet.NewReaderProperties(memory.DefaultAllocator),
+		pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	return tbl
+}
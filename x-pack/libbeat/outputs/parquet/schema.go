@@ -0,0 +1,283 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package parquet
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/mapping"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+)
+
+type columnKind uint8
+
+const (
+	stringColumn columnKind = iota
+	longColumn
+	doubleColumn
+	booleanColumn
+	timestampColumn
+)
+
+// fieldTypes maps the field types used in the field definitions to the
+// column kinds. Types that are not listed, like object or geo_point, are
+// written as JSON encoded strings.
+var fieldTypes = map[string]columnKind{
+	"keyword":          stringColumn,
+	"constant_keyword": stringColumn,
+	"wildcard":         stringColumn,
+	"text":             stringColumn,
+	"match_only_text":  stringColumn,
+	"ip":               stringColumn,
+	"version":          stringColumn,
+	"long":             longColumn,
+	"integer":          longColumn,
+	"short":            longColumn,
+	"byte":             longColumn,
+	"unsigned_long":    longColumn,
+	"float":            doubleColumn,
+	"half_float":       doubleColumn,
+	"scaled_float":     doubleColumn,
+	"double":           doubleColumn,
+	"boolean":          booleanColumn,
+	"date":             timestampColumn,
+	"date_nanos":       timestampColumn,
+}
+
+type column struct {
+	name string
+	kind columnKind
+}
+
+// schema describes the columns of the Parquet files. Columns are named
+// after the dotted field names and are all optional.
+type schema struct {
+	columns []column
+	arrow   *arrow.Schema
+}
+
+// newSchema creates the schema for the configured fields. The types of
+// fields without an explicit type are looked up in the field definitions.
+func newSchema(fields []fieldConfig, definitions mapping.Fields) (*schema, error) {
+	types := map[string]*mapping.Field{}
+	flattenFields(definitions, "", types)
+
+	s := &schema{}
+	arrowFields := make([]arrow.Field, 0, len(fields))
+	for _, f := range fields {
+		typ := f.Type
+		if typ == "" {
+			def, ok := types[f.Name]
+			if !ok {
+				return nil, fmt.Errorf("field '%v' is not defined in the field definitions, its type must be configured", f.Name)
+			}
+			typ = fieldType(def, types)
+		}
+
+		kind, ok := fieldTypes[typ]
+		if !ok && f.Type != "" && f.Type != "object" && f.Type != "flattened" {
+			return nil, fmt.Errorf("unsupported type '%v' for field '%v'", f.Type, f.Name)
+		}
+
+		s.columns = append(s.columns, column{name: f.Name, kind: kind})
+		arrowFields = append(arrowFields, arrow.Field{Name: f.Name, Type: kind.arrowType(), Nullable: true})
+	}
+	s.arrow = arrow.NewSchema(arrowFields, nil)
+	return s, nil
+}
+
+// flattenFields collects all leaf fields of the definitions by their full
+// dotted name.
+func flattenFields(fields mapping.Fields, prefix string, out map[string]*mapping.Field) {
+	for i := range fields {
+		f := &fields[i]
+		name := f.Name
+		if prefix != "" {
+			name = prefix + "." + f.Name
+		}
+		if len(f.Fields) > 0 {
+			flattenFields(f.Fields, name, out)
+			continue
+		}
+		out[name] = f
+	}
+}
+
+func fieldType(f *mapping.Field, types map[string]*mapping.Field) string {
+	switch f.Type {
+	case "":
+		return "keyword"
+	case "alias":
+		if target, ok := types[f.AliasPath]; ok && target.Type != "alias" {
+			return fieldType(target, types)
+		}
+	}
+	return f.Type
+}
+
+func (k columnKind) arrowType() arrow.DataType {
+	switch k {
+	case longColumn:
+		return arrow.PrimitiveTypes.Int64
+	case doubleColumn:
+		return arrow.PrimitiveTypes.Float64
+	case booleanColumn:
+		return arrow.FixedWidthTypes.Boolean
+	case timestampColumn:
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+// record converts events into an Arrow record. Missing fields and values
+// that cannot be converted to the column type are written as null.
+func (s *schema) record(mem memory.Allocator, events []publisher.Event) arrow.Record {
+	b := array.NewRecordBuilder(mem, s.arrow)
+	defer b.Release()
+
+	for i := range events {
+		event := &events[i].Content
+		for j, c := range s.columns {
+			var value interface{}
+			if c.name == "@timestamp" {
+				value = event.Timestamp
+			} else {
+				value, _ = event.GetValue(c.name)
+			}
+			appendValue(b.Field(j), c.kind, value)
+		}
+	}
+	return b.NewRecord()
+}
+
+func appendValue(b array.Builder, kind columnKind, value interface{}) {
+	if value == nil {
+		b.AppendNull()
+		return
+	}
+
+	switch kind {
+	case longColumn:
+		if v, ok := toInt64(value); ok {
+			b.(*array.Int64Builder).Append(v)
+			return
+		}
+	case doubleColumn:
+		if v, ok := toFloat64(value); ok {
+			b.(*array.Float64Builder).Append(v)
+			return
+		}
+	case booleanColumn:
+		if v, ok := toBool(value); ok {
+			b.(*array.BooleanBuilder).Append(v)
+			return
+		}
+	case timestampColumn:
+		if v, ok := toTime(value); ok {
+			b.(*array.TimestampBuilder).Append(arrow.Timestamp(v.UnixMicro()))
+			return
+		}
+	default:
+		if v, ok := toString(value); ok {
+			b.(*array.StringBuilder).Append(v)
+			return
+		}
+	}
+	b.AppendNull()
+}
+
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case string:
+		i, err := strconv.ParseInt(v, 10, 64)
+		return i, err == nil
+	case json.Number:
+		i, err := v.Int64()
+		return i, err == nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		return int64(u), u <= math.MaxInt64 //nolint:gosec // overflow is checked
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		return int64(f), f == math.Trunc(f) && math.Abs(f) < math.MaxInt64
+	}
+	return 0, false
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func toBool(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	}
+	return false, false
+}
+
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case common.Time:
+		return time.Time(v), true
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+// toString returns strings as they are and encodes all other values, like
+// numbers, arrays or objects, as JSON.
+func toString(value interface{}) (string, bool) {
+	if s, ok := value.(string); ok {
+		return s, true
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
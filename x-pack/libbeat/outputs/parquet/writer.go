@@ -0,0 +1,177 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package parquet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// tmpSuffix is appended to the name of the file currently being written.
+// Parquet files can only be read once their footer is written, so files are
+// renamed when they are closed.
+const tmpSuffix = ".tmp"
+
+// rotatingWriter writes records to Parquet files in a directory. A new file
+// is started once the current one exceeds the maximum size or is older than
+// the rotation interval.
+type rotatingWriter struct {
+	log         *logp.Logger
+	dir         string
+	prefix      string
+	permissions os.FileMode
+	maxSize     int64
+	interval    time.Duration
+
+	schema     *arrow.Schema
+	props      *parquet.WriterProperties
+	arrowProps pqarrow.ArrowWriterProperties
+
+	file    *os.File
+	counter *countingWriter
+	writer  *pqarrow.FileWriter
+	name    string
+	opened  time.Time
+	seq     int
+	total   int64
+
+	now func() time.Time
+}
+
+// countingWriter counts the bytes written to the current file and to all
+// files.
+type countingWriter struct {
+	file  *os.File
+	n     int64
+	total *int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.file.Write(p)
+	w.n += int64(n)
+	*w.total += int64(n)
+	return n, err
+}
+
+func newRotatingWriter(log *logp.Logger, config parquetConfig, schema *arrow.Schema, mem memory.Allocator) (*rotatingWriter, error) {
+	if err := os.MkdirAll(config.Path, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create directory '%v': %w", config.Path, err)
+	}
+
+	return &rotatingWriter{
+		log:         log,
+		dir:         config.Path,
+		prefix:      config.Filename,
+		permissions: os.FileMode(config.Permissions),
+		maxSize:     int64(config.RotateEveryKb) * 1024,
+		interval:    config.RotateInterval,
+		schema:      schema,
+		props: parquet.NewWriterProperties(
+			parquet.WithAllocator(mem),
+			parquet.WithCompression(compressionCodecs[strings.ToLower(config.Compression)]),
+			parquet.WithMaxRowGroupLength(config.RowGroupSize),
+		),
+		arrowProps: pqarrow.NewArrowWriterProperties(
+			pqarrow.WithAllocator(mem),
+			pqarrow.WithStoreSchema(),
+		),
+		now: time.Now,
+	}, nil
+}
+
+// write appends the record as new row groups to the current file, opening
+// a new file if necessary, and rotates the file once it is full. Row groups
+// are flushed to the file immediately, so written records are on disk once
+// write returns, although the file can only be read after it is closed.
+func (w *rotatingWriter) write(rec arrow.Record) error {
+	if w.writer == nil {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+
+	if err := w.writer.Write(rec); err != nil {
+		return fmt.Errorf("failed to write to '%v': %w", w.name, err)
+	}
+
+	if w.counter.n >= w.maxSize {
+		return w.close()
+	}
+	return nil
+}
+
+// rotateIfExpired closes the current file if it is older than the rotation
+// interval.
+func (w *rotatingWriter) rotateIfExpired() error {
+	if w.writer == nil || w.interval <= 0 || w.now().Sub(w.opened) < w.interval {
+		return nil
+	}
+	return w.close()
+}
+
+func (w *rotatingWriter) open() error {
+	w.opened = w.now()
+	w.seq++
+	w.name = filepath.Join(w.dir, fmt.Sprintf("%s-%s-%d.parquet",
+		w.prefix, w.opened.UTC().Format("20060102T150405Z"), w.seq))
+
+	f, err := os.OpenFile(w.name+tmpSuffix, os.O_CREATE|os.O_EXCL|os.O_WRONLY, w.permissions)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	counter := &countingWriter{file: f, total: &w.total}
+	writer, err := pqarrow.NewFileWriter(w.schema, counter, w.props, w.arrowProps)
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return fmt.Errorf("failed to create parquet writer: %w", err)
+	}
+
+	w.file, w.counter, w.writer = f, counter, writer
+	w.log.Debugf("Opened parquet file %v", w.name)
+	return nil
+}
+
+// written returns the number of bytes written to all files.
+func (w *rotatingWriter) written() int64 {
+	return w.total
+}
+
+// close writes the footer of the current file and moves it to its final
+// name.
+func (w *rotatingWriter) close() error {
+	if w.writer == nil {
+		return nil
+	}
+
+	// FileWriter.Close also closes the underlying writer if it is an
+	// io.Closer, which the counting writer is not.
+	err := w.writer.Close()
+	if cerr := w.file.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(w.name+tmpSuffix, w.name)
+	}
+
+	name := w.name
+	w.file, w.counter, w.writer = nil, nil, nil
+	if err != nil {
+		return fmt.Errorf("failed to close '%v': %w", name, err)
+	}
+	w.log.Debugf("Closed parquet file %v", name)
+	return nil
+}
@@ -29,6 +29,7 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure/app_insights"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure/billing"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure/log_analytics"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure/monitor"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure/storage"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/benchmark"
@@ -69,6 +70,8 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/performance"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/sysmetric"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/tablespace"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/otlp"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/otlp/metrics"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/panw"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/panw/interfaces"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/panw/routing"
@@ -78,6 +81,8 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/prometheus/collector"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/prometheus/remote_write"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/redisenterprise"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/snmp"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/snmp/metrics"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/sql"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/sql/query"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/stan"
@@ -8,11 +8,13 @@ package azure
 
 import (
 	"fmt"
+	"path"
 	"slices"
 	"sync"
 	"time"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/paths"
 )
 
 func init() {
@@ -75,7 +77,12 @@ var supportedMonitorMetricsets = []string{"monitor", "container_registry", "cont
 // NewMetricSet will instantiate a new azure metricset
 func NewMetricSet(base mb.BaseMetricSet) (*MetricSet, error) {
 	metricsetName := base.Name()
-	var config Config
+	// The batch API is used by default, it falls back to one request
+	// per resource in the locations where it is not available.
+	config := Config{
+		EnableBatchApi:            true,
+		MetricDefinitionsCacheTTL: DefaultMetricDefinitionsCacheTTL,
+	}
 	err := base.Module().UnpackConfig(&config)
 	if err != nil {
 		return nil, fmt.Errorf("error unpack raw module config using UnpackConfig: %w", err)
@@ -129,6 +136,16 @@ func NewMetricSet(base mb.BaseMetricSet) (*MetricSet, error) {
 		}
 	}
 
+	if config.MetricDefinitionsCacheTTL > 0 {
+		// Persist the metric definitions, so they are reused after a restart.
+		cacheDir := paths.Resolve(paths.Data, path.Join("state", base.Module().Name(), metricsetName))
+		if monitorClient != nil {
+			monitorClient.useMetricDefinitionCache(cacheDir, config.MetricDefinitionsCacheTTL)
+		} else {
+			monitorBatchClient.useMetricDefinitionCache(cacheDir, config.MetricDefinitionsCacheTTL)
+		}
+	}
+
 	return &MetricSet{
 		BaseMetricSet: base,
 		Client:        monitorClient,
@@ -181,6 +198,10 @@ func fetch(m *MetricSet, report mb.ReporterV2) error {
 		return err
 	}
 
+	if m.Client.Config.EmitResourceInventory {
+		m.Client.ReportResourceInventory(report)
+	}
+
 	if len(m.Client.ResourceConfigurations.Metrics) == 0 {
 		// error message is previously logged in the InitResources,
 		// no error event should be created
@@ -239,6 +260,10 @@ func fetchBatch(m *MetricSet, report mb.ReporterV2) error {
 	if err != nil {
 		return err
 	}
+
+	if m.BatchClient.Config.EmitResourceInventory {
+		m.BatchClient.ReportResourceInventory(report)
+	}
 	// Check if the channel is nil before entering the loop
 	if m.BatchClient.ResourceConfigurations.MetricDefinitionsChan == nil {
 		return fmt.Errorf("no resources were found based on all the configurations options entered")
@@ -432,7 +457,7 @@ func hasConfigOptions(config []string) bool {
 //	  │                                                        │              |
 //	Start                                                     End             |
 //	  │                                                        │              |
-func calculateTimespan(referenceTime time.Time, timeGrain string, config Config) (time.Time, time.Time) {
+func calculateTimespan(referenceTime time.Time, timeGrain string, period time.Duration, latency time.Duration) (time.Time, time.Time) {
 	// The timespan duration is the maximum of the time grain and the
 	// collection period.
 	//
@@ -448,7 +473,7 @@ func calculateTimespan(referenceTime time.Time, timeGrain string, config Config)
 	//
 	// If the time grain is 5 minutes and the collection period is
 	// 1 minute, we will collect one PT5M metric in five collections.
-	timespanDuration := max(asDuration(timeGrain), config.Period)
+	timespanDuration := max(asDuration(timeGrain), period)
 
 	// The end time is equal to the reference time in most cases.
 	//
@@ -459,7 +484,10 @@ func calculateTimespan(referenceTime time.Time, timeGrain string, config Config)
 	// For example, if the Azure service publishes the metric values
 	// with a delay of 30s / 1m, we can set the delay to one minute
 	// to always collect the latest metric values.
-	endTime := referenceTime.Add(config.Latency * -1)
+	//
+	// The latency can be set at the module level or overridden for
+	// each resource configuration.
+	endTime := referenceTime.Add(latency * -1)
 	startTime := endTime.Add(timespanDuration * -1)
 
 	return startTime, endTime
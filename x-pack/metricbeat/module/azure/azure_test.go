@@ -12,6 +12,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
 )
 
 func TestGroupMetricsDefinitionsByResourceId(t *testing.T) {
@@ -50,7 +52,7 @@ func TestCalculateTimespan(t *testing.T) {
 			Period: 5 * time.Minute,
 		}
 
-		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg)
+		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg.Period, cfg.Latency)
 
 		require.Equal(t, "2024-07-30T18:51:00Z", startTime.Format(time.RFC3339))
 		require.Equal(t, "2024-07-30T18:56:00Z", endTime.Format(time.RFC3339))
@@ -63,7 +65,7 @@ func TestCalculateTimespan(t *testing.T) {
 			Period: 1 * time.Minute,
 		}
 
-		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg)
+		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg.Period, cfg.Latency)
 
 		require.Equal(t, "2024-07-30T18:55:00Z", startTime.Format(time.RFC3339))
 		require.Equal(t, "2024-07-30T18:56:00Z", endTime.Format(time.RFC3339))
@@ -76,7 +78,7 @@ func TestCalculateTimespan(t *testing.T) {
 			Period: 5 * time.Minute,
 		}
 
-		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg)
+		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg.Period, cfg.Latency)
 
 		require.Equal(t, "2024-07-30T18:51:00Z", startTime.Format(time.RFC3339))
 		require.Equal(t, "2024-07-30T18:56:00Z", endTime.Format(time.RFC3339))
@@ -89,7 +91,7 @@ func TestCalculateTimespan(t *testing.T) {
 			Period: 60 * time.Minute,
 		}
 
-		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg)
+		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg.Period, cfg.Latency)
 
 		require.Equal(t, "2024-07-30T17:56:00Z", startTime.Format(time.RFC3339))
 		require.Equal(t, "2024-07-30T18:56:00Z", endTime.Format(time.RFC3339))
@@ -101,7 +103,7 @@ func TestCalculateTimespan(t *testing.T) {
 		cfg := Config{
 			Period: 5 * time.Minute,
 		}
-		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg)
+		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg.Period, cfg.Latency)
 
 		require.Equal(t, "2024-07-30T17:56:00Z", startTime.Format(time.RFC3339))
 		require.Equal(t, "2024-07-30T18:56:00Z", endTime.Format(time.RFC3339))
@@ -118,7 +120,7 @@ func TestCalculateTimespanWithLatency(t *testing.T) {
 			Latency: 1 * time.Minute,
 		}
 
-		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg)
+		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg.Period, cfg.Latency)
 
 		require.Equal(t, "2024-07-30T18:50:00Z", startTime.Format(time.RFC3339))
 		require.Equal(t, "2024-07-30T18:55:00Z", endTime.Format(time.RFC3339))
@@ -132,9 +134,88 @@ func TestCalculateTimespanWithLatency(t *testing.T) {
 			Latency: 1 * time.Minute,
 		}
 
-		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg)
+		startTime, endTime := calculateTimespan(referenceTime, timeGrain, cfg.Period, cfg.Latency)
 
 		require.Equal(t, "2024-07-30T18:54:00Z", startTime.Format(time.RFC3339))
 		require.Equal(t, "2024-07-30T18:55:00Z", endTime.Format(time.RFC3339))
 	})
 }
+
+func TestResourceLatency(t *testing.T) {
+	resourceLatency := 5 * time.Minute
+	cfg := Config{
+		Period:  5 * time.Minute,
+		Latency: 1 * time.Minute,
+		Resources: []ResourceConfig{
+			{Id: []string{"resource-1"}},
+			{Id: []string{"resource-2"}, Latency: &resourceLatency},
+		},
+	}
+
+	t.Run("resource config without latency falls back to the module latency", func(t *testing.T) {
+		require.Equal(t, 1*time.Minute, cfg.resourceLatency(cfg.Resources[0]))
+	})
+
+	t.Run("resource config with latency overrides the module latency", func(t *testing.T) {
+		require.Equal(t, 5*time.Minute, cfg.resourceLatency(cfg.Resources[1]))
+	})
+
+	t.Run("client uses the latency of the resource", func(t *testing.T) {
+		client := &BaseClient{Config: cfg}
+		client.addResource(Resource{Id: "resource-1", Latency: cfg.resourceLatency(cfg.Resources[0])})
+		client.addResource(Resource{Id: "resource-2", Latency: cfg.resourceLatency(cfg.Resources[1])})
+
+		referenceTime, _ := time.Parse(time.RFC3339, "2024-07-30T18:56:00Z")
+		startTime, endTime := calculateTimespan(referenceTime, "PT1M", cfg.Period, client.resourceLatency("resource-2"))
+
+		require.Equal(t, "2024-07-30T18:46:00Z", startTime.Format(time.RFC3339))
+		require.Equal(t, "2024-07-30T18:51:00Z", endTime.Format(time.RFC3339))
+		require.Equal(t, 1*time.Minute, client.resourceLatency("resource-1"))
+		require.Equal(t, 1*time.Minute, client.resourceLatency("unknown"))
+	})
+
+	t.Run("metric registry uses the timespan of the resource", func(t *testing.T) {
+		client := &BaseClient{Config: cfg, MetricRegistry: NewMetricRegistry(logptest.NewTestingLogger(t, ""))}
+		client.addResource(Resource{Id: "resource-2", Latency: cfg.resourceLatency(cfg.Resources[1])})
+		metric := Metric{ResourceId: "resource-2", Namespace: "test", TimeGrain: "PT5M"}
+
+		referenceTime, _ := time.Parse(time.RFC3339, "2024-07-30T18:56:00Z")
+		_, endTime := client.metricTimespan(referenceTime, metric)
+		require.Equal(t, "2024-07-30T18:51:00Z", endTime.Format(time.RFC3339))
+		client.MetricRegistry.Update(metric, MetricCollectionInfo{timeGrain: "PT5M", timestamp: endTime})
+
+		_, endTime = client.metricTimespan(referenceTime.Add(time.Minute), metric)
+		require.False(t, client.MetricRegistry.NeedsUpdate(endTime, metric))
+
+		_, endTime = client.metricTimespan(referenceTime.Add(5*time.Minute), metric)
+		require.True(t, client.MetricRegistry.NeedsUpdate(endTime, metric))
+	})
+}
+
+func TestSplitByDimension(t *testing.T) {
+	t.Run("split_by_dimension splits by all dimensions", func(t *testing.T) {
+		require.True(t, MetricConfig{SplitByDimension: true}.SplitsByDimension())
+	})
+
+	t.Run("a single wildcard dimension splits by all dimensions", func(t *testing.T) {
+		require.True(t, MetricConfig{Dimensions: []DimensionConfig{{Name: "*", Value: "*"}}}.SplitsByDimension())
+	})
+
+	t.Run("configured dimensions do not split by all dimensions", func(t *testing.T) {
+		require.False(t, MetricConfig{Dimensions: []DimensionConfig{{Name: "ApiName", Value: "*"}}}.SplitsByDimension())
+	})
+
+	t.Run("split_by_dimension cannot be used with dimensions", func(t *testing.T) {
+		cfg := Config{
+			AuthType:                AuthTypeManagedIdentity,
+			ResourceManagerEndpoint: DefaultBaseURI,
+			ActiveDirectoryEndpoint: "https://login.microsoftonline.com/",
+			Resources: []ResourceConfig{{Metrics: []MetricConfig{{
+				Name:             []string{"TotalRequests"},
+				SplitByDimension: true,
+				Dimensions:       []DimensionConfig{{Name: "ApiName", Value: "*"}},
+			}}}},
+		}
+		require.ErrorContains(t, cfg.Validate(), "split_by_dimension cannot be used together with dimensions")
+	})
+}
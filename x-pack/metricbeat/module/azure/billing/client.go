@@ -28,6 +28,7 @@ type Client struct {
 // Usage contains the usage details and forecast values.
 type Usage struct {
 	UsageDetails []armconsumption.UsageDetailClassification
+	FocusCosts   []FocusCost
 	Forecasts    armcostmanagement.QueryResult
 }
 
@@ -64,6 +65,46 @@ func (client *Client) GetMetrics(timeOpts TimeIntervalOptions) (Usage, error) {
 	// Fetch the usage details
 	//
 
+	if export := client.Config.BillingFocusExport; export != nil {
+		client.Log.
+			With("billing.focus_export.storage_account", export.StorageAccount).
+			With("billing.focus_export.container", export.Container).
+			With("billing.focus_export.path", export.Path).
+			With("billing.usage.start_time", timeOpts.usageStart).
+			With("billing.usage.end_time", timeOpts.usageEnd).
+			Infow("Getting usage details from FOCUS export")
+
+		focusCosts, err := client.BillingService.GetFocusCosts(*export, timeOpts.usageStart, timeOpts.usageEnd)
+		if err != nil {
+			return usage, fmt.Errorf("retrieving usage details from FOCUS export failed in client: %w", err)
+		}
+		usage.FocusCosts = focusCosts
+	} else if err := client.getUsageDetails(scope, timeOpts, &usage); err != nil {
+		return usage, err
+	}
+
+	//
+	// Fetch the Forecast
+	//
+
+	client.Log.
+		With("billing.scope", scope).
+		With("billing.forecast.start_time", timeOpts.forecastStart).
+		With("billing.forecast.end_time", timeOpts.forecastEnd).
+		Infow("Getting forecast for scope")
+
+	queryResult, err := client.BillingService.GetForecast(scope, timeOpts.forecastStart, timeOpts.forecastEnd)
+	if err != nil {
+		return usage, fmt.Errorf("retrieving forecast - forecast costs failed in client: %w", err)
+	}
+
+	usage.Forecasts = queryResult
+
+	return usage, nil
+}
+
+// getUsageDetails fetches the usage details from the consumption API.
+func (client *Client) getUsageDetails(scope string, timeOpts TimeIntervalOptions, usage *Usage) error {
 	client.Log.
 		With("billing.scope", scope).
 		With("billing.usage.start_time", timeOpts.usageStart).
@@ -85,27 +126,10 @@ func (client *Client) GetMetrics(timeOpts TimeIntervalOptions) (Usage, error) {
 		timeOpts.usageEnd.Format("2006-01-02"),   // endDate
 	)
 	if err != nil {
-		return usage, fmt.Errorf("retrieving usage details failed in client: %w", err)
+		return fmt.Errorf("retrieving usage details failed in client: %w", err)
 	}
 
 	usage.UsageDetails = append(usage.UsageDetails, result.Value...)
 
-	//
-	// Fetch the Forecast
-	//
-
-	client.Log.
-		With("billing.scope", scope).
-		With("billing.forecast.start_time", timeOpts.forecastStart).
-		With("billing.forecast.end_time", timeOpts.forecastEnd).
-		Infow("Getting forecast for scope")
-
-	queryResult, err := client.BillingService.GetForecast(scope, timeOpts.forecastStart, timeOpts.forecastEnd)
-	if err != nil {
-		return usage, fmt.Errorf("retrieving forecast - forecast costs failed in client: %w", err)
-	}
-
-	usage.Forecasts = queryResult
-
-	return usage, nil
+	return nil
 }
@@ -63,4 +63,18 @@ func TestClient(t *testing.T) {
 		// assert.Equal(t, len(results.ForecastCosts), 2)
 		m.AssertExpectations(t)
 	})
+	t.Run("return usage details from the FOCUS export", func(t *testing.T) {
+		client := NewMockClient()
+		export := azure.FocusExportConfig{StorageAccount: "account", Container: "exports", Path: "focus/daily"}
+		client.Config = azure.Config{BillingFocusExport: &export}
+		m := &MockService{}
+		m.On("GetForecast", mock.Anything, mock.Anything, mock.Anything).Return(armcostmanagement.QueryResult{}, nil)
+		m.On("GetFocusCosts", export, usageStart, usageEnd).Return([]FocusCost{{focusBilledCost: "1.5"}}, nil)
+		client.BillingService = m
+		usage, err := client.GetMetrics(opts)
+		assert.NoError(t, err)
+		assert.Len(t, usage.FocusCosts, 1)
+		assert.Empty(t, usage.UsageDetails)
+		m.AssertExpectations(t)
+	})
 }
@@ -132,6 +132,12 @@ func EventsMapping(
 		}
 	}
 
+	//
+	// FOCUS costs
+	//
+
+	events = append(events, getEventsFromFocusCosts(results.FocusCosts, timeOpts, logger)...)
+
 	//
 	// Forecasts
 	//
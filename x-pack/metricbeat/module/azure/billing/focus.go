@@ -0,0 +1,219 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package billing
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// FOCUS columns mapped to the billing fields.
+//
+// See https://focus.finops.org/focus-specification/ for the meaning of each
+// column, and the x_ prefixed columns for the Microsoft extensions.
+const (
+	focusBilledCost          = "BilledCost"
+	focusEffectiveCost       = "EffectiveCost"
+	focusListCost            = "ListCost"
+	focusBillingCurrency     = "BillingCurrency"
+	focusBillingAccountID    = "BillingAccountId"
+	focusBillingAccountName  = "BillingAccountName"
+	focusChargeCategory      = "ChargeCategory"
+	focusChargePeriodStart   = "ChargePeriodStart"
+	focusConsumedQuantity    = "ConsumedQuantity"
+	focusContractedUnitPrice = "ContractedUnitPrice"
+	focusRegionID            = "RegionId"
+	focusResourceID          = "ResourceId"
+	focusResourceName        = "ResourceName"
+	focusResourceType        = "ResourceType"
+	focusServiceCategory     = "ServiceCategory"
+	focusServiceName         = "ServiceName"
+	focusSubAccountID        = "SubAccountId"
+	focusSubAccountName      = "SubAccountName"
+	focusTags                = "Tags"
+	focusResourceGroupName   = "x_ResourceGroupName"
+)
+
+// FocusCost is a row of a cost export in the FinOps Open Cost and Usage
+// Specification (FOCUS) format, keyed by column name.
+type FocusCost map[string]string
+
+// focusExportBlob is a file of a FOCUS cost export.
+type focusExportBlob struct {
+	name         string
+	lastModified time.Time
+}
+
+// isFocusExportData returns true if the blob contains the cost data of an
+// export run, as opposed to the manifest of the run.
+func isFocusExportData(name string) bool {
+	return strings.HasSuffix(name, ".csv") || strings.HasSuffix(name, ".csv.gz")
+}
+
+// latestFocusExportRun returns the names of the data files of the most recent
+// export run. Each run of an export writes its files to a separate directory,
+// so the run is the directory of the most recently modified data file.
+func latestFocusExportRun(blobs []focusExportBlob) []string {
+	var latest focusExportBlob
+	for _, b := range blobs {
+		if isFocusExportData(b.name) && b.lastModified.After(latest.lastModified) {
+			latest = b
+		}
+	}
+	if latest.name == "" {
+		return nil
+	}
+
+	dir := path.Dir(latest.name)
+	var names []string
+	for _, b := range blobs {
+		if isFocusExportData(b.name) && path.Dir(b.name) == dir {
+			names = append(names, b.name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// readFocusCosts reads the rows of a FOCUS export in the CSV format and returns
+// the ones with a charge period starting between startTime and endTime.
+func readFocusCosts(r io.Reader, startTime, endTime time.Time) ([]FocusCost, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading FOCUS export header: %w", err)
+	}
+	// Exports are written with a byte order mark.
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	var costs []FocusCost
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return costs, fmt.Errorf("reading FOCUS export row: %w", err)
+		}
+
+		cost := make(FocusCost, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				cost[column] = record[i]
+			}
+		}
+
+		chargePeriodStart, err := parseFocusDate(cost[focusChargePeriodStart])
+		if err != nil || chargePeriodStart.Before(startTime) || chargePeriodStart.After(endTime) {
+			continue
+		}
+		costs = append(costs, cost)
+	}
+	return costs, nil
+}
+
+// parseFocusDate parses the date and time columns of a FOCUS export.
+func parseFocusDate(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported FOCUS date format: %q", value)
+}
+
+// getEventsFromFocusCosts maps the FOCUS cost rows to usage details events.
+func getEventsFromFocusCosts(costs []FocusCost, timeOpts TimeIntervalOptions, logger *logp.Logger) []mb.Event {
+	events := make([]mb.Event, 0, len(costs))
+	for _, cost := range costs {
+		event := mb.Event{
+			Timestamp: time.Now().UTC(),
+			RootFields: mapstr.M{
+				"cloud.provider": "azure",
+			},
+			ModuleFields: mapstr.M{},
+			MetricSetFields: mapstr.M{
+				"usage_start": timeOpts.usageStart,
+				"usage_end":   timeOpts.usageEnd,
+			},
+		}
+
+		if subscriptionID := cost[focusSubAccountID]; subscriptionID != "" {
+			// Sub account IDs are subscription resource IDs.
+			_, _ = event.ModuleFields.Put("subscription_id", getResourceNameFromPath(subscriptionID))
+		}
+		putFocusString(event.ModuleFields, "subscription_name", cost[focusSubAccountName])
+		putFocusString(event.ModuleFields, "resource.name", cost[focusResourceName])
+		putFocusString(event.ModuleFields, "resource.type", cost[focusResourceType])
+		putFocusString(event.ModuleFields, "resource.group", strings.ToLower(cost[focusResourceGroupName]))
+		if tags := cost[focusTags]; tags != "" && tags != "{}" {
+			var resourceTags map[string]string
+			if err := json.Unmarshal([]byte(tags), &resourceTags); err != nil {
+				logger.Warnf("unsupported FOCUS tags format: %v", err)
+			} else if len(resourceTags) > 0 {
+				_, _ = event.ModuleFields.Put("resource.tags", resourceTags)
+			}
+		}
+
+		putFocusString(event.MetricSetFields, "product", cost[focusServiceName])
+		putFocusString(event.MetricSetFields, "currency", cost[focusBillingCurrency])
+		putFocusString(event.MetricSetFields, "account_name", cost[focusBillingAccountName])
+		putFocusString(event.MetricSetFields, "account_id", cost[focusBillingAccountID])
+		putFocusString(event.MetricSetFields, "charge_category", cost[focusChargeCategory])
+		putFocusString(event.MetricSetFields, "service_category", cost[focusServiceCategory])
+		putFocusFloat(event.MetricSetFields, "pretax_cost", cost[focusBilledCost], logger)
+		putFocusFloat(event.MetricSetFields, "effective_cost", cost[focusEffectiveCost], logger)
+		putFocusFloat(event.MetricSetFields, "list_cost", cost[focusListCost], logger)
+		putFocusFloat(event.MetricSetFields, "unit_price", cost[focusContractedUnitPrice], logger)
+		putFocusFloat(event.MetricSetFields, "quantity", cost[focusConsumedQuantity], logger)
+		if usageDate, err := parseFocusDate(cost[focusChargePeriodStart]); err == nil {
+			_, _ = event.MetricSetFields.Put("usage_date", usageDate)
+		}
+
+		putFocusString(event.RootFields, "cloud.region", cost[focusRegionID])
+		putFocusString(event.RootFields, "cloud.instance.id", cost[focusResourceID])
+		putFocusString(event.RootFields, "cloud.instance.name", cost[focusResourceName])
+
+		events = append(events, event)
+	}
+	return events
+}
+
+func putFocusString(fields mapstr.M, key, value string) {
+	if value != "" {
+		_, _ = fields.Put(key, value)
+	}
+}
+
+func putFocusFloat(fields mapstr.M, key, value string, logger *logp.Logger) {
+	if value == "" {
+		return
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		logger.Warnf("unsupported FOCUS %s format: not a number: %q", key, value)
+		return
+	}
+	_, _ = fields.Put(key, f)
+}
@@ -0,0 +1,88 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package billing
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+const focusExport = "\ufeffBilledCost,BillingCurrency,ChargePeriodStart,ChargeCategory,EffectiveCost,ResourceId,ResourceName,ResourceType,RegionId,ServiceName,SubAccountId,SubAccountName,Tags,x_ResourceGroupName\n" +
+	`0.5,USD,2024-05-01T00:00:00Z,Usage,0.4,/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Compute/virtualMachines/vm1,vm1,Virtual machine,eastus,Virtual Machines,/subscriptions/sub1,Sub 1,"{""env"":""prod""}",RG1` + "\n" +
+	`2.0,USD,2024-05-02T00:00:00Z,Usage,2.0,/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Storage/storageAccounts/sa1,sa1,Storage account,eastus,Storage,/subscriptions/sub1,Sub 1,{},RG1` + "\n"
+
+func TestReadFocusCosts(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour).Add(-time.Second)
+
+	costs, err := readFocusCosts(strings.NewReader(focusExport), start, end)
+	require.NoError(t, err)
+	require.Len(t, costs, 1)
+	assert.Equal(t, "0.5", costs[0][focusBilledCost])
+	assert.Equal(t, "vm1", costs[0][focusResourceName])
+
+	costs, err = readFocusCosts(strings.NewReader(""), start, end)
+	require.NoError(t, err)
+	assert.Empty(t, costs)
+}
+
+func TestLatestFocusExportRun(t *testing.T) {
+	day1 := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	blobs := []focusExportBlob{
+		{name: "focus/daily/20240501-20240531/run1/part_0_0001.csv.gz", lastModified: day1},
+		{name: "focus/daily/20240501-20240531/run1/manifest.json", lastModified: day1},
+		{name: "focus/daily/20240501-20240531/run2/part_1_0001.csv.gz", lastModified: day2},
+		{name: "focus/daily/20240501-20240531/run2/part_0_0001.csv.gz", lastModified: day2.Add(-time.Minute)},
+		{name: "focus/daily/20240501-20240531/run2/manifest.json", lastModified: day2.Add(time.Minute)},
+	}
+
+	assert.Equal(t, []string{
+		"focus/daily/20240501-20240531/run2/part_0_0001.csv.gz",
+		"focus/daily/20240501-20240531/run2/part_1_0001.csv.gz",
+	}, latestFocusExportRun(blobs))
+	assert.Empty(t, latestFocusExportRun(blobs[1:2]))
+}
+
+func TestGetEventsFromFocusCosts(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour).Add(-time.Second)
+	costs, err := readFocusCosts(strings.NewReader(focusExport), start, end)
+	require.NoError(t, err)
+
+	events := getEventsFromFocusCosts(costs, TimeIntervalOptions{usageStart: start, usageEnd: end}, logptest.NewTestingLogger(t, ""))
+	require.Len(t, events, 1)
+	event := events[0]
+
+	assert.Equal(t, "sub1", event.ModuleFields["subscription_id"])
+	assert.Equal(t, "Sub 1", event.ModuleFields["subscription_name"])
+	resourceName, _ := event.ModuleFields.GetValue("resource.name")
+	assert.Equal(t, "vm1", resourceName)
+	resourceGroup, _ := event.ModuleFields.GetValue("resource.group")
+	assert.Equal(t, "rg1", resourceGroup)
+	resourceTags, _ := event.ModuleFields.GetValue("resource.tags")
+	assert.Equal(t, map[string]string{"env": "prod"}, resourceTags)
+
+	assert.Equal(t, 0.5, event.MetricSetFields["pretax_cost"])
+	assert.Equal(t, 0.4, event.MetricSetFields["effective_cost"])
+	assert.Equal(t, "USD", event.MetricSetFields["currency"])
+	assert.Equal(t, "Virtual Machines", event.MetricSetFields["product"])
+	assert.Equal(t, "Usage", event.MetricSetFields["charge_category"])
+	assert.Equal(t, start, event.MetricSetFields["usage_date"])
+	assert.NotContains(t, event.MetricSetFields, "list_cost")
+
+	region, _ := event.RootFields.GetValue("cloud.region")
+	assert.Equal(t, "eastus", region)
+	instanceID, _ := event.RootFields.GetValue("cloud.instance.id")
+	assert.Equal(t, "/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Compute/virtualMachines/vm1", instanceID)
+}
@@ -54,3 +54,13 @@ func (service *MockService) GetUsageDetails(
 	args := service.Called(scope, expand, filter, metricType, startDate, endDate)
 	return args.Get(0).(armconsumption.UsageDetailsListResult), args.Error(1)
 }
+
+// GetFocusCosts is a mock function for the billing service
+func (service *MockService) GetFocusCosts(
+	export azure.FocusExportConfig,
+	startTime,
+	endTime time.Time,
+) ([]FocusCost, error) {
+	args := service.Called(export, startTime, endTime)
+	return args.Get(0).([]FocusCost), args.Error(1)
+}
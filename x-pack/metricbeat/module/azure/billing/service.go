@@ -7,8 +7,12 @@
 package billing
 
 import (
+	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/elastic/beats/v7/x-pack/metricbeat/module/azure"
@@ -17,9 +21,9 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/consumption/armconsumption"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 )
 
 // Service offers access to Azure Usage Details and Forecast data.
@@ -37,12 +41,18 @@ type Service interface {
 		startDate string,
 		endDate string,
 	) (armconsumption.UsageDetailsListResult, error)
+	GetFocusCosts(
+		export azure.FocusExportConfig,
+		startTime,
+		endTime time.Time,
+	) ([]FocusCost, error)
 }
 
 // UsageService is a thin wrapper to the Usage Details API and the Forecast API from the Azure SDK for Go.
 type UsageService struct {
 	usageDetailsClient *armconsumption.UsageDetailsClient
 	forecastClient     *armcostmanagement.ForecastClient
+	blobClient         *azblob.Client
 	context            context.Context
 	log                *logp.Logger
 }
@@ -70,9 +80,7 @@ func NewService(config azure.Config) (*UsageService, error) {
 		},
 	}
 
-	credential, err := azidentity.NewClientSecretCredential(config.TenantId, config.ClientId, config.ClientSecret, &azidentity.ClientSecretCredentialOptions{
-		ClientOptions: clientOptions,
-	})
+	credential, err := config.NewCredential(clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't create client credentials: %w", err)
 	}
@@ -98,6 +106,17 @@ func NewService(config azure.Config) (*UsageService, error) {
 		log:                logp.NewLogger("azure billing service"),
 	}
 
+	if config.BillingFocusExport != nil {
+		service.blobClient, err = azblob.NewClient(
+			fmt.Sprintf("https://%s.blob.core.windows.net/", config.BillingFocusExport.StorageAccount),
+			credential,
+			&azblob.ClientOptions{ClientOptions: clientOptions},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create storage client: %w", err)
+		}
+	}
+
 	return &service, nil
 }
 
@@ -198,3 +217,69 @@ func (service *UsageService) GetUsageDetails(
 
 	return usageDetails, nil
 }
+
+// GetFocusCosts fetches the costs of the given time interval from the most recent run of a FOCUS cost export.
+func (service *UsageService) GetFocusCosts(
+	export azure.FocusExportConfig,
+	startTime,
+	endTime time.Time,
+) ([]FocusCost, error) {
+	if service.blobClient == nil {
+		return nil, errors.New("no storage client configured for the FOCUS export")
+	}
+
+	var blobs []focusExportBlob
+	pager := service.blobClient.NewListBlobsFlatPager(export.Container, &azblob.ListBlobsFlatOptions{
+		Prefix: &export.Path,
+	})
+	for pager.More() {
+		nextPage, err := pager.NextPage(service.context)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range nextPage.Segment.BlobItems {
+			if item.Name == nil || item.Properties == nil || item.Properties.LastModified == nil {
+				continue
+			}
+			blobs = append(blobs, focusExportBlob{name: *item.Name, lastModified: *item.Properties.LastModified})
+		}
+	}
+
+	names := latestFocusExportRun(blobs)
+	if len(names) == 0 {
+		service.log.Warnf("no FOCUS export data found in container %s with path %s", export.Container, export.Path)
+		return nil, nil
+	}
+
+	var costs []FocusCost
+	for _, name := range names {
+		blobCosts, err := service.readFocusExportBlob(export.Container, name, startTime, endTime)
+		if err != nil {
+			return nil, fmt.Errorf("reading FOCUS export file %s: %w", name, err)
+		}
+		costs = append(costs, blobCosts...)
+	}
+
+	return costs, nil
+}
+
+// readFocusExportBlob downloads a data file of a FOCUS export and returns its costs of the given time interval.
+func (service *UsageService) readFocusExportBlob(container, name string, startTime, endTime time.Time) ([]FocusCost, error) {
+	resp, err := service.blobClient.DownloadStream(service.context, container, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body io.Reader = resp.Body
+	if strings.HasSuffix(name, ".gz") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	return readFocusCosts(body, startTime, endTime)
+}
@@ -22,6 +22,8 @@ import (
 // MetricCollectionInfo contains information about the last time
 // a metric was collected and the time grain used.
 type MetricCollectionInfo struct {
+	// timestamp is the end time of the timespan the metric values were
+	// collected for.
 	timestamp time.Time
 	timeGrain string
 }
@@ -34,6 +36,12 @@ type BaseClient struct {
 	Log                 *logp.Logger
 	Resources           []Resource
 	MetricRegistry      *MetricRegistry
+	// resourceLatencies holds the latency of each resource in Resources,
+	// by resource ID.
+	resourceLatencies map[string]time.Duration
+	// inventoryFingerprint identifies the resources reported by the last
+	// resource inventory.
+	inventoryFingerprint uint64
 }
 
 // Client represents the azure client which will make use of the azure sdk go metrics related clients
@@ -82,7 +90,7 @@ func (client *Client) InitResources(fn mapResourceMetrics) error {
 
 	var metrics []Metric
 	//reset client resources
-	client.Resources = []Resource{}
+	client.resetResources()
 	for _, resource := range client.Config.Resources {
 		// retrieve azure resources information
 		resourceList, err := client.AzureMonitorService.GetResourceDefinitions(resource.Id, resource.Group, resource.Type, resource.Query)
@@ -90,26 +98,29 @@ func (client *Client) InitResources(fn mapResourceMetrics) error {
 			err = fmt.Errorf("failed to retrieve resources: %w", err)
 			return err
 		}
+		resourceList = filterResourcesByTags(resourceList, resource.Tags)
 
 		if len(resourceList) == 0 {
-			err = fmt.Errorf("failed to retrieve resources: No resources returned using the configuration options resource ID %s, resource group %s, resource type %s, resource query %s",
-				resource.Id, resource.Group, resource.Type, resource.Query)
+			err = fmt.Errorf("failed to retrieve resources: No resources returned using the configuration options resource ID %s, resource group %s, resource type %s, resource query %s, resource tags %v",
+				resource.Id, resource.Group, resource.Type, resource.Query, resource.Tags)
 			client.Log.Error(err)
 			continue
 		}
 
+		latency := client.Config.resourceLatency(resource)
+
 		// Map resources to the client
 		for _, resource := range resourceList {
-			if !containsResource(*resource.ID, client.Resources) {
-				client.Resources = append(client.Resources, Resource{
-					Id:           *resource.ID,
-					Name:         *resource.Name,
-					Location:     *resource.Location,
-					Type:         *resource.Type,
-					Group:        getResourceGroupFromId(*resource.ID),
-					Tags:         mapTags(resource.Tags),
-					Subscription: client.Config.SubscriptionId})
-			}
+			client.addResource(Resource{
+				Id:           *resource.ID,
+				Name:         *resource.Name,
+				Location:     *resource.Location,
+				Type:         *resource.Type,
+				Group:        getResourceGroupFromId(*resource.ID),
+				Tags:         mapTags(resource.Tags),
+				Subscription: client.Config.SubscriptionId,
+				Latency:      latency,
+			})
 		}
 
 		// Collects and stores metrics definitions for the cloud resources.
@@ -135,7 +146,7 @@ func (client *Client) GetMetricValues(referenceTime time.Time, metrics []Metric,
 	var result []Metric
 
 	for _, metric := range metrics {
-		startTime, endTime := calculateTimespan(referenceTime, metric.TimeGrain, client.Config)
+		startTime, endTime := client.metricTimespan(referenceTime, metric)
 		timespan := fmt.Sprintf("%s/%s", startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
 
 		//
@@ -157,7 +168,7 @@ func (client *Client) GetMetricValues(referenceTime time.Time, metrics []Metric,
 		// the time grain of the metric, we can determine if the metric needs
 		// to be collected again, or if we can skip it.
 		//
-		if !client.MetricRegistry.NeedsUpdate(referenceTime, metric) {
+		if !client.MetricRegistry.NeedsUpdate(endTime, metric) {
 			continue
 		}
 
@@ -197,7 +208,7 @@ func (client *Client) GetMetricValues(referenceTime time.Time, metrics []Metric,
 		// the API response.
 		client.MetricRegistry.Update(metric, MetricCollectionInfo{
 			timeGrain: timeGrain,
-			timestamp: referenceTime,
+			timestamp: endTime,
 		})
 
 		for i, currentMetric := range client.ResourceConfigurations.Metrics {
@@ -350,6 +361,43 @@ func (client *BaseClient) LookupResource(resourceId string) Resource {
 	return Resource{}
 }
 
+// resetResources removes all the resources of the client.
+func (client *BaseClient) resetResources() {
+	client.Resources = []Resource{}
+	client.resourceLatencies = map[string]time.Duration{}
+}
+
+// addResource adds the resource to the client, unless a resource with the
+// same ID was already added.
+func (client *BaseClient) addResource(resource Resource) {
+	if _, found := client.resourceLatencies[resource.Id]; found {
+		return
+	}
+	if client.resourceLatencies == nil {
+		client.resourceLatencies = map[string]time.Duration{}
+	}
+	client.Resources = append(client.Resources, resource)
+	client.resourceLatencies[resource.Id] = resource.Latency
+}
+
+// resourceLatency returns the latency to apply to the metric values of the
+// given resource, falling back to the module level latency when the resource
+// is unknown.
+func (client *BaseClient) resourceLatency(resourceId string) time.Duration {
+	if latency, found := client.resourceLatencies[resourceId]; found {
+		return latency
+	}
+	return client.Config.Latency
+}
+
+// metricTimespan returns the start and end times of the metric values to
+// collect for the metric at the reference time. The metric registry tracks
+// collections by the end time, so that the latency of the resource is taken
+// into account in the same way when checking if the metric needs an update.
+func (client *BaseClient) metricTimespan(referenceTime time.Time, metric Metric) (time.Time, time.Time) {
+	return calculateTimespan(referenceTime, metric.TimeGrain, client.Config.Period, client.resourceLatency(metric.ResourceId))
+}
+
 // AddVmToResource will add the vm details to the resource
 func (client *BaseClient) AddVmToResource(resourceId string, vm VmResource) {
 	if len(vm.Id) > 0 && len(vm.Name) > 0 {
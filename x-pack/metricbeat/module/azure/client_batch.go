@@ -7,11 +7,15 @@
 package azure
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
@@ -24,6 +28,10 @@ import (
 type BatchClient struct {
 	*BaseClient
 	ResourceConfigurations ConcurrentResourceConfig
+	// batchUnavailable contains the locations whose regional batch endpoint is
+	// not available. Metric values of resources in these locations are
+	// collected with one request per resource instead.
+	batchUnavailable map[string]bool
 }
 
 // Resource definitions grouping criteria
@@ -35,6 +43,7 @@ type ResDefGroupingCriteria struct {
 	Aggregations   string
 	TimeGrain      string
 	Dimensions     string
+	Latency        time.Duration
 }
 
 // concurrentMapResourceMetrics function type will map the configuration options to Batch Client metrics (depending on the metricset)
@@ -97,7 +106,7 @@ func (client *BatchClient) InitResources(fn concurrentMapResourceMetrics) error
 	// Initialize a WaitGroup to track all goroutines
 	var wg sync.WaitGroup
 	//reset client resources
-	client.Resources = []Resource{}
+	client.resetResources()
 	for _, resourceConfig := range client.Config.Resources {
 		// retrieve azure resources information
 		resourceList, err := client.AzureMonitorService.GetResourceDefinitions(resourceConfig.Id, resourceConfig.Group, resourceConfig.Type, resourceConfig.Query)
@@ -105,10 +114,11 @@ func (client *BatchClient) InitResources(fn concurrentMapResourceMetrics) error
 			err = fmt.Errorf("failed to retrieve resources: %w", err)
 			return err
 		}
+		resourceList = filterResourcesByTags(resourceList, resourceConfig.Tags)
 
 		if len(resourceList) == 0 {
-			err = fmt.Errorf("failed to retrieve resources: No resources returned using the configuration options resource ID %s, resource group %s, resource type %s, resource query %s",
-				resourceConfig.Id, resourceConfig.Group, resourceConfig.Type, resourceConfig.Query)
+			err = fmt.Errorf("failed to retrieve resources: No resources returned using the configuration options resource ID %s, resource group %s, resource type %s, resource query %s, resource tags %v",
+				resourceConfig.Id, resourceConfig.Group, resourceConfig.Type, resourceConfig.Query, resourceConfig.Tags)
 			client.Log.Error(err)
 			continue
 		}
@@ -118,18 +128,20 @@ func (client *BatchClient) InitResources(fn concurrentMapResourceMetrics) error
 			client.ResourceConfigurations.ErrorChan = make(chan error, 1)
 		}
 
+		latency := client.Config.resourceLatency(resourceConfig)
+
 		// Map resources to the client
 		for _, resource := range resourceList {
-			if !containsResource(*resource.ID, client.Resources) {
-				client.Resources = append(client.Resources, Resource{
-					Id:           *resource.ID,
-					Name:         *resource.Name,
-					Location:     *resource.Location,
-					Type:         *resource.Type,
-					Group:        getResourceGroupFromId(*resource.ID),
-					Tags:         mapTags(resource.Tags),
-					Subscription: client.Config.SubscriptionId})
-			}
+			client.addResource(Resource{
+				Id:           *resource.ID,
+				Name:         *resource.Name,
+				Location:     *resource.Location,
+				Type:         *resource.Type,
+				Group:        getResourceGroupFromId(*resource.ID),
+				Tags:         mapTags(resource.Tags),
+				Subscription: client.Config.SubscriptionId,
+				Latency:      latency,
+			})
 		}
 
 		// Collects and stores metrics definitions for the cloud resources.
@@ -152,7 +164,7 @@ func (client *BatchClient) GetMetricsInBatch(groupedMetrics map[ResDefGroupingCr
 	var result []Metric
 	for criteria, metricsDefinitions := range groupedMetrics {
 		// Same end time for all metrics in the same batch.
-		startTime, endTime := calculateTimespan(referenceTime, criteria.TimeGrain, client.Config)
+		startTime, endTime := calculateTimespan(referenceTime, criteria.TimeGrain, client.Config.Period, criteria.Latency)
 
 		// Limit batch size to 50 resources (if you have more, you can split the batch)
 		filter := ""
@@ -174,6 +186,10 @@ func (client *BatchClient) GetMetricsInBatch(groupedMetrics map[ResDefGroupingCr
 
 			// Slice the Metric Names by batches of 20 due to batch api limitation
 			names := strings.Split(criteria.Names, ",")
+			if client.batchUnavailable[criteria.Location] {
+				result = append(result, client.getMetricValuesPerResource(batchMetrics, names, startTime, endTime, filter, referenceTime, reporter)...)
+				continue
+			}
 			for j := 0; j < len(names); j += metricNameLimit {
 				endMetric := j + metricNameLimit
 				if endMetric > len(names) {
@@ -193,6 +209,12 @@ func (client *BatchClient) GetMetricsInBatch(groupedMetrics map[ResDefGroupingCr
 					filter,
 					criteria.Location,
 				)
+				if err != nil && isBatchEndpointUnavailable(err) {
+					client.Log.Warnf("batch API endpoint is not available in location %s, falling back to collecting the metric values of each resource: %v", criteria.Location, err)
+					client.markBatchUnavailable(criteria.Location)
+					result = append(result, client.getMetricValuesPerResource(batchMetrics, names[j:], startTime, endTime, filter, referenceTime, reporter)...)
+					break
+				}
 				if err != nil {
 					err = fmt.Errorf("error while listing metric values by resource ID %s and namespace  %s: %w", metricsDefinitions[0].ResourceSubId, metricsDefinitions[0].Namespace, err)
 					client.Log.Error(err)
@@ -204,7 +226,7 @@ func (client *BatchClient) GetMetricsInBatch(groupedMetrics map[ResDefGroupingCr
 				for i, v := range response {
 					client.MetricRegistry.Update(metricsDefinitions[i], MetricCollectionInfo{
 						timeGrain: *response[i].Interval,
-						timestamp: referenceTime,
+						timestamp: endTime,
 					})
 					values := mapBatchMetricValues(client, v)
 					metricsDefinitions[i].Values = append(metricsDefinitions[i].Values, values...)
@@ -223,6 +245,64 @@ func (client *BatchClient) GetMetricsInBatch(groupedMetrics map[ResDefGroupingCr
 	return result
 }
 
+// getMetricValuesPerResource collects the metric values of each metric with a
+// separate request to the metrics API of its resource. It is used when the batch
+// API endpoint is not available in the location of the resources.
+func (client *BatchClient) getMetricValuesPerResource(metrics []Metric, names []string, startTime, endTime time.Time, filter string, referenceTime time.Time, reporter mb.ReporterV2) []Metric {
+	var result []Metric
+	timespan := fmt.Sprintf("%s/%s", startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
+	for i := range metrics {
+		resp, timeGrain, err := client.AzureMonitorService.GetMetricValues(
+			metrics[i].ResourceSubId,
+			metrics[i].Namespace,
+			metrics[i].TimeGrain,
+			timespan,
+			names,
+			metrics[i].Aggregations,
+			filter,
+		)
+		if err != nil {
+			err = fmt.Errorf("error while listing metric values by resource ID %s and namespace  %s: %w", metrics[i].ResourceSubId, metrics[i].Namespace, err)
+			client.Log.Error(err)
+			reporter.Error(err)
+			continue
+		}
+
+		client.MetricRegistry.Update(metrics[i], MetricCollectionInfo{
+			timeGrain: timeGrain,
+			timestamp: endTime,
+		})
+		metrics[i].Values = append(metrics[i].Values, mapMetricValues(resp, nil)...)
+		if metrics[i].TimeGrain == "" {
+			metrics[i].TimeGrain = timeGrain
+		}
+		result = append(result, metrics[i])
+	}
+	return result
+}
+
+// markBatchUnavailable records that the batch API endpoint of the location is not available.
+func (client *BatchClient) markBatchUnavailable(location string) {
+	if client.batchUnavailable == nil {
+		client.batchUnavailable = make(map[string]bool)
+	}
+	client.batchUnavailable[location] = true
+}
+
+// isBatchEndpointUnavailable returns true if the error shows that the regional
+// batch API endpoint does not exist, as opposed to a failure of the request itself.
+func isBatchEndpointUnavailable(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsNotFound
+	}
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
 // GroupAndStoreMetrics groups received metricsDefinitions and stores them in a in memory store
 func (client *BatchClient) GroupAndStoreMetrics(metricsDefinitions []Metric, referenceTime time.Time, store map[ResDefGroupingCriteria]*MetricStore) {
 	for _, metric := range metricsDefinitions {
@@ -234,6 +314,7 @@ func (client *BatchClient) GroupAndStoreMetrics(metricsDefinitions []Metric, ref
 			Names:          strings.Join(metric.Names, ","),
 			TimeGrain:      metric.TimeGrain,
 			Dimensions:     getDimensionKey(metric.Dimensions),
+			Latency:        client.resourceLatency(metric.ResourceId),
 		}
 
 		//
@@ -255,7 +336,8 @@ func (client *BatchClient) GroupAndStoreMetrics(metricsDefinitions []Metric, ref
 		// the time grain of the metric, we can determine if the metric needs
 		// to be collected again, or if we can skip it.
 		//
-		if !client.MetricRegistry.NeedsUpdate(referenceTime, metric) {
+		_, endTime := client.metricTimespan(referenceTime, metric)
+		if !client.MetricRegistry.NeedsUpdate(endTime, metric) {
 			continue
 		}
 		if _, exists := store[criteria]; !exists {
@@ -8,13 +8,18 @@ package azure
 
 import (
 	"errors"
+	"fmt"
+	"net"
+	"net/http"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/monitor/query/azmetrics"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -174,4 +179,68 @@ func TestGetMetricsInBatch(t *testing.T) {
 
 		m.AssertExpectations(t)
 	})
+
+	t.Run("fall back to one request per resource when the batch endpoint is not available", func(t *testing.T) {
+		client := NewMockBatchClient()
+		criteria := ResDefGroupingCriteria{
+			Namespace:      "Microsoft.EventHub/Namespaces",
+			SubscriptionID: "subscription",
+			Location:       "westeurope",
+			Names:          "ActiveConnections",
+			TimeGrain:      "PT1M",
+		}
+		metricsDef := []Metric{
+			{
+				ResourceId:    "resourceId1",
+				ResourceSubId: "resourceId1",
+				Namespace:     "Microsoft.EventHub/Namespaces",
+				Names:         []string{"ActiveConnections"},
+				Aggregations:  "Average",
+				TimeGrain:     "PT1M",
+			},
+		}
+		groupedMetrics := map[ResDefGroupingCriteria][]Metric{
+			criteria: metricsDef,
+		}
+		referenceTime := time.Now().UTC()
+
+		m := &MockService{}
+		m.On("QueryResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Once().
+			Return([]azmetrics.MetricData{}, &net.DNSError{Err: "no such host", Name: "westeurope.metrics.monitor.azure.com", IsNotFound: true})
+		m.On("GetMetricValues", "resourceId1", "Microsoft.EventHub/Namespaces", "PT1M", mock.Anything, []string{"ActiveConnections"}, "Average", "").Twice().Return(
+			[]armmonitor.Metric{{
+				Name: &armmonitor.LocalizableString{Value: to.Ptr("ActiveConnections")},
+				Timeseries: []*armmonitor.TimeSeriesElement{{
+					Data: []*armmonitor.MetricValue{{
+						Average:   to.Ptr(1.0),
+						TimeStamp: to.Ptr(referenceTime),
+					}},
+				}},
+			}},
+			"PT1M",
+			nil,
+		)
+		client.AzureMonitorService = m
+		mr := MockReporterV2{}
+
+		metricValues := client.GetMetricsInBatch(groupedMetrics, referenceTime, &mr)
+		require.Equal(t, len(metricValues), 1)
+		require.Equal(t, len(metricValues[0].Values), 1)
+		assert.Equal(t, *metricValues[0].Values[0].avg, 1.0)
+		assert.True(t, client.batchUnavailable["westeurope"])
+
+		// The batch endpoint is not tried again for the location.
+		metricValues = client.GetMetricsInBatch(groupedMetrics, referenceTime.Add(time.Minute), &mr)
+		require.Equal(t, len(metricValues), 1)
+
+		m.AssertExpectations(t)
+	})
+}
+
+func TestIsBatchEndpointUnavailable(t *testing.T) {
+	assert.True(t, isBatchEndpointUnavailable(fmt.Errorf("query failed: %w", &net.DNSError{Err: "no such host", IsNotFound: true})))
+	assert.True(t, isBatchEndpointUnavailable(&azcore.ResponseError{StatusCode: http.StatusNotFound}))
+	assert.False(t, isBatchEndpointUnavailable(&azcore.ResponseError{StatusCode: http.StatusTooManyRequests}))
+	assert.False(t, isBatchEndpointUnavailable(&net.DNSError{Err: "server misbehaving", IsTemporary: true}))
+	assert.False(t, isBatchEndpointUnavailable(errors.New("invalid parameters or no metrics found")))
 }
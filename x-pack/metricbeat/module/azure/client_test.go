@@ -65,6 +65,46 @@ func TestInitResources(t *testing.T) {
 		assert.Equal(t, len(client.ResourceConfigurations.Metrics), 0)
 		m.AssertExpectations(t)
 	})
+	t.Run("only keep resources matching the configured tags", func(t *testing.T) {
+		client := NewMockClient()
+		client.Config = Config{
+			Resources: []ResourceConfig{
+				{
+					Group: []string{"group"},
+					Tags:  map[string]string{"env": "prod"},
+				},
+			},
+		}
+		m := &MockService{}
+		m.On("GetResourceDefinitions", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*armresources.GenericResourceExpanded{
+			{
+				ID:       to.Ptr("/subscriptions/123/resourceGroups/group/providers/Microsoft.Compute/virtualMachines/vm1"),
+				Name:     to.Ptr("vm1"),
+				Location: to.Ptr("westeurope"),
+				Type:     to.Ptr("Microsoft.Compute/virtualMachines"),
+				Tags:     map[string]*string{"Env": to.Ptr("prod")},
+			},
+			{
+				ID:       to.Ptr("/subscriptions/123/resourceGroups/group/providers/Microsoft.Compute/virtualMachines/vm2"),
+				Name:     to.Ptr("vm2"),
+				Location: to.Ptr("westeurope"),
+				Type:     to.Ptr("Microsoft.Compute/virtualMachines"),
+				Tags:     map[string]*string{"env": to.Ptr("dev")},
+			},
+		}, nil)
+		client.AzureMonitorService = m
+
+		var mapped []*armresources.GenericResourceExpanded
+		err := client.InitResources(func(_ *Client, resources []*armresources.GenericResourceExpanded, _ ResourceConfig) ([]Metric, error) {
+			mapped = resources
+			return nil, nil
+		})
+		require.NoError(t, err)
+		require.Len(t, client.Resources, 1)
+		assert.Equal(t, "vm1", client.Resources[0].Name)
+		require.Len(t, mapped, 1)
+		assert.Equal(t, "vm1", *mapped[0].Name)
+	})
 }
 
 func TestGetMetricValues(t *testing.T) {
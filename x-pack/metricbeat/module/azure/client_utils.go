@@ -17,6 +17,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/sdk/monitor/query/azmetrics"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 )
 
 // DefaultTimeGrain is set as default timegrain for the azure metrics
@@ -121,6 +122,39 @@ func mapTags(azureTags map[string]*string) map[string]string {
 	return tags
 }
 
+// filterResourcesByTags returns the resources that have all the given tags.
+// Tag names are matched case-insensitively, like Azure does, and tag values
+// are matched exactly.
+func filterResourcesByTags(resources []*armresources.GenericResourceExpanded, tags map[string]string) []*armresources.GenericResourceExpanded {
+	if len(tags) == 0 {
+		return resources
+	}
+	var filtered []*armresources.GenericResourceExpanded
+	for _, resource := range resources {
+		if hasTags(resource.Tags, tags) {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered
+}
+
+// hasTags reports whether azureTags contains all the given tags.
+func hasTags(azureTags map[string]*string, tags map[string]string) bool {
+	for name, value := range tags {
+		var found bool
+		for azureName, azureValue := range azureTags {
+			if strings.EqualFold(azureName, name) && azureValue != nil && *azureValue == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 // compareMetricValues will compare 2 pointer values
 func compareMetricValues(metVal *float64, metricVal *float64) bool {
 	if metVal == nil && metricVal == nil {
@@ -10,7 +10,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/stretchr/testify/assert"
 )
@@ -162,6 +164,28 @@ func TestContainsResource(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestFilterResourcesByTags(t *testing.T) {
+	resources := []*armresources.GenericResourceExpanded{
+		{Name: to.Ptr("prod-web"), Tags: map[string]*string{"env": to.Ptr("prod"), "team": to.Ptr("web")}},
+		{Name: to.Ptr("prod-db"), Tags: map[string]*string{"ENV": to.Ptr("prod"), "team": to.Ptr("db")}},
+		{Name: to.Ptr("dev-web"), Tags: map[string]*string{"env": to.Ptr("dev"), "team": to.Ptr("web")}},
+		{Name: to.Ptr("untagged")},
+	}
+	names := func(resources []*armresources.GenericResourceExpanded) []string {
+		var names []string
+		for _, r := range resources {
+			names = append(names, *r.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"prod-web", "prod-db", "dev-web", "untagged"}, names(filterResourcesByTags(resources, nil)))
+	assert.Equal(t, []string{"prod-web", "prod-db"}, names(filterResourcesByTags(resources, map[string]string{"env": "prod"})))
+	assert.Equal(t, []string{"prod-web"}, names(filterResourcesByTags(resources, map[string]string{"env": "prod", "team": "web"})))
+	// tag values are case-sensitive
+	assert.Empty(t, filterResourcesByTags(resources, map[string]string{"env": "PROD"}))
+}
+
 func TestGetVM(t *testing.T) {
 	vmName := "resource name1"
 	vmResourceList := []VmResource{
@@ -8,6 +8,7 @@ package azure
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -16,6 +17,8 @@ import (
 const (
 	// DefaultBaseURI is the default URI used for the service Insights
 	DefaultBaseURI = "https://management.azure.com/"
+	// DefaultMetricDefinitionsCacheTTL is how long the persisted metric definitions are reused by default
+	DefaultMetricDefinitionsCacheTTL = 24 * time.Hour
 )
 
 var (
@@ -30,9 +33,11 @@ var (
 // Config options
 type Config struct {
 	// shared config options
-	ClientId       string        `config:"client_id"  validate:"required"`
-	ClientSecret   string        `config:"client_secret"  validate:"required"`
-	TenantId       string        `config:"tenant_id"  validate:"required"`
+	// AuthType is the authentication method, client_secret (default), managed_identity, workload_identity or default
+	AuthType       string        `config:"auth_type"`
+	ClientId       string        `config:"client_id"`
+	ClientSecret   string        `config:"client_secret"`
+	TenantId       string        `config:"tenant_id"`
 	SubscriptionId string        `config:"subscription_id"  validate:"required"`
 	Period         time.Duration `config:"period" validate:"nonzero,required"`
 	// Latency is the time it takes for the Azure service to publish the metric values.
@@ -49,8 +54,14 @@ type Config struct {
 	// specific to billing
 	BillingScopeDepartment string `config:"billing_scope_department"` // retrieve usage details from department scope
 	BillingScopeAccountId  string `config:"billing_scope_account_id"` // retrieve usage details from billing account ID scope
+	// retrieve usage details from a FOCUS cost export instead of the consumption API
+	BillingFocusExport *FocusExportConfig `config:"billing_focus_export"`
 	// Use BatchApi for metric values collection
-	EnableBatchApi bool `config:"enable_batch_api"` // defaults to false
+	EnableBatchApi bool `config:"enable_batch_api"` // defaults to true
+	// Publish an inventory event for each discovered resource when the resource list changes
+	EmitResourceInventory bool `config:"emit_resource_inventory"` // defaults to false
+	// How long the persisted metric definitions of a resource type are reused, 0 disables the cache
+	MetricDefinitionsCacheTTL time.Duration `config:"metric_definitions_cache_ttl" validate:"min=0"` // defaults to 24h
 }
 
 // ResourceConfig contains resource and metric list specific configuration.
@@ -61,6 +72,12 @@ type ResourceConfig struct {
 	Type        string         `config:"resource_type"`
 	Query       string         `config:"resource_query"`
 	ServiceType []string       `config:"service_type"`
+	// Tags restricts the resources to the ones that have all the given
+	// ARM resource tags.
+	Tags map[string]string `config:"tags"`
+	// Latency overrides the module level latency for the resources
+	// matched by this configuration.
+	Latency *time.Duration `config:"latency"`
 }
 
 // MetricConfig contains metric specific configuration.
@@ -70,18 +87,47 @@ type MetricConfig struct {
 	Aggregations []string          `config:"aggregations"`
 	Dimensions   []DimensionConfig `config:"dimensions"`
 	Timegrain    string            `config:"timegrain"`
+	// SplitByDimension splits the metrics by every dimension they support, so that one time series
+	// is returned for each dimension value without having to enumerate the dimensions.
+	// Setting dimensions to a single dimension named "*" has the same effect.
+	SplitByDimension bool `config:"split_by_dimension"`
 	// namespaces can be unsupported by some resources and supported in some, this configuration option makes sure no error messages are returned if namespace is unsupported
 	// info messages will be logged instead. Same situation with metrics, some are being removed from the API, we would like to make sure that does not affect the module
 	IgnoreUnsupported bool `config:"ignore_unsupported"`
 }
 
+// FocusExportConfig contains the location of a Cost Management export in the FOCUS format.
+type FocusExportConfig struct {
+	StorageAccount string `config:"storage_account" validate:"required"`
+	Container      string `config:"container" validate:"required"`
+	// Path is the directory of the export in the container, the root folder path and the export name.
+	Path string `config:"path"`
+}
+
 // DimensionConfig contains dimensions specific configuration.
 type DimensionConfig struct {
 	Name  string `config:"name"`
 	Value string `config:"value"`
 }
 
+// SplitsByDimension returns true if the metrics are split by all the dimensions they support.
+func (m MetricConfig) SplitsByDimension() bool {
+	return m.SplitByDimension || (len(m.Dimensions) == 1 && m.Dimensions[0].Name == "*")
+}
+
+// resourceLatency returns the latency configured for the given resource
+// configuration, or the module level latency if it is not set.
+func (conf *Config) resourceLatency(resourceConfig ResourceConfig) time.Duration {
+	if resourceConfig.Latency != nil {
+		return *resourceConfig.Latency
+	}
+	return conf.Latency
+}
+
 func (conf *Config) Validate() error {
+	if err := conf.validateAuth(); err != nil {
+		return err
+	}
 	if conf.ResourceManagerEndpoint == "" {
 		conf.ResourceManagerEndpoint = DefaultBaseURI
 	}
@@ -101,5 +147,15 @@ func (conf *Config) Validate() error {
 			return fmt.Errorf("no active directory endpoint has been configured")
 		}
 	}
+	for _, resource := range conf.Resources {
+		if resource.Latency != nil && *resource.Latency < 0 {
+			return fmt.Errorf("resource latency must be positive, got %s", *resource.Latency)
+		}
+		for _, metric := range resource.Metrics {
+			if metric.SplitByDimension && len(metric.Dimensions) > 0 {
+				return fmt.Errorf("split_by_dimension cannot be used together with dimensions for the metrics %s", strings.Join(metric.Name, ", "))
+			}
+		}
+	}
 	return nil
 }
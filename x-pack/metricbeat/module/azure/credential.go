@@ -0,0 +1,74 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package azure
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+const (
+	// AuthTypeClientSecret authenticates with the client secret of a service principal.
+	AuthTypeClientSecret = "client_secret"
+	// AuthTypeManagedIdentity authenticates with the system-assigned managed identity,
+	// or the user-assigned managed identity with the configured client ID.
+	AuthTypeManagedIdentity = "managed_identity"
+	// AuthTypeWorkloadIdentity authenticates with an AKS workload identity, configured
+	// with the environment variables set by the workload identity webhook.
+	AuthTypeWorkloadIdentity = "workload_identity"
+	// AuthTypeDefault authenticates with the first of the environment, workload identity,
+	// managed identity and Azure CLI credentials that is available.
+	AuthTypeDefault = "default"
+)
+
+// validateAuth checks that the options required by the authentication type are set.
+func (conf *Config) validateAuth() error {
+	switch conf.AuthType {
+	case "", AuthTypeClientSecret:
+		if conf.ClientId == "" || conf.ClientSecret == "" || conf.TenantId == "" {
+			return fmt.Errorf("client_id, client_secret and tenant_id are required when auth_type is %s", AuthTypeClientSecret)
+		}
+	case AuthTypeManagedIdentity, AuthTypeWorkloadIdentity, AuthTypeDefault:
+	default:
+		return fmt.Errorf("unsupported auth_type %q, must be one of %s, %s, %s or %s",
+			conf.AuthType, AuthTypeClientSecret, AuthTypeManagedIdentity, AuthTypeWorkloadIdentity, AuthTypeDefault)
+	}
+	return nil
+}
+
+// NewCredential returns the credential the clients authenticate with, depending on the auth type.
+func (conf *Config) NewCredential(clientOptions policy.ClientOptions) (azcore.TokenCredential, error) {
+	switch conf.AuthType {
+	case AuthTypeManagedIdentity:
+		opts := &azidentity.ManagedIdentityCredentialOptions{ClientOptions: clientOptions}
+		if conf.ClientId != "" {
+			opts.ID = azidentity.ClientID(conf.ClientId)
+		}
+		return azidentity.NewManagedIdentityCredential(opts)
+	case AuthTypeWorkloadIdentity:
+		// Unset options are read from the AZURE_CLIENT_ID, AZURE_TENANT_ID
+		// and AZURE_FEDERATED_TOKEN_FILE environment variables.
+		return azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+			ClientOptions: clientOptions,
+			ClientID:      conf.ClientId,
+			TenantID:      conf.TenantId,
+		})
+	case AuthTypeDefault:
+		return azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+			ClientOptions: clientOptions,
+			TenantID:      conf.TenantId,
+		})
+	default:
+		return azidentity.NewClientSecretCredential(conf.TenantId, conf.ClientId, conf.ClientSecret,
+			&azidentity.ClientSecretCredentialOptions{
+				ClientOptions: clientOptions,
+			})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package azure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAuth(t *testing.T) {
+	cases := []struct {
+		name    string
+		config  Config
+		wantErr string
+	}{
+		{
+			name:   "client secret",
+			config: Config{ClientId: "client", ClientSecret: "secret", TenantId: "tenant"},
+		},
+		{
+			name:    "client secret without secret",
+			config:  Config{AuthType: AuthTypeClientSecret, ClientId: "client", TenantId: "tenant"},
+			wantErr: "client_id, client_secret and tenant_id are required when auth_type is client_secret",
+		},
+		{
+			name:   "system-assigned managed identity",
+			config: Config{AuthType: AuthTypeManagedIdentity},
+		},
+		{
+			name:   "user-assigned managed identity",
+			config: Config{AuthType: AuthTypeManagedIdentity, ClientId: "client"},
+		},
+		{
+			name:   "workload identity",
+			config: Config{AuthType: AuthTypeWorkloadIdentity},
+		},
+		{
+			name:   "default",
+			config: Config{AuthType: AuthTypeDefault},
+		},
+		{
+			name:    "unsupported",
+			config:  Config{AuthType: "certificate"},
+			wantErr: `unsupported auth_type "certificate"`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.config.validateAuth()
+			if c.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, c.wantErr)
+		})
+	}
+}
+
+func TestNewCredential(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token"), 0o600))
+	t.Setenv("AZURE_FEDERATED_TOKEN_FILE", tokenFile)
+
+	cases := []struct {
+		config Config
+		want   interface{}
+	}{
+		{
+			config: Config{ClientId: "client", ClientSecret: "secret", TenantId: "tenant"},
+			want:   &azidentity.ClientSecretCredential{},
+		},
+		{
+			config: Config{AuthType: AuthTypeManagedIdentity, ClientId: "client"},
+			want:   &azidentity.ManagedIdentityCredential{},
+		},
+		{
+			config: Config{AuthType: AuthTypeWorkloadIdentity, ClientId: "client", TenantId: "tenant"},
+			want:   &azidentity.WorkloadIdentityCredential{},
+		},
+		{
+			config: Config{AuthType: AuthTypeDefault, TenantId: "tenant"},
+			want:   &azidentity.DefaultAzureCredential{},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.config.AuthType, func(t *testing.T) {
+			credential, err := c.config.NewCredential(policy.ClientOptions{})
+			require.NoError(t, err)
+			assert.IsType(t, c.want, credential)
+		})
+	}
+}
@@ -19,5 +19,5 @@ func init() {
 // AssetAzure returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/azure.
 func AssetAzure() string {
-	return "eJzkmc1u4zYQx+95ikFO7QLrB8ihQLbdwx62XfTjLNDUWGZXIrXDoTfu0xckRVmWZPlLdhM0hwCRxPn/hvxzJE7ew1fcPoH4xxE+ALDiEp/g8dn//fgAkKOVpGpWRj/BTw8AEJ+FyuSu9EMISxQWn6AQDwArhWVun8KD70GLCnfB/Q9va/8oGVc3V7ojuqNYVViQULq9k0Z/xe13Q3nn+ghl+vlzjfAciZFJyZG4SZHQGkcSB4Jd3BPkUhywNUq1UthF7ae7l/K2xr0bhzM+gpFQ/HAwK+AO1qi0/z2vtI94knR/dmfQbldgGLuda1HYxbtRWbP8GyX3bsWL2RRY55GsEnWtdNE8//ju8bwkomPbNALswK/+t63FiGHP3iFtKLBYouSOZZOadcs2RKby6zW7AeHTL9OCPX/OIbkXsq1VdV0qKWZLshNvLMdcVaitMnrfiwd8eMSDp/pvAnmvUnbgBuDxEbt4dzb3qjTiwM1LqT9HGCBkRxrzIa6o60xpq4o126PlvX2jLZHFaQTdZR7RmSr6lgVxlgser78jN04ogCHocGzSRJ3PrYg6P6w37hY4o+L2XQOz1lufQMM4ah3L+5ndxDd9kf+7aQi/ObRsM2mc5oV11ajymDOOSP8eI0MIPK29EqrE/BbiMfIEg7NIKXmn1Tc3PvMXIPxlkSaELdpQ9W+i/UcMfjRv4XiNmv3mwHxmhudu7Kg3wbMk890iZawqpQubaeTvhr5muaOwcRdiU8xF9iFqQaMFjRYkrZMAbdipd6CzYf+eg0YoUW0wuwNcI3UeX01GeoPq4i4TuJM7D5MNi/IuhEFpGg5fJIZ4N6jUH1Psif3Z0W/m6YYEIDitBpS4wfIYkkXa3JwoikwA1UgrQ5XQEuMi+RJbYWVom4mNUKVYlpgtt4x2Tjd92clCkoUoC60sBNnTsZtNk9WkNoLvRd2oQqN6KbSsXVYjSdQsCowb+W7ssnawE497+9oU/iP4s7GVCSvmyTOL0uj8buSffotu8XwQtQenjKUqS6WLW5wxmtAgtP/W8QufIwtVnnpAlY4ItdyOztVFLboPDdHPTeTF+GoSsnjJpLH9xbh4mUJHpgpV06zAR4YlrgwhsHgZp3BasS81cr6vb60YvviIoGxoj4bw6SzoKyIb2Bq3gB+2xhF8fAZDYHgdvto1k5Acx/w4zvzNCc2Kx1fsAuLPKKzzk7TGNjbUjuRaWMw9nDTaugrzRW+KOR6skfxXdvhW9OvuryZX1kjK5ON55FgL4go19/t/cH2PeBd82PzeWdDkbtCYuEL4SwwYm+MrQ2Eq0uSF17h3gqF2dg+Y0m/jLJzXZzyNx+JwpAsQpVH3E79eeLIZ0Ngli3bZb83C9WbYdyOoA4YUMhT3md34a+c/Jak0PkelaYw5Z6EnDCr3J+SVQjrEwE6U81fnEBYGYZOsL9ZSWJ5dOAU+LB2dP3MHrHkhd8e2711T1Y4x21S9vun+f1z3Ke7ee99RTiSQWSlKtMhvI5MWt70/kptmoTRSprRl/933ulNraCHRTiREWCjLtH0bCSXaiYSa1+rbyKeBHaSTCxZLYTFriv9rziaxphfVIJnKaMWGTj/tFCeedYaB+yebw1vasiF/oH3FE9sgHpzX5v5b8Eg/lX8DAAD//4191+g="
+	return "eJzUWd1u2zgTvfdTDHL1fUXrB8jFAunPAgW226DdXAs0OZK5lUhlOEyiPv2ClChLtpTYsrfBIlehOeec+fFwSL+DH9hcg/jpCVcArLnEa7i6Cf9frQAUOkm6Zm3NNfy2AoB2L1RW+TKYEJYoHF5DIVYAucZSueu48R0YUeEOPPxxU4etZH3drQwthlasKyxIaNN/kqx/YPNoSQ3WJ1Smv7+2CNEbqJBJywncxEjorCeJB4RDuUfQJRxwNUqdaxxK3Xd35HJTD8mf8/gFGUlKMAebAw9kTVIHAZelDohHUe9H9wLcie8gcztWFoVbv9mzbmnt5m+UvPdRu5g9J2ywJatEXWtTdPuv3lyd5kRbsb0bUexq34XgiKvFKKxz+p7hS8mKUOCwRMmoDtic3/QQmVbncw4B4fPH5wn36vMSlCPIRCrqutRSXMzJAd6Uj0pXaJy2ZlyLM3X4Qg0eW3/PSB51yoG4A+HtFrd+c7LuvLSCL6v6SysGCNmTQbVeJZmirjNtnC627FZJX2oK/cm1QRYzZ90gfQOcYRNPVI4FcaYEp6JqnRgszHgQvn/ReLc3YaJRSxHRqEO8w6xNZGw2W8dkalpRENRxj1LjuFV4Vl4SyH81KYT3Hh27TFpveO181dkcZmAG+luLABFgGjsXukR1DniLMMHhHVIS742+93gqxZ1DmgB26GJrPAv7ewsyq1t43qLh0KNRLeS4GWK0uBN8G7KPDiljXWlTuMwgP1r6kSlP8XxYi4fiVOb3LSZ0mNBhQsJ8VoCLlXxB9gB4HDWhRP2A2QXJO8jj+GuyMhSGKS4agB3scTLYsigvqiAiTpPjk8Rof0an+ZQwJup7gN/5eQEGEJyiBiU+YDlH6ZAeLsbYgk0Q1ki5pUoYiW0QQ+ursLLUZOJB6FJsSsw2DaNbks3bHTwkeGjhoYeHCP+yrK4Ys5r0g+BLq+rQoUM/VZSsfVYjSTQsCsxi2V5cm6w97EggkiyV+C+LO1qWtjHSIXiZQ2mNuriyz1/bbAYd0HL0U9tGl6U2xZKZrTMFYRR4FxKikIUu5wZq6YnQyGY1fwGb8e59x/ShQ1iPo0vI4imT1vGpYQtzrKhCRsLLRkCADeaWEFg8jVm80Ry+ehJPJbkzmuE2WIJ28eUmwqSZN3QAttBYv4b/NdYTfLoBS2B5G6cowyQkh++lxP+PNd17YVhzc6qiLyhcuBcGLQkDak9yKxyqQC6tcb5Ctd4LUbCokJHCVKTCuRQqIKymaqiRtFVjnQprQVyh4eG9/4QCCCJ2ILubfiKoySov+XTg29YwwkBuKaYnOR+PjZApS310xo7Fss/ipWTB7SFaz91K4ocZGrUYePJy0qUpa9O0exI5MRnjbIPeS7iQsQktzPafg5fG9NW/aRGnabRa3lM6YNAq3BpyjbTPwV6Uy7tLNIfePMGGJiOF48XACeAQOmZ/6Y22a+Rpb8LEPEfJYcJfqldUllj/jDdOxyDy0ESUdjH67i3kZCsQ8PvXD3ff2y34VFvay3ipzwhZRBUc6yoAtT31WGq5FVRgFu6VhaUFp1iIwlYXW3T8Lk6iIEvhnM7Tw0dX8i3RW8B1sYa7mA9LcNs1oGPldr3rTL3JOmlLHZG3guFRuC4q6jlVfQBtVXvG7KHq3qv6GSP+0rT3cjX5avXSi9WEJzvWCSGZk6JEh/w6inr61UCbYaENUnjm5DAx/lppHTsk9glhhIV2TM3rCEvsE8K64nwdXR15L0sJFhvhMOsOqV+pKnGnA7IXVdoiE0aUDYeX2wUz/zf7uHuWh00Df9gCbhIi3HskjXMXgPA6Fn+UWjx2fP6YGtGYt0fuFOyNI2GxWS8bRtKvaYlYWpPrwhOqyNW0rbAPSdhC9nHMT+h8yUe+0U8m+QWB0pa+Mi5pJPs4SlJYi2J3zbiyRrOl+RooZipgZ5hSvGteji2J4pd2rI7yoNK79df49u1L+mcAAUtKbw=="
 }
@@ -0,0 +1,94 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package azure
+
+import (
+	"hash/fnv"
+	"slices"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// ReportResourceInventory publishes one inventory event per discovered
+// resource. Events are only published when the list of resources changed
+// since the last report, so the inventory is sent once after each change
+// instead of on every collection.
+func (client *BaseClient) ReportResourceInventory(reporter mb.ReporterV2) {
+	fingerprint := resourcesFingerprint(client.Resources)
+	if fingerprint == client.inventoryFingerprint {
+		return
+	}
+
+	for _, resource := range client.Resources {
+		if !reporter.Event(buildInventoryEvent(resource)) {
+			// the metricset is closing, report again on the next fetch
+			return
+		}
+	}
+	client.inventoryFingerprint = fingerprint
+	client.Log.Debugf("reported the inventory of %d resources", len(client.Resources))
+}
+
+// buildInventoryEvent builds the inventory event of a resource.
+func buildInventoryEvent(resource Resource) mb.Event {
+	event := mb.Event{
+		ModuleFields: mapstr.M{
+			"resource": mapstr.M{
+				"id":    resource.Id,
+				"type":  resource.Type,
+				"group": resource.Group,
+				"name":  resource.Name,
+			},
+			"subscription_id": resource.Subscription,
+		},
+		RootFields: mapstr.M{
+			"cloud": mapstr.M{
+				"provider": "azure",
+				"region":   resource.Location,
+			},
+			"event": mapstr.M{
+				"kind": "asset",
+			},
+		},
+	}
+	if len(resource.Tags) > 0 {
+		_, _ = event.ModuleFields.Put("resource.tags", resource.Tags)
+	}
+	return event
+}
+
+// resourcesFingerprint returns a hash of the inventory relevant details of
+// the resources, independent of their order.
+func resourcesFingerprint(resources []Resource) uint64 {
+	entries := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		tags := make([]string, 0, len(resource.Tags))
+		for k, v := range resource.Tags {
+			tags = append(tags, k+"="+v)
+		}
+		slices.Sort(tags)
+		entries = append(entries, strings.Join([]string{
+			resource.Id,
+			resource.Type,
+			resource.Location,
+			resource.Group,
+			resource.Name,
+			resource.Subscription,
+			strings.Join(tags, ","),
+		}, "|"))
+	}
+	slices.Sort(entries)
+
+	h := fnv.New64a()
+	for _, entry := range entries {
+		h.Write([]byte(entry))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
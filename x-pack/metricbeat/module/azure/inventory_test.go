@@ -0,0 +1,78 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestReportResourceInventory(t *testing.T) {
+	resources := []*armresources.GenericResourceExpanded{
+		{
+			ID:       to.Ptr("/subscriptions/123/resourceGroups/group1/providers/Microsoft.Compute/virtualMachines/vm1"),
+			Name:     to.Ptr("vm1"),
+			Location: to.Ptr("westeurope"),
+			Type:     to.Ptr("Microsoft.Compute/virtualMachines"),
+			Tags:     map[string]*string{"env": to.Ptr("prod")},
+		},
+		{
+			ID:       to.Ptr("/subscriptions/123/resourceGroups/group2/providers/Microsoft.Storage/storageAccounts/sa1"),
+			Name:     to.Ptr("sa1"),
+			Location: to.Ptr("northeurope"),
+			Type:     to.Ptr("Microsoft.Storage/storageAccounts"),
+		},
+	}
+
+	client := NewMockClient()
+	client.Config = resourceIDConfig
+	client.Config.SubscriptionId = "123"
+	client.Config.EmitResourceInventory = true
+	m := &MockService{}
+	m.On("GetResourceDefinitions", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(resources, nil)
+	client.AzureMonitorService = m
+	require.NoError(t, client.InitResources(mockMapResourceMetrics))
+
+	mr := &MockReporterV2{}
+	mr.On("Event", mock.Anything).Return(true)
+	client.ReportResourceInventory(mr)
+
+	mr.AssertNumberOfCalls(t, "Event", 2)
+	event := mr.Calls[0].Arguments.Get(0).(mb.Event)
+	assert.Equal(t, mapstr.M{
+		"resource": mapstr.M{
+			"id":    "/subscriptions/123/resourceGroups/group1/providers/Microsoft.Compute/virtualMachines/vm1",
+			"type":  "Microsoft.Compute/virtualMachines",
+			"group": "group1",
+			"name":  "vm1",
+			"tags":  map[string]string{"env": "prod"},
+		},
+		"subscription_id": "123",
+	}, event.ModuleFields)
+	assert.Equal(t, "westeurope", event.RootFields["cloud"].(mapstr.M)["region"])
+	assert.Equal(t, "asset", event.RootFields["event"].(mapstr.M)["kind"])
+
+	t.Run("unchanged resources are not reported again", func(t *testing.T) {
+		require.NoError(t, client.InitResources(mockMapResourceMetrics))
+		client.ReportResourceInventory(mr)
+		mr.AssertNumberOfCalls(t, "Event", 2)
+	})
+
+	t.Run("changed resources are reported", func(t *testing.T) {
+		client.Resources[1].Tags = map[string]string{"env": "dev"}
+		client.ReportResourceInventory(mr)
+		mr.AssertNumberOfCalls(t, "Event", 4)
+	})
+}
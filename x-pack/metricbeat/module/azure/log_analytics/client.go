@@ -0,0 +1,117 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package log_analytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/azure"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// rowNumberColumn is the column added to the configured queries to paginate
+// the results. It is removed from the rows before they are returned.
+const rowNumberColumn = "elastic_row_number"
+
+// Client represents the azure client which will make use of the Log Analytics query API
+type Client struct {
+	Service Service
+	Config  Config
+	Log     *logp.Logger
+}
+
+// NewClient builds a new client for the Log Analytics query API
+func NewClient(azureConfig azure.Config, config Config) (*Client, error) {
+	service, err := NewService(azureConfig, config)
+	if err != nil {
+		return nil, err
+	}
+	client := &Client{
+		Service: service,
+		Config:  config,
+		Log:     logp.NewLogger("azure log analytics client"),
+	}
+	return client, nil
+}
+
+// GetRows runs the query over the [start, end) time interval and returns
+// the rows of all the result pages.
+func (client *Client) GetRows(ctx context.Context, query QueryConfig, start, end time.Time) ([]Table, error) {
+	timespan := calculateTimespan(start, end)
+	pageSize := query.PageSize
+
+	var tables []Table
+	for offset := 0; ; offset += pageSize {
+		client.Log.
+			With("log_analytics.query.name", query.Name).
+			With("log_analytics.query.timespan", timespan).
+			With("log_analytics.query.offset", offset).
+			Debugw("Running log analytics query")
+
+		result, err := client.Service.Query(ctx, client.Config.WorkspaceId, pagedQuery(query.Query, offset, pageSize), timespan)
+		if err != nil {
+			return tables, fmt.Errorf("query %q failed: %w", query.Name, err)
+		}
+
+		rows := 0
+		for _, table := range result.Tables {
+			table = removeColumn(table, rowNumberColumn)
+			rows += len(table.Rows)
+			tables = append(tables, table)
+		}
+
+		if rows < pageSize {
+			return tables, nil
+		}
+	}
+}
+
+// pagedQuery restricts the query results to the page of pageSize rows
+// starting at offset.
+func pagedQuery(query string, offset, pageSize int) string {
+	return fmt.Sprintf("%s\n| serialize %s = row_number()\n| where %s > %d and %s <= %d",
+		query, rowNumberColumn, rowNumberColumn, offset, rowNumberColumn, offset+pageSize)
+}
+
+// removeColumn returns the table without the named column.
+func removeColumn(table Table, name string) Table {
+	idx := -1
+	for i, column := range table.Columns {
+		if column.Name == name {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return table
+	}
+
+	columns := make([]Column, 0, len(table.Columns)-1)
+	columns = append(columns, table.Columns[:idx]...)
+	columns = append(columns, table.Columns[idx+1:]...)
+
+	rows := make([][]interface{}, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		if idx >= len(row) {
+			rows = append(rows, row)
+			continue
+		}
+		trimmed := make([]interface{}, 0, len(row)-1)
+		trimmed = append(trimmed, row[:idx]...)
+		trimmed = append(trimmed, row[idx+1:]...)
+		rows = append(rows, trimmed)
+	}
+
+	return Table{Name: table.Name, Columns: columns, Rows: rows}
+}
+
+// calculateTimespan returns the ISO 8601 interval between start and end.
+func calculateTimespan(start, end time.Time) string {
+	return fmt.Sprintf("%s/%s", start.UTC().Format(time.RFC3339Nano), end.UTC().Format(time.RFC3339Nano))
+}
@@ -0,0 +1,61 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package log_analytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClient(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(5 * time.Minute)
+	query := QueryConfig{Name: "errors", Query: "AzureDiagnostics", PageSize: 2}
+	columns := []Column{{Name: "Message", Type: "string"}, {Name: rowNumberColumn, Type: "long"}}
+
+	t.Run("return error on failed query", func(t *testing.T) {
+		client := NewMockClient()
+		client.Config = Config{WorkspaceId: "workspace"}
+		m := &MockService{}
+		m.On("Query", mock.Anything, "workspace", mock.Anything, mock.Anything).Return(QueryResult{}, errors.New("invalid query"))
+		client.Service = m
+		_, err := client.GetRows(context.Background(), query, start, end)
+		assert.Error(t, err)
+		m.AssertExpectations(t)
+	})
+	t.Run("return rows of all pages", func(t *testing.T) {
+		client := NewMockClient()
+		client.Config = Config{WorkspaceId: "workspace"}
+		m := &MockService{}
+		timespan := "2024-03-01T10:00:00Z/2024-03-01T10:05:00Z"
+		m.On("Query", mock.Anything, "workspace", pagedQuery("AzureDiagnostics", 0, 2), timespan).Return(QueryResult{
+			Tables: []Table{{Name: "PrimaryResult", Columns: columns, Rows: [][]interface{}{{"a", 1}, {"b", 2}}}},
+		}, nil).Once()
+		m.On("Query", mock.Anything, "workspace", pagedQuery("AzureDiagnostics", 2, 2), timespan).Return(QueryResult{
+			Tables: []Table{{Name: "PrimaryResult", Columns: columns, Rows: [][]interface{}{{"c", 3}}}},
+		}, nil).Once()
+		client.Service = m
+		tables, err := client.GetRows(context.Background(), query, start, end)
+		assert.NoError(t, err)
+		assert.Len(t, tables, 2)
+		assert.Equal(t, []Column{{Name: "Message", Type: "string"}}, tables[0].Columns)
+		assert.Equal(t, [][]interface{}{{"a"}, {"b"}}, tables[0].Rows)
+		assert.Equal(t, [][]interface{}{{"c"}}, tables[1].Rows)
+		m.AssertExpectations(t)
+	})
+}
+
+func TestPagedQuery(t *testing.T) {
+	assert.Equal(t,
+		"AzureDiagnostics | where Level == 'Error'\n| serialize elastic_row_number = row_number()\n| where elastic_row_number > 100 and elastic_row_number <= 200",
+		pagedQuery("AzureDiagnostics | where Level == 'Error'", 100, 100))
+}
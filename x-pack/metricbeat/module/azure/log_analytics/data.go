@@ -0,0 +1,92 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package log_analytics
+
+import (
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// resourceIdColumn is the standard Log Analytics column holding the id of
+// the resource that emitted the record.
+const resourceIdColumn = "_ResourceId"
+
+// EventsMapping maps the rows returned by a query to events, one per row.
+func EventsMapping(tables []Table, query QueryConfig, workspaceId string, subscriptionId string, log *logp.Logger) []mb.Event {
+	var events []mb.Event
+	for _, table := range tables {
+		for _, row := range table.Rows {
+			events = append(events, buildEvent(table.Columns, row, query, workspaceId, subscriptionId, log))
+		}
+	}
+	return events
+}
+
+func buildEvent(columns []Column, row []interface{}, query QueryConfig, workspaceId string, subscriptionId string, log *logp.Logger) mb.Event {
+	result := mapstr.M{}
+	for i, column := range columns {
+		if i >= len(row) || row[i] == nil {
+			continue
+		}
+		result[column.Name] = row[i]
+	}
+
+	event := mb.Event{
+		ModuleFields: mapstr.M{
+			"subscription_id": subscriptionId,
+		},
+		MetricSetFields: mapstr.M{
+			"workspace_id": workspaceId,
+			"query": mapstr.M{
+				"name": query.Name,
+			},
+			"result": result,
+		},
+		RootFields: mapstr.M{
+			"cloud": mapstr.M{
+				"provider": "azure",
+			},
+		},
+	}
+
+	if value, ok := result[query.TimestampField].(string); ok {
+		timestamp, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			log.Debugf("could not parse %s value %q of query %s: %v", query.TimestampField, value, query.Name, err)
+		} else {
+			event.Timestamp = timestamp
+		}
+	}
+
+	if value, ok := result[resourceIdColumn].(string); ok && value != "" {
+		addResourceFields(&event, value, log)
+	}
+
+	return event
+}
+
+// addResourceFields adds the resource context of the record to the event.
+func addResourceFields(event *mb.Event, resourceId string, log *logp.Logger) {
+	_, _ = event.ModuleFields.Put("resource.id", resourceId)
+
+	resource, err := arm.ParseResourceID(resourceId)
+	if err != nil {
+		log.Debugf("could not parse resource id %q: %v", resourceId, err)
+		return
+	}
+	_, _ = event.ModuleFields.Put("resource.name", resource.Name)
+	_, _ = event.ModuleFields.Put("resource.group", resource.ResourceGroupName)
+	_, _ = event.ModuleFields.Put("resource.type", resource.ResourceType.String())
+	if resource.SubscriptionID != "" {
+		_, _ = event.ModuleFields.Put("subscription_id", resource.SubscriptionID)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package log_analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestEventsMapping(t *testing.T) {
+	query := QueryConfig{Name: "errors", TimestampField: defaultTimestampField}
+	tables := []Table{
+		{
+			Name: "PrimaryResult",
+			Columns: []Column{
+				{Name: "TimeGenerated", Type: "datetime"},
+				{Name: "Level", Type: "string"},
+				{Name: "_ResourceId", Type: "string"},
+				{Name: "Count", Type: "long"},
+			},
+			Rows: [][]interface{}{
+				{"2024-03-01T10:01:02.5Z", "Error", "/subscriptions/sub-id/resourceGroups/my-group/providers/Microsoft.Web/sites/my-site", float64(3)},
+				{"not a date", "Warning", nil, float64(1)},
+			},
+		},
+	}
+
+	events := EventsMapping(tables, query, "workspace", "module-sub-id", logp.NewLogger("test"))
+	assert.Len(t, events, 2)
+
+	event := events[0]
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 1, 2, 500000000, time.UTC), event.Timestamp)
+	assert.Equal(t, mapstr.M{
+		"subscription_id": "sub-id",
+		"resource": mapstr.M{
+			"id":    "/subscriptions/sub-id/resourceGroups/my-group/providers/Microsoft.Web/sites/my-site",
+			"name":  "my-site",
+			"group": "my-group",
+			"type":  "Microsoft.Web/sites",
+		},
+	}, event.ModuleFields)
+	assert.Equal(t, mapstr.M{
+		"workspace_id": "workspace",
+		"query":        mapstr.M{"name": "errors"},
+		"result": mapstr.M{
+			"TimeGenerated": "2024-03-01T10:01:02.5Z",
+			"Level":         "Error",
+			"_ResourceId":   "/subscriptions/sub-id/resourceGroups/my-group/providers/Microsoft.Web/sites/my-site",
+			"Count":         float64(3),
+		},
+	}, event.MetricSetFields)
+
+	event = events[1]
+	assert.True(t, event.Timestamp.IsZero())
+	assert.Equal(t, mapstr.M{"subscription_id": "module-sub-id"}, event.ModuleFields)
+	assert.Equal(t, mapstr.M{"Level": "Warning", "Count": float64(1), "TimeGenerated": "not a date"}, event.MetricSetFields["result"])
+}
@@ -0,0 +1,142 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package log_analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/azure"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	metricsetName = "log_analytics"
+
+	// DefaultEndpoint is the default URI of the Log Analytics query API.
+	DefaultEndpoint = "https://api.loganalytics.io"
+
+	defaultTimestampField = "TimeGenerated"
+	defaultPageSize       = 1000
+)
+
+// Config options
+type Config struct {
+	WorkspaceId string        `config:"workspace_id" validate:"required"`
+	Endpoint    string        `config:"log_analytics_endpoint"`
+	Audience    string        `config:"log_analytics_audience"`
+	Queries     []QueryConfig `config:"queries" validate:"required"`
+}
+
+// QueryConfig contains the configuration of a single KQL query.
+type QueryConfig struct {
+	Name           string `config:"name" validate:"required"`
+	Query          string `config:"query" validate:"required"`
+	TimestampField string `config:"timestamp_field"`
+	PageSize       int    `config:"page_size" validate:"positive"`
+}
+
+// Validate checks the configuration and applies the defaults.
+func (conf *Config) Validate() error {
+	if conf.Endpoint == "" {
+		conf.Endpoint = DefaultEndpoint
+	}
+	names := make(map[string]struct{}, len(conf.Queries))
+	for i := range conf.Queries {
+		query := &conf.Queries[i]
+		if _, ok := names[query.Name]; ok {
+			return fmt.Errorf("duplicate query name %q", query.Name)
+		}
+		names[query.Name] = struct{}{}
+		if query.TimestampField == "" {
+			query.TimestampField = defaultTimestampField
+		}
+		if query.PageSize == 0 {
+			query.PageSize = defaultPageSize
+		}
+	}
+	return nil
+}
+
+func init() {
+	mb.Registry.MustAddMetricSet("azure", metricsetName, New, mb.WithHostParser(parse.EmptyHostParser))
+}
+
+// MetricSet struct used for log analytics.
+type MetricSet struct {
+	mb.BaseMetricSet
+	log         *logp.Logger
+	client      *Client
+	azureConfig azure.Config
+	// lastEnd holds the end of the last queried interval for each query,
+	// so consecutive fetches do not query overlapping intervals.
+	lastEnd map[string]time.Time
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	var azureConfig azure.Config
+	if err := base.Module().UnpackConfig(&azureConfig); err != nil {
+		return nil, fmt.Errorf("error unpack raw module config using UnpackConfig: %w", err)
+	}
+	var config Config
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, fmt.Errorf("error unpack raw module config using UnpackConfig: %w", err)
+	}
+	client, err := NewClient(azureConfig, config)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing the log analytics client: module azure - %s metricset: %w", metricsetName, err)
+	}
+	return &MetricSet{
+		BaseMetricSet: base,
+		log:           base.Logger().Named(metricsetName),
+		client:        client,
+		azureConfig:   azureConfig,
+		lastEnd:       make(map[string]time.Time),
+	}, nil
+}
+
+// Fetch runs the configured queries and reports one event per returned row.
+func (m *MetricSet) Fetch(report mb.ReporterV2) error {
+	// Compensate for the time it takes Azure to ingest the records.
+	referenceTime := time.Now().UTC().Add(-m.azureConfig.Latency)
+
+	var errs []error
+	for _, query := range m.client.Config.Queries {
+		start, end := m.queryInterval(query.Name, referenceTime)
+
+		tables, err := m.client.GetRows(context.Background(), query, start, end)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		m.lastEnd[query.Name] = end
+
+		events := EventsMapping(tables, query, m.client.Config.WorkspaceId, m.azureConfig.SubscriptionId, m.log)
+		for _, event := range events {
+			if !report.Event(event) {
+				return errors.Join(errs...)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// queryInterval returns the interval the query should be run over. The
+// interval starts where the last successful run of the query ended, or one
+// period before the reference time on the first run.
+func (m *MetricSet) queryInterval(name string, referenceTime time.Time) (time.Time, time.Time) {
+	start, ok := m.lastEnd[name]
+	if !ok || start.After(referenceTime) {
+		start = referenceTime.Add(-m.Module().Config().Period)
+	}
+	return start, referenceTime
+}
@@ -0,0 +1,36 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package log_analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	t.Run("applies defaults", func(t *testing.T) {
+		config := Config{
+			WorkspaceId: "workspace",
+			Queries:     []QueryConfig{{Name: "errors", Query: "AzureDiagnostics"}},
+		}
+		assert.NoError(t, config.Validate())
+		assert.Equal(t, DefaultEndpoint, config.Endpoint)
+		assert.Equal(t, defaultTimestampField, config.Queries[0].TimestampField)
+		assert.Equal(t, defaultPageSize, config.Queries[0].PageSize)
+	})
+	t.Run("rejects duplicate query names", func(t *testing.T) {
+		config := Config{
+			WorkspaceId: "workspace",
+			Queries: []QueryConfig{
+				{Name: "errors", Query: "AzureDiagnostics"},
+				{Name: "errors", Query: "AppExceptions"},
+			},
+		}
+		assert.Error(t, config.Validate())
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package log_analytics
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// MockService mock for the log analytics service
+type MockService struct {
+	mock.Mock
+}
+
+// NewMockClient instantiates a new client with the mock log analytics service
+func NewMockClient() *Client {
+	return &Client{
+		new(MockService),
+		Config{},
+		logp.NewLogger("test azure log analytics"),
+	}
+}
+
+// Query is a mock function for the log analytics service
+func (service *MockService) Query(ctx context.Context, workspaceId string, query string, timespan string) (QueryResult, error) {
+	args := service.Called(ctx, workspaceId, query, timespan)
+	return args.Get(0).(QueryResult), args.Error(1)
+}
@@ -0,0 +1,117 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package log_analytics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/azure"
+)
+
+// Service offers access to the Log Analytics query API.
+type Service interface {
+	Query(ctx context.Context, workspaceId string, query string, timespan string) (QueryResult, error)
+}
+
+// QueryResult is the response body of a Log Analytics query.
+type QueryResult struct {
+	Tables []Table `json:"tables"`
+}
+
+// Table is a single table returned by a Log Analytics query.
+type Table struct {
+	Name    string          `json:"name"`
+	Columns []Column        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// Column describes a column of a Log Analytics table.
+type Column struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// queryBody is the request body of a Log Analytics query.
+type queryBody struct {
+	Query    string `json:"query"`
+	Timespan string `json:"timespan,omitempty"`
+}
+
+// LogAnalyticsService is a thin wrapper around the Log Analytics query REST API.
+type LogAnalyticsService struct {
+	pipeline runtime.Pipeline
+	endpoint string
+}
+
+// NewService builds a new LogAnalyticsService using the given module and
+// metricset config.
+func NewService(azureConfig azure.Config, config Config) (*LogAnalyticsService, error) {
+	clientOptions := policy.ClientOptions{
+		Cloud: cloud.Configuration{
+			Services:                     cloud.AzurePublic.Services,
+			ActiveDirectoryAuthorityHost: azureConfig.ActiveDirectoryEndpoint,
+		},
+	}
+
+	credential, err := azureConfig.NewCredential(clientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create client credentials: %w", err)
+	}
+
+	audience := config.Audience
+	if audience == "" {
+		audience = config.Endpoint
+	}
+	scope := strings.TrimSuffix(audience, "/") + "/.default"
+
+	pipeline := runtime.NewPipeline("log_analytics", "v1", runtime.PipelineOptions{
+		PerRetry: []policy.Policy{
+			runtime.NewBearerTokenPolicy(credential, []string{scope}, nil),
+		},
+	}, &clientOptions)
+
+	return &LogAnalyticsService{
+		pipeline: pipeline,
+		endpoint: config.Endpoint,
+	}, nil
+}
+
+// Query runs the given KQL query against the workspace, restricted to the given
+// ISO 8601 timespan.
+func (service *LogAnalyticsService) Query(ctx context.Context, workspaceId string, query string, timespan string) (QueryResult, error) {
+	var result QueryResult
+
+	endpoint := runtime.JoinPaths(service.endpoint, "v1", "workspaces", url.PathEscape(workspaceId), "query")
+	req, err := runtime.NewRequest(ctx, http.MethodPost, endpoint)
+	if err != nil {
+		return result, fmt.Errorf("couldn't create query request: %w", err)
+	}
+	if err := runtime.MarshalAsJSON(req, queryBody{Query: query, Timespan: timespan}); err != nil {
+		return result, fmt.Errorf("couldn't encode query request: %w", err)
+	}
+
+	resp, err := service.pipeline.Do(req)
+	if err != nil {
+		return result, err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return result, runtime.NewResponseError(resp)
+	}
+	if err := runtime.UnmarshalAsJSON(resp, &result); err != nil {
+		return result, fmt.Errorf("couldn't decode query response: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,182 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package azure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// metricDefinitionCacheFile is the name of the file the metric definitions are persisted to.
+const metricDefinitionCacheFile = "metric_definitions.json"
+
+// metricDefinitionCacheEntry contains the metric definitions of a resource type and namespace
+// and the time they were retrieved.
+type metricDefinitionCacheEntry struct {
+	Timestamp   time.Time                      `json:"timestamp"`
+	Definitions []*armmonitor.MetricDefinition `json:"definitions"`
+}
+
+// metricDefinitionCache persists the metric definitions of the resource types to a local file,
+// so that they are not retrieved again for every resource and after every restart.
+// Metric definitions are kept until they are older than the ttl.
+type metricDefinitionCache struct {
+	mu      sync.Mutex
+	path    string
+	ttl     time.Duration
+	entries map[string]metricDefinitionCacheEntry
+	log     *logp.Logger
+}
+
+// newMetricDefinitionCache creates a cache persisted in dir and loads the metric definitions
+// that have not expired yet. A missing or unreadable cache file results in an empty cache.
+func newMetricDefinitionCache(dir string, ttl time.Duration, log *logp.Logger) *metricDefinitionCache {
+	cache := &metricDefinitionCache{
+		path:    filepath.Join(dir, metricDefinitionCacheFile),
+		ttl:     ttl,
+		entries: make(map[string]metricDefinitionCacheEntry),
+		log:     log,
+	}
+	if err := cache.load(time.Now()); err != nil {
+		log.Warnf("ignoring metric definition cache %s: %v", cache.path, err)
+		cache.entries = make(map[string]metricDefinitionCacheEntry)
+	}
+	return cache
+}
+
+// load reads the cache file and keeps the entries that have not expired at now.
+func (c *metricDefinitionCache) load(now time.Time) error {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	var entries map[string]metricDefinitionCacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	for key, entry := range entries {
+		if !c.expired(entry, now) {
+			c.entries[key] = entry
+		}
+	}
+	c.log.Debugf("loaded %d metric definition cache entries from %s", len(c.entries), c.path)
+	return nil
+}
+
+func (c *metricDefinitionCache) expired(entry metricDefinitionCacheEntry, now time.Time) bool {
+	return now.Sub(entry.Timestamp) >= c.ttl
+}
+
+// get returns the metric definitions stored for the key if they have not expired.
+func (c *metricDefinitionCache) get(key string, now time.Time) ([]*armmonitor.MetricDefinition, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if c.expired(entry, now) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.Definitions, true
+}
+
+// put stores the metric definitions for the key and writes the cache to disk.
+func (c *metricDefinitionCache) put(key string, definitions []*armmonitor.MetricDefinition, now time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = metricDefinitionCacheEntry{Timestamp: now, Definitions: definitions}
+	return c.save()
+}
+
+// save writes the cache to a temporary file and renames it, so a partially
+// written cache file is never loaded.
+func (c *metricDefinitionCache) save() error {
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return fmt.Errorf("encoding metric definition cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("creating metric definition cache directory: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing metric definition cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("writing metric definition cache: %w", err)
+	}
+	return nil
+}
+
+// metricDefinitionCacheKey returns the cache key of the metric definitions of a resource,
+// made of its subscription, resource type and the metric namespace.
+func metricDefinitionCacheKey(resourceId string, namespace string) (string, bool) {
+	id, err := arm.ParseResourceID(resourceId)
+	if err != nil || id.SubscriptionID == "" {
+		return "", false
+	}
+	return strings.ToLower(id.SubscriptionID + "/" + id.ResourceType.String() + "/" + namespace), true
+}
+
+// useMetricDefinitionCache makes the client retrieve the metric definitions through a
+// cache persisted in dir.
+func (client *BaseClient) useMetricDefinitionCache(dir string, ttl time.Duration) {
+	client.AzureMonitorService = &cachedMetricDefinitionService{
+		Service: client.AzureMonitorService,
+		cache:   newMetricDefinitionCache(dir, ttl, client.Log),
+	}
+}
+
+// cachedMetricDefinitionService is a Service that retrieves the metric definitions of a
+// resource type once and reuses them for the other resources of the type until they expire.
+type cachedMetricDefinitionService struct {
+	Service
+	cache *metricDefinitionCache
+}
+
+// GetMetricDefinitionsWithRetry returns the cached metric definitions of the resource type
+// or retrieves and caches them.
+func (service *cachedMetricDefinitionService) GetMetricDefinitionsWithRetry(resourceId string, namespace string) (armmonitor.MetricDefinitionCollection, error) {
+	key, ok := metricDefinitionCacheKey(resourceId, namespace)
+	if !ok {
+		return service.Service.GetMetricDefinitionsWithRetry(resourceId, namespace)
+	}
+	if definitions, found := service.cache.get(key, time.Now()); found {
+		return armmonitor.MetricDefinitionCollection{Value: definitions}, nil
+	}
+
+	metricDefinitions, err := service.Service.GetMetricDefinitionsWithRetry(resourceId, namespace)
+	if err != nil {
+		return metricDefinitions, err
+	}
+	// Empty results are not cached, the namespace may not be supported
+	// by this resource only.
+	if len(metricDefinitions.Value) > 0 {
+		if err := service.cache.put(key, metricDefinitions.Value, time.Now()); err != nil {
+			service.cache.log.Warnf("failed to persist the metric definitions of %s: %v", key, err)
+		}
+	}
+	return metricDefinitions, nil
+}
@@ -0,0 +1,125 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package azure
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+const (
+	cachedResourceID1 = "/subscriptions/sub1/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1"
+	cachedResourceID2 = "/subscriptions/sub1/resourceGroups/group2/providers/Microsoft.Storage/storageAccounts/account2"
+)
+
+func mockCachedMetricDefinitions() armmonitor.MetricDefinitionCollection {
+	return armmonitor.MetricDefinitionCollection{
+		Value: []*armmonitor.MetricDefinition{
+			{Name: &armmonitor.LocalizableString{Value: to.Ptr("UsedCapacity")}},
+		},
+	}
+}
+
+func TestMetricDefinitionCacheKey(t *testing.T) {
+	key1, ok := metricDefinitionCacheKey(cachedResourceID1, "Microsoft.Storage/storageAccounts")
+	require.True(t, ok)
+	assert.Equal(t, "sub1/microsoft.storage/storageaccounts/microsoft.storage/storageaccounts", key1)
+
+	key2, ok := metricDefinitionCacheKey(cachedResourceID2, "Microsoft.Storage/storageAccounts")
+	require.True(t, ok)
+	assert.Equal(t, key1, key2, "resources of the same type share the cache key")
+
+	_, ok = metricDefinitionCacheKey("invalid", "namespace")
+	assert.False(t, ok)
+}
+
+func TestCachedMetricDefinitionService(t *testing.T) {
+	log := logptest.NewTestingLogger(t, "")
+
+	t.Run("retrieves the metric definitions once per resource type", func(t *testing.T) {
+		m := &MockService{}
+		m.On("GetMetricDefinitionsWithRetry", cachedResourceID1, "namespace").Once().Return(mockCachedMetricDefinitions(), nil)
+		service := &cachedMetricDefinitionService{Service: m, cache: newMetricDefinitionCache(t.TempDir(), time.Hour, log)}
+
+		for _, id := range []string{cachedResourceID1, cachedResourceID2} {
+			definitions, err := service.GetMetricDefinitionsWithRetry(id, "namespace")
+			require.NoError(t, err)
+			assert.Equal(t, mockCachedMetricDefinitions(), definitions)
+		}
+		m.AssertExpectations(t)
+	})
+
+	t.Run("reuses the persisted metric definitions after a restart", func(t *testing.T) {
+		dir := t.TempDir()
+		m := &MockService{}
+		m.On("GetMetricDefinitionsWithRetry", cachedResourceID1, "namespace").Once().Return(mockCachedMetricDefinitions(), nil)
+		service := &cachedMetricDefinitionService{Service: m, cache: newMetricDefinitionCache(dir, time.Hour, log)}
+		_, err := service.GetMetricDefinitionsWithRetry(cachedResourceID1, "namespace")
+		require.NoError(t, err)
+
+		restarted := &cachedMetricDefinitionService{Service: &MockService{}, cache: newMetricDefinitionCache(dir, time.Hour, log)}
+		definitions, err := restarted.GetMetricDefinitionsWithRetry(cachedResourceID2, "namespace")
+		require.NoError(t, err)
+		require.Len(t, definitions.Value, 1)
+		assert.Equal(t, "UsedCapacity", *definitions.Value[0].Name.Value)
+		m.AssertExpectations(t)
+	})
+
+	t.Run("retrieves the metric definitions again when they expired", func(t *testing.T) {
+		m := &MockService{}
+		m.On("GetMetricDefinitionsWithRetry", cachedResourceID1, "namespace").Twice().Return(mockCachedMetricDefinitions(), nil)
+		cache := newMetricDefinitionCache(t.TempDir(), time.Hour, log)
+		service := &cachedMetricDefinitionService{Service: m, cache: cache}
+
+		_, err := service.GetMetricDefinitionsWithRetry(cachedResourceID1, "namespace")
+		require.NoError(t, err)
+		key, _ := metricDefinitionCacheKey(cachedResourceID1, "namespace")
+		require.NoError(t, cache.put(key, mockCachedMetricDefinitions().Value, time.Now().Add(-2*time.Hour)))
+
+		_, err = service.GetMetricDefinitionsWithRetry(cachedResourceID1, "namespace")
+		require.NoError(t, err)
+		m.AssertExpectations(t)
+	})
+
+	t.Run("does not cache errors and empty metric definitions", func(t *testing.T) {
+		m := &MockService{}
+		m.On("GetMetricDefinitionsWithRetry", cachedResourceID1, "namespace").Once().Return(armmonitor.MetricDefinitionCollection{}, errors.New("throttled"))
+		m.On("GetMetricDefinitionsWithRetry", cachedResourceID1, "namespace").Twice().Return(armmonitor.MetricDefinitionCollection{}, nil)
+		service := &cachedMetricDefinitionService{Service: m, cache: newMetricDefinitionCache(t.TempDir(), time.Hour, log)}
+
+		_, err := service.GetMetricDefinitionsWithRetry(cachedResourceID1, "namespace")
+		require.Error(t, err)
+		for i := 0; i < 2; i++ {
+			_, err = service.GetMetricDefinitionsWithRetry(cachedResourceID1, "namespace")
+			require.NoError(t, err)
+		}
+		m.AssertExpectations(t)
+	})
+
+	t.Run("ignores a corrupted cache file", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, metricDefinitionCacheFile), []byte("{"), 0o600))
+		m := &MockService{}
+		m.On("GetMetricDefinitionsWithRetry", mock.Anything, mock.Anything).Once().Return(mockCachedMetricDefinitions(), nil)
+		service := &cachedMetricDefinitionService{Service: m, cache: newMetricDefinitionCache(dir, time.Hour, log)}
+
+		_, err := service.GetMetricDefinitionsWithRetry(cachedResourceID1, "namespace")
+		require.NoError(t, err)
+		m.AssertExpectations(t)
+	})
+}
@@ -45,7 +45,11 @@ func (m *MetricRegistry) Update(metric Metric, info MetricCollectionInfo) {
 }
 
 // NeedsUpdate returns true if the metric needs to be collected again
-// for the given `referenceTime`.
+// for a timespan ending at `referenceTime`.
+//
+// The reference time must be the end time of the timespan computed for the
+// metric, like the timestamps stored with `Update`, so that the latency of
+// the resource doesn't affect the time elapsed since the last collection.
 func (m *MetricRegistry) NeedsUpdate(referenceTime time.Time, metric Metric) bool {
 	// Build a key to store the metric in the registry.
 	// The key is a combination of the namespace,
@@ -58,7 +62,7 @@ func (m *MetricRegistry) NeedsUpdate(referenceTime time.Time, metric Metric) boo
 
 		// Adjust the last collection time by adding a small jitter to avoid
 		// skipping collections when the collection period is close (usually < 1s).
-		timeSinceLastCollection := time.Since(lastCollection.timestamp) + m.jitter
+		timeSinceLastCollection := referenceTime.Sub(lastCollection.timestamp) + m.jitter
 
 		if timeSinceLastCollection < timeGrainDuration {
 			m.logger.Debugw(
@@ -65,12 +65,18 @@ func mapMetrics(client *azure.Client, resources []*armresources.GenericResourceE
 
 			// map dimensions
 			var dim []azure.Dimension
-			if len(metric.Dimensions) > 0 {
+			if len(metric.Dimensions) > 0 && !metric.SplitsByDimension() {
 				for _, dimension := range metric.Dimensions {
 					dim = append(dim, azure.Dimension(dimension))
 				}
 			}
 			for key, metricGroup := range metricGroups {
+				if metric.SplitsByDimension() {
+					for _, group := range groupByDimensions(metricGroup) {
+						metrics = append(metrics, client.CreateMetric(*resource.ID, "", metric.Namespace, group.metricNames, key, group.dimensions, metric.Timegrain))
+					}
+					continue
+				}
 				var metricNames []string
 				for _, metricName := range metricGroup {
 					metricNames = append(metricNames, *metricName.Name.Value)
@@ -213,3 +219,36 @@ func getMetricDefinitionsByNames(metricDefs []*armmonitor.MetricDefinition, name
 	}
 	return metrics
 }
+
+// dimensionGroup contains the names of the metrics that support the same dimensions.
+type dimensionGroup struct {
+	metricNames []string
+	dimensions  []azure.Dimension
+}
+
+// groupByDimensions groups the metric definitions by the dimensions they support. Each dimension of a group
+// is filtered on the '*' value, so Azure Monitor returns a time series for every value of the dimension.
+func groupByDimensions(metricDefs []*armmonitor.MetricDefinition) []dimensionGroup {
+	var groups []dimensionGroup
+	groupIndex := make(map[string]int)
+	for _, def := range metricDefs {
+		var dimensions []azure.Dimension
+		var dimensionNames []string
+		for _, dimension := range def.Dimensions {
+			if dimension == nil || dimension.Value == nil {
+				continue
+			}
+			dimensions = append(dimensions, azure.Dimension{Name: *dimension.Value, Value: "*"})
+			dimensionNames = append(dimensionNames, *dimension.Value)
+		}
+		key := strings.Join(dimensionNames, ",")
+		i, ok := groupIndex[key]
+		if !ok {
+			i = len(groups)
+			groupIndex[key] = i
+			groups = append(groups, dimensionGroup{dimensions: dimensions})
+		}
+		groups[i].metricNames = append(groups[i].metricNames, *def.Name.Value)
+	}
+	return groups
+}
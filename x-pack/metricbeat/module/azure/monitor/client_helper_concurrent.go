@@ -75,12 +75,18 @@ func getMappedResourceDefinitions(client *azure.BatchClient, resourceId string,
 
 		// map dimensions
 		var dim []azure.Dimension
-		if len(metric.Dimensions) > 0 {
+		if len(metric.Dimensions) > 0 && !metric.SplitsByDimension() {
 			for _, dimension := range metric.Dimensions {
 				dim = append(dim, azure.Dimension(dimension))
 			}
 		}
 		for key, metricGroup := range metricGroups {
+			if metric.SplitsByDimension() {
+				for _, group := range groupByDimensions(metricGroup) {
+					metrics = append(metrics, client.CreateMetric(resourceId, "", metric.Namespace, location, subscriptionId, group.metricNames, key, group.dimensions, metric.Timegrain))
+				}
+				continue
+			}
 			var metricNames []string
 			for _, metricName := range metricGroup {
 				metricNames = append(metricNames, *metricName.Name.Value)
@@ -130,6 +130,34 @@ func TestMapMetric(t *testing.T) {
 		assert.Equal(t, metrics[0].Dimensions, []azure.Dimension{{Name: "location", Value: "West Europe"}})
 		m.AssertExpectations(t)
 	})
+	t.Run("return one metric per dimension set when split by dimension is configured", func(t *testing.T) {
+		m := &azure.MockService{}
+		m.On("GetMetricDefinitionsWithRetry", mock.Anything, mock.Anything).Return(armmonitor.MetricDefinitionCollection{
+			Value: MockMetricDefinitionsWithDimensions(),
+		}, nil)
+		client.AzureMonitorService = m
+		splitConfig := azure.MetricConfig{Namespace: "namespace", Name: []string{"*"}, Aggregations: []string{"Average"}, SplitByDimension: true}
+		resourceConfig.Metrics = []azure.MetricConfig{splitConfig}
+		metrics, err := mapMetrics(client, []*armresources.GenericResourceExpanded{resource}, resourceConfig)
+		assert.NoError(t, err)
+
+		assert.Equal(t, len(metrics), 2)
+		assert.Equal(t, metrics[0].Names, []string{"TotalRequests", "BytesRead"})
+		assert.Equal(t, metrics[0].Dimensions, []azure.Dimension{{Name: "ApiName", Value: "*"}})
+		assert.Equal(t, metrics[1].Names, []string{"Capacity"})
+		assert.Equal(t, metrics[1].Dimensions, []azure.Dimension(nil))
+		m.AssertExpectations(t)
+	})
+}
+
+// MockMetricDefinitionsWithDimensions returns the mocked metric definitions where
+// TotalRequests and BytesRead support the ApiName dimension.
+func MockMetricDefinitionsWithDimensions() []*armmonitor.MetricDefinition {
+	apiName := "ApiName"
+	defs := MockMetricDefinitions()
+	defs[0].Dimensions = []*armmonitor.LocalizableString{{Value: &apiName}}
+	defs[2].Dimensions = []*armmonitor.LocalizableString{{Value: &apiName}}
+	return defs
 }
 
 func TestFilterSConfiguredMetrics(t *testing.T) {
@@ -172,3 +200,11 @@ func TestGetMetricDefinitionsByNames(t *testing.T) {
 	assert.Equal(t, len(result), 1)
 	assert.Equal(t, *result[0].Name.Value, "TotalRequests")
 }
+
+func TestGroupByDimensions(t *testing.T) {
+	groups := groupByDimensions(MockMetricDefinitionsWithDimensions())
+	assert.Equal(t, groups, []dimensionGroup{
+		{metricNames: []string{"TotalRequests", "BytesRead"}, dimensions: []azure.Dimension{{Name: "ApiName", Value: "*"}}},
+		{metricNames: []string{"Capacity"}},
+	})
+}
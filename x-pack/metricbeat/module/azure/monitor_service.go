@@ -21,7 +21,6 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/monitor/query/azmetrics"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
@@ -71,10 +70,7 @@ func NewService(config Config) (*MonitorService, error) {
 		},
 	}
 
-	credential, err := azidentity.NewClientSecretCredential(config.TenantId, config.ClientId, config.ClientSecret,
-		&azidentity.ClientSecretCredentialOptions{
-			ClientOptions: clientOptions,
-		})
+	credential, err := config.NewCredential(clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't create client credentials: %w", err)
 	}
@@ -20,6 +20,9 @@ type Resource struct {
 	Tags         map[string]string
 	Subscription string
 	Type         string
+	// Latency is the lookback offset applied when collecting the metric
+	// values of the resource.
+	Latency time.Duration
 	// will be filled if cloud data is necessary, atm only in case of compute_vm and compute_vm_scaleset
 	Vms []VmResource
 }
@@ -60,8 +60,8 @@ func TestFetch(t *testing.T) {
 	assert.NoError(t, err)
 	ms, ok := metricsets[0].(*MetricSet)
 	assert.True(t, ok)
-	assert.Equal(t, len(ms.Client.Config.Resources), 1)
-	assert.Equal(t, ms.Client.Config.Resources[0].Query, fmt.Sprintf("resourceType eq '%s'", defaultStorageAccountNamespace))
+	assert.Equal(t, len(ms.BatchClient.Config.Resources), 1)
+	assert.Equal(t, ms.BatchClient.Config.Resources[0].Query, fmt.Sprintf("resourceType eq '%s'", defaultStorageAccountNamespace))
 
 	c, err = config.NewConfigFrom(resourceConfig)
 	if err != nil {
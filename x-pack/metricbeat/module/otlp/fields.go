@@ -0,0 +1,23 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Code generated by beats/dev-tools/cmd/asset/asset.go - DO NOT EDIT.
+
+package otlp
+
+import (
+	"github.com/elastic/beats/v7/libbeat/asset"
+)
+
+func init() {
+	if err := asset.SetFields("metricbeat", "otlp", asset.ModuleFieldsPri, AssetOtlp); err != nil {
+		panic(err)
+	}
+}
+
+// AssetOtlp returns asset data.
+// This is the base64 encoded zlib format compressed contents of module/otlp.
+func AssetOtlp() string {
+	return "eJzUlMFu2zAMhu95ih+5dCtSP4APA7bz2g5Y0Wsry6yjRRIFiWqXtx/k2InXJG3XrcMG+OCICvl9JqUzrGhdg8WGGSBGLNWYX159/jKfAZEsqUQ1GhI1A1pKOpoghn2NDzMAOCeJRidE0mTuqcVdZAcVgjValY0J9D1wFOM7yJIcHowsyxsuA/krsuRI4hohsrBmi3el+vtqBtwZsm2q+zpn8MrRlrQsyTpQjS5yHlcO8JXntvzpFpq9KONTX9w95n4Ga7HjjpQ4R02LbQHjk8TsyEuvjKQ5EJRvoUSiabJQAt+V9CaiVaIQ2HhJ1ZBiajq1taohm6rTbWC05uYbaZksbxZuNtEVrR84tpPwkS9Tno+PEQ8B7pBG+2qn9rf5RgTIUskwXtRO27qP3bekKu+T5K+guVCORo7Dbe+hImmO7cug7ikmw/73uK43Sf4Y2hCY1Ng/bsD+FfEC1PNfPnrNegDt4Xaw+wdnqnByWt0rm+nkp+jRCd2b0pZzY+n4jhunQjC+G7bPT+eP9j7xDcpzXeBKwxQ6lTtagOPmp2cPx56FvdFI2S1gzarcKMjhrOUHD83ZC8XqmPkQ/w/cD4o+67c0SbiLyr3WcJvg7SQ/Zb0iSZuebus93bLX6lj23duZXGTXUD+c/YlKu0vE+Be5pez+1VH8mt14az4n92MAIWefmA=="
+}
@@ -0,0 +1,194 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"math"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/prometheus/collector"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// eventsMapping converts the data points of an export request into events.
+// Data points sharing the same resource, scope, attributes and timestamp are
+// grouped into the same event. It also returns the number of data points that
+// were rejected because their type is not supported.
+func eventsMapping(cc collector.CounterCache, md pmetric.Metrics) ([]mb.Event, int64) {
+	g := eventGroups{}
+	var rejected int64
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		resource := mapstr.M(rms.At(i).Resource().Attributes().AsRaw())
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			scope := sms.At(j).Scope()
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					g.addNumberDataPoints(resource, scope, metric.Name(), "value", metric.Gauge().DataPoints())
+				case pmetric.MetricTypeSum:
+					// Non monotonic sums, like up-down counters, behave like gauges
+					field := "value"
+					if metric.Sum().IsMonotonic() {
+						field = "counter"
+					}
+					g.addNumberDataPoints(resource, scope, metric.Name(), field, metric.Sum().DataPoints())
+				case pmetric.MetricTypeHistogram:
+					cumulative := metric.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+					g.addHistogramDataPoints(cc, resource, scope, metric.Name(), cumulative, metric.Histogram().DataPoints())
+				case pmetric.MetricTypeExponentialHistogram:
+					rejected += int64(metric.ExponentialHistogram().DataPoints().Len())
+				case pmetric.MetricTypeSummary:
+					rejected += int64(metric.Summary().DataPoints().Len())
+				}
+			}
+		}
+	}
+
+	events := make([]mb.Event, 0, len(g))
+	for _, e := range g {
+		events = append(events, e)
+	}
+	return events, rejected
+}
+
+// eventGroups holds the events being built, by the key of the data points
+// they group.
+type eventGroups map[string]mb.Event
+
+// metrics returns the metrics of the event grouping the data points with the
+// given resource, scope, attributes and timestamp.
+func (g eventGroups) metrics(resource mapstr.M, scope pcommon.InstrumentationScope, attributes pcommon.Map, ts pcommon.Timestamp) mapstr.M {
+	labels := mapstr.M(attributes.AsRaw())
+	key := resource.String() + scope.Name() + scope.Version() + labels.String() + ts.String()
+
+	e, ok := g[key]
+	if !ok {
+		e = mb.Event{
+			RootFields: mapstr.M{},
+			ModuleFields: mapstr.M{
+				"metrics": mapstr.M{},
+			},
+		}
+		if ts != 0 {
+			e.Timestamp = ts.AsTime()
+		}
+		if len(labels) > 0 {
+			e.ModuleFields["labels"] = labels
+		}
+		if len(resource) > 0 {
+			e.ModuleFields["resource"] = mapstr.M{"attributes": resource}
+		}
+		if scope.Name() != "" {
+			_, _ = e.ModuleFields.Put("scope.name", scope.Name())
+		}
+		if scope.Version() != "" {
+			_, _ = e.ModuleFields.Put("scope.version", scope.Version())
+		}
+		if name, ok := resource["service.name"].(string); ok && name != "" {
+			_, _ = e.RootFields.Put("service.name", name)
+		}
+		g[key] = e
+	}
+	return e.ModuleFields["metrics"].(mapstr.M)
+}
+
+func (g eventGroups) addNumberDataPoints(resource mapstr.M, scope pcommon.InstrumentationScope, name, field string, dps pmetric.NumberDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.Flags().NoRecordedValue() {
+			continue
+		}
+
+		var value interface{}
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			value = dp.IntValue()
+		case pmetric.NumberDataPointValueTypeDouble:
+			v := dp.DoubleValue()
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				continue
+			}
+			value = v
+		default:
+			continue
+		}
+
+		g.metrics(resource, scope, dp.Attributes(), dp.Timestamp())[name] = mapstr.M{field: value}
+	}
+}
+
+func (g eventGroups) addHistogramDataPoints(cc collector.CounterCache, resource mapstr.M, scope pcommon.InstrumentationScope, name string, cumulative bool, dps pmetric.HistogramDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.Flags().NoRecordedValue() {
+			continue
+		}
+
+		data := mapstr.M{
+			"count": dp.Count(),
+		}
+		if dp.HasSum() {
+			data["sum"] = dp.Sum()
+		}
+		key := name + resource.String() + scope.Name() + mapstr.M(dp.Attributes().AsRaw()).String()
+		if histogram := histogramToES(cc, key, dp, cumulative); histogram != nil {
+			data["histogram"] = histogram
+		}
+
+		g.metrics(resource, scope, dp.Attributes(), dp.Timestamp())[name] = data
+	}
+}
+
+// histogramToES converts the explicit buckets of a histogram data point to an
+// ES histogram, using the same centroids as Prometheus histograms:
+//
+//   - for the first bucket, its upper bound if it is negative, or half of it otherwise
+//   - for the bucket above the last bound, the last bound
+//   - for all other buckets, the midpoint between its bounds
+//
+// Bucket counts of cumulative histograms are increased since the histogram
+// was created, they are converted to the increase since the previous export,
+// so the first export of a histogram reports zero counts.
+func histogramToES(cc collector.CounterCache, key string, dp pmetric.HistogramDataPoint, cumulative bool) mapstr.M {
+	bounds := dp.ExplicitBounds().AsRaw()
+	bucketCounts := dp.BucketCounts().AsRaw()
+	if len(bounds) == 0 || len(bucketCounts) != len(bounds)+1 {
+		return nil
+	}
+
+	values := make([]float64, 0, len(bucketCounts))
+	counts := make([]uint64, 0, len(bucketCounts))
+	for i, count := range bucketCounts {
+		switch {
+		case i == len(bounds):
+			values = append(values, bounds[i-1])
+		case i == 0 && bounds[0] < 0:
+			values = append(values, bounds[0])
+		case i == 0:
+			values = append(values, bounds[0]/2)
+		default:
+			values = append(values, bounds[i-1]+(bounds[i]-bounds[i-1])/2)
+		}
+
+		if cumulative {
+			count, _ = cc.RateUint64(key+strconv.Itoa(i), count)
+		}
+		counts = append(counts, count)
+	}
+
+	return mapstr.M{
+		"values": values,
+		"counts": counts,
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	serverhelper "github.com/elastic/beats/v7/metricbeat/helper/server"
+	httpserver "github.com/elastic/beats/v7/metricbeat/helper/server/http"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/prometheus/collector"
+)
+
+const (
+	// metricsPath is the path OTLP/HTTP exporters send metrics to, so
+	// exporters can be pointed to the base URL of the server.
+	metricsPath = "/v1/metrics"
+
+	protobufContentType = "application/x-protobuf"
+	jsonContentType     = "application/json"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("otlp", "metrics", New,
+		mb.WithHostParser(parse.EmptyHostParser),
+		mb.DefaultMetricSet(),
+	)
+}
+
+// MetricSet receives OTLP metric export requests over HTTP and reports their
+// data points as events.
+type MetricSet struct {
+	mb.BaseMetricSet
+	server serverhelper.Server
+	events chan mb.Event
+
+	// counters is not safe for concurrent use, requests are handled concurrently
+	mu       sync.Mutex
+	counters collector.CounterCache
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The otlp metrics metricset is beta.")
+
+	m := &MetricSet{
+		BaseMetricSet: base,
+		events:        make(chan mb.Event),
+		// use a counter cache with a timeout of 5x the period, as a safe value
+		// to make sure that all counters are available between exports
+		counters: collector.NewCounterCache(base.Module().Config().Period * 5),
+	}
+
+	svc, err := httpserver.NewHttpServerWithHandler(base, m.handleFunc)
+	if err != nil {
+		return nil, err
+	}
+	m.server = svc
+	return m, nil
+}
+
+// Run starts the server and reports the received events until the reporter
+// is done.
+func (m *MetricSet) Run(reporter mb.PushReporterV2) {
+	m.counters.Start()
+	defer m.counters.Stop()
+
+	_ = m.server.Start()
+
+	for {
+		select {
+		case <-reporter.Done():
+			m.server.Stop()
+			return
+		case e := <-m.events:
+			reporter.Event(e)
+		}
+	}
+}
+
+func (m *MetricSet) handleFunc(writer http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != metricsPath {
+		http.NotFound(writer, req)
+		return
+	}
+	if req.Method != http.MethodPost {
+		http.Error(writer, "OTLP metrics must be sent with POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	contentType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || (contentType != protobufContentType && contentType != jsonContentType) {
+		http.Error(writer, "unsupported content type, expected "+protobufContentType+" or "+jsonContentType, http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body := io.Reader(req.Body)
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			m.Logger().Errorf("Decode error %v", err)
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	payload, err := io.ReadAll(body)
+	if err != nil {
+		m.Logger().Errorf("Read error %v", err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	exportReq := pmetricotlp.NewExportRequest()
+	if contentType == jsonContentType {
+		err = exportReq.UnmarshalJSON(payload)
+	} else {
+		err = exportReq.UnmarshalProto(payload)
+	}
+	if err != nil {
+		m.Logger().Errorf("Unmarshal error %v", err)
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m.mu.Lock()
+	events, rejected := eventsMapping(m.counters, exportReq.Metrics())
+	m.mu.Unlock()
+
+	for _, e := range events {
+		select {
+		case <-req.Context().Done():
+			return
+		case m.events <- e:
+		}
+	}
+
+	resp := pmetricotlp.NewExportResponse()
+	if rejected > 0 {
+		resp.PartialSuccess().SetRejectedDataPoints(rejected)
+		resp.PartialSuccess().SetErrorMessage("exponential histograms and summaries are not supported")
+	}
+	var out []byte
+	if contentType == jsonContentType {
+		out, err = resp.MarshalJSON()
+	} else {
+		out, err = resp.MarshalProto()
+	}
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writer.Header().Set("Content-Type", contentType)
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(out)
+}
@@ -0,0 +1,231 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package metrics
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/prometheus/collector"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+var testTimestamp = time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+
+func testMetrics(bucketCounts []uint64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("checkout.instrumentation")
+	sm.Scope().SetVersion("1.2.0")
+	ts := pcommon.NewTimestampFromTime(testTimestamp)
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("queue.size")
+	dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(12)
+
+	counter := sm.Metrics().AppendEmpty()
+	counter.SetName("http.server.requests")
+	sum := counter.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp = sum.DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.Attributes().PutStr("http.route", "/cart")
+	dp.SetDoubleValue(42)
+
+	upDown := sm.Metrics().AppendEmpty()
+	upDown.SetName("http.server.active_requests")
+	sum = upDown.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp = sum.DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.Attributes().PutStr("http.route", "/cart")
+	dp.SetIntValue(3)
+
+	duration := sm.Metrics().AppendEmpty()
+	duration.SetName("http.server.duration")
+	histogram := duration.SetEmptyHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	hdp := histogram.DataPoints().AppendEmpty()
+	hdp.SetTimestamp(ts)
+	hdp.Attributes().PutStr("http.route", "/cart")
+	hdp.ExplicitBounds().FromRaw([]float64{0.25, 0.5, 1})
+	hdp.BucketCounts().FromRaw(bucketCounts)
+	var count uint64
+	for _, c := range bucketCounts {
+		count += c
+	}
+	hdp.SetCount(count)
+	hdp.SetSum(float64(count) * 0.3)
+
+	exponential := sm.Metrics().AppendEmpty()
+	exponential.SetName("http.client.duration")
+	exponential.SetEmptyExponentialHistogram().DataPoints().AppendEmpty().SetTimestamp(ts)
+
+	return md
+}
+
+func findEvent(t *testing.T, events []mb.Event, hasLabels bool) mb.Event {
+	t.Helper()
+	for _, e := range events {
+		if _, ok := e.ModuleFields["labels"]; ok == hasLabels {
+			return e
+		}
+	}
+	t.Fatalf("no event found (labels: %v)", hasLabels)
+	return mb.Event{}
+}
+
+func TestEventsMapping(t *testing.T) {
+	cc := collector.NewCounterCache(time.Minute)
+
+	events, rejected := eventsMapping(cc, testMetrics([]uint64{1, 2, 3, 0}))
+	assert.Equal(t, int64(1), rejected)
+	require.Len(t, events, 2)
+
+	e := findEvent(t, events, false)
+	assert.Equal(t, testTimestamp, e.Timestamp.UTC())
+	assert.Equal(t, mapstr.M{"service": mapstr.M{"name": "checkout"}}, e.RootFields)
+	assert.Equal(t, mapstr.M{
+		"metrics": mapstr.M{
+			"queue.size": mapstr.M{"value": int64(12)},
+		},
+		"resource": mapstr.M{
+			"attributes": mapstr.M{"service.name": "checkout"},
+		},
+		"scope": mapstr.M{
+			"name":    "checkout.instrumentation",
+			"version": "1.2.0",
+		},
+	}, e.ModuleFields)
+
+	e = findEvent(t, events, true)
+	assert.Equal(t, mapstr.M{"http.route": "/cart"}, e.ModuleFields["labels"])
+	assert.Equal(t, mapstr.M{
+		"http.server.requests":        mapstr.M{"counter": float64(42)},
+		"http.server.active_requests": mapstr.M{"value": int64(3)},
+		"http.server.duration": mapstr.M{
+			"count": uint64(6),
+			"sum":   float64(6) * 0.3,
+			"histogram": mapstr.M{
+				"values": []float64{0.125, 0.375, 0.75, 1},
+				// Cumulative histograms report zero counts on the first export
+				"counts": []uint64{0, 0, 0, 0},
+			},
+		},
+	}, e.ModuleFields["metrics"])
+
+	events, _ = eventsMapping(cc, testMetrics([]uint64{1, 4, 3, 1}))
+	e = findEvent(t, events, true)
+	histogram, err := e.ModuleFields.GetValue("metrics.http.server.duration")
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{0, 2, 0, 1}, histogram.(mapstr.M)["histogram"].(mapstr.M)["counts"])
+}
+
+func TestDeltaHistogram(t *testing.T) {
+	md := testMetrics([]uint64{1, 2, 3, 0})
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	metrics.At(3).Histogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	metrics.At(3).Histogram().DataPoints().At(0).ExplicitBounds().FromRaw([]float64{-1, 0, 1})
+
+	events, _ := eventsMapping(collector.NewCounterCache(time.Minute), md)
+	e := findEvent(t, events, true)
+	histogram, err := e.ModuleFields.GetValue("metrics.http.server.duration")
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{
+		"values": []float64{-1, -0.5, 0.5, 1},
+		"counts": []uint64{1, 2, 3, 0},
+	}, histogram.(mapstr.M)["histogram"])
+}
+
+func newTestMetricSet(t *testing.T) *MetricSet {
+	t.Helper()
+	config := map[string]interface{}{
+		"module":     "otlp",
+		"metricsets": []string{"metrics"},
+		"host":       "localhost",
+		"port":       4318,
+	}
+	m, ok := mbtest.NewPushMetricSetV2(t, config).(*MetricSet)
+	require.True(t, ok)
+	// Buffer the events so the handler doesn't wait for the reporter
+	m.events = make(chan mb.Event, 10)
+	return m
+}
+
+func TestHandleFunc(t *testing.T) {
+	exportReq := pmetricotlp.NewExportRequestFromMetrics(testMetrics([]uint64{1, 2, 3, 0}))
+	protobufPayload, err := exportReq.MarshalProto()
+	require.NoError(t, err)
+	jsonPayload, err := exportReq.MarshalJSON()
+	require.NoError(t, err)
+
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, err = gz.Write(protobufPayload)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	for _, tc := range []struct {
+		name        string
+		path        string
+		contentType string
+		encoding    string
+		payload     []byte
+		status      int
+		events      int
+	}{
+		{"protobuf", metricsPath, protobufContentType, "", protobufPayload, http.StatusOK, 2},
+		{"gzipped protobuf", metricsPath, protobufContentType, "gzip", gzipped.Bytes(), http.StatusOK, 2},
+		{"json", metricsPath, jsonContentType + "; charset=utf-8", "", jsonPayload, http.StatusOK, 2},
+		{"traces", "/v1/traces", protobufContentType, "", protobufPayload, http.StatusNotFound, 0},
+		{"unsupported content type", metricsPath, "text/plain", "", protobufPayload, http.StatusUnsupportedMediaType, 0},
+		{"invalid payload", metricsPath, jsonContentType, "", []byte("{"), http.StatusBadRequest, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newTestMetricSet(t)
+
+			req := httptest.NewRequest(http.MethodPost, tc.path, bytes.NewReader(tc.payload))
+			req.Header.Set("Content-Type", tc.contentType)
+			if tc.encoding != "" {
+				req.Header.Set("Content-Encoding", tc.encoding)
+			}
+			rec := httptest.NewRecorder()
+			m.handleFunc(rec, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+			assert.Len(t, m.events, tc.events)
+			if tc.status != http.StatusOK {
+				return
+			}
+
+			resp := pmetricotlp.NewExportResponse()
+			if tc.contentType == protobufContentType {
+				assert.NoError(t, resp.UnmarshalProto(rec.Body.Bytes()))
+			} else {
+				assert.NoError(t, resp.UnmarshalJSON(rec.Body.Bytes()))
+			}
+			assert.Equal(t, int64(1), resp.PartialSuccess().RejectedDataPoints())
+		})
+	}
+}
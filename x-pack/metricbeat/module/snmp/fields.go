@@ -0,0 +1,23 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Code generated by beats/dev-tools/cmd/asset/asset.go - DO NOT EDIT.
+
+package snmp
+
+import (
+	"github.com/elastic/beats/v7/libbeat/asset"
+)
+
+func init() {
+	if err := asset.SetFields("metricbeat", "snmp", asset.ModuleFieldsPri, AssetSnmp); err != nil {
+		panic(err)
+	}
+}
+
+// AssetSnmp returns asset data.
+// This is the base64 encoded zlib format compressed contents of module/snmp.
+func AssetSnmp() string {
+	return "eJykk81u2zAQhO96ioGPgZIH0KGnXnxIGqBArwlNjeSt+SOQK7t++4L6MWzERdEUIOD1zv58Q0mPOPDcIAc/VICKOjbYfH95ft1UQKKjyWywo5oKaJltkkElhgZfKgB4piaxGan88sgWXYoeZQJMz6D5qQI6oWtzM3U8IhjPy86S0vPABn2K45q5s6mc99L0DhuDGgkZuieOxo38GwBwC3EN4syOLj89XIQVKO5+0upVek68zeqB51NM7ZX8B+hyfsyQsZuQv22/FnajMIkIURFGzyS2hpMDkTVJ6DNiwvYVpm0Tc2Z++oDuy63bf2dv47hzvK++eTMMEvqldPOw+YzFxdBkdXElQdkz5Ro2jkGnqDdjz1xDxRMq9jCZ7lw0eseumlvqTzyIF+O5Mk7j1j88MmiNLqY5nJwYpHiag6n4I5KElr/+D2lbRqwYy74LXo3TXuweUl4YIo9dJ5ficrlrXBpNp0yrsgiSYKMbfbhmv/2wfw8Alw0vRw=="
+}
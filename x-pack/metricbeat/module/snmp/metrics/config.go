@@ -0,0 +1,205 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// credentials are the settings used to authenticate the requests to an agent.
+type credentials struct {
+	Version   string `config:"version"`
+	Community string `config:"community"`
+
+	// SNMPv3 user-based security model settings
+	SecurityLevel string `config:"security_level"`
+	Username      string `config:"username"`
+	AuthProtocol  string `config:"auth_protocol"`
+	AuthPassword  string `config:"auth_password"`
+	PrivProtocol  string `config:"priv_protocol"`
+	PrivPassword  string `config:"priv_password"`
+	ContextName   string `config:"context_name"`
+}
+
+// hostCredentials override the credentials of the module for some of its hosts.
+type hostCredentials struct {
+	Hosts       []string    `config:"hosts" validate:"required"`
+	Credentials credentials `config:",inline"`
+}
+
+type oidConfig struct {
+	OID  string `config:"oid" validate:"required"`
+	Name string `config:"name" validate:"required"`
+}
+
+type tableConfig struct {
+	Name    string      `config:"name" validate:"required"`
+	Columns []oidConfig `config:"columns" validate:"required"`
+}
+
+type config struct {
+	Credentials     credentials       `config:",inline"`
+	HostCredentials []hostCredentials `config:"host_credentials"`
+
+	Timeout        time.Duration `config:"timeout"`
+	Retries        int           `config:"retries" validate:"min=0"`
+	MaxRepetitions uint32        `config:"max_repetitions" validate:"min=1"`
+
+	OIDs   []oidConfig   `config:"oids"`
+	Tables []tableConfig `config:"tables"`
+}
+
+func defaultConfig() config {
+	return config{
+		Credentials: credentials{
+			Version:   "2c",
+			Community: "public",
+		},
+		Timeout:        5 * time.Second,
+		Retries:        3,
+		MaxRepetitions: 10,
+	}
+}
+
+// Validate checks that the configuration requests at least one OID, and that
+// the credentials of all the hosts are valid.
+func (c *config) Validate() error {
+	if len(c.OIDs) == 0 && len(c.Tables) == 0 {
+		return errors.New("at least one of oids or tables must be configured")
+	}
+	if _, err := c.Credentials.params(); err != nil {
+		return err
+	}
+	for _, hc := range c.HostCredentials {
+		if _, err := c.forHosts(hc).params(); err != nil {
+			return fmt.Errorf("invalid credentials for hosts %v: %w", hc.Hosts, err)
+		}
+	}
+	return nil
+}
+
+// credentialsFor returns the credentials used for the host with the given URI.
+// Hosts are compared once parsed, so they match regardless of the default
+// scheme and port being written or not.
+func (c *config) credentialsFor(uri string) credentials {
+	for _, hc := range c.HostCredentials {
+		for _, h := range hc.Hosts {
+			hostData, err := hostParser(nil, h)
+			if err == nil && hostData.URI == uri {
+				return c.forHosts(hc)
+			}
+		}
+	}
+	return c.Credentials
+}
+
+// forHosts returns the credentials of the module with the settings of the
+// host credentials applied on top of them.
+func (c *config) forHosts(hc hostCredentials) credentials {
+	creds := c.Credentials
+	override := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	override(&creds.Version, hc.Credentials.Version)
+	override(&creds.Community, hc.Credentials.Community)
+	override(&creds.SecurityLevel, hc.Credentials.SecurityLevel)
+	override(&creds.Username, hc.Credentials.Username)
+	override(&creds.AuthProtocol, hc.Credentials.AuthProtocol)
+	override(&creds.AuthPassword, hc.Credentials.AuthPassword)
+	override(&creds.PrivProtocol, hc.Credentials.PrivProtocol)
+	override(&creds.PrivPassword, hc.Credentials.PrivPassword)
+	override(&creds.ContextName, hc.Credentials.ContextName)
+	return creds
+}
+
+var authProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"md5":    gosnmp.MD5,
+	"sha":    gosnmp.SHA,
+	"sha224": gosnmp.SHA224,
+	"sha256": gosnmp.SHA256,
+	"sha384": gosnmp.SHA384,
+	"sha512": gosnmp.SHA512,
+}
+
+var privProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"des":     gosnmp.DES,
+	"aes":     gosnmp.AES,
+	"aes192":  gosnmp.AES192,
+	"aes256":  gosnmp.AES256,
+	"aes192c": gosnmp.AES192C,
+	"aes256c": gosnmp.AES256C,
+}
+
+// params returns the client settings for the credentials.
+func (c credentials) params() (*gosnmp.GoSNMP, error) {
+	switch c.Version {
+	case "1":
+		return &gosnmp.GoSNMP{Version: gosnmp.Version1, Community: c.Community}, nil
+	case "2c":
+		return &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: c.Community}, nil
+	case "3":
+	default:
+		return nil, fmt.Errorf("unsupported SNMP version '%s', expected 1, 2c or 3", c.Version)
+	}
+
+	if c.Username == "" {
+		return nil, errors.New("username is required for SNMP version 3")
+	}
+	usm := &gosnmp.UsmSecurityParameters{
+		UserName:               c.Username,
+		AuthenticationProtocol: gosnmp.NoAuth,
+		PrivacyProtocol:        gosnmp.NoPriv,
+	}
+
+	var flags gosnmp.SnmpV3MsgFlags
+	switch strings.ToLower(c.SecurityLevel) {
+	case "", "noauthnopriv":
+		flags = gosnmp.NoAuthNoPriv
+	case "authnopriv":
+		flags = gosnmp.AuthNoPriv
+	case "authpriv":
+		flags = gosnmp.AuthPriv
+	default:
+		return nil, fmt.Errorf("unsupported security level '%s', expected noAuthNoPriv, authNoPriv or authPriv", c.SecurityLevel)
+	}
+
+	if flags&gosnmp.AuthNoPriv != 0 {
+		protocol, ok := authProtocols[strings.ToLower(c.AuthProtocol)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported auth protocol '%s'", c.AuthProtocol)
+		}
+		if c.AuthPassword == "" {
+			return nil, errors.New("auth_password is required for security level " + c.SecurityLevel)
+		}
+		usm.AuthenticationProtocol = protocol
+		usm.AuthenticationPassphrase = c.AuthPassword
+	}
+	if flags == gosnmp.AuthPriv {
+		protocol, ok := privProtocols[strings.ToLower(c.PrivProtocol)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported priv protocol '%s'", c.PrivProtocol)
+		}
+		if c.PrivPassword == "" {
+			return nil, errors.New("priv_password is required for security level " + c.SecurityLevel)
+		}
+		usm.PrivacyProtocol = protocol
+		usm.PrivacyPassphrase = c.PrivPassword
+	}
+
+	return &gosnmp.GoSNMP{
+		Version:            gosnmp.Version3,
+		SecurityModel:      gosnmp.UserSecurityModel,
+		MsgFlags:           flags,
+		SecurityParameters: usm,
+		ContextName:        c.ContextName,
+	}, nil
+}
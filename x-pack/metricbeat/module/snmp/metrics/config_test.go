@@ -0,0 +1,126 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func unpackConfig(t *testing.T, settings map[string]interface{}) (config, error) {
+	t.Helper()
+	if _, ok := settings["oids"]; !ok {
+		settings["oids"] = []map[string]interface{}{{"oid": "1.3.6.1.2.1.1.3.0", "name": "uptime"}}
+	}
+	c, err := conf.NewConfigFrom(settings)
+	require.NoError(t, err)
+
+	config := defaultConfig()
+	err = c.Unpack(&config)
+	return config, err
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		settings map[string]interface{}
+		err      string
+	}{
+		"default": {
+			settings: map[string]interface{}{},
+		},
+		"no oids": {
+			settings: map[string]interface{}{"oids": []map[string]interface{}{}},
+			err:      "at least one of oids or tables must be configured",
+		},
+		"unsupported version": {
+			settings: map[string]interface{}{"version": "4"},
+			err:      "unsupported SNMP version '4'",
+		},
+		"v3 without username": {
+			settings: map[string]interface{}{"version": 3},
+			err:      "username is required",
+		},
+		"v3 unsupported auth protocol": {
+			settings: map[string]interface{}{
+				"version": 3, "username": "monitor", "security_level": "authNoPriv",
+				"auth_protocol": "sha1024", "auth_password": "secret",
+			},
+			err: "unsupported auth protocol 'sha1024'",
+		},
+		"v3 without priv password": {
+			settings: map[string]interface{}{
+				"version": 3, "username": "monitor", "security_level": "authPriv",
+				"auth_protocol": "sha", "auth_password": "secret", "priv_protocol": "aes",
+			},
+			err: "priv_password is required",
+		},
+		"invalid host credentials": {
+			settings: map[string]interface{}{
+				"host_credentials": []map[string]interface{}{
+					{"hosts": []string{"switch1"}, "version": 3},
+				},
+			},
+			err: "invalid credentials for hosts [switch1]",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := unpackConfig(t, test.settings)
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestCredentialsFor(t *testing.T) {
+	config, err := unpackConfig(t, map[string]interface{}{
+		"community": "monitoring",
+		"host_credentials": []map[string]interface{}{
+			{"hosts": []string{"switch1", "udp://switch2:161"}, "community": "switches"},
+			{
+				"hosts": []string{"router1"}, "version": "3", "username": "monitor",
+				"security_level": "authPriv", "auth_protocol": "SHA256", "auth_password": "auth-secret",
+				"priv_protocol": "AES", "priv_password": "priv-secret", "context_name": "router",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	params, err := config.credentialsFor("udp://server1:161").params()
+	require.NoError(t, err)
+	assert.Equal(t, gosnmp.Version2c, params.Version)
+	assert.Equal(t, "monitoring", params.Community)
+
+	params, err = config.credentialsFor("udp://switch2:161").params()
+	require.NoError(t, err)
+	assert.Equal(t, gosnmp.Version2c, params.Version)
+	assert.Equal(t, "switches", params.Community)
+
+	params, err = config.credentialsFor("udp://router1:161").params()
+	require.NoError(t, err)
+	assert.Equal(t, gosnmp.Version3, params.Version)
+	assert.Equal(t, gosnmp.UserSecurityModel, params.SecurityModel)
+	assert.Equal(t, gosnmp.AuthPriv, params.MsgFlags)
+	assert.Equal(t, "router", params.ContextName)
+	usm, ok := params.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	require.True(t, ok)
+	assert.Equal(t, "monitor", usm.UserName)
+	assert.Equal(t, gosnmp.SHA256, usm.AuthenticationProtocol)
+	assert.Equal(t, "auth-secret", usm.AuthenticationPassphrase)
+	assert.Equal(t, gosnmp.AES, usm.PrivacyProtocol)
+	assert.Equal(t, "priv-secret", usm.PrivacyPassphrase)
+}
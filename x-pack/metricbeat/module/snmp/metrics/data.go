@@ -0,0 +1,158 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// normalizeOID returns the OID in the form returned by the agents, with a
+// leading dot.
+func normalizeOID(oid string) string {
+	if strings.HasPrefix(oid, ".") {
+		return oid
+	}
+	return "." + oid
+}
+
+// eventFromPDUs builds an event with the values of the configured OIDs. Numeric
+// values are stored as metrics and the other ones as labels. OIDs without a
+// value in the agent are not reported.
+func eventFromPDUs(oids []oidConfig, pdus []gosnmp.SnmpPDU) (mb.Event, bool) {
+	names := make(map[string]string, len(oids))
+	for _, oid := range oids {
+		names[normalizeOID(oid.OID)] = oid.Name
+	}
+
+	metrics := mapstr.M{}
+	labels := mapstr.M{}
+	for _, pdu := range pdus {
+		name, ok := names[normalizeOID(pdu.Name)]
+		if !ok {
+			continue
+		}
+		putValue(metrics, labels, name, pdu)
+	}
+	if len(metrics) == 0 && len(labels) == 0 {
+		return mb.Event{}, false
+	}
+	return newEvent("", "", metrics, labels), true
+}
+
+// eventsFromTable builds an event for each row of the table, identified by the
+// index of the row, which is the suffix of the OIDs after the OID of its column.
+func eventsFromTable(table tableConfig, pdus []gosnmp.SnmpPDU) []mb.Event {
+	type row struct {
+		metrics mapstr.M
+		labels  mapstr.M
+	}
+	rows := map[string]*row{}
+	var indexes []string
+
+	for _, pdu := range pdus {
+		oid := normalizeOID(pdu.Name)
+		for _, column := range table.Columns {
+			prefix := normalizeOID(column.OID) + "."
+			if !strings.HasPrefix(oid, prefix) {
+				continue
+			}
+			index := strings.TrimPrefix(oid, prefix)
+			r, found := rows[index]
+			if !found {
+				r = &row{metrics: mapstr.M{}, labels: mapstr.M{}}
+				rows[index] = r
+				indexes = append(indexes, index)
+			}
+			putValue(r.metrics, r.labels, column.Name, pdu)
+			break
+		}
+	}
+
+	events := make([]mb.Event, 0, len(indexes))
+	for _, index := range indexes {
+		r := rows[index]
+		if len(r.metrics) == 0 && len(r.labels) == 0 {
+			continue
+		}
+		events = append(events, newEvent(table.Name, index, r.metrics, r.labels))
+	}
+	return events
+}
+
+func newEvent(table, index string, metrics, labels mapstr.M) mb.Event {
+	moduleFields := mapstr.M{}
+	if table != "" {
+		moduleFields["table"] = table
+	}
+	if index != "" {
+		moduleFields["index"] = index
+	}
+	if len(labels) > 0 {
+		moduleFields["labels"] = labels
+	}
+
+	event := mb.Event{MetricSetFields: metrics}
+	if len(moduleFields) > 0 {
+		event.ModuleFields = moduleFields
+	}
+	return event
+}
+
+// putValue stores the value of the PDU in the metrics if it is numeric, or in
+// the labels otherwise.
+func putValue(metrics, labels mapstr.M, name string, pdu gosnmp.SnmpPDU) {
+	switch pdu.Type {
+	case gosnmp.Integer, gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks, gosnmp.Counter64, gosnmp.Uinteger32:
+		_, _ = metrics.Put(name, integerValue(gosnmp.ToBigInt(pdu.Value)))
+	case gosnmp.OpaqueFloat:
+		if v, ok := pdu.Value.(float32); ok {
+			_, _ = metrics.Put(name, float64(v))
+		}
+	case gosnmp.OpaqueDouble:
+		if v, ok := pdu.Value.(float64); ok {
+			_, _ = metrics.Put(name, v)
+		}
+	case gosnmp.OctetString:
+		if v, ok := pdu.Value.([]byte); ok {
+			_, _ = labels.Put(name, octetString(v))
+		}
+	case gosnmp.IPAddress, gosnmp.ObjectIdentifier:
+		if v, ok := pdu.Value.(string); ok {
+			_, _ = labels.Put(name, v)
+		}
+	}
+	// Other types, like NoSuchObject, NoSuchInstance or EndOfMibView, have no value.
+}
+
+func integerValue(v *big.Int) interface{} {
+	if v.IsInt64() {
+		return v.Int64()
+	}
+	return v.Uint64()
+}
+
+// octetString returns the string of printable octets as is, and the other
+// ones, like MAC addresses, as colon separated hexadecimal octets.
+func octetString(b []byte) string {
+	s := string(b)
+	if utf8.ValidString(s) && strings.IndexFunc(s, func(r rune) bool { return !unicode.IsPrint(r) && !unicode.IsSpace(r) }) < 0 {
+		return s
+	}
+
+	parts := make([]string, len(b))
+	for i := range b {
+		parts[i] = hex.EncodeToString(b[i : i+1])
+	}
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,171 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/gosnmp/gosnmp"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+)
+
+const defaultPort = "161"
+
+func init() {
+	mb.Registry.MustAddMetricSet("snmp", "metrics", New,
+		mb.WithHostParser(hostParser),
+		mb.DefaultMetricSet(),
+	)
+}
+
+// hostParser accepts hosts as an address, with an optional port, or as an URL
+// with the udp or tcp scheme.
+func hostParser(_ mb.Module, host string) (mb.HostData, error) {
+	return parse.ParseURL(host, "udp", "", "", "", defaultPort, "")
+}
+
+// MetricSet retrieves the values of the configured OIDs from an SNMP agent.
+type MetricSet struct {
+	mb.BaseMetricSet
+	config    config
+	transport string
+	address   string
+	port      uint16
+	creds     credentials
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	cfgwarn.Beta("The snmp metrics metricset is beta.")
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(base.HostData().URI)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing host %s: %w", base.HostData().SanitizedURI, err)
+	}
+	if u.Scheme != "udp" && u.Scheme != "tcp" {
+		return nil, fmt.Errorf("unsupported scheme '%s' for host %s, expected udp or tcp", u.Scheme, base.HostData().SanitizedURI)
+	}
+	port, err := strconv.ParseUint(u.Port(), 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port for host %s: %w", base.HostData().SanitizedURI, err)
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		config:        config,
+		transport:     u.Scheme,
+		address:       u.Hostname(),
+		port:          uint16(port),
+		creds:         config.credentialsFor(base.HostData().URI),
+	}, nil
+}
+
+// Fetch retrieves the configured OIDs and tables from the agent. The values of
+// the OIDs are reported in a single event, and each row of a table in its own event.
+func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
+	client, err := m.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Conn.Close()
+
+	if len(m.config.OIDs) > 0 {
+		pdus, err := m.get(client)
+		if err != nil {
+			return fmt.Errorf("error getting OIDs from %s: %w", m.Host(), err)
+		}
+		if event, ok := eventFromPDUs(m.config.OIDs, pdus); ok {
+			reporter.Event(event)
+		}
+	}
+
+	for _, table := range m.config.Tables {
+		pdus, err := m.walk(client, table)
+		if err != nil {
+			return fmt.Errorf("error walking table %s from %s: %w", table.Name, m.Host(), err)
+		}
+		for _, event := range eventsFromTable(table, pdus) {
+			reporter.Event(event)
+		}
+	}
+	return nil
+}
+
+func (m *MetricSet) connect(ctx context.Context) (*gosnmp.GoSNMP, error) {
+	client, err := m.creds.params()
+	if err != nil {
+		return nil, err
+	}
+	client.Target = m.address
+	client.Port = m.port
+	client.Transport = m.transport
+	client.Context = ctx
+	client.Timeout = m.config.Timeout
+	client.Retries = m.config.Retries
+	client.MaxRepetitions = m.config.MaxRepetitions
+	client.MaxOids = gosnmp.MaxOids
+
+	if err := client.Connect(); err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %w", net.JoinHostPort(m.address, strconv.Itoa(int(m.port))), err)
+	}
+	return client, nil
+}
+
+// get retrieves the values of the OIDs, with as many requests as needed to
+// stay under the maximum number of OIDs of a request.
+func (m *MetricSet) get(client *gosnmp.GoSNMP) ([]gosnmp.SnmpPDU, error) {
+	var pdus []gosnmp.SnmpPDU
+	for start := 0; start < len(m.config.OIDs); start += client.MaxOids {
+		end := min(start+client.MaxOids, len(m.config.OIDs))
+		oids := make([]string, 0, end-start)
+		for _, oid := range m.config.OIDs[start:end] {
+			oids = append(oids, oid.OID)
+		}
+
+		packet, err := client.Get(oids)
+		if err != nil {
+			return nil, err
+		}
+		if packet.Error != gosnmp.NoError {
+			return nil, fmt.Errorf("agent returned error %s for OID %d of the request", packet.Error, packet.ErrorIndex)
+		}
+		pdus = append(pdus, packet.Variables...)
+	}
+	return pdus, nil
+}
+
+// walk retrieves all the values of the columns of a table. Bulk requests are
+// used, except with SNMP version 1, which doesn't support them.
+func (m *MetricSet) walk(client *gosnmp.GoSNMP, table tableConfig) ([]gosnmp.SnmpPDU, error) {
+	var pdus []gosnmp.SnmpPDU
+	for _, column := range table.Columns {
+		var (
+			results []gosnmp.SnmpPDU
+			err     error
+		)
+		if client.Version == gosnmp.Version1 {
+			results, err = client.WalkAll(column.OID)
+		} else {
+			results, err = client.BulkWalkAll(column.OID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error walking column %s: %w", column.Name, err)
+		}
+		pdus = append(pdus, results...)
+	}
+	return pdus, nil
+}
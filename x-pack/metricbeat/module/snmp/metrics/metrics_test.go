@@ -0,0 +1,243 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !integration
+
+package metrics
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// testAgent is an SNMPv2c agent serving a fixed set of variables.
+type testAgent struct {
+	conn      net.PacketConn
+	community string
+	variables []gosnmp.SnmpPDU
+}
+
+func newTestAgent(t *testing.T, community string, variables []gosnmp.SnmpPDU) *testAgent {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	sort.Slice(variables, func(i, j int) bool {
+		return compareOIDs(variables[i].Name, variables[j].Name) < 0
+	})
+	a := &testAgent{conn: conn, community: community, variables: variables}
+	go a.serve()
+	return a
+}
+
+func (a *testAgent) host() string {
+	return "udp://" + a.conn.LocalAddr().String()
+}
+
+func (a *testAgent) serve() {
+	decoder := &gosnmp.GoSNMP{Version: gosnmp.Version2c, Logger: gosnmp.NewLogger(nil)}
+	buf := make([]byte, 65535)
+	for {
+		n, addr, err := a.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		request, err := decoder.SnmpDecodePacket(buf[:n])
+		if err != nil || request.Community != a.community {
+			// Agents don't answer requests with a wrong community.
+			continue
+		}
+
+		var variables []gosnmp.SnmpPDU
+		switch request.PDUType {
+		case gosnmp.GetRequest:
+			for _, v := range request.Variables {
+				variables = append(variables, a.get(v.Name))
+			}
+		case gosnmp.GetNextRequest:
+			for _, v := range request.Variables {
+				variables = append(variables, a.next(v.Name))
+			}
+		case gosnmp.GetBulkRequest:
+			for _, v := range request.Variables {
+				name := v.Name
+				for i := uint32(0); i < request.MaxRepetitions; i++ {
+					next := a.next(name)
+					variables = append(variables, next)
+					if next.Type == gosnmp.EndOfMibView {
+						break
+					}
+					name = next.Name
+				}
+			}
+		default:
+			continue
+		}
+
+		response := &gosnmp.SnmpPacket{
+			Version:   gosnmp.Version2c,
+			Community: a.community,
+			PDUType:   gosnmp.GetResponse,
+			RequestID: request.RequestID,
+			Variables: variables,
+			Logger:    gosnmp.NewLogger(nil),
+		}
+		out, err := response.MarshalMsg()
+		if err != nil {
+			continue
+		}
+		_, _ = a.conn.WriteTo(out, addr)
+	}
+}
+
+func (a *testAgent) get(name string) gosnmp.SnmpPDU {
+	for _, v := range a.variables {
+		if compareOIDs(v.Name, name) == 0 {
+			return v
+		}
+	}
+	return gosnmp.SnmpPDU{Name: name, Type: gosnmp.NoSuchObject}
+}
+
+func (a *testAgent) next(name string) gosnmp.SnmpPDU {
+	for _, v := range a.variables {
+		if compareOIDs(v.Name, name) > 0 {
+			return v
+		}
+	}
+	return gosnmp.SnmpPDU{Name: name, Type: gosnmp.EndOfMibView}
+}
+
+func compareOIDs(a, b string) int {
+	pa := strings.Split(strings.TrimPrefix(a, "."), ".")
+	pb := strings.Split(strings.TrimPrefix(b, "."), ".")
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		na, _ := strconv.Atoi(pa[i])
+		nb, _ := strconv.Atoi(pb[i])
+		if na != nb {
+			return na - nb
+		}
+	}
+	return len(pa) - len(pb)
+}
+
+var testVariables = []gosnmp.SnmpPDU{
+	{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: []byte("Test switch")},
+	{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(123456)},
+	{Name: ".1.3.6.1.2.1.1.5.0", Type: gosnmp.OctetString, Value: []byte("switch1")},
+
+	{Name: ".1.3.6.1.2.1.2.2.1.2.1", Type: gosnmp.OctetString, Value: []byte("lo")},
+	{Name: ".1.3.6.1.2.1.2.2.1.2.2", Type: gosnmp.OctetString, Value: []byte("eth0")},
+	{Name: ".1.3.6.1.2.1.2.2.1.6.1", Type: gosnmp.OctetString, Value: []byte{}},
+	{Name: ".1.3.6.1.2.1.2.2.1.6.2", Type: gosnmp.OctetString, Value: []byte{0x00, 0x50, 0x56, 0x01, 0x02, 0x03}},
+	{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: gosnmp.Integer, Value: 1},
+	{Name: ".1.3.6.1.2.1.2.2.1.8.2", Type: gosnmp.Integer, Value: 2},
+	{Name: ".1.3.6.1.2.1.31.1.1.1.6.1", Type: gosnmp.Counter64, Value: uint64(1024)},
+	{Name: ".1.3.6.1.2.1.31.1.1.1.6.2", Type: gosnmp.Counter64, Value: uint64(18446744073709551615)},
+}
+
+func getConfig(host string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "snmp",
+		"metricsets": []string{"metrics"},
+		"hosts":      []string{host},
+		"timeout":    "1s",
+		"retries":    0,
+		"oids": []map[string]interface{}{
+			{"oid": "1.3.6.1.2.1.1.1.0", "name": "description"},
+			{"oid": "1.3.6.1.2.1.1.3.0", "name": "uptime"},
+			{"oid": "1.3.6.1.2.1.1.5.0", "name": "name"},
+			{"oid": "1.3.6.1.2.1.1.4.0", "name": "contact"},
+		},
+		"tables": []map[string]interface{}{
+			{
+				"name": "interfaces",
+				"columns": []map[string]interface{}{
+					{"oid": "1.3.6.1.2.1.2.2.1.2", "name": "name"},
+					{"oid": "1.3.6.1.2.1.2.2.1.6", "name": "mac_address"},
+					{"oid": "1.3.6.1.2.1.2.2.1.8", "name": "oper_status"},
+					{"oid": "1.3.6.1.2.1.31.1.1.1.6", "name": "in_octets"},
+				},
+			},
+		},
+	}
+}
+
+func TestFetch(t *testing.T) {
+	agent := newTestAgent(t, "public", testVariables)
+
+	f := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(agent.host()))
+	events, errs := mbtest.ReportingFetchV2WithContext(f)
+	require.Empty(t, errs)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, mapstr.M{"uptime": int64(123456)}, events[0].MetricSetFields)
+	assert.Equal(t, mapstr.M{
+		"labels": mapstr.M{"description": "Test switch", "name": "switch1"},
+	}, events[0].ModuleFields)
+
+	assert.Equal(t, mapstr.M{"oper_status": int64(1), "in_octets": int64(1024)}, events[1].MetricSetFields)
+	assert.Equal(t, mapstr.M{
+		"table":  "interfaces",
+		"index":  "1",
+		"labels": mapstr.M{"name": "lo", "mac_address": ""},
+	}, events[1].ModuleFields)
+
+	assert.Equal(t, mapstr.M{"oper_status": int64(2), "in_octets": uint64(18446744073709551615)}, events[2].MetricSetFields)
+	assert.Equal(t, mapstr.M{
+		"table":  "interfaces",
+		"index":  "2",
+		"labels": mapstr.M{"name": "eth0", "mac_address": "00:50:56:01:02:03"},
+	}, events[2].ModuleFields)
+}
+
+func TestFetchMaxRepetitions(t *testing.T) {
+	agent := newTestAgent(t, "public", testVariables)
+
+	// Tables are walked with as many requests as needed.
+	config := getConfig(agent.host())
+	config["max_repetitions"] = 1
+	f := mbtest.NewReportingMetricSetV2WithContext(t, config)
+	events, errs := mbtest.ReportingFetchV2WithContext(f)
+	require.Empty(t, errs)
+	assert.Len(t, events, 3)
+}
+
+func TestFetchHostCredentials(t *testing.T) {
+	agent := newTestAgent(t, "private", testVariables)
+
+	config := getConfig(agent.host())
+	f := mbtest.NewReportingMetricSetV2WithContext(t, config)
+	_, errs := mbtest.ReportingFetchV2WithContext(f)
+	require.NotEmpty(t, errs, "the agent doesn't answer requests with the default community")
+
+	config["host_credentials"] = []map[string]interface{}{
+		{"hosts": []string{agent.host()}, "community": "private"},
+	}
+	f = mbtest.NewReportingMetricSetV2WithContext(t, config)
+	events, errs := mbtest.ReportingFetchV2WithContext(f)
+	require.Empty(t, errs)
+	assert.Len(t, events, 3)
+}
+
+func TestData(t *testing.T) {
+	agent := newTestAgent(t, "public", testVariables)
+
+	f := mbtest.NewReportingMetricSetV2WithContext(t, getConfig(agent.host()))
+	err := mbtest.WriteEventsReporterV2WithContextCond(f, t, "", func(e mapstr.M) bool {
+		table, _ := e.GetValue("snmp.table")
+		return table == "interfaces"
+	})
+	require.NoError(t, err)
+}
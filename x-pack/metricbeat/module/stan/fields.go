@@ -19,5 +19,5 @@ func init() {
 // AssetStan returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/stan.
 func AssetStan() string {
-	return "eJy8l0Fv4zYQhe/+FQP3kgDO5u7DFkGSBQKkLlqnp8VilxZHFmFqqHBGa7i/vqBkyrJNJ0ErVYfAkOV5H58mb8gb2OBuDiyKJgBixOIcpktRNJ0AaOTMm0qMozl8ngBA8yT85nRtcQLg0aJinMNaTQAYRQyteQ5fp8x2OoNpIVJNv00AcoNW87ypcQOkSuxUwyW7KlTxrq72dxLa4foRfvQDMkeiDHGoIYbFZAxSKIEtegSPSkPuXQkLJQxL8ahKQ2tg9D/Rw9Xy5W5xva/ZJzuia579ZHT3TcTc4G7rfP/+BdhwvRQYZZ8ezkQyW7MMpLKvlZQpFBFaPhPpO/6OxH3PcoJboGAtR2tv2zX+DUi6coYEShRvsr7kcbfE69T/Pnf4e/TFZXPeoY8mhYrgcpACIXTBYQXRpCRIicxqjZyEsY7W/4KkLlfoA8sJR1Irgqx2MiZFUx4MNf68ZUhuPMt3xteBWL6EesD4WiNlHRaL86hPeD7BU37Qh89QGrr6Gj4aAq5XnQ5/uwatRIF1zFAohsoxm5Xdgcuy2nvUybVZNejSntVHV5ak0VhJMRDKHzXW2FaElWLUUFeOoPGCziEVaSjMukAW8Fg5L6ijw80ijp8/j1BRMkzmOLK7BuXG4k+06QyKOevRqkA6RAKFJQwcQTGnb/vZ4yg369qr8MNWtJ1SD3fPvy8eZ3D//Nfy5fHPx4frJKZ3dkDKpzxCop41tdvINAzkNHZdu1/I1TMqjX4GX5y1bhs+3SvSRivBNG5mDZKkc8yQ4LrXTB/29STKUhrdW+2nxKgUvea8qPnGlB7BlpTISGNu0UnHwqAyH8JYWdvivIZEGn/UHUjaCZc54rpE/R7PeaRdeIujb6f6woMkmzkNhaF3VvtOOya/av8tvxt9IRkSk3A8tHDjCM8wKGaXmWaAbM3J5I2UTZeMx7i4e1m2Gu2h5m1Lz5lnYHJQtOuEfvmftkwkQ+6ZNuS2dLYp2Xt0ZEF79FMhXjYXCCskbc4w/nue7OseEq45d96CuD7m6iS5I5XLc2so3Uor5yx2J+QPgj3xiSyUym9C0HEU+/XSRsdaTGfCQCTt+xQHK4xyB5bJPwEAAP//IHuKGQ=="
+	return "eJy8l0Fr40gQhe/6FQ/vJQFncvdhhpBkIJDNspvsaRhm2uqS1KTVrXSVxnh//dKy5ch2O9lZpAETgtWu99Wj/Lp8gWdaL8CiXAaIEUsLzB5FuVkGaOI8mEaMdwt8zAB0J/G7162lDAhkSTEtUKoMYBIxruQFvsyY7WyOWSXSzL5mQGHIal50NS7gVE071fiWrJtYJfi22b6T0I6v7/FD35F7J8o4jjXEsJicIZUSrCgQAimNIvgaD0oYjxJI1caVYAo/KODs8enq4Xxbc0i2R9ed/WD07kmP+UzrlQ/D90/AxtdTRb3s3c2RSG5blpFUtrWSMpVyjiwPPnPs+DsS1wPLHS7horXcW3u56fEfkNONN05QkwSTDyX3pwVI+z/kjn/3Hpw25x363qRYEb6AVIQ4Ba8d9CYlQWpiViVxEsZ6V/4PkrZeUogsBxxJrR5kuZYpKbryMK7z5y1DChNYvjG9jMTyOdYD00tLLt9hsfhA+oDnA+6KV318RG3c2Reml3iO2+VOh7+eQytRsJ4ZlWI0ntks7Ro+z9sQSCd7s2rU1u7Vf+0sSaOpkWoklD9bamlTEUvFpNE23qHzwh1DKqdRmbIiFgRqfBDSvcPx+cH57BA9hvM4meOdXXcoF5Z+kE1nUJ+zgayKpGMkUGxh5Ajqc/pyQJ57V5iyDSomRuxOaHNL3Vzd//FwO8f1/d+PT7d/3d6cJzGDtyNS3hU9JOl5VzvGlFSG4bym3dRuGzm7J6UpzPHZW+tX8b9r5bTRSiiNm1tDTjhJbJxQSeHniBNRltLo9fdSYlKKwXCe1Hzjlp7AlpTIRNfcw066LwyVhxjGytoNzktMpOmvuleSrmrcH7mtSb/Hkx1CnZqcydepofAoyWZ00tvxNqvtOO8NPs42X8tvRp9IhsRNOB1aTLE9PMNQzD433QWyMlIlKbupnY7x4erpcTOJmx81b1t6zDyHKaDceif02y9amZwkTfn572u3Mz07v3JHS8nWoz0LOpdWiqHy5xOEDTltXDkS32uebOvuonPzu/MS4oeYw+1o37dyuqzdOtUPjfNxw3NyjIY1yRy5r5s2jlDXQTxh9zbXg3JxORwccscntwKdicnudRvU8sTisvTeknIHz94x4Y6PhHuRT0kCXxTWuMkI4u1Xq/AcLxruxdIkLMpa0lOSbL5P4rGkXu5TBgAAkP07APjL+Xo="
 }
@@ -30,6 +30,8 @@ var (
 		"offline":   c.Bool("is_offline"),
 		"stalled":   c.Bool("is_stalled"),
 		"pending":   c.Int("pending_count"),
+		"lag":       c.Int("lag"),
+		"durable":   c.Bool("is_durable", s.Optional),
 	}
 )
 
@@ -82,6 +84,16 @@ type Channels struct {
 	Channels  []Channel `json:"channels,omitempty"`
 }
 
+// subscriptionLag returns the number of messages of the channel that were not
+// sent to the subscription yet, based on the last sequence of the channel
+func subscriptionLag(lastSeq int64, sub map[string]interface{}) int64 {
+	lastSent, ok := sub["last_sent"].(float64)
+	if !ok || int64(lastSent) >= lastSeq {
+		return 0
+	}
+	return lastSeq - int64(lastSent)
+}
+
 // eventsMapping maps the top-level channel metrics AND also per-channel metrics AND subscriptions
 func eventsMapping(content []byte, r mb.ReporterV2) error {
 	var err error
@@ -96,6 +108,7 @@ func eventsMapping(content []byte, r mb.ReporterV2) error {
 			sub["channel"] = ch.Name
 			sub["server_id"] = channels.ServerID
 			sub["cluster_id"] = channels.ClusterID
+			sub["lag"] = subscriptionLag(ch.LastSeq, sub)
 			evt, err = eventMapping(sub)
 			if err != nil {
 				r.Error(fmt.Errorf("error mapping subscription event: %w", err))
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
 func TestEventMapping(t *testing.T) {
@@ -50,14 +51,97 @@ func TestFetchEventContent(t *testing.T) {
 
 }
 
+func TestEventMappingLag(t *testing.T) {
+	content, err := ioutil.ReadFile("./_meta/test/subscriptions.json")
+	assert.NoError(t, err)
+	reporter := &mbtest.CapturingReporterV2{}
+	err = eventsMapping(content, reporter)
+	assert.NoError(t, err)
+
+	var lagging []mapstr.M
+	for _, evt := range reporter.GetEvents() {
+		if lag, _ := evt.MetricSetFields.GetValue("lag"); lag.(int64) > 0 {
+			lagging = append(lagging, evt.MetricSetFields)
+		}
+	}
+	// only the subscription to system.index did not get the last message
+	if assert.Len(t, lagging, 1) {
+		assert.Equal(t, "system.index", lagging[0]["channel"])
+		assert.Equal(t, "gorat_0", lagging[0]["id"])
+		assert.Equal(t, int64(1), lagging[0]["lag"])
+		assert.Equal(t, true, lagging[0]["durable"])
+	}
+}
+
+func TestSubscriptionLag(t *testing.T) {
+	assert.Equal(t, int64(6), subscriptionLag(10, map[string]interface{}{"last_sent": float64(4)}))
+	assert.Equal(t, int64(0), subscriptionLag(10, map[string]interface{}{"last_sent": float64(10)}))
+	// the channel may have been truncated after the last message was sent
+	assert.Equal(t, int64(0), subscriptionLag(0, map[string]interface{}{"last_sent": float64(4)}))
+	assert.Equal(t, int64(0), subscriptionLag(10, map[string]interface{}{}))
+}
+
+func TestFetchWithBasicAuth(t *testing.T) {
+	response, err := ioutil.ReadFile("./_meta/test/subscriptions.json")
+	assert.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "monitor" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "1", r.URL.Query().Get("subs"))
+		w.Header().Set("Content-Type", "application/json;")
+		w.WriteHeader(http.StatusOK)
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     "stan",
+		"metricsets": []string{"subscriptions"},
+		"hosts":      []string{server.URL},
+		"username":   "monitor",
+		"password":   "secret",
+	}
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	assert.Empty(t, errs)
+	assert.Len(t, events, 115)
+
+	config["password"] = "wrong"
+	metricSet = mbtest.NewReportingMetricSetV2Error(t, config)
+	_, errs = mbtest.ReportingFetchV2Error(metricSet)
+	assert.NotEmpty(t, errs)
+}
+
+func TestFetchWithTLS(t *testing.T) {
+	server := httptest.NewTLSServer(handler())
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":                "stan",
+		"metricsets":            []string{"subscriptions"},
+		"hosts":                 []string{server.URL},
+		"ssl.verification_mode": "none",
+	}
+	metricSet := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(metricSet)
+	assert.Empty(t, errs)
+	assert.Len(t, events, 115)
+}
+
 func initServer() *httptest.Server {
+	return httptest.NewServer(handler())
+}
+
+func handler() http.Handler {
 	absPath, _ := filepath.Abs("./_meta/test/")
 
 	response, _ := ioutil.ReadFile(absPath + "/subscriptions.json")
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(200)
 		w.Header().Set("Content-Type", "application/json;")
 		w.Write([]byte(response))
-	}))
-	return server
+	})
 }